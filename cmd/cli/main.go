@@ -7,7 +7,10 @@ import (
 )
 
 func main() {
-	if err := cli.Root().Execute(); err != nil {
+	ctx, stop := cli.SignalContext()
+	err := cli.Root().ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	jobsRunWait bool
+)
+
+var AdminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Registry administration commands",
+	Long:  `Commands for registry operators. Requires registry admin permissions.`,
+}
+
+var adminJobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Inspect and run background jobs",
+	Long: `Background jobs are the registry's recurring and startup tasks, such as reconciling deployments
+and importing seed data.`,
+}
+
+var adminJobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List background jobs and their last run",
+	Args:  cobra.NoArgs,
+	RunE:  runAdminJobsList,
+}
+
+var adminJobsRunCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "Run a background job now",
+	Example: `  arctl admin jobs run reconcile
+  arctl admin jobs run seed-import --wait`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdminJobsRun,
+}
+
+func init() {
+	adminJobsRunCmd.Flags().BoolVar(&jobsRunWait, "wait", false, "Wait for the job to finish and report its result")
+
+	adminJobsCmd.AddCommand(adminJobsListCmd, adminJobsRunCmd)
+	AdminCmd.AddCommand(adminJobsCmd)
+}
+
+func runAdminJobsList(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	statuses, err := apiClient.ListJobs()
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(statuses)
+	}
+
+	if len(statuses) == 0 {
+		fmt.Println("No background jobs registered")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Name", "Schedule", "Last Run", "Status", "Next Run", "Runs", "Failures")
+	for _, j := range statuses {
+		lastRun, status := "<never>", "-"
+		if j.LastRun != nil {
+			lastRun = printer.FormatAge(j.LastRun.StartedAt) + " ago"
+			status = j.LastRun.Status
+		}
+		nextRun := "-"
+		if j.NextRunAt != nil {
+			nextRun = "in " + time.Until(*j.NextRunAt).Round(time.Second).String()
+		}
+		t.AddRow(j.Name, jobSchedule(j), lastRun, status, nextRun, j.RunCount, j.FailureCount)
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+
+	for _, j := range statuses {
+		if j.LastRun != nil && j.LastRun.Error != "" {
+			fmt.Printf("\n%s: %s\n", j.Name, j.LastRun.Error)
+		}
+	}
+	return nil
+}
+
+func runAdminJobsRun(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	name := args[0]
+
+	if _, err := apiClient.RunJob(name); err != nil {
+		return err
+	}
+	if !jobsRunWait {
+		fmt.Printf("Started job %s\n", name)
+		return nil
+	}
+
+	fmt.Printf("Running job %s...\n", name)
+	for {
+		time.Sleep(time.Second)
+		statuses, err := apiClient.ListJobs()
+		if err != nil {
+			return err
+		}
+		var job *models.JobStatus
+		for i := range statuses {
+			if statuses[i].Name == name {
+				job = &statuses[i]
+			}
+		}
+		if job == nil {
+			return fmt.Errorf("job %s no longer exists", name)
+		}
+		if job.Running || job.LastRun == nil {
+			continue
+		}
+		if job.LastRun.Status == models.JobRunStatusFailed {
+			return fmt.Errorf("job %s failed: %s", name, job.LastRun.Error)
+		}
+		took := ""
+		if job.LastRun.FinishedAt != nil {
+			took = " in " + job.LastRun.FinishedAt.Sub(job.LastRun.StartedAt).Round(time.Millisecond).String()
+		}
+		printer.PrintSuccess(fmt.Sprintf("Job %s succeeded%s", name, took))
+		return nil
+	}
+}
+
+func jobSchedule(j models.JobStatus) string {
+	if j.IntervalSeconds <= 0 {
+		return "manual"
+	}
+	return "every " + (time.Duration(j.IntervalSeconds) * time.Second).String()
+}
@@ -30,6 +30,11 @@ func init() {
 	AgentCmd.AddCommand(InitCmd)
 	AgentCmd.AddCommand(BuildCmd)
 	AgentCmd.AddCommand(RunCmd)
+	AgentCmd.AddCommand(EvalCmd)
+	AgentCmd.AddCommand(ChatCmd)
+	AgentCmd.AddCommand(SessionsCmd)
+	AgentCmd.AddCommand(LogsCmd)
+	AgentCmd.AddCommand(AttachCmd)
 	AgentCmd.AddCommand(AddSkillCmd)
 	AgentCmd.AddCommand(AddMcpCmd)
 	AgentCmd.AddCommand(PublishCmd)
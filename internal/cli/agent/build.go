@@ -17,22 +17,30 @@ var BuildCmd = &cobra.Command{
 	Long: `Build Docker images for an agent project created with the init command.
 
 This command looks for agent.yaml in the specified directory, regenerates template artifacts,
-and invokes docker build (plus optional push) for both the agent and any command-type MCP servers.`,
-	Args:    cobra.ExactArgs(1),
-	RunE:    runBuild,
-	Example: `arctl agent build ./my-agent`,
+and invokes docker build (plus optional push) for both the agent and any command-type MCP servers.
+
+With --sbom, an SBOM of the agent image is generated with syft and written to sbom.json in the project
+directory. 'arctl agent publish' attaches it to the published agent version.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBuild,
+	Example: `arctl agent build ./my-agent
+arctl agent build ./my-agent --sbom --sbom-format cyclonedx`,
 }
 
 var (
-	buildImage    string
-	buildPush     bool
-	buildPlatform string
+	buildImage      string
+	buildPush       bool
+	buildPlatform   string
+	buildSBOM       bool
+	buildSBOMFormat string
 )
 
 func init() {
 	BuildCmd.Flags().StringVar(&buildImage, "image", "", "Full image specification (e.g., ghcr.io/myorg/my-agent:v1.0.0)")
 	BuildCmd.Flags().BoolVar(&buildPush, "push", false, "Push the image to the registry")
 	BuildCmd.Flags().StringVar(&buildPlatform, "platform", "", "Target platform for Docker build (e.g., linux/amd64, linux/arm64)")
+	BuildCmd.Flags().BoolVar(&buildSBOM, "sbom", false, "Generate an SBOM of the agent image (requires syft)")
+	BuildCmd.Flags().StringVar(&buildSBOMFormat, "sbom-format", "spdx", "SBOM format (spdx, cyclonedx)")
 }
 
 func runBuild(cmd *cobra.Command, args []string) error {
@@ -74,6 +82,16 @@ func runBuild(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if buildSBOM {
+		sbomPath, err := filepath.Abs(filepath.Join(projectDir, docker.SBOMFileName))
+		if err != nil {
+			return fmt.Errorf("failed to resolve SBOM path: %w", err)
+		}
+		if err := mainDocker.GenerateSBOM(imageName, buildSBOMFormat, sbomPath); err != nil {
+			return err
+		}
+	}
+
 	if buildPush {
 		if err := mainDocker.Push(imageName); err != nil {
 			return err
@@ -0,0 +1,126 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/sessions"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/tui"
+	"github.com/spf13/cobra"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+var ChatCmd = &cobra.Command{
+	Use:   "chat [agent-name]",
+	Short: "Chat with a running agent",
+	Long: `Open the chat interface against an agent that is already running, for example one started with
+'arctl agent run' in another terminal. Chats are saved in ~/.arctl/sessions; use --resume to continue one.
+
+A resumed chat reuses the session's A2A context ID, so an agent that still holds the conversation
+continues it. An agent that was restarted since only sees the new messages.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runChat,
+	Example: `  arctl agent chat dice
+  arctl agent chat --url http://localhost:8081 planner
+  arctl agent chat --resume 3f2a9c`,
+}
+
+var (
+	chatURL    string
+	chatResume string
+)
+
+func init() {
+	ChatCmd.Flags().StringVar(&chatURL, "url", "http://localhost:8080", "URL of the agent's A2A endpoint")
+	ChatCmd.Flags().StringVar(&chatResume, "resume", "", "ID (or unique ID prefix) of a saved session to continue")
+}
+
+func runChat(cmd *cobra.Command, args []string) error {
+	store, err := sessions.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	if chatResume == "" {
+		agentName := "agent"
+		if len(args) > 0 {
+			agentName = args[0]
+		}
+		return chatWithSession(cmd.Context(), store, newChatSession(agentName, chatURL))
+	}
+
+	session, err := store.Load(chatResume)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		session.Agent = args[0]
+	}
+	if cmd.Flags().Changed("url") || session.AgentURL == "" {
+		session.AgentURL = chatURL
+	}
+	return chatWithSession(cmd.Context(), store, session)
+}
+
+// launchChat starts a new, saved chat session with the agent at agentURL.
+func launchChat(ctx context.Context, agentName, agentURL string) error {
+	store, err := sessions.DefaultStore()
+	if err != nil {
+		return err
+	}
+	return chatWithSession(ctx, store, newChatSession(agentName, agentURL))
+}
+
+func newChatSession(agentName, agentURL string) *sessions.Session {
+	now := time.Now()
+	return &sessions.Session{
+		ID:        protocol.GenerateContextID(),
+		Agent:     agentName,
+		AgentURL:  agentURL,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// chatWithSession runs the chat TUI for a session and saves every message as it is sent or received.
+func chatWithSession(ctx context.Context, store *sessions.Store, session *sessions.Session) error {
+	client, err := a2aclient.NewA2AClient(session.AgentURL, a2aclient.WithTimeout(60*time.Second))
+	if err != nil {
+		return fmt.Errorf("failed to create chat client: %w", err)
+	}
+
+	sendFn := func(ctx context.Context, params protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+		ch, err := client.StreamMessage(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		return ch, nil
+	}
+
+	history := make([]tui.ChatMessage, 0, len(session.Messages))
+	for _, m := range session.Messages {
+		history = append(history, tui.ChatMessage{Role: m.Role, Text: m.Text, Timestamp: m.Timestamp})
+	}
+
+	// The TUI owns the terminal, so save errors are reported once it exits.
+	var saveErr error
+	onMessage := func(m tui.ChatMessage) {
+		session.Append(m.Role, m.Text, m.Timestamp)
+		if err := store.Save(session); err != nil {
+			saveErr = err
+		}
+	}
+
+	if err := tui.RunChat(session.Agent, session.ID, sendFn, verbose, tui.ChatOptions{History: history, OnMessage: onMessage}); err != nil {
+		return err
+	}
+	if saveErr != nil {
+		return fmt.Errorf("failed to save chat session: %w", saveErr)
+	}
+	if len(session.Messages) > 0 {
+		fmt.Printf("Chat saved as session %s. Resume it with: arctl agent chat --resume %s\n", session.ID, session.ID)
+	}
+	return nil
+}
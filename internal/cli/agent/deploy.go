@@ -3,9 +3,12 @@ package agent
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/spf13/cobra"
 )
@@ -18,8 +21,14 @@ var DeployCmd = &cobra.Command{
 Example:
   arctl agent deploy my-agent --version latest
   arctl agent deploy my-agent --version 1.2.3
-  arctl agent deploy my-agent --version latest --runtime kubernetes`,
-	Args: cobra.ExactArgs(1),
+  arctl agent deploy my-agent --version latest --runtime kubernetes
+  arctl agent deploy my-agent --version latest --target edge-docker
+  arctl agent deploy my-agent --version latest --replicas 3
+
+--target deploys to a named target configured on the registry server, such as a remote docker host or another
+kubernetes context, and takes precedence over --runtime. 'arctl mcp targets' lists them.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completion.Names(completion.Agents),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		runtimeFlag, _ := cmd.Flags().GetString("runtime")
 		if runtimeFlag != "" {
@@ -33,14 +42,15 @@ Example:
 }
 
 func runDeploy(cmd *cobra.Command, args []string) error {
-	if len(args) == 0 {
-		return cmd.Help()
+	name, err := completion.NameArg(completion.Agents, args)
+	if err != nil {
+		return err
 	}
-
-	name := args[0]
 	version, _ := cmd.Flags().GetString("version")
 	runtime, _ := cmd.Flags().GetString("runtime")
 	namespace, _ := cmd.Flags().GetString("namespace")
+	target, _ := cmd.Flags().GetString("target")
+	replicas, _ := cmd.Flags().GetInt("replicas")
 
 	if version == "" {
 		version = "latest"
@@ -78,6 +88,16 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	if namespace != "" {
 		config["KAGENT_NAMESPACE"] = namespace
 	}
+	if replicas > 0 {
+		config[registry.ResourceConfigPrefix+registry.ResourceReplicas] = strconv.Itoa(replicas)
+		if err := registry.ValidateResourceConfig(config); err != nil {
+			return err
+		}
+	}
+
+	if target != "" {
+		return deployTarget(name, version, config, target)
+	}
 
 	// Handle runtime-specific deployment logic
 	switch runtime {
@@ -118,7 +138,7 @@ func buildDeployConfig(manifest *models.AgentManifest) map[string]string {
 
 // deployLocal deploys an agent to the local/docker runtime
 func deployLocal(name, version string, config map[string]string) error {
-	deployment, err := apiClient.DeployAgent(name, version, config, "local")
+	deployment, err := apiClient.DeployAgent(name, version, config, "local", "")
 	if err != nil {
 		return fmt.Errorf("failed to deploy agent: %w", err)
 	}
@@ -129,7 +149,7 @@ func deployLocal(name, version string, config map[string]string) error {
 
 // deployKubernetes deploys an agent to the kubernetes runtime
 func deployKubernetes(name, version string, config map[string]string, namespace string) error {
-	deployment, err := apiClient.DeployAgent(name, version, config, "kubernetes")
+	deployment, err := apiClient.DeployAgent(name, version, config, "kubernetes", "")
 	if err != nil {
 		return fmt.Errorf("failed to deploy agent: %w", err)
 	}
@@ -138,9 +158,22 @@ func deployKubernetes(name, version string, config map[string]string, namespace
 	return nil
 }
 
+// deployTarget deploys an agent to a named deployment target of the registry
+func deployTarget(name, version string, config map[string]string, target string) error {
+	deployment, err := apiClient.DeployAgent(name, version, config, "", target)
+	if err != nil {
+		return fmt.Errorf("failed to deploy agent: %w", err)
+	}
+
+	fmt.Printf("Agent '%s' version '%s' deployed to target '%s' (%s runtime)\n", deployment.ServerName, deployment.Version, deployment.Target, deployment.Runtime)
+	return nil
+}
+
 func init() {
 	DeployCmd.Flags().String("version", "latest", "Agent version to deploy")
 	DeployCmd.Flags().String("runtime", "local", "Deployment runtime target (local, kubernetes)")
 	DeployCmd.Flags().Bool("prefer-remote", false, "Prefer using a remote source when available")
+	DeployCmd.Flags().String("target", "", "Named deployment target configured on the registry server (overrides --runtime)")
+	DeployCmd.Flags().Int("replicas", 0, "Number of agent instances to run; the agent gateway balances requests across them")
 	DeployCmd.Flags().String("namespace", "", "Kubernetes namespace for agent deployment")
 }
@@ -5,9 +5,12 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
 )
 
-// Executor wraps docker CLI operations with a working directory and verbosity.
+// Executor wraps docker CLI operations with a working directory and verbosity. It runs podman instead when
+// ARCTL_CONTAINER_ENGINE=podman is set or docker is not installed.
 type Executor struct {
 	Verbose bool
 	WorkDir string
@@ -23,10 +26,21 @@ func NewExecutor(verbose bool, workDir string) *Executor {
 
 // CheckAvailability ensures docker CLI and daemon are reachable.
 func (e *Executor) CheckAvailability() error {
-	if _, err := exec.LookPath("docker"); err != nil {
-		return fmt.Errorf("docker command not found in PATH. Please install Docker")
+	engine := utils.ContainerEngine()
+	if _, err := exec.LookPath(engine); err != nil {
+		if engine == utils.ContainerEnginePodman {
+			return fmt.Errorf("podman command not found in PATH. Please install Podman or unset %s", utils.ContainerEngineEnvVar)
+		}
+		return fmt.Errorf("docker command not found in PATH. Please install Docker or Podman")
 	}
 
+	if engine == utils.ContainerEnginePodman {
+		// Rootless podman has no daemon; podman info fails when its machine or service is not running
+		if err := exec.Command(engine, "info", "--format", "{{.Version.Version}}").Run(); err != nil {
+			return fmt.Errorf("podman is not accessible. Start the podman machine (podman machine start) or check your podman setup")
+		}
+		return nil
+	}
 	cmd := exec.Command("docker", "version", "--format", "{{.Server.Version}}")
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("docker daemon is not running or not accessible. Please start Docker Desktop or the Docker daemon")
@@ -36,14 +50,15 @@ func (e *Executor) CheckAvailability() error {
 
 // Run executes docker with the provided arguments.
 func (e *Executor) Run(args ...string) error {
+	engine := utils.ContainerEngine()
 	if e.Verbose {
-		fmt.Printf("Running: docker %s\n", strings.Join(args, " "))
+		fmt.Printf("Running: %s %s\n", engine, strings.Join(args, " "))
 		if e.WorkDir != "" {
 			fmt.Printf("Working directory: %s\n", e.WorkDir)
 		}
 	}
 
-	cmd := exec.Command("docker", args...)
+	cmd := exec.Command(engine, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if e.WorkDir != "" {
@@ -73,13 +88,8 @@ func (e *Executor) Push(imageName string) error {
 	return nil
 }
 
-// ComposeCommand returns the docker compose invocation (docker compose vs docker-compose).
+// ComposeCommand returns the docker compose invocation (docker compose vs docker-compose, or their podman
+// equivalents).
 func ComposeCommand() []string {
-	if _, err := exec.LookPath("docker"); err == nil {
-		cmd := exec.Command("docker", "compose", "version")
-		if err := cmd.Run(); err == nil {
-			return []string{"docker", "compose"}
-		}
-	}
-	return []string{"docker-compose"}
+	return utils.ComposeCommand()
 }
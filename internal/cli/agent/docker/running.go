@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+)
+
+// Container is a running container started by docker compose.
+type Container struct {
+	ID      string
+	Name    string
+	Project string
+	Service string
+}
+
+// FindComposeService returns the running containers of the compose service with the given name, across all
+// compose projects. Agents run by arctl are compose services named after the agent.
+func FindComposeService(service string) ([]Container, error) {
+	out, err := exec.Command(utils.ContainerEngine(), "ps",
+		"--filter", "label=com.docker.compose.service="+service,
+		"--format", `{{.ID}}\t{{.Names}}\t{{.Label "com.docker.compose.project"}}`,
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running containers: %w", err)
+	}
+	return parseContainers(string(out), service), nil
+}
+
+func parseContainers(out, service string) []Container {
+	var containers []Container
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		containers = append(containers, Container{ID: fields[0], Name: fields[1], Project: fields[2], Service: service})
+	}
+	return containers
+}
+
+// HostPort returns the host port a container port is published on.
+func HostPort(containerID string, containerPort int) (int, error) {
+	out, err := exec.Command(utils.ContainerEngine(), "port", containerID, fmt.Sprintf("%d/tcp", containerPort)).Output()
+	if err != nil {
+		return 0, fmt.Errorf("port %d of container %s is not published", containerPort, containerID)
+	}
+	return parseHostPort(string(out))
+}
+
+// parseHostPort reads the port from `docker port` output such as "0.0.0.0:8081\n[::]:8081".
+func parseHostPort(out string) (int, error) {
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		idx := strings.LastIndex(line, ":")
+		if idx < 0 {
+			continue
+		}
+		if port, err := strconv.Atoi(strings.TrimSpace(line[idx+1:])); err == nil {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("unexpected docker port output: %q", out)
+}
@@ -0,0 +1,27 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContainers(t *testing.T) {
+	out := "1a2b\tmy-agent-dice-1\tmy-agent\nc3d4\tarctl-multi-agent-dice-1\tarctl-multi-agent\n"
+	containers := parseContainers(out, "dice")
+	require.Len(t, containers, 2)
+	assert.Equal(t, Container{ID: "1a2b", Name: "my-agent-dice-1", Project: "my-agent", Service: "dice"}, containers[0])
+	assert.Equal(t, "arctl-multi-agent", containers[1].Project)
+
+	assert.Empty(t, parseContainers("", "dice"))
+}
+
+func TestParseHostPort(t *testing.T) {
+	port, err := parseHostPort("0.0.0.0:8081\n[::]:8081\n")
+	require.NoError(t, err)
+	assert.Equal(t, 8081, port)
+
+	_, err = parseHostPort("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// SBOMFileName is the file, relative to the project directory, that build writes the image SBOM to
+// and publish picks it up from.
+const SBOMFileName = "sbom.json"
+
+// sbomFormats maps the supported SBOM formats to their syft output names.
+var sbomFormats = map[string]string{
+	"spdx":      "spdx-json",
+	"cyclonedx": "cyclonedx-json",
+}
+
+// GenerateSBOM scans a built image with syft and writes a JSON SBOM in the given format (spdx or cyclonedx) to outputPath.
+func (e *Executor) GenerateSBOM(imageName, format, outputPath string) error {
+	syftFormat, ok := sbomFormats[format]
+	if !ok {
+		return fmt.Errorf("unsupported SBOM format %q (expected spdx or cyclonedx)", format)
+	}
+	if _, err := exec.LookPath("syft"); err != nil {
+		return fmt.Errorf("syft command not found in PATH. Install it from https://github.com/anchore/syft to generate SBOMs")
+	}
+
+	// Scan the local docker daemon image so unpushed builds work too
+	args := []string{"scan", "docker:" + imageName, "-o", syftFormat + "=" + outputPath}
+	if !e.Verbose {
+		args = append(args, "-q")
+	} else {
+		fmt.Printf("Running: syft %s\n", strings.Join(args, " "))
+	}
+
+	cmd := exec.Command("syft", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if e.WorkDir != "" {
+		cmd.Dir = e.WorkDir
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sbom generation failed: %w", err)
+	}
+	fmt.Printf("✅ Generated %s SBOM for %s: %s\n", format, imageName, outputPath)
+	return nil
+}
@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/eval"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var EvalCmd = &cobra.Command{
+	Use:   "eval [project-directory-or-agent-name]",
+	Short: "Run an evaluation suite against an agent",
+	Long: `Run an agent locally like 'arctl agent run', send every case of an evaluation suite to it over A2A
+and score the answers. Each case runs in a new conversation and passes when all its checks pass:
+
+  exact     the answer equals the value, ignoring surrounding whitespace
+  contains  the answer contains every value, ignoring case
+  judge     an LLM judge decides whether the answer meets a plain-language expectation
+
+The judge model is configured in the suite's 'judge' section or with --judge-provider and --judge-model.
+The command exits with an error when any case fails, and --report writes the results as JSON for CI.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEval,
+	Example: `  arctl agent eval ./my-agent --suite eval.yaml
+  arctl agent eval dice --suite eval.yaml --report eval-report.json
+  arctl agent eval ./my-agent --suite eval.yaml --judge-provider anthropic --judge-model claude-3-5-haiku-latest`,
+}
+
+var (
+	evalSuite         string
+	evalReport        string
+	evalJudgeProvider string
+	evalJudgeModel    string
+	evalCaseTimeout   time.Duration
+)
+
+func init() {
+	EvalCmd.Flags().StringVar(&evalSuite, "suite", "eval.yaml", "Path to the evaluation suite")
+	EvalCmd.Flags().StringVar(&evalReport, "report", "", "Write the results as JSON to this file ('-' for stdout)")
+	EvalCmd.Flags().StringVar(&evalJudgeProvider, "judge-provider", "", "LLM judge provider (openai, anthropic, gemini); overrides the suite")
+	EvalCmd.Flags().StringVar(&evalJudgeModel, "judge-model", "", "LLM judge model; overrides the suite")
+	EvalCmd.Flags().DurationVar(&evalCaseTimeout, "case-timeout", 2*time.Minute, "Maximum time to wait for the agent to answer a case")
+}
+
+func runEval(cmd *cobra.Command, args []string) error {
+	suite, err := eval.LoadSuite(evalSuite)
+	if err != nil {
+		return err
+	}
+
+	var judge eval.Judge
+	if suite.NeedsJudge() {
+		cfg := eval.JudgeConfig{}
+		if suite.Judge != nil {
+			cfg = *suite.Judge
+		}
+		if evalJudgeProvider != "" {
+			cfg.Provider = evalJudgeProvider
+		}
+		if evalJudgeModel != "" {
+			cfg.Model = evalJudgeModel
+		}
+		if cfg.Provider == "" {
+			return fmt.Errorf("the suite has judge expectations; configure a judge in the suite or with --judge-provider and --judge-model")
+		}
+		// Fail before starting the agent when the judge is misconfigured.
+		if judge, err = eval.NewJudge(cfg); err != nil {
+			return err
+		}
+	}
+
+	var report *eval.Report
+	session := func(ctx context.Context, manifest *models.AgentManifest) error {
+		send, err := eval.NewA2ASender("http://localhost:8080", evalCaseTimeout)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Running %d eval case(s)...\n", len(suite.Cases))
+		report = eval.Run(ctx, suite, manifest.Name, send, judge)
+		return nil
+	}
+
+	prepared, err := prepareTarget(args[0])
+	if err != nil {
+		return err
+	}
+	defer prepared.cleanup()
+	if err := runAgent(cmd.Context(), prepared.composeData, prepared.manifest, prepared.workDir, session); err != nil {
+		return err
+	}
+
+	printEvalReport(report)
+	if evalReport != "" {
+		if err := writeEvalReport(report, evalReport); err != nil {
+			return err
+		}
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d eval case(s) failed", report.Failed, report.Total)
+	}
+	return nil
+}
+
+func printEvalReport(report *eval.Report) {
+	fmt.Println()
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Case", "Result", "Duration", "Details")
+	for _, c := range report.Cases {
+		result := "PASS"
+		if !c.Passed {
+			result = "FAIL"
+		}
+		t.AddRow(c.Name, result, (time.Duration(c.DurationMs) * time.Millisecond).String(), caseDetails(c))
+	}
+	if err := t.Render(); err != nil {
+		printer.PrintError(fmt.Sprintf("failed to render table: %v", err))
+	}
+
+	summary := fmt.Sprintf("%d/%d eval case(s) passed", report.Passed, report.Total)
+	if report.Failed > 0 {
+		printer.PrintError(summary)
+	} else {
+		printer.PrintSuccess(summary)
+	}
+}
+
+func caseDetails(c eval.CaseResult) string {
+	if c.Error != "" {
+		return "error: " + c.Error
+	}
+	var details []string
+	for _, check := range c.Checks {
+		if check.Passed {
+			continue
+		}
+		detail := check.Check
+		if check.Detail != "" {
+			detail += ": " + check.Detail
+		}
+		details = append(details, detail)
+	}
+	return printer.TruncateString(strings.Join(details, "; "), 80)
+}
+
+func writeEvalReport(report *eval.Report, path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal eval report: %w", err)
+	}
+	if path == "-" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write eval report: %w", err)
+	}
+	fmt.Printf("Wrote eval report to %s\n", path)
+	return nil
+}
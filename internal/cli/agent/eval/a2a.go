@@ -0,0 +1,85 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+// NewA2ASender returns a SendFunc that streams each prompt to the A2A agent at agentURL in a new context and
+// returns the final text of the answer. Tool calls and other data parts are ignored.
+func NewA2ASender(agentURL string, timeout time.Duration) (SendFunc, error) {
+	client, err := a2aclient.NewA2AClient(agentURL, a2aclient.WithTimeout(timeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create A2A client: %w", err)
+	}
+
+	return func(ctx context.Context, prompt string) (string, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		contextID := protocol.GenerateContextID()
+		events, err := client.StreamMessage(ctx, protocol.SendMessageParams{
+			Message: protocol.Message{
+				Kind:      protocol.KindMessage,
+				Role:      protocol.MessageRoleUser,
+				ContextID: &contextID,
+				Parts:     []protocol.Part{protocol.NewTextPart(prompt)},
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+
+		var artifacts strings.Builder
+		var last string
+		for ev := range events {
+			switch res := ev.Result.(type) {
+			case *protocol.TaskStatusUpdateEvent:
+				if res.Status.Message != nil && res.Status.Message.Role == protocol.MessageRoleAgent {
+					if text := textOf(res.Status.Message.Parts); text != "" {
+						last = text
+					}
+				}
+			case *protocol.TaskArtifactUpdateEvent:
+				artifacts.WriteString(textOf(res.Artifact.Parts))
+			case *protocol.Message:
+				if res.Role == protocol.MessageRoleAgent {
+					if text := textOf(res.Parts); text != "" {
+						last = text
+					}
+				}
+			case *protocol.Task:
+				for _, msg := range res.History {
+					if msg.Role == protocol.MessageRoleAgent {
+						if text := textOf(msg.Parts); text != "" {
+							last = text
+						}
+					}
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("no answer within %s: %w", timeout, ctx.Err())
+		}
+
+		if artifacts.Len() > 0 {
+			return artifacts.String(), nil
+		}
+		return last, nil
+	}, nil
+}
+
+func textOf(parts []protocol.Part) string {
+	var b strings.Builder
+	for _, p := range parts {
+		if tp, ok := p.(*protocol.TextPart); ok {
+			b.WriteString(tp.Text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubJudge struct{ verdict Verdict }
+
+func (j stubJudge) Judge(context.Context, string, string, string) (*Verdict, error) {
+	return &j.verdict, nil
+}
+
+func TestRun(t *testing.T) {
+	suite := &Suite{
+		Name: "dice",
+		Cases: []Case{
+			{Name: "exact", Prompt: "say hi", Expect: Expectation{Exact: "hi"}},
+			{Name: "contains", Prompt: "roll", Expect: Expectation{Contains: []string{"Rolled", "6"}}},
+			{Name: "judged", Prompt: "roll", Expect: Expectation{Contains: []string{"rolled"}, Judge: "reports a roll"}},
+			{Name: "broken", Prompt: "fail", Expect: Expectation{Exact: "x"}},
+		},
+	}
+	require.NoError(t, suite.Validate())
+
+	send := func(_ context.Context, prompt string) (string, error) {
+		switch prompt {
+		case "say hi":
+			return "  hi\n", nil
+		case "roll":
+			return "I rolled a 4.", nil
+		default:
+			return "", errors.New("connection refused")
+		}
+	}
+
+	report := Run(context.Background(), suite, "dice", send, stubJudge{verdict: Verdict{Pass: true}})
+
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 2, report.Passed)
+	assert.Equal(t, 2, report.Failed)
+	assert.True(t, report.Cases[0].Passed)
+	assert.False(t, report.Cases[1].Passed)
+	assert.Equal(t, `missing "6"`, report.Cases[1].Checks[0].Detail)
+	assert.True(t, report.Cases[2].Passed)
+	assert.Len(t, report.Cases[2].Checks, 2)
+	assert.Equal(t, "connection refused", report.Cases[3].Error)
+}
+
+func TestSuiteValidate(t *testing.T) {
+	assert.ErrorContains(t, (&Suite{}).Validate(), "at least one case")
+	assert.ErrorContains(t, (&Suite{Cases: []Case{{Prompt: "hi"}}}).Validate(), "at least one of exact, contains or judge")
+
+	suite := &Suite{Cases: []Case{{Prompt: "hi", Expect: Expectation{Judge: "greets"}}}}
+	require.NoError(t, suite.Validate())
+	assert.Equal(t, "case-1", suite.Cases[0].Name)
+	assert.True(t, suite.NeedsJudge())
+}
+
+func TestParseVerdict(t *testing.T) {
+	verdict, err := parseVerdict("```json\n{\"pass\": false, \"reason\": \"no roll reported\"}\n```")
+	require.NoError(t, err)
+	assert.Equal(t, &Verdict{Pass: false, Reason: "no roll reported"}, verdict)
+
+	_, err = parseVerdict("yes")
+	assert.Error(t, err)
+}
@@ -0,0 +1,241 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// JudgeConfig selects the model used to score `judge` expectations.
+type JudgeConfig struct {
+	// Provider is one of openai, anthropic or gemini. OpenAI-compatible endpoints can be used with BaseURL.
+	Provider string `yaml:"provider" json:"provider"`
+	Model    string `yaml:"model" json:"model"`
+	// BaseURL overrides the provider's API endpoint.
+	BaseURL string `yaml:"baseURL,omitempty" json:"baseURL,omitempty"`
+	// APIKeyEnv names the environment variable holding the API key, defaulting to the provider's usual variable.
+	APIKeyEnv string `yaml:"apiKeyEnv,omitempty" json:"apiKeyEnv,omitempty"`
+}
+
+// Verdict is the decision of a judge.
+type Verdict struct {
+	Pass   bool   `json:"pass"`
+	Reason string `json:"reason"`
+}
+
+// Judge decides whether an answer meets a plain-language expectation.
+type Judge interface {
+	Judge(ctx context.Context, prompt, response, criteria string) (*Verdict, error)
+}
+
+var judgeDefaults = map[string]struct {
+	baseURL   string
+	apiKeyEnv string
+}{
+	"openai":    {baseURL: "https://api.openai.com/v1", apiKeyEnv: "OPENAI_API_KEY"},
+	"anthropic": {baseURL: "https://api.anthropic.com/v1", apiKeyEnv: "ANTHROPIC_API_KEY"},
+	"gemini":    {baseURL: "https://generativelanguage.googleapis.com/v1beta", apiKeyEnv: "GOOGLE_API_KEY"},
+}
+
+// NewJudge creates an LLM judge for the configured provider.
+func NewJudge(cfg JudgeConfig) (Judge, error) {
+	provider := strings.ToLower(cfg.Provider)
+	defaults, ok := judgeDefaults[provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported judge provider %q, expected openai, anthropic or gemini", cfg.Provider)
+	}
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("judge model is required")
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaults.baseURL
+	}
+	apiKeyEnv := cfg.APIKeyEnv
+	if apiKeyEnv == "" {
+		apiKeyEnv = defaults.apiKeyEnv
+	}
+	apiKey := os.Getenv(apiKeyEnv)
+	if apiKey == "" {
+		return nil, fmt.Errorf("%s is required for the %s judge", apiKeyEnv, provider)
+	}
+
+	return &llmJudge{
+		provider:   provider,
+		model:      cfg.Model,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+const judgeInstructions = `You are grading the answer of an AI agent in an automated test.
+Decide whether the answer meets the expectation. Reply with only a JSON object of the form
+{"pass": true or false, "reason": "<one sentence>"}.`
+
+type llmJudge struct {
+	provider   string
+	model      string
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func (j *llmJudge) Judge(ctx context.Context, prompt, response, criteria string) (*Verdict, error) {
+	question := fmt.Sprintf("Prompt sent to the agent:\n%s\n\nAnswer of the agent:\n%s\n\nExpectation:\n%s", prompt, response, criteria)
+
+	var (
+		text string
+		err  error
+	)
+	switch j.provider {
+	case "openai":
+		text, err = j.completeOpenAI(ctx, question)
+	case "anthropic":
+		text, err = j.completeAnthropic(ctx, question)
+	case "gemini":
+		text, err = j.completeGemini(ctx, question)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseVerdict(text)
+}
+
+func (j *llmJudge) completeOpenAI(ctx context.Context, question string) (string, error) {
+	body := map[string]any{
+		"model": j.model,
+		"messages": []map[string]string{
+			{"role": "system", "content": judgeInstructions},
+			{"role": "user", "content": question},
+		},
+		"temperature": 0,
+	}
+	var parsed struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	headers := map[string]string{"Authorization": "Bearer " + j.apiKey}
+	if err := j.post(ctx, j.baseURL+"/chat/completions", headers, body, &parsed); err != nil {
+		return "", err
+	}
+	if len(parsed.Choices) == 0 {
+		return "", fmt.Errorf("judge returned no choices")
+	}
+	return parsed.Choices[0].Message.Content, nil
+}
+
+func (j *llmJudge) completeAnthropic(ctx context.Context, question string) (string, error) {
+	body := map[string]any{
+		"model":      j.model,
+		"max_tokens": 256,
+		"system":     judgeInstructions,
+		"messages": []map[string]string{
+			{"role": "user", "content": question},
+		},
+	}
+	var parsed struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	headers := map[string]string{"x-api-key": j.apiKey, "anthropic-version": "2023-06-01"}
+	if err := j.post(ctx, j.baseURL+"/messages", headers, body, &parsed); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, c := range parsed.Content {
+		if c.Type == "text" {
+			b.WriteString(c.Text)
+		}
+	}
+	return b.String(), nil
+}
+
+func (j *llmJudge) completeGemini(ctx context.Context, question string) (string, error) {
+	body := map[string]any{
+		"systemInstruction": map[string]any{"parts": []map[string]string{{"text": judgeInstructions}}},
+		"contents": []map[string]any{
+			{"role": "user", "parts": []map[string]string{{"text": question}}},
+		},
+		"generationConfig": map[string]any{"temperature": 0},
+	}
+	var parsed struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+	}
+	endpoint := fmt.Sprintf("%s/models/%s:generateContent", j.baseURL, j.model)
+	if err := j.post(ctx, endpoint, map[string]string{"x-goog-api-key": j.apiKey}, body, &parsed); err != nil {
+		return "", err
+	}
+	if len(parsed.Candidates) == 0 {
+		return "", fmt.Errorf("judge returned no candidates")
+	}
+	var b strings.Builder
+	for _, p := range parsed.Candidates[0].Content.Parts {
+		b.WriteString(p.Text)
+	}
+	return b.String(), nil
+}
+
+func (j *llmJudge) post(ctx context.Context, endpoint string, headers map[string]string, in, out any) error {
+	reqBody, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to marshal judge request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create judge request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := j.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("judge request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read judge response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("judge provider returned %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode judge response: %w", err)
+	}
+	return nil
+}
+
+// parseVerdict extracts the JSON verdict from the judge's reply, tolerating surrounding text or code fences.
+func parseVerdict(text string) (*Verdict, error) {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("judge reply is not a JSON verdict: %q", text)
+	}
+	var verdict Verdict
+	if err := json.Unmarshal([]byte(text[start:end+1]), &verdict); err != nil {
+		return nil, fmt.Errorf("judge reply is not a JSON verdict: %w", err)
+	}
+	return &verdict, nil
+}
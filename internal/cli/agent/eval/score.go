@@ -0,0 +1,125 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CheckResult is the outcome of one check of a case.
+type CheckResult struct {
+	Check  string `json:"check"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// CaseResult is the outcome of a case.
+type CaseResult struct {
+	Name       string        `json:"name"`
+	Prompt     string        `json:"prompt"`
+	Response   string        `json:"response"`
+	Passed     bool          `json:"passed"`
+	Checks     []CheckResult `json:"checks,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	DurationMs int64         `json:"durationMs"`
+}
+
+// Report is the outcome of a suite run.
+type Report struct {
+	Suite  string       `json:"suite"`
+	Agent  string       `json:"agent"`
+	Total  int          `json:"total"`
+	Passed int          `json:"passed"`
+	Failed int          `json:"failed"`
+	Cases  []CaseResult `json:"cases"`
+}
+
+// SendFunc sends a prompt to the agent in a new conversation and returns the text of its answer.
+type SendFunc func(ctx context.Context, prompt string) (string, error)
+
+// Run sends every case of the suite to the agent and scores the answers. judge may be nil when no case uses it.
+func Run(ctx context.Context, suite *Suite, agentName string, send SendFunc, judge Judge) *Report {
+	report := &Report{Suite: suite.Name, Agent: agentName, Total: len(suite.Cases)}
+	for _, c := range suite.Cases {
+		result := runCase(ctx, c, send, judge)
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Cases = append(report.Cases, result)
+	}
+	return report
+}
+
+func runCase(ctx context.Context, c Case, send SendFunc, judge Judge) CaseResult {
+	result := CaseResult{Name: c.Name, Prompt: c.Prompt}
+
+	start := time.Now()
+	response, err := send(ctx, c.Prompt)
+	result.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Response = response
+
+	if c.Expect.Exact != "" {
+		result.Checks = append(result.Checks, ScoreExact(response, c.Expect.Exact))
+	}
+	if len(c.Expect.Contains) > 0 {
+		result.Checks = append(result.Checks, ScoreContains(response, c.Expect.Contains))
+	}
+	if c.Expect.Judge != "" {
+		result.Checks = append(result.Checks, scoreJudge(ctx, judge, c.Prompt, response, c.Expect.Judge))
+	}
+
+	result.Passed = true
+	for _, check := range result.Checks {
+		result.Passed = result.Passed && check.Passed
+	}
+	return result
+}
+
+// ScoreExact passes when the response equals the expected answer, ignoring surrounding whitespace.
+func ScoreExact(response, expected string) CheckResult {
+	if strings.TrimSpace(response) == strings.TrimSpace(expected) {
+		return CheckResult{Check: "exact", Passed: true}
+	}
+	return CheckResult{Check: "exact", Detail: fmt.Sprintf("expected %q", strings.TrimSpace(expected))}
+}
+
+// ScoreContains passes when the response contains every expected value, ignoring case.
+func ScoreContains(response string, expected []string) CheckResult {
+	lower := strings.ToLower(response)
+	var missing []string
+	for _, want := range expected {
+		if !strings.Contains(lower, strings.ToLower(want)) {
+			missing = append(missing, want)
+		}
+	}
+	if len(missing) > 0 {
+		return CheckResult{Check: "contains", Detail: fmt.Sprintf("missing %s", strings.Join(quoteAll(missing), ", "))}
+	}
+	return CheckResult{Check: "contains", Passed: true}
+}
+
+func scoreJudge(ctx context.Context, judge Judge, prompt, response, criteria string) CheckResult {
+	if judge == nil {
+		return CheckResult{Check: "judge", Detail: "no judge configured"}
+	}
+	verdict, err := judge.Judge(ctx, prompt, response, criteria)
+	if err != nil {
+		return CheckResult{Check: "judge", Detail: fmt.Sprintf("judge failed: %v", err)}
+	}
+	return CheckResult{Check: "judge", Passed: verdict.Pass, Detail: verdict.Reason}
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return quoted
+}
@@ -0,0 +1,94 @@
+package eval
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Suite is an evaluation suite, usually loaded from eval.yaml.
+//
+//	name: dice
+//	judge:
+//	  provider: openai
+//	  model: gpt-4o-mini
+//	cases:
+//	  - name: rolls a die
+//	    prompt: Roll a 6-sided die
+//	    expect:
+//	      contains: ["rolled"]
+//	      judge: The answer reports a single roll between 1 and 6
+type Suite struct {
+	Name  string       `yaml:"name" json:"name"`
+	Judge *JudgeConfig `yaml:"judge,omitempty" json:"judge,omitempty"`
+	Cases []Case       `yaml:"cases" json:"cases"`
+}
+
+// Case is a single prompt sent to the agent in a fresh conversation, with the behavior expected from the answer.
+type Case struct {
+	Name   string      `yaml:"name" json:"name"`
+	Prompt string      `yaml:"prompt" json:"prompt"`
+	Expect Expectation `yaml:"expect" json:"expect"`
+}
+
+// Expectation lists the checks an answer must pass. All configured checks must pass for the case to pass.
+type Expectation struct {
+	// Exact requires the answer to equal the value, ignoring surrounding whitespace.
+	Exact string `yaml:"exact,omitempty" json:"exact,omitempty"`
+	// Contains requires the answer to contain every value, ignoring case.
+	Contains []string `yaml:"contains,omitempty" json:"contains,omitempty"`
+	// Judge describes the expected behavior in plain language; an LLM judge decides whether the answer meets it.
+	Judge string `yaml:"judge,omitempty" json:"judge,omitempty"`
+}
+
+func (e Expectation) empty() bool {
+	return e.Exact == "" && len(e.Contains) == 0 && e.Judge == ""
+}
+
+// LoadSuite reads and validates an evaluation suite.
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read eval suite: %w", err)
+	}
+
+	var suite Suite
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("failed to parse eval suite: %w", err)
+	}
+	if err := suite.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid eval suite: %w", err)
+	}
+	return &suite, nil
+}
+
+// Validate checks that every case has a prompt and at least one expectation.
+func (s *Suite) Validate() error {
+	if len(s.Cases) == 0 {
+		return fmt.Errorf("at least one case is required")
+	}
+	for i := range s.Cases {
+		c := &s.Cases[i]
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("case-%d", i+1)
+		}
+		if c.Prompt == "" {
+			return fmt.Errorf("cases[%d] (%s): prompt is required", i, c.Name)
+		}
+		if c.Expect.empty() {
+			return fmt.Errorf("cases[%d] (%s): at least one of exact, contains or judge is required", i, c.Name)
+		}
+	}
+	return nil
+}
+
+// NeedsJudge reports whether any case is scored by the LLM judge.
+func (s *Suite) NeedsJudge() bool {
+	for _, c := range s.Cases {
+		if c.Expect.Judge != "" {
+			return true
+		}
+	}
+	return false
+}
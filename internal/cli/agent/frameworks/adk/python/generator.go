@@ -51,24 +51,11 @@ func (g *PythonGenerator) Generate(agentConfig *common.AgentConfig) error {
 		return fmt.Errorf("failed to generate project: %w", err)
 	}
 
-	manifest := &models.AgentManifest{
-		Name:              agentConfig.Name,
-		Image:             agentConfig.Image,
-		Language:          agentConfig.Language,
-		Framework:         agentConfig.Framework,
-		ModelProvider:     agentConfig.ModelProvider,
-		ModelName:         agentConfig.ModelName,
-		Description:       agentConfig.Description,
-		TelemetryEndpoint: agentConfig.TelemetryEndpoint,
-		McpServers:        agentConfig.McpServers,
-	}
-
-	manager := common.NewManifestManager(agentConfig.Directory)
-	if err := manager.Save(manifest); err != nil {
-		return fmt.Errorf("failed to write agent manifest: %w", err)
+	if err := g.WriteProjectFiles(*agentConfig); err != nil {
+		return err
 	}
 
-	if err := relocateAgentPackage(agentConfig.Directory, projectPackageDir); err != nil {
+	if err := common.RelocateAgentPackage(agentConfig.Directory, projectPackageDir); err != nil {
 		return err
 	}
 
@@ -76,34 +63,9 @@ func (g *PythonGenerator) Generate(agentConfig *common.AgentConfig) error {
 	return nil
 }
 
-func relocateAgentPackage(projectDir, packageDir string) error {
-	agentDir := filepath.Join(projectDir, "agent")
-	if _, err := os.Stat(agentDir); err != nil {
-		return nil
-	}
-
-	entries, err := os.ReadDir(agentDir)
-	if err != nil {
-		return fmt.Errorf("failed to read agent directory: %w", err)
-	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		src := filepath.Join(agentDir, entry.Name())
-		dst := filepath.Join(packageDir, entry.Name())
-		if err := os.Rename(src, dst); err != nil {
-			return fmt.Errorf("failed to move %s to %s: %w", src, dst, err)
-		}
-	}
-
-	if err := os.Remove(agentDir); err != nil {
-		return fmt.Errorf("failed to remove agent directory: %w", err)
-	}
-
-	return nil
+// RenderMcpTools renders <name>/mcp_tools.py for the MCP servers in the manifest.
+func (g *PythonGenerator) RenderMcpTools(manifest *models.AgentManifest) (string, []byte, error) {
+	return common.RenderMcpToolsFile(g.BaseGenerator, manifest, "agent/mcp_tools.py.tmpl", filepath.Join(manifest.Name, "mcp_tools.py"))
 }
 
 func printSummary(cfg *common.AgentConfig) {
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 )
 
@@ -44,10 +45,6 @@ func (c AgentConfig) ShouldSkipPath(path string) bool {
 	if strings.HasPrefix(path, "mcp_server") {
 		return true
 	}
-	// Skip OTLP collector config unless telemetry is enabled.
-	if path == "otel-collector-config.yaml" && c.TelemetryEndpoint == "" {
-		return true
-	}
 	return false
 }
 
@@ -132,6 +129,53 @@ func (g *BaseGenerator) GenerateProject(config AgentConfig) error {
 	return nil
 }
 
+// WriteProjectFiles writes the files every framework shares: the agent.yaml manifest and docker-compose.yaml.
+func (g *BaseGenerator) WriteProjectFiles(config AgentConfig) error {
+	manifest := &models.AgentManifest{
+		Name:              config.Name,
+		Image:             config.Image,
+		Language:          config.Language,
+		Framework:         config.Framework,
+		ModelProvider:     config.ModelProvider,
+		ModelName:         config.ModelName,
+		Description:       config.Description,
+		TelemetryEndpoint: config.TelemetryEndpoint,
+		McpServers:        config.McpServers,
+	}
+	if err := NewManifestManager(config.Directory).Save(manifest); err != nil {
+		return fmt.Errorf("failed to write agent manifest: %w", err)
+	}
+
+	return WriteCompose(config.Directory, ComposeConfig{
+		Name:              config.Name,
+		Version:           utils.SanitizeVersion(config.Version),
+		Image:             config.Image,
+		ModelProvider:     config.ModelProvider,
+		ModelName:         config.ModelName,
+		TelemetryEndpoint: config.TelemetryEndpoint,
+		EnvVars:           config.EnvVars,
+		McpServers:        config.McpServers,
+	})
+}
+
+// RenderMcpToolsFile renders the MCP tools template of a generator for the servers in the manifest and returns it
+// along with its project-relative target path.
+func RenderMcpToolsFile(g *BaseGenerator, manifest *models.AgentManifest, templatePath, target string) (string, []byte, error) {
+	templateBytes, err := g.ReadTemplateFile(templatePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read mcp tools template: %w", err)
+	}
+	rendered, err := g.RenderTemplate(string(templateBytes), struct {
+		McpServers []models.McpServerType
+	}{
+		McpServers: manifest.McpServers,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to render mcp tools template: %w", err)
+	}
+	return target, []byte(rendered), nil
+}
+
 // RenderTemplate renders a template string with the provided data.
 func (g *BaseGenerator) RenderTemplate(tmplContent string, data any) (string, error) {
 	tmpl, err := template.New("template").Parse(tmplContent)
@@ -153,6 +197,38 @@ func (g *BaseGenerator) ReadTemplateFile(templatePath string) ([]byte, error) {
 	return fs.ReadFile(g.templateFiles, fullPath)
 }
 
+// RelocateAgentPackage moves the files rendered from templates/agent into the Python package directory of the
+// project, which is named after the agent.
+func RelocateAgentPackage(projectDir, packageDir string) error {
+	agentDir := filepath.Join(projectDir, "agent")
+	if _, err := os.Stat(agentDir); err != nil {
+		return nil
+	}
+
+	entries, err := os.ReadDir(agentDir)
+	if err != nil {
+		return fmt.Errorf("failed to read agent directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		src := filepath.Join(agentDir, entry.Name())
+		dst := filepath.Join(packageDir, entry.Name())
+		if err := os.Rename(src, dst); err != nil {
+			return fmt.Errorf("failed to move %s to %s: %w", src, dst, err)
+		}
+	}
+
+	if err := os.Remove(agentDir); err != nil {
+		return fmt.Errorf("failed to remove agent directory: %w", err)
+	}
+
+	return nil
+}
+
 func initGitRepo(dir string, verbose bool) error {
 	cmd := exec.Command("git", "init")
 	cmd.Dir = dir
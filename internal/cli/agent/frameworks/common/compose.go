@@ -0,0 +1,80 @@
+package common
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// The docker-compose.yaml and OpenTelemetry collector config are the same for every framework:
+// all agent images are started as `<agent-name> --local` and serve A2A on port 8080.
+//
+//go:embed templates/docker-compose.yaml.tmpl templates/otel-collector-config.yaml
+var composeFS embed.FS
+
+// ComposeConfig is the data rendered into an agent project's docker-compose.yaml.
+type ComposeConfig struct {
+	Name              string
+	Version           string
+	Image             string
+	ModelProvider     string
+	ModelName         string
+	TelemetryEndpoint string
+	EnvVars           []string
+	McpServers        []models.McpServerType
+}
+
+// ComposeConfigFromManifest builds the compose data of a project. The version is sanitized for use in paths.
+func ComposeConfigFromManifest(manifest *models.AgentManifest, version, image string, envVars []string) ComposeConfig {
+	return ComposeConfig{
+		Name:              manifest.Name,
+		Version:           utils.SanitizeVersion(version),
+		Image:             image,
+		ModelProvider:     manifest.ModelProvider,
+		ModelName:         manifest.ModelName,
+		TelemetryEndpoint: manifest.TelemetryEndpoint,
+		EnvVars:           envVars,
+		McpServers:        manifest.McpServers,
+	}
+}
+
+// RenderCompose renders docker-compose.yaml for an agent project.
+func RenderCompose(cfg ComposeConfig) ([]byte, error) {
+	content, err := composeFS.ReadFile("templates/docker-compose.yaml.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker-compose template: %w", err)
+	}
+	rendered, err := (&BaseGenerator{}).RenderTemplate(string(content), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render docker-compose template: %w", err)
+	}
+	return []byte(rendered), nil
+}
+
+// WriteCompose renders docker-compose.yaml into dir, along with the collector config it mounts when
+// telemetry is enabled.
+func WriteCompose(dir string, cfg ComposeConfig) error {
+	rendered, err := RenderCompose(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "docker-compose.yaml"), rendered, 0o644); err != nil {
+		return fmt.Errorf("failed to write docker-compose.yaml: %w", err)
+	}
+
+	if cfg.TelemetryEndpoint == "" {
+		return nil
+	}
+	collectorConfig, err := composeFS.ReadFile("templates/otel-collector-config.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to read collector config: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "otel-collector-config.yaml"), collectorConfig, 0o644); err != nil {
+		return fmt.Errorf("failed to write otel-collector-config.yaml: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package custom
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
+)
+
+// Language is the language recorded for custom agents, which may be written in anything that fits in a container.
+const Language = "any"
+
+//go:embed templates/*
+var templatesFS embed.FS
+
+// Generator renders a minimal project around a user-provided Dockerfile.
+type Generator struct {
+	*common.BaseGenerator
+}
+
+// NewGenerator instantiates a custom Dockerfile generator.
+func NewGenerator() *Generator {
+	return &Generator{
+		BaseGenerator: common.NewBaseGenerator(templatesFS),
+	}
+}
+
+// Generate scaffolds a new agent on disk.
+func (g *Generator) Generate(agentConfig *common.AgentConfig) error {
+	if agentConfig == nil {
+		return fmt.Errorf("agent config is required")
+	}
+
+	agentConfig.Framework = "custom"
+	agentConfig.Language = Language
+
+	if err := g.GenerateProject(*agentConfig); err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+
+	if err := os.Chmod(filepath.Join(agentConfig.Directory, "entrypoint.sh"), 0o755); err != nil {
+		return fmt.Errorf("failed to make entrypoint.sh executable: %w", err)
+	}
+
+	if err := g.WriteProjectFiles(*agentConfig); err != nil {
+		return err
+	}
+
+	printSummary(agentConfig)
+	return nil
+}
+
+func printSummary(cfg *common.AgentConfig) {
+	fmt.Printf("✅ Successfully created %s project in %s\n", cfg.Framework, cfg.Directory)
+	fmt.Printf("📁 Project structure:\n")
+	fmt.Printf("   %s/\n", cfg.Name)
+	fmt.Printf("   ├── agent.yaml\n")
+	fmt.Printf("   ├── agent-card.json\n")
+	fmt.Printf("   ├── Dockerfile\n")
+	fmt.Printf("   ├── entrypoint.sh\n")
+	fmt.Printf("   ├── docker-compose.yaml\n")
+	fmt.Printf("   └── README.md\n")
+	if cfg.TelemetryEndpoint != "" {
+		fmt.Printf("   └── otel-collector-config.yaml\n")
+	}
+}
@@ -2,9 +2,16 @@ package frameworks
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	adkpython "github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/adk/python"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/custom"
+	langgraphpython "github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/langgraph/python"
+	langgraphts "github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/langgraph/typescript"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 )
 
 // Generator describes a framework/language scaffold generator.
@@ -12,17 +19,110 @@ type Generator interface {
 	Generate(agentConfig *common.AgentConfig) error
 }
 
-// NewGenerator instantiates the generator for the requested framework/language.
+// McpToolsGenerator is implemented by generators whose projects contain generated MCP tool wiring that has to be
+// re-rendered when the MCP servers in agent.yaml change.
+type McpToolsGenerator interface {
+	Generator
+	// RenderMcpTools returns the path, relative to the project directory, and content of the MCP tools file.
+	RenderMcpTools(manifest *models.AgentManifest) (string, []byte, error)
+}
+
+// Framework describes an agent framework that `arctl agent init` can scaffold.
+type Framework struct {
+	Name        string
+	Description string
+	// Languages lists the supported languages; the first one is the default.
+	Languages []string
+
+	newGenerator func(language string) Generator
+}
+
+// DefaultLanguage returns the language used when none is requested.
+func (f Framework) DefaultLanguage() string {
+	return f.Languages[0]
+}
+
+var registry = map[string]Framework{}
+
+// register adds a framework to the registry. It panics on duplicates, as registration happens at init time.
+func register(f Framework) {
+	if _, exists := registry[f.Name]; exists {
+		panic(fmt.Sprintf("agent framework %q registered twice", f.Name))
+	}
+	registry[f.Name] = f
+}
+
+func init() {
+	register(Framework{
+		Name:        "adk",
+		Description: "Google Agent Development Kit",
+		Languages:   []string{"python"},
+		newGenerator: func(string) Generator {
+			return adkpython.NewPythonGenerator()
+		},
+	})
+	register(Framework{
+		Name:        "langgraph",
+		Description: "LangGraph ReAct agent served over A2A",
+		Languages:   []string{"python", "typescript"},
+		newGenerator: func(language string) Generator {
+			if language == "typescript" {
+				return langgraphts.NewTypeScriptGenerator()
+			}
+			return langgraphpython.NewPythonGenerator()
+		},
+	})
+	register(Framework{
+		Name:        "custom",
+		Description: "Bring your own code: a Dockerfile and entrypoint to fill in",
+		Languages:   []string{custom.Language},
+		newGenerator: func(string) Generator {
+			return custom.NewGenerator()
+		},
+	})
+}
+
+// Get returns the registered framework with the given name.
+func Get(name string) (Framework, error) {
+	f, ok := registry[strings.ToLower(name)]
+	if !ok {
+		return Framework{}, fmt.Errorf("unsupported framework: %s. Supported frameworks: %s", name, strings.Join(Names(), ", "))
+	}
+	return f, nil
+}
+
+// List returns all registered frameworks sorted by name.
+func List() []Framework {
+	frameworks := make([]Framework, 0, len(registry))
+	for _, f := range registry {
+		frameworks = append(frameworks, f)
+	}
+	sort.Slice(frameworks, func(i, j int) bool { return frameworks[i].Name < frameworks[j].Name })
+	return frameworks
+}
+
+// Names returns the names of all registered frameworks, sorted.
+func Names() []string {
+	var names []string
+	for _, f := range List() {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// NewGenerator instantiates the generator for the requested framework/language. An empty language selects the
+// framework's default.
 func NewGenerator(framework, language string) (Generator, error) {
-	switch framework {
-	case "adk":
-		switch language {
-		case "python":
-			return adkpython.NewPythonGenerator(), nil
-		default:
-			return nil, fmt.Errorf("unsupported language %q for framework %q", language, framework)
-		}
-	default:
-		return nil, fmt.Errorf("unsupported framework: %s", framework)
+	f, err := Get(framework)
+	if err != nil {
+		return nil, err
+	}
+	language = strings.ToLower(language)
+	if language == "" {
+		language = f.DefaultLanguage()
+	}
+	if !slices.Contains(f.Languages, language) {
+		return nil, fmt.Errorf("unsupported language %q for framework %q. Supported languages: %s", language, f.Name, strings.Join(f.Languages, ", "))
 	}
+	return f.newGenerator(language), nil
 }
@@ -0,0 +1,65 @@
+package frameworks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestNewGenerator(t *testing.T) {
+	_, err := NewGenerator("langgraph", "")
+	require.NoError(t, err)
+
+	_, err = NewGenerator("ADK", "python")
+	require.NoError(t, err)
+
+	_, err = NewGenerator("langgraph", "typescript")
+	assert.ErrorContains(t, err, "unsupported language")
+
+	_, err = NewGenerator("autogen", "python")
+	assert.ErrorContains(t, err, "unsupported framework")
+}
+
+func TestGenerateWritesSharedProjectFiles(t *testing.T) {
+	for _, f := range List() {
+		for _, language := range f.Languages {
+			t.Run(f.Name+"/"+language, func(t *testing.T) {
+				dir := t.TempDir()
+				gen, err := NewGenerator(f.Name, language)
+				require.NoError(t, err)
+
+				require.NoError(t, gen.Generate(&common.AgentConfig{
+					Name:          "dice",
+					Image:         "localhost:5001/dice:latest",
+					Directory:     dir,
+					ModelProvider: "openai",
+					ModelName:     "gpt-4o-mini",
+					McpServers:    []models.McpServerType{{Type: "remote", Name: "search", URL: "https://example.com/mcp"}},
+				}))
+
+				manifest, err := common.NewManifestManager(dir).Load()
+				require.NoError(t, err)
+				assert.Equal(t, f.Name, manifest.Framework)
+				assert.Equal(t, language, manifest.Language)
+
+				compose, err := os.ReadFile(filepath.Join(dir, "docker-compose.yaml"))
+				require.NoError(t, err)
+				assert.Contains(t, string(compose), `command: ["dice", "--local"]`)
+				assert.FileExists(t, filepath.Join(dir, "Dockerfile"))
+
+				if toolsGen, ok := gen.(McpToolsGenerator); ok {
+					path, content, err := toolsGen.RenderMcpTools(manifest)
+					require.NoError(t, err)
+					assert.Contains(t, string(content), "https://example.com/mcp")
+					assert.FileExists(t, filepath.Join(dir, path))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package python
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+//go:embed templates/* templates/agent/* dice-agent-instruction.md
+var templatesFS embed.FS
+
+// PythonGenerator renders LangGraph Python agents served over A2A.
+type PythonGenerator struct {
+	*common.BaseGenerator
+}
+
+// NewPythonGenerator instantiates a LangGraph Python generator.
+func NewPythonGenerator() *PythonGenerator {
+	return &PythonGenerator{
+		BaseGenerator: common.NewBaseGenerator(templatesFS),
+	}
+}
+
+// Generate scaffolds a new agent on disk.
+func (g *PythonGenerator) Generate(agentConfig *common.AgentConfig) error {
+	if agentConfig == nil {
+		return fmt.Errorf("agent config is required")
+	}
+
+	projectPackageDir := filepath.Join(agentConfig.Directory, agentConfig.Name)
+	if err := os.MkdirAll(projectPackageDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create package directory: %w", err)
+	}
+
+	if agentConfig.Instruction == "" {
+		defaultInstructions, err := templatesFS.ReadFile("dice-agent-instruction.md")
+		if err != nil {
+			return fmt.Errorf("failed to read default instructions: %w", err)
+		}
+		agentConfig.Instruction = string(defaultInstructions)
+	}
+
+	agentConfig.Framework = "langgraph"
+	agentConfig.Language = "python"
+
+	if err := g.GenerateProject(*agentConfig); err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+
+	if err := g.WriteProjectFiles(*agentConfig); err != nil {
+		return err
+	}
+
+	if err := common.RelocateAgentPackage(agentConfig.Directory, projectPackageDir); err != nil {
+		return err
+	}
+
+	printSummary(agentConfig)
+	return nil
+}
+
+// RenderMcpTools renders <name>/mcp_tools.py for the MCP servers in the manifest.
+func (g *PythonGenerator) RenderMcpTools(manifest *models.AgentManifest) (string, []byte, error) {
+	return common.RenderMcpToolsFile(g.BaseGenerator, manifest, "agent/mcp_tools.py.tmpl", filepath.Join(manifest.Name, "mcp_tools.py"))
+}
+
+func printSummary(cfg *common.AgentConfig) {
+	fmt.Printf("✅ Successfully created %s project in %s\n", cfg.Framework, cfg.Directory)
+	fmt.Printf("🤖 Model configuration: %s (%s)\n", cfg.ModelProvider, cfg.ModelName)
+	fmt.Printf("📁 Project structure:\n")
+	fmt.Printf("   %s/\n", cfg.Name)
+	fmt.Printf("   ├── %s/\n", cfg.Name)
+	fmt.Printf("   │   ├── __init__.py\n")
+	fmt.Printf("   │   ├── agent.py\n")
+	fmt.Printf("   │   ├── server.py\n")
+	fmt.Printf("   │   ├── mcp_tools.py\n")
+	fmt.Printf("   │   └── agent-card.json\n")
+	fmt.Printf("   ├── agent.yaml\n")
+	fmt.Printf("   ├── pyproject.toml\n")
+	fmt.Printf("   ├── Dockerfile\n")
+	fmt.Printf("   ├── docker-compose.yaml\n")
+	fmt.Printf("   ├── README.md\n")
+	fmt.Printf("   └── .python-version\n")
+	if cfg.TelemetryEndpoint != "" {
+		fmt.Printf("   └── otel-collector-config.yaml\n")
+	}
+}
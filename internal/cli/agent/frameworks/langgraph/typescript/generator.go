@@ -0,0 +1,81 @@
+package typescript
+
+import (
+	"embed"
+	"fmt"
+	"path/filepath"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+//go:embed templates/* templates/src/* dice-agent-instruction.md
+var templatesFS embed.FS
+
+// TypeScriptGenerator renders LangGraph.js agents served over A2A.
+type TypeScriptGenerator struct {
+	*common.BaseGenerator
+}
+
+// NewTypeScriptGenerator instantiates a LangGraph TypeScript generator.
+func NewTypeScriptGenerator() *TypeScriptGenerator {
+	return &TypeScriptGenerator{
+		BaseGenerator: common.NewBaseGenerator(templatesFS),
+	}
+}
+
+// Generate scaffolds a new agent on disk.
+func (g *TypeScriptGenerator) Generate(agentConfig *common.AgentConfig) error {
+	if agentConfig == nil {
+		return fmt.Errorf("agent config is required")
+	}
+
+	if agentConfig.Instruction == "" {
+		defaultInstructions, err := templatesFS.ReadFile("dice-agent-instruction.md")
+		if err != nil {
+			return fmt.Errorf("failed to read default instructions: %w", err)
+		}
+		agentConfig.Instruction = string(defaultInstructions)
+	}
+
+	agentConfig.Framework = "langgraph"
+	agentConfig.Language = "typescript"
+
+	if err := g.GenerateProject(*agentConfig); err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+
+	if err := g.WriteProjectFiles(*agentConfig); err != nil {
+		return err
+	}
+
+	printSummary(agentConfig)
+	return nil
+}
+
+// RenderMcpTools renders src/mcpTools.ts for the MCP servers in the manifest.
+func (g *TypeScriptGenerator) RenderMcpTools(manifest *models.AgentManifest) (string, []byte, error) {
+	return common.RenderMcpToolsFile(g.BaseGenerator, manifest, "src/mcpTools.ts.tmpl", filepath.Join("src", "mcpTools.ts"))
+}
+
+func printSummary(cfg *common.AgentConfig) {
+	fmt.Printf("✅ Successfully created %s (TypeScript) project in %s\n", cfg.Framework, cfg.Directory)
+	fmt.Printf("🤖 Model configuration: %s (%s)\n", cfg.ModelProvider, cfg.ModelName)
+	fmt.Printf("📁 Project structure:\n")
+	fmt.Printf("   %s/\n", cfg.Name)
+	fmt.Printf("   ├── src/\n")
+	fmt.Printf("   │   ├── agent.ts\n")
+	fmt.Printf("   │   ├── server.ts\n")
+	fmt.Printf("   │   └── mcpTools.ts\n")
+	fmt.Printf("   ├── agent.yaml\n")
+	fmt.Printf("   ├── agent-card.json\n")
+	fmt.Printf("   ├── instruction.md\n")
+	fmt.Printf("   ├── package.json\n")
+	fmt.Printf("   ├── tsconfig.json\n")
+	fmt.Printf("   ├── Dockerfile\n")
+	fmt.Printf("   ├── docker-compose.yaml\n")
+	fmt.Printf("   └── README.md\n")
+	if cfg.TelemetryEndpoint != "" {
+		fmt.Printf("   └── otel-collector-config.yaml\n")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks"
@@ -16,25 +17,32 @@ import (
 const adkBaseImageVersion = "0.7.12"
 
 var InitCmd = &cobra.Command{
-	Use:   "init [framework] [language] [agent-name]",
+	Use:   "init [framework] [language] <agent-name>",
 	Short: "Initialize a new agent project",
 	Long: `Initialize a new agent project using the specified framework and language.
 
+Select the framework with --framework (or the legacy positional arguments). Supported frameworks:
+` + frameworkHelp() + `
 You can customize the root agent instructions using the --instruction-file flag.
 You can select a specific model using --model-provider and --model-name flags.
 If no custom instruction file is provided, a default dice-rolling instruction will be used.
 If no model flags are provided, defaults to Gemini (gemini-2.0-flash).
 
 Examples:
-arctl agent init adk python dice
+arctl agent init dice
+arctl agent init dice --framework langgraph --model-provider OpenAI
+arctl agent init dice --framework langgraph --language typescript
+arctl agent init dice --framework custom
 arctl agent init adk python dice --instruction-file instructions.md
 arctl agent init adk python dice --model-provider Gemini --model-name gemini-2.0-flash`,
-	Args:    cobra.ExactArgs(3),
+	Args:    cobra.RangeArgs(1, 3),
 	RunE:    runInit,
-	Example: `arctl agent init adk python dice`,
+	Example: `arctl agent init dice --framework langgraph`,
 }
 
 var (
+	initFramework         string
+	initLanguage          string
 	initInstructionFile   string
 	initModelProvider     string
 	initModelName         string
@@ -43,6 +51,8 @@ var (
 )
 
 func init() {
+	InitCmd.Flags().StringVar(&initFramework, "framework", "adk", "Agent framework ("+strings.Join(frameworks.Names(), ", ")+")")
+	InitCmd.Flags().StringVar(&initLanguage, "language", "", "Language of the framework (defaults to the framework's default language)")
 	InitCmd.Flags().StringVar(&initInstructionFile, "instruction-file", "", "Path to file containing custom instructions for the root agent")
 	InitCmd.Flags().StringVar(&initModelProvider, "model-provider", "Gemini", "Model provider (OpenAI, Anthropic, Gemini, AzureOpenAI)")
 	InitCmd.Flags().StringVar(&initModelName, "model-name", "gemini-2.0-flash", "Model name (e.g., gpt-4, claude-3-5-sonnet, gemini-2.0-flash)")
@@ -55,9 +65,10 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return cmd.Help()
 	}
 
-	framework := strings.ToLower(args[0])
-	language := strings.ToLower(args[1])
-	agentName := args[2]
+	framework, language, agentName, err := resolveInitArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 
 	if err := validateFrameworkAndLanguage(framework, language); err != nil {
 		return err
@@ -66,6 +77,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if err := utils.ValidatePythonIdentifier(agentName); err != nil {
 		return fmt.Errorf("invalid agent name: %w", err)
 	}
+	if language == "typescript" && agentName != strings.ToLower(agentName) {
+		return fmt.Errorf("invalid agent name: %s. TypeScript agents are npm packages and must be lowercase", agentName)
+	}
 
 	modelProvider, err := normalizeModelProvider(initModelProvider)
 	if err != nil {
@@ -128,20 +142,53 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("✓ Successfully created agent: %s\n", agentName)
-	printAgentNextSteps(agentName)
+	printAgentNextSteps(agentName, framework, language)
 	return nil
 }
 
+// resolveInitArgs supports `init <name>`, `init <framework> <name>` and the legacy
+// `init <framework> <language> <name>`, with --framework and --language as the flag equivalents.
+func resolveInitArgs(cmd *cobra.Command, args []string) (framework, language, agentName string, err error) {
+	framework = strings.ToLower(initFramework)
+	language = strings.ToLower(initLanguage)
+	agentName = args[len(args)-1]
+
+	if len(args) >= 2 {
+		positional := strings.ToLower(args[0])
+		if cmd.Flags().Changed("framework") && positional != framework {
+			return "", "", "", fmt.Errorf("framework %q conflicts with --framework %q", args[0], initFramework)
+		}
+		framework = positional
+	}
+	if len(args) == 3 {
+		positional := strings.ToLower(args[1])
+		if cmd.Flags().Changed("language") && positional != language {
+			return "", "", "", fmt.Errorf("language %q conflicts with --language %q", args[1], initLanguage)
+		}
+		language = positional
+	}
+	return framework, language, agentName, nil
+}
+
 func validateFrameworkAndLanguage(framework, language string) error {
-	if framework != "adk" {
-		return fmt.Errorf("unsupported framework: %s. Only 'adk' is supported", framework)
+	f, err := frameworks.Get(framework)
+	if err != nil {
+		return err
 	}
-	if language != "python" {
-		return fmt.Errorf("unsupported language: %s. Only 'python' is supported for ADK", language)
+	if language != "" && !slices.Contains(f.Languages, language) {
+		return fmt.Errorf("unsupported language: %s. Supported languages for %s: %s", language, f.Name, strings.Join(f.Languages, ", "))
 	}
 	return nil
 }
 
+func frameworkHelp() string {
+	var b strings.Builder
+	for _, f := range frameworks.List() {
+		fmt.Fprintf(&b, "  %-10s %s (%s)\n", f.Name, f.Description, strings.Join(f.Languages, ", "))
+	}
+	return b.String()
+}
+
 var supportedModelProviders = map[string]struct{}{
 	"openai":      {},
 	"anthropic":   {},
@@ -195,11 +242,18 @@ func defaultImage(agentName string) string {
 	return fmt.Sprintf("%s/%s:latest", registry, agentName)
 }
 
-func printAgentNextSteps(agentName string) {
+func printAgentNextSteps(agentName, framework, language string) {
 	fmt.Printf("   Note: MCP server directories are created when you run 'arctl agent add-mcp'\n")
 	fmt.Printf("\n🚀 Next steps:\n")
 	fmt.Printf("   1. cd %s\n", agentName)
-	fmt.Printf("   2. Customize your agent in %s/agent.py\n", agentName)
+	switch {
+	case framework == "custom":
+		fmt.Printf("   2. Replace the Dockerfile and entrypoint.sh with your agent\n")
+	case language == "typescript":
+		fmt.Printf("   2. Customize your agent in src/agent.ts\n")
+	default:
+		fmt.Printf("   2. Customize your agent in %s/agent.py\n", agentName)
+	}
 	fmt.Printf("   3. Build the agent image (add --push to publish to your registry)\n")
 	fmt.Printf("      arctl agent build .\n")
 	fmt.Printf("   4. Run the agent locally\n")
@@ -16,7 +16,6 @@ import (
 var (
 	listAll      bool
 	listPageSize int
-	outputFormat string
 )
 
 var ListCmd = &cobra.Command{
@@ -47,16 +46,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Handle different output formats
-	switch outputFormat {
-	case "json":
-		return outputDataJson(agents)
-	case "yaml":
-		return outputDataYaml(agents)
-	default:
-		displayPaginatedAgents(agents, deployedAgents, listPageSize, listAll)
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(agents)
 	}
-
+	displayPaginatedAgents(agents, deployedAgents, listPageSize, listAll)
 	return nil
 }
 
@@ -110,7 +103,11 @@ func displayPaginatedAgents(agents []*models.AgentResponse, deployedAgents []*cl
 
 func printAgentsTable(agents []*models.AgentResponse, deployedAgents []*client.DeploymentResponse) {
 	t := printer.NewTablePrinter(os.Stdout)
-	t.SetHeaders("Name", "Version", "Framework", "Language", "Provider", "Model", "Deployed", "Published")
+	headers := []string{"Name", "Version", "Framework", "Language", "Provider", "Model", "Deployed", "Published"}
+	if t.Wide() {
+		headers = append(headers, "Description")
+	}
+	t.SetHeaders(headers...)
 
 	deployedMap := make(map[string]*client.DeploymentResponse)
 	for _, d := range deployedAgents {
@@ -134,7 +131,7 @@ func printAgentsTable(agents []*models.AgentResponse, deployedAgents []*client.D
 			publishedStatus = "True"
 		}
 
-		t.AddRow(
+		row := []any{
 			printer.TruncateString(a.Agent.Name, 40),
 			a.Agent.Version,
 			printer.EmptyValueOrDefault(a.Agent.Framework, "<none>"),
@@ -143,7 +140,11 @@ func printAgentsTable(agents []*models.AgentResponse, deployedAgents []*client.D
 			printer.TruncateString(printer.EmptyValueOrDefault(a.Agent.ModelName, "<none>"), 30),
 			deployedStatus,
 			publishedStatus,
-		)
+		}
+		if t.Wide() {
+			row = append(row, a.Agent.Description)
+		}
+		t.AddRow(row...)
 	}
 
 	if err := t.Render(); err != nil {
@@ -151,22 +152,7 @@ func printAgentsTable(agents []*models.AgentResponse, deployedAgents []*client.D
 	}
 }
 
-func outputDataJson(data any) error {
-	p := printer.New(printer.OutputTypeJSON, false)
-	if err := p.PrintJSON(data); err != nil {
-		return fmt.Errorf("failed to output JSON: %w", err)
-	}
-	return nil
-}
-
-func outputDataYaml(data any) error {
-	// For now, YAML is not implemented, fallback to JSON
-	fmt.Println("YAML output not yet implemented, using JSON:")
-	return outputDataJson(data)
-}
-
 func init() {
 	ListCmd.Flags().BoolVarP(&listAll, "all", "a", false, "Show all items without pagination")
 	ListCmd.Flags().IntVarP(&listPageSize, "page-size", "p", 15, "Number of items per page")
-	ListCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
 }
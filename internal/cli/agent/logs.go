@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/docker"
+	"github.com/spf13/cobra"
+)
+
+var LogsCmd = &cobra.Command{
+	Use:   "logs <agent-name>",
+	Short: "Show the logs of a running agent",
+	Long: `Show the container logs of an agent started with 'arctl agent run'. The agent's compose project is found
+from its running container, so the command works from any directory.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLogs,
+	Example: `  arctl agent logs dice
+  arctl agent logs dice --follow
+  arctl agent logs dice --all --tail 100`,
+}
+
+var AttachCmd = &cobra.Command{
+	Use:   "attach <agent-name>",
+	Short: "Open the chat with an already running agent",
+	Long: `Open the chat interface against an agent started with 'arctl agent run', for example from another terminal,
+without starting a new compose stack. The agent is reached on the host port its container publishes.`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runAttach,
+	Example: `  arctl agent attach dice`,
+}
+
+var (
+	logsFollow bool
+	logsAll    bool
+	logsTail   string
+)
+
+func init() {
+	LogsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Stream new log output")
+	LogsCmd.Flags().BoolVar(&logsAll, "all", false, "Include the logs of the agent's MCP servers and other services in its compose project")
+	LogsCmd.Flags().StringVar(&logsTail, "tail", "all", "Number of lines to show from the end of the logs")
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	container, err := findRunningAgent(args[0])
+	if err != nil {
+		return err
+	}
+
+	composeCmd := docker.ComposeCommand()
+	logsArgs := append(composeCmd[1:], "-p", container.Project, "logs", "--tail", logsTail)
+	if logsFollow {
+		logsArgs = append(logsArgs, "--follow")
+	}
+	if !logsAll {
+		logsArgs = append(logsArgs, container.Service)
+	}
+
+	logsCmd := exec.CommandContext(cmd.Context(), composeCmd[0], logsArgs...)
+	logsCmd.Stdout = os.Stdout
+	logsCmd.Stderr = os.Stderr
+	if err := logsCmd.Run(); err != nil {
+		return fmt.Errorf("failed to fetch logs of %s: %w", args[0], err)
+	}
+	return nil
+}
+
+func runAttach(cmd *cobra.Command, args []string) error {
+	container, err := findRunningAgent(args[0])
+	if err != nil {
+		return err
+	}
+	port, err := docker.HostPort(container.ID, 8080)
+	if err != nil {
+		return err
+	}
+	agentURL := fmt.Sprintf("http://localhost:%d", port)
+
+	if verbose {
+		fmt.Printf("Attaching to %s in compose project %s at %s\n", container.Service, container.Project, agentURL)
+	}
+	return launchChat(cmd.Context(), args[0], agentURL)
+}
+
+// findRunningAgent returns the container of a running agent. An agent running in several compose projects
+// at once is ambiguous, as it cannot be told which one is meant.
+func findRunningAgent(name string) (docker.Container, error) {
+	containers, err := docker.FindComposeService(name)
+	if err != nil {
+		return docker.Container{}, err
+	}
+	switch len(containers) {
+	case 0:
+		return docker.Container{}, fmt.Errorf("agent %q is not running; start it with 'arctl agent run'", name)
+	case 1:
+		return containers[0], nil
+	}
+	var projects []string
+	for _, c := range containers {
+		projects = append(projects, c.Project)
+	}
+	return docker.Container{}, fmt.Errorf("agent %q is running in several compose projects (%s); stop all but one", name, strings.Join(projects, ", "))
+}
@@ -0,0 +1,322 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/docker"
+)
+
+// sharedAgentNetwork is the compose network all agents of a multi-agent run join, so they can call each other.
+const sharedAgentNetwork = "arctl-agents"
+
+// runningAgent is an agent of a multi-agent run with the host port it is published on.
+type runningAgent struct {
+	*preparedAgent
+	hostPort int
+}
+
+func (a runningAgent) hostURL() string {
+	return fmt.Sprintf("http://localhost:%d", a.hostPort)
+}
+
+// runMultiAgent runs several agents in one compose project behind a local gateway and chats with one of them.
+func runMultiAgent(ctx context.Context, targets []string) error {
+	var agents []runningAgent
+	defer func() {
+		for _, a := range agents {
+			a.cleanup()
+		}
+	}()
+
+	for _, target := range targets {
+		prepared, err := prepareTarget(target)
+		if err != nil {
+			return err
+		}
+		for _, existing := range agents {
+			if existing.manifest.Name == prepared.manifest.Name {
+				prepared.cleanup()
+				return fmt.Errorf("agent %q is given more than once", prepared.manifest.Name)
+			}
+		}
+		if err := validateAPIKey(prepared.manifest.ModelProvider); err != nil {
+			prepared.cleanup()
+			return err
+		}
+		agents = append(agents, runningAgent{preparedAgent: prepared})
+	}
+
+	chatIndex := 0
+	if runChatAgent != "" {
+		chatIndex = slices.IndexFunc(agents, func(a runningAgent) bool { return a.manifest.Name == runChatAgent })
+		if chatIndex < 0 {
+			return fmt.Errorf("--chat %q is not one of the agents being run", runChatAgent)
+		}
+	}
+
+	ports, err := allocatePorts(len(agents), runGatewayPort+1)
+	if err != nil {
+		return err
+	}
+	for i := range agents {
+		agents[i].hostPort = ports[i]
+	}
+
+	composeData, err := combineCompose(agents)
+	if err != nil {
+		return err
+	}
+	workDir, err := os.MkdirTemp("", "arctl-multi-agent-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(workDir) }()
+
+	composeCmd := docker.ComposeCommand()
+	commonArgs := append(composeCmd[1:], "-p", "arctl-multi-agent", "-f", "-")
+
+	upCmd := exec.CommandContext(ctx, composeCmd[0], append(commonArgs, "up", "-d")...)
+	upCmd.Dir = workDir
+	upCmd.Stdin = bytes.NewReader(composeData)
+	if verbose {
+		upCmd.Stdout = os.Stdout
+		upCmd.Stderr = os.Stderr
+	}
+	if err := upCmd.Run(); err != nil {
+		return fmt.Errorf("failed to start docker compose: %w", err)
+	}
+	fmt.Println("✓ Docker containers started")
+
+	sessionErr := runMultiAgentSession(ctx, agents, chatIndex, composeCmd, commonArgs, composeData, workDir)
+
+	fmt.Println("\nStopping docker compose...")
+	downCmd := exec.Command(composeCmd[0], append(commonArgs, "down")...)
+	downCmd.Dir = workDir
+	downCmd.Stdin = bytes.NewReader(composeData)
+	if verbose {
+		downCmd.Stdout = os.Stdout
+		downCmd.Stderr = os.Stderr
+	}
+	if err := downCmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to stop docker compose: %v\n", err)
+	} else {
+		fmt.Println("✓ Stopped docker compose")
+	}
+
+	return sessionErr
+}
+
+func runMultiAgentSession(ctx context.Context, agents []runningAgent, chatIndex int, composeCmd, commonArgs []string, composeData []byte, workDir string) error {
+	for _, a := range agents {
+		fmt.Printf("Waiting for agent '%s' to be ready...\n", a.manifest.Name)
+		if err := waitForAgent(ctx, a.hostURL(), 60*time.Second); err != nil {
+			printComposeLogs(composeCmd, commonArgs, composeData, workDir)
+			return fmt.Errorf("agent %s: %w", a.manifest.Name, err)
+		}
+	}
+
+	gateway := &http.Server{
+		Addr:              fmt.Sprintf("localhost:%d", runGatewayPort),
+		Handler:           newAgentGateway(agents),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := gateway.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Warning: A2A gateway stopped: %v\n", err)
+		}
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = gateway.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("\nA2A routing table (gateway at http://localhost:%d):\n", runGatewayPort)
+	for _, a := range agents {
+		fmt.Printf("  %-20s host %s  gateway http://localhost:%d/%s/  compose http://%s:8080\n",
+			a.manifest.Name, a.hostURL(), runGatewayPort, a.manifest.Name, a.manifest.Name)
+	}
+	fmt.Println()
+
+	chat := agents[chatIndex]
+	return launchChat(ctx, chat.manifest.Name, chat.hostURL())
+}
+
+// allocatePorts returns n free local ports, scanning upwards from start.
+func allocatePorts(n, start int) ([]int, error) {
+	var ports []int
+	for port := start; len(ports) < n && port < 65536; port++ {
+		l, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+		if err != nil {
+			continue
+		}
+		_ = l.Close()
+		ports = append(ports, port)
+	}
+	if len(ports) < n {
+		return nil, fmt.Errorf("could not find %d free ports from %d", n, start)
+	}
+	return ports, nil
+}
+
+// agentRoutes maps every agent name to the URL other agents reach it at inside compose.
+func agentRoutes(agents []runningAgent) map[string]string {
+	routes := make(map[string]string, len(agents))
+	for _, a := range agents {
+		routes[a.manifest.Name] = fmt.Sprintf("http://%s:8080", a.manifest.Name)
+	}
+	return routes
+}
+
+// combineCompose merges the compose files of several agents into one project. Agent services keep their names and
+// are published on their host port. MCP server services are prefixed with their agent's name and keep their
+// original name as an alias on a network private to the agent, so the generated MCP tool URLs keep working.
+// Relative paths are resolved against each agent's work directory.
+func combineCompose(agents []runningAgent) ([]byte, error) {
+	routes := agentRoutes(agents)
+	routesJSON, err := json.Marshal(routes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal A2A routing table: %w", err)
+	}
+	routingEnv := []any{"A2A_AGENT_URLS=" + string(routesJSON)}
+	for _, a := range agents {
+		routingEnv = append(routingEnv, fmt.Sprintf("A2A_AGENT_URL_%s=%s", envName(a.manifest.Name), routes[a.manifest.Name]))
+	}
+
+	services := map[string]any{}
+	networks := map[string]any{sharedAgentNetwork: map[string]any{"driver": "bridge"}}
+
+	for _, a := range agents {
+		var doc map[string]any
+		if err := yaml.Unmarshal(a.composeData, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse compose file of %s: %w", a.manifest.Name, err)
+		}
+		agentServices, _ := doc["services"].(map[string]any)
+
+		baseDir := a.workDir
+		if baseDir == "" {
+			if baseDir, err = os.Getwd(); err != nil {
+				return nil, fmt.Errorf("failed to get current working directory: %w", err)
+			}
+		}
+		agentNetwork := a.manifest.Name + "-net"
+		networks[agentNetwork] = map[string]any{"driver": "bridge"}
+
+		for name, raw := range agentServices {
+			svc, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			absolutizeComposePaths(svc, baseDir)
+			delete(svc, "networks")
+
+			key := name
+			switch name {
+			case "otel-collector":
+				// Every agent ships the same collector; run it once.
+				if _, exists := services[key]; exists {
+					continue
+				}
+				svc["networks"] = []any{sharedAgentNetwork}
+			case a.manifest.Name:
+				svc["ports"] = []any{fmt.Sprintf("%d:8080", a.hostPort)}
+				env, _ := svc["environment"].([]any)
+				svc["environment"] = append(env, routingEnv...)
+				svc["networks"] = map[string]any{
+					agentNetwork:       map[string]any{},
+					sharedAgentNetwork: map[string]any{"aliases": []any{name}},
+				}
+			default:
+				key = a.manifest.Name + "-" + name
+				svc["networks"] = map[string]any{
+					agentNetwork: map[string]any{"aliases": []any{name}},
+				}
+			}
+
+			if _, exists := services[key]; exists {
+				return nil, fmt.Errorf("compose service %q is defined by more than one agent", key)
+			}
+			services[key] = svc
+		}
+	}
+
+	out, err := yaml.Marshal(map[string]any{"services": services, "networks": networks})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render combined compose file: %w", err)
+	}
+	return out, nil
+}
+
+// absolutizeComposePaths rewrites the relative build contexts and bind mount sources of a service.
+func absolutizeComposePaths(svc map[string]any, baseDir string) {
+	abs := func(p string) string {
+		if filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(baseDir, p)
+	}
+
+	if build, ok := svc["build"].(map[string]any); ok {
+		if ctxPath, ok := build["context"].(string); ok {
+			build["context"] = abs(ctxPath)
+		}
+	}
+
+	volumes, _ := svc["volumes"].([]any)
+	for i, v := range volumes {
+		switch vol := v.(type) {
+		case map[string]any:
+			if src, ok := vol["source"].(string); ok && vol["type"] == "bind" {
+				vol["source"] = abs(src)
+			}
+		case string:
+			if src, rest, found := strings.Cut(vol, ":"); found && (strings.HasPrefix(src, ".") || strings.Contains(src, "/")) {
+				volumes[i] = abs(src) + ":" + rest
+			}
+		}
+	}
+}
+
+// envName turns an agent name into the suffix of an environment variable name.
+func envName(name string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name))
+}
+
+// newAgentGateway routes /<agent-name>/... to the agent's host port and serves the routing table on /.
+func newAgentGateway(agents []runningAgent) http.Handler {
+	mux := http.NewServeMux()
+	table := map[string]string{}
+	for _, a := range agents {
+		target, _ := url.Parse(a.hostURL())
+		proxy := httputil.NewSingleHostReverseProxy(target)
+		prefix := "/" + a.manifest.Name
+		mux.Handle(prefix+"/", http.StripPrefix(prefix, proxy))
+		table[a.manifest.Name] = a.hostURL()
+	}
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(table)
+	})
+	return mux
+}
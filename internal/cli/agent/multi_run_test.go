@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestCombineCompose(t *testing.T) {
+	composeFor := func(name string) []byte {
+		return []byte(`services:
+  ` + name + `:
+    image: localhost:5001/` + name + `:latest
+    build:
+      context: .
+    ports:
+      - "8080:8080"
+    environment:
+      - AGENT_NAME=` + name + `
+    volumes:
+      - type: bind
+        source: ./` + name + `
+        target: /config
+  search:
+    image: localhost:5001/` + name + `-search:latest
+    build:
+      context: ./search
+    expose:
+      - "3000"
+`)
+	}
+	agents := []runningAgent{
+		{preparedAgent: &preparedAgent{manifest: &models.AgentManifest{Name: "planner"}, composeData: composeFor("planner"), workDir: "/work/planner"}, hostPort: 8081},
+		{preparedAgent: &preparedAgent{manifest: &models.AgentManifest{Name: "researcher"}, composeData: composeFor("researcher"), workDir: "/work/researcher"}, hostPort: 8082},
+	}
+
+	out, err := combineCompose(agents)
+	require.NoError(t, err)
+
+	var doc struct {
+		Services map[string]struct {
+			Build struct {
+				Context string `yaml:"context"`
+			} `yaml:"build"`
+			Ports       []string         `yaml:"ports"`
+			Environment []string         `yaml:"environment"`
+			Volumes     []map[string]any `yaml:"volumes"`
+			Networks    map[string]struct {
+				Aliases []string `yaml:"aliases"`
+			} `yaml:"networks"`
+		} `yaml:"services"`
+		Networks map[string]any `yaml:"networks"`
+	}
+	require.NoError(t, yaml.Unmarshal(out, &doc))
+
+	assert.Len(t, doc.Services, 4)
+	planner := doc.Services["planner"]
+	assert.Equal(t, []string{"8081:8080"}, planner.Ports)
+	assert.Equal(t, "/work/planner", planner.Build.Context)
+	assert.Equal(t, "/work/planner/planner", planner.Volumes[0]["source"])
+	assert.Contains(t, planner.Environment, `A2A_AGENT_URLS={"planner":"http://planner:8080","researcher":"http://researcher:8080"}`)
+	assert.Contains(t, planner.Environment, "A2A_AGENT_URL_RESEARCHER=http://researcher:8080")
+	assert.Equal(t, []string{"planner"}, planner.Networks[sharedAgentNetwork].Aliases)
+
+	search := doc.Services["researcher-search"]
+	assert.Equal(t, "/work/researcher/search", search.Build.Context)
+	assert.Equal(t, []string{"search"}, search.Networks["researcher-net"].Aliases)
+	assert.NotContains(t, search.Networks, sharedAgentNetwork)
+
+	assert.Contains(t, doc.Networks, "planner-net")
+	assert.Contains(t, doc.Networks, sharedAgentNetwork)
+}
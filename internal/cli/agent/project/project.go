@@ -8,9 +8,9 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/adk/python"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
-	"github.com/agentregistry-dev/agentregistry/internal/utils"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 )
@@ -56,35 +56,38 @@ func defaultRegistry() string {
 	return registry
 }
 
-// RegenerateMcpTools updates the generated mcp_tools.py file based on manifest state.
+// RegenerateMcpTools updates the generated MCP tools file based on manifest state. Frameworks without
+// generated MCP tool wiring are left untouched.
 func RegenerateMcpTools(projectDir string, manifest *models.AgentManifest, verbose bool) error {
 	if manifest == nil || manifest.Name == "" {
 		return fmt.Errorf("manifest missing name")
 	}
 
-	agentPackageDir := filepath.Join(projectDir, manifest.Name)
-	if _, err := os.Stat(agentPackageDir); err != nil {
-		// Not an ADK layout; nothing to do.
-		return nil
+	framework := manifest.Framework
+	if framework == "" {
+		framework = "adk"
 	}
-
-	gen := python.NewPythonGenerator()
-	templateBytes, err := gen.ReadTemplateFile("agent/mcp_tools.py.tmpl")
+	gen, err := frameworks.NewGenerator(framework, manifest.Language)
 	if err != nil {
-		return fmt.Errorf("failed to read mcp_tools template: %w", err)
+		// Unknown framework; nothing to do.
+		return nil
+	}
+	toolsGen, ok := gen.(frameworks.McpToolsGenerator)
+	if !ok {
+		return nil
 	}
 
-	rendered, err := gen.RenderTemplate(string(templateBytes), struct {
-		McpServers []models.McpServerType
-	}{
-		McpServers: manifest.McpServers,
-	})
+	relPath, rendered, err := toolsGen.RenderMcpTools(manifest)
 	if err != nil {
-		return fmt.Errorf("failed to render mcp_tools template: %w", err)
+		return err
 	}
 
-	target := filepath.Join(agentPackageDir, "mcp_tools.py")
-	if err := os.WriteFile(target, []byte(rendered), 0o644); err != nil {
+	target := filepath.Join(projectDir, relPath)
+	if _, err := os.Stat(filepath.Dir(target)); err != nil {
+		// Not a generated project layout; nothing to do.
+		return nil
+	}
+	if err := os.WriteFile(target, rendered, 0o644); err != nil {
 		return fmt.Errorf("failed to write %s: %w", target, err)
 	}
 	if verbose {
@@ -93,56 +96,23 @@ func RegenerateMcpTools(projectDir string, manifest *models.AgentManifest, verbo
 	return nil
 }
 
-// RegenerateDockerCompose rewrites docker-compose.yaml using the embedded template.
+// RegenerateDockerCompose rewrites docker-compose.yaml using the shared compose template.
 func RegenerateDockerCompose(projectDir string, manifest *models.AgentManifest, version string, verbose bool) error {
 	if manifest == nil {
 		return fmt.Errorf("manifest is required")
 	}
 
-	envVars := EnvVarsFromManifest(manifest)
 	image := manifest.Image
 	if image == "" {
 		image = ConstructImageName("", manifest.Name)
 	}
-	gen := python.NewPythonGenerator()
-	templateBytes, err := gen.ReadTemplateFile("docker-compose.yaml.tmpl")
-	if err != nil {
-		return fmt.Errorf("failed to read docker-compose template: %w", err)
-	}
-
-	// Sanitize version for filesystem use in template
-	sanitizedVersion := utils.SanitizeVersion(version)
-
-	rendered, err := gen.RenderTemplate(string(templateBytes), struct {
-		Name              string
-		Version           string
-		Image             string
-		ModelProvider     string
-		ModelName         string
-		TelemetryEndpoint string
-		EnvVars           []string
-		McpServers        []models.McpServerType
-	}{
-		Name:              manifest.Name,
-		Version:           sanitizedVersion,
-		Image:             image,
-		ModelProvider:     manifest.ModelProvider,
-		ModelName:         manifest.ModelName,
-		TelemetryEndpoint: manifest.TelemetryEndpoint,
-		EnvVars:           envVars,
-		McpServers:        manifest.McpServers,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to render docker-compose: %w", err)
-	}
-
-	target := filepath.Join(projectDir, "docker-compose.yaml")
-	if err := os.WriteFile(target, []byte(rendered), 0o644); err != nil {
-		return fmt.Errorf("failed to write docker-compose.yaml: %w", err)
+	cfg := common.ComposeConfigFromManifest(manifest, version, image, EnvVarsFromManifest(manifest))
+	if err := common.WriteCompose(projectDir, cfg); err != nil {
+		return err
 	}
 
 	if verbose {
-		fmt.Printf("Updated %s\n", target)
+		fmt.Printf("Updated %s\n", filepath.Join(projectDir, "docker-compose.yaml"))
 	}
 	return nil
 }
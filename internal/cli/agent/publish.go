@@ -1,9 +1,13 @@
 package agent
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/docker"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/kagent-dev/kagent/go/cli/config"
@@ -23,7 +27,11 @@ This command supports two forms:
 
 Examples:
 arctl agent publish ./my-agent
-arctl agent publish my-agent --version latest`,
+arctl agent publish my-agent --version latest
+
+When publishing from a project directory, README.md in the project directory is stored as the README of the
+published version, and an SBOM generated by 'arctl agent build --sbom'
+(sbom.json in the project directory) or passed with --sbom is attached to the published version.`,
 	Args:    cobra.ExactArgs(1),
 	RunE:    runPublish,
 	Example: `arctl agent publish ./my-agent`,
@@ -31,22 +39,31 @@ arctl agent publish my-agent --version latest`,
 
 var publishVersion string
 var githubRepository string
+var publishSBOMPath string
+var publishVisibility string
 
 func init() {
 	PublishCmd.Flags().StringVar(&publishVersion, "version", "", "Specify version to publish (when publishing an existing registry agent)")
 	PublishCmd.Flags().StringVar(&githubRepository, "github", "", "Specify the GitHub repository for the agent")
+	PublishCmd.Flags().StringVar(&publishSBOMPath, "sbom", "", "Path to an SPDX or CycloneDX JSON SBOM to attach (defaults to sbom.json in the project directory if present)")
+	PublishCmd.Flags().StringVar(&publishVisibility, "visibility", "", "Who can see the agent: public (default), unlisted (left out of listings and search) or private (members of --workspace only)")
 }
 
 func runPublish(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return cmd.Help()
 	}
+	if !models.ValidVisibility(publishVisibility) {
+		return fmt.Errorf("--visibility must be one of %s", strings.Join(models.Visibilities, ", "))
+	}
 	cfg := &config.Config{}
 	publishCfg := &publishAgentCfg{
 		Config: cfg,
 	}
 	publishCfg.Version = publishVersion
 	publishCfg.GitHubRepository = githubRepository
+	publishCfg.SBOMPath = publishSBOMPath
+	publishCfg.Visibility = publishVisibility
 
 	arg := args[0]
 
@@ -60,6 +77,11 @@ func runPublish(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("API client not initialized")
 		}
 
+		if publishCfg.Visibility != "" {
+			if err := apiClient.SetVisibility("agent", agentName, version, publishCfg.Visibility); err != nil {
+				return err
+			}
+		}
 		if err := apiClient.PublishAgentStatus(agentName, version); err != nil {
 			return fmt.Errorf("failed to publish agent: %w", err)
 		}
@@ -83,6 +105,8 @@ type publishAgentCfg struct {
 	ProjectDir       string
 	Version          string
 	GitHubRepository string
+	SBOMPath         string
+	Visibility       string
 }
 
 func publishAgent(cfg *publishAgentCfg) error {
@@ -119,6 +143,7 @@ func publishAgent(cfg *publishAgentCfg) error {
 		AgentManifest: publishManifest,
 		Version:       version,
 		Status:        "active",
+		Visibility:    cfg.Visibility,
 	}
 
 	if cfg.GitHubRepository != "" {
@@ -135,5 +160,45 @@ func publishAgent(cfg *publishAgentCfg) error {
 
 	fmt.Printf("Agent '%s' version %s published successfully\n", jsn.Name, jsn.Version)
 
+	if err := uploadAgentReadme(cfg.ProjectDir, jsn.Name, jsn.Version); err != nil {
+		return fmt.Errorf("failed to upload README: %w", err)
+	}
+	return uploadAgentSBOM(cfg, jsn.Name, jsn.Version)
+}
+
+// uploadAgentReadme stores README.md of the project directory, when present, as the README of the published version
+func uploadAgentReadme(projectDir, name, version string) error {
+	content, err := os.ReadFile(filepath.Join(projectDir, "README.md"))
+	if errors.Is(err, os.ErrNotExist) || len(content) == 0 {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return apiClient.UploadAgentReadme(name, version, content, "text/markdown")
+}
+
+// uploadAgentSBOM attaches the SBOM for a freshly published agent version. An explicit --sbom path must exist;
+// the default sbom.json written by 'arctl agent build --sbom' is only uploaded when present.
+func uploadAgentSBOM(cfg *publishAgentCfg, name, version string) error {
+	sbomPath := cfg.SBOMPath
+	if sbomPath == "" {
+		sbomPath = filepath.Join(cfg.ProjectDir, docker.SBOMFileName)
+		if _, err := os.Stat(sbomPath); err != nil {
+			return nil
+		}
+	}
+
+	content, err := os.ReadFile(sbomPath)
+	if err != nil {
+		return fmt.Errorf("failed to read SBOM: %w", err)
+	}
+
+	meta, err := apiClient.UploadAgentSBOM(name, version, content)
+	if err != nil {
+		return fmt.Errorf("failed to attach SBOM: %w", err)
+	}
+
+	fmt.Printf("Attached %s SBOM (%d components) to agent '%s' version %s\n", meta.Format, meta.ComponentCount, name, version)
 	return nil
 }
@@ -13,28 +13,39 @@ import (
 	"time"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/docker"
-	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/adk/python"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/project"
-	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/tui"
 	agentutils "github.com/agentregistry-dev/agentregistry/internal/cli/agent/utils"
-	"github.com/agentregistry-dev/agentregistry/internal/utils"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/spf13/cobra"
-	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
-	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
 var RunCmd = &cobra.Command{
-	Use:   "run [project-directory-or-agent-name]",
+	Use:   "run [project-directory-or-agent-name...]",
 	Short: "Run an agent locally and launch the interactive chat",
 	Long: `Run an agent project locally via docker compose. If the argument is a directory,
 arctl uses the local files; otherwise it fetches the agent by name from the registry and
-launches the same chat interface.`,
-	Args: cobra.ExactArgs(1),
+launches the same chat interface.
+
+With several arguments, all agents run in one compose project. Each agent gets a free host port,
+and a gateway on --gateway-port routes /<agent-name>/ to it. Inside compose, agents reach each other
+at http://<agent-name>:8080; the A2A_AGENT_URLS environment variable of every agent holds this
+routing table as JSON. The chat connects to the agent selected with --chat, the first one by default.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: runRun,
 	Example: `arctl agent run ./my-agent
-  arctl agent run dice`,
+  arctl agent run dice
+  arctl agent run ./planner ./researcher dice --chat planner`,
+}
+
+var (
+	runGatewayPort int
+	runChatAgent   string
+)
+
+func init() {
+	RunCmd.Flags().IntVar(&runGatewayPort, "gateway-port", 8080, "Port of the local A2A gateway when running several agents")
+	RunCmd.Flags().StringVar(&runChatAgent, "chat", "", "Agent to chat with when running several agents (defaults to the first)")
 }
 
 var providerAPIKeys = map[string]string{
@@ -48,34 +59,54 @@ func runRun(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return cmd.Help()
 	}
+	if len(args) > 1 {
+		return runMultiAgent(cmd.Context(), args)
+	}
 
-	target := args[0]
+	prepared, err := prepareTarget(args[0])
+	if err != nil {
+		return err
+	}
+	defer prepared.cleanup()
+	return runAgent(cmd.Context(), prepared.composeData, prepared.manifest, prepared.workDir, chatSession)
+}
+
+// prepareTarget prepares a local project directory, or otherwise an agent fetched from the registry by name.
+func prepareTarget(target string) (*preparedAgent, error) {
 	if info, err := os.Stat(target); err == nil && info.IsDir() {
 		fmt.Println("Running agent from local directory:", target)
-		return runFromDirectory(cmd.Context(), target)
+		return prepareFromDirectory(target)
 	}
 
 	agentModel, err := apiClient.GetAgentByName(target)
 	if err != nil {
-		return fmt.Errorf("failed to resolve agent %q: %w", target, err)
+		return nil, fmt.Errorf("failed to resolve agent %q: %w", target, err)
 	}
 	manifest := agentModel.Agent.AgentManifest
-	version := agentModel.Agent.Version
-	return runFromManifest(cmd.Context(), &manifest, version, nil)
+	return prepareFromManifest(&manifest, agentModel.Agent.Version)
 }
 
+// agentSession interacts with an agent once it is up and answering at http://localhost:8080.
+type agentSession func(ctx context.Context, manifest *models.AgentManifest) error
+
+// chatSession launches the interactive chat, the session of `arctl agent run`.
+func chatSession(ctx context.Context, manifest *models.AgentManifest) error {
+	return launchChat(ctx, manifest.Name, "http://localhost:8080")
+}
+
+// prepareFromDirectory resolves the MCP servers of a local project and refreshes its docker-compose.yaml.
 // Note: The below implementation may be redundant in most cases.
 // It allows for registry-type MCP server resolution at run-time, but in doing so, it regenerates folders for servers which were already accounted for (i.e. command-type get generated during their `add-cmd` command)
 // This is not a major issue or breaking, but something we could improve in the future.
-func runFromDirectory(ctx context.Context, projectDir string) error {
+func prepareFromDirectory(projectDir string) (*preparedAgent, error) {
 	manifest, err := project.LoadManifest(projectDir)
 	if err != nil {
-		return fmt.Errorf("failed to load agent.yaml: %w", err)
+		return nil, fmt.Errorf("failed to load agent.yaml: %w", err)
 	}
 
 	// Always clear previously resolved registry artifacts to avoid stale folders.
 	if err := project.CleanupRegistryDir(projectDir, verbose); err != nil {
-		return fmt.Errorf("failed to clean registry directory: %w", err)
+		return nil, fmt.Errorf("failed to clean registry directory: %w", err)
 	}
 
 	var serversForConfig []common.PythonMCPServer
@@ -96,7 +127,7 @@ func runFromDirectory(ctx context.Context, projectDir string) error {
 		}
 		servers, err := agentutils.ParseAgentManifestServers(manifest, verbose)
 		if err != nil {
-			return fmt.Errorf("failed to parse agent manifest mcp servers: %w", err)
+			return nil, fmt.Errorf("failed to parse agent manifest mcp servers: %w", err)
 		}
 		manifest.McpServers = servers
 
@@ -132,7 +163,7 @@ func runFromDirectory(ctx context.Context, projectDir string) error {
 			tmpManifest.McpServers = registryResolvedServers
 			// create directories and build images for the registry-resolved servers
 			if err := project.EnsureMcpServerDirectories(projectDir, &tmpManifest, verbose); err != nil {
-				return fmt.Errorf("failed to create MCP server directories: %w", err)
+				return nil, fmt.Errorf("failed to create MCP server directories: %w", err)
 			}
 		} else if verbose {
 			fmt.Println("[registry-resolve] No registry-resolved command servers to build")
@@ -153,23 +184,20 @@ func runFromDirectory(ctx context.Context, projectDir string) error {
 		serversForConfig,
 		verbose,
 	); err != nil {
-		return fmt.Errorf("failed to refresh resolved MCP server config: %w", err)
+		return nil, fmt.Errorf("failed to refresh resolved MCP server config: %w", err)
 	}
 
 	if err := project.RegenerateDockerCompose(projectDir, manifest, "", verbose); err != nil {
-		return fmt.Errorf("failed to refresh docker-compose.yaml: %w", err)
+		return nil, fmt.Errorf("failed to refresh docker-compose.yaml: %w", err)
 	}
 
 	composePath := filepath.Join(projectDir, "docker-compose.yaml")
 	data, err := os.ReadFile(composePath)
 	if err != nil {
-		return fmt.Errorf("failed to read docker-compose.yaml: %w", err)
+		return nil, fmt.Errorf("failed to read docker-compose.yaml: %w", err)
 	}
 
-	return runFromManifest(ctx, manifest, "", &runContext{
-		composeData: data,
-		workDir:     projectDir,
-	})
+	return &preparedAgent{manifest: manifest, composeData: data, workDir: projectDir}, nil
 }
 
 // hasRegistryServers checks if the manifest has any registry-type MCP servers.
@@ -182,192 +210,153 @@ func hasRegistryServers(manifest *models.AgentManifest) bool {
 	return false
 }
 
-// runFromManifest runs an agent based on a manifest, with optional pre-resolved data.
-//   - overrides is non-nil when coming from runFromDirectory: compose and resolved MCP config
-//     are already prepared (including cleanup), so this function skips resolution/cleanup.
-//   - when overrides is nil, this function resolves registry MCP servers (if any), builds them,
-//     renders compose, and creates mcp-servers.json for registry runs.
-func runFromManifest(ctx context.Context, manifest *models.AgentManifest, version string, overrides *runContext) error {
+// preparedAgent is an agent whose compose file and resolved MCP server config are ready to start.
+type preparedAgent struct {
+	manifest    *models.AgentManifest
+	composeData []byte
+	// workDir is the directory compose runs in; relative paths in composeData are resolved against it.
+	workDir string
+}
+
+// cleanup removes the temporary directory of registry-run agents.
+func (p *preparedAgent) cleanup() {
+	if p.workDir != "" && strings.Contains(p.workDir, "arctl-registry-resolve-") {
+		if err := os.RemoveAll(p.workDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove temporary directory %s: %v\n", p.workDir, err)
+		}
+	}
+}
+
+// prepareFromManifest resolves registry MCP servers (if any), builds them, renders compose,
+// and creates mcp-servers.json in a temporary directory.
+func prepareFromManifest(manifest *models.AgentManifest, version string) (*preparedAgent, error) {
 	if manifest == nil {
-		return fmt.Errorf("agent manifest is required")
+		return nil, fmt.Errorf("agent manifest is required")
 	}
 
-	var composeData []byte
 	workDir := ""
-
-	useOverrides := overrides != nil
 	var serversForConfig []common.PythonMCPServer
 
-	if useOverrides {
-		// servers already resolved, compose already generated (i.e. from runFromDirectory)
+	// Resolve registry-type MCP servers (if any) and build registry-resolved command servers.
+	if hasRegistryServers(manifest) {
 		if verbose {
-			fmt.Println("[registry-resolve] Using pre-resolved overrides from runFromDirectory")
-		}
-		composeData = overrides.composeData
-		workDir = overrides.workDir
-	} else {
-		// Resolve registry-type MCP servers (if any) and build registry-resolved command servers.
-		if hasRegistryServers(manifest) {
-			if verbose {
-				fmt.Println("[registry-resolve] Detected registry-type MCP servers in manifest (runFromManifest path)")
-				fmt.Printf("[registry-resolve] Total MCP servers in manifest: %d\n", len(manifest.McpServers))
-				for i, srv := range manifest.McpServers {
-					fmt.Printf("[registry-resolve]   [%d] name=%q type=%q registryServerName=%q registryURL=%q version=%q\n",
-						i, srv.Name, srv.Type, srv.RegistryServerName, srv.RegistryURL, srv.RegistryServerVersion)
-				}
+			fmt.Println("[registry-resolve] Detected registry-type MCP servers in manifest (registry run)")
+			fmt.Printf("[registry-resolve] Total MCP servers in manifest: %d\n", len(manifest.McpServers))
+			for i, srv := range manifest.McpServers {
+				fmt.Printf("[registry-resolve]   [%d] name=%q type=%q registryServerName=%q registryURL=%q version=%q\n",
+					i, srv.Name, srv.Type, srv.RegistryServerName, srv.RegistryURL, srv.RegistryServerVersion)
 			}
+		}
 
-			if verbose {
-				fmt.Println("[registry-resolve] Starting resolution of registry servers...")
-			}
-			servers, err := agentutils.ParseAgentManifestServers(manifest, verbose)
-			if err != nil {
-				return fmt.Errorf("failed to parse agent manifest mcp servers: %w", err)
-			}
-			manifest.McpServers = servers
+		if verbose {
+			fmt.Println("[registry-resolve] Starting resolution of registry servers...")
+		}
+		servers, err := agentutils.ParseAgentManifestServers(manifest, verbose)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse agent manifest mcp servers: %w", err)
+		}
+		manifest.McpServers = servers
 
-			if verbose {
-				fmt.Printf("[registry-resolve] Resolution complete. Total servers after resolution: %d\n", len(manifest.McpServers))
-				for i, srv := range manifest.McpServers {
-					fmt.Printf("[registry-resolve]   [%d] name=%q type=%q build=%q image=%q command=%q\n",
-						i, srv.Name, srv.Type, srv.Build, srv.Image, srv.Command)
-				}
+		if verbose {
+			fmt.Printf("[registry-resolve] Resolution complete. Total servers after resolution: %d\n", len(manifest.McpServers))
+			for i, srv := range manifest.McpServers {
+				fmt.Printf("[registry-resolve]   [%d] name=%q type=%q build=%q image=%q command=%q\n",
+					i, srv.Name, srv.Type, srv.Build, srv.Image, srv.Command)
 			}
+		}
 
-			// Separate servers that need building (npm/pypi) from those that don't (OCI)
-			var serversToBuild []models.McpServerType
-			for _, srv := range manifest.McpServers {
-				if srv.Type == "command" && strings.HasPrefix(srv.Build, "registry/") {
-					serversToBuild = append(serversToBuild, srv)
-					if verbose {
-						fmt.Printf("[registry-resolve] Including server %q for build (type=command, build=%q)\n", srv.Name, srv.Build)
-					}
-				} else if verbose {
-					if srv.Type == "command" && srv.Build == "" && srv.Image != "" {
-						fmt.Printf("[registry-resolve] Skipping server %q for build (OCI image %q ready to use)\n", srv.Name, srv.Image)
-					} else {
-						fmt.Printf("[registry-resolve] Skipping server %q for build (type=%q, build=%q)\n", srv.Name, srv.Type, srv.Build)
-					}
+		// Separate servers that need building (npm/pypi) from those that don't (OCI)
+		var serversToBuild []models.McpServerType
+		for _, srv := range manifest.McpServers {
+			if srv.Type == "command" && strings.HasPrefix(srv.Build, "registry/") {
+				serversToBuild = append(serversToBuild, srv)
+				if verbose {
+					fmt.Printf("[registry-resolve] Including server %q for build (type=command, build=%q)\n", srv.Name, srv.Build)
+				}
+			} else if verbose {
+				if srv.Type == "command" && srv.Build == "" && srv.Image != "" {
+					fmt.Printf("[registry-resolve] Skipping server %q for build (OCI image %q ready to use)\n", srv.Name, srv.Image)
+				} else {
+					fmt.Printf("[registry-resolve] Skipping server %q for build (type=%q, build=%q)\n", srv.Name, srv.Type, srv.Build)
 				}
 			}
+		}
 
-			// Always create temp directory for mcp-servers.json (needed for both OCI and non-OCI servers)
-			tmpDir, err := os.MkdirTemp("", "arctl-registry-resolve-*")
-			if err != nil {
-				return fmt.Errorf("failed to create temporary directory: %w", err)
-			}
+		// Always create temp directory for mcp-servers.json (needed for both OCI and non-OCI servers)
+		tmpDir, err := os.MkdirTemp("", "arctl-registry-resolve-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		if verbose {
+			fmt.Printf("[registry-resolve] Created temporary directory: %s\n", tmpDir)
+		}
+		workDir = tmpDir
+
+		// Build only servers that need building (npm/pypi, not OCI)
+		if len(serversToBuild) > 0 {
 			if verbose {
-				fmt.Printf("[registry-resolve] Created temporary directory: %s\n", tmpDir)
+				fmt.Printf("[registry-resolve] %d registry-resolved servers require directory setup and build\n", len(serversToBuild))
 			}
-			workDir = tmpDir
 
-			// Build only servers that need building (npm/pypi, not OCI)
-			if len(serversToBuild) > 0 {
-				if verbose {
-					fmt.Printf("[registry-resolve] %d registry-resolved servers require directory setup and build\n", len(serversToBuild))
-				}
-
-				tmpManifest := *manifest
-				tmpManifest.McpServers = serversToBuild
-
-				if verbose {
-					fmt.Println("[registry-resolve] Creating MCP server directories...")
-				}
-				if err := project.EnsureMcpServerDirectories(tmpDir, &tmpManifest, verbose); err != nil {
-					return fmt.Errorf("failed to create mcp server directories: %w", err)
-				}
+			tmpManifest := *manifest
+			tmpManifest.McpServers = serversToBuild
 
-				if verbose {
-					fmt.Println("[registry-resolve] Building registry-resolved server images...")
-				}
-				if err := buildRegistryResolvedServers(tmpDir, &tmpManifest, verbose); err != nil {
-					return fmt.Errorf("failed to build registry server images: %w", err)
-				}
-			} else if verbose {
-				fmt.Println("[registry-resolve] No registry-resolved command servers to build (OCI images only)")
+			if verbose {
+				fmt.Println("[registry-resolve] Creating MCP server directories...")
+			}
+			if err := project.EnsureMcpServerDirectories(tmpDir, &tmpManifest, verbose); err != nil {
+				return nil, fmt.Errorf("failed to create mcp server directories: %w", err)
 			}
 
-			// Create MCP config for ALL resolved command-type servers (including OCI which don't need building)
-			serversForConfig = common.PythonServersFromManifest(manifest)
 			if verbose {
-				fmt.Printf("[registry-resolve] Created %d server configurations for MCP config (includes OCI servers)\n", len(serversForConfig))
+				fmt.Println("[registry-resolve] Building registry-resolved server images...")
+			}
+			if err := buildRegistryResolvedServers(tmpDir, &tmpManifest, verbose); err != nil {
+				return nil, fmt.Errorf("failed to build registry server images: %w", err)
 			}
 		} else if verbose {
-			fmt.Println("[registry-resolve] No registry-type MCP servers found in manifest")
+			fmt.Println("[registry-resolve] No registry-resolved command servers to build (OCI images only)")
 		}
 
-		data, err := renderComposeFromManifest(manifest, version)
-		if err != nil {
-			return err
-		}
-		composeData = data
-
-		// Clean and write the resolved MCP server config when this function performed resolution.
-		if err := common.RefreshMCPConfig(
-			&common.MCPConfigTarget{BaseDir: workDir, AgentName: manifest.Name, Version: version},
-			serversForConfig,
-			verbose,
-		); err != nil {
-			return err
+		// Create MCP config for ALL resolved command-type servers (including OCI which don't need building)
+		serversForConfig = common.PythonServersFromManifest(manifest)
+		if verbose {
+			fmt.Printf("[registry-resolve] Created %d server configurations for MCP config (includes OCI servers)\n", len(serversForConfig))
 		}
+	} else if verbose {
+		fmt.Println("[registry-resolve] No registry-type MCP servers found in manifest")
 	}
 
-	err := runAgent(ctx, composeData, manifest, workDir)
-
-	// Clean up temp directory for registry-run agents
-	if !useOverrides && workDir != "" && strings.Contains(workDir, "arctl-registry-resolve-") {
-		if cleanupErr := os.RemoveAll(workDir); cleanupErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to remove temporary directory %s: %v\n", workDir, cleanupErr)
-		}
+	composeData, err := renderComposeFromManifest(manifest, version)
+	if err != nil {
+		return nil, err
 	}
 
-	return err
-}
+	// Clean and write the resolved MCP server config when this function performed resolution.
+	if err := common.RefreshMCPConfig(
+		&common.MCPConfigTarget{BaseDir: workDir, AgentName: manifest.Name, Version: version},
+		serversForConfig,
+		verbose,
+	); err != nil {
+		return nil, err
+	}
 
-type runContext struct {
-	composeData []byte
-	workDir     string
+	return &preparedAgent{manifest: manifest, composeData: composeData, workDir: workDir}, nil
 }
 
 func renderComposeFromManifest(manifest *models.AgentManifest, version string) ([]byte, error) {
-	gen := python.NewPythonGenerator()
-	templateBytes, err := gen.ReadTemplateFile("docker-compose.yaml.tmpl")
-	if err != nil {
-		return nil, fmt.Errorf("failed to read docker-compose template: %w", err)
-	}
-
 	image := manifest.Image
 	if image == "" {
 		image = project.ConstructImageName("", manifest.Name)
 	}
 
-	// Sanitize version for filesystem use in template
-	sanitizedVersion := utils.SanitizeVersion(version)
-
-	rendered, err := gen.RenderTemplate(string(templateBytes), struct {
-		Name          string
-		Version       string
-		Image         string
-		ModelProvider string
-		ModelName     string
-		EnvVars       []string
-		McpServers    []models.McpServerType
-	}{
-		Name:          manifest.Name,
-		Version:       sanitizedVersion,
-		Image:         image,
-		ModelProvider: manifest.ModelProvider,
-		ModelName:     manifest.ModelName,
-		EnvVars:       project.EnvVarsFromManifest(manifest),
-		McpServers:    manifest.McpServers,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to render docker-compose template: %w", err)
-	}
-	return []byte(rendered), nil
+	cfg := common.ComposeConfigFromManifest(manifest, version, image, project.EnvVarsFromManifest(manifest))
+	// Registry runs do not ship a collector config, so telemetry stays disabled.
+	cfg.TelemetryEndpoint = ""
+	return common.RenderCompose(cfg)
 }
 
-func runAgent(ctx context.Context, composeData []byte, manifest *models.AgentManifest, workDir string) error {
+func runAgent(ctx context.Context, composeData []byte, manifest *models.AgentManifest, workDir string, session agentSession) error {
 	if err := validateAPIKey(manifest.ModelProvider); err != nil {
 		return err
 	}
@@ -399,9 +388,7 @@ func runAgent(ctx context.Context, composeData []byte, manifest *models.AgentMan
 
 	fmt.Printf("✓ Agent '%s' is running at http://localhost:8080\n", manifest.Name)
 
-	if err := launchChat(ctx, manifest.Name); err != nil {
-		return err
-	}
+	sessionErr := session(ctx, manifest)
 
 	fmt.Println("\nStopping docker compose...")
 	downCmd := exec.Command(composeCmd[0], append(commonArgs, "down")...)
@@ -417,7 +404,7 @@ func runAgent(ctx context.Context, composeData []byte, manifest *models.AgentMan
 		fmt.Println("✓ Stopped docker compose")
 	}
 
-	return nil
+	return sessionErr
 }
 
 func waitForAgent(ctx context.Context, agentURL string, timeout time.Duration) error {
@@ -467,24 +454,6 @@ func printComposeLogs(composeCmd []string, commonArgs []string, composeData []by
 	fmt.Fprintf(os.Stderr, "Container logs:\n%s\n", string(output))
 }
 
-func launchChat(ctx context.Context, agentName string) error {
-	sessionID := protocol.GenerateContextID()
-	client, err := a2aclient.NewA2AClient("http://localhost:8080", a2aclient.WithTimeout(60*time.Second))
-	if err != nil {
-		return fmt.Errorf("failed to create chat client: %w", err)
-	}
-
-	sendFn := func(ctx context.Context, params protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
-		ch, err := client.StreamMessage(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-		return ch, nil
-	}
-
-	return tui.RunChat(agentName, sessionID, sendFn, verbose)
-}
-
 func validateAPIKey(modelProvider string) error {
 	envVar, ok := providerAPIKeys[strings.ToLower(modelProvider)]
 	if !ok || envVar == "" {
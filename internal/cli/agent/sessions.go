@@ -0,0 +1,145 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/sessions"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var SessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Manage saved agent chat sessions",
+	Long:  `List, show, export and delete the chat sessions saved in ~/.arctl/sessions.`,
+	Example: `  arctl agent sessions list
+  arctl agent sessions show 3f2a9c
+  arctl agent sessions export 3f2a9c -o chat.md
+  arctl agent sessions delete 3f2a9c`,
+}
+
+var sessionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved chat sessions",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := sessions.DefaultStore()
+		if err != nil {
+			return err
+		}
+		list, err := store.List()
+		if err != nil {
+			return err
+		}
+		if sessionsAgent != "" {
+			var filtered []*sessions.Session
+			for _, s := range list {
+				if s.Agent == sessionsAgent {
+					filtered = append(filtered, s)
+				}
+			}
+			list = filtered
+		}
+		if len(list) == 0 {
+			fmt.Println("No chat sessions found")
+			return nil
+		}
+
+		t := printer.NewTablePrinter(os.Stdout)
+		t.SetHeaders("ID", "Agent", "Messages", "Last Message", "Updated")
+		for _, s := range list {
+			last := ""
+			if len(s.Messages) > 0 {
+				last = strings.ReplaceAll(s.Messages[len(s.Messages)-1].Text, "\n", " ")
+			}
+			t.AddRow(shortSessionID(s.ID), s.Agent, len(s.Messages), printer.TruncateString(last, 50), printer.FormatAge(s.UpdatedAt))
+		}
+		return t.Render()
+	},
+}
+
+var sessionsShowCmd = &cobra.Command{
+	Use:   "show <session-id>",
+	Short: "Print the transcript of a chat session",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		session, err := loadSession(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Print(session.Markdown())
+		return nil
+	},
+}
+
+var sessionsExportCmd = &cobra.Command{
+	Use:   "export <session-id>",
+	Short: "Export a chat session as markdown",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		session, err := loadSession(args[0])
+		if err != nil {
+			return err
+		}
+		if sessionsOutput == "" || sessionsOutput == "-" {
+			fmt.Print(session.Markdown())
+			return nil
+		}
+		if err := os.WriteFile(sessionsOutput, []byte(session.Markdown()), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", sessionsOutput, err)
+		}
+		printer.PrintSuccess(fmt.Sprintf("Exported session %s to %s", session.ID, sessionsOutput))
+		return nil
+	},
+}
+
+var sessionsDeleteCmd = &cobra.Command{
+	Use:   "delete <session-id>",
+	Short: "Delete a saved chat session",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := sessions.DefaultStore()
+		if err != nil {
+			return err
+		}
+		id, err := store.Delete(args[0])
+		if err != nil {
+			return err
+		}
+		printer.PrintSuccess(fmt.Sprintf("Deleted session %s", id))
+		return nil
+	},
+}
+
+var (
+	sessionsAgent  string
+	sessionsOutput string
+)
+
+func init() {
+	sessionsListCmd.Flags().StringVar(&sessionsAgent, "agent", "", "Only list sessions with this agent")
+	sessionsExportCmd.Flags().StringVarP(&sessionsOutput, "output", "o", "", "File to write the markdown to (defaults to stdout)")
+
+	SessionsCmd.AddCommand(sessionsListCmd)
+	SessionsCmd.AddCommand(sessionsShowCmd)
+	SessionsCmd.AddCommand(sessionsExportCmd)
+	SessionsCmd.AddCommand(sessionsDeleteCmd)
+}
+
+func loadSession(id string) (*sessions.Session, error) {
+	store, err := sessions.DefaultStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.Load(id)
+}
+
+// shortSessionID shortens a session ID for display; any unique prefix is accepted as an ID.
+func shortSessionID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,197 @@
+// Package sessions persists agent chat sessions in ~/.arctl/sessions so they can be listed, exported and resumed.
+package sessions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when no session matches an ID.
+var ErrNotFound = errors.New("session not found")
+
+// Message is a single chat message.
+type Message struct {
+	Role      string    `json:"role"`
+	Text      string    `json:"text"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Session is a chat with an agent. Its ID doubles as the A2A context ID of the conversation.
+type Session struct {
+	ID        string    `json:"id"`
+	Agent     string    `json:"agent"`
+	AgentURL  string    `json:"agentUrl"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Messages  []Message `json:"messages"`
+}
+
+// Store reads and writes sessions, one JSON file per session.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store backed by dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultStore returns the store at ~/.arctl/sessions
+func DefaultStore() (*Store, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return NewStore(filepath.Join(home, ".arctl", "sessions")), nil
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// Save writes the session, readable only by the current user
+func (s *Store) Save(session *Session) error {
+	if session.ID == "" || strings.ContainsAny(session.ID, `/\`) {
+		return fmt.Errorf("invalid session id %q", session.ID)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create sessions directory: %w", err)
+	}
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	// Write to a temp file and rename so a crash never leaves a truncated session behind
+	tmp, err := os.CreateTemp(s.dir, ".session-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(session.ID)); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// Load reads the session with the given ID or unique ID prefix
+func (s *Store) Load(id string) (*Session, error) {
+	fullID, err := s.resolve(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.read(s.path(fullID))
+}
+
+// List returns all sessions, most recently updated first
+func (s *Store) List() ([]*Session, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	var result []*Session
+	for _, p := range paths {
+		session, err := s.read(p)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, session)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UpdatedAt.After(result[j].UpdatedAt) })
+	return result, nil
+}
+
+// Delete removes the session with the given ID or unique ID prefix and returns its full ID
+func (s *Store) Delete(id string) (string, error) {
+	fullID, err := s.resolve(id)
+	if err != nil {
+		return "", err
+	}
+	if err := os.Remove(s.path(fullID)); err != nil {
+		return "", fmt.Errorf("failed to delete session: %w", err)
+	}
+	return fullID, nil
+}
+
+// resolve expands an ID prefix, as shown by `arctl agent sessions list`, into a full session ID
+func (s *Store) resolve(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\*?[`) {
+		return "", fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	if _, err := os.Stat(s.path(id)); err == nil {
+		return id, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(s.dir, id+"*.json"))
+	if err != nil {
+		return "", fmt.Errorf("failed to look up session: %w", err)
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: %s", ErrNotFound, id)
+	case 1:
+		return strings.TrimSuffix(filepath.Base(matches[0]), ".json"), nil
+	default:
+		return "", fmt.Errorf("session id %s is ambiguous, %d sessions match", id, len(matches))
+	}
+}
+
+func (s *Store) read(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session file %s: %w", path, err)
+	}
+	return &session, nil
+}
+
+// Append adds a message to the session and bumps its update time
+func (s *Session) Append(role, text string, at time.Time) {
+	s.Messages = append(s.Messages, Message{Role: role, Text: text, Timestamp: at})
+	s.UpdatedAt = at
+}
+
+// Markdown renders the session as a markdown transcript
+func (s *Session) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Chat with %s\n\n", s.Agent)
+	fmt.Fprintf(&b, "- Session: `%s`\n", s.ID)
+	if s.AgentURL != "" {
+		fmt.Fprintf(&b, "- Agent URL: %s\n", s.AgentURL)
+	}
+	fmt.Fprintf(&b, "- Started: %s\n", s.CreatedAt.Format(time.RFC3339))
+	for _, m := range s.Messages {
+		fmt.Fprintf(&b, "\n## %s (%s)\n\n%s\n", roleTitle(m.Role), m.Timestamp.Format(time.RFC3339), strings.TrimSpace(m.Text))
+	}
+	return b.String()
+}
+
+func roleTitle(role string) string {
+	switch role {
+	case "user":
+		return "You"
+	case "agent":
+		return "Agent"
+	default:
+		return role
+	}
+}
@@ -0,0 +1,56 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(t.TempDir())
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	older := &Session{ID: "abc123", Agent: "dice", AgentURL: "http://localhost:8080", CreatedAt: start, UpdatedAt: start}
+	older.Append("user", "Roll a die", start.Add(time.Second))
+	older.Append("agent", "I rolled a 4.", start.Add(2*time.Second))
+	require.NoError(t, store.Save(older))
+
+	newer := &Session{ID: "abd456", Agent: "dice", CreatedAt: start, UpdatedAt: start.Add(time.Hour)}
+	require.NoError(t, store.Save(newer))
+
+	list, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "abd456", list[0].ID)
+
+	loaded, err := store.Load("abc")
+	require.NoError(t, err)
+	assert.Equal(t, older.Messages, loaded.Messages)
+
+	_, err = store.Load("ab")
+	assert.ErrorContains(t, err, "ambiguous")
+	_, err = store.Load("zzz")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	id, err := store.Delete("abd")
+	require.NoError(t, err)
+	assert.Equal(t, "abd456", id)
+	list, err = store.List()
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestSessionMarkdown(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	session := &Session{ID: "abc123", Agent: "dice", CreatedAt: at}
+	session.Append("user", "Roll a die", at)
+	session.Append("agent", "I rolled a 4.\n", at)
+
+	assert.Equal(t, "# Chat with dice\n\n"+
+		"- Session: `abc123`\n"+
+		"- Started: 2025-01-02T03:04:05Z\n"+
+		"\n## You (2025-01-02T03:04:05Z)\n\nRoll a die\n"+
+		"\n## Agent (2025-01-02T03:04:05Z)\n\nI rolled a 4.\n", session.Markdown())
+}
@@ -4,20 +4,22 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	"github.com/spf13/cobra"
 )
 
 var (
-	showOutputFormat string
+	showReadme bool
 )
 
 var ShowCmd = &cobra.Command{
-	Use:   "show <agent-name>",
-	Short: "Show details of an agent",
-	Long:  `Shows detailed information about an agent.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runShow,
+	Use:               "show <agent-name>",
+	Short:             "Show details of an agent",
+	Long:              `Shows detailed information about an agent.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.Names(completion.Agents),
+	RunE:              runShow,
 }
 
 func runShow(cmd *cobra.Command, args []string) error {
@@ -36,9 +38,21 @@ func runShow(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Handle JSON output format
-	if showOutputFormat == "json" {
-		return outputDataJson(agent)
+	if showReadme {
+		readme, err := apiClient.GetAgentReadme(agentName, agent.Agent.Version)
+		if err != nil {
+			return err
+		}
+		if readme == nil {
+			fmt.Printf("Agent '%s' has no README\n", agentName)
+			return nil
+		}
+		fmt.Println(printer.RenderMarkdown(readme.Content, readme.ContentType))
+		return nil
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(agent)
 	}
 
 	// Display agent details in table format
@@ -54,6 +68,7 @@ func runShow(cmd *cobra.Command, args []string) error {
 	t.AddRow("Model Name", printer.EmptyValueOrDefault(agent.Agent.ModelName, "<none>"))
 	t.AddRow("Status", agent.Meta.Official.Status)
 	t.AddRow("Website", printer.EmptyValueOrDefault(agent.Agent.WebsiteURL, "<none>"))
+	t.AddRow("SBOM", sbomSummary(agent.Agent.Name, agent.Agent.Version))
 
 	if !agent.Meta.Official.PublishedAt.IsZero() {
 		t.AddRow("Published", printer.FormatAge(agent.Meta.Official.PublishedAt))
@@ -69,6 +84,18 @@ func runShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// sbomSummary describes the SBOM attached to an agent version, e.g. "42 dependencies (spdx)".
+func sbomSummary(name, version string) string {
+	sbom, err := apiClient.GetAgentSBOM(name, version)
+	if err != nil {
+		return "<unavailable>"
+	}
+	if sbom == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%d dependencies (%s)", sbom.ComponentCount, sbom.Format)
+}
+
 func init() {
-	ShowCmd.Flags().StringVarP(&showOutputFormat, "output", "o", "table", "Output format (table, json)")
+	ShowCmd.Flags().BoolVar(&showReadme, "readme", false, "Render the README of the latest version instead of its details")
 }
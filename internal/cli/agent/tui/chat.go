@@ -20,9 +20,32 @@ import (
 // SendMessageFn mirrors the A2A client's StreamMessage signature.
 type SendMessageFn func(ctx context.Context, params protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error)
 
+// ChatMessage is a message shown in the chat transcript.
+type ChatMessage struct {
+	Role      string
+	Text      string
+	Timestamp time.Time
+}
+
+// ChatOptions configures optional chat behavior.
+type ChatOptions struct {
+	// History is shown before the first new message, e.g. when resuming a session.
+	History []ChatMessage
+	// OnMessage is called for every user message sent and every agent answer received.
+	OnMessage func(ChatMessage)
+}
+
 // RunChat starts the chat UI and blocks until the user exits.
-func RunChat(agentRef string, sessionID string, sendFn SendMessageFn, verbose bool) error {
+func RunChat(agentRef string, sessionID string, sendFn SendMessageFn, verbose bool, opts ChatOptions) error {
 	model := newChatModel(agentRef, sessionID, sendFn, verbose)
+	model.onMessage = opts.OnMessage
+	for _, msg := range opts.History {
+		style := theme.UserStyle()
+		if msg.Role == string(protocol.MessageRoleAgent) {
+			style = theme.AgentStyle()
+		}
+		model.appendLine(style.Render(fmt.Sprintf("%s:", msg.Role)) + "\n" + msg.Text)
+	}
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	_, err := p.Run()
 	return err
@@ -67,6 +90,8 @@ type chatModel struct {
 	streaming bool
 
 	showInput bool
+
+	onMessage func(ChatMessage)
 }
 
 func newChatModel(agentRef string, sessionID string, send SendMessageFn, verbose bool) *chatModel {
@@ -249,6 +274,13 @@ func (m *chatModel) waitNext() tea.Cmd {
 
 func (m *chatModel) appendUser(text string) {
 	m.appendLine(theme.UserStyle().Render("You:") + " " + text)
+	m.record(protocol.MessageRoleUser, text)
+}
+
+func (m *chatModel) record(role protocol.MessageRole, text string) {
+	if m.onMessage != nil {
+		m.onMessage(ChatMessage{Role: string(role), Text: text, Timestamp: time.Now()})
+	}
 }
 
 func (m *chatModel) appendEvent(ev protocol.StreamingMessageEvent) {
@@ -272,6 +304,7 @@ func (m *chatModel) appendEvent(ev protocol.StreamingMessageEvent) {
 			text := extractTextFromParts(res.Artifact.Parts)
 			if strings.TrimSpace(text) != "" {
 				m.appendLine(theme.AgentStyle().Render("Agent:") + "\n" + text)
+				m.record(protocol.MessageRoleAgent, text)
 			}
 		}
 	case *protocol.Message:
@@ -391,6 +424,7 @@ func (m *chatModel) handleMessageParts(msg protocol.Message, shouldDisplay bool)
 			style := theme.UserStyle()
 			if msg.Role == protocol.MessageRoleAgent {
 				style = theme.AgentStyle()
+				m.record(protocol.MessageRoleAgent, text)
 			}
 			m.appendLine(style.Render(fmt.Sprintf("%s:", msg.Role)) + "\n" + text)
 		}
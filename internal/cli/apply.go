@@ -0,0 +1,522 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/cliconfig"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Actions of an apply plan
+const (
+	applyCreate    = "create"
+	applyUpdate    = "update"
+	applyReplace   = "replace"
+	applyRemove    = "remove"
+	applyUnchanged = "unchanged"
+)
+
+var (
+	applyFile      string
+	applyPrune     bool
+	applyDryRun    bool
+	applyRuntime   string
+	applyNamespace string
+)
+
+var ApplyCmd = &cobra.Command{
+	Use:   "apply -f <file>",
+	Short: "Converge registries and deployments to a declarative file",
+	Long: `Reads a YAML file describing the desired registries and deployed MCP servers and agents, compares it with
+the current state and makes the changes needed to match it:
+
+  registries:
+    - name: staging
+      url: https://registry.staging.example.com/v0
+  servers:
+    - name: io.github.myorg/weather
+      version: 1.2.0
+      profile: prod
+      env:
+        LOG_LEVEL: debug
+  agents:
+    - name: io.github.myorg/planner
+      target: edge-docker
+      env:
+        MODEL: gpt-4o
+
+Registries are contexts of the config file; they are created or updated, never removed. Servers take name, version
+(latest when omitted), profile, env, args, headers, resources, runtime, target, namespace and preferRemote; agents
+the same except profile and preferRemote.
+
+A listed server or agent that is not deployed is created. A deployed one whose config differs is updated in place,
+and one that moved to another runtime or target is replaced. Other deployed versions of a listed server or agent
+are removed; with --prune, deployments of servers and agents the file does not list are removed too.
+
+Use --dry-run to only print the plan. The command fails when any action fails, which makes it suitable for CI.`,
+	Example: `  arctl apply -f registry.yaml --dry-run
+  arctl apply -f registry.yaml --prune`,
+	Args: cobra.NoArgs,
+	RunE: runApply,
+}
+
+func init() {
+	ApplyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "File describing the desired state ('-' reads stdin)")
+	ApplyCmd.Flags().BoolVar(&applyPrune, "prune", false, "Remove deployments of servers and agents the file does not list")
+	ApplyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Print the plan without changing anything")
+	ApplyCmd.Flags().StringVar(&applyRuntime, "runtime", "local", "Runtime of servers and agents that set neither runtime nor target")
+	ApplyCmd.Flags().StringVar(&applyNamespace, "namespace", "default", "Kubernetes namespace of servers and agents that do not set one")
+	_ = ApplyCmd.MarkFlagRequired("file")
+}
+
+// applyDocument is the desired state read by arctl apply
+type applyDocument struct {
+	Registries []applyRegistry  `json:"registries,omitempty" yaml:"registries"`
+	Servers    []*applyResource `json:"servers,omitempty" yaml:"servers"`
+	Agents     []*applyResource `json:"agents,omitempty" yaml:"agents"`
+}
+
+// applyRegistry is a context of the config file
+type applyRegistry struct {
+	Name string `json:"name" yaml:"name"`
+	URL  string `json:"url" yaml:"url"`
+}
+
+// applyResource is a deployed MCP server or agent
+type applyResource struct {
+	Name                      string `json:"name" yaml:"name"`
+	Version                   string `json:"version,omitempty" yaml:"version"`
+	Profile                   string `json:"profile,omitempty" yaml:"profile"`
+	Runtime                   string `json:"runtime,omitempty" yaml:"runtime"`
+	Target                    string `json:"target,omitempty" yaml:"target"`
+	Namespace                 string `json:"namespace,omitempty" yaml:"namespace"`
+	PreferRemote              bool   `json:"preferRemote,omitempty" yaml:"preferRemote"`
+	models.InstallationConfig `yaml:",inline"`
+
+	resourceType string
+	// config is the deployment config the resource converges to, with its profile and namespace applied
+	config map[string]string
+}
+
+func (r *applyResource) key() string {
+	return r.resourceType + " " + r.Name + "@" + r.Version
+}
+
+// applyAction is a step of an apply plan and, once applied, its outcome
+type applyAction struct {
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType"`
+	Name         string `json:"name"`
+	Version      string `json:"version,omitempty"`
+	Detail       string `json:"detail,omitempty"`
+	Error        string `json:"error,omitempty"`
+
+	resource *applyResource
+	registry *applyRegistry
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	data, err := readApplyFile(applyFile)
+	if err != nil {
+		return err
+	}
+	doc, err := parseApplyDocument(data)
+	if err != nil {
+		return err
+	}
+	return applyState(commandContext(cmd), doc, applyPrune, applyDryRun)
+}
+
+// applyState converges the registries and deployments to a document, or with dryRun only prints the plan
+func applyState(ctx context.Context, doc *applyDocument, prune, dryRun bool) error {
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Every listed version and profile is resolved before anything changes, so a typo fails the whole apply
+	var desired []*applyResource
+	for _, r := range slices.Concat(doc.Servers, doc.Agents) {
+		if err := resolveApplyResource(r); err != nil {
+			return err
+		}
+		desired = append(desired, r)
+	}
+	current, err := apiClient.GetDeployedServers()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	plan := slices.Concat(planRegistries(doc.Registries, cfg), planDeployments(desired, current, prune))
+	if !dryRun {
+		executeApplyPlan(ctx, plan)
+	}
+
+	if printer.Format().IsStructured() {
+		if err := printer.PrintStructured(plan); err != nil {
+			return err
+		}
+	} else if err := printApplyPlan(plan, dryRun); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, a := range plan {
+		if a.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d action(s) failed", failed)
+	}
+	return nil
+}
+
+func readApplyFile(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// parseApplyDocument reads an apply file, rejecting unknown fields and resources listed twice
+func parseApplyDocument(data []byte) (*applyDocument, error) {
+	var doc applyDocument
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid apply file: %w", err)
+	}
+
+	registries := map[string]bool{}
+	for i, r := range doc.Registries {
+		if r.Name == "" || r.URL == "" {
+			return nil, fmt.Errorf("invalid apply file: registry %d needs a name and a url", i+1)
+		}
+		if registries[r.Name] {
+			return nil, fmt.Errorf("invalid apply file: registry %s is listed more than once", r.Name)
+		}
+		registries[r.Name] = true
+	}
+
+	seen := map[string]bool{}
+	for _, group := range []struct {
+		resourceType string
+		resources    []*applyResource
+	}{
+		{models.CollectionItemTypeMCP, doc.Servers},
+		{models.CollectionItemTypeAgent, doc.Agents},
+	} {
+		for i, r := range group.resources {
+			if r == nil || r.Name == "" {
+				return nil, fmt.Errorf("invalid apply file: %s %d has no name", group.resourceType, i+1)
+			}
+			if group.resourceType == models.CollectionItemTypeAgent && (r.Profile != "" || r.PreferRemote) {
+				return nil, fmt.Errorf("invalid apply file: agent %s cannot set profile or preferRemote", r.Name)
+			}
+			r.resourceType = group.resourceType
+			r.Version = cmp.Or(r.Version, "latest")
+			if seen[r.key()] {
+				return nil, fmt.Errorf("invalid apply file: %s %s version %s is listed more than once", r.resourceType, r.Name, r.Version)
+			}
+			seen[r.key()] = true
+		}
+	}
+	return &doc, nil
+}
+
+// resolveApplyResource pins the version of a resource to a published one and computes the config it converges to
+func resolveApplyResource(r *applyResource) error {
+	switch r.resourceType {
+	case models.CollectionItemTypeMCP:
+		server, err := apiClient.GetServerByNameAndVersion(r.Name, r.Version, true)
+		if err != nil {
+			return fmt.Errorf("failed to get server %s: %w", r.Name, err)
+		}
+		if server == nil {
+			return fmt.Errorf("server %s version %s is not published", r.Name, r.Version)
+		}
+		r.Version = server.Server.Version
+	case models.CollectionItemTypeAgent:
+		agent, err := apiClient.GetAgentByNameAndVersion(r.Name, r.Version)
+		if err != nil {
+			return fmt.Errorf("failed to get agent %s: %w", r.Name, err)
+		}
+		if agent == nil {
+			return fmt.Errorf("agent %s version %s is not published", r.Name, r.Version)
+		}
+		r.Version = agent.Agent.Version
+	}
+
+	r.Runtime = cmp.Or(r.Runtime, applyRuntime)
+	config := r.InstallationConfig.Flatten()
+	if namespace := cmp.Or(r.Namespace, applyNamespace); r.Runtime == "kubernetes" && r.Target == "" && namespace != "" {
+		config["KAGENT_NAMESPACE"] = namespace
+	}
+	if r.Profile != "" {
+		profile, err := apiClient.GetConfigProfile(r.Name, r.Profile)
+		if err != nil {
+			return err
+		}
+		config = models.MergeConfig(profile.ResolvedConfig, config)
+	}
+	if err := registry.ValidateResourceConfig(config); err != nil {
+		return fmt.Errorf("%s %s: %w", r.resourceType, r.Name, err)
+	}
+	r.config = config
+	return nil
+}
+
+// planRegistries creates the registries missing from the config file and updates those whose URL changed
+func planRegistries(desired []applyRegistry, cfg *cliconfig.Config) []applyAction {
+	var plan []applyAction
+	for i := range desired {
+		r := &desired[i]
+		action := applyAction{ResourceType: "registry", Name: r.Name, Detail: r.URL, registry: r}
+		existing, ok := cfg.Contexts[r.Name]
+		switch {
+		case !ok:
+			action.Action = applyCreate
+		case existing.RegistryURL != r.URL:
+			action.Action = applyUpdate
+			action.Detail = fmt.Sprintf("url %s → %s", existing.RegistryURL, r.URL)
+		default:
+			action.Action = applyUnchanged
+		}
+		plan = append(plan, action)
+	}
+	return plan
+}
+
+// planDeployments compares the desired resources with the current deployments. Changes to listed resources come
+// first, so a new version is deployed before the version it supersedes is removed.
+func planDeployments(desired []*applyResource, current []*client.DeploymentResponse, prune bool) []applyAction {
+	currentByKey := map[string]*client.DeploymentResponse{}
+	for _, d := range current {
+		currentByKey[deploymentKey(d)] = d
+	}
+	listed := map[string]string{}
+	desiredKeys := map[string]bool{}
+	for _, r := range desired {
+		listed[r.resourceType+" "+r.Name] = r.Version
+		desiredKeys[r.key()] = true
+	}
+
+	var plan []applyAction
+	for _, r := range desired {
+		action := applyAction{ResourceType: r.resourceType, Name: r.Name, Version: r.Version, resource: r}
+		d, ok := currentByKey[r.key()]
+		switch {
+		case !ok:
+			action.Action = applyCreate
+			action.Detail = placementOf(r)
+		case placementChange(d, r) != "":
+			action.Action = applyReplace
+			action.Detail = placementChange(d, r)
+		case !maps.Equal(d.Config, r.config):
+			action.Action = applyUpdate
+			action.Detail = "config: " + strings.Join(changedConfigKeys(d.Config, r.config), ", ")
+		default:
+			action.Action = applyUnchanged
+		}
+		plan = append(plan, action)
+	}
+
+	for _, d := range current {
+		if desiredKeys[deploymentKey(d)] {
+			continue
+		}
+		resourceType := cmp.Or(d.ResourceType, models.CollectionItemTypeMCP)
+		action := applyAction{Action: applyRemove, ResourceType: resourceType, Name: d.ServerName, Version: d.Version}
+		if version, ok := listed[resourceType+" "+d.ServerName]; ok {
+			action.Detail = "superseded by " + version
+		} else if prune {
+			action.Detail = "not listed"
+		} else {
+			continue
+		}
+		plan = append(plan, action)
+	}
+	return plan
+}
+
+func deploymentKey(d *client.DeploymentResponse) string {
+	return cmp.Or(d.ResourceType, models.CollectionItemTypeMCP) + " " + d.ServerName + "@" + d.Version
+}
+
+func placementOf(r *applyResource) string {
+	if r.Target != "" {
+		return "target " + r.Target
+	}
+	return r.Runtime + " runtime"
+}
+
+// placementChange describes how the runtime, target or transport of a deployment differs from the desired one, or
+// returns "" when they match
+func placementChange(d *client.DeploymentResponse, r *applyResource) string {
+	// Deployments without a named target report their runtime as target
+	currentTarget := d.Target
+	if currentTarget == d.Runtime {
+		currentTarget = ""
+	}
+	switch {
+	case r.Target != "" && currentTarget != r.Target:
+		return fmt.Sprintf("%s → target %s", cmp.Or(currentTarget, d.Runtime+" runtime"), r.Target)
+	case r.Target == "" && currentTarget != "":
+		return fmt.Sprintf("target %s → %s runtime", currentTarget, r.Runtime)
+	case r.Target == "" && d.Runtime != "" && d.Runtime != r.Runtime:
+		return fmt.Sprintf("%s runtime → %s runtime", d.Runtime, r.Runtime)
+	case d.PreferRemote != r.PreferRemote:
+		return fmt.Sprintf("preferRemote %t → %t", d.PreferRemote, r.PreferRemote)
+	}
+	return ""
+}
+
+// changedConfigKeys lists the keys set, changed or removed between two configs, without their values, which may be
+// secrets
+func changedConfigKeys(current, desired map[string]string) []string {
+	var keys []string
+	for key, value := range desired {
+		if old, ok := current[key]; !ok || old != value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range current {
+		if _, ok := desired[key]; !ok {
+			keys = append(keys, "-"+key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// executeApplyPlan carries out a plan, recording the error of each action that fails and going on with the others.
+// Once ctx is canceled, the remaining actions are recorded as interrupted.
+func executeApplyPlan(ctx context.Context, plan []applyAction) {
+	var registries []*applyAction
+	for i := range plan {
+		a := &plan[i]
+		var err error
+		switch {
+		case a.Action == applyUnchanged:
+			continue
+		case ctx.Err() != nil:
+			err = errors.New("interrupted")
+		case a.registry != nil:
+			registries = append(registries, a)
+		case a.Action == applyCreate:
+			err = deployApplyResource(a.resource)
+		case a.Action == applyUpdate:
+			_, err = apiClient.UpdateDeploymentConfig(a.Name, a.Version, a.ResourceType, a.resource.config)
+		case a.Action == applyReplace:
+			if err = apiClient.RemoveDeployment(a.Name, a.Version, a.ResourceType); err == nil {
+				err = deployApplyResource(a.resource)
+			}
+		case a.Action == applyRemove:
+			err = apiClient.RemoveDeployment(a.Name, a.Version, a.ResourceType)
+		}
+		if err != nil {
+			a.Error = err.Error()
+		}
+	}
+	if len(registries) == 0 {
+		return
+	}
+
+	// The registries change in one update of the config file, keeping the tokens of existing contexts
+	_, err := updateConfig(func(cfg *cliconfig.Config) error {
+		for _, a := range registries {
+			entry := &cliconfig.Context{RegistryURL: a.registry.URL}
+			if existing, ok := cfg.Contexts[a.registry.Name]; ok {
+				entry.Token = existing.Token
+			}
+			if err := cfg.SetContext(a.registry.Name, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for _, a := range registries {
+			a.Error = err.Error()
+		}
+	}
+}
+
+func deployApplyResource(r *applyResource) error {
+	runtimeTarget := r.Runtime
+	if r.Target != "" {
+		// The target decides the runtime
+		runtimeTarget = ""
+	}
+	var err error
+	if r.resourceType == models.CollectionItemTypeAgent {
+		_, err = apiClient.DeployAgent(r.Name, r.Version, r.config, runtimeTarget, r.Target)
+	} else {
+		_, err = apiClient.DeployServer(r.Name, r.Version, r.config, r.PreferRemote, runtimeTarget, r.Target, "")
+	}
+	return err
+}
+
+func printApplyPlan(plan []applyAction, dryRun bool) error {
+	if len(plan) == 0 {
+		fmt.Println("Nothing to apply")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Action", "Type", "Name", "Version", "Detail", "Status")
+	counts := map[string]int{}
+	for _, a := range plan {
+		status := "OK"
+		switch {
+		case a.Error != "":
+			status = "Failed: " + a.Error
+		case dryRun && a.Action != applyUnchanged:
+			status = "Planned"
+		case a.Action == applyUnchanged:
+			status = "-"
+		}
+		t.AddRow(a.Action, a.ResourceType, a.Name, printer.EmptyValueOrDefault(a.Version, "-"), printer.TruncateString(a.Detail, 60), status)
+		if a.Error == "" {
+			counts[a.Action]++
+		}
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("%d to create, %d to update, %d to replace, %d to remove, %d unchanged",
+		counts[applyCreate], counts[applyUpdate], counts[applyReplace], counts[applyRemove], counts[applyUnchanged])
+	if dryRun {
+		fmt.Printf("\nDry run: %s\n", summary)
+	} else {
+		fmt.Printf("\nApplied: %s\n", strings.ReplaceAll(summary, " to ", " "))
+	}
+	return nil
+}
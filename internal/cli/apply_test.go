@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/cliconfig"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseApplyDocument(t *testing.T) {
+	doc, err := parseApplyDocument([]byte(`
+registries:
+  - name: staging
+    url: https://registry.staging.example.com/v0
+servers:
+  - name: io.github.user/weather
+    version: 1.2.0
+    env:
+      LOG_LEVEL: debug
+agents:
+  - name: io.github.user/planner
+    target: edge-docker
+`))
+	require.NoError(t, err)
+	require.Len(t, doc.Registries, 1)
+	require.Len(t, doc.Servers, 1)
+	require.Len(t, doc.Agents, 1)
+	assert.Equal(t, "mcp", doc.Servers[0].resourceType)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, doc.Servers[0].InstallationConfig.Flatten())
+	assert.Equal(t, "agent", doc.Agents[0].resourceType)
+	assert.Equal(t, "latest", doc.Agents[0].Version)
+
+	for name, data := range map[string]string{
+		"unknown field":      "servers:\n  - name: io.github.user/weather\n    envs:\n      A: b",
+		"missing name":       "servers:\n  - version: 1.0.0",
+		"duplicate":          "servers:\n  - name: io.github.user/weather\n  - name: io.github.user/weather\n    version: latest",
+		"registry no url":    "registries:\n  - name: staging",
+		"duplicate registry": "registries:\n  - {name: a, url: http://a}\n  - {name: a, url: http://b}",
+		"agent profile":      "agents:\n  - name: io.github.user/planner\n    profile: prod",
+	} {
+		_, err := parseApplyDocument([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestPlanDeployments(t *testing.T) {
+	desired := []*applyResource{
+		{Name: "io.github.user/new", Version: "1.0.0", Runtime: "local", resourceType: "mcp", config: map[string]string{}},
+		{Name: "io.github.user/same", Version: "1.0.0", Runtime: "local", resourceType: "mcp", config: map[string]string{"A": "1"}},
+		{Name: "io.github.user/config", Version: "1.0.0", Runtime: "local", resourceType: "mcp", config: map[string]string{"A": "2", "B": "1"}},
+		{Name: "io.github.user/moved", Version: "1.0.0", Runtime: "local", Target: "edge-docker", resourceType: "mcp"},
+		{Name: "io.github.user/upgraded", Version: "2.0.0", Runtime: "local", resourceType: "agent"},
+	}
+	current := []*client.DeploymentResponse{
+		{ServerName: "io.github.user/same", Version: "1.0.0", Runtime: "local", Target: "local", Config: map[string]string{"A": "1"}},
+		{ServerName: "io.github.user/config", Version: "1.0.0", Runtime: "local", ResourceType: "mcp", Config: map[string]string{"A": "1", "C": "1"}},
+		{ServerName: "io.github.user/moved", Version: "1.0.0", Runtime: "local", ResourceType: "mcp"},
+		{ServerName: "io.github.user/upgraded", Version: "1.0.0", Runtime: "local", ResourceType: "agent"},
+		{ServerName: "io.github.user/unlisted", Version: "1.0.0", Runtime: "local", ResourceType: "mcp"},
+	}
+	summarize := func(plan []applyAction) []string {
+		var out []string
+		for _, a := range plan {
+			out = append(out, a.Action+" "+a.ResourceType+" "+a.Name+"@"+a.Version+" "+a.Detail)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{
+		"create mcp io.github.user/new@1.0.0 local runtime",
+		"unchanged mcp io.github.user/same@1.0.0 ",
+		"update mcp io.github.user/config@1.0.0 config: -C, A, B",
+		"replace mcp io.github.user/moved@1.0.0 local runtime → target edge-docker",
+		"create agent io.github.user/upgraded@2.0.0 local runtime",
+		"remove agent io.github.user/upgraded@1.0.0 superseded by 2.0.0",
+	}, summarize(planDeployments(desired, current, false)))
+
+	pruned := planDeployments(desired, current, true)
+	assert.Equal(t, "remove mcp io.github.user/unlisted@1.0.0 not listed", summarize(pruned)[len(pruned)-1])
+}
+
+func TestPlanRegistries(t *testing.T) {
+	cfg := &cliconfig.Config{Contexts: map[string]*cliconfig.Context{
+		"prod":    {RegistryURL: "https://registry.example.com/v0"},
+		"staging": {RegistryURL: "https://old.example.com/v0"},
+	}}
+	plan := planRegistries([]applyRegistry{
+		{Name: "prod", URL: "https://registry.example.com/v0"},
+		{Name: "staging", URL: "https://registry.staging.example.com/v0"},
+		{Name: "dev", URL: "http://localhost:12121/v0"},
+	}, cfg)
+	require.Len(t, plan, 3)
+	assert.Equal(t, applyUnchanged, plan[0].Action)
+	assert.Equal(t, applyUpdate, plan[1].Action)
+	assert.Equal(t, applyCreate, plan[2].Action)
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditActor        string
+	auditAction       string
+	auditResourceType string
+	auditResource     string
+	auditSince        string
+	auditUntil        string
+	auditLimit        int
+)
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the registry audit log",
+	Long: `Shows who performed mutating operations (create, update, publish, delete, deploy, config changes) on the registry and when.
+Requires registry admin permissions.
+
+--since and --until accept an RFC3339 timestamp (2025-08-07T13:15:04Z) or a duration relative to now (e.g. 24h).`,
+	Example: `  arctl audit
+  arctl audit --actor alice@example.com --since 24h
+  arctl audit --type mcp --resource io.github.user/weather -o json`,
+	RunE: runAudit,
+}
+
+func init() {
+	AuditCmd.Flags().StringVar(&auditActor, "actor", "", "Filter by actor (JWT subject)")
+	AuditCmd.Flags().StringVar(&auditAction, "action", "", "Filter by action (create, update, publish, unpublish, delete, deploy, undeploy, config_change, promote, rollback)")
+	AuditCmd.Flags().StringVarP(&auditResourceType, "type", "t", "", "Filter by resource type (mcp, agent, skill, role)")
+	AuditCmd.Flags().StringVarP(&auditResource, "resource", "r", "", "Filter by resource name")
+	AuditCmd.Flags().StringVar(&auditSince, "since", "", "Only show entries at or after this time (RFC3339 or duration, e.g. 24h)")
+	AuditCmd.Flags().StringVar(&auditUntil, "until", "", "Only show entries before this time (RFC3339 or duration, e.g. 1h)")
+	AuditCmd.Flags().IntVarP(&auditLimit, "limit", "l", 50, "Maximum number of entries to show (0 for all)")
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	query := client.AuditLogQuery{
+		Actor:        auditActor,
+		Action:       auditAction,
+		ResourceType: auditResourceType,
+		ResourceName: auditResource,
+		Limit:        auditLimit,
+	}
+
+	now := time.Now()
+	var err error
+	if query.Since, err = parseAuditTime(auditSince, now); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if query.Until, err = parseAuditTime(auditUntil, now); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	entries, err := apiClient.GetAuditLog(query)
+	if err != nil {
+		return fmt.Errorf("failed to get audit log: %w", err)
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No audit log entries found")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Time", "Actor", "Action", "Type", "Resource", "Version")
+	for _, e := range entries {
+		t.AddRow(
+			printer.FormatTimestampShort(e.OccurredAt),
+			printer.TruncateString(e.Actor, 40),
+			e.Action,
+			e.ResourceType,
+			printer.TruncateString(e.ResourceName, 50),
+			printer.EmptyValueOrDefault(e.Version, "<none>"),
+		)
+	}
+	return t.Render()
+}
+
+// parseAuditTime parses an RFC3339 timestamp or a duration relative to now (e.g. "24h")
+func parseAuditTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC3339 timestamp or duration, got %q", value)
+	}
+	return now.Add(-d), nil
+}
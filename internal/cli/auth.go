@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tokenPermissions []string
+	tokenExpiresIn   string
+	tokenListAll     bool
+)
+
+var AuthCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage registry authentication",
+}
+
+var authTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage long-lived API tokens",
+	Long: `Manage long-lived API tokens for non-interactive clients such as CI systems.
+Use a token by setting ARCTL_API_TOKEN (or sending "Authorization: Bearer <token>").`,
+}
+
+var authTokenCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an API token",
+	Long: `Creates a scoped API token owned by the current user. Permissions are given as <action>:<resource-pattern>
+and must be within your own permissions. The token value is only shown once.`,
+	Example: `  arctl auth token create github-actions --permission publish:io.github.myorg/* --permission push:io.github.myorg/*
+  arctl auth token create nightly --permission publish:io.github.myorg/nightly --expires-in 720h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuthTokenCreate,
+}
+
+var authTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API tokens",
+	RunE:  runAuthTokenList,
+}
+
+var authTokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an API token",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAuthTokenRevoke,
+}
+
+func init() {
+	authTokenCreateCmd.Flags().StringArrayVarP(&tokenPermissions, "permission", "p", nil, "Permission to grant as <action>:<resource-pattern> (repeatable)")
+	authTokenCreateCmd.Flags().StringVar(&tokenExpiresIn, "expires-in", "", "Token lifetime as a duration (e.g. 720h); tokens do not expire by default")
+	_ = authTokenCreateCmd.MarkFlagRequired("permission")
+
+	authTokenListCmd.Flags().BoolVar(&tokenListAll, "all", false, "List the tokens of all users (registry admins only)")
+
+	authTokenCmd.AddCommand(authTokenCreateCmd, authTokenListCmd, authTokenRevokeCmd)
+	AuthCmd.AddCommand(authTokenCmd)
+}
+
+func runAuthTokenCreate(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	permissions := make([]auth.Permission, 0, len(tokenPermissions))
+	for _, p := range tokenPermissions {
+		perm, err := parseTokenPermission(p)
+		if err != nil {
+			return err
+		}
+		permissions = append(permissions, perm)
+	}
+
+	var expiresAt *time.Time
+	if tokenExpiresIn != "" {
+		d, err := time.ParseDuration(tokenExpiresIn)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid --expires-in %q: expected a positive duration", tokenExpiresIn)
+		}
+		t := time.Now().Add(d)
+		expiresAt = &t
+	}
+
+	token, err := apiClient.CreateAPIToken(args[0], permissions, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	fmt.Printf("Created API token %q (id %d)\n", token.Name, token.ID)
+	fmt.Println("Store it securely, it will not be shown again:")
+	fmt.Println()
+	fmt.Println(token.Token)
+	return nil
+}
+
+func runAuthTokenList(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	tokens, err := apiClient.ListAPITokens(tokenListAll)
+	if err != nil {
+		return fmt.Errorf("failed to list API tokens: %w", err)
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(tokens)
+	}
+
+	if len(tokens) == 0 {
+		fmt.Println("No API tokens found")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("ID", "Name", "Subject", "Prefix", "Permissions", "Created", "Expires", "Last Used", "Status")
+	for _, tok := range tokens {
+		perms := make([]string, len(tok.Permissions))
+		for i, p := range tok.Permissions {
+			perms[i] = string(p.Action) + ":" + p.ResourcePattern
+		}
+		expires, lastUsed, status := "<never>", "<never>", "active"
+		if tok.ExpiresAt != nil {
+			expires = printer.FormatTimestampShort(*tok.ExpiresAt)
+			if tok.ExpiresAt.Before(time.Now()) {
+				status = "expired"
+			}
+		}
+		if tok.LastUsedAt != nil {
+			lastUsed = printer.FormatAge(*tok.LastUsedAt)
+		}
+		if tok.RevokedAt != nil {
+			status = "revoked"
+		}
+		t.AddRow(
+			strconv.FormatInt(tok.ID, 10),
+			tok.Name,
+			printer.TruncateString(tok.Subject, 30),
+			tok.Prefix+"...",
+			printer.TruncateString(strings.Join(perms, ","), 50),
+			printer.FormatTimestampShort(tok.CreatedAt),
+			expires,
+			lastUsed,
+			status,
+		)
+	}
+	return t.Render()
+}
+
+func runAuthTokenRevoke(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid token id %q", args[0])
+	}
+	if err := apiClient.RevokeAPIToken(id); err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+
+	fmt.Printf("Revoked API token %d\n", id)
+	return nil
+}
+
+// parseTokenPermission parses a permission in the form <action>:<resource-pattern>
+func parseTokenPermission(value string) (auth.Permission, error) {
+	action, pattern, ok := strings.Cut(value, ":")
+	if !ok || action == "" || pattern == "" {
+		return auth.Permission{}, fmt.Errorf("invalid permission %q: expected <action>:<resource-pattern>", value)
+	}
+	switch auth.PermissionAction(action) {
+	case auth.PermissionActionRead, auth.PermissionActionPush, auth.PermissionActionPublish,
+		auth.PermissionActionEdit, auth.PermissionActionDelete, auth.PermissionActionDeploy:
+	default:
+		return auth.Permission{}, fmt.Errorf("invalid permission %q: unknown action %q", value, action)
+	}
+	return auth.Permission{Action: auth.PermissionAction(action), ResourcePattern: pattern}, nil
+}
@@ -0,0 +1,475 @@
+package cli
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/vulnscan"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/spf13/cobra"
+)
+
+// bundleFormatVersion is the bundle format written by bundle create. Install rejects bundles with a newer format.
+const bundleFormatVersion = 1
+
+// Members of a bundle, a plain tar since the saved images are already compressed
+const (
+	bundleFileManifest = "manifest.json"
+	bundleFileServers  = "servers.json"
+	bundleFileAgents   = "agents.json"
+	bundleFileReadmes  = "readmes.json"
+	bundleFileImages   = "images.tar"
+
+	// bundleMaxJSONSize bounds the JSON members of a bundle
+	bundleMaxJSONSize = 64 << 20
+)
+
+var (
+	bundleType       string
+	bundleVersion    string
+	bundleOut        string
+	bundleNoImages   bool
+	bundleSkipImages bool
+	bundleNoPublish  bool
+)
+
+// bundleManifest describes a bundle
+type bundleManifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	ResourceType  string    `json:"resourceType"`
+	Name          string    `json:"name"`
+	Version       string    `json:"version"`
+	Images        []string  `json:"images"`
+}
+
+// bundleContents is the registry content of a bundle. For an agent, servers holds the registry MCP servers it uses.
+type bundleContents struct {
+	manifest bundleManifest
+	servers  []*apiv0.ServerJSON
+	agents   []*models.AgentJSON
+	readmes  seed.ReadmeFile
+}
+
+var BundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Package servers and agents for air-gapped registries",
+	Long: `Bundles carry a server or agent between registries without network access: the registry entries, READMEs
+and the container images they run, saved with docker save (or podman save).`,
+}
+
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Package an MCP server or agent with its images",
+	Long: `Packages an MCP server or agent into a single tar: its registry entry and README, and its container images.
+An agent bundle also contains the registry MCP servers the agent uses, with their READMEs and images.
+
+Only OCI packages are saved as images. npm and pypi packages are fetched when they start, so servers that use
+them still need access to a package mirror. Images missing locally are pulled first.`,
+	Example: `  arctl bundle create io.github.example/weather --version 1.0.0 --out weather.tar
+  arctl bundle create my-agent --type agent --out my-agent.tar`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundleCreate,
+}
+
+var bundleInstallCmd = &cobra.Command{
+	Use:   "install <bundle.tar>",
+	Short: "Load the images of a bundle and install its entries",
+	Long: `Loads the container images of a bundle with docker load (or podman load) and creates and publishes its
+servers and agents in the registry, without network access. Entries that already exist are left unchanged.`,
+	Example: `  arctl bundle install weather.tar
+  arctl bundle install my-agent.tar --skip-images`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundleInstall,
+}
+
+func init() {
+	bundleCreateCmd.Flags().StringVar(&bundleType, "type", "", "Resource type (mcp, agent); detected from the registry when empty")
+	bundleCreateCmd.Flags().StringVar(&bundleVersion, "version", "latest", "Version to bundle")
+	bundleCreateCmd.Flags().StringVar(&bundleOut, "out", "", "Bundle file to write (default <name>-<version>.tar)")
+	bundleCreateCmd.Flags().BoolVar(&bundleNoImages, "no-images", false, "Only bundle the registry entries, not the container images")
+
+	bundleInstallCmd.Flags().BoolVar(&bundleSkipImages, "skip-images", false, "Do not load the container images of the bundle")
+	bundleInstallCmd.Flags().BoolVar(&bundleNoPublish, "no-publish", false, "Create the entries without publishing them")
+
+	BundleCmd.AddCommand(bundleCreateCmd, bundleInstallCmd)
+}
+
+func runBundleCreate(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	name := args[0]
+
+	contents, err := collectBundle(name, bundleType, bundleVersion)
+	if err != nil {
+		return err
+	}
+
+	outputPath := bundleOut
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("%s-%s.tar", utils.SanitizeVersion(name), contents.manifest.Version)
+	}
+
+	var imagesPath string
+	if !bundleNoImages && len(contents.manifest.Images) > 0 {
+		imagesPath, err = saveImages(cmd.Context(), contents.manifest.Images)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.Remove(imagesPath) }()
+	} else {
+		contents.manifest.Images = []string{}
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+	err = writeBundle(f, contents, imagesPath)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(outputPath)
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	fmt.Printf("✓ Bundled %s %s v%s (%d servers, %d agents, %d images) to %s\n", contents.manifest.ResourceType, name,
+		contents.manifest.Version, len(contents.servers), len(contents.agents), len(contents.manifest.Images), outputPath)
+	return nil
+}
+
+// collectBundle fetches the registry entries, READMEs and image references of a server or agent
+func collectBundle(name, resourceType, version string) (*bundleContents, error) {
+	var server *apiv0.ServerResponse
+	var agent *models.AgentResponse
+	var err error
+	if resourceType == "" || resourceType == "mcp" {
+		if server, err = apiClient.GetServerByNameAndVersion(name, version, false); err != nil {
+			return nil, err
+		}
+	}
+	if resourceType == "" || resourceType == "agent" {
+		if agent, err = apiClient.GetAgentByNameAndVersion(name, version); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case resourceType != "" && resourceType != "mcp" && resourceType != "agent":
+		return nil, fmt.Errorf("invalid --type %q, expected mcp or agent", resourceType)
+	case server != nil && agent != nil:
+		return nil, fmt.Errorf("both an MCP server and an agent are named %s; choose one with --type", name)
+	case server == nil && agent == nil:
+		return nil, fmt.Errorf("no MCP server or agent %s version %s found", name, version)
+	}
+
+	contents := &bundleContents{readmes: seed.ReadmeFile{}}
+	var images []string
+	if server != nil {
+		if err := addBundleServer(contents, &server.Server); err != nil {
+			return nil, err
+		}
+		contents.manifest.ResourceType = "mcp"
+		contents.manifest.Version = server.Server.Version
+	} else {
+		contents.agents = append(contents.agents, &agent.Agent)
+		if agent.Agent.Image != "" {
+			images = append(images, agent.Agent.Image)
+		}
+		for _, mcpServer := range agent.Agent.McpServers {
+			switch {
+			case mcpServer.Type == "command" && mcpServer.Image != "":
+				images = append(images, mcpServer.Image)
+			case mcpServer.Type == "registry" && mcpServer.RegistryURL == "":
+				depServer, err := apiClient.GetServerByNameAndVersion(mcpServer.RegistryServerName, versionOrLatest(mcpServer.RegistryServerVersion), false)
+				if err != nil {
+					return nil, err
+				}
+				if depServer == nil {
+					return nil, fmt.Errorf("MCP server %s used by agent %s not found", mcpServer.RegistryServerName, name)
+				}
+				if err := addBundleServer(contents, &depServer.Server); err != nil {
+					return nil, err
+				}
+			case mcpServer.Type == "registry":
+				fmt.Printf("Warning: MCP server %s of agent %s is resolved from %s and is not bundled\n", mcpServer.RegistryServerName, name, mcpServer.RegistryURL)
+			}
+		}
+		contents.manifest.ResourceType = "agent"
+		contents.manifest.Version = agent.Agent.Version
+	}
+	for _, s := range contents.servers {
+		images = append(images, vulnscan.ImageRefs(s)...)
+	}
+
+	slices.Sort(images)
+	contents.manifest.FormatVersion = bundleFormatVersion
+	contents.manifest.CreatedAt = time.Now().UTC()
+	contents.manifest.Name = name
+	contents.manifest.Images = slices.Compact(images)
+	return contents, nil
+}
+
+// addBundleServer adds a server and its README to a bundle
+func addBundleServer(contents *bundleContents, server *apiv0.ServerJSON) error {
+	contents.servers = append(contents.servers, server)
+	readme, err := apiClient.GetServerReadme(server.Name, server.Version)
+	if err != nil {
+		return err
+	}
+	if readme != nil {
+		contents.readmes[seed.Key(server.Name, server.Version)] = seed.EncodeReadme([]byte(readme.Content), readme.ContentType)
+	}
+	return nil
+}
+
+func versionOrLatest(version string) string {
+	if version == "" {
+		return "latest"
+	}
+	return version
+}
+
+// saveImages pulls the images that are missing locally and saves all of them into a temporary tar
+func saveImages(ctx context.Context, images []string) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, image := range images {
+		if err := utils.ContainerCommand(ctx, "image", "inspect", image).Run(); err == nil {
+			continue
+		}
+		fmt.Printf("Pulling %s...\n", image)
+		pull := utils.ContainerCommand(ctx, "pull", image)
+		pull.Stdout, pull.Stderr = os.Stdout, os.Stderr
+		if err := pull.Run(); err != nil {
+			return "", fmt.Errorf("failed to pull %s: %w", image, err)
+		}
+	}
+
+	f, err := os.CreateTemp("", "arctl-bundle-images-*.tar")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	_ = f.Close()
+
+	fmt.Printf("Saving %d image(s)...\n", len(images))
+	save := utils.ContainerCommand(ctx, append([]string{"save", "-o", f.Name()}, images...)...)
+	if out, err := save.CombinedOutput(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to save images: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return f.Name(), nil
+}
+
+// writeBundle writes the contents of a bundle as a tar stream, followed by the saved images in imagesPath if set
+func writeBundle(w io.Writer, c *bundleContents, imagesPath string) error {
+	tw := tar.NewWriter(w)
+
+	files := []struct {
+		name string
+		v    any
+	}{
+		{bundleFileManifest, c.manifest},
+		{bundleFileServers, nonNilSlice(c.servers)},
+		{bundleFileAgents, nonNilSlice(c.agents)},
+		{bundleFileReadmes, c.readmes},
+	}
+	for _, f := range files {
+		data, err := json.MarshalIndent(f.v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", f.name, err)
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(data)), ModTime: c.manifest.CreatedAt}); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	if imagesPath != "" {
+		images, err := os.Open(imagesPath)
+		if err != nil {
+			return fmt.Errorf("failed to open saved images: %w", err)
+		}
+		defer func() { _ = images.Close() }()
+		info, err := images.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to read saved images: %w", err)
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: bundleFileImages, Mode: 0o644, Size: info.Size(), ModTime: c.manifest.CreatedAt}); err != nil {
+			return fmt.Errorf("failed to write %s: %w", bundleFileImages, err)
+		}
+		if _, err := io.Copy(tw, images); err != nil {
+			return fmt.Errorf("failed to write %s: %w", bundleFileImages, err)
+		}
+	}
+
+	return tw.Close()
+}
+
+// readBundle decodes a bundle written by writeBundle. The saved images are streamed to loadImages when it is set.
+func readBundle(r io.Reader, loadImages func(io.Reader) error) (*bundleContents, error) {
+	c := &bundleContents{readmes: seed.ReadmeFile{}}
+	targets := map[string]any{
+		bundleFileManifest: &c.manifest,
+		bundleFileServers:  &c.servers,
+		bundleFileAgents:   &c.agents,
+		bundleFileReadmes:  &c.readmes,
+	}
+
+	seenManifest := false
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Name == bundleFileImages {
+			if loadImages != nil {
+				if err := loadImages(tr); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		target, ok := targets[hdr.Name]
+		if !ok {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, bundleMaxJSONSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+		if len(data) > bundleMaxJSONSize {
+			return nil, fmt.Errorf("%s exceeds the maximum size of %d bytes", hdr.Name, bundleMaxJSONSize)
+		}
+		if err := json.Unmarshal(data, target); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", hdr.Name, err)
+		}
+		if hdr.Name == bundleFileManifest {
+			seenManifest = true
+			if c.manifest.FormatVersion > bundleFormatVersion {
+				return nil, fmt.Errorf("bundle format %d is newer than the supported format %d; upgrade arctl", c.manifest.FormatVersion, bundleFormatVersion)
+			}
+		}
+	}
+	if !seenManifest {
+		return nil, fmt.Errorf("not a bundle: %s is missing", bundleFileManifest)
+	}
+	return c, nil
+}
+
+func runBundleInstall(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var loadImages func(io.Reader) error
+	if !bundleSkipImages {
+		loadImages = func(images io.Reader) error {
+			fmt.Println("Loading images...")
+			load := utils.ContainerCommand(ctx, "load")
+			load.Stdin = images
+			load.Stdout, load.Stderr = os.Stdout, os.Stderr
+			if err := load.Run(); err != nil {
+				return fmt.Errorf("failed to load images: %w", err)
+			}
+			return nil
+		}
+	}
+	contents, err := readBundle(f, loadImages)
+	if err != nil {
+		return err
+	}
+
+	installed := 0
+	for _, server := range contents.servers {
+		existing, err := apiClient.GetServerByNameAndVersion(server.Name, server.Version, false)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			fmt.Printf("MCP server %s v%s already exists, skipping\n", server.Name, server.Version)
+			continue
+		}
+		if _, err := apiClient.PushMCPServer(server); err != nil {
+			return fmt.Errorf("failed to create MCP server %s v%s: %w", server.Name, server.Version, err)
+		}
+		if readme, ok := contents.readmes[seed.Key(server.Name, server.Version)]; ok && readme.Content != "" {
+			content, contentType, err := readme.Decode()
+			if err != nil {
+				return fmt.Errorf("failed to decode README of %s v%s: %w", server.Name, server.Version, err)
+			}
+			if err := apiClient.UploadServerReadme(server.Name, server.Version, content, contentType); err != nil {
+				return fmt.Errorf("failed to store README of %s v%s: %w", server.Name, server.Version, err)
+			}
+		}
+		if !bundleNoPublish {
+			if err := apiClient.PublishMCPServerStatus(server.Name, server.Version); err != nil {
+				return fmt.Errorf("failed to publish MCP server %s v%s: %w", server.Name, server.Version, err)
+			}
+		}
+		installed++
+	}
+
+	for _, agent := range contents.agents {
+		existing, err := apiClient.GetAgentByNameAndVersion(agent.Name, agent.Version)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			fmt.Printf("Agent %s v%s already exists, skipping\n", agent.Name, agent.Version)
+			continue
+		}
+		if _, err := apiClient.PushAgent(agent); err != nil {
+			return fmt.Errorf("failed to create agent %s v%s: %w", agent.Name, agent.Version, err)
+		}
+		if !bundleNoPublish {
+			if err := apiClient.PublishAgentStatus(agent.Name, agent.Version); err != nil {
+				return fmt.Errorf("failed to publish agent %s v%s: %w", agent.Name, agent.Version, err)
+			}
+		}
+		installed++
+	}
+
+	fmt.Printf("✓ Installed %d of %d entries from %s %s v%s\n", installed, len(contents.servers)+len(contents.agents),
+		contents.manifest.ResourceType, contents.manifest.Name, contents.manifest.Version)
+	return nil
+}
+
+func nonNilSlice[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
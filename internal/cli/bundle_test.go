@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	imagesPath := filepath.Join(t.TempDir(), "images.tar")
+	require.NoError(t, os.WriteFile(imagesPath, []byte("saved images"), 0o644))
+
+	server := &apiv0.ServerJSON{Name: "io.github.example/weather", Version: "1.0.0", Description: "Weather"}
+	contents := &bundleContents{
+		manifest: bundleManifest{
+			FormatVersion: bundleFormatVersion,
+			CreatedAt:     time.Now().UTC(),
+			ResourceType:  "agent",
+			Name:          "my-agent",
+			Version:       "0.1.0",
+			Images:        []string{"ghcr.io/example/my-agent:0.1.0"},
+		},
+		servers: []*apiv0.ServerJSON{server},
+		agents:  []*models.AgentJSON{{AgentManifest: models.AgentManifest{Name: "my-agent"}, Version: "0.1.0"}},
+		readmes: seed.ReadmeFile{seed.Key(server.Name, server.Version): seed.EncodeReadme([]byte("# Weather"), "text/markdown")},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeBundle(&buf, contents, imagesPath))
+
+	var loaded []byte
+	got, err := readBundle(&buf, func(r io.Reader) error {
+		var err error
+		loaded, err = io.ReadAll(r)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "saved images", string(loaded))
+
+	assert.Equal(t, contents.manifest.Name, got.manifest.Name)
+	assert.Equal(t, contents.manifest.Images, got.manifest.Images)
+	require.Len(t, got.servers, 1)
+	assert.Equal(t, server.Name, got.servers[0].Name)
+	require.Len(t, got.agents, 1)
+	assert.Equal(t, "my-agent", got.agents[0].Name)
+
+	readme, contentType, err := got.readmes[seed.Key(server.Name, server.Version)].Decode()
+	require.NoError(t, err)
+	assert.Equal(t, "# Weather", string(readme))
+	assert.Equal(t, "text/markdown", contentType)
+}
+
+func TestReadBundleRejectsArchiveWithoutManifest(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: bundleFileServers, Mode: 0o644, Size: 2}))
+	_, err := tw.Write([]byte("[]"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	_, err = readBundle(&buf, nil)
+	assert.ErrorContains(t, err, "not a bundle")
+}
@@ -0,0 +1,360 @@
+// Package cliconfig reads and writes the arctl configuration file, ~/.arctl/config.yaml by default.
+//
+// The file holds defaults for the global flags (registry endpoint, output format, verbosity), the runtime used by
+// deploy commands, the telemetry opt-out and named contexts that each point at a registry. Flags and environment
+// variables always take precedence over the file.
+package cliconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/statefile"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"gopkg.in/yaml.v3"
+)
+
+// PathEnvVar overrides the location of the config file
+const PathEnvVar = "ARCTL_CONFIG"
+
+// Context is a named registry the CLI can switch between
+type Context struct {
+	RegistryURL string `json:"registryUrl" yaml:"registryUrl"`
+	// Token is an optional API token for the registry; without it the credentials stored by `arctl login` for the
+	// registry URL are used
+	Token string `json:"token,omitempty" yaml:"token,omitempty"`
+}
+
+// Config is the content of the config file
+type Config struct {
+	// RegistryURL is the registry used when no context is selected
+	RegistryURL string `json:"registryUrl,omitempty" yaml:"registryUrl,omitempty"`
+	// Runtime is the default --runtime of deploy commands
+	Runtime string `json:"runtime,omitempty" yaml:"runtime,omitempty"`
+	// Output is the default --output format
+	Output string `json:"output,omitempty" yaml:"output,omitempty"`
+	// Verbose turns on verbose output of commands that support it
+	Verbose bool `json:"verbose,omitempty" yaml:"verbose,omitempty"`
+	// DisableTelemetry turns off the anonymous usage pings, like ARCTL_DISABLE_TELEMETRY
+	DisableTelemetry bool `json:"disableTelemetry,omitempty" yaml:"disableTelemetry,omitempty"`
+	// RequestTimeout is the default --request-timeout, a Go duration such as 30s or 2m
+	RequestTimeout string `json:"requestTimeout,omitempty" yaml:"requestTimeout,omitempty"`
+	// CurrentContext is the name of the selected context
+	CurrentContext string `json:"currentContext,omitempty" yaml:"currentContext,omitempty"`
+	// Contexts are the named registries, keyed by name
+	Contexts map[string]*Context `json:"contexts,omitempty" yaml:"contexts,omitempty"`
+}
+
+// Settable keys of `arctl config set`; contexts are set with contexts.<name>.registry-url and contexts.<name>.token
+const (
+	KeyRegistryURL      = "registry-url"
+	KeyRuntime          = "runtime"
+	KeyOutput           = "output"
+	KeyVerbose          = "verbose"
+	KeyDisableTelemetry = "disable-telemetry"
+	KeyRequestTimeout   = "request-timeout"
+	KeyCurrentContext   = "current-context"
+	KeyToken            = "token"
+)
+
+// Keys lists the settable top-level keys
+var Keys = []string{KeyRegistryURL, KeyRuntime, KeyOutput, KeyVerbose, KeyDisableTelemetry, KeyRequestTimeout, KeyCurrentContext}
+
+// DefaultPath returns $ARCTL_CONFIG, or ~/.arctl/config.yaml when it is not set
+func DefaultPath() (string, error) {
+	if path := strings.TrimSpace(os.Getenv(PathEnvVar)); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(home, ".arctl", "config.yaml"), nil
+}
+
+// Load reads the config file at path. A missing file yields an empty config.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save writes the config file to path. Commands changing the config use Update, which keeps a concurrent command
+// from overwriting their change.
+func Save(path string, cfg *Config) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := statefile.WriteAtomic(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// Update changes the config file at path under its lock: it reads the file, applies change and writes the result,
+// unless change fails. It returns the updated config.
+func Update(path string, change func(*Config) error) (*Config, error) {
+	unlock, err := statefile.Lock(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(cfg); err != nil {
+		return nil, err
+	}
+	if err := Save(path, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ActiveRegistryURL returns the registry of the current context, or the top-level registry URL when no context
+// is selected. It is empty when neither is configured.
+func (c *Config) ActiveRegistryURL() string {
+	if ctx, ok := c.Contexts[c.CurrentContext]; ok && ctx.RegistryURL != "" {
+		return ctx.RegistryURL
+	}
+	return c.RegistryURL
+}
+
+// ActiveToken returns the API token of the current context, if it has one
+func (c *Config) ActiveToken() string {
+	if ctx, ok := c.Contexts[c.CurrentContext]; ok {
+		return ctx.Token
+	}
+	return ""
+}
+
+// SetContext adds or replaces a context
+func (c *Config) SetContext(name string, ctx *Context) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("context name must not be empty")
+	}
+	if ctx.RegistryURL == "" {
+		return fmt.Errorf("context %q needs a registry URL", name)
+	}
+	if c.Contexts == nil {
+		c.Contexts = map[string]*Context{}
+	}
+	c.Contexts[name] = ctx
+	return nil
+}
+
+// DeleteContext removes a context, deselecting it when it is the current one
+func (c *Config) DeleteContext(name string) error {
+	if _, ok := c.Contexts[name]; !ok {
+		return fmt.Errorf("context %q is not configured", name)
+	}
+	delete(c.Contexts, name)
+	if c.CurrentContext == name {
+		c.CurrentContext = ""
+	}
+	return nil
+}
+
+// Redacted returns a copy of the config with context tokens masked, for display
+func (c *Config) Redacted() *Config {
+	out := *c
+	out.Contexts = make(map[string]*Context, len(c.Contexts))
+	for name, ctx := range c.Contexts {
+		redacted := *ctx
+		if redacted.Token != "" {
+			redacted.Token = "REDACTED"
+		}
+		out.Contexts[name] = &redacted
+	}
+	return &out
+}
+
+// ContextNames returns the names of the configured contexts in order
+func (c *Config) ContextNames() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseContext selects a configured context
+func (c *Config) UseContext(name string) error {
+	if _, ok := c.Contexts[name]; !ok {
+		return fmt.Errorf("context %q is not configured (known contexts: %s)", name, strings.Join(c.ContextNames(), ", "))
+	}
+	c.CurrentContext = name
+	return nil
+}
+
+// Get returns the value of a key as shown by `arctl config get`
+func (c *Config) Get(key string) (string, error) {
+	if name, field, ok := contextKey(key); ok {
+		ctx, found := c.Contexts[name]
+		if !found {
+			return "", fmt.Errorf("context %q is not configured", name)
+		}
+		switch field {
+		case KeyRegistryURL:
+			return ctx.RegistryURL, nil
+		case KeyToken:
+			return ctx.Token, nil
+		default:
+			return "", unknownKey(key)
+		}
+	}
+
+	switch key {
+	case KeyRegistryURL:
+		return c.RegistryURL, nil
+	case KeyRuntime:
+		return c.Runtime, nil
+	case KeyOutput:
+		return c.Output, nil
+	case KeyVerbose:
+		return strconv.FormatBool(c.Verbose), nil
+	case KeyDisableTelemetry:
+		return strconv.FormatBool(c.DisableTelemetry), nil
+	case KeyRequestTimeout:
+		return c.RequestTimeout, nil
+	case KeyCurrentContext:
+		return c.CurrentContext, nil
+	default:
+		return "", unknownKey(key)
+	}
+}
+
+// Set validates and sets the value of a key. An empty value clears it.
+func (c *Config) Set(key, value string) error {
+	value = strings.TrimSpace(value)
+
+	if name, field, ok := contextKey(key); ok {
+		switch field {
+		case KeyRegistryURL:
+			if value == "" {
+				if _, ok := c.Contexts[name]; !ok {
+					return nil
+				}
+				return c.DeleteContext(name)
+			}
+			ctx := &Context{RegistryURL: value}
+			if existing, ok := c.Contexts[name]; ok {
+				ctx.Token = existing.Token
+			}
+			return c.SetContext(name, ctx)
+		case KeyToken:
+			ctx, ok := c.Contexts[name]
+			if !ok {
+				return fmt.Errorf("context %q is not configured; set contexts.%s.%s first", name, name, KeyRegistryURL)
+			}
+			ctx.Token = value
+			return nil
+		default:
+			return unknownKey(key)
+		}
+	}
+
+	switch key {
+	case KeyRegistryURL:
+		c.RegistryURL = value
+	case KeyRuntime:
+		if value != "" {
+			if err := runtime.ValidateRuntime(value); err != nil {
+				return err
+			}
+		}
+		c.Runtime = value
+	case KeyOutput:
+		if value != "" {
+			if _, err := printer.ParseOutputType(value); err != nil {
+				return err
+			}
+		}
+		c.Output = value
+	case KeyVerbose:
+		b, err := parseBool(key, value)
+		if err != nil {
+			return err
+		}
+		c.Verbose = b
+	case KeyDisableTelemetry:
+		b, err := parseBool(key, value)
+		if err != nil {
+			return err
+		}
+		c.DisableTelemetry = b
+	case KeyRequestTimeout:
+		if value != "" {
+			if _, err := ParseRequestTimeout(value); err != nil {
+				return err
+			}
+		}
+		c.RequestTimeout = value
+	case KeyCurrentContext:
+		if value == "" {
+			c.CurrentContext = ""
+			return nil
+		}
+		return c.UseContext(value)
+	default:
+		return unknownKey(key)
+	}
+	return nil
+}
+
+// contextKey splits contexts.<name>.<field>
+func contextKey(key string) (name, field string, ok bool) {
+	rest, found := strings.CutPrefix(key, "contexts.")
+	if !found {
+		return "", "", false
+	}
+	i := strings.LastIndex(rest, ".")
+	if i <= 0 {
+		return "", "", false
+	}
+	return rest[:i], rest[i+1:], true
+}
+
+// ParseRequestTimeout parses a request timeout, a positive Go duration
+func ParseRequestTimeout(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a positive duration such as 30s or 2m", KeyRequestTimeout, value)
+	}
+	return d, nil
+}
+
+func parseBool(key, value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false, got %q", key, value)
+	}
+	return b, nil
+}
+
+func unknownKey(key string) error {
+	return fmt.Errorf("unknown config key %q (known keys: %s, contexts.<name>.%s, contexts.<name>.%s)", key, strings.Join(Keys, ", "), KeyRegistryURL, KeyToken)
+}
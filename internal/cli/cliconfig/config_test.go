@@ -0,0 +1,97 @@
+package cliconfig
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.ActiveRegistryURL())
+
+	require.NoError(t, cfg.Set(KeyRegistryURL, "http://localhost:12121/v0"))
+	require.NoError(t, cfg.Set(KeyOutput, "yaml"))
+	require.NoError(t, cfg.Set(KeyDisableTelemetry, "true"))
+	require.NoError(t, cfg.Set("contexts.staging.registry-url", "https://staging.example.com/v0"))
+	require.NoError(t, cfg.UseContext("staging"))
+	require.NoError(t, Save(path, cfg))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "https://staging.example.com/v0", loaded.ActiveRegistryURL())
+	assert.True(t, loaded.DisableTelemetry)
+
+	value, err := loaded.Get(KeyOutput)
+	require.NoError(t, err)
+	assert.Equal(t, "yaml", value)
+
+	// Removing the selected context falls back to the top-level registry
+	require.NoError(t, loaded.Set("contexts.staging.registry-url", ""))
+	assert.Empty(t, loaded.CurrentContext)
+	assert.Equal(t, "http://localhost:12121/v0", loaded.ActiveRegistryURL())
+}
+
+func TestConfigSetValidates(t *testing.T) {
+	cfg := &Config{}
+	assert.Error(t, cfg.Set(KeyOutput, "xml"))
+	assert.Error(t, cfg.Set(KeyVerbose, "sometimes"))
+	assert.Error(t, cfg.Set(KeyRuntime, "mainframe"))
+	assert.Error(t, cfg.Set(KeyRequestTimeout, "soon"))
+	assert.Error(t, cfg.Set(KeyRequestTimeout, "-5s"))
+	require.NoError(t, cfg.Set(KeyRequestTimeout, "2m"))
+	assert.Equal(t, "2m", cfg.RequestTimeout)
+	assert.Error(t, cfg.Set("colour", "blue"))
+	assert.Error(t, cfg.UseContext("prod"))
+	assert.Error(t, cfg.Set("contexts.prod.token", "secret"))
+}
+
+func TestConfigContextTokens(t *testing.T) {
+	cfg := &Config{}
+	require.NoError(t, cfg.SetContext("prod", &Context{RegistryURL: "https://registry.example.com/v0", Token: "secret"}))
+	assert.Empty(t, cfg.ActiveToken())
+
+	require.NoError(t, cfg.UseContext("prod"))
+	assert.Equal(t, "secret", cfg.ActiveToken())
+
+	// Changing the registry URL keeps the token
+	require.NoError(t, cfg.Set("contexts.prod.registry-url", "https://registry2.example.com/v0"))
+	assert.Equal(t, "secret", cfg.ActiveToken())
+
+	redacted := cfg.Redacted()
+	assert.Equal(t, "REDACTED", redacted.Contexts["prod"].Token)
+	assert.Equal(t, "secret", cfg.Contexts["prod"].Token, "redacting must not modify the config")
+
+	require.NoError(t, cfg.DeleteContext("prod"))
+	assert.Empty(t, cfg.CurrentContext)
+	assert.Empty(t, cfg.ActiveToken())
+	assert.Error(t, cfg.DeleteContext("prod"))
+	assert.Error(t, cfg.SetContext("staging", &Context{}))
+}
+
+func TestConfigUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	cfg, err := Update(path, func(cfg *Config) error {
+		return cfg.SetContext("staging", &Context{RegistryURL: "https://registry.staging.example.com/v0"})
+	})
+	require.NoError(t, err)
+	assert.Contains(t, cfg.Contexts, "staging")
+
+	// A failed change leaves the file as it was
+	_, err = Update(path, func(cfg *Config) error {
+		return cfg.UseContext("missing")
+	})
+	require.Error(t, err)
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "https://registry.staging.example.com/v0", loaded.Contexts["staging"].RegistryURL)
+	assert.Empty(t, loaded.CurrentContext)
+	assert.NoFileExists(t, path+".lock")
+}
@@ -0,0 +1,202 @@
+// Package completion completes registry artifact names in shell completions and interactive pickers
+package completion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/statefile"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+// Kind is the type of artifact a name belongs to
+type Kind string
+
+const (
+	Servers Kind = "servers"
+	Agents  Kind = "agents"
+	Skills  Kind = "skills"
+)
+
+// cacheTTL is how long fetched names are reused, so repeated <TAB> presses do not hit the registry each time
+const cacheTTL = time.Minute
+
+var apiClient *client.Client
+
+// SetAPIClient sets the client names are fetched with. Completion requests skip the usual daemon start and
+// connectivity check, so the client may point at a registry that is not reachable; completion then offers nothing.
+func SetAPIClient(c *client.Client) {
+	apiClient = c
+}
+
+// Entry is an artifact name with a short description
+type Entry struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// cacheFile is the on-disk form of the names of one kind
+type cacheFile struct {
+	BaseURL   string    `json:"baseUrl"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	Entries   []Entry   `json:"entries"`
+}
+
+// Names returns a cobra completion function that completes the first positional argument with the names of
+// published artifacts of the given kind
+func Names(kind Kind) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		entries, err := List(kind)
+		if err != nil {
+			cobra.CompErrorln(err.Error())
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var completions []cobra.Completion
+		for _, e := range entries {
+			if !strings.HasPrefix(e.Name, toComplete) {
+				continue
+			}
+			if e.Description == "" {
+				completions = append(completions, e.Name)
+				continue
+			}
+			completions = append(completions, cobra.CompletionWithDesc(e.Name, printer.TruncateString(e.Description, 60)))
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Kinds lists every kind of artifact whose names are cached
+var Kinds = []Kind{Servers, Agents, Skills}
+
+// List returns the names of the published artifacts of a kind, sorted and without duplicate versions. Results are
+// cached for a minute per registry in the user cache directory.
+func List(kind Kind) ([]Entry, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("API client not initialized")
+	}
+	path := cachePath(apiClient.BaseURL, kind)
+	if entries, ok := readCache(path, apiClient.BaseURL); ok {
+		return entries, nil
+	}
+
+	entries, err := fetch(apiClient, kind)
+	if err != nil {
+		return nil, err
+	}
+	writeCache(path, cacheFile{BaseURL: apiClient.BaseURL, FetchedAt: time.Now(), Entries: entries})
+	return entries, nil
+}
+
+// Refresh fetches the names of a kind from the registry of c and replaces its cached names, returning how many
+// names it cached
+func Refresh(c *client.Client, kind Kind) (int, error) {
+	entries, err := fetch(c, kind)
+	if err != nil {
+		return 0, err
+	}
+	writeCache(cachePath(c.BaseURL, kind), cacheFile{BaseURL: c.BaseURL, FetchedAt: time.Now(), Entries: entries})
+	return len(entries), nil
+}
+
+func fetch(c *client.Client, kind Kind) ([]Entry, error) {
+	seen := map[string]Entry{}
+	add := func(name, description string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = Entry{Name: name, Description: description}
+		}
+	}
+
+	switch kind {
+	case Servers:
+		servers, err := c.GetPublishedServers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, s := range servers {
+			add(s.Server.Name, s.Server.Description)
+		}
+	case Agents:
+		agents, err := c.GetAgents()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list agents: %w", err)
+		}
+		for _, a := range agents {
+			add(a.Agent.Name, a.Agent.Description)
+		}
+	case Skills:
+		skills, err := c.GetSkills()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list skills: %w", err)
+		}
+		for _, s := range skills {
+			add(s.Skill.Name, s.Skill.Description)
+		}
+	default:
+		return nil, fmt.Errorf("unknown artifact kind %q", kind)
+	}
+
+	entries := make([]Entry, 0, len(seen))
+	for _, e := range seen {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// cachePath returns the cache file of a kind for one registry, so that switching contexts keeps the names of each
+func cachePath(baseURL string, kind Kind) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(baseURL))
+	return filepath.Join(dir, "arctl", "completion", hex.EncodeToString(sum[:8]), string(kind)+".json")
+}
+
+func readCache(path, baseURL string) ([]Entry, bool) {
+	if path == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cache cacheFile
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, false
+	}
+	if cache.BaseURL != baseURL || time.Since(cache.FetchedAt) > cacheTTL {
+		return nil, false
+	}
+	return cache.Entries, true
+}
+
+// writeCache stores fetched names; failures only cost a refetch, so they are ignored
+func writeCache(path string, cache cacheFile) {
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	// Concurrent completions may write the cache at once; a rename keeps readers from seeing a partial file
+	_ = statefile.WriteAtomic(path, data, 0644)
+}
@@ -0,0 +1,61 @@
+package completion
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestNames(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/v0/skills", r.URL.Path)
+		resp := models.SkillListResponse{Skills: []models.SkillResponse{
+			{Skill: models.SkillJSON{Name: "com.example/summarize", Version: "1.0.0", Description: "Summarizes documents"}},
+			{Skill: models.SkillJSON{Name: "com.example/summarize", Version: "1.1.0", Description: "Summarizes documents"}},
+			{Skill: models.SkillJSON{Name: "com.example/search", Version: "0.1.0"}},
+			{Skill: models.SkillJSON{Name: "io.github.other/translate", Version: "2.0.0"}},
+		}}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	SetAPIClient(client.NewClient(srv.URL+"/v0", ""))
+	defer SetAPIClient(nil)
+
+	complete := Names(Skills)
+	completions, directive := complete(&cobra.Command{}, nil, "com.example/s")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Equal(t, []cobra.Completion{
+		"com.example/search",
+		"com.example/summarize\tSummarizes documents",
+	}, completions)
+
+	// Names are cached, so completing again does not hit the registry
+	completions, _ = complete(&cobra.Command{}, nil, "io.")
+	assert.Equal(t, []cobra.Completion{"io.github.other/translate"}, completions)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// Only the first positional argument is completed
+	completions, _ = complete(&cobra.Command{}, []string{"com.example/search"}, "")
+	assert.Empty(t, completions)
+}
+
+func TestList_NoClient(t *testing.T) {
+	SetAPIClient(nil)
+	_, err := List(Servers)
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package completion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/prompt"
+)
+
+// singular names a kind in messages
+var singular = map[Kind]string{
+	Servers: "MCP server",
+	Agents:  "agent",
+	Skills:  "skill",
+}
+
+// NameArg returns args[0] when given. Otherwise, in a terminal, it opens a fuzzy finder over the published
+// artifacts of the kind and returns the chosen name; without a terminal it returns an error asking for the name.
+func NameArg(kind Kind, args []string) (string, error) {
+	if len(args) > 0 && strings.TrimSpace(args[0]) != "" {
+		return args[0], nil
+	}
+	if !prompt.IsInteractive() {
+		return "", fmt.Errorf("a %s name is required", singular[kind])
+	}
+
+	entries, err := List(kind)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no published %ss found in the registry", singular[kind])
+	}
+	items := make([]prompt.Item, len(entries))
+	for i, e := range entries {
+		items[i] = prompt.Item{Name: e.Name, Detail: e.Description}
+	}
+
+	idx, err := prompt.FuzzySelect("Select a "+singular[kind], items)
+	if errors.Is(err, prompt.ErrCancelled) {
+		return "", fmt.Errorf("no %s selected", singular[kind])
+	}
+	if err != nil {
+		return "", err
+	}
+	return entries[idx].Name, nil
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/cliconfig"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and edit the arctl config file",
+	Long: `View and edit the arctl config file (~/.arctl/config.yaml, or $ARCTL_CONFIG).
+
+The file sets defaults for the registry endpoint, the deployment runtime, the output format, verbosity and the
+telemetry opt-out, and holds named contexts that each point at a registry. Flags and environment variables
+take precedence over the file.
+
+Keys:
+  registry-url                   Registry used when no context is selected
+  runtime                        Default --runtime of deploy commands (local, kubernetes)
+  output                         Default --output format (table, wide, json, yaml)
+  verbose                        Verbose output (true, false)
+  disable-telemetry              Turn off anonymous usage pings (true, false)
+  request-timeout                Default --request-timeout of API requests (e.g. 30s, 2m)
+  current-context                Selected context
+  contexts.<name>.registry-url   Registry of a named context
+  contexts.<name>.token          API token of a named context (optional; 'arctl login' works per registry too)
+
+'arctl context' manages contexts with a shorter syntax.`,
+	Example: `  arctl config set output yaml
+  arctl config set contexts.staging.registry-url https://registry.staging.example.com/v0
+  arctl config use-context staging
+  arctl config get registry-url
+  arctl config view`,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a config value; an empty value clears it",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a config value",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configUseContextCmd = &cobra.Command{
+	Use:   "use-context <name>",
+	Short: "Select the context later commands use",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigUseContext,
+}
+
+var configViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Print the config file",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigView,
+}
+
+func init() {
+	configSetCmd.ValidArgs = cliconfig.Keys
+	ConfigCmd.AddCommand(configSetCmd, configGetCmd, configUseContextCmd, configViewCmd)
+}
+
+// loadConfig reads the config file together with its path
+func loadConfig() (*cliconfig.Config, string, error) {
+	path, err := cliconfig.DefaultPath()
+	if err != nil {
+		return nil, "", err
+	}
+	cfg, err := cliconfig.Load(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return cfg, path, nil
+}
+
+// updateConfig changes the config file under its lock, so concurrent commands do not overwrite each other's changes
+func updateConfig(change func(*cliconfig.Config) error) (*cliconfig.Config, error) {
+	path, err := cliconfig.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return cliconfig.Update(path, change)
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	if _, err := updateConfig(func(cfg *cliconfig.Config) error {
+		return cfg.Set(args[0], args[1])
+	}); err != nil {
+		return err
+	}
+	if args[1] == "" {
+		printer.PrintSuccess(fmt.Sprintf("Cleared %s", args[0]))
+	} else {
+		printer.PrintSuccess(fmt.Sprintf("Set %s to %s", args[0], args[1]))
+	}
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	value, err := cfg.Get(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
+
+func runConfigUseContext(cmd *cobra.Command, args []string) error {
+	cfg, err := updateConfig(func(cfg *cliconfig.Config) error {
+		return cfg.UseContext(args[0])
+	})
+	if err != nil {
+		return err
+	}
+	printer.PrintSuccess(fmt.Sprintf("Switched to context %s (%s)", args[0], cfg.ActiveRegistryURL()))
+	return nil
+}
+
+func runConfigView(cmd *cobra.Command, args []string) error {
+	cfg, path, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	// Context tokens are secrets and never printed
+	redacted := cfg.Redacted()
+	if printer.Format() == printer.OutputTypeJSON {
+		return printer.PrintStructured(redacted)
+	}
+	fmt.Printf("# %s\n", path)
+	return printer.New(printer.OutputTypeYAML, false).PrintYAML(redacted)
+}
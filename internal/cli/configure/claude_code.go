@@ -27,7 +27,7 @@ func (c *ClaudeCodeConfigurer) GetConfigPath() (string, error) {
 	return ".mcp.json", nil
 }
 
-func (c *ClaudeCodeConfigurer) CreateConfig(url string, configPath string) (any, error) {
+func (c *ClaudeCodeConfigurer) readConfig(configPath string) (claudeConfig, error) {
 	config := claudeConfig{
 		MCPServers: make(map[string]claudeServerConfig),
 	}
@@ -38,9 +38,20 @@ func (c *ClaudeCodeConfigurer) CreateConfig(url string, configPath string) (any,
 			return config, fmt.Errorf("failed to parse existing config: %w", err)
 		}
 	}
+	if config.MCPServers == nil {
+		config.MCPServers = make(map[string]claudeServerConfig)
+	}
+	return config, nil
+}
+
+func (c *ClaudeCodeConfigurer) CreateConfig(name string, url string, configPath string) (any, error) {
+	config, err := c.readConfig(configPath)
+	if err != nil {
+		return config, err
+	}
 
 	// Add or update the arctl HTTP server
-	config.MCPServers["arctl"] = claudeServerConfig{
+	config.MCPServers[name] = claudeServerConfig{
 		Type: "http",
 		URL:  url,
 	}
@@ -48,6 +59,16 @@ func (c *ClaudeCodeConfigurer) CreateConfig(url string, configPath string) (any,
 	return config, nil
 }
 
+func (c *ClaudeCodeConfigurer) RemoveConfig(name string, configPath string) (any, bool, error) {
+	config, err := c.readConfig(configPath)
+	if err != nil {
+		return config, false, err
+	}
+	_, found := config.MCPServers[name]
+	delete(config.MCPServers, name)
+	return config, found, nil
+}
+
 func (c *ClaudeCodeConfigurer) GetClientName() string {
 	return "Claude Code Editor"
 }
@@ -0,0 +1,30 @@
+package configure
+
+import "path/filepath"
+
+// ClaudeDesktopConfigurer handles Claude Desktop MCP configuration
+type ClaudeDesktopConfigurer struct{}
+
+func (c *ClaudeDesktopConfigurer) GetConfigPath() (string, error) {
+	dir, err := userConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "Claude", "claude_desktop_config.json"), nil
+}
+
+func (c *ClaudeDesktopConfigurer) CreateConfig(name string, url string, configPath string) (any, error) {
+	// Claude Desktop only launches stdio servers from its config, so the HTTP endpoint is bridged with mcp-remote
+	return mergeServerEntry(configPath, "mcpServers", name, map[string]any{
+		"command": "npx",
+		"args":    []string{"-y", "mcp-remote", url},
+	})
+}
+
+func (c *ClaudeDesktopConfigurer) RemoveConfig(name string, configPath string) (any, bool, error) {
+	return removeServerEntry(configPath, "mcpServers", name)
+}
+
+func (c *ClaudeDesktopConfigurer) GetClientName() string {
+	return "Claude Desktop"
+}
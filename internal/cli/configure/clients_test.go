@@ -0,0 +1,222 @@
+package configure
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUserLevelConfigurers_PreserveOtherSettings(t *testing.T) {
+	url := "http://localhost:21212/mcp"
+	tests := []struct {
+		name       string
+		configurer ClientConfigurer
+		serversKey string
+		existing   string
+		check      func(t *testing.T, entry map[string]any)
+	}{
+		{
+			name:       "claude desktop",
+			configurer: &ClaudeDesktopConfigurer{},
+			serversKey: "mcpServers",
+			existing:   `{"globalShortcut": "Ctrl+Space", "mcpServers": {"github": {"command": "github-mcp"}}}`,
+			check: func(t *testing.T, entry map[string]any) {
+				args, _ := entry["args"].([]any)
+				if entry["command"] != "npx" || len(args) != 3 || args[2] != url {
+					t.Errorf("Expected an mcp-remote bridge to %s, got %v", url, entry)
+				}
+			},
+		},
+		{
+			name:       "windsurf",
+			configurer: &WindsurfConfigurer{},
+			serversKey: "mcpServers",
+			existing:   `{"mcpServers": {"github": {"command": "github-mcp"}}}`,
+			check: func(t *testing.T, entry map[string]any) {
+				if entry["serverUrl"] != url {
+					t.Errorf("Expected serverUrl %s, got %v", url, entry)
+				}
+			},
+		},
+		{
+			name:       "zed",
+			configurer: &ZedConfigurer{},
+			serversKey: "context_servers",
+			existing:   `{"theme": "One Dark", "context_servers": {"github": {"command": {"path": "github-mcp"}}}}`,
+			check: func(t *testing.T, entry map[string]any) {
+				if entry["url"] != url {
+					t.Errorf("Expected url %s, got %v", url, entry)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configPath, []byte(tt.existing), 0644); err != nil {
+				t.Fatalf("Failed to write existing config: %v", err)
+			}
+
+			config, err := tt.configurer.CreateConfig(serverName, url, configPath)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if err := writeConfigFile(configPath, config); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+			var written, original map[string]any
+			if err := json.Unmarshal(data, &written); err != nil {
+				t.Fatalf("Written config is not valid JSON: %v", err)
+			}
+			_ = json.Unmarshal([]byte(tt.existing), &original)
+
+			for key, value := range original {
+				if key == tt.serversKey {
+					continue
+				}
+				if written[key] != value {
+					t.Errorf("Expected setting %s to be preserved, got %v", key, written[key])
+				}
+			}
+			servers, ok := written[tt.serversKey].(map[string]any)
+			if !ok {
+				t.Fatalf("Expected %s to be an object", tt.serversKey)
+			}
+			if _, ok := servers["github"]; !ok {
+				t.Error("Expected existing github server to be preserved")
+			}
+			entry, ok := servers["arctl"].(map[string]any)
+			if !ok {
+				t.Fatal("Expected arctl server to exist")
+			}
+			tt.check(t, entry)
+		})
+	}
+}
+
+func TestUserLevelConfigurers_NewFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "missing", "settings.json")
+	config, err := (&ZedConfigurer{}).CreateConfig(serverName, "http://localhost:21212/mcp", configPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	servers, ok := config.(map[string]any)["context_servers"].(map[string]any)
+	if !ok || servers["arctl"] == nil {
+		t.Errorf("Expected arctl context server, got %v", config)
+	}
+}
+
+func TestUserLevelConfigurers_InvalidServersKey(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"mcpServers": []}`), 0644); err != nil {
+		t.Fatalf("Failed to write existing config: %v", err)
+	}
+	if _, err := (&WindsurfConfigurer{}).CreateConfig(serverName, "http://localhost:21212/mcp", configPath); err == nil {
+		t.Error("Expected an error when mcpServers is not an object")
+	}
+}
+
+func TestZedConfigurer_GetConfigPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	path, err := (&ZedConfigurer{}).GetConfigPath()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if filepath.Base(path) != "settings.json" {
+		t.Errorf("Expected a settings.json path, got %s", path)
+	}
+}
+
+func TestConfigurers_RemoveConfig(t *testing.T) {
+	t.Run("user-level client keeps other servers", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "claude_desktop_config.json")
+		existing := `{"mcpServers": {"arctl": {"command": "npx"}, "arctl-staging": {"command": "npx"}, "other": {"command": "other"}}}`
+		if err := os.WriteFile(configPath, []byte(existing), 0644); err != nil {
+			t.Fatalf("Failed to write existing config: %v", err)
+		}
+
+		config, found, err := (&ClaudeDesktopConfigurer{}).RemoveConfig("arctl-staging", configPath)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !found {
+			t.Fatal("Expected arctl-staging to be found")
+		}
+		servers := config.(map[string]any)["mcpServers"].(map[string]any)
+		if _, ok := servers["arctl-staging"]; ok {
+			t.Error("Expected arctl-staging to be removed")
+		}
+		if servers["arctl"] == nil || servers["other"] == nil {
+			t.Errorf("Expected other servers to be preserved, got %v", servers)
+		}
+	})
+
+	t.Run("missing entry is reported", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "mcp_config.json")
+		_, found, err := (&WindsurfConfigurer{}).RemoveConfig(serverName, configPath)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if found {
+			t.Error("Expected no entry to be found in a missing file")
+		}
+	})
+
+	t.Run("cursor removes the legacy entry", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "mcp.json")
+		if err := os.WriteFile(configPath, []byte(`{"mcpServers": {"ARCTL": {"url": "http://localhost:21212/mcp"}}}`), 0644); err != nil {
+			t.Fatalf("Failed to write existing config: %v", err)
+		}
+
+		config, found, err := (&CursorConfigurer{}).RemoveConfig(serverName, configPath)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !found {
+			t.Error("Expected the legacy ARCTL entry to count as found")
+		}
+		if servers := config.(cursorConfig).MCPServers; len(servers) != 0 {
+			t.Errorf("Expected no servers left, got %v", servers)
+		}
+	})
+}
+
+func TestServerEntryName(t *testing.T) {
+	tests := []struct {
+		profile string
+		want    string
+		wantErr bool
+	}{
+		{profile: "", want: "arctl"},
+		{profile: "default", want: "arctl"},
+		{profile: "staging", want: "arctl-staging"},
+		{profile: "eu-prod-2", want: "arctl-eu-prod-2"},
+		{profile: "Prod", wantErr: true},
+		{profile: "-prod", wantErr: true},
+		{profile: "prod us", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			got, err := serverEntryName(tt.profile)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error for profile %q", tt.profile)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
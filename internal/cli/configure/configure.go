@@ -4,43 +4,59 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	configureURL  string
-	configurePort string
+	configureURL     string
+	configurePort    string
+	configureList    bool
+	configureRemove  bool
+	configureProfile string
 )
 
 // clientConfigurers maps client names to their configurers
 var clientConfigurers = map[string]ClientConfigurer{
-	"vscode":      &VSCodeConfigurer{},
-	"cursor":      &CursorConfigurer{},
-	"claude-code": &ClaudeCodeConfigurer{},
+	"vscode":         &VSCodeConfigurer{},
+	"cursor":         &CursorConfigurer{},
+	"claude-code":    &ClaudeCodeConfigurer{},
+	"claude-desktop": &ClaudeDesktopConfigurer{},
+	"windsurf":       &WindsurfConfigurer{},
+	"zed":            &ZedConfigurer{},
 }
 
 // NewConfigureCmd creates the configure command
 var ConfigureCmd = &cobra.Command{
 	Use:   "configure [client-name]",
 	Short: "Configure a client",
-	Long:  `Creates the .json configuration for each client, so it can connect to arctl.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Creates the .json configuration for each client, so it can connect to arctl.
+
+VS Code, Cursor and Claude Code are configured for the current project. Claude Desktop, Windsurf and
+Zed are configured in their per-user config files; other settings in those files are preserved.
+
+Use --profile to add several gateways (e.g. local, staging, prod) to the same client: each profile is
+written as its own arctl-<profile> server. --remove deletes the server of the selected profile again.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Show supported clients if no argument provided
-		if len(args) == 0 {
-			fmt.Println("Supported clients:")
-			for name, configurer := range clientConfigurers {
-				fmt.Printf("  %-15s - %s\n", name, configurer.GetClientName())
+		if configureList || len(args) == 0 {
+			printSupportedClients()
+			if !configureList {
+				fmt.Println("\nUsage:")
+				fmt.Println("  arctl configure <client-name>")
+				fmt.Println("\nExamples:")
+				fmt.Println("  arctl configure cursor")
+				fmt.Println("  arctl configure claude-desktop")
+				fmt.Println("  arctl configure claude-code --port 3000")
+				fmt.Println("  arctl configure vscode --port 3000")
+				fmt.Println("  arctl configure vscode --profile staging --url https://staging.example.com/mcp")
+				fmt.Println("  arctl configure vscode --remove")
 			}
-			fmt.Println("\nUsage:")
-			fmt.Println("  arctl configure <client-name>")
-			fmt.Println("\nExamples:")
-			fmt.Println("  arctl configure cursor")
-			fmt.Println("  arctl configure claude-code --port 3000")
-			fmt.Println("  arctl configure vscode --port 3000")
 			return
 		}
 
@@ -52,10 +68,9 @@ var ConfigureCmd = &cobra.Command{
 			log.Fatalf("Client '%s' is not supported. Run 'arctl configure' to see supported clients.", clientName)
 		}
 
-		// Build the URL
-		url := fmt.Sprintf("http://localhost:%s/mcp", configurePort)
-		if configureURL != "" {
-			url = configureURL
+		name, err := serverEntryName(configureProfile)
+		if err != nil {
+			log.Fatalf("%v", err)
 		}
 
 		// Get the config path
@@ -64,8 +79,30 @@ var ConfigureCmd = &cobra.Command{
 			log.Fatalf("Failed to get config path: %v", err)
 		}
 
+		if configureRemove {
+			config, found, err := configurer.RemoveConfig(name, configPath)
+			if err != nil {
+				log.Fatalf("Failed to update %s config: %v", configurer.GetClientName(), err)
+			}
+			if !found {
+				fmt.Printf("%s has no %s server configured in %s\n", configurer.GetClientName(), name, configPath)
+				return
+			}
+			if err := writeConfigFile(configPath, config); err != nil {
+				log.Fatalf("Failed to write config file: %v", err)
+			}
+			fmt.Printf("✓ Removed %s from %s\n", name, configurer.GetClientName())
+			return
+		}
+
+		// Build the URL
+		url := fmt.Sprintf("http://localhost:%s/mcp", configurePort)
+		if configureURL != "" {
+			url = configureURL
+		}
+
 		// Create the config
-		config, err := configurer.CreateConfig(url, configPath)
+		config, err := configurer.CreateConfig(name, url, configPath)
 		if err != nil {
 			log.Fatalf("Failed to create %s config: %v", configurer.GetClientName(), err)
 		}
@@ -75,13 +112,30 @@ var ConfigureCmd = &cobra.Command{
 			log.Fatalf("Failed to write config file: %v", err)
 		}
 
-		fmt.Printf("✓ Configured %s\n", configurer.GetClientName())
+		fmt.Printf("✓ Configured %s (%s -> %s)\n", configurer.GetClientName(), name, url)
 	},
 }
 
 func init() {
 	ConfigureCmd.Flags().StringVar(&configureURL, "url", "", "Custom MCP server URL (default: http://localhost:21212/mcp")
 	ConfigureCmd.Flags().StringVar(&configurePort, "port", "21212", "Port for the MCP server")
+	ConfigureCmd.Flags().BoolVar(&configureList, "list", false, "List supported clients and the config file each one writes")
+	ConfigureCmd.Flags().BoolVar(&configureRemove, "remove", false, "Remove the arctl server from the client config instead of adding it")
+	ConfigureCmd.Flags().StringVar(&configureProfile, "profile", "", "Gateway profile; each profile is written as a separate arctl-<profile> server (e.g. staging, prod)")
+}
+
+// printSupportedClients lists the supported clients in name order with their config paths
+func printSupportedClients() {
+	names := slices.Sorted(maps.Keys(clientConfigurers))
+	fmt.Println("Supported clients:")
+	for _, name := range names {
+		configurer := clientConfigurers[name]
+		path, err := configurer.GetConfigPath()
+		if err != nil {
+			path = "unknown: " + err.Error()
+		}
+		fmt.Printf("  %-15s - %-20s %s\n", name, configurer.GetClientName(), path)
+	}
 }
 
 func writeConfigFile(configPath string, config any) error {
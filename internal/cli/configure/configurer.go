@@ -6,8 +6,12 @@ type ClientConfigurer interface {
 	GetConfigPath() (string, error)
 
 	// CreateConfig creates or updates the MCP configuration for the client
-	// It should read existing config, merge with the new server, and return the updated config
-	CreateConfig(url string, configPath string) (any, error)
+	// It should read existing config, add or replace the server called name, and return the updated config
+	CreateConfig(name string, url string, configPath string) (any, error)
+
+	// RemoveConfig reads the existing config and removes the server called name from it
+	// It returns the updated config and whether the server was present
+	RemoveConfig(name string, configPath string) (any, bool, error)
 
 	// GetClientName returns the display name of the client
 	GetClientName() string
@@ -9,6 +9,9 @@ import (
 // CursorConfigurer handles Cursor MCP configuration
 type CursorConfigurer struct{}
 
+// cursorLegacyServerName is the key earlier versions of arctl registered the default server under
+const cursorLegacyServerName = "ARCTL"
+
 // cursorServerConfig represents a Cursor MCP server configuration
 type cursorServerConfig struct {
 	URL string `json:"url"`
@@ -23,7 +26,7 @@ func (c *CursorConfigurer) GetConfigPath() (string, error) {
 	return ".cursor/mcp.json", nil
 }
 
-func (c *CursorConfigurer) CreateConfig(url string, configPath string) (any, error) {
+func (c *CursorConfigurer) readConfig(configPath string) (cursorConfig, error) {
 	config := cursorConfig{
 		MCPServers: make(map[string]cursorServerConfig),
 	}
@@ -34,15 +37,45 @@ func (c *CursorConfigurer) CreateConfig(url string, configPath string) (any, err
 			return config, fmt.Errorf("failed to parse existing config: %w", err)
 		}
 	}
+	if config.MCPServers == nil {
+		config.MCPServers = make(map[string]cursorServerConfig)
+	}
+	return config, nil
+}
+
+func (c *CursorConfigurer) CreateConfig(name string, url string, configPath string) (any, error) {
+	config, err := c.readConfig(configPath)
+	if err != nil {
+		return config, err
+	}
 
-	// Add or update the ARCTL server
-	config.MCPServers["ARCTL"] = cursorServerConfig{
+	// Add or update the ARCTL server, replacing the entry written under the legacy name
+	if name == serverName {
+		delete(config.MCPServers, cursorLegacyServerName)
+	}
+	config.MCPServers[name] = cursorServerConfig{
 		URL: url,
 	}
 
 	return config, nil
 }
 
+func (c *CursorConfigurer) RemoveConfig(name string, configPath string) (any, bool, error) {
+	config, err := c.readConfig(configPath)
+	if err != nil {
+		return config, false, err
+	}
+	_, found := config.MCPServers[name]
+	delete(config.MCPServers, name)
+	if name == serverName {
+		if _, legacy := config.MCPServers[cursorLegacyServerName]; legacy {
+			found = true
+			delete(config.MCPServers, cursorLegacyServerName)
+		}
+	}
+	return config, found, nil
+}
+
 func (c *CursorConfigurer) GetClientName() string {
 	return "Cursor AI Editor"
 }
@@ -0,0 +1,98 @@
+package configure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+)
+
+// serverName is the key arctl is registered under in client configs
+const serverName = "arctl"
+
+// profileNameRegex restricts profile names to what every client accepts as a server key
+var profileNameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// serverEntryName returns the key arctl is registered under for a gateway profile: "arctl" for the default
+// profile and "arctl-<profile>" otherwise, so several gateways can be configured side by side
+func serverEntryName(profile string) (string, error) {
+	if profile == "" || profile == "default" {
+		return serverName, nil
+	}
+	if !profileNameRegex.MatchString(profile) {
+		return "", fmt.Errorf("invalid profile %q: use lowercase letters, digits and '-'", profile)
+	}
+	return serverName + "-" + profile, nil
+}
+
+// readServers reads the JSON config at configPath, if any, and returns it with its serversKey object. Unlike the
+// typed configs of the project-level clients the whole file is kept, which matters for user-level files such as
+// Zed's settings.json.
+func readServers(configPath, serversKey string) (map[string]any, map[string]any, error) {
+	config := map[string]any{}
+	if data, err := os.ReadFile(configPath); err == nil && len(data) > 0 {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse existing config %s (comments are not supported): %w", configPath, err)
+		}
+	}
+
+	servers, ok := config[serversKey].(map[string]any)
+	if !ok {
+		if existing, present := config[serversKey]; present && existing != nil {
+			return nil, nil, fmt.Errorf("%q in %s is not an object", serversKey, configPath)
+		}
+		servers = map[string]any{}
+	}
+	config[serversKey] = servers
+	return config, servers, nil
+}
+
+// mergeServerEntry sets entry as the server called name in the serversKey object of the config at configPath,
+// keeping every other setting of the file
+func mergeServerEntry(configPath, serversKey, name string, entry map[string]any) (map[string]any, error) {
+	config, servers, err := readServers(configPath, serversKey)
+	if err != nil {
+		return nil, err
+	}
+	servers[name] = entry
+	return config, nil
+}
+
+// removeServerEntry deletes the server called name from the serversKey object of the config at configPath,
+// keeping every other setting of the file
+func removeServerEntry(configPath, serversKey, name string) (map[string]any, bool, error) {
+	config, servers, err := readServers(configPath, serversKey)
+	if err != nil {
+		return nil, false, err
+	}
+	_, found := servers[name]
+	delete(servers, name)
+	return config, found, nil
+}
+
+// userConfigDir returns the per-user application config directory: ~/Library/Application Support on macOS,
+// %AppData% on Windows and $XDG_CONFIG_HOME (default ~/.config) elsewhere
+func userConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the user config directory: %w", err)
+	}
+	return dir, nil
+}
+
+// xdgConfigDir returns $XDG_CONFIG_HOME or ~/.config, which some clients use on macOS as well as Linux
+func xdgConfigDir() (string, error) {
+	if runtime.GOOS == "windows" {
+		return userConfigDir()
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the home directory: %w", err)
+	}
+	return filepath.Join(home, ".config"), nil
+}
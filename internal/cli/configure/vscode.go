@@ -24,7 +24,7 @@ func (v *VSCodeConfigurer) GetConfigPath() (string, error) {
 	return ".vscode/mcp.json", nil
 }
 
-func (v *VSCodeConfigurer) CreateConfig(url string, configPath string) (any, error) {
+func (v *VSCodeConfigurer) readConfig(configPath string) (mcpConfig, error) {
 	config := mcpConfig{
 		Servers: make(map[string]mcpServerConfig),
 	}
@@ -35,9 +35,20 @@ func (v *VSCodeConfigurer) CreateConfig(url string, configPath string) (any, err
 			return config, fmt.Errorf("failed to parse existing config: %w", err)
 		}
 	}
+	if config.Servers == nil {
+		config.Servers = make(map[string]mcpServerConfig)
+	}
+	return config, nil
+}
+
+func (v *VSCodeConfigurer) CreateConfig(name string, url string, configPath string) (any, error) {
+	config, err := v.readConfig(configPath)
+	if err != nil {
+		return config, err
+	}
 
 	// Add or update the arctl server
-	config.Servers["arctl"] = mcpServerConfig{
+	config.Servers[name] = mcpServerConfig{
 		Type: "http",
 		URL:  url,
 	}
@@ -45,6 +56,16 @@ func (v *VSCodeConfigurer) CreateConfig(url string, configPath string) (any, err
 	return config, nil
 }
 
+func (v *VSCodeConfigurer) RemoveConfig(name string, configPath string) (any, bool, error) {
+	config, err := v.readConfig(configPath)
+	if err != nil {
+		return config, false, err
+	}
+	_, found := config.Servers[name]
+	delete(config.Servers, name)
+	return config, found, nil
+}
+
 func (v *VSCodeConfigurer) GetClientName() string {
 	return "Visual Studio Code"
 }
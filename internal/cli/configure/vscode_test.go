@@ -40,7 +40,7 @@ func TestVSCodeConfigurer_CreateConfig(t *testing.T) {
 	url := "http://localhost:8080/mcp"
 
 	// Test creating a new config
-	config, err := configurer.CreateConfig(url, configPath)
+	config, err := configurer.CreateConfig("arctl", url, configPath)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -97,7 +97,7 @@ func TestVSCodeConfigurer_CreateConfig_MergesExisting(t *testing.T) {
 	configurer := &VSCodeConfigurer{}
 	url := "http://localhost:8080/mcp"
 
-	config, err := configurer.CreateConfig(url, configPath)
+	config, err := configurer.CreateConfig("arctl", url, configPath)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
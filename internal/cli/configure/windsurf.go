@@ -0,0 +1,32 @@
+package configure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WindsurfConfigurer handles Windsurf MCP configuration
+type WindsurfConfigurer struct{}
+
+func (w *WindsurfConfigurer) GetConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the home directory: %w", err)
+	}
+	return filepath.Join(home, ".codeium", "windsurf", "mcp_config.json"), nil
+}
+
+func (w *WindsurfConfigurer) CreateConfig(name string, url string, configPath string) (any, error) {
+	return mergeServerEntry(configPath, "mcpServers", name, map[string]any{
+		"serverUrl": url,
+	})
+}
+
+func (w *WindsurfConfigurer) RemoveConfig(name string, configPath string) (any, bool, error) {
+	return removeServerEntry(configPath, "mcpServers", name)
+}
+
+func (w *WindsurfConfigurer) GetClientName() string {
+	return "Windsurf Editor"
+}
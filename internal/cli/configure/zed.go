@@ -0,0 +1,34 @@
+package configure
+
+import (
+	"path/filepath"
+	"runtime"
+)
+
+// ZedConfigurer handles Zed context server configuration
+type ZedConfigurer struct{}
+
+func (z *ZedConfigurer) GetConfigPath() (string, error) {
+	dir, err := xdgConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(dir, "Zed", "settings.json"), nil
+	}
+	return filepath.Join(dir, "zed", "settings.json"), nil
+}
+
+func (z *ZedConfigurer) CreateConfig(name string, url string, configPath string) (any, error) {
+	return mergeServerEntry(configPath, "context_servers", name, map[string]any{
+		"url": url,
+	})
+}
+
+func (z *ZedConfigurer) RemoveConfig(name string, configPath string) (any, bool, error) {
+	return removeServerEntry(configPath, "context_servers", name)
+}
+
+func (z *ZedConfigurer) GetClientName() string {
+	return "Zed Editor"
+}
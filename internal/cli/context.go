@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/cliconfig"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contextAddURL   string
+	contextAddToken string
+	contextAddUse   bool
+)
+
+var ContextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Switch between registries",
+	Long: `A context names a registry, such as the local daemon, a team staging registry or production. Every
+list, show, publish and deploy command talks to the registry of the current context; use the global --context
+flag to pick another one for a single command.
+
+Credentials follow the context: 'arctl login' stores a token per registry URL, and a context can also carry its
+own API token. --registry-url and ARCTL_API_BASE_URL still take precedence over the current context.`,
+	Example: `  arctl context add staging --registry-url https://registry.staging.example.com/v0
+  arctl context use staging
+  arctl mcp list --context prod
+  arctl context list`,
+}
+
+var contextListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the configured contexts",
+	Args:    cobra.NoArgs,
+	RunE:    runContextList,
+}
+
+var contextUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Select the context later commands use",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigUseContext,
+}
+
+var contextCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Print the current context",
+	Args:  cobra.NoArgs,
+	RunE:  runContextCurrent,
+}
+
+var contextAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or replace a context",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runContextAdd,
+}
+
+var contextDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a context",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runContextDelete,
+}
+
+func init() {
+	contextAddCmd.Flags().StringVar(&contextAddURL, "registry-url", "", "Registry API URL of the context (e.g. https://registry.example.com/v0)")
+	contextAddCmd.Flags().StringVar(&contextAddToken, "token", "", "API token for the registry (optional; 'arctl login' also works per registry)")
+	contextAddCmd.Flags().BoolVar(&contextAddUse, "use", false, "Switch to the context after adding it")
+	_ = contextAddCmd.MarkFlagRequired("registry-url")
+
+	contextNames := func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		cfg, _, err := loadConfig()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return cfg.ContextNames(), cobra.ShellCompDirectiveNoFileComp
+	}
+	contextUseCmd.ValidArgsFunction = contextNames
+	contextDeleteCmd.ValidArgsFunction = contextNames
+
+	ContextCmd.AddCommand(contextListCmd, contextUseCmd, contextCurrentCmd, contextAddCmd, contextDeleteCmd)
+}
+
+// contextListEntry is the structured output of arctl context list
+type contextListEntry struct {
+	Name        string `json:"name"`
+	RegistryURL string `json:"registryUrl"`
+	Current     bool   `json:"current"`
+	Token       bool   `json:"hasToken"`
+}
+
+func runContextList(cmd *cobra.Command, args []string) error {
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]contextListEntry, 0, len(cfg.Contexts))
+	for _, name := range cfg.ContextNames() {
+		ctx := cfg.Contexts[name]
+		entries = append(entries, contextListEntry{
+			Name:        name,
+			RegistryURL: ctx.RegistryURL,
+			Current:     name == cfg.CurrentContext,
+			Token:       ctx.Token != "",
+		})
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Println("No contexts configured. Add one with 'arctl context add <name> --registry-url <url>'.")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Current", "Name", "Registry", "Token")
+	for _, e := range entries {
+		current, token := "", "-"
+		if e.Current {
+			current = "*"
+		}
+		if e.Token {
+			token = "set"
+		}
+		t.AddRow(current, e.Name, e.RegistryURL, token)
+	}
+	return t.Render()
+}
+
+func runContextCurrent(cmd *cobra.Command, args []string) error {
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.CurrentContext == "" {
+		return fmt.Errorf("no context selected; commands use %s", printer.EmptyValueOrDefault(cfg.RegistryURL, "the local registry"))
+	}
+	fmt.Println(cfg.CurrentContext)
+	return nil
+}
+
+func runContextAdd(cmd *cobra.Command, args []string) error {
+	if _, err := updateConfig(func(cfg *cliconfig.Config) error {
+		if err := cfg.SetContext(args[0], &cliconfig.Context{RegistryURL: contextAddURL, Token: contextAddToken}); err != nil {
+			return err
+		}
+		if contextAddUse {
+			cfg.CurrentContext = args[0]
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Added context %s (%s)", args[0], contextAddURL)
+	if contextAddUse {
+		msg += " and switched to it"
+	}
+	printer.PrintSuccess(msg)
+	return nil
+}
+
+func runContextDelete(cmd *cobra.Command, args []string) error {
+	if _, err := updateConfig(func(cfg *cliconfig.Config) error {
+		return cfg.DeleteContext(args[0])
+	}); err != nil {
+		return err
+	}
+	printer.PrintSuccess(fmt.Sprintf("Deleted context %s", args[0]))
+	return nil
+}
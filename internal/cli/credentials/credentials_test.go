@@ -0,0 +1,149 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "nested", "credentials.json"))
+
+	cred, err := store.Get("http://localhost:12121/v0")
+	require.NoError(t, err)
+	assert.Nil(t, cred)
+
+	require.NoError(t, store.Put("http://localhost:12121/v0/", &Credential{Method: MethodGitHub, RegistryToken: "jwt"}))
+
+	info, err := os.Stat(store.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	cred, err = store.Get("http://localhost:12121/v0")
+	require.NoError(t, err)
+	require.NotNil(t, cred)
+	assert.Equal(t, "jwt", cred.RegistryToken)
+
+	removed, err := store.Delete("http://localhost:12121/v0")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = store.Delete("http://localhost:12121/v0")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
+
+type fakeExchanger struct {
+	githubToken string
+	idToken     string
+	err         error
+}
+
+func (f *fakeExchanger) ExchangeGitHubToken(token string) (*auth.TokenResponse, error) {
+	f.githubToken = token
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &auth.TokenResponse{RegistryToken: "new-jwt", ExpiresAt: int(time.Now().Add(5 * time.Minute).Unix())}, nil
+}
+
+func (f *fakeExchanger) ExchangeOIDCToken(token string) (*auth.TokenResponse, error) {
+	f.idToken = token
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &auth.TokenResponse{RegistryToken: "new-jwt", ExpiresAt: int(time.Now().Add(5 * time.Minute).Unix())}, nil
+}
+
+func TestStoreTokenRefreshesExpired(t *testing.T) {
+	const registry = "https://registry.example.com/v0"
+	store := NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	ex := &fakeExchanger{}
+
+	// Valid token is returned as-is
+	require.NoError(t, store.Put(registry, &Credential{
+		Method: MethodGitHub, RegistryToken: "jwt", ExpiresAt: time.Now().Add(time.Hour), AccessToken: "gho_x",
+	}))
+	token, err := store.Token(context.Background(), registry, ex)
+	require.NoError(t, err)
+	assert.Equal(t, "jwt", token)
+	assert.Empty(t, ex.githubToken)
+
+	// Expired token is re-exchanged and persisted
+	require.NoError(t, store.Put(registry, &Credential{
+		Method: MethodGitHub, RegistryToken: "jwt", ExpiresAt: time.Now().Add(-time.Minute), AccessToken: "gho_x",
+	}))
+	token, err = store.Token(context.Background(), registry, ex)
+	require.NoError(t, err)
+	assert.Equal(t, "new-jwt", token)
+	assert.Equal(t, "gho_x", ex.githubToken)
+
+	cred, err := store.Get(registry)
+	require.NoError(t, err)
+	assert.Equal(t, "new-jwt", cred.RegistryToken)
+
+	// Not logged in
+	token, err = store.Token(context.Background(), "https://other.example.com/v0", ex)
+	require.NoError(t, err)
+	assert.Empty(t, token)
+}
+
+func TestRefreshOIDCUsesRefreshToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.Form.Get("grant_type"))
+		assert.Equal(t, "rt-1", r.Form.Get("refresh_token"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"at","id_token":"idt","refresh_token":"rt-2"}`))
+	}))
+	defer srv.Close()
+
+	ex := &fakeExchanger{}
+	cred := &Credential{Method: MethodOIDC, ClientID: "cli", TokenEndpoint: srv.URL, RefreshToken: "rt-1"}
+	require.NoError(t, Refresh(context.Background(), ex, cred))
+	assert.Equal(t, "idt", ex.idToken)
+	assert.Equal(t, "rt-2", cred.RefreshToken)
+	assert.Equal(t, "new-jwt", cred.RegistryToken)
+
+	ex.err = errors.New("boom")
+	assert.Error(t, Refresh(context.Background(), ex, cred))
+}
+
+func TestPollDeviceToken(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"gho_abc"}`))
+	}))
+	defer srv.Close()
+
+	tok, err := PollDeviceToken(context.Background(), srv.URL, "cli", &DeviceCode{DeviceCode: "dc", Interval: 1, ExpiresIn: 30})
+	require.NoError(t, err)
+	assert.Equal(t, "gho_abc", tok.AccessToken)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestPollDeviceTokenDenied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"error":"access_denied"}`))
+	}))
+	defer srv.Close()
+
+	_, err := PollDeviceToken(context.Background(), srv.URL, "cli", &DeviceCode{DeviceCode: "dc", Interval: 1, ExpiresIn: 30})
+	assert.ErrorContains(t, err, "denied")
+}
@@ -0,0 +1,197 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GitHub OAuth device flow endpoints
+const (
+	GitHubDeviceCodeURL = "https://github.com/login/device/code"
+	GitHubTokenURL      = "https://github.com/login/oauth/access_token"
+)
+
+// GitHubScopes are the scopes the registry needs to resolve the user's namespaces (user and org membership)
+var GitHubScopes = []string{"read:org", "read:user"}
+
+// OIDCScopes are requested from OIDC providers; offline_access yields a refresh token for auto-refresh
+var OIDCScopes = []string{"openid", "profile", "email", "offline_access"}
+
+// DeviceCode is the response to an RFC 8628 device authorization request
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// OAuthToken is an OAuth token endpoint response
+type OAuthToken struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	IDToken          string `json:"id_token,omitempty"`
+	ExpiresIn        int    `json:"expires_in,omitempty"`
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// OIDCEndpoints are the endpoints discovered from an OIDC issuer
+type OIDCEndpoints struct {
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// DiscoverOIDC reads the issuer's OpenID configuration
+func DiscoverOIDC(ctx context.Context, issuer string) (*OIDCEndpoints, error) {
+	wellKnown := strings.TrimRight(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenID configuration: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch OpenID configuration: %s", resp.Status)
+	}
+
+	var endpoints OIDCEndpoints
+	if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenID configuration: %w", err)
+	}
+	if endpoints.DeviceAuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("OIDC provider %s does not support the device authorization flow", issuer)
+	}
+	if endpoints.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC provider %s does not advertise a token endpoint", issuer)
+	}
+	return &endpoints, nil
+}
+
+// RequestDeviceCode starts a device authorization flow
+func RequestDeviceCode(ctx context.Context, endpoint, clientID string, scopes []string) (*DeviceCode, error) {
+	form := url.Values{"client_id": {clientID}}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	var dc DeviceCode
+	if err := postForm(ctx, endpoint, form, &dc); err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+	if dc.DeviceCode == "" || dc.UserCode == "" {
+		return nil, fmt.Errorf("failed to request device code: incomplete response")
+	}
+	return &dc, nil
+}
+
+// PollDeviceToken polls the token endpoint until the user approves or denies the device code, or it expires
+func PollDeviceToken(ctx context.Context, tokenEndpoint, clientID string, dc *DeviceCode) (*OAuthToken, error) {
+	interval := time.Duration(max(dc.Interval, 1)) * time.Second
+	deadline := time.Now().Add(time.Duration(dc.ExpiresIn) * time.Second)
+	form := url.Values{
+		"client_id":   {clientID},
+		"device_code": {dc.DeviceCode},
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+	}
+
+	for {
+		if dc.ExpiresIn > 0 && time.Now().After(deadline) {
+			return nil, fmt.Errorf("device code expired before login was completed")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var tok OAuthToken
+		if err := postForm(ctx, tokenEndpoint, form, &tok); err != nil && tok.Error == "" {
+			return nil, err
+		}
+		switch tok.Error {
+		case "":
+			if tok.AccessToken == "" && tok.IDToken == "" {
+				return nil, fmt.Errorf("token response did not include a token")
+			}
+			return &tok, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return nil, fmt.Errorf("login was denied")
+		case "expired_token":
+			return nil, fmt.Errorf("device code expired before login was completed")
+		default:
+			return nil, tokenError(&tok)
+		}
+	}
+}
+
+// RefreshOAuthToken exchanges a refresh token for a new token set
+func RefreshOAuthToken(ctx context.Context, tokenEndpoint, clientID, refreshToken string) (*OAuthToken, error) {
+	form := url.Values{
+		"client_id":     {clientID},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	}
+	var tok OAuthToken
+	if err := postForm(ctx, tokenEndpoint, form, &tok); err != nil && tok.Error == "" {
+		return nil, err
+	}
+	if tok.Error != "" {
+		return nil, tokenError(&tok)
+	}
+	return &tok, nil
+}
+
+func tokenError(tok *OAuthToken) error {
+	if tok.ErrorDescription != "" {
+		return fmt.Errorf("%s: %s", tok.Error, tok.ErrorDescription)
+	}
+	return errors.New(tok.Error)
+}
+
+// postForm posts an OAuth form and decodes the JSON response into out. OAuth error responses
+// (400 with an error body) are decoded as well, so callers can inspect the error code.
+func postForm(ctx context.Context, endpoint string, form url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// GitHub returns form-encoded responses unless JSON is requested explicitly
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	decodeErr := json.Unmarshal(body, out)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("failed to parse response: %w", decodeErr)
+	}
+	return nil
+}
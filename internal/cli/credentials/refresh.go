@@ -0,0 +1,97 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// refreshLeeway renews registry tokens shortly before they expire so in-flight commands don't fail mid-way
+const refreshLeeway = time.Minute
+
+// Exchanger mints registry tokens from upstream credentials; implemented by the registry API client
+type Exchanger interface {
+	ExchangeGitHubToken(githubToken string) (*auth.TokenResponse, error)
+	ExchangeOIDCToken(idToken string) (*auth.TokenResponse, error)
+}
+
+// SetRegistryToken records a freshly minted registry token on the credential
+func (c *Credential) SetRegistryToken(resp *auth.TokenResponse) {
+	c.RegistryToken = resp.RegistryToken
+	c.ExpiresAt = time.Unix(int64(resp.ExpiresAt), 0)
+}
+
+// Refresh mints a new registry token for cred from its stored upstream OAuth state
+func Refresh(ctx context.Context, ex Exchanger, cred *Credential) error {
+	switch cred.Method {
+	case MethodGitHub:
+		// GitHub OAuth app tokens don't expire, so re-exchanging is enough; expiring
+		// GitHub App user tokens come with a refresh token and are renewed on failure.
+		resp, err := ex.ExchangeGitHubToken(cred.AccessToken)
+		if err != nil && cred.RefreshToken != "" {
+			var tok *OAuthToken
+			if tok, err = refreshUpstream(ctx, cred); err == nil {
+				cred.AccessToken = tok.AccessToken
+				resp, err = ex.ExchangeGitHubToken(cred.AccessToken)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to refresh GitHub login: %w", err)
+		}
+		cred.SetRegistryToken(resp)
+		return nil
+	case MethodOIDC:
+		if cred.RefreshToken == "" {
+			return fmt.Errorf("OIDC login has no refresh token")
+		}
+		tok, err := refreshUpstream(ctx, cred)
+		if err != nil {
+			return fmt.Errorf("failed to refresh OIDC login: %w", err)
+		}
+		if tok.IDToken == "" {
+			return fmt.Errorf("failed to refresh OIDC login: provider did not return an ID token")
+		}
+		resp, err := ex.ExchangeOIDCToken(tok.IDToken)
+		if err != nil {
+			return fmt.Errorf("failed to refresh OIDC login: %w", err)
+		}
+		cred.SetRegistryToken(resp)
+		return nil
+	default:
+		return fmt.Errorf("unknown login method %q", cred.Method)
+	}
+}
+
+func refreshUpstream(ctx context.Context, cred *Credential) (*OAuthToken, error) {
+	tok, err := RefreshOAuthToken(ctx, cred.TokenEndpoint, cred.ClientID, cred.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	// Providers that rotate refresh tokens invalidate the old one
+	if tok.RefreshToken != "" {
+		cred.RefreshToken = tok.RefreshToken
+	}
+	return tok, nil
+}
+
+// Token returns a valid registry token for registryURL, refreshing and persisting it when it is about to expire.
+// It returns an empty token when the user has not logged in to the registry.
+func (s *Store) Token(ctx context.Context, registryURL string, ex Exchanger) (string, error) {
+	cred, err := s.Get(registryURL)
+	if err != nil || cred == nil {
+		return "", err
+	}
+	if !cred.Expired(time.Now(), refreshLeeway) {
+		return cred.RegistryToken, nil
+	}
+
+	if err := Refresh(ctx, ex, cred); err != nil {
+		return "", err
+	}
+	if err := s.Put(registryURL, cred); err != nil {
+		return "", err
+	}
+	return cred.RegistryToken, nil
+}
@@ -0,0 +1,156 @@
+// Package credentials stores registry credentials obtained by `arctl login` and keeps them fresh.
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/statefile"
+)
+
+// Login methods supported by `arctl login`
+const (
+	MethodGitHub = "github"
+	MethodOIDC   = "oidc"
+)
+
+// Credential is the stored login state for a single registry
+type Credential struct {
+	Method        string    `json:"method"`
+	Subject       string    `json:"subject,omitempty"`
+	RegistryToken string    `json:"registryToken"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+
+	// Upstream OAuth state used to mint a new registry token once the current one expires
+	ClientID      string `json:"clientId,omitempty"`
+	TokenEndpoint string `json:"tokenEndpoint,omitempty"`
+	AccessToken   string `json:"accessToken,omitempty"`
+	RefreshToken  string `json:"refreshToken,omitempty"`
+}
+
+// Expired reports whether the registry token expires within leeway of now
+func (c *Credential) Expired(now time.Time, leeway time.Duration) bool {
+	return c.ExpiresAt.IsZero() || !now.Add(leeway).Before(c.ExpiresAt)
+}
+
+// File is the on-disk credentials file, keyed by registry URL
+type File struct {
+	Registries map[string]*Credential `json:"registries"`
+}
+
+// Store reads and writes the credentials file
+type Store struct {
+	path string
+}
+
+// NewStore returns a store backed by the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultStore returns the store at ~/.arctl/credentials.json
+func DefaultStore() (*Store, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return NewStore(filepath.Join(home, ".arctl", "credentials.json")), nil
+}
+
+// Path returns the location of the credentials file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the credentials file. A missing file yields an empty set of credentials.
+func (s *Store) Load() (*File, error) {
+	f := &File{Registries: map[string]*Credential{}}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %s: %w", s.path, err)
+	}
+	if f.Registries == nil {
+		f.Registries = map[string]*Credential{}
+	}
+	return f, nil
+}
+
+// Save writes the credentials file, readable only by the current user
+func (s *Store) Save(f *File) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+	if err := statefile.WriteAtomic(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	return nil
+}
+
+// Get returns the credential for registryURL, or nil if not logged in
+func (s *Store) Get(registryURL string) (*Credential, error) {
+	f, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return f.Registries[registryKey(registryURL)], nil
+}
+
+// Put stores the credential for registryURL
+func (s *Store) Put(registryURL string, cred *Credential) error {
+	unlock, err := statefile.Lock(s.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := s.Load()
+	if err != nil {
+		return err
+	}
+	f.Registries[registryKey(registryURL)] = cred
+	return s.Save(f)
+}
+
+// Delete removes the credential for registryURL. It reports whether a credential was removed.
+func (s *Store) Delete(registryURL string) (bool, error) {
+	unlock, err := statefile.Lock(s.path)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	f, err := s.Load()
+	if err != nil {
+		return false, err
+	}
+	key := registryKey(registryURL)
+	if _, ok := f.Registries[key]; !ok {
+		return false, nil
+	}
+	delete(f.Registries, key)
+	return true, s.Save(f)
+}
+
+// DeleteAll removes the credentials file
+func (s *Store) DeleteAll() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove credentials: %w", err)
+	}
+	return nil
+}
+
+func registryKey(registryURL string) string {
+	return strings.TrimRight(strings.TrimSpace(registryURL), "/")
+}
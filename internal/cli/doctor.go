@@ -0,0 +1,455 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/jackc/pgx/v5"
+	"github.com/spf13/cobra"
+)
+
+// Doctor check statuses
+const (
+	doctorPass = "pass"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+)
+
+// doctorPorts are the ports the registry uses: the server's default listen address, the daemon's API port
+// and the agent gateway
+var doctorPorts = []struct {
+	port  int
+	usage string
+}{
+	{8080, "registry server (AGENT_REGISTRY_SERVER_ADDRESS)"},
+	{12121, "registry API (daemon)"},
+	{21212, "agent gateway"},
+}
+
+var (
+	doctorBaseURL string
+	doctorToken   string
+)
+
+// doctorTimeout bounds each external command and connection attempt
+const doctorTimeout = 5 * time.Second
+
+// doctorResult is the outcome of one diagnostic check
+type doctorResult struct {
+	Check  string `json:"check"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the local agentregistry environment",
+	Long: `Checks the tools and services arctl depends on: docker and docker compose, the docker daemon,
+the registry API and its readiness (database, migrations and runtime), the database, port conflicts,
+stale runtime directories and dangling containers.
+
+Doctor does not start the registry daemon. It exits with an error when any check fails.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+// SetDoctorTarget sets the registry that doctor checks. The root command calls it instead of creating
+// an API client, since creating one fails when the registry is unreachable.
+func SetDoctorTarget(baseURL, token string) {
+	doctorBaseURL = baseURL
+	doctorToken = token
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	var results []doctorResult
+	dockerOK := true
+	for _, check := range []func(context.Context) doctorResult{checkDocker, checkDockerCompose, checkDockerDaemon} {
+		r := check(ctx)
+		results = append(results, r)
+		if r.Status == doctorFail {
+			dockerOK = false
+		}
+	}
+
+	api := checkRegistryAPI()
+	results = append(results, api)
+	if api.Status == doctorPass {
+		results = append(results, checkRegistryReadiness())
+	}
+	if r, ok := checkDatabase(ctx); ok {
+		results = append(results, r)
+	}
+	results = append(results, checkPorts(ctx, dockerOK)...)
+	results = append(results, checkRuntimeDirs(ctx, dockerOK))
+	if dockerOK {
+		results = append(results, checkDanglingContainers(ctx))
+	}
+
+	if err := printDoctorResults(results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Status == doctorFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+func checkDocker(context.Context) doctorResult {
+	engine := utils.ContainerEngine()
+	r := doctorResult{Check: engine}
+	path, err := exec.LookPath(engine)
+	if err != nil {
+		r.Status, r.Detail = doctorFail, engine+" CLI not found in PATH"
+		r.Hint = "Install Docker: https://docs.docker.com/get-docker/"
+		if engine == utils.ContainerEnginePodman {
+			r.Hint = "Install Podman: https://podman.io/docs/installation"
+		}
+		return r
+	}
+	r.Status, r.Detail = doctorPass, path
+	return r
+}
+
+func checkDockerCompose(ctx context.Context) doctorResult {
+	compose := utils.ComposeCommand()
+	r := doctorResult{Check: strings.Join(compose, " ")}
+	out, err := runDoctorCommand(ctx, compose[0], append(compose[1:], "version", "--short")...)
+	if err != nil {
+		r.Status, r.Detail = doctorFail, r.Check+" is not available"
+		r.Hint = "Install the compose plugin: https://docs.docker.com/compose/install/"
+		return r
+	}
+	r.Status, r.Detail = doctorPass, "v"+strings.TrimPrefix(strings.TrimSpace(out), "v")
+	return r
+}
+
+func checkDockerDaemon(ctx context.Context) doctorResult {
+	if utils.ContainerEngine() == utils.ContainerEnginePodman {
+		r := doctorResult{Check: "podman service"}
+		out, err := runDoctorCommand(ctx, utils.ContainerEnginePodman, "info", "--format", "{{.Version.Version}}")
+		if err != nil {
+			r.Status, r.Detail = doctorFail, "cannot connect to podman"
+			r.Hint = "Start the podman machine ('podman machine start'), or the podman socket ('systemctl --user start podman.socket') for rootless podman"
+			return r
+		}
+		r.Status, r.Detail = doctorPass, "server "+strings.TrimSpace(out)
+		return r
+	}
+
+	r := doctorResult{Check: "docker daemon"}
+	out, err := runDoctorCommand(ctx, "docker", "info", "--format", "{{.ServerVersion}}")
+	if err != nil {
+		r.Status, r.Detail = doctorFail, "cannot connect to the docker daemon"
+		r.Hint = "Start Docker Desktop or the docker service, and check that your user can access the docker socket"
+		return r
+	}
+	r.Status, r.Detail = doctorPass, "server "+strings.TrimSpace(out)
+	return r
+}
+
+func checkRegistryAPI() doctorResult {
+	r := doctorResult{Check: "registry API"}
+	base := doctorBaseURL
+	if base == "" {
+		base = client.DefaultBaseURL
+	}
+	c := client.NewClient(base, doctorToken)
+	if err := c.Ping(); err != nil {
+		r.Status, r.Detail = doctorFail, fmt.Sprintf("%s is not reachable: %v", c.BaseURL, err)
+		if isLocalURL(c.BaseURL) {
+			r.Hint = "Run any arctl command (e.g. arctl mcp list) to start the registry daemon, or check 'docker logs agentregistry-server'"
+		} else {
+			r.Hint = "Check --registry-url / ARCTL_API_BASE_URL and your network connection"
+		}
+		return r
+	}
+	r.Status, r.Detail = doctorPass, c.BaseURL
+	if v, err := c.GetVersion(); err == nil {
+		r.Detail += " (server " + v.Version + ")"
+	}
+	return r
+}
+
+// checkRegistryReadiness reports the components the registry's readiness probe found failing. The runtime is only
+// checked for a local registry, whose container engine is the one deployments run on.
+func checkRegistryReadiness() doctorResult {
+	r := doctorResult{Check: "registry readiness"}
+	base := doctorBaseURL
+	if base == "" {
+		base = client.DefaultBaseURL
+	}
+	c := client.NewClient(base, doctorToken)
+	readiness, err := c.GetReadiness(isLocalURL(c.BaseURL))
+	if err != nil {
+		r.Status, r.Detail = doctorWarn, fmt.Sprintf("readiness is not available: %v", err)
+		r.Hint = "The registry may be older than this arctl; upgrade it to get dependency checks"
+		return r
+	}
+
+	var failed, passed []string
+	for _, component := range readiness.Components {
+		if component.Status == models.ReadinessStatusOK {
+			passed = append(passed, component.Name)
+			continue
+		}
+		failed = append(failed, component.Name+": "+component.Message)
+	}
+	if len(failed) > 0 {
+		r.Status, r.Detail = doctorFail, strings.Join(failed, "; ")
+		r.Hint = "Check the registry server logs ('docker logs agentregistry-server' for the local registry)"
+		return r
+	}
+	r.Status, r.Detail = doctorPass, strings.Join(passed, ", ")+" ok"
+	return r
+}
+
+// checkDatabase connects to AGENT_REGISTRY_DATABASE_URL. It only runs when that variable is set or the
+// registry is local, since the database of a remote registry is not reachable from here.
+func checkDatabase(ctx context.Context) (doctorResult, bool) {
+	base := doctorBaseURL
+	if base == "" {
+		base = client.DefaultBaseURL
+	}
+	if os.Getenv("AGENT_REGISTRY_DATABASE_URL") == "" && !isLocalURL(base) {
+		return doctorResult{}, false
+	}
+
+	cfg := config.NewConfig()
+	r := doctorResult{Check: "database"}
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+	conn, err := pgx.Connect(ctx, cfg.DatabaseURL)
+	if err == nil {
+		err = conn.Ping(ctx)
+		_ = conn.Close(context.Background())
+	}
+	if err != nil {
+		r.Status, r.Detail = doctorFail, fmt.Sprintf("cannot connect to %s: %v", redactDatabaseURL(cfg.DatabaseURL), err)
+		r.Hint = "Check that postgres is running ('docker ps --filter name=agent-registry-postgres') and AGENT_REGISTRY_DATABASE_URL is correct"
+		return r, true
+	}
+	r.Status, r.Detail = doctorPass, redactDatabaseURL(cfg.DatabaseURL)
+	return r, true
+}
+
+// checkPorts reports ports that are taken by something other than agentregistry
+func checkPorts(ctx context.Context, dockerOK bool) []doctorResult {
+	var results []doctorResult
+	for _, p := range doctorPorts {
+		r := doctorResult{Check: fmt.Sprintf("port %d", p.port)}
+		if portFree(p.port) {
+			r.Status, r.Detail = doctorPass, "free ("+p.usage+")"
+			results = append(results, r)
+			continue
+		}
+
+		owner := ""
+		if dockerOK {
+			out, err := runDoctorCommand(ctx, utils.ContainerEngine(), "ps", "--filter", "publish="+strconv.Itoa(p.port), "--format", "{{.Names}}")
+			if err == nil {
+				owner = strings.TrimSpace(strings.SplitN(out, "\n", 2)[0])
+			}
+		}
+		switch {
+		case isAgentRegistryContainer(owner):
+			r.Status, r.Detail = doctorPass, "in use by "+owner
+		case owner != "":
+			r.Status, r.Detail = doctorWarn, "in use by container "+owner
+			r.Hint = fmt.Sprintf("Stop the container ('docker stop %s') if the %s needs this port", owner, p.usage)
+		default:
+			r.Status, r.Detail = doctorWarn, "in use by another process"
+			r.Hint = fmt.Sprintf("Find it with 'lsof -i :%d' and stop it if the %s needs this port", p.port, p.usage)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// checkRuntimeDirs looks for directories left behind by 'arctl mcp run' and 'arctl agent run'
+// whose compose project no longer exists
+func checkRuntimeDirs(ctx context.Context, dockerOK bool) doctorResult {
+	r := doctorResult{Check: "runtime directories"}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		r.Status, r.Detail = doctorWarn, fmt.Sprintf("cannot determine home directory: %v", err)
+		return r
+	}
+	base := filepath.Join(home, ".arctl", "runtime")
+	entries, err := os.ReadDir(base)
+	if errors.Is(err, os.ErrNotExist) {
+		r.Status, r.Detail = doctorPass, "none"
+		return r
+	}
+	if err != nil {
+		r.Status, r.Detail = doctorWarn, fmt.Sprintf("cannot read %s: %v", base, err)
+		return r
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() {
+			dirs = append(dirs, e.Name())
+		}
+	}
+	if !dockerOK {
+		r.Status, r.Detail = doctorPass, fmt.Sprintf("%d in %s (not checked without docker)", len(dirs), base)
+		return r
+	}
+
+	out, err := runDoctorCommand(ctx, utils.ContainerEngine(), "ps", "-a", "--format", `{{.Label "com.docker.compose.project"}}`)
+	if err != nil {
+		r.Status, r.Detail = doctorWarn, fmt.Sprintf("cannot list containers: %v", err)
+		return r
+	}
+	stale := staleRuntimeDirs(dirs, composeProjects(out))
+	if len(stale) == 0 {
+		r.Status, r.Detail = doctorPass, fmt.Sprintf("%d in use", len(dirs))
+		return r
+	}
+	r.Status, r.Detail = doctorWarn, fmt.Sprintf("%d stale in %s: %s", len(stale), base, strings.Join(stale, ", "))
+	r.Hint = fmt.Sprintf("Remove them with 'rm -rf %s/<name>'", base)
+	return r
+}
+
+// checkDanglingContainers reports stopped containers left by arctl runs and deployments
+func checkDanglingContainers(ctx context.Context) doctorResult {
+	r := doctorResult{Check: "dangling containers"}
+	out, err := runDoctorCommand(ctx, utils.ContainerEngine(), "ps", "-a",
+		"--filter", "status=exited", "--filter", "status=dead", "--filter", "status=created",
+		"--format", `{{.Names}}	{{.Label "com.docker.compose.project"}}`)
+	if err != nil {
+		r.Status, r.Detail = doctorWarn, fmt.Sprintf("cannot list containers: %v", err)
+		return r
+	}
+	dangling := danglingContainers(out)
+	if len(dangling) == 0 {
+		r.Status, r.Detail = doctorPass, "none"
+		return r
+	}
+	r.Status, r.Detail = doctorWarn, fmt.Sprintf("%d stopped: %s", len(dangling), strings.Join(dangling, ", "))
+	r.Hint = "Remove them with 'docker rm " + strings.Join(dangling, " ") + "'"
+	return r
+}
+
+// composeProjects parses one compose project label per line
+func composeProjects(out string) map[string]bool {
+	projects := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		if p := strings.TrimSpace(line); p != "" {
+			projects[p] = true
+		}
+	}
+	return projects
+}
+
+// staleRuntimeDirs returns the runtime directories whose compose project has no containers.
+// Run directories are named after their compose project.
+func staleRuntimeDirs(dirs []string, projects map[string]bool) []string {
+	var stale []string
+	for _, d := range dirs {
+		if !projects[d] {
+			stale = append(stale, d)
+		}
+	}
+	return stale
+}
+
+// danglingContainers picks the arctl containers from "name<TAB>compose project" lines
+func danglingContainers(out string) []string {
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		name, project, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		if name == "" {
+			continue
+		}
+		if strings.HasPrefix(project, "arctl-") || project == "agentregistry" || isAgentRegistryContainer(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isAgentRegistryContainer matches the daemon containers and those of the runtime and arctl run compose projects
+func isAgentRegistryContainer(name string) bool {
+	return strings.HasPrefix(name, "agentregistry") || strings.HasPrefix(name, "agent-registry") || strings.HasPrefix(name, "arctl-")
+}
+
+func portFree(port int) bool {
+	l, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
+
+func isLocalURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Hostname()) {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
+
+func runDoctorCommand(ctx context.Context, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	return string(out), err
+}
+
+func printDoctorResults(results []doctorResult) error {
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(results)
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Check", "Status", "Detail")
+	for _, r := range results {
+		t.AddRow(r.Check, strings.ToUpper(r.Status), r.Detail)
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+
+	var hints []doctorResult
+	for _, r := range results {
+		if r.Status != doctorPass && r.Hint != "" {
+			hints = append(hints, r)
+		}
+	}
+	if len(hints) > 0 {
+		fmt.Println("\nRemediation:")
+		for _, r := range hints {
+			fmt.Printf("  %s: %s\n", r.Check, r.Hint)
+		}
+	}
+	return nil
+}
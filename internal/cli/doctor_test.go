@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleRuntimeDirs(t *testing.T) {
+	projects := composeProjects("arctl-run-1111\n\nagentregistry\narctl-run-1111\n")
+	assert.Equal(t, map[string]bool{"arctl-run-1111": true, "agentregistry": true}, projects)
+
+	stale := staleRuntimeDirs([]string{"arctl-run-1111", "arctl-run-2222"}, projects)
+	assert.Equal(t, []string{"arctl-run-2222"}, stale)
+}
+
+func TestDanglingContainers(t *testing.T) {
+	out := "arctl-run-1111-agent_gateway-1\tarctl-run-1111\n" +
+		"agentregistry_runtime-my-server-1\tagentregistry_runtime\n" +
+		"agent-registry-postgres\tagentregistry\n" +
+		"unrelated-db\tother\n" +
+		"\n"
+	assert.Equal(t, []string{
+		"arctl-run-1111-agent_gateway-1",
+		"agentregistry_runtime-my-server-1",
+		"agent-registry-postgres",
+	}, danglingContainers(out))
+}
+
+func TestIsLocalURL(t *testing.T) {
+	assert.True(t, isLocalURL("http://localhost:12121/v0"))
+	assert.True(t, isLocalURL("http://127.0.0.1:8080"))
+	assert.False(t, isLocalURL("https://registry.example.com"))
+}
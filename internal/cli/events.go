@@ -0,0 +1,419 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/dockercompose"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+// Event categories accepted by --type
+const (
+	eventTypeDeployment = "deployment"
+	eventTypePublish    = "publish"
+	eventTypeChange     = "change"
+	eventTypeRuntime    = "runtime"
+)
+
+var eventTypes = []string{eventTypeDeployment, eventTypePublish, eventTypeChange, eventTypeRuntime}
+
+// runtimeContainerActions are the docker container actions worth surfacing; exec and attach noise is dropped
+var runtimeContainerActions = map[string]bool{
+	"create":        true,
+	"start":         true,
+	"restart":       true,
+	"stop":          true,
+	"kill":          true,
+	"die":           true,
+	"oom":           true,
+	"destroy":       true,
+	"health_status": true,
+}
+
+var (
+	eventsFollow   bool
+	eventsTypes    []string
+	eventsSince    string
+	eventsInterval time.Duration
+)
+
+// systemEvent is a single entry of the unified feed, sourced either from the registry audit log or the local runtime
+type systemEvent struct {
+	Time         time.Time `json:"time"`
+	Source       string    `json:"source"` // "registry" or "runtime"
+	Type         string    `json:"type"`
+	Action       string    `json:"action"`
+	ResourceType string    `json:"resourceType,omitempty"`
+	Resource     string    `json:"resource"`
+	Version      string    `json:"version,omitempty"`
+	Actor        string    `json:"actor,omitempty"`
+}
+
+var EventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show a chronological feed of registry and runtime events",
+	Long: `Shows publishes, deployments and other registry changes together with container events from the
+local runtime in a single chronological feed. Use --follow to keep streaming new events.
+
+Registry events are read from the audit log and require registry admin permissions. Runtime events
+are read from the local docker daemon and are skipped when docker is not available.`,
+	Args: cobra.NoArgs,
+	RunE: runEvents,
+	Example: `  arctl events
+  arctl events --since 24h --type deployment,publish
+  arctl events --follow --type deployment,runtime`,
+}
+
+func init() {
+	EventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Keep streaming new events")
+	EventsCmd.Flags().StringSliceVarP(&eventsTypes, "type", "t", nil, "Only show these event types ("+strings.Join(eventTypes, ", ")+")")
+	EventsCmd.Flags().StringVar(&eventsSince, "since", "1h", "Show events at or after this time (RFC3339 or duration, e.g. 24h)")
+	EventsCmd.Flags().DurationVar(&eventsInterval, "interval", 2*time.Second, "How often to poll the registry for new events when following")
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	types := make(map[string]bool, len(eventsTypes))
+	for _, t := range eventsTypes {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if !slices.Contains(eventTypes, t) {
+			return fmt.Errorf("invalid event type %q (expected one of: %s)", t, strings.Join(eventTypes, ", "))
+		}
+		types[t] = true
+	}
+	wants := func(eventType string) bool {
+		return len(types) == 0 || types[eventType]
+	}
+
+	now := time.Now()
+	since, err := parseAuditTime(eventsSince, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	wantRegistry := wants(eventTypeDeployment) || wants(eventTypePublish) || wants(eventTypeChange)
+	wantRuntime := wants(eventTypeRuntime)
+
+	if !eventsFollow {
+		var events []systemEvent
+		var registryErr error
+		if wantRegistry {
+			var entries []*models.AuditLogEntry
+			entries, registryErr = apiClient.GetAuditLog(client.AuditLogQuery{Since: since, Until: now})
+			for _, e := range entries {
+				if ev := auditEvent(e); wants(ev.Type) {
+					events = append(events, ev)
+				}
+			}
+		}
+		if wantRuntime {
+			runtimeEvents, err := readRuntimeEvents(cmd.Context(), since, now)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping runtime events: %v\n", err)
+			}
+			events = append(events, runtimeEvents...)
+		}
+		if registryErr != nil {
+			if len(events) == 0 {
+				return fmt.Errorf("failed to get registry events: %w", registryErr)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: skipping registry events: %v\n", registryErr)
+		}
+
+		sortEvents(events)
+		return printEvents(events)
+	}
+
+	return followEvents(cmd.Context(), since, wantRegistry, wantRuntime, wants)
+}
+
+// followEvents prints events from since onwards as they happen until interrupted.
+// The registry has no push channel for audit entries, so it is polled; runtime events are streamed from docker.
+func followEvents(ctx context.Context, since time.Time, wantRegistry, wantRuntime bool, wants func(string) bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	out := make(chan systemEvent, 64)
+	if wantRuntime {
+		go func() {
+			if err := streamRuntimeEvents(ctx, since, out); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping runtime events: %v\n", err)
+			}
+		}()
+	}
+
+	var ticker <-chan time.Time
+	var lastID int64
+	if wantRegistry {
+		t := time.NewTicker(eventsInterval)
+		defer t.Stop()
+		ticker = t.C
+
+		// Print the backlog before streaming so the feed starts in order
+		entries, err := apiClient.GetAuditLog(client.AuditLogQuery{Since: since})
+		if err != nil {
+			return fmt.Errorf("failed to get registry events: %w", err)
+		}
+		lastID = printNewAuditEvents(entries, lastID, wants)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-out:
+			printEventLine(ev)
+		case <-ticker:
+			// Audit timestamps are second-granular, so re-query from the last second seen and dedupe by ID
+			entries, err := apiClient.GetAuditLog(client.AuditLogQuery{Since: time.Now().Add(-eventsInterval - time.Second)})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to poll registry events: %v\n", err)
+				continue
+			}
+			lastID = printNewAuditEvents(entries, lastID, wants)
+		}
+	}
+}
+
+// printNewAuditEvents prints audit entries newer than lastID in chronological order and returns the new high-water mark
+func printNewAuditEvents(entries []*models.AuditLogEntry, lastID int64, wants func(string) bool) int64 {
+	var events []systemEvent
+	maxID := lastID
+	for _, e := range entries {
+		if e.ID <= lastID {
+			continue
+		}
+		maxID = max(maxID, e.ID)
+		if ev := auditEvent(e); wants(ev.Type) {
+			events = append(events, ev)
+		}
+	}
+	sortEvents(events)
+	for _, ev := range events {
+		printEventLine(ev)
+	}
+	return maxID
+}
+
+// auditEvent converts an audit log entry into a feed event
+func auditEvent(e *models.AuditLogEntry) systemEvent {
+	return systemEvent{
+		Time:         e.OccurredAt,
+		Source:       "registry",
+		Type:         auditEventType(e.Action),
+		Action:       e.Action,
+		ResourceType: e.ResourceType,
+		Resource:     e.ResourceName,
+		Version:      e.Version,
+		Actor:        e.Actor,
+	}
+}
+
+// auditEventType maps an audit action onto the event category used by --type
+func auditEventType(action string) string {
+	switch action {
+	case models.AuditActionDeploy, models.AuditActionUndeploy, models.AuditActionConfigChange:
+		return eventTypeDeployment
+	case models.AuditActionPublish, models.AuditActionUnpublish:
+		return eventTypePublish
+	default:
+		return eventTypeChange
+	}
+}
+
+// dockerEvent is the subset of `docker events --format '{{json .}}'` output the feed uses
+type dockerEvent struct {
+	Type   string `json:"Type"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+	TimeNano int64 `json:"timeNano"`
+}
+
+// parseRuntimeEvent converts a line of docker events JSON output into a feed event.
+// ok is false for lines that are not container lifecycle events.
+func parseRuntimeEvent(line []byte) (ev systemEvent, ok bool, err error) {
+	var de dockerEvent
+	if err := json.Unmarshal(line, &de); err != nil {
+		return systemEvent{}, false, fmt.Errorf("failed to parse docker event: %w", err)
+	}
+	// health_status events carry the status in the action, e.g. "health_status: unhealthy"
+	action, detail, _ := strings.Cut(de.Action, ":")
+	if de.Type != "container" || !runtimeContainerActions[action] {
+		return systemEvent{}, false, nil
+	}
+	if detail = strings.TrimSpace(detail); detail != "" {
+		action = action + " (" + detail + ")"
+	}
+
+	resource := de.Actor.Attributes["com.docker.compose.service"]
+	if resource == "" {
+		resource = de.Actor.Attributes["name"]
+	}
+	return systemEvent{
+		Time:         time.Unix(0, de.TimeNano),
+		Source:       "runtime",
+		Type:         eventTypeRuntime,
+		Action:       action,
+		ResourceType: "container",
+		Resource:     resource,
+	}, true, nil
+}
+
+func dockerEventsArgs(since, until time.Time) []string {
+	args := []string{
+		"events",
+		"--filter", "type=container",
+		"--filter", "label=com.docker.compose.project=" + dockercompose.DefaultProjectName,
+		"--format", "{{json .}}",
+		"--since", strconv.FormatInt(since.Unix(), 10),
+	}
+	if !until.IsZero() {
+		args = append(args, "--until", strconv.FormatInt(until.Unix(), 10))
+	}
+	return args
+}
+
+// readRuntimeEvents returns the local runtime container events in [since, until]
+func readRuntimeEvents(ctx context.Context, since, until time.Time) ([]systemEvent, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, fmt.Errorf("docker command not found in PATH")
+	}
+
+	output, err := exec.CommandContext(ctx, "docker", dockerEventsArgs(since, until)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker events failed: %w", err)
+	}
+
+	var events []systemEvent
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		ev, ok, err := parseRuntimeEvent(scanner.Bytes())
+		if err != nil {
+			return events, err
+		}
+		if ok {
+			events = append(events, ev)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// streamRuntimeEvents sends local runtime container events to out until ctx is cancelled
+func streamRuntimeEvents(ctx context.Context, since time.Time, out chan<- systemEvent) error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return fmt.Errorf("docker command not found in PATH")
+	}
+
+	cmd := exec.CommandContext(ctx, "docker", dockerEventsArgs(since, time.Time{})...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("docker events failed: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		ev, ok, err := parseRuntimeEvent(scanner.Bytes())
+		if err != nil || !ok {
+			continue
+		}
+		select {
+		case out <- ev:
+		case <-ctx.Done():
+		}
+	}
+	return cmd.Wait()
+}
+
+func sortEvents(events []systemEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+}
+
+func printEvents(events []systemEvent) error {
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(events)
+	}
+
+	if len(events) == 0 {
+		fmt.Println("No events found")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Time", "Type", "Action", "Resource", "Version", "Actor")
+	for _, ev := range events {
+		t.AddRow(
+			printer.FormatTimestampShort(ev.Time),
+			ev.Type,
+			ev.Action,
+			printer.TruncateString(eventResource(ev), 60),
+			printer.EmptyValueOrDefault(ev.Version, "<none>"),
+			printer.EmptyValueOrDefault(printer.TruncateString(ev.Actor, 40), "<none>"),
+		)
+	}
+	return t.Render()
+}
+
+// printEventLine prints a single event while following; JSON output is newline-delimited and YAML output is a
+// stream of documents
+func printEventLine(ev systemEvent) {
+	if printer.Format() == printer.OutputTypeYAML {
+		fmt.Println("---")
+		_ = printer.PrintStructured(ev)
+		return
+	}
+	if printer.Format().IsStructured() {
+		b, err := json.Marshal(ev)
+		if err != nil {
+			return
+		}
+		fmt.Println(string(b))
+		return
+	}
+
+	line := fmt.Sprintf("%s  %-10s  %-12s  %s", printer.FormatTimestampShort(ev.Time), ev.Type, ev.Action, eventResource(ev))
+	if ev.Version != "" {
+		line += "@" + ev.Version
+	}
+	if ev.Actor != "" {
+		line += "  by " + ev.Actor
+	}
+	fmt.Println(line)
+}
+
+func eventResource(ev systemEvent) string {
+	if ev.ResourceType == "" {
+		return ev.Resource
+	}
+	return ev.ResourceType + "/" + ev.Resource
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEventType(t *testing.T) {
+	assert.Equal(t, eventTypeDeployment, auditEventType(models.AuditActionDeploy))
+	assert.Equal(t, eventTypeDeployment, auditEventType(models.AuditActionUndeploy))
+	assert.Equal(t, eventTypeDeployment, auditEventType(models.AuditActionConfigChange))
+	assert.Equal(t, eventTypePublish, auditEventType(models.AuditActionPublish))
+	assert.Equal(t, eventTypePublish, auditEventType(models.AuditActionUnpublish))
+	assert.Equal(t, eventTypeChange, auditEventType(models.AuditActionDelete))
+}
+
+func TestParseRuntimeEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantOK   bool
+		action   string
+		resource string
+	}{
+		{
+			name:     "container start uses compose service name",
+			line:     `{"Type":"container","Action":"start","Actor":{"ID":"abc","Attributes":{"name":"agentregistry_runtime-weather-1","com.docker.compose.service":"weather"}},"timeNano":1700000000000000000}`,
+			wantOK:   true,
+			action:   "start",
+			resource: "weather",
+		},
+		{
+			name:     "health status keeps the status",
+			line:     `{"Type":"container","Action":"health_status: unhealthy","Actor":{"ID":"abc","Attributes":{"name":"gw"}},"timeNano":1700000000000000000}`,
+			wantOK:   true,
+			action:   "health_status (unhealthy)",
+			resource: "gw",
+		},
+		{
+			name: "exec noise is dropped",
+			line: `{"Type":"container","Action":"exec_start: sh","Actor":{"ID":"abc"},"timeNano":1700000000000000000}`,
+		},
+		{
+			name: "non-container events are dropped",
+			line: `{"Type":"network","Action":"connect","Actor":{"ID":"abc"},"timeNano":1700000000000000000}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, ok, err := parseRuntimeEvent([]byte(tt.line))
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.action, ev.Action)
+			assert.Equal(t, tt.resource, ev.Resource)
+			assert.Equal(t, eventTypeRuntime, ev.Type)
+			assert.True(t, ev.Time.Equal(time.Unix(0, 1700000000000000000)))
+		})
+	}
+
+	_, _, err := parseRuntimeEvent([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestPrintNewAuditEventsDedupes(t *testing.T) {
+	now := time.Now()
+	entries := []*models.AuditLogEntry{
+		{ID: 3, OccurredAt: now, Action: models.AuditActionPublish, ResourceType: "mcp", ResourceName: "a"},
+		{ID: 2, OccurredAt: now.Add(-time.Second), Action: models.AuditActionDeploy, ResourceType: "mcp", ResourceName: "a"},
+	}
+	all := func(string) bool { return true }
+
+	assert.Equal(t, int64(3), printNewAuditEvents(entries, 0, all))
+	assert.Equal(t, int64(3), printNewAuditEvents(entries, 3, all))
+}
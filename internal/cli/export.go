@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/archive"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/exporter"
@@ -19,13 +21,17 @@ import (
 var (
 	exportOutput       string
 	exportReadmeOutput string
+	exportAll          bool
 )
 
 var ExportCmd = &cobra.Command{
 	Use:    "export",
 	Hidden: true,
 	Short:  "Export servers from the registry database",
-	Long:   "Exports all MCP server entries from the local registry database into a JSON seed file compatible with arctl import.",
+	Long: `Exports all MCP server entries from the local registry database into a JSON seed file compatible with arctl import.
+
+With --all, exports the entire registry (servers, READMEs, agents, skills, deployments and embeddings)
+into a single versioned tar.gz archive that arctl import --all restores into another registry.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		outputPath := strings.TrimSpace(exportOutput)
 		if outputPath == "" {
@@ -50,6 +56,9 @@ var ExportCmd = &cobra.Command{
 				log.Printf("Warning: failed to close database: %v", closeErr)
 			}
 		}()
+		if err := db.UseBlobStorage(cfg.BlobStorage, cfg.BlobInlineMaxBytes); err != nil {
+			return err
+		}
 
 		registryService := service.NewRegistryService(db, cfg, nil)
 		exporterService := exporter.NewService(registryService)
@@ -59,6 +68,10 @@ var ExportCmd = &cobra.Command{
 			exportCtx = context.Background()
 		}
 
+		if exportAll {
+			return exportArchive(exportCtx, db, outputPath, cfg.Version)
+		}
+
 		exporterService.SetReadmeOutputPath(exportReadmeOutput)
 
 		count, err := exporterService.ExportToPath(exportCtx, outputPath)
@@ -71,8 +84,33 @@ var ExportCmd = &cobra.Command{
 	},
 }
 
+func exportArchive(ctx context.Context, db *database.PostgreSQL, outputPath, registryVersion string) error {
+	if exportReadmeOutput != "" {
+		return errors.New("--readme-output cannot be combined with --all (READMEs are part of the archive)")
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+	manifest, err := archive.Export(ctx, db, f, registryVersion)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(outputPath)
+		return fmt.Errorf("failed to export registry: %w", err)
+	}
+
+	c := manifest.Counts
+	fmt.Printf("✓ Exported %d servers, %d READMEs, %d agents, %d skills, %d deployments and %d embeddings to %s\n",
+		c.Servers, c.Readmes, c.Agents, c.Skills, c.Deployments, c.Embeddings, outputPath)
+	return nil
+}
+
 func init() {
-	ExportCmd.Flags().StringVar(&exportOutput, "output", "", "Destination seed file path (required)")
+	ExportCmd.Flags().StringVar(&exportOutput, "output", "", "Destination seed file path, or archive path (.tar.gz) with --all (required)")
+	ExportCmd.Flags().BoolVar(&exportAll, "all", false, "Export the entire registry (servers, agents, skills, deployments and embeddings) as a tar.gz archive")
 	ExportCmd.Flags().StringVar(&exportReadmeOutput, "readme-output", "", "Optional README seed output path")
 	_ = ExportCmd.MarkFlagRequired("output")
 }
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportRuntimeFormat string
+	exportRuntimeOut    string
+)
+
+var exportRuntimeCmd = &cobra.Command{
+	Use:   "runtime",
+	Short: "Render the current deployments as a manifest bundle",
+	Long: `Renders all current deployments as a reviewable bundle of the files they are applied from, so the runtime
+can be versioned and managed through git instead of imperative deploy commands.
+
+Each deployment target gets its own directory in the bundle:
+  --format compose     docker targets: docker-compose.yaml, agent-gateway.yaml and the MCP server config of each agent
+  --format kustomize   kubernetes targets: one manifest per agent, MCP server and config map, a kustomization.yaml
+                       per target and one at the root, so the bundle can be applied with kubectl apply -k
+  --format helm        kubernetes targets: a Helm chart per target, with one template per object and a values.yaml
+                       keyed by object kind and name (agents, mcpServers, remoteMCPServers, configMaps)
+
+Secret references in deployment configs are exported unresolved, so the bundle is safe to commit. Existing
+files in the output directory are overwritten.`,
+	Example: `  arctl export runtime --format compose --out ./deploy/
+  arctl export runtime --format kustomize --out ./deploy/
+  arctl export runtime --format helm --out ./charts/ && helm install agents ./charts/kubernetes`,
+	RunE: runExportRuntime,
+}
+
+func init() {
+	exportRuntimeCmd.Flags().StringVar(&exportRuntimeFormat, "format", models.RuntimeExportFormatCompose, "Bundle format (compose, kustomize, helm)")
+	exportRuntimeCmd.Flags().StringVar(&exportRuntimeOut, "out", "./deploy", "Directory to write the bundle to")
+	ExportCmd.AddCommand(exportRuntimeCmd)
+}
+
+func runExportRuntime(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	export, err := apiClient.ExportRuntime(exportRuntimeFormat)
+	if err != nil {
+		return fmt.Errorf("failed to export deployments: %w", err)
+	}
+	if len(export.Files) == 0 {
+		fmt.Printf("No deployments to export in %s format\n", export.Format)
+		return nil
+	}
+
+	for _, file := range export.Files {
+		// Paths come from the registry, so make sure they stay inside the output directory
+		clean := path.Clean(file.Path)
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("refusing to write %q outside of %s", file.Path, exportRuntimeOut)
+		}
+		dest := filepath.Join(exportRuntimeOut, filepath.FromSlash(clean))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", dest, err)
+		}
+		if err := os.WriteFile(dest, []byte(file.Content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", dest, err)
+		}
+	}
+
+	fmt.Printf("✓ Exported %d files in %s format to %s\n", len(export.Files), export.Format, exportRuntimeOut)
+	return nil
+}
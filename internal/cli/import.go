@@ -6,20 +6,25 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/archive"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/embeddings"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/importer"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/vulnscan"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/spf13/cobra"
 )
 
 var (
 	importSource             string
+	importFrom               string
 	importSkipValidation     bool
 	importHeaders            []string
 	importTimeout            time.Duration
@@ -29,16 +34,53 @@ var (
 	importProgressCache      string
 	enrichServerData         bool
 	importGenerateEmbeddings bool
+	importImageScanner       string
+	importAll                bool
+	importCrawlState         string
+	importStrategy           string
+	importDryRun             bool
+	importRequireVerified    bool
+	importMinisignPublicKey  string
+	importConcurrency        int
 )
 
 var ImportCmd = &cobra.Command{
 	Use:    "import",
 	Hidden: true,
 	Short:  "Import servers into the registry database",
-	Long:   "Imports MCP server entries from a JSON seed file or a registry /v0/servers endpoint into the local registry database.",
+	Long: `Imports MCP server entries from a JSON seed file or a registry /v0/servers endpoint into the local registry database.
+
+With --from, searches a package registry instead and builds draft entries (name, description, version and package)
+from the packages found, e.g. --from npm:keyword=mcp-server or --from "pypi:classifier=Framework :: MCP".
+Add &limit=N to import more than the first 100 results. Imported entries stay unpublished until curated.
+
+With --from github-org:<org>, crawls the repositories of a GitHub organization for server.json and agent.yaml
+files and imports the servers and agents they describe. Pass --crawl-state to remember ETags between runs, so a
+scheduled re-crawl only downloads the repositories that changed.
+
+Entries whose name and version already exist are skipped. --strategy chooses another way to resolve such conflicts:
+overwrite replaces existing versions, newest-version-wins only imports versions newer than the latest one, and
+rename-with-suffix imports servers whose name is taken by a server from another repository as <name>-2, <name>-3...
+--dry-run logs what would be created, overwritten, renamed and skipped without writing anything.
+
+Seed files with a detached <file>.sha256 checksum or <file>.minisig signature next to them can be verified before
+they are applied: --require-verification rejects seed files without a valid checksum, and --minisign-public-key
+requires a valid signature made with that key.
+
+With --all, restores an archive written by arctl export --all (servers, READMEs, agents, skills,
+deployments and embeddings). Versions that already exist are skipped, so the import can be re-run.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if importFrom != "" {
+			if importSource != "" {
+				return errors.New("--source and --from cannot be used together")
+			}
+			if !strings.HasPrefix(importFrom, "npm:") && !strings.HasPrefix(importFrom, "pypi:") && !strings.HasPrefix(importFrom, "github-org:") {
+				return fmt.Errorf("--from must be an npm: or pypi: search or a github-org: organization, got %q", importFrom)
+			}
+			importSource = importFrom
+		}
 		if strings.TrimSpace(importSource) == "" {
-			return errors.New("--source is required (file path, HTTP URL, or /v0/servers endpoint)")
+			return errors.New("--source is required (file path, HTTP URL, or /v0/servers endpoint), or --from for a package registry search or GitHub organization")
 		}
 
 		// Load config and optionally override validation
@@ -64,6 +106,13 @@ var ImportCmd = &cobra.Command{
 				log.Printf("Warning: failed to close database: %v", closeErr)
 			}
 		}()
+		if err := db.UseBlobStorage(cfg.BlobStorage, cfg.BlobInlineMaxBytes); err != nil {
+			return err
+		}
+
+		if importAll {
+			return importArchive(context.Background(), db, importSource)
+		}
 
 		registryService := service.NewRegistryService(db, cfg, nil)
 
@@ -88,9 +137,34 @@ var ImportCmd = &cobra.Command{
 		importerService.SetHTTPClient(httpClient)
 		importerService.SetRequestHeaders(headerMap)
 		importerService.SetUpdateIfExists(importUpdate)
+		if cmd.Flags().Changed("strategy") {
+			strategy, err := importer.ParseConflictStrategy(importStrategy)
+			if err != nil {
+				return err
+			}
+			if importUpdate && strategy != importer.ConflictOverwrite {
+				return fmt.Errorf("--update cannot be combined with --strategy %s", strategy)
+			}
+			importerService.SetConflictStrategy(strategy)
+		}
+		importerService.SetDryRun(importDryRun)
+		importerService.SetConcurrency(importConcurrency)
+		verifier, err := seed.NewVerifier(importRequireVerified, importMinisignPublicKey)
+		if err != nil {
+			return fmt.Errorf("invalid --minisign-public-key: %w", err)
+		}
+		importerService.SetSeedVerifier(verifier)
 		importerService.SetGitHubToken(importGithubToken)
 		importerService.SetReadmeSeedPath(importReadmeSeed)
 		importerService.SetProgressCachePath(importProgressCache)
+		importerService.SetCrawlStatePath(importCrawlState)
+		if importImageScanner != "" {
+			scanner, err := vulnscan.New(importImageScanner)
+			if err != nil {
+				return err
+			}
+			importerService.SetImageScanner(scanner)
+		}
 		if importGenerateEmbeddings {
 			provider, err := embeddings.Factory(&cfg.Embeddings, httpClient)
 			if err != nil {
@@ -109,16 +183,51 @@ var ImportCmd = &cobra.Command{
 	},
 }
 
+func importArchive(ctx context.Context, db *database.PostgreSQL, path string) error {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return errors.New("--all requires a local archive path as --source")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	result, err := archive.Import(ctx, db, f)
+	if result != nil {
+		c, s := result.Created, result.Skipped
+		fmt.Printf("Imported %d servers, %d READMEs, %d agents, %d skills, %d deployments and %d embeddings\n",
+			c.Servers, c.Readmes, c.Agents, c.Skills, c.Deployments, c.Embeddings)
+		if s.Servers+s.Agents+s.Skills+s.Deployments > 0 {
+			fmt.Printf("Skipped %d servers, %d agents, %d skills and %d deployments that already exist\n",
+				s.Servers, s.Agents, s.Skills, s.Deployments)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to import registry archive: %w", err)
+	}
+	return nil
+}
+
 func init() {
-	ImportCmd.Flags().StringVar(&importSource, "source", "", "Seed file path, HTTP URL, or registry /v0/servers URL (required)")
+	ImportCmd.Flags().StringVar(&importSource, "source", "", "Seed file path, HTTP URL, or registry /v0/servers URL")
+	ImportCmd.Flags().StringVar(&importFrom, "from", "", "Package registry search to build draft entries from: npm:keyword=<keyword>, npm:text=<text>, pypi:classifier=<classifier>, pypi:q=<text>, or a GitHub organization to crawl: github-org:<org>")
 	ImportCmd.Flags().BoolVar(&importSkipValidation, "skip-validation", false, "Disable registry validation for this import run")
 	ImportCmd.Flags().StringArrayVar(&importHeaders, "request-header", nil, "Additional request header in key=value form (repeatable)")
 	ImportCmd.Flags().DurationVar(&importTimeout, "timeout", 30*time.Second, "HTTP request timeout")
-	ImportCmd.Flags().StringVar(&importGithubToken, "github-token", "", "GitHub token for higher rate limits when enriching metadata")
+	ImportCmd.Flags().StringVar(&importGithubToken, "github-token", "", "GitHub token for higher rate limits when enriching metadata or crawling an organization")
+	ImportCmd.Flags().StringVar(&importCrawlState, "crawl-state", "", "Optional path to store the ETags of a github-org: crawl so re-crawls only fetch changed repositories")
 	ImportCmd.Flags().BoolVar(&importUpdate, "update", false, "Update existing entries if name/version already exists")
+	ImportCmd.Flags().StringVar(&importStrategy, "strategy", string(importer.ConflictSkip), "How to import entries that collide with existing servers: skip, overwrite, newest-version-wins or rename-with-suffix")
+	ImportCmd.Flags().IntVar(&importConcurrency, "concurrency", 10, "How many servers to create and enrich at the same time")
+	ImportCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Report what the import would create, overwrite, rename and skip without writing anything")
+	ImportCmd.Flags().BoolVar(&importRequireVerified, "require-verification", false, "Only import seed files with a valid detached <file>.sha256 checksum (and <file>.minisig signature with --minisign-public-key)")
+	ImportCmd.Flags().StringVar(&importMinisignPublicKey, "minisign-public-key", "", "Minisign public key seed files must be signed with")
 	ImportCmd.Flags().StringVar(&importReadmeSeed, "readme-seed", "", "Optional README seed file path or URL")
 	ImportCmd.Flags().StringVar(&importProgressCache, "progress-cache", "", "Optional path to store import progress for resuming interrupted runs")
 	ImportCmd.Flags().BoolVar(&enrichServerData, "enrich-server-data", false, "Enrich server data during import (may increase import time)")
+	ImportCmd.Flags().StringVar(&importImageScanner, "image-scanner", "", "Scan the OCI images of imported servers for vulnerabilities with this scanner (trivy or grype)")
 	ImportCmd.Flags().BoolVar(&importGenerateEmbeddings, "generate-embeddings", false, "Generate semantic embeddings during import (requires embeddings configuration)")
-	_ = ImportCmd.MarkFlagRequired("source")
+	ImportCmd.Flags().BoolVar(&importAll, "all", false, "Import an entire registry archive written by arctl export --all")
 }
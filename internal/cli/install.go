@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/prompt"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/skill"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/spf13/cobra"
+)
+
+var (
+	installVersion   string
+	installRuntime   string
+	installSkillsDir string
+	installYes       bool
+)
+
+var InstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Deploy everything a registry collection lists, or every MCP server matching a pattern",
+}
+
+var installMCPCmd = &cobra.Command{
+	Use:   "mcp <name-or-pattern>",
+	Short: "Deploy the latest version of every MCP server matching a name or glob pattern",
+	Long: `Deploys the latest published version of every MCP server whose name matches the argument, a full name or a
+glob pattern: * matches any characters except '/', ? a single character and [...] a character class. Quote the
+pattern so the shell does not expand it. Servers that are already deployed are left unchanged.
+
+Servers that need configuration such as API keys cannot be deployed without it; deploy those with
+'arctl mcp deploy', or list them all with their configuration in a file for 'arctl mcp deploy --file'.`,
+	Example: `  arctl install mcp 'io.github.myorg/*'
+  arctl install mcp 'io.github.myorg/weather-*' --runtime kubernetes -y`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInstallMCP,
+}
+
+var installCollectionCmd = &cobra.Command{
+	Use:   "collection <name>",
+	Short: "Deploy the servers and agents of a collection and pull its skills",
+	Long: `Installs a published collection in one step: its MCP servers and agents are deployed and its skills are
+pulled into the skills directory. Servers and agents that are already deployed are left unchanged.
+
+Servers and agents that need configuration such as API keys cannot be deployed without it; deploy those with
+'arctl mcp deploy' or 'arctl agent deploy' and the required values.`,
+	Example: `  arctl install collection io.example/data-engineering
+  arctl install collection io.example/data-engineering --version 1.2.0 --runtime kubernetes -y`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInstallCollection,
+}
+
+func init() {
+	installCollectionCmd.Flags().StringVar(&installVersion, "version", "latest", "Collection version to install")
+	installCollectionCmd.Flags().StringVar(&installRuntime, "runtime", "local", "Runtime to deploy servers and agents to (local, kubernetes)")
+	installCollectionCmd.Flags().StringVar(&installSkillsDir, "skills-dir", "skills", "Directory to pull skills into")
+	installCollectionCmd.Flags().BoolVarP(&installYes, "yes", "y", false, "Install without asking")
+
+	installMCPCmd.Flags().StringVar(&installRuntime, "runtime", "local", "Runtime to deploy servers to (local, kubernetes)")
+	installMCPCmd.Flags().BoolVarP(&installYes, "yes", "y", false, "Install without asking")
+
+	InstallCmd.AddCommand(installCollectionCmd, installMCPCmd)
+}
+
+func runInstallCollection(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	name := args[0]
+
+	collection, err := apiClient.GetCollection(name, installVersion)
+	if err != nil {
+		return err
+	}
+	if collection == nil {
+		return fmt.Errorf("collection %s version %s not found", name, installVersion)
+	}
+	items := collection.Collection.Items
+
+	fmt.Printf("Collection %s v%s lists %d item(s):\n", collection.Collection.Name, collection.Collection.Version, len(items))
+	for _, item := range items {
+		fmt.Printf("  - %s %s (%s)\n", item.Type, item.Name, collectionItemVersion(item))
+	}
+	if !installYes {
+		ok, err := confirm("Install them now?")
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	deployments, err := apiClient.GetDeployedServers()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	ctx := commandContext(cmd)
+	var failed []string
+	for i, item := range items {
+		if ctx.Err() != nil {
+			return interruptedError("installing", len(items)-i)
+		}
+		version := collectionItemVersion(item)
+		if item.Type != models.CollectionItemTypeSkill && collectionItemDeployed(deployments, item) {
+			printer.PrintInfo(fmt.Sprintf("%s %s is already deployed", item.Type, item.Name))
+			continue
+		}
+
+		printer.PrintInfo(fmt.Sprintf("Installing %s %s (%s)...", item.Type, item.Name, version))
+		switch item.Type {
+		case models.CollectionItemTypeMCP:
+			_, err = apiClient.DeployServer(item.Name, version, map[string]string{}, false, installRuntime, "", "")
+		case models.CollectionItemTypeAgent:
+			_, err = apiClient.DeployAgent(item.Name, version, map[string]string{}, installRuntime, "")
+		case models.CollectionItemTypeSkill:
+			_, err = skill.Pull(item.Name, item.Version, filepath.Join(installSkillsDir, filepath.Base(item.Name)))
+		default:
+			err = fmt.Errorf("unknown item type %q", item.Type)
+		}
+		if err != nil {
+			printer.PrintError(fmt.Sprintf("failed to install %s %s: %v", item.Type, item.Name, err))
+			failed = append(failed, item.Name)
+			continue
+		}
+		printer.PrintSuccess(fmt.Sprintf("Installed %s %s", item.Type, item.Name))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to install %s; install them individually with their required configuration", strings.Join(failed, ", "))
+	}
+	printer.PrintSuccess(fmt.Sprintf("Installed collection %s v%s", collection.Collection.Name, collection.Collection.Version))
+	return nil
+}
+
+func runInstallMCP(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	pattern := args[0]
+	if err := validateNamePattern(pattern); err != nil {
+		return err
+	}
+
+	var servers []*apiv0.ServerResponse
+	filter := client.ServerListFilter{Search: namePatternPrefix(pattern), Version: "latest"}
+	for server, err := range apiClient.ListServersIter(commandContext(cmd), filter) {
+		if err != nil {
+			return fmt.Errorf("failed to list servers: %w", err)
+		}
+		if matchesNamePattern(pattern, server.Server.Name) {
+			servers = append(servers, server)
+		}
+	}
+	if len(servers) == 0 {
+		return fmt.Errorf("no published MCP server matches %s", pattern)
+	}
+
+	deployments, err := apiClient.GetDeployedServers()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+	var pending []*apiv0.ServerResponse
+	for _, server := range servers {
+		item := models.CollectionItem{Type: models.CollectionItemTypeMCP, Name: server.Server.Name}
+		if collectionItemDeployed(deployments, item) {
+			printer.PrintInfo(fmt.Sprintf("mcp %s is already deployed", server.Server.Name))
+			continue
+		}
+		pending = append(pending, server)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	fmt.Printf("%d MCP server(s) match %s:\n", len(pending), pattern)
+	for _, server := range pending {
+		fmt.Printf("  - %s (%s)\n", server.Server.Name, server.Server.Version)
+	}
+	if !installYes {
+		ok, err := confirm("Deploy them now?")
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	ctx := commandContext(cmd)
+	var failed []string
+	for i, server := range pending {
+		if ctx.Err() != nil {
+			return interruptedError("deploying", len(pending)-i)
+		}
+		if _, err := apiClient.DeployServer(server.Server.Name, server.Server.Version, map[string]string{}, false, installRuntime, "", ""); err != nil {
+			printer.PrintError(fmt.Sprintf("failed to deploy %s: %v", server.Server.Name, err))
+			failed = append(failed, server.Server.Name)
+			continue
+		}
+		printer.PrintSuccess(fmt.Sprintf("Deployed %s (v%s)", server.Server.Name, server.Server.Version))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to deploy %s; deploy them individually with their required configuration", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// interruptedError reports a command stopped by Ctrl-C between the steps of a long operation
+func interruptedError(step string, remaining int) error {
+	return fmt.Errorf("interrupted before %s %d remaining item(s)", step, remaining)
+}
+
+// confirm asks a yes/no question, defaulting to yes. Without a terminal it fails, asking for --yes instead.
+func confirm(question string) (bool, error) {
+	if !prompt.IsInteractive() {
+		return false, fmt.Errorf("confirm with --yes")
+	}
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("%s [Y/n]: ", question)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("error reading input: %w", err)
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "" || response == "y" || response == "yes", nil
+}
+
+// collectionItemDeployed reports whether a server or agent of a collection is deployed. An item without a version
+// is satisfied by any deployed version.
+func collectionItemDeployed(deployments []*client.DeploymentResponse, item models.CollectionItem) bool {
+	for _, d := range deployments {
+		resourceType := d.ResourceType
+		if resourceType == "" {
+			resourceType = models.CollectionItemTypeMCP
+		}
+		if resourceType != item.Type || d.ServerName != item.Name {
+			continue
+		}
+		if item.Version == "" || item.Version == "latest" || d.Version == item.Version {
+			return true
+		}
+	}
+	return false
+}
+
+func collectionItemVersion(item models.CollectionItem) string {
+	if item.Version == "" {
+		return "latest"
+	}
+	return item.Version
+}
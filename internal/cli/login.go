@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/credentials"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+)
+
+var (
+	loginMethod   string
+	loginClientID string
+	loginIssuer   string
+	logoutAll     bool
+)
+
+var LoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in to the registry",
+	Long: `Logs in to the registry using the GitHub or OIDC device flow and stores the resulting registry token
+in ~/.arctl/credentials.json. The stored token is used automatically by other commands and is
+refreshed when it expires. ARCTL_API_TOKEN and --registry-token take precedence over it.
+
+The login method and client settings are discovered from the registry; use --method, --client-id
+and --issuer to override them.`,
+	Example: `  arctl login
+  arctl login --method oidc
+  arctl login --registry-url https://registry.example.com`,
+	Args: cobra.NoArgs,
+	RunE: runLogin,
+}
+
+var LogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Log out of the registry",
+	Long:  `Removes the stored credentials for the current registry, or for all registries with --all.`,
+	Args:  cobra.NoArgs,
+	RunE:  runLogout,
+}
+
+func init() {
+	LoginCmd.Flags().StringVar(&loginMethod, "method", "", "Login method (github, oidc); defaults to the first method the registry supports")
+	LoginCmd.Flags().StringVar(&loginClientID, "client-id", "", "OAuth client ID (overrides the registry's configuration)")
+	LoginCmd.Flags().StringVar(&loginIssuer, "issuer", "", "OIDC issuer URL (overrides the registry's configuration)")
+
+	LogoutCmd.Flags().BoolVar(&logoutAll, "all", false, "Remove the stored credentials for all registries")
+}
+
+func runLogin(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	store, err := credentials.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	// Exchange with an unauthenticated client; a stale stored token would be rejected by the registry
+	anon := client.NewClient(apiClient.BaseURL, "")
+
+	method, clientID, issuer, err := resolveLoginMethod(anon)
+	if err != nil {
+		return err
+	}
+
+	cred := &credentials.Credential{Method: method, ClientID: clientID}
+	var resp *auth.TokenResponse
+	switch method {
+	case credentials.MethodGitHub:
+		cred.TokenEndpoint = credentials.GitHubTokenURL
+		tok, err := runDeviceFlow(ctx, credentials.GitHubDeviceCodeURL, credentials.GitHubTokenURL, clientID, credentials.GitHubScopes)
+		if err != nil {
+			return err
+		}
+		cred.AccessToken = tok.AccessToken
+		cred.RefreshToken = tok.RefreshToken
+		if resp, err = anon.ExchangeGitHubToken(tok.AccessToken); err != nil {
+			return fmt.Errorf("failed to exchange GitHub token: %w", err)
+		}
+	case credentials.MethodOIDC:
+		endpoints, err := credentials.DiscoverOIDC(ctx, issuer)
+		if err != nil {
+			return err
+		}
+		cred.TokenEndpoint = endpoints.TokenEndpoint
+		tok, err := runDeviceFlow(ctx, endpoints.DeviceAuthorizationEndpoint, endpoints.TokenEndpoint, clientID, credentials.OIDCScopes)
+		if err != nil {
+			return err
+		}
+		if tok.IDToken == "" {
+			return fmt.Errorf("OIDC provider did not return an ID token")
+		}
+		cred.RefreshToken = tok.RefreshToken
+		if resp, err = anon.ExchangeOIDCToken(tok.IDToken); err != nil {
+			return fmt.Errorf("failed to exchange OIDC token: %w", err)
+		}
+	}
+
+	cred.SetRegistryToken(resp)
+	cred.Subject = registryTokenSubject(cred.RegistryToken)
+	if err := store.Put(apiClient.BaseURL, cred); err != nil {
+		return err
+	}
+
+	if cred.Subject != "" {
+		fmt.Printf("Logged in to %s as %s\n", apiClient.BaseURL, cred.Subject)
+	} else {
+		fmt.Printf("Logged in to %s\n", apiClient.BaseURL)
+	}
+	if cred.RefreshToken == "" && method == credentials.MethodOIDC {
+		fmt.Println("Note: the provider did not issue a refresh token; run 'arctl login' again when the session expires.")
+	}
+	return nil
+}
+
+// resolveLoginMethod picks the login method and client settings from flags, falling back to the registry's login config
+func resolveLoginMethod(c *client.Client) (method, clientID, issuer string, err error) {
+	method = loginMethod
+	if method != "" && method != credentials.MethodGitHub && method != credentials.MethodOIDC {
+		return "", "", "", fmt.Errorf("invalid login method %q (expected github or oidc)", method)
+	}
+
+	// The registry config is only needed for values that weren't given on the command line
+	needConfig := method == "" || loginClientID == "" || (method == credentials.MethodOIDC && loginIssuer == "")
+	if needConfig {
+		lc, err := c.GetLoginConfig()
+		if err != nil {
+			return "", "", "", fmt.Errorf("failed to get login configuration from registry: %w", err)
+		}
+		if method == "" {
+			switch {
+			case lc.GitHub != nil:
+				method = credentials.MethodGitHub
+			case lc.OIDC != nil:
+				method = credentials.MethodOIDC
+			default:
+				return "", "", "", fmt.Errorf("registry does not support interactive login; use an API token via ARCTL_API_TOKEN instead")
+			}
+		}
+		switch {
+		case method == credentials.MethodGitHub && lc.GitHub != nil:
+			clientID = lc.GitHub.ClientID
+		case method == credentials.MethodOIDC && lc.OIDC != nil:
+			clientID, issuer = lc.OIDC.ClientID, lc.OIDC.Issuer
+		}
+	}
+
+	if loginClientID != "" {
+		clientID = loginClientID
+	}
+	if loginIssuer != "" {
+		issuer = loginIssuer
+	}
+	if clientID == "" {
+		return "", "", "", fmt.Errorf("registry has no %s login configured; pass --client-id", method)
+	}
+	if method == credentials.MethodOIDC && issuer == "" {
+		return "", "", "", fmt.Errorf("registry has no OIDC issuer configured; pass --issuer")
+	}
+	return method, clientID, issuer, nil
+}
+
+func runDeviceFlow(ctx context.Context, deviceEndpoint, tokenEndpoint, clientID string, scopes []string) (*credentials.OAuthToken, error) {
+	dc, err := credentials.RequestDeviceCode(ctx, deviceEndpoint, clientID, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("To log in, open %s and enter the code: %s\n", dc.VerificationURI, dc.UserCode)
+	if dc.VerificationURIComplete != "" {
+		fmt.Printf("Or open %s\n", dc.VerificationURIComplete)
+	}
+	fmt.Println("Waiting for authorization...")
+
+	tok, err := credentials.PollDeviceToken(ctx, tokenEndpoint, clientID, dc)
+	if err != nil {
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+	return tok, nil
+}
+
+// registryTokenSubject extracts the authenticated subject from a registry JWT for display.
+// The token was just issued by the registry, so its signature isn't verified here.
+func registryTokenSubject(token string) string {
+	claims := &auth.JWTClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return ""
+	}
+	return claims.AuthMethodSubject
+}
+
+func runLogout(cmd *cobra.Command, args []string) error {
+	store, err := credentials.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	if logoutAll {
+		if err := store.DeleteAll(); err != nil {
+			return err
+		}
+		fmt.Println("Removed stored credentials for all registries")
+		return nil
+	}
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	removed, err := store.Delete(apiClient.BaseURL)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		fmt.Printf("Not logged in to %s\n", apiClient.BaseURL)
+		return nil
+	}
+	fmt.Printf("Logged out of %s\n", apiClient.BaseURL)
+	return nil
+}
+
+// StoredRegistryToken returns the token saved by 'arctl login' for registryURL, refreshing it if it has expired.
+// It returns an empty token when there is no usable login.
+func StoredRegistryToken(ctx context.Context, registryURL string) string {
+	store, err := credentials.DefaultStore()
+	if err != nil {
+		return ""
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	token, err := store.Token(ctx, registryURL, client.NewClient(registryURL, ""))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: stored login for %s could not be refreshed (%v); run 'arctl login' again\n", registryURL, err)
+		return ""
+	}
+	return token
+}
@@ -0,0 +1,181 @@
+package mcp
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	artifactsVersion   string
+	artifactsOutputDir string
+	artifactsKind      string
+	artifactsMediaType string
+)
+
+var ArtifactsCmd = &cobra.Command{
+	Use:   "artifacts",
+	Short: "Manage the artifact files attached to an MCP server version",
+	Long: `Manage the artifact files, such as JSON schemas, example configs, prompt templates and binaries,
+that publishers attach to a server version. Artifacts are kept in the registry's blob storage.`,
+	Example: `  arctl mcp artifacts list com.example/weather
+  arctl mcp artifacts push com.example/weather ./config.example.json --kind example-config --version 1.2.0
+  arctl mcp artifacts pull com.example/weather config.example.json --output-dir ./weather`,
+}
+
+var artifactsListCmd = &cobra.Command{
+	Use:   "list <server-name>",
+	Short: "List the artifacts of a server version",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runArtifactsList,
+}
+
+var artifactsPullCmd = &cobra.Command{
+	Use:   "pull <server-name> [file-name...]",
+	Short: "Download the artifacts of a server version",
+	Long:  `Downloads the named artifact files of a server version, or all of them when no file names are given.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runArtifactsPull,
+}
+
+var artifactsPushCmd = &cobra.Command{
+	Use:   "push <server-name> <file>",
+	Short: "Attach a file to a server version",
+	Long: `Attaches a file to a server version under its base name, replacing an artifact of the same name.
+The media type is derived from the file extension unless --media-type is set.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runArtifactsPush,
+}
+
+func init() {
+	ArtifactsCmd.PersistentFlags().StringVar(&artifactsVersion, "version", "latest", "Server version")
+	artifactsPullCmd.Flags().StringVarP(&artifactsOutputDir, "output-dir", "d", ".", "Directory to write the artifacts to")
+	artifactsPushCmd.Flags().StringVar(&artifactsKind, "kind", models.AttachmentKindOther, "Kind of artifact: "+strings.Join(models.AttachmentKinds, ", "))
+	artifactsPushCmd.Flags().StringVar(&artifactsMediaType, "media-type", "", "Media type of the file")
+
+	ArtifactsCmd.AddCommand(artifactsListCmd)
+	ArtifactsCmd.AddCommand(artifactsPullCmd)
+	ArtifactsCmd.AddCommand(artifactsPushCmd)
+}
+
+func runArtifactsList(cmd *cobra.Command, args []string) error {
+	serverName := args[0]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	artifacts, err := apiClient.ListServerArtifacts(serverName, artifactsVersion)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(artifacts)
+	}
+
+	if len(artifacts) == 0 {
+		fmt.Printf("%s@%s has no artifacts\n", serverName, artifactsVersion)
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("File", "Kind", "Size", "Digest", "Uploaded")
+	for _, a := range artifacts {
+		t.AddRow(a.FileName, a.Kind, printer.FormatBytes(a.SizeBytes), printer.TruncateString(a.Digest, 19), printer.FormatAge(a.CreatedAt))
+	}
+	return t.Render()
+}
+
+func runArtifactsPull(cmd *cobra.Command, args []string) error {
+	serverName := args[0]
+	fileNames := args[1:]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	if len(fileNames) == 0 {
+		artifacts, err := apiClient.ListServerArtifacts(serverName, artifactsVersion)
+		if err != nil {
+			return err
+		}
+		if len(artifacts) == 0 {
+			return fmt.Errorf("%s@%s has no artifacts", serverName, artifactsVersion)
+		}
+		for _, a := range artifacts {
+			fileNames = append(fileNames, a.FileName)
+		}
+	}
+
+	if err := os.MkdirAll(artifactsOutputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	for _, fileName := range fileNames {
+		// The registry rejects names with path separators, but never trust them when writing to disk
+		if fileName != filepath.Base(fileName) || fileName == "." || fileName == ".." {
+			return fmt.Errorf("invalid artifact file name %q", fileName)
+		}
+		path := filepath.Join(artifactsOutputDir, fileName)
+		size, err := pullArtifact(serverName, fileName, path)
+		if err != nil {
+			return err
+		}
+		printer.PrintSuccess(fmt.Sprintf("Downloaded %s (%s)", path, printer.FormatBytes(size)))
+	}
+	return nil
+}
+
+func pullArtifact(serverName, fileName, path string) (int64, error) {
+	body, err := apiClient.DownloadServerArtifact(serverName, artifactsVersion, fileName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download %s: %w", fileName, err)
+	}
+	defer func() { _ = body.Close() }()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	size, err := io.Copy(f, body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return size, nil
+}
+
+func runArtifactsPush(cmd *cobra.Command, args []string) error {
+	serverName, path := args[0], args[1]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	attachment, err := apiClient.UploadServerArtifact(serverName, artifactsVersion, filepath.Base(path), artifactsKind, artifactsMediaType, f)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(attachment)
+	}
+	printer.PrintSuccess(fmt.Sprintf("Attached %s (%s, %s) to %s@%s", attachment.FileName, attachment.Kind,
+		printer.FormatBytes(attachment.SizeBytes), serverName, attachment.Version))
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/mcp/build"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/mcp/manifest"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
 	"github.com/stoewer/go-strcase"
 
 	"github.com/spf13/cobra"
@@ -88,7 +89,7 @@ func runBuild(cmd *cobra.Command, args []string) error {
 }
 
 func runDocker(args ...string) error {
-	cmd := exec.Command("docker", args...)
+	cmd := exec.Command(utils.ContainerEngine(), args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
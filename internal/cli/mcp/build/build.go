@@ -8,6 +8,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
 )
 
 // Options contains configuration for building MCP servers
@@ -109,22 +111,22 @@ func (b *Builder) buildDockerImage(opts Options, projectType string) error {
 	args = append(args, ".")
 
 	if opts.Verbose {
-		fmt.Printf("Running: docker %s\n", strings.Join(args, " "))
+		fmt.Printf("Running: %s %s\n", utils.ContainerEngine(), strings.Join(args, " "))
 	}
 
 	// Create docker command
-	cmd := exec.Command("docker", args...)
+	cmd := exec.Command(utils.ContainerEngine(), args...)
 	cmd.Dir = opts.ProjectDir
 
 	// Show real-time output from docker build
 	return b.runCommandWithOutput(cmd, imageName)
 }
 
-// checkDockerAvailable verifies that Docker is available and running
+// checkDockerAvailable verifies that Docker (or podman) is available and running
 func (b *Builder) checkDockerAvailable() error {
-	cmd := exec.Command("docker", "version", "--format", "{{.Server.Version}}")
+	cmd := exec.Command(utils.ContainerEngine(), "info")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("docker is not available or not running. Please ensure Docker is installed and running")
+		return fmt.Errorf("%s is not available or not running. Please ensure Docker or Podman is installed and running", utils.ContainerEngine())
 	}
 	return nil
 }
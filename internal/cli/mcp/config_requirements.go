@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/prompt"
+	registryutils "github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry/utils"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// resolveServerConfig checks config against the environment variables, arguments and headers the server declares.
+// Values that do not match their declared choices or format are rejected. Required values that are missing are asked
+// for in a terminal, secrets without echo; with --yes or without a terminal, the deploy fails listing all of them.
+func resolveServerConfig(server *apiv0.ServerJSON, config map[string]string, preferRemote bool) error {
+	reqs := registryutils.ConfigRequirements(server, preferRemote)
+	missing, err := registryutils.CheckConfig(reqs, config)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if deployYes || !prompt.IsInteractive() {
+		return missingConfigError(server.Name, missing)
+	}
+
+	fmt.Printf("%s needs %d more setting(s):\n", server.Name, len(missing))
+	for _, req := range missing {
+		for {
+			value, err := prompt.Input(configLabel(req), req.IsSecret)
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				fmt.Println("A value is required")
+				continue
+			}
+			if err := req.Validate(value); err != nil {
+				fmt.Println(err)
+				continue
+			}
+			config[req.Key()] = value
+			break
+		}
+	}
+	return nil
+}
+
+// configLabel describes a requirement in a prompt
+func configLabel(req registryutils.ConfigRequirement) string {
+	label := fmt.Sprintf("%s %s", req.Kind, req.Name)
+	if req.Description != "" {
+		label += " (" + req.Description + ")"
+	}
+	if len(req.Choices) > 0 {
+		label += " [" + strings.Join(req.Choices, "|") + "]"
+	}
+	return label
+}
+
+func missingConfigError(serverName string, missing []registryutils.ConfigRequirement) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s is missing required configuration:", serverName)
+	for _, req := range missing {
+		flag := "--env"
+		switch req.Kind {
+		case registryutils.ConfigKindArg:
+			flag = "--arg"
+		case registryutils.ConfigKindHeader:
+			flag = "--header"
+		}
+		fmt.Fprintf(&b, "\n  %s %s=<value>", flag, req.Name)
+		if req.Description != "" {
+			fmt.Fprintf(&b, "\t%s", req.Description)
+		}
+	}
+	return errors.New(b.String())
+}
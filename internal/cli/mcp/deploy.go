@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/spf13/cobra"
 )
 
@@ -15,17 +19,107 @@ var (
 	deployPreferRemote bool
 	deployYes          bool
 	deployRuntime      string
+	deployTarget       string
 	deployNamespace    string
+	deployOrigin       string
+	deploySwitchOrigin bool
+	deployRequireSign  bool
+	deployTrustedKeys  []string
+	deployLimitCPU     string
+	deployLimitMemory  string
+	deployRequestCPU   string
+	deployRequestMem   string
+	deployRestart      string
+	deployReplicas     string
+	deployRestrict     bool
+	deployCanary       int
+	deployProfile      string
+	deployFilePath     string
 )
 
 var DeployCmd = &cobra.Command{
-	Use:           "deploy <server-name>",
-	Short:         "Deploy an MCP server",
-	Long:          `Deploy an MCP server to the runtime.`,
-	Args:          cobra.ExactArgs(1),
-	RunE:          runDeploy,
-	SilenceUsage:  true,  // Don't show usage on deployment errors
-	SilenceErrors: false, // Still show error messages
+	Use:   "deploy [server-name | --file servers.yaml]",
+	Short: "Deploy an MCP server",
+	Long: `Deploy an MCP server to the runtime.
+
+By default the server manifest is resolved from this registry. Use --origin to resolve it from another registry
+instead; the deployment stays pinned to that registry on every reconcile. To move an existing deployment to a
+different origin, use --switch-origin together with --origin (an empty --origin pins it back to this registry).
+
+If the server version carries a publisher signature it is verified before deploying, and deployment fails when the
+signature does not match. Use --require-signed to also reject unsigned servers, and --trusted-key to only accept
+signatures from specific key fingerprints.
+
+The environment variables, arguments and headers the server manifest declares are checked before deploying:
+values must match their declared choices and format (number, boolean), and required values without a default must
+be set. In a terminal, arctl asks for the missing ones, without echoing secrets; with --yes, or when not attached
+to a terminal, the deploy fails listing them.
+
+Values passed with --env, --arg or --header may reference external secrets instead of containing them:
+secretRef://vault/<path>#<key>, secretRef://aws/<secret-id>[#<key>] or env://<NAME> (a variable of the registry
+host). Only the reference is stored in the registry; the runtime resolves it every time the deployment is reconciled.
+
+Use --profile to start from a config profile of the server stored in the registry, such as dev or prod (see
+'arctl mcp profile'). Values passed with --env, --arg, --header and the resource flags override individual keys of
+the profile.
+
+Use --file to deploy a fleet of servers from a manifest instead of a single server:
+
+  servers:
+    - name: io.github.user/weather
+      version: 1.2.0
+      profile: prod
+      env:
+        LOG_LEVEL: debug
+    - name: io.github.user/github
+      target: edge-docker
+      headers:
+        Authorization: secretRef://vault/secret/data/github#token
+      resources:
+        REPLICAS: "2"
+
+Each server takes name, version (latest when omitted), profile, env, args, headers, resources (CPU_LIMIT,
+MEMORY_LIMIT, CPU_REQUEST, MEMORY_REQUEST, RESTART_POLICY, REPLICAS, RESTRICT_EGRESS), runtime, target, namespace and preferRemote.
+--runtime, --target, --namespace, --prefer-remote, --require-signed, --trusted-key and --yes apply to every server
+that does not set its own. Servers are deployed one after the other; a failure is reported and the others are
+still deployed.
+
+Use --limit-cpu, --limit-memory, --request-cpu and --request-memory to bound the resources of the server container,
+--restart to set its restart policy on the local runtime and --replicas to run several instances of it behind the
+agent gateway. They override defaults the publisher declared in the server manifest under
+"_meta.io.modelcontextprotocol.registry/publisher-provided.aregistry.ai/resources". CPU is in cores (0.5) or
+millicores (500m); memory accepts Docker (512m, 1g) or Kubernetes (512Mi, 1Gi) units.
+
+Use --restrict-egress to only let a server run from a package connect to the hosts of the remotes its manifest
+declares and of the registry its package is downloaded from. On the local runtime the server is moved to an
+internal network whose only way out is a proxy allowing those hosts; stdio servers, which run inside the agent
+gateway, cannot be restricted. On kubernetes a NetworkPolicy limits the server to DNS and HTTP(S) to public
+addresses and records the allowed hosts in its aregistry.ai/egress-allowed-hosts annotation.
+
+Use --target to deploy to a named target configured on the registry server, such as a remote docker host or
+another kubernetes context, instead of the built-in target of --runtime. 'arctl mcp targets' lists them.
+
+Use --canary to roll a new version of a deployed server out next to the current one: the agent gateway sends the
+given percentage of the server's traffic to the new version, which inherits the target and origin (and, unless
+configuration is passed, the configuration) of the current deployment. Finish the rollout with 'arctl mcp promote'
+or abort it with 'arctl mcp remove'. A canary that keeps failing its health checks is rolled back automatically.`,
+	Example: `  arctl mcp deploy io.github.user/weather
+  arctl mcp deploy io.github.user/weather --origin https://registry.example.com
+  arctl mcp deploy io.github.user/weather --switch-origin --origin ""
+  arctl mcp deploy io.github.user/weather --require-signed --trusted-key SHA256:3f1a...
+  arctl mcp deploy io.github.user/weather -e API_KEY=secretRef://vault/secret/data/weather#api_key
+  arctl mcp deploy io.github.user/weather --profile prod -e LOG_LEVEL=debug
+  arctl mcp deploy io.github.user/weather --limit-cpu 0.5 --limit-memory 512m --restart unless-stopped
+  arctl mcp deploy io.github.user/weather --replicas 3
+  arctl mcp deploy io.github.user/weather --restrict-egress
+  arctl mcp deploy io.github.user/weather --target edge-docker
+  arctl mcp deploy io.github.user/weather --version 1.3.0 --canary 10
+  arctl mcp deploy --file servers.yaml --yes`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completion.Names(completion.Servers),
+	RunE:              runDeploy,
+	SilenceUsage:      true,  // Don't show usage on deployment errors
+	SilenceErrors:     false, // Still show error messages
 }
 
 func init() {
@@ -36,41 +130,72 @@ func init() {
 	DeployCmd.Flags().BoolVar(&deployPreferRemote, "prefer-remote", false, "Prefer remote deployment over local")
 	DeployCmd.Flags().BoolVarP(&deployYes, "yes", "y", false, "Automatically accept all prompts (use default/latest version)")
 	DeployCmd.Flags().StringVar(&deployRuntime, "runtime", "local", "Deployment runtime target (local, kubernetes)")
+	DeployCmd.Flags().StringVar(&deployTarget, "target", "", "Named deployment target configured on the registry server (overrides --runtime)")
 	DeployCmd.Flags().StringVar(&deployNamespace, "namespace", "default", "Kubernetes namespace for deployment (only used with --runtime kubernetes)")
+	DeployCmd.Flags().StringVar(&deployOrigin, "origin", "", "Base URL of the registry to resolve the server manifest from (defaults to this registry)")
+	DeployCmd.Flags().BoolVar(&deploySwitchOrigin, "switch-origin", false, "Switch the origin of an existing deployment to --origin instead of creating a new deployment")
+	DeployCmd.Flags().BoolVar(&deployRequireSign, "require-signed", false, "Refuse to deploy servers without a valid publisher signature")
+	DeployCmd.Flags().StringArrayVar(&deployTrustedKeys, "trusted-key", nil, "Only accept signatures from this key fingerprint (repeatable)")
+	DeployCmd.Flags().StringVar(&deployLimitCPU, "limit-cpu", "", "CPU limit in cores (e.g. 0.5 or 500m)")
+	DeployCmd.Flags().StringVar(&deployLimitMemory, "limit-memory", "", "Memory limit (e.g. 512m or 512Mi)")
+	DeployCmd.Flags().StringVar(&deployRequestCPU, "request-cpu", "", "CPU reserved for the server in cores")
+	DeployCmd.Flags().StringVar(&deployRequestMem, "request-memory", "", "Memory reserved for the server")
+	DeployCmd.Flags().StringVar(&deployReplicas, "replicas", "", "Number of server instances to run; the agent gateway balances requests across them")
+	DeployCmd.Flags().BoolVar(&deployRestrict, "restrict-egress", false, "Only let the server connect to the hosts of its declared remotes and package registry")
+	DeployCmd.Flags().IntVar(&deployCanary, "canary", 0, "Roll this version out as a canary receiving this percentage (1-99) of the traffic of the deployed version")
+	DeployCmd.Flags().StringVar(&deployProfile, "profile", "", "Start from this config profile of the server; --env, --arg and --header override its keys")
+	DeployCmd.Flags().StringVarP(&deployFilePath, "file", "f", "", "Deploy the servers listed in this YAML manifest")
+	DeployCmd.Flags().StringVar(&deployRestart, "restart", "", "Restart policy on the local runtime (no, always, on-failure, unless-stopped)")
 }
 
 func runDeploy(cmd *cobra.Command, args []string) error {
-	serverName := args[0]
-
 	if apiClient == nil {
 		return fmt.Errorf("API client not initialized")
 	}
 
-	config := make(map[string]string)
-
-	for _, env := range deployEnv {
-		parts := strings.SplitN(env, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid env format (expected KEY=VALUE): %s", env)
+	if deployFilePath != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--file lists the servers to deploy and cannot be combined with a server name")
+		}
+		if deploySwitchOrigin || deployOrigin != "" || deployCanary != 0 || deployProfile != "" {
+			return fmt.Errorf("--file cannot be combined with --origin, --switch-origin, --canary or --profile")
 		}
-		config[parts[0]] = parts[1]
+		return deployFromFile(cmd.Context(), deployFilePath)
 	}
 
-	for _, arg := range deployArgs {
-		parts := strings.SplitN(arg, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid arg format (expected KEY=VALUE): %s", arg)
-		}
-		config["ARG_"+parts[0]] = parts[1]
+	serverName, err := completion.NameArg(completion.Servers, args)
+	if err != nil {
+		return err
+	}
+
+	if deploySwitchOrigin {
+		return switchDeploymentOrigin(serverName)
+	}
+	if deployCanary != 0 && (deploySwitchOrigin || deployOrigin != "" || deployTarget != "") {
+		return fmt.Errorf("--canary inherits the origin and target of the current deployment and cannot be combined with --origin, --target or --switch-origin")
 	}
 
-	for _, header := range deployHeaders {
-		parts := strings.SplitN(header, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid header format (expected KEY=VALUE): %s", header)
+	installConfig, err := parseConfigFlags(deployEnv, deployArgs, deployHeaders)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range map[string]string{
+		registry.ResourceCPULimit:      deployLimitCPU,
+		registry.ResourceMemoryLimit:   deployLimitMemory,
+		registry.ResourceCPURequest:    deployRequestCPU,
+		registry.ResourceMemoryRequest: deployRequestMem,
+		registry.ResourceRestartPolicy: deployRestart,
+		registry.ResourceReplicas:      deployReplicas,
+	} {
+		if value != "" {
+			installConfig.Resources[key] = value
 		}
-		config["HEADER_"+parts[0]] = parts[1]
 	}
+	if deployRestrict {
+		installConfig.Resources[registry.ResourceRestrictEgress] = "true"
+	}
+	config := installConfig.Flatten()
 
 	// Add namespace to config for Kubernetes deployments
 	if deployRuntime == "kubernetes" && deployNamespace != "" {
@@ -81,13 +206,40 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("version is required")
 	}
 
+	// Servers resolved from another registry do not need to exist in this one
+	if deployOrigin != "" {
+		if deployRequireSign {
+			return fmt.Errorf("--require-signed is not supported together with --origin")
+		}
+		if config, err = applyConfigProfile(serverName, deployProfile, config); err != nil {
+			return err
+		}
+		return deployServer(serverName, config)
+	}
+
 	// Ensure the server with the specified version is published
 	server, err := apiClient.GetServerByNameAndVersion(serverName, deployVersion, true)
 	if err != nil {
 		return fmt.Errorf("failed to get server: %w", err)
 	}
 	if server == nil {
-		return fmt.Errorf("server not found: %s", serverName)
+		// Fall back to matching on the short name (the part after the namespace)
+		matches := findServersByName(serverName)
+		if len(matches) == 0 {
+			return fmt.Errorf("server not found: %s", serverName)
+		}
+		match, err := selectServerMatch(serverName, matches)
+		if err != nil {
+			return err
+		}
+		serverName = match.Server.Name
+		server, err = apiClient.GetServerByNameAndVersion(serverName, deployVersion, true)
+		if err != nil {
+			return fmt.Errorf("failed to get server: %w", err)
+		}
+		if server == nil {
+			return fmt.Errorf("server not found: %s (version %s)", serverName, deployVersion)
+		}
 	}
 
 	isPublished, err := isServerPublished(serverName, deployVersion)
@@ -98,24 +250,158 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("server %s version %s is not published", serverName, deployVersion)
 	}
 
+	if err := verifyServerSignature(&server.Server, deployRequireSign, deployTrustedKeys); err != nil {
+		return err
+	}
+
+	if config, err = applyConfigProfile(server.Server.Name, deployProfile, config); err != nil {
+		return err
+	}
+
+	// A canary deployed without configuration inherits the configuration of the deployed version
+	if deployCanary == 0 || deployProfile != "" || len(deployEnv)+len(deployArgs)+len(deployHeaders) > 0 {
+		if err := resolveServerConfig(&server.Server, config, deployPreferRemote); err != nil {
+			return err
+		}
+	}
+
+	return deployServer(server.Server.Name, config)
+}
+
+// parseConfigFlags collects KEY=VALUE flags into the sections of a deployment config
+func parseConfigFlags(envs, args, headers []string) (models.InstallationConfig, error) {
+	installConfig := models.InstallationConfig{
+		Env:       map[string]string{},
+		Args:      map[string]string{},
+		Headers:   map[string]string{},
+		Resources: map[string]string{},
+	}
+	for _, flag := range []struct {
+		kind    string
+		values  []string
+		section map[string]string
+	}{
+		{"env", envs, installConfig.Env},
+		{"arg", args, installConfig.Args},
+		{"header", headers, installConfig.Headers},
+	} {
+		for _, value := range flag.values {
+			key, v, ok := strings.Cut(value, "=")
+			if !ok {
+				return installConfig, fmt.Errorf("invalid %s format (expected KEY=VALUE): %s", flag.kind, value)
+			}
+			flag.section[key] = v
+		}
+	}
+	return installConfig, nil
+}
+
+// applyConfigProfile starts the config from the resolved config profile of the server, when one is named, letting the
+// keys of config override individual keys of the profile
+func applyConfigProfile(serverName, profileName string, config map[string]string) (map[string]string, error) {
+	if profileName != "" {
+		profile, err := apiClient.GetConfigProfile(serverName, profileName)
+		if err != nil {
+			return nil, err
+		}
+		config = models.MergeConfig(profile.ResolvedConfig, config)
+	}
+	if err := registry.ValidateResourceConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func deployServer(serverName string, config map[string]string) error {
+	if deployCanary != 0 {
+		return deployServerCanary(serverName, config)
+	}
+
 	// Deploy server via API (server will handle reconciliation)
 	fmt.Println("\nDeploying server...")
-	deployment, err := apiClient.DeployServer(server.Server.Name, deployVersion, config, deployPreferRemote, deployRuntime)
+	runtimeTarget := deployRuntime
+	if deployTarget != "" {
+		// The target decides the runtime
+		runtimeTarget = ""
+	}
+	deployment, err := apiClient.DeployServer(serverName, deployVersion, config, deployPreferRemote, runtimeTarget, deployTarget, deployOrigin)
 	if err != nil {
 		return fmt.Errorf("failed to deploy server: %w", err)
 	}
 
-	fmt.Printf("\n✓ Deployed %s (v%s) to %s runtime\n", deployment.ServerName, deployment.Version, deployRuntime)
+	if deployment.Runtime != "" {
+		deployRuntime = deployment.Runtime
+	}
+	if deployment.Target != "" && deployment.Target != deployRuntime {
+		fmt.Printf("\n✓ Deployed %s (v%s) to target %s (%s runtime)\n", deployment.ServerName, deployment.Version, deployment.Target, deployRuntime)
+	} else {
+		fmt.Printf("\n✓ Deployed %s (v%s) to %s runtime\n", deployment.ServerName, deployment.Version, deployRuntime)
+	}
+	if deployment.Origin != "" {
+		fmt.Printf("Origin: %s\n", deployment.Origin)
+	}
 	if deployRuntime == "kubernetes" {
 		fmt.Printf("Namespace: %s\n", deployNamespace)
 	}
 	if len(config) > 0 {
 		fmt.Printf("Configuration: %d setting(s)\n", len(config))
 	}
-	if deployRuntime == "local" {
+	if deployRuntime == "local" && (deployment.Target == "" || deployment.Target == "local") {
 		fmt.Printf("\nServer deployment recorded. The registry will reconcile containers automatically.\n")
 		fmt.Printf("Agent Gateway endpoint: http://localhost:21212/mcp\n")
 	}
 
 	return nil
 }
+
+// deployServerCanary rolls the selected version out next to the deployed version of the server
+func deployServerCanary(serverName string, config map[string]string) error {
+	fmt.Println("\nDeploying canary...")
+	deployment, err := apiClient.DeployServerCanary(serverName, deployVersion, config, deployCanary)
+	if err != nil {
+		return fmt.Errorf("failed to deploy canary: %w", err)
+	}
+
+	fmt.Printf("\n✓ Deployed %s (v%s) as a canary receiving %d%% of its traffic\n", deployment.ServerName, deployment.Version, deployment.CanaryWeight)
+	fmt.Printf("Promote it with 'arctl mcp promote %s' or abort with 'arctl mcp remove %s --version %s'.\n", deployment.ServerName, deployment.ServerName, deployment.Version)
+	return nil
+}
+
+// switchDeploymentOrigin moves an existing MCP server deployment to the registry given by --origin
+func switchDeploymentOrigin(serverName string) error {
+	deployments, err := apiClient.GetDeployedServers()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	var matches []*client.DeploymentResponse
+	for _, d := range deployments {
+		if d.ServerName != serverName || d.ResourceType != "mcp" {
+			continue
+		}
+		if deployVersion != "" && deployVersion != "latest" && d.Version != deployVersion {
+			continue
+		}
+		matches = append(matches, d)
+	}
+
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no deployment found for %s", serverName)
+	case 1:
+	default:
+		return fmt.Errorf("%s has %d deployed versions, please specify one with --version", serverName, len(matches))
+	}
+
+	deployment, err := apiClient.UpdateDeploymentOrigin(serverName, matches[0].Version, deployOrigin)
+	if err != nil {
+		return fmt.Errorf("failed to switch deployment origin: %w", err)
+	}
+
+	origin := deployment.Origin
+	if origin == "" {
+		origin = "this registry"
+	}
+	fmt.Printf("✓ %s (v%s) now resolves from %s\n", deployment.ServerName, deployment.Version, origin)
+	return nil
+}
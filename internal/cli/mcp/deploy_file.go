@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// deployFile is the manifest 'arctl mcp deploy --file' reads: the servers to deploy with their versions and config
+type deployFile struct {
+	Servers []deployFileEntry `yaml:"servers"`
+}
+
+// deployFileEntry is a server of a deploy file. Runtime, target and preferRemote default to the flags of the
+// command. env, args, headers and resources are the sections of the deployment config; resources keys are the
+// names of the resource flags' settings, such as CPU_LIMIT or REPLICAS.
+type deployFileEntry struct {
+	Name                      string `yaml:"name"`
+	Version                   string `yaml:"version"`
+	Profile                   string `yaml:"profile"`
+	Runtime                   string `yaml:"runtime"`
+	Target                    string `yaml:"target"`
+	Namespace                 string `yaml:"namespace"`
+	PreferRemote              bool   `yaml:"preferRemote"`
+	models.InstallationConfig `yaml:",inline"`
+}
+
+func (e deployFileEntry) version() string {
+	return cmp.Or(e.Version, "latest")
+}
+
+// loadDeployFile reads a deploy file, rejecting unknown fields and servers listed twice with the same version
+func loadDeployFile(path string) (*deployFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deploy file: %w", err)
+	}
+	return parseDeployFile(data)
+}
+
+func parseDeployFile(data []byte) (*deployFile, error) {
+	var file deployFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid deploy file: %w", err)
+	}
+	if len(file.Servers) == 0 {
+		return nil, fmt.Errorf("invalid deploy file: no servers listed")
+	}
+
+	seen := map[string]bool{}
+	for i, entry := range file.Servers {
+		if entry.Name == "" {
+			return nil, fmt.Errorf("invalid deploy file: server %d has no name", i+1)
+		}
+		key := entry.Name + "@" + entry.version()
+		if seen[key] {
+			return nil, fmt.Errorf("invalid deploy file: %s version %s is listed more than once", entry.Name, entry.version())
+		}
+		seen[key] = true
+	}
+	return &file, nil
+}
+
+// deployFromFile deploys every server of a deploy file, going on past failures and reporting them at the end. It stops
+// before the next server once ctx is canceled.
+func deployFromFile(ctx context.Context, path string) error {
+	file, err := loadDeployFile(path)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for i, entry := range file.Servers {
+		if ctx.Err() != nil {
+			return fmt.Errorf("interrupted before deploying %d remaining server(s)", len(file.Servers)-i)
+		}
+		printer.PrintInfo(fmt.Sprintf("Deploying %s (%s)...", entry.Name, entry.version()))
+		deployment, err := deployFileServer(entry)
+		if err != nil {
+			printer.PrintError(fmt.Sprintf("failed to deploy %s: %v", entry.Name, err))
+			failed = append(failed, entry.Name)
+			continue
+		}
+		target := cmp.Or(deployment.Target, deployment.Runtime)
+		printer.PrintSuccess(fmt.Sprintf("Deployed %s (v%s) to %s", deployment.ServerName, deployment.Version, target))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to deploy %d of %d server(s): %s", len(failed), len(file.Servers), strings.Join(failed, ", "))
+	}
+	fmt.Printf("\nDeployed %d server(s). The registry will reconcile them automatically.\n", len(file.Servers))
+	return nil
+}
+
+// deployFileServer deploys a server of a deploy file with the checks of a single deploy: the version must be
+// published, its signature valid and its required config set
+func deployFileServer(entry deployFileEntry) (*client.DeploymentResponse, error) {
+	config := entry.InstallationConfig.Flatten()
+	runtimeTarget := cmp.Or(entry.Runtime, deployRuntime)
+	if namespace := cmp.Or(entry.Namespace, deployNamespace); runtimeTarget == "kubernetes" && namespace != "" {
+		config["KAGENT_NAMESPACE"] = namespace
+	}
+
+	server, err := apiClient.GetServerByNameAndVersion(entry.Name, entry.version(), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server: %w", err)
+	}
+	if server == nil {
+		return nil, fmt.Errorf("server not found: %s (version %s)", entry.Name, entry.version())
+	}
+	version := server.Server.Version
+
+	isPublished, err := isServerPublished(entry.Name, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if server is published: %w", err)
+	}
+	if !isPublished {
+		return nil, fmt.Errorf("version %s is not published", version)
+	}
+	if err := verifyServerSignature(&server.Server, deployRequireSign, deployTrustedKeys); err != nil {
+		return nil, err
+	}
+
+	if config, err = applyConfigProfile(entry.Name, entry.Profile, config); err != nil {
+		return nil, err
+	}
+	preferRemote := entry.PreferRemote || deployPreferRemote
+	if err := resolveServerConfig(&server.Server, config, preferRemote); err != nil {
+		return nil, err
+	}
+
+	target := cmp.Or(entry.Target, deployTarget)
+	if target != "" {
+		// The target decides the runtime
+		runtimeTarget = ""
+	}
+	return apiClient.DeployServer(entry.Name, version, config, preferRemote, runtimeTarget, target, "")
+}
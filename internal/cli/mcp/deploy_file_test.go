@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeployFile(t *testing.T) {
+	file, err := parseDeployFile([]byte(`
+servers:
+  - name: io.github.user/weather
+    version: 1.2.0
+    profile: prod
+    env:
+      LOG_LEVEL: debug
+    args:
+      port: "8080"
+  - name: io.github.user/github
+    target: edge-docker
+    preferRemote: true
+    headers:
+      Authorization: Bearer token
+    resources:
+      REPLICAS: "2"
+`))
+	require.NoError(t, err)
+	require.Len(t, file.Servers, 2)
+
+	weather := file.Servers[0]
+	assert.Equal(t, "1.2.0", weather.version())
+	assert.Equal(t, "prod", weather.Profile)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug", "ARG_port": "8080"}, weather.InstallationConfig.Flatten())
+
+	github := file.Servers[1]
+	assert.Equal(t, "latest", github.version())
+	assert.Equal(t, "edge-docker", github.Target)
+	assert.True(t, github.PreferRemote)
+	assert.Equal(t, map[string]string{"HEADER_Authorization": "Bearer token", "RESOURCE_REPLICAS": "2"}, github.InstallationConfig.Flatten())
+
+	for name, data := range map[string]string{
+		"empty":         ``,
+		"no servers":    `servers: []`,
+		"missing name":  "servers:\n  - version: 1.0.0",
+		"unknown field": "servers:\n  - name: io.github.user/weather\n    envs:\n      A: b",
+		"duplicate":     "servers:\n  - name: io.github.user/weather\n  - name: io.github.user/weather\n    version: latest",
+	} {
+		_, err := parseDeployFile([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
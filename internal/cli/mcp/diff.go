@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffVersion string
+)
+
+var DiffCmd = &cobra.Command{
+	Use:   "diff <server-name>",
+	Short: "Show how a deployed MCP server drifted from its desired state",
+	Long: `Compare the desired state of a deployed MCP server, rendered from its registry record and stored configuration,
+with what actually runs on its target: the rendered docker-compose.yaml and the containers on docker targets, or the
+MCPServer and RemoteMCPServer objects on kubernetes targets.
+
+Values of environment variables and headers are redacted; only the fact that they differ is shown.`,
+	Example: `  arctl mcp diff io.github.user/weather
+  arctl mcp diff io.github.user/weather --version 1.2.0 -o json`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runDiff,
+	SilenceUsage:  true,  // Don't show usage on diff errors
+	SilenceErrors: false, // Still show error messages
+}
+
+func init() {
+	DiffCmd.Flags().StringVar(&diffVersion, "version", "", "Version of the deployment to compare (required when several versions are deployed)")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	serverName := args[0]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	version := diffVersion
+	if version == "" {
+		deployments, err := apiClient.GetDeployedServers()
+		if err != nil {
+			return fmt.Errorf("failed to get deployments: %w", err)
+		}
+		var matches []*client.DeploymentResponse
+		for _, d := range deployments {
+			if d.ServerName == serverName && d.ResourceType == "mcp" {
+				matches = append(matches, d)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return fmt.Errorf("no deployment found for %s", serverName)
+		case 1:
+			version = matches[0].Version
+		default:
+			return fmt.Errorf("%s has %d deployed versions, please specify one with --version", serverName, len(matches))
+		}
+	}
+
+	diff, err := apiClient.DiffDeployment(serverName, version, "mcp")
+	if err != nil {
+		return fmt.Errorf("failed to compare %s with its runtime: %w", serverName, err)
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(diff)
+	}
+
+	if diff.InSync {
+		fmt.Printf("✓ %s (v%s) matches its desired state on %s\n", diff.ServerName, diff.Version, diff.Target)
+		return nil
+	}
+
+	fmt.Printf("%s (v%s) drifted from its desired state on %s:\n\n", diff.ServerName, diff.Version, diff.Target)
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Resource", "Field", "Desired", "Actual")
+	for _, d := range diff.Drift {
+		t.AddRow(d.Resource, d.Field, orDash(printer.TruncateString(d.Desired, 60)), orDash(printer.TruncateString(d.Actual, 60)))
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	fmt.Println("\nRun 'arctl admin jobs run reconcile' to restore the desired state.")
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
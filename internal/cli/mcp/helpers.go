@@ -7,6 +7,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/prompt"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
@@ -142,3 +144,42 @@ func selectServerVersion(resourceName, requestedVersion string, autoYes bool) (*
 
 	return publishedVersions[0], nil
 }
+
+// selectServerMatch disambiguates between several servers matching searchName.
+// In a terminal the user picks one from a list; otherwise an error asking for the full name is returned.
+func selectServerMatch(searchName string, matches []*apiv0.ServerResponse) (*apiv0.ServerResponse, error) {
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+
+	names := make([]string, len(matches))
+	rows := make([][]string, len(matches))
+	for i, s := range matches {
+		names[i] = s.Server.Name
+		registryType := ""
+		if len(s.Server.Packages) > 0 {
+			registryType = s.Server.Packages[0].RegistryType
+		} else if len(s.Server.Remotes) > 0 {
+			registryType = s.Server.Remotes[0].Type
+		}
+		rows[i] = []string{
+			s.Server.Name,
+			printer.EmptyValueOrDefault(registryType, "<none>"),
+			s.Server.Version,
+			printer.TruncateString(s.Server.Description, 60),
+		}
+	}
+
+	idx, err := prompt.Select(
+		fmt.Sprintf("Multiple MCP servers match '%s':", searchName),
+		[]string{"Name", "Registry", "Version", "Description"},
+		rows,
+	)
+	if errors.Is(err, prompt.ErrNotInteractive) {
+		return nil, fmt.Errorf("multiple servers match '%s' (%s); please use the full server name", searchName, strings.Join(names, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return matches[idx], nil
+}
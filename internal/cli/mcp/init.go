@@ -21,7 +21,8 @@ var InitCmd = &cobra.Command{
 	Long: `Initialize a new MCP server project with dynamic tool loading.
 
 This command provides subcommands to initialize a new MCP server project
-using one of the supported frameworks.`,
+using one of the supported frameworks, or to generate a server.json for a
+server already distributed as an npm, PyPI or OCI package or a remote endpoint.`,
 	RunE: runInit,
 }
 
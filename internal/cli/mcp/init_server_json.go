@@ -0,0 +1,284 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// registryTypeRemote generates a server.json with a remote endpoint instead of a package
+const registryTypeRemote = "remote"
+
+var serverJSONRegistryTypes = []string{model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeOCI, registryTypeRemote}
+
+var (
+	serverJSONRegistryType string
+	serverJSONIdentifier   string
+	serverJSONVersion      string
+	serverJSONTransport    string
+	serverJSONURL          string
+	serverJSONRepository   string
+	serverJSONEnv          []string
+	serverJSONSecretEnv    []string
+	serverJSONDir          string
+)
+
+var initServerJSONCmd = &cobra.Command{
+	Use:   "server-json <server-name>",
+	Short: "Generate a server.json for an existing npm, PyPI, OCI or remote MCP server",
+	Long: `Generate a server.json describing an MCP server that is already published as an npm or PyPI
+package, an OCI image, or hosted as a remote endpoint, together with a stub README.md.
+
+Missing values are prompted for unless --non-interactive is set. The generated file is checked
+with the same validators as 'arctl mcp validate' (without registry lookups).`,
+	Example: `  arctl mcp init server-json io.github.myorg/weather --registry-type npm --package-id @myorg/weather-mcp --version 1.2.0 \
+    --env WEATHER_UNITS --secret-env WEATHER_API_KEY
+  arctl mcp init server-json com.example/weather --registry-type remote --url https://mcp.example.com/mcp
+  arctl mcp init server-json io.github.myorg/weather --registry-type oci --package-id ghcr.io/myorg/weather:1.2.0`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInitServerJSON,
+}
+
+func init() {
+	InitCmd.AddCommand(initServerJSONCmd)
+	initServerJSONCmd.Flags().StringVar(&serverJSONRegistryType, "registry-type", "", "Where the server is distributed: npm, pypi, oci or remote")
+	initServerJSONCmd.Flags().StringVar(&serverJSONIdentifier, "package-id", "", "Package name (npm, pypi) or image reference (oci)")
+	initServerJSONCmd.Flags().StringVar(&serverJSONVersion, "version", "", "Server and package version (default 1.0.0)")
+	initServerJSONCmd.Flags().StringVar(&serverJSONTransport, "transport", "", "Transport: stdio, streamable-http or sse (default stdio for packages, streamable-http for remotes)")
+	initServerJSONCmd.Flags().StringVar(&serverJSONURL, "url", "", "Endpoint URL of a remote server, or of a package using an HTTP transport")
+	initServerJSONCmd.Flags().StringVar(&serverJSONRepository, "repository", "", "Source repository URL (e.g. https://github.com/myorg/weather-mcp)")
+	initServerJSONCmd.Flags().StringArrayVar(&serverJSONEnv, "env", nil, "Environment variable the server reads, as NAME or NAME=description (repeatable)")
+	initServerJSONCmd.Flags().StringArrayVar(&serverJSONSecretEnv, "secret-env", nil, "Secret environment variable, as NAME or NAME=description (repeatable)")
+	initServerJSONCmd.Flags().StringVar(&serverJSONDir, "dir", ".", "Directory to write server.json and README.md to")
+}
+
+func runInitServerJSON(_ *cobra.Command, args []string) error {
+	serverName := args[0]
+	if err := promptServerJSONOptions(); err != nil {
+		return err
+	}
+
+	serverJSON, err := buildServerJSON(serverName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(serverJSONDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", serverJSONDir, err)
+	}
+	serverJSONPath := filepath.Join(serverJSONDir, "server.json")
+	if fileExists(serverJSONPath) && !initForce {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", serverJSONPath)
+	}
+	data, err := json.MarshalIndent(serverJSON, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal server.json: %w", err)
+	}
+	if err := os.WriteFile(serverJSONPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", serverJSONPath, err)
+	}
+	fmt.Printf("✓ Created %s\n", serverJSONPath)
+
+	readmePath := filepath.Join(serverJSONDir, "README.md")
+	if !fileExists(readmePath) || initForce {
+		if err := os.WriteFile(readmePath, []byte(serverJSONReadme(serverJSON)), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", readmePath, err)
+		}
+		fmt.Printf("✓ Created %s\n", readmePath)
+	}
+
+	report := validators.CheckServerJSON(context.Background(), *serverJSON, false)
+	if len(report.Issues) > 0 {
+		fmt.Println()
+		printValidationReport(serverJSONPath, report)
+	}
+
+	fmt.Printf("\nTo check the package exists and publish the server:\n")
+	fmt.Printf("  arctl mcp validate %s\n", serverJSONPath)
+	fmt.Printf("  arctl mcp publish --dir %s\n", serverJSONDir)
+	return nil
+}
+
+// promptServerJSONOptions asks for the values not given as flags, unless running non-interactively
+func promptServerJSONOptions() error {
+	if initNonInteractive {
+		return nil
+	}
+
+	var err error
+	for !slices.Contains(serverJSONRegistryTypes, serverJSONRegistryType) {
+		serverJSONRegistryType, err = promptForInput(fmt.Sprintf("Enter registry type (%s): ", strings.Join(serverJSONRegistryTypes, ", ")))
+		if err != nil {
+			return fmt.Errorf("failed to read registry type: %w", err)
+		}
+	}
+	if serverJSONRegistryType == registryTypeRemote {
+		if serverJSONURL == "" {
+			if serverJSONURL, err = promptForInput("Enter the remote endpoint URL: "); err != nil {
+				return fmt.Errorf("failed to read URL: %w", err)
+			}
+		}
+	} else if serverJSONIdentifier == "" {
+		prompt := "Enter the package name: "
+		if serverJSONRegistryType == model.RegistryTypeOCI {
+			prompt = "Enter the image reference (e.g. ghcr.io/myorg/server:1.0.0): "
+		}
+		if serverJSONIdentifier, err = promptForInput(prompt); err != nil {
+			return fmt.Errorf("failed to read package: %w", err)
+		}
+	}
+	if serverJSONVersion == "" {
+		if serverJSONVersion, err = promptForInput("Enter version (default 1.0.0): "); err != nil {
+			return fmt.Errorf("failed to read version: %w", err)
+		}
+	}
+	if initDescription == "" {
+		initDescription = promptForDescription()
+	}
+	if len(serverJSONEnv) == 0 && len(serverJSONSecretEnv) == 0 {
+		names, err := promptForInput("Enter environment variables the server reads, comma separated (optional): ")
+		if err != nil {
+			return fmt.Errorf("failed to read environment variables: %w", err)
+		}
+		for name := range strings.SplitSeq(names, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				serverJSONEnv = append(serverJSONEnv, name)
+			}
+		}
+	}
+	return nil
+}
+
+// buildServerJSON assembles a server.json from the collected options
+func buildServerJSON(serverName string) (*apiv0.ServerJSON, error) {
+	version := serverJSONVersion
+	if version == "" {
+		version = "1.0.0"
+	}
+	serverJSON := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: initDescription,
+		Version:     version,
+	}
+	if serverJSONRepository != "" {
+		serverJSON.Repository = &model.Repository{URL: serverJSONRepository, Source: repositorySource(serverJSONRepository)}
+	}
+
+	env := make([]model.KeyValueInput, 0, len(serverJSONEnv)+len(serverJSONSecretEnv))
+	for _, value := range serverJSONEnv {
+		env = append(env, envDeclaration(value, false))
+	}
+	for _, value := range serverJSONSecretEnv {
+		env = append(env, envDeclaration(value, true))
+	}
+
+	switch serverJSONRegistryType {
+	case registryTypeRemote:
+		if serverJSONURL == "" {
+			return nil, fmt.Errorf("--url is required for remote servers")
+		}
+		if len(env) > 0 {
+			return nil, fmt.Errorf("remote servers cannot declare environment variables")
+		}
+		transport := serverJSONTransport
+		if transport == "" {
+			transport = model.TransportTypeStreamableHTTP
+		}
+		serverJSON.Remotes = []model.Transport{{Type: transport, URL: serverJSONURL}}
+
+	case model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeOCI:
+		if serverJSONIdentifier == "" {
+			return nil, fmt.Errorf("--package-id is required for %s packages", serverJSONRegistryType)
+		}
+		transport := serverJSONTransport
+		if transport == "" {
+			transport = model.TransportTypeStdio
+		}
+		pkg := model.Package{
+			RegistryType:         serverJSONRegistryType,
+			Identifier:           serverJSONIdentifier,
+			Transport:            model.Transport{Type: transport, URL: serverJSONURL},
+			EnvironmentVariables: env,
+		}
+		switch serverJSONRegistryType {
+		case model.RegistryTypeNPM:
+			pkg.RegistryBaseURL = model.RegistryURLNPM
+			pkg.Version = version
+			pkg.RunTimeHint = "npx"
+		case model.RegistryTypePyPI:
+			pkg.RegistryBaseURL = model.RegistryURLPyPI
+			pkg.Version = version
+			pkg.RunTimeHint = "uvx"
+		case model.RegistryTypeOCI:
+			pkg.RunTimeHint = "docker"
+		}
+		serverJSON.Packages = []model.Package{pkg}
+
+	default:
+		return nil, fmt.Errorf("unsupported registry type %q, expected one of %s", serverJSONRegistryType, strings.Join(serverJSONRegistryTypes, ", "))
+	}
+	return serverJSON, nil
+}
+
+// envDeclaration parses NAME or NAME=description into a required environment variable declaration
+func envDeclaration(value string, secret bool) model.KeyValueInput {
+	name, description, _ := strings.Cut(value, "=")
+	kv := model.KeyValueInput{Name: strings.TrimSpace(name)}
+	kv.Description = strings.TrimSpace(description)
+	kv.IsRequired = true
+	kv.IsSecret = secret
+	return kv
+}
+
+// repositorySource derives the repository source identifier from its URL
+func repositorySource(repoURL string) string {
+	switch {
+	case strings.HasPrefix(repoURL, model.RegistryURLGitHub):
+		return "github"
+	case strings.HasPrefix(repoURL, model.RegistryURLGitLab):
+		return "gitlab"
+	}
+	return ""
+}
+
+// serverJSONReadme renders a stub README for a generated server.json
+func serverJSONReadme(serverJSON *apiv0.ServerJSON) string {
+	var b strings.Builder
+	title := serverJSON.Name[strings.LastIndex(serverJSON.Name, "/")+1:]
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	if serverJSON.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", serverJSON.Description)
+	}
+
+	b.WriteString("## Usage\n\n")
+	fmt.Fprintf(&b, "Run the server locally with the Agent Registry CLI:\n\n```sh\narctl mcp run %s\n```\n\n", serverJSON.Name)
+
+	var env []model.KeyValueInput
+	for _, pkg := range serverJSON.Packages {
+		env = append(env, pkg.EnvironmentVariables...)
+	}
+	if len(env) > 0 {
+		b.WriteString("## Configuration\n\n| Variable | Description | Secret |\n| --- | --- | --- |\n")
+		for _, kv := range env {
+			secret := "no"
+			if kv.IsSecret {
+				secret = "yes"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", kv.Name, kv.Description, secret)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Tools\n\nTODO: describe the tools this server provides.\n")
+	return b.String()
+}
@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/spf13/cobra"
+)
+
+// signingKeyEnv names the environment variable holding the default signing key path
+const signingKeyEnv = "ARCTL_SIGNING_KEY"
+
+var (
+	keygenOutput string
+	keygenForce  bool
+)
+
+var KeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate a key pair for signing MCP servers",
+	Long: `Generates an Ed25519 key pair for 'arctl mcp publish --sign'.
+
+The private key is written to <output>.key (mode 0600) and the public key to <output>.pub.
+Registry operators can restrict accepted keys by adding the printed fingerprint to TRUSTED_SIGNING_KEYS.`,
+	Example: `  arctl mcp keygen
+  arctl mcp keygen --output ~/.arctl/signing`,
+	Args: cobra.NoArgs,
+	RunE: runKeygen,
+}
+
+func init() {
+	KeygenCmd.Flags().StringVarP(&keygenOutput, "output", "o", "arctl-signing", "Path prefix for the generated key files")
+	KeygenCmd.Flags().BoolVar(&keygenForce, "force", false, "Overwrite existing key files")
+}
+
+func runKeygen(cmd *cobra.Command, args []string) error {
+	privPath, pubPath := keygenOutput+".key", keygenOutput+".pub"
+	if !keygenForce {
+		for _, p := range []string{privPath, pubPath} {
+			if _, err := os.Stat(p); err == nil {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", p)
+			}
+		}
+	}
+
+	privPEM, pubPEM, err := signing.GenerateKey()
+	if err != nil {
+		return err
+	}
+	pub, err := signing.ParsePublicKey(pubPEM)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := signing.Fingerprint(pub)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(privPath, privPEM, 0o600); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err := os.WriteFile(pubPath, pubPEM, 0o644); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	printer.PrintSuccess(fmt.Sprintf("Wrote private key to %s and public key to %s", privPath, pubPath))
+	fmt.Printf("Fingerprint: %s\n", fingerprint)
+	return nil
+}
+
+// signServer signs a server.json with the key at keyPath (or $ARCTL_SIGNING_KEY) and uploads the signature
+func signServer(server *apiv0.ServerJSON, keyPath string) error {
+	if keyPath == "" {
+		keyPath = os.Getenv(signingKeyEnv)
+	}
+	if keyPath == "" {
+		return fmt.Errorf("a signing key is required: use --key or set %s", signingKeyEnv)
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read signing key: %w", err)
+	}
+	key, err := signing.ParsePrivateKey(keyPEM)
+	if err != nil {
+		return err
+	}
+
+	sig, alg, err := signing.Sign(key, server)
+	if err != nil {
+		return err
+	}
+	pubPEM, err := signing.EncodePublicKey(key.Public())
+	if err != nil {
+		return err
+	}
+
+	stored, err := apiClient.SetServerSignature(server.Name, server.Version, &models.ServerSignature{
+		Algorithm: alg,
+		Signature: sig,
+		PublicKey: string(pubPEM),
+	})
+	if err != nil {
+		return err
+	}
+	printer.PrintInfo(fmt.Sprintf("Signed %s (v%s) with key %s", server.Name, server.Version, stored.Fingerprint))
+	return nil
+}
+
+// verifyServerSignature checks the registry-held signature of a server against its server.json.
+// An invalid signature or an untrusted key is always an error; a missing signature is only an error when required.
+func verifyServerSignature(server *apiv0.ServerJSON, requireSigned bool, trustedKeys []string) error {
+	sig, err := apiClient.GetServerSignature(server.Name, server.Version)
+	if err != nil {
+		return err
+	}
+	if sig == nil {
+		if requireSigned {
+			return fmt.Errorf("server %s version %s is not signed", server.Name, server.Version)
+		}
+		return nil
+	}
+
+	pub, err := signing.ParsePublicKey([]byte(sig.PublicKey))
+	if err != nil {
+		return fmt.Errorf("invalid signing key for %s: %w", server.Name, err)
+	}
+	fingerprint, err := signing.Fingerprint(pub)
+	if err != nil {
+		return err
+	}
+	if len(trustedKeys) > 0 && !slices.Contains(trustedKeys, fingerprint) {
+		return fmt.Errorf("server %s version %s is signed with untrusted key %s", server.Name, server.Version, fingerprint)
+	}
+	if err := signing.Verify(pub, server, sig.Algorithm, sig.Signature); err != nil {
+		return fmt.Errorf("signature of %s version %s is invalid: %w", server.Name, server.Version, err)
+	}
+
+	fmt.Printf("✓ Verified signature of %s (v%s) by key %s\n", server.Name, server.Version, fingerprint)
+	return nil
+}
@@ -3,6 +3,7 @@ package mcp
 import (
 	"bufio"
 	"fmt"
+	"iter"
 	"log"
 	"os"
 	"strings"
@@ -16,24 +17,43 @@ import (
 var (
 	listAll      bool
 	listPageSize int
+	listPage     int
+	listLimit    int
+	listFilter   string
+	listDeployed bool
+	listLicense  string
+	listTags     []string
 	filterType   string
 	sortBy       string
-	outputFormat string
 )
 
 var ListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List MCP servers",
-	Long:  `List MCP servers from connected registries.`,
-	RunE:  runList,
+	Long: `List MCP servers from connected registries.
+
+Servers are fetched from the registry a page at a time, so --filter, --limit and --page keep large
+registries fast: only the servers needed for the output are fetched.`,
+	Example: `  arctl mcp list --filter weather
+  arctl mcp list --deployed
+  arctl mcp list --page 3 --page-size 50
+  arctl mcp list --type oci --limit 20 -o json
+  arctl mcp list --license MIT
+  arctl mcp list --tag database`,
+	RunE: runList,
 }
 
 func init() {
 	ListCmd.Flags().BoolVarP(&listAll, "all", "a", false, "Show all items without pagination")
 	ListCmd.Flags().IntVarP(&listPageSize, "page-size", "p", 15, "Number of items per page")
+	ListCmd.Flags().IntVar(&listPage, "page", 0, "Show only this page (1-based) of --page-size servers, without prompting")
+	ListCmd.Flags().IntVar(&listLimit, "limit", 0, "Show at most this many servers")
+	ListCmd.Flags().StringVarP(&listFilter, "filter", "f", "", "Only show servers whose name contains this text")
+	ListCmd.Flags().BoolVar(&listDeployed, "deployed", false, "Only show deployed servers")
+	ListCmd.Flags().StringVar(&listLicense, "license", "", "Only show servers with this SPDX license (e.g., MIT, Apache-2.0)")
+	ListCmd.Flags().StringSliceVar(&listTags, "tag", nil, "Only show servers with this tag (repeatable; servers must carry all given tags)")
 	ListCmd.Flags().StringVarP(&filterType, "type", "t", "", "Filter by registry type (e.g., npm, pypi, oci, sse, streamable-http)")
 	ListCmd.Flags().StringVarP(&sortBy, "sortBy", "s", "name", "Sort by column (name, version, type, status, updated)")
-	ListCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -41,98 +61,157 @@ func runList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("API client not initialized")
 	}
 
-	servers, err := apiClient.GetPublishedServers()
-	if err != nil {
-		return fmt.Errorf("failed to get servers: %w", err)
+	if listPage < 0 || listLimit < 0 {
+		return fmt.Errorf("--page and --limit must not be negative")
+	}
+	if listPage > 0 && listPageSize <= 0 {
+		return fmt.Errorf("--page requires a positive --page-size")
+	}
+
+	// Name, license and tag filtering happen in the registry; the other filters are applied while streaming
+	servers := apiClient.ListServersIter(cmd.Context(), client.ServerListFilter{Search: listFilter, License: listLicense, Tags: listTags})
+
+	// Filter by type if specified
+	if filterType != "" {
+		servers = filterServersByType(servers, filterType)
 	}
 
 	deployedServers, err := apiClient.GetDeployedServers()
 	if err != nil {
+		if listDeployed {
+			return fmt.Errorf("failed to get deployed servers: %w", err)
+		}
 		log.Printf("Warning: Failed to get deployed servers: %v", err)
 		deployedServers = nil
 	}
+	if listDeployed {
+		servers = filterDeployedServers(servers, deployedServers)
+	}
 
-	// Filter by type if specified
-	if filterType != "" {
-		servers = filterServersByType(servers, filterType)
+	// --page and --limit select a bounded window, so the iterator stops fetching once it is filled
+	windowed := listPage > 0 || listLimit > 0
+	if listPage > 0 {
+		servers = sliceServers(servers, (listPage-1)*listPageSize, listPageSize)
+	}
+	if listLimit > 0 {
+		servers = sliceServers(servers, 0, listLimit)
+	}
+
+	// The registry returns servers ordered by name, so the interactive table can be streamed
+	// page by page; other orderings and structured output need the complete list.
+	if !printer.Format().IsStructured() && !listAll && !windowed && strings.ToLower(sortBy) == "name" {
+		return streamPaginatedServers(servers, deployedServers, listPageSize)
 	}
 
-	if len(servers) == 0 {
-		if filterType != "" {
-			fmt.Printf("No MCP servers found with type '%s'\n", filterType)
-		} else {
-			fmt.Println("No MCP servers available")
+	var all []*v0.ServerResponse
+	for s, err := range servers {
+		if err != nil {
+			return fmt.Errorf("failed to get servers: %w", err)
 		}
-		return nil
+		all = append(all, s)
 	}
 
-	// Handle different output formats
-	switch outputFormat {
-	case "json":
-		return outputDataJson(servers)
-	case "yaml":
-		return outputDataYaml(servers)
-	default:
-		displayPaginatedServers(servers, deployedServers, listPageSize, listAll)
+	if len(all) == 0 {
+		printNoServers()
+		return nil
 	}
 
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(all)
+	}
+	sortServers(all, sortBy)
+	printServersTable(all, deployedServers)
 	return nil
 }
 
-func displayPaginatedServers(servers []*v0.ServerResponse, deployedServers []*client.DeploymentResponse, pageSize int, showAll bool) {
-	// Sort servers before displaying
-	sortServers(servers, sortBy)
-	total := len(servers)
+func printNoServers() {
+	switch {
+	case listPage > 1:
+		fmt.Printf("No MCP servers on page %d\n", listPage)
+	case listFilter != "" || listLicense != "" || len(listTags) > 0 || filterType != "" || listDeployed:
+		fmt.Println("No MCP servers match the given filters")
+	default:
+		fmt.Println("No MCP servers available")
+	}
+}
+
+// streamPaginatedServers shows servers a page at a time, only fetching more from the registry when the user continues
+func streamPaginatedServers(servers iter.Seq2[*v0.ServerResponse, error], deployedServers []*client.DeploymentResponse, pageSize int) error {
+	next, stop := iter.Pull2(servers)
+	defer stop()
+
+	// take reads up to n servers (all remaining when n <= 0)
+	take := func(n int) ([]*v0.ServerResponse, error) {
+		var page []*v0.ServerResponse
+		for n <= 0 || len(page) < n {
+			s, err, ok := next()
+			if !ok {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get servers: %w", err)
+			}
+			page = append(page, s)
+		}
+		return page, nil
+	}
 
-	if showAll || total <= pageSize {
-		// Show all items
-		printServersTable(servers, deployedServers)
-		return
+	// Read one server past the page to know whether more are available
+	page, err := take(pageSize + 1)
+	if err != nil {
+		return err
+	}
+	if len(page) == 0 {
+		printNoServers()
+		return nil
 	}
 
-	// Simple pagination with Enter to continue
 	reader := bufio.NewReader(os.Stdin)
-	start := 0
+	shown := 0
+	for {
+		if len(page) <= pageSize {
+			printServersTable(page, deployedServers)
+			if shown > 0 {
+				fmt.Printf("\nShowing all %d servers.\n", shown+len(page))
+			}
+			return nil
+		}
 
-	for start < total {
-		end := min(start+pageSize, total)
+		current, lookahead := page[:pageSize], page[pageSize]
+		printServersTable(current, deployedServers)
+		shown += len(current)
 
-		// Display current page
-		printServersTable(servers[start:end], deployedServers)
+		fmt.Printf("\nShowing %d-%d servers. More available.\n", shown-len(current)+1, shown)
+		fmt.Print("Press Enter to continue, 'a' for all, or 'q' to quit: ")
 
-		// Check if there are more items
-		remaining := total - end
-		if remaining > 0 {
-			fmt.Printf("\nShowing %d-%d of %d servers. %d more available.\n", start+1, end, total, remaining)
-			fmt.Print("Press Enter to continue, 'a' for all, or 'q' to quit: ")
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Println("\nStopping pagination.")
+			return nil
+		}
 
-			response, err := reader.ReadString('\n')
+		switch strings.TrimSpace(strings.ToLower(response)) {
+		case "a", "all":
+			// Show all remaining
+			fmt.Println()
+			rest, err := take(0)
 			if err != nil {
-				fmt.Println("\nStopping pagination.")
-				return
+				return err
 			}
-
-			response = strings.TrimSpace(strings.ToLower(response))
-
-			switch response {
-			case "a", "all":
-				// Show all remaining
-				fmt.Println()
-				printServersTable(servers[end:], deployedServers)
-				return
-			case "q", "quit":
-				// Quit pagination
-				fmt.Println()
-				return
-			default:
-				// Enter or any other key continues to next page
-				start = end
-				fmt.Println()
+			printServersTable(append([]*v0.ServerResponse{lookahead}, rest...), deployedServers)
+			return nil
+		case "q", "quit":
+			// Quit pagination
+			fmt.Println()
+			return nil
+		default:
+			// Enter or any other key continues to next page
+			fmt.Println()
+			rest, err := take(pageSize)
+			if err != nil {
+				return err
 			}
-		} else {
-			// No more items
-			fmt.Printf("\nShowing all %d servers.\n", total)
-			return
+			page = append([]*v0.ServerResponse{lookahead}, rest...)
 		}
 	}
 }
@@ -209,7 +288,11 @@ func sortServers(servers []*v0.ServerResponse, column string) {
 
 func printServersTable(servers []*v0.ServerResponse, deployedServers []*client.DeploymentResponse) {
 	t := printer.NewTablePrinter(os.Stdout)
-	t.SetHeaders("Name", "Version", "Type", "Published", "Deployed", "Updated")
+	headers := []string{"Name", "Version", "Type", "Published", "Deployed", "Updated"}
+	if t.Wide() {
+		headers = append(headers, "Description")
+	}
+	t.SetHeaders(headers...)
 
 	// Create a map of deployed servers by name and version
 	deployedMap := make(map[string]map[string]*client.DeploymentResponse)
@@ -256,14 +339,18 @@ func printServersTable(servers []*v0.ServerResponse, deployedServers []*client.D
 			// If this specific version is not deployed, show False even if another version is deployed
 		}
 
-		t.AddRow(
+		row := []any{
 			printer.TruncateString(fullName, 50),
 			s.Server.Version,
 			registryType,
 			publishedStatus,
 			deployedStatus,
 			updatedAt,
-		)
+		}
+		if t.Wide() {
+			row = append(row, s.Server.Description)
+		}
+		t.AddRow(row...)
 	}
 
 	if err := t.Render(); err != nil {
@@ -272,37 +359,69 @@ func printServersTable(servers []*v0.ServerResponse, deployedServers []*client.D
 }
 
 // filterServersByType filters servers by their registry type
-func filterServersByType(servers []*v0.ServerResponse, typeFilter string) []*v0.ServerResponse {
+func filterServersByType(servers iter.Seq2[*v0.ServerResponse, error], typeFilter string) iter.Seq2[*v0.ServerResponse, error] {
 	typeFilter = strings.ToLower(typeFilter)
-	var filtered []*v0.ServerResponse
 
-	for _, s := range servers {
-		// Extract registry type from packages or remotes
-		serverType := ""
-		if len(s.Server.Packages) > 0 {
-			serverType = strings.ToLower(s.Server.Packages[0].RegistryType)
-		} else if len(s.Server.Remotes) > 0 {
-			serverType = strings.ToLower(s.Server.Remotes[0].Type)
-		}
+	return func(yield func(*v0.ServerResponse, error) bool) {
+		for s, err := range servers {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			// Extract registry type from packages or remotes
+			serverType := ""
+			if len(s.Server.Packages) > 0 {
+				serverType = strings.ToLower(s.Server.Packages[0].RegistryType)
+			} else if len(s.Server.Remotes) > 0 {
+				serverType = strings.ToLower(s.Server.Remotes[0].Type)
+			}
 
-		if serverType == typeFilter {
-			filtered = append(filtered, s)
+			if serverType == typeFilter && !yield(s, nil) {
+				return
+			}
 		}
 	}
-
-	return filtered
 }
 
-func outputDataJson(data any) error {
-	p := printer.New(printer.OutputTypeJSON, false)
-	if err := p.PrintJSON(data); err != nil {
-		return fmt.Errorf("failed to output JSON: %w", err)
+// filterDeployedServers keeps the server versions that have a deployment
+func filterDeployedServers(servers iter.Seq2[*v0.ServerResponse, error], deployedServers []*client.DeploymentResponse) iter.Seq2[*v0.ServerResponse, error] {
+	deployed := make(map[string]bool, len(deployedServers))
+	for _, d := range deployedServers {
+		deployed[d.ServerName+"@"+d.Version] = true
+	}
+
+	return func(yield func(*v0.ServerResponse, error) bool) {
+		for s, err := range servers {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if deployed[s.Server.Name+"@"+s.Server.Version] && !yield(s, nil) {
+				return
+			}
+		}
 	}
-	return nil
 }
 
-func outputDataYaml(data any) error {
-	// For now, YAML is not implemented, fallback to JSON
-	fmt.Println("YAML output not yet implemented, using JSON:")
-	return outputDataJson(data)
+// sliceServers skips offset servers and yields at most limit more, stopping the underlying iterator afterwards
+func sliceServers(servers iter.Seq2[*v0.ServerResponse, error], offset, limit int) iter.Seq2[*v0.ServerResponse, error] {
+	return func(yield func(*v0.ServerResponse, error) bool) {
+		seen, yielded := 0, 0
+		for s, err := range servers {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if seen++; seen <= offset {
+				continue
+			}
+			if !yield(s, nil) {
+				return
+			}
+			if yielded++; yielded >= limit {
+				return
+			}
+		}
+	}
 }
@@ -30,12 +30,25 @@ func init() {
 	McpCmd.AddCommand(InitCmd)
 	McpCmd.AddCommand(BuildCmd)
 	McpCmd.AddCommand(AddToolCmd)
+	McpCmd.AddCommand(ArtifactsCmd)
 	McpCmd.AddCommand(PublishCmd)
 	McpCmd.AddCommand(DeleteCmd)
 	McpCmd.AddCommand(DeployCmd)
+	McpCmd.AddCommand(DiffCmd)
 	McpCmd.AddCommand(RemoveCmd)
+	McpCmd.AddCommand(ReviewsCmd)
+	McpCmd.AddCommand(RollbackCmd)
+	McpCmd.AddCommand(PromoteCmd)
+	McpCmd.AddCommand(ProfileCmd)
 	McpCmd.AddCommand(ListCmd)
 	McpCmd.AddCommand(RunCmd)
 	McpCmd.AddCommand(ShowCmd)
+	McpCmd.AddCommand(StatusCmd)
+	McpCmd.AddCommand(TargetsCmd)
+	McpCmd.AddCommand(TestCmd)
+	McpCmd.AddCommand(TopCmd)
 	McpCmd.AddCommand(UnpublishCmd)
+	McpCmd.AddCommand(ValidateCmd)
+	McpCmd.AddCommand(VersionsCmd)
+	McpCmd.AddCommand(KeygenCmd)
 }
@@ -0,0 +1,170 @@
+package mcp
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileEnv         []string
+	profileArgs        []string
+	profileHeaders     []string
+	profileExtends     string
+	profileDescription string
+)
+
+var ProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage the config profiles of MCP servers",
+	Long: `Config profiles are named deployment configs of a server, such as dev or prod, stored in the registry.
+'arctl mcp deploy <server> --profile <name>' starts from the env vars, arguments and headers of the profile.
+
+A profile can extend another profile of the same server: it inherits every key of that profile and overrides the
+keys it sets itself. Values may reference external secrets (secretRef://...), as with 'arctl mcp deploy'.`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:               "list <server-name>",
+	Short:             "List the config profiles of a server",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.Names(completion.Servers),
+	RunE:              runProfileList,
+}
+
+var profileShowCmd = &cobra.Command{
+	Use:   "show <server-name> <profile>",
+	Short: "Show the resolved config of a profile",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runProfileShow,
+}
+
+var profileSetCmd = &cobra.Command{
+	Use:   "set <server-name> <profile>",
+	Short: "Create or replace a config profile",
+	Example: `  arctl mcp profile set io.github.user/weather base -e LOG_LEVEL=info -e API_KEY=secretRef://vault/secret/data/weather#api_key
+  arctl mcp profile set io.github.user/weather prod --extends base -e LOG_LEVEL=warn --header X-Region=eu`,
+	Args: cobra.ExactArgs(2),
+	RunE: runProfileSet,
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <server-name> <profile>",
+	Short: "Delete a config profile no other profile extends",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runProfileDelete,
+}
+
+func init() {
+	profileSetCmd.Flags().StringArrayVarP(&profileEnv, "env", "e", []string{}, "Environment variables (KEY=VALUE)")
+	profileSetCmd.Flags().StringArrayVarP(&profileArgs, "arg", "a", []string{}, "Runtime arguments (KEY=VALUE)")
+	profileSetCmd.Flags().StringArrayVar(&profileHeaders, "header", []string{}, "HTTP headers for remote servers (KEY=VALUE)")
+	profileSetCmd.Flags().StringVar(&profileExtends, "extends", "", "Profile of the same server to inherit keys from")
+	profileSetCmd.Flags().StringVar(&profileDescription, "description", "", "What the profile is for")
+
+	ProfileCmd.AddCommand(profileListCmd, profileShowCmd, profileSetCmd, profileDeleteCmd)
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	profiles, err := apiClient.GetConfigProfiles(args[0])
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(profiles)
+	}
+	if len(profiles) == 0 {
+		fmt.Printf("%s has no config profiles\n", args[0])
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Name", "Extends", "Keys", "Description", "Updated")
+	for _, p := range profiles {
+		t.AddRow(p.Name, printer.EmptyValueOrDefault(p.Extends, "-"), len(p.Config),
+			printer.TruncateString(p.Description, 50), printer.FormatAge(p.UpdatedAt))
+	}
+	return t.Render()
+}
+
+func runProfileShow(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	profile, err := apiClient.GetConfigProfile(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(profile)
+	}
+
+	fmt.Printf("Profile %s of %s\n", profile.Name, profile.ServerName)
+	if profile.Description != "" {
+		fmt.Println(profile.Description)
+	}
+	if profile.Extends != "" {
+		fmt.Printf("Extends: %s\n", profile.Extends)
+	}
+	fmt.Println()
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Key", "Value", "Source")
+	for _, key := range slices.Sorted(maps.Keys(profile.ResolvedConfig)) {
+		source := profile.Name
+		if _, ok := profile.Config[key]; !ok {
+			source = "inherited"
+		}
+		t.AddRow(key, profile.ResolvedConfig[key], source)
+	}
+	return t.Render()
+}
+
+func runProfileSet(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	installConfig, err := parseConfigFlags(profileEnv, profileArgs, profileHeaders)
+	if err != nil {
+		return err
+	}
+
+	profile, err := apiClient.PutConfigProfile(&models.ConfigProfile{
+		ServerName:  args[0],
+		Name:        args[1],
+		Description: profileDescription,
+		Extends:     profileExtends,
+		Config:      installConfig.Flatten(),
+	})
+	if err != nil {
+		return err
+	}
+	printer.PrintSuccess(fmt.Sprintf("Saved profile %s of %s (%d key(s))", profile.Name, profile.ServerName, len(profile.ResolvedConfig)))
+	return nil
+}
+
+func runProfileDelete(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	if err := apiClient.DeleteConfigProfile(args[0], args[1]); err != nil {
+		return err
+	}
+	printer.PrintSuccess(fmt.Sprintf("Deleted profile %s of %s", args[1], args[0]))
+	return nil
+}
@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var PromoteCmd = &cobra.Command{
+	Use:   "promote <server-name>",
+	Short: "Promote the canary deployment of an MCP server",
+	Long: `Finish the canary rollout of an MCP server started with 'arctl mcp deploy --canary'.
+
+The canary version takes all of the server's traffic and the previously deployed version is removed.`,
+	Example:       `  arctl mcp promote io.github.user/weather`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runPromote,
+	SilenceUsage:  true,  // Don't show usage on promotion errors
+	SilenceErrors: false, // Still show error messages
+}
+
+func runPromote(cmd *cobra.Command, args []string) error {
+	serverName := args[0]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	deployment, err := apiClient.PromoteDeployment(serverName)
+	if err != nil {
+		return fmt.Errorf("failed to promote %s: %w", serverName, err)
+	}
+
+	fmt.Printf("✓ Promoted %s (v%s); it now receives all traffic\n", deployment.ServerName, deployment.Version)
+	return nil
+}
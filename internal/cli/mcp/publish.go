@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,6 +11,9 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/mcp/build"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/mcp/manifest"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -34,6 +38,19 @@ var (
 	packageVersion string
 	publishDesc    string
 	publishArgs    []string
+
+	// Flags for signing the published server.json
+	signFlag       bool
+	signingKeyPath string
+
+	// Flag for publishing a directory of server.json files
+	publishDir string
+
+	// Flag for the release notes of the published version
+	publishChangelog string
+
+	// Flag for who can see the published version
+	publishVisibility string
 )
 
 var PublishCmd = &cobra.Command{
@@ -41,10 +58,11 @@ var PublishCmd = &cobra.Command{
 	Short: "Build and publish an MCP Server or re-publish an existing server",
 	Long: `Publish an MCP Server to the registry.
 
-This command supports three modes:
+This command supports four modes:
 1. Build and publish from local folder: Provide a path to a folder containing mcp.yaml
 2. Re-publish existing server: Provide a server name from the registry to change its status to published
 3. Publish package reference: Use --registry-type flag to publish NPM/PyPI/OCI package references
+4. Batch publish: Use --dir to publish every server.json file (*.json) in a directory in bulk
 
 Examples:
   # Build and publish from local folder
@@ -56,19 +74,40 @@ Examples:
   # Re-publish an existing server from the registry
   arctl mcp publish io.github.example/my-server --version 1.0.0
 
+  # Sign the server.json with a key from 'arctl mcp keygen'
+  arctl mcp publish ./my-server --docker-url docker.io/myorg --push --sign --key arctl-signing.key
+
   # Publish an NPM package reference (name must be namespace/name format)
   arctl mcp publish myorg/filesystem-server \
     --registry-type npm \
     --package-id @modelcontextprotocol/server-filesystem \
     --version 1.0.0 \
     --description "Filesystem MCP server" \
-    --arg /path/to/directory`,
+    --arg /path/to/directory
 
-	Args: cobra.ExactArgs(1),
+  # Publish every server.json file in a directory
+  arctl mcp publish --dir ./servers/
+
+  # Publish a server only the members of a workspace can see
+  arctl mcp publish ./my-server --docker-url docker.io/myorg --push --workspace data-platform --visibility private`,
+
+	Args: cobra.MaximumNArgs(1),
 	RunE: runMCPServerPublish,
 }
 
 func runMCPServerPublish(cmd *cobra.Command, args []string) error {
+	if !models.ValidVisibility(publishVisibility) {
+		return fmt.Errorf("--visibility must be one of %s", strings.Join(models.Visibilities, ", "))
+	}
+	if publishDir != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--dir cannot be combined with a server name or folder argument")
+		}
+		return publishServerDir(publishDir)
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("requires a mcp server folder path or server name (or --dir)")
+	}
 	input := args[0]
 
 	// If registry type is provided, we're in package reference mode
@@ -202,7 +241,7 @@ func publishPackageReference(serverName string) error {
 	}
 
 	printer.PrintInfo(fmt.Sprintf("Publishing %s reference: %s", normalizedType, serverJSON.Name))
-	_, err := apiClient.PublishMCPServer(serverJSON)
+	err := publishServerJSON(serverJSON)
 	if err != nil {
 		return fmt.Errorf("failed to publish package reference: %w", err)
 	}
@@ -224,15 +263,29 @@ func publishExistingServer(serverName string, version string) error {
 		return fmt.Errorf("server %s version %s is already published", serverName, version)
 	}
 
-	servers, err := apiClient.GetAllServers()
-	if err != nil {
-		return fmt.Errorf("failed to get servers: %w", err)
-	}
+	servers := apiClient.ListServersIter(context.Background(), client.ServerListFilter{
+		Search:             serverName,
+		Version:            version,
+		IncludeUnpublished: true,
+	})
 
-	for _, server := range servers {
+	for server, err := range servers {
+		if err != nil {
+			return fmt.Errorf("failed to get servers: %w", err)
+		}
 		if server.Server.Name == serverName && server.Server.Version == version {
 			// We found the entry, it's not published yet, so we can publish it.
 			fmt.Printf("Publishing server: %s, Version: %s\n", server.Server.Name, server.Server.Version)
+			if signFlag {
+				if err := signServer(&server.Server, signingKeyPath); err != nil {
+					return err
+				}
+			}
+			if publishVisibility != "" {
+				if err := apiClient.SetVisibility("mcp", serverName, version, publishVisibility); err != nil {
+					return err
+				}
+			}
 			err = apiClient.PublishMCPServerStatus(serverName, version)
 			if err != nil {
 				return fmt.Errorf("failed to publish server: %w", err)
@@ -315,7 +368,7 @@ func buildAndPublishLocal(absPath string) error {
 			printer.PrintInfo("[DRY RUN] Would push Docker image: " + imageRef)
 		} else {
 			printer.PrintInfo("Pushing Docker image: docker push " + imageRef)
-			pushCmd := exec.Command("docker", "push", imageRef)
+			pushCmd := exec.Command(utils.ContainerEngine(), "push", imageRef)
 			pushCmd.Stdout = os.Stdout
 			pushCmd.Stderr = os.Stderr
 			if err := pushCmd.Run(); err != nil {
@@ -329,7 +382,7 @@ func buildAndPublishLocal(absPath string) error {
 		j, _ := json.Marshal(serverJSON)
 		printer.PrintInfo("[DRY RUN] Would publish mcp server to registry " + apiClient.BaseURL + ": " + string(j))
 	} else {
-		_, err = apiClient.PublishMCPServer(serverJSON)
+		err = publishServerJSON(serverJSON)
 		if err != nil {
 			return fmt.Errorf("failed to publish mcp server to registry: %w", err)
 		}
@@ -339,6 +392,48 @@ func buildAndPublishLocal(absPath string) error {
 	return nil
 }
 
+// publishServerJSON pushes and publishes a server, signing it in between when --sign is set
+// so that registries requiring signed servers accept the publish
+func publishServerJSON(serverJSON *apiv0.ServerJSON) error {
+	if err := addChangelog(serverJSON, publishChangelog); err != nil {
+		return err
+	}
+	if publishVisibility != "" {
+		models.SetServerVisibility(serverJSON, publishVisibility)
+	}
+	if !signFlag {
+		_, err := apiClient.PublishMCPServer(serverJSON)
+		return err
+	}
+
+	if _, err := apiClient.PushMCPServer(serverJSON); err != nil {
+		return err
+	}
+	if err := signServer(serverJSON, signingKeyPath); err != nil {
+		return err
+	}
+	return apiClient.PublishMCPServerStatus(serverJSON.Name, serverJSON.Version)
+}
+
+// addChangelog includes the release notes in a markdown file in the publisher-provided metadata of a server
+func addChangelog(serverJSON *apiv0.ServerJSON, path string) error {
+	if path == "" {
+		return nil
+	}
+	notes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+	if serverJSON.Meta == nil {
+		serverJSON.Meta = &apiv0.ServerMeta{}
+	}
+	if serverJSON.Meta.PublisherProvided == nil {
+		serverJSON.Meta.PublisherProvided = map[string]any{}
+	}
+	serverJSON.Meta.PublisherProvided[models.ChangelogMetadataKey] = strings.TrimSpace(string(notes))
+	return nil
+}
+
 // sanitizeRepoName converts a skill name to a docker-friendly repo name
 func sanitizeRepoName(name string) string {
 	n := strings.TrimSpace(strings.ToLower(name))
@@ -448,4 +543,17 @@ func init() {
 	PublishCmd.Flags().StringVar(&packageVersion, "package-version", "", "Package version (defaults to --version if not specified)")
 	PublishCmd.Flags().StringVar(&publishDesc, "description", "", "Server description (required for package reference publishing)")
 	PublishCmd.Flags().StringArrayVar(&publishArgs, "arg", nil, "Package argument to pass when running (repeatable, e.g., --arg /path/to/dir)")
+
+	// Flags for signing
+	PublishCmd.Flags().BoolVar(&signFlag, "sign", false, "Sign the server.json and attach the signature in the registry")
+	PublishCmd.Flags().StringVar(&signingKeyPath, "key", "", "Path to the PEM private key used with --sign (defaults to $"+signingKeyEnv+")")
+
+	// Flag for batch publishing
+	PublishCmd.Flags().StringVar(&publishDir, "dir", "", "Publish every server.json file (*.json) in this directory in bulk")
+
+	// Flag for release notes
+	PublishCmd.Flags().StringVar(&publishChangelog, "changelog", "", "Markdown file with the release notes of this version, shown by 'arctl mcp show --changelog'")
+
+	// Flag for visibility
+	PublishCmd.Flags().StringVar(&publishVisibility, "visibility", "", "Who can see the server: public (default), unlisted (left out of listings and search) or private (members of --workspace only)")
 }
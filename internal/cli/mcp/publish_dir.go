@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// batchPublishSize matches the maximum batch size accepted by the registry
+const batchPublishSize = 100
+
+// publishServerDir publishes every server.json file in dir through the batch publish endpoint
+func publishServerDir(dir string) error {
+	if signFlag {
+		return fmt.Errorf("--sign is not supported with --dir; publish signed servers one at a time")
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	slices.Sort(files)
+	if len(files) == 0 {
+		return fmt.Errorf("no server.json files (*.json) found in %s", dir)
+	}
+
+	servers := make([]*apiv0.ServerJSON, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var server apiv0.ServerJSON
+		if err := json.Unmarshal(data, &server); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if publishVisibility != "" {
+			models.SetServerVisibility(&server, publishVisibility)
+		}
+		servers = append(servers, &server)
+	}
+
+	if dryRunFlag {
+		for i, server := range servers {
+			printer.PrintInfo(fmt.Sprintf("[DRY RUN] Would publish %s (v%s) from %s", server.Name, server.Version, filepath.Base(files[i])))
+		}
+		return nil
+	}
+
+	printer.PrintInfo(fmt.Sprintf("Publishing %d servers from %s to %s", len(servers), dir, apiClient.BaseURL))
+	var results []models.BatchPublishResult
+	failed := 0
+	for start := 0; start < len(servers); start += batchPublishSize {
+		end := min(start+batchPublishSize, len(servers))
+		resp, err := apiClient.PublishMCPServersBatch(servers[start:end], true)
+		if err != nil {
+			return fmt.Errorf("failed to publish servers: %w", err)
+		}
+		for _, r := range resp.Results {
+			r.Index += start
+			results = append(results, r)
+		}
+		failed += resp.Failed
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("File", "Name", "Version", "Status", "Error")
+	for _, r := range results {
+		t.AddRow(filepath.Base(files[r.Index]), r.Name, r.Version, r.Status, r.Error)
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d servers failed to publish", failed, len(servers))
+	}
+	printer.PrintSuccess(fmt.Sprintf("Published %d servers", len(servers)))
+	return nil
+}
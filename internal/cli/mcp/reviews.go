@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reviewsRating  int
+	reviewsComment string
+)
+
+var ReviewsCmd = &cobra.Command{
+	Use:   "reviews <server-name>",
+	Short: "Show or submit ratings and reviews of an MCP server",
+	Long: `Shows the average rating and newest reviews of an MCP server.
+
+With --rate, rates the server (1-5 stars) as the signed-in user, optionally with a short --comment.
+Each user has one review per server; rating again replaces it.`,
+	Example: `  arctl mcp reviews com.example/weather
+  arctl mcp reviews com.example/weather --rate 5 --comment "Fast and reliable"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviews,
+}
+
+func init() {
+	ReviewsCmd.Flags().IntVar(&reviewsRating, "rate", 0, "Rate the server from 1 to 5 stars")
+	ReviewsCmd.Flags().StringVar(&reviewsComment, "comment", "", "Short review to submit with --rate")
+}
+
+func runReviews(cmd *cobra.Command, args []string) error {
+	serverName := args[0]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	if cmd.Flags().Changed("rate") {
+		if _, err := apiClient.SubmitReview("mcp", serverName, reviewsRating, reviewsComment); err != nil {
+			return err
+		}
+		printer.PrintSuccess(fmt.Sprintf("Rated %s %d/5", serverName, reviewsRating))
+	} else if reviewsComment != "" {
+		return fmt.Errorf("--comment requires --rate")
+	}
+
+	resp, err := apiClient.ListReviews("mcp", serverName)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(resp)
+	}
+
+	if resp.Rating.Count == 0 {
+		fmt.Printf("%s has no reviews yet\n", serverName)
+		return nil
+	}
+	fmt.Printf("%s: %s %.1f/5 from %d review(s)\n\n", serverName, stars(resp.Rating.Average), resp.Rating.Average, resp.Rating.Count)
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Rating", "Author", "Review", "Updated")
+	for _, r := range resp.Reviews {
+		t.AddRow(stars(float64(r.Rating)), r.Author, printer.TruncateString(r.Body, 60), printer.FormatAge(r.UpdatedAt))
+	}
+	return t.Render()
+}
+
+// stars renders a rating as filled and empty stars, rounded to the nearest star
+func stars(rating float64) string {
+	filled := min(max(int(rating+0.5), 0), 5)
+	return strings.Repeat("★", filled) + strings.Repeat("☆", 5-filled)
+}
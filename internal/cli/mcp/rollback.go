@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rollbackToRevision int
+	rollbackList       bool
+)
+
+var RollbackCmd = &cobra.Command{
+	Use:   "rollback <server-name>",
+	Short: "Roll a deployed MCP server back to an earlier revision",
+	Long: `Roll a deployed MCP server back to the version and configuration of an earlier revision.
+
+The registry records a revision every time a deployment is created, its configuration or origin changes, a canary
+is promoted or it is rolled back. Without --to-revision the server returns to the revision before the latest one.
+Use --list to show the revision history instead.`,
+	Example: `  arctl mcp rollback io.github.user/weather
+  arctl mcp rollback io.github.user/weather --to-revision 3
+  arctl mcp rollback io.github.user/weather --list`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runRollback,
+	SilenceUsage:  true,  // Don't show usage on rollback errors
+	SilenceErrors: false, // Still show error messages
+}
+
+func init() {
+	RollbackCmd.Flags().IntVar(&rollbackToRevision, "to-revision", 0, "Revision to roll back to (defaults to the previous revision)")
+	RollbackCmd.Flags().BoolVar(&rollbackList, "list", false, "List the revisions of the deployment instead of rolling back")
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	serverName := args[0]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	if rollbackList {
+		return listRevisions(serverName)
+	}
+	if rollbackToRevision < 0 {
+		return fmt.Errorf("--to-revision must be a positive revision number")
+	}
+
+	deployment, err := apiClient.RollbackDeployment(serverName, "mcp", rollbackToRevision)
+	if err != nil {
+		return fmt.Errorf("failed to roll back %s: %w", serverName, err)
+	}
+
+	fmt.Printf("✓ Rolled %s back to v%s\n", deployment.ServerName, deployment.Version)
+	fmt.Println("The registry will reconcile containers automatically.")
+	return nil
+}
+
+func listRevisions(serverName string) error {
+	revisions, err := apiClient.ListDeploymentRevisions(serverName, "mcp")
+	if err != nil {
+		return fmt.Errorf("failed to list revisions of %s: %w", serverName, err)
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(revisions)
+	}
+
+	if len(revisions) == 0 {
+		fmt.Printf("No revisions recorded for %s\n", serverName)
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Revision", "Version", "Change", "Target", "Settings", "Created")
+	for _, rev := range revisions {
+		target := rev.Target
+		if target == "" {
+			target = rev.Runtime
+		}
+		t.AddRow(strconv.Itoa(rev.Revision), rev.Version, rev.Change, target, strconv.Itoa(len(rev.Config)), printer.FormatAge(rev.CreatedAt))
+	}
+	return t.Render()
+}
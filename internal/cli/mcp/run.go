@@ -11,11 +11,13 @@ import (
 	"path/filepath"
 	"syscall"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/mcp/manifest"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/dockercompose"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
 	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/spf13/cobra"
 	"github.com/stoewer/go-strcase"
@@ -32,7 +34,7 @@ var (
 )
 
 var RunCmd = &cobra.Command{
-	Use:   "run <server-name|path>",
+	Use:   "run [server-name|path]",
 	Short: "Run an MCP server",
 	Long: `Run an MCP server locally.
 
@@ -40,9 +42,11 @@ You can run either:
   - A server from the registry by name (e.g., 'arctl mcp run @modelcontextprotocol/server-everything')
   - A local MCP project by path (e.g., 'arctl mcp run .' or 'arctl mcp run ./my-mcp-server')
 
-For local projects, the server must be built first using 'arctl mcp build'.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRun,
+For local projects, the server must be built first using 'arctl mcp build'. Without an argument, an interactive
+list of the published servers opens; type to filter it.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completion.Names(completion.Servers),
+	RunE:              runRun,
 }
 
 func init() {
@@ -56,22 +60,27 @@ func init() {
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
-	serverNameOrPath := args[0]
-
-	if isLocalPath(serverNameOrPath) {
-		return runLocalMCPServer(serverNameOrPath)
+	if len(args) > 0 && isLocalPath(args[0]) {
+		return runLocalMCPServer(args[0])
 	}
 
 	if apiClient == nil {
 		return fmt.Errorf("API client not initialized")
 	}
 
+	serverNameOrPath, err := completion.NameArg(completion.Servers, args)
+	if err != nil {
+		return err
+	}
+
 	// Use the common server version selection logic
 	server, err := selectServerVersion(serverNameOrPath, runVersion, runYes)
 	if err != nil {
 		return err
 	}
 
+	_ = apiClient.ReportUsage("mcp", server.Server.Name, models.UsageEventDownload)
+
 	// Proceed with running the server
 	if err := runMCPServerWithRuntime(server); err != nil {
 		return fmt.Errorf("error running MCP server: %w", err)
@@ -190,7 +199,7 @@ func waitForShutdown(runtimeDir, projectName string, inspectorCmd *exec.Cmd) err
 
 	// Stop the docker compose services
 	fmt.Println("Stopping Docker containers...")
-	stopCmd := exec.Command("docker", "compose", "-p", projectName, "down")
+	stopCmd := utils.ComposeCmd(context.Background(), "-p", projectName, "down")
 	stopCmd.Dir = runtimeDir
 	stopCmd.Stdout = os.Stdout
 	stopCmd.Stderr = os.Stderr
@@ -367,7 +376,7 @@ func runLocalMCPServerWithDocker(manifest *manifest.ProjectManifest, imageName s
 	fmt.Println()
 
 	// Create the docker run command
-	dockerCmd := exec.Command("docker", args...)
+	dockerCmd := exec.Command(utils.ContainerEngine(), args...)
 	dockerCmd.Stdout = os.Stdout
 	dockerCmd.Stderr = os.Stderr
 	dockerCmd.Stdin = os.Stdin
@@ -428,7 +437,7 @@ func waitForDockerContainer(dockerCmd *exec.Cmd, containerName string, inspector
 
 		// Stop the container
 		fmt.Println("Stopping Docker container...")
-		stopCmd := exec.Command("docker", "stop", containerName)
+		stopCmd := exec.Command(utils.ContainerEngine(), "stop", containerName)
 		if err := stopCmd.Run(); err != nil {
 			fmt.Printf("Warning: Failed to stop container: %v\n", err)
 		} else {
@@ -455,7 +464,7 @@ func waitForDockerContainer(dockerCmd *exec.Cmd, containerName string, inspector
 
 // checkDockerImageExists verifies that a Docker image exists locally
 func checkDockerImageExists(imageName string) error {
-	cmd := exec.Command("docker", "image", "inspect", imageName)
+	cmd := exec.Command(utils.ContainerEngine(), "image", "inspect", imageName)
 	cmd.Stdout = nil
 	cmd.Stderr = nil
 	if err := cmd.Run(); err != nil {
@@ -1,32 +1,52 @@
 package mcp
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/prompt"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/spf13/cobra"
 )
 
 var (
-	showOutputFormat string
-	showVersion      string
+	showVersion    string
+	showTools      bool
+	showIntrospect bool
+	showChangelog  bool
 )
 
 var ShowCmd = &cobra.Command{
 	Use:   "show <server-name>",
 	Short: "Show details of an MCP server",
-	Long:  `Shows detailed information about an MCP server.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runShow,
+	Long: `Shows detailed information about an MCP server.
+
+With --tools, shows the tools, resources and prompts the server reported when the registry last introspected it.
+--introspect asks the registry to start or connect to the server and refresh that inventory first.
+With --changelog, shows the release notes of each version and the server.json fields that changed since the
+version before it.`,
+	Example: `  arctl mcp show io.github.example/weather
+  arctl mcp show io.github.example/weather --tools
+  arctl mcp show io.github.example/weather --tools --introspect --version 1.2.0
+  arctl mcp show io.github.example/weather --changelog`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.Names(completion.Servers),
+	RunE:              runShow,
 }
 
 func init() {
-	ShowCmd.Flags().StringVarP(&showOutputFormat, "output", "o", "table", "Output format (table, json)")
 	ShowCmd.Flags().StringVar(&showVersion, "version", "", "Show specific version of the server")
+	ShowCmd.Flags().BoolVar(&showTools, "tools", false, "Show the tools, resources and prompts of the server")
+	ShowCmd.Flags().BoolVar(&showIntrospect, "introspect", false, "Refresh the tool inventory before showing it (implies --tools)")
+	ShowCmd.Flags().BoolVar(&showChangelog, "changelog", false, "Show the release notes and server.json changes of each version")
 }
 
 func runShow(cmd *cobra.Command, args []string) error {
@@ -42,6 +62,21 @@ func runShow(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Several different servers share the short name; let the user pick one when possible
+	if !printer.Format().IsStructured() && prompt.IsInteractive() {
+		if groups := groupServersByBaseName(servers); len(groups) > 1 {
+			candidates := make([]*v0.ServerResponse, len(groups))
+			for i, group := range groups {
+				candidates[i] = group.Servers[0]
+			}
+			selected, err := selectServerMatch(serverName, candidates)
+			if err != nil {
+				return err
+			}
+			servers = groups[slices.Index(candidates, selected)].Servers
+		}
+	}
+
 	// Filter by version if specified
 	if showVersion != "" {
 		var filteredServers []*v0.ServerResponse
@@ -65,25 +100,28 @@ func runShow(cmd *cobra.Command, args []string) error {
 		servers = filteredServers
 	}
 
-	// Handle JSON output format
-	if showOutputFormat == "json" {
+	if showTools || showIntrospect {
+		groups := groupServersByBaseName(servers)
+		if len(groups) > 1 {
+			return fmt.Errorf("%d servers match '%s', use the full server name", len(groups), serverName)
+		}
+		return runShowTools(groups[0].BaseName, showVersion)
+	}
+
+	if showChangelog {
+		groups := groupServersByBaseName(servers)
+		if len(groups) > 1 {
+			return fmt.Errorf("%d servers match '%s', use the full server name", len(groups), serverName)
+		}
+		return runShowChangelog(groups[0].BaseName, showVersion)
+	}
+
+	if printer.Format().IsStructured() {
+		// A single server is output as an object, several as an array
 		if len(servers) == 1 {
-			// Single server - output as object
-			fmt.Println(servers[0])
-		} else {
-			// Multiple servers - output as array
-			fmt.Println("[")
-			for i, server := range servers {
-				fmt.Print(server)
-				if i < len(servers)-1 {
-					fmt.Println(",")
-				} else {
-					fmt.Println()
-				}
-			}
-			fmt.Println("]")
+			return printer.PrintStructured(servers[0])
 		}
-		return nil
+		return printer.PrintStructured(servers)
 	}
 
 	// Group servers by base name (same server, different versions)
@@ -172,11 +210,155 @@ func showServerDetails(server *v0.ServerResponse, otherVersions []string) {
 	t.AddRow("Status", registryStatus)
 	t.AddRow("Updated", printer.EmptyValueOrDefault(updatedAt, "<none>"))
 	t.AddRow("Website", printer.EmptyValueOrDefault(server.Server.WebsiteURL, "<none>"))
+	t.AddRow("License", licenseSummary(models.LicenseFromMeta(server.Server.Meta)))
+	t.AddRow("Tags", printer.EmptyValueOrDefault(strings.Join(models.ServerTagsFromMeta(server.Server.Meta), ", "), "<none>"))
+	if vulns := models.VulnerabilitySummaryFromMeta(server.Server.Meta); vulns != nil {
+		t.AddRow("Vulnerabilities", vulnerabilitySummary(vulns))
+	}
 	if err := t.Render(); err != nil {
 		printer.PrintError(fmt.Sprintf("failed to render table: %v", err))
 	}
 }
 
+// runShowTools prints the introspected capabilities of a server version, refreshing them first with --introspect
+func runShowChangelog(serverName, version string) error {
+	changelog, err := apiClient.GetServerChangelog(serverName)
+	if err != nil {
+		return err
+	}
+	if version != "" {
+		changelog.Versions = slices.DeleteFunc(changelog.Versions, func(e models.ChangelogEntry) bool {
+			return e.Version != version
+		})
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(changelog)
+	}
+
+	for i, entry := range changelog.Versions {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("## %s (%s)\n", entry.Version, printer.FormatTimestampShort(entry.PublishedAt))
+		if entry.Notes != "" {
+			fmt.Printf("\n%s\n", strings.TrimSpace(printer.RenderMarkdown(entry.Notes, "text/markdown")))
+		}
+		if entry.PreviousVersion == "" {
+			fmt.Println("\nFirst version")
+			continue
+		}
+		if len(entry.Changes) == 0 {
+			fmt.Printf("\nNo changes to server.json since %s\n", entry.PreviousVersion)
+			continue
+		}
+		fmt.Printf("\nChanges since %s:\n", entry.PreviousVersion)
+		t := printer.NewTablePrinter(os.Stdout)
+		t.SetHeaders("Field", "Change", "Previous", "Current")
+		for _, c := range entry.Changes {
+			t.AddRow(c.Field, c.Change, printer.TruncateString(c.Previous, 40), printer.TruncateString(c.Current, 40))
+		}
+		if err := t.Render(); err != nil {
+			return fmt.Errorf("failed to render table: %w", err)
+		}
+	}
+	return nil
+}
+
+func runShowTools(serverName, version string) error {
+	var (
+		caps *models.ServerCapabilities
+		err  error
+	)
+	if showIntrospect {
+		caps, err = apiClient.IntrospectServer(serverName, version)
+	} else {
+		caps, err = apiClient.GetServerTools(serverName, version)
+	}
+	if err != nil {
+		return err
+	}
+	if caps == nil {
+		fmt.Printf("Server '%s' has not been introspected yet. Run with --introspect to do it now.\n", serverName)
+		return nil
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(caps)
+	}
+
+	fmt.Printf("%s v%s (%s, introspected %s ago)\n", caps.ServerName, caps.Version, caps.Source, printer.FormatAge(caps.IntrospectedAt))
+	if caps.Error != "" {
+		fmt.Printf("Introspection failed: %s\n", caps.Error)
+		return nil
+	}
+
+	fmt.Printf("\nTools (%d):\n", len(caps.Tools))
+	if len(caps.Tools) > 0 {
+		t := printer.NewTablePrinter(os.Stdout)
+		t.SetHeaders("Name", "Description")
+		for _, tool := range caps.Tools {
+			t.AddRow(tool.Name, printer.TruncateString(printer.EmptyValueOrDefault(tool.Description, "<none>"), 80))
+		}
+		if err := t.Render(); err != nil {
+			return fmt.Errorf("failed to render table: %w", err)
+		}
+	}
+	if len(caps.Resources) > 0 {
+		fmt.Printf("\nResources (%d):\n", len(caps.Resources))
+		t := printer.NewTablePrinter(os.Stdout)
+		t.SetHeaders("URI", "Name", "MIME Type")
+		for _, r := range caps.Resources {
+			t.AddRow(r.URI, r.Name, printer.EmptyValueOrDefault(r.MIMEType, "<none>"))
+		}
+		if err := t.Render(); err != nil {
+			return fmt.Errorf("failed to render table: %w", err)
+		}
+	}
+	if len(caps.Prompts) > 0 {
+		fmt.Printf("\nPrompts (%d):\n", len(caps.Prompts))
+		t := printer.NewTablePrinter(os.Stdout)
+		t.SetHeaders("Name", "Arguments", "Description")
+		for _, p := range caps.Prompts {
+			argNames := make([]string, 0, len(p.Arguments))
+			for _, arg := range p.Arguments {
+				argNames = append(argNames, arg.Name)
+			}
+			t.AddRow(p.Name, printer.EmptyValueOrDefault(strings.Join(argNames, ", "), "<none>"), printer.TruncateString(p.Description, 60))
+		}
+		if err := t.Render(); err != nil {
+			return fmt.Errorf("failed to render table: %w", err)
+		}
+	}
+	return nil
+}
+
+// licenseSummary formats a license as "GPL-3.0-only (copyleft)", flagging licenses that constrain redistribution
+func licenseSummary(license *models.ServerLicense) string {
+	if license == nil {
+		return "<unknown>"
+	}
+	if license.Copyleft() {
+		return license.SPDX + " (copyleft)"
+	}
+	return license.SPDX
+}
+
+// vulnerabilitySummary formats image scan results as "critical=1, high=3 (trivy, scanned 2d ago)"
+func vulnerabilitySummary(v *models.VulnerabilitySummary) string {
+	text := fmt.Sprintf("critical=%d, high=%d, medium=%d, low=%d", v.Critical, v.High, v.Medium, v.Low)
+	failed := 0
+	for _, img := range v.Images {
+		if img.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		text += fmt.Sprintf(", %d image(s) not scanned", failed)
+	}
+	return fmt.Sprintf("%s (%s, scanned %s ago)", text, v.Scanner, printer.FormatAge(v.ScannedAt))
+}
+
 // ServerVersionGroup groups servers with the same base name but different versions
 type ServerVersionGroup struct {
 	BaseName string
@@ -212,23 +394,22 @@ func groupServersByBaseName(servers []*v0.ServerResponse) []ServerVersionGroup {
 }
 
 func findServersByName(searchName string) []*v0.ServerResponse {
-	servers, err := apiClient.GetPublishedServers()
-	if err != nil {
-		log.Fatalf("Failed to get servers: %v", err)
-	}
+	// Narrow the listing server-side by substring so the whole catalog isn't downloaded
+	servers := apiClient.ListServersIter(context.Background(), client.ServerListFilter{Search: searchName})
+
+	// An exact match on the full name wins; otherwise match on the name part (after /)
+	var matches []*v0.ServerResponse
+	searchLower := strings.ToLower(searchName)
+
+	for s, err := range servers {
+		if err != nil {
+			log.Fatalf("Failed to get servers: %v", err)
+		}
 
-	// First, try exact match with full name
-	for _, s := range servers {
 		if s.Server.Name == searchName {
 			return []*v0.ServerResponse{s}
 		}
-	}
-
-	// If no exact match, search for name part (after /)
-	var matches []*v0.ServerResponse
-	searchLower := strings.ToLower(searchName)
 
-	for _, s := range servers {
 		// Extract name part (after /)
 		parts := strings.Split(s.Server.Name, "/")
 		var namePart string
@@ -239,8 +420,7 @@ func findServersByName(searchName string) []*v0.ServerResponse {
 		}
 
 		if namePart == searchLower {
-			serverCopy := s
-			matches = append(matches, serverCopy)
+			matches = append(matches, s)
 		}
 	}
 
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var StatusCmd = &cobra.Command{
+	Use:   "status [server-name]",
+	Short: "Show the health of deployed MCP servers",
+	Long: `Shows the container state and healthcheck result of deployed MCP servers.
+
+On the local runtime the registry checks deployments periodically and restarts failed containers. A server that
+keeps failing after its restart budget (AGENT_REGISTRY_HEALTH_RESTART_BUDGET) is marked failed. Stdio and remote
+servers are served by the agent gateway and report its state.`,
+	Example: `  arctl mcp status
+  arctl mcp status io.github.user/weather -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStatus,
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	health, err := apiClient.GetDeploymentHealth()
+	if err != nil {
+		return fmt.Errorf("failed to get deployment health: %w", err)
+	}
+
+	servers := make([]models.DeploymentHealth, 0, len(health))
+	for _, h := range health {
+		if h.ResourceType != "mcp" || (len(args) == 1 && h.ServerName != args[0]) {
+			continue
+		}
+		servers = append(servers, h)
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(servers)
+	}
+
+	if len(servers) == 0 {
+		if len(args) == 1 {
+			return fmt.Errorf("%s is not deployed", args[0])
+		}
+		fmt.Println("No MCP servers are deployed")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Name", "Version", "Runtime", "Status", "State", "Health", "Replicas", "Restarts")
+	unhealthy := 0
+	for _, h := range servers {
+		target := h.Runtime
+		if target == "" {
+			target = "local"
+		}
+		healthCol := h.Health
+		if healthCol == "" {
+			healthCol = "-"
+		}
+		if h.Service == runtime.GatewayServiceName {
+			healthCol += " (gateway)"
+		}
+		if h.Status == models.DeploymentStatusFailed || h.Health == runtime.ServiceHealthUnhealthy ||
+			(h.State != runtime.ServiceStateRunning && h.State != runtime.ServiceStateUnknown) {
+			unhealthy++
+		}
+		replicas := fmt.Sprintf("%d/%d", h.CurrentReplicas, h.DesiredReplicas)
+		if h.State == runtime.ServiceStateUnknown || h.Service == runtime.GatewayServiceName {
+			replicas = fmt.Sprintf("-/%d", h.DesiredReplicas)
+		}
+		t.AddRow(h.ServerName, h.Version, target, h.Status, h.State, healthCol, replicas, strconv.Itoa(h.Restarts))
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	if unhealthy > 0 {
+		fmt.Printf("\n%d server(s) unhealthy. Check their logs with `docker compose logs` in the runtime directory.\n", unhealthy)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var TargetsCmd = &cobra.Command{
+	Use:   "targets",
+	Short: "List the deployment targets of the registry",
+	Long: `Lists the named targets MCP servers and agents can be deployed to with --target.
+
+Besides the built-in "local" docker host and "kubernetes" context, the registry server can be configured with
+remote docker hosts (ssh:// or tcp://) and further kubernetes contexts in the file named by
+AGENT_REGISTRY_DEPLOYMENT_TARGETS_FILE.`,
+	Example: `  arctl mcp targets
+  arctl mcp targets -o json`,
+	Args: cobra.NoArgs,
+	RunE: runTargets,
+}
+
+func runTargets(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	targets, err := apiClient.ListDeploymentTargets()
+	if err != nil {
+		return fmt.Errorf("failed to list deployment targets: %w", err)
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(targets)
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Name", "Type", "Runtime", "Endpoint", "Namespace")
+	for _, target := range targets {
+		endpoint := target.Host
+		if target.Context != "" {
+			endpoint = "context " + target.Context
+		}
+		if endpoint == "" {
+			endpoint = "-"
+		}
+		namespace := target.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		t.AddRow(target.Name, target.Type, target.Runtime(), endpoint, namespace)
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	return nil
+}
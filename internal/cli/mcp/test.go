@@ -0,0 +1,261 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/introspect"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/dockercompose"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/internal/version"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testVersion    string
+	testEnvVars    []string
+	testArgVars    []string
+	testHeaderVars []string
+	testTool       string
+	testToolArgs   string
+	testTimeout    time.Duration
+	testYes        bool
+	testVerbose    bool
+)
+
+var TestCmd = &cobra.Command{
+	Use:   "test <server-name>",
+	Short: "Try an MCP server before installing it",
+	Long: `Starts an MCP server from the registry in a throwaway local runtime, performs the MCP handshake and lists its
+tools, resources and prompts. With --tool, one tool is also called with the JSON arguments of --tool-args.
+
+Everything is torn down when the command exits, including on CTRL+C. The command fails when the server cannot be
+started, the handshake fails, or the called tool reports an error.`,
+	Example: `  arctl mcp test io.github.user/weather
+  arctl mcp test io.github.user/weather -e API_KEY=... --tool get_forecast --tool-args '{"city":"Paris"}'
+  arctl mcp test io.github.user/weather --version 1.2.0 -o json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.Names(completion.Servers),
+	RunE:              runTest,
+	SilenceUsage:      true,
+}
+
+func init() {
+	TestCmd.Flags().StringVar(&testVersion, "version", "", "Version of the server to test")
+	TestCmd.Flags().StringArrayVarP(&testEnvVars, "env", "e", []string{}, "Environment variables (key=value)")
+	TestCmd.Flags().StringArrayVar(&testArgVars, "arg", []string{}, "Runtime arguments (key=value)")
+	TestCmd.Flags().StringArrayVar(&testHeaderVars, "header", []string{}, "Headers for remote servers (key=value)")
+	TestCmd.Flags().StringVar(&testTool, "tool", "", "Name of a tool to call")
+	TestCmd.Flags().StringVar(&testToolArgs, "tool-args", "{}", "JSON object of arguments for --tool")
+	TestCmd.Flags().DurationVar(&testTimeout, "timeout", 3*time.Minute, "How long to wait for the server to start and answer")
+	TestCmd.Flags().BoolVarP(&testYes, "yes", "y", false, "Automatically accept all prompts (use default values)")
+	TestCmd.Flags().BoolVar(&testVerbose, "verbose", false, "Enable verbose logging")
+}
+
+// testReport is the -o json output of arctl mcp test
+type testReport struct {
+	Capabilities *models.ServerCapabilities `json:"capabilities"`
+	ToolResult   *mcp.CallToolResult        `json:"toolResult,omitempty"`
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	var toolArgs map[string]any
+	if testTool != "" {
+		if err := json.Unmarshal([]byte(testToolArgs), &toolArgs); err != nil {
+			return fmt.Errorf("invalid --tool-args, expected a JSON object: %w", err)
+		}
+	}
+
+	server, err := selectServerVersion(args[0], testVersion, testYes)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, testTimeout)
+	defer cancel()
+
+	endpoint, teardown, err := startTestRuntime(ctx, server)
+	if teardown != nil {
+		defer teardown()
+	}
+	if err != nil {
+		return err
+	}
+
+	session, err := connectWithRetry(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = session.Close() }()
+
+	caps, err := introspect.ListCapabilities(ctx, session)
+	if err != nil {
+		return err
+	}
+	caps.ServerName = server.Server.Name
+	caps.Version = server.Server.Version
+
+	report := testReport{Capabilities: caps}
+	if testTool != "" {
+		report.ToolResult, err = session.CallTool(ctx, &mcp.CallToolParams{Name: testTool, Arguments: toolArgs})
+		if err != nil {
+			return fmt.Errorf("failed to call tool %s: %w", testTool, err)
+		}
+	}
+
+	if printer.Format().IsStructured() {
+		if err := printer.PrintStructured(report); err != nil {
+			return err
+		}
+	} else {
+		printTestReport(report)
+	}
+
+	if report.ToolResult != nil && report.ToolResult.IsError {
+		return fmt.Errorf("tool %s reported an error", testTool)
+	}
+	return nil
+}
+
+// startTestRuntime deploys the server into its own compose project and returns the gateway endpoint serving it.
+// The returned teardown removes the project and must be called even when an error is returned.
+func startTestRuntime(ctx context.Context, server *apiv0.ServerResponse) (string, func(), error) {
+	envValues, err := parseKeyValuePairs(testEnvVars)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse environment variables: %w", err)
+	}
+	argValues, err := parseKeyValuePairs(testArgVars)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse arguments: %w", err)
+	}
+	headerValues, err := parseKeyValuePairs(testHeaderVars)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse headers: %w", err)
+	}
+
+	projectName, runtimeDir, err := generateRuntimePaths("arctl-test-")
+	if err != nil {
+		return "", nil, err
+	}
+	agentGatewayPort, err := utils.FindAvailablePort()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find available port: %w", err)
+	}
+
+	teardown := func() {
+		if !printer.Format().IsStructured() {
+			fmt.Println("\nTearing down test runtime...")
+		}
+		downCmd := utils.ComposeCmd(context.Background(), "-p", projectName, "down", "--volumes", "--remove-orphans")
+		downCmd.Dir = runtimeDir
+		if testVerbose {
+			downCmd.Stdout = os.Stdout
+			downCmd.Stderr = os.Stderr
+		}
+		if err := downCmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to stop test containers of project %s: %v\n", projectName, err)
+		}
+		if err := os.RemoveAll(runtimeDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove runtime directory %s: %v\n", runtimeDir, err)
+		}
+	}
+
+	agentRuntime := runtime.NewAgentRegistryRuntime(
+		registry.NewTranslator(),
+		dockercompose.NewAgentGatewayTranslatorWithProjectName(runtimeDir, agentGatewayPort, projectName),
+		runtimeDir,
+		testVerbose,
+	)
+	if !printer.Format().IsStructured() {
+		fmt.Printf("Starting %s (version %s) in a test runtime...\n", server.Server.Name, server.Server.Version)
+	}
+	if err := agentRuntime.ReconcileAll(ctx, []*registry.MCPServerRunRequest{{
+		RegistryServer: &server.Server,
+		EnvValues:      envValues,
+		ArgValues:      argValues,
+		HeaderValues:   headerValues,
+	}}, nil); err != nil {
+		return "", teardown, fmt.Errorf("failed to start server: %w", err)
+	}
+
+	return fmt.Sprintf("http://localhost:%d/mcp", agentGatewayPort), teardown, nil
+}
+
+// connectWithRetry performs the MCP handshake, retrying while the gateway and the server behind it start
+func connectWithRetry(ctx context.Context, endpoint string) (*mcp.ClientSession, error) {
+	client := mcp.NewClient(&mcp.Implementation{Name: "arctl-test", Version: version.Version}, nil)
+	var lastErr error
+	for {
+		session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: endpoint, MaxRetries: -1}, nil)
+		if err == nil {
+			return session, nil
+		}
+		lastErr = err
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("server did not complete the MCP handshake within %s: %w", testTimeout, lastErr)
+			}
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+func printTestReport(report testReport) {
+	caps := report.Capabilities
+	fmt.Printf("\n✓ MCP handshake succeeded (protocol %s)\n", caps.ProtocolVersion)
+
+	fmt.Printf("\nTools (%d):\n", len(caps.Tools))
+	if len(caps.Tools) > 0 {
+		t := printer.NewTablePrinter(os.Stdout)
+		t.SetHeaders("Name", "Description")
+		for _, tool := range caps.Tools {
+			t.AddRow(tool.Name, printer.TruncateString(printer.EmptyValueOrDefault(tool.Description, "<none>"), 80))
+		}
+		if err := t.Render(); err != nil {
+			printer.PrintError(fmt.Sprintf("failed to render table: %v", err))
+		}
+	}
+	fmt.Printf("Resources: %d, prompts: %d\n", len(caps.Resources), len(caps.Prompts))
+
+	if report.ToolResult == nil {
+		return
+	}
+	if report.ToolResult.IsError {
+		fmt.Printf("\n✗ %s returned an error:\n", testTool)
+	} else {
+		fmt.Printf("\n✓ %s returned:\n", testTool)
+	}
+	for _, content := range report.ToolResult.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			fmt.Println(text.Text)
+			continue
+		}
+		data, _ := json.MarshalIndent(content, "", "  ")
+		fmt.Println(string(data))
+	}
+	if len(report.ToolResult.Content) == 0 && report.ToolResult.StructuredContent != nil {
+		data, _ := json.MarshalIndent(report.ToolResult.StructuredContent, "", "  ")
+		fmt.Println(string(data))
+	}
+}
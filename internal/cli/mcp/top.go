@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var TopCmd = &cobra.Command{
+	Use:   "top <server-name>",
+	Short: "Show the CPU and memory usage of a deployed MCP server",
+	Long: `Samples the CPU and memory usage of the containers of every deployed version of an MCP server.
+
+Docker targets are sampled with docker stats. Kubernetes targets are read from the metrics API, which requires
+metrics-server in the cluster. Stdio and remote servers are served by the agent gateway and have no containers of
+their own.`,
+	Example: `  arctl mcp top io.github.user/weather
+  arctl mcp top io.github.user/weather -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTop,
+}
+
+func runTop(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	metrics, err := apiClient.GetDeploymentMetrics(args[0], "mcp")
+	if err != nil {
+		return fmt.Errorf("failed to get deployment metrics: %w", err)
+	}
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(metrics)
+	}
+
+	if len(metrics.Containers) > 0 {
+		t := printer.NewTablePrinter(os.Stdout)
+		t.SetHeaders("Version", "Container", "CPU", "Memory", "Limit")
+		for _, c := range metrics.Containers {
+			limit := "-"
+			if c.MemoryLimitBytes > 0 {
+				limit = printer.FormatBytes(c.MemoryLimitBytes)
+			}
+			t.AddRow(c.Version, c.Name, fmt.Sprintf("%dm", c.CPUMillicores), printer.FormatBytes(c.MemoryBytes), limit)
+		}
+		if err := t.Render(); err != nil {
+			return err
+		}
+	}
+	for _, note := range metrics.Notes {
+		fmt.Println("Note: " + note)
+	}
+	return nil
+}
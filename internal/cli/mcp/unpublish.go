@@ -2,13 +2,15 @@ package mcp
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	unpublishVersion string
-	unpublishAll     bool
+	unpublishVersion  string
+	unpublishVersions []string
+	unpublishAll      bool
 )
 
 var UnpublishCmd = &cobra.Command{
@@ -19,13 +21,19 @@ var UnpublishCmd = &cobra.Command{
 This marks the server as unpublished, hiding it from public listings.
 The server data is not deleted and can be re-published later.
 
-Use --all to unpublish all versions of the server.`,
+Use --versions to unpublish several specific versions at once (other versions stay visible),
+or --all to unpublish all versions of the server.
+Use 'arctl mcp versions <server-name>' to see the publish state of each version.`,
+	Example: `  arctl mcp unpublish io.github.user/weather --version 1.2.0
+  arctl mcp unpublish io.github.user/weather --versions 1.2.0,1.2.1
+  arctl mcp unpublish io.github.user/weather --all`,
 	Args: cobra.ExactArgs(1),
 	RunE: runUnpublish,
 }
 
 func init() {
 	UnpublishCmd.Flags().StringVar(&unpublishVersion, "version", "", "Specify the version of the server to unpublish (defaults to latest)")
+	UnpublishCmd.Flags().StringSliceVar(&unpublishVersions, "versions", nil, "Comma-separated list of versions to unpublish (e.g. 1.2.0,1.2.1)")
 	UnpublishCmd.Flags().BoolVar(&unpublishAll, "all", false, "Unpublish all versions of the server")
 }
 
@@ -37,8 +45,14 @@ func runUnpublish(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate flags
-	if unpublishAll && unpublishVersion != "" {
-		return fmt.Errorf("cannot specify both --all and --version flags")
+	flagsSet := 0
+	for _, set := range []bool{unpublishAll, unpublishVersion != "", len(unpublishVersions) > 0} {
+		if set {
+			flagsSet++
+		}
+	}
+	if flagsSet > 1 {
+		return fmt.Errorf("only one of --all, --version and --versions can be specified")
 	}
 
 	// If --all flag is set, unpublish all versions
@@ -46,6 +60,10 @@ func runUnpublish(cmd *cobra.Command, args []string) error {
 		return unpublishAllVersions(serverName)
 	}
 
+	if len(unpublishVersions) > 0 {
+		return unpublishSelectedVersions(serverName, unpublishVersions)
+	}
+
 	if unpublishVersion == "" {
 		return fmt.Errorf("version is required")
 	}
@@ -115,3 +133,24 @@ func unpublishAllVersions(serverName string) error {
 
 	return nil
 }
+
+// unpublishSelectedVersions unpublishes the given versions in a single batch; either all or none are unpublished
+func unpublishSelectedVersions(serverName string, versions []string) error {
+	cleaned := make([]string, 0, len(versions))
+	for _, v := range versions {
+		if v = strings.TrimSpace(v); v != "" {
+			cleaned = append(cleaned, v)
+		}
+	}
+	if len(cleaned) == 0 {
+		return fmt.Errorf("--versions requires at least one version")
+	}
+
+	fmt.Printf("Unpublishing server: %s (versions %s)\n", serverName, strings.Join(cleaned, ", "))
+	if err := apiClient.SetServerVersionsPublished(serverName, cleaned, false); err != nil {
+		return fmt.Errorf("failed to unpublish versions: %w", err)
+	}
+
+	fmt.Printf("MCP server '%s' versions %s unpublished successfully\n", serverName, strings.Join(cleaned, ", "))
+	return nil
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateSkipRegistries bool
+)
+
+var ValidateCmd = &cobra.Command{
+	Use:   "validate [server.json]",
+	Short: "Validate a server.json file before publishing",
+	Long: `Run the checks the registry applies when publishing a server against a local server.json file:
+the schema version, name and version format, repository, website and icon URLs, package and remote
+transports, reverse-DNS namespace matching of remote and website URLs, and that each package exists
+in its registry (npm, PyPI, NuGet, OCI, MCPB) and references the server.
+
+All problems are reported at once. Errors would make the registry reject the server; warnings point
+at recommended fields that are missing. The command exits with an error when any errors are found.`,
+	Example: `  arctl mcp validate
+  arctl mcp validate ./server.json --skip-registry-check
+  arctl mcp validate ./server.json -o json`,
+	Args:          cobra.MaximumNArgs(1),
+	RunE:          runValidate,
+	SilenceUsage:  true,  // Don't show usage on validation errors
+	SilenceErrors: false, // Still show error messages
+}
+
+func init() {
+	ValidateCmd.Flags().BoolVar(&validateSkipRegistries, "skip-registry-check", false, "Skip checking that packages exist in their registries (no network access)")
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	path := "server.json"
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(data, &serverJSON); err != nil {
+		return fmt.Errorf("%s is not valid server.json: %w", path, err)
+	}
+
+	report := validators.CheckServerJSON(context.Background(), serverJSON, !validateSkipRegistries)
+	// Unknown fields are dropped by the registry, which usually means a typo
+	strict := json.NewDecoder(bytes.NewReader(data))
+	strict.DisallowUnknownFields()
+	if err := strict.Decode(&apiv0.ServerJSON{}); err != nil {
+		report.Issues = append(report.Issues, validators.Issue{
+			Severity: validators.SeverityWarning,
+			Message:  strings.TrimPrefix(err.Error(), "json: ") + "; it will be ignored",
+		})
+	}
+
+	if printer.Format().IsStructured() {
+		if err := printer.PrintStructured(report); err != nil {
+			return err
+		}
+	} else {
+		printValidationReport(path, report)
+	}
+
+	if !report.Valid() {
+		return fmt.Errorf("%s has %d error(s)", path, report.Count(validators.SeverityError))
+	}
+	return nil
+}
+
+func printValidationReport(path string, report *validators.Report) {
+	errorCount, warningCount := report.Count(validators.SeverityError), report.Count(validators.SeverityWarning)
+	if errorCount == 0 && warningCount == 0 {
+		fmt.Printf("✓ %s is valid\n", path)
+		return
+	}
+
+	fmt.Printf("%s: %d error(s), %d warning(s)\n", path, errorCount, warningCount)
+	for _, severity := range []string{validators.SeverityError, validators.SeverityWarning} {
+		for _, issue := range report.Issues {
+			if issue.Severity != severity {
+				continue
+			}
+			marker := "✗"
+			if severity == validators.SeverityWarning {
+				marker = "!"
+			}
+			if issue.Field != "" {
+				fmt.Printf("  %s %s: %s\n", marker, issue.Field, issue.Message)
+			} else {
+				fmt.Printf("  %s %s\n", marker, issue.Message)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var VersionsCmd = &cobra.Command{
+	Use:   "versions <server-name>",
+	Short: "List all versions of an MCP server with their publish state",
+	Long: `Lists every version of an MCP server together with its lifecycle status
+(active, deprecated, deleted) and whether it is published in public listings.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVersions,
+}
+
+func runVersions(cmd *cobra.Command, args []string) error {
+	serverName := args[0]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	resp, err := apiClient.GetServerVersionsStatus(serverName)
+	if err != nil {
+		return fmt.Errorf("failed to get server versions: %w", err)
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(resp)
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Version", "Published", "Status", "Latest", "Updated")
+	for _, v := range resp.Versions {
+		published := "unpublished"
+		if v.Published {
+			published = "published"
+		}
+		latest := ""
+		if v.IsLatest {
+			latest = "*"
+		}
+		t.AddRow(v.Version, published, v.Status, latest, printer.FormatAge(v.UpdatedAt))
+	}
+	return t.Render()
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	namespaceGitHubToken string
+)
+
+var authNamespaceCmd = &cobra.Command{
+	Use:   "namespace",
+	Short: "Verify ownership of publishing namespaces",
+	Long: `Verify that you own a namespace so that only you can publish under it.
+io.github.<owner> namespaces are verified through GitHub user or organization membership,
+other namespaces (e.g. com.example) through a DNS TXT record on the domain they name.`,
+}
+
+var authNamespaceListCmd = &cobra.Command{
+	Use:   "list [namespace]",
+	Short: "List verified namespaces",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runAuthNamespaceList,
+}
+
+var authNamespaceChallengeCmd = &cobra.Command{
+	Use:   "challenge <namespace>",
+	Short: "Show the DNS TXT record that verifies a namespace",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAuthNamespaceChallenge,
+}
+
+var authNamespaceVerifyCmd = &cobra.Command{
+	Use:   "verify <namespace>",
+	Short: "Verify ownership of a namespace",
+	Example: `  arctl auth namespace verify io.github.myorg --github-token $GITHUB_TOKEN
+  arctl auth namespace challenge com.example   # publish the shown TXT record, then:
+  arctl auth namespace verify com.example`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuthNamespaceVerify,
+}
+
+func init() {
+	authNamespaceVerifyCmd.Flags().StringVar(&namespaceGitHubToken, "github-token", "",
+		"GitHub token used to check private organization membership (defaults to GITHUB_TOKEN)")
+
+	authNamespaceCmd.AddCommand(authNamespaceListCmd, authNamespaceChallengeCmd, authNamespaceVerifyCmd)
+	AuthCmd.AddCommand(authNamespaceCmd)
+}
+
+func runAuthNamespaceList(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	filter := ""
+	if len(args) == 1 {
+		filter = args[0]
+	}
+	namespaces, err := apiClient.ListNamespaces(filter)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(namespaces)
+	}
+
+	if len(namespaces) == 0 {
+		fmt.Println("No verified namespaces found")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Namespace", "Owner", "Method", "Verified")
+	for _, ns := range namespaces {
+		t.AddRow(ns.Namespace, printer.TruncateString(ns.Subject, 30), ns.Method, printer.FormatAge(ns.VerifiedAt))
+	}
+	return t.Render()
+}
+
+func runAuthNamespaceChallenge(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	challenge, err := apiClient.GetNamespaceChallenge(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("To verify %s, add this DNS TXT record and run `arctl auth namespace verify %s`:\n\n", challenge.Namespace, challenge.Namespace)
+	fmt.Printf("  Name:  %s\n", challenge.RecordName)
+	fmt.Printf("  Value: %s\n", challenge.RecordValue)
+	return nil
+}
+
+func runAuthNamespaceVerify(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	githubToken := namespaceGitHubToken
+	if githubToken == "" {
+		githubToken = os.Getenv("GITHUB_TOKEN")
+	}
+	verified, err := apiClient.VerifyNamespace(args[0], githubToken)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Verified namespace %s for %s (%s)\n", verified.Namespace, verified.Subject, verified.Method)
+	return nil
+}
@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Item is an entry offered by FuzzySelect
+type Item struct {
+	// Name is shown as the item title and is what the filter matches against
+	Name string
+	// Detail is shown below the name, e.g. the version and description
+	Detail string
+}
+
+// fuzzyItem adapts Item to the list component, remembering its position in the caller's slice
+type fuzzyItem struct {
+	Item
+	index int
+}
+
+func (i fuzzyItem) Title() string       { return i.Name }
+func (i fuzzyItem) Description() string { return i.Detail }
+func (i fuzzyItem) FilterValue() string { return i.Name }
+
+var fuzzyDocStyle = lipgloss.NewStyle().Margin(1, 2)
+
+type fuzzyModel struct {
+	list      list.Model
+	selected  int
+	cancelled bool
+}
+
+func (m fuzzyModel) Init() tea.Cmd { return nil }
+
+func (m fuzzyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := fuzzyDocStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v)
+		return m, nil
+
+	case tea.KeyMsg:
+		if key.Matches(msg, m.list.KeyMap.ForceQuit) {
+			m.cancelled = true
+			return m, tea.Quit
+		}
+		filtering := m.list.FilterState() == list.Filtering
+		if !filtering && key.Matches(msg, m.list.KeyMap.Quit) && !m.list.IsFiltered() {
+			m.cancelled = true
+			return m, tea.Quit
+		}
+		if msg.Type == tea.KeyEnter {
+			// Enter while typing a filter accepts it and picks the best match right away
+			if filtering {
+				m.list, _ = m.list.Update(msg)
+			}
+			if item, ok := m.list.SelectedItem().(fuzzyItem); ok {
+				m.selected = item.index
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m fuzzyModel) View() string {
+	return fuzzyDocStyle.Render(m.list.View())
+}
+
+// FuzzySelect opens a full-screen list of items that is filtered as the user types and returns the index of the
+// chosen item. It returns ErrCancelled if the user quits and ErrNotInteractive when not attached to a terminal.
+func FuzzySelect(title string, items []Item) (int, error) {
+	if !IsInteractive() {
+		return -1, ErrNotInteractive
+	}
+	if len(items) == 0 {
+		return -1, errors.New("nothing to select from")
+	}
+
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = fuzzyItem{Item: item, index: i}
+	}
+	l := list.New(listItems, list.NewDefaultDelegate(), 0, 0)
+	l.Title = title
+	l.SetStatusBarItemName("item", "items")
+	// Start in filter mode so typing narrows the list immediately
+	l.SetFilterState(list.Filtering)
+
+	result, err := tea.NewProgram(fuzzyModel{list: l, selected: -1}, tea.WithAltScreen()).Run()
+	if err != nil {
+		return -1, fmt.Errorf("failed to run selector: %w", err)
+	}
+	m := result.(fuzzyModel)
+	if m.cancelled || m.selected < 0 {
+		return -1, ErrCancelled
+	}
+	return m.selected, nil
+}
@@ -0,0 +1,34 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Input asks for a single line of input, without echoing it when secret. It returns ErrNotInteractive when not
+// attached to a terminal and ErrCancelled when the input ends.
+func Input(label string, secret bool) (string, error) {
+	if !IsInteractive() {
+		return "", ErrNotInteractive
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "%s: ", label)
+
+	if secret {
+		value, err := term.ReadPassword(int(os.Stdin.Fd()))
+		_, _ = fmt.Fprintln(os.Stdout)
+		if err != nil {
+			return "", ErrCancelled
+		}
+		return strings.TrimSpace(string(value)), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", ErrCancelled
+	}
+	return strings.TrimSpace(line), nil
+}
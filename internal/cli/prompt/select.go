@@ -0,0 +1,85 @@
+// Package prompt contains interactive helpers shared by arctl commands
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// ErrNotInteractive is returned by Select when stdin is not a terminal
+var ErrNotInteractive = errors.New("not running in an interactive terminal")
+
+// ErrCancelled is returned by Select when the user aborts the selection
+var ErrCancelled = errors.New("selection cancelled")
+
+// IsInteractive reports whether stdin and stdout are attached to a terminal
+func IsInteractive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Select prints the rows as a numbered table and asks the user to pick one.
+// It returns the index of the selected row, ErrCancelled if the user quits,
+// or ErrNotInteractive when not attached to a terminal.
+func Select(title string, headers []string, rows [][]string) (int, error) {
+	if !IsInteractive() {
+		return -1, ErrNotInteractive
+	}
+	return selectFrom(os.Stdin, os.Stdout, title, headers, rows)
+}
+
+func selectFrom(in io.Reader, out io.Writer, title string, headers []string, rows [][]string) (int, error) {
+	if len(rows) == 0 {
+		return -1, errors.New("nothing to select from")
+	}
+
+	_, _ = fmt.Fprintln(out, title)
+	t := printer.NewTablePrinter(out)
+	t.SetHeaders(append([]string{"#"}, headers...)...)
+	for i, row := range rows {
+		values := make([]any, 0, len(row)+1)
+		values = append(values, i+1)
+		for _, v := range row {
+			values = append(values, v)
+		}
+		t.AddRow(values...)
+	}
+	if err := t.Render(); err != nil {
+		return -1, fmt.Errorf("failed to render table: %w", err)
+	}
+
+	reader := bufio.NewReader(in)
+	for {
+		_, _ = fmt.Fprintf(out, "Select [1-%d] or 'q' to quit: ", len(rows))
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return -1, ErrCancelled
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response == "q" || response == "quit" {
+			return -1, ErrCancelled
+		}
+
+		n, err := strconv.Atoi(response)
+		if err != nil || n < 1 || n > len(rows) {
+			_, _ = fmt.Fprintf(out, "Invalid selection %q\n", response)
+			continue
+		}
+		return n - 1, nil
+	}
+}
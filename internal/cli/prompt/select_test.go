@@ -0,0 +1,43 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSelectFrom(t *testing.T) {
+	rows := [][]string{
+		{"io.github.alice/weather", "npm", "1.0.0"},
+		{"io.github.bob/weather", "oci", "2.1.0"},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr error
+	}{
+		{name: "first option", input: "1\n", want: 0},
+		{name: "retries after invalid input", input: "abc\n3\n2\n", want: 1},
+		{name: "quit", input: "q\n", want: -1, wantErr: ErrCancelled},
+		{name: "eof", input: "", want: -1, wantErr: ErrCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := selectFrom(strings.NewReader(tt.input), &out, "Pick one:", []string{"Name", "Registry", "Version"}, rows)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected index %d, got %d", tt.want, got)
+			}
+			if !strings.Contains(out.String(), "io.github.bob/weather") {
+				t.Errorf("expected options to be printed, got %q", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var quotaNamespace string
+
+var QuotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "Show your deployment and publish quotas",
+	Long: `Shows the quotas the registry applies to you and how much of them you use.
+Deployments are counted per workspace, or per user outside any workspace. Publishes are counted per user over the
+last 24 hours. Registry admins are exempt from quotas.`,
+	Example: `  arctl quota
+  arctl quota --namespace io.github.myorg`,
+	Args: cobra.NoArgs,
+	RunE: runQuota,
+}
+
+func init() {
+	QuotaCmd.Flags().StringVar(&quotaNamespace, "namespace", "", "Also count the servers published under this namespace")
+}
+
+func runQuota(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	quota, err := apiClient.GetQuota(quotaNamespace)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(quota)
+	}
+
+	scope := quota.Scope
+	if quota.Exempt {
+		scope += " (exempt as registry admin)"
+	}
+	fmt.Printf("Scope: %s\n\n", scope)
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Quota", "Used", "Limit")
+	t.AddRow("Deployments", strconv.Itoa(quota.Deployments.Used), formatQuotaLimit(quota.Deployments))
+	t.AddRow("Publishes (24h)", strconv.Itoa(quota.PublishesPerDay.Used), formatQuotaLimit(quota.PublishesPerDay))
+	if quota.Namespace != "" {
+		t.AddRow("Servers in "+quota.Namespace, strconv.Itoa(quota.ServersPerNamespace.Used), formatQuotaLimit(quota.ServersPerNamespace))
+	}
+	return t.Render()
+}
+
+// formatQuotaLimit renders a quota limit, showing 0 as unlimited
+func formatQuotaLimit(usage models.QuotaUsage) string {
+	if usage.Limit <= 0 {
+		return "unlimited"
+	}
+	return strconv.Itoa(usage.Limit)
+}
@@ -0,0 +1,309 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/cliconfig"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// defaultRegistryName names the top-level registry URL of the config file among the contexts
+const defaultRegistryName = "default"
+
+var (
+	refreshRegistry    string
+	refreshConcurrency int
+	refreshTimeout     time.Duration
+)
+
+var RefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Refresh the cached names of every configured registry",
+	Long: `Fetches the server, agent and skill names of every context in the config file, and of the top-level
+registry URL, and replaces the names cached for shell completion and interactive pickers.
+
+Registries are refreshed concurrently; a registry that does not answer within --timeout is reported as failed
+without holding up the others. Use --registry to refresh a single context.`,
+	Example: `  arctl refresh
+  arctl refresh --registry staging
+  arctl refresh --concurrency 8 --timeout 10s`,
+	Args: cobra.NoArgs,
+	RunE: runRefresh,
+}
+
+func init() {
+	RefreshCmd.Flags().StringVar(&refreshRegistry, "registry", "", "Refresh only this context (\""+defaultRegistryName+"\" for the top-level registry URL)")
+	RefreshCmd.Flags().IntVar(&refreshConcurrency, "concurrency", 4, "How many registries to refresh at the same time")
+	RefreshCmd.Flags().DurationVar(&refreshTimeout, "timeout", 30*time.Second, "How long to wait for each registry")
+}
+
+// refreshTarget is a registry to refresh
+type refreshTarget struct {
+	Name        string
+	RegistryURL string
+	token       string
+}
+
+// refreshResult is the outcome of refreshing one registry
+type refreshResult struct {
+	Registry    string         `json:"registry"`
+	RegistryURL string         `json:"registryUrl"`
+	Counts      map[string]int `json:"counts"`
+	Duration    time.Duration  `json:"duration"`
+	Error       string         `json:"error,omitempty"`
+}
+
+func runRefresh(cmd *cobra.Command, args []string) error {
+	if refreshConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if refreshTimeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	targets, err := refreshTargets(cfg, refreshRegistry)
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext(cmd)
+	progress := newRefreshProgress(targets, !printer.Format().IsStructured())
+	results := make([]refreshResult, len(targets))
+
+	// A bounded pool of workers refreshes the registries; results keep the order of the targets
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(refreshConcurrency, len(targets)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = refreshRegistryNames(ctx, targets[i], refreshTimeout, progress)
+			}
+		}()
+	}
+	for i := range targets {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	progress.finish()
+
+	if printer.Format().IsStructured() {
+		if err := printer.PrintStructured(results); err != nil {
+			return err
+		}
+	} else if err := printRefreshSummary(results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d registries failed to refresh", failed, len(results))
+	}
+	return nil
+}
+
+// refreshTargets returns the contexts of the config file and its top-level registry URL, or only the named one
+func refreshTargets(cfg *cliconfig.Config, name string) ([]refreshTarget, error) {
+	var targets []refreshTarget
+	if _, ok := cfg.Contexts[defaultRegistryName]; !ok && (cfg.RegistryURL != "" || len(cfg.Contexts) == 0) {
+		targets = append(targets, refreshTarget{Name: defaultRegistryName, RegistryURL: refreshURL(cfg.RegistryURL)})
+	}
+	for _, contextName := range cfg.ContextNames() {
+		c := cfg.Contexts[contextName]
+		targets = append(targets, refreshTarget{Name: contextName, RegistryURL: refreshURL(c.RegistryURL), token: c.Token})
+	}
+	if name == "" {
+		return targets, nil
+	}
+
+	known := make([]string, 0, len(targets))
+	for _, t := range targets {
+		if t.Name == name {
+			return []refreshTarget{t}, nil
+		}
+		known = append(known, t.Name)
+	}
+	return nil, fmt.Errorf("registry %q is not configured (known registries: %s)", name, strings.Join(known, ", "))
+}
+
+// refreshURL applies the defaults the root command applies to --registry-url
+func refreshURL(raw string) string {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return client.DefaultBaseURL
+	}
+	if !strings.HasPrefix(trimmed, "http://") && !strings.HasPrefix(trimmed, "https://") {
+		return "http://" + trimmed
+	}
+	return trimmed
+}
+
+// refreshRegistryNames refreshes the cached names of every kind for one registry, giving up after timeout or once the
+// command is interrupted. Requests still in flight are canceled; the command does not wait for them to return.
+func refreshRegistryNames(ctx context.Context, target refreshTarget, timeout time.Duration, progress *refreshProgress) refreshResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := refreshResult{Registry: target.Name, RegistryURL: target.RegistryURL, Counts: map[string]int{}}
+	progress.update(target.Name, 0, "fetching")
+
+	type fetched struct {
+		counts map[string]int
+		err    error
+	}
+	done := make(chan fetched, 1)
+	go func() {
+		token := target.token
+		if token == "" {
+			token = StoredRegistryToken(ctx, target.RegistryURL)
+		}
+		c := client.NewClient(target.RegistryURL, token)
+		c.SetContext(ctx)
+		counts := map[string]int{}
+		for i, kind := range completion.Kinds {
+			n, err := completion.Refresh(c, kind)
+			if err != nil {
+				done <- fetched{err: err}
+				return
+			}
+			counts[string(kind)] = n
+			progress.update(target.Name, i+1, string(kind))
+		}
+		done <- fetched{counts: counts}
+	}()
+
+	select {
+	case f := <-done:
+		result.Counts = f.counts
+		if f.err != nil {
+			result.Error = f.err.Error()
+		}
+	case <-ctx.Done():
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		result.Error = err.Error()
+	}
+	result.Duration = time.Since(start).Round(time.Millisecond)
+
+	if result.Error != "" {
+		progress.update(target.Name, -1, "failed")
+	} else {
+		progress.update(target.Name, len(completion.Kinds), "done")
+	}
+	return result
+}
+
+func printRefreshSummary(results []refreshResult) error {
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Registry", "URL", "Servers", "Agents", "Skills", "Duration", "Status")
+	for _, r := range results {
+		status := "OK"
+		if r.Error != "" {
+			status = "Failed: " + r.Error
+		}
+		t.AddRow(r.Registry, r.RegistryURL, r.Counts[string(completion.Servers)], r.Counts[string(completion.Agents)],
+			r.Counts[string(completion.Skills)], r.Duration.String(), status)
+	}
+	return t.Render()
+}
+
+// refreshProgress shows a progress bar per registry on stderr. On a terminal the bars are redrawn in place as the
+// registries progress side by side; otherwise a line is printed when a registry finishes.
+type refreshProgress struct {
+	mu      sync.Mutex
+	enabled bool
+	tty     bool
+	names   []string
+	lines   map[string]string
+	// ended holds the registries that finished, whose bars late updates must not overwrite
+	ended map[string]bool
+	drawn bool
+}
+
+func newRefreshProgress(targets []refreshTarget, enabled bool) *refreshProgress {
+	p := &refreshProgress{
+		enabled: enabled,
+		tty:     term.IsTerminal(int(os.Stderr.Fd())),
+		lines:   map[string]string{},
+		ended:   map[string]bool{},
+	}
+	for _, t := range targets {
+		p.names = append(p.names, t.Name)
+		p.lines[t.Name] = progressLine(t.Name, 0, "waiting")
+	}
+	return p
+}
+
+// update records that a registry finished step kinds (-1 when it failed) and redraws the bars
+func (p *refreshProgress) update(name string, step int, status string) {
+	if !p.enabled {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ended[name] {
+		return
+	}
+
+	p.lines[name] = progressLine(name, step, status)
+	if status == "done" || status == "failed" {
+		p.ended[name] = true
+		if !p.tty {
+			fmt.Fprintln(os.Stderr, p.lines[name])
+		}
+	}
+	if !p.tty {
+		return
+	}
+	if p.drawn {
+		fmt.Fprintf(os.Stderr, "\x1b[%dA", len(p.names))
+	}
+	for _, n := range p.names {
+		fmt.Fprintf(os.Stderr, "\r\x1b[K%s\n", p.lines[n])
+	}
+	p.drawn = true
+}
+
+// finish leaves a blank line between the bars and the summary
+func (p *refreshProgress) finish() {
+	if p.enabled && p.tty && p.drawn {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+func progressLine(name string, step int, status string) string {
+	const width = 20
+	total := len(completion.Kinds)
+	filled := 0
+	if step > 0 {
+		filled = width * step / total
+	}
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", width-filled)
+	if step < 0 {
+		bar = strings.Repeat("x", width)
+	}
+	return fmt.Sprintf("%-20s [%s] %s", printer.TruncateString(name, 20), bar, status)
+}
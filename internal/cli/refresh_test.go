@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/cliconfig"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTargets(t *testing.T) {
+	cfg := &cliconfig.Config{
+		RegistryURL: "localhost:12121/v0",
+		Contexts: map[string]*cliconfig.Context{
+			"staging": {RegistryURL: "https://registry.staging.example.com/v0", Token: "staging-token"},
+			"prod":    {RegistryURL: "https://registry.example.com/v0"},
+		},
+	}
+
+	targets, err := refreshTargets(cfg, "")
+	require.NoError(t, err)
+	require.Len(t, targets, 3)
+	assert.Equal(t, refreshTarget{Name: "default", RegistryURL: "http://localhost:12121/v0"}, targets[0])
+	assert.Equal(t, "prod", targets[1].Name)
+	assert.Equal(t, refreshTarget{Name: "staging", RegistryURL: "https://registry.staging.example.com/v0", token: "staging-token"}, targets[2])
+
+	targets, err = refreshTargets(cfg, "staging")
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, "staging", targets[0].Name)
+
+	_, err = refreshTargets(cfg, "missing")
+	assert.ErrorContains(t, err, "known registries: default, prod, staging")
+
+	// Without any configuration the local daemon is refreshed
+	targets, err = refreshTargets(&cliconfig.Config{}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []refreshTarget{{Name: "default", RegistryURL: client.DefaultBaseURL}}, targets)
+}
@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/backup"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupDestination string
+	backupFormat      string
+	backupKeep        int
+	backupMaxAge      time.Duration
+	restoreFrom       string
+	restoreYes        bool
+)
+
+var RegistryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Back up and restore the registry database",
+	Long: `Commands that operate directly on the registry database configured by AGENT_REGISTRY_DATABASE_URL.
+
+Backups are stored in a local directory, s3://bucket/prefix (using the aws CLI) or gs://bucket/prefix
+(using the gcloud CLI). The default location is AGENT_REGISTRY_BACKUP_LOCATION, or ./backups.`,
+}
+
+var registryBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Snapshot the registry",
+	Long: `Snapshots the registry as a json archive (servers, READMEs, agents, skills, deployments and embeddings)
+or as a pg_dump of the whole database, and stores it in the backup location.
+
+With --keep or --max-age, older backups are deleted afterwards. The newest backup is always kept.`,
+	Example: `  arctl registry backup
+  arctl registry backup --destination s3://my-bucket/agentregistry --keep 7
+  arctl registry backup --format pgdump --destination /var/backups/agentregistry`,
+	Args: cobra.NoArgs,
+	RunE: runRegistryBackup,
+}
+
+var registryBackupsCmd = &cobra.Command{
+	Use:   "backups",
+	Short: "List the backups in a backup location",
+	Args:  cobra.NoArgs,
+	RunE:  runRegistryBackups,
+}
+
+var registryRestoreCmd = &cobra.Command{
+	Use:   "restore <backup>",
+	Short: "Restore the registry from a backup",
+	Long: `Restores a backup created by arctl registry backup. The backup is a name from arctl registry backups,
+or "latest" for the newest backup.
+
+Json backups are merged into the registry: versions that already exist are kept, so a restore can be re-run.
+Pgdump backups replace the registry database and require confirmation.`,
+	Example: `  arctl registry restore latest
+  arctl registry restore agentregistry-20261016T120000Z.tar.gz --from s3://my-bucket/agentregistry
+  arctl registry restore ./backups/agentregistry-20261016T120000Z.dump --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRegistryRestore,
+}
+
+func init() {
+	registryBackupCmd.Flags().StringVar(&backupDestination, "destination", "", "Backup location: a directory, s3://bucket/prefix or gs://bucket/prefix")
+	registryBackupCmd.Flags().StringVar(&backupFormat, "format", "", "Backup format: json (default) or pgdump")
+	registryBackupCmd.Flags().IntVar(&backupKeep, "keep", 0, "Keep only this many most recent backups after backing up")
+	registryBackupCmd.Flags().DurationVar(&backupMaxAge, "max-age", 0, "Delete backups older than this after backing up (e.g. 720h)")
+	registryBackupsCmd.Flags().StringVar(&backupDestination, "from", "", "Backup location: a directory, s3://bucket/prefix or gs://bucket/prefix")
+	registryRestoreCmd.Flags().StringVar(&restoreFrom, "from", "", "Backup location: a directory, s3://bucket/prefix or gs://bucket/prefix")
+	registryRestoreCmd.Flags().BoolVarP(&restoreYes, "yes", "y", false, "Do not ask for confirmation before replacing the database")
+
+	RegistryCmd.AddCommand(registryBackupCmd, registryBackupsCmd, registryRestoreCmd)
+}
+
+func runRegistryBackup(cmd *cobra.Command, args []string) error {
+	cfg := config.NewConfig()
+	store, err := backup.NewStorage(backupLocation(cfg, backupDestination))
+	if err != nil {
+		return err
+	}
+	format := backupFormat
+	if format == "" {
+		format = cfg.BackupFormat
+	}
+	parsed, err := backup.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+
+	db, err := openRegistryDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeRegistryDatabase(db)
+
+	ctx := commandContext(cmd)
+	b, err := backup.Create(ctx, db, store, backup.Options{
+		Format:          parsed,
+		DatabaseURL:     cfg.DatabaseURL,
+		RegistryVersion: cfg.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+	printer.PrintSuccess(fmt.Sprintf("Backup %s written to %s (%s)", b.Name, store, printer.FormatBytes(b.Size)))
+
+	deleted, err := backup.Prune(ctx, store, backup.Retention{Keep: backupKeep, MaxAge: backupMaxAge})
+	for _, name := range deleted {
+		fmt.Printf("Deleted old backup %s\n", name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply retention policy: %w", err)
+	}
+	return nil
+}
+
+func runRegistryBackups(cmd *cobra.Command, args []string) error {
+	cfg := config.NewConfig()
+	store, err := backup.NewStorage(backupLocation(cfg, backupDestination))
+	if err != nil {
+		return err
+	}
+	backups, err := backup.List(commandContext(cmd), store)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(backups)
+	}
+
+	if len(backups) == 0 {
+		fmt.Printf("No backups in %s\n", store)
+		return nil
+	}
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Name", "Format", "Size", "Age")
+	for _, b := range backups {
+		t.AddRow(b.Name, string(b.Format), printer.FormatBytes(b.Size), printer.FormatAge(b.CreatedAt))
+	}
+	return t.Render()
+}
+
+func runRegistryRestore(cmd *cobra.Command, args []string) error {
+	cfg := config.NewConfig()
+	name := args[0]
+	location := restoreFrom
+	// A path to a backup file restores from its directory
+	if location == "" && strings.ContainsRune(name, os.PathSeparator) {
+		location, name = filepath.Dir(name), filepath.Base(name)
+	}
+	store, err := backup.NewStorage(backupLocation(cfg, location))
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext(cmd)
+	if name == "latest" {
+		backups, err := backup.List(ctx, store)
+		if err != nil {
+			return err
+		}
+		if len(backups) == 0 {
+			return fmt.Errorf("no backups in %s", store)
+		}
+		name = backups[0].Name
+	}
+
+	if strings.HasSuffix(name, ".dump") && !restoreYes {
+		fmt.Printf("Restoring %s replaces the registry database at %s. Continue? [y/N]: ", name, redactDatabaseURL(cfg.DatabaseURL))
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			return errors.New("restore cancelled")
+		}
+	}
+
+	db, err := openRegistryDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeRegistryDatabase(db)
+
+	result, err := backup.Restore(ctx, db, store, name, backup.Options{DatabaseURL: cfg.DatabaseURL})
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+	if result != nil {
+		c := result.Created
+		fmt.Printf("Restored %d servers, %d agents, %d skills and %d deployments (%d already existed)\n",
+			c.Servers, c.Agents, c.Skills, c.Deployments,
+			result.Skipped.Servers+result.Skipped.Agents+result.Skipped.Skills+result.Skipped.Deployments)
+	}
+	printer.PrintSuccess(fmt.Sprintf("Restored %s from %s", name, store))
+	return nil
+}
+
+// backupLocation picks the flag value, then AGENT_REGISTRY_BACKUP_LOCATION, then ./backups
+func backupLocation(cfg *config.Config, flag string) string {
+	if flag != "" {
+		return flag
+	}
+	if cfg.BackupLocation != "" {
+		return cfg.BackupLocation
+	}
+	return "backups"
+}
+
+func openRegistryDatabase(cfg *config.Config) (*database.PostgreSQL, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Like import and export, these commands connect to the database directly, without a user session
+	db, err := database.NewPostgreSQL(ctx, cfg.DatabaseURL, auth.Authorizer{Authz: nil})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := db.UseBlobStorage(cfg.BlobStorage, cfg.BlobInlineMaxBytes); err != nil {
+		closeRegistryDatabase(db)
+		return nil, err
+	}
+	return db, nil
+}
+
+func closeRegistryDatabase(db *database.PostgreSQL) {
+	if err := db.Close(); err != nil {
+		log.Printf("Warning: failed to close database: %v", err)
+	}
+}
+
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// redactDatabaseURL hides the password of a connection string for display
+func redactDatabaseURL(databaseURL string) string {
+	scheme, rest, ok := strings.Cut(databaseURL, "://")
+	if !ok {
+		return databaseURL
+	}
+	userinfo, host, ok := strings.Cut(rest, "@")
+	if !ok {
+		return databaseURL
+	}
+	user, _, _ := strings.Cut(userinfo, ":")
+	return scheme + "://" + user + "@" + host
+}
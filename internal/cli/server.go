@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateTo     int
+	migrateStatus bool
+)
+
+var ServerCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Registry server maintenance commands",
+	Long:  `Commands that operate directly on the registry database configured by AGENT_REGISTRY_DATABASE_URL.`,
+}
+
+var serverMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply or roll back database schema migrations",
+	Long: `Migrates the registry database schema. The registry server applies pending migrations at startup;
+this command lets operators inspect the schema version, migrate ahead of a rollout, or roll back to an
+older version before downgrading the server.
+
+Rolling back requires a down migration for every migration being reverted and deletes the data stored
+by the reverted schema. Take a backup first (arctl registry backup).`,
+	Example: `  arctl server migrate
+  arctl server migrate --status
+  arctl server migrate --to 26`,
+	Args: cobra.NoArgs,
+	RunE: runServerMigrate,
+}
+
+func init() {
+	serverMigrateCmd.Flags().IntVar(&migrateTo, "to", -1, "Target schema version (default: latest)")
+	serverMigrateCmd.Flags().BoolVar(&migrateStatus, "status", false, "Show the applied and pending migrations without changing anything")
+
+	ServerCmd.AddCommand(serverMigrateCmd)
+}
+
+func runServerMigrate(cmd *cobra.Command, args []string) error {
+	cfg := config.NewConfig()
+
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 10*time.Minute)
+	defer cancel()
+
+	// Connect without internaldb.NewPostgreSQL, which would apply all pending migrations
+	conn, err := pgx.Connect(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	migrator := database.NewMigrator(conn, internaldb.DefaultMigratorConfig())
+
+	if migrateStatus {
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printMigrationStatus(statuses)
+	}
+
+	if err := migrator.MigrateTo(ctx, migrateTo); err != nil {
+		return err
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	printer.PrintSuccess(fmt.Sprintf("Database schema is at version %d", schemaVersion(statuses)))
+	return nil
+}
+
+// schemaVersion returns the newest applied migration version, or 0 when none is applied
+func schemaVersion(statuses []database.MigrationStatus) int {
+	version := 0
+	for _, s := range statuses {
+		if s.Applied {
+			version = s.Version
+		}
+	}
+	return version
+}
+
+func printMigrationStatus(statuses []database.MigrationStatus) error {
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(statuses)
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Version", "Name", "Status", "Applied", "Reversible")
+	for _, s := range statuses {
+		status, applied := "pending", "-"
+		if s.Applied {
+			status = "applied"
+			if s.AppliedAt != nil {
+				applied = printer.FormatAge(*s.AppliedAt)
+			}
+		}
+		reversible := "no"
+		if s.Reversible {
+			reversible = "yes"
+		}
+		t.AddRow(s.Version, s.Name, status, applied, reversible)
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	fmt.Printf("\nSchema version: %d\n", schemaVersion(statuses))
+	return nil
+}
@@ -0,0 +1,49 @@
+package skill
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	deprecateVersion string
+	deprecateUndo    bool
+)
+
+var DeprecateCmd = &cobra.Command{
+	Use:   "deprecate <skill-name>",
+	Short: "Mark a skill version as deprecated",
+	Long: `Marks a skill version as deprecated. Deprecated versions stay published and installable,
+but clients are told to move to a newer version.
+
+Use --undo to mark the version active again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeprecate,
+}
+
+func init() {
+	DeprecateCmd.Flags().StringVar(&deprecateVersion, "version", "", "Version of the skill to deprecate (required)")
+	DeprecateCmd.Flags().BoolVar(&deprecateUndo, "undo", false, "Mark the version active again")
+	_ = DeprecateCmd.MarkFlagRequired("version")
+}
+
+func runDeprecate(cmd *cobra.Command, args []string) error {
+	skillName := args[0]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	status := "deprecated"
+	if deprecateUndo {
+		status = "active"
+	}
+
+	if _, err := apiClient.SetSkillStatus(skillName, deprecateVersion, status); err != nil {
+		return fmt.Errorf("failed to mark skill %s: %w", status, err)
+	}
+
+	fmt.Printf("Skill '%s' version %s marked %s\n", skillName, deprecateVersion, status)
+	return nil
+}
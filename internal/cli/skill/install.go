@@ -0,0 +1,141 @@
+package skill
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/prompt"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	installYes      bool
+	installNoDeploy bool
+	installRuntime  string
+)
+
+var InstallCmd = &cobra.Command{
+	Use:   "install [skill-name] [output-directory]",
+	Short: "Pull a skill and deploy the MCP servers it needs",
+	Long: `Pull a skill like 'arctl skill pull', then check the MCP servers the skill declares as dependencies.
+Servers that are not deployed yet are listed and, after confirmation, deployed so the skill is usable right away.
+
+Servers that need configuration such as API keys cannot be deployed without it; deploy those with
+'arctl mcp deploy' and the required --env, --arg or --header values.`,
+	Example: `  arctl skill install com.example/research
+  arctl skill install com.example/research ./skills/research -y
+  arctl skill install com.example/research --no-deploy`,
+	Args:              cobra.RangeArgs(0, 2),
+	ValidArgsFunction: completion.Names(completion.Skills),
+	RunE:              runInstall,
+	SilenceUsage:      true,
+}
+
+func init() {
+	InstallCmd.Flags().BoolVarP(&installYes, "yes", "y", false, "Deploy missing MCP servers without asking")
+	InstallCmd.Flags().BoolVar(&installNoDeploy, "no-deploy", false, "Only report missing MCP servers, do not deploy them")
+	InstallCmd.Flags().StringVar(&installRuntime, "runtime", "local", "Runtime to deploy missing MCP servers to (local, kubernetes)")
+}
+
+func runInstall(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	args, err := skillNameArgs(args)
+	if err != nil {
+		return err
+	}
+	skill, err := pullSkill(args)
+	if err != nil {
+		return err
+	}
+	_ = apiClient.ReportUsage("skill", skill.Skill.Name, models.UsageEventInstall)
+
+	deps := skill.Skill.MCPServers
+	if len(deps) == 0 {
+		return nil
+	}
+
+	deployments, err := apiClient.GetDeployedServers()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+	missing := missingServerDependencies(deps, deployments)
+	if len(missing) == 0 {
+		printer.PrintSuccess(fmt.Sprintf("All %d MCP server dependencies are deployed", len(deps)))
+		return nil
+	}
+
+	fmt.Printf("\nThe skill needs %d MCP server(s) that are not deployed:\n", len(missing))
+	for _, dep := range missing {
+		fmt.Printf("  - %s (%s)\n", dep.Name, dependencyVersion(dep))
+	}
+
+	if installNoDeploy || (!installYes && !prompt.IsInteractive()) {
+		fmt.Println("\nDeploy them with: arctl mcp deploy <server-name> --version <version>")
+		return nil
+	}
+	if !installYes {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Print("Deploy them now? [Y/n]: ")
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("error reading input: %w", err)
+		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "" && response != "y" && response != "yes" {
+			return nil
+		}
+	}
+
+	var failed []string
+	for _, dep := range missing {
+		printer.PrintInfo(fmt.Sprintf("Deploying %s (%s)...", dep.Name, dependencyVersion(dep)))
+		if _, err := apiClient.DeployServer(dep.Name, dependencyVersion(dep), map[string]string{}, false, installRuntime, "", ""); err != nil {
+			printer.PrintError(fmt.Sprintf("failed to deploy %s: %v", dep.Name, err))
+			failed = append(failed, dep.Name)
+			continue
+		}
+		printer.PrintSuccess(fmt.Sprintf("Deployed %s", dep.Name))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to deploy %s; deploy them with 'arctl mcp deploy' and their required configuration", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// missingServerDependencies returns the dependencies that have no MCP server deployment. A dependency without
+// a version is satisfied by any deployed version of the server.
+func missingServerDependencies(deps []models.SkillServerDependency, deployments []*client.DeploymentResponse) []models.SkillServerDependency {
+	var missing []models.SkillServerDependency
+	for _, dep := range deps {
+		found := false
+		for _, d := range deployments {
+			if d.ResourceType != "" && d.ResourceType != "mcp" {
+				continue
+			}
+			if d.ServerName == dep.Name && (dep.Version == "" || dep.Version == "latest" || d.Version == dep.Version) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, dep)
+		}
+	}
+	return missing
+}
+
+func dependencyVersion(dep models.SkillServerDependency) string {
+	if dep.Version == "" {
+		return "latest"
+	}
+	return dep.Version
+}
@@ -0,0 +1,31 @@
+package skill
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestMissingServerDependencies(t *testing.T) {
+	deployments := []*client.DeploymentResponse{
+		{ServerName: "com.example/search", Version: "1.0.0", ResourceType: "mcp"},
+		{ServerName: "com.example/fetch", Version: "2.0.0", ResourceType: "mcp"},
+		{ServerName: "com.example/writer", Version: "1.0.0", ResourceType: "agent"},
+	}
+	deps := []models.SkillServerDependency{
+		{Name: "com.example/search"},
+		{Name: "com.example/fetch", Version: "1.0.0"},
+		{Name: "com.example/writer"},
+		{Name: "com.example/fetch", Version: "2.0.0"},
+	}
+
+	missing := missingServerDependencies(deps, deployments)
+
+	assert.Equal(t, []models.SkillServerDependency{
+		{Name: "com.example/fetch", Version: "1.0.0"},
+		{Name: "com.example/writer"},
+	}, missing)
+}
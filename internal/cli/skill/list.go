@@ -14,7 +14,6 @@ import (
 var (
 	listAll      bool
 	listPageSize int
-	outputFormat string
 )
 
 var ListCmd = &cobra.Command{
@@ -27,7 +26,6 @@ var ListCmd = &cobra.Command{
 func init() {
 	ListCmd.Flags().BoolVarP(&listAll, "all", "a", false, "Show all items without pagination")
 	ListCmd.Flags().IntVarP(&listPageSize, "page-size", "p", 15, "Number of items per page")
-	ListCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -45,16 +43,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Handle different output formats
-	switch outputFormat {
-	case "json":
-		return outputDataJson(skills)
-	case "yaml":
-		return outputDataYaml(skills)
-	default:
-		displayPaginatedSkills(skills, listPageSize, listAll)
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(skills)
 	}
-
+	displayPaginatedSkills(skills, listPageSize, listAll)
 	return nil
 }
 
@@ -116,7 +108,11 @@ func displayPaginatedSkills(skills []*models.SkillResponse, pageSize int, showAl
 
 func printSkillsTable(skills []*models.SkillResponse) {
 	t := printer.NewTablePrinter(os.Stdout)
-	t.SetHeaders("Name", "Title", "Version", "Category", "Published", "Website")
+	headers := []string{"Name", "Title", "Version", "Category", "Published", "Website"}
+	if t.Wide() {
+		headers = append(headers, "Description")
+	}
+	t.SetHeaders(headers...)
 
 	for _, s := range skills {
 		publishedStatus := "False"
@@ -124,31 +120,21 @@ func printSkillsTable(skills []*models.SkillResponse) {
 			publishedStatus = "True"
 		}
 
-		t.AddRow(
+		row := []any{
 			printer.TruncateString(s.Skill.Name, 40),
 			printer.TruncateString(s.Skill.Title, 40),
 			s.Skill.Version,
 			printer.EmptyValueOrDefault(s.Skill.Category, "<none>"),
 			publishedStatus,
 			s.Skill.WebsiteURL,
-		)
+		}
+		if t.Wide() {
+			row = append(row, s.Skill.Description)
+		}
+		t.AddRow(row...)
 	}
 
 	if err := t.Render(); err != nil {
 		printer.PrintError(fmt.Sprintf("failed to render table: %v", err))
 	}
 }
-
-func outputDataJson(data any) error {
-	p := printer.New(printer.OutputTypeJSON, false)
-	if err := p.PrintJSON(data); err != nil {
-		return fmt.Errorf("failed to output JSON: %w", err)
-	}
-	return nil
-}
-
-func outputDataYaml(data any) error {
-	// For now, YAML is not implemented, fallback to JSON
-	fmt.Println("YAML output not yet implemented, using JSON:")
-	return outputDataJson(data)
-}
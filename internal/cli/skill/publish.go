@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	"github.com/spf13/cobra"
@@ -18,11 +19,12 @@ import (
 
 var (
 	// Flags for skill publish command
-	dockerUrl    string
-	dockerTag    string
-	platformFlag string
-	pushFlag     bool
-	dryRunFlag   bool
+	dockerUrl      string
+	dockerTag      string
+	platformFlag   string
+	pushFlag       bool
+	dryRunFlag     bool
+	visibilityFlag string
 )
 
 var PublishCmd = &cobra.Command{
@@ -31,7 +33,11 @@ var PublishCmd = &cobra.Command{
 	Long: `Wrap a Claude Skill in a Docker image and publish it to both Docker registry and agent registry.
 	
 The skill folder must contain a SKILL.md file with proper YAML frontmatter.
-Use --multi flag to auto-detect and process multiple skill folders.`,
+Use --multi flag to auto-detect and process multiple skill folders.
+
+MCP servers the skill needs are declared under "mcp-servers" in the frontmatter, or under
+"mcpServers" in an optional skill.json, each with a name and optionally a version and the tools
+the skill calls. The registry rejects the skill when a declared server or tool does not exist.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runPublish,
 }
@@ -43,6 +49,7 @@ func init() {
 	PublishCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show what would be done without actually doing it")
 	PublishCmd.Flags().StringVar(&dockerTag, "tag", "latest", "Docker image tag to use")
 	PublishCmd.Flags().StringVar(&platformFlag, "platform", "", "Target platform(s) for the build (e.g., linux/amd64, linux/arm64, or linux/amd64,linux/arm64)")
+	PublishCmd.Flags().StringVar(&visibilityFlag, "visibility", "", "Who can see the skill: public (default), unlisted (left out of listings and search) or private (members of --workspace only)")
 
 	_ = PublishCmd.MarkFlagRequired("docker-url")
 }
@@ -53,6 +60,9 @@ func runPublish(cmd *cobra.Command, args []string) error {
 	if apiClient == nil {
 		return fmt.Errorf("API client not initialized")
 	}
+	if !models.ValidVisibility(visibilityFlag) {
+		return fmt.Errorf("--visibility must be one of %s", strings.Join(models.Visibilities, ", "))
+	}
 
 	// Validate path exists
 	absPath, err := filepath.Abs(skillPath)
@@ -87,6 +97,7 @@ func runPublish(cmd *cobra.Command, args []string) error {
 			errs = append(errs, fmt.Errorf("failed to build skill '%s': %w", skill, err))
 			continue
 		}
+		skillJson.Visibility = visibilityFlag
 
 		if dryRunFlag {
 			j, _ := json.Marshal(skillJson)
@@ -95,6 +106,10 @@ func runPublish(cmd *cobra.Command, args []string) error {
 			_, err = apiClient.PublishSkill(skillJson)
 			if err != nil {
 				errs = append(errs, fmt.Errorf("failed to publish skill '%s': %w", skill, err))
+				continue
+			}
+			if err := uploadSkillReadme(skill, skillJson); err != nil {
+				errs = append(errs, fmt.Errorf("failed to upload README of skill '%s': %w", skill, err))
 			}
 		}
 	}
@@ -121,8 +136,9 @@ func buildSkillDockerImage(skillPath string) (*models.SkillJSON, error) {
 
 	// Extract YAML frontmatter between leading --- blocks
 	type frontmatter struct {
-		Name        string `yaml:"name"`
-		Description string `yaml:"description"`
+		Name        string                         `yaml:"name"`
+		Description string                         `yaml:"description"`
+		MCPServers  []models.SkillServerDependency `yaml:"mcp-servers"`
 	}
 
 	scanner := bufio.NewScanner(f)
@@ -193,7 +209,7 @@ func buildSkillDockerImage(skillPath string) (*models.SkillJSON, error) {
 		args = append(args, "-f", "-", skillPath)
 
 		printer.PrintInfo("Building Docker image (Dockerfile via stdin): docker " + strings.Join(args, " "))
-		cmd := exec.Command("docker", args...)
+		cmd := exec.Command(utils.ContainerEngine(), args...)
 		cmd.Dir = skillPath
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -210,7 +226,7 @@ func buildSkillDockerImage(skillPath string) (*models.SkillJSON, error) {
 			printer.PrintInfo("[DRY RUN] Would push Docker image: " + imageRef)
 		} else {
 			printer.PrintInfo("Pushing Docker image: docker push " + imageRef)
-			pushCmd := exec.Command("docker", "push", imageRef)
+			pushCmd := exec.Command(utils.ContainerEngine(), "push", imageRef)
 			pushCmd.Stdout = os.Stdout
 			pushCmd.Stderr = os.Stderr
 			if err := pushCmd.Run(); err != nil {
@@ -224,6 +240,14 @@ func buildSkillDockerImage(skillPath string) (*models.SkillJSON, error) {
 		Name:        fm.Name,
 		Description: fm.Description,
 		Version:     ver,
+		MCPServers:  fm.MCPServers,
+	}
+	deps, err := readSkillJSONDependencies(skillPath)
+	if err != nil {
+		return nil, err
+	}
+	if deps != nil {
+		skill.MCPServers = deps
 	}
 
 	// package info for docker image
@@ -238,6 +262,42 @@ func buildSkillDockerImage(skillPath string) (*models.SkillJSON, error) {
 	return skill, nil
 }
 
+// readSkillJSONDependencies returns the MCP servers declared in an optional skill.json next to SKILL.md.
+// Declarations in skill.json take precedence over the mcp-servers key of the SKILL.md frontmatter.
+func readSkillJSONDependencies(skillPath string) ([]models.SkillServerDependency, error) {
+	data, err := os.ReadFile(filepath.Join(skillPath, "skill.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skill.json: %w", err)
+	}
+	var manifest models.SkillJSON
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse skill.json: %w", err)
+	}
+	return manifest.MCPServers, nil
+}
+
+// uploadSkillReadme stores README.md of the skill folder, or SKILL.md when there is none, as the README of
+// the published version
+func uploadSkillReadme(skillPath string, skill *models.SkillJSON) error {
+	for _, name := range []string{"README.md", "SKILL.md"} {
+		content, err := os.ReadFile(filepath.Join(skillPath, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(content) == 0 {
+			continue
+		}
+		return apiClient.UploadSkillReadme(skill.Name, skill.Version, content, "text/markdown")
+	}
+	return nil
+}
+
 // detectSkills scans the given path for skill folders
 // If multiMode is true, it looks for subdirectories containing SKILL.md
 // Otherwise, it expects the path itself to be a skill folder
@@ -8,32 +8,63 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	"github.com/spf13/cobra"
 )
 
 var PullCmd = &cobra.Command{
-	Use:   "pull <skill-name> [output-directory]",
+	Use:   "pull [skill-name] [output-directory]",
 	Short: "Pull a skill from the registry and extract it locally",
 	Long: `Pull a skill's Docker image from the registry and extract its contents to a local directory.
 	
 If output-directory is not specified, it will be extracted to ./skills/<skill-name>`,
-	Args: cobra.RangeArgs(1, 2),
-	RunE: runPull,
+	Args:              cobra.RangeArgs(0, 2),
+	ValidArgsFunction: completion.Names(completion.Skills),
+	RunE:              runPull,
 }
 
 func runPull(cmd *cobra.Command, args []string) error {
-	skillName := args[0]
-
 	if apiClient == nil {
 		return fmt.Errorf("API client not initialized")
 	}
 
-	// Determine output directory
+	args, err := skillNameArgs(args)
+	if err != nil {
+		return err
+	}
+	_, err = pullSkill(args)
+	return err
+}
+
+// skillNameArgs fills in the skill name, letting the user pick one interactively when it was omitted
+func skillNameArgs(args []string) ([]string, error) {
+	name, err := completion.NameArg(completion.Skills, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return []string{name}, nil
+	}
+	return args, nil
+}
+
+// pullSkill extracts the skill named by args[0] into args[1], or ./skills/<skill-name> when omitted,
+// and returns the skill it pulled
+func pullSkill(args []string) (*models.SkillResponse, error) {
 	outputDir := ""
 	if len(args) > 1 {
 		outputDir = args[1]
-	} else {
+	}
+	return Pull(args[0], "", outputDir)
+}
+
+// Pull extracts a skill version, or its latest version when version is empty, into outputDir, or
+// ./skills/<skill-name> when empty, and returns the skill it pulled
+func Pull(skillName, version, outputDir string) (*models.SkillResponse, error) {
+	if outputDir == "" {
 		outputDir = filepath.Join("skills", sanitizeRepoName(skillName))
 	}
 
@@ -41,13 +72,19 @@ func runPull(cmd *cobra.Command, args []string) error {
 
 	// 1. Fetch skill metadata from registry
 	printer.PrintInfo("Fetching skill metadata from registry...")
-	skillResp, err := apiClient.GetSkillByName(skillName)
+	var skillResp *models.SkillResponse
+	var err error
+	if version == "" || version == "latest" {
+		skillResp, err = apiClient.GetSkillByName(skillName)
+	} else {
+		skillResp, err = apiClient.GetSkillByNameAndVersion(skillName, version)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to fetch skill from registry: %w", err)
+		return nil, fmt.Errorf("failed to fetch skill from registry: %w", err)
 	}
 
 	if skillResp == nil {
-		return fmt.Errorf("skill '%s' not found in registry", skillName)
+		return nil, fmt.Errorf("skill '%s' not found in registry", skillName)
 	}
 
 	printer.PrintSuccess(fmt.Sprintf("Found skill: %s (version %s)", skillResp.Skill.Name, skillResp.Skill.Version))
@@ -62,28 +99,28 @@ func runPull(cmd *cobra.Command, args []string) error {
 	}
 
 	if dockerImage == "" {
-		return fmt.Errorf("skill does not have a Docker package")
+		return nil, fmt.Errorf("skill does not have a Docker package")
 	}
 
 	printer.PrintInfo(fmt.Sprintf("Docker image: %s", dockerImage))
 
 	// 3. Pull the Docker image
 	printer.PrintInfo("Pulling Docker image...")
-	pullCmd := exec.Command("docker", "pull", dockerImage)
+	pullCmd := exec.Command(utils.ContainerEngine(), "pull", dockerImage)
 	pullCmd.Stdout = os.Stdout
 	pullCmd.Stderr = os.Stderr
 	if err := pullCmd.Run(); err != nil {
-		return fmt.Errorf("failed to pull Docker image: %w", err)
+		return nil, fmt.Errorf("failed to pull Docker image: %w", err)
 	}
 
 	// 4. Create output directory
 	absOutputDir, err := filepath.Abs(outputDir)
 	if err != nil {
-		return fmt.Errorf("failed to resolve output directory: %w", err)
+		return nil, fmt.Errorf("failed to resolve output directory: %w", err)
 	}
 
 	if err := os.MkdirAll(absOutputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
 	// 5. Extract contents from Docker image
@@ -91,45 +128,46 @@ func runPull(cmd *cobra.Command, args []string) error {
 
 	// For images built FROM scratch, we need to provide a dummy command
 	// Create a container from the image (without running it)
-	createCmd := exec.Command("docker", "create", "--entrypoint", "/bin/sh", dockerImage, "-c", "echo")
+	createCmd := exec.Command(utils.ContainerEngine(), "create", "--entrypoint", "/bin/sh", dockerImage, "-c", "echo")
 	createOutput, err := createCmd.CombinedOutput()
 	if err != nil {
 		// If that fails, try without entrypoint override (for images with proper entrypoints)
-		createCmd = exec.Command("docker", "create", dockerImage)
+		createCmd = exec.Command(utils.ContainerEngine(), "create", dockerImage)
 		createOutput, err = createCmd.CombinedOutput()
 		if err != nil {
-			return fmt.Errorf("failed to create container from image: %w\nOutput: %s", err, string(createOutput))
+			return nil, fmt.Errorf("failed to create container from image: %w\nOutput: %s", err, string(createOutput))
 		}
 	}
 	containerIDStr := strings.TrimSpace(string(createOutput))
 
 	// Ensure we clean up the container
 	defer func() {
-		rmCmd := exec.Command("docker", "rm", containerIDStr)
+		rmCmd := exec.Command(utils.ContainerEngine(), "rm", containerIDStr)
 		_ = rmCmd.Run()
 	}()
 
 	// Extract to a temporary directory first
 	tempDir, err := os.MkdirTemp("", "skill-extract-*")
 	if err != nil {
-		return fmt.Errorf("failed to create temp directory: %w", err)
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
 	}
 	defer func() { _ = os.RemoveAll(tempDir) }()
 
 	// Copy contents from container to temp directory
-	cpCmd := exec.Command("docker", "cp", containerIDStr+":"+"/.", tempDir)
+	cpCmd := exec.Command(utils.ContainerEngine(), "cp", containerIDStr+":"+"/.", tempDir)
 	cpCmd.Stderr = os.Stderr
 	if err := cpCmd.Run(); err != nil {
-		return fmt.Errorf("failed to extract contents from container: %w", err)
+		return nil, fmt.Errorf("failed to extract contents from container: %w", err)
 	}
 
 	// Copy only non-empty files and folders to the final destination
 	if err := copyNonEmptyContents(tempDir, absOutputDir); err != nil {
-		return fmt.Errorf("failed to copy non-empty contents: %w", err)
+		return nil, fmt.Errorf("failed to copy non-empty contents: %w", err)
 	}
 
 	printer.PrintSuccess(fmt.Sprintf("Successfully pulled skill to: %s", absOutputDir))
-	return nil
+	_ = apiClient.ReportUsage("skill", skillResp.Skill.Name, models.UsageEventDownload)
+	return skillResp, nil
 }
 
 // copyNonEmptyContents recursively copies only non-empty files and directories
@@ -3,25 +3,28 @@ package skill
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	"github.com/spf13/cobra"
 )
 
 var (
-	showOutputFormat string
+	showReadme bool
 )
 
 var ShowCmd = &cobra.Command{
-	Use:   "show <skill-name>",
-	Short: "Show details of a skill",
-	Long:  `Shows detailed information about a skill from the registry.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runShow,
+	Use:               "show <skill-name>",
+	Short:             "Show details of a skill",
+	Long:              `Shows detailed information about a skill from the registry.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.Names(completion.Skills),
+	RunE:              runShow,
 }
 
 func init() {
-	ShowCmd.Flags().StringVarP(&showOutputFormat, "output", "o", "table", "Output format (table, json)")
+	ShowCmd.Flags().BoolVar(&showReadme, "readme", false, "Render the README of the latest version instead of its details")
 }
 
 func runShow(cmd *cobra.Command, args []string) error {
@@ -41,12 +44,23 @@ func runShow(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Handle JSON output format
-	if showOutputFormat == "json" {
-		fmt.Println(skill)
+	if showReadme {
+		readme, err := apiClient.GetSkillReadme(skillName, skill.Skill.Version)
+		if err != nil {
+			return err
+		}
+		if readme == nil {
+			fmt.Printf("Skill '%s' has no README\n", skillName)
+			return nil
+		}
+		fmt.Println(printer.RenderMarkdown(readme.Content, readme.ContentType))
 		return nil
 	}
 
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(skill)
+	}
+
 	// Display skill details in table format
 	t := printer.NewTablePrinter(os.Stdout)
 	t.SetHeaders("Property", "Value")
@@ -56,6 +70,13 @@ func runShow(cmd *cobra.Command, args []string) error {
 	t.AddRow("Category", skill.Skill.Category)
 	t.AddRow("Status", skill.Meta.Official.Status)
 	t.AddRow("Website", skill.Skill.WebsiteURL)
+	for _, dep := range skill.Skill.MCPServers {
+		value := dep.Name + " (" + dependencyVersion(dep) + ")"
+		if len(dep.Tools) > 0 {
+			value += ": " + strings.Join(dep.Tools, ", ")
+		}
+		t.AddRow("MCP Server", value)
+	}
 	if err := t.Render(); err != nil {
 		return fmt.Errorf("failed to render table: %w", err)
 	}
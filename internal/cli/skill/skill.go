@@ -20,6 +20,7 @@ var SkillCmd = &cobra.Command{
 	Example: `arctl skill list
 arctl skill show my-skill
 arctl skill publish ./my-skill
+arctl skill versions my-skill
 arctl skill remove my-skill`,
 }
 
@@ -31,7 +32,10 @@ func init() {
 	SkillCmd.AddCommand(PublishCmd)
 	SkillCmd.AddCommand(DeleteCmd)
 	SkillCmd.AddCommand(PullCmd)
+	SkillCmd.AddCommand(InstallCmd)
 	SkillCmd.AddCommand(ShowCmd)
 	SkillCmd.AddCommand(RemoveCmd)
 	SkillCmd.AddCommand(UnpublishCmd)
+	SkillCmd.AddCommand(VersionsCmd)
+	SkillCmd.AddCommand(DeprecateCmd)
 }
@@ -0,0 +1,50 @@
+package skill
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var VersionsCmd = &cobra.Command{
+	Use:   "versions <skill-name>",
+	Short: "List all versions of a skill with their publish state",
+	Long: `Lists every version of a skill together with its lifecycle status
+(active, deprecated, deleted) and whether it is published in public listings.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVersions,
+}
+
+func runVersions(cmd *cobra.Command, args []string) error {
+	skillName := args[0]
+
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	resp, err := apiClient.GetSkillVersionsStatus(skillName)
+	if err != nil {
+		return fmt.Errorf("failed to get skill versions: %w", err)
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(resp)
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Version", "Published", "Status", "Latest", "Updated")
+	for _, v := range resp.Versions {
+		published := "unpublished"
+		if v.Published {
+			published = "published"
+		}
+		latest := ""
+		if v.IsLatest {
+			latest = "*"
+		}
+		t.AddRow(v.Version, published, v.Status, latest, printer.FormatAge(v.UpdatedAt))
+	}
+	return t.Render()
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/cliconfig"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateOutput string
+	statePrune  bool
+	stateDryRun bool
+)
+
+var StateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Export and import the registries and deployments of your arctl setup",
+	Long: `Moves an arctl setup to a new machine or shares a team baseline: export writes the registries of the config
+file and the deployed MCP servers and agents, with their versions and config, as JSON; import converges another setup
+to that file. The file is an apply document, so it can also be used with arctl apply -f.
+
+Registry tokens are not exported. Deployment config is exported as stored, so secrets set as plain values end up in the
+file; use secretRef:// values to keep them out of it.`,
+}
+
+var stateExportCmd = &cobra.Command{
+	Use:     "export",
+	Short:   "Write the registries and deployments as JSON",
+	Example: `  arctl state export > state.json`,
+	Args:    cobra.NoArgs,
+	RunE:    runStateExport,
+}
+
+var stateImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Converge the registries and deployments to an exported state",
+	Long: `Reads a file written by arctl state export, or stdin when no file or '-' is given, and converges to it like
+arctl apply: missing registries and deployments are created and differing ones updated. With --prune, deployments the
+file does not list are removed.`,
+	Example: `  arctl state import state.json
+  arctl state import --dry-run < state.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStateImport,
+}
+
+func init() {
+	stateExportCmd.Flags().StringVarP(&stateOutput, "file", "f", "", "Write to a file instead of stdout")
+	stateImportCmd.Flags().BoolVar(&statePrune, "prune", false, "Remove deployments the file does not list")
+	stateImportCmd.Flags().BoolVar(&stateDryRun, "dry-run", false, "Print the plan without changing anything")
+	StateCmd.AddCommand(stateExportCmd, stateImportCmd)
+}
+
+func runStateExport(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	deployments, err := apiClient.GetDeployedServers()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	doc, skipped := exportState(cfg, deployments)
+	for _, d := range skipped {
+		fmt.Fprintf(os.Stderr, "Skipping canary deployment of %s %s (%d%% of traffic)\n", d.ServerName, d.Version, d.CanaryWeight)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	data = append(data, '\n')
+	if stateOutput == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(stateOutput, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", stateOutput, err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d registries, %d servers and %d agents to %s\n",
+		len(doc.Registries), len(doc.Servers), len(doc.Agents), stateOutput)
+	return nil
+}
+
+// exportState describes the contexts of the config file and the deployments as an apply document. Canary
+// deployments, which apply cannot create, are returned apart.
+func exportState(cfg *cliconfig.Config, deployments []*client.DeploymentResponse) (*applyDocument, []*client.DeploymentResponse) {
+	doc := &applyDocument{}
+	for _, name := range cfg.ContextNames() {
+		doc.Registries = append(doc.Registries, applyRegistry{Name: name, URL: cfg.Contexts[name].RegistryURL})
+	}
+
+	var skipped []*client.DeploymentResponse
+	for _, d := range deployments {
+		if d.CanaryWeight > 0 {
+			skipped = append(skipped, d)
+			continue
+		}
+		r := &applyResource{
+			Name:               d.ServerName,
+			Version:            d.Version,
+			Runtime:            d.Runtime,
+			PreferRemote:       d.PreferRemote,
+			InstallationConfig: models.ParseInstallationConfig(d.Config),
+		}
+		// Deployments without a named target report their runtime as target
+		if d.Target != d.Runtime {
+			r.Target = d.Target
+		}
+		if r.Runtime == "kubernetes" && r.Target == "" {
+			r.Namespace = r.Env["KAGENT_NAMESPACE"]
+			delete(r.Env, "KAGENT_NAMESPACE")
+		}
+		if cmp.Or(d.ResourceType, models.CollectionItemTypeMCP) == models.CollectionItemTypeAgent {
+			doc.Agents = append(doc.Agents, r)
+		} else {
+			doc.Servers = append(doc.Servers, r)
+		}
+	}
+
+	byName := func(a, b *applyResource) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.Version, b.Version))
+	}
+	slices.SortFunc(doc.Servers, byName)
+	slices.SortFunc(doc.Agents, byName)
+	return doc, skipped
+}
+
+func runStateImport(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	path := "-"
+	if len(args) == 1 {
+		path = args[0]
+	}
+	data, err := readApplyFile(path)
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return fmt.Errorf("state file %s is empty", path)
+	}
+	doc, err := parseApplyDocument(data)
+	if err != nil {
+		return err
+	}
+	return applyState(commandContext(cmd), doc, statePrune, stateDryRun)
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/cliconfig"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportState(t *testing.T) {
+	cfg := &cliconfig.Config{Contexts: map[string]*cliconfig.Context{
+		"prod": {RegistryURL: "https://registry.example.com/v0", Token: "secret"},
+	}}
+	deployments := []*client.DeploymentResponse{
+		{ServerName: "io.github.user/weather", Version: "1.0.0", Runtime: "kubernetes", Target: "kubernetes",
+			Config: map[string]string{"KAGENT_NAMESPACE": "tools", "LOG_LEVEL": "debug", "ARG_port": "8080"}},
+		{ServerName: "io.github.user/weather", Version: "1.1.0", Runtime: "local", ResourceType: "mcp", CanaryWeight: 10},
+		{ServerName: "io.github.user/planner", Version: "2.0.0", Runtime: "docker", Target: "edge-docker", ResourceType: "agent"},
+	}
+
+	doc, skipped := exportState(cfg, deployments)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, []applyRegistry{{Name: "prod", URL: "https://registry.example.com/v0"}}, doc.Registries)
+	require.Len(t, doc.Servers, 1)
+	assert.Equal(t, "tools", doc.Servers[0].Namespace)
+	assert.Empty(t, doc.Servers[0].Target)
+	require.Len(t, doc.Agents, 1)
+	assert.Equal(t, "edge-docker", doc.Agents[0].Target)
+
+	// An export reads back as the same document
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+	parsed, err := parseApplyDocument(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug", "ARG_port": "8080"}, parsed.Servers[0].InstallationConfig.Flatten())
+	assert.Equal(t, "kubernetes", parsed.Servers[0].Runtime)
+	assert.Equal(t, "2.0.0", parsed.Agents[0].Version)
+}
@@ -0,0 +1,85 @@
+// Package statefile safely changes the files arctl keeps under ~/.arctl when several arctl commands run at once,
+// such as from scripts.
+package statefile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrLocked is returned when another arctl command holds the lock of a file for longer than the lock timeout
+var ErrLocked = errors.New("file is locked by another arctl command")
+
+var (
+	// LockTimeout is how long Lock waits for another command to release a file
+	LockTimeout = 10 * time.Second
+	// staleLockAge is the age after which a lock is assumed to be left over by a command that crashed. Commands
+	// hold locks for a single read-modify-write of a small file.
+	staleLockAge = time.Minute
+	retryDelay   = 50 * time.Millisecond
+)
+
+// Lock takes the lock of path, waiting up to LockTimeout for another command to release it, and returns the function
+// releasing it. The lock is a path.lock file holding the process ID, which works the same on every platform.
+func Lock(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create directory of %s: %w", path, err)
+	}
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(LockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err == nil {
+			_, _ = f.WriteString(strconv.Itoa(os.Getpid()))
+			_ = f.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			holder := "another arctl command"
+			if data, readErr := os.ReadFile(lockPath); readErr == nil && len(strings.TrimSpace(string(data))) > 0 {
+				holder += " (pid " + strings.TrimSpace(string(data)) + ")"
+			}
+			return nil, fmt.Errorf("%w: %s is changing %s; retry once it finishes, or remove %s if no arctl command is running",
+				ErrLocked, holder, path, lockPath)
+		}
+		time.Sleep(retryDelay)
+	}
+}
+
+// WriteAtomic writes data to path through a temp file and a rename, so readers never see a partly written file and a
+// crash never leaves a truncated one behind
+func WriteAtomic(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
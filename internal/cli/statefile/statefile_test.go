@@ -0,0 +1,68 @@
+package statefile
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+	LockTimeout = 200 * time.Millisecond
+	t.Cleanup(func() { LockTimeout = 10 * time.Second })
+
+	unlock, err := Lock(path)
+	require.NoError(t, err)
+
+	_, err = Lock(path)
+	require.ErrorIs(t, err, ErrLocked)
+	assert.Contains(t, err.Error(), path+".lock")
+
+	unlock()
+	unlock, err = Lock(path)
+	require.NoError(t, err)
+	unlock()
+
+	// A lock left over by a crashed command is taken over
+	require.NoError(t, os.WriteFile(path+".lock", []byte("1"), 0o600))
+	old := time.Now().Add(-2 * staleLockAge)
+	require.NoError(t, os.Chtimes(path+".lock", old, old))
+	unlock, err = Lock(path)
+	require.NoError(t, err)
+	unlock()
+}
+
+func TestLockSerializesUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counter")
+	require.NoError(t, WriteAtomic(path, []byte{}, 0o600))
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := Lock(path)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer unlock()
+			data, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			assert.NoError(t, WriteAtomic(path, append(data, 'x'), 0o600))
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, data, 20)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneDryRun bool
+	pruneYes    bool
+)
+
+var SystemCmd = &cobra.Command{
+	Use:   "system",
+	Short: "Inspect and maintain the runtime of the registry",
+	Long:  `Commands for registry operators maintaining the deployment runtime. Requires registry admin permissions.`,
+}
+
+var systemPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove images and runtime files no deployment uses anymore",
+	Long: `Removes the container images the runtime pulled that no deployment references anymore, the MCP config
+directories of agents that are no longer deployed, the directories of deployment targets that are no longer
+configured, and gateway configs without a compose project. Images still used by a container are kept.
+
+The registry also prunes in the background when RUNTIME_PRUNE_INTERVAL is set, or when the runtime-prune job is run.`,
+	Example: `  arctl system prune --dry-run
+  arctl system prune --yes`,
+	Args: cobra.NoArgs,
+	RunE: runSystemPrune,
+}
+
+var systemDfCmd = &cobra.Command{
+	Use:   "df",
+	Short: "Show the disk usage of the registry and its runtime",
+	Long: `Shows the size of the local ~/.arctl directory, and of the registry database, the runtime directory, and the
+images and volumes of the runtime on every docker target. Images and volumes no deployment uses anymore can be
+removed with 'arctl system prune'.`,
+	Example: `  arctl system df
+  arctl system df -o json`,
+	Args: cobra.NoArgs,
+	RunE: runSystemDf,
+}
+
+func init() {
+	systemPruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Only report what would be removed")
+	systemPruneCmd.Flags().BoolVarP(&pruneYes, "yes", "y", false, "Remove without asking")
+
+	SystemCmd.AddCommand(systemPruneCmd)
+	SystemCmd.AddCommand(systemDfCmd)
+}
+
+func runSystemPrune(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	if !pruneDryRun && !pruneYes && !printer.Format().IsStructured() {
+		plan, err := apiClient.PruneRuntime(true)
+		if err != nil {
+			return err
+		}
+		if err := printPruneReport(plan); err != nil || countReclaimed(plan) == 0 {
+			return err
+		}
+		fmt.Print("\nRemove these artifacts? [y/N]: ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			return errors.New("prune cancelled")
+		}
+		fmt.Println()
+	}
+
+	report, err := apiClient.PruneRuntime(pruneDryRun)
+	if err != nil {
+		return err
+	}
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(report)
+	}
+	if err := printPruneReport(report); err != nil {
+		return err
+	}
+	for _, item := range report.Items {
+		if item.Error != "" {
+			return fmt.Errorf("failed to remove some artifacts")
+		}
+	}
+	return nil
+}
+
+func printPruneReport(report *models.PruneReport) error {
+	if len(report.Items) == 0 {
+		fmt.Println("Nothing to prune")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Kind", "Target", "Name", "Size", "Status")
+	for _, item := range report.Items {
+		status := "removed"
+		switch {
+		case item.Skipped != "":
+			status = "kept: " + item.Skipped
+		case item.Error != "":
+			status = "failed: " + item.Error
+		case report.DryRun:
+			status = "would remove"
+		}
+		t.AddRow(item.Kind, item.Target, item.Name, printer.FormatBytes(item.Size), status)
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+
+	verb := "Reclaimed"
+	if report.DryRun {
+		verb = "Would reclaim"
+	}
+	fmt.Printf("\n%s %s from %d artifact(s)\n", verb, printer.FormatBytes(report.ReclaimedBytes), countReclaimed(report))
+	return nil
+}
+
+// countReclaimed counts the artifacts a prune removed, or would remove in a dry run
+func countReclaimed(report *models.PruneReport) int {
+	n := 0
+	for _, item := range report.Items {
+		if item.Reclaimed() {
+			n++
+		}
+	}
+	return n
+}
+
+func runSystemDf(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	usage, err := apiClient.GetSystemUsage()
+	if err != nil {
+		return err
+	}
+	var configBytes int64
+	if home, err := os.UserHomeDir(); err == nil {
+		configBytes = localDirSize(filepath.Join(home, ".arctl"))
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(struct {
+			ConfigDirBytes int64 `json:"configDirBytes"`
+			*models.SystemUsage
+		}{configBytes, usage})
+	}
+
+	var imageBytes, volumeBytes int64
+	for _, image := range usage.Images {
+		imageBytes += image.Size
+	}
+	for _, volume := range usage.Volumes {
+		volumeBytes += volume.Size
+	}
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Type", "Count", "Size")
+	t.AddRow("Local config (~/.arctl)", "-", printer.FormatBytes(configBytes))
+	t.AddRow("Registry database", "-", printer.FormatBytes(usage.DatabaseBytes))
+	t.AddRow("Runtime directory", "-", printer.FormatBytes(usage.RuntimeDirBytes))
+	t.AddRow("Images", len(usage.Images), printer.FormatBytes(imageBytes))
+	t.AddRow("Volumes", len(usage.Volumes), printer.FormatBytes(volumeBytes))
+	if err := t.Render(); err != nil {
+		return err
+	}
+
+	if len(usage.Images) > 0 {
+		fmt.Println()
+		t = printer.NewTablePrinter(os.Stdout)
+		t.SetHeaders("Target", "Image", "Size", "In Use")
+		for _, image := range usage.Images {
+			t.AddRow(image.Target, image.Name, printer.FormatBytes(image.Size), image.InUse)
+		}
+		if err := t.Render(); err != nil {
+			return err
+		}
+	}
+	if len(usage.Volumes) > 0 {
+		fmt.Println()
+		t = printer.NewTablePrinter(os.Stdout)
+		t.SetHeaders("Target", "Volume", "Size", "In Use")
+		for _, volume := range usage.Volumes {
+			t.AddRow(volume.Target, volume.Name, printer.FormatBytes(volume.Size), volume.InUse)
+		}
+		if err := t.Render(); err != nil {
+			return err
+		}
+	}
+	for _, warning := range usage.Warnings {
+		fmt.Println("Warning: " + warning)
+	}
+	return nil
+}
+
+// localDirSize returns the total size of the regular files below dir, or 0 when it does not exist
+func localDirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
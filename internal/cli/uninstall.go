@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	uninstallVersion string
+	uninstallYes     bool
+)
+
+var UninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the deployments of MCP servers or agents matching a name or pattern",
+}
+
+var uninstallMCPCmd = &cobra.Command{
+	Use:   "mcp <name-or-pattern>",
+	Short: "Remove the deployments of MCP servers matching a name or glob pattern",
+	Long: `Removes every deployment of the MCP servers whose name matches the argument, a full name or a glob pattern:
+* matches any characters except '/', ? a single character and [...] a character class. Quote the pattern so the
+shell does not expand it.`,
+	Example: `  arctl uninstall mcp 'io.github.myorg/*'
+  arctl uninstall mcp 'io.github.myorg/weather-*' --version 1.0.0 --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runUninstall(commandContext(cmd), models.CollectionItemTypeMCP, args[0])
+	},
+}
+
+var uninstallAgentCmd = &cobra.Command{
+	Use:     "agent <name-or-pattern>",
+	Short:   "Remove the deployments of agents matching a name or glob pattern",
+	Example: `  arctl uninstall agent 'io.github.myorg/*'`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runUninstall(commandContext(cmd), models.CollectionItemTypeAgent, args[0])
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{uninstallMCPCmd, uninstallAgentCmd} {
+		cmd.Flags().StringVar(&uninstallVersion, "version", "", "Only remove deployments of this version")
+		cmd.Flags().BoolVarP(&uninstallYes, "yes", "y", false, "Remove without asking")
+	}
+	UninstallCmd.AddCommand(uninstallMCPCmd, uninstallAgentCmd)
+}
+
+func runUninstall(ctx context.Context, resourceType, pattern string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	if err := validateNamePattern(pattern); err != nil {
+		return err
+	}
+
+	deployments, err := apiClient.GetDeployedServers()
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+	matches := matchDeployments(deployments, resourceType, pattern, uninstallVersion)
+	if len(matches) == 0 {
+		return fmt.Errorf("no %s deployment matches %s", resourceType, pattern)
+	}
+
+	fmt.Printf("%d deployment(s) match %s:\n", len(matches), pattern)
+	for _, d := range matches {
+		fmt.Printf("  - %s %s (%s)\n", resourceType, d.ServerName, d.Version)
+	}
+	if !uninstallYes {
+		ok, err := confirm("Remove them now?")
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	var failed []string
+	for i, d := range matches {
+		if ctx.Err() != nil {
+			return interruptedError("removing", len(matches)-i)
+		}
+		if err := apiClient.RemoveDeployment(d.ServerName, d.Version, resourceType); err != nil {
+			printer.PrintError(fmt.Sprintf("failed to remove %s %s: %v", d.ServerName, d.Version, err))
+			failed = append(failed, d.ServerName+"@"+d.Version)
+			continue
+		}
+		printer.PrintSuccess(fmt.Sprintf("Removed %s %s", d.ServerName, d.Version))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to remove %s", strings.Join(failed, ", "))
+	}
+	fmt.Println("The registry will reconcile the runtime automatically.")
+	return nil
+}
+
+// validateNamePattern rejects a malformed glob pattern, which path.Match only reports when it gets to the bad part
+func validateNamePattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// matchesNamePattern reports whether a resource name matches a glob pattern of validateNamePattern
+func matchesNamePattern(pattern, name string) bool {
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
+
+// namePatternPrefix returns the literal part of a pattern before its first wildcard, used to narrow listings
+func namePatternPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, `*?[\`); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
+
+// matchDeployments returns the deployments of a resource type whose name matches pattern, of the given version
+// unless version is empty
+func matchDeployments(deployments []*client.DeploymentResponse, resourceType, pattern, version string) []*client.DeploymentResponse {
+	var matches []*client.DeploymentResponse
+	for _, d := range deployments {
+		deploymentType := d.ResourceType
+		if deploymentType == "" {
+			deploymentType = models.CollectionItemTypeMCP
+		}
+		if deploymentType != resourceType || !matchesNamePattern(pattern, d.ServerName) {
+			continue
+		}
+		if version != "" && d.Version != version {
+			continue
+		}
+		matches = append(matches, d)
+	}
+	return matches
+}
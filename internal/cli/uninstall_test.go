@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchDeployments(t *testing.T) {
+	deployments := []*client.DeploymentResponse{
+		{ServerName: "io.github.myorg/weather", Version: "1.0.0"},
+		{ServerName: "io.github.myorg/weather", Version: "1.1.0", ResourceType: "mcp"},
+		{ServerName: "io.github.myorg/github", Version: "2.0.0", ResourceType: "mcp"},
+		{ServerName: "io.github.myorg/planner", Version: "1.0.0", ResourceType: "agent"},
+		{ServerName: "io.github.other/weather", Version: "1.0.0", ResourceType: "mcp"},
+	}
+	names := func(matches []*client.DeploymentResponse) []string {
+		var out []string
+		for _, d := range matches {
+			out = append(out, d.ServerName+"@"+d.Version)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"io.github.myorg/weather@1.0.0", "io.github.myorg/weather@1.1.0", "io.github.myorg/github@2.0.0"},
+		names(matchDeployments(deployments, "mcp", "io.github.myorg/*", "")))
+	assert.Equal(t, []string{"io.github.myorg/weather@1.1.0"}, names(matchDeployments(deployments, "mcp", "io.github.myorg/*", "1.1.0")))
+	assert.Equal(t, []string{"io.github.myorg/weather@1.0.0", "io.github.other/weather@1.0.0"},
+		names(matchDeployments(deployments, "mcp", "io.github.*/weather", "1.0.0")))
+	assert.Equal(t, []string{"io.github.myorg/planner@1.0.0"}, names(matchDeployments(deployments, "agent", "io.github.myorg/*", "")))
+	// * does not cross the namespace separator
+	assert.Empty(t, matchDeployments(deployments, "mcp", "io.github.*", ""))
+
+	assert.Error(t, validateNamePattern("io.github.myorg/[weather"))
+	assert.NoError(t, validateNamePattern("io.github.myorg/weather"))
+	assert.Equal(t, "io.github.myorg/", namePatternPrefix("io.github.myorg/*"))
+	assert.Equal(t, "io.github.", namePatternPrefix("io.github.[mo]*/weather"))
+	assert.Equal(t, "io.github.myorg/weather", namePatternPrefix("io.github.myorg/weather"))
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	workspaceDisplayName string
+	workspaceDescription string
+	workspaceMemberRole  string
+)
+
+var WorkspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Manage team workspaces",
+	Long: `Workspaces let several teams share one registry. Entries, deployments and API tokens created with
+--workspace (or ARCTL_WORKSPACE) belong to that workspace, and its unpublished entries are only visible to its members.`,
+}
+
+var workspaceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the workspaces you are a member of",
+	RunE:  runWorkspaceList,
+}
+
+var workspaceCreateCmd = &cobra.Command{
+	Use:     "create <name>",
+	Short:   "Create a workspace owned by you",
+	Example: `  arctl workspace create data-platform --display-name "Data Platform"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runWorkspaceCreate,
+}
+
+var workspaceDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a workspace once its entries and deployments are removed",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorkspaceDelete,
+}
+
+var workspaceMembersCmd = &cobra.Command{
+	Use:   "members <name>",
+	Short: "List the members of a workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorkspaceMembers,
+}
+
+var workspaceAddMemberCmd = &cobra.Command{
+	Use:     "add-member <name> <subject>",
+	Short:   "Add a member to a workspace or change their role",
+	Example: `  arctl workspace add-member data-platform alice@example.com --role owner`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runWorkspaceAddMember,
+}
+
+var workspaceRemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member <name> <subject>",
+	Short: "Remove a member from a workspace",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runWorkspaceRemoveMember,
+}
+
+func init() {
+	workspaceCreateCmd.Flags().StringVar(&workspaceDisplayName, "display-name", "", "Human readable workspace name")
+	workspaceCreateCmd.Flags().StringVar(&workspaceDescription, "description", "", "Workspace description")
+	workspaceAddMemberCmd.Flags().StringVar(&workspaceMemberRole, "role", models.WorkspaceRoleMember, "Role of the member (owner, member)")
+
+	WorkspaceCmd.AddCommand(workspaceListCmd, workspaceCreateCmd, workspaceDeleteCmd, workspaceMembersCmd,
+		workspaceAddMemberCmd, workspaceRemoveMemberCmd)
+}
+
+func runWorkspaceList(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	workspaces, err := apiClient.GetWorkspaces()
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(workspaces)
+	}
+	if len(workspaces) == 0 {
+		fmt.Println("No workspaces found")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Name", "Display Name", "Created By", "Created")
+	for _, w := range workspaces {
+		t.AddRow(w.Name, printer.EmptyValueOrDefault(w.DisplayName, "<none>"), w.CreatedBy, printer.FormatAge(w.CreatedAt))
+	}
+	return t.Render()
+}
+
+func runWorkspaceCreate(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	workspace, err := apiClient.CreateWorkspace(&models.Workspace{
+		Name:        args[0],
+		DisplayName: workspaceDisplayName,
+		Description: workspaceDescription,
+	})
+	if err != nil {
+		return err
+	}
+	printer.PrintSuccess(fmt.Sprintf("Created workspace %s", workspace.Name))
+	return nil
+}
+
+func runWorkspaceDelete(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	if err := apiClient.DeleteWorkspace(args[0]); err != nil {
+		return err
+	}
+	printer.PrintSuccess(fmt.Sprintf("Deleted workspace %s", args[0]))
+	return nil
+}
+
+func runWorkspaceMembers(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	members, err := apiClient.GetWorkspaceMembers(args[0])
+	if err != nil {
+		return err
+	}
+
+	if printer.Format().IsStructured() {
+		return printer.PrintStructured(members)
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Subject", "Role", "Added")
+	for _, m := range members {
+		t.AddRow(m.Subject, m.Role, printer.FormatAge(m.AddedAt))
+	}
+	return t.Render()
+}
+
+func runWorkspaceAddMember(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	member, err := apiClient.SetWorkspaceMember(args[0], args[1], workspaceMemberRole)
+	if err != nil {
+		return err
+	}
+	printer.PrintSuccess(fmt.Sprintf("%s is now %s of workspace %s", member.Subject, member.Role, args[0]))
+	return nil
+}
+
+func runWorkspaceRemoveMember(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	if err := apiClient.RemoveWorkspaceMember(args[0], args[1]); err != nil {
+		return err
+	}
+	printer.PrintSuccess(fmt.Sprintf("Removed %s from workspace %s", args[1], args[0]))
+	return nil
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,7 +14,10 @@ import (
 	"time"
 
 	internalv0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	v0auth "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/auth"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
@@ -22,12 +26,17 @@ type Client struct {
 	BaseURL    string
 	httpClient *http.Client
 	token      string
+	workspace  string
+	// ctx is the context of every request, canceled when the command is interrupted
+	ctx context.Context
 }
 
 const (
 	defaultRegistryName = "local"
 	defaultBaseURL      = "http://localhost:12121/v0"
 	DefaultBaseURL      = defaultBaseURL
+	// DefaultTimeout bounds each request unless SetTimeout changes it
+	DefaultTimeout = 30 * time.Second
 )
 
 // NewClientFromEnv constructs a client using environment variables
@@ -44,8 +53,9 @@ func NewClient(baseURL, token string) *Client {
 		BaseURL: baseURL,
 		token:   token,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		ctx: context.Background(),
 	}
 }
 
@@ -78,6 +88,25 @@ func pingWithRetry(c *Client) error {
 	return fmt.Errorf("failed to reach API after %d attempts: %w", attempts, lastErr)
 }
 
+// SetContext makes every request use ctx, so canceling it, such as on Ctrl-C, aborts the request in flight and fails
+// the ones after it
+func (c *Client) SetContext(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c.ctx = ctx
+}
+
+// SetTimeout bounds how long a single request may take, including reading its response
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+}
+
+// SetWorkspace makes every request act in the given workspace; empty acts outside any workspace
+func (c *Client) SetWorkspace(workspace string) {
+	c.workspace = workspace
+}
+
 // Close is a no-op in API mode
 func (c *Client) Close() error { return nil }
 
@@ -94,26 +123,32 @@ func (c *Client) baseURLWithoutVersion() string {
 
 func (c *Client) newRequest(method, pathWithQuery string) (*http.Request, error) {
 	fullURL := strings.TrimRight(c.BaseURL, "/") + pathWithQuery
-	req, err := http.NewRequest(method, fullURL, nil)
+	req, err := http.NewRequestWithContext(c.ctx, method, fullURL, nil)
 	if err != nil {
 		return nil, err
 	}
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
+	if c.workspace != "" {
+		req.Header.Set(auth.WorkspaceHeader, c.workspace)
+	}
 	return req, nil
 }
 
 func (c *Client) newAdminRequest(method, pathWithQuery string) (*http.Request, error) {
 	base := c.baseURLWithoutVersion()
 	fullURL := strings.TrimRight(base, "/") + pathWithQuery
-	req, err := http.NewRequest(method, fullURL, nil)
+	req, err := http.NewRequestWithContext(c.ctx, method, fullURL, nil)
 	if err != nil {
 		return nil, err
 	}
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
+	if c.workspace != "" {
+		req.Header.Set(auth.WorkspaceHeader, c.workspace)
+	}
 	return req, nil
 }
 
@@ -163,6 +198,30 @@ func (c *Client) Ping() error {
 	return c.doJSON(req, nil)
 }
 
+// GetReadiness returns the readiness report of the registry. A registry that is not ready answers with 503 and
+// still reports which components failed.
+func (c *Client) GetReadiness(checkRuntime bool) (*models.Readiness, error) {
+	req, err := c.newRequest(http.MethodGet, "/readyz?runtime="+strconv.FormatBool(checkRuntime))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status: %s, %s", resp.Status, string(errBody))
+	}
+	var readiness models.Readiness
+	if err := json.NewDecoder(resp.Body).Decode(&readiness); err != nil {
+		return nil, fmt.Errorf("failed to decode readiness: %w", err)
+	}
+	return &readiness, nil
+}
+
 func (c *Client) GetVersion() (*internalv0.VersionBody, error) {
 	req, err := c.newRequest(http.MethodGet, "/version")
 	if err != nil {
@@ -175,68 +234,16 @@ func (c *Client) GetVersion() (*internalv0.VersionBody, error) {
 	return &resp, nil
 }
 
+// GetAllServers returns all MCP servers, including unpublished ones (admin endpoint).
+// Prefer ListServersIter when the full catalog doesn't need to be held in memory.
 func (c *Client) GetAllServers() ([]*v0.ServerResponse, error) {
-	limit := 100
-	cursor := ""
-	var all []*v0.ServerResponse
-
-	for {
-		req, err := c.newAdminRequest(http.MethodGet, "/admin/v0/servers?limit="+strconv.Itoa(limit)+"&cursor="+url.QueryEscape(cursor))
-		if err != nil {
-			return nil, err
-		}
-
-		var resp v0.ServerListResponse
-		if err := c.doJSON(req, &resp); err != nil {
-			return nil, err
-		}
-
-		for _, s := range resp.Servers {
-			all = append(all, &s)
-		}
-
-		if resp.Metadata.NextCursor == "" {
-			break
-		}
-		cursor = resp.Metadata.NextCursor
-	}
-
-	return all, nil
+	return collectServers(c.ListServersIter(context.Background(), ServerListFilter{IncludeUnpublished: true}))
 }
 
-// GetPublishedServers returns all published MCP servers
+// GetPublishedServers returns all published MCP servers.
+// Prefer ListServersIter when the full catalog doesn't need to be held in memory.
 func (c *Client) GetPublishedServers() ([]*v0.ServerResponse, error) {
-	// Cursor-based pagination to fetch all servers
-	limit := 100
-	cursor := ""
-	var all []*v0.ServerResponse
-
-	for {
-		q := fmt.Sprintf("/servers?limit=%d", limit)
-		if cursor != "" {
-			q += "&cursor=" + url.QueryEscape(cursor)
-		}
-		req, err := c.newRequest(http.MethodGet, q)
-		if err != nil {
-			return nil, err
-		}
-
-		var resp v0.ServerListResponse
-		if err := c.doJSON(req, &resp); err != nil {
-			return nil, err
-		}
-
-		for _, s := range resp.Servers {
-			all = append(all, &s)
-		}
-
-		if resp.Metadata.NextCursor == "" {
-			break
-		}
-		cursor = resp.Metadata.NextCursor
-	}
-
-	return all, nil
+	return collectServers(c.ListServersIter(context.Background(), ServerListFilter{}))
 }
 
 // GetServerByName returns a server by name (latest version)
@@ -544,6 +551,14 @@ func (c *Client) PublishMCPServer(server *v0.ServerJSON) (*v0.ServerResponse, er
 	return c.GetServerByNameAndVersion(server.Name, server.Version, true)
 }
 
+// PublishMCPServersBatch creates many MCP servers in one request, publishing them too when publish is set.
+// Servers are created independently, so the response reports a result per server.
+func (c *Client) PublishMCPServersBatch(servers []*v0.ServerJSON, publish bool) (*models.BatchPublishResponse, error) {
+	var resp models.BatchPublishResponse
+	err := c.doJsonRequest(http.MethodPost, "/publish/batch?publish="+strconv.FormatBool(publish), servers, &resp)
+	return &resp, err
+}
+
 // UnpublishMCPServer unpublishes an MCP server from the registry
 func (c *Client) UnpublishMCPServer(name, version string) error {
 	encName := url.PathEscape(name)
@@ -556,6 +571,39 @@ func (c *Client) UnpublishMCPServer(name, version string) error {
 	return c.doJSON(req, nil)
 }
 
+// GetServerVersionsStatus returns the publish state of every version of an MCP server
+func (c *Client) GetServerVersionsStatus(name string) (*models.ServerVersionsStatusResponse, error) {
+	req, err := c.newAdminRequest(http.MethodGet, "/admin/v0/servers/"+url.PathEscape(name)+"/versions-status")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.ServerVersionsStatusResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetServerVersionsPublished publishes or unpublishes several versions of an MCP server in one request
+func (c *Client) SetServerVersionsPublished(name string, versions []string, published bool) error {
+	req, err := c.newAdminRequest(http.MethodPost, "/admin/v0/servers/"+url.PathEscape(name)+"/versions-status")
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"versions":  versions,
+		"published": published,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal versions status request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Body = io.NopCloser(bytes.NewReader(payload))
+	return c.doJSON(req, nil)
+}
+
 // UnpublishSkill unpublishes a skill from the registry
 func (c *Client) UnpublishSkill(name, version string) error {
 	encName := url.PathEscape(name)
@@ -595,6 +643,122 @@ func (c *Client) GetSkillVersions(name string) ([]*models.SkillResponse, error)
 	return result, nil
 }
 
+// GetSkillVersionsStatus returns the publish and lifecycle state of every version of a skill
+func (c *Client) GetSkillVersionsStatus(name string) (*models.SkillVersionsStatusResponse, error) {
+	req, err := c.newAdminRequest(http.MethodGet, "/admin/v0/skills/"+url.PathEscape(name)+"/versions-status")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.SkillVersionsStatusResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetSkillStatus changes the lifecycle status (active, deprecated, deleted) of a skill version
+func (c *Client) SetSkillStatus(name, version, status string) (*models.SkillResponse, error) {
+	path := "/admin/v0/skills/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version) + "/status?status=" + url.QueryEscape(status)
+	req, err := c.newAdminRequest(http.MethodPut, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.SkillResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UploadServerReadme stores the README of an existing server version
+func (c *Client) UploadServerReadme(name, version string, content []byte, contentType string) error {
+	payload := map[string]string{
+		"content":     string(content),
+		"contentType": contentType,
+	}
+	path := "/servers/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version) + "/readme"
+	return c.doJsonRequest(http.MethodPut, path, payload, nil)
+}
+
+// GetServerReadme returns the README of a server version ("latest" or empty for the latest version).
+// It returns nil when the server has no README.
+func (c *Client) GetServerReadme(name, version string) (*internalv0.ServerReadmeResponse, error) {
+	path := "/servers/" + url.PathEscape(name) + "/versions/" + url.PathEscape(versionOrLatest(version)) + "/readme"
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp internalv0.ServerReadmeResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		if asHTTPStatus(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get server README: %w", err)
+	}
+	return &resp, nil
+}
+
+// UploadAgentReadme stores the README of an existing agent version
+func (c *Client) UploadAgentReadme(name, version string, content []byte, contentType string) error {
+	payload := map[string]string{
+		"content":     string(content),
+		"contentType": contentType,
+	}
+	path := "/agents/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version) + "/readme"
+	return c.doJsonRequest(http.MethodPut, path, payload, nil)
+}
+
+// GetAgentReadme returns the README of an agent version ("latest" or empty for the latest version).
+// It returns nil when the agent has no README.
+func (c *Client) GetAgentReadme(name, version string) (*internalv0.AgentReadmeResponse, error) {
+	path := "/agents/" + url.PathEscape(name) + "/versions/" + url.PathEscape(versionOrLatest(version)) + "/readme"
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp internalv0.AgentReadmeResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		if asHTTPStatus(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get agent README: %w", err)
+	}
+	return &resp, nil
+}
+
+// UploadSkillReadme stores the README of an existing skill version
+func (c *Client) UploadSkillReadme(name, version string, content []byte, contentType string) error {
+	payload := map[string]string{
+		"content":     string(content),
+		"contentType": contentType,
+	}
+	path := "/skills/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version) + "/readme"
+	return c.doJsonRequest(http.MethodPut, path, payload, nil)
+}
+
+// GetSkillReadme returns the README of a skill version ("latest" or empty for the latest version).
+// It returns nil when the skill has no README.
+func (c *Client) GetSkillReadme(name, version string) (*internalv0.SkillReadmeResponse, error) {
+	path := "/skills/" + url.PathEscape(name) + "/versions/" + url.PathEscape(versionOrLatest(version)) + "/readme"
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp internalv0.SkillReadmeResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		if asHTTPStatus(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get skill README: %w", err)
+	}
+	return &resp, nil
+}
+
 // GetSkillByNameAndVersion returns a specific version of a skill
 func (c *Client) GetSkillByNameAndVersion(name, version string) (*models.SkillResponse, error) {
 	encName := url.PathEscape(name)
@@ -735,6 +899,9 @@ type DeploymentResponse struct {
 	PreferRemote bool              `json:"preferRemote"`
 	ResourceType string            `json:"resourceType"`
 	Runtime      string            `json:"runtime"`
+	Target       string            `json:"target,omitempty"`
+	Origin       string            `json:"origin,omitempty"`
+	CanaryWeight int               `json:"canaryWeight,omitempty"`
 }
 
 // DeploymentsListResponse represents the list of deployments
@@ -763,6 +930,22 @@ func (c *Client) GetDeployedServers() ([]*DeploymentResponse, error) {
 	return result, nil
 }
 
+// GetDeploymentHealth retrieves the runtime state of all deployments
+func (c *Client) GetDeploymentHealth() ([]models.DeploymentHealth, error) {
+	req, err := c.newRequest(http.MethodGet, "/deployments/health")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Deployments []models.DeploymentHealth `json:"deployments"`
+	}
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Deployments, nil
+}
+
 // GetDeployedServerByNameAndVersion retrieves a specific deployment by name and version
 func (c *Client) GetDeployedServerByNameAndVersion(name string, version string, resourceType string) (*DeploymentResponse, error) {
 	encName := url.PathEscape(name)
@@ -784,8 +967,25 @@ func (c *Client) GetDeployedServerByNameAndVersion(name string, version string,
 	return &deployment, nil
 }
 
-// DeployServer deploys a server with configuration
-func (c *Client) DeployServer(name, version string, config map[string]string, preferRemote bool, runtimeTarget string) (*DeploymentResponse, error) {
+// ListDeploymentTargets retrieves the named deployment targets configured on the registry
+func (c *Client) ListDeploymentTargets() ([]runtime.Target, error) {
+	req, err := c.newRequest(http.MethodGet, "/deployments/targets")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Targets []runtime.Target `json:"targets"`
+	}
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Targets, nil
+}
+
+// DeployServer deploys a server with configuration. A non-empty target selects a named deployment target of the
+// registry and a non-empty origin resolves the manifest from that registry.
+func (c *Client) DeployServer(name, version string, config map[string]string, preferRemote bool, runtimeTarget, target, origin string) (*DeploymentResponse, error) {
 	payload := internalv0.DeploymentRequest{
 		ServerName:   name,
 		Version:      version,
@@ -793,6 +993,8 @@ func (c *Client) DeployServer(name, version string, config map[string]string, pr
 		PreferRemote: preferRemote,
 		ResourceType: "mcp",
 		Runtime:      runtimeTarget,
+		Target:       target,
+		Origin:       origin,
 	}
 
 	var deployment DeploymentResponse
@@ -803,14 +1005,44 @@ func (c *Client) DeployServer(name, version string, config map[string]string, pr
 	return &deployment, nil
 }
 
-// DeployAgent deploys an agent with configuration
-func (c *Client) DeployAgent(name, version string, config map[string]string, runtimeTarget string) (*DeploymentResponse, error) {
+// DeployServerCanary rolls a version of a deployed server out as a canary that receives weight percent of its
+// traffic. An empty config inherits the configuration of the current deployment.
+func (c *Client) DeployServerCanary(name, version string, config map[string]string, weight int) (*DeploymentResponse, error) {
+	payload := internalv0.DeploymentRequest{
+		ServerName:   name,
+		Version:      version,
+		Config:       config,
+		ResourceType: "mcp",
+		CanaryWeight: weight,
+	}
+
+	var deployment DeploymentResponse
+	if err := c.doJsonRequest(http.MethodPost, "/deployments", payload, &deployment); err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+// PromoteDeployment finishes the canary rollout of a server, replacing the previously deployed version
+func (c *Client) PromoteDeployment(name string) (*DeploymentResponse, error) {
+	var deployment DeploymentResponse
+	if err := c.doJsonRequest(http.MethodPost, "/deployments/"+url.PathEscape(name)+"/promote", nil, &deployment); err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+// DeployAgent deploys an agent with configuration. A non-empty target selects a named deployment target of the registry.
+func (c *Client) DeployAgent(name, version string, config map[string]string, runtimeTarget, target string) (*DeploymentResponse, error) {
 	payload := internalv0.DeploymentRequest{
 		ServerName:   name,
 		Version:      version,
 		Config:       config,
 		ResourceType: "agent",
 		Runtime:      runtimeTarget,
+		Target:       target,
 	}
 
 	var deployment DeploymentResponse
@@ -837,6 +1069,70 @@ func (c *Client) UpdateDeploymentConfig(name string, version string, resourceTyp
 	return &deployment, nil
 }
 
+// UpdateDeploymentOrigin switches the registry an MCP server deployment resolves its manifest from
+func (c *Client) UpdateDeploymentOrigin(name string, version string, origin string) (*DeploymentResponse, error) {
+	encName := url.PathEscape(name)
+	encVersion := url.PathEscape(version)
+	payload := internalv0.DeploymentOriginUpdate{Origin: origin}
+
+	var deployment DeploymentResponse
+	if err := c.doJsonRequest(http.MethodPut, "/deployments/"+encName+"/versions/"+encVersion+"/origin?resourceType=mcp", payload, &deployment); err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+// DiffDeployment compares the desired state of a deployment with what runs on its target
+func (c *Client) DiffDeployment(name string, version string, resourceType string) (*models.DeploymentDiff, error) {
+	var diff models.DeploymentDiff
+	if err := c.doJsonRequest(http.MethodGet, "/deployments/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version)+"/diff?resourceType="+resourceType, nil, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// ExportRuntime renders all current deployments as a compose, kustomize or helm bundle
+func (c *Client) ExportRuntime(format string) (*models.RuntimeExport, error) {
+	var export models.RuntimeExport
+	if err := c.doJsonRequest(http.MethodGet, "/deployments/export?format="+url.QueryEscape(format), nil, &export); err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ListDeploymentRevisions returns the revision history of a deployment, newest first
+func (c *Client) ListDeploymentRevisions(name string, resourceType string) ([]*models.DeploymentRevision, error) {
+	var resp struct {
+		Revisions []*models.DeploymentRevision `json:"revisions"`
+	}
+	if err := c.doJsonRequest(http.MethodGet, "/deployments/"+url.PathEscape(name)+"/revisions?resourceType="+resourceType, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Revisions, nil
+}
+
+// GetDeploymentMetrics samples the CPU and memory usage of the containers of a deployment
+func (c *Client) GetDeploymentMetrics(name string, resourceType string) (*models.DeploymentMetrics, error) {
+	var resp models.DeploymentMetrics
+	if err := c.doJsonRequest(http.MethodGet, "/deployments/"+url.PathEscape(name)+"/metrics?resourceType="+resourceType, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RollbackDeployment returns a deployment to an earlier revision; revision 0 selects the one before the latest
+func (c *Client) RollbackDeployment(name string, resourceType string, revision int) (*DeploymentResponse, error) {
+	payload := internalv0.DeploymentRollbackRequest{ResourceType: resourceType, Revision: revision}
+
+	var deployment DeploymentResponse
+	if err := c.doJsonRequest(http.MethodPost, "/deployments/"+url.PathEscape(name)+"/rollback", payload, &deployment); err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
 // RemoveDeployment removes a deployment
 func (c *Client) RemoveDeployment(name string, version string, resourceType string) error {
 	encName := url.PathEscape(name)
@@ -848,3 +1144,544 @@ func (c *Client) RemoveDeployment(name string, version string, resourceType stri
 
 	return c.doJSON(req, nil)
 }
+
+// AuditLogQuery holds optional filters for GetAuditLog
+type AuditLogQuery struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceName string
+	Since        time.Time
+	Until        time.Time
+	// Limit caps the total number of entries returned; 0 means no cap
+	Limit int
+}
+
+// GetAuditLog returns audit log entries (newest first) matching the query. Requires registry admin permissions.
+func (c *Client) GetAuditLog(query AuditLogQuery) ([]*models.AuditLogEntry, error) {
+	pageSize := 100
+	if query.Limit > 0 && query.Limit < pageSize {
+		pageSize = query.Limit
+	}
+
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(pageSize))
+	if query.Actor != "" {
+		params.Set("actor", query.Actor)
+	}
+	if query.Action != "" {
+		params.Set("action", query.Action)
+	}
+	if query.ResourceType != "" {
+		params.Set("resourceType", query.ResourceType)
+	}
+	if query.ResourceName != "" {
+		params.Set("resourceName", query.ResourceName)
+	}
+	if !query.Since.IsZero() {
+		params.Set("since", query.Since.UTC().Format(time.RFC3339))
+	}
+	if !query.Until.IsZero() {
+		params.Set("until", query.Until.UTC().Format(time.RFC3339))
+	}
+
+	var all []*models.AuditLogEntry
+	for {
+		req, err := c.newRequest(http.MethodGet, "/audit?"+params.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		var resp models.AuditLogResponse
+		if err := c.doJSON(req, &resp); err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Entries {
+			all = append(all, &resp.Entries[i])
+		}
+
+		if query.Limit > 0 && len(all) >= query.Limit {
+			return all[:query.Limit], nil
+		}
+		if resp.Metadata.NextCursor == "" {
+			break
+		}
+		params.Set("cursor", resp.Metadata.NextCursor)
+	}
+
+	return all, nil
+}
+
+// CreateAPIToken mints a long-lived API token. The returned token value is only available once.
+func (c *Client) CreateAPIToken(name string, permissions []auth.Permission, expiresAt *time.Time) (*models.CreatedAPIToken, error) {
+	payload := struct {
+		Name        string            `json:"name"`
+		Permissions []auth.Permission `json:"permissions"`
+		ExpiresAt   *time.Time        `json:"expiresAt,omitempty"`
+	}{Name: name, Permissions: permissions, ExpiresAt: expiresAt}
+
+	var resp models.CreatedAPIToken
+	if err := c.doJsonRequest(http.MethodPost, "/auth/tokens", payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListAPITokens lists the caller's API tokens, or all tokens when all is set (admin only)
+func (c *Client) ListAPITokens(all bool) ([]models.APIToken, error) {
+	path := "/auth/tokens"
+	if all {
+		path += "?all=true"
+	}
+	var resp models.APITokenListResponse
+	if err := c.doJsonRequest(http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Tokens, nil
+}
+
+// RevokeAPIToken revokes an API token by ID
+func (c *Client) RevokeAPIToken(id int64) error {
+	return c.doJsonRequest(http.MethodDelete, "/auth/tokens/"+strconv.FormatInt(id, 10), nil, nil)
+}
+
+// AgentSBOM is an SBOM document downloaded from the registry
+type AgentSBOM struct {
+	Format         string
+	ComponentCount int
+	Content        []byte
+}
+
+// UploadAgentSBOM attaches an SPDX or CycloneDX JSON SBOM to an agent version
+func (c *Client) UploadAgentSBOM(name, version string, content []byte) (*internalv0.AgentSBOMMetadata, error) {
+	req, err := c.newRequest(http.MethodPut, "/agents/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version)+"/sbom")
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Body = io.NopCloser(bytes.NewReader(content))
+	req.ContentLength = int64(len(content))
+
+	var resp internalv0.AgentSBOMMetadata
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAgentSBOM downloads the SBOM of an agent version. Returns nil if the version has no SBOM.
+func (c *Client) GetAgentSBOM(name, version string) (*AgentSBOM, error) {
+	req, err := c.newRequest(http.MethodGet, "/agents/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version)+"/sbom")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status: %s, %s", resp.Status, string(errBody))
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SBOM: %w", err)
+	}
+	count, _ := strconv.Atoi(resp.Header.Get("X-SBOM-Component-Count"))
+	return &AgentSBOM{
+		Format:         resp.Header.Get("X-SBOM-Format"),
+		ComponentCount: count,
+		Content:        content,
+	}, nil
+}
+
+// SetServerSignature attaches a publisher signature to a server version, replacing any existing one
+func (c *Client) SetServerSignature(name, version string, sig *models.ServerSignature) (*models.ServerSignature, error) {
+	body := map[string]any{
+		"algorithm": sig.Algorithm,
+		"signature": sig.Signature,
+		"publicKey": sig.PublicKey,
+	}
+	var resp models.ServerSignature
+	path := "/servers/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version) + "/signature"
+	if err := c.doJsonRequest(http.MethodPut, path, body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to upload server signature: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetServerSignature returns the publisher signature of a server version. Returns nil if the version is unsigned.
+func (c *Client) GetServerSignature(name, version string) (*models.ServerSignature, error) {
+	req, err := c.newRequest(http.MethodGet, "/servers/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version)+"/signature")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.ServerSignature
+	if err := c.doJSON(req, &resp); err != nil {
+		if asHTTPStatus(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get server signature: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetServerChangelog returns the versions of a server, newest first, with their release notes and the server.json
+// fields that changed since the version before each
+func (c *Client) GetServerChangelog(name string) (*models.ServerChangelog, error) {
+	req, err := c.newRequest(http.MethodGet, "/servers/"+url.PathEscape(name)+"/changelog")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.ServerChangelog
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get server changelog: %w", err)
+	}
+	return &resp, nil
+}
+
+// ListServerArtifacts returns the artifact files attached to a server version ("latest" or empty for the latest version)
+func (c *Client) ListServerArtifacts(name, version string) ([]models.Attachment, error) {
+	req, err := c.newRequest(http.MethodGet, serverArtifactsPath(name, version))
+	if err != nil {
+		return nil, err
+	}
+	var resp models.AttachmentListResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list server artifacts: %w", err)
+	}
+	return resp.Artifacts, nil
+}
+
+// DownloadServerArtifact opens an artifact file attached to a server version; the caller must close the returned body
+func (c *Client) DownloadServerArtifact(name, version, fileName string) (io.ReadCloser, error) {
+	req, err := c.newRequest(http.MethodGet, serverArtifactsPath(name, version)+"/"+url.PathEscape(fileName))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status: %s, %s", resp.Status, string(errBody))
+	}
+	return resp.Body, nil
+}
+
+// UploadServerArtifact attaches a file to a server version, replacing a file of the same name. An empty kind is
+// stored as "other" and an empty media type is derived from the file extension.
+func (c *Client) UploadServerArtifact(name, version, fileName, kind, mediaType string, content io.Reader) (*models.Attachment, error) {
+	path := serverArtifactsPath(name, version) + "/" + url.PathEscape(fileName)
+	if kind != "" {
+		path += "?kind=" + url.QueryEscape(kind)
+	}
+	req, err := c.newRequest(http.MethodPut, path)
+	if err != nil {
+		return nil, err
+	}
+	if mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+	req.Header.Set("Content-Type", mediaType)
+	req.Body = io.NopCloser(content)
+
+	var resp models.Attachment
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to upload server artifact: %w", err)
+	}
+	return &resp, nil
+}
+
+func serverArtifactsPath(name, version string) string {
+	return "/servers/" + url.PathEscape(name) + "/versions/" + url.PathEscape(versionOrLatest(version)) + "/artifacts"
+}
+
+// GetServerTools returns the introspected tools, resources and prompts of a server version ("latest" or empty for the
+// latest version). Returns nil if the version has not been introspected.
+func (c *Client) GetServerTools(name, version string) (*models.ServerCapabilities, error) {
+	req, err := c.newRequest(http.MethodGet, "/servers/"+url.PathEscape(name)+"/tools?version="+url.QueryEscape(versionOrLatest(version)))
+	if err != nil {
+		return nil, err
+	}
+	var resp models.ServerCapabilities
+	if err := c.doJSON(req, &resp); err != nil {
+		if asHTTPStatus(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get server tools: %w", err)
+	}
+	return &resp, nil
+}
+
+// IntrospectServer asks the registry to start or connect to a server version and refresh its stored capabilities
+func (c *Client) IntrospectServer(name, version string) (*models.ServerCapabilities, error) {
+	var resp models.ServerCapabilities
+	path := "/servers/" + url.PathEscape(name) + "/introspect?version=" + url.QueryEscape(versionOrLatest(version))
+	if err := c.doJsonRequest(http.MethodPost, path, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to introspect server: %w", err)
+	}
+	return &resp, nil
+}
+
+// DisableTelemetryEnvVar turns off the anonymous usage pings of the CLI when set to a true value; DO_NOT_TRACK=1 is
+// honored as well
+const DisableTelemetryEnvVar = "ARCTL_DISABLE_TELEMETRY"
+
+// usagePingTimeout keeps a slow or unreachable registry from delaying the command that reports usage
+const usagePingTimeout = 2 * time.Second
+
+// telemetryDisabledByConfig is set from the disable-telemetry setting of the arctl config file
+var telemetryDisabledByConfig bool
+
+// SetTelemetryDisabled opts out of anonymous usage pings regardless of the environment
+func SetTelemetryDisabled(disabled bool) {
+	telemetryDisabledByConfig = disabled
+}
+
+// TelemetryDisabled reports whether the user opted out of anonymous usage pings
+func TelemetryDisabled() bool {
+	if telemetryDisabledByConfig {
+		return true
+	}
+	for _, name := range []string{DisableTelemetryEnvVar, "DO_NOT_TRACK"} {
+		if enabled, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(name))); err == nil && enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportUsage sends an anonymous usage ping (download or install) for a server ("mcp"), agent or skill. The ping
+// carries no credentials and is skipped when telemetry is disabled; failures are ignored by callers.
+func (c *Client) ReportUsage(artifactType, name, event string) error {
+	if TelemetryDisabled() {
+		return nil
+	}
+	body, err := json.Marshal(models.UsagePing{ArtifactType: artifactType, Name: name, Event: event})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), usagePingTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(c.BaseURL, "/")+"/usage", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.doJSON(req, nil)
+}
+
+// GetArtifactStats returns the usage counts of a server ("mcp"), agent or skill
+func (c *Client) GetArtifactStats(artifactType, name string) (*models.ArtifactStats, error) {
+	collection := map[string]string{"mcp": "servers", "agent": "agents", "skill": "skills"}[artifactType]
+	if collection == "" {
+		return nil, fmt.Errorf("unknown artifact type %q", artifactType)
+	}
+	req, err := c.newRequest(http.MethodGet, "/"+collection+"/"+url.PathEscape(name)+"/stats")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.ArtifactStats
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetQuota returns the quotas that apply to the caller and their usage, counting the servers of namespace when set
+func (c *Client) GetQuota(namespace string) (*models.Quota, error) {
+	path := "/quota"
+	if namespace != "" {
+		path += "?namespace=" + url.QueryEscape(namespace)
+	}
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	var resp models.Quota
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get quota: %w", err)
+	}
+	return &resp, nil
+}
+
+// ListReviews returns the newest reviews of a server ("mcp") or agent with its average rating
+func (c *Client) ListReviews(artifactType, name string) (*models.ReviewListResponse, error) {
+	collection, err := reviewCollection(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(http.MethodGet, "/"+collection+"/"+url.PathEscape(name)+"/reviews")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.ReviewListResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	return &resp, nil
+}
+
+// SubmitReview rates and reviews a server ("mcp") or agent as the signed-in user, replacing their earlier review
+func (c *Client) SubmitReview(artifactType, name string, rating int, body string) (*models.Review, error) {
+	collection, err := reviewCollection(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	in := internalv0.ReviewBody{Rating: rating, Body: body}
+	var resp models.Review
+	if err := c.doJsonRequest(http.MethodPost, "/"+collection+"/"+url.PathEscape(name)+"/reviews", in, &resp); err != nil {
+		return nil, fmt.Errorf("failed to submit review: %w", err)
+	}
+	return &resp, nil
+}
+
+func reviewCollection(artifactType string) (string, error) {
+	switch artifactType {
+	case "mcp":
+		return "servers", nil
+	case "agent":
+		return "agents", nil
+	}
+	return "", fmt.Errorf("only servers and agents can be reviewed")
+}
+
+// ListNamespaces returns verified namespaces, optionally only the verifications of one namespace
+func (c *Client) ListNamespaces(namespace string) ([]models.VerifiedNamespace, error) {
+	path := "/namespaces"
+	if namespace != "" {
+		path += "?namespace=" + url.QueryEscape(namespace)
+	}
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	var resp models.NamespaceListResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	return resp.Namespaces, nil
+}
+
+// GetNamespaceChallenge returns the DNS TXT record that verifies a namespace for the signed-in user
+func (c *Client) GetNamespaceChallenge(namespace string) (*models.NamespaceChallenge, error) {
+	req, err := c.newRequest(http.MethodGet, "/namespaces/challenge?namespace="+url.QueryEscape(namespace))
+	if err != nil {
+		return nil, err
+	}
+	var resp models.NamespaceChallenge
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get namespace challenge: %w", err)
+	}
+	return &resp, nil
+}
+
+// VerifyNamespace proves that the signed-in user owns a namespace. githubToken is optional and lets the registry
+// check private GitHub organization membership.
+func (c *Client) VerifyNamespace(namespace, githubToken string) (*models.VerifiedNamespace, error) {
+	in := internalv0.VerifyNamespaceBody{Namespace: namespace, GitHubToken: githubToken}
+	var resp models.VerifiedNamespace
+	if err := c.doJsonRequest(http.MethodPost, "/namespaces/verify", in, &resp); err != nil {
+		return nil, fmt.Errorf("failed to verify namespace: %w", err)
+	}
+	return &resp, nil
+}
+
+func versionOrLatest(version string) string {
+	if version == "" {
+		return "latest"
+	}
+	return version
+}
+
+// ListJobs returns the status of the registry's background jobs (admin only)
+func (c *Client) ListJobs() ([]models.JobStatus, error) {
+	req, err := c.newAdminRequest(http.MethodGet, "/admin/v0/jobs")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.JobListResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	return resp.Jobs, nil
+}
+
+// RunJob starts a background job immediately (admin only)
+func (c *Client) RunJob(name string) (*models.JobStatus, error) {
+	req, err := c.newAdminRequest(http.MethodPost, "/admin/v0/jobs/"+url.PathEscape(name)+"/run")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.JobStatus
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to run job: %w", err)
+	}
+	return &resp, nil
+}
+
+// PruneRuntime removes the runtime artifacts no deployment uses anymore, or only reports them in a dry run (admin only)
+func (c *Client) PruneRuntime(dryRun bool) (*models.PruneReport, error) {
+	req, err := c.newAdminRequest(http.MethodPost, "/admin/v0/system/prune?dryRun="+strconv.FormatBool(dryRun))
+	if err != nil {
+		return nil, err
+	}
+	var resp models.PruneReport
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to prune runtime: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetSystemUsage reports the disk usage of the registry database and the runtime (admin only)
+func (c *Client) GetSystemUsage() (*models.SystemUsage, error) {
+	req, err := c.newAdminRequest(http.MethodGet, "/admin/v0/system/df")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.SystemUsage
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetLoginConfig returns the interactive login methods supported by the registry
+func (c *Client) GetLoginConfig() (*v0auth.LoginConfig, error) {
+	var resp v0auth.LoginConfig
+	if err := c.doJsonRequest(http.MethodGet, "/auth/login-config", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExchangeGitHubToken exchanges a GitHub OAuth access token for a Registry JWT
+func (c *Client) ExchangeGitHubToken(githubToken string) (*auth.TokenResponse, error) {
+	payload := map[string]string{"github_token": githubToken}
+	var resp auth.TokenResponse
+	if err := c.doJsonRequest(http.MethodPost, "/auth/github-at", payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExchangeOIDCToken exchanges an OIDC ID token for a Registry JWT
+func (c *Client) ExchangeOIDCToken(idToken string) (*auth.TokenResponse, error) {
+	payload := map[string]string{"oidc_token": idToken}
+	var resp auth.TokenResponse
+	if err := c.doJsonRequest(http.MethodPost, "/auth/oidc", payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientContextAndTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := NewClient(srv.URL+"/v0", "")
+	ctx, cancel := context.WithCancel(context.Background())
+	c.SetContext(ctx)
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	err := c.Ping()
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), DefaultTimeout)
+
+	// Requests after the cancellation fail without reaching the registry
+	require.ErrorIs(t, c.Ping(), context.Canceled)
+
+	c.SetContext(context.Background())
+	c.SetTimeout(20 * time.Millisecond)
+	start = time.Now()
+	require.Error(t, c.Ping())
+	assert.Less(t, time.Since(start), DefaultTimeout)
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// GetCollections returns the latest version of each published collection
+func (c *Client) GetCollections() ([]models.CollectionResponse, error) {
+	req, err := c.newRequest(http.MethodGet, "/collections")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.CollectionListResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+	return resp.Collections, nil
+}
+
+// GetCollection returns a published collection version, or its latest version when version is empty. It returns nil
+// when the collection does not exist.
+func (c *Client) GetCollection(name, version string) (*models.CollectionResponse, error) {
+	if version == "" {
+		version = "latest"
+	}
+	req, err := c.newRequest(http.MethodGet, "/collections/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version))
+	if err != nil {
+		return nil, err
+	}
+	var resp models.CollectionResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		if respErr := asHTTPStatus(err); respErr == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return &resp, nil
+}
+
+// PushCollection creates a collection version without publishing it (published=false)
+func (c *Client) PushCollection(collection *models.CollectionJSON) (*models.CollectionResponse, error) {
+	var resp models.CollectionResponse
+	err := c.doJsonRequest(http.MethodPost, "/collections", collection, &resp)
+	return &resp, err
+}
+
+// PublishCollectionStatus marks an existing collection version as published
+func (c *Client) PublishCollectionStatus(name, version string) error {
+	req, err := c.newAdminRequest(http.MethodPost, "/admin/v0/collections/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version)+"/publish")
+	if err != nil {
+		return err
+	}
+	return c.doJSON(req, nil)
+}
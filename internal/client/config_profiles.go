@@ -0,0 +1,70 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func configProfilesPath(serverName string) string {
+	return "/servers/" + url.PathEscape(serverName) + "/profiles"
+}
+
+// GetConfigProfiles returns the config profiles of a server
+func (c *Client) GetConfigProfiles(serverName string) ([]models.ConfigProfile, error) {
+	req, err := c.newRequest(http.MethodGet, configProfilesPath(serverName))
+	if err != nil {
+		return nil, err
+	}
+	var resp models.ConfigProfileListResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get config profiles: %w", err)
+	}
+	return resp.Profiles, nil
+}
+
+// GetConfigProfile returns a config profile of a server with its config resolved over the profiles it extends
+func (c *Client) GetConfigProfile(serverName, name string) (*models.ConfigProfile, error) {
+	req, err := c.newRequest(http.MethodGet, configProfilesPath(serverName)+"/"+url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+	var resp models.ConfigProfile
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get config profile %s: %w", name, err)
+	}
+	return &resp, nil
+}
+
+// PutConfigProfile creates or replaces a config profile of a server
+func (c *Client) PutConfigProfile(profile *models.ConfigProfile) (*models.ConfigProfile, error) {
+	payload := struct {
+		Description string            `json:"description,omitempty"`
+		Extends     string            `json:"extends,omitempty"`
+		Config      map[string]string `json:"config"`
+	}{profile.Description, profile.Extends, profile.Config}
+	if payload.Config == nil {
+		payload.Config = map[string]string{}
+	}
+
+	var resp models.ConfigProfile
+	path := configProfilesPath(profile.ServerName) + "/" + url.PathEscape(profile.Name)
+	if err := c.doJsonRequest(http.MethodPut, path, payload, &resp); err != nil {
+		return nil, fmt.Errorf("failed to save config profile: %w", err)
+	}
+	return &resp, nil
+}
+
+// DeleteConfigProfile deletes a config profile of a server
+func (c *Client) DeleteConfigProfile(serverName, name string) error {
+	req, err := c.newRequest(http.MethodDelete, configProfilesPath(serverName)+"/"+url.PathEscape(name))
+	if err != nil {
+		return err
+	}
+	if err := c.doJSON(req, nil); err != nil {
+		return fmt.Errorf("failed to delete config profile: %w", err)
+	}
+	return nil
+}
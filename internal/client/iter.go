@@ -0,0 +1,262 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Retry behaviour for paginated listing. Variables rather than constants so tests can shorten them.
+var (
+	pageRetryAttempts  = 4
+	pageRetryBaseDelay = 500 * time.Millisecond
+	pageRetryMaxDelay  = 30 * time.Second
+)
+
+// ServerListFilter narrows the servers returned by ListServersIter
+type ServerListFilter struct {
+	// Search matches servers whose name contains the given substring
+	Search string
+	// Version is "latest" or an exact version; empty returns all versions
+	Version string
+	// License only returns servers with this SPDX license identifier
+	License string
+	// Tags only returns servers carrying all of these tags
+	Tags []string
+	// UpdatedSince only returns servers updated after this time
+	UpdatedSince time.Time
+	// IncludeUnpublished lists from the admin endpoint, which also returns unpublished servers
+	IncludeUnpublished bool
+	// Summary only returns the name, title, description and version of each server
+	Summary bool
+	// PageSize is the number of servers requested per page (default and maximum 100)
+	PageSize int
+}
+
+// ListServersIter returns an iterator over the servers matching filter. Pages are fetched lazily as the
+// caller advances, so only one page is held in memory, and breaking out of the loop stops further requests.
+// Transient failures (network errors, 429 and 5xx gateway responses) are retried with backoff, honoring
+// Retry-After, and the iterator pauses when the server reports the rate limit has been exhausted.
+// On a non-retryable error the iterator yields the error once and stops.
+func (c *Client) ListServersIter(ctx context.Context, filter ServerListFilter) iter.Seq2[*v0.ServerResponse, error] {
+	return func(yield func(*v0.ServerResponse, error) bool) {
+		params := url.Values{}
+		pageSize := filter.PageSize
+		if pageSize <= 0 || pageSize > 100 {
+			pageSize = 100
+		}
+		params.Set("limit", strconv.Itoa(pageSize))
+		if filter.Search != "" {
+			params.Set("search", filter.Search)
+		}
+		if filter.Version != "" {
+			params.Set("version", filter.Version)
+		}
+		if filter.License != "" {
+			params.Set("license", filter.License)
+		}
+		if len(filter.Tags) > 0 {
+			params.Set("tags", strings.Join(filter.Tags, ","))
+		}
+		if !filter.UpdatedSince.IsZero() {
+			params.Set("updated_since", filter.UpdatedSince.UTC().Format(time.RFC3339))
+		}
+		if filter.Summary {
+			params.Set("view", "summary")
+		}
+
+		for {
+			newReq := func() (*http.Request, error) {
+				if filter.IncludeUnpublished {
+					return c.newAdminRequest(http.MethodGet, "/admin/v0/servers?"+params.Encode())
+				}
+				return c.newRequest(http.MethodGet, "/servers?"+params.Encode())
+			}
+
+			var resp v0.ServerListResponse
+			wait, err := c.fetchPage(ctx, newReq, &resp)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to list servers: %w", err))
+				return
+			}
+
+			for i := range resp.Servers {
+				if !yield(&resp.Servers[i], nil) {
+					return
+				}
+			}
+
+			if resp.Metadata.NextCursor == "" {
+				return
+			}
+			params.Set("cursor", resp.Metadata.NextCursor)
+
+			// Back off before the next page when the server says the rate limit is used up
+			if err := sleepCtx(ctx, wait); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+// collectServers drains a server iterator into a slice
+func collectServers(seq iter.Seq2[*v0.ServerResponse, error]) ([]*v0.ServerResponse, error) {
+	var all []*v0.ServerResponse
+	for s, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, s)
+	}
+	return all, nil
+}
+
+// fetchPage performs a GET built by newReq and decodes the JSON body into out, retrying transient failures.
+// It returns how long to wait before the next request according to the response's rate-limit headers.
+func (c *Client) fetchPage(ctx context.Context, newReq func() (*http.Request, error), out any) (time.Duration, error) {
+	var lastErr error
+	for attempt := range pageRetryAttempts {
+		if attempt > 0 {
+			if err := sleepCtx(ctx, retryDelay(attempt, lastErr)); err != nil {
+				return 0, err
+			}
+		}
+
+		req, err := newReq()
+		if err != nil {
+			return 0, err
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			_ = resp.Body.Close()
+			statusErr := &retryableStatusError{
+				err:        fmt.Errorf("unexpected status: %s, %s", resp.Status, string(errBody)),
+				retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			}
+			if !isRetryableStatus(resp.StatusCode) {
+				return 0, statusErr.err
+			}
+			lastErr = statusErr
+			continue
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(out)
+		_ = resp.Body.Close()
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode response: %w", err)
+		}
+		return rateLimitWait(resp.Header, time.Now()), nil
+	}
+
+	var statusErr *retryableStatusError
+	if errors.As(lastErr, &statusErr) {
+		lastErr = statusErr.err
+	}
+	return 0, fmt.Errorf("giving up after %d attempts: %w", pageRetryAttempts, lastErr)
+}
+
+// retryableStatusError carries the server's Retry-After hint alongside a retryable HTTP error
+type retryableStatusError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableStatusError) Error() string { return e.err.Error() }
+
+func (e *retryableStatusError) Unwrap() error { return e.err }
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryDelay returns the wait before retry attempt n: the server's Retry-After if given, otherwise exponential backoff
+func retryDelay(attempt int, lastErr error) time.Duration {
+	var statusErr *retryableStatusError
+	if errors.As(lastErr, &statusErr) && statusErr.retryAfter > 0 {
+		return min(statusErr.retryAfter, pageRetryMaxDelay)
+	}
+	return min(pageRetryBaseDelay<<(attempt-1), pageRetryMaxDelay)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// rateLimitWait returns how long to pause when the rate-limit headers report no remaining requests.
+// Both the IETF draft (RateLimit-Remaining/RateLimit-Reset, reset in seconds) and the common
+// X-RateLimit-* variants (reset as a unix timestamp) are understood.
+func rateLimitWait(h http.Header, now time.Time) time.Duration {
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		remaining := h.Get(prefix + "Remaining")
+		if remaining == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(remaining); err != nil || n > 0 {
+			return 0
+		}
+		reset, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64)
+		if err != nil || reset <= 0 {
+			return pageRetryBaseDelay
+		}
+		// Values larger than a day can only be absolute unix timestamps
+		var wait time.Duration
+		if reset > 86400 {
+			wait = time.Unix(reset, 0).Sub(now)
+		} else {
+			wait = time.Duration(reset) * time.Second
+		}
+		return min(max(wait, 0), pageRetryMaxDelay)
+	}
+	return 0
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	pageRetryBaseDelay = time.Millisecond
+}
+
+// pagedServers serves three pages of two servers each, failing the first request with 503
+func pagedServers(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		if n == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		page := 0
+		if c := r.URL.Query().Get("cursor"); c != "" {
+			page, _ = strconv.Atoi(c)
+		}
+		resp := v0.ServerListResponse{}
+		for i := range 2 {
+			resp.Servers = append(resp.Servers, v0.ServerResponse{
+				Server: model.ServerJSON{Name: "io.example/s" + strconv.Itoa(page*2+i), Version: "1.0.0"},
+			})
+		}
+		if page < 2 {
+			resp.Metadata.NextCursor = strconv.Itoa(page + 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+func TestListServersIter(t *testing.T) {
+	var requests atomic.Int32
+	srv := pagedServers(t, &requests)
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/v0", "")
+	var names []string
+	for s, err := range c.ListServersIter(context.Background(), ServerListFilter{PageSize: 2}) {
+		require.NoError(t, err)
+		names = append(names, s.Server.Name)
+	}
+
+	assert.Len(t, names, 6)
+	assert.Equal(t, "io.example/s5", names[5])
+	// One failed attempt plus one request per page
+	assert.Equal(t, int32(4), requests.Load())
+}
+
+func TestListServersIterEarlyTermination(t *testing.T) {
+	var requests atomic.Int32
+	srv := pagedServers(t, &requests)
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/v0", "")
+	for s, err := range c.ListServersIter(context.Background(), ServerListFilter{PageSize: 2}) {
+		require.NoError(t, err)
+		if s.Server.Name == "io.example/s1" {
+			break
+		}
+	}
+
+	// Only the first page was fetched
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestListServersIterNonRetryableError(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/v0", "")
+	var errs int
+	for _, err := range c.ListServersIter(context.Background(), ServerListFilter{}) {
+		require.Error(t, err)
+		errs++
+	}
+	assert.Equal(t, 1, errs)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	h := http.Header{}
+	assert.Zero(t, rateLimitWait(h, now))
+
+	h.Set("RateLimit-Remaining", "5")
+	assert.Zero(t, rateLimitWait(h, now))
+
+	h.Set("RateLimit-Remaining", "0")
+	h.Set("RateLimit-Reset", "3")
+	assert.Equal(t, 3*time.Second, rateLimitWait(h, now))
+
+	h = http.Header{}
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(10*time.Second).Unix(), 10))
+	assert.Equal(t, 10*time.Second, rateLimitWait(h, now))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2", now))
+	assert.Equal(t, 5*time.Second, parseRetryAfter(now.Add(5*time.Second).UTC().Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// SetVisibility changes the visibility of a server (artifact type "mcp"), agent or skill version
+func (c *Client) SetVisibility(artifactType, name, version, visibility string) error {
+	var collection string
+	switch artifactType {
+	case "mcp":
+		collection = "servers"
+	case "agent":
+		collection = "agents"
+	case "skill":
+		collection = "skills"
+	default:
+		return fmt.Errorf("unsupported artifact type %q", artifactType)
+	}
+
+	path := "/" + collection + "/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version) + "/visibility"
+	body := map[string]string{"visibility": visibility}
+	if err := c.doJsonRequest(http.MethodPut, path, body, nil); err != nil {
+		return fmt.Errorf("failed to set visibility: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// GetWorkspaces returns the workspaces the caller is a member of
+func (c *Client) GetWorkspaces() ([]models.Workspace, error) {
+	req, err := c.newRequest(http.MethodGet, "/workspaces")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.WorkspaceListResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get workspaces: %w", err)
+	}
+	return resp.Workspaces, nil
+}
+
+// CreateWorkspace creates a workspace owned by the caller
+func (c *Client) CreateWorkspace(workspace *models.Workspace) (*models.Workspace, error) {
+	var resp models.Workspace
+	if err := c.doJsonRequest(http.MethodPost, "/workspaces", workspace, &resp); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	return &resp, nil
+}
+
+// DeleteWorkspace deletes a workspace
+func (c *Client) DeleteWorkspace(name string) error {
+	req, err := c.newRequest(http.MethodDelete, "/workspaces/"+url.PathEscape(name))
+	if err != nil {
+		return err
+	}
+	if err := c.doJSON(req, nil); err != nil {
+		return fmt.Errorf("failed to delete workspace: %w", err)
+	}
+	return nil
+}
+
+// GetWorkspaceMembers returns the members of a workspace
+func (c *Client) GetWorkspaceMembers(name string) ([]models.WorkspaceMember, error) {
+	req, err := c.newRequest(http.MethodGet, "/workspaces/"+url.PathEscape(name)+"/members")
+	if err != nil {
+		return nil, err
+	}
+	var resp models.WorkspaceMemberListResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get workspace members: %w", err)
+	}
+	return resp.Members, nil
+}
+
+// SetWorkspaceMember adds a member to a workspace or changes their role
+func (c *Client) SetWorkspaceMember(name, subject, role string) (*models.WorkspaceMember, error) {
+	var resp models.WorkspaceMember
+	body := map[string]string{"role": role}
+	path := "/workspaces/" + url.PathEscape(name) + "/members/" + url.PathEscape(subject)
+	if err := c.doJsonRequest(http.MethodPut, path, body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to set workspace member: %w", err)
+	}
+	return &resp, nil
+}
+
+// RemoveWorkspaceMember removes a member from a workspace
+func (c *Client) RemoveWorkspaceMember(name, subject string) error {
+	req, err := c.newRequest(http.MethodDelete, "/workspaces/"+url.PathEscape(name)+"/members/"+url.PathEscape(subject))
+	if err != nil {
+		return err
+	}
+	if err := c.doJSON(req, nil); err != nil {
+		return fmt.Errorf("failed to remove workspace member: %w", err)
+	}
+	return nil
+}
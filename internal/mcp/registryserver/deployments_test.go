@@ -4,12 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -39,7 +45,7 @@ func (f *fakeRegistry) GetDeploymentByNameAndVersion(ctx context.Context, name,
 	return nil, errors.New("not implemented")
 }
 
-func (f *fakeRegistry) DeployServer(ctx context.Context, name, version string, config map[string]string, preferRemote bool, runtime string) (*models.Deployment, error) {
+func (f *fakeRegistry) DeployServer(ctx context.Context, name, version string, config map[string]string, preferRemote bool, runtime string, _ string) (*models.Deployment, error) {
 	if f.deployServerFn != nil {
 		return f.deployServerFn(ctx, name, version, config, preferRemote, runtime)
 	}
@@ -86,6 +92,9 @@ func (f *fakeRegistry) GetAllVersionsByServerName(context.Context, string, bool)
 func (f *fakeRegistry) CreateServer(context.Context, *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
 	return nil, errors.New("not implemented")
 }
+func (f *fakeRegistry) CreateServersBatch(context.Context, []*apiv0.ServerJSON, bool) []error {
+	return nil
+}
 func (f *fakeRegistry) UpdateServer(context.Context, string, string, *apiv0.ServerJSON, *string) (*apiv0.ServerResponse, error) {
 	return nil, errors.New("not implemented")
 }
@@ -122,6 +131,9 @@ func (f *fakeRegistry) GetAllVersionsByAgentName(context.Context, string) ([]*mo
 func (f *fakeRegistry) CreateAgent(context.Context, *models.AgentJSON) (*models.AgentResponse, error) {
 	return nil, errors.New("not implemented")
 }
+func (f *fakeRegistry) CreateAgentsBatch(context.Context, []*models.AgentJSON, bool) []error {
+	return nil
+}
 func (f *fakeRegistry) PublishAgent(context.Context, string, string) error {
 	return errors.New("not implemented")
 }
@@ -146,6 +158,247 @@ func (f *fakeRegistry) GetAllVersionsBySkillName(context.Context, string) ([]*mo
 func (f *fakeRegistry) CreateSkill(context.Context, *models.SkillJSON) (*models.SkillResponse, error) {
 	return nil, errors.New("not implemented")
 }
+func (f *fakeRegistry) CreateSkillsBatch(context.Context, []*models.SkillJSON, bool) []error {
+	return nil
+}
+func (f *fakeRegistry) GetDeploymentHealth(context.Context) ([]models.DeploymentHealth, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) CheckReadiness(context.Context, bool) *models.Readiness {
+	return &models.Readiness{Status: models.ReadinessStatusOK}
+}
+func (f *fakeRegistry) Shutdown(context.Context) error {
+	return nil
+}
+func (f *fakeRegistry) PutBlob(context.Context, io.Reader) (*models.Blob, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) OpenBlob(context.Context, string) (io.ReadCloser, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) UploadAttachment(context.Context, *models.Attachment, io.Reader) (*models.Attachment, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) ListAttachments(context.Context, string, string, string) ([]*models.Attachment, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) OpenAttachment(context.Context, string, string, string, string) (*models.Attachment, io.ReadCloser, error) {
+	return nil, nil, nil
+}
+func (f *fakeRegistry) DeleteAttachment(context.Context, string, string, string, string) error {
+	return nil
+}
+func (f *fakeRegistry) IntrospectServer(context.Context, string, string) (*models.ServerCapabilities, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) GetServerChangelog(context.Context, string, bool) (*models.ServerChangelog, error) {
+	return nil, database.ErrNotFound
+}
+func (f *fakeRegistry) GetServerCapabilities(context.Context, string, string) (*models.ServerCapabilities, error) {
+	return nil, database.ErrNotFound
+}
+func (f *fakeRegistry) SearchTools(context.Context, string, int) ([]models.ToolSearchResult, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) SetSkillStatus(context.Context, string, string, string) (*models.SkillResponse, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) GetSkillVersionStatuses(context.Context, string) ([]*models.SkillVersionStatus, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) StoreSkillReadme(context.Context, string, string, []byte, string) error {
+	return nil
+}
+func (f *fakeRegistry) GetSkillReadmeLatest(context.Context, string) (*database.SkillReadme, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) GetSkillReadmeByVersion(context.Context, string, string) (*database.SkillReadme, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) CreateCollection(context.Context, *models.CollectionJSON) (*models.CollectionResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) GetCollection(context.Context, string, string) (*models.CollectionResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) ListCollections(context.Context, bool) ([]*models.CollectionResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) PublishCollection(context.Context, string, string) error {
+	return errors.New("not implemented")
+}
+func (f *fakeRegistry) UnpublishCollection(context.Context, string, string) error {
+	return errors.New("not implemented")
+}
+func (f *fakeRegistry) DeleteCollection(context.Context, string, string) error {
+	return errors.New("not implemented")
+}
+func (f *fakeRegistry) CreateWorkspace(context.Context, *models.Workspace) (*models.Workspace, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) ListWorkspaces(context.Context) ([]models.Workspace, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) GetWorkspace(context.Context, string) (*models.Workspace, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) CheckWorkspaceAccess(context.Context, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeRegistry) DeleteWorkspace(context.Context, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeRegistry) ListWorkspaceMembers(context.Context, string) ([]models.WorkspaceMember, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) SetWorkspaceMember(context.Context, string, string, string) (*models.WorkspaceMember, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) RemoveWorkspaceMember(context.Context, string, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeRegistry) SetVisibility(context.Context, string, string, string, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeRegistry) RenameServer(context.Context, string, string) (*apiv0.ServerResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) ResolveServerAlias(context.Context, string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (f *fakeRegistry) ListTrash(context.Context) ([]models.TrashEntry, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) RestoreServer(context.Context, string, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeRegistry) PurgeServer(context.Context, string, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeRegistry) AddEventHandler(service.EventHandler) {}
+
+func (f *fakeRegistry) GetServersByNames(context.Context, []string, bool) ([]*apiv0.ServerResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) ListConfigProfiles(context.Context, string) ([]models.ConfigProfile, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) GetConfigProfile(context.Context, string, string) (*models.ConfigProfile, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) PutConfigProfile(context.Context, *models.ConfigProfile) (*models.ConfigProfile, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) DeleteConfigProfile(context.Context, string, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeRegistry) GetQuota(context.Context, string) (*models.Quota, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) PruneRuntime(context.Context, bool) (*models.PruneReport, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) GetSystemUsage(context.Context) (*models.SystemUsage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) GetDeploymentMetrics(context.Context, string, string) (*models.DeploymentMetrics, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistry) ListDeploymentTargets(context.Context) ([]runtime.Target, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) DeployServerCanary(context.Context, string, string, map[string]string, int) (*models.Deployment, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) PromoteDeployment(context.Context, string) (*models.Deployment, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) ListDeploymentRevisions(context.Context, string, string) ([]*models.DeploymentRevision, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) RollbackDeployment(context.Context, string, string, int) (*models.Deployment, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) DiffDeployment(context.Context, string, string, string) (*models.DeploymentDiff, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) ExportRuntime(context.Context, string) (*models.RuntimeExport, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) StoreAgentReadme(context.Context, string, string, []byte, string) error {
+	return nil
+}
+func (f *fakeRegistry) GetAgentReadmeLatest(context.Context, string) (*database.AgentReadme, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) GetAgentReadmeByVersion(context.Context, string, string) (*database.AgentReadme, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) ListServersDueForEnrichment(context.Context, time.Time, int) ([]*models.ServerEnrichment, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) RecordServerEnrichment(context.Context, string, string, map[string]any, error) error {
+	return nil
+}
+func (f *fakeRegistry) RecordArtifactUsage(context.Context, string, string, string) error {
+	return nil
+}
+func (f *fakeRegistry) GetArtifactStats(context.Context, string, string) (*models.ArtifactStats, error) {
+	return nil, database.ErrNotFound
+}
+func (f *fakeRegistry) ListTags(context.Context) ([]models.Tag, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) SubmitReview(context.Context, string, string, int, string) (*models.Review, error) {
+	return nil, database.ErrNotFound
+}
+func (f *fakeRegistry) ListReviews(context.Context, string, string, bool, string, int) (*models.ReviewListResponse, error) {
+	return nil, database.ErrNotFound
+}
+func (f *fakeRegistry) GetRatingSummaries(context.Context, string, []string) (map[string]models.RatingSummary, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) ModerateReview(context.Context, int64, bool) (*models.Review, error) {
+	return nil, database.ErrNotFound
+}
+func (f *fakeRegistry) DeleteReview(context.Context, int64) error {
+	return nil
+}
+func (f *fakeRegistry) VerifyNamespace(context.Context, string, string) (*models.VerifiedNamespace, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) GetNamespaceChallenge(context.Context, string) (*models.NamespaceChallenge, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) ListVerifiedNamespaces(context.Context, string, string) ([]models.VerifiedNamespace, error) {
+	return nil, nil
+}
+func (f *fakeRegistry) RevokeNamespace(context.Context, string, string) error {
+	return nil
+}
 func (f *fakeRegistry) PublishSkill(context.Context, string, string) error {
 	return errors.New("not implemented")
 }
@@ -164,6 +417,84 @@ func (f *fakeRegistry) UpsertAgentEmbedding(context.Context, string, string, *da
 func (f *fakeRegistry) GetAgentEmbeddingMetadata(context.Context, string, string) (*database.SemanticEmbeddingMetadata, error) {
 	return nil, errors.New("not implemented")
 }
+func (f *fakeRegistry) GetServerVersionStatuses(context.Context, string) ([]*models.ServerVersionStatus, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) SetServerVersionsPublished(context.Context, string, []string, bool) error {
+	return errors.New("not implemented")
+}
+func (f *fakeRegistry) UpdateDeploymentOrigin(context.Context, string, string, string) (*models.Deployment, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) StoreAgentSBOM(context.Context, string, string, []byte) (*database.AgentSBOM, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) GetAgentSBOM(context.Context, string, string) (*database.AgentSBOM, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) SetServerSignature(context.Context, *models.ServerSignature) (*models.ServerSignature, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) GetServerSignature(context.Context, string, string) (*models.ServerSignature, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) RegisterJob(jobs.Job) error {
+	return nil
+}
+func (f *fakeRegistry) ListJobs(context.Context) ([]models.JobStatus, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) RunJob(context.Context, string) (*models.JobStatus, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) CreateImport(context.Context, string, models.ImportOptions) (*models.ImportStatus, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) UpdateImport(context.Context, *models.ImportStatus) error {
+	return nil
+}
+func (f *fakeRegistry) GetImport(context.Context, string) (*models.ImportStatus, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) ListImports(context.Context) ([]models.ImportStatus, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) StartImport(context.Context, models.ImportRequest, service.ImportRunner) (*models.ImportStatus, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) CancelImport(context.Context, string) (*models.ImportStatus, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) ListDeploymentPolicies(context.Context) ([]models.DeploymentPolicy, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) PutDeploymentPolicy(context.Context, *models.DeploymentPolicy) (*models.DeploymentPolicy, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) DeleteDeploymentPolicy(context.Context, string) error {
+	return errors.New("not implemented")
+}
+func (f *fakeRegistry) CreateRoleBinding(context.Context, *models.RoleBinding) (*models.RoleBinding, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) ListRoleBindings(context.Context, *string) ([]*models.RoleBinding, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) DeleteRoleBinding(context.Context, int64) error {
+	return errors.New("not implemented")
+}
+func (f *fakeRegistry) CreateAPIToken(context.Context, string, []auth.Permission, *time.Time) (*models.CreatedAPIToken, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) ListAPITokens(context.Context, bool) ([]*models.APIToken, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *fakeRegistry) RevokeAPIToken(context.Context, int64) error {
+	return errors.New("not implemented")
+}
+func (f *fakeRegistry) ListAuditLog(context.Context, *models.AuditLogFilter, string, int) ([]*models.AuditLogEntry, string, error) {
+	return nil, "", errors.New("not implemented")
+}
 
 func TestDeploymentTools_ListAndGet(t *testing.T) {
 	ctx := context.Background()
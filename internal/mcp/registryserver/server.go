@@ -5,12 +5,14 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	restv0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -21,9 +23,28 @@ const (
 	maxPageLimit     = 100
 )
 
-// NewServer constructs an MCP server that exposes read-only discovery tools backed by the registry service.
-// All endpoints are restricted to published content to keep the surface area safe for unauthenticated agents.
-func NewServer(registry service.RegistryService) *mcp.Server {
+// Option configures the registry MCP server
+type Option func(*options)
+
+type options struct {
+	authn auth.AuthnProvider
+}
+
+// WithAuthn lets tools that change the registry authenticate the token passed in their arguments, for clients
+// that cannot set an Authorization header on the MCP connection
+func WithAuthn(authn auth.AuthnProvider) Option {
+	return func(o *options) { o.authn = authn }
+}
+
+// NewServer constructs an MCP server that exposes discovery, publishing and deployment tools backed by the registry
+// service. Discovery tools are restricted to published content to keep the surface area safe for unauthenticated
+// agents; publishing and deployment are subject to the caller's permissions.
+func NewServer(registry service.RegistryService, opts ...Option) *mcp.Server {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "agentregistry-mcp",
 		Version: version.Version,
@@ -33,7 +54,9 @@ func NewServer(registry service.RegistryService) *mcp.Server {
 
 	addAgentTools(server, registry)
 	addServerTools(server, registry)
+	addPublishTools(server, registry, o.authn)
 	addSkillTools(server, registry)
+	addReadmeTools(server, registry)
 	addDeploymentTools(server, registry)
 	addMetaTools(server)
 
@@ -204,6 +227,96 @@ func addServerTools(server *mcp.Server, registry service.RegistryService) {
 		}, nil
 	})
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_servers",
+		Description: "Search published MCP servers by name, or by meaning with semantic search. Returns the latest version of each match.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args searchServersArgs) (*mcp.CallToolResult, apiv0.ServerListResponse, error) {
+		query := strings.TrimSpace(args.Query)
+		if query == "" {
+			return nil, apiv0.ServerListResponse{}, fmt.Errorf("query is required")
+		}
+		published, isLatest := true, true
+		filter := &database.ServerFilter{Published: &published, IsLatest: &isLatest}
+		if args.Semantic {
+			filter.Semantic = &database.SemanticSearchOptions{RawQuery: query, Threshold: args.Threshold}
+		} else {
+			filter.SubstringName = &query
+		}
+
+		servers, nextCursor, err := registry.ListServers(ctx, filter, args.Cursor, clampLimit(args.Limit))
+		if err != nil {
+			return nil, apiv0.ServerListResponse{}, err
+		}
+		out := apiv0.ServerListResponse{
+			Servers:  make([]apiv0.ServerResponse, len(servers)),
+			Metadata: apiv0.Metadata{NextCursor: nextCursor, Count: len(servers)},
+		}
+		for i, s := range servers {
+			out.Servers[i] = *s
+		}
+		return nil, out, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_server_details",
+		Description: "Fetch a published MCP server version (defaults to latest) with its tools, usage statistics, rating and other published versions",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}) (*mcp.CallToolResult, ServerDetails, error) {
+		if args.Name == "" {
+			return nil, ServerDetails{}, fmt.Errorf("name is required")
+		}
+		version := args.Version
+		if version == "" {
+			version = "latest"
+		}
+		serverResp, err := fetchSingleServer(ctx, registry, args.Name, version, true)
+		if err != nil {
+			return nil, ServerDetails{}, err
+		}
+
+		// Everything besides the server itself is decoration; missing data is left out rather than failing the call
+		details := ServerDetails{Server: *serverResp}
+		if caps, err := registry.GetServerCapabilities(ctx, args.Name, serverResp.Server.Version); err == nil {
+			details.Capabilities = caps
+		}
+		if stats, err := registry.GetArtifactStats(ctx, "mcp", args.Name); err == nil {
+			details.Stats = stats
+		}
+		if ratings, err := registry.GetRatingSummaries(ctx, "mcp", []string{args.Name}); err == nil {
+			if rating, ok := ratings[args.Name]; ok {
+				details.Rating = &rating
+			}
+		}
+		if readme, err := registry.GetServerReadmeByVersion(ctx, args.Name, serverResp.Server.Version); err == nil && readme != nil {
+			details.HasReadme = true
+		}
+		if versions, err := registry.GetAllVersionsByServerName(ctx, args.Name, true); err == nil {
+			for _, v := range versions {
+				details.Versions = append(details.Versions, v.Server.Version)
+			}
+		}
+		return nil, details, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find_tool",
+		Description: "Find tools by name or description across published MCP servers. Returns the server providing each tool and its input schema.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args struct {
+		Query string `json:"query"`
+		Limit int    `json:"limit,omitempty"`
+	}) (*mcp.CallToolResult, models.ToolSearchResponse, error) {
+		if args.Query == "" {
+			return nil, models.ToolSearchResponse{}, fmt.Errorf("query is required")
+		}
+		tools, err := registry.SearchTools(ctx, args.Query, clampLimit(args.Limit))
+		if err != nil {
+			return nil, models.ToolSearchResponse{}, err
+		}
+		return nil, models.ToolSearchResponse{Tools: tools, Count: len(tools)}, nil
+	})
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_server_readme",
 		Description: "Fetch the README for a published server version (defaults to latest)",
@@ -242,6 +355,175 @@ func addServerTools(server *mcp.Server, registry service.RegistryService) {
 	})
 }
 
+type searchServersArgs struct {
+	Query     string  `json:"query" jsonschema:"Text to match against server names, or to compare by meaning when semantic is set"`
+	Semantic  bool    `json:"semantic,omitempty" jsonschema:"Rank servers by semantic similarity to the query instead of matching names"`
+	Threshold float64 `json:"threshold,omitempty" jsonschema:"Maximum cosine distance of semantic matches"`
+	Cursor    string  `json:"cursor,omitempty"`
+	Limit     int     `json:"limit,omitempty"`
+}
+
+// ServerDetails is a published server version together with what the registry knows about it
+type ServerDetails struct {
+	Server       apiv0.ServerResponse       `json:"server"`
+	Capabilities *models.ServerCapabilities `json:"capabilities,omitempty"`
+	Stats        *models.ArtifactStats      `json:"stats,omitempty"`
+	Rating       *models.RatingSummary      `json:"rating,omitempty"`
+	HasReadme    bool                       `json:"has_readme"`
+	Versions     []string                   `json:"versions,omitempty"`
+}
+
+type publishServerArgs struct {
+	Server apiv0.ServerJSON `json:"server" jsonschema:"The server.json of the server version to publish"`
+	Token  string           `json:"token,omitempty" jsonschema:"Registry or API token to publish with, when the MCP connection is not authenticated"`
+	Draft  bool             `json:"draft,omitempty" jsonschema:"Store the version without publishing it"`
+}
+
+func addPublishTools(server *mcp.Server, registry service.RegistryService, authn auth.AuthnProvider) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "publish_server",
+		Description: "Publish an MCP server version from its server.json. Requires publish permission for the server's namespace, taken from the connection or the token argument.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args publishServerArgs) (*mcp.CallToolResult, apiv0.ServerResponse, error) {
+		if args.Server.Name == "" || args.Server.Version == "" {
+			return nil, apiv0.ServerResponse{}, errors.New("server.name and server.version are required")
+		}
+		ctx, err := authenticateToken(ctx, authn, args.Token)
+		if err != nil {
+			return nil, apiv0.ServerResponse{}, err
+		}
+
+		created, err := registry.CreateServer(ctx, &args.Server)
+		if err != nil {
+			return nil, apiv0.ServerResponse{}, publishError(args.Server.Name, err)
+		}
+		if args.Draft {
+			return nil, *created, nil
+		}
+		if err := registry.PublishServer(ctx, args.Server.Name, args.Server.Version); err != nil {
+			return nil, apiv0.ServerResponse{}, publishError(args.Server.Name, err)
+		}
+		published, err := registry.GetServerByNameAndVersion(ctx, args.Server.Name, args.Server.Version, false)
+		if err != nil {
+			return nil, apiv0.ServerResponse{}, err
+		}
+		return nil, *published, nil
+	})
+}
+
+// authenticateToken returns ctx carrying the session of token, or ctx unchanged when no token is given
+func authenticateToken(ctx context.Context, authn auth.AuthnProvider, token string) (context.Context, error) {
+	if token == "" {
+		return ctx, nil
+	}
+	if authn == nil {
+		return nil, errors.New("this registry does not accept tokens")
+	}
+	header := "Bearer " + strings.TrimPrefix(token, "Bearer ")
+	session, err := authn.Authenticate(ctx, func(name string) string {
+		if name == "Authorization" {
+			return header
+		}
+		return ""
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if session == nil {
+		return nil, fmt.Errorf("invalid token: %w", auth.ErrUnauthenticated)
+	}
+	return auth.AuthSessionTo(ctx, session), nil
+}
+
+func publishError(name string, err error) error {
+	if errors.Is(err, auth.ErrUnauthenticated) || errors.Is(err, auth.ErrForbidden) {
+		return fmt.Errorf("not allowed to publish %s, pass a token with publish permission for its namespace: %w", name, err)
+	}
+	return err
+}
+
+// ReadmePayload is a README of a server, agent or skill version
+type ReadmePayload struct {
+	Type        string    `json:"type"`
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Content     string    `json:"content"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int       `json:"size_bytes"`
+	SHA256      string    `json:"sha256"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+func addReadmeTools(server *mcp.Server, registry service.RegistryService) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_readme",
+		Description: "Fetch the README of a server, agent or skill version (defaults to latest)",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args struct {
+		Type    string `json:"type" jsonschema:"Artifact type: server, agent or skill"`
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}) (*mcp.CallToolResult, ReadmePayload, error) {
+		if args.Name == "" {
+			return nil, ReadmePayload{}, fmt.Errorf("name is required")
+		}
+		latest := args.Version == "" || args.Version == "latest"
+
+		var out ReadmePayload
+		switch args.Type {
+		case "server", "mcp":
+			var readme *database.ServerReadme
+			var err error
+			if latest {
+				readme, err = registry.GetServerReadmeLatest(ctx, args.Name)
+			} else {
+				readme, err = registry.GetServerReadmeByVersion(ctx, args.Name, args.Version)
+			}
+			if err != nil {
+				return nil, ReadmePayload{}, err
+			}
+			if readme != nil {
+				out = ReadmePayload{Type: "server", Name: readme.ServerName, Version: readme.Version, Content: string(readme.Content),
+					ContentType: readme.ContentType, SizeBytes: readme.SizeBytes, SHA256: hex.EncodeToString(readme.SHA256), FetchedAt: readme.FetchedAt}
+			}
+		case "agent":
+			var readme *database.AgentReadme
+			var err error
+			if latest {
+				readme, err = registry.GetAgentReadmeLatest(ctx, args.Name)
+			} else {
+				readme, err = registry.GetAgentReadmeByVersion(ctx, args.Name, args.Version)
+			}
+			if err != nil {
+				return nil, ReadmePayload{}, err
+			}
+			if readme != nil {
+				out = ReadmePayload{Type: "agent", Name: readme.AgentName, Version: readme.Version, Content: string(readme.Content),
+					ContentType: readme.ContentType, SizeBytes: readme.SizeBytes, SHA256: hex.EncodeToString(readme.SHA256), FetchedAt: readme.FetchedAt}
+			}
+		case "skill":
+			var readme *database.SkillReadme
+			var err error
+			if latest {
+				readme, err = registry.GetSkillReadmeLatest(ctx, args.Name)
+			} else {
+				readme, err = registry.GetSkillReadmeByVersion(ctx, args.Name, args.Version)
+			}
+			if err != nil {
+				return nil, ReadmePayload{}, err
+			}
+			if readme != nil {
+				out = ReadmePayload{Type: "skill", Name: readme.SkillName, Version: readme.Version, Content: string(readme.Content),
+					ContentType: readme.ContentType, SizeBytes: readme.SizeBytes, SHA256: hex.EncodeToString(readme.SHA256), FetchedAt: readme.FetchedAt}
+			}
+		default:
+			return nil, ReadmePayload{}, fmt.Errorf("type must be server, agent or skill")
+		}
+		if out.Name == "" {
+			return nil, ReadmePayload{}, fmt.Errorf("%s %s has no README: %w", args.Type, args.Name, database.ErrNotFound)
+		}
+		return nil, out, nil
+	})
+}
+
 type listSkillsArgs = restv0.ListSkillsInput
 
 func addSkillTools(server *mcp.Server, registry service.RegistryService) {
@@ -411,12 +693,17 @@ func addDeploymentTools(server *mcp.Server, registry service.RegistryService) {
 			return nil, models.Deployment{}, errors.New("name and version are required")
 		}
 
-		runtimeTarget := args.Runtime
-		if runtimeTarget == "" {
-			runtimeTarget = "local"
+		runtimeTarget, err := restv0.DeploymentRuntimeTarget(ctx, registry, &args.DeploymentRequest)
+		if err != nil {
+			return nil, models.Deployment{}, err
 		}
 
-		deployment, err := registry.DeployServer(ctx, args.ServerName, args.Version, args.Config, args.PreferRemote, runtimeTarget)
+		var deployment *models.Deployment
+		if args.CanaryWeight > 0 {
+			deployment, err = registry.DeployServerCanary(ctx, args.ServerName, args.Version, args.DeploymentConfig(), args.CanaryWeight)
+		} else {
+			deployment, err = registry.DeployServer(ctx, args.ServerName, args.Version, args.DeploymentConfig(), args.PreferRemote, runtimeTarget, args.Origin)
+		}
 		if err != nil {
 			return nil, models.Deployment{}, err
 		}
@@ -432,12 +719,12 @@ func addDeploymentTools(server *mcp.Server, registry service.RegistryService) {
 			return nil, models.Deployment{}, errors.New("name and version are required")
 		}
 
-		runtimeTarget := args.Runtime
-		if runtimeTarget == "" {
-			runtimeTarget = "local"
+		runtimeTarget, err := restv0.DeploymentRuntimeTarget(ctx, registry, &args.DeploymentRequest)
+		if err != nil {
+			return nil, models.Deployment{}, err
 		}
 
-		deployment, err := registry.DeployAgent(ctx, args.ServerName, args.Version, args.Config, args.PreferRemote, runtimeTarget)
+		deployment, err := registry.DeployAgent(ctx, args.ServerName, args.Version, args.DeploymentConfig(), args.PreferRemote, runtimeTarget)
 		if err != nil {
 			return nil, models.Deployment{}, err
 		}
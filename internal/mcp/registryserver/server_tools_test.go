@@ -3,13 +3,19 @@ package registryserver
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -22,6 +28,9 @@ type discoveryRegistry struct {
 	agents       []*models.AgentResponse
 	skills       []*models.SkillResponse
 	serverReadme *database.ServerReadme
+	agentReadme  *database.AgentReadme
+	tools        []models.ToolSearchResult
+	published    []string
 }
 
 func (d *discoveryRegistry) ListServers(context.Context, *database.ServerFilter, string, int) ([]*apiv0.ServerResponse, string, error) {
@@ -39,8 +48,16 @@ func (d *discoveryRegistry) GetServerByNameAndVersion(context.Context, string, s
 func (d *discoveryRegistry) GetAllVersionsByServerName(context.Context, string, bool) ([]*apiv0.ServerResponse, error) {
 	return d.servers, nil
 }
-func (d *discoveryRegistry) CreateServer(context.Context, *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
-	return nil, database.ErrNotFound
+func (d *discoveryRegistry) CreateServer(ctx context.Context, s *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	if _, ok := auth.AuthSessionFrom(ctx); !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+	created := &apiv0.ServerResponse{Server: *s}
+	d.servers = append(d.servers, created)
+	return created, nil
+}
+func (d *discoveryRegistry) CreateServersBatch(context.Context, []*apiv0.ServerJSON, bool) []error {
+	return nil
 }
 func (d *discoveryRegistry) UpdateServer(context.Context, string, string, *apiv0.ServerJSON, *string) (*apiv0.ServerResponse, error) {
 	return nil, database.ErrNotFound
@@ -54,8 +71,9 @@ func (d *discoveryRegistry) GetServerReadmeLatest(context.Context, string) (*dat
 func (d *discoveryRegistry) GetServerReadmeByVersion(context.Context, string, string) (*database.ServerReadme, error) {
 	return d.serverReadme, nil
 }
-func (d *discoveryRegistry) PublishServer(context.Context, string, string) error {
-	return database.ErrNotFound
+func (d *discoveryRegistry) PublishServer(_ context.Context, name, version string) error {
+	d.published = append(d.published, name+"@"+version)
+	return nil
 }
 func (d *discoveryRegistry) UnpublishServer(context.Context, string, string) error {
 	return database.ErrNotFound
@@ -83,6 +101,9 @@ func (d *discoveryRegistry) GetAllVersionsByAgentName(context.Context, string) (
 func (d *discoveryRegistry) CreateAgent(context.Context, *models.AgentJSON) (*models.AgentResponse, error) {
 	return nil, database.ErrNotFound
 }
+func (d *discoveryRegistry) CreateAgentsBatch(context.Context, []*models.AgentJSON, bool) []error {
+	return nil
+}
 func (d *discoveryRegistry) PublishAgent(context.Context, string, string) error {
 	return database.ErrNotFound
 }
@@ -112,6 +133,247 @@ func (d *discoveryRegistry) GetAllVersionsBySkillName(context.Context, string) (
 func (d *discoveryRegistry) CreateSkill(context.Context, *models.SkillJSON) (*models.SkillResponse, error) {
 	return nil, database.ErrNotFound
 }
+func (d *discoveryRegistry) CreateSkillsBatch(context.Context, []*models.SkillJSON, bool) []error {
+	return nil
+}
+func (d *discoveryRegistry) GetDeploymentHealth(context.Context) ([]models.DeploymentHealth, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) CheckReadiness(context.Context, bool) *models.Readiness {
+	return &models.Readiness{Status: models.ReadinessStatusOK}
+}
+func (d *discoveryRegistry) Shutdown(context.Context) error {
+	return nil
+}
+func (d *discoveryRegistry) PutBlob(context.Context, io.Reader) (*models.Blob, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) OpenBlob(context.Context, string) (io.ReadCloser, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) UploadAttachment(context.Context, *models.Attachment, io.Reader) (*models.Attachment, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) ListAttachments(context.Context, string, string, string) ([]*models.Attachment, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) OpenAttachment(context.Context, string, string, string, string) (*models.Attachment, io.ReadCloser, error) {
+	return nil, nil, nil
+}
+func (d *discoveryRegistry) DeleteAttachment(context.Context, string, string, string, string) error {
+	return nil
+}
+func (d *discoveryRegistry) IntrospectServer(context.Context, string, string) (*models.ServerCapabilities, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) GetServerChangelog(context.Context, string, bool) (*models.ServerChangelog, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) GetServerCapabilities(context.Context, string, string) (*models.ServerCapabilities, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) SearchTools(context.Context, string, int) ([]models.ToolSearchResult, error) {
+	return d.tools, nil
+}
+func (d *discoveryRegistry) SetSkillStatus(context.Context, string, string, string) (*models.SkillResponse, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) GetSkillVersionStatuses(context.Context, string) ([]*models.SkillVersionStatus, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) StoreSkillReadme(context.Context, string, string, []byte, string) error {
+	return nil
+}
+func (d *discoveryRegistry) GetSkillReadmeLatest(context.Context, string) (*database.SkillReadme, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) GetSkillReadmeByVersion(context.Context, string, string) (*database.SkillReadme, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) CreateCollection(context.Context, *models.CollectionJSON) (*models.CollectionResponse, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) GetCollection(context.Context, string, string) (*models.CollectionResponse, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) ListCollections(context.Context, bool) ([]*models.CollectionResponse, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) PublishCollection(context.Context, string, string) error {
+	return nil
+}
+func (d *discoveryRegistry) UnpublishCollection(context.Context, string, string) error {
+	return nil
+}
+func (d *discoveryRegistry) DeleteCollection(context.Context, string, string) error {
+	return nil
+}
+func (d *discoveryRegistry) CreateWorkspace(context.Context, *models.Workspace) (*models.Workspace, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) ListWorkspaces(context.Context) ([]models.Workspace, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) GetWorkspace(context.Context, string) (*models.Workspace, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) CheckWorkspaceAccess(context.Context, string) error {
+	return nil
+}
+
+func (d *discoveryRegistry) DeleteWorkspace(context.Context, string) error {
+	return nil
+}
+
+func (d *discoveryRegistry) ListWorkspaceMembers(context.Context, string) ([]models.WorkspaceMember, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) SetWorkspaceMember(context.Context, string, string, string) (*models.WorkspaceMember, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) RemoveWorkspaceMember(context.Context, string, string) error {
+	return nil
+}
+
+func (d *discoveryRegistry) SetVisibility(context.Context, string, string, string, string) error {
+	return nil
+}
+
+func (d *discoveryRegistry) RenameServer(context.Context, string, string) (*apiv0.ServerResponse, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) ResolveServerAlias(context.Context, string) (string, error) {
+	return "", database.ErrNotFound
+}
+
+func (d *discoveryRegistry) ListTrash(context.Context) ([]models.TrashEntry, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) RestoreServer(context.Context, string, string) error {
+	return nil
+}
+
+func (d *discoveryRegistry) PurgeServer(context.Context, string, string) error {
+	return nil
+}
+
+func (d *discoveryRegistry) AddEventHandler(service.EventHandler) {}
+
+func (d *discoveryRegistry) GetServersByNames(context.Context, []string, bool) ([]*apiv0.ServerResponse, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) ListConfigProfiles(context.Context, string) ([]models.ConfigProfile, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) GetConfigProfile(context.Context, string, string) (*models.ConfigProfile, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) PutConfigProfile(context.Context, *models.ConfigProfile) (*models.ConfigProfile, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) DeleteConfigProfile(context.Context, string, string) error {
+	return nil
+}
+
+func (d *discoveryRegistry) GetQuota(context.Context, string) (*models.Quota, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) PruneRuntime(context.Context, bool) (*models.PruneReport, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) GetSystemUsage(context.Context) (*models.SystemUsage, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) GetDeploymentMetrics(context.Context, string, string) (*models.DeploymentMetrics, error) {
+	return nil, nil
+}
+
+func (d *discoveryRegistry) ListDeploymentTargets(context.Context) ([]runtime.Target, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) DeployServerCanary(context.Context, string, string, map[string]string, int) (*models.Deployment, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) PromoteDeployment(context.Context, string) (*models.Deployment, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) ListDeploymentRevisions(context.Context, string, string) ([]*models.DeploymentRevision, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) RollbackDeployment(context.Context, string, string, int) (*models.Deployment, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) DiffDeployment(context.Context, string, string, string) (*models.DeploymentDiff, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) ExportRuntime(context.Context, string) (*models.RuntimeExport, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) StoreAgentReadme(context.Context, string, string, []byte, string) error {
+	return nil
+}
+func (d *discoveryRegistry) GetAgentReadmeLatest(context.Context, string) (*database.AgentReadme, error) {
+	return d.agentReadme, nil
+}
+func (d *discoveryRegistry) GetAgentReadmeByVersion(context.Context, string, string) (*database.AgentReadme, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) ListServersDueForEnrichment(context.Context, time.Time, int) ([]*models.ServerEnrichment, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) RecordServerEnrichment(context.Context, string, string, map[string]any, error) error {
+	return nil
+}
+func (d *discoveryRegistry) RecordArtifactUsage(context.Context, string, string, string) error {
+	return nil
+}
+func (d *discoveryRegistry) GetArtifactStats(context.Context, string, string) (*models.ArtifactStats, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) ListTags(context.Context) ([]models.Tag, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) SubmitReview(context.Context, string, string, int, string) (*models.Review, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) ListReviews(context.Context, string, string, bool, string, int) (*models.ReviewListResponse, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) GetRatingSummaries(context.Context, string, []string) (map[string]models.RatingSummary, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) ModerateReview(context.Context, int64, bool) (*models.Review, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) DeleteReview(context.Context, int64) error {
+	return nil
+}
+func (d *discoveryRegistry) VerifyNamespace(context.Context, string, string) (*models.VerifiedNamespace, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) GetNamespaceChallenge(context.Context, string) (*models.NamespaceChallenge, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) ListVerifiedNamespaces(context.Context, string, string) ([]models.VerifiedNamespace, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) RevokeNamespace(context.Context, string, string) error {
+	return nil
+}
 func (d *discoveryRegistry) PublishSkill(context.Context, string, string) error {
 	return database.ErrNotFound
 }
@@ -126,7 +388,7 @@ func (d *discoveryRegistry) GetDeployments(context.Context, *models.DeploymentFi
 func (d *discoveryRegistry) GetDeploymentByNameAndVersion(context.Context, string, string, string) (*models.Deployment, error) {
 	return nil, database.ErrNotFound
 }
-func (d *discoveryRegistry) DeployServer(context.Context, string, string, map[string]string, bool, string) (*models.Deployment, error) {
+func (d *discoveryRegistry) DeployServer(context.Context, string, string, map[string]string, bool, string, string) (*models.Deployment, error) {
 	return nil, database.ErrNotFound
 }
 func (d *discoveryRegistry) DeployAgent(context.Context, string, string, map[string]string, bool, string) (*models.Deployment, error) {
@@ -135,9 +397,87 @@ func (d *discoveryRegistry) DeployAgent(context.Context, string, string, map[str
 func (d *discoveryRegistry) UpdateDeploymentConfig(context.Context, string, string, string, map[string]string) (*models.Deployment, error) {
 	return nil, database.ErrNotFound
 }
+func (d *discoveryRegistry) UpdateDeploymentOrigin(context.Context, string, string, string) (*models.Deployment, error) {
+	return nil, database.ErrNotFound
+}
 func (d *discoveryRegistry) RemoveDeployment(context.Context, string, string, string) error {
 	return database.ErrNotFound
 }
+func (d *discoveryRegistry) GetServerVersionStatuses(context.Context, string) ([]*models.ServerVersionStatus, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) SetServerVersionsPublished(context.Context, string, []string, bool) error {
+	return database.ErrNotFound
+}
+func (d *discoveryRegistry) ListAuditLog(context.Context, *models.AuditLogFilter, string, int) ([]*models.AuditLogEntry, string, error) {
+	return nil, "", nil
+}
+func (d *discoveryRegistry) StoreAgentSBOM(context.Context, string, string, []byte) (*database.AgentSBOM, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) GetAgentSBOM(context.Context, string, string) (*database.AgentSBOM, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) SetServerSignature(context.Context, *models.ServerSignature) (*models.ServerSignature, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) GetServerSignature(context.Context, string, string) (*models.ServerSignature, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) RegisterJob(jobs.Job) error {
+	return nil
+}
+func (d *discoveryRegistry) ListJobs(context.Context) ([]models.JobStatus, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) RunJob(context.Context, string) (*models.JobStatus, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) CreateImport(context.Context, string, models.ImportOptions) (*models.ImportStatus, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) UpdateImport(context.Context, *models.ImportStatus) error {
+	return nil
+}
+func (d *discoveryRegistry) GetImport(context.Context, string) (*models.ImportStatus, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) ListImports(context.Context) ([]models.ImportStatus, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) StartImport(context.Context, models.ImportRequest, service.ImportRunner) (*models.ImportStatus, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) CancelImport(context.Context, string) (*models.ImportStatus, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) ListDeploymentPolicies(context.Context) ([]models.DeploymentPolicy, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) PutDeploymentPolicy(context.Context, *models.DeploymentPolicy) (*models.DeploymentPolicy, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) DeleteDeploymentPolicy(context.Context, string) error {
+	return database.ErrNotFound
+}
+func (d *discoveryRegistry) CreateRoleBinding(context.Context, *models.RoleBinding) (*models.RoleBinding, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) ListRoleBindings(context.Context, *string) ([]*models.RoleBinding, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) DeleteRoleBinding(context.Context, int64) error {
+	return database.ErrNotFound
+}
+func (d *discoveryRegistry) CreateAPIToken(context.Context, string, []auth.Permission, *time.Time) (*models.CreatedAPIToken, error) {
+	return nil, database.ErrNotFound
+}
+func (d *discoveryRegistry) ListAPITokens(context.Context, bool) ([]*models.APIToken, error) {
+	return nil, nil
+}
+func (d *discoveryRegistry) RevokeAPIToken(context.Context, int64) error {
+	return database.ErrNotFound
+}
 func (d *discoveryRegistry) ReconcileAll(context.Context) error { return nil }
 func (d *discoveryRegistry) UpsertServerEmbedding(context.Context, string, string, *database.SemanticEmbedding) error {
 	return database.ErrNotFound
@@ -177,6 +517,15 @@ func TestServerTools_ListAndReadme(t *testing.T) {
 			},
 		},
 		serverReadme: readme,
+		tools: []models.ToolSearchResult{{
+			ServerName: "com.example/echo",
+			Version:    "1.0.0",
+			Tool: models.ToolInfo{
+				Name:        "echo",
+				Description: "Echo the input back",
+				InputSchema: map[string]any{"type": "object"},
+			},
+		}},
 	}
 
 	server := NewServer(reg)
@@ -204,6 +553,20 @@ func TestServerTools_ListAndReadme(t *testing.T) {
 	require.Len(t, listOut.Servers, 1)
 	assert.Equal(t, "com.example/echo", listOut.Servers[0].Server.Name)
 
+	// find_tool
+	res, err = clientSession.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "find_tool",
+		Arguments: map[string]any{"query": "echo"},
+	})
+	require.NoError(t, err)
+	raw, _ = json.Marshal(res.StructuredContent)
+	var toolsOut models.ToolSearchResponse
+	require.NoError(t, json.Unmarshal(raw, &toolsOut))
+	require.Len(t, toolsOut.Tools, 1)
+	assert.Equal(t, "com.example/echo", toolsOut.Tools[0].ServerName)
+	assert.Equal(t, "echo", toolsOut.Tools[0].Tool.Name)
+	assert.Equal(t, map[string]any{"type": "object"}, toolsOut.Tools[0].Tool.InputSchema)
+
 	// get_server_readme
 	res, err = clientSession.CallTool(ctx, &mcp.CallToolParams{
 		Name: "get_server_readme",
@@ -329,3 +692,121 @@ func TestAgentAndSkillTools_ListAndGet(t *testing.T) {
 	require.NoError(t, json.Unmarshal(raw, &skillOne))
 	assert.Equal(t, "com.example/skill", skillOne.Skill.Name)
 }
+
+// tokenAuthn accepts a single token and signs its bearer in as publisher
+type tokenAuthn struct{ token string }
+
+type publisherSession struct{}
+
+func (publisherSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: "publisher", AuthMethod: auth.MethodAPIToken}}
+}
+
+func (a tokenAuthn) Authenticate(_ context.Context, header func(string) string, _ url.Values) (auth.Session, error) {
+	if header("Authorization") != "Bearer "+a.token {
+		return nil, auth.ErrUnauthenticated
+	}
+	return publisherSession{}, nil
+}
+
+func connectTestClient(t *testing.T, server *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Wait() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = clientSession.Close() })
+	return clientSession
+}
+
+func TestServerTools_SearchDetailsAndReadme(t *testing.T) {
+	ctx := context.Background()
+	reg := &discoveryRegistry{
+		servers: []*apiv0.ServerResponse{{
+			Server: apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: "com.example/weather", Description: "Weather", Version: "1.2.0"},
+		}},
+		serverReadme: &database.ServerReadme{ServerName: "com.example/weather", Version: "1.2.0", Content: []byte("# Weather"), ContentType: "text/markdown"},
+		agentReadme:  &database.AgentReadme{AgentName: "com.example/planner", Version: "0.1.0", Content: []byte("# Planner"), ContentType: "text/markdown"},
+	}
+	session := connectTestClient(t, NewServer(reg))
+
+	// search_servers
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "search_servers", Arguments: map[string]any{"query": "weather"}})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+	raw, _ := json.Marshal(res.StructuredContent)
+	var searchOut apiv0.ServerListResponse
+	require.NoError(t, json.Unmarshal(raw, &searchOut))
+	require.Len(t, searchOut.Servers, 1)
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "search_servers", Arguments: map[string]any{"query": " "}})
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+
+	// get_server_details
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "get_server_details", Arguments: map[string]any{"name": "com.example/weather"}})
+	require.NoError(t, err)
+	raw, _ = json.Marshal(res.StructuredContent)
+	var details ServerDetails
+	require.NoError(t, json.Unmarshal(raw, &details))
+	assert.Equal(t, "1.2.0", details.Server.Server.Version)
+	assert.True(t, details.HasReadme)
+	assert.Equal(t, []string{"1.2.0"}, details.Versions)
+	assert.Nil(t, details.Capabilities)
+
+	// get_readme for a server and an agent
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "get_readme", Arguments: map[string]any{"type": "server", "name": "com.example/weather"}})
+	require.NoError(t, err)
+	raw, _ = json.Marshal(res.StructuredContent)
+	var readme ReadmePayload
+	require.NoError(t, json.Unmarshal(raw, &readme))
+	assert.Equal(t, "# Weather", readme.Content)
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "get_readme", Arguments: map[string]any{"type": "agent", "name": "com.example/planner"}})
+	require.NoError(t, err)
+	raw, _ = json.Marshal(res.StructuredContent)
+	require.NoError(t, json.Unmarshal(raw, &readme))
+	assert.Equal(t, "agent", readme.Type)
+	assert.Equal(t, "# Planner", readme.Content)
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "get_readme", Arguments: map[string]any{"type": "skill", "name": "com.example/missing"}})
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+}
+
+func TestPublishServerTool(t *testing.T) {
+	ctx := context.Background()
+	reg := &discoveryRegistry{}
+	session := connectTestClient(t, NewServer(reg, WithAuthn(tokenAuthn{token: "s3cret"})))
+
+	serverJSON := map[string]any{
+		"$schema":     model.CurrentSchemaURL,
+		"name":        "com.example/new-server",
+		"description": "New server",
+		"version":     "1.0.0",
+	}
+
+	// Without a token the connection is anonymous and the registry refuses the publish
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "publish_server", Arguments: map[string]any{"server": serverJSON}})
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "publish_server", Arguments: map[string]any{"server": serverJSON, "token": "wrong"}})
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Empty(t, reg.servers)
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "publish_server", Arguments: map[string]any{"server": serverJSON, "token": "s3cret"}})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+	raw, _ := json.Marshal(res.StructuredContent)
+	var out apiv0.ServerResponse
+	require.NoError(t, json.Unmarshal(raw, &out))
+	assert.Equal(t, "com.example/new-server", out.Server.Name)
+	assert.Equal(t, []string{"com.example/new-server@1.0.0"}, reg.published)
+}
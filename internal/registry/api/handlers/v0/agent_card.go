@@ -0,0 +1,158 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// AgentCardInput represents the input for getting an agent's A2A card
+type AgentCardInput struct {
+	AgentName string `path:"agentName" json:"agentName" doc:"URL-encoded agent name" example:"com.example%2Fmy-agent"`
+	Version   string `query:"version" json:"version,omitempty" doc:"Agent version; defaults to the latest version" required:"false" example:"1.0.0"`
+}
+
+// AgentDirectoryEntry describes an agent listed by the discovery endpoint
+type AgentDirectoryEntry struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	URL         string `json:"url,omitempty" doc:"Primary A2A endpoint of the agent, if known"`
+	CardURL     string `json:"cardUrl" doc:"Path of the agent's A2A card on this registry"`
+	Deployed    bool   `json:"deployed" doc:"Whether the agent is deployed through this registry"`
+}
+
+// AgentDirectory is the body of the agent discovery endpoint
+type AgentDirectory struct {
+	Agents []AgentDirectoryEntry `json:"agents"`
+}
+
+// RegisterAgentCardEndpoints registers the A2A agent card endpoint of published agents
+func RegisterAgentCardEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-agent-card" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/agents/{agentName}/card",
+		Summary:     "Get an agent's A2A card",
+		Description: "Get the A2A agent card of a published agent, generated from its manifest. Locally deployed agents list the agent gateway as an endpoint.",
+		Tags:        []string{"agents"},
+	}, func(ctx context.Context, input *AgentCardInput) (*Response[models.AgentCard], error) {
+		agentName, err := url.PathUnescape(input.AgentName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid agent name encoding", err)
+		}
+
+		var agent *models.AgentResponse
+		if input.Version == "" || input.Version == "latest" {
+			agent, err = registry.GetAgentByName(ctx, agentName)
+		} else {
+			agent, err = registry.GetAgentByNameAndVersion(ctx, agentName, input.Version)
+		}
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Agent not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get agent", err)
+		}
+		if agent.Meta.Official == nil || !agent.Meta.Official.Published {
+			return nil, huma.Error404NotFound("Agent not found")
+		}
+
+		deploymentURL, err := agentDeploymentURL(ctx, registry, cfg, agent.Agent.Name, agent.Agent.Version)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get agent deployment", err)
+		}
+		return &Response[models.AgentCard]{Body: *models.NewAgentCard(&agent.Agent, deploymentURL)}, nil
+	})
+}
+
+// RegisterAgentDiscoveryEndpoint registers /.well-known/agents, which lists the latest version of every published
+// agent with the location of its A2A card.
+func RegisterAgentDiscoveryEndpoint(api huma.API, cardPathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-agent-cards",
+		Method:      http.MethodGet,
+		Path:        "/.well-known/agents",
+		Summary:     "Discover A2A agents",
+		Description: "List the published agents of this registry with the location of their A2A cards, so A2A clients can discover them.",
+		Tags:        []string{"agents"},
+	}, func(ctx context.Context, _ *struct{}) (*Response[AgentDirectory], error) {
+		published, isLatest := true, true
+		filter := &database.AgentFilter{Published: &published, IsLatest: &isLatest}
+
+		resourceType := "agent"
+		deployments, err := registry.GetDeployments(ctx, &models.DeploymentFilter{ResourceType: &resourceType})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get agent deployments", err)
+		}
+		deployed := map[string]*models.Deployment{}
+		for _, d := range deployments {
+			deployed[d.ServerName+"@"+d.Version] = d
+		}
+
+		directory := AgentDirectory{Agents: []AgentDirectoryEntry{}}
+		cursor := ""
+		for {
+			agents, next, err := registry.ListAgents(ctx, filter, cursor, 100)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to list agents", err)
+			}
+			for _, a := range agents {
+				deploymentURL := ""
+				d, isDeployed := deployed[a.Agent.Name+"@"+a.Agent.Version]
+				if isDeployed && d.Runtime == "local" {
+					deploymentURL = agentGatewayURL(cfg, a.Agent.Name)
+				}
+				card := models.NewAgentCard(&a.Agent, deploymentURL)
+				directory.Agents = append(directory.Agents, AgentDirectoryEntry{
+					Name:        a.Agent.Name,
+					Version:     a.Agent.Version,
+					Description: a.Agent.Description,
+					URL:         card.URL,
+					CardURL:     fmt.Sprintf("%s/agents/%s/card", cardPathPrefix, url.PathEscape(a.Agent.Name)),
+					Deployed:    isDeployed,
+				})
+			}
+			if next == "" || len(agents) == 0 {
+				break
+			}
+			cursor = next
+		}
+		return &Response[AgentDirectory]{Body: directory}, nil
+	})
+}
+
+// agentDeploymentURL returns the agent gateway URL of a locally deployed agent version, or "" if it is not
+// deployed locally. Agents deployed to Kubernetes are reached through the cluster and are not listed.
+func agentDeploymentURL(ctx context.Context, registry service.RegistryService, cfg *config.Config, agentName, version string) (string, error) {
+	deployment, err := registry.GetDeploymentByNameAndVersion(ctx, agentName, version, "agent")
+	if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if deployment.Runtime != "local" {
+		return "", nil
+	}
+	return agentGatewayURL(cfg, agentName), nil
+}
+
+// agentGatewayURL is the URL the local agent gateway routes to an agent at
+func agentGatewayURL(cfg *config.Config, agentName string) string {
+	base := strings.TrimSuffix(cfg.AgentGatewayURL, "/")
+	if base == "" {
+		base = fmt.Sprintf("http://localhost:%d", cfg.AgentGatewayPort)
+	}
+	return fmt.Sprintf("%s/agents/%s", base, agentName)
+}
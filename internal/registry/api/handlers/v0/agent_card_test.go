@@ -0,0 +1,94 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentCardEndpoints(t *testing.T) {
+	ctx := internaldb.WithTestSession(context.Background())
+	cfg := &config.Config{AgentGatewayPort: 8081}
+	registryService := service.NewRegistryService(internaldb.NewTestDB(t), cfg, nil)
+
+	agent := &models.AgentJSON{
+		AgentManifest: models.AgentManifest{
+			Name:        "com.example/planner",
+			Description: "Plans trips",
+			Framework:   "adk",
+			McpServers:  []models.McpServerType{{Type: "remote", Name: "weather", URL: "http://weather:3000/mcp"}},
+		},
+		Version: "1.0.0",
+		Remotes: []model.Transport{{
+			Type: "streamable-http",
+			URL:  "https://planner.example.com/a2a",
+			Headers: []model.KeyValueInput{
+				{Name: "Authorization", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true}}},
+			},
+		}},
+	}
+	_, err := registryService.CreateAgent(ctx, agent)
+	require.NoError(t, err)
+	_, err = registryService.CreateAgent(ctx, &models.AgentJSON{
+		AgentManifest: models.AgentManifest{Name: "com.example/draft", Description: "Not published"},
+		Version:       "0.1.0",
+	})
+	require.NoError(t, err)
+	require.NoError(t, registryService.PublishAgent(ctx, "com.example/planner", "1.0.0"))
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAgentCardEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterAgentDiscoveryEndpoint(api, "/v0", registryService, cfg)
+
+	t.Run("card of a published agent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/agents/com.example%2Fplanner/card", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var card models.AgentCard
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &card))
+		assert.Equal(t, "com.example/planner", card.Name)
+		assert.Equal(t, "1.0.0", card.Version)
+		assert.Equal(t, "https://planner.example.com/a2a", card.URL)
+		assert.Equal(t, models.AgentSecurityScheme{Type: "http", Scheme: "bearer"}, card.SecuritySchemes["bearer"])
+		require.Len(t, card.Skills, 2)
+		assert.Equal(t, "planner", card.Skills[0].ID)
+		assert.Equal(t, "weather", card.Skills[1].ID)
+	})
+
+	t.Run("unpublished agent has no card", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/agents/com.example%2Fdraft/card", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("discovery lists published agents", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/.well-known/agents", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var directory v0.AgentDirectory
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &directory))
+		require.Len(t, directory.Agents, 1)
+		assert.Equal(t, "com.example/planner", directory.Agents[0].Name)
+		assert.Equal(t, "/v0/agents/com.example%2Fplanner/card", directory.Agents[0].CardURL)
+		assert.False(t, directory.Agents[0].Deployed)
+	})
+}
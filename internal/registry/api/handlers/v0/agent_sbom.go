@@ -0,0 +1,134 @@
+package v0
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// UploadAgentSBOMInput represents the input for attaching an SBOM to an agent version
+type UploadAgentSBOMInput struct {
+	AgentVersionDetailInput
+	RawBody []byte `contentType:"application/json" doc:"SPDX or CycloneDX JSON document"`
+}
+
+// AgentSBOMMetadata describes a stored agent SBOM
+type AgentSBOMMetadata struct {
+	AgentName      string    `json:"agentName"`
+	Version        string    `json:"version"`
+	Format         string    `json:"format" doc:"SBOM format (spdx, cyclonedx)"`
+	ComponentCount int       `json:"componentCount" doc:"Number of components (packages) listed in the SBOM"`
+	SizeBytes      int       `json:"sizeBytes"`
+	Sha256         string    `json:"sha256"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// AgentSBOMDocumentResponse returns the raw SBOM document with its metadata in headers
+type AgentSBOMDocumentResponse struct {
+	ContentType    string `header:"Content-Type"`
+	Format         string `header:"X-SBOM-Format"`
+	ComponentCount string `header:"X-SBOM-Component-Count"`
+	Body           []byte
+}
+
+// RegisterAgentSBOMEndpoints registers the endpoints to attach and fetch agent SBOMs
+func RegisterAgentSBOMEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"agents"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-agent-sbom" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/agents/{agentName}/versions/{version}/sbom",
+		Summary:     "Get agent SBOM",
+		Description: "Download the SBOM (SPDX or CycloneDX JSON) of an agent version's image. Use the special version 'latest' for the latest version.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *AgentVersionDetailInput) (*AgentSBOMDocumentResponse, error) {
+		agentName, version, err := decodeAgentVersion(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if version == "latest" {
+			agent, err := registry.GetAgentByName(ctx, agentName)
+			if err != nil {
+				return nil, agentSBOMError(err, "Failed to get agent")
+			}
+			version = agent.Agent.Version
+		}
+
+		sbom, err := registry.GetAgentSBOM(ctx, agentName, version)
+		if err != nil {
+			return nil, agentSBOMError(err, "Failed to get agent SBOM")
+		}
+
+		return &AgentSBOMDocumentResponse{
+			ContentType:    "application/json",
+			Format:         sbom.Format,
+			ComponentCount: strconv.Itoa(sbom.ComponentCount),
+			Body:           sbom.Content,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "upload-agent-sbom" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/agents/{agentName}/versions/{version}/sbom",
+		Summary:     "Attach an SBOM to an agent version",
+		Description: "Store the SBOM (SPDX or CycloneDX JSON) generated for an agent version's image, replacing any existing one.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *UploadAgentSBOMInput) (*Response[AgentSBOMMetadata], error) {
+		agentName, version, err := decodeAgentVersion(&input.AgentVersionDetailInput)
+		if err != nil {
+			return nil, err
+		}
+
+		sbom, err := registry.StoreAgentSBOM(ctx, agentName, version, input.RawBody)
+		if err != nil {
+			return nil, agentSBOMError(err, "Failed to store agent SBOM")
+		}
+
+		return &Response[AgentSBOMMetadata]{
+			Body: AgentSBOMMetadata{
+				AgentName:      sbom.AgentName,
+				Version:        sbom.Version,
+				Format:         sbom.Format,
+				ComponentCount: sbom.ComponentCount,
+				SizeBytes:      sbom.SizeBytes,
+				Sha256:         hex.EncodeToString(sbom.SHA256),
+				CreatedAt:      sbom.CreatedAt,
+			},
+		}, nil
+	})
+}
+
+func decodeAgentVersion(input *AgentVersionDetailInput) (string, string, error) {
+	agentName, err := url.PathUnescape(input.AgentName)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid agent name encoding", err)
+	}
+	version, err := url.PathUnescape(input.Version)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid version encoding", err)
+	}
+	return agentName, version, nil
+}
+
+func agentSBOMError(err error, msg string) error {
+	if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+		return huma.Error404NotFound("SBOM not found")
+	}
+	if errors.Is(err, database.ErrInvalidInput) {
+		return huma.Error400BadRequest(err.Error(), err)
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
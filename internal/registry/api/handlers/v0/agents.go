@@ -2,12 +2,15 @@ package v0
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	agentmodels "github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
@@ -24,6 +27,8 @@ type ListAgentsInput struct {
 	Version                string  `query:"version" json:"version,omitempty" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
 	Semantic               bool    `query:"semantic_search" json:"semantic_search,omitempty" doc:"Use semantic search for the search term"`
 	SemanticMatchThreshold float64 `query:"semantic_threshold" json:"semantic_threshold,omitempty" doc:"Optional maximum cosine distance when semantic_search is enabled" required:"false"`
+	Sort                   string  `query:"sort" json:"sort,omitempty" doc:"Sort order: name (default) or popularity (most downloaded, deployed and installed first)" required:"false" enum:"name,popularity" example:"popularity"`
+	Tags                   string  `query:"tags" json:"tags,omitempty" doc:"Comma-separated tags; only agents carrying all of them are returned" required:"false" example:"search"`
 }
 
 // AgentDetailInput represents the input for getting agent details
@@ -42,6 +47,26 @@ type AgentVersionsInput struct {
 	AgentName string `path:"agentName" json:"agentName" doc:"URL-encoded agent name" example:"com.example%2Fmy-agent"`
 }
 
+// AgentReadmeResponse is the payload for agent README fetch endpoints
+type AgentReadmeResponse struct {
+	Content     string    `json:"content"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int       `json:"size_bytes"`
+	Sha256      string    `json:"sha256"`
+	Version     string    `json:"version"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// UploadAgentReadmeInput represents the input for uploading the README of an agent version
+type UploadAgentReadmeInput struct {
+	AgentName string `path:"agentName" json:"agentName" doc:"URL-encoded agent name" example:"com.example%2Fmy-agent"`
+	Version   string `path:"version" json:"version" doc:"URL-encoded agent version" example:"1.0.0"`
+	Body      struct {
+		Content     string `json:"content" doc:"README document" minLength:"1"`
+		ContentType string `json:"contentType,omitempty" doc:"Media type of the document" required:"false" example:"text/markdown"`
+	}
+}
+
 // RegisterAgentsEndpoints registers all agent-related endpoints with a custom path prefix
 // isAdmin: if true, shows all resources; if false, only shows published resources
 func RegisterAgentsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, isAdmin bool) {
@@ -98,6 +123,9 @@ func RegisterAgentsEndpoints(api huma.API, pathPrefix string, registry service.R
 			}
 		}
 
+		filter.Tags = parseTagsParam(input.Tags)
+		filter.SortByPopularity = input.Sort == "popularity"
+
 		agents, nextCursor, err := registry.ListAgents(ctx, filter, input.Cursor, input.Limit)
 		if err != nil {
 			if errors.Is(err, database.ErrInvalidInput) {
@@ -110,8 +138,18 @@ func RegisterAgentsEndpoints(api huma.API, pathPrefix string, registry service.R
 		}
 
 		agentValues := make([]agentmodels.AgentResponse, len(agents))
+		names := make([]string, len(agents))
 		for i, a := range agents {
 			agentValues[i] = *a
+			names[i] = a.Agent.Name
+		}
+		// Ratings are decoration; a failure to load them does not fail the listing
+		if ratings, err := registry.GetRatingSummaries(ctx, "agent", names); err == nil {
+			for i := range agentValues {
+				if rating, ok := ratings[agentValues[i].Agent.Name]; ok {
+					agentValues[i].Meta.Rating = &rating
+				}
+			}
 		}
 		return &Response[agentmodels.AgentListResponse]{
 			Body: agentmodels.AgentListResponse{
@@ -221,6 +259,120 @@ func RegisterAgentsEndpoints(api huma.API, pathPrefix string, registry service.R
 			},
 		}, nil
 	})
+
+	// Get latest agent README
+	huma.Register(api, huma.Operation{
+		OperationID: "get-agent-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/agents/{agentName}/readme",
+		Summary:     "Get agent README",
+		Description: "Fetch the README document for the latest version of an Agentic agent",
+		Tags:        tags,
+	}, func(ctx context.Context, input *AgentDetailInput) (*Response[AgentReadmeResponse], error) {
+		agentName, err := url.PathUnescape(input.AgentName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid agent name encoding", err)
+		}
+
+		readme, err := registry.GetAgentReadmeLatest(ctx, agentName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("README not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to fetch agent README", err)
+		}
+		return &Response[AgentReadmeResponse]{Body: toAgentReadmeResponse(readme)}, nil
+	})
+
+	// Get agent README for a specific version
+	huma.Register(api, huma.Operation{
+		OperationID: "get-agent-version-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/agents/{agentName}/versions/{version}/readme",
+		Summary:     "Get agent README for a version",
+		Description: "Fetch the README document for a specific version of an Agentic agent",
+		Tags:        tags,
+	}, func(ctx context.Context, input *AgentVersionDetailInput) (*Response[AgentReadmeResponse], error) {
+		agentName, err := url.PathUnescape(input.AgentName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid agent name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		var readme *database.AgentReadme
+		if version == "latest" {
+			readme, err = registry.GetAgentReadmeLatest(ctx, agentName)
+		} else {
+			readme, err = registry.GetAgentReadmeByVersion(ctx, agentName, version)
+		}
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("README not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to fetch agent README", err)
+		}
+		return &Response[AgentReadmeResponse]{Body: toAgentReadmeResponse(readme)}, nil
+	})
+}
+
+func toAgentReadmeResponse(readme *database.AgentReadme) AgentReadmeResponse {
+	shaValue := ""
+	if len(readme.SHA256) > 0 {
+		shaValue = hex.EncodeToString(readme.SHA256)
+	}
+	return AgentReadmeResponse{
+		Content:     string(readme.Content),
+		ContentType: readme.ContentType,
+		SizeBytes:   readme.SizeBytes,
+		Sha256:      shaValue,
+		Version:     readme.Version,
+		FetchedAt:   readme.FetchedAt,
+	}
+}
+
+// RegisterAgentsReadmeUploadEndpoint registers the endpoint that stores the README of an existing agent version
+func RegisterAgentsReadmeUploadEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID:  "upload-agent-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPut,
+		Path:         pathPrefix + "/agents/{agentName}/versions/{version}/readme",
+		Summary:      "Upload agent README",
+		Description:  "Store or replace the README document of an existing Agentic agent version.",
+		Tags:         []string{"agents", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
+		Security:     []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *UploadAgentReadmeInput) (*Response[EmptyResponse], error) {
+		agentName, err := url.PathUnescape(input.AgentName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid agent name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		if err := registry.StoreAgentReadme(ctx, agentName, version, []byte(input.Body.Content), input.Body.ContentType); err != nil {
+			if problem := sizeProblem(err); problem != nil {
+				return nil, problem
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Agent not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to store agent README", err)
+		}
+
+		return &Response[EmptyResponse]{
+			Body: EmptyResponse{
+				Message: "Agent README stored successfully",
+			},
+		}, nil
+	})
 }
 
 // CreateAgentInput represents the input for creating/updating an agent
@@ -233,6 +385,18 @@ func createAgentHandler(ctx context.Context, input *CreateAgentInput, registry s
 	// Create/update the agent (published defaults to false in the service layer)
 	createdAgent, err := registry.CreateAgent(ctx, &input.Body)
 	if err != nil {
+		if problem := validationProblem(err); problem != nil {
+			return nil, problem
+		}
+		if problem := sizeProblem(err); problem != nil {
+			return nil, problem
+		}
+		if problem := quotaProblem(err); problem != nil {
+			return nil, problem
+		}
+		if errors.Is(err, namespace.ErrNotOwned) {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
 		if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 			return nil, huma.Error404NotFound("Not found")
 		}
@@ -244,28 +408,30 @@ func createAgentHandler(ctx context.Context, input *CreateAgentInput, registry s
 
 // RegisterAgentsCreateEndpoint registers the public agents create/update endpoint at /agents/publish
 // This endpoint creates or updates an agent in the registry (published defaults to false)
-func RegisterAgentsCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+func RegisterAgentsCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	huma.Register(api, huma.Operation{
-		OperationID: "create-agent" + strings.ReplaceAll(pathPrefix, "/", "-"),
-		Method:      http.MethodPost,
-		Path:        pathPrefix + "/agents/publish",
-		Summary:     "Create/update Agentic agent",
-		Description: "Create a new Agentic agent in the registry or update an existing one. By default, agents are created as unpublished (published=false).",
-		Tags:        []string{"agents", "publish"},
-		Security:    []map[string][]string{{"bearer": {}}},
+		OperationID:  "create-agent" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/agents/publish",
+		Summary:      "Create/update Agentic agent",
+		Description:  "Create a new Agentic agent in the registry or update an existing one. By default, agents are created as unpublished (published=false).",
+		Tags:         []string{"agents", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
+		Security:     []map[string][]string{{"bearer": {}}},
 	}, func(ctx context.Context, input *CreateAgentInput) (*Response[agentmodels.AgentResponse], error) {
 		return createAgentHandler(ctx, input, registry)
 	})
 
 	// Also register a dedicated /agents/push endpoint for "push" operations that create an unpublished agent.
 	huma.Register(api, huma.Operation{
-		OperationID: "push-agent" + strings.ReplaceAll(pathPrefix, "/", "-"),
-		Method:      http.MethodPost,
-		Path:        pathPrefix + "/agents/push",
-		Summary:     "Push Agentic agent (create unpublished)",
-		Description: "Create a new Agentic agent in the registry as an unpublished entry (published=false).",
-		Tags:        []string{"agents", "publish"},
-		Security:    []map[string][]string{{"bearer": {}}},
+		OperationID:  "push-agent" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/agents/push",
+		Summary:      "Push Agentic agent (create unpublished)",
+		Description:  "Create a new Agentic agent in the registry as an unpublished entry (published=false).",
+		Tags:         []string{"agents", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
+		Security:     []map[string][]string{{"bearer": {}}},
 	}, func(ctx context.Context, input *CreateAgentInput) (*Response[agentmodels.AgentResponse], error) {
 		return createAgentHandler(ctx, input, registry)
 	})
@@ -273,18 +439,31 @@ func RegisterAgentsCreateEndpoint(api huma.API, pathPrefix string, registry serv
 
 // RegisterAdminAgentsCreateEndpoint registers the admin agents create/update endpoint at /agents
 // This endpoint creates or updates an agent in the registry (published defaults to false)
-func RegisterAdminAgentsCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+func RegisterAdminAgentsCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	huma.Register(api, huma.Operation{
-		OperationID: "admin-create-agent" + strings.ReplaceAll(pathPrefix, "/", "-"),
-		Method:      http.MethodPost,
-		Path:        pathPrefix + "/agents",
-		Summary:     "Create/update Agentic agent (Admin)",
-		Description: "Create a new Agentic agent in the registry or update an existing one. By default, agents are created as unpublished (published=false).",
-		Tags:        []string{"agents", "admin"},
+		OperationID:  "admin-create-agent" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/agents",
+		Summary:      "Create/update Agentic agent (Admin)",
+		Description:  "Create a new Agentic agent in the registry or update an existing one. By default, agents are created as unpublished (published=false).",
+		Tags:         []string{"agents", "admin"},
+		MaxBodyBytes: publishBodyLimit(cfg),
 	}, func(ctx context.Context, input *CreateAgentInput) (*Response[agentmodels.AgentResponse], error) {
 		// Create/update the agent (published defaults to false in the service layer)
 		createdAgent, err := registry.CreateAgent(ctx, &input.Body)
 		if err != nil {
+			if problem := validationProblem(err); problem != nil {
+				return nil, problem
+			}
+			if problem := sizeProblem(err); problem != nil {
+				return nil, problem
+			}
+			if problem := quotaProblem(err); problem != nil {
+				return nil, problem
+			}
+			if errors.Is(err, namespace.ErrNotOwned) {
+				return nil, huma.Error403Forbidden(err.Error())
+			}
 			if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 				return nil, huma.Error404NotFound("Not found")
 			}
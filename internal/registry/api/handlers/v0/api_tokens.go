@@ -0,0 +1,111 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ListAPITokensInput represents the input for listing API tokens
+type ListAPITokensInput struct {
+	All bool `query:"all" json:"all,omitempty" doc:"List the tokens of all users (registry admins only)" required:"false"`
+}
+
+// CreateAPITokenInput represents the input for minting an API token
+type CreateAPITokenInput struct {
+	Body struct {
+		Name        string            `json:"name" doc:"Human readable token name" minLength:"1" example:"github-actions"`
+		Permissions []auth.Permission `json:"permissions" doc:"Permissions granted to the token; must be within the caller's own permissions" minItems:"1"`
+		ExpiresAt   *time.Time        `json:"expiresAt,omitempty" doc:"Optional expiry time (RFC3339)" required:"false"`
+	}
+}
+
+// APITokenInput represents the path parameters for a single API token
+type APITokenInput struct {
+	ID int64 `path:"id" json:"id" doc:"API token ID" example:"1"`
+}
+
+// RegisterAPITokenEndpoints registers the API token management endpoints
+func RegisterAPITokenEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"auth"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-api-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/tokens",
+		Summary:     "Create an API token",
+		Description: "Mint a long-lived, scoped API token for non-interactive clients such as CI. The token value is only returned in this response.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CreateAPITokenInput) (*Response[models.CreatedAPIToken], error) {
+		token, err := registry.CreateAPIToken(ctx, input.Body.Name, input.Body.Permissions, input.Body.ExpiresAt)
+		if err != nil {
+			if errors.Is(err, database.ErrAlreadyExists) {
+				return nil, huma.Error409Conflict("API token already exists")
+			}
+			return nil, apiTokenError(err, "Failed to create API token")
+		}
+		return &Response[models.CreatedAPIToken]{Body: *token}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-api-tokens" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/auth/tokens",
+		Summary:     "List API tokens",
+		Description: "List the caller's API tokens. Token values are never returned.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ListAPITokensInput) (*Response[models.APITokenListResponse], error) {
+		tokens, err := registry.ListAPITokens(ctx, input.All)
+		if err != nil {
+			return nil, apiTokenError(err, "Failed to list API tokens")
+		}
+
+		values := make([]models.APIToken, len(tokens))
+		for i, t := range tokens {
+			values[i] = *t
+		}
+		return &Response[models.APITokenListResponse]{
+			Body: models.APITokenListResponse{Tokens: values},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-api-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/auth/tokens/{id}",
+		Summary:     "Revoke an API token",
+		Description: "Revoke one of the caller's API tokens. Registry admins may revoke any token.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *APITokenInput) (*Response[EmptyResponse], error) {
+		if err := registry.RevokeAPIToken(ctx, input.ID); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("API token not found")
+			}
+			return nil, apiTokenError(err, "Failed to revoke API token")
+		}
+		return &Response[EmptyResponse]{
+			Body: EmptyResponse{Message: "API token revoked successfully"},
+		}, nil
+	})
+}
+
+func apiTokenError(err error, msg string) error {
+	if errors.Is(err, database.ErrInvalidInput) {
+		return huma.Error400BadRequest(err.Error(), err)
+	}
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		return huma.Error401Unauthorized("Authentication required")
+	}
+	if errors.Is(err, auth.ErrForbidden) {
+		return huma.Error403Forbidden(err.Error())
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
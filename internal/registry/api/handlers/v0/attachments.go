@@ -0,0 +1,306 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/blobstore"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ServerArtifactsInput identifies the server version whose artifacts are listed
+type ServerArtifactsInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" json:"version" doc:"URL-encoded server version, or 'latest'" example:"1.0.0"`
+}
+
+// ServerArtifactInput identifies an artifact file of a server version
+type ServerArtifactInput struct {
+	ServerArtifactsInput
+	FileName string `path:"fileName" json:"fileName" doc:"URL-encoded artifact file name" example:"config.example.json"`
+}
+
+// AgentArtifactsInput identifies the agent version whose artifacts are listed
+type AgentArtifactsInput struct {
+	AgentName string `path:"agentName" json:"agentName" doc:"URL-encoded agent name" example:"my-agent"`
+	Version   string `path:"version" json:"version" doc:"URL-encoded agent version, or 'latest'" example:"1.0.0"`
+}
+
+// AgentArtifactInput identifies an artifact file of an agent version
+type AgentArtifactInput struct {
+	AgentArtifactsInput
+	FileName string `path:"fileName" json:"fileName" doc:"URL-encoded artifact file name" example:"prompt.md"`
+}
+
+// ArtifactUpload streams the content of an uploaded artifact file instead of buffering it
+type ArtifactUpload struct {
+	Kind        string `query:"kind" doc:"Kind of artifact" enum:"schema,example-config,prompt-template,binary,other" default:"other"`
+	ContentType string `header:"Content-Type" doc:"Media type of the file; derived from the file extension when omitted"`
+	body        io.Reader
+}
+
+// Resolve captures the request body reader
+func (u *ArtifactUpload) Resolve(ctx huma.Context) []error {
+	u.body = ctx.BodyReader()
+	return nil
+}
+
+// UploadServerArtifactInput represents the input for attaching an artifact file to a server version
+type UploadServerArtifactInput struct {
+	ServerArtifactInput
+	ArtifactUpload
+}
+
+// UploadAgentArtifactInput represents the input for attaching an artifact file to an agent version
+type UploadAgentArtifactInput struct {
+	AgentArtifactInput
+	ArtifactUpload
+}
+
+// RegisterAttachmentsEndpoints registers the endpoints to attach, list, download and remove the artifact files of
+// server and agent versions
+func RegisterAttachmentsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	suffix := strings.ReplaceAll(pathPrefix, "/", "-")
+	serverPath := pathPrefix + "/servers/{serverName}/versions/{version}/artifacts"
+	agentPath := pathPrefix + "/agents/{agentName}/versions/{version}/artifacts"
+	uploadBody := &huma.RequestBody{
+		Description: "Artifact file content",
+		Required:    true,
+		Content: map[string]*huma.MediaType{
+			"application/octet-stream": {},
+		},
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-artifacts" + suffix,
+		Method:      http.MethodGet,
+		Path:        serverPath,
+		Summary:     "List server artifacts",
+		Description: "List the artifact files, such as schemas, example configs and prompt templates, attached to a server version.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerArtifactsInput) (*Response[models.AttachmentListResponse], error) {
+		return listAttachments(ctx, registry, "mcp", input.ServerName, input.Version)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-artifact" + suffix,
+		Method:      http.MethodGet,
+		Path:        serverPath + "/{fileName}",
+		Summary:     "Download a server artifact",
+		Description: "Download an artifact file attached to a server version.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerArtifactInput) (*huma.StreamResponse, error) {
+		return openAttachment(ctx, registry, "mcp", input.ServerName, input.Version, input.FileName)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "upload-server-artifact" + suffix,
+		Method:      http.MethodPut,
+		Path:        serverPath + "/{fileName}",
+		Summary:     "Attach an artifact to a server version",
+		Description: "Attach an artifact file to a server version, replacing a file of the same name. The content is kept in blob storage, so BLOB_STORAGE must be configured.",
+		Tags:        []string{"servers"},
+		RequestBody: uploadBody,
+	}, func(ctx context.Context, input *UploadServerArtifactInput) (*Response[models.Attachment], error) {
+		return uploadAttachment(ctx, registry, "mcp", input.ServerName, input.Version, input.FileName, &input.ArtifactUpload)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-server-artifact" + suffix,
+		Method:        http.MethodDelete,
+		Path:          serverPath + "/{fileName}",
+		Summary:       "Remove a server artifact",
+		Description:   "Remove an artifact file from a server version.",
+		Tags:          []string{"servers"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *ServerArtifactInput) (*struct{}, error) {
+		return deleteAttachment(ctx, registry, "mcp", input.ServerName, input.Version, input.FileName)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-agent-artifacts" + suffix,
+		Method:      http.MethodGet,
+		Path:        agentPath,
+		Summary:     "List agent artifacts",
+		Description: "List the artifact files, such as schemas, example configs and prompt templates, attached to an agent version.",
+		Tags:        []string{"agents"},
+	}, func(ctx context.Context, input *AgentArtifactsInput) (*Response[models.AttachmentListResponse], error) {
+		return listAttachments(ctx, registry, "agent", input.AgentName, input.Version)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-agent-artifact" + suffix,
+		Method:      http.MethodGet,
+		Path:        agentPath + "/{fileName}",
+		Summary:     "Download an agent artifact",
+		Description: "Download an artifact file attached to an agent version.",
+		Tags:        []string{"agents"},
+	}, func(ctx context.Context, input *AgentArtifactInput) (*huma.StreamResponse, error) {
+		return openAttachment(ctx, registry, "agent", input.AgentName, input.Version, input.FileName)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "upload-agent-artifact" + suffix,
+		Method:      http.MethodPut,
+		Path:        agentPath + "/{fileName}",
+		Summary:     "Attach an artifact to an agent version",
+		Description: "Attach an artifact file to an agent version, replacing a file of the same name. The content is kept in blob storage, so BLOB_STORAGE must be configured.",
+		Tags:        []string{"agents"},
+		RequestBody: uploadBody,
+	}, func(ctx context.Context, input *UploadAgentArtifactInput) (*Response[models.Attachment], error) {
+		return uploadAttachment(ctx, registry, "agent", input.AgentName, input.Version, input.FileName, &input.ArtifactUpload)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-agent-artifact" + suffix,
+		Method:        http.MethodDelete,
+		Path:          agentPath + "/{fileName}",
+		Summary:       "Remove an agent artifact",
+		Description:   "Remove an artifact file from an agent version.",
+		Tags:          []string{"agents"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *AgentArtifactInput) (*struct{}, error) {
+		return deleteAttachment(ctx, registry, "agent", input.AgentName, input.Version, input.FileName)
+	})
+}
+
+func listAttachments(ctx context.Context, registry service.RegistryService, artifactType, rawName, rawVersion string) (*Response[models.AttachmentListResponse], error) {
+	name, version, err := resolveAttachmentTarget(ctx, registry, artifactType, rawName, rawVersion)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := registry.ListAttachments(ctx, artifactType, name, version)
+	if err != nil {
+		return nil, attachmentError(err, "Failed to list artifacts")
+	}
+	resp := models.AttachmentListResponse{Artifacts: make([]models.Attachment, 0, len(attachments))}
+	for _, a := range attachments {
+		resp.Artifacts = append(resp.Artifacts, *a)
+	}
+	return &Response[models.AttachmentListResponse]{Body: resp}, nil
+}
+
+func openAttachment(ctx context.Context, registry service.RegistryService, artifactType, rawName, rawVersion, rawFileName string) (*huma.StreamResponse, error) {
+	name, version, err := resolveAttachmentTarget(ctx, registry, artifactType, rawName, rawVersion)
+	if err != nil {
+		return nil, err
+	}
+	fileName, err := url.PathUnescape(rawFileName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid file name encoding", err)
+	}
+	attachment, content, err := registry.OpenAttachment(ctx, artifactType, name, version, fileName)
+	if err != nil {
+		return nil, attachmentError(err, "Failed to get artifact")
+	}
+	return &huma.StreamResponse{
+		Body: func(hctx huma.Context) {
+			defer content.Close()
+			hctx.SetHeader("Content-Type", attachment.MediaType)
+			hctx.SetHeader("Content-Length", strconv.FormatInt(attachment.SizeBytes, 10))
+			hctx.SetHeader("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+			hctx.SetHeader("ETag", `"`+attachment.Digest+`"`)
+			hctx.SetHeader("X-Artifact-Kind", attachment.Kind)
+			if _, err := io.Copy(hctx.BodyWriter(), content); err != nil {
+				log.Printf("Failed to stream artifact %s of %s@%s: %v", attachment.FileName, name, version, err)
+			}
+		},
+	}, nil
+}
+
+func uploadAttachment(ctx context.Context, registry service.RegistryService, artifactType, rawName, rawVersion, rawFileName string, upload *ArtifactUpload) (*Response[models.Attachment], error) {
+	name, version, err := resolveAttachmentTarget(ctx, registry, artifactType, rawName, rawVersion)
+	if err != nil {
+		return nil, err
+	}
+	fileName, err := url.PathUnescape(rawFileName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid file name encoding", err)
+	}
+	// A generic content type says nothing about the file; let the registry derive one from the file extension
+	mediaType := upload.ContentType
+	if mediaType == "application/octet-stream" {
+		mediaType = ""
+	}
+
+	attachment, err := registry.UploadAttachment(ctx, &models.Attachment{
+		ArtifactType: artifactType,
+		ArtifactName: name,
+		Version:      version,
+		FileName:     fileName,
+		Kind:         upload.Kind,
+		MediaType:    mediaType,
+	}, upload.body)
+	if err != nil {
+		return nil, attachmentError(err, "Failed to store artifact")
+	}
+	return &Response[models.Attachment]{Body: *attachment}, nil
+}
+
+func deleteAttachment(ctx context.Context, registry service.RegistryService, artifactType, rawName, rawVersion, rawFileName string) (*struct{}, error) {
+	name, version, err := resolveAttachmentTarget(ctx, registry, artifactType, rawName, rawVersion)
+	if err != nil {
+		return nil, err
+	}
+	fileName, err := url.PathUnescape(rawFileName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid file name encoding", err)
+	}
+	if err := registry.DeleteAttachment(ctx, artifactType, name, version, fileName); err != nil {
+		return nil, attachmentError(err, "Failed to remove artifact")
+	}
+	return nil, nil
+}
+
+// resolveAttachmentTarget decodes the name and version path parameters, resolving the version "latest"
+func resolveAttachmentTarget(ctx context.Context, registry service.RegistryService, artifactType, rawName, rawVersion string) (string, string, error) {
+	name, err := url.PathUnescape(rawName)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid name encoding", err)
+	}
+	version, err := url.PathUnescape(rawVersion)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid version encoding", err)
+	}
+	if version != "latest" {
+		return name, version, nil
+	}
+
+	switch artifactType {
+	case "mcp":
+		server, err := registry.GetServerByName(ctx, name)
+		if err != nil {
+			return "", "", attachmentError(err, "Failed to get server")
+		}
+		return name, server.Server.Version, nil
+	default:
+		agent, err := registry.GetAgentByName(ctx, name)
+		if err != nil {
+			return "", "", attachmentError(err, "Failed to get agent")
+		}
+		return name, agent.Agent.Version, nil
+	}
+}
+
+func attachmentError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound) || errors.Is(err, blobstore.ErrNotFound):
+		return huma.Error404NotFound("Not found")
+	case errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error403Forbidden("Forbidden")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error(), err)
+	}
+	return blobError(err, msg)
+}
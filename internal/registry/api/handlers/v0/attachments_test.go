@@ -0,0 +1,105 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestServerArtifactsEndpoints(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{BlobStorage: t.TempDir(), ArtifactMaxBytes: 1024}
+	registryService := service.NewRegistryService(internaldb.NewTestDB(t), cfg, nil)
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(func(hctx huma.Context, next func(huma.Context)) {
+		next(huma.WithContext(hctx, internaldb.WithTestSession(hctx.Context())))
+	})
+	v0.RegisterAttachmentsEndpoints(api, "/v0", registryService)
+
+	do := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	base := "/v0/servers/" + url.PathEscape("com.example/weather") + "/versions/1.0.0/artifacts"
+	content := `{"city": "Toronto"}`
+
+	w := do(http.MethodPut, base+"/config.example.json?kind=example-config", "application/octet-stream", content)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var attachment models.Attachment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &attachment))
+	assert.Equal(t, "config.example.json", attachment.FileName)
+	assert.Equal(t, models.AttachmentKindExampleConfig, attachment.Kind)
+	assert.Equal(t, "application/json", attachment.MediaType)
+	assert.Equal(t, int64(len(content)), attachment.SizeBytes)
+	assert.True(t, strings.HasPrefix(attachment.Digest, "sha256:"))
+
+	w = do(http.MethodGet, "/v0/servers/"+url.PathEscape("com.example/weather")+"/versions/latest/artifacts", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list models.AttachmentListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Artifacts, 1)
+	assert.Equal(t, attachment.Digest, list.Artifacts[0].Digest)
+
+	w = do(http.MethodGet, base+"/config.example.json", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, content, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=config.example.json`, w.Header().Get("Content-Disposition"))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"unknown version", http.MethodGet, strings.Replace(base, "1.0.0", "9.9.9", 1), "", http.StatusNotFound},
+		{"unknown file", http.MethodGet, base + "/missing.json", "", http.StatusNotFound},
+		{"unknown kind", http.MethodPut, base + "/notes.txt?kind=manual", "notes", http.StatusUnprocessableEntity},
+		{"invalid file name", http.MethodPut, base + "/notes%5Cv1.txt", "notes", http.StatusBadRequest},
+		{"too large", http.MethodPut, base + "/big.bin?kind=binary", strings.Repeat("x", 1025), http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(tt.method, tt.path, "application/octet-stream", tt.body)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	w = do(http.MethodDelete, base+"/config.example.json", "", "")
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	w = do(http.MethodGet, base+"/config.example.json", "", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,93 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ListAuditLogInput represents the input for listing audit log entries
+type ListAuditLogInput struct {
+	Cursor       string `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false" example:"1042"`
+	Limit        int    `query:"limit" json:"limit,omitempty" doc:"Number of items per page" default:"50" minimum:"1" maximum:"500" example:"50"`
+	Actor        string `query:"actor" json:"actor,omitempty" doc:"Filter by actor (JWT subject)" required:"false" example:"user@example.com"`
+	Action       string `query:"action" json:"action,omitempty" doc:"Filter by action" required:"false" enum:"create,update,publish,unpublish,delete,deploy,undeploy,config_change,promote,rollback"`
+	ResourceType string `query:"resourceType" json:"resourceType,omitempty" doc:"Filter by resource type" required:"false" enum:"mcp,agent,skill,role"`
+	ResourceName string `query:"resourceName" json:"resourceName,omitempty" doc:"Filter by resource name" required:"false" example:"io.github.user/weather"`
+	Since        string `query:"since" json:"since,omitempty" doc:"Only return entries at or after this time (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Until        string `query:"until" json:"until,omitempty" doc:"Only return entries before this time (RFC3339 datetime)" required:"false" example:"2025-08-08T13:15:04.280Z"`
+}
+
+// RegisterAuditEndpoints registers the admin-only audit log endpoint
+func RegisterAuditEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-audit-log" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/audit",
+		Summary:     "List audit log entries",
+		Description: "Get a paginated list of mutating registry operations (newest first). Requires registry admin permissions.",
+		Tags:        []string{"audit"},
+	}, func(ctx context.Context, input *ListAuditLogInput) (*Response[models.AuditLogResponse], error) {
+		filter := &models.AuditLogFilter{}
+		if input.Actor != "" {
+			filter.Actor = &input.Actor
+		}
+		if input.Action != "" {
+			filter.Action = &input.Action
+		}
+		if input.ResourceType != "" {
+			filter.ResourceType = &input.ResourceType
+		}
+		if input.ResourceName != "" {
+			filter.ResourceName = &input.ResourceName
+		}
+		if input.Since != "" {
+			since, err := time.Parse(time.RFC3339, input.Since)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+			}
+			filter.Since = &since
+		}
+		if input.Until != "" {
+			until, err := time.Parse(time.RFC3339, input.Until)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid until format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+			}
+			filter.Until = &until
+		}
+
+		entries, nextCursor, err := registry.ListAuditLog(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error403Forbidden("Audit log access requires registry admin permissions")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get audit log", err)
+		}
+
+		entryValues := make([]models.AuditLogEntry, len(entries))
+		for i, entry := range entries {
+			entryValues[i] = *entry
+		}
+
+		return &Response[models.AuditLogResponse]{
+			Body: models.AuditLogResponse{
+				Entries: entryValues,
+				Metadata: models.AuditMetadata{
+					NextCursor: nextCursor,
+					Count:      len(entries),
+				},
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,113 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditLogEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(internaldb.NewTestDB(t), &config.Config{EnableRegistryValidation: false}, nil)
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/audited-server",
+		Description: "Audited server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	require.NoError(t, registryService.PublishServer(ctx, "com.example/audited-server", "1.0.0"))
+
+	newAPI := func(admin bool) *http.ServeMux {
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		if admin {
+			api.UseMiddleware(func(hctx huma.Context, next func(huma.Context)) {
+				next(huma.WithContext(hctx, internaldb.WithTestSession(hctx.Context())))
+			})
+		}
+		v0.RegisterAuditEndpoints(api, "/v0", registryService)
+		return mux
+	}
+
+	t.Run("non-admin is forbidden", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/audit", nil)
+		w := httptest.NewRecorder()
+		newAPI(false).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedActions []string
+	}{
+		{
+			name:            "all entries newest first",
+			query:           "",
+			expectedStatus:  http.StatusOK,
+			expectedActions: []string{models.AuditActionPublish, models.AuditActionCreate},
+		},
+		{
+			name:            "filter by action",
+			query:           "?action=create",
+			expectedStatus:  http.StatusOK,
+			expectedActions: []string{models.AuditActionCreate},
+		},
+		{
+			name:            "filter by resource name",
+			query:           "?resourceType=mcp&resourceName=com.example/other",
+			expectedStatus:  http.StatusOK,
+			expectedActions: []string{},
+		},
+		{
+			name:           "invalid since",
+			query:          "?since=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid cursor",
+			query:          "?cursor=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/audit"+tt.query, nil)
+			w := httptest.NewRecorder()
+			newAPI(true).ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp models.AuditLogResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			actions := make([]string, 0, len(resp.Entries))
+			for _, e := range resp.Entries {
+				assert.Equal(t, "anonymous", e.Actor)
+				assert.Equal(t, "com.example/audited-server", e.ResourceName)
+				actions = append(actions, e.Action)
+			}
+			assert.Equal(t, tt.expectedActions, actions)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// LoginConfig describes the interactive login methods a registry supports. Clients use it to run
+// the matching OAuth device flow before exchanging the result for a Registry JWT.
+type LoginConfig struct {
+	GitHub *GitHubLoginConfig `json:"github,omitempty"`
+	OIDC   *OIDCLoginConfig   `json:"oidc,omitempty"`
+}
+
+// GitHubLoginConfig holds the public GitHub OAuth app settings
+type GitHubLoginConfig struct {
+	ClientID string `json:"clientId" doc:"GitHub OAuth app client ID used for the device flow"`
+}
+
+// OIDCLoginConfig holds the public OIDC provider settings
+type OIDCLoginConfig struct {
+	Issuer   string `json:"issuer" doc:"OIDC issuer URL"`
+	ClientID string `json:"clientId" doc:"OIDC client ID used for the device flow"`
+}
+
+// GetLoginConfig returns the login methods enabled by cfg. Only public client identifiers are exposed.
+func GetLoginConfig(cfg *config.Config) *LoginConfig {
+	lc := &LoginConfig{}
+	if cfg.GithubClientID != "" {
+		lc.GitHub = &GitHubLoginConfig{ClientID: cfg.GithubClientID}
+	}
+	if cfg.OIDCEnabled && cfg.OIDCIssuer != "" && cfg.OIDCClientID != "" {
+		lc.OIDC = &OIDCLoginConfig{Issuer: cfg.OIDCIssuer, ClientID: cfg.OIDCClientID}
+	}
+	return lc
+}
+
+// RegisterLoginConfigEndpoint registers the endpoint advertising the supported login methods
+func RegisterLoginConfigEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-login-config" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/auth/login-config",
+		Summary:     "Get login configuration",
+		Description: "Returns the interactive login methods supported by this registry and their public client settings",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, _ *struct{}) (*v0.Response[LoginConfig], error) {
+		return &v0.Response[LoginConfig]{Body: *GetLoginConfig(cfg)}, nil
+	})
+}
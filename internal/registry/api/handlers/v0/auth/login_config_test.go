@@ -0,0 +1,38 @@
+package auth_test
+
+import (
+	"testing"
+
+	v0auth "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/auth"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLoginConfig(t *testing.T) {
+	t.Run("nothing configured", func(t *testing.T) {
+		lc := v0auth.GetLoginConfig(&config.Config{})
+		assert.Nil(t, lc.GitHub)
+		assert.Nil(t, lc.OIDC)
+	})
+
+	t.Run("github and oidc", func(t *testing.T) {
+		lc := v0auth.GetLoginConfig(&config.Config{
+			GithubClientID:     "gh-client",
+			GithubClientSecret: "secret",
+			OIDCEnabled:        true,
+			OIDCIssuer:         "https://issuer.example.com",
+			OIDCClientID:       "oidc-client",
+		})
+		require.NotNil(t, lc.GitHub)
+		assert.Equal(t, "gh-client", lc.GitHub.ClientID)
+		require.NotNil(t, lc.OIDC)
+		assert.Equal(t, "https://issuer.example.com", lc.OIDC.Issuer)
+		assert.Equal(t, "oidc-client", lc.OIDC.ClientID)
+	})
+
+	t.Run("oidc disabled", func(t *testing.T) {
+		lc := v0auth.GetLoginConfig(&config.Config{OIDCIssuer: "https://issuer.example.com", OIDCClientID: "oidc-client"})
+		assert.Nil(t, lc.OIDC)
+	})
+}
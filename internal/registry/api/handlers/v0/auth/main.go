@@ -24,4 +24,7 @@ func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config)
 
 	// Register anonymous authentication endpoint
 	RegisterNoneEndpoint(api, pathPrefix, cfg)
+
+	// Register login method discovery endpoint used by `arctl login`
+	RegisterLoginConfigEndpoint(api, pathPrefix, cfg)
 }
@@ -0,0 +1,151 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// BatchCreateServersInput represents the input for creating many servers at once
+type BatchCreateServersInput struct {
+	Publish bool               `query:"publish" json:"publish,omitempty" doc:"Also publish each server that is created" required:"false"`
+	Body    []apiv0.ServerJSON `body:""`
+}
+
+// BatchCreateAgentsInput represents the input for creating many agents at once
+type BatchCreateAgentsInput struct {
+	Publish bool               `query:"publish" json:"publish,omitempty" doc:"Also publish each agent that is created" required:"false"`
+	Body    []models.AgentJSON `body:""`
+}
+
+// BatchCreateSkillsInput represents the input for creating many skills at once
+type BatchCreateSkillsInput struct {
+	Publish bool               `query:"publish" json:"publish,omitempty" doc:"Also publish each skill that is created" required:"false"`
+	Body    []models.SkillJSON `body:""`
+}
+
+// RegisterBatchCreateEndpoints registers the batch create endpoints for servers, agents and skills.
+// Each item is created in its own transaction, so the response reports a result per item.
+func RegisterBatchCreateEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config, agentsAndSkills bool) {
+	huma.Register(api, huma.Operation{
+		OperationID:  "batch-create-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/publish/batch",
+		Summary:      "Create MCP servers in bulk",
+		Description:  fmt.Sprintf("Create up to %d MCP server versions in one request. Each server is validated and created independently; the response reports a result per server. With publish=true, each server is also published.", service.MaxBatchSize),
+		Tags:         []string{"servers", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
+		Security:     []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *BatchCreateServersInput) (*Response[models.BatchPublishResponse], error) {
+		if err := checkBatchInput(len(input.Body)); err != nil {
+			return nil, err
+		}
+		servers := make([]*apiv0.ServerJSON, len(input.Body))
+		names := make([][2]string, len(input.Body))
+		for i := range input.Body {
+			servers[i] = &input.Body[i]
+			names[i] = [2]string{input.Body[i].Name, input.Body[i].Version}
+		}
+		errs := registry.CreateServersBatch(ctx, servers, input.Publish)
+		return &Response[models.BatchPublishResponse]{Body: batchResponse(names, errs, input.Publish)}, nil
+	})
+
+	if !agentsAndSkills {
+		return
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID:  "batch-create-agents" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/agents/publish/batch",
+		Summary:      "Create agents in bulk",
+		Description:  fmt.Sprintf("Create up to %d agent versions in one request. Each agent is validated and created independently; the response reports a result per agent. With publish=true, each agent is also published.", service.MaxBatchSize),
+		Tags:         []string{"agents", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
+		Security:     []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *BatchCreateAgentsInput) (*Response[models.BatchPublishResponse], error) {
+		if err := checkBatchInput(len(input.Body)); err != nil {
+			return nil, err
+		}
+		agents := make([]*models.AgentJSON, len(input.Body))
+		names := make([][2]string, len(input.Body))
+		for i := range input.Body {
+			agents[i] = &input.Body[i]
+			names[i] = [2]string{input.Body[i].Name, input.Body[i].Version}
+		}
+		errs := registry.CreateAgentsBatch(ctx, agents, input.Publish)
+		return &Response[models.BatchPublishResponse]{Body: batchResponse(names, errs, input.Publish)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:  "batch-create-skills" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/skills/publish/batch",
+		Summary:      "Create skills in bulk",
+		Description:  fmt.Sprintf("Create up to %d skill versions in one request. Each skill is validated and created independently; the response reports a result per skill. With publish=true, each skill is also published.", service.MaxBatchSize),
+		Tags:         []string{"skills", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
+		Security:     []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *BatchCreateSkillsInput) (*Response[models.BatchPublishResponse], error) {
+		if err := checkBatchInput(len(input.Body)); err != nil {
+			return nil, err
+		}
+		skills := make([]*models.SkillJSON, len(input.Body))
+		names := make([][2]string, len(input.Body))
+		for i := range input.Body {
+			skills[i] = &input.Body[i]
+			names[i] = [2]string{input.Body[i].Name, input.Body[i].Version}
+		}
+		errs := registry.CreateSkillsBatch(ctx, skills, input.Publish)
+		return &Response[models.BatchPublishResponse]{Body: batchResponse(names, errs, input.Publish)}, nil
+	})
+}
+
+func checkBatchInput(n int) error {
+	if n == 0 {
+		return huma.Error400BadRequest("Batch is empty")
+	}
+	if n > service.MaxBatchSize {
+		return huma.Error400BadRequest(fmt.Sprintf("Batch has %d items, the maximum is %d", n, service.MaxBatchSize))
+	}
+	return nil
+}
+
+// batchResponse pairs the name and version of each item with its error
+func batchResponse(names [][2]string, errs []error, publish bool) models.BatchPublishResponse {
+	success := models.BatchItemCreated
+	if publish {
+		success = models.BatchItemPublished
+	}
+	resp := models.BatchPublishResponse{Results: make([]models.BatchPublishResult, len(names))}
+	for i, n := range names {
+		result := models.BatchPublishResult{Index: i, Name: n[0], Version: n[1], Status: success}
+		var err error
+		if i < len(errs) {
+			err = errs[i]
+		}
+		switch {
+		case err == nil:
+			resp.Succeeded++
+		case errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated):
+			// Match the single-item endpoints, which do not reveal why access was denied
+			result.Status, result.Error = models.BatchItemFailed, "not found"
+			resp.Failed++
+		default:
+			result.Status, result.Error = models.BatchItemFailed, err.Error()
+			resp.Failed++
+		}
+		resp.Results[i] = result
+	}
+	return resp
+}
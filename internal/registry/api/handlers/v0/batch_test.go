@@ -0,0 +1,96 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCreateServersEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false, // Disable for unit tests
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig, nil)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterBatchCreateEndpoints(api, "/v0", registryService, testConfig, true)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	post := func(body any) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish/batch?publish=true", bytes.NewReader(data))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("reports a result per server", func(t *testing.T) {
+		rr := post([]apiv0.ServerJSON{
+			{Schema: model.CurrentSchemaURL, Name: "com.example/batch-one", Description: "First", Version: "1.0.0"},
+			{Schema: model.CurrentSchemaURL, Name: "invalid-name", Description: "Invalid", Version: "1.0.0"},
+			{Schema: model.CurrentSchemaURL, Name: "com.example/batch-two", Description: "Second", Version: "1.0.0"},
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp models.BatchPublishResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Results, 3)
+		assert.Equal(t, 2, resp.Succeeded)
+		assert.Equal(t, 1, resp.Failed)
+		assert.Equal(t, models.BatchItemPublished, resp.Results[0].Status)
+		assert.Equal(t, models.BatchItemFailed, resp.Results[1].Status)
+		assert.NotEmpty(t, resp.Results[1].Error)
+		assert.Equal(t, models.BatchItemPublished, resp.Results[2].Status)
+
+		// The failed item does not roll back the others
+		server, err := registryService.GetServerByNameAndVersion(context.Background(), "com.example/batch-two", "1.0.0", true)
+		require.NoError(t, err)
+		assert.Equal(t, "com.example/batch-two", server.Server.Name)
+	})
+
+	t.Run("rejects an empty batch", func(t *testing.T) {
+		rr := post([]apiv0.ServerJSON{})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("rejects an oversized batch", func(t *testing.T) {
+		servers := make([]apiv0.ServerJSON, service.MaxBatchSize+1)
+		rr := post(servers)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
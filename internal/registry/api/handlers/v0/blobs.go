@@ -0,0 +1,98 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/blobstore"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// UploadBlobInput streams the request body into blob storage instead of buffering it like a RawBody field would
+type UploadBlobInput struct {
+	body io.Reader
+}
+
+// Resolve captures the request body reader
+func (i *UploadBlobInput) Resolve(ctx huma.Context) []error {
+	i.body = ctx.BodyReader()
+	return nil
+}
+
+// BlobInput represents the path parameters of a blob
+type BlobInput struct {
+	Digest string `path:"digest" json:"digest" doc:"Blob digest" example:"sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"`
+}
+
+// RegisterBlobsEndpoints registers the endpoints that stream content into and out of blob storage
+func RegisterBlobsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"blobs"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "upload-blob" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/blobs",
+		Summary:     "Upload a blob",
+		Description: "Stream content into the registry's blob storage and get back its SHA-256 digest. Uploading the same content again returns the same digest. Requires BLOB_STORAGE to be configured.",
+		Tags:        tags,
+		RequestBody: &huma.RequestBody{
+			Description: "Blob content",
+			Required:    true,
+			Content: map[string]*huma.MediaType{
+				"application/octet-stream": {},
+			},
+		},
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *UploadBlobInput) (*Response[models.Blob], error) {
+		blob, err := registry.PutBlob(ctx, input.body)
+		if err != nil {
+			return nil, blobError(err, "Failed to store blob")
+		}
+		return &Response[models.Blob]{Body: *blob}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-blob" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/blobs/{digest}",
+		Summary:     "Download a blob",
+		Description: "Stream the content of a blob by its digest. Blobs never change, so responses can be cached indefinitely.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *BlobInput) (*huma.StreamResponse, error) {
+		content, err := registry.OpenBlob(ctx, input.Digest)
+		if err != nil {
+			return nil, blobError(err, "Failed to read blob")
+		}
+		return &huma.StreamResponse{
+			Body: func(hctx huma.Context) {
+				defer content.Close()
+				hctx.SetHeader("Content-Type", "application/octet-stream")
+				hctx.SetHeader("ETag", `"`+input.Digest+`"`)
+				hctx.SetHeader("Cache-Control", "public, max-age=31536000, immutable")
+				if _, err := io.Copy(hctx.BodyWriter(), content); err != nil {
+					log.Printf("Failed to stream blob %s: %v", input.Digest, err)
+				}
+			},
+		}, nil
+	})
+}
+
+func blobError(err error, msg string) error {
+	switch {
+	case errors.Is(err, blobstore.ErrNotConfigured):
+		return huma.Error503ServiceUnavailable("Blob storage is not configured on this registry")
+	case errors.Is(err, blobstore.ErrNotFound):
+		return huma.Error404NotFound("Blob not found")
+	case errors.Is(err, blobstore.ErrInvalidDigest):
+		return huma.Error400BadRequest(err.Error(), err)
+	case errors.Is(err, blobstore.ErrTooLarge):
+		return huma.NewError(http.StatusRequestEntityTooLarge, err.Error())
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
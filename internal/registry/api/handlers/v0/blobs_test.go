@@ -0,0 +1,74 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestBlobsEndpoints(t *testing.T) {
+	newMux := func(cfg *config.Config) *http.ServeMux {
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		v0.RegisterBlobsEndpoints(api, "/v0", service.NewRegistryService(internaldb.NewTestDB(t), cfg, nil))
+		return mux
+	}
+	mux := newMux(&config.Config{BlobStorage: t.TempDir(), BlobMaxUploadBytes: 1024})
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/blobs", strings.NewReader(`{"$schema": "http://json-schema.org/draft-07/schema#"}`))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var blob models.Blob
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &blob))
+	assert.True(t, strings.HasPrefix(blob.Digest, "sha256:"))
+	assert.Equal(t, int64(54), blob.Size)
+
+	req = httptest.NewRequest(http.MethodGet, "/v0/blobs/"+blob.Digest, nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"$schema": "http://json-schema.org/draft-07/schema#"}`, w.Body.String())
+	assert.Equal(t, `"`+blob.Digest+`"`, w.Header().Get("ETag"))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"unknown digest", http.MethodGet, "/v0/blobs/sha256:" + strings.Repeat("0", 64), "", http.StatusNotFound},
+		{"invalid digest", http.MethodGet, "/v0/blobs/md5:abc", "", http.StatusBadRequest},
+		{"too large", http.MethodPost, "/v0/blobs", strings.Repeat("x", 1025), http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	// Without BLOB_STORAGE the endpoints are unavailable
+	req = httptest.NewRequest(http.MethodPost, "/v0/blobs", strings.NewReader("content"))
+	w = httptest.NewRecorder()
+	newMux(&config.Config{}).ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
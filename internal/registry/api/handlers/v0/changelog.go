@@ -0,0 +1,47 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ServerChangelogInput identifies the server whose changelog is read
+type ServerChangelogInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// RegisterServerChangelogEndpoints registers the endpoint listing the release notes and server.json changes of every
+// version of a server. Public routes only include published versions.
+func RegisterServerChangelogEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, isAdmin bool) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-changelog" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/changelog",
+		Summary:     "Get server changelog",
+		Description: "List the versions of a server, newest first, with the release notes their publishers provided under the aregistry.ai/changelog publisher-provided metadata key and the fields of server.json that changed since the previous version.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerChangelogInput) (*Response[models.ServerChangelog], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		changelog, err := registry.GetServerChangelog(ctx, serverName, !isAdmin)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server changelog", err)
+		}
+		return &Response[models.ServerChangelog]{Body: *changelog}, nil
+	})
+}
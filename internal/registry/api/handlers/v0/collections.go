@@ -0,0 +1,206 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// CollectionDetailInput identifies a collection
+type CollectionDetailInput struct {
+	CollectionName string `path:"collectionName" json:"collectionName" doc:"URL-encoded collection name" example:"com.example%2Fdata-engineering"`
+}
+
+// CollectionVersionDetailInput identifies a collection version
+type CollectionVersionDetailInput struct {
+	CollectionName string `path:"collectionName" json:"collectionName" doc:"URL-encoded collection name" example:"com.example%2Fdata-engineering"`
+	Version        string `path:"version" json:"version" doc:"URL-encoded collection version, or 'latest'" example:"1.0.0"`
+}
+
+// CreateCollectionInput is the body of a request creating a collection version
+type CreateCollectionInput struct {
+	Body models.CollectionJSON
+}
+
+// RegisterCollectionsEndpoints registers the collection endpoints. Public routes only show published collections;
+// admin routes show all of them and can also publish, unpublish and delete collection versions.
+func RegisterCollectionsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, isAdmin bool) {
+	tags := []string{"collections"}
+	if isAdmin {
+		tags = append(tags, "admin")
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-collections" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/collections",
+		Summary:     "List collections",
+		Description: "List the latest version of each collection of servers, agents and skills",
+		Tags:        tags,
+	}, func(ctx context.Context, _ *struct{}) (*Response[models.CollectionListResponse], error) {
+		collections, err := registry.ListCollections(ctx, !isAdmin)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list collections", err)
+		}
+		values := make([]models.CollectionResponse, len(collections))
+		for i, c := range collections {
+			values[i] = *c
+		}
+		return &Response[models.CollectionListResponse]{Body: models.CollectionListResponse{Collections: values}}, nil
+	})
+
+	getCollection := func(ctx context.Context, rawName, rawVersion string) (*Response[models.CollectionResponse], error) {
+		name, err := url.PathUnescape(rawName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid collection name encoding", err)
+		}
+		version, err := url.PathUnescape(rawVersion)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+		collection, err := registry.GetCollection(ctx, name, version)
+		if err != nil {
+			return nil, collectionError(err, "Failed to get collection")
+		}
+		if !isAdmin && !collection.Meta.Official.Published {
+			return nil, huma.Error404NotFound("Collection not found")
+		}
+		return &Response[models.CollectionResponse]{Body: *collection}, nil
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-collection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/collections/{collectionName}",
+		Summary:     "Get collection",
+		Description: "Get the latest version of a collection",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CollectionDetailInput) (*Response[models.CollectionResponse], error) {
+		return getCollection(ctx, input.CollectionName, "")
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-collection-version" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/collections/{collectionName}/versions/{version}",
+		Summary:     "Get collection version",
+		Description: "Get a specific version of a collection. Use the special version 'latest' to get the latest version.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CollectionVersionDetailInput) (*Response[models.CollectionResponse], error) {
+		return getCollection(ctx, input.CollectionName, input.Version)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-collection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/collections",
+		Summary:     "Create collection",
+		Description: "Create a new version of a collection. Every server, agent and skill it lists must exist in the registry. Collections are created as unpublished (published=false).",
+		Tags:        tags,
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *CreateCollectionInput) (*Response[models.CollectionResponse], error) {
+		created, err := registry.CreateCollection(ctx, &input.Body)
+		if err != nil {
+			if problem := validationProblem(err); problem != nil {
+				return nil, problem
+			}
+			return nil, collectionError(err, "Failed to create collection")
+		}
+		return &Response[models.CollectionResponse]{Body: *created}, nil
+	})
+
+	if !isAdmin {
+		return
+	}
+
+	setPublished := func(ctx context.Context, input *CollectionVersionDetailInput, publish bool) (*Response[EmptyResponse], error) {
+		name, err := url.PathUnescape(input.CollectionName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid collection name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+		if publish {
+			err = registry.PublishCollection(ctx, name, version)
+		} else {
+			err = registry.UnpublishCollection(ctx, name, version)
+		}
+		if err != nil {
+			return nil, collectionError(err, "Failed to update collection")
+		}
+		message := "Collection unpublished successfully"
+		if publish {
+			message = "Collection published successfully"
+		}
+		return &Response[EmptyResponse]{Body: EmptyResponse{Message: message}}, nil
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "publish-collection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/collections/{collectionName}/versions/{version}/publish",
+		Summary:     "Publish collection",
+		Description: "Mark a collection version as published, making it visible in public listings",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CollectionVersionDetailInput) (*Response[EmptyResponse], error) {
+		return setPublished(ctx, input, true)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "unpublish-collection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/collections/{collectionName}/versions/{version}/unpublish",
+		Summary:     "Unpublish collection",
+		Description: "Mark a collection version as unpublished, hiding it from public listings",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CollectionVersionDetailInput) (*Response[EmptyResponse], error) {
+		return setPublished(ctx, input, false)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-collection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/collections/{collectionName}/versions/{version}",
+		Summary:     "Delete collection version",
+		Description: "Delete a collection version. The servers, agents and skills it lists are not affected.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CollectionVersionDetailInput) (*Response[EmptyResponse], error) {
+		name, err := url.PathUnescape(input.CollectionName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid collection name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+		if err := registry.DeleteCollection(ctx, name, version); err != nil {
+			return nil, collectionError(err, "Failed to delete collection")
+		}
+		return &Response[EmptyResponse]{Body: EmptyResponse{Message: "Collection deleted successfully"}}, nil
+	})
+}
+
+func collectionError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound), errors.Is(err, auth.ErrForbidden), errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error404NotFound("Collection not found")
+	case errors.Is(err, namespace.ErrNotOwned):
+		return huma.Error403Forbidden(err.Error())
+	case errors.Is(err, database.ErrInvalidVersion):
+		return huma.Error409Conflict("Collection version already exists")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
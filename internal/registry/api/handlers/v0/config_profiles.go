@@ -0,0 +1,141 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ConfigProfilesInput represents the path parameters for the config profiles of a server
+type ConfigProfilesInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+}
+
+// ConfigProfileInput represents the path parameters for a single config profile
+type ConfigProfileInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+	Profile    string `path:"profile" json:"profile" doc:"Profile name" example:"prod"`
+}
+
+// PutConfigProfileInput represents the input for creating or replacing a config profile
+type PutConfigProfileInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+	Profile    string `path:"profile" json:"profile" doc:"Profile name: lowercase letters, digits, '.', '_' or '-'" example:"prod"`
+	Body       struct {
+		Description string            `json:"description,omitempty" doc:"What the profile is for" required:"false"`
+		Extends     string            `json:"extends,omitempty" doc:"Profile of the same server whose keys this profile inherits; keys set in config override them" required:"false" example:"base"`
+		Config      map[string]string `json:"config" doc:"Configuration key-value pairs in the form deployments take: env vars, ARG_ prefixed args and HEADER_ prefixed headers. Values may be secret references."`
+	}
+}
+
+// RegisterConfigProfilesEndpoints registers the endpoints managing the named config profiles of servers
+func RegisterConfigProfilesEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"servers", "deployments"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-config-profiles" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/profiles",
+		Summary:     "List server config profiles",
+		Description: "List the named config profiles of an MCP server. Requires permission to deploy the server.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ConfigProfilesInput) (*Response[models.ConfigProfileListResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		profiles, err := registry.ListConfigProfiles(ctx, serverName)
+		if err != nil {
+			return nil, configProfileError(err, "Failed to list config profiles")
+		}
+		return &Response[models.ConfigProfileListResponse]{
+			Body: models.ConfigProfileListResponse{Profiles: profiles},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-config-profile" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/profiles/{profile}",
+		Summary:     "Get a server config profile",
+		Description: "Get a config profile of an MCP server. resolvedConfig holds its config merged over the profiles it extends, which is what deployments using the profile start from. Requires permission to deploy the server.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ConfigProfileInput) (*Response[models.ConfigProfile], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		profile, err := registry.GetConfigProfile(ctx, serverName, input.Profile)
+		if err != nil {
+			return nil, configProfileError(err, "Failed to get config profile")
+		}
+		return &Response[models.ConfigProfile]{Body: *profile}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "put-config-profile" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/profiles/{profile}",
+		Summary:     "Create or replace a server config profile",
+		Description: "Create or replace a named config profile of an MCP server, such as dev or prod. A profile can extend another profile of the server and override individual keys. Requires permission to deploy the server.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *PutConfigProfileInput) (*Response[models.ConfigProfile], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		profile, err := registry.PutConfigProfile(ctx, &models.ConfigProfile{
+			ServerName:  serverName,
+			Name:        input.Profile,
+			Description: input.Body.Description,
+			Extends:     input.Body.Extends,
+			Config:      input.Body.Config,
+		})
+		if err != nil {
+			return nil, configProfileError(err, "Failed to save config profile")
+		}
+		return &Response[models.ConfigProfile]{Body: *profile}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-config-profile" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/servers/{serverName}/profiles/{profile}",
+		Summary:     "Delete a server config profile",
+		Description: "Delete a config profile of an MCP server. Profiles other profiles extend cannot be deleted. Requires permission to deploy the server.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ConfigProfileInput) (*Response[EmptyResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		if err := registry.DeleteConfigProfile(ctx, serverName, input.Profile); err != nil {
+			return nil, configProfileError(err, "Failed to delete config profile")
+		}
+		return &Response[EmptyResponse]{
+			Body: EmptyResponse{Message: "Config profile deleted successfully"},
+		}, nil
+	})
+}
+
+func configProfileError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Server or config profile not found")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error(), err)
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Config profiles require permission to deploy the server")
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
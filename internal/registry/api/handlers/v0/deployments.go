@@ -3,11 +3,16 @@ package v0
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
+	"strconv"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/policy"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	regtranslator "github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
@@ -16,12 +21,34 @@ import (
 
 // DeploymentRequest represents the input for deploying a server
 type DeploymentRequest struct {
-	ServerName   string            `json:"serverName" doc:"Server name to deploy" example:"io.github.user/weather"`
-	Version      string            `json:"version" doc:"Version to deploy (use 'latest' for latest version)" default:"latest" example:"1.0.0"`
-	Config       map[string]string `json:"config,omitempty" doc:"Configuration key-value pairs (env vars, args, headers)"`
-	PreferRemote bool              `json:"preferRemote,omitempty" doc:"Prefer remote deployment over local" default:"false"`
-	ResourceType string            `json:"resourceType,omitempty" doc:"Type of resource to deploy (mcp, agent)" default:"mcp" example:"mcp" enum:"mcp,agent"`
-	Runtime      string            `json:"runtime,omitempty" doc:"Runtime target (local, kubernetes)" default:"local" example:"local" enum:"local,kubernetes"`
+	ServerName     string            `json:"serverName" doc:"Server name to deploy" example:"io.github.user/weather"`
+	Version        string            `json:"version" doc:"Version to deploy (use 'latest' for latest version)" default:"latest" example:"1.0.0"`
+	Config         map[string]string `json:"config,omitempty" doc:"Configuration key-value pairs (env vars, args, headers). Values may reference external secrets as secretRef://vault/<path>#<key>, secretRef://aws/<secret-id>[#<key>] or env://<NAME>; only the reference is stored and the runtime resolves it on reconcile."`
+	PreferRemote   bool              `json:"preferRemote,omitempty" doc:"Prefer remote deployment over local" default:"false"`
+	ResourceType   string            `json:"resourceType,omitempty" doc:"Type of resource to deploy (mcp, agent)" default:"mcp" example:"mcp" enum:"mcp,agent"`
+	Runtime        string            `json:"runtime,omitempty" doc:"Runtime target (local, kubernetes). Defaults to local, or to the runtime of target when set." example:"local" enum:"local,kubernetes"`
+	Target         string            `json:"target,omitempty" doc:"Named deployment target configured on the registry server (see /deployments/targets). Takes precedence over runtime, which must match the target's runtime when both are set." example:"edge-docker"`
+	Origin         string            `json:"origin,omitempty" doc:"Base URL of the registry to resolve the server manifest from (MCP servers only). Defaults to this registry." example:"https://registry.example.com"`
+	CanaryWeight   int               `json:"canaryWeight,omitempty" doc:"Roll this version of an already deployed MCP server out as a canary that gets this percentage of its traffic at the agent gateway. The canary inherits the target of the current deployment; finish the rollout with POST /deployments/{serverName}/promote." minimum:"0" maximum:"99" example:"10"`
+	Replicas       int               `json:"replicas,omitempty" doc:"Number of instances to run. Stored in the deployment config as RESOURCE_REPLICAS; the agent gateway balances requests across local replicas." minimum:"0" maximum:"50" example:"3"`
+	RestrictEgress bool              `json:"restrictEgress,omitempty" doc:"Limit the outbound connections of an MCP server run from a package to the hosts of its remotes and package registry. Stored in the deployment config as RESOURCE_RESTRICT_EGRESS." default:"false"`
+}
+
+// DeploymentConfig returns the deployment config of the request with the replica count and egress restriction
+// folded in
+func (r *DeploymentRequest) DeploymentConfig() map[string]string {
+	if r.Replicas == 0 && !r.RestrictEgress {
+		return r.Config
+	}
+	config := make(map[string]string, len(r.Config)+2)
+	maps.Copy(config, r.Config)
+	if r.Replicas != 0 {
+		config[regtranslator.ResourceConfigPrefix+regtranslator.ResourceReplicas] = strconv.Itoa(r.Replicas)
+	}
+	if r.RestrictEgress {
+		config[regtranslator.ResourceConfigPrefix+regtranslator.ResourceRestrictEgress] = "true"
+	}
+	return config
 }
 
 // DeploymentConfigUpdate represents the input for updating deployment configuration
@@ -29,6 +56,11 @@ type DeploymentConfigUpdate struct {
 	Config map[string]string `json:"config" doc:"Configuration key-value pairs to set"`
 }
 
+// DeploymentOriginUpdate represents the input for switching the origin registry of a deployment
+type DeploymentOriginUpdate struct {
+	Origin string `json:"origin" doc:"Base URL of the registry to resolve the server manifest from. Empty pins the deployment to this registry." example:"https://registry.example.com"`
+}
+
 // DeploymentResponse represents a deployment
 type DeploymentResponse struct {
 	Body models.Deployment
@@ -41,6 +73,13 @@ type DeploymentsListResponse struct {
 	}
 }
 
+// DeploymentHealthResponse represents the runtime state of all deployments
+type DeploymentHealthResponse struct {
+	Body struct {
+		Deployments []models.DeploymentHealth `json:"deployments" doc:"Runtime state of each deployment"`
+	}
+}
+
 // DeploymentInput represents path parameters for deployment operations
 type DeploymentInput struct {
 	ServerName   string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
@@ -54,6 +93,66 @@ type DeploymentsListInput struct {
 	Runtime      string `query:"runtime" json:"runtime,omitempty" doc:"Filter by runtime (local, kubernetes)" example:"local" enum:"local,kubernetes"`
 }
 
+// DeploymentTargetsResponse represents the deployment targets of the registry
+type DeploymentTargetsResponse struct {
+	Body struct {
+		Targets []runtime.Target `json:"targets" doc:"Named targets deployments can land on"`
+	}
+}
+
+// DeploymentRevisionsResponse represents the revision history of a deployment
+type DeploymentRevisionsResponse struct {
+	Body struct {
+		Revisions []*models.DeploymentRevision `json:"revisions" doc:"Revisions of the deployment, newest first"`
+	}
+}
+
+// DeploymentRollbackRequest represents the input for rolling a deployment back to an earlier revision
+type DeploymentRollbackRequest struct {
+	ResourceType string `json:"resourceType,omitempty" doc:"Type of resource to roll back (mcp, agent)" default:"mcp" enum:"mcp,agent"`
+	Revision     int    `json:"revision,omitempty" doc:"Revision to roll back to; defaults to the revision before the latest one" minimum:"0" example:"3"`
+}
+
+// DeploymentDiffResponse represents the drift of a deployment from its desired state
+type DeploymentDiffResponse struct {
+	Body models.DeploymentDiff
+}
+
+// RuntimeExportResponse represents the current deployments rendered as a manifest bundle
+type RuntimeExportResponse struct {
+	Body models.RuntimeExport
+}
+
+// DeploymentRuntimeTarget returns the runtime or named target a deploy request lands on, as accepted by
+// DeployServer and DeployAgent. Errors wrap database.ErrInvalidInput.
+func DeploymentRuntimeTarget(ctx context.Context, registry service.RegistryService, req *DeploymentRequest) (string, error) {
+	if req.Target == "" {
+		runtimeTarget := req.Runtime
+		if runtimeTarget == "" {
+			runtimeTarget = "local"
+		}
+		if err := runtime.ValidateRuntime(runtimeTarget); err != nil {
+			return "", fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+		}
+		return runtimeTarget, nil
+	}
+
+	targets, err := registry.ListDeploymentTargets(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range targets {
+		if t.Name != req.Target {
+			continue
+		}
+		if req.Runtime != "" && req.Runtime != t.Runtime() {
+			return "", fmt.Errorf("%w: target %s deploys to the %s runtime, not %s", database.ErrInvalidInput, t.Name, t.Runtime(), req.Runtime)
+		}
+		return t.Name, nil
+	}
+	return "", fmt.Errorf("%w: unknown deployment target %q", database.ErrInvalidInput, req.Target)
+}
+
 // RegisterDeploymentsEndpoints registers all deployment-related endpoints
 func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry service.RegistryService) {
 	// List all deployments
@@ -74,6 +173,9 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 			r := input.Runtime
 			filter.Runtime = &r
 		}
+		if workspace := auth.WorkspaceFrom(ctx); workspace != "" {
+			filter.Workspace = &workspace
+		}
 
 		deployments, err := registry.GetDeployments(ctx, filter)
 		if err != nil {
@@ -92,6 +194,74 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 		return resp, nil
 	})
 
+	// Get the runtime health of all deployments
+	huma.Register(api, huma.Operation{
+		OperationID: "get-deployment-health",
+		Method:      http.MethodGet,
+		Path:        basePath + "/deployments/health",
+		Summary:     "Get deployment health",
+		Description: "Retrieve the container state and healthcheck result of every deployment, and how often it was restarted automatically",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct{}) (*DeploymentHealthResponse, error) {
+		health, err := registry.GetDeploymentHealth(ctx)
+		if err != nil {
+			if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to retrieve deployment health", err)
+		}
+
+		resp := &DeploymentHealthResponse{}
+		resp.Body.Deployments = health
+		if resp.Body.Deployments == nil {
+			resp.Body.Deployments = []models.DeploymentHealth{}
+		}
+		return resp, nil
+	})
+
+	// List the deployment targets
+	huma.Register(api, huma.Operation{
+		OperationID: "list-deployment-targets",
+		Method:      http.MethodGet,
+		Path:        basePath + "/deployments/targets",
+		Summary:     "List deployment targets",
+		Description: "List the named targets deployments can be sent to: the built-in local docker host and kubernetes context, and those configured in DEPLOYMENT_TARGETS_FILE",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct{}) (*DeploymentTargetsResponse, error) {
+		targets, err := registry.ListDeploymentTargets(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list deployment targets", err)
+		}
+		resp := &DeploymentTargetsResponse{}
+		resp.Body.Targets = targets
+		return resp, nil
+	})
+
+	// Export the deployments as a manifest bundle
+	huma.Register(api, huma.Operation{
+		OperationID: "export-deployments",
+		Method:      http.MethodGet,
+		Path:        basePath + "/deployments/export",
+		Summary:     "Export deployments",
+		Description: "Render all current deployments as a bundle of the files they are applied from, one directory per target: compose files and agent gateway config for docker targets, or kubernetes manifests with a kustomization or a Helm chart for kubernetes targets. Secret references are exported unresolved.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct {
+		Format string `query:"format" json:"format" doc:"Bundle format" default:"compose" enum:"compose,kustomize,helm"`
+	}) (*RuntimeExportResponse, error) {
+		export, err := registry.ExportRuntime(ctx, input.Format)
+		if err != nil {
+			if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to export deployments", err)
+		}
+
+		return &RuntimeExportResponse{Body: *export}, nil
+	})
+
 	// Get a specific deployment
 	huma.Register(api, huma.Operation{
 		OperationID: "get-deployment",
@@ -124,6 +294,41 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 		return &DeploymentResponse{Body: *deployment}, nil
 	})
 
+	// Compare a deployment with what runs on its target
+	huma.Register(api, huma.Operation{
+		OperationID: "diff-deployment",
+		Method:      http.MethodGet,
+		Path:        basePath + "/deployments/{serverName}/versions/{version}/diff",
+		Summary:     "Detect deployment drift",
+		Description: "Compare the desired state of a deployment, rendered from its registry record and stored configuration, with what actually runs on its target (the rendered compose file and containers, or the kubernetes objects) and report the differences. Values of environment variables and headers are redacted.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct {
+		DeploymentInput
+	}) (*DeploymentDiffResponse, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		diff, err := registry.DiffDeployment(ctx, serverName, version, input.ResourceType)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Deployment not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to compare deployment with its runtime", err)
+		}
+
+		return &DeploymentDiffResponse{Body: *diff}, nil
+	})
+
 	// Deploy a server
 	huma.Register(api, huma.Operation{
 		OperationID: "deploy-server",
@@ -146,32 +351,49 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 			return nil, huma.Error400BadRequest("Invalid resource type. Must be 'mcp' or 'agent'")
 		}
 
-		runtimeTarget := input.Body.Runtime
-		if runtimeTarget == "" {
-			runtimeTarget = "local"
+		runtimeTarget, err := DeploymentRuntimeTarget(ctx, registry, &input.Body)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid runtime target", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to resolve deployment target", err)
 		}
-		if err := runtime.ValidateRuntime(runtimeTarget); err != nil {
-			return nil, huma.Error400BadRequest("Invalid runtime target", err)
+
+		if input.Body.Origin != "" && resourceType != "mcp" {
+			return nil, huma.Error400BadRequest("Origin is only supported for MCP server deployments")
+		}
+		if input.Body.CanaryWeight > 0 && (resourceType != "mcp" || input.Body.Origin != "" || input.Body.Target != "") {
+			return nil, huma.Error400BadRequest("Canaries are only supported for MCP servers and inherit the origin and target of the current deployment")
 		}
 
 		var deployment *models.Deployment
-		var err error
 
 		// Route to appropriate service method based on resource type
-		switch resourceType {
-		case "mcp":
-			deployment, err = registry.DeployServer(ctx, input.Body.ServerName, input.Body.Version, input.Body.Config, input.Body.PreferRemote, runtimeTarget)
-		case "agent":
-			deployment, err = registry.DeployAgent(ctx, input.Body.ServerName, input.Body.Version, input.Body.Config, input.Body.PreferRemote, runtimeTarget)
+		switch {
+		case input.Body.CanaryWeight > 0:
+			deployment, err = registry.DeployServerCanary(ctx, input.Body.ServerName, input.Body.Version, input.Body.DeploymentConfig(), input.Body.CanaryWeight)
+		case resourceType == "mcp":
+			deployment, err = registry.DeployServer(ctx, input.Body.ServerName, input.Body.Version, input.Body.DeploymentConfig(), input.Body.PreferRemote, runtimeTarget, input.Body.Origin)
+		case resourceType == "agent":
+			deployment, err = registry.DeployAgent(ctx, input.Body.ServerName, input.Body.Version, input.Body.DeploymentConfig(), input.Body.PreferRemote, runtimeTarget)
 		}
 
 		if err != nil {
+			if errors.Is(err, policy.ErrDenied) {
+				return nil, huma.Error403Forbidden(err.Error())
+			}
+			if problem := quotaProblem(err); problem != nil {
+				return nil, problem
+			}
 			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 				return nil, huma.Error404NotFound("Resource not found in registry")
 			}
 			if errors.Is(err, database.ErrAlreadyExists) {
 				return nil, huma.Error409Conflict("Resource is already deployed")
 			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
 			// Check for "not yet implemented" error
 			if err.Error() == "agent deployment is not yet implemented" {
 				return nil, huma.Error501NotImplemented("Agent deployment is not yet supported")
@@ -206,15 +428,182 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 
 		deployment, err := registry.UpdateDeploymentConfig(ctx, serverName, version, input.ResourceType, input.Body.Config)
 		if err != nil {
+			if errors.Is(err, policy.ErrDenied) {
+				return nil, huma.Error403Forbidden(err.Error())
+			}
 			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 				return nil, huma.Error404NotFound("Deployment not found")
 			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
 			return nil, huma.Error500InternalServerError("Failed to update deployment configuration", err)
 		}
 
 		return &DeploymentResponse{Body: *deployment}, nil
 	})
 
+	// Switch the origin registry of a deployment
+	huma.Register(api, huma.Operation{
+		OperationID: "update-deployment-origin",
+		Method:      http.MethodPut,
+		Path:        basePath + "/deployments/{serverName}/versions/{version}/origin",
+		Summary:     "Update deployment origin",
+		Description: "Switch the registry an MCP server deployment resolves its manifest from. The deployment is re-resolved from the new origin on every reconcile.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct {
+		DeploymentInput
+		Body DeploymentOriginUpdate
+	}) (*DeploymentResponse, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		if input.ResourceType != "" && input.ResourceType != "mcp" {
+			return nil, huma.Error400BadRequest("Origin is only supported for MCP server deployments")
+		}
+
+		deployment, err := registry.UpdateDeploymentOrigin(ctx, serverName, version, input.Body.Origin)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Deployment not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to update deployment origin", err)
+		}
+
+		return &DeploymentResponse{Body: *deployment}, nil
+	})
+
+	// Promote a canary deployment
+	huma.Register(api, huma.Operation{
+		OperationID: "promote-deployment",
+		Method:      http.MethodPost,
+		Path:        basePath + "/deployments/{serverName}/promote",
+		Summary:     "Promote a canary deployment",
+		Description: "Finish the canary rollout of an MCP server: the canary version takes all of its traffic and the previous version is removed",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct {
+		ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+	}) (*DeploymentResponse, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		deployment, err := registry.PromoteDeployment(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("No canary deployment of " + serverName)
+			}
+			return nil, huma.Error500InternalServerError("Failed to promote deployment", err)
+		}
+
+		return &DeploymentResponse{Body: *deployment}, nil
+	})
+
+	// List the revisions of a deployment
+	huma.Register(api, huma.Operation{
+		OperationID: "list-deployment-revisions",
+		Method:      http.MethodGet,
+		Path:        basePath + "/deployments/{serverName}/revisions",
+		Summary:     "List deployment revisions",
+		Description: "List the revision history of a deployment: the version and configuration it ran after every deploy, config change, promotion and rollback",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct {
+		ServerName   string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+		ResourceType string `query:"resourceType" json:"resourceType" doc:"Resource type (mcp, agent)" default:"mcp" enum:"mcp,agent"`
+	}) (*DeploymentRevisionsResponse, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		revisions, err := registry.ListDeploymentRevisions(ctx, serverName, input.ResourceType)
+		if err != nil {
+			if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Deployment not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to list deployment revisions", err)
+		}
+
+		resp := &DeploymentRevisionsResponse{}
+		resp.Body.Revisions = revisions
+		if resp.Body.Revisions == nil {
+			resp.Body.Revisions = []*models.DeploymentRevision{}
+		}
+		return resp, nil
+	})
+
+	// Sample the resource usage of a deployment
+	huma.Register(api, huma.Operation{
+		OperationID: "get-deployment-metrics",
+		Method:      http.MethodGet,
+		Path:        basePath + "/deployments/{serverName}/metrics",
+		Summary:     "Get deployment resource usage",
+		Description: "Sample the CPU and memory usage of the containers of every deployed version of a server or agent, from docker stats on docker targets and the metrics API (metrics-server) on kubernetes targets",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct {
+		ServerName   string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+		ResourceType string `query:"resourceType" json:"resourceType" doc:"Resource type (mcp, agent)" default:"mcp" enum:"mcp,agent"`
+	}) (*Response[models.DeploymentMetrics], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		metrics, err := registry.GetDeploymentMetrics(ctx, serverName, input.ResourceType)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Deployment not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get deployment metrics", err)
+		}
+		return &Response[models.DeploymentMetrics]{Body: *metrics}, nil
+	})
+
+	// Roll a deployment back to an earlier revision
+	huma.Register(api, huma.Operation{
+		OperationID: "rollback-deployment",
+		Method:      http.MethodPost,
+		Path:        basePath + "/deployments/{serverName}/rollback",
+		Summary:     "Roll back a deployment",
+		Description: "Return a deployment to the version and configuration of an earlier revision. The rollback is recorded as a new revision.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct {
+		ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+		Body       DeploymentRollbackRequest
+	}) (*DeploymentResponse, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		deployment, err := registry.RollbackDeployment(ctx, serverName, input.Body.ResourceType, input.Body.Revision)
+		if err != nil {
+			if errors.Is(err, policy.ErrDenied) {
+				return nil, huma.Error403Forbidden(err.Error())
+			}
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound(err.Error())
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to roll back deployment", err)
+		}
+
+		return &DeploymentResponse{Body: *deployment}, nil
+	})
+
 	// Remove a deployment
 	huma.Register(api, huma.Operation{
 		OperationID: "remove-deployment",
@@ -93,6 +93,9 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 		}
 		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr)
 		if err != nil {
+			if problem := sizeProblem(err); problem != nil {
+				return nil, problem
+			}
 			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 				return nil, huma.Error404NotFound("Server not found")
 			}
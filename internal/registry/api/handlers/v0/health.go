@@ -10,7 +10,9 @@ import (
 	"go.opentelemetry.io/otel/metric"
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 )
 
 // HealthBody represents the health check response body
@@ -41,6 +43,48 @@ func RegisterHealthEndpoint(api huma.API, pathPrefix string, cfg *config.Config,
 	})
 }
 
+// ReadinessInput represents the input for the readiness check
+type ReadinessInput struct {
+	Runtime bool `query:"runtime" doc:"Also check that the container engine of the local runtime is reachable" default:"false"`
+}
+
+// ReadinessOutput is the readiness report, served with 503 when a component fails
+type ReadinessOutput struct {
+	Status int
+	Body   models.Readiness
+}
+
+// RegisterLivenessEndpoints registers the liveness and readiness probes with a custom path prefix. Liveness only
+// reports that the process serves requests; readiness also checks the dependencies of the registry.
+func RegisterLivenessEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-livez" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/livez",
+		Summary:     "Liveness probe",
+		Description: "Report that the server process is running. It does not check dependencies, so a database outage does not restart the registry.",
+		Tags:        []string{"health"},
+	}, func(_ context.Context, _ *struct{}) (*Response[HealthBody], error) {
+		return &Response[HealthBody]{Body: HealthBody{Status: models.ReadinessStatusOK}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-readyz" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/readyz",
+		Summary:     "Readiness probe",
+		Description: "Check the database connection, pending schema migrations and optionally the local runtime. Responds with 503 and the failing components when the registry is not ready.",
+		Tags:        []string{"health"},
+	}, func(ctx context.Context, input *ReadinessInput) (*ReadinessOutput, error) {
+		readiness := registry.CheckReadiness(ctx, input.Runtime)
+		status := http.StatusOK
+		if !readiness.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		return &ReadinessOutput{Status: status, Body: *readiness}, nil
+	})
+}
+
 // recordHealthMetrics records the health check metrics
 func recordHealthMetrics(ctx context.Context, metrics *telemetry.Metrics, path string, version string) {
 	attrs := []attribute.KeyValue{
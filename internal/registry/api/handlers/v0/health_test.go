@@ -2,6 +2,7 @@ package v0_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,10 +10,14 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 )
 
 func TestHealthEndpoint(t *testing.T) {
@@ -84,3 +89,29 @@ func TestHealthEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestLivenessEndpoints(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	registryService := service.NewRegistryService(internaldb.NewTestDB(t), &config.Config{}, nil)
+	v0.RegisterLivenessEndpoints(api, "/v0", registryService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/livez", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"ok"`)
+
+	req = httptest.NewRequest(http.MethodGet, "/v0/readyz", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var readiness models.Readiness
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &readiness))
+	assert.True(t, readiness.Ready())
+	require.Len(t, readiness.Components, 2)
+	assert.Equal(t, "database", readiness.Components[0].Name)
+	assert.Equal(t, "migrations", readiness.Components[1].Name)
+	assert.Contains(t, readiness.Components[1].Message, "schema version")
+}
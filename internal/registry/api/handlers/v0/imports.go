@@ -0,0 +1,135 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/importer"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ImportInput identifies an import
+type ImportInput struct {
+	ID string `path:"id" json:"id" doc:"Import ID" example:"3f9a1c0d2b7e4a65"`
+}
+
+// StartImportInput is the body of a request starting an import
+type StartImportInput struct {
+	Body models.ImportRequest
+}
+
+// RegisterImportsEndpoints registers the admin-only endpoints following the progress of background imports. Admin
+// routes can also start, list and cancel imports.
+func RegisterImportsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config, isAdmin bool) {
+	tags := []string{"imports", "admin"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-import" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/imports/{id}",
+		Summary:     "Get import status",
+		Description: "Get the progress of a background import: how many entries were processed, created, updated, skipped and failed, and which failed. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ImportInput) (*Response[models.ImportStatus], error) {
+		status, err := registry.GetImport(ctx, input.ID)
+		if err != nil {
+			return nil, importError(err, "Failed to get import")
+		}
+		return &Response[models.ImportStatus]{Body: *status}, nil
+	})
+
+	if !isAdmin {
+		return
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-imports" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/imports",
+		Summary:     "List imports",
+		Description: "List the most recent imports, newest first. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, _ *struct{}) (*Response[models.ImportListResponse], error) {
+		imports, err := registry.ListImports(ctx)
+		if err != nil {
+			return nil, importError(err, "Failed to list imports")
+		}
+		return &Response[models.ImportListResponse]{Body: models.ImportListResponse{Imports: imports}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "start-import" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/imports",
+		Summary:       "Start an import",
+		Description:   "Import servers from a seed file path or URL, a registry, a package registry search or a GitHub organization. The import runs in the background; follow it with the returned import ID. Requires registry admin permissions.",
+		Tags:          tags,
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *StartImportInput) (*Response[models.ImportStatus], error) {
+		strategy := importer.ConflictSkip
+		if input.Body.Options.Strategy != "" {
+			parsed, err := importer.ParseConflictStrategy(input.Body.Options.Strategy)
+			if err != nil {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			strategy = parsed
+		}
+
+		status, err := registry.StartImport(ctx, input.Body, func(ctx context.Context, status *models.ImportStatus) error {
+			verifier, err := seed.NewVerifier(cfg.SeedRequireVerification, cfg.SeedMinisignPublicKey)
+			if err != nil {
+				return fmt.Errorf("invalid SEED_MINISIGN_PUBLIC_KEY: %w", err)
+			}
+			importerService := importer.NewService(registry)
+			importerService.SetSeedVerifier(verifier)
+			importerService.SetConcurrency(cfg.ImportConcurrency)
+			importerService.SetGitHubToken(cfg.EnrichGitHubToken)
+			importerService.SetImportID(status.ID)
+			importerService.SetConflictStrategy(strategy)
+			importerService.SetDryRun(status.Options.DryRun)
+			return importerService.ImportFromPath(ctx, status.Source, status.Options.Enrich)
+		})
+		if err != nil {
+			return nil, importError(err, "Failed to start import")
+		}
+		return &Response[models.ImportStatus]{Body: *status}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "cancel-import" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/imports/{id}/cancel",
+		Summary:     "Cancel an import",
+		Description: "Cancel a running import. Entries it already imported are kept. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ImportInput) (*Response[models.ImportStatus], error) {
+		status, err := registry.CancelImport(ctx, input.ID)
+		if err != nil {
+			return nil, importError(err, "Failed to cancel import")
+		}
+		return &Response[models.ImportStatus]{Body: *status}, nil
+	})
+}
+
+func importError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Import not found")
+	case errors.Is(err, service.ErrImportNotRunning):
+		return huma.Error409Conflict("Import is not running")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error403Forbidden("Imports require registry admin permissions")
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
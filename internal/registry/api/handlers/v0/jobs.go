@@ -0,0 +1,67 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// JobInput represents the path parameters for a single background job
+type JobInput struct {
+	Name string `path:"name" json:"name" doc:"Job name" example:"reconcile"`
+}
+
+// RegisterJobsEndpoints registers the admin-only background job endpoints
+func RegisterJobsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"jobs", "admin"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-jobs" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/jobs",
+		Summary:     "List background jobs",
+		Description: "List the registry's background jobs with their last run and next scheduled run. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, _ *struct{}) (*Response[models.JobListResponse], error) {
+		statuses, err := registry.ListJobs(ctx)
+		if err != nil {
+			return nil, jobError(err, "Failed to list jobs")
+		}
+		return &Response[models.JobListResponse]{Body: models.JobListResponse{Jobs: statuses}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "run-job" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/jobs/{name}/run",
+		Summary:       "Run a background job",
+		Description:   "Start a background job immediately. The job runs asynchronously; poll the job list for its result. Requires registry admin permissions.",
+		Tags:          tags,
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *JobInput) (*Response[models.JobStatus], error) {
+		status, err := registry.RunJob(ctx, input.Name)
+		if err != nil {
+			return nil, jobError(err, "Failed to run job")
+		}
+		return &Response[models.JobStatus]{Body: *status}, nil
+	})
+}
+
+func jobError(err error, msg string) error {
+	switch {
+	case errors.Is(err, jobs.ErrJobNotFound):
+		return huma.Error404NotFound("Job not found")
+	case errors.Is(err, jobs.ErrJobRunning):
+		return huma.Error409Conflict("Job is already running")
+	case errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error403Forbidden("Job management requires registry admin permissions")
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
@@ -0,0 +1,27 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// publishBodyLimit returns the MaxBodyBytes of the operations creating servers, agents and skills and uploading
+// READMEs. Huma refuses larger bodies with 413 before reading them; 0 keeps its default of 1 MiB.
+func publishBodyLimit(cfg *config.Config) int64 {
+	if cfg == nil {
+		return 0
+	}
+	return cfg.PublishMaxBodyBytes
+}
+
+// sizeProblem maps a document or README over its size limit to 413, or returns nil for other errors
+func sizeProblem(err error) error {
+	if errors.Is(err, validators.ErrDocumentTooLarge) || errors.Is(err, validators.ErrReadmeTooLarge) {
+		return huma.NewError(http.StatusRequestEntityTooLarge, err.Error())
+	}
+	return nil
+}
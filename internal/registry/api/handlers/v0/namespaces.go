@@ -0,0 +1,126 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ListNamespacesInput represents the input for listing verified namespaces
+type ListNamespacesInput struct {
+	Namespace string `query:"namespace" json:"namespace,omitempty" doc:"Only list verifications of this namespace" example:"io.github.myorg"`
+	Subject   string `query:"subject" json:"subject,omitempty" doc:"Only list namespaces verified by this user"`
+}
+
+// NamespaceChallengeInput represents the input for getting the DNS challenge of a namespace
+type NamespaceChallengeInput struct {
+	Namespace string `query:"namespace" json:"namespace" required:"true" doc:"Namespace to verify" example:"com.example"`
+}
+
+// VerifyNamespaceBody is the request body for verifying a namespace
+type VerifyNamespaceBody struct {
+	Namespace   string `json:"namespace" required:"true" doc:"Namespace to verify" example:"io.github.myorg"`
+	GitHubToken string `json:"githubToken,omitempty" doc:"GitHub token used to check private organization membership of io.github namespaces"`
+}
+
+// VerifyNamespaceInput represents the input for verifying a namespace
+type VerifyNamespaceInput struct {
+	Body VerifyNamespaceBody
+}
+
+// RevokeNamespaceInput represents the input for revoking a namespace verification
+type RevokeNamespaceInput struct {
+	Namespace string `path:"namespace" json:"namespace" doc:"Verified namespace" example:"com.example"`
+	Subject   string `query:"subject" json:"subject" required:"true" doc:"User whose verification is revoked"`
+}
+
+// RegisterNamespacesEndpoints registers the endpoints for listing and verifying namespace ownership
+func RegisterNamespacesEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespaces" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces",
+		Summary:     "List verified namespaces",
+		Description: "List namespaces whose ownership has been verified and the users who verified them. Only these users may publish under a verified namespace.",
+		Tags:        []string{"namespaces"},
+	}, func(ctx context.Context, input *ListNamespacesInput) (*Response[models.NamespaceListResponse], error) {
+		namespaces, err := registry.ListVerifiedNamespaces(ctx, input.Namespace, input.Subject)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list namespaces", err)
+		}
+		return &Response[models.NamespaceListResponse]{Body: models.NamespaceListResponse{Namespaces: namespaces}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-namespace-challenge" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/challenge",
+		Summary:     "Get namespace DNS challenge",
+		Description: "Get the DNS TXT record to publish on the domain of a namespace to verify it. The record is specific to the signed-in user.",
+		Tags:        []string{"namespaces"},
+	}, func(ctx context.Context, input *NamespaceChallengeInput) (*Response[models.NamespaceChallenge], error) {
+		challenge, err := registry.GetNamespaceChallenge(ctx, input.Namespace)
+		if err != nil {
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error401Unauthorized("Sign in to verify namespaces")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to get namespace challenge", err)
+		}
+		return &Response[models.NamespaceChallenge]{Body: *challenge}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "verify-namespace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespaces/verify",
+		Summary:     "Verify namespace ownership",
+		Description: "Prove ownership of a namespace. io.github.<owner> namespaces are verified through GitHub user or organization membership, other namespaces through the DNS TXT record from the challenge endpoint.",
+		Tags:        []string{"namespaces"},
+	}, func(ctx context.Context, input *VerifyNamespaceInput) (*Response[models.VerifiedNamespace], error) {
+		verified, err := registry.VerifyNamespace(ctx, input.Body.Namespace, input.Body.GitHubToken)
+		if err != nil {
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error401Unauthorized("Sign in to verify namespaces")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			if errors.Is(err, namespace.ErrVerificationFailed) {
+				return nil, huma.Error403Forbidden(err.Error())
+			}
+			return nil, huma.Error502BadGateway("Failed to verify namespace", err)
+		}
+		return &Response[models.VerifiedNamespace]{Body: *verified}, nil
+	})
+}
+
+// RegisterNamespaceRevokeEndpoint registers the admin endpoint for revoking namespace verifications
+func RegisterNamespaceRevokeEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-namespace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/namespaces/{namespace}",
+		Summary:     "Revoke namespace verification",
+		Description: "Remove a user's verification of a namespace. Once no verifications remain, the namespace is open again.",
+		Tags:        []string{"namespaces"},
+	}, func(ctx context.Context, input *RevokeNamespaceInput) (*Response[EmptyResponse], error) {
+		if err := registry.RevokeNamespace(ctx, input.Namespace, input.Subject); err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Namespace verification not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to revoke namespace verification", err)
+		}
+		return &Response[EmptyResponse]{Body: EmptyResponse{Message: "Namespace verification revoked"}}, nil
+	})
+}
@@ -0,0 +1,104 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// DeploymentPolicyInput represents the path parameters for a single deployment policy
+type DeploymentPolicyInput struct {
+	Name string `path:"name" json:"name" doc:"Policy name" example:"signed-servers-only"`
+}
+
+// PutDeploymentPolicyInput represents the input for creating or replacing a deployment policy
+type PutDeploymentPolicyInput struct {
+	Name string `path:"name" json:"name" doc:"Policy name" example:"signed-servers-only"`
+	Body struct {
+		Description   string   `json:"description,omitempty" doc:"What the policy is for" required:"false"`
+		Rule          string   `json:"rule" doc:"Rule to enforce" enum:"require-signed,image-allowlist,secret-env-refs" example:"require-signed"`
+		Enforcement   string   `json:"enforcement,omitempty" doc:"deny rejects violating deployments, warn only logs them" enum:"deny,warn" default:"deny" required:"false"`
+		ResourceTypes []string `json:"resourceTypes,omitempty" doc:"Resource types the policy applies to; empty applies to both" enum:"mcp,agent" required:"false"`
+		Registries    []string `json:"registries,omitempty" doc:"Allowed image registries or repository prefixes (image-allowlist)" required:"false"`
+	}
+}
+
+// RegisterPoliciesEndpoints registers the admin-only deployment policy endpoints
+func RegisterPoliciesEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"policies", "admin"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-deployment-policies" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/policies",
+		Summary:     "List deployment policies",
+		Description: "List the policies evaluated before every deployment, from the registry's policy file and from this API. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, _ *struct{}) (*Response[models.DeploymentPolicyListResponse], error) {
+		policies, err := registry.ListDeploymentPolicies(ctx)
+		if err != nil {
+			return nil, policyError(err, "Failed to list deployment policies")
+		}
+		return &Response[models.DeploymentPolicyListResponse]{
+			Body: models.DeploymentPolicyListResponse{Policies: policies},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "put-deployment-policy" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/policies/{name}",
+		Summary:     "Create or replace a deployment policy",
+		Description: "Create or replace a policy evaluated before servers and agents are deployed: require-signed rejects unsigned servers, image-allowlist rejects images outside the listed registries, and secret-env-refs rejects inline values for secret env vars. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *PutDeploymentPolicyInput) (*Response[models.DeploymentPolicy], error) {
+		policy, err := registry.PutDeploymentPolicy(ctx, &models.DeploymentPolicy{
+			Name:          input.Name,
+			Description:   input.Body.Description,
+			Rule:          input.Body.Rule,
+			Enforcement:   input.Body.Enforcement,
+			ResourceTypes: input.Body.ResourceTypes,
+			Registries:    input.Body.Registries,
+		})
+		if err != nil {
+			return nil, policyError(err, "Failed to save deployment policy")
+		}
+		return &Response[models.DeploymentPolicy]{Body: *policy}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-deployment-policy" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/policies/{name}",
+		Summary:     "Delete a deployment policy",
+		Description: "Delete a policy managed through this API. Policies from the registry's policy file can only be removed there. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *DeploymentPolicyInput) (*Response[EmptyResponse], error) {
+		if err := registry.DeleteDeploymentPolicy(ctx, input.Name); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Deployment policy not found")
+			}
+			return nil, policyError(err, "Failed to delete deployment policy")
+		}
+		return &Response[EmptyResponse]{
+			Body: EmptyResponse{Message: "Deployment policy deleted successfully"},
+		}, nil
+	})
+}
+
+func policyError(err error, msg string) error {
+	if errors.Is(err, database.ErrInvalidInput) {
+		return huma.Error400BadRequest(err.Error(), err)
+	}
+	if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+		return huma.Error403Forbidden("Deployment policies require registry admin permissions")
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
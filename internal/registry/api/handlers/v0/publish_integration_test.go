@@ -53,7 +53,7 @@ func TestPublishIntegration(t *testing.T) {
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
 
 	// Register the endpoint
-	v0.RegisterCreateEndpoint(api, "/v0", registryService)
+	v0.RegisterCreateEndpoint(api, "/v0", registryService, testConfig)
 
 	t.Run("successful publish with GitHub auth", func(t *testing.T) {
 		publishReq := apiv0.ServerJSON{
@@ -41,7 +41,7 @@ func TestPublishRegistryValidation(t *testing.T) {
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
 
 	// Register the endpoint
-	v0.RegisterCreateEndpoint(api, "/v0", registryService)
+	v0.RegisterCreateEndpoint(api, "/v0", registryService, testConfig)
 
 	t.Run("publish fails with npm registry validation error", func(t *testing.T) {
 		publishReq := apiv0.ServerJSON{
@@ -0,0 +1,47 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// QuotaInput optionally names the namespace whose servers are counted
+type QuotaInput struct {
+	Namespace string `query:"namespace" json:"namespace,omitempty" doc:"Namespace whose servers are counted against the per-namespace quota" required:"false" example:"com.example"`
+}
+
+// RegisterQuotaEndpoints registers the endpoint callers read their quotas and usage from
+func RegisterQuotaEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-quota" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/quota",
+		Summary:     "Get quota usage",
+		Description: "Get the deployment and publish quotas that apply to the caller and how much of them is used. A limit of 0 means unlimited.",
+		Tags:        []string{"quota"},
+	}, func(ctx context.Context, input *QuotaInput) (*Response[models.Quota], error) {
+		quota, err := registry.GetQuota(ctx, input.Namespace)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get quota", err)
+		}
+		return &Response[models.Quota]{Body: *quota}, nil
+	})
+}
+
+// quotaProblem maps a quota error to 429 Too Many Requests for the publish rate, which frees up over time, and to
+// 403 Forbidden for the other quotas. It returns nil for any other error.
+func quotaProblem(err error) error {
+	switch {
+	case errors.Is(err, service.ErrPublishQuotaExceeded):
+		return huma.Error429TooManyRequests(err.Error())
+	case errors.Is(err, service.ErrQuotaExceeded):
+		return huma.Error403Forbidden(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ListServerReviewsInput represents the input for listing the reviews of a server
+type ListServerReviewsInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Cursor     string `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false"`
+	Limit      int    `query:"limit" json:"limit,omitempty" doc:"Number of reviews per page" default:"30" minimum:"1" maximum:"100"`
+}
+
+// ListAgentReviewsInput represents the input for listing the reviews of an agent
+type ListAgentReviewsInput struct {
+	AgentName string `path:"agentName" json:"agentName" doc:"URL-encoded agent name" example:"com.example%2Fmy-agent"`
+	Cursor    string `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false"`
+	Limit     int    `query:"limit" json:"limit,omitempty" doc:"Number of reviews per page" default:"30" minimum:"1" maximum:"100"`
+}
+
+// ReviewBody is the rating and review a user submits
+type ReviewBody struct {
+	Rating int    `json:"rating" doc:"Star rating" minimum:"1" maximum:"5" example:"5"`
+	Body   string `json:"body,omitempty" doc:"Short review" maxLength:"2000" required:"false"`
+}
+
+// SubmitServerReviewInput represents the input for reviewing a server
+type SubmitServerReviewInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body       ReviewBody
+}
+
+// SubmitAgentReviewInput represents the input for reviewing an agent
+type SubmitAgentReviewInput struct {
+	AgentName string `path:"agentName" json:"agentName" doc:"URL-encoded agent name" example:"com.example%2Fmy-agent"`
+	Body      ReviewBody
+}
+
+// ReviewIDInput identifies a review
+type ReviewIDInput struct {
+	ID int64 `path:"id" json:"id" doc:"Review ID" example:"42"`
+}
+
+// ModerateReviewInput represents the input for hiding or restoring a review
+type ModerateReviewInput struct {
+	ID   int64 `path:"id" json:"id" doc:"Review ID" example:"42"`
+	Body struct {
+		Hidden bool `json:"hidden" doc:"Hide the review from public listings and ratings"`
+	}
+}
+
+// RegisterReviewsEndpoints registers the endpoints for listing, submitting and deleting reviews of servers and agents.
+// Admin listings include reviews hidden by moderators.
+func RegisterReviewsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, isAdmin bool) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-reviews" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/reviews",
+		Summary:     "List server reviews",
+		Description: "List the reviews of a server, newest first, with its average rating.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ListServerReviewsInput) (*Response[models.ReviewListResponse], error) {
+		return listReviews(ctx, registry, "mcp", input.ServerName, isAdmin, input.Cursor, input.Limit)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-agent-reviews" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/agents/{agentName}/reviews",
+		Summary:     "List agent reviews",
+		Description: "List the reviews of an agent, newest first, with its average rating.",
+		Tags:        []string{"agents"},
+	}, func(ctx context.Context, input *ListAgentReviewsInput) (*Response[models.ReviewListResponse], error) {
+		return listReviews(ctx, registry, "agent", input.AgentName, isAdmin, input.Cursor, input.Limit)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "submit-server-review" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/reviews",
+		Summary:     "Review a server",
+		Description: "Rate and review a server. Each user has one review per server; submitting again replaces it.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *SubmitServerReviewInput) (*Response[models.Review], error) {
+		return submitReview(ctx, registry, "mcp", input.ServerName, input.Body)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "submit-agent-review" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/agents/{agentName}/reviews",
+		Summary:     "Review an agent",
+		Description: "Rate and review an agent. Each user has one review per agent; submitting again replaces it.",
+		Tags:        []string{"agents"},
+	}, func(ctx context.Context, input *SubmitAgentReviewInput) (*Response[models.Review], error) {
+		return submitReview(ctx, registry, "agent", input.AgentName, input.Body)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-review" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/reviews/{id}",
+		Summary:     "Delete a review",
+		Description: "Delete one of your reviews. Admins may delete any review.",
+		Tags:        []string{"reviews"},
+	}, func(ctx context.Context, input *ReviewIDInput) (*Response[EmptyResponse], error) {
+		if err := registry.DeleteReview(ctx, input.ID); err != nil {
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error401Unauthorized("Sign in to delete reviews")
+			}
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) {
+				return nil, huma.Error404NotFound("Review not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete review", err)
+		}
+		return &Response[EmptyResponse]{Body: EmptyResponse{Message: "Review deleted"}}, nil
+	})
+}
+
+// RegisterReviewModerationEndpoints registers the admin endpoint for hiding and restoring reviews
+func RegisterReviewModerationEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "moderate-review" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPatch,
+		Path:        pathPrefix + "/reviews/{id}",
+		Summary:     "Moderate a review",
+		Description: "Hide a review from public listings and ratings, or restore it.",
+		Tags:        []string{"reviews"},
+	}, func(ctx context.Context, input *ModerateReviewInput) (*Response[models.Review], error) {
+		review, err := registry.ModerateReview(ctx, input.ID, input.Body.Hidden)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Review not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to moderate review", err)
+		}
+		return &Response[models.Review]{Body: *review}, nil
+	})
+}
+
+func listReviews(ctx context.Context, registry service.RegistryService, artifactType, rawName string, includeHidden bool, cursor string, limit int) (*Response[models.ReviewListResponse], error) {
+	name, err := url.PathUnescape(rawName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid name encoding")
+	}
+	resp, err := registry.ListReviews(ctx, artifactType, name, includeHidden, cursor, limit)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidInput) {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+			return nil, huma.Error404NotFound("Not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to list reviews", err)
+	}
+	return &Response[models.ReviewListResponse]{Body: *resp}, nil
+}
+
+func submitReview(ctx context.Context, registry service.RegistryService, artifactType, rawName string, body ReviewBody) (*Response[models.Review], error) {
+	name, err := url.PathUnescape(rawName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid name encoding")
+	}
+	review, err := registry.SubmitReview(ctx, artifactType, name, body.Rating, body.Body)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnauthenticated) {
+			return nil, huma.Error401Unauthorized("Sign in to submit reviews")
+		}
+		if errors.Is(err, database.ErrInvalidInput) {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) {
+			return nil, huma.Error404NotFound("Not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to submit review", err)
+	}
+	return &Response[models.Review]{Body: *review}, nil
+}
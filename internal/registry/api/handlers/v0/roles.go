@@ -0,0 +1,116 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ListRoleBindingsInput represents the input for listing role bindings
+type ListRoleBindingsInput struct {
+	Subject string `query:"subject" json:"subject,omitempty" doc:"Only list bindings of this subject" required:"false" example:"alice"`
+}
+
+// CreateRoleBindingInput represents the input for creating a role binding
+type CreateRoleBindingInput struct {
+	Body struct {
+		Subject   string `json:"subject" doc:"JWT subject the role is granted to" minLength:"1" example:"alice"`
+		Role      string `json:"role" doc:"Role to grant" enum:"admin,publisher,reader" example:"publisher"`
+		Namespace string `json:"namespace" doc:"Namespace pattern the role applies to" minLength:"1" example:"io.github.myorg/*"`
+	}
+}
+
+// RoleBindingInput represents the path parameters for a single role binding
+type RoleBindingInput struct {
+	ID int64 `path:"id" json:"id" doc:"Role binding ID" example:"1"`
+}
+
+// RegisterRolesEndpoints registers the admin-only role binding management endpoints
+func RegisterRolesEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"roles", "admin"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-role-bindings" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/roles",
+		Summary:     "List role bindings",
+		Description: "List the roles (admin, publisher, reader) bound to subjects on namespaces. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ListRoleBindingsInput) (*Response[models.RoleBindingListResponse], error) {
+		var subject *string
+		if input.Subject != "" {
+			subject = &input.Subject
+		}
+
+		bindings, err := registry.ListRoleBindings(ctx, subject)
+		if err != nil {
+			return nil, roleBindingError(err, "Failed to list role bindings")
+		}
+
+		values := make([]models.RoleBinding, len(bindings))
+		for i, b := range bindings {
+			values[i] = *b
+		}
+		return &Response[models.RoleBindingListResponse]{
+			Body: models.RoleBindingListResponse{Bindings: values},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-role-binding" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/roles",
+		Summary:     "Create a role binding",
+		Description: "Grant a role to a subject on a namespace pattern (e.g. io.github.myorg/*). Once a namespace has a binding, only subjects with a role granting the action may modify resources in it. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CreateRoleBindingInput) (*Response[models.RoleBinding], error) {
+		binding, err := registry.CreateRoleBinding(ctx, &models.RoleBinding{
+			Subject:   input.Body.Subject,
+			Role:      input.Body.Role,
+			Namespace: input.Body.Namespace,
+		})
+		if err != nil {
+			if errors.Is(err, database.ErrAlreadyExists) {
+				return nil, huma.Error409Conflict("Role binding already exists")
+			}
+			return nil, roleBindingError(err, "Failed to create role binding")
+		}
+		return &Response[models.RoleBinding]{Body: *binding}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-role-binding" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/roles/{id}",
+		Summary:     "Delete a role binding",
+		Description: "Revoke a role binding. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *RoleBindingInput) (*Response[EmptyResponse], error) {
+		if err := registry.DeleteRoleBinding(ctx, input.ID); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Role binding not found")
+			}
+			return nil, roleBindingError(err, "Failed to delete role binding")
+		}
+		return &Response[EmptyResponse]{
+			Body: EmptyResponse{Message: "Role binding deleted successfully"},
+		}, nil
+	})
+}
+
+func roleBindingError(err error, msg string) error {
+	if errors.Is(err, database.ErrInvalidInput) {
+		return huma.Error400BadRequest(err.Error(), err)
+	}
+	if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+		return huma.Error403Forbidden("Role management requires registry admin permissions")
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
@@ -0,0 +1,78 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// jsonSchemaDialect is the JSON Schema version of the published artifact schemas
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// RegisterSchemasEndpoints registers the endpoints serving the JSON Schemas of agent and skill payloads
+func RegisterSchemasEndpoints(api huma.API, pathPrefix string) {
+	schemas := []struct {
+		kind string
+		typ  reflect.Type
+	}{
+		{"agent", reflect.TypeOf(models.AgentJSON{})},
+		{"skill", reflect.TypeOf(models.SkillJSON{})},
+	}
+	for _, s := range schemas {
+		path := pathPrefix + "/schemas/" + s.kind + ".json"
+		huma.Register(api, huma.Operation{
+			OperationID: "get-" + s.kind + "-schema" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodGet,
+			Path:        path,
+			Summary:     "Get " + s.kind + " JSON Schema",
+			Description: "Get the JSON Schema that " + s.kind + " publish payloads are validated against.",
+			Tags:        []string{"schemas"},
+		}, func(ctx context.Context, _ *struct{}) (*Response[map[string]any], error) {
+			schema, err := artifactSchema(s.typ, path)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to render schema", err)
+			}
+			return &Response[map[string]any]{Body: schema}, nil
+		})
+	}
+}
+
+// artifactSchema renders a standalone JSON Schema for a payload type, with the nested types under $defs
+func artifactSchema(t reflect.Type, id string) (map[string]any, error) {
+	registry := huma.NewMapRegistry("#/$defs/", huma.DefaultSchemaNamer)
+	data, err := json.Marshal(registry.Schema(t, false, ""))
+	if err != nil {
+		return nil, err
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	schema["$schema"] = jsonSchemaDialect
+	schema["$id"] = id
+	if defs := registry.Map(); len(defs) > 0 {
+		schema["$defs"] = defs
+	}
+	return schema, nil
+}
+
+// validationProblem converts field-level validation errors of a payload into a 422 response listing every invalid
+// field, or returns nil for other errors
+func validationProblem(err error) error {
+	var verr *validators.ValidationError
+	if !errors.As(err, &verr) {
+		return nil
+	}
+	details := make([]error, len(verr.Fields))
+	for i, f := range verr.Fields {
+		details[i] = &huma.ErrorDetail{Location: "body." + f.Field, Message: f.Message, Value: f.Value}
+	}
+	return huma.Error422UnprocessableEntity("invalid "+verr.Kind+" payload", details...)
+}
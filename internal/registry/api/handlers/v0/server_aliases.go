@@ -0,0 +1,102 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ServerLookupResponse is the response of server lookups by name. Lookups by the former name of a renamed server
+// answer with 301 and the current location, and still include the data.
+type ServerLookupResponse struct {
+	Status   int
+	Location string `header:"Location" doc:"Current location of a renamed server"`
+	Body     models.ServerListResponse
+}
+
+// RenameServerInput represents the input for renaming a server
+type RenameServerInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body       struct {
+		NewName string `json:"newName" doc:"New name of the server" minLength:"1" example:"com.example/renamed-server"`
+	}
+}
+
+// RegisterServerRenameEndpoint registers the endpoint renaming servers
+func RegisterServerRenameEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "rename-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/rename",
+		Summary:     "Rename MCP server",
+		Description: "Rename every version of an MCP server (admin only). The former name is kept as an alias, so lookups by it are redirected to the new name. Signatures cover the name and are dropped.",
+		Tags:        []string{"servers", "admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RenameServerInput) (*Response[models.ServerResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		renamed, err := registry.RenameServer(ctx, serverName, input.Body.NewName)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server not found")
+			case errors.Is(err, database.ErrAlreadyExists):
+				return nil, huma.Error409Conflict("A server named " + input.Body.NewName + " already exists")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest(err.Error())
+			case errors.Is(err, auth.ErrUnauthenticated):
+				return nil, huma.Error401Unauthorized("Authentication required")
+			case errors.Is(err, auth.ErrForbidden):
+				return nil, huma.Error403Forbidden("Only registry admins can rename servers")
+			}
+			return nil, huma.Error500InternalServerError("Failed to rename server", err)
+		}
+		return &Response[models.ServerResponse]{Body: normalizeServerResponse(renamed)}, nil
+	})
+}
+
+// followServerAlias runs a lookup by server name. When nothing is found and the name is the former name of a renamed
+// server, it runs the lookup again with the current name and answers with a 301 pointing to location(currentName).
+func followServerAlias(
+	ctx context.Context,
+	registry service.RegistryService,
+	serverName string,
+	location func(string) string,
+	lookup func(string) (*Response[models.ServerListResponse], error),
+) (*ServerLookupResponse, error) {
+	resp, err := lookup(serverName)
+	if err == nil {
+		return &ServerLookupResponse{Body: resp.Body}, nil
+	}
+	var statusErr huma.StatusError
+	if !errors.As(err, &statusErr) || statusErr.GetStatus() != http.StatusNotFound {
+		return nil, err
+	}
+
+	currentName, aliasErr := registry.ResolveServerAlias(ctx, serverName)
+	if aliasErr != nil {
+		return nil, err
+	}
+	resp, err = lookup(currentName)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerLookupResponse{
+		Status:   http.StatusMovedPermanently,
+		Location: location(currentName),
+		Body:     resp.Body,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerCapabilitiesInput identifies the server version whose capabilities are read or refreshed
+type ServerCapabilitiesInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `query:"version" json:"version,omitempty" doc:"Server version ('latest' or an exact version)" default:"latest" example:"1.0.0"`
+}
+
+// SearchToolsInput represents the input for searching tools across servers
+type SearchToolsInput struct {
+	Query string `query:"q" json:"q" doc:"Text to find in tool names and descriptions" minLength:"1" example:"weather"`
+	Limit int    `query:"limit" json:"limit,omitempty" doc:"Maximum number of tools to return" default:"30" minimum:"1" maximum:"100" example:"30"`
+}
+
+// RegisterServerCapabilitiesEndpoints registers the endpoints exposing, searching and refreshing the introspected tools of servers
+func RegisterServerCapabilitiesEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-tools" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/tools",
+		Summary:     "Get server tools",
+		Description: "Get the tools, resources and prompts a server version reported when it was last introspected.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerCapabilitiesInput) (*Response[models.ServerCapabilities], error) {
+		server, err := resolveServerVersion(ctx, registry, input)
+		if err != nil {
+			return nil, err
+		}
+
+		caps, err := registry.GetServerCapabilities(ctx, server.Server.Name, server.Server.Version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server has not been introspected")
+			}
+			if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server tools", err)
+		}
+		return &Response[models.ServerCapabilities]{Body: *caps}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "search-tools" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/search/tools",
+		Summary:     "Search tools",
+		Description: "Find tools by name or description across the latest published server versions that have been introspected. Tools matching by name are listed first.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *SearchToolsInput) (*Response[models.ToolSearchResponse], error) {
+		tools, err := registry.SearchTools(ctx, input.Query, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to search tools", err)
+		}
+		return &Response[models.ToolSearchResponse]{
+			Body: models.ToolSearchResponse{Tools: tools, Count: len(tools)},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "introspect-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/introspect",
+		Summary:     "Introspect server",
+		Description: "Start or connect to a server version, list its tools, resources and prompts and store them. A failed introspection is stored with the reason in the error field.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerCapabilitiesInput) (*Response[models.ServerCapabilities], error) {
+		server, err := resolveServerVersion(ctx, registry, input)
+		if err != nil {
+			return nil, err
+		}
+
+		caps, err := registry.IntrospectServer(ctx, server.Server.Name, server.Server.Version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to introspect server", err)
+		}
+		return &Response[models.ServerCapabilities]{Body: *caps}, nil
+	})
+}
+
+// resolveServerVersion looks up the server version named by the input, resolving "latest"
+func resolveServerVersion(ctx context.Context, registry service.RegistryService, input *ServerCapabilitiesInput) (*apiv0.ServerResponse, error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	var server *apiv0.ServerResponse
+	if input.Version == "" || input.Version == "latest" {
+		server, err = registry.GetServerByName(ctx, serverName)
+	} else {
+		server, err = registry.GetServerByNameAndVersion(ctx, serverName, input.Version, true)
+	}
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+			return nil, huma.Error404NotFound("Server not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+	return server, nil
+}
@@ -0,0 +1,60 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerSecurityInput represents the input for getting the security report of a server
+type ServerSecurityInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `query:"version" json:"version,omitempty" doc:"Server version ('latest' or an exact version)" default:"latest" example:"1.0.0"`
+}
+
+// RegisterServerSecurityEndpoints registers the endpoint exposing image vulnerability scan results
+func RegisterServerSecurityEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-security" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/security",
+		Summary:     "Get server security report",
+		Description: "Get the vulnerability summary of the OCI images referenced by a published server version. The summary is absent when the server has not been scanned.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerSecurityInput) (*Response[models.ServerSecurity], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		var server *apiv0.ServerResponse
+		if input.Version == "" || input.Version == "latest" {
+			server, err = registry.GetServerByName(ctx, serverName)
+		} else {
+			server, err = registry.GetServerByNameAndVersion(ctx, serverName, input.Version, true)
+		}
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server", err)
+		}
+
+		return &Response[models.ServerSecurity]{
+			Body: models.ServerSecurity{
+				ServerName:      server.Server.Name,
+				Version:         server.Server.Version,
+				Vulnerabilities: models.VulnerabilitySummaryFromMeta(server.Server.Meta),
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,104 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ServerSignatureInput identifies the server version whose signature is read
+type ServerSignatureInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" json:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
+// UploadServerSignatureInput represents the input for attaching a publisher signature to a server version
+type UploadServerSignatureInput struct {
+	ServerSignatureInput
+	Body struct {
+		Algorithm string `json:"algorithm" doc:"Signature algorithm" enum:"ed25519,ecdsa-p256-sha256"`
+		Signature []byte `json:"signature" doc:"Base64 encoded signature over the server.json without _meta"`
+		PublicKey string `json:"publicKey" doc:"PEM encoded PKIX public key of the signer"`
+	}
+}
+
+// RegisterServerSignatureEndpoints registers the endpoints to attach and fetch server signatures
+func RegisterServerSignatureEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"servers"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-signature" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/signature",
+		Summary:     "Get server signature",
+		Description: "Get the publisher signature and public key attached to a server version.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ServerSignatureInput) (*Response[models.ServerSignature], error) {
+		serverName, version, err := decodeServerVersion(input)
+		if err != nil {
+			return nil, err
+		}
+
+		sig, err := registry.GetServerSignature(ctx, serverName, version)
+		if err != nil {
+			return nil, serverSignatureError(err, "Failed to get server signature")
+		}
+		return &Response[models.ServerSignature]{Body: *sig}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "upload-server-signature" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/signature",
+		Summary:     "Sign a server version",
+		Description: "Attach a publisher signature to a server version, replacing any existing one. The signature is verified against the stored server.json.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *UploadServerSignatureInput) (*Response[models.ServerSignature], error) {
+		serverName, version, err := decodeServerVersion(&input.ServerSignatureInput)
+		if err != nil {
+			return nil, err
+		}
+
+		sig, err := registry.SetServerSignature(ctx, &models.ServerSignature{
+			ServerName: serverName,
+			Version:    version,
+			Algorithm:  input.Body.Algorithm,
+			Signature:  input.Body.Signature,
+			PublicKey:  input.Body.PublicKey,
+		})
+		if err != nil {
+			return nil, serverSignatureError(err, "Failed to store server signature")
+		}
+		return &Response[models.ServerSignature]{Body: *sig}, nil
+	})
+}
+
+func decodeServerVersion(input *ServerSignatureInput) (string, string, error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+	version, err := url.PathUnescape(input.Version)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid version encoding", err)
+	}
+	return serverName, version, nil
+}
+
+func serverSignatureError(err error, msg string) error {
+	if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+		return huma.Error404NotFound("Signature not found")
+	}
+	if errors.Is(err, database.ErrInvalidInput) {
+		return huma.Error400BadRequest(err.Error(), err)
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
@@ -67,6 +70,10 @@ type ListServersInput struct {
 	Version                string  `query:"version" json:"version,omitempty" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
 	Semantic               bool    `query:"semantic_search" json:"semantic_search,omitempty" doc:"Use semantic search for the search term (hybrid with substring filter when search is set)" default:"false"`
 	SemanticMatchThreshold float64 `query:"semantic_threshold" json:"semantic_threshold,omitempty" doc:"Optional maximum distance for semantic matches (cosine distance)" required:"false"`
+	Sort                   string  `query:"sort" json:"sort,omitempty" doc:"Sort order: name (default) or popularity (most downloaded, deployed and installed first)" required:"false" enum:"name,popularity" example:"popularity"`
+	License                string  `query:"license" json:"license,omitempty" doc:"Filter by SPDX license identifier (case-insensitive)" required:"false" example:"MIT"`
+	Tags                   string  `query:"tags" json:"tags,omitempty" doc:"Comma-separated tags; only servers carrying all of them are returned" required:"false" example:"database,postgres"`
+	View                   string  `query:"view" json:"view,omitempty" doc:"full (default) returns each server.json; summary only returns the name, title, description and version of each server, which is much faster for large registries" required:"false" enum:"full,summary" example:"summary"`
 }
 
 // ServerDetailInput represents the input for getting server details
@@ -87,6 +94,15 @@ type ServerVersionsInput struct {
 	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 }
 
+// ServerVersionsStatusInput represents the input for publishing or unpublishing several versions at once
+type ServerVersionsStatusInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body       struct {
+		Versions  []string `json:"versions" doc:"Versions to update" minItems:"1"`
+		Published bool     `json:"published" doc:"Whether the versions should be published (true) or unpublished (false)"`
+	}
+}
+
 // ServerReadmeResponse is the payload for README fetch endpoints
 type ServerReadmeResponse struct {
 	Content     string    `json:"content"`
@@ -97,6 +113,16 @@ type ServerReadmeResponse struct {
 	FetchedAt   time.Time `json:"fetched_at"`
 }
 
+// UploadServerReadmeInput represents the input for uploading the README of a server version
+type UploadServerReadmeInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" json:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Body       struct {
+		Content     string `json:"content" doc:"README document" minLength:"1"`
+		ContentType string `json:"contentType,omitempty" doc:"Media type of the document" required:"false" example:"text/markdown"`
+	}
+}
+
 // RegisterServersEndpoints registers all server-related endpoints with a custom path prefix
 // isAdmin: if true, shows all resources; if false, only shows published resources
 func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, isAdmin bool) {
@@ -106,7 +132,7 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			Method:      http.MethodDelete,
 			Path:        pathPrefix + "/servers/{serverName}/versions/{version}",
 			Summary:     "Delete MCP server version",
-			Description: "Permanently delete an MCP server version from the registry.",
+			Description: "Move an MCP server version to the trash. It can be restored via /admin/v0/trash until the trash retention has passed.",
 			Tags:        []string{"servers", "admin"},
 		}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[EmptyResponse], error) {
 			serverName, err := url.PathUnescape(input.ServerName)
@@ -212,6 +238,14 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			}
 		}
 
+		if input.License != "" {
+			filter.License = &input.License
+		}
+		filter.Tags = parseTagsParam(input.Tags)
+
+		filter.SortByPopularity = input.Sort == "popularity"
+		filter.Summary = input.View == "summary"
+
 		// Get paginated results with filtering
 		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
 		if err != nil {
@@ -226,8 +260,18 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 
 		// Convert []*ServerResponse to []ServerResponse while normalizing metadata.
 		serverValues := make([]models.ServerResponse, len(servers))
+		names := make([]string, len(servers))
 		for i, server := range servers {
 			serverValues[i] = normalizeServerResponse(server)
+			names[i] = server.Server.Name
+		}
+		// Ratings are decoration; a failure to load them does not fail the listing
+		if ratings, err := registry.GetRatingSummaries(ctx, "mcp", names); err == nil {
+			for i := range serverValues {
+				if rating, ok := ratings[serverValues[i].Server.Name]; ok {
+					serverValues[i].Meta.Rating = &rating
+				}
+			}
 		}
 
 		return &Response[models.ServerListResponse]{
@@ -248,9 +292,9 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/servers/{serverName}/versions/{version}",
 		Summary:     "Get specific MCP server version",
-		Description: "Get detailed information about a specific version of an MCP server. Set 'all=true' query parameter to get all versions. Set 'published_only=true' to filter to only published versions (only applies when all=true).",
+		Description: "Get detailed information about a specific version of an MCP server. Set 'all=true' query parameter to get all versions. Set 'published_only=true' to filter to only published versions (only applies when all=true). Requests for the former name of a renamed server are answered with a 301 pointing to its current name, together with the data.",
 		Tags:        tags,
-	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[models.ServerListResponse], error) {
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*ServerLookupResponse, error) {
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -263,93 +307,102 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			return nil, huma.Error400BadRequest("Invalid version encoding", err)
 		}
 
-		// If all=true, return all versions
-		if input.All {
-			// Determine if we should filter to published only
-			onlyPublished := input.PublishedOnly
-			// For public endpoints, always filter to published only
-			if !isAdmin {
-				onlyPublished = true
+		location := func(name string) string {
+			path := pathPrefix + "/servers/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version)
+			if input.All {
+				path += fmt.Sprintf("?all=true&published_only=%t", input.PublishedOnly)
 			}
+			return path
+		}
+		return followServerAlias(ctx, registry, serverName, location, func(serverName string) (*Response[models.ServerListResponse], error) {
+			// If all=true, return all versions
+			if input.All {
+				// Determine if we should filter to published only
+				onlyPublished := input.PublishedOnly
+				// For public endpoints, always filter to published only
+				if !isAdmin {
+					onlyPublished = true
+				}
 
-			servers, err := registry.GetAllVersionsByServerName(ctx, serverName, onlyPublished)
-			if err != nil {
-				if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
-					return nil, huma.Error404NotFound("Server not found")
+				servers, err := registry.GetAllVersionsByServerName(ctx, serverName, onlyPublished)
+				if err != nil {
+					if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+						return nil, huma.Error404NotFound("Server not found")
+					}
+					return nil, huma.Error500InternalServerError("Failed to get server versions", err)
 				}
-				return nil, huma.Error500InternalServerError("Failed to get server versions", err)
-			}
 
-			// Convert []*ServerResponse to []ServerResponse
-			serverValues := make([]models.ServerResponse, len(servers))
-			for i, server := range servers {
-				serverValues[i] = normalizeServerResponse(server)
-			}
+				// Convert []*ServerResponse to []ServerResponse
+				serverValues := make([]models.ServerResponse, len(servers))
+				for i, server := range servers {
+					serverValues[i] = normalizeServerResponse(server)
+				}
 
-			return &Response[models.ServerListResponse]{
-				Body: models.ServerListResponse{
-					Servers: serverValues,
-					Metadata: models.ServerMetadata{
-						Count: len(servers),
+				return &Response[models.ServerListResponse]{
+					Body: models.ServerListResponse{
+						Servers: serverValues,
+						Metadata: models.ServerMetadata{
+							Count: len(servers),
+						},
 					},
-				},
-			}, nil
-		}
-
-		// Default behavior: return a single version (wrapped in a list for consistency)
-		// For public endpoints, always filter to published only
-		publishedOnly := input.PublishedOnly
-		if !isAdmin {
-			publishedOnly = true
-		}
+				}, nil
+			}
 
-		var serverResponse *apiv0.ServerResponse
+			// Default behavior: return a single version (wrapped in a list for consistency)
+			// For public endpoints, always filter to published only
+			publishedOnly := input.PublishedOnly
+			if !isAdmin {
+				publishedOnly = true
+			}
 
-		// Handle "latest" as a special version string
-		if version == "latest" {
-			// Get all versions and find the latest one
-			servers, err := registry.GetAllVersionsByServerName(ctx, serverName, publishedOnly)
-			if err != nil {
-				if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+			var serverResponse *apiv0.ServerResponse
+
+			// Handle "latest" as a special version string
+			if version == "latest" {
+				// Get all versions and find the latest one
+				servers, err := registry.GetAllVersionsByServerName(ctx, serverName, publishedOnly)
+				if err != nil {
+					if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+						return nil, huma.Error404NotFound("Server not found")
+					}
+					return nil, huma.Error500InternalServerError("Failed to get server versions", err)
+				}
+				if len(servers) == 0 {
 					return nil, huma.Error404NotFound("Server not found")
 				}
-				return nil, huma.Error500InternalServerError("Failed to get server versions", err)
-			}
-			if len(servers) == 0 {
-				return nil, huma.Error404NotFound("Server not found")
-			}
-			// Find the latest version (should be marked with IsLatest=true)
-			var latestServer *apiv0.ServerResponse
-			for _, s := range servers {
-				if s.Meta.Official != nil && s.Meta.Official.IsLatest {
-					latestServer = s
-					break
+				// Find the latest version (should be marked with IsLatest=true)
+				var latestServer *apiv0.ServerResponse
+				for _, s := range servers {
+					if s.Meta.Official != nil && s.Meta.Official.IsLatest {
+						latestServer = s
+						break
+					}
 				}
-			}
-			// If no server is marked as latest, use the first one (shouldn't happen, but be defensive)
-			if latestServer == nil {
-				latestServer = servers[0]
-			}
-			serverResponse = latestServer
-		} else {
-			serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version, publishedOnly)
-			if err != nil {
-				if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
-					return nil, huma.Error404NotFound("Server not found")
+				// If no server is marked as latest, use the first one (shouldn't happen, but be defensive)
+				if latestServer == nil {
+					latestServer = servers[0]
+				}
+				serverResponse = latestServer
+			} else {
+				serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version, publishedOnly)
+				if err != nil {
+					if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+						return nil, huma.Error404NotFound("Server not found")
+					}
+					return nil, huma.Error500InternalServerError("Failed to get server details", err)
 				}
-				return nil, huma.Error500InternalServerError("Failed to get server details", err)
 			}
-		}
 
-		// Return single server wrapped in a list response
-		return &Response[models.ServerListResponse]{
-			Body: models.ServerListResponse{
-				Servers: []models.ServerResponse{normalizeServerResponse(serverResponse)},
-				Metadata: models.ServerMetadata{
-					Count: 1,
+			// Return single server wrapped in a list response
+			return &Response[models.ServerListResponse]{
+				Body: models.ServerListResponse{
+					Servers: []models.ServerResponse{normalizeServerResponse(serverResponse)},
+					Metadata: models.ServerMetadata{
+						Count: 1,
+					},
 				},
-			},
-		}, nil
+			}, nil
+		})
 	})
 
 	// Get server versions endpoint
@@ -358,40 +411,45 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/servers/{serverName}/versions",
 		Summary:     "Get all versions of an MCP server",
-		Description: "Get all available versions for a specific MCP server",
+		Description: "Get all available versions for a specific MCP server. Requests for the former name of a renamed server are answered with a 301 pointing to its current name, together with the data.",
 		Tags:        tags,
-	}, func(ctx context.Context, input *ServerVersionsInput) (*Response[models.ServerListResponse], error) {
+	}, func(ctx context.Context, input *ServerVersionsInput) (*ServerLookupResponse, error) {
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
 			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
 		}
 
-		// Get all versions for this server
-		// For public endpoints, only get published versions (published = true)
-		// For admin endpoints, get all versions (published = true or false)
-		servers, err := registry.GetAllVersionsByServerName(ctx, serverName, !isAdmin)
-		if err != nil {
-			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
-				return nil, huma.Error404NotFound("Server not found")
-			}
-			return nil, huma.Error500InternalServerError("Failed to get server versions", err)
+		location := func(name string) string {
+			return pathPrefix + "/servers/" + url.PathEscape(name) + "/versions"
 		}
+		return followServerAlias(ctx, registry, serverName, location, func(serverName string) (*Response[models.ServerListResponse], error) {
+			// Get all versions for this server
+			// For public endpoints, only get published versions (published = true)
+			// For admin endpoints, get all versions (published = true or false)
+			servers, err := registry.GetAllVersionsByServerName(ctx, serverName, !isAdmin)
+			if err != nil {
+				if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+					return nil, huma.Error404NotFound("Server not found")
+				}
+				return nil, huma.Error500InternalServerError("Failed to get server versions", err)
+			}
 
-		// Convert []*ServerResponse to []ServerResponse
-		serverValues := make([]models.ServerResponse, len(servers))
-		for i, server := range servers {
-			serverValues[i] = normalizeServerResponse(server)
-		}
+			// Convert []*ServerResponse to []ServerResponse
+			serverValues := make([]models.ServerResponse, len(servers))
+			for i, server := range servers {
+				serverValues[i] = normalizeServerResponse(server)
+			}
 
-		return &Response[models.ServerListResponse]{
-			Body: models.ServerListResponse{
-				Servers: serverValues,
-				Metadata: models.ServerMetadata{
-					Count: len(servers),
+			return &Response[models.ServerListResponse]{
+				Body: models.ServerListResponse{
+					Servers: serverValues,
+					Metadata: models.ServerMetadata{
+						Count: len(servers),
+					},
 				},
-			},
-		}, nil
+			}, nil
+		})
 	})
 
 	// Get latest server README endpoint
@@ -483,6 +541,15 @@ func createServerHandler(ctx context.Context, input *CreateServerInput, registry
 	// Create/update the server (published defaults to false in the service layer)
 	createdServer, err := registry.CreateServer(ctx, &input.Body)
 	if err != nil {
+		if problem := sizeProblem(err); problem != nil {
+			return nil, problem
+		}
+		if problem := quotaProblem(err); problem != nil {
+			return nil, problem
+		}
+		if errors.Is(err, namespace.ErrNotOwned) {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
 		if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 			return nil, huma.Error404NotFound("Not found")
 		}
@@ -496,14 +563,15 @@ func createServerHandler(ctx context.Context, input *CreateServerInput, registry
 
 // RegisterCreateEndpoint registers the public create/update server endpoint at /publish
 // This endpoint creates or updates a server in the registry (published defaults to false)
-func RegisterCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+func RegisterCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	huma.Register(api, huma.Operation{
-		OperationID: "create-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
-		Method:      http.MethodPost,
-		Path:        pathPrefix + "/publish",
-		Summary:     "Create/update MCP server",
-		Description: "Create a new MCP server in the registry or update an existing one. By default, servers are created as unpublished (published=false).",
-		Tags:        []string{"servers", "publish"},
+		OperationID:  "create-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/publish",
+		Summary:      "Create/update MCP server",
+		Description:  "Create a new MCP server in the registry or update an existing one. By default, servers are created as unpublished (published=false).",
+		Tags:         []string{"servers", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
@@ -512,16 +580,59 @@ func RegisterCreateEndpoint(api huma.API, pathPrefix string, registry service.Re
 	})
 }
 
+// RegisterServersReadmeUploadEndpoint registers the endpoint that stores the README of an existing server version
+func RegisterServersReadmeUploadEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID:  "upload-server-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPut,
+		Path:         pathPrefix + "/servers/{serverName}/versions/{version}/readme",
+		Summary:      "Upload server README",
+		Description:  "Store or replace the README document of an existing MCP server version.",
+		Tags:         []string{"servers", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
+		Security:     []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *UploadServerReadmeInput) (*Response[EmptyResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		if err := registry.StoreServerReadme(ctx, serverName, version, []byte(input.Body.Content), input.Body.ContentType); err != nil {
+			if problem := sizeProblem(err); problem != nil {
+				return nil, problem
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to store server README", err)
+		}
+
+		return &Response[EmptyResponse]{
+			Body: EmptyResponse{
+				Message: "Server README stored successfully",
+			},
+		}, nil
+	})
+}
+
 // RegisterAdminCreateEndpoint registers the admin create/update server endpoint at /servers
 // This endpoint creates or updates a server in the registry (published defaults to false)
-func RegisterAdminCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+func RegisterAdminCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	huma.Register(api, huma.Operation{
-		OperationID: "admin-create-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
-		Method:      http.MethodPost,
-		Path:        pathPrefix + "/servers",
-		Summary:     "Create/update MCP server (Admin)",
-		Description: "Create a new MCP server in the registry or update an existing one. By default, servers are created as unpublished (published=false).",
-		Tags:        []string{"servers", "admin"},
+		OperationID:  "admin-create-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/servers",
+		Summary:      "Create/update MCP server (Admin)",
+		Description:  "Create a new MCP server in the registry or update an existing one. By default, servers are created as unpublished (published=false).",
+		Tags:         []string{"servers", "admin"},
+		MaxBodyBytes: publishBodyLimit(cfg),
 	}, func(ctx context.Context, input *CreateServerInput) (*Response[models.ServerResponse], error) {
 		return createServerHandler(ctx, input, registry)
 	})
@@ -551,6 +662,9 @@ func RegisterPublishStatusEndpoints(api huma.API, pathPrefix string, registry se
 
 		// Call the service to publish the server
 		if err := registry.PublishServer(ctx, serverName, version); err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
 			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 				return nil, huma.Error404NotFound("Server not found")
 			}
@@ -597,4 +711,74 @@ func RegisterPublishStatusEndpoints(api huma.API, pathPrefix string, registry se
 			},
 		}, nil
 	})
+
+	// Versions status endpoint - lists the published/lifecycle state of every version
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-versions-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions-status",
+		Summary:     "Get publish state of all server versions",
+		Description: "List every version of a server with its lifecycle status and whether it is published.",
+		Tags:        []string{"servers", "admin"},
+	}, func(ctx context.Context, input *ServerVersionsInput) (*Response[models.ServerVersionsStatusResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		statuses, err := registry.GetServerVersionStatuses(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server versions status", err)
+		}
+
+		versions := make([]models.ServerVersionStatus, len(statuses))
+		for i, st := range statuses {
+			versions[i] = *st
+		}
+
+		return &Response[models.ServerVersionsStatusResponse]{
+			Body: models.ServerVersionsStatusResponse{
+				Name:     serverName,
+				Versions: versions,
+			},
+		}, nil
+	})
+
+	// Batch publish/unpublish endpoint - changes the published state of several versions atomically
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-versions-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/versions-status",
+		Summary:     "Publish or unpublish several server versions",
+		Description: "Publish or unpublish a set of versions of a server in a single transaction. If any version fails, no version is changed.",
+		Tags:        []string{"servers", "admin"},
+	}, func(ctx context.Context, input *ServerVersionsStatusInput) (*Response[EmptyResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		if err := registry.SetServerVersionsPublished(ctx, serverName, input.Body.Versions, input.Body.Published); err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to update server versions status", err)
+		}
+
+		action := "unpublished"
+		if input.Body.Published {
+			action = "published"
+		}
+		return &Response[EmptyResponse]{
+			Body: EmptyResponse{
+				Message: fmt.Sprintf("%d version(s) %s successfully", len(input.Body.Versions), action),
+			},
+		}, nil
+	})
 }
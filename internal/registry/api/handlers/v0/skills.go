@@ -2,12 +2,15 @@ package v0
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	skillmodels "github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
@@ -22,6 +25,7 @@ type ListSkillsInput struct {
 	UpdatedSince string `query:"updated_since" json:"updated_since,omitempty" doc:"Filter skills updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
 	Search       string `query:"search" json:"search,omitempty" doc:"Search skills by name (substring match)" required:"false" example:"filesystem"`
 	Version      string `query:"version" json:"version,omitempty" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Sort         string `query:"sort" json:"sort,omitempty" doc:"Sort order: name (default) or popularity (most downloaded, deployed and installed first)" required:"false" enum:"name,popularity" example:"popularity"`
 }
 
 // SkillDetailInput represents the input for getting skill details
@@ -40,6 +44,33 @@ type SkillVersionsInput struct {
 	SkillName string `path:"skillName" json:"skillName" doc:"URL-encoded skill name" example:"com.example%2Fmy-skill"`
 }
 
+// SkillReadmeResponse is the payload for skill README fetch endpoints
+type SkillReadmeResponse struct {
+	Content     string    `json:"content"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int       `json:"size_bytes"`
+	Sha256      string    `json:"sha256"`
+	Version     string    `json:"version"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// UploadSkillReadmeInput represents the input for uploading the README of a skill version
+type UploadSkillReadmeInput struct {
+	SkillName string `path:"skillName" json:"skillName" doc:"URL-encoded skill name" example:"com.example%2Fmy-skill"`
+	Version   string `path:"version" json:"version" doc:"URL-encoded skill version" example:"1.0.0"`
+	Body      struct {
+		Content     string `json:"content" doc:"README document" minLength:"1"`
+		ContentType string `json:"contentType,omitempty" doc:"Media type of the document" required:"false" example:"text/markdown"`
+	}
+}
+
+// SetSkillStatusInput represents the input for changing the lifecycle status of a skill version
+type SetSkillStatusInput struct {
+	SkillName string `path:"skillName" json:"skillName" doc:"URL-encoded skill name" example:"com.example%2Fmy-skill"`
+	Version   string `path:"version" json:"version" doc:"URL-encoded skill version" example:"1.0.0"`
+	Status    string `query:"status" doc:"New status for the skill (active, deprecated, deleted)" enum:"active,deprecated,deleted"`
+}
+
 // RegisterSkillsEndpoints registers all skill-related endpoints with a custom path prefix
 // isAdmin: if true, shows all resources; if false, only shows published resources
 func RegisterSkillsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, isAdmin bool) {
@@ -88,8 +119,13 @@ func RegisterSkillsEndpoints(api huma.API, pathPrefix string, registry service.R
 			}
 		}
 
+		filter.SortByPopularity = input.Sort == "popularity"
+
 		skills, nextCursor, err := registry.ListSkills(ctx, filter, input.Cursor, input.Limit)
 		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
 			if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 				return nil, huma.Error404NotFound("Not found")
 			}
@@ -178,6 +214,120 @@ func RegisterSkillsEndpoints(api huma.API, pathPrefix string, registry service.R
 			},
 		}, nil
 	})
+
+	// Get latest skill README
+	huma.Register(api, huma.Operation{
+		OperationID: "get-skill-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/skills/{skillName}/readme",
+		Summary:     "Get skill README",
+		Description: "Fetch the README document for the latest version of an Agentic skill",
+		Tags:        tags,
+	}, func(ctx context.Context, input *SkillDetailInput) (*Response[SkillReadmeResponse], error) {
+		skillName, err := url.PathUnescape(input.SkillName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid skill name encoding", err)
+		}
+
+		readme, err := registry.GetSkillReadmeLatest(ctx, skillName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("README not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to fetch skill README", err)
+		}
+		return &Response[SkillReadmeResponse]{Body: toSkillReadmeResponse(readme)}, nil
+	})
+
+	// Get skill README for a specific version
+	huma.Register(api, huma.Operation{
+		OperationID: "get-skill-version-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/skills/{skillName}/versions/{version}/readme",
+		Summary:     "Get skill README for a version",
+		Description: "Fetch the README document for a specific version of an Agentic skill",
+		Tags:        tags,
+	}, func(ctx context.Context, input *SkillVersionDetailInput) (*Response[SkillReadmeResponse], error) {
+		skillName, err := url.PathUnescape(input.SkillName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid skill name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		var readme *database.SkillReadme
+		if version == "latest" {
+			readme, err = registry.GetSkillReadmeLatest(ctx, skillName)
+		} else {
+			readme, err = registry.GetSkillReadmeByVersion(ctx, skillName, version)
+		}
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("README not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to fetch skill README", err)
+		}
+		return &Response[SkillReadmeResponse]{Body: toSkillReadmeResponse(readme)}, nil
+	})
+}
+
+func toSkillReadmeResponse(readme *database.SkillReadme) SkillReadmeResponse {
+	shaValue := ""
+	if len(readme.SHA256) > 0 {
+		shaValue = hex.EncodeToString(readme.SHA256)
+	}
+	return SkillReadmeResponse{
+		Content:     string(readme.Content),
+		ContentType: readme.ContentType,
+		SizeBytes:   readme.SizeBytes,
+		Sha256:      shaValue,
+		Version:     readme.Version,
+		FetchedAt:   readme.FetchedAt,
+	}
+}
+
+// RegisterSkillsReadmeUploadEndpoint registers the endpoint that stores the README of an existing skill version
+func RegisterSkillsReadmeUploadEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID:  "upload-skill-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPut,
+		Path:         pathPrefix + "/skills/{skillName}/versions/{version}/readme",
+		Summary:      "Upload skill README",
+		Description:  "Store or replace the README document of an existing Agentic skill version.",
+		Tags:         []string{"skills", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
+		Security:     []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *UploadSkillReadmeInput) (*Response[EmptyResponse], error) {
+		skillName, err := url.PathUnescape(input.SkillName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid skill name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		if err := registry.StoreSkillReadme(ctx, skillName, version, []byte(input.Body.Content), input.Body.ContentType); err != nil {
+			if problem := sizeProblem(err); problem != nil {
+				return nil, problem
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Skill not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to store skill README", err)
+		}
+
+		return &Response[EmptyResponse]{
+			Body: EmptyResponse{
+				Message: "Skill README stored successfully",
+			},
+		}, nil
+	})
 }
 
 // CreateSkillInput represents the input for creating/updating a skill
@@ -190,6 +340,18 @@ func createSkillHandler(ctx context.Context, input *CreateSkillInput, registry s
 	// Create/update the skill (published defaults to false in the service layer)
 	createdSkill, err := registry.CreateSkill(ctx, &input.Body)
 	if err != nil {
+		if problem := validationProblem(err); problem != nil {
+			return nil, problem
+		}
+		if problem := sizeProblem(err); problem != nil {
+			return nil, problem
+		}
+		if problem := quotaProblem(err); problem != nil {
+			return nil, problem
+		}
+		if errors.Is(err, namespace.ErrNotOwned) {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
 		if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 			return nil, huma.Error404NotFound("Not found")
 		}
@@ -201,15 +363,16 @@ func createSkillHandler(ctx context.Context, input *CreateSkillInput, registry s
 
 // RegisterSkillsCreateEndpoint registers the public skills create/update endpoint at /skills/publish
 // This endpoint creates or updates a skill in the registry (published defaults to false)
-func RegisterSkillsCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+func RegisterSkillsCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	huma.Register(api, huma.Operation{
-		OperationID: "create-skill" + strings.ReplaceAll(pathPrefix, "/", "-"),
-		Method:      http.MethodPost,
-		Path:        pathPrefix + "/skills/publish",
-		Summary:     "Create/update Agentic skill",
-		Description: "Create a new Agentic skill in the registry or update an existing one. By default, skills are created as unpublished (published=false).",
-		Tags:        []string{"skills", "publish"},
-		Security:    []map[string][]string{{"bearer": {}}},
+		OperationID:  "create-skill" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/skills/publish",
+		Summary:      "Create/update Agentic skill",
+		Description:  "Create a new Agentic skill in the registry or update an existing one. By default, skills are created as unpublished (published=false).",
+		Tags:         []string{"skills", "publish"},
+		MaxBodyBytes: publishBodyLimit(cfg),
+		Security:     []map[string][]string{{"bearer": {}}},
 	}, func(ctx context.Context, input *CreateSkillInput) (*Response[skillmodels.SkillResponse], error) {
 		return createSkillHandler(ctx, input, registry)
 	})
@@ -217,18 +380,31 @@ func RegisterSkillsCreateEndpoint(api huma.API, pathPrefix string, registry serv
 
 // RegisterAdminSkillsCreateEndpoint registers the admin skills create/update endpoint at /skills
 // This endpoint creates or updates a skill in the registry (published defaults to false)
-func RegisterAdminSkillsCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+func RegisterAdminSkillsCreateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	huma.Register(api, huma.Operation{
-		OperationID: "admin-create-skill" + strings.ReplaceAll(pathPrefix, "/", "-"),
-		Method:      http.MethodPost,
-		Path:        pathPrefix + "/skills",
-		Summary:     "Create/update Agentic skill (Admin)",
-		Description: "Create a new Agentic skill in the registry or update an existing one. By default, skills are created as unpublished (published=false).",
-		Tags:        []string{"skills", "admin"},
+		OperationID:  "admin-create-skill" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/skills",
+		Summary:      "Create/update Agentic skill (Admin)",
+		Description:  "Create a new Agentic skill in the registry or update an existing one. By default, skills are created as unpublished (published=false).",
+		Tags:         []string{"skills", "admin"},
+		MaxBodyBytes: publishBodyLimit(cfg),
 	}, func(ctx context.Context, input *CreateSkillInput) (*Response[skillmodels.SkillResponse], error) {
 		// Create/update the skill (published defaults to false in the service layer)
 		createdSkill, err := registry.CreateSkill(ctx, &input.Body)
 		if err != nil {
+			if problem := validationProblem(err); problem != nil {
+				return nil, problem
+			}
+			if problem := sizeProblem(err); problem != nil {
+				return nil, problem
+			}
+			if problem := quotaProblem(err); problem != nil {
+				return nil, problem
+			}
+			if errors.Is(err, namespace.ErrNotOwned) {
+				return nil, huma.Error403Forbidden(err.Error())
+			}
 			if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
 				return nil, huma.Error404NotFound("Not found")
 			}
@@ -309,4 +485,69 @@ func RegisterSkillsPublishStatusEndpoints(api huma.API, pathPrefix string, regis
 			},
 		}, nil
 	})
+
+	// Versions status endpoint - lists the published/lifecycle state of every version
+	huma.Register(api, huma.Operation{
+		OperationID: "get-skill-versions-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/skills/{skillName}/versions-status",
+		Summary:     "Get publish state of all skill versions",
+		Description: "List every version of a skill with its lifecycle status and whether it is published.",
+		Tags:        []string{"skills", "admin"},
+	}, func(ctx context.Context, input *SkillVersionsInput) (*Response[skillmodels.SkillVersionsStatusResponse], error) {
+		skillName, err := url.PathUnescape(input.SkillName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid skill name encoding", err)
+		}
+
+		statuses, err := registry.GetSkillVersionStatuses(ctx, skillName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Skill not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get skill versions status", err)
+		}
+
+		versions := make([]skillmodels.SkillVersionStatus, len(statuses))
+		for i, st := range statuses {
+			versions[i] = *st
+		}
+		return &Response[skillmodels.SkillVersionsStatusResponse]{
+			Body: skillmodels.SkillVersionsStatusResponse{
+				Name:     skillName,
+				Versions: versions,
+			},
+		}, nil
+	})
+
+	// Status endpoint - marks a skill version active, deprecated or deleted
+	huma.Register(api, huma.Operation{
+		OperationID: "set-skill-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/skills/{skillName}/versions/{version}/status",
+		Summary:     "Change the status of a skill version",
+		Description: "Set the lifecycle status of a skill version to active, deprecated or deleted. Deleted skills cannot be undeleted.",
+		Tags:        []string{"skills", "admin"},
+	}, func(ctx context.Context, input *SetSkillStatusInput) (*Response[skillmodels.SkillResponse], error) {
+		skillName, err := url.PathUnescape(input.SkillName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid skill name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		updated, err := registry.SetSkillStatus(ctx, skillName, version, input.Status)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Skill not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to change skill status", err)
+		}
+		return &Response[skillmodels.SkillResponse]{Body: *updated}, nil
+	})
 }
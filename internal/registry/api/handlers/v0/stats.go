@@ -0,0 +1,108 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ServerStatsInput identifies the server whose usage counts are read
+type ServerStatsInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// AgentStatsInput identifies the agent whose usage counts are read
+type AgentStatsInput struct {
+	AgentName string `path:"agentName" json:"agentName" doc:"URL-encoded agent name" example:"com.example%2Fmy-agent"`
+}
+
+// SkillStatsInput identifies the skill whose usage counts are read
+type SkillStatsInput struct {
+	SkillName string `path:"skillName" json:"skillName" doc:"URL-encoded skill name" example:"com.example%2Fmy-skill"`
+}
+
+// UsagePingInput represents an anonymous usage event reported by the CLI
+type UsagePingInput struct {
+	Body models.UsagePing
+}
+
+// RegisterStatsEndpoints registers the usage statistics endpoints of servers, agents and skills and the endpoint
+// the CLI reports anonymous usage to
+func RegisterStatsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-stats" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/stats",
+		Summary:     "Get server usage statistics",
+		Description: "Get how often a server was downloaded, deployed and installed, across all of its versions.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerStatsInput) (*Response[models.ArtifactStats], error) {
+		return getArtifactStats(ctx, registry, "mcp", input.ServerName, "Server")
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-agent-stats" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/agents/{agentName}/stats",
+		Summary:     "Get agent usage statistics",
+		Description: "Get how often an agent was downloaded, deployed and installed, across all of its versions.",
+		Tags:        []string{"agents"},
+	}, func(ctx context.Context, input *AgentStatsInput) (*Response[models.ArtifactStats], error) {
+		return getArtifactStats(ctx, registry, "agent", input.AgentName, "Agent")
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-skill-stats" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/skills/{skillName}/stats",
+		Summary:     "Get skill usage statistics",
+		Description: "Get how often a skill was downloaded and installed, across all of its versions.",
+		Tags:        []string{"skills"},
+	}, func(ctx context.Context, input *SkillStatsInput) (*Response[models.ArtifactStats], error) {
+		return getArtifactStats(ctx, registry, "skill", input.SkillName, "Skill")
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "report-usage" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/usage",
+		Summary:       "Report anonymous usage",
+		Description:   "Count a download or install of a server, agent or skill. The CLI reports these unless telemetry is disabled; no user or machine details are stored.",
+		Tags:          []string{"stats"},
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *UsagePingInput) (*Response[EmptyResponse], error) {
+		if err := registry.RecordArtifactUsage(ctx, input.Body.ArtifactType, input.Body.Name, input.Body.Event); err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error404NotFound("Not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to record usage", err)
+		}
+		return &Response[EmptyResponse]{Body: EmptyResponse{Message: "Usage recorded"}}, nil
+	})
+}
+
+func getArtifactStats(ctx context.Context, registry service.RegistryService, artifactType, rawName, label string) (*Response[models.ArtifactStats], error) {
+	name, err := url.PathUnescape(rawName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid " + strings.ToLower(label) + " name encoding")
+	}
+	stats, err := registry.GetArtifactStats(ctx, artifactType, name)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) || errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+			return nil, huma.Error404NotFound(label + " not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get "+strings.ToLower(label)+" stats", err)
+	}
+	return &Response[models.ArtifactStats]{Body: *stats}, nil
+}
@@ -0,0 +1,60 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// PruneInput selects whether a prune only reports what it would remove
+type PruneInput struct {
+	DryRun bool `query:"dryRun" json:"dryRun,omitempty" doc:"Only report what would be removed" default:"false"`
+}
+
+// RegisterSystemEndpoints registers the admin endpoints maintaining the runtime of the registry
+func RegisterSystemEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"system", "admin"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "prune-runtime" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/system/prune",
+		Summary:     "Prune runtime artifacts",
+		Description: "Remove the container images, runtime directories and gateway configs no deployment uses anymore, and report their sizes. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *PruneInput) (*Response[models.PruneReport], error) {
+		report, err := registry.PruneRuntime(ctx, input.DryRun)
+		if err != nil {
+			return nil, systemError(err, "Failed to prune runtime artifacts")
+		}
+		return &Response[models.PruneReport]{Body: *report}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-system-usage" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/system/df",
+		Summary:     "Get disk usage",
+		Description: "Report the size of the registry database, the runtime directory, and the images and volumes of the runtime on every docker target. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, _ *struct{}) (*Response[models.SystemUsage], error) {
+		usage, err := registry.GetSystemUsage(ctx)
+		if err != nil {
+			return nil, systemError(err, "Failed to get disk usage")
+		}
+		return &Response[models.SystemUsage]{Body: *usage}, nil
+	})
+}
+
+func systemError(err error, msg string) error {
+	if errors.Is(err, auth.ErrForbidden) || errors.Is(err, auth.ErrUnauthenticated) {
+		return huma.Error403Forbidden("Runtime maintenance requires registry admin permissions")
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
@@ -0,0 +1,37 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// RegisterTagsEndpoints registers the endpoint listing the tags of published servers and agents
+func RegisterTagsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tags" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/tags",
+		Summary:     "List tags",
+		Description: "List the tags of published servers and agents with how many of each carry them, most used first. Counts are refreshed every few minutes.",
+		Tags:        []string{"tags"},
+	}, func(ctx context.Context, _ *struct{}) (*Response[models.TagListResponse], error) {
+		tags, err := registry.ListTags(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list tags", err)
+		}
+		return &Response[models.TagListResponse]{Body: models.TagListResponse{Tags: tags}}, nil
+	})
+}
+
+// parseTagsParam splits a comma-separated tags query parameter into normalized tags
+func parseTagsParam(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	return models.NormalizeTags(strings.Split(raw, ","))
+}
@@ -0,0 +1,103 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// TrashServerInput identifies a deleted server version in the trash
+type TrashServerInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" json:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
+// RegisterTrashEndpoints registers the admin endpoints listing, restoring and purging deleted server versions
+func RegisterTrashEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"servers", "admin"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-trash" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/trash",
+		Summary:     "List deleted servers",
+		Description: "List the deleted server versions that can still be restored, most recently deleted first, with when they will be purged. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, _ *struct{}) (*Response[models.TrashListResponse], error) {
+		entries, err := registry.ListTrash(ctx)
+		if err != nil {
+			return nil, trashError(err, "Failed to list deleted servers")
+		}
+		return &Response[models.TrashListResponse]{Body: models.TrashListResponse{Entries: entries}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/trash/servers/{serverName}/versions/{version}/restore",
+		Summary:     "Restore deleted server",
+		Description: "Restore a deleted server version from the trash. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *TrashServerInput) (*Response[EmptyResponse], error) {
+		serverName, version, err := decodeTrashServer(input)
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.RestoreServer(ctx, serverName, version); err != nil {
+			return nil, trashError(err, "Failed to restore server")
+		}
+		return &Response[EmptyResponse]{Body: EmptyResponse{Message: "Server restored successfully"}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "purge-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/trash/servers/{serverName}/versions/{version}",
+		Summary:     "Purge deleted server",
+		Description: "Permanently remove a deleted server version from the trash. Requires registry admin permissions.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *TrashServerInput) (*Response[EmptyResponse], error) {
+		serverName, version, err := decodeTrashServer(input)
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.PurgeServer(ctx, serverName, version); err != nil {
+			return nil, trashError(err, "Failed to purge server")
+		}
+		return &Response[EmptyResponse]{Body: EmptyResponse{Message: "Server purged successfully"}}, nil
+	})
+}
+
+func decodeTrashServer(input *TrashServerInput) (string, string, error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+	version, err := url.PathUnescape(input.Version)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid version encoding", err)
+	}
+	return serverName, version, nil
+}
+
+func trashError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Server not found in the trash")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Forbidden")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
@@ -0,0 +1,107 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// VisibilityBody is the body of a request changing the visibility of an entry
+type VisibilityBody struct {
+	Visibility string `json:"visibility" doc:"Who can see the entry: public, unlisted (left out of listings and search) or private (workspace members only)" enum:"public,unlisted,private"`
+}
+
+// SetServerVisibilityInput changes the visibility of a server version
+type SetServerVisibilityInput struct {
+	ServerName string `path:"serverName" json:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" json:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Body       VisibilityBody
+}
+
+// SetAgentVisibilityInput changes the visibility of an agent version
+type SetAgentVisibilityInput struct {
+	AgentName string `path:"agentName" json:"agentName" doc:"URL-encoded agent name" example:"my-agent"`
+	Version   string `path:"version" json:"version" doc:"URL-encoded agent version" example:"1.0.0"`
+	Body      VisibilityBody
+}
+
+// SetSkillVisibilityInput changes the visibility of a skill version
+type SetSkillVisibilityInput struct {
+	SkillName string `path:"skillName" json:"skillName" doc:"URL-encoded skill name" example:"my-skill"`
+	Version   string `path:"version" json:"version" doc:"URL-encoded skill version" example:"1.0.0"`
+	Body      VisibilityBody
+}
+
+// RegisterVisibilityEndpoints registers the endpoints changing the visibility of server, agent and skill versions
+func RegisterVisibilityEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-visibility" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/visibility",
+		Summary:     "Set MCP server visibility",
+		Description: "Make a server version public, unlisted or private to its workspace.",
+		Tags:        []string{"servers"},
+		Security:    security,
+	}, func(ctx context.Context, input *SetServerVisibilityInput) (*Response[EmptyResponse], error) {
+		return setVisibility(ctx, registry, "mcp", "Server", input.ServerName, input.Version, input.Body.Visibility)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-agent-visibility" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/agents/{agentName}/versions/{version}/visibility",
+		Summary:     "Set agent visibility",
+		Description: "Make an agent version public, unlisted or private to its workspace.",
+		Tags:        []string{"agents"},
+		Security:    security,
+	}, func(ctx context.Context, input *SetAgentVisibilityInput) (*Response[EmptyResponse], error) {
+		return setVisibility(ctx, registry, "agent", "Agent", input.AgentName, input.Version, input.Body.Visibility)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-skill-visibility" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/skills/{skillName}/versions/{version}/visibility",
+		Summary:     "Set skill visibility",
+		Description: "Make a skill version public, unlisted or private to its workspace.",
+		Tags:        []string{"skills"},
+		Security:    security,
+	}, func(ctx context.Context, input *SetSkillVisibilityInput) (*Response[EmptyResponse], error) {
+		return setVisibility(ctx, registry, "skill", "Skill", input.SkillName, input.Version, input.Body.Visibility)
+	})
+}
+
+func setVisibility(ctx context.Context, registry service.RegistryService, artifactType, kind, rawName, rawVersion, visibility string) (*Response[EmptyResponse], error) {
+	name, err := url.PathUnescape(rawName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid "+strings.ToLower(kind)+" name encoding", err)
+	}
+	version, err := url.PathUnescape(rawVersion)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid version encoding", err)
+	}
+
+	if err := registry.SetVisibility(ctx, artifactType, name, version, visibility); err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			return nil, huma.Error404NotFound(kind + " not found")
+		case errors.Is(err, auth.ErrUnauthenticated):
+			return nil, huma.Error401Unauthorized("Authentication required")
+		case errors.Is(err, auth.ErrForbidden):
+			return nil, huma.Error403Forbidden("Forbidden")
+		case errors.Is(err, database.ErrInvalidInput):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("Failed to set visibility", err)
+	}
+	return &Response[EmptyResponse]{Body: EmptyResponse{Message: kind + " visibility set to " + visibility}}, nil
+}
@@ -0,0 +1,285 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// WorkspaceInput identifies a workspace
+type WorkspaceInput struct {
+	WorkspaceName string `path:"workspaceName" json:"workspaceName" doc:"Workspace name" example:"data-platform"`
+}
+
+// WorkspaceEntriesInput lists the entries of a workspace
+type WorkspaceEntriesInput struct {
+	WorkspaceName string `path:"workspaceName" json:"workspaceName" doc:"Workspace name" example:"data-platform"`
+	Cursor        string `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false"`
+	Limit         int    `query:"limit" json:"limit,omitempty" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+}
+
+// CreateWorkspaceInput is the body of a request creating a workspace
+type CreateWorkspaceInput struct {
+	Body models.Workspace
+}
+
+// WorkspaceMemberInput identifies a member of a workspace
+type WorkspaceMemberInput struct {
+	WorkspaceName string `path:"workspaceName" json:"workspaceName" doc:"Workspace name" example:"data-platform"`
+	Subject       string `path:"subject" json:"subject" doc:"URL-encoded member subject" example:"alice%40example.com"`
+}
+
+// SetWorkspaceMemberInput adds a member to a workspace or changes their role
+type SetWorkspaceMemberInput struct {
+	WorkspaceName string `path:"workspaceName" json:"workspaceName" doc:"Workspace name" example:"data-platform"`
+	Subject       string `path:"subject" json:"subject" doc:"URL-encoded member subject" example:"alice%40example.com"`
+	Body          struct {
+		Role string `json:"role,omitempty" doc:"Role of the member" enum:"owner,member" default:"member"`
+	}
+}
+
+// RegisterWorkspacesEndpoints registers the endpoints managing workspaces and their members, and listing the entries
+// and deployments that belong to a workspace. Workspace listings include unpublished entries.
+func RegisterWorkspacesEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	tags := []string{"workspaces"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-workspaces" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/workspaces",
+		Summary:     "List workspaces",
+		Description: "List the workspaces the caller is a member of. Registry admins see every workspace.",
+		Tags:        tags,
+	}, func(ctx context.Context, _ *struct{}) (*Response[models.WorkspaceListResponse], error) {
+		workspaces, err := registry.ListWorkspaces(ctx)
+		if err != nil {
+			return nil, workspaceError(err, "Failed to list workspaces")
+		}
+		return &Response[models.WorkspaceListResponse]{Body: models.WorkspaceListResponse{Workspaces: workspaces}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-workspace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/workspaces",
+		Summary:     "Create workspace",
+		Description: "Create a workspace owned by the caller",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CreateWorkspaceInput) (*Response[models.Workspace], error) {
+		workspace, err := registry.CreateWorkspace(ctx, &input.Body)
+		if err != nil {
+			if errors.Is(err, database.ErrAlreadyExists) {
+				return nil, huma.Error409Conflict("Workspace already exists")
+			}
+			return nil, workspaceError(err, "Failed to create workspace")
+		}
+		return &Response[models.Workspace]{Body: *workspace}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-workspace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/workspaces/{workspaceName}",
+		Summary:     "Get workspace",
+		Description: "Get a workspace the caller is a member of",
+		Tags:        tags,
+	}, func(ctx context.Context, input *WorkspaceInput) (*Response[models.Workspace], error) {
+		workspace, err := registry.GetWorkspace(ctx, input.WorkspaceName)
+		if err != nil {
+			return nil, workspaceError(err, "Failed to get workspace")
+		}
+		return &Response[models.Workspace]{Body: *workspace}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-workspace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/workspaces/{workspaceName}",
+		Summary:     "Delete workspace",
+		Description: "Delete a workspace with its members and API tokens. Entries and deployments must be removed first.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *WorkspaceInput) (*Response[EmptyResponse], error) {
+		if err := registry.DeleteWorkspace(ctx, input.WorkspaceName); err != nil {
+			return nil, workspaceError(err, "Failed to delete workspace")
+		}
+		return &Response[EmptyResponse]{Body: EmptyResponse{Message: "Workspace deleted successfully"}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-workspace-members" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/workspaces/{workspaceName}/members",
+		Summary:     "List workspace members",
+		Description: "List the members of a workspace, owners first",
+		Tags:        tags,
+	}, func(ctx context.Context, input *WorkspaceInput) (*Response[models.WorkspaceMemberListResponse], error) {
+		members, err := registry.ListWorkspaceMembers(ctx, input.WorkspaceName)
+		if err != nil {
+			return nil, workspaceError(err, "Failed to list workspace members")
+		}
+		return &Response[models.WorkspaceMemberListResponse]{Body: models.WorkspaceMemberListResponse{Members: members}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-workspace-member" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/workspaces/{workspaceName}/members/{subject}",
+		Summary:     "Add workspace member",
+		Description: "Add a member to a workspace or change their role. Only owners may manage members.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *SetWorkspaceMemberInput) (*Response[models.WorkspaceMember], error) {
+		subject, err := url.PathUnescape(input.Subject)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid subject encoding", err)
+		}
+		member, err := registry.SetWorkspaceMember(ctx, input.WorkspaceName, subject, input.Body.Role)
+		if err != nil {
+			return nil, workspaceError(err, "Failed to set workspace member")
+		}
+		return &Response[models.WorkspaceMember]{Body: *member}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "remove-workspace-member" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/workspaces/{workspaceName}/members/{subject}",
+		Summary:     "Remove workspace member",
+		Description: "Remove a member from a workspace. Members may remove themselves; the last owner cannot be removed.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *WorkspaceMemberInput) (*Response[EmptyResponse], error) {
+		subject, err := url.PathUnescape(input.Subject)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid subject encoding", err)
+		}
+		if err := registry.RemoveWorkspaceMember(ctx, input.WorkspaceName, subject); err != nil {
+			return nil, workspaceError(err, "Failed to remove workspace member")
+		}
+		return &Response[EmptyResponse]{Body: EmptyResponse{Message: "Workspace member removed successfully"}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-workspace-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/workspaces/{workspaceName}/servers",
+		Summary:     "List workspace servers",
+		Description: "List the MCP servers of a workspace, including unpublished ones",
+		Tags:        tags,
+	}, func(ctx context.Context, input *WorkspaceEntriesInput) (*Response[models.ServerListResponse], error) {
+		if err := registry.CheckWorkspaceAccess(ctx, input.WorkspaceName); err != nil {
+			return nil, workspaceError(err, "Failed to list workspace servers")
+		}
+		servers, nextCursor, err := registry.ListServers(ctx, &database.ServerFilter{Workspace: &input.WorkspaceName}, input.Cursor, input.Limit)
+		if err != nil {
+			return nil, workspaceError(err, "Failed to list workspace servers")
+		}
+		values := make([]models.ServerResponse, len(servers))
+		for i, server := range servers {
+			values[i] = normalizeServerResponse(server)
+		}
+		return &Response[models.ServerListResponse]{
+			Body: models.ServerListResponse{
+				Servers:  values,
+				Metadata: models.ServerMetadata{NextCursor: nextCursor, Count: len(servers)},
+			},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-workspace-agents" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/workspaces/{workspaceName}/agents",
+		Summary:     "List workspace agents",
+		Description: "List the agents of a workspace, including unpublished ones",
+		Tags:        tags,
+	}, func(ctx context.Context, input *WorkspaceEntriesInput) (*Response[models.AgentListResponse], error) {
+		if err := registry.CheckWorkspaceAccess(ctx, input.WorkspaceName); err != nil {
+			return nil, workspaceError(err, "Failed to list workspace agents")
+		}
+		agents, nextCursor, err := registry.ListAgents(ctx, &database.AgentFilter{Workspace: &input.WorkspaceName}, input.Cursor, input.Limit)
+		if err != nil {
+			return nil, workspaceError(err, "Failed to list workspace agents")
+		}
+		values := make([]models.AgentResponse, len(agents))
+		for i, a := range agents {
+			values[i] = *a
+		}
+		return &Response[models.AgentListResponse]{
+			Body: models.AgentListResponse{
+				Agents:   values,
+				Metadata: models.AgentMetadata{NextCursor: nextCursor, Count: len(agents)},
+			},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-workspace-skills" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/workspaces/{workspaceName}/skills",
+		Summary:     "List workspace skills",
+		Description: "List the skills of a workspace, including unpublished ones",
+		Tags:        tags,
+	}, func(ctx context.Context, input *WorkspaceEntriesInput) (*Response[models.SkillListResponse], error) {
+		if err := registry.CheckWorkspaceAccess(ctx, input.WorkspaceName); err != nil {
+			return nil, workspaceError(err, "Failed to list workspace skills")
+		}
+		skills, nextCursor, err := registry.ListSkills(ctx, &database.SkillFilter{Workspace: &input.WorkspaceName}, input.Cursor, input.Limit)
+		if err != nil {
+			return nil, workspaceError(err, "Failed to list workspace skills")
+		}
+		values := make([]models.SkillResponse, len(skills))
+		for i, s := range skills {
+			values[i] = *s
+		}
+		return &Response[models.SkillListResponse]{
+			Body: models.SkillListResponse{
+				Skills:   values,
+				Metadata: models.SkillMetadata{NextCursor: nextCursor, Count: len(skills)},
+			},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-workspace-deployments" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/workspaces/{workspaceName}/deployments",
+		Summary:     "List workspace deployments",
+		Description: "List the deployments of a workspace",
+		Tags:        tags,
+	}, func(ctx context.Context, input *WorkspaceInput) (*DeploymentsListResponse, error) {
+		if err := registry.CheckWorkspaceAccess(ctx, input.WorkspaceName); err != nil {
+			return nil, workspaceError(err, "Failed to list workspace deployments")
+		}
+		deployments, err := registry.GetDeployments(ctx, &models.DeploymentFilter{Workspace: &input.WorkspaceName})
+		if err != nil {
+			return nil, workspaceError(err, "Failed to list workspace deployments")
+		}
+		resp := &DeploymentsListResponse{}
+		resp.Body.Deployments = make([]models.Deployment, 0, len(deployments))
+		for _, d := range deployments {
+			resp.Body.Deployments = append(resp.Body.Deployments, *d)
+		}
+		return resp, nil
+	})
+}
+
+func workspaceError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Workspace not found")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden(err.Error())
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// systemdListenFDsStart is the first file descriptor systemd passes to a socket-activated service
+const systemdListenFDsStart = 3
+
+// listen returns the listener the server accepts connections on: the socket passed by systemd socket activation
+// when there is one, otherwise a new socket bound to addr, with SO_REUSEPORT if reusePort is set. Either way a
+// new registry process can take over the address while the old one drains its connections.
+func listen(addr string, reusePort bool) (net.Listener, error) {
+	if ln, ok, err := systemdListener(); ok || err != nil {
+		return ln, err
+	}
+	if reusePort {
+		return listenReusePort(addr)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// systemdListener returns the first socket passed via LISTEN_FDS, reporting false when the process was not
+// socket activated
+func systemdListener() (net.Listener, bool, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, false, nil
+	}
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, false, nil
+	}
+	// Children of the registry must not think the sockets are theirs
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDS")
+	_ = os.Unsetenv("LISTEN_FDNAMES")
+
+	f := os.NewFile(uintptr(systemdListenFDsStart), "LISTEN_FD_3")
+	defer func() { _ = f.Close() }()
+	ln, err := net.FileListener(f)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to use the socket passed by systemd: %w", err)
+	}
+	return ln, true, nil
+}
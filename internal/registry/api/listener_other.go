@@ -0,0 +1,13 @@
+//go:build !unix
+
+package api
+
+import (
+	"fmt"
+	"net"
+)
+
+// listenReusePort is not supported on this platform
+func listenReusePort(addr string) (net.Listener, error) {
+	return nil, fmt.Errorf("SO_REUSEPORT is not supported on this platform; unset AGENT_REGISTRY_SERVER_REUSE_PORT to listen on %s", addr)
+}
@@ -142,11 +142,17 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 	// Create a new API using humago adapter for standard library
 	api := humago.New(mux, humaConfig)
 
+	// Trace every request first so authentication and handlers run inside the request span
+	api.UseMiddleware(TracingMiddleware(WithSkipPaths("/health", "/livez", "/readyz", "/metrics", "/ping")))
+
 	// Add authn middleware if configured
 	if authnProvider != nil {
 		api.UseMiddleware(auth.AuthnMiddleware(authnProvider))
 	}
 
+	// Resolve the workspace of the request once the caller is known
+	api.UseMiddleware(WorkspaceMiddleware(api, registry))
+
 	// Add OpenAPI tag metadata with descriptions
 	api.OpenAPI().Tags = []*huma.Tag{
 		{
@@ -169,6 +175,10 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 			Name:        "auth",
 			Description: "Authentication operations for obtaining tokens to publish servers",
 		},
+		{
+			Name:        "workspaces",
+			Description: "Operations for managing team workspaces and listing their entries",
+		},
 		{
 			Name:        "admin",
 			Description: "Administrative operations for managing servers (requires elevated permissions)",
@@ -189,7 +199,7 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 
 	// Add metrics middleware with options
 	api.UseMiddleware(MetricTelemetryMiddleware(metrics,
-		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
+		WithSkipPaths("/health", "/livez", "/readyz", "/metrics", "/ping", "/docs"),
 	))
 
 	// Register all API routes (public and admin) for all versions
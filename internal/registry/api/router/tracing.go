@@ -0,0 +1,74 @@
+package router
+
+import (
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+)
+
+// headerCarrier adapts huma request headers for trace context extraction
+type headerCarrier struct {
+	ctx huma.Context
+}
+
+func (c headerCarrier) Get(key string) string { return c.ctx.Header(key) }
+
+func (c headerCarrier) Set(string, string) {}
+
+func (c headerCarrier) Keys() []string {
+	var keys []string
+	c.ctx.EachHeader(func(name, _ string) {
+		keys = append(keys, name)
+	})
+	return keys
+}
+
+// TracingMiddleware starts a server span for each request, continuing the trace of the caller
+// when the request carries a traceparent header
+func TracingMiddleware(options ...MiddlewareOption) func(huma.Context, func(huma.Context)) {
+	config := &middlewareConfig{
+		skipPaths: make(map[string]bool),
+	}
+
+	for _, opt := range options {
+		opt(config)
+	}
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		path := ctx.URL().Path
+		pathParts := strings.Split(path, "/")
+		if config.skipPaths["/"+pathParts[len(pathParts)-1]] || config.skipPaths[path] {
+			next(ctx)
+			return
+		}
+
+		routePath := getRoutePath(ctx)
+		parent := otel.GetTextMapPropagator().Extract(ctx.Context(), headerCarrier{ctx: ctx})
+		spanCtx, span := telemetry.Tracer().Start(parent, ctx.Method()+" "+routePath,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", ctx.Method()),
+				attribute.String("http.route", routePath),
+			),
+		)
+		defer span.End()
+
+		if op := ctx.Operation(); op != nil && op.OperationID != "" {
+			span.SetAttributes(attribute.String("operation.id", op.OperationID))
+		}
+
+		next(huma.WithContext(ctx, spanCtx))
+
+		status := ctx.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, "")
+		}
+	}
+}
@@ -27,6 +27,9 @@ func RegisterRoutes(
 	// Admin API endpoints (show all resources, including unpublished)
 	registerAdminRoutes(api, "/admin/v0", cfg, registry, metrics, versionInfo)
 	registerAdminRoutes(api, "/admin/v0.1", cfg, registry, metrics, versionInfo)
+
+	// A2A discovery of the published agents
+	v0.RegisterAgentDiscoveryEndpoint(api, "/v0", registry, cfg)
 }
 
 // registerPublicRoutes registers public API routes for a version
@@ -43,19 +46,45 @@ func registerPublicRoutes(
 	isAdmin := false
 
 	// Common endpoints (available in all versions)
-	registerCommonEndpoints(api, pathPrefix, cfg, metrics, versionInfo)
+	registerCommonEndpoints(api, pathPrefix, cfg, registry, metrics, versionInfo)
 	v0.RegisterServersEndpoints(api, pathPrefix, registry, isAdmin)
-	v0.RegisterCreateEndpoint(api, pathPrefix, registry)
+	v0.RegisterCreateEndpoint(api, pathPrefix, registry, cfg)
+	v0.RegisterServersReadmeUploadEndpoint(api, pathPrefix, registry, cfg)
+	v0.RegisterBatchCreateEndpoints(api, pathPrefix, registry, cfg, pathPrefix == "/v0")
 	v0.RegisterEditEndpoints(api, pathPrefix, registry)
+	v0.RegisterServerRenameEndpoint(api, pathPrefix, registry)
+	v0.RegisterServerSignatureEndpoints(api, pathPrefix, registry)
+	v0.RegisterServerSecurityEndpoints(api, pathPrefix, registry)
+	v0.RegisterServerCapabilitiesEndpoints(api, pathPrefix, registry)
+	v0.RegisterServerChangelogEndpoints(api, pathPrefix, registry, isAdmin)
 	v0auth.RegisterAuthEndpoints(api, pathPrefix, cfg)
 	v0.RegisterDeploymentsEndpoints(api, pathPrefix, registry)
+	v0.RegisterConfigProfilesEndpoints(api, pathPrefix, registry)
 
-	// v0-only endpoints (agents and skills)
+	// v0-only endpoints (agents, skills, tags, audit log, blobs, artifacts and imports)
 	if pathPrefix == "/v0" {
 		v0.RegisterAgentsEndpoints(api, pathPrefix, registry, isAdmin)
-		v0.RegisterAgentsCreateEndpoint(api, pathPrefix, registry)
+		v0.RegisterAgentsCreateEndpoint(api, pathPrefix, registry, cfg)
+		v0.RegisterAgentsReadmeUploadEndpoint(api, pathPrefix, registry, cfg)
+		v0.RegisterAgentSBOMEndpoints(api, pathPrefix, registry)
+		v0.RegisterAgentCardEndpoints(api, pathPrefix, registry, cfg)
 		v0.RegisterSkillsEndpoints(api, pathPrefix, registry, isAdmin)
-		v0.RegisterSkillsCreateEndpoint(api, pathPrefix, registry)
+		v0.RegisterSkillsCreateEndpoint(api, pathPrefix, registry, cfg)
+		v0.RegisterSkillsReadmeUploadEndpoint(api, pathPrefix, registry, cfg)
+		v0.RegisterCollectionsEndpoints(api, pathPrefix, registry, isAdmin)
+		v0.RegisterStatsEndpoints(api, pathPrefix, registry)
+		v0.RegisterQuotaEndpoints(api, pathPrefix, registry)
+		v0.RegisterTagsEndpoints(api, pathPrefix, registry)
+		v0.RegisterReviewsEndpoints(api, pathPrefix, registry, isAdmin)
+		v0.RegisterNamespacesEndpoints(api, pathPrefix, registry)
+		v0.RegisterSchemasEndpoints(api, pathPrefix)
+		v0.RegisterAuditEndpoints(api, pathPrefix, registry)
+		v0.RegisterAPITokenEndpoints(api, pathPrefix, registry)
+		v0.RegisterWorkspacesEndpoints(api, pathPrefix, registry)
+		v0.RegisterVisibilityEndpoints(api, pathPrefix, registry)
+		v0.RegisterBlobsEndpoints(api, pathPrefix, registry)
+		v0.RegisterAttachmentsEndpoints(api, pathPrefix, registry)
+		v0.RegisterImportsEndpoints(api, pathPrefix, registry, cfg, isAdmin)
 	}
 }
 
@@ -73,21 +102,38 @@ func registerAdminRoutes(
 	isAdmin := true
 
 	// Common endpoints
-	registerCommonEndpoints(api, pathPrefix, cfg, metrics, versionInfo)
+	registerCommonEndpoints(api, pathPrefix, cfg, registry, metrics, versionInfo)
 	v0.RegisterServersEndpoints(api, pathPrefix, registry, isAdmin)
-	v0.RegisterAdminCreateEndpoint(api, pathPrefix, registry)
+	v0.RegisterAdminCreateEndpoint(api, pathPrefix, registry, cfg)
+	v0.RegisterServersReadmeUploadEndpoint(api, pathPrefix, registry, cfg)
 	v0.RegisterPublishStatusEndpoints(api, pathPrefix, registry)
+	v0.RegisterServerChangelogEndpoints(api, pathPrefix, registry, isAdmin)
 	v0.RegisterEditEndpoints(api, pathPrefix, registry)
+	v0.RegisterServerRenameEndpoint(api, pathPrefix, registry)
 	v0.RegisterDeploymentsEndpoints(api, pathPrefix, registry)
+	v0.RegisterConfigProfilesEndpoints(api, pathPrefix, registry)
 
-	// v0-only admin endpoints (agents and skills)
+	// v0-only admin endpoints (agents, skills, roles, jobs, imports, policies and trash)
 	if pathPrefix == "/admin/v0" {
 		v0.RegisterAgentsEndpoints(api, pathPrefix, registry, isAdmin)
-		v0.RegisterAdminAgentsCreateEndpoint(api, pathPrefix, registry)
+		v0.RegisterAdminAgentsCreateEndpoint(api, pathPrefix, registry, cfg)
+		v0.RegisterAgentsReadmeUploadEndpoint(api, pathPrefix, registry, cfg)
 		v0.RegisterAgentsPublishStatusEndpoints(api, pathPrefix, registry)
 		v0.RegisterSkillsEndpoints(api, pathPrefix, registry, isAdmin)
-		v0.RegisterAdminSkillsCreateEndpoint(api, pathPrefix, registry)
+		v0.RegisterAdminSkillsCreateEndpoint(api, pathPrefix, registry, cfg)
+		v0.RegisterSkillsReadmeUploadEndpoint(api, pathPrefix, registry, cfg)
 		v0.RegisterSkillsPublishStatusEndpoints(api, pathPrefix, registry)
+		v0.RegisterCollectionsEndpoints(api, pathPrefix, registry, isAdmin)
+		v0.RegisterReviewsEndpoints(api, pathPrefix, registry, isAdmin)
+		v0.RegisterReviewModerationEndpoints(api, pathPrefix, registry)
+		v0.RegisterNamespacesEndpoints(api, pathPrefix, registry)
+		v0.RegisterNamespaceRevokeEndpoint(api, pathPrefix, registry)
+		v0.RegisterRolesEndpoints(api, pathPrefix, registry)
+		v0.RegisterJobsEndpoints(api, pathPrefix, registry)
+		v0.RegisterSystemEndpoints(api, pathPrefix, registry)
+		v0.RegisterImportsEndpoints(api, pathPrefix, registry, cfg, isAdmin)
+		v0.RegisterPoliciesEndpoints(api, pathPrefix, registry)
+		v0.RegisterTrashEndpoints(api, pathPrefix, registry)
 	}
 }
 
@@ -96,10 +142,12 @@ func registerCommonEndpoints(
 	api huma.API,
 	pathPrefix string,
 	cfg *config.Config,
+	registry service.RegistryService,
 	metrics *telemetry.Metrics,
 	versionInfo *v0.VersionBody,
 ) {
 	v0.RegisterHealthEndpoint(api, pathPrefix, cfg, metrics)
+	v0.RegisterLivenessEndpoints(api, pathPrefix, registry)
 	v0.RegisterPingEndpoint(api, pathPrefix)
 	v0.RegisterVersionEndpoint(api, pathPrefix, versionInfo)
 }
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// WorkspaceMiddleware places requests in the workspace named by the X-Workspace header, or in the workspace a
+// workspace API token is bound to, after checking that the caller is a member of it. It must run after
+// authentication.
+func WorkspaceMiddleware(api huma.API, registry service.RegistryService) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		workspace := ctx.Header(auth.WorkspaceHeader)
+		if session, ok := auth.AuthSessionFrom(ctx.Context()); ok {
+			if bound := session.Principal().User.Workspace; bound != "" {
+				if workspace != "" && workspace != bound {
+					_ = huma.WriteErr(api, ctx, http.StatusForbidden, "API token is bound to workspace "+bound)
+					return
+				}
+				workspace = bound
+			}
+		}
+		if workspace == "" {
+			next(ctx)
+			return
+		}
+
+		if err := registry.CheckWorkspaceAccess(ctx.Context(), workspace); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				_ = huma.WriteErr(api, ctx, http.StatusNotFound, "Workspace not found")
+				return
+			}
+			_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "Failed to check workspace access", err)
+			return
+		}
+		next(huma.WithContext(ctx, auth.WorkspaceTo(ctx.Context(), workspace)))
+	}
+}
@@ -144,10 +144,14 @@ func (s *Server) Start() error {
 	log.Printf("HTTP server starting on %s", s.config.ServerAddress)
 	log.Printf("Web UI available at http://localhost%s/", s.config.ServerAddress)
 	log.Printf("API documentation at http://localhost%s/docs", s.config.ServerAddress)
-	return s.server.ListenAndServe()
+	ln, err := listen(s.config.ServerAddress, s.config.ServerReusePort)
+	if err != nil {
+		return err
+	}
+	return s.server.Serve(ln)
 }
 
-// Shutdown gracefully shuts down the server
+// Shutdown stops accepting connections and waits until ctx is done for in-flight requests to finish
 func (s *Server) Shutdown(ctx context.Context) error {
 	return s.server.Shutdown(ctx)
 }
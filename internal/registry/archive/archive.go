@@ -0,0 +1,225 @@
+// Package archive exports an entire registry (servers, READMEs, agents, skills, deployments and embeddings)
+// into a single versioned tar.gz of JSON seeds, and imports such an archive into another registry.
+//
+// The archive contains:
+//
+//	manifest.json     format version, creation time and counts
+//	servers.json      []apiv0.ServerJSON, the same seed format arctl import reads
+//	readmes.json      README seed file keyed by name@version
+//	agents.json       []models.AgentJSON
+//	skills.json       []models.SkillJSON
+//	status.json       status, published and latest flags of every server, agent and skill version
+//	deployments.json  []models.Deployment
+//	embeddings.json   semantic embeddings of servers and agents keyed by name@version
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// FormatVersion is the archive format written by Export. Import rejects archives with a newer format.
+const FormatVersion = 1
+
+const (
+	fileManifest    = "manifest.json"
+	fileServers     = "servers.json"
+	fileReadmes     = "readmes.json"
+	fileAgents      = "agents.json"
+	fileSkills      = "skills.json"
+	fileStatus      = "status.json"
+	fileDeployments = "deployments.json"
+	fileEmbeddings  = "embeddings.json"
+
+	// maxFileSize bounds a single archive member to guard against decompression bombs
+	maxFileSize = 1 << 30
+)
+
+// Manifest describes an archive
+type Manifest struct {
+	FormatVersion   int       `json:"formatVersion"`
+	CreatedAt       time.Time `json:"createdAt"`
+	RegistryVersion string    `json:"registryVersion,omitempty"`
+	Counts          Counts    `json:"counts"`
+}
+
+// Counts tallies the resources in an archive or processed by an import
+type Counts struct {
+	Servers     int `json:"servers"`
+	Readmes     int `json:"readmes"`
+	Agents      int `json:"agents"`
+	Skills      int `json:"skills"`
+	Deployments int `json:"deployments"`
+	Embeddings  int `json:"embeddings"`
+}
+
+// versionState is the registry-managed state of a resource version that its JSON document does not carry
+type versionState struct {
+	Status      string    `json:"status"`
+	Published   bool      `json:"published"`
+	IsLatest    bool      `json:"isLatest"`
+	PublishedAt time.Time `json:"publishedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type statusFile struct {
+	Servers map[string]versionState `json:"servers"`
+	Agents  map[string]versionState `json:"agents"`
+	Skills  map[string]versionState `json:"skills"`
+}
+
+type embedding struct {
+	Vector     []float32 `json:"vector"`
+	Provider   string    `json:"provider,omitempty"`
+	Model      string    `json:"model,omitempty"`
+	Dimensions int       `json:"dimensions"`
+	Checksum   string    `json:"checksum,omitempty"`
+	Generated  time.Time `json:"generatedAt"`
+}
+
+type embeddingsFile struct {
+	Servers map[string]embedding `json:"servers"`
+	Agents  map[string]embedding `json:"agents"`
+}
+
+// contents is the decoded content of an archive
+type contents struct {
+	manifest    Manifest
+	servers     []*apiv0.ServerJSON
+	readmes     seed.ReadmeFile
+	agents      []*models.AgentJSON
+	skills      []*models.SkillJSON
+	status      statusFile
+	deployments []*models.Deployment
+	embeddings  embeddingsFile
+}
+
+func newContents() *contents {
+	return &contents{
+		readmes:    seed.ReadmeFile{},
+		status:     statusFile{Servers: map[string]versionState{}, Agents: map[string]versionState{}, Skills: map[string]versionState{}},
+		embeddings: embeddingsFile{Servers: map[string]embedding{}, Agents: map[string]embedding{}},
+	}
+}
+
+// write encodes the contents as a tar.gz stream
+func (c *contents) write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	files := []struct {
+		name string
+		v    any
+	}{
+		{fileManifest, c.manifest},
+		{fileServers, nonNil(c.servers)},
+		{fileReadmes, c.readmes},
+		{fileAgents, nonNil(c.agents)},
+		{fileSkills, nonNil(c.skills)},
+		{fileStatus, c.status},
+		{fileDeployments, nonNil(c.deployments)},
+		{fileEmbeddings, c.embeddings},
+	}
+	for _, f := range files {
+		data, err := json.MarshalIndent(f.v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", f.name, err)
+		}
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: c.manifest.CreatedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// read decodes a tar.gz stream written by write
+func read(r io.Reader) (*contents, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a registry archive (expected tar.gz): %w", err)
+	}
+	defer gz.Close()
+
+	c := newContents()
+	targets := map[string]any{
+		fileManifest:    &c.manifest,
+		fileServers:     &c.servers,
+		fileReadmes:     &c.readmes,
+		fileAgents:      &c.agents,
+		fileSkills:      &c.skills,
+		fileStatus:      &c.status,
+		fileDeployments: &c.deployments,
+		fileEmbeddings:  &c.embeddings,
+	}
+
+	seenManifest := false
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		target, ok := targets[hdr.Name]
+		if !ok || hdr.Typeflag != tar.TypeReg {
+			// Unknown members are ignored so newer minor additions stay readable
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxFileSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+		if len(data) > maxFileSize {
+			return nil, fmt.Errorf("%s exceeds the maximum size of %d bytes", hdr.Name, maxFileSize)
+		}
+		if err := json.Unmarshal(data, target); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", hdr.Name, err)
+		}
+		if hdr.Name == fileManifest {
+			seenManifest = true
+		}
+	}
+
+	if !seenManifest {
+		return nil, fmt.Errorf("not a registry archive: %s is missing", fileManifest)
+	}
+	if c.manifest.FormatVersion < 1 || c.manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %d (this build supports up to %d)", c.manifest.FormatVersion, FormatVersion)
+	}
+	return c, nil
+}
+
+// nonNil makes empty lists encode as [] rather than null
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
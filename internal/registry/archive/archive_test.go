@@ -0,0 +1,74 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	c := newContents()
+	c.manifest = Manifest{FormatVersion: FormatVersion, CreatedAt: time.Now().UTC().Truncate(time.Second), RegistryVersion: "v1.2.3"}
+	c.servers = []*apiv0.ServerJSON{{Name: "io.github.acme/weather", Version: "1.0.0", Description: "Weather"}}
+	c.readmes[seed.Key("io.github.acme/weather", "1.0.0")] = seed.EncodeReadme([]byte("# Weather"), "text/markdown")
+	c.agents = []*models.AgentJSON{{AgentManifest: models.AgentManifest{Name: "planner"}, Version: "0.1.0"}}
+	c.status.Servers[seed.Key("io.github.acme/weather", "1.0.0")] = versionState{Status: "active", Published: true, IsLatest: true}
+	c.embeddings.Agents[seed.Key("planner", "0.1.0")] = embedding{Vector: []float32{0.5, -1}, Dimensions: 2}
+
+	var buf bytes.Buffer
+	require.NoError(t, c.write(&buf))
+
+	got, err := read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, c.manifest, got.manifest)
+	require.Len(t, got.servers, 1)
+	assert.Equal(t, "Weather", got.servers[0].Description)
+	require.Len(t, got.agents, 1)
+	assert.Equal(t, "planner", got.agents[0].Name)
+	assert.Empty(t, got.skills)
+	assert.True(t, got.status.Servers[seed.Key("io.github.acme/weather", "1.0.0")].IsLatest)
+	assert.Equal(t, []float32{0.5, -1}, got.embeddings.Agents[seed.Key("planner", "0.1.0")].Vector)
+
+	content, contentType, err := got.readmes[seed.Key("io.github.acme/weather", "1.0.0")].Decode()
+	require.NoError(t, err)
+	assert.Equal(t, "# Weather", string(content))
+	assert.Equal(t, "text/markdown", contentType)
+}
+
+func TestReadRejectsNewerFormat(t *testing.T) {
+	c := newContents()
+	c.manifest = Manifest{FormatVersion: FormatVersion + 1}
+	var buf bytes.Buffer
+	require.NoError(t, c.write(&buf))
+
+	_, err := read(&buf)
+	assert.ErrorContains(t, err, "unsupported archive format version")
+}
+
+func TestReadRequiresManifest(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	data, err := json.Marshal([]apiv0.ServerJSON{})
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: fileServers, Mode: 0o644, Size: int64(len(data))}))
+	_, err = tw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	_, err = read(&buf)
+	assert.ErrorContains(t, err, "manifest.json is missing")
+
+	_, err = read(bytes.NewReader([]byte(`[{"name": "not an archive"}]`)))
+	assert.ErrorContains(t, err, "not a registry archive")
+}
@@ -0,0 +1,201 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const pageSize = 100
+
+// Export writes every server, README, agent, skill, deployment and embedding in the database to w as a tar.gz archive
+func Export(ctx context.Context, db database.Database, w io.Writer, registryVersion string) (*Manifest, error) {
+	c := newContents()
+	c.manifest = Manifest{
+		FormatVersion:   FormatVersion,
+		CreatedAt:       time.Now().UTC(),
+		RegistryVersion: registryVersion,
+	}
+
+	if err := exportServers(ctx, db, c); err != nil {
+		return nil, err
+	}
+	if err := exportAgents(ctx, db, c); err != nil {
+		return nil, err
+	}
+	if err := exportSkills(ctx, db, c); err != nil {
+		return nil, err
+	}
+
+	deployments, err := db.GetDeployments(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	c.deployments = deployments
+
+	c.manifest.Counts = Counts{
+		Servers:     len(c.servers),
+		Readmes:     len(c.readmes),
+		Agents:      len(c.agents),
+		Skills:      len(c.skills),
+		Deployments: len(c.deployments),
+		Embeddings:  len(c.embeddings.Servers) + len(c.embeddings.Agents),
+	}
+
+	if err := c.write(w); err != nil {
+		return nil, err
+	}
+	return &c.manifest, nil
+}
+
+func exportServers(ctx context.Context, db database.Database, c *contents) error {
+	cursor := ""
+	for {
+		records, next, err := db.ListServers(ctx, nil, nil, cursor, pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, record := range records {
+			if record == nil {
+				continue
+			}
+			server := record.Server
+			key := seed.Key(server.Name, server.Version)
+			c.servers = append(c.servers, &server)
+
+			published, err := db.IsServerPublished(ctx, nil, server.Name, server.Version)
+			if err != nil {
+				return fmt.Errorf("failed to read publish state of %s: %w", key, err)
+			}
+			c.status.Servers[key] = serverState(record.Meta.Official, published)
+
+			readme, err := db.GetServerReadme(ctx, nil, server.Name, server.Version)
+			if err != nil && !errors.Is(err, database.ErrNotFound) {
+				return fmt.Errorf("failed to fetch README of %s: %w", key, err)
+			}
+			if readme != nil && len(readme.Content) > 0 {
+				c.readmes[key] = seed.EncodeReadme(readme.Content, readme.ContentType)
+			}
+
+			emb, err := db.GetServerEmbedding(ctx, nil, server.Name, server.Version)
+			if err != nil {
+				return fmt.Errorf("failed to fetch embedding of %s: %w", key, err)
+			}
+			if emb != nil {
+				c.embeddings.Servers[key] = fromSemanticEmbedding(emb)
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func exportAgents(ctx context.Context, db database.Database, c *contents) error {
+	cursor := ""
+	for {
+		records, next, err := db.ListAgents(ctx, nil, nil, cursor, pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list agents: %w", err)
+		}
+		for _, record := range records {
+			if record == nil {
+				continue
+			}
+			agent := record.Agent
+			key := seed.Key(agent.Name, agent.Version)
+			c.agents = append(c.agents, &agent)
+			if official := record.Meta.Official; official != nil {
+				c.status.Agents[key] = versionState{
+					Status:      official.Status,
+					Published:   official.Published,
+					IsLatest:    official.IsLatest,
+					PublishedAt: official.PublishedAt,
+					UpdatedAt:   official.UpdatedAt,
+				}
+			}
+
+			emb, err := db.GetAgentEmbedding(ctx, nil, agent.Name, agent.Version)
+			if err != nil {
+				return fmt.Errorf("failed to fetch embedding of agent %s: %w", key, err)
+			}
+			if emb != nil {
+				c.embeddings.Agents[key] = fromSemanticEmbedding(emb)
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func exportSkills(ctx context.Context, db database.Database, c *contents) error {
+	cursor := ""
+	for {
+		records, next, err := db.ListSkills(ctx, nil, nil, cursor, pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list skills: %w", err)
+		}
+		for _, record := range records {
+			if record == nil {
+				continue
+			}
+			skill := record.Skill
+			c.skills = append(c.skills, &skill)
+			if official := record.Meta.Official; official != nil {
+				c.status.Skills[seed.Key(skill.Name, skill.Version)] = versionState{
+					Status:      official.Status,
+					Published:   official.Published,
+					IsLatest:    official.IsLatest,
+					PublishedAt: official.PublishedAt,
+					UpdatedAt:   official.UpdatedAt,
+				}
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func serverState(official *apiv0.RegistryExtensions, published bool) versionState {
+	state := versionState{Published: published}
+	if official != nil {
+		state.Status = string(official.Status)
+		state.IsLatest = official.IsLatest
+		state.PublishedAt = official.PublishedAt
+		state.UpdatedAt = official.UpdatedAt
+	}
+	return state
+}
+
+func fromSemanticEmbedding(e *database.SemanticEmbedding) embedding {
+	return embedding{
+		Vector:     e.Vector,
+		Provider:   e.Provider,
+		Model:      e.Model,
+		Dimensions: e.Dimensions,
+		Checksum:   e.Checksum,
+		Generated:  e.Generated,
+	}
+}
+
+func (e embedding) toSemanticEmbedding() *database.SemanticEmbedding {
+	return &database.SemanticEmbedding{
+		Vector:     e.Vector,
+		Provider:   e.Provider,
+		Model:      e.Model,
+		Dimensions: e.Dimensions,
+		Checksum:   e.Checksum,
+		Generated:  e.Generated,
+	}
+}
@@ -0,0 +1,270 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ImportResult reports what an import created and what already existed in the target registry
+type ImportResult struct {
+	Manifest Manifest `json:"manifest"`
+	Created  Counts   `json:"created"`
+	Skipped  Counts   `json:"skipped"`
+}
+
+// Import restores an archive written by Export. Versions that already exist in the target registry are skipped,
+// so an import can be re-run after a partial failure. Registry validation is bypassed: the archive is
+// restored as it was, including status, publish state and which version is latest.
+func Import(ctx context.Context, db database.Database, r io.Reader) (*ImportResult, error) {
+	c, err := read(r)
+	if err != nil {
+		return nil, err
+	}
+	result := &ImportResult{Manifest: c.manifest}
+
+	for _, server := range c.servers {
+		created, err := importServer(ctx, db, c, server, result)
+		if err != nil {
+			return result, fmt.Errorf("failed to import server %s: %w", seed.Key(server.Name, server.Version), err)
+		}
+		if created {
+			result.Created.Servers++
+		} else {
+			result.Skipped.Servers++
+		}
+	}
+
+	for _, agent := range c.agents {
+		created, err := importAgent(ctx, db, c, agent, result)
+		if err != nil {
+			return result, fmt.Errorf("failed to import agent %s: %w", seed.Key(agent.Name, agent.Version), err)
+		}
+		if created {
+			result.Created.Agents++
+		} else {
+			result.Skipped.Agents++
+		}
+	}
+
+	for _, skill := range c.skills {
+		created, err := importSkill(ctx, db, c, skill)
+		if err != nil {
+			return result, fmt.Errorf("failed to import skill %s: %w", seed.Key(skill.Name, skill.Version), err)
+		}
+		if created {
+			result.Created.Skills++
+		} else {
+			result.Skipped.Skills++
+		}
+	}
+
+	for _, dep := range c.deployments {
+		if dep == nil || dep.IsExternal {
+			continue
+		}
+		err := db.CreateDeployment(ctx, nil, dep)
+		switch {
+		case errors.Is(err, database.ErrAlreadyExists):
+			result.Skipped.Deployments++
+		case err != nil:
+			return result, fmt.Errorf("failed to import deployment %s (%s): %w", seed.Key(dep.ServerName, dep.Version), dep.ResourceType, err)
+		default:
+			result.Created.Deployments++
+		}
+	}
+
+	return result, nil
+}
+
+func importServer(ctx context.Context, db database.Database, c *contents, server *apiv0.ServerJSON, result *ImportResult) (bool, error) {
+	key := seed.Key(server.Name, server.Version)
+	state := stateOrDefault(c.status.Servers[key])
+
+	var created bool
+	err := db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		exists, err := db.CheckVersionExists(ctx, tx, server.Name, server.Version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		if err := db.AcquirePublishLock(ctx, tx, server.Name); err != nil {
+			return err
+		}
+		// The archive decides which version is latest
+		if state.IsLatest {
+			if err := db.UnmarkAsLatest(ctx, tx, server.Name); err != nil {
+				return err
+			}
+		}
+		if _, err := db.CreateServer(ctx, tx, server, &apiv0.RegistryExtensions{
+			Status:      model.Status(state.Status),
+			PublishedAt: state.PublishedAt,
+			UpdatedAt:   state.UpdatedAt,
+			IsLatest:    state.IsLatest,
+		}); err != nil {
+			return err
+		}
+		if state.Published {
+			if err := db.PublishServer(ctx, tx, server.Name, server.Version); err != nil {
+				return err
+			}
+		}
+
+		if entry, ok := c.readmes[key]; ok {
+			content, contentType, err := entry.Decode()
+			if err != nil {
+				return fmt.Errorf("invalid README: %w", err)
+			}
+			if len(content) > 0 {
+				if contentType == "" {
+					contentType = "text/markdown"
+				}
+				if err := db.UpsertServerReadme(ctx, tx, &database.ServerReadme{
+					ServerName:  server.Name,
+					Version:     server.Version,
+					Content:     content,
+					ContentType: contentType,
+					SizeBytes:   len(content),
+					FetchedAt:   time.Now(),
+				}); err != nil {
+					return err
+				}
+				result.Created.Readmes++
+			}
+		}
+
+		created = true
+		return nil
+	})
+	if err != nil || !created {
+		return created, err
+	}
+
+	// Embeddings are written outside the transaction: they can be regenerated, so a dimension mismatch
+	// with the target registry must not roll back the server itself
+	if emb, ok := c.embeddings.Servers[key]; ok && len(emb.Vector) > 0 {
+		if err := db.SetServerEmbedding(ctx, nil, server.Name, server.Version, emb.toSemanticEmbedding()); err != nil {
+			log.Printf("Warning: skipping embedding of server %s: %v", key, err)
+		} else {
+			result.Created.Embeddings++
+		}
+	}
+	return true, nil
+}
+
+func importAgent(ctx context.Context, db database.Database, c *contents, agent *models.AgentJSON, result *ImportResult) (bool, error) {
+	key := seed.Key(agent.Name, agent.Version)
+	state := stateOrDefault(c.status.Agents[key])
+
+	var created bool
+	err := db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		exists, err := db.CheckAgentVersionExists(ctx, tx, agent.Name, agent.Version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		if state.IsLatest {
+			if err := db.UnmarkAgentAsLatest(ctx, tx, agent.Name); err != nil {
+				return err
+			}
+		}
+		if _, err := db.CreateAgent(ctx, tx, agent, &models.AgentRegistryExtensions{
+			Status:      state.Status,
+			PublishedAt: state.PublishedAt,
+			UpdatedAt:   state.UpdatedAt,
+			IsLatest:    state.IsLatest,
+		}); err != nil {
+			return err
+		}
+		if state.Published {
+			if err := db.PublishAgent(ctx, tx, agent.Name, agent.Version); err != nil {
+				return err
+			}
+		}
+
+		created = true
+		return nil
+	})
+	if err != nil || !created {
+		return created, err
+	}
+
+	if emb, ok := c.embeddings.Agents[key]; ok && len(emb.Vector) > 0 {
+		if err := db.SetAgentEmbedding(ctx, nil, agent.Name, agent.Version, emb.toSemanticEmbedding()); err != nil {
+			log.Printf("Warning: skipping embedding of agent %s: %v", key, err)
+		} else {
+			result.Created.Embeddings++
+		}
+	}
+	return true, nil
+}
+
+func importSkill(ctx context.Context, db database.Database, c *contents, skill *models.SkillJSON) (bool, error) {
+	state := stateOrDefault(c.status.Skills[seed.Key(skill.Name, skill.Version)])
+
+	var created bool
+	err := db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		exists, err := db.CheckSkillVersionExists(ctx, tx, skill.Name, skill.Version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		if state.IsLatest {
+			if err := db.UnmarkSkillAsLatest(ctx, tx, skill.Name); err != nil {
+				return err
+			}
+		}
+		if _, err := db.CreateSkill(ctx, tx, skill, &models.SkillRegistryExtensions{
+			Status:      state.Status,
+			PublishedAt: state.PublishedAt,
+			UpdatedAt:   state.UpdatedAt,
+			IsLatest:    state.IsLatest,
+		}); err != nil {
+			return err
+		}
+		if state.Published {
+			if err := db.PublishSkill(ctx, tx, skill.Name, skill.Version); err != nil {
+				return err
+			}
+		}
+
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// stateOrDefault fills in the state of a version missing from status.json (e.g. a hand-edited archive)
+func stateOrDefault(state versionState) versionState {
+	now := time.Now()
+	if state.Status == "" {
+		state.Status = string(model.StatusActive)
+	}
+	if state.PublishedAt.IsZero() {
+		state.PublishedAt = now
+	}
+	if state.UpdatedAt.IsZero() {
+		state.UpdatedAt = now
+	}
+	return state
+}
@@ -0,0 +1,243 @@
+// Package backup snapshots the registry into a storage location (a local directory, S3 or GCS),
+// restores snapshots and applies a retention policy to them.
+//
+// Two formats are supported: "json", the logical registry archive written by the archive package, which
+// can be restored into any registry version that reads its format; and "pgdump", a pg_dump custom-format
+// dump of the whole database, which requires the PostgreSQL client tools and a matching schema version.
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/archive"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// Format is a backup format
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatPgDump Format = "pgdump"
+)
+
+const (
+	namePrefix = "agentregistry-"
+	timeLayout = "20060102T150405Z"
+)
+
+// extensions maps each format to the file extension of its backups
+var extensions = map[Format]string{
+	FormatJSON:   ".tar.gz",
+	FormatPgDump: ".dump",
+}
+
+// ParseFormat validates a format name; empty selects json
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(s))) {
+	case "", FormatJSON:
+		return FormatJSON, nil
+	case FormatPgDump:
+		return FormatPgDump, nil
+	}
+	return "", fmt.Errorf("unknown backup format %q (expected json or pgdump)", s)
+}
+
+// Backup describes a backup in a storage location
+type Backup struct {
+	Name      string    `json:"name"`
+	Format    Format    `json:"format"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Options configures a backup or restore
+type Options struct {
+	Format Format
+	// DatabaseURL is the connection string handed to pg_dump and pg_restore
+	DatabaseURL string
+	// RegistryVersion is recorded in the manifest of json backups
+	RegistryVersion string
+}
+
+// Retention decides which backups Prune deletes. The newest backup is always kept.
+type Retention struct {
+	// Keep is the number of most recent backups to keep; zero keeps any number
+	Keep int
+	// MaxAge deletes backups older than this; zero keeps backups of any age
+	MaxAge time.Duration
+}
+
+// Enabled reports whether the retention policy deletes anything
+func (r Retention) Enabled() bool {
+	return r.Keep > 0 || r.MaxAge > 0
+}
+
+// Create snapshots the registry and stores it under a timestamped name
+func Create(ctx context.Context, db database.Database, store Storage, opts Options) (*Backup, error) {
+	format, err := ParseFormat(string(opts.Format))
+	if err != nil {
+		return nil, err
+	}
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	name := namePrefix + createdAt.Format(timeLayout) + extensions[format]
+
+	// Stage the snapshot in a temporary file so a failed export never reaches the storage location
+	tmp, err := os.CreateTemp("", "arctl-backup-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	switch format {
+	case FormatPgDump:
+		if err := pgDump(ctx, opts.DatabaseURL, tmp); err != nil {
+			return nil, err
+		}
+	default:
+		if _, err := archive.Export(ctx, db, tmp, opts.RegistryVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := store.Put(ctx, name, tmp); err != nil {
+		return nil, fmt.Errorf("failed to store backup in %s: %w", store, err)
+	}
+	return &Backup{Name: name, Format: format, Size: size, CreatedAt: createdAt}, nil
+}
+
+// Restore loads a backup into the registry. Json backups are merged: versions that already exist are kept.
+// Pgdump backups replace the database objects they contain. The import result is nil for pgdump backups.
+func Restore(ctx context.Context, db database.Database, store Storage, name string, opts Options) (*archive.ImportResult, error) {
+	b, ok := parseName(name)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a registry backup name (expected %s<timestamp>.tar.gz or .dump)", name, namePrefix)
+	}
+	r, err := store.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	if b.Format == FormatPgDump {
+		return nil, pgRestore(ctx, opts.DatabaseURL, r)
+	}
+	return archive.Import(ctx, db, r)
+}
+
+// List returns the backups in a storage location, newest first. Other files are ignored.
+func List(ctx context.Context, store Storage) ([]Backup, error) {
+	objects, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var backups []Backup
+	for _, o := range objects {
+		b, ok := parseName(o.Name)
+		if !ok {
+			continue
+		}
+		b.Size = o.Size
+		backups = append(backups, b)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+// Prune deletes the backups the retention policy no longer keeps and returns their names
+func Prune(ctx context.Context, store Storage, retention Retention) ([]string, error) {
+	if !retention.Enabled() {
+		return nil, nil
+	}
+	backups, err := List(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	var deleted []string
+	for _, b := range expired(backups, retention, time.Now()) {
+		if err := store.Delete(ctx, b.Name); err != nil {
+			return deleted, fmt.Errorf("failed to delete backup %s: %w", b.Name, err)
+		}
+		deleted = append(deleted, b.Name)
+	}
+	return deleted, nil
+}
+
+// expired returns the backups, sorted newest first, that the retention policy deletes
+func expired(backups []Backup, retention Retention, now time.Time) []Backup {
+	var out []Backup
+	for i, b := range backups {
+		if i == 0 {
+			continue
+		}
+		if (retention.Keep > 0 && i >= retention.Keep) || (retention.MaxAge > 0 && now.Sub(b.CreatedAt) > retention.MaxAge) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// parseName recognizes the names written by Create
+func parseName(name string) (Backup, bool) {
+	if !strings.HasPrefix(name, namePrefix) {
+		return Backup{}, false
+	}
+	for format, ext := range extensions {
+		stamp, ok := strings.CutSuffix(strings.TrimPrefix(name, namePrefix), ext)
+		if !ok {
+			continue
+		}
+		createdAt, err := time.Parse(timeLayout, stamp)
+		if err != nil {
+			return Backup{}, false
+		}
+		return Backup{Name: name, Format: format, CreatedAt: createdAt}, true
+	}
+	return Backup{}, false
+}
+
+func pgDump(ctx context.Context, databaseURL string, w io.Writer) error {
+	if databaseURL == "" {
+		return fmt.Errorf("a database URL is required for pgdump backups")
+	}
+	cmd := exec.CommandContext(ctx, "pg_dump", "--format=custom", "--no-owner", "--no-privileges", "--dbname", databaseURL)
+	var stderr bytes.Buffer
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pg_dump failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func pgRestore(ctx context.Context, databaseURL string, r io.Reader) error {
+	if databaseURL == "" {
+		return fmt.Errorf("a database URL is required to restore pgdump backups")
+	}
+	cmd := exec.CommandContext(ctx, "pg_restore", "--clean", "--if-exists", "--no-owner", "--no-privileges", "--single-transaction", "--dbname", databaseURL)
+	var stderr bytes.Buffer
+	cmd.Stdin = r
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pg_restore failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseName(t *testing.T) {
+	b, ok := parseName("agentregistry-20261016T120000Z.tar.gz")
+	require.True(t, ok)
+	assert.Equal(t, FormatJSON, b.Format)
+	assert.Equal(t, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), b.CreatedAt)
+
+	b, ok = parseName("agentregistry-20261016T120000Z.dump")
+	require.True(t, ok)
+	assert.Equal(t, FormatPgDump, b.Format)
+
+	for _, name := range []string{"notes.txt", "agentregistry-latest.tar.gz", "agentregistry-20261016T120000Z.zip"} {
+		_, ok := parseName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	var backups []Backup
+	for i := range 5 {
+		backups = append(backups, Backup{Name: string(rune('a' + i)), CreatedAt: now.Add(-time.Duration(i) * 24 * time.Hour)})
+	}
+
+	names := func(bs []Backup) []string {
+		var out []string
+		for _, b := range bs {
+			out = append(out, b.Name)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"d", "e"}, names(expired(backups, Retention{Keep: 3}, now)))
+	assert.Equal(t, []string{"c", "d", "e"}, names(expired(backups, Retention{MaxAge: 36 * time.Hour}, now)))
+	assert.Equal(t, []string{"b", "c", "d", "e"}, names(expired(backups, Retention{Keep: 1, MaxAge: 100 * 24 * time.Hour}, now)))
+
+	// The newest backup survives even when it is older than MaxAge
+	assert.Equal(t, []string{"b", "c", "d", "e"}, names(expired(backups, Retention{MaxAge: time.Hour}, now.Add(30*24*time.Hour))))
+}
+
+func TestLocalStorageAndPrune(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "backups")
+	store, err := NewStorage("file://" + dir)
+	require.NoError(t, err)
+
+	names := []string{
+		"agentregistry-20261014T000000Z.tar.gz",
+		"agentregistry-20261015T000000Z.dump",
+		"agentregistry-20261016T000000Z.tar.gz",
+	}
+	for _, name := range names {
+		require.NoError(t, store.Put(ctx, name, strings.NewReader(name)))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("unrelated"), 0o600))
+
+	backups, err := List(ctx, store)
+	require.NoError(t, err)
+	require.Len(t, backups, 3)
+	assert.Equal(t, names[2], backups[0].Name)
+	assert.Equal(t, int64(len(names[2])), backups[0].Size)
+
+	r, err := store.Get(ctx, names[1])
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, names[1], string(content))
+
+	_, err = store.Get(ctx, "agentregistry-20200101T000000Z.tar.gz")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	deleted, err := Prune(ctx, store, Retention{Keep: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{names[0]}, deleted)
+	_, err = os.Stat(filepath.Join(dir, "README"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,18 @@
+package backup
+
+import "github.com/agentregistry-dev/agentregistry/internal/registry/objectstore"
+
+// ErrNotFound is returned when a backup does not exist in the storage location
+var ErrNotFound = objectstore.ErrNotFound
+
+// Storage stores backup files
+type Storage = objectstore.Storage
+
+// Object is a file in a storage location
+type Object = objectstore.Object
+
+// NewStorage returns the storage for a backup location: a local directory (path or file://), s3://bucket/prefix
+// using the aws CLI, or gs://bucket/prefix using the gcloud CLI
+func NewStorage(location string) (Storage, error) {
+	return objectstore.New(location)
+}
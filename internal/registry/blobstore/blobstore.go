@@ -0,0 +1,165 @@
+// Package blobstore keeps large content such as READMEs and artifacts outside the database. Blobs are addressed by
+// the SHA-256 digest of their content, so storing the same content twice is a no-op and a blob never changes once
+// written.
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/objectstore"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// DigestPrefix prefixes the hex SHA-256 of a blob's content in its digest
+const DigestPrefix = "sha256:"
+
+var (
+	// ErrNotFound is returned when no blob has the requested digest
+	ErrNotFound = errors.New("blob not found")
+	// ErrInvalidDigest is returned for a digest that is not sha256:<64 hex characters>
+	ErrInvalidDigest = errors.New("invalid blob digest")
+	// ErrDigestMismatch is returned when stored content no longer hashes to its digest
+	ErrDigestMismatch = errors.New("blob content does not match its digest")
+	// ErrTooLarge is returned when content exceeds the size limit of an upload
+	ErrTooLarge = errors.New("blob is too large")
+	// ErrNotConfigured is returned by callers that need blob storage when BLOB_STORAGE is not set
+	ErrNotConfigured = errors.New("blob storage is not configured")
+)
+
+// Store keeps blobs in an object storage location: a local directory, s3://bucket/prefix or gs://bucket/prefix
+type Store struct {
+	objects objectstore.Storage
+}
+
+// New returns the blob store for a location
+func New(location string) (*Store, error) {
+	objects, err := objectstore.New(location)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{objects: objects}, nil
+}
+
+// String returns the location for display
+func (s *Store) String() string { return s.objects.String() }
+
+// Put stores the content read from r and returns its digest. The content is spooled to a temporary file while it
+// is hashed, so r can be larger than memory. Content larger than maxSize bytes is rejected; zero means no limit.
+func (s *Store) Put(ctx context.Context, r io.Reader, maxSize int64) (*models.Blob, error) {
+	tmp, err := os.CreateTemp("", "arctl-blob-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer blob: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if maxSize > 0 {
+		r = io.LimitReader(r, maxSize+1)
+	}
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	if maxSize > 0 && size > maxSize {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", ErrTooLarge, maxSize)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to buffer blob: %w", err)
+	}
+
+	blob := &models.Blob{Digest: Digest(h.Sum(nil)), Size: size}
+	if err := s.objects.Put(ctx, objectName(blob.Digest), tmp); err != nil {
+		return nil, fmt.Errorf("failed to store blob %s: %w", blob.Digest, err)
+	}
+	return blob, nil
+}
+
+// PutBytes stores content that is already in memory
+func (s *Store) PutBytes(ctx context.Context, content []byte) (*models.Blob, error) {
+	sum := sha256.Sum256(content)
+	blob := &models.Blob{Digest: Digest(sum[:]), Size: int64(len(content))}
+	if err := s.objects.Put(ctx, objectName(blob.Digest), bytes.NewReader(content)); err != nil {
+		return nil, fmt.Errorf("failed to store blob %s: %w", blob.Digest, err)
+	}
+	return blob, nil
+}
+
+// Open returns the content of a blob; the caller must close it
+func (s *Store) Open(ctx context.Context, digest string) (io.ReadCloser, error) {
+	if err := ValidateDigest(digest); err != nil {
+		return nil, err
+	}
+	r, err := s.objects.Get(ctx, objectName(digest))
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, digest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", digest, err)
+	}
+	return r, nil
+}
+
+// ReadAll returns the content of a blob, verifying that it still matches its digest
+func (s *Store) ReadAll(ctx context.Context, digest string) ([]byte, error) {
+	r, err := s.Open(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", digest, err)
+	}
+	sum := sha256.Sum256(content)
+	if Digest(sum[:]) != digest {
+		return nil, fmt.Errorf("%w: %s", ErrDigestMismatch, digest)
+	}
+	return content, nil
+}
+
+// Delete removes a blob. Callers must make sure nothing references it anymore, since identical content stored by
+// different owners shares one blob.
+func (s *Store) Delete(ctx context.Context, digest string) error {
+	if err := ValidateDigest(digest); err != nil {
+		return err
+	}
+	err := s.objects.Delete(ctx, objectName(digest))
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return fmt.Errorf("%w: %s", ErrNotFound, digest)
+	}
+	return err
+}
+
+// Digest formats a raw SHA-256 sum as a blob digest
+func Digest(sum []byte) string {
+	return DigestPrefix + hex.EncodeToString(sum)
+}
+
+// ValidateDigest checks that digest is sha256:<64 lowercase hex characters>
+func ValidateDigest(digest string) error {
+	hexSum, ok := strings.CutPrefix(digest, DigestPrefix)
+	if !ok || len(hexSum) != sha256.Size*2 || strings.ToLower(hexSum) != hexSum {
+		return fmt.Errorf("%w: %q", ErrInvalidDigest, digest)
+	}
+	if _, err := hex.DecodeString(hexSum); err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidDigest, digest)
+	}
+	return nil
+}
+
+// objectName maps a digest to a flat object name; ":" is not valid in file names on every platform
+func objectName(digest string) string {
+	return strings.Replace(digest, ":", "-", 1)
+}
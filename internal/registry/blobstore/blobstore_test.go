@@ -0,0 +1,71 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := New(dir)
+	require.NoError(t, err)
+
+	_, err = store.Put(ctx, strings.NewReader("# README\n"), 8)
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	blob, err := store.Put(ctx, strings.NewReader("# README\n"), 9)
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:f12c1087f067461d6bcfcfe912d95386b92e9472e97faae09d71b44df55ef43b", blob.Digest)
+	assert.Equal(t, int64(9), blob.Size)
+
+	// The same content maps to the same blob
+	again, err := store.PutBytes(ctx, []byte("# README\n"))
+	require.NoError(t, err)
+	assert.Equal(t, blob, again)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "sha256-"+strings.TrimPrefix(blob.Digest, DigestPrefix), entries[0].Name())
+
+	r, err := store.Open(ctx, blob.Digest)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "# README\n", string(content))
+
+	// Content that was changed behind the store's back is detected
+	require.NoError(t, os.WriteFile(filepath.Join(dir, entries[0].Name()), []byte("tampered"), 0o600))
+	_, err = store.ReadAll(ctx, blob.Digest)
+	assert.ErrorIs(t, err, ErrDigestMismatch)
+
+	require.NoError(t, store.Delete(ctx, blob.Digest))
+	_, err = store.Open(ctx, blob.Digest)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, store.Delete(ctx, blob.Digest), ErrNotFound)
+}
+
+func TestValidateDigest(t *testing.T) {
+	valid := "sha256:" + strings.Repeat("ab", 32)
+	assert.NoError(t, ValidateDigest(valid))
+
+	for _, digest := range []string{
+		"",
+		strings.Repeat("ab", 32),
+		"sha512:" + strings.Repeat("ab", 32),
+		"sha256:" + strings.Repeat("AB", 32),
+		"sha256:" + strings.Repeat("zz", 32),
+		"sha256:abc",
+		"sha256:../../etc/passwd",
+	} {
+		assert.ErrorIs(t, ValidateDigest(digest), ErrInvalidDigest, digest)
+	}
+}
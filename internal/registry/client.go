@@ -1,11 +1,15 @@
 package registry
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -13,17 +17,78 @@ import (
 	"github.com/schollz/progressbar/v3"
 )
 
-// Client handles communication with registries
+// Client handles communication with registries. Requests that fail with a 5xx or 429 status or a network error
+// are retried with exponential backoff, responses may be gzip-compressed, and responses carrying an ETag or
+// Last-Modified header are cached so that repeated fetches of an unchanged page are answered with a 304.
 type Client struct {
 	HTTPClient *http.Client
+
+	maxRetries   int
+	retryBackoff time.Duration
+	// sem bounds the requests in flight at the same time
+	sem      chan struct{}
+	cacheDir string
+}
+
+// ClientOptions configures a Client. Zero values keep the defaults.
+type ClientOptions struct {
+	// Timeout bounds each request (default 30s)
+	Timeout time.Duration
+	// MaxRetries is how often a failed request is retried (default 3); negative disables retries
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled for each further retry (default 500ms)
+	RetryBackoff time.Duration
+	// MaxConcurrency bounds the requests the client makes at the same time (default 4)
+	MaxConcurrency int
+	// CacheDir holds the responses used for conditional requests (default: arctl/registry in the user cache directory)
+	CacheDir string
+	// DisableCache turns off conditional requests
+	DisableCache bool
 }
 
+const (
+	defaultTimeout        = 30 * time.Second
+	defaultMaxRetries     = 3
+	defaultRetryBackoff   = 500 * time.Millisecond
+	defaultMaxConcurrency = 4
+	// maxRetryAfter caps how long a Retry-After header can make the client wait
+	maxRetryAfter = time.Minute
+)
+
 // NewClient creates a new registry client
 func NewClient() *Client {
+	return NewClientWithOptions(ClientOptions{})
+}
+
+// NewClientWithOptions creates a registry client with the given timeout, retry, concurrency and cache settings
+func NewClientWithOptions(opts ClientOptions) *Client {
+	timeout := cmp.Or(opts.Timeout, defaultTimeout)
+	maxRetries := opts.MaxRetries
+	switch {
+	case maxRetries == 0:
+		maxRetries = defaultMaxRetries
+	case maxRetries < 0:
+		maxRetries = 0
+	}
+
+	cacheDir := opts.CacheDir
+	if cacheDir == "" && !opts.DisableCache {
+		if dir, err := os.UserCacheDir(); err == nil {
+			cacheDir = filepath.Join(dir, "arctl", "registry")
+		}
+	}
+	if opts.DisableCache {
+		cacheDir = ""
+	}
+
 	return &Client{
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: timeout,
 		},
+		maxRetries:   maxRetries,
+		retryBackoff: cmp.Or(opts.RetryBackoff, defaultRetryBackoff),
+		sem:          make(chan struct{}, cmp.Or(max(opts.MaxConcurrency, 0), defaultMaxConcurrency)),
+		cacheDir:     cacheDir,
 	}
 }
 
@@ -32,24 +97,18 @@ func (c *Client) ValidateRegistry(baseURL string) error {
 	// Try to fetch the first page with limit=1 to validate
 	testURL := fmt.Sprintf("%s?limit=1", baseURL)
 
-	resp, err := c.HTTPClient.Get(testURL)
+	page, err := c.get(testURL)
 	if err != nil {
+		var statusErr *statusError
+		if errors.As(err, &statusErr) {
+			return fmt.Errorf("registry returned status %d (expected 200)", statusErr.code)
+		}
 		return fmt.Errorf("failed to connect to registry: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("registry returned status %d (expected 200)", resp.StatusCode)
-	}
 
 	// Try to parse the response to validate it's a proper registry
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read registry response: %w", err)
-	}
-
 	var registryResp types.RegistryResponse
-	if err := json.Unmarshal(body, &registryResp); err != nil {
+	if err := json.Unmarshal(page.body, &registryResp); err != nil {
 		return fmt.Errorf("invalid registry format: %w", err)
 	}
 
@@ -65,7 +124,6 @@ type FetchOptions struct {
 // FetchAllServers fetches all servers from a registry with pagination
 func (c *Client) FetchAllServers(baseURL string, opts FetchOptions) ([]types.ServerEntry, error) {
 	var allServers []types.ServerEntry
-	cursor := ""
 	pageCount := 0
 	const pageLimit = 100
 
@@ -90,41 +148,28 @@ func (c *Client) FetchAllServers(baseURL string, opts FetchOptions) ([]types.Ser
 		)
 	}
 
-	// Fetch all pages using cursor-based pagination
+	// Fetch all pages, following the registry's Link headers or else its cursors
+	fetchURL := fmt.Sprintf("%s?limit=%d", baseURL, pageLimit)
 	for {
 		pageCount++
 
-		// Build URL with pagination parameters
-		fetchURL := fmt.Sprintf("%s?limit=%d", baseURL, pageLimit)
-		if cursor != "" {
-			fetchURL = fmt.Sprintf("%s&cursor=%s", fetchURL, url.QueryEscape(cursor))
-		}
-
 		if opts.Verbose && !opts.ShowProgress {
 			fmt.Printf("    Fetching page %d...\n", pageCount)
 		}
 
 		// Fetch registry data
-		resp, err := c.HTTPClient.Get(fetchURL)
+		page, err := c.get(fetchURL)
 		if err != nil {
+			var statusErr *statusError
+			if errors.As(err, &statusErr) {
+				return nil, fmt.Errorf("unexpected status code on page %d: %d", pageCount, statusErr.code)
+			}
 			return nil, fmt.Errorf("failed to fetch page %d: %w", pageCount, err)
 		}
 
-		if resp.StatusCode != http.StatusOK {
-			_ = resp.Body.Close()
-			return nil, fmt.Errorf("unexpected status code on page %d: %d", pageCount, resp.StatusCode)
-		}
-
-		// Read response body
-		body, err := io.ReadAll(resp.Body)
-		_ = resp.Body.Close()
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response on page %d: %w", pageCount, err)
-		}
-
 		// Parse JSON
 		var registryResp types.RegistryResponse
-		if err := json.Unmarshal(body, &registryResp); err != nil {
+		if err := json.Unmarshal(page.body, &registryResp); err != nil {
 			return nil, fmt.Errorf("failed to parse JSON on page %d: %w", pageCount, err)
 		}
 
@@ -147,11 +192,14 @@ func (c *Client) FetchAllServers(baseURL string, opts FetchOptions) ([]types.Ser
 		}
 
 		// Check if there are more pages
+		if page.next != "" && page.next != fetchURL {
+			fetchURL = page.next
+			continue
+		}
 		if registryResp.Metadata.NextCursor == "" {
 			break
 		}
-
-		cursor = registryResp.Metadata.NextCursor
+		fetchURL = fmt.Sprintf("%s?limit=%d&cursor=%s", baseURL, pageLimit, url.QueryEscape(registryResp.Metadata.NextCursor))
 	}
 
 	if opts.ShowProgress && bar != nil {
@@ -178,20 +226,17 @@ func (c *Client) FetchServer(baseURL string, name string, version string) (*type
 	encodedName := url.PathEscape(name)
 	fetchURL := fmt.Sprintf("%s/%s/versions/%s", baseURL, encodedName, version)
 
-	resp, err := c.HTTPClient.Get(fetchURL)
+	page, err := c.get(fetchURL)
 	if err != nil {
+		var statusErr *statusError
+		if errors.As(err, &statusErr) {
+			return nil, statusErr
+		}
 		return nil, fmt.Errorf("failed to fetch server by name: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	// Check HTTP status code before attempting to decode
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
-	}
 
 	var registryResp types.RegistryResponse
-	if err := json.NewDecoder(resp.Body).Decode(&registryResp); err != nil {
+	if err := json.Unmarshal(page.body, &registryResp); err != nil {
 		return nil, fmt.Errorf("failed to decode server list response: %w", err)
 	}
 
@@ -214,20 +259,17 @@ func (c *Client) FetchServerVersions(baseURL string, serverName string) ([]types
 	encodedName := url.PathEscape(serverName)
 	fetchURL := fmt.Sprintf("%s/%s/versions", baseURL, encodedName)
 
-	resp, err := c.HTTPClient.Get(fetchURL)
+	page, err := c.get(fetchURL)
 	if err != nil {
+		var statusErr *statusError
+		if errors.As(err, &statusErr) {
+			return nil, statusErr
+		}
 		return nil, fmt.Errorf("failed to fetch server versions: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	// Check HTTP status code before attempting to decode
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
-	}
 
 	var registryResp types.RegistryResponse
-	if err := json.NewDecoder(resp.Body).Decode(&registryResp); err != nil {
+	if err := json.Unmarshal(page.body, &registryResp); err != nil {
 		return nil, fmt.Errorf("failed to decode server versions response: %w", err)
 	}
 
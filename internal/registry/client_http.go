@@ -0,0 +1,226 @@
+package registry
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statusError is returned for a response other than 200 OK once retries are exhausted
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.code, e.body)
+}
+
+// page is the body of a successful response and the URL of the next page its Link header points to, if any
+type page struct {
+	body []byte
+	next string
+}
+
+// cachedPage is the on-disk form of a response kept for conditional requests
+type cachedPage struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Next         string `json:"next,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// get fetches fetchURL. Network errors and 429 or 5xx responses are retried with exponential backoff, honoring
+// Retry-After. When a cached copy of the URL exists the request is conditional and a 304 returns the cached body.
+func (c *Client) get(fetchURL string) (*page, error) {
+	c.sem <- struct{}{}
+	defer func() { <-c.sem }()
+
+	cached := c.readCache(fetchURL)
+	delay := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(fetchURL, cached)
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			return c.readPage(fetchURL, resp, cached)
+		}
+		if attempt >= c.maxRetries {
+			if err != nil {
+				return nil, err
+			}
+			return c.readPage(fetchURL, resp, cached)
+		}
+
+		wait := delay
+		if err == nil {
+			if after, ok := retryAfter(resp.Header); ok {
+				wait = after
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		time.Sleep(wait)
+		delay *= 2
+	}
+}
+
+func (c *Client) send(fetchURL string, cached *cachedPage) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, fetchURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Setting Accept-Encoding ourselves turns off the transport's transparent decompression; readPage decodes gzip
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("Accept", "application/json")
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	return c.HTTPClient.Do(req)
+}
+
+func (c *Client) readPage(fetchURL string, resp *http.Response, cached *cachedPage) (*page, error) {
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return &page{body: cached.Body, next: cached.Next}, nil
+	}
+
+	var reader io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress response: %w", err)
+		}
+		defer func() { _ = gz.Close() }()
+		reader = gz
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{code: resp.StatusCode, body: string(body)}
+	}
+
+	p := &page{body: body, next: nextLink(fetchURL, resp.Header.Values("Link"))}
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if etag != "" || lastModified != "" {
+		c.writeCache(fetchURL, &cachedPage{ETag: etag, LastModified: lastModified, Next: p.next, Body: body})
+	}
+	return p, nil
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = time.Until(at)
+	} else {
+		return 0, false
+	}
+	return min(max(wait, 0), maxRetryAfter), true
+}
+
+// nextLink returns the target of the rel="next" entry of Link headers, resolved against the URL of the response
+func nextLink(fetchURL string, links []string) string {
+	for _, header := range links {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") || !containsField(strings.Trim(value, `"`), "next") {
+					continue
+				}
+				base, err := url.Parse(fetchURL)
+				if err != nil {
+					return ""
+				}
+				ref, err := url.Parse(target[1 : len(target)-1])
+				if err != nil {
+					return ""
+				}
+				return base.ResolveReference(ref).String()
+			}
+		}
+	}
+	return ""
+}
+
+// containsField reports whether a space-separated list such as a rel value contains field
+func containsField(list, field string) bool {
+	for _, f := range strings.Fields(list) {
+		if strings.EqualFold(f, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) cachePath(fetchURL string) string {
+	if c.cacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(fetchURL))
+	return filepath.Join(c.cacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *Client) readCache(fetchURL string) *cachedPage {
+	path := c.cachePath(fetchURL)
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cached cachedPage
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil
+	}
+	return &cached
+}
+
+// writeCache stores a response for later conditional requests; failures only cost a full response, so they are
+// ignored
+func (c *Client) writeCache(fetchURL string, cached *cachedPage) {
+	path := c.cachePath(fetchURL)
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
@@ -1,10 +1,12 @@
 package registry
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/types"
 )
@@ -533,3 +535,89 @@ func TestFetchAllServers_PaginationLimit(t *testing.T) {
 		t.Fatalf("FetchAllServers() failed: %v", err)
 	}
 }
+
+func TestFetchAllServers_RetriesServerErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.RegistryResponse{
+			Servers: []types.ServerEntry{{Server: types.ServerSpec{Name: "io.test/server1", Version: "1.0.0"}}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{RetryBackoff: time.Millisecond, DisableCache: true})
+	servers, err := client.FetchAllServers(server.URL, FetchOptions{})
+	if err != nil {
+		t.Fatalf("FetchAllServers() failed: %v", err)
+	}
+	if len(servers) != 1 || attempts != 3 {
+		t.Errorf("Expected 1 server after 3 attempts, got %d servers after %d attempts", len(servers), attempts)
+	}
+}
+
+func TestFetchServer_GzipAndConditionalRequests(t *testing.T) {
+	requests, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Expected Accept-Encoding gzip, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_ = json.NewEncoder(gz).Encode(types.RegistryResponse{
+			Servers: []types.ServerEntry{{Server: types.ServerSpec{Name: "io.test/server1", Version: "1.0.0"}}},
+		})
+		_ = gz.Close()
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheDir: t.TempDir()})
+	for range 2 {
+		entry, err := client.FetchServer(server.URL, "io.test/server1", "1.0.0")
+		if err != nil {
+			t.Fatalf("FetchServer() failed: %v", err)
+		}
+		if entry.Server.Name != "io.test/server1" {
+			t.Errorf("Expected server io.test/server1, got %q", entry.Server.Name)
+		}
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("Expected the second request to be answered with 304, got %d requests and %d 304s", requests, notModified)
+	}
+}
+
+func TestFetchAllServers_FollowsLinkHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := "io.test/server1"
+		if r.URL.Query().Get("page") == "2" {
+			name = "io.test/server2"
+		} else {
+			w.Header().Set("Link", `</v0/servers?page=2>; rel="next"`)
+		}
+		_ = json.NewEncoder(w).Encode(types.RegistryResponse{
+			Servers: []types.ServerEntry{{Server: types.ServerSpec{Name: name, Version: "1.0.0"}}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{DisableCache: true})
+	servers, err := client.FetchAllServers(server.URL, FetchOptions{})
+	if err != nil {
+		t.Fatalf("FetchAllServers() failed: %v", err)
+	}
+	if len(servers) != 2 || servers[1].Server.Name != "io.test/server2" {
+		t.Errorf("Expected the linked second page to be fetched, got %d servers", len(servers))
+	}
+}
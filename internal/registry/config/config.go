@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"time"
 
 	env "github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
@@ -23,6 +24,18 @@ type Config struct {
 	EnableAnonymousAuth      bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
 	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
 
+	// Database connection pool
+	// DatabaseReadURL is a read-only replica serving list, get and search queries made outside a transaction; reads fall
+	// back to DATABASE_URL while it cannot be reached. Empty sends every query to DATABASE_URL.
+	DatabaseReadURL string `env:"DATABASE_READ_URL" envDefault:""`
+	// DatabaseMaxConns and DatabaseMinConns bound the connections kept by each connection pool
+	DatabaseMaxConns int32 `env:"DATABASE_MAX_CONNS" envDefault:"30"`
+	DatabaseMinConns int32 `env:"DATABASE_MIN_CONNS" envDefault:"5"`
+	// DatabaseMaxConnLifetime closes connections older than this, so they are refreshed regularly
+	DatabaseMaxConnLifetime time.Duration `env:"DATABASE_MAX_CONN_LIFETIME" envDefault:"2h"`
+	// DatabaseMaxConnIdleTime closes connections unused for this long
+	DatabaseMaxConnIdleTime time.Duration `env:"DATABASE_MAX_CONN_IDLE_TIME" envDefault:"30m"`
+
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
 	OIDCIssuer       string `env:"OIDC_ISSUER" envDefault:""`
@@ -37,11 +50,145 @@ type Config struct {
 
 	// Agent Gateway Configuration
 	AgentGatewayPort uint16 `env:"AGENT_GATEWAY_PORT" envDefault:"8081"`
+	// AgentGatewayURL is the base URL clients reach the agent gateway at, used as the endpoint of locally deployed
+	// agents in their A2A agent cards. Defaults to http://localhost:<AGENT_GATEWAY_PORT>.
+	AgentGatewayURL string `env:"AGENT_GATEWAY_URL" envDefault:""`
 
 	// Runtime Configuration
 	ReconcileOnStartup bool   `env:"RECONCILE_ON_STARTUP" envDefault:"true"`
 	RuntimeDir         string `env:"RUNTIME_DIR" envDefault:"/tmp/arctl-runtime"`
 	Verbose            bool   `env:"VERBOSE" envDefault:"false"`
+	// DeploymentTargetsFile is a YAML file of named deployment targets (remote docker hosts over ssh/tcp, kubernetes
+	// contexts) in addition to the built-in "local" and "kubernetes" targets
+	DeploymentTargetsFile string `env:"DEPLOYMENT_TARGETS_FILE" envDefault:""`
+	// ReconcileInterval periodically reconciles deployments in the background; zero only reconciles on changes
+	ReconcileInterval time.Duration `env:"RECONCILE_INTERVAL" envDefault:"0"`
+	// HealthCheckInterval is how often local deployments are checked and failed containers restarted; zero disables it
+	HealthCheckInterval time.Duration `env:"HEALTH_CHECK_INTERVAL" envDefault:"30s"`
+	// HealthRestartBudget is how many times a failing container is restarted before its deployment is marked failed
+	HealthRestartBudget int `env:"HEALTH_RESTART_BUDGET" envDefault:"3"`
+	// RuntimePruneInterval runs the runtime-prune job periodically; zero only runs it when triggered via /admin/v0/jobs
+	RuntimePruneInterval time.Duration `env:"RUNTIME_PRUNE_INTERVAL" envDefault:"0"`
+
+	// Server signing policy
+	// RequireSignedServers rejects publishing server versions that have no publisher signature
+	RequireSignedServers bool `env:"REQUIRE_SIGNED_SERVERS" envDefault:"false"`
+	// TrustedSigningKeys restricts accepted signatures to these comma-separated key fingerprints (SHA256:<hex>); empty trusts any key
+	TrustedSigningKeys string `env:"TRUSTED_SIGNING_KEYS" envDefault:""`
+
+	// Image vulnerability scanning
+	// ImageScanner scans the OCI images of servers when they are published: "trivy", "grype" or empty to disable
+	ImageScanner string `env:"IMAGE_SCANNER" envDefault:""`
+
+	// Capability introspection
+	// IntrospectOnPublish starts or connects to servers when they are published to record their tools, resources and prompts
+	IntrospectOnPublish bool `env:"INTROSPECT_ON_PUBLISH" envDefault:"false"`
+	// IntrospectTimeout bounds a single introspection, including pulling the package image
+	IntrospectTimeout time.Duration `env:"INTROSPECT_TIMEOUT" envDefault:"60s"`
+	// IntrospectConcurrency limits how many servers are introspected at the same time
+	IntrospectConcurrency int `env:"INTROSPECT_CONCURRENCY" envDefault:"2"`
+
+	// Server enrichment
+	// EnrichInterval refreshes the scorecard scores, dependency health and image pull stats of existing servers
+	// periodically, re-enriching servers last enriched longer ago than this; zero only enriches when triggered via /admin/v0/jobs
+	EnrichInterval time.Duration `env:"ENRICH_INTERVAL" envDefault:"0"`
+	// EnrichConcurrency limits how many servers are enriched at the same time
+	EnrichConcurrency int `env:"ENRICH_CONCURRENCY" envDefault:"2"`
+	// EnrichGitHubToken raises GitHub rate limits and enables security alert counts during enrichment
+	EnrichGitHubToken string `env:"ENRICH_GITHUB_TOKEN" envDefault:""`
+
+	// Namespace ownership
+	// RequireVerifiedNamespaces only lets non-admins publish under namespaces they verified via /v0/namespaces/verify;
+	// otherwise unverified namespaces stay open and only verified ones are restricted to their owners
+	RequireVerifiedNamespaces bool `env:"REQUIRE_VERIFIED_NAMESPACES" envDefault:"false"`
+
+	// Deployment admission policies
+	// DeploymentPolicyFile is a YAML or JSON file of policies evaluated before every deployment, in addition to those managed via /admin/v0/policies
+	DeploymentPolicyFile string `env:"DEPLOYMENT_POLICY_FILE" envDefault:""`
+
+	// Quotas keep a shared registry from being monopolized; zero disables a quota, and registry admins are exempt
+	// QuotaMaxDeployments limits the deployments of a workspace, or of a user outside any workspace
+	QuotaMaxDeployments int `env:"QUOTA_MAX_DEPLOYMENTS" envDefault:"0"`
+	// QuotaMaxPublishesPerDay limits the server, agent and skill versions a user publishes in 24 hours
+	QuotaMaxPublishesPerDay int `env:"QUOTA_MAX_PUBLISHES_PER_DAY" envDefault:"0"`
+	// QuotaMaxServersPerNamespace limits the distinct servers published under a namespace
+	QuotaMaxServersPerNamespace int `env:"QUOTA_MAX_SERVERS_PER_NAMESPACE" envDefault:"0"`
+
+	// Scheduled backups
+	// BackupLocation is where the backup job stores snapshots: a directory, s3://bucket/prefix or gs://bucket/prefix; empty disables the job
+	BackupLocation string `env:"BACKUP_LOCATION" envDefault:""`
+	// BackupInterval runs the backup job periodically; zero only runs it when triggered via /admin/v0/jobs
+	BackupInterval time.Duration `env:"BACKUP_INTERVAL" envDefault:"0"`
+	// BackupFormat is "json" (logical registry archive) or "pgdump" (requires pg_dump)
+	BackupFormat string `env:"BACKUP_FORMAT" envDefault:"json"`
+	// BackupRetentionCount keeps this many most recent backups; zero keeps any number
+	BackupRetentionCount int `env:"BACKUP_RETENTION_COUNT" envDefault:"0"`
+	// BackupRetentionAge deletes backups older than this (e.g. 720h); zero keeps backups of any age
+	BackupRetentionAge time.Duration `env:"BACKUP_RETENTION_AGE" envDefault:"0"`
+
+	// Trash
+	// TrashRetention is how long deleted server versions stay restorable before the trash-purge job removes them for
+	// good; zero keeps them until they are purged via /admin/v0/trash
+	TrashRetention time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+
+	// Event outbox
+	// OutboxDispatchInterval is how often the outbox-dispatch job delivers the registry events written to the outbox to
+	// event handlers; zero only delivers them when triggered via /admin/v0/jobs
+	OutboxDispatchInterval time.Duration `env:"OUTBOX_DISPATCH_INTERVAL" envDefault:"5s"`
+
+	// Blob storage
+	// BlobStorage is where READMEs and uploaded blobs are kept, addressed by SHA-256: a directory, s3://bucket/prefix
+	// or gs://bucket/prefix; empty keeps READMEs in PostgreSQL and disables the blob endpoints
+	BlobStorage string `env:"BLOB_STORAGE" envDefault:""`
+	// BlobInlineMaxBytes keeps READMEs up to this size in PostgreSQL even when blob storage is configured
+	BlobInlineMaxBytes int `env:"BLOB_INLINE_MAX_BYTES" envDefault:"16384"`
+	// BlobMaxUploadBytes limits the size of a blob uploaded via /v0/blobs
+	BlobMaxUploadBytes int64 `env:"BLOB_MAX_UPLOAD_BYTES" envDefault:"104857600"`
+	// ArtifactMaxBytes limits the size of an artifact file attached to a server or agent version
+	ArtifactMaxBytes int64 `env:"ARTIFACT_MAX_BYTES" envDefault:"52428800"`
+
+	// Published URLs
+	// AllowPrivateURLs accepts remote, repository and website URLs that point at localhost or private addresses, for
+	// registries used in development. Otherwise such URLs are rejected at publish time, as deployments fetch them.
+	AllowPrivateURLs bool `env:"ALLOW_PRIVATE_URLS" envDefault:"false"`
+	// ResolveURLHosts also resolves the hosts of published URLs and rejects those that do not resolve or resolve to a
+	// private address
+	ResolveURLHosts bool `env:"RESOLVE_URL_HOSTS" envDefault:"false"`
+
+	// Payload limits
+	// PublishMaxBodyBytes limits the request body of the endpoints creating servers, agents and skills and uploading
+	// READMEs; larger requests are refused with 413 before they are read. Zero keeps the default of 1 MiB.
+	PublishMaxBodyBytes int64 `env:"PUBLISH_MAX_BODY_BYTES" envDefault:"4194304"`
+	// DocumentMaxBytes limits the size of a server.json, agent or skill document as stored; zero is unlimited
+	DocumentMaxBytes int `env:"DOCUMENT_MAX_BYTES" envDefault:"262144"`
+	// ReadmeMaxBytes limits the size of an uploaded README; zero is unlimited
+	ReadmeMaxBytes int `env:"README_MAX_BYTES" envDefault:"1048576"`
+
+	// Tracing
+	// OTLPEndpoint exports traces over OTLP/HTTP to this URL (e.g. http://localhost:4318); tracing is also enabled by the standard OTEL_EXPORTER_OTLP_ENDPOINT
+	OTLPEndpoint string `env:"OTLP_ENDPOINT" envDefault:""`
+	// TraceSampleRatio is the fraction of traces started by the registry that are sampled; traces continued from callers follow their decision
+	TraceSampleRatio float64 `env:"TRACE_SAMPLE_RATIO" envDefault:"1"`
+
+	// Shutdown and restarts
+	// ShutdownDelay keeps serving after SIGTERM while /readyz already fails, so load balancers stop routing to this
+	// instance before its listener closes
+	ShutdownDelay time.Duration `env:"SHUTDOWN_DELAY" envDefault:"0"`
+	// ShutdownGracePeriod is how long in-flight requests and background jobs get to finish before they are cancelled
+	ShutdownGracePeriod time.Duration `env:"SHUTDOWN_GRACE_PERIOD" envDefault:"30s"`
+	// ServerReusePort binds the server address with SO_REUSEPORT, so a new registry process can start listening
+	// before the old one has drained. A socket passed by systemd socket activation is always used instead.
+	ServerReusePort bool `env:"SERVER_REUSE_PORT" envDefault:"false"`
+
+	// ImportConcurrency is how many servers a SEED_FROM import creates and enriches at the same time
+	ImportConcurrency int `env:"IMPORT_CONCURRENCY" envDefault:"10"`
+
+	// Seed verification
+	// SeedRequireVerification only imports SEED_FROM seed files that have a detached <file>.sha256 checksum (and a
+	// <file>.minisig signature when SEED_MINISIGN_PUBLIC_KEY is set), and rejects sources that cannot be verified
+	SeedRequireVerification bool `env:"SEED_REQUIRE_VERIFICATION" envDefault:"false"`
+	// SeedMinisignPublicKey is the minisign public key SEED_FROM seed files must be signed with; empty only checks checksums
+	SeedMinisignPublicKey string `env:"SEED_MINISIGN_PUBLIC_KEY" envDefault:""`
 
 	// Embeddings / Semantic Search
 	Embeddings EmbeddingsConfig
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// UpsertAgentReadme stores or updates the README of an agent version
+func (db *PostgreSQL) UpsertAgentReadme(ctx context.Context, tx pgx.Tx, readme *database.AgentReadme) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if readme == nil || readme.AgentName == "" || readme.Version == "" {
+		return fmt.Errorf("%w: agent name and version are required", database.ErrInvalidInput)
+	}
+	if readme.ContentType == "" {
+		readme.ContentType = "text/markdown"
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: readme.AgentName,
+		Type: auth.PermissionArtifactTypeAgent,
+	}); err != nil {
+		return err
+	}
+
+	if readme.SizeBytes == 0 {
+		readme.SizeBytes = len(readme.Content)
+	}
+	if len(readme.SHA256) == 0 {
+		sum := sha256.Sum256(readme.Content)
+		readme.SHA256 = sum[:]
+	}
+	if readme.FetchedAt.IsZero() {
+		readme.FetchedAt = time.Now()
+	}
+
+	content := readme.Content
+	blobSum, err := db.offloadReadme(ctx, readme.Content)
+	if err != nil {
+		return fmt.Errorf("failed to store agent readme: %w", err)
+	}
+	if blobSum != nil {
+		content, readme.SHA256 = []byte{}, blobSum
+	}
+
+	query := `
+        INSERT INTO agent_readmes (agent_name, version, content, content_type, size_bytes, sha256, fetched_at, blob_stored)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (agent_name, version) DO UPDATE
+        SET content = EXCLUDED.content,
+            content_type = EXCLUDED.content_type,
+            size_bytes = EXCLUDED.size_bytes,
+            sha256 = EXCLUDED.sha256,
+            fetched_at = EXCLUDED.fetched_at,
+            blob_stored = EXCLUDED.blob_stored
+    `
+	if _, err := db.getExecutor(tx).Exec(ctx, query,
+		readme.AgentName,
+		readme.Version,
+		content,
+		readme.ContentType,
+		readme.SizeBytes,
+		readme.SHA256,
+		readme.FetchedAt,
+		blobSum != nil,
+	); err != nil {
+		return fmt.Errorf("failed to upsert agent readme: %w", err)
+	}
+	return nil
+}
+
+// GetAgentReadme retrieves the README of a specific agent version
+func (db *PostgreSQL) GetAgentReadme(ctx context.Context, tx pgx.Tx, agentName, version string) (*database.AgentReadme, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: agentName,
+		Type: auth.PermissionArtifactTypeAgent,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT agent_name, version, content, content_type, size_bytes, sha256, fetched_at, blob_stored
+        FROM agent_readmes
+        WHERE agent_name = $1 AND version = $2
+    `
+	return db.scanAgentReadme(ctx, db.getExecutor(tx).QueryRow(ctx, query, agentName, version))
+}
+
+// GetLatestAgentReadme retrieves the README of the latest version of an agent
+func (db *PostgreSQL) GetLatestAgentReadme(ctx context.Context, tx pgx.Tx, agentName string) (*database.AgentReadme, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: agentName,
+		Type: auth.PermissionArtifactTypeAgent,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT ar.agent_name, ar.version, ar.content, ar.content_type, ar.size_bytes, ar.sha256, ar.fetched_at, ar.blob_stored
+        FROM agent_readmes ar
+        INNER JOIN agents a ON ar.agent_name = a.agent_name AND ar.version = a.version
+        WHERE ar.agent_name = $1 AND a.is_latest = true
+        LIMIT 1
+    `
+	return db.scanAgentReadme(ctx, db.getExecutor(tx).QueryRow(ctx, query, agentName))
+}
+
+func (db *PostgreSQL) scanAgentReadme(ctx context.Context, row pgx.Row) (*database.AgentReadme, error) {
+	var readme database.AgentReadme
+	var blobStored bool
+	if err := row.Scan(
+		&readme.AgentName,
+		&readme.Version,
+		&readme.Content,
+		&readme.ContentType,
+		&readme.SizeBytes,
+		&readme.SHA256,
+		&readme.FetchedAt,
+		&blobStored,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan agent readme: %w", err)
+	}
+	content, err := db.loadReadme(ctx, readme.Content, readme.SHA256, blobStored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agent readme: %w", err)
+	}
+	readme.Content = content
+	return &readme, nil
+}
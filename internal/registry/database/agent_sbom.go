@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// UpsertAgentSBOM stores or replaces the SBOM of an agent version
+func (db *PostgreSQL) UpsertAgentSBOM(ctx context.Context, tx pgx.Tx, sbom *database.AgentSBOM) error {
+	if sbom == nil || sbom.AgentName == "" || sbom.Version == "" {
+		return fmt.Errorf("%w: agent name and version are required", database.ErrInvalidInput)
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: sbom.AgentName,
+		Type: auth.PermissionArtifactTypeAgent,
+	}); err != nil {
+		return err
+	}
+
+	if sbom.SizeBytes == 0 {
+		sbom.SizeBytes = len(sbom.Content)
+	}
+	if len(sbom.SHA256) == 0 {
+		sum := sha256.Sum256(sbom.Content)
+		sbom.SHA256 = sum[:]
+	}
+	if sbom.CreatedAt.IsZero() {
+		sbom.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO agent_sboms (agent_name, version, format, content, component_count, size_bytes, sha256, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (agent_name, version) DO UPDATE
+		SET format = EXCLUDED.format,
+		    content = EXCLUDED.content,
+		    component_count = EXCLUDED.component_count,
+		    size_bytes = EXCLUDED.size_bytes,
+		    sha256 = EXCLUDED.sha256,
+		    created_at = EXCLUDED.created_at
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query,
+		sbom.AgentName,
+		sbom.Version,
+		sbom.Format,
+		sbom.Content,
+		sbom.ComponentCount,
+		sbom.SizeBytes,
+		sbom.SHA256,
+		sbom.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to upsert agent SBOM: %w", err)
+	}
+
+	return nil
+}
+
+// GetAgentSBOM retrieves the SBOM of a specific agent version
+func (db *PostgreSQL) GetAgentSBOM(ctx context.Context, tx pgx.Tx, agentName, version string) (*database.AgentSBOM, error) {
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: agentName,
+		Type: auth.PermissionArtifactTypeAgent,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT agent_name, version, format, content, component_count, size_bytes, sha256, created_at
+		FROM agent_sboms
+		WHERE agent_name = $1 AND version = $2
+	`
+
+	var sbom database.AgentSBOM
+	err := db.getExecutor(tx).QueryRow(ctx, query, agentName, version).Scan(
+		&sbom.AgentName,
+		&sbom.Version,
+		&sbom.Format,
+		&sbom.Content,
+		&sbom.ComponentCount,
+		&sbom.SizeBytes,
+		&sbom.SHA256,
+		&sbom.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get agent SBOM: %w", err)
+	}
+
+	return &sbom, nil
+}
@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+var _ auth.APITokenStore = &PostgreSQL{}
+
+const apiTokenColumns = `id, name, subject, token_prefix, permissions, created_at, expires_at, last_used_at, revoked_at, COALESCE(workspace, '')`
+
+// CreateAPIToken stores a new API token owned by the caller. Only the token hash is persisted.
+// Non-admin callers may only grant permissions they hold themselves. A token created within a workspace is bound to it.
+func (db *PostgreSQL) CreateAPIToken(ctx context.Context, tx pgx.Tx, token *models.APIToken, tokenHash string) error {
+	session, ok := auth.AuthSessionFrom(ctx)
+	if !ok {
+		return auth.ErrUnauthenticated
+	}
+	user := session.Principal().User
+	if user.Subject == "" {
+		return fmt.Errorf("%w: API tokens can only be created by an identified user", auth.ErrForbidden)
+	}
+
+	if token == nil || token.Name == "" {
+		return fmt.Errorf("%w: token name is required", database.ErrInvalidInput)
+	}
+	if len(token.Permissions) == 0 {
+		return fmt.Errorf("%w: at least one permission is required", database.ErrInvalidInput)
+	}
+	if !db.authz.IsRegistryAdmin(ctx) {
+		for _, perm := range token.Permissions {
+			if !auth.PermissionCovered(user.Permissions, perm) {
+				return fmt.Errorf("%w: cannot grant %s on %q", auth.ErrForbidden, perm.Action, perm.ResourcePattern)
+			}
+		}
+	}
+
+	permissionsJSON, err := json.Marshal(token.Permissions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token permissions: %w", err)
+	}
+
+	token.Subject = user.Subject
+	token.Workspace = auth.WorkspaceFrom(ctx)
+	query := `
+		INSERT INTO api_tokens (name, subject, token_hash, token_prefix, permissions, expires_at, workspace)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	err = db.getExecutor(tx).QueryRow(ctx, query, token.Name, token.Subject, tokenHash, token.Prefix, permissionsJSON, token.ExpiresAt, nullableWorkspace(ctx)).
+		Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return database.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	return nil
+}
+
+// ListAPITokens lists the caller's API tokens, or every token when all is set (registry admin only). Within a
+// workspace only the tokens bound to it are listed.
+func (db *PostgreSQL) ListAPITokens(ctx context.Context, tx pgx.Tx, all bool) ([]*models.APIToken, error) {
+	session, ok := auth.AuthSessionFrom(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens`
+	args := []any{}
+	if all {
+		if !db.authz.IsRegistryAdmin(ctx) {
+			return nil, auth.ErrForbidden
+		}
+	} else {
+		query += ` WHERE subject = $1`
+		args = append(args, session.Principal().User.Subject)
+	}
+	if workspace := auth.WorkspaceFrom(ctx); workspace != "" {
+		if len(args) == 0 {
+			query += ` WHERE workspace = $1`
+		} else {
+			query += ` AND workspace = $2`
+		}
+		args = append(args, workspace)
+	}
+	query += ` ORDER BY id`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.APIToken
+	for rows.Next() {
+		t, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating API tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// RevokeAPIToken revokes a token by ID. Callers may revoke their own tokens; registry admins may revoke any token.
+func (db *PostgreSQL) RevokeAPIToken(ctx context.Context, tx pgx.Tx, id int64) (*models.APIToken, error) {
+	session, ok := auth.AuthSessionFrom(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	row := db.getExecutor(tx).QueryRow(ctx, `SELECT `+apiTokenColumns+` FROM api_tokens WHERE id = $1`, id)
+	token, err := scanAPIToken(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	subject := session.Principal().User.Subject
+	if (subject == "" || token.Subject != subject) && !db.authz.IsRegistryAdmin(ctx) {
+		// Do not reveal the existence of other users' tokens
+		return nil, database.ErrNotFound
+	}
+	if token.RevokedAt != nil {
+		return token, nil
+	}
+
+	err = db.getExecutor(tx).QueryRow(ctx, `UPDATE api_tokens SET revoked_at = NOW() WHERE id = $1 RETURNING revoked_at`, id).
+		Scan(&token.RevokedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke API token: %w", err)
+	}
+
+	return token, nil
+}
+
+// LookupAPIToken resolves a token hash to its identity for authentication.
+// It intentionally skips authz checks since it runs before the caller is authenticated.
+func (db *PostgreSQL) LookupAPIToken(ctx context.Context, tokenHash string) (*auth.APITokenIdentity, error) {
+	query := `
+		UPDATE api_tokens SET last_used_at = NOW()
+		WHERE token_hash = $1
+		  AND revoked_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		RETURNING id, subject, permissions, COALESCE(workspace, '')
+	`
+
+	var identity auth.APITokenIdentity
+	var permissionsJSON []byte
+	err := db.pool.QueryRow(ctx, query, tokenHash).Scan(&identity.TokenID, &identity.Subject, &permissionsJSON, &identity.Workspace)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to look up API token: %w", err)
+	}
+	if err := json.Unmarshal(permissionsJSON, &identity.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token permissions: %w", err)
+	}
+
+	return &identity, nil
+}
+
+func scanAPIToken(row pgx.Row) (*models.APIToken, error) {
+	var t models.APIToken
+	var permissionsJSON []byte
+	var expiresAt, lastUsedAt, revokedAt *time.Time
+	if err := row.Scan(&t.ID, &t.Name, &t.Subject, &t.Prefix, &permissionsJSON, &t.CreatedAt, &expiresAt, &lastUsedAt, &revokedAt, &t.Workspace); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan API token: %w", err)
+	}
+	if err := json.Unmarshal(permissionsJSON, &t.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token permissions: %w", err)
+	}
+	t.ExpiresAt, t.LastUsedAt, t.RevokedAt = expiresAt, lastUsedAt, revokedAt
+	return &t, nil
+}
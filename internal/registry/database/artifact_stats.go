@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// usageColumns maps usage events to their counter column
+var usageColumns = map[string]string{
+	models.UsageEventDownload: "downloads",
+	models.UsageEventDeploy:   "deploys",
+	models.UsageEventInstall:  "installs",
+}
+
+// statsResourceTypes maps artifact types to the resource type checked by authz
+var statsResourceTypes = map[string]auth.PermissionArtifactType{
+	"mcp":   auth.PermissionArtifactTypeServer,
+	"agent": auth.PermissionArtifactTypeAgent,
+	"skill": auth.PermissionArtifactTypeSkill,
+}
+
+// IncrementArtifactUsage counts one usage event of an artifact. Anyone who can read the artifact may count usage.
+func (db *PostgreSQL) IncrementArtifactUsage(ctx context.Context, tx pgx.Tx, artifactType, name, event string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	column, ok := usageColumns[event]
+	if !ok {
+		return fmt.Errorf("%w: unknown usage event %q", database.ErrInvalidInput, event)
+	}
+	resourceType, ok := statsResourceTypes[artifactType]
+	if !ok || name == "" {
+		return fmt.Errorf("%w: artifact type must be mcp, agent or skill and name is required", database.ErrInvalidInput)
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{Name: name, Type: resourceType}); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+        INSERT INTO artifact_stats (artifact_type, artifact_name, %[1]s, updated_at)
+        VALUES ($1, $2, 1, NOW())
+        ON CONFLICT (artifact_type, artifact_name) DO UPDATE
+        SET %[1]s = artifact_stats.%[1]s + 1,
+            updated_at = NOW()
+    `, column)
+	if _, err := db.getExecutor(tx).Exec(ctx, query, artifactType, name); err != nil {
+		return fmt.Errorf("failed to increment artifact usage: %w", err)
+	}
+	return nil
+}
+
+// GetArtifactStats retrieves the usage counts of an artifact; artifacts that were never used have zero counts
+func (db *PostgreSQL) GetArtifactStats(ctx context.Context, tx pgx.Tx, artifactType, name string) (*models.ArtifactStats, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	resourceType, ok := statsResourceTypes[artifactType]
+	if !ok {
+		return nil, fmt.Errorf("%w: artifact type must be mcp, agent or skill", database.ErrInvalidInput)
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{Name: name, Type: resourceType}); err != nil {
+		return nil, err
+	}
+
+	stats := &models.ArtifactStats{ArtifactType: artifactType, Name: name}
+	query := `
+        SELECT downloads, deploys, installs, updated_at
+        FROM artifact_stats
+        WHERE artifact_type = $1 AND artifact_name = $2
+    `
+	err := db.getExecutor(tx).QueryRow(ctx, query, artifactType, name).Scan(
+		&stats.Downloads,
+		&stats.Deploys,
+		&stats.Installs,
+		&stats.UpdatedAt,
+	)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get artifact stats: %w", err)
+	}
+	stats.Popularity = stats.Downloads + stats.Deploys + stats.Installs
+	return stats, nil
+}
+
+// popularityOrder returns the ORDER BY term ranking the rows of a list query by the usage counts of the artifact
+// named by nameColumn, most used first
+func popularityOrder(artifactType, nameColumn string) string {
+	return fmt.Sprintf(`COALESCE((
+            SELECT st.downloads + st.deploys + st.installs
+            FROM artifact_stats st
+            WHERE st.artifact_type = '%s' AND st.artifact_name = %s
+        ), 0) DESC`, artifactType, nameColumn)
+}
+
+// popularityOffset parses the cursor of a list sorted by popularity. Usage counts change between pages, so those
+// lists page by offset instead of by the name of the last row.
+func popularityOffset(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("%w: invalid cursor for sort=popularity", database.ErrInvalidInput)
+	}
+	return offset, nil
+}
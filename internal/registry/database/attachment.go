@@ -0,0 +1,177 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+const attachmentColumns = `artifact_type, artifact_name, version, file_name, kind, media_type, digest, size_bytes, uploaded_by, created_at`
+
+// attachmentResourceType returns the resource type checked by authz for an artifact type that can have attachments
+func attachmentResourceType(artifactType string) (auth.PermissionArtifactType, error) {
+	resourceType, ok := statsResourceTypes[artifactType]
+	if !ok || artifactType == "skill" {
+		return "", fmt.Errorf("%w: artifacts can only be attached to servers and agents", database.ErrInvalidInput)
+	}
+	return resourceType, nil
+}
+
+// UpsertAttachment records an artifact file attached to a server or agent version, replacing an earlier file of the
+// same name
+func (db *PostgreSQL) UpsertAttachment(ctx context.Context, tx pgx.Tx, attachment *models.Attachment) (*models.Attachment, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if attachment == nil || attachment.ArtifactName == "" || attachment.Version == "" || attachment.FileName == "" {
+		return nil, fmt.Errorf("%w: artifact name, version and file name are required", database.ErrInvalidInput)
+	}
+	resourceType, err := attachmentResourceType(attachment.ArtifactType)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionPublish, auth.Resource{Name: attachment.ArtifactName, Type: resourceType}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO attachments (artifact_type, artifact_name, version, file_name, kind, media_type, digest, size_bytes, uploaded_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        ON CONFLICT (artifact_type, artifact_name, version, file_name) DO UPDATE
+        SET kind = EXCLUDED.kind,
+            media_type = EXCLUDED.media_type,
+            digest = EXCLUDED.digest,
+            size_bytes = EXCLUDED.size_bytes,
+            uploaded_by = EXCLUDED.uploaded_by,
+            created_at = NOW()
+        RETURNING ` + attachmentColumns
+	return scanAttachment(db.getExecutor(tx).QueryRow(ctx, query,
+		attachment.ArtifactType,
+		attachment.ArtifactName,
+		attachment.Version,
+		attachment.FileName,
+		attachment.Kind,
+		attachment.MediaType,
+		attachment.Digest,
+		attachment.SizeBytes,
+		attachment.UploadedBy,
+	))
+}
+
+// ListAttachments returns the artifact files attached to a server or agent version, ordered by file name
+func (db *PostgreSQL) ListAttachments(ctx context.Context, tx pgx.Tx, artifactType, name, version string) ([]*models.Attachment, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	resourceType, err := attachmentResourceType(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{Name: name, Type: resourceType}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT ` + attachmentColumns + `
+        FROM attachments
+        WHERE artifact_type = $1 AND artifact_name = $2 AND version = $3
+        ORDER BY file_name
+    `
+	rows, err := db.getExecutor(tx).Query(ctx, query, artifactType, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// GetAttachment retrieves one artifact file attached to a server or agent version
+func (db *PostgreSQL) GetAttachment(ctx context.Context, tx pgx.Tx, artifactType, name, version, fileName string) (*models.Attachment, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	resourceType, err := attachmentResourceType(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{Name: name, Type: resourceType}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT ` + attachmentColumns + `
+        FROM attachments
+        WHERE artifact_type = $1 AND artifact_name = $2 AND version = $3 AND file_name = $4
+    `
+	return scanAttachment(db.getExecutor(tx).QueryRow(ctx, query, artifactType, name, version, fileName))
+}
+
+// DeleteAttachment removes an artifact file from a server or agent version and returns it. The blob is kept since
+// other versions may attach the same content.
+func (db *PostgreSQL) DeleteAttachment(ctx context.Context, tx pgx.Tx, artifactType, name, version, fileName string) (*models.Attachment, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	resourceType, err := attachmentResourceType(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionPublish, auth.Resource{Name: name, Type: resourceType}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        DELETE FROM attachments
+        WHERE artifact_type = $1 AND artifact_name = $2 AND version = $3 AND file_name = $4
+        RETURNING ` + attachmentColumns
+	return scanAttachment(db.getExecutor(tx).QueryRow(ctx, query, artifactType, name, version, fileName))
+}
+
+// deleteAttachments removes the attachments of a deleted server or agent version
+func deleteAttachments(ctx context.Context, executor Executor, artifactType, name, version string) error {
+	query := `DELETE FROM attachments WHERE artifact_type = $1 AND artifact_name = $2 AND version = $3`
+	if _, err := executor.Exec(ctx, query, artifactType, name, version); err != nil {
+		return fmt.Errorf("failed to delete attachments: %w", err)
+	}
+	return nil
+}
+
+func scanAttachment(row pgx.Row) (*models.Attachment, error) {
+	var attachment models.Attachment
+	if err := row.Scan(
+		&attachment.ArtifactType,
+		&attachment.ArtifactName,
+		&attachment.Version,
+		&attachment.FileName,
+		&attachment.Kind,
+		&attachment.MediaType,
+		&attachment.Digest,
+		&attachment.SizeBytes,
+		&attachment.UploadedBy,
+		&attachment.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan attachment: %w", err)
+	}
+	return &attachment, nil
+}
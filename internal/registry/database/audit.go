@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// CreateAuditLogEntry records a mutating operation in the audit log
+func (db *PostgreSQL) CreateAuditLogEntry(ctx context.Context, tx pgx.Tx, entry *models.AuditLogEntry) error {
+	if entry == nil {
+		return fmt.Errorf("%w: audit entry is required", database.ErrInvalidInput)
+	}
+
+	details := entry.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit details: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_log (actor, auth_method, action, resource_type, resource_name, version, details)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, occurred_at
+	`
+
+	err = db.getExecutor(tx).QueryRow(ctx, query,
+		entry.Actor,
+		entry.AuthMethod,
+		entry.Action,
+		entry.ResourceType,
+		entry.ResourceName,
+		entry.Version,
+		detailsJSON,
+	).Scan(&entry.ID, &entry.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditLogEntries retrieves audit log entries (newest first) with optional filtering.
+// The cursor is the ID of the last entry of the previous page.
+func (db *PostgreSQL) ListAuditLogEntries(ctx context.Context, tx pgx.Tx, filter *models.AuditLogFilter, cursor string, limit int) ([]*models.AuditLogEntry, string, error) {
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return nil, "", auth.ErrForbidden
+	}
+
+	if limit <= 0 {
+		limit = 50
+	}
+
+	var whereConditions []string
+	args := []any{}
+	argIndex := 1
+
+	if filter != nil {
+		if filter.Actor != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("actor = $%d", argIndex))
+			args = append(args, *filter.Actor)
+			argIndex++
+		}
+		if filter.Action != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("action = $%d", argIndex))
+			args = append(args, *filter.Action)
+			argIndex++
+		}
+		if filter.ResourceType != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("resource_type = $%d", argIndex))
+			args = append(args, *filter.ResourceType)
+			argIndex++
+		}
+		if filter.ResourceName != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("resource_name = $%d", argIndex))
+			args = append(args, *filter.ResourceName)
+			argIndex++
+		}
+		if filter.Since != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("occurred_at >= $%d", argIndex))
+			args = append(args, *filter.Since)
+			argIndex++
+		}
+		if filter.Until != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("occurred_at < $%d", argIndex))
+			args = append(args, *filter.Until)
+			argIndex++
+		}
+	}
+
+	if cursor != "" {
+		cursorID, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid audit cursor %q", database.ErrInvalidInput, cursor)
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("id < $%d", argIndex))
+		args = append(args, cursorID)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, occurred_at, actor, auth_method, action, resource_type, resource_name, version, details
+		FROM audit_log
+		%s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, whereClause, argIndex)
+	args = append(args, limit)
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.AuditLogEntry
+	for rows.Next() {
+		var e models.AuditLogEntry
+		var detailsJSON []byte
+		if err := rows.Scan(
+			&e.ID,
+			&e.OccurredAt,
+			&e.Actor,
+			&e.AuthMethod,
+			&e.Action,
+			&e.ResourceType,
+			&e.ResourceName,
+			&e.Version,
+			&detailsJSON,
+		); err != nil {
+			return nil, "", fmt.Errorf("failed to scan audit log entry: %w", err)
+		}
+		if len(detailsJSON) > 0 {
+			if err := json.Unmarshal(detailsJSON, &e.Details); err != nil {
+				return nil, "", fmt.Errorf("failed to unmarshal audit details: %w", err)
+			}
+		}
+		entries = append(entries, &e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating audit log: %w", err)
+	}
+
+	nextCursor := ""
+	if len(entries) > 0 && len(entries) >= limit {
+		nextCursor = strconv.FormatInt(entries[len(entries)-1].ID, 10)
+	}
+
+	return entries, nextCursor, nil
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/blobstore"
+)
+
+// UseBlobStorage keeps README content larger than inlineMax bytes in the blob store at location instead of the
+// readme tables. An empty location keeps all content in PostgreSQL. READMEs stored before are read from wherever
+// they were written.
+func (db *PostgreSQL) UseBlobStorage(location string, inlineMax int) error {
+	if location == "" {
+		db.blobs = nil
+		return nil
+	}
+	store, err := blobstore.New(location)
+	if err != nil {
+		return fmt.Errorf("invalid blob storage: %w", err)
+	}
+	db.blobs = store
+	db.blobInlineMax = inlineMax
+	return nil
+}
+
+// offloadReadme moves README content to the blob store when it is larger than the inline limit. It returns the
+// SHA-256 of the stored blob, which is its key, or nil when the content stays in the row.
+func (db *PostgreSQL) offloadReadme(ctx context.Context, content []byte) ([]byte, error) {
+	if db.blobs == nil || len(content) <= db.blobInlineMax {
+		return nil, nil
+	}
+	// A blob written for a transaction that later rolls back is harmless: storing the same content again reuses it
+	if _, err := db.blobs.PutBytes(ctx, content); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+	return sum[:], nil
+}
+
+// loadReadme returns the content of a README row, reading it from the blob store when the row refers to a blob
+func (db *PostgreSQL) loadReadme(ctx context.Context, content []byte, sha256Sum []byte, blobStored bool) ([]byte, error) {
+	if !blobStored {
+		return content, nil
+	}
+	if db.blobs == nil {
+		return nil, fmt.Errorf("readme content is in blob storage but BLOB_STORAGE is not configured")
+	}
+	return db.blobs.ReadAll(ctx, blobstore.Digest(sha256Sum))
+}
@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+const collectionColumns = `collection_name, version, value, is_latest, published, published_at, updated_at`
+
+// CreateCollection inserts a collection version. When isLatest is set the current latest version is unmarked first.
+func (db *PostgreSQL) CreateCollection(ctx context.Context, tx pgx.Tx, collection *models.CollectionJSON, isLatest bool) (*models.CollectionResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionPush, auth.Resource{
+		Name: collection.Name,
+		Type: auth.PermissionArtifactTypeCollection,
+	}); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal collection: %w", err)
+	}
+
+	executor := db.getExecutor(tx)
+	if isLatest {
+		if _, err := executor.Exec(ctx, `UPDATE collections SET is_latest = false WHERE collection_name = $1 AND is_latest`, collection.Name); err != nil {
+			return nil, fmt.Errorf("failed to unmark latest collection version: %w", err)
+		}
+	}
+
+	query := `
+        INSERT INTO collections (collection_name, version, value, is_latest)
+        VALUES ($1, $2, $3, $4)
+        RETURNING ` + collectionColumns
+	created, err := scanCollection(executor.QueryRow(ctx, query, collection.Name, collection.Version, value, isLatest))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return nil, database.ErrInvalidVersion
+		}
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+	return created, nil
+}
+
+// GetCollection retrieves a collection version, or its latest version when version is empty
+func (db *PostgreSQL) GetCollection(ctx context.Context, tx pgx.Tx, name, version string) (*models.CollectionResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: name,
+		Type: auth.PermissionArtifactTypeCollection,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + collectionColumns + ` FROM collections WHERE collection_name = $1 AND is_latest`
+	args := []any{name}
+	if version != "" {
+		query = `SELECT ` + collectionColumns + ` FROM collections WHERE collection_name = $1 AND version = $2`
+		args = append(args, version)
+	}
+	collection, err := scanCollection(db.getExecutor(tx).QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return collection, nil
+}
+
+// ListCollections retrieves the latest version of each collection by name, optionally only published ones
+func (db *PostgreSQL) ListCollections(ctx context.Context, tx pgx.Tx, publishedOnly bool) ([]*models.CollectionResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + collectionColumns + ` FROM collections WHERE is_latest`
+	if publishedOnly {
+		query += ` AND published`
+	}
+	query += ` ORDER BY collection_name`
+	rows, err := db.getExecutor(tx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	defer rows.Close()
+
+	collections := []*models.CollectionResponse{}
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan collection: %w", err)
+		}
+		collections = append(collections, collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating collections: %w", err)
+	}
+	return collections, nil
+}
+
+// SetCollectionPublished publishes or unpublishes a collection version
+func (db *PostgreSQL) SetCollectionPublished(ctx context.Context, tx pgx.Tx, name, version string, published bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionPublish, auth.Resource{
+		Name: name,
+		Type: auth.PermissionArtifactTypeCollection,
+	}); err != nil {
+		return err
+	}
+
+	query := `UPDATE collections SET published = $3, updated_at = NOW() WHERE collection_name = $1 AND version = $2`
+	result, err := db.getExecutor(tx).Exec(ctx, query, name, version, published)
+	if err != nil {
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteCollection removes a collection version. When it was the latest version, the most recently created remaining
+// version becomes the latest.
+func (db *PostgreSQL) DeleteCollection(ctx context.Context, tx pgx.Tx, name, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionDelete, auth.Resource{
+		Name: name,
+		Type: auth.PermissionArtifactTypeCollection,
+	}); err != nil {
+		return err
+	}
+
+	executor := db.getExecutor(tx)
+	var wasLatest bool
+	err := executor.QueryRow(ctx, `DELETE FROM collections WHERE collection_name = $1 AND version = $2 RETURNING is_latest`, name, version).Scan(&wasLatest)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if !wasLatest {
+		return nil
+	}
+
+	query := `
+        UPDATE collections SET is_latest = true
+        WHERE collection_name = $1 AND version = (
+            SELECT version FROM collections WHERE collection_name = $1 ORDER BY published_at DESC LIMIT 1
+        )
+    `
+	if _, err := executor.Exec(ctx, query, name); err != nil {
+		return fmt.Errorf("failed to promote latest collection version: %w", err)
+	}
+	return nil
+}
+
+func scanCollection(row pgx.Row) (*models.CollectionResponse, error) {
+	var (
+		collection models.CollectionResponse
+		official   models.CollectionRegistryExtensions
+		value      []byte
+		name       string
+		version    string
+	)
+	if err := row.Scan(&name, &version, &value, &official.IsLatest, &official.Published, &official.PublishedAt, &official.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(value, &collection.Collection); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal collection: %w", err)
+	}
+	collection.Collection.Name = name
+	collection.Collection.Version = version
+	collection.Meta.Official = &official
+	return &collection, nil
+}
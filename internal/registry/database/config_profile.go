@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// checkConfigProfileAccess requires permission to deploy the server: profiles hold the values its deployments get
+func (db *PostgreSQL) checkConfigProfileAccess(ctx context.Context, serverName string) error {
+	return db.authz.Check(ctx, auth.PermissionActionDeploy, auth.Resource{
+		Name: serverName,
+		Type: auth.PermissionArtifactTypeServer,
+	})
+}
+
+// ListConfigProfiles lists the config profiles of a server ordered by name
+func (db *PostgreSQL) ListConfigProfiles(ctx context.Context, tx pgx.Tx, serverName string) ([]*models.ConfigProfile, error) {
+	if err := db.checkConfigProfileAccess(ctx, serverName); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT server_name, name, description, extends, config, created_by, created_at, updated_at
+		FROM config_profiles
+		WHERE server_name = $1
+		ORDER BY name
+	`
+
+	rows, err := db.getReader(tx).Query(ctx, query, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query config profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*models.ConfigProfile
+	for rows.Next() {
+		profile, err := scanConfigProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating config profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+// GetConfigProfile retrieves a config profile of a server by name
+func (db *PostgreSQL) GetConfigProfile(ctx context.Context, tx pgx.Tx, serverName, name string) (*models.ConfigProfile, error) {
+	if err := db.checkConfigProfileAccess(ctx, serverName); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT server_name, name, description, extends, config, created_by, created_at, updated_at
+		FROM config_profiles
+		WHERE server_name = $1 AND name = $2
+	`
+
+	profile, err := scanConfigProfile(db.getReader(tx).QueryRow(ctx, query, serverName, name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// UpsertConfigProfile creates or replaces a config profile of a server, keeping the creation time of a replaced one
+func (db *PostgreSQL) UpsertConfigProfile(ctx context.Context, tx pgx.Tx, profile *models.ConfigProfile) error {
+	if profile == nil || profile.ServerName == "" || profile.Name == "" {
+		return fmt.Errorf("%w: server name and profile name are required", database.ErrInvalidInput)
+	}
+	if err := db.checkConfigProfileAccess(ctx, profile.ServerName); err != nil {
+		return err
+	}
+
+	configJSON, err := marshalDeploymentConfig(profile.Config)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO config_profiles (server_name, name, description, extends, config, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (server_name, name) DO UPDATE
+		SET description = EXCLUDED.description,
+		    extends = EXCLUDED.extends,
+		    config = EXCLUDED.config,
+		    created_by = EXCLUDED.created_by,
+		    updated_at = EXCLUDED.updated_at
+		RETURNING created_at, updated_at
+	`
+
+	err = db.getExecutor(tx).QueryRow(ctx, query,
+		profile.ServerName,
+		profile.Name,
+		profile.Description,
+		profile.Extends,
+		configJSON,
+		profile.CreatedBy,
+	).Scan(&profile.CreatedAt, &profile.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert config profile: %w", err)
+	}
+	return nil
+}
+
+// DeleteConfigProfile removes a config profile of a server
+func (db *PostgreSQL) DeleteConfigProfile(ctx context.Context, tx pgx.Tx, serverName, name string) error {
+	if err := db.checkConfigProfileAccess(ctx, serverName); err != nil {
+		return err
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM config_profiles WHERE server_name = $1 AND name = $2`, serverName, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete config profile: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+func scanConfigProfile(row pgx.Row) (*models.ConfigProfile, error) {
+	var profile models.ConfigProfile
+	var configJSON []byte
+	if err := row.Scan(
+		&profile.ServerName,
+		&profile.Name,
+		&profile.Description,
+		&profile.Extends,
+		&configJSON,
+		&profile.CreatedBy,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan config profile: %w", err)
+	}
+
+	config, err := unmarshalDeploymentConfig(configJSON)
+	if err != nil {
+		return nil, err
+	}
+	profile.Config = config
+	return &profile, nil
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// ListDeploymentPolicies lists the deployment policies managed through the API.
+// It intentionally skips authz checks since policies are evaluated on behalf of every deployer.
+func (db *PostgreSQL) ListDeploymentPolicies(ctx context.Context, tx pgx.Tx) ([]*models.DeploymentPolicy, error) {
+	query := `
+		SELECT name, description, rule, enforcement, resource_types, registries, created_by, updated_at
+		FROM deployment_policies
+		ORDER BY name
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deployment policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*models.DeploymentPolicy
+	for rows.Next() {
+		p := models.DeploymentPolicy{Source: models.PolicySourceAPI}
+		var updatedAt time.Time
+		if err := rows.Scan(&p.Name, &p.Description, &p.Rule, &p.Enforcement, &p.ResourceTypes, &p.Registries, &p.CreatedBy, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan deployment policy: %w", err)
+		}
+		p.UpdatedAt = &updatedAt
+		policies = append(policies, &p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deployment policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// UpsertDeploymentPolicy creates or replaces a deployment policy (registry admin only)
+func (db *PostgreSQL) UpsertDeploymentPolicy(ctx context.Context, tx pgx.Tx, policy *models.DeploymentPolicy) error {
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return auth.ErrForbidden
+	}
+
+	if policy == nil || policy.Name == "" || policy.Rule == "" {
+		return fmt.Errorf("%w: policy name and rule are required", database.ErrInvalidInput)
+	}
+
+	resourceTypes := policy.ResourceTypes
+	if resourceTypes == nil {
+		resourceTypes = []string{}
+	}
+	registries := policy.Registries
+	if registries == nil {
+		registries = []string{}
+	}
+
+	query := `
+		INSERT INTO deployment_policies (name, description, rule, enforcement, resource_types, registries, created_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (name) DO UPDATE
+		SET description = EXCLUDED.description,
+		    rule = EXCLUDED.rule,
+		    enforcement = EXCLUDED.enforcement,
+		    resource_types = EXCLUDED.resource_types,
+		    registries = EXCLUDED.registries,
+		    created_by = EXCLUDED.created_by,
+		    updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+
+	var updatedAt time.Time
+	err := db.getExecutor(tx).QueryRow(ctx, query,
+		policy.Name,
+		policy.Description,
+		policy.Rule,
+		policy.Enforcement,
+		resourceTypes,
+		registries,
+		policy.CreatedBy,
+	).Scan(&updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert deployment policy: %w", err)
+	}
+	policy.UpdatedAt = &updatedAt
+	policy.Source = models.PolicySourceAPI
+
+	return nil
+}
+
+// DeleteDeploymentPolicy removes a deployment policy by name (registry admin only)
+func (db *PostgreSQL) DeleteDeploymentPolicy(ctx context.Context, tx pgx.Tx, name string) error {
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return auth.ErrForbidden
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM deployment_policies WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete deployment policy: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// CreateDeploymentRevision appends a revision to the history of a deployment, numbering it after the latest one.
+// Revisions are recorded after an authorized change of the deployment, so no separate authz check is made.
+func (db *PostgreSQL) CreateDeploymentRevision(ctx context.Context, tx pgx.Tx, rev *models.DeploymentRevision) error {
+	if rev == nil || rev.ServerName == "" || rev.ResourceType == "" || rev.Version == "" {
+		return fmt.Errorf("%w: name, resource type and version are required", database.ErrInvalidInput)
+	}
+
+	configJSON, err := marshalDeploymentConfig(rev.Config)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO deployment_revisions (server_name, resource_type, revision, version, config, prefer_remote, runtime, target, origin, change)
+		SELECT $1, $2, COALESCE(MAX(revision), 0) + 1, $3, $4, $5, $6, $7, $8, $9
+		FROM deployment_revisions
+		WHERE server_name = $1 AND resource_type = $2
+		RETURNING revision, created_at
+	`
+
+	err = db.getExecutor(tx).QueryRow(ctx, query,
+		rev.ServerName,
+		rev.ResourceType,
+		rev.Version,
+		configJSON,
+		rev.PreferRemote,
+		rev.Runtime,
+		rev.Target,
+		rev.Origin,
+		rev.Change,
+	).Scan(&rev.Revision, &rev.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create deployment revision: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeploymentRevisions returns the revision history of a deployment, newest first
+func (db *PostgreSQL) ListDeploymentRevisions(ctx context.Context, tx pgx.Tx, serverName, resourceType string) ([]*models.DeploymentRevision, error) {
+	artifactType := auth.PermissionArtifactTypeServer
+	if resourceType == "agent" {
+		artifactType = auth.PermissionArtifactTypeAgent
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: serverName,
+		Type: artifactType,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT server_name, resource_type, revision, version, config, prefer_remote, runtime, target, origin, change, created_at
+		FROM deployment_revisions
+		WHERE server_name = $1 AND resource_type = $2
+		ORDER BY revision DESC
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName, resourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deployment revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*models.DeploymentRevision
+	for rows.Next() {
+		var rev models.DeploymentRevision
+		var configJSON []byte
+		if err := rows.Scan(
+			&rev.ServerName,
+			&rev.ResourceType,
+			&rev.Revision,
+			&rev.Version,
+			&configJSON,
+			&rev.PreferRemote,
+			&rev.Runtime,
+			&rev.Target,
+			&rev.Origin,
+			&rev.Change,
+			&rev.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan deployment revision: %w", err)
+		}
+		config, err := unmarshalDeploymentConfig(configJSON)
+		if err != nil {
+			return nil, err
+		}
+		rev.Config = config
+		revisions = append(revisions, &rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deployment revisions: %w", err)
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// GetServerEmbedding retrieves the full semantic embedding (including the vector) of a server version.
+// It returns nil when the version exists but has no embedding.
+func (db *PostgreSQL) GetServerEmbedding(ctx context.Context, tx pgx.Tx, serverName, version string) (*database.SemanticEmbedding, error) {
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: serverName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return nil, err
+	}
+	return db.getEmbedding(ctx, tx, `
+		SELECT semantic_embedding::text, semantic_embedding_provider, semantic_embedding_model,
+		       semantic_embedding_dimensions, semantic_embedding_checksum, semantic_embedding_generated_at
+		FROM servers
+		WHERE server_name = $1 AND version = $2
+	`, serverName, version)
+}
+
+// GetAgentEmbedding retrieves the full semantic embedding (including the vector) of an agent version.
+// It returns nil when the version exists but has no embedding.
+func (db *PostgreSQL) GetAgentEmbedding(ctx context.Context, tx pgx.Tx, agentName, version string) (*database.SemanticEmbedding, error) {
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: agentName,
+		Type: auth.PermissionArtifactTypeAgent,
+	}); err != nil {
+		return nil, err
+	}
+	return db.getEmbedding(ctx, tx, `
+		SELECT semantic_embedding::text, semantic_embedding_provider, semantic_embedding_model,
+		       semantic_embedding_dimensions, semantic_embedding_checksum, semantic_embedding_generated_at
+		FROM agents
+		WHERE agent_name = $1 AND version = $2
+	`, agentName, version)
+}
+
+func (db *PostgreSQL) getEmbedding(ctx context.Context, tx pgx.Tx, query, name, version string) (*database.SemanticEmbedding, error) {
+	var (
+		vector      sql.NullString
+		provider    sql.NullString
+		model       sql.NullString
+		dimensions  sql.NullInt32
+		checksum    sql.NullString
+		generatedAt sql.NullTime
+	)
+	err := db.getExecutor(tx).QueryRow(ctx, query, name, version).Scan(&vector, &provider, &model, &dimensions, &checksum, &generatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch embedding: %w", err)
+	}
+	if !vector.Valid {
+		return nil, nil
+	}
+
+	vec, err := parseVectorLiteral(vector.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse embedding of %s@%s: %w", name, version, err)
+	}
+	return &database.SemanticEmbedding{
+		Vector:     vec,
+		Provider:   provider.String,
+		Model:      model.String,
+		Dimensions: int(dimensions.Int32),
+		Checksum:   checksum.String,
+		Generated:  generatedAt.Time,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+const importColumns = `id, source, options, status, progress, error, created_by, started_at, finished_at`
+
+// importProgress is the part of an import status stored in the progress column
+type importProgress struct {
+	Total     int                    `json:"total"`
+	Processed int                    `json:"processed"`
+	Created   int                    `json:"created"`
+	Updated   int                    `json:"updated"`
+	Skipped   int                    `json:"skipped"`
+	Failed    int                    `json:"failed"`
+	Failures  []models.ImportFailure `json:"failures,omitempty"`
+}
+
+// CreateImport records a new import. Imports are started by the registry itself or by admins through the service,
+// which checks their permissions, so no authz check is done here.
+func (db *PostgreSQL) CreateImport(ctx context.Context, tx pgx.Tx, status *models.ImportStatus) error {
+	if status == nil || status.ID == "" || status.Source == "" {
+		return fmt.Errorf("%w: import ID and source are required", database.ErrInvalidInput)
+	}
+	options, progress, err := marshalImport(status)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO imports (id, source, options, status, progress, error, created_by, started_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	if _, err := db.getExecutor(tx).Exec(ctx, query,
+		status.ID, status.Source, options, status.Status, progress, status.Error, status.CreatedBy, status.StartedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create import: %w", err)
+	}
+	return nil
+}
+
+// UpdateImport records the status and progress of an import
+func (db *PostgreSQL) UpdateImport(ctx context.Context, tx pgx.Tx, status *models.ImportStatus) error {
+	_, progress, err := marshalImport(status)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE imports
+        SET status = $2, progress = $3, error = $4, finished_at = $5
+        WHERE id = $1
+    `
+	result, err := db.getExecutor(tx).Exec(ctx, query, status.ID, status.Status, progress, status.Error, status.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update import: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+// GetImport retrieves an import by ID
+func (db *PostgreSQL) GetImport(ctx context.Context, tx pgx.Tx, id string) (*models.ImportStatus, error) {
+	query := `SELECT ` + importColumns + ` FROM imports WHERE id = $1`
+	return scanImport(db.getExecutor(tx).QueryRow(ctx, query, id))
+}
+
+// ListImports returns the most recent imports, newest first
+func (db *PostgreSQL) ListImports(ctx context.Context, tx pgx.Tx, limit int) ([]models.ImportStatus, error) {
+	query := `SELECT ` + importColumns + ` FROM imports ORDER BY started_at DESC, id LIMIT $1`
+	rows, err := db.getExecutor(tx).Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query imports: %w", err)
+	}
+	defer rows.Close()
+
+	imports := []models.ImportStatus{}
+	for rows.Next() {
+		status, err := scanImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, *status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating imports: %w", err)
+	}
+	return imports, nil
+}
+
+func marshalImport(status *models.ImportStatus) ([]byte, []byte, error) {
+	options, err := json.Marshal(status.Options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal import options: %w", err)
+	}
+	progress, err := json.Marshal(importProgress{
+		Total:     status.Total,
+		Processed: status.Processed,
+		Created:   status.Created,
+		Updated:   status.Updated,
+		Skipped:   status.Skipped,
+		Failed:    status.Failed,
+		Failures:  status.Failures,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal import progress: %w", err)
+	}
+	return options, progress, nil
+}
+
+func scanImport(row pgx.Row) (*models.ImportStatus, error) {
+	var (
+		status            models.ImportStatus
+		options, progress []byte
+	)
+	if err := row.Scan(
+		&status.ID,
+		&status.Source,
+		&options,
+		&status.Status,
+		&progress,
+		&status.Error,
+		&status.CreatedBy,
+		&status.StartedAt,
+		&status.FinishedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan import: %w", err)
+	}
+	if err := json.Unmarshal(options, &status.Options); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal import options: %w", err)
+	}
+	var p importProgress
+	if err := json.Unmarshal(progress, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal import progress: %w", err)
+	}
+	status.Total, status.Processed, status.Failures = p.Total, p.Processed, p.Failures
+	status.Created, status.Updated, status.Skipped, status.Failed = p.Created, p.Updated, p.Skipped, p.Failed
+	return &status, nil
+}
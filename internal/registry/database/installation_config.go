@@ -0,0 +1,35 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// marshalDeploymentConfig encodes a flat deployment config in the sectioned form deployments are stored in
+func marshalDeploymentConfig(config map[string]string) ([]byte, error) {
+	data, err := json.Marshal(models.ParseInstallationConfig(config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalDeploymentConfig decodes a stored deployment config into its flat form. Configs stored flat, before
+// migration 051 sectioned them, are still read.
+func unmarshalDeploymentConfig(data []byte) (map[string]string, error) {
+	if len(data) == 0 {
+		return map[string]string{}, nil
+	}
+	// Sections are objects, while every value of a flat config is a string
+	flat := map[string]string{}
+	if err := json.Unmarshal(data, &flat); err == nil {
+		return flat, nil
+	}
+	var sectioned models.InstallationConfig
+	if err := json.Unmarshal(data, &sectioned); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return sectioned.Flatten(), nil
+}
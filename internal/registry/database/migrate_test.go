@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator_MigrateTo(t *testing.T) {
+	db, ok := NewTestDB(t).(*PostgreSQL)
+	require.True(t, ok)
+	ctx := context.Background()
+
+	conn, err := db.pool.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+	migrator := database.NewMigrator(conn.Conn(), DefaultMigratorConfig())
+
+	tableExists := func(name string) bool {
+		var exists bool
+		require.NoError(t, conn.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", name).Scan(&exists))
+		return exists
+	}
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, statuses)
+	latest := statuses[len(statuses)-1].Version
+	for _, s := range statuses {
+		assert.True(t, s.Applied, "migration %s should be applied", s.Name)
+	}
+	require.True(t, tableExists("deployment_policies"))
+
+	// Roll back the two newest migrations
+	require.NoError(t, migrator.MigrateTo(ctx, 26))
+	assert.False(t, tableExists("deployment_policies"))
+	assert.False(t, tableExists("server_signatures"))
+	assert.True(t, tableExists("agent_sboms"))
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, s := range statuses {
+		assert.Equal(t, s.Version <= 26, s.Applied, "migration %s", s.Name)
+	}
+
+	// Migrations without a down migration cannot be rolled back
+	err = migrator.MigrateTo(ctx, 1)
+	require.ErrorIs(t, err, database.ErrIrreversibleMigration)
+	assert.True(t, tableExists("agent_sboms"), "nothing is rolled back when the rollback cannot complete")
+
+	assert.Error(t, migrator.MigrateTo(ctx, 9999))
+
+	require.NoError(t, migrator.Migrate(ctx))
+	assert.True(t, tableExists("deployment_policies"))
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, latest, statuses[len(statuses)-1].Version)
+	assert.True(t, statuses[len(statuses)-1].Applied)
+}
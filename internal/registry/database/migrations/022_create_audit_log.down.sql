@@ -0,0 +1,3 @@
+-- Revert 022: drop the audit log
+
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,25 @@
+-- Create audit_log table to record every mutating registry operation
+-- Each row captures who (actor), what (action + resource) and when
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id             BIGSERIAL PRIMARY KEY,
+    occurred_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    actor          VARCHAR(255) NOT NULL,
+    auth_method    VARCHAR(50) NOT NULL DEFAULT '',
+    action         VARCHAR(50) NOT NULL,
+    resource_type  VARCHAR(50) NOT NULL,
+    resource_name  VARCHAR(255) NOT NULL,
+    version        VARCHAR(255) NOT NULL DEFAULT '',
+    details        JSONB DEFAULT '{}'::jsonb
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log (occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor);
+CREATE INDEX IF NOT EXISTS idx_audit_log_resource ON audit_log (resource_type, resource_name);
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log (action);
+
+ALTER TABLE audit_log ADD CONSTRAINT check_audit_log_actor_not_empty
+CHECK (length(trim(actor)) > 0);
+
+ALTER TABLE audit_log ADD CONSTRAINT check_audit_log_resource_name_not_empty
+CHECK (length(trim(resource_name)) > 0);
@@ -0,0 +1,3 @@
+-- Revert 023: drop role bindings
+
+DROP TABLE IF EXISTS role_bindings;
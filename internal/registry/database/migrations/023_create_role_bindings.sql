@@ -0,0 +1,17 @@
+-- Role-based access control: roles bound to subjects on namespace patterns
+
+CREATE TABLE IF NOT EXISTS role_bindings (
+    id BIGSERIAL PRIMARY KEY,
+    subject VARCHAR(255) NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    namespace VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT role_bindings_role_check CHECK (role IN ('admin', 'publisher', 'reader')),
+    CONSTRAINT role_bindings_subject_not_empty CHECK (subject <> ''),
+    CONSTRAINT role_bindings_namespace_not_empty CHECK (namespace <> ''),
+    CONSTRAINT role_bindings_unique UNIQUE (subject, role, namespace)
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_bindings_subject ON role_bindings (subject);
@@ -0,0 +1,3 @@
+-- Revert 024: drop API tokens
+
+DROP TABLE IF EXISTS api_tokens;
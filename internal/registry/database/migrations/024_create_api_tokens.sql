@@ -0,0 +1,20 @@
+-- Long-lived API tokens for non-interactive clients (e.g. CI). Only a SHA-256 hash of each token is stored.
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    token_prefix VARCHAR(16) NOT NULL,
+    permissions JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT api_tokens_name_not_empty CHECK (name <> ''),
+    CONSTRAINT api_tokens_subject_not_empty CHECK (subject <> ''),
+    CONSTRAINT api_tokens_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_tokens_subject ON api_tokens (subject);
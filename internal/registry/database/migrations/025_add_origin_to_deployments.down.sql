@@ -0,0 +1,3 @@
+-- Revert 025: drop the deployment origin column
+
+ALTER TABLE deployments DROP COLUMN IF EXISTS origin;
@@ -0,0 +1,7 @@
+-- Track which registry's manifest a deployment was resolved from.
+-- An empty origin means the deployment resolves from this registry.
+
+ALTER TABLE deployments
+ADD COLUMN IF NOT EXISTS origin VARCHAR(2048) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN deployments.origin IS 'Base URL of the registry the deployed manifest is resolved from; empty for this registry';
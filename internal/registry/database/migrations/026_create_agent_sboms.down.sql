@@ -0,0 +1,3 @@
+-- Revert 026: drop agent SBOMs
+
+DROP TABLE IF EXISTS agent_sboms;
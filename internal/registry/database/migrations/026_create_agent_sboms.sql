@@ -0,0 +1,17 @@
+-- Software bills of materials (SPDX or CycloneDX JSON) attached to agent versions
+
+CREATE TABLE IF NOT EXISTS agent_sboms (
+    agent_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    format VARCHAR(32) NOT NULL,
+    content BYTEA NOT NULL,
+    component_count INTEGER NOT NULL DEFAULT 0,
+    size_bytes INTEGER NOT NULL,
+    sha256 BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (agent_name, version),
+    CONSTRAINT agent_sboms_format_check CHECK (format IN ('spdx', 'cyclonedx')),
+    CONSTRAINT fk_agent_sboms_agent FOREIGN KEY (agent_name, version)
+        REFERENCES agents(agent_name, version)
+        ON DELETE CASCADE
+);
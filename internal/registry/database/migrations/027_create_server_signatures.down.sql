@@ -0,0 +1,3 @@
+-- Revert 027: drop server signatures
+
+DROP TABLE IF EXISTS server_signatures;
@@ -0,0 +1,19 @@
+-- Publisher signatures over server.json documents
+
+CREATE TABLE IF NOT EXISTS server_signatures (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    algorithm VARCHAR(32) NOT NULL,
+    signature BYTEA NOT NULL,
+    public_key TEXT NOT NULL,
+    fingerprint VARCHAR(128) NOT NULL,
+    signed_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, version),
+    CONSTRAINT server_signatures_algorithm_check CHECK (algorithm IN ('ed25519', 'ecdsa-p256-sha256')),
+    CONSTRAINT fk_server_signatures_server FOREIGN KEY (server_name, version)
+        REFERENCES servers(server_name, version)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_server_signatures_fingerprint ON server_signatures (fingerprint);
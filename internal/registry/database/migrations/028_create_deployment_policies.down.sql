@@ -0,0 +1,3 @@
+-- Revert 028: drop deployment policies
+
+DROP TABLE IF EXISTS deployment_policies;
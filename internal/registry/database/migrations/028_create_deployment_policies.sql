@@ -0,0 +1,13 @@
+-- Deployment admission policies managed through the admin API
+
+CREATE TABLE IF NOT EXISTS deployment_policies (
+    name VARCHAR(63) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    rule VARCHAR(64) NOT NULL,
+    enforcement VARCHAR(16) NOT NULL DEFAULT 'deny',
+    resource_types TEXT[] NOT NULL DEFAULT '{}',
+    registries TEXT[] NOT NULL DEFAULT '{}',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT deployment_policies_enforcement_check CHECK (enforcement IN ('deny', 'warn'))
+);
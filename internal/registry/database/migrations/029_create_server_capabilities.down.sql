@@ -0,0 +1,3 @@
+-- Revert 029: drop server capabilities
+
+DROP TABLE IF EXISTS server_capabilities;
@@ -0,0 +1,17 @@
+-- Tool, resource and prompt inventory reported by server versions when they were introspected
+
+CREATE TABLE IF NOT EXISTS server_capabilities (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    protocol_version VARCHAR(64) NOT NULL DEFAULT '',
+    source VARCHAR(32) NOT NULL,
+    tools JSONB NOT NULL DEFAULT '[]',
+    resources JSONB NOT NULL DEFAULT '[]',
+    prompts JSONB NOT NULL DEFAULT '[]',
+    error TEXT NOT NULL DEFAULT '',
+    introspected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, version),
+    CONSTRAINT fk_server_capabilities_server FOREIGN KEY (server_name, version)
+        REFERENCES servers(server_name, version)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- Revert 030: drop skill readmes
+
+DROP TABLE IF EXISTS skill_readmes;
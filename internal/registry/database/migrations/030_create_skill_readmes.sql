@@ -0,0 +1,15 @@
+-- README documents uploaded for skill versions, mirroring server_readmes
+
+CREATE TABLE IF NOT EXISTS skill_readmes (
+    skill_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    content BYTEA NOT NULL,
+    content_type TEXT NOT NULL DEFAULT 'text/markdown',
+    size_bytes INTEGER NOT NULL,
+    sha256 BYTEA NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (skill_name, version),
+    CONSTRAINT fk_skill_readmes_skill FOREIGN KEY (skill_name, version)
+        REFERENCES skills(skill_name, version)
+        ON DELETE CASCADE
+);
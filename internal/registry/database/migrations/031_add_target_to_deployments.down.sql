@@ -0,0 +1,3 @@
+-- Revert 031: drop the deployment target column
+
+ALTER TABLE deployments DROP COLUMN IF EXISTS target;
@@ -0,0 +1,7 @@
+-- Record the named deployment target (docker host or kubernetes context) a deployment lands on.
+-- An empty target means the built-in target of the deployment's runtime.
+
+ALTER TABLE deployments
+ADD COLUMN IF NOT EXISTS target VARCHAR(255) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN deployments.target IS 'Name of the deployment target; empty for the built-in target of the runtime';
@@ -0,0 +1,3 @@
+-- Revert 032: drop the deployment canary weight column
+
+ALTER TABLE deployments DROP COLUMN IF EXISTS canary_weight;
@@ -0,0 +1,7 @@
+-- Record the canary weight of a deployment: the percentage of traffic the agent gateway routes to a canary
+-- version running alongside the stable deployment of the same MCP server. Zero marks a stable deployment.
+
+ALTER TABLE deployments
+ADD COLUMN IF NOT EXISTS canary_weight INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN deployments.canary_weight IS 'Percentage of traffic routed to this canary version; 0 for stable deployments';
@@ -0,0 +1,3 @@
+-- Revert 033: drop deployment revisions
+
+DROP TABLE IF EXISTS deployment_revisions;
@@ -0,0 +1,16 @@
+-- History of deployment revisions, so an upgrade or config change can be rolled back
+
+CREATE TABLE IF NOT EXISTS deployment_revisions (
+    server_name VARCHAR(255) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL,
+    revision INTEGER NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    config JSONB NOT NULL DEFAULT '{}',
+    prefer_remote BOOLEAN NOT NULL DEFAULT FALSE,
+    runtime VARCHAR(50) NOT NULL DEFAULT 'local',
+    target VARCHAR(255) NOT NULL DEFAULT '',
+    origin TEXT NOT NULL DEFAULT '',
+    change VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, resource_type, revision)
+);
@@ -0,0 +1,3 @@
+-- Revert 034: drop agent readmes
+
+DROP TABLE IF EXISTS agent_readmes;
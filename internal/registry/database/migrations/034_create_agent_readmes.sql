@@ -0,0 +1,15 @@
+-- README documents uploaded for agent versions, mirroring server_readmes
+
+CREATE TABLE IF NOT EXISTS agent_readmes (
+    agent_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    content BYTEA NOT NULL,
+    content_type TEXT NOT NULL DEFAULT 'text/markdown',
+    size_bytes INTEGER NOT NULL,
+    sha256 BYTEA NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (agent_name, version),
+    CONSTRAINT fk_agent_readmes_agent FOREIGN KEY (agent_name, version)
+        REFERENCES agents(agent_name, version)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- Revert 035: drop server enrichments
+
+DROP TABLE IF EXISTS server_enrichments;
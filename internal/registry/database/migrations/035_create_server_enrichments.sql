@@ -0,0 +1,14 @@
+-- When the details of server versions were last refreshed by the enrichment job
+
+CREATE TABLE IF NOT EXISTS server_enrichments (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    enriched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    error TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (server_name, version),
+    CONSTRAINT fk_server_enrichments_server FOREIGN KEY (server_name, version)
+        REFERENCES servers(server_name, version)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_server_enrichments_enriched_at ON server_enrichments (enriched_at);
@@ -0,0 +1,3 @@
+-- Revert 036: drop artifact usage stats
+
+DROP TABLE IF EXISTS artifact_stats;
@@ -0,0 +1,12 @@
+-- Usage counts of servers, agents and skills, used for stats and sort=popularity
+
+CREATE TABLE IF NOT EXISTS artifact_stats (
+    artifact_type VARCHAR(16) NOT NULL,
+    artifact_name VARCHAR(255) NOT NULL,
+    downloads BIGINT NOT NULL DEFAULT 0,
+    deploys BIGINT NOT NULL DEFAULT 0,
+    installs BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (artifact_type, artifact_name),
+    CONSTRAINT check_artifact_stats_type CHECK (artifact_type IN ('mcp', 'agent', 'skill'))
+);
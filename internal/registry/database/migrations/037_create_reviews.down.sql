@@ -0,0 +1,3 @@
+-- Revert 037: drop reviews
+
+DROP TABLE IF EXISTS reviews;
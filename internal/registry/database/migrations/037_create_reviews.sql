@@ -0,0 +1,18 @@
+-- Star ratings and short reviews of servers and agents, one per user and artifact
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id BIGSERIAL PRIMARY KEY,
+    artifact_type VARCHAR(16) NOT NULL,
+    artifact_name VARCHAR(255) NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    rating SMALLINT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    hidden BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_reviews_author UNIQUE (artifact_type, artifact_name, author),
+    CONSTRAINT check_reviews_type CHECK (artifact_type IN ('mcp', 'agent')),
+    CONSTRAINT check_reviews_rating CHECK (rating BETWEEN 1 AND 5)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_artifact ON reviews (artifact_type, artifact_name, id DESC);
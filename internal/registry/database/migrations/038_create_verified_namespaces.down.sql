@@ -0,0 +1,3 @@
+-- Revert 038: drop verified_namespaces
+
+DROP TABLE IF EXISTS verified_namespaces;
@@ -0,0 +1,10 @@
+-- Namespaces whose ownership a user proved through GitHub organization membership or a DNS TXT record
+
+CREATE TABLE IF NOT EXISTS verified_namespaces (
+    namespace VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    method VARCHAR(16) NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (namespace, subject),
+    CONSTRAINT check_verified_namespaces_method CHECK (method IN ('github', 'dns'))
+);
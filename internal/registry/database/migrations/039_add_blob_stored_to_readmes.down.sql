@@ -0,0 +1,6 @@
+-- Revert 039: drop blob_stored from the readme tables. READMEs kept in blob storage must be moved back into
+-- PostgreSQL first, as their rows hold no content.
+
+ALTER TABLE skill_readmes DROP COLUMN IF EXISTS blob_stored;
+ALTER TABLE agent_readmes DROP COLUMN IF EXISTS blob_stored;
+ALTER TABLE server_readmes DROP COLUMN IF EXISTS blob_stored;
@@ -0,0 +1,6 @@
+-- README content larger than BLOB_INLINE_MAX_BYTES moves to blob storage when it is configured. The row keeps the
+-- SHA-256 of the content, which is the blob's key, and an empty content column.
+
+ALTER TABLE server_readmes ADD COLUMN IF NOT EXISTS blob_stored BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE agent_readmes ADD COLUMN IF NOT EXISTS blob_stored BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE skill_readmes ADD COLUMN IF NOT EXISTS blob_stored BOOLEAN NOT NULL DEFAULT false;
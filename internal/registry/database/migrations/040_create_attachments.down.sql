@@ -0,0 +1,3 @@
+-- Revert 040: drop artifact attachments
+
+DROP TABLE IF EXISTS attachments;
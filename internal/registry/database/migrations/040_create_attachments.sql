@@ -0,0 +1,18 @@
+-- Artifact files attached to server and agent versions. The content is kept in blob storage under its digest.
+
+CREATE TABLE IF NOT EXISTS attachments (
+    artifact_type VARCHAR(16) NOT NULL,
+    artifact_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    media_type VARCHAR(255) NOT NULL,
+    digest VARCHAR(71) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    uploaded_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (artifact_type, artifact_name, version, file_name),
+    CONSTRAINT check_attachments_type CHECK (artifact_type IN ('mcp', 'agent'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_digest ON attachments (digest);
@@ -0,0 +1,3 @@
+-- Revert 041: drop imports
+
+DROP TABLE IF EXISTS imports;
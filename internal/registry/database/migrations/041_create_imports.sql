@@ -0,0 +1,16 @@
+-- Imports of seed data, started at startup or through the imports API, with their options and progress
+
+CREATE TABLE IF NOT EXISTS imports (
+    id VARCHAR(64) PRIMARY KEY,
+    source TEXT NOT NULL,
+    options JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(16) NOT NULL,
+    progress JSONB NOT NULL DEFAULT '{}',
+    error TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_imports_status CHECK (status IN ('running', 'succeeded', 'failed', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_imports_started_at ON imports (started_at DESC);
@@ -0,0 +1,4 @@
+-- Revert 042: drop the license column from servers. The licenses stay in each server's _meta.
+
+DROP INDEX IF EXISTS idx_servers_license;
+ALTER TABLE servers DROP COLUMN IF EXISTS license;
@@ -0,0 +1,7 @@
+-- The license of each server version, taken from the aregistry.ai/license publisher-provided _meta entry, as an
+-- indexed column for ?license= filtering
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS license VARCHAR(255)
+    GENERATED ALWAYS AS (value #>> '{_meta,io.modelcontextprotocol.registry/publisher-provided,aregistry.ai/license,spdx}') STORED;
+
+CREATE INDEX IF NOT EXISTS idx_servers_license ON servers (lower(license));
@@ -0,0 +1,7 @@
+-- Revert 043: drop the tags table and the tags columns of servers and agents. The tags stay in each entry's JSON.
+
+DROP TABLE IF EXISTS tags;
+DROP INDEX IF EXISTS idx_agents_tags;
+ALTER TABLE agents DROP COLUMN IF EXISTS tags;
+DROP INDEX IF EXISTS idx_servers_tags;
+ALTER TABLE servers DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,18 @@
+-- Tags of servers and agents as indexed columns for ?tags= filtering, and the number of published servers and
+-- agents carrying each tag, refreshed by the tag-counts job. Server tags live in the aregistry.ai/tags
+-- publisher-provided _meta entry, agent tags in the agent's tags field.
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS tags JSONB
+    GENERATED ALWAYS AS (COALESCE(value #> '{_meta,io.modelcontextprotocol.registry/publisher-provided,aregistry.ai/tags}', '[]'::jsonb)) STORED;
+CREATE INDEX IF NOT EXISTS idx_servers_tags ON servers USING GIN (tags);
+
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS tags JSONB
+    GENERATED ALWAYS AS (COALESCE(value -> 'tags', '[]'::jsonb)) STORED;
+CREATE INDEX IF NOT EXISTS idx_agents_tags ON agents USING GIN (tags);
+
+CREATE TABLE IF NOT EXISTS tags (
+    name VARCHAR(50) PRIMARY KEY,
+    server_count INTEGER NOT NULL DEFAULT 0,
+    agent_count INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+-- Revert 044: drop the collections table.
+
+DROP TABLE IF EXISTS collections;
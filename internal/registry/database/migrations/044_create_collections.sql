@@ -0,0 +1,17 @@
+-- Collections: named, versioned lists of servers, agents and skills installed together. Each row is one version of
+-- a collection; value holds the CollectionJSON payload.
+
+CREATE TABLE IF NOT EXISTS collections (
+    collection_name VARCHAR(255) NOT NULL,
+    version         VARCHAR(255) NOT NULL,
+    value           JSONB NOT NULL,
+    is_latest       BOOLEAN NOT NULL DEFAULT true,
+    published       BOOLEAN NOT NULL DEFAULT false,
+    published_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT collections_pkey PRIMARY KEY (collection_name, version)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_latest ON collections (collection_name) WHERE is_latest;
+CREATE INDEX IF NOT EXISTS idx_collections_published ON collections (published);
@@ -0,0 +1,13 @@
+-- Revert 045: drop the workspace columns and the workspaces tables. Entries, deployments and tokens are kept.
+
+ALTER TABLE api_tokens DROP COLUMN IF EXISTS workspace;
+ALTER TABLE deployments DROP COLUMN IF EXISTS workspace;
+DROP INDEX IF EXISTS idx_skills_workspace;
+ALTER TABLE skills DROP COLUMN IF EXISTS workspace;
+DROP INDEX IF EXISTS idx_agents_workspace;
+ALTER TABLE agents DROP COLUMN IF EXISTS workspace;
+DROP INDEX IF EXISTS idx_servers_workspace;
+ALTER TABLE servers DROP COLUMN IF EXISTS workspace;
+DROP INDEX IF EXISTS idx_workspace_members_subject;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,33 @@
+-- Workspaces group the entries, deployments, members and API tokens of a team. Entries and deployments keep the
+-- workspace they were created in; unpublished entries of a workspace are only visible to its members. A workspace
+-- cannot be deleted while entries or deployments still belong to it; its API tokens are deleted with it.
+
+CREATE TABLE IF NOT EXISTS workspaces (
+    name VARCHAR(63) PRIMARY KEY,
+    display_name VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT workspaces_name_format CHECK (name ~ '^[a-z0-9]([a-z0-9-]*[a-z0-9])?$')
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace VARCHAR(63) NOT NULL REFERENCES workspaces (name) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT workspace_members_pkey PRIMARY KEY (workspace, subject),
+    CONSTRAINT workspace_members_role CHECK (role IN ('owner', 'member'))
+);
+CREATE INDEX IF NOT EXISTS idx_workspace_members_subject ON workspace_members (subject);
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS workspace VARCHAR(63) REFERENCES workspaces (name);
+CREATE INDEX IF NOT EXISTS idx_servers_workspace ON servers (workspace) WHERE workspace IS NOT NULL;
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS workspace VARCHAR(63) REFERENCES workspaces (name);
+CREATE INDEX IF NOT EXISTS idx_agents_workspace ON agents (workspace) WHERE workspace IS NOT NULL;
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS workspace VARCHAR(63) REFERENCES workspaces (name);
+CREATE INDEX IF NOT EXISTS idx_skills_workspace ON skills (workspace) WHERE workspace IS NOT NULL;
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS workspace VARCHAR(63) REFERENCES workspaces (name);
+ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS workspace VARCHAR(63) REFERENCES workspaces (name) ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+-- Revert 046: drop the visibility columns. The visibility stays in each entry's JSON.
+
+ALTER TABLE skills DROP COLUMN IF EXISTS visibility;
+ALTER TABLE agents DROP COLUMN IF EXISTS visibility;
+ALTER TABLE servers DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,18 @@
+-- Visibility of servers, agents and skills: public, unlisted (left out of listings and search) or private (only
+-- visible to the members of the entry's workspace). It is kept in each entry's JSON, the server's in the
+-- aregistry.ai/visibility publisher-provided _meta entry, so editing an entry changes it.
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS visibility VARCHAR(20)
+    GENERATED ALWAYS AS (COALESCE(NULLIF(value #>> '{_meta,io.modelcontextprotocol.registry/publisher-provided,aregistry.ai/visibility}', ''), 'public')) STORED;
+ALTER TABLE servers ADD CONSTRAINT servers_visibility_check
+    CHECK (visibility IN ('public', 'unlisted', 'private') AND (visibility <> 'private' OR workspace IS NOT NULL));
+
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS visibility VARCHAR(20)
+    GENERATED ALWAYS AS (COALESCE(NULLIF(value ->> 'visibility', ''), 'public')) STORED;
+ALTER TABLE agents ADD CONSTRAINT agents_visibility_check
+    CHECK (visibility IN ('public', 'unlisted', 'private') AND (visibility <> 'private' OR workspace IS NOT NULL));
+
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS visibility VARCHAR(20)
+    GENERATED ALWAYS AS (COALESCE(NULLIF(value ->> 'visibility', ''), 'public')) STORED;
+ALTER TABLE skills ADD CONSTRAINT skills_visibility_check
+    CHECK (visibility IN ('public', 'unlisted', 'private') AND (visibility <> 'private' OR workspace IS NOT NULL));
@@ -0,0 +1,19 @@
+-- Revert 047: drop server aliases and restore the foreign keys without ON UPDATE CASCADE.
+
+ALTER TABLE server_enrichments DROP CONSTRAINT IF EXISTS fk_server_enrichments_server;
+ALTER TABLE server_enrichments ADD CONSTRAINT fk_server_enrichments_server FOREIGN KEY (server_name, version)
+    REFERENCES servers(server_name, version) ON DELETE CASCADE;
+
+ALTER TABLE server_capabilities DROP CONSTRAINT IF EXISTS fk_server_capabilities_server;
+ALTER TABLE server_capabilities ADD CONSTRAINT fk_server_capabilities_server FOREIGN KEY (server_name, version)
+    REFERENCES servers(server_name, version) ON DELETE CASCADE;
+
+ALTER TABLE server_signatures DROP CONSTRAINT IF EXISTS fk_server_signatures_server;
+ALTER TABLE server_signatures ADD CONSTRAINT fk_server_signatures_server FOREIGN KEY (server_name, version)
+    REFERENCES servers(server_name, version) ON DELETE CASCADE;
+
+ALTER TABLE server_readmes DROP CONSTRAINT IF EXISTS fk_server_readmes_server;
+ALTER TABLE server_readmes ADD CONSTRAINT fk_server_readmes_server FOREIGN KEY (server_name, version)
+    REFERENCES servers(server_name, version) ON DELETE CASCADE;
+
+DROP TABLE IF EXISTS server_aliases;
@@ -0,0 +1,27 @@
+-- Former names of renamed servers. Lookups by a former name are redirected to the server's current name.
+
+CREATE TABLE IF NOT EXISTS server_aliases (
+    alias VARCHAR(255) PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_server_aliases_server_name ON server_aliases (server_name);
+
+-- Renaming a server updates the rows that belong to its versions
+ALTER TABLE server_readmes DROP CONSTRAINT IF EXISTS fk_server_readmes_server;
+ALTER TABLE server_readmes ADD CONSTRAINT fk_server_readmes_server FOREIGN KEY (server_name, version)
+    REFERENCES servers(server_name, version) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE server_signatures DROP CONSTRAINT IF EXISTS fk_server_signatures_server;
+ALTER TABLE server_signatures ADD CONSTRAINT fk_server_signatures_server FOREIGN KEY (server_name, version)
+    REFERENCES servers(server_name, version) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE server_capabilities DROP CONSTRAINT IF EXISTS fk_server_capabilities_server;
+ALTER TABLE server_capabilities ADD CONSTRAINT fk_server_capabilities_server FOREIGN KEY (server_name, version)
+    REFERENCES servers(server_name, version) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE server_enrichments DROP CONSTRAINT IF EXISTS fk_server_enrichments_server;
+ALTER TABLE server_enrichments ADD CONSTRAINT fk_server_enrichments_server FOREIGN KEY (server_name, version)
+    REFERENCES servers(server_name, version) ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,6 @@
+-- Revert 048: drop soft delete. Server versions in the trash are removed for good.
+
+DELETE FROM servers WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_servers_deleted_at;
+ALTER TABLE servers DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE servers DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,7 @@
+-- Soft delete of server versions. Deleted versions stay in the table, hidden from lookups, until they are restored
+-- or purged once the trash retention has passed.
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS deleted_by VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_servers_deleted_at ON servers (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Revert 049: drop the event outbox
+
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,20 @@
+-- Create the transactional outbox holding registry events until they are delivered
+-- Events are written in the same transaction as the change they describe and drained by the outbox-dispatch job
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              BIGSERIAL PRIMARY KEY,
+    occurred_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    event_type      VARCHAR(100) NOT NULL,
+    resource_type   VARCHAR(50) NOT NULL,
+    resource_name   VARCHAR(255) NOT NULL,
+    version         VARCHAR(255) NOT NULL DEFAULT '',
+    actor           VARCHAR(255) NOT NULL DEFAULT '',
+    details         JSONB NOT NULL DEFAULT '{}'::jsonb,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error      TEXT,
+    delivered_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (next_attempt_at, id) WHERE delivered_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_delivered_at ON outbox_events (delivered_at) WHERE delivered_at IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Revert 050: drop the covering list index and the server summary columns
+
+DROP INDEX IF EXISTS idx_servers_list_covering;
+
+ALTER TABLE servers DROP COLUMN IF EXISTS description;
+ALTER TABLE servers DROP COLUMN IF EXISTS title;
@@ -0,0 +1,9 @@
+-- Generated title and description columns and a covering index, so pages of servers in the summary view are read
+-- from the (server_name, version) index without loading the server.json documents
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS title TEXT GENERATED ALWAYS AS (value ->> 'title') STORED;
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS description TEXT GENERATED ALWAYS AS (value ->> 'description') STORED;
+
+CREATE INDEX IF NOT EXISTS idx_servers_list_covering ON servers (server_name, version)
+    INCLUDE (status, published, published_at, updated_at, is_latest, workspace, visibility, title, description)
+    WHERE deleted_at IS NULL;
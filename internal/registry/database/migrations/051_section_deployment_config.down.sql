@@ -0,0 +1,21 @@
+-- Revert 051: flatten sectioned deployment configs back into ARG_, HEADER_ and RESOURCE_ prefixed keys
+
+CREATE FUNCTION flatten_deployment_config(sectioned JSONB) RETURNS JSONB AS $$
+    SELECT COALESCE(jsonb_object_agg(
+        CASE section
+            WHEN 'args' THEN 'ARG_' || entry.key
+            WHEN 'headers' THEN 'HEADER_' || entry.key
+            WHEN 'resources' THEN 'RESOURCE_' || entry.key
+            ELSE entry.key
+        END, entry.value), '{}'::jsonb)
+    FROM jsonb_each(sectioned) AS s(section, entries), jsonb_each(s.entries) AS entry
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE deployments SET config = flatten_deployment_config(config)
+WHERE config IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM jsonb_each(config) WHERE jsonb_typeof(value) <> 'object');
+
+UPDATE deployment_revisions SET config = flatten_deployment_config(config)
+WHERE NOT EXISTS (SELECT 1 FROM jsonb_each(config) WHERE jsonb_typeof(value) <> 'object');
+
+DROP FUNCTION flatten_deployment_config(JSONB);
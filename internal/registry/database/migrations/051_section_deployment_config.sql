@@ -0,0 +1,36 @@
+-- Store deployment config in env, args, headers and resources sections instead of flat keys where ARG_, HEADER_ and
+-- RESOURCE_ prefixes mark the sections other than the environment. Configs that already hold sections are left alone.
+
+CREATE FUNCTION section_deployment_config(flat JSONB) RETURNS JSONB AS $$
+    SELECT COALESCE(jsonb_object_agg(section, entries), '{}'::jsonb)
+    FROM (
+        SELECT section, jsonb_object_agg(name, value) AS entries
+        FROM (
+            SELECT
+                CASE
+                    WHEN key LIKE 'ARG\_%' AND length(key) > 4 THEN 'args'
+                    WHEN key LIKE 'HEADER\_%' AND length(key) > 7 THEN 'headers'
+                    WHEN key LIKE 'RESOURCE\_%' AND length(key) > 9 THEN 'resources'
+                    ELSE 'env'
+                END AS section,
+                CASE
+                    WHEN key LIKE 'ARG\_%' AND length(key) > 4 THEN substr(key, 5)
+                    WHEN key LIKE 'HEADER\_%' AND length(key) > 7 THEN substr(key, 8)
+                    WHEN key LIKE 'RESOURCE\_%' AND length(key) > 9 THEN substr(key, 10)
+                    ELSE key
+                END AS name,
+                value
+            FROM jsonb_each(flat)
+        ) AS keyed
+        GROUP BY section
+    ) AS sections
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE deployments SET config = section_deployment_config(config)
+WHERE config IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM jsonb_each(config) WHERE jsonb_typeof(value) = 'object');
+
+UPDATE deployment_revisions SET config = section_deployment_config(config)
+WHERE NOT EXISTS (SELECT 1 FROM jsonb_each(config) WHERE jsonb_typeof(value) = 'object');
+
+DROP FUNCTION section_deployment_config(JSONB);
@@ -0,0 +1,3 @@
+-- Revert 052: drop server config profiles
+
+DROP TABLE IF EXISTS config_profiles;
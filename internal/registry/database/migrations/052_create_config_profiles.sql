@@ -0,0 +1,15 @@
+-- Create named config profiles of servers, e.g. dev and prod, that deployments can start from
+-- config holds the sectioned form deployments store their config in; extends names a profile of the same server
+-- whose keys this profile inherits
+
+CREATE TABLE IF NOT EXISTS config_profiles (
+    server_name VARCHAR(255) NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    extends     VARCHAR(100) NOT NULL DEFAULT '',
+    config      JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_by  VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, name)
+);
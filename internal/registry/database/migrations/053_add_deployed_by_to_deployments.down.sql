@@ -0,0 +1,4 @@
+-- Revert 053: drop the creator of deployments
+
+DROP INDEX IF EXISTS idx_deployments_deployed_by;
+ALTER TABLE deployments DROP COLUMN IF EXISTS deployed_by;
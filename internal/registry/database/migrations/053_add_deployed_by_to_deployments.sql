@@ -0,0 +1,4 @@
+-- Record who created each deployment, so deployment quotas can be counted per user outside of workspaces
+
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS deployed_by VARCHAR(255) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_deployments_deployed_by ON deployments (deployed_by) WHERE workspace IS NULL;
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// UpsertVerifiedNamespace records that a subject proved ownership of a namespace, refreshing the method and time of
+// an earlier verification
+func (db *PostgreSQL) UpsertVerifiedNamespace(ctx context.Context, tx pgx.Tx, verified *models.VerifiedNamespace) (*models.VerifiedNamespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if verified == nil || verified.Namespace == "" || verified.Subject == "" || verified.Method == "" {
+		return nil, fmt.Errorf("%w: namespace, subject and method are required", database.ErrInvalidInput)
+	}
+
+	query := `
+        INSERT INTO verified_namespaces (namespace, subject, method)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (namespace, subject) DO UPDATE
+        SET method = EXCLUDED.method,
+            verified_at = NOW()
+        RETURNING namespace, subject, method, verified_at
+    `
+	var result models.VerifiedNamespace
+	if err := db.getExecutor(tx).QueryRow(ctx, query, verified.Namespace, verified.Subject, verified.Method).Scan(
+		&result.Namespace,
+		&result.Subject,
+		&result.Method,
+		&result.VerifiedAt,
+	); err != nil {
+		return nil, fmt.Errorf("failed to store verified namespace: %w", err)
+	}
+	return &result, nil
+}
+
+// ListVerifiedNamespaces returns verified namespaces ordered by namespace, optionally only those of one namespace or
+// one subject
+func (db *PostgreSQL) ListVerifiedNamespaces(ctx context.Context, tx pgx.Tx, namespace, subject string) ([]models.VerifiedNamespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+        SELECT namespace, subject, method, verified_at
+        FROM verified_namespaces
+        WHERE ($1 = '' OR namespace = $1) AND ($2 = '' OR subject = $2)
+        ORDER BY namespace, verified_at
+    `
+	rows, err := db.getExecutor(tx).Query(ctx, query, namespace, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query verified namespaces: %w", err)
+	}
+	defer rows.Close()
+
+	namespaces := []models.VerifiedNamespace{}
+	for rows.Next() {
+		var verified models.VerifiedNamespace
+		if err := rows.Scan(&verified.Namespace, &verified.Subject, &verified.Method, &verified.VerifiedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan verified namespace: %w", err)
+		}
+		namespaces = append(namespaces, verified)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating verified namespaces: %w", err)
+	}
+	return namespaces, nil
+}
+
+// DeleteVerifiedNamespace revokes the verification of a namespace for a subject. Only registry admins may revoke
+// verifications.
+func (db *PostgreSQL) DeleteVerifiedNamespace(ctx context.Context, tx pgx.Tx, namespace, subject string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return auth.ErrForbidden
+	}
+
+	var deleted string
+	err := db.getExecutor(tx).QueryRow(ctx,
+		`DELETE FROM verified_namespaces WHERE namespace = $1 AND subject = $2 RETURNING namespace`,
+		namespace, subject,
+	).Scan(&deleted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete verified namespace: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// CreateOutboxEvent writes an event to the outbox. Pass the transaction of the change the event describes, so the
+// event is only delivered when the change is committed.
+func (db *PostgreSQL) CreateOutboxEvent(ctx context.Context, tx pgx.Tx, event *models.Event) error {
+	if event == nil {
+		return fmt.Errorf("%w: event is required", database.ErrInvalidInput)
+	}
+
+	details := event.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event details: %w", err)
+	}
+
+	err = db.getExecutor(tx).QueryRow(ctx, `
+		INSERT INTO outbox_events (event_type, resource_type, resource_name, version, actor, details)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, occurred_at
+	`, event.Type, event.ResourceType, event.ResourceName, event.Version, event.Actor, detailsJSON).Scan(&event.ID, &event.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to create outbox event: %w", err)
+	}
+	return nil
+}
+
+// ClaimOutboxEvents locks up to limit undelivered events that are due, oldest first. Events locked by another
+// transaction are skipped, so several registry instances can drain the outbox at the same time. The events stay
+// locked until tx ends, which must be a transaction.
+func (db *PostgreSQL) ClaimOutboxEvents(ctx context.Context, tx pgx.Tx, limit int) ([]models.Event, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("%w: claiming outbox events requires a transaction", database.ErrInvalidInput)
+	}
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, event_type, occurred_at, resource_type, resource_name, version, actor, details, attempts
+		FROM outbox_events
+		WHERE delivered_at IS NULL AND next_attempt_at <= NOW()
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.Event{}
+	for rows.Next() {
+		var event models.Event
+		var detailsJSON []byte
+		if err := rows.Scan(&event.ID, &event.Type, &event.OccurredAt, &event.ResourceType, &event.ResourceName,
+			&event.Version, &event.Actor, &detailsJSON, &event.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		if len(detailsJSON) > 0 {
+			if err := json.Unmarshal(detailsJSON, &event.Details); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal event details: %w", err)
+			}
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox events: %w", err)
+	}
+	return events, nil
+}
+
+// MarkOutboxEventDelivered records that an event reached every event handler
+func (db *PostgreSQL) MarkOutboxEventDelivered(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	_, err := db.getExecutor(tx).Exec(ctx, `
+		UPDATE outbox_events SET delivered_at = NOW(), last_error = NULL WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkOutboxEventFailed records a failed delivery of an event and when to retry it
+func (db *PostgreSQL) MarkOutboxEventFailed(ctx context.Context, tx pgx.Tx, id int64, lastError string, retryAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	_, err := db.getExecutor(tx).Exec(ctx, `
+		UPDATE outbox_events SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1
+	`, id, lastError, retryAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteDeliveredOutboxEvents removes the events delivered before deliveredBefore and returns how many were removed
+func (db *PostgreSQL) DeleteDeliveredOutboxEvents(ctx context.Context, tx pgx.Tx, deliveredBefore time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return 0, auth.ErrForbidden
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `
+		DELETE FROM outbox_events WHERE delivered_at IS NOT NULL AND delivered_at < $1
+	`, deliveredBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete delivered outbox events: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
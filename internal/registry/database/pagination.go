@@ -0,0 +1,22 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keysetCondition returns the condition selecting the rows after cursor in (name, version) order, where cursor is the
+// "<name>:<version>" of the last row of the previous page or just a name. The row comparison lets PostgreSQL start
+// the scan of the (name, version) index right after the cursor instead of skipping the rows before it.
+func keysetCondition(nameColumn, cursor string, argIndex int) (string, []any) {
+	name, version, ok := strings.Cut(cursor, ":")
+	if !ok {
+		return fmt.Sprintf("%s > $%d", nameColumn, argIndex), []any{cursor}
+	}
+	return fmt.Sprintf("(%s, version) > ($%d, $%d)", nameColumn, argIndex, argIndex+1), []any{name, version}
+}
+
+// keysetCursor returns the cursor of the page following a row
+func keysetCursor(name, version string) string {
+	return name + ":" + version
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replicaRetryDelay is how long reads go to the primary after the read replica could not be reached
+const replicaRetryDelay = 30 * time.Second
+
+// PoolConfig tunes a PostgreSQL connection pool. Zero values keep the pgxpool defaults.
+type PoolConfig struct {
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+}
+
+// DefaultPoolConfig returns the pool settings used when none are configured
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxConns:        30,               // Handle good concurrent load
+		MinConns:        5,                // Keep connections warm for fast response
+		MaxConnLifetime: 2 * time.Hour,    // Refresh connections regularly for stability
+		MaxConnIdleTime: 30 * time.Minute, // Keep connections available for bursts
+	}
+}
+
+// newPool parses connectionURI and creates a pool with the given settings
+func newPool(ctx context.Context, connectionURI string, poolConfig PoolConfig) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connectionURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PostgreSQL config: %w", err)
+	}
+
+	if poolConfig.MaxConns > 0 {
+		config.MaxConns = poolConfig.MaxConns
+	}
+	if poolConfig.MinConns > 0 {
+		config.MinConns = min(poolConfig.MinConns, config.MaxConns)
+	}
+	if poolConfig.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = poolConfig.MaxConnLifetime
+	}
+	if poolConfig.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = poolConfig.MaxConnIdleTime
+	}
+	config.ConnConfig.Tracer = queryTracer{} // Trace queries made within a traced request
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PostgreSQL pool: %w", err)
+	}
+	return pool, nil
+}
+
+// UseReadReplica sends the list, get and search queries made outside a transaction to the read-only replica at
+// connectionURI. While the replica cannot be reached, those queries fall back to the primary. The replica is not
+// migrated, so it must replicate the primary's schema.
+func (db *PostgreSQL) UseReadReplica(ctx context.Context, connectionURI string, poolConfig PoolConfig) error {
+	if connectionURI == "" {
+		db.replica = nil
+		return nil
+	}
+	pool, err := newPool(ctx, connectionURI, poolConfig)
+	if err != nil {
+		return fmt.Errorf("invalid read replica: %w", err)
+	}
+	replica := &readReplica{pool: pool, primary: db.pool}
+	if err := pool.Ping(ctx); err != nil {
+		replica.markDown(err)
+	}
+	db.replica = replica
+	return nil
+}
+
+// getReader returns the executor for read-only queries: tx when given, otherwise the read replica unless it is
+// unreachable, otherwise the primary
+func (db *PostgreSQL) getReader(tx pgx.Tx) Executor {
+	if tx != nil {
+		return tx
+	}
+	if db.replica != nil && db.replica.available() {
+		return db.replica
+	}
+	return db.pool
+}
+
+// readReplica runs queries on a read replica, retrying them on the primary when the replica cannot be reached
+type readReplica struct {
+	pool    *pgxpool.Pool
+	primary *pgxpool.Pool
+	// downUntil is when, in Unix nanoseconds, the replica is tried again after it could not be reached
+	downUntil atomic.Int64
+}
+
+var _ Executor = (*readReplica)(nil)
+
+func (r *readReplica) available() bool {
+	return time.Now().UnixNano() >= r.downUntil.Load()
+}
+
+func (r *readReplica) markDown(err error) {
+	now := time.Now()
+	if r.downUntil.Swap(now.Add(replicaRetryDelay).UnixNano()) < now.UnixNano() {
+		log.Printf("Warning: read replica unreachable, reading from the primary for %s: %v", replicaRetryDelay, err)
+	}
+}
+
+// unreachable reports whether err means the query never reached the replica, so it is safe to run it elsewhere
+func (r *readReplica) unreachable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || pgconn.SafeToRetry(err)
+}
+
+// Exec runs on the primary: the replica only serves reads
+func (r *readReplica) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return r.primary.Exec(ctx, sql, arguments...)
+}
+
+func (r *readReplica) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if r.unreachable(ctx, err) {
+		r.markDown(err)
+		return r.primary.Query(ctx, sql, args...)
+	}
+	return rows, err
+}
+
+func (r *readReplica) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return &replicaRow{
+		row: r.pool.QueryRow(ctx, sql, args...),
+		fallback: func(err error) pgx.Row {
+			if !r.unreachable(ctx, err) {
+				return nil
+			}
+			r.markDown(err)
+			return r.primary.QueryRow(ctx, sql, args...)
+		},
+	}
+}
+
+// replicaRow is a row read from the replica that is read from the primary instead when the replica was unreachable
+type replicaRow struct {
+	row      pgx.Row
+	fallback func(err error) pgx.Row
+}
+
+func (r *replicaRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	if err == nil {
+		return nil
+	}
+	if row := r.fallback(err); row != nil {
+		return row.Scan(dest...)
+	}
+	return err
+}
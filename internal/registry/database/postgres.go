@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/blobstore"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
@@ -26,6 +28,11 @@ import (
 type PostgreSQL struct {
 	pool  *pgxpool.Pool
 	authz auth.Authorizer
+	// replica serves reads outside transactions when DATABASE_READ_URL is configured
+	replica *readReplica
+	// blobs keeps README content larger than blobInlineMax bytes when BLOB_STORAGE is configured
+	blobs         *blobstore.Store
+	blobInlineMax int
 }
 
 const semanticMetadataKey = "aregistry.ai/semantic"
@@ -47,22 +54,14 @@ func (db *PostgreSQL) getExecutor(tx pgx.Tx) Executor {
 
 // NewPostgreSQL creates a new instance of the PostgreSQL database
 func NewPostgreSQL(ctx context.Context, connectionURI string, authz auth.Authorizer) (*PostgreSQL, error) {
-	// Parse connection config for pool settings
-	config, err := pgxpool.ParseConfig(connectionURI)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse PostgreSQL config: %w", err)
-	}
-
-	// Configure pool for stability-focused defaults
-	config.MaxConns = 30                      // Handle good concurrent load
-	config.MinConns = 5                       // Keep connections warm for fast response
-	config.MaxConnIdleTime = 30 * time.Minute // Keep connections available for bursts
-	config.MaxConnLifetime = 2 * time.Hour    // Refresh connections regularly for stability
+	return NewPostgreSQLWithPool(ctx, connectionURI, authz, DefaultPoolConfig())
+}
 
-	// Create connection pool with configured settings
-	pool, err := pgxpool.NewWithConfig(ctx, config)
+// NewPostgreSQLWithPool creates a new instance of the PostgreSQL database with the given connection pool settings
+func NewPostgreSQLWithPool(ctx context.Context, connectionURI string, authz auth.Authorizer, poolConfig PoolConfig) (*PostgreSQL, error) {
+	pool, err := newPool(ctx, connectionURI, poolConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create PostgreSQL pool: %w", err)
+		return nil, err
 	}
 
 	// Test the connection
@@ -112,6 +111,14 @@ func (db *PostgreSQL) ListServers(
 			return nil, "", fmt.Errorf("invalid semantic embedding: %w", err)
 		}
 	}
+	byPopularity := filter != nil && filter.SortByPopularity && !semanticActive
+	var offset int
+	if byPopularity {
+		var err error
+		if offset, err = popularityOffset(cursor); err != nil {
+			return nil, "", err
+		}
+	}
 
 	var whereConditions []string
 	args := []any{}
@@ -153,25 +160,45 @@ func (db *PostgreSQL) ListServers(
 			args = append(args, *filter.Published)
 			argIndex++
 		}
+		if filter.License != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("lower(license) = lower($%d)", argIndex))
+			args = append(args, *filter.License)
+			argIndex++
+		}
+		if len(filter.Tags) > 0 {
+			tags, err := json.Marshal(filter.Tags)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid tags filter: %w", err)
+			}
+			whereConditions = append(whereConditions, fmt.Sprintf("tags @> $%d::jsonb", argIndex))
+			args = append(args, string(tags))
+			argIndex++
+		}
+		if filter.Workspace != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("workspace = $%d", argIndex))
+			args = append(args, *filter.Workspace)
+			argIndex++
+		}
+	}
+	// Deleted versions stay in the trash until they are restored or purged
+	whereConditions = append(whereConditions, "deleted_at IS NULL")
+	// Unlisted entries stay reachable by name, e.g. for listing their versions, but are left out of other listings
+	listing := filter == nil || (filter.Name == nil && filter.RemoteURL == nil)
+	if condition, conditionArgs := db.workspaceVisibility(ctx, argIndex, listing); condition != "" {
+		whereConditions = append(whereConditions, condition)
+		args = append(args, conditionArgs...)
+		argIndex++
 	}
 
 	if semanticActive {
 		whereConditions = append(whereConditions, "semantic_embedding IS NOT NULL")
 	}
 
-	if cursor != "" && !semanticActive {
-		parts := strings.SplitN(cursor, ":", 2)
-		if len(parts) == 2 {
-			cursorServerName := parts[0]
-			cursorVersion := parts[1]
-			whereConditions = append(whereConditions, fmt.Sprintf("(server_name > $%d OR (server_name = $%d AND version > $%d))", argIndex, argIndex+1, argIndex+2))
-			args = append(args, cursorServerName, cursorServerName, cursorVersion)
-			argIndex += 3
-		} else {
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name > $%d", argIndex))
-			args = append(args, cursor)
-			argIndex++
-		}
+	if cursor != "" && !semanticActive && !byPopularity {
+		condition, cursorArgs := keysetCondition("server_name", cursor, argIndex)
+		whereConditions = append(whereConditions, condition)
+		args = append(args, cursorArgs...)
+		argIndex += len(cursorArgs)
 	}
 
 	whereClause := ""
@@ -179,9 +206,19 @@ func (db *PostgreSQL) ListServers(
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
+	// The summary view reads the generated title and description columns instead of the JSON document, so a page of
+	// latest versions can be served from the covering (server_name, version) index
+	summary := filter != nil && filter.Summary
+	documentColumns := "value"
+	if summary {
+		documentColumns = "COALESCE(title, ''), COALESCE(description, '')"
+	}
 	selectClause := `
-        SELECT server_name, version, status, published, published_at, updated_at, is_latest, value`
+        SELECT server_name, version, status, published, published_at, updated_at, is_latest, ` + documentColumns
 	orderClause := "ORDER BY server_name, version"
+	if byPopularity {
+		orderClause = "ORDER BY " + popularityOrder("mcp", "server_name") + ", server_name, version"
+	}
 
 	if semanticActive {
 		selectClause += fmt.Sprintf(", semantic_embedding <=> $%d::vector AS semantic_score", argIndex)
@@ -207,11 +244,11 @@ func (db *PostgreSQL) ListServers(
         FROM servers
         %s
         %s
-        LIMIT $%d
-    `, selectClause, whereClause, orderClause, argIndex)
-	args = append(args, limit)
+        LIMIT $%d OFFSET $%d
+    `, selectClause, whereClause, orderClause, argIndex, argIndex+1)
+	args = append(args, limit, offset)
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	rows, err := db.getReader(tx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to query servers: %w", err)
 	}
@@ -219,25 +256,30 @@ func (db *PostgreSQL) ListServers(
 
 	var results []*apiv0.ServerResponse
 	for rows.Next() {
-		var serverName, version, status string
+		var serverName, version, status, title, description string
 		var published, isLatest bool
 		var publishedAt, updatedAt time.Time
 		var valueJSON []byte
 		var semanticScore sql.NullFloat64
 
-		var scanErr error
-		if semanticActive {
-			scanErr = rows.Scan(&serverName, &version, &status, &published, &publishedAt, &updatedAt, &isLatest, &valueJSON, &semanticScore)
+		dest := []any{&serverName, &version, &status, &published, &publishedAt, &updatedAt, &isLatest}
+		if summary {
+			dest = append(dest, &title, &description)
 		} else {
-			scanErr = rows.Scan(&serverName, &version, &status, &published, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+			dest = append(dest, &valueJSON)
 		}
-		if scanErr != nil {
-			return nil, "", fmt.Errorf("failed to scan server row: %w", scanErr)
+		if semanticActive {
+			dest = append(dest, &semanticScore)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
 		}
 
-		var serverJSON apiv0.ServerJSON
-		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-			return nil, "", fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		serverJSON := apiv0.ServerJSON{Name: serverName, Version: version, Title: title, Description: description}
+		if !summary {
+			if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+				return nil, "", fmt.Errorf("failed to unmarshal server JSON: %w", err)
+			}
 		}
 
 		if semanticActive && semanticScore.Valid {
@@ -264,9 +306,11 @@ func (db *PostgreSQL) ListServers(
 	}
 
 	nextCursor := ""
-	if !semanticActive && len(results) > 0 && len(results) >= limit {
+	if byPopularity && len(results) >= limit {
+		nextCursor = strconv.Itoa(offset + len(results))
+	} else if !semanticActive && len(results) > 0 && len(results) >= limit {
 		lastResult := results[len(results)-1]
-		nextCursor = lastResult.Server.Name + ":" + lastResult.Server.Version
+		nextCursor = keysetCursor(lastResult.Server.Name, lastResult.Server.Version)
 	}
 
 	return results, nextCursor, nil
@@ -300,10 +344,11 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 		return nil, err
 	}
 
+	visible, args := db.andWorkspaceVisible(ctx, serverName)
 	query := `
 		SELECT server_name, version, status, published_at, updated_at, is_latest, published, value
 		FROM servers
-		WHERE server_name = $1 AND is_latest = true
+		WHERE server_name = $1 AND is_latest = true` + visible + `
 		ORDER BY published_at DESC
 		LIMIT 1
 	`
@@ -313,7 +358,7 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	var isLatest, published bool
 	var valueJSON []byte
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &published, &valueJSON)
+	err := db.getReader(tx).QueryRow(ctx, query, args...).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &published, &valueJSON)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
@@ -356,11 +401,11 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 		return nil, err
 	}
 
+	visible, args := db.andWorkspaceVisible(ctx, serverName, version)
 	query := `
 		SELECT server_name, version, status, published, published_at, updated_at, is_latest, value
 		FROM servers
-		WHERE server_name = $1 AND version = $2
-	`
+		WHERE server_name = $1 AND version = $2 AND deleted_at IS NULL` + visible
 
 	if publishedOnly {
 		query += ` AND published = true`
@@ -376,7 +421,7 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	var publishedAt, updatedAt time.Time
 	var valueJSON []byte
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &published, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	err := db.getReader(tx).QueryRow(ctx, query, args...).Scan(&name, &vers, &status, &published, &publishedAt, &updatedAt, &isLatest, &valueJSON)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
@@ -420,10 +465,11 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 		return nil, err
 	}
 
+	visible, args := db.andWorkspaceVisible(ctx, serverName)
 	query := `
 		SELECT server_name, version, status, published, published_at, updated_at, is_latest, value
 		FROM servers
-		WHERE server_name = $1`
+		WHERE server_name = $1 AND deleted_at IS NULL` + visible
 
 	if publishedOnly {
 		query += ` AND published = true`
@@ -433,7 +479,7 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 		ORDER BY published_at DESC
 	`
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, serverName)
+	rows, err := db.getReader(tx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query server versions: %w", err)
 	}
@@ -484,6 +530,127 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 	return results, nil
 }
 
+// GetServersByNames retrieves every version of the named servers in a single query, ordered by name and then most
+// recently published first. Names without a visible version are left out.
+func (db *PostgreSQL) GetServersByNames(ctx context.Context, tx pgx.Tx, serverNames []string, publishedOnly bool) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(serverNames) == 0 {
+		return nil, nil
+	}
+
+	for _, serverName := range serverNames {
+		if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+			Name: serverName,
+			Type: auth.PermissionArtifactTypeServer,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	visible, args := db.andWorkspaceVisible(ctx, serverNames)
+	query := `
+		SELECT server_name, version, status, published, published_at, updated_at, is_latest, value
+		FROM servers
+		WHERE server_name = ANY($1) AND deleted_at IS NULL` + visible
+
+	if publishedOnly {
+		query += ` AND published = true`
+	}
+
+	query += `
+		ORDER BY server_name, published_at DESC
+	`
+
+	rows, err := db.getReader(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query servers: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*apiv0.ServerResponse
+	for rows.Next() {
+		var name, version, status string
+		var published, isLatest bool
+		var publishedAt, updatedAt time.Time
+		var valueJSON []byte
+
+		if err := rows.Scan(&name, &version, &status, &published, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan server row: %w", err)
+		}
+
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+
+		results = append(results, &apiv0.ServerResponse{
+			Server: serverJSON,
+			Meta: apiv0.ResponseMeta{
+				Official: &apiv0.RegistryExtensions{
+					Status:      model.Status(status),
+					PublishedAt: publishedAt,
+					UpdatedAt:   updatedAt,
+					IsLatest:    isLatest,
+				},
+			},
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// ListServerVersionStatuses retrieves the status and published flag of every version of a server, newest first
+func (db *PostgreSQL) ListServerVersionStatuses(ctx context.Context, tx pgx.Tx, serverName string) ([]*models.ServerVersionStatus, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: serverName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT version, status, published, is_latest, published_at, updated_at
+		FROM servers
+		WHERE server_name = $1 AND deleted_at IS NULL
+		ORDER BY published_at DESC
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server version statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*models.ServerVersionStatus
+	for rows.Next() {
+		var vs models.ServerVersionStatus
+		if err := rows.Scan(&vs.Version, &vs.Status, &vs.Published, &vs.IsLatest, &vs.PublishedAt, &vs.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan server version status: %w", err)
+		}
+		results = append(results, &vs)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, database.ErrNotFound
+	}
+
+	return results, nil
+}
+
 // CreateServer inserts a new server version with official metadata
 func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
@@ -513,8 +680,8 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 
 	// Insert the new server version using composite primary key
 	insertQuery := `
-		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value, workspace)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
 	_, err = db.getExecutor(tx).Exec(ctx, insertQuery,
@@ -525,9 +692,13 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
 		valueJSON,
+		nullableWorkspace(ctx),
 	)
 
 	if err != nil {
+		if isVisibilityViolation(err) {
+			return nil, errPrivateOutsideWorkspace
+		}
 		return nil, fmt.Errorf("failed to insert server: %w", err)
 	}
 
@@ -575,7 +746,7 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 	query := `
 		UPDATE servers
 		SET value = $1, updated_at = NOW()
-		WHERE server_name = $2 AND version = $3
+		WHERE server_name = $2 AND version = $3 AND deleted_at IS NULL
 		RETURNING server_name, version, status, published, published_at, updated_at, is_latest
 	`
 
@@ -588,6 +759,9 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
+		if isVisibilityViolation(err) {
+			return nil, errPrivateOutsideWorkspace
+		}
 		return nil, fmt.Errorf("failed to update server: %w", err)
 	}
 
@@ -624,7 +798,7 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 	query := `
 		UPDATE servers
 		SET status = $1, updated_at = NOW()
-		WHERE server_name = $2 AND version = $3
+		WHERE server_name = $2 AND version = $3 AND deleted_at IS NULL
 		RETURNING server_name, version, status, published, value, published_at, updated_at, is_latest
 	`
 
@@ -876,7 +1050,7 @@ func (db *PostgreSQL) PublishServer(ctx context.Context, tx pgx.Tx, serverName,
 	}
 
 	executor := db.getExecutor(tx)
-	query := `UPDATE servers SET published = true, published_date = NOW() WHERE server_name = $1 AND version = $2`
+	query := `UPDATE servers SET published = true, published_date = NOW() WHERE server_name = $1 AND version = $2 AND deleted_at IS NULL`
 
 	result, err := executor.Exec(ctx, query, serverName, version)
 	if err != nil {
@@ -905,7 +1079,7 @@ func (db *PostgreSQL) UnpublishServer(ctx context.Context, tx pgx.Tx, serverName
 	}
 
 	executor := db.getExecutor(tx)
-	query := `UPDATE servers SET published = false, unpublished_date = NOW() WHERE server_name = $1 AND version = $2`
+	query := `UPDATE servers SET published = false, unpublished_date = NOW() WHERE server_name = $1 AND version = $2 AND deleted_at IS NULL`
 
 	result, err := executor.Exec(ctx, query, serverName, version)
 	if err != nil {
@@ -941,6 +1115,9 @@ func (db *PostgreSQL) DeleteServer(ctx context.Context, tx pgx.Tx, serverName, v
 	if result.RowsAffected() == 0 {
 		return database.ErrNotFound
 	}
+	if err := deleteAttachments(ctx, executor, "mcp", serverName, version); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -958,7 +1135,7 @@ func (db *PostgreSQL) IsServerPublished(ctx context.Context, tx pgx.Tx, serverNa
 	}
 
 	executor := db.getExecutor(tx)
-	query := `SELECT published FROM servers WHERE server_name = $1 AND version = $2`
+	query := `SELECT published FROM servers WHERE server_name = $1 AND version = $2 AND deleted_at IS NULL`
 
 	var published bool
 	err := executor.QueryRow(ctx, query, serverName, version).Scan(&published)
@@ -1149,26 +1326,37 @@ func (db *PostgreSQL) UpsertServerReadme(ctx context.Context, tx pgx.Tx, readme
 		readme.FetchedAt = time.Now()
 	}
 
+	content := readme.Content
+	blobSum, err := db.offloadReadme(ctx, readme.Content)
+	if err != nil {
+		return fmt.Errorf("failed to store server readme: %w", err)
+	}
+	if blobSum != nil {
+		content, readme.SHA256 = []byte{}, blobSum
+	}
+
 	executor := db.getExecutor(tx)
 	query := `
-        INSERT INTO server_readmes (server_name, version, content, content_type, size_bytes, sha256, fetched_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        INSERT INTO server_readmes (server_name, version, content, content_type, size_bytes, sha256, fetched_at, blob_stored)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
         ON CONFLICT (server_name, version) DO UPDATE
         SET content = EXCLUDED.content,
             content_type = EXCLUDED.content_type,
             size_bytes = EXCLUDED.size_bytes,
             sha256 = EXCLUDED.sha256,
-            fetched_at = EXCLUDED.fetched_at
+            fetched_at = EXCLUDED.fetched_at,
+            blob_stored = EXCLUDED.blob_stored
     `
 
 	if _, err := executor.Exec(ctx, query,
 		readme.ServerName,
 		readme.Version,
-		readme.Content,
+		content,
 		readme.ContentType,
 		readme.SizeBytes,
 		readme.SHA256,
 		readme.FetchedAt,
+		blobSum != nil,
 	); err != nil {
 		return fmt.Errorf("failed to upsert server readme: %w", err)
 	}
@@ -1189,16 +1377,16 @@ func (db *PostgreSQL) GetServerReadme(ctx context.Context, tx pgx.Tx, serverName
 		return nil, err
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getReader(tx)
 	query := `
-        SELECT server_name, version, content, content_type, size_bytes, sha256, fetched_at
+        SELECT server_name, version, content, content_type, size_bytes, sha256, fetched_at, blob_stored
         FROM server_readmes
         WHERE server_name = $1 AND version = $2
         LIMIT 1
     `
 
 	row := executor.QueryRow(ctx, query, serverName, version)
-	return scanServerReadme(row)
+	return db.scanServerReadme(ctx, row)
 }
 
 func (db *PostgreSQL) GetLatestServerReadme(ctx context.Context, tx pgx.Tx, serverName string) (*database.ServerReadme, error) {
@@ -1214,9 +1402,9 @@ func (db *PostgreSQL) GetLatestServerReadme(ctx context.Context, tx pgx.Tx, serv
 		return nil, err
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getReader(tx)
 	query := `
-        SELECT sr.server_name, sr.version, sr.content, sr.content_type, sr.size_bytes, sr.sha256, sr.fetched_at
+        SELECT sr.server_name, sr.version, sr.content, sr.content_type, sr.size_bytes, sr.sha256, sr.fetched_at, sr.blob_stored
         FROM server_readmes sr
         INNER JOIN servers s ON sr.server_name = s.server_name AND sr.version = s.version
         WHERE sr.server_name = $1 AND s.is_latest = true
@@ -1224,11 +1412,12 @@ func (db *PostgreSQL) GetLatestServerReadme(ctx context.Context, tx pgx.Tx, serv
     `
 
 	row := executor.QueryRow(ctx, query, serverName)
-	return scanServerReadme(row)
+	return db.scanServerReadme(ctx, row)
 }
 
-func scanServerReadme(row pgx.Row) (*database.ServerReadme, error) {
+func (db *PostgreSQL) scanServerReadme(ctx context.Context, row pgx.Row) (*database.ServerReadme, error) {
 	var readme database.ServerReadme
+	var blobStored bool
 	if err := row.Scan(
 		&readme.ServerName,
 		&readme.Version,
@@ -1237,12 +1426,18 @@ func scanServerReadme(row pgx.Row) (*database.ServerReadme, error) {
 		&readme.SizeBytes,
 		&readme.SHA256,
 		&readme.FetchedAt,
+		&blobStored,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to scan server readme: %w", err)
 	}
+	content, err := db.loadReadme(ctx, readme.Content, readme.SHA256, blobStored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server readme: %w", err)
+	}
+	readme.Content = content
 	return &readme, nil
 }
 
@@ -1268,6 +1463,14 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 			return nil, "", fmt.Errorf("invalid semantic embedding: %w", err)
 		}
 	}
+	byPopularity := filter != nil && filter.SortByPopularity && !semanticActive
+	var offset int
+	if byPopularity {
+		var err error
+		if offset, err = popularityOffset(cursor); err != nil {
+			return nil, "", err
+		}
+	}
 
 	var whereConditions []string
 	args := []any{}
@@ -1309,25 +1512,38 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 			args = append(args, *filter.Published)
 			argIndex++
 		}
+		if len(filter.Tags) > 0 {
+			tags, err := json.Marshal(filter.Tags)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid tags filter: %w", err)
+			}
+			whereConditions = append(whereConditions, fmt.Sprintf("tags @> $%d::jsonb", argIndex))
+			args = append(args, string(tags))
+			argIndex++
+		}
+		if filter.Workspace != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("workspace = $%d", argIndex))
+			args = append(args, *filter.Workspace)
+			argIndex++
+		}
+	}
+	// Unlisted entries stay reachable by name, e.g. for listing their versions, but are left out of other listings
+	listing := filter == nil || (filter.Name == nil && filter.RemoteURL == nil)
+	if condition, conditionArgs := db.workspaceVisibility(ctx, argIndex, listing); condition != "" {
+		whereConditions = append(whereConditions, condition)
+		args = append(args, conditionArgs...)
+		argIndex++
 	}
 
 	if semanticActive {
 		whereConditions = append(whereConditions, "semantic_embedding IS NOT NULL")
 	}
 
-	if cursor != "" && !semanticActive {
-		parts := strings.SplitN(cursor, ":", 2)
-		if len(parts) == 2 {
-			cursorName := parts[0]
-			cursorVersion := parts[1]
-			whereConditions = append(whereConditions, fmt.Sprintf("(agent_name > $%d OR (agent_name = $%d AND version > $%d))", argIndex, argIndex+1, argIndex+2))
-			args = append(args, cursorName, cursorName, cursorVersion)
-			argIndex += 3
-		} else {
-			whereConditions = append(whereConditions, fmt.Sprintf("agent_name > $%d", argIndex))
-			args = append(args, cursor)
-			argIndex++
-		}
+	if cursor != "" && !semanticActive && !byPopularity {
+		condition, cursorArgs := keysetCondition("agent_name", cursor, argIndex)
+		whereConditions = append(whereConditions, condition)
+		args = append(args, cursorArgs...)
+		argIndex += len(cursorArgs)
 	}
 
 	whereClause := ""
@@ -1338,6 +1554,9 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 	selectClause := `
 		SELECT agent_name, version, status, published_at, updated_at, is_latest, published, value`
 	orderClause := "ORDER BY agent_name, version"
+	if byPopularity {
+		orderClause = "ORDER BY " + popularityOrder("agent", "agent_name") + ", agent_name, version"
+	}
 
 	if semanticActive {
 		selectClause += fmt.Sprintf(", semantic_embedding <=> $%d::vector AS semantic_score", argIndex)
@@ -1364,11 +1583,11 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 		FROM agents
 		%s
 		%s
-		LIMIT $%d
-	`, selectClause, whereClause, orderClause, argIndex)
-	args = append(args, limit)
+		LIMIT $%d OFFSET $%d
+	`, selectClause, whereClause, orderClause, argIndex, argIndex+1)
+	args = append(args, limit, offset)
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	rows, err := db.getReader(tx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to query agents: %w", err)
 	}
@@ -1422,9 +1641,11 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 	}
 
 	nextCursor := ""
-	if !semanticActive && len(results) > 0 && len(results) >= limit {
+	if byPopularity && len(results) >= limit {
+		nextCursor = strconv.Itoa(offset + len(results))
+	} else if !semanticActive && len(results) > 0 && len(results) >= limit {
 		last := results[len(results)-1]
-		nextCursor = last.Agent.Name + ":" + last.Agent.Version
+		nextCursor = keysetCursor(last.Agent.Name, last.Agent.Version)
 	}
 	return results, nextCursor, nil
 }
@@ -1442,10 +1663,11 @@ func (db *PostgreSQL) GetAgentByName(ctx context.Context, tx pgx.Tx, agentName s
 		return nil, err
 	}
 
+	visible, args := db.andWorkspaceVisible(ctx, agentName)
 	query := `
 		SELECT agent_name, version, status, published_at, updated_at, is_latest, published, value
 		FROM agents
-		WHERE agent_name = $1 AND is_latest = true
+		WHERE agent_name = $1 AND is_latest = true` + visible + `
 		ORDER BY published_at DESC
 		LIMIT 1
 	`
@@ -1453,7 +1675,7 @@ func (db *PostgreSQL) GetAgentByName(ctx context.Context, tx pgx.Tx, agentName s
 	var publishedAt, updatedAt time.Time
 	var isLatest, published bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, agentName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &published, &valueJSON); err != nil {
+	if err := db.getReader(tx).QueryRow(ctx, query, args...).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &published, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -1490,17 +1712,18 @@ func (db *PostgreSQL) GetAgentByNameAndVersion(ctx context.Context, tx pgx.Tx, a
 		return nil, err
 	}
 
+	visible, args := db.andWorkspaceVisible(ctx, agentName, version)
 	query := `
 		SELECT agent_name, version, status, published_at, updated_at, is_latest, value
 		FROM agents
-		WHERE agent_name = $1 AND version = $2
+		WHERE agent_name = $1 AND version = $2` + visible + `
 		LIMIT 1
 	`
 	var name, vers, status string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, agentName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+	if err := db.getReader(tx).QueryRow(ctx, query, args...).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -1535,13 +1758,14 @@ func (db *PostgreSQL) GetAllVersionsByAgentName(ctx context.Context, tx pgx.Tx,
 		return nil, err
 	}
 
+	visible, args := db.andWorkspaceVisible(ctx, agentName)
 	query := `
 		SELECT agent_name, version, status, published_at, updated_at, is_latest, value
 		FROM agents
-		WHERE agent_name = $1
+		WHERE agent_name = $1` + visible + `
 		ORDER BY published_at DESC
 	`
-	rows, err := db.getExecutor(tx).Query(ctx, query, agentName)
+	rows, err := db.getReader(tx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query agent versions: %w", err)
 	}
@@ -1603,8 +1827,8 @@ func (db *PostgreSQL) CreateAgent(ctx context.Context, tx pgx.Tx, agentJSON *mod
 		return nil, fmt.Errorf("failed to marshal agent JSON: %w", err)
 	}
 	insert := `
-		INSERT INTO agents (agent_name, version, status, published_at, updated_at, is_latest, value)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO agents (agent_name, version, status, published_at, updated_at, is_latest, value, workspace)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 	if _, err := db.getExecutor(tx).Exec(ctx, insert,
 		agentJSON.Name,
@@ -1614,7 +1838,11 @@ func (db *PostgreSQL) CreateAgent(ctx context.Context, tx pgx.Tx, agentJSON *mod
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
 		valueJSON,
+		nullableWorkspace(ctx),
 	); err != nil {
+		if isVisibilityViolation(err) {
+			return nil, errPrivateOutsideWorkspace
+		}
 		return nil, fmt.Errorf("failed to insert agent: %w", err)
 	}
 	return &models.AgentResponse{
@@ -1660,6 +1888,9 @@ func (db *PostgreSQL) UpdateAgent(ctx context.Context, tx pgx.Tx, agentName, ver
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
+		if isVisibilityViolation(err) {
+			return nil, errPrivateOutsideWorkspace
+		}
 		return nil, fmt.Errorf("failed to update agent: %w", err)
 	}
 	return &models.AgentResponse{
@@ -2109,38 +2340,56 @@ func (db *PostgreSQL) ListSkills(ctx context.Context, tx pgx.Tx, filter *databas
 			args = append(args, *filter.Published)
 			argIndex++
 		}
+		if filter.Workspace != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("workspace = $%d", argIndex))
+			args = append(args, *filter.Workspace)
+			argIndex++
+		}
+	}
+	// Unlisted entries stay reachable by name, e.g. for listing their versions, but are left out of other listings
+	listing := filter == nil || (filter.Name == nil && filter.RemoteURL == nil)
+	if condition, conditionArgs := db.workspaceVisibility(ctx, argIndex, listing); condition != "" {
+		whereConditions = append(whereConditions, condition)
+		args = append(args, conditionArgs...)
+		argIndex++
 	}
 
-	if cursor != "" {
-		parts := strings.SplitN(cursor, ":", 2)
-		if len(parts) == 2 {
-			cursorName := parts[0]
-			cursorVersion := parts[1]
-			whereConditions = append(whereConditions, fmt.Sprintf("(skill_name > $%d OR (skill_name = $%d AND version > $%d))", argIndex, argIndex+1, argIndex+2))
-			args = append(args, cursorName, cursorName, cursorVersion)
-			argIndex += 3
-		} else {
-			whereConditions = append(whereConditions, fmt.Sprintf("skill_name > $%d", argIndex))
-			args = append(args, cursor)
-			argIndex++
+	byPopularity := filter != nil && filter.SortByPopularity
+	var offset int
+	if byPopularity {
+		var err error
+		if offset, err = popularityOffset(cursor); err != nil {
+			return nil, "", err
 		}
 	}
 
+	if cursor != "" && !byPopularity {
+		condition, cursorArgs := keysetCondition("skill_name", cursor, argIndex)
+		whereConditions = append(whereConditions, condition)
+		args = append(args, cursorArgs...)
+		argIndex += len(cursorArgs)
+	}
+
 	whereClause := ""
 	if len(whereConditions) > 0 {
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
+	orderClause := "ORDER BY skill_name, version"
+	if byPopularity {
+		orderClause = "ORDER BY " + popularityOrder("skill", "skill_name") + ", skill_name, version"
+	}
+
 	query := fmt.Sprintf(`
         SELECT skill_name, version, status, published_at, updated_at, is_latest, published, value
         FROM skills
         %s
-        ORDER BY skill_name, version
-        LIMIT $%d
-    `, whereClause, argIndex)
-	args = append(args, limit)
+        %s
+        LIMIT $%d OFFSET $%d
+    `, whereClause, orderClause, argIndex, argIndex+1)
+	args = append(args, limit, offset)
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	rows, err := db.getReader(tx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to query skills: %w", err)
 	}
@@ -2181,9 +2430,11 @@ func (db *PostgreSQL) ListSkills(ctx context.Context, tx pgx.Tx, filter *databas
 	}
 
 	nextCursor := ""
-	if len(results) > 0 && len(results) >= limit {
+	if byPopularity && len(results) >= limit {
+		nextCursor = strconv.Itoa(offset + len(results))
+	} else if len(results) > 0 && len(results) >= limit {
 		last := results[len(results)-1]
-		nextCursor = last.Skill.Name + ":" + last.Skill.Version
+		nextCursor = keysetCursor(last.Skill.Name, last.Skill.Version)
 	}
 	return results, nextCursor, nil
 }
@@ -2200,10 +2451,11 @@ func (db *PostgreSQL) GetSkillByName(ctx context.Context, tx pgx.Tx, skillName s
 		return nil, err
 	}
 
+	visible, args := db.andWorkspaceVisible(ctx, skillName)
 	query := `
         SELECT skill_name, version, status, published_at, updated_at, is_latest, published, value
         FROM skills
-        WHERE skill_name = $1 AND is_latest = true
+        WHERE skill_name = $1 AND is_latest = true` + visible + `
         ORDER BY published_at DESC
         LIMIT 1
     `
@@ -2211,7 +2463,7 @@ func (db *PostgreSQL) GetSkillByName(ctx context.Context, tx pgx.Tx, skillName s
 	var publishedAt, updatedAt time.Time
 	var isLatest, published bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, skillName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &published, &valueJSON); err != nil {
+	if err := db.getReader(tx).QueryRow(ctx, query, args...).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &published, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -2247,17 +2499,18 @@ func (db *PostgreSQL) GetSkillByNameAndVersion(ctx context.Context, tx pgx.Tx, s
 		return nil, err
 	}
 
+	visible, args := db.andWorkspaceVisible(ctx, skillName, version)
 	query := `
         SELECT skill_name, version, status, published_at, updated_at, is_latest, published, value
         FROM skills
-        WHERE skill_name = $1 AND version = $2
+        WHERE skill_name = $1 AND version = $2` + visible + `
         LIMIT 1
     `
 	var name, vers, status string
 	var publishedAt, updatedAt time.Time
 	var isLatest, published bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, skillName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &published, &valueJSON); err != nil {
+	if err := db.getReader(tx).QueryRow(ctx, query, args...).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &published, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -2293,13 +2546,14 @@ func (db *PostgreSQL) GetAllVersionsBySkillName(ctx context.Context, tx pgx.Tx,
 		return nil, err
 	}
 
+	visible, args := db.andWorkspaceVisible(ctx, skillName)
 	query := `
         SELECT skill_name, version, status, published_at, updated_at, is_latest, published, value
         FROM skills
-        WHERE skill_name = $1
+        WHERE skill_name = $1` + visible + `
         ORDER BY published_at DESC
     `
-	rows, err := db.getExecutor(tx).Query(ctx, query, skillName)
+	rows, err := db.getReader(tx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query skill versions: %w", err)
 	}
@@ -2362,8 +2616,8 @@ func (db *PostgreSQL) CreateSkill(ctx context.Context, tx pgx.Tx, skillJSON *mod
 		return nil, fmt.Errorf("failed to marshal skill JSON: %w", err)
 	}
 	insert := `
-        INSERT INTO skills (skill_name, version, status, published_at, updated_at, is_latest, value)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        INSERT INTO skills (skill_name, version, status, published_at, updated_at, is_latest, value, workspace)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     `
 	if _, err := db.getExecutor(tx).Exec(ctx, insert,
 		skillJSON.Name,
@@ -2373,7 +2627,11 @@ func (db *PostgreSQL) CreateSkill(ctx context.Context, tx pgx.Tx, skillJSON *mod
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
 		valueJSON,
+		nullableWorkspace(ctx),
 	); err != nil {
+		if isVisibilityViolation(err) {
+			return nil, errPrivateOutsideWorkspace
+		}
 		return nil, fmt.Errorf("failed to insert skill: %w", err)
 	}
 	return &models.SkillResponse{
@@ -2419,6 +2677,9 @@ func (db *PostgreSQL) UpdateSkill(ctx context.Context, tx pgx.Tx, skillName, ver
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
+		if isVisibilityViolation(err) {
+			return nil, errPrivateOutsideWorkspace
+		}
 		return nil, fmt.Errorf("failed to update skill: %w", err)
 	}
 	return &models.SkillResponse{
@@ -2689,14 +2950,15 @@ func (db *PostgreSQL) CreateDeployment(ctx context.Context, tx pgx.Tx, deploymen
 
 	executor := db.getExecutor(tx)
 
-	configJSON, err := json.Marshal(deployment.Config)
+	configJSON, err := marshalDeploymentConfig(deployment.Config)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return err
 	}
+	actor, _ := auth.ActorFrom(ctx)
 
 	query := `
-		INSERT INTO deployments (server_name, version, status, config, prefer_remote, resource_type, runtime)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO deployments (server_name, version, status, config, prefer_remote, resource_type, runtime, origin, target, canary_weight, workspace, deployed_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	// Default to 'mcp' if not specified
@@ -2717,6 +2979,11 @@ func (db *PostgreSQL) CreateDeployment(ctx context.Context, tx pgx.Tx, deploymen
 		deployment.PreferRemote,
 		resourceType,
 		runtime,
+		deployment.Origin,
+		deployment.Target,
+		deployment.CanaryWeight,
+		nullableWorkspace(ctx),
+		actor,
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -2734,12 +3001,19 @@ func (db *PostgreSQL) GetDeployments(ctx context.Context, tx pgx.Tx) ([]*models.
 	executor := db.getExecutor(tx)
 
 	query := `
-		SELECT server_name, version, deployed_at, updated_at, status, config, prefer_remote, resource_type, runtime
-		FROM deployments
+		SELECT server_name, version, deployed_at, updated_at, status, config, prefer_remote, resource_type, runtime, origin, target, canary_weight,
+		       COALESCE(workspace, '')
+		FROM deployments`
+	condition, args := db.workspaceMembership(ctx, 1)
+	if condition != "" {
+		query += `
+		WHERE ` + condition
+	}
+	query += `
 		ORDER BY deployed_at DESC
 	`
 
-	rows, err := executor.Query(ctx, query)
+	rows, err := executor.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query deployments: %w", err)
 	}
@@ -2760,18 +3034,17 @@ func (db *PostgreSQL) GetDeployments(ctx context.Context, tx pgx.Tx) ([]*models.
 			&d.PreferRemote,
 			&d.ResourceType,
 			&d.Runtime,
+			&d.Origin,
+			&d.Target,
+			&d.CanaryWeight,
+			&d.Workspace,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan deployment: %w", err)
 		}
 
-		if len(configJSON) > 0 {
-			if err := json.Unmarshal(configJSON, &d.Config); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal config: %w", err)
-			}
-		}
-		if d.Config == nil {
-			d.Config = make(map[string]string)
+		if d.Config, err = unmarshalDeploymentConfig(configJSON); err != nil {
+			return nil, err
 		}
 
 		deployments = append(deployments, &d)
@@ -2801,7 +3074,8 @@ func (db *PostgreSQL) GetDeploymentByNameAndVersion(ctx context.Context, tx pgx.
 	executor := db.getExecutor(tx)
 
 	query := `
-		SELECT server_name, version, deployed_at, updated_at, status, config, prefer_remote, resource_type, runtime
+		SELECT server_name, version, deployed_at, updated_at, status, config, prefer_remote, resource_type, runtime, origin, target, canary_weight,
+		       COALESCE(workspace, '')
 		FROM deployments
 		WHERE server_name = $1 AND version = $2 AND resource_type = $3
 	`
@@ -2819,6 +3093,10 @@ func (db *PostgreSQL) GetDeploymentByNameAndVersion(ctx context.Context, tx pgx.
 		&d.PreferRemote,
 		&d.ResourceType,
 		&d.Runtime,
+		&d.Origin,
+		&d.Target,
+		&d.CanaryWeight,
+		&d.Workspace,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -2827,13 +3105,8 @@ func (db *PostgreSQL) GetDeploymentByNameAndVersion(ctx context.Context, tx pgx.
 		return nil, fmt.Errorf("failed to get deployment: %w", err)
 	}
 
-	if len(configJSON) > 0 {
-		if err := json.Unmarshal(configJSON, &d.Config); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
-		}
-	}
-	if d.Config == nil {
-		d.Config = make(map[string]string)
+	if d.Config, err = unmarshalDeploymentConfig(configJSON); err != nil {
+		return nil, err
 	}
 
 	return &d, nil
@@ -2855,9 +3128,9 @@ func (db *PostgreSQL) UpdateDeploymentConfig(ctx context.Context, tx pgx.Tx, ser
 
 	executor := db.getExecutor(tx)
 
-	configJSON, err := json.Marshal(config)
+	configJSON, err := marshalDeploymentConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return err
 	}
 
 	query := `
@@ -2878,6 +3151,40 @@ func (db *PostgreSQL) UpdateDeploymentConfig(ctx context.Context, tx pgx.Tx, ser
 	return nil
 }
 
+// UpdateDeploymentOrigin changes the registry a deployment resolves its manifest from
+func (db *PostgreSQL) UpdateDeploymentOrigin(ctx context.Context, tx pgx.Tx, serverName string, version string, resourceType string, origin string) error {
+	// Authz check (determine resource type)
+	artifactType := auth.PermissionArtifactTypeServer
+	if resourceType == "agent" {
+		artifactType = auth.PermissionArtifactTypeAgent
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionDeploy, auth.Resource{
+		Name: serverName,
+		Type: artifactType,
+	}); err != nil {
+		return err
+	}
+
+	executor := db.getExecutor(tx)
+
+	query := `
+		UPDATE deployments
+		SET origin = $4
+		WHERE server_name = $1 AND version = $2 AND resource_type = $3
+	`
+
+	result, err := executor.Exec(ctx, query, serverName, version, resourceType, origin)
+	if err != nil {
+		return fmt.Errorf("failed to update deployment origin: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
 // UpdateDeploymentStatus updates the status of a deployment
 func (db *PostgreSQL) UpdateDeploymentStatus(ctx context.Context, tx pgx.Tx, serverName, version string, resourceType string, status string) error {
 	// Authz check (determine resource type)
@@ -2912,6 +3219,40 @@ func (db *PostgreSQL) UpdateDeploymentStatus(ctx context.Context, tx pgx.Tx, ser
 	return nil
 }
 
+// UpdateDeploymentCanaryWeight sets the traffic percentage of a canary deployment; zero makes it the stable one
+func (db *PostgreSQL) UpdateDeploymentCanaryWeight(ctx context.Context, tx pgx.Tx, serverName, version, resourceType string, weight int) error {
+	// Authz check (determine resource type)
+	artifactType := auth.PermissionArtifactTypeServer
+	if resourceType == "agent" {
+		artifactType = auth.PermissionArtifactTypeAgent
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionDeploy, auth.Resource{
+		Name: serverName,
+		Type: artifactType,
+	}); err != nil {
+		return err
+	}
+
+	executor := db.getExecutor(tx)
+
+	query := `
+		UPDATE deployments
+		SET canary_weight = $4
+		WHERE server_name = $1 AND version = $2 AND resource_type = $3
+	`
+
+	result, err := executor.Exec(ctx, query, serverName, version, resourceType, weight)
+	if err != nil {
+		return fmt.Errorf("failed to update deployment canary weight: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
 // RemoveDeployment removes a deployment
 func (db *PostgreSQL) RemoveDeployment(ctx context.Context, tx pgx.Tx, serverName string, version string, resourceType string) error {
 	// Authz check (determine resource type)
@@ -2963,12 +3304,48 @@ func (db *PostgreSQL) DeleteAgent(ctx context.Context, tx pgx.Tx, agentName, ver
 	if result.RowsAffected() == 0 {
 		return database.ErrNotFound
 	}
+	if err := deleteAttachments(ctx, executor, "agent", agentName, version); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// IsRegistryAdmin reports whether the caller in ctx has registry-wide admin permissions
+func (db *PostgreSQL) IsRegistryAdmin(ctx context.Context) bool {
+	return db.authz.IsRegistryAdmin(ctx)
+}
+
+// Ping checks that the database accepts connections
+func (db *PostgreSQL) Ping(ctx context.Context) error {
+	return db.pool.Ping(ctx)
+}
+
+// DatabaseSize returns the disk space the registry database takes in bytes
+func (db *PostgreSQL) DatabaseSize(ctx context.Context) (int64, error) {
+	var size int64
+	if err := db.pool.QueryRow(ctx, `SELECT pg_database_size(current_database())`).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+	return size, nil
+}
+
+// MigrationStatus returns the migrations of the registry and whether each one has been applied
+func (db *PostgreSQL) MigrationStatus(ctx context.Context) ([]database.MigrationStatus, error) {
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	return database.NewMigrator(conn.Conn(), DefaultMigratorConfig()).Status(ctx)
+}
+
 // Close closes the database connection
 func (db *PostgreSQL) Close() error {
+	if db.replica != nil {
+		db.replica.pool.Close()
+	}
 	db.pool.Close()
 	return nil
 }
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Quota usage is counted for the quota checks of the service and the caller's own usage report, so these methods
+// are not subject to authorization. They read from the primary, so a lagging replica cannot let a quota be exceeded.
+
+// CountDeploymentsInScope counts the deployments of a workspace or, when workspace is empty, those deployedBy created
+// outside any workspace
+func (db *PostgreSQL) CountDeploymentsInScope(ctx context.Context, tx pgx.Tx, workspace, deployedBy string) (int, error) {
+	query := `SELECT COUNT(*) FROM deployments WHERE workspace = $1`
+	arg := workspace
+	if workspace == "" {
+		query = `SELECT COUNT(*) FROM deployments WHERE workspace IS NULL AND deployed_by = $1`
+		arg = deployedBy
+	}
+
+	var count int
+	if err := db.getExecutor(tx).QueryRow(ctx, query, arg).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count deployments: %w", err)
+	}
+	return count, nil
+}
+
+// CountAuditLogEntriesSince counts the audit log entries of an actor with the given action on any of resourceTypes
+// recorded after since
+func (db *PostgreSQL) CountAuditLogEntriesSince(ctx context.Context, tx pgx.Tx, actor, action string, resourceTypes []string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM audit_log
+		WHERE actor = $1 AND action = $2 AND resource_type = ANY($3) AND occurred_at > $4
+	`
+	var count int
+	if err := db.getExecutor(tx).QueryRow(ctx, query, actor, action, resourceTypes, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count audit log entries: %w", err)
+	}
+	return count, nil
+}
+
+// CountServersInNamespace counts the distinct servers whose name is under a namespace, including deleted versions
+// still in the trash
+func (db *PostgreSQL) CountServersInNamespace(ctx context.Context, tx pgx.Tx, namespace string) (int, error) {
+	query := `SELECT COUNT(DISTINCT server_name) FROM servers WHERE starts_with(server_name, $1 || '/')`
+	var count int
+	if err := db.getExecutor(tx).QueryRow(ctx, query, namespace).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count servers of namespace %s: %w", namespace, err)
+	}
+	return count, nil
+}
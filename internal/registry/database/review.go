@@ -0,0 +1,204 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+const reviewColumns = `id, artifact_type, artifact_name, author, rating, body, hidden, created_at, updated_at`
+
+// UpsertReview stores the review of an author, replacing their earlier review of the same artifact. A review hidden
+// by a moderator stays hidden when it is edited.
+func (db *PostgreSQL) UpsertReview(ctx context.Context, tx pgx.Tx, review *models.Review) (*models.Review, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if review == nil || review.Name == "" || review.Author == "" {
+		return nil, fmt.Errorf("%w: artifact name and author are required", database.ErrInvalidInput)
+	}
+	resourceType, ok := statsResourceTypes[review.ArtifactType]
+	if !ok || review.ArtifactType == "skill" {
+		return nil, fmt.Errorf("%w: only servers and agents can be reviewed", database.ErrInvalidInput)
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{Name: review.Name, Type: resourceType}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO reviews (artifact_type, artifact_name, author, rating, body)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (artifact_type, artifact_name, author) DO UPDATE
+        SET rating = EXCLUDED.rating,
+            body = EXCLUDED.body,
+            updated_at = NOW()
+        RETURNING ` + reviewColumns
+	return scanReview(db.getExecutor(tx).QueryRow(ctx, query,
+		review.ArtifactType,
+		review.Name,
+		review.Author,
+		review.Rating,
+		review.Body,
+	))
+}
+
+// ListReviews returns the reviews of an artifact, newest first, with cursor-based pagination. Hidden reviews are
+// only included for registry admins who ask for them.
+func (db *PostgreSQL) ListReviews(ctx context.Context, tx pgx.Tx, artifactType, name string, includeHidden bool, cursor string, limit int) ([]*models.Review, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+	resourceType, ok := statsResourceTypes[artifactType]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: only servers and agents can be reviewed", database.ErrInvalidInput)
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{Name: name, Type: resourceType}); err != nil {
+		return nil, "", err
+	}
+	if limit <= 0 {
+		limit = 30
+	}
+
+	whereClause := "WHERE artifact_type = $1 AND artifact_name = $2"
+	args := []any{artifactType, name}
+	if !includeHidden || !db.authz.IsRegistryAdmin(ctx) {
+		whereClause += " AND NOT hidden"
+	}
+	if cursor != "" {
+		cursorID, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid review cursor %q", database.ErrInvalidInput, cursor)
+		}
+		args = append(args, cursorID)
+		whereClause += fmt.Sprintf(" AND id < $%d", len(args))
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM reviews
+        %s
+        ORDER BY id DESC
+        LIMIT $%d
+    `, reviewColumns, whereClause, len(args))
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []*models.Review
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating reviews: %w", err)
+	}
+
+	nextCursor := ""
+	if len(reviews) >= limit {
+		nextCursor = strconv.FormatInt(reviews[len(reviews)-1].ID, 10)
+	}
+	return reviews, nextCursor, nil
+}
+
+// GetRatingSummaries aggregates the visible reviews of the named artifacts. Artifacts without reviews are omitted.
+func (db *PostgreSQL) GetRatingSummaries(ctx context.Context, tx pgx.Tx, artifactType string, names []string) (map[string]models.RatingSummary, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	summaries := make(map[string]models.RatingSummary)
+	if len(names) == 0 {
+		return summaries, nil
+	}
+
+	query := `
+        SELECT artifact_name, AVG(rating)::float8, COUNT(*)
+        FROM reviews
+        WHERE artifact_type = $1 AND artifact_name = ANY($2) AND NOT hidden
+        GROUP BY artifact_name
+    `
+	rows, err := db.getExecutor(tx).Query(ctx, query, artifactType, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ratings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var summary models.RatingSummary
+		if err := rows.Scan(&name, &summary.Average, &summary.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		summaries[name] = summary
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ratings: %w", err)
+	}
+	return summaries, nil
+}
+
+// SetReviewHidden hides or restores a review. Only registry admins may moderate reviews.
+func (db *PostgreSQL) SetReviewHidden(ctx context.Context, tx pgx.Tx, id int64, hidden bool) (*models.Review, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+
+	query := `
+        UPDATE reviews
+        SET hidden = $2
+        WHERE id = $1
+        RETURNING ` + reviewColumns
+	return scanReview(db.getExecutor(tx).QueryRow(ctx, query, id, hidden))
+}
+
+// DeleteReview deletes a review and returns it. Authors may delete their own reviews and registry admins any review.
+func (db *PostgreSQL) DeleteReview(ctx context.Context, tx pgx.Tx, id int64, author string) (*models.Review, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `DELETE FROM reviews WHERE id = $1`
+	args := []any{id}
+	if !db.authz.IsRegistryAdmin(ctx) {
+		query += ` AND author = $2`
+		args = append(args, author)
+	}
+	return scanReview(db.getExecutor(tx).QueryRow(ctx, query+` RETURNING `+reviewColumns, args...))
+}
+
+func scanReview(row pgx.Row) (*models.Review, error) {
+	var review models.Review
+	if err := row.Scan(
+		&review.ID,
+		&review.ArtifactType,
+		&review.Name,
+		&review.Author,
+		&review.Rating,
+		&review.Body,
+		&review.Hidden,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan review: %w", err)
+	}
+	return &review, nil
+}
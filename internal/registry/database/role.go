@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+var _ auth.RoleBindingStore = &PostgreSQL{}
+
+// RoleBindings returns every role binding for use by the RBAC authz provider.
+// It intentionally skips authz checks since it is called from within them.
+func (db *PostgreSQL) RoleBindings(ctx context.Context) ([]auth.RoleBinding, error) {
+	rows, err := db.pool.Query(ctx, `SELECT subject, role, namespace FROM role_bindings`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query role bindings: %w", err)
+	}
+	defer rows.Close()
+
+	var bindings []auth.RoleBinding
+	for rows.Next() {
+		var b auth.RoleBinding
+		var role string
+		if err := rows.Scan(&b.Subject, &role, &b.Namespace); err != nil {
+			return nil, fmt.Errorf("failed to scan role binding: %w", err)
+		}
+		b.Role = auth.Role(role)
+		bindings = append(bindings, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating role bindings: %w", err)
+	}
+
+	return bindings, nil
+}
+
+// CreateRoleBinding grants a role on a namespace to a subject (registry admin only)
+func (db *PostgreSQL) CreateRoleBinding(ctx context.Context, tx pgx.Tx, binding *models.RoleBinding) error {
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return auth.ErrForbidden
+	}
+
+	if binding == nil || binding.Subject == "" || binding.Namespace == "" {
+		return fmt.Errorf("%w: subject and namespace are required", database.ErrInvalidInput)
+	}
+	if !auth.Role(binding.Role).IsValid() {
+		return fmt.Errorf("%w: unknown role %q (expected admin, publisher or reader)", database.ErrInvalidInput, binding.Role)
+	}
+
+	query := `
+		INSERT INTO role_bindings (subject, role, namespace, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := db.getExecutor(tx).QueryRow(ctx, query, binding.Subject, binding.Role, binding.Namespace, binding.CreatedBy).
+		Scan(&binding.ID, &binding.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return database.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create role binding: %w", err)
+	}
+
+	return nil
+}
+
+// ListRoleBindings lists role bindings, optionally only those of a subject (registry admin only)
+func (db *PostgreSQL) ListRoleBindings(ctx context.Context, tx pgx.Tx, subject *string) ([]*models.RoleBinding, error) {
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+
+	query := `
+		SELECT id, subject, role, namespace, created_by, created_at
+		FROM role_bindings
+	`
+	args := []any{}
+	if subject != nil {
+		query += ` WHERE subject = $1`
+		args = append(args, *subject)
+	}
+	query += ` ORDER BY namespace, subject, role`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query role bindings: %w", err)
+	}
+	defer rows.Close()
+
+	var bindings []*models.RoleBinding
+	for rows.Next() {
+		var b models.RoleBinding
+		if err := rows.Scan(&b.ID, &b.Subject, &b.Role, &b.Namespace, &b.CreatedBy, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan role binding: %w", err)
+		}
+		bindings = append(bindings, &b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating role bindings: %w", err)
+	}
+
+	return bindings, nil
+}
+
+// DeleteRoleBinding removes a role binding by ID (registry admin only)
+func (db *PostgreSQL) DeleteRoleBinding(ctx context.Context, tx pgx.Tx, id int64) (*models.RoleBinding, error) {
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+
+	query := `
+		DELETE FROM role_bindings
+		WHERE id = $1
+		RETURNING id, subject, role, namespace, created_by, created_at
+	`
+
+	var b models.RoleBinding
+	err := db.getExecutor(tx).QueryRow(ctx, query, id).
+		Scan(&b.ID, &b.Subject, &b.Role, &b.Namespace, &b.CreatedBy, &b.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to delete role binding: %w", err)
+	}
+
+	return &b, nil
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// RenameServer renames every version of a server together with its READMEs, capabilities, stats, reviews,
+// attachments and config profiles, and records the former name as an alias of the new one. Aliases of the former name are moved to the
+// new name. Signatures cover the server name, so they are dropped. Deployments keep running under the former name.
+func (db *PostgreSQL) RenameServer(ctx context.Context, tx pgx.Tx, serverName, newName, renamedBy string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if newName == "" || newName == serverName {
+		return fmt.Errorf("%w: new server name must differ from the current one", database.ErrInvalidInput)
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: serverName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return err
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionPush, auth.Resource{
+		Name: newName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return err
+	}
+
+	executor := db.getExecutor(tx)
+
+	var taken bool
+	if err := executor.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM servers WHERE server_name = $1)`, newName).Scan(&taken); err != nil {
+		return fmt.Errorf("failed to check server name: %w", err)
+	}
+	if taken {
+		return database.ErrAlreadyExists
+	}
+
+	if _, err := executor.Exec(ctx, `DELETE FROM server_signatures WHERE server_name = $1`, serverName); err != nil {
+		return fmt.Errorf("failed to drop server signatures: %w", err)
+	}
+
+	result, err := executor.Exec(ctx, `
+		UPDATE servers
+		SET server_name = $2, value = jsonb_set(value, '{name}', to_jsonb($2::text)), updated_at = NOW()
+		WHERE server_name = $1
+	`, serverName, newName)
+	if err != nil {
+		return fmt.Errorf("failed to rename server: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+
+	statements := []string{
+		`DELETE FROM artifact_stats WHERE artifact_type = 'mcp' AND artifact_name = $2`,
+		`UPDATE artifact_stats SET artifact_name = $2 WHERE artifact_type = 'mcp' AND artifact_name = $1`,
+		`DELETE FROM reviews WHERE artifact_type = 'mcp' AND artifact_name = $2`,
+		`UPDATE reviews SET artifact_name = $2 WHERE artifact_type = 'mcp' AND artifact_name = $1`,
+		`DELETE FROM attachments WHERE artifact_type = 'mcp' AND artifact_name = $2`,
+		`UPDATE attachments SET artifact_name = $2 WHERE artifact_type = 'mcp' AND artifact_name = $1`,
+		`DELETE FROM config_profiles WHERE server_name = $2`,
+		`UPDATE config_profiles SET server_name = $2 WHERE server_name = $1`,
+		`DELETE FROM server_aliases WHERE alias = $2`,
+		`UPDATE server_aliases SET server_name = $2 WHERE server_name = $1`,
+	}
+	for _, statement := range statements {
+		if _, err := executor.Exec(ctx, statement, serverName, newName); err != nil {
+			return fmt.Errorf("failed to rename server: %w", err)
+		}
+	}
+
+	_, err = executor.Exec(ctx, `
+		INSERT INTO server_aliases (alias, server_name, created_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (alias) DO UPDATE SET server_name = EXCLUDED.server_name, created_by = EXCLUDED.created_by, created_at = NOW()
+	`, serverName, newName, renamedBy)
+	if err != nil {
+		return fmt.Errorf("failed to record server alias: %w", err)
+	}
+	return nil
+}
+
+// GetServerAlias returns the current name of a server renamed from alias
+func (db *PostgreSQL) GetServerAlias(ctx context.Context, tx pgx.Tx, alias string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	var serverName string
+	err := db.getExecutor(tx).QueryRow(ctx, `SELECT server_name FROM server_aliases WHERE alias = $1`, alias).Scan(&serverName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", database.ErrNotFound
+		}
+		return "", fmt.Errorf("failed to get server alias: %w", err)
+	}
+	return serverName, nil
+}
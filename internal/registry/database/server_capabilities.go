@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// UpsertServerCapabilities stores or replaces the introspected capabilities of a server version
+func (db *PostgreSQL) UpsertServerCapabilities(ctx context.Context, tx pgx.Tx, caps *models.ServerCapabilities) error {
+	if caps == nil || caps.ServerName == "" || caps.Version == "" {
+		return fmt.Errorf("%w: server name and version are required", database.ErrInvalidInput)
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: caps.ServerName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return err
+	}
+
+	if caps.IntrospectedAt.IsZero() {
+		caps.IntrospectedAt = time.Now()
+	}
+
+	toolsJSON, err := json.Marshal(nonNil(caps.Tools))
+	if err != nil {
+		return fmt.Errorf("failed to marshal tools: %w", err)
+	}
+	resourcesJSON, err := json.Marshal(nonNil(caps.Resources))
+	if err != nil {
+		return fmt.Errorf("failed to marshal resources: %w", err)
+	}
+	promptsJSON, err := json.Marshal(nonNil(caps.Prompts))
+	if err != nil {
+		return fmt.Errorf("failed to marshal prompts: %w", err)
+	}
+
+	query := `
+		INSERT INTO server_capabilities (server_name, version, protocol_version, source, tools, resources, prompts, error, introspected_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (server_name, version) DO UPDATE
+		SET protocol_version = EXCLUDED.protocol_version,
+		    source = EXCLUDED.source,
+		    tools = EXCLUDED.tools,
+		    resources = EXCLUDED.resources,
+		    prompts = EXCLUDED.prompts,
+		    error = EXCLUDED.error,
+		    introspected_at = EXCLUDED.introspected_at
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query,
+		caps.ServerName,
+		caps.Version,
+		caps.ProtocolVersion,
+		caps.Source,
+		toolsJSON,
+		resourcesJSON,
+		promptsJSON,
+		caps.Error,
+		caps.IntrospectedAt,
+	); err != nil {
+		return fmt.Errorf("failed to upsert server capabilities: %w", err)
+	}
+
+	return nil
+}
+
+// GetServerCapabilities retrieves the introspected capabilities of a specific server version
+func (db *PostgreSQL) GetServerCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string) (*models.ServerCapabilities, error) {
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: serverName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT server_name, version, protocol_version, source, tools, resources, prompts, error, introspected_at
+		FROM server_capabilities
+		WHERE server_name = $1 AND version = $2
+	`
+
+	var caps models.ServerCapabilities
+	var toolsJSON, resourcesJSON, promptsJSON []byte
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(
+		&caps.ServerName,
+		&caps.Version,
+		&caps.ProtocolVersion,
+		&caps.Source,
+		&toolsJSON,
+		&resourcesJSON,
+		&promptsJSON,
+		&caps.Error,
+		&caps.IntrospectedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server capabilities: %w", err)
+	}
+
+	if err := json.Unmarshal(toolsJSON, &caps.Tools); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tools: %w", err)
+	}
+	if err := json.Unmarshal(resourcesJSON, &caps.Resources); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resources: %w", err)
+	}
+	if err := json.Unmarshal(promptsJSON, &caps.Prompts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prompts: %w", err)
+	}
+
+	return &caps, nil
+}
+
+// nonNil keeps empty lists stored as [] rather than null
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// SearchServerTools finds tools whose name or description contains query among the introspected tools of the
+// latest published server versions. Tools matching by name are returned first.
+func (db *PostgreSQL) SearchServerTools(ctx context.Context, tx pgx.Tx, query string, limit int) ([]models.ToolSearchResult, error) {
+	if limit <= 0 {
+		limit = 30
+	}
+
+	sqlQuery := `
+		SELECT c.server_name, c.version, t.tool
+		FROM server_capabilities c
+		JOIN servers s ON s.server_name = c.server_name AND s.version = c.version
+		CROSS JOIN LATERAL jsonb_array_elements(c.tools) AS t(tool)
+		WHERE s.published AND s.is_latest
+		  AND (t.tool->>'name' ILIKE $1 OR t.tool->>'description' ILIKE $1)
+		ORDER BY (t.tool->>'name' ILIKE $1) DESC, c.server_name, t.tool->>'name'
+		LIMIT $2
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, sqlQuery, "%"+query+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search server tools: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.ToolSearchResult{}
+	for rows.Next() {
+		var result models.ToolSearchResult
+		var toolJSON []byte
+		if err := rows.Scan(&result.ServerName, &result.Version, &toolJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan tool row: %w", err)
+		}
+		if err := json.Unmarshal(toolJSON, &result.Tool); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tool: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return results, nil
+}
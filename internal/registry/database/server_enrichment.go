@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// UpsertServerEnrichment records when the details of a server version were last refreshed
+func (db *PostgreSQL) UpsertServerEnrichment(ctx context.Context, tx pgx.Tx, enrichment *models.ServerEnrichment) error {
+	if enrichment == nil || enrichment.ServerName == "" || enrichment.Version == "" {
+		return fmt.Errorf("%w: server name and version are required", database.ErrInvalidInput)
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: enrichment.ServerName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return err
+	}
+
+	if enrichment.EnrichedAt == nil {
+		now := time.Now()
+		enrichment.EnrichedAt = &now
+	}
+
+	query := `
+		INSERT INTO server_enrichments (server_name, version, enriched_at, error)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (server_name, version) DO UPDATE
+		SET enriched_at = EXCLUDED.enriched_at,
+		    error = EXCLUDED.error
+	`
+	if _, err := db.getExecutor(tx).Exec(ctx, query,
+		enrichment.ServerName,
+		enrichment.Version,
+		*enrichment.EnrichedAt,
+		enrichment.Error,
+	); err != nil {
+		return fmt.Errorf("failed to upsert server enrichment: %w", err)
+	}
+	return nil
+}
+
+// ListServersDueForEnrichment returns the latest versions of servers that were never enriched or last enriched before
+// enrichedBefore, least recently enriched first. Deleted servers are skipped. Only registry admins may list them.
+func (db *PostgreSQL) ListServersDueForEnrichment(ctx context.Context, tx pgx.Tx, enrichedBefore time.Time, limit int) ([]*models.ServerEnrichment, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	query := `
+		SELECT s.server_name, s.version, se.enriched_at, COALESCE(se.error, '')
+		FROM servers s
+		LEFT JOIN server_enrichments se ON se.server_name = s.server_name AND se.version = s.version
+		WHERE s.is_latest = true
+		  AND s.status <> 'deleted'
+		  AND (se.enriched_at IS NULL OR se.enriched_at < $1)
+		ORDER BY se.enriched_at ASC NULLS FIRST, s.server_name
+		LIMIT $2
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, enrichedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query servers due for enrichment: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*models.ServerEnrichment
+	for rows.Next() {
+		var e models.ServerEnrichment
+		if err := rows.Scan(&e.ServerName, &e.Version, &e.EnrichedAt, &e.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan server enrichment: %w", err)
+		}
+		results = append(results, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// UpsertServerSignature stores or replaces the publisher signature of a server version
+func (db *PostgreSQL) UpsertServerSignature(ctx context.Context, tx pgx.Tx, sig *models.ServerSignature) error {
+	if sig == nil || sig.ServerName == "" || sig.Version == "" {
+		return fmt.Errorf("%w: server name and version are required", database.ErrInvalidInput)
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionPublish, auth.Resource{
+		Name: sig.ServerName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return err
+	}
+
+	if sig.CreatedAt.IsZero() {
+		sig.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO server_signatures (server_name, version, algorithm, signature, public_key, fingerprint, signed_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (server_name, version) DO UPDATE
+		SET algorithm = EXCLUDED.algorithm,
+		    signature = EXCLUDED.signature,
+		    public_key = EXCLUDED.public_key,
+		    fingerprint = EXCLUDED.fingerprint,
+		    signed_by = EXCLUDED.signed_by,
+		    created_at = EXCLUDED.created_at
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query,
+		sig.ServerName,
+		sig.Version,
+		sig.Algorithm,
+		sig.Signature,
+		sig.PublicKey,
+		sig.Fingerprint,
+		sig.SignedBy,
+		sig.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to upsert server signature: %w", err)
+	}
+
+	return nil
+}
+
+// GetServerSignature retrieves the publisher signature of a specific server version
+func (db *PostgreSQL) GetServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string) (*models.ServerSignature, error) {
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: serverName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT server_name, version, algorithm, signature, public_key, fingerprint, signed_by, created_at
+		FROM server_signatures
+		WHERE server_name = $1 AND version = $2
+	`
+
+	var sig models.ServerSignature
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(
+		&sig.ServerName,
+		&sig.Version,
+		&sig.Algorithm,
+		&sig.Signature,
+		&sig.PublicKey,
+		&sig.Fingerprint,
+		&sig.SignedBy,
+		&sig.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server signature: %w", err)
+	}
+
+	return &sig, nil
+}
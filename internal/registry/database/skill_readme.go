@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// UpsertSkillReadme stores or updates the README of a skill version
+func (db *PostgreSQL) UpsertSkillReadme(ctx context.Context, tx pgx.Tx, readme *database.SkillReadme) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if readme == nil || readme.SkillName == "" || readme.Version == "" {
+		return fmt.Errorf("%w: skill name and version are required", database.ErrInvalidInput)
+	}
+	if readme.ContentType == "" {
+		readme.ContentType = "text/markdown"
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: readme.SkillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return err
+	}
+
+	if readme.SizeBytes == 0 {
+		readme.SizeBytes = len(readme.Content)
+	}
+	if len(readme.SHA256) == 0 {
+		sum := sha256.Sum256(readme.Content)
+		readme.SHA256 = sum[:]
+	}
+	if readme.FetchedAt.IsZero() {
+		readme.FetchedAt = time.Now()
+	}
+
+	content := readme.Content
+	blobSum, err := db.offloadReadme(ctx, readme.Content)
+	if err != nil {
+		return fmt.Errorf("failed to store skill readme: %w", err)
+	}
+	if blobSum != nil {
+		content, readme.SHA256 = []byte{}, blobSum
+	}
+
+	query := `
+        INSERT INTO skill_readmes (skill_name, version, content, content_type, size_bytes, sha256, fetched_at, blob_stored)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (skill_name, version) DO UPDATE
+        SET content = EXCLUDED.content,
+            content_type = EXCLUDED.content_type,
+            size_bytes = EXCLUDED.size_bytes,
+            sha256 = EXCLUDED.sha256,
+            fetched_at = EXCLUDED.fetched_at,
+            blob_stored = EXCLUDED.blob_stored
+    `
+	if _, err := db.getExecutor(tx).Exec(ctx, query,
+		readme.SkillName,
+		readme.Version,
+		content,
+		readme.ContentType,
+		readme.SizeBytes,
+		readme.SHA256,
+		readme.FetchedAt,
+		blobSum != nil,
+	); err != nil {
+		return fmt.Errorf("failed to upsert skill readme: %w", err)
+	}
+	return nil
+}
+
+// GetSkillReadme retrieves the README of a specific skill version
+func (db *PostgreSQL) GetSkillReadme(ctx context.Context, tx pgx.Tx, skillName, version string) (*database.SkillReadme, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: skillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT skill_name, version, content, content_type, size_bytes, sha256, fetched_at, blob_stored
+        FROM skill_readmes
+        WHERE skill_name = $1 AND version = $2
+    `
+	return db.scanSkillReadme(ctx, db.getExecutor(tx).QueryRow(ctx, query, skillName, version))
+}
+
+// GetLatestSkillReadme retrieves the README of the latest version of a skill
+func (db *PostgreSQL) GetLatestSkillReadme(ctx context.Context, tx pgx.Tx, skillName string) (*database.SkillReadme, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: skillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT sr.skill_name, sr.version, sr.content, sr.content_type, sr.size_bytes, sr.sha256, sr.fetched_at, sr.blob_stored
+        FROM skill_readmes sr
+        INNER JOIN skills s ON sr.skill_name = s.skill_name AND sr.version = s.version
+        WHERE sr.skill_name = $1 AND s.is_latest = true
+        LIMIT 1
+    `
+	return db.scanSkillReadme(ctx, db.getExecutor(tx).QueryRow(ctx, query, skillName))
+}
+
+func (db *PostgreSQL) scanSkillReadme(ctx context.Context, row pgx.Row) (*database.SkillReadme, error) {
+	var readme database.SkillReadme
+	var blobStored bool
+	if err := row.Scan(
+		&readme.SkillName,
+		&readme.Version,
+		&readme.Content,
+		&readme.ContentType,
+		&readme.SizeBytes,
+		&readme.SHA256,
+		&readme.FetchedAt,
+		&blobStored,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan skill readme: %w", err)
+	}
+	content, err := db.loadReadme(ctx, readme.Content, readme.SHA256, blobStored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load skill readme: %w", err)
+	}
+	readme.Content = content
+	return &readme, nil
+}
+
+// ListSkillVersionStatuses retrieves the status and published flag of every version of a skill, newest first
+func (db *PostgreSQL) ListSkillVersionStatuses(ctx context.Context, tx pgx.Tx, skillName string) ([]*models.SkillVersionStatus, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: skillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT version, status, published, is_latest, published_at, updated_at
+		FROM skills
+		WHERE skill_name = $1
+		ORDER BY published_at DESC
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, skillName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skill version statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*models.SkillVersionStatus
+	for rows.Next() {
+		var vs models.SkillVersionStatus
+		if err := rows.Scan(&vs.Version, &vs.Status, &vs.Published, &vs.IsLatest, &vs.PublishedAt, &vs.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan skill version status: %w", err)
+		}
+		results = append(results, &vs)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, database.ErrNotFound
+	}
+	return results, nil
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// RefreshTagCounts recounts the published public servers and agents carrying each tag, counting their latest
+// versions, and drops tags nothing carries anymore. Counts only cover published public entries, so no authz check is
+// needed.
+func (db *PostgreSQL) RefreshTagCounts(ctx context.Context, tx pgx.Tx) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	query := `
+        WITH counts AS (
+            SELECT tag, SUM(servers) AS servers, SUM(agents) AS agents
+            FROM (
+                SELECT jsonb_array_elements_text(tags) AS tag, 1 AS servers, 0 AS agents
+                FROM servers WHERE is_latest AND published AND visibility = 'public'
+                UNION ALL
+                SELECT jsonb_array_elements_text(tags) AS tag, 0 AS servers, 1 AS agents
+                FROM agents WHERE is_latest AND published AND visibility = 'public'
+            ) tagged
+            GROUP BY tag
+        ), upserted AS (
+            INSERT INTO tags (name, server_count, agent_count, updated_at)
+            SELECT tag, servers, agents, NOW() FROM counts
+            ON CONFLICT (name) DO UPDATE
+            SET server_count = EXCLUDED.server_count,
+                agent_count = EXCLUDED.agent_count,
+                updated_at = NOW()
+        )
+        DELETE FROM tags WHERE name NOT IN (SELECT tag FROM counts)
+    `
+	if _, err := db.getExecutor(tx).Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to refresh tag counts: %w", err)
+	}
+	return nil
+}
+
+// ListTags returns the tags with their counts, most used first
+func (db *PostgreSQL) ListTags(ctx context.Context, tx pgx.Tx) ([]models.Tag, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	query := `
+        SELECT name, server_count, agent_count
+        FROM tags
+        ORDER BY server_count + agent_count DESC, name
+    `
+	rows, err := db.getExecutor(tx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []models.Tag{}
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.Name, &tag.Servers, &tag.Agents); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+	return tags, nil
+}
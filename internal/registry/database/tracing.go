@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+)
+
+// maxTracedStatementLength keeps large generated queries from bloating spans
+const maxTracedStatementLength = 2048
+
+// queryTracer creates a span for each query. Queries only get a span inside a sampled trace,
+// so background work without a request span does not produce one root trace per query.
+type queryTracer struct{}
+
+var _ pgx.QueryTracer = queryTracer{}
+
+// querySpanKey marks the contexts carrying a span started by queryTracer
+type querySpanKey struct{}
+
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx
+	}
+	ctx, _ = telemetry.Tracer().Start(ctx, "db "+queryOperation(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", truncateStatement(data.SQL)),
+		),
+	)
+	return context.WithValue(ctx, querySpanKey{}, true)
+}
+
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	if started, _ := ctx.Value(querySpanKey{}).(bool); !started {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	span.End()
+}
+
+// queryOperation returns the leading SQL keyword (SELECT, INSERT, ...) for the span name
+func queryOperation(sql string) string {
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
+
+func truncateStatement(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if len(sql) > maxTracedStatementLength {
+		return sql[:maxTracedStatementLength] + "..."
+	}
+	return sql
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// TrashServer soft-deletes a server version: it is hidden from lookups until it is restored or purged. When it was the
+// latest version, the most recently published remaining version becomes the latest.
+func (db *PostgreSQL) TrashServer(ctx context.Context, tx pgx.Tx, serverName, version, deletedBy string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionDelete, auth.Resource{
+		Name: serverName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return err
+	}
+
+	executor := db.getExecutor(tx)
+
+	var wasLatest bool
+	err := executor.QueryRow(ctx, `
+		SELECT is_latest FROM servers
+		WHERE server_name = $1 AND version = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, serverName, version).Scan(&wasLatest)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.ErrNotFound
+		}
+		return fmt.Errorf("failed to get server: %w", err)
+	}
+
+	_, err = executor.Exec(ctx, `
+		UPDATE servers SET deleted_at = NOW(), deleted_by = $3, is_latest = false
+		WHERE server_name = $1 AND version = $2
+	`, serverName, version, deletedBy)
+	if err != nil {
+		return fmt.Errorf("failed to delete server: %w", err)
+	}
+
+	if wasLatest {
+		return db.promoteLatestServerVersion(ctx, executor, serverName)
+	}
+	return nil
+}
+
+// RestoreServer restores a soft-deleted server version. It becomes the latest version again when no other version is.
+func (db *PostgreSQL) RestoreServer(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionDelete, auth.Resource{
+		Name: serverName,
+		Type: auth.PermissionArtifactTypeServer,
+	}); err != nil {
+		return err
+	}
+
+	executor := db.getExecutor(tx)
+	result, err := executor.Exec(ctx, `
+		UPDATE servers SET deleted_at = NULL, deleted_by = NULL
+		WHERE server_name = $1 AND version = $2 AND deleted_at IS NOT NULL
+	`, serverName, version)
+	if err != nil {
+		return fmt.Errorf("failed to restore server: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return db.promoteLatestServerVersion(ctx, executor, serverName)
+}
+
+// promoteLatestServerVersion marks the most recently published version of a server that is not deleted as the latest,
+// unless a version already is
+func (db *PostgreSQL) promoteLatestServerVersion(ctx context.Context, executor Executor, serverName string) error {
+	_, err := executor.Exec(ctx, `
+		UPDATE servers SET is_latest = true
+		WHERE server_name = $1 AND version = (
+			SELECT version FROM servers
+			WHERE server_name = $1 AND deleted_at IS NULL
+			ORDER BY published_at DESC
+			LIMIT 1
+		)
+		AND NOT EXISTS (SELECT 1 FROM servers WHERE server_name = $1 AND is_latest AND deleted_at IS NULL)
+	`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to mark latest version: %w", err)
+	}
+	return nil
+}
+
+// ListTrashedServers lists the soft-deleted server versions, most recently deleted first. With deletedBefore set, only
+// the versions deleted before it are listed.
+func (db *PostgreSQL) ListTrashedServers(ctx context.Context, tx pgx.Tx, deletedBefore *time.Time) ([]models.TrashEntry, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if !db.authz.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `
+		SELECT server_name, version, deleted_at, COALESCE(deleted_by, '')
+		FROM servers
+		WHERE deleted_at IS NOT NULL AND ($1::timestamptz IS NULL OR deleted_at < $1)
+		ORDER BY deleted_at DESC, server_name, version
+	`, deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted servers: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.TrashEntry{}
+	for rows.Next() {
+		entry := models.TrashEntry{ArtifactType: "mcp"}
+		if err := rows.Scan(&entry.Name, &entry.Version, &entry.DeletedAt, &entry.DeletedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted server: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deleted servers: %w", err)
+	}
+	return entries, nil
+}
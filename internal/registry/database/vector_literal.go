@@ -27,3 +27,25 @@ func vectorLiteral(vec []float32) (string, error) {
 	b.WriteByte(']')
 	return b.String(), nil
 }
+
+// parseVectorLiteral parses pgvector's textual representation ("[1,2,3]") into a slice of float32 values.
+func parseVectorLiteral(s string) ([]float32, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return nil, fmt.Errorf("invalid vector literal")
+	}
+	body := s[1 : len(s)-1]
+	if body == "" {
+		return nil, fmt.Errorf("vector must not be empty")
+	}
+	parts := strings.Split(body, ",")
+	vec := make([]float32, len(parts))
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector value at index %d: %w", i, err)
+		}
+		vec[i] = float32(v)
+	}
+	return vec, nil
+}
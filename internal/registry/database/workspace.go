@@ -0,0 +1,263 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// workspaceVisibility returns a condition hiding the entries the caller may not see, using $argIndex for the caller's
+// subject. Members of a workspace see all of its entries. Everyone else sees published or workspace-less entries
+// that are not private, and listings additionally leave out unlisted entries. Registry admins see every entry, so the
+// condition is empty for them.
+func (db *PostgreSQL) workspaceVisibility(ctx context.Context, argIndex int, listing bool) (string, []any) {
+	if db.authz.IsRegistryAdmin(ctx) {
+		return "", nil
+	}
+	visibility := "visibility <> 'private'"
+	if listing {
+		visibility = "visibility = 'public'"
+	}
+	subject, _ := auth.ActorFrom(ctx)
+	return fmt.Sprintf("(workspace IN (SELECT workspace FROM workspace_members WHERE subject = $%d) OR ((workspace IS NULL OR published) AND %s))", argIndex, visibility), []any{subject}
+}
+
+// andWorkspaceVisible returns the workspaceVisibility condition for direct lookups prefixed with AND, for a WHERE
+// clause that already uses the given arguments, together with the extended arguments
+func (db *PostgreSQL) andWorkspaceVisible(ctx context.Context, args ...any) (string, []any) {
+	condition, conditionArgs := db.workspaceVisibility(ctx, len(args)+1, false)
+	if condition == "" {
+		return "", args
+	}
+	return " AND " + condition, append(args, conditionArgs...)
+}
+
+// workspaceMembership returns a condition hiding the rows of workspaces the caller is not a member of, for tables
+// without a published flag such as deployments
+func (db *PostgreSQL) workspaceMembership(ctx context.Context, argIndex int) (string, []any) {
+	if db.authz.IsRegistryAdmin(ctx) {
+		return "", nil
+	}
+	subject, _ := auth.ActorFrom(ctx)
+	return fmt.Sprintf("(workspace IS NULL OR workspace IN (SELECT workspace FROM workspace_members WHERE subject = $%d))", argIndex), []any{subject}
+}
+
+// errPrivateOutsideWorkspace is returned when a private entry would not belong to a workspace
+var errPrivateOutsideWorkspace = fmt.Errorf("%w: private entries must be published in a workspace", database.ErrInvalidInput)
+
+// isVisibilityViolation reports whether err comes from the visibility check constraint of servers, agents or skills
+func isVisibilityViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23514" && strings.HasSuffix(pgErr.ConstraintName, "_visibility_check")
+}
+
+// nullableWorkspace returns the workspace a context acts in, or nil to store NULL
+func nullableWorkspace(ctx context.Context) *string {
+	if workspace := auth.WorkspaceFrom(ctx); workspace != "" {
+		return &workspace
+	}
+	return nil
+}
+
+// CreateWorkspace creates a workspace with its creator as owner
+func (db *PostgreSQL) CreateWorkspace(ctx context.Context, tx pgx.Tx, workspace *models.Workspace) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	query := `
+        INSERT INTO workspaces (name, display_name, description, created_by)
+        VALUES ($1, $2, $3, $4)
+        RETURNING created_at
+    `
+	err := executor.QueryRow(ctx, query, workspace.Name, workspace.DisplayName, workspace.Description, workspace.CreatedBy).Scan(&workspace.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return database.ErrAlreadyExists
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23514" { // check_violation
+			return fmt.Errorf("%w: workspace names are lowercase letters, digits and dashes", database.ErrInvalidInput)
+		}
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	_, err = executor.Exec(ctx, `INSERT INTO workspace_members (workspace, subject, role) VALUES ($1, $2, $3)`,
+		workspace.Name, workspace.CreatedBy, models.WorkspaceRoleOwner)
+	if err != nil {
+		return fmt.Errorf("failed to add workspace owner: %w", err)
+	}
+	return nil
+}
+
+// GetWorkspace retrieves a workspace by name
+func (db *PostgreSQL) GetWorkspace(ctx context.Context, tx pgx.Tx, name string) (*models.Workspace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var workspace models.Workspace
+	query := `SELECT name, display_name, description, created_by, created_at FROM workspaces WHERE name = $1`
+	err := db.getExecutor(tx).QueryRow(ctx, query, name).
+		Scan(&workspace.Name, &workspace.DisplayName, &workspace.Description, &workspace.CreatedBy, &workspace.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	return &workspace, nil
+}
+
+// ListWorkspaces lists the workspaces a subject is a member of, or every workspace when subject is empty
+func (db *PostgreSQL) ListWorkspaces(ctx context.Context, tx pgx.Tx, subject string) ([]models.Workspace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT name, display_name, description, created_by, created_at FROM workspaces`
+	var args []any
+	if subject != "" {
+		query += ` WHERE name IN (SELECT workspace FROM workspace_members WHERE subject = $1)`
+		args = append(args, subject)
+	}
+	query += ` ORDER BY name`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	workspaces := []models.Workspace{}
+	for rows.Next() {
+		var workspace models.Workspace
+		if err := rows.Scan(&workspace.Name, &workspace.DisplayName, &workspace.Description, &workspace.CreatedBy, &workspace.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan workspace: %w", err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workspaces: %w", err)
+	}
+	return workspaces, nil
+}
+
+// DeleteWorkspace deletes a workspace with its members and API tokens. It fails while entries or deployments still
+// belong to the workspace.
+func (db *PostgreSQL) DeleteWorkspace(ctx context.Context, tx pgx.Tx, name string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM workspaces WHERE name = $1`, name)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("%w: entries or deployments still belong to workspace %s", database.ErrInvalidInput, name)
+		}
+		return fmt.Errorf("failed to delete workspace: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+// GetWorkspaceRole returns the role of a subject in a workspace, or ErrNotFound when it is not a member
+func (db *PostgreSQL) GetWorkspaceRole(ctx context.Context, tx pgx.Tx, workspace, subject string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	var role string
+	query := `SELECT role FROM workspace_members WHERE workspace = $1 AND subject = $2`
+	err := db.getExecutor(tx).QueryRow(ctx, query, workspace, subject).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", database.ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace role: %w", err)
+	}
+	return role, nil
+}
+
+// ListWorkspaceMembers lists the members of a workspace, owners first
+func (db *PostgreSQL) ListWorkspaceMembers(ctx context.Context, tx pgx.Tx, workspace string) ([]models.WorkspaceMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+        SELECT subject, role, added_at
+        FROM workspace_members
+        WHERE workspace = $1
+        ORDER BY role = 'owner' DESC, subject
+    `
+	rows, err := db.getExecutor(tx).Query(ctx, query, workspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.WorkspaceMember{}
+	for rows.Next() {
+		var member models.WorkspaceMember
+		if err := rows.Scan(&member.Subject, &member.Role, &member.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan workspace member: %w", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workspace members: %w", err)
+	}
+	return members, nil
+}
+
+// SetWorkspaceMember adds a member to a workspace or changes the role of an existing member
+func (db *PostgreSQL) SetWorkspaceMember(ctx context.Context, tx pgx.Tx, workspace string, member *models.WorkspaceMember) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+        INSERT INTO workspace_members (workspace, subject, role)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (workspace, subject) DO UPDATE SET role = EXCLUDED.role
+        RETURNING added_at
+    `
+	err := db.getExecutor(tx).QueryRow(ctx, query, workspace, member.Subject, member.Role).Scan(&member.AddedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return database.ErrNotFound
+		}
+		return fmt.Errorf("failed to set workspace member: %w", err)
+	}
+	return nil
+}
+
+// RemoveWorkspaceMember removes a member from a workspace
+func (db *PostgreSQL) RemoveWorkspaceMember(ctx context.Context, tx pgx.Tx, workspace, subject string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM workspace_members WHERE workspace = $1 AND subject = $2`, workspace, subject)
+	if err != nil {
+		return fmt.Errorf("failed to remove workspace member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
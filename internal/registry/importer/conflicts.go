@@ -0,0 +1,196 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ConflictStrategy decides what an import does with entries whose name collides with a server already in the
+// registry or earlier in the same import
+type ConflictStrategy string
+
+const (
+	// ConflictSkip keeps existing versions and imports only new ones
+	ConflictSkip ConflictStrategy = "skip"
+	// ConflictOverwrite replaces existing versions with the imported ones
+	ConflictOverwrite ConflictStrategy = "overwrite"
+	// ConflictNewestVersionWins imports only the newest version of each server, and only if it is newer than the
+	// latest version already in the registry
+	ConflictNewestVersionWins ConflictStrategy = "newest-version-wins"
+	// ConflictRenameWithSuffix imports servers whose name is taken by a server from another repository under the
+	// name with a numeric suffix (my-server-2, my-server-3, ...)
+	ConflictRenameWithSuffix ConflictStrategy = "rename-with-suffix"
+)
+
+// ConflictStrategies lists the supported conflict strategies
+var ConflictStrategies = []ConflictStrategy{ConflictSkip, ConflictOverwrite, ConflictNewestVersionWins, ConflictRenameWithSuffix}
+
+// ParseConflictStrategy parses a conflict strategy name
+func ParseConflictStrategy(name string) (ConflictStrategy, error) {
+	for _, strategy := range ConflictStrategies {
+		if string(strategy) == name {
+			return strategy, nil
+		}
+	}
+	names := make([]string, len(ConflictStrategies))
+	for i, strategy := range ConflictStrategies {
+		names[i] = string(strategy)
+	}
+	return "", fmt.Errorf("unknown conflict strategy %q (expected one of %s)", name, strings.Join(names, ", "))
+}
+
+type importAction string
+
+const (
+	importActionCreate    importAction = "create"
+	importActionOverwrite importAction = "overwrite"
+	importActionRename    importAction = "rename"
+	importActionSkip      importAction = "skip"
+)
+
+// plannedImport is what an import does with one entry of its source
+type plannedImport struct {
+	server       *apiv0.ServerJSON
+	originalName string
+	action       importAction
+	reason       string
+}
+
+// existingServer is what the plan knows about a server name: its versions and the repository they come from
+type existingServer struct {
+	versions   map[string]bool
+	latest     string
+	latestAt   time.Time
+	repository string
+}
+
+// planImport applies the conflict strategy to the servers of an import, against the registry and each other
+func (s *Service) planImport(ctx context.Context, servers []*apiv0.ServerJSON) ([]plannedImport, error) {
+	known := map[string]*existingServer{}
+	lookup := func(name string) (*existingServer, error) {
+		if existing, ok := known[name]; ok {
+			return existing, nil
+		}
+		existing := &existingServer{versions: map[string]bool{}}
+		versions, err := s.registry.GetAllVersionsByServerName(ctx, name, false)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("failed to look up existing versions of %s: %w", name, err)
+		}
+		for _, v := range versions {
+			existing.add(&v.Server, serverPublishedAt(v))
+		}
+		known[name] = existing
+		return existing, nil
+	}
+
+	// Newest version wins within the import too: find the newest version of each name up front. Versions that do not
+	// compare, such as non-semver ones, are ordered as they appear in the source.
+	newest := map[string]*apiv0.ServerJSON{}
+	if s.conflictStrategy == ConflictNewestVersionWins {
+		for _, server := range servers {
+			if current, ok := newest[server.Name]; !ok || service.CompareVersions(server.Version, current.Version, time.Time{}, time.Time{}) >= 0 {
+				newest[server.Name] = server
+			}
+		}
+	}
+
+	plan := make([]plannedImport, 0, len(servers))
+	for _, server := range servers {
+		entry := plannedImport{server: server, originalName: server.Name, action: importActionCreate}
+		existing, err := lookup(server.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		switch s.conflictStrategy {
+		case ConflictOverwrite:
+			if existing.versions[server.Version] {
+				entry.action = importActionOverwrite
+			}
+		case ConflictNewestVersionWins:
+			switch {
+			case newest[server.Name] != server:
+				entry.action, entry.reason = importActionSkip, fmt.Sprintf("a newer version %s is imported", newest[server.Name].Version)
+			case existing.versions[server.Version]:
+				entry.action, entry.reason = importActionSkip, "version already exists"
+			case existing.latest != "" && service.CompareVersions(server.Version, existing.latest, time.Now(), existing.latestAt) <= 0:
+				entry.action, entry.reason = importActionSkip, fmt.Sprintf("not newer than the latest version %s", existing.latest)
+			}
+		case ConflictRenameWithSuffix:
+			repository := serverRepositoryURL(server)
+			for suffix := 2; repository != "" && existing.repository != "" && existing.repository != repository; suffix++ {
+				renamed := fmt.Sprintf("%s-%d", entry.originalName, suffix)
+				if existing, err = lookup(renamed); err != nil {
+					return nil, err
+				}
+				copied := *server
+				copied.Name = renamed
+				entry.server, entry.action = &copied, importActionRename
+			}
+			if existing.versions[server.Version] {
+				entry.action, entry.reason = importActionSkip, "version already exists"
+			}
+		default:
+			if existing.versions[server.Version] {
+				entry.action, entry.reason = importActionSkip, "version already exists"
+			}
+		}
+
+		if entry.action != importActionSkip {
+			existing.add(entry.server, time.Now())
+		}
+		plan = append(plan, entry)
+	}
+	return plan, nil
+}
+
+func (e *existingServer) add(server *apiv0.ServerJSON, publishedAt time.Time) {
+	e.versions[server.Version] = true
+	if e.repository == "" {
+		e.repository = serverRepositoryURL(server)
+	}
+	if e.latest == "" || service.CompareVersions(server.Version, e.latest, publishedAt, e.latestAt) > 0 {
+		e.latest, e.latestAt = server.Version, publishedAt
+	}
+}
+
+// serverRepositoryURL identifies where a server comes from. Servers without a repository are never renamed.
+func serverRepositoryURL(server *apiv0.ServerJSON) string {
+	if server.Repository == nil || server.Repository.URL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(strings.ToLower(server.Repository.URL), ".git")
+}
+
+func serverPublishedAt(server *apiv0.ServerResponse) time.Time {
+	if server.Meta.Official == nil {
+		return time.Time{}
+	}
+	return server.Meta.Official.PublishedAt
+}
+
+// logImportPlan reports what an import would change, for dry runs
+func logImportPlan(plan []plannedImport) {
+	counts := map[importAction]int{}
+	for _, entry := range plan {
+		counts[entry.action]++
+		switch entry.action {
+		case importActionRename:
+			log.Printf("[dry-run] rename %s@%s -> %s", entry.originalName, entry.server.Version, entry.server.Name)
+		case importActionSkip:
+			log.Printf("[dry-run] skip %s@%s: %s", entry.originalName, entry.server.Version, entry.reason)
+		default:
+			log.Printf("[dry-run] %s %s@%s", entry.action, entry.server.Name, entry.server.Version)
+		}
+	}
+	log.Printf("[dry-run] %d to create, %d to overwrite, %d to rename, %d to skip; nothing was written",
+		counts[importActionCreate], counts[importActionOverwrite], counts[importActionRename], counts[importActionSkip])
+}
@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"slices"
 	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 )
 
 type dependencyHealthSummary struct {
@@ -16,6 +18,8 @@ type dependencyHealthSummary struct {
 	Ecosystems          map[string]int
 	CopyleftCount       int
 	UnknownLicenseCount int
+	// RootLicense is the license the SBOM declares for the repository itself
+	RootLicense string
 }
 
 func (d *dependencyHealthSummary) summaryString() string {
@@ -113,8 +117,11 @@ func (s *Service) fetchDependencyHealthSummary(ctx context.Context, owner, repo
 	}
 	var payload struct {
 		SBOM struct {
-			Packages []struct {
+			DocumentDescribes []string `json:"documentDescribes"`
+			Packages          []struct {
+				SPDXID               string   `json:"SPDXID"`
 				Name                 string   `json:"name"`
+				LicenseDeclared      string   `json:"licenseDeclared"`
 				LicenseConcluded     string   `json:"licenseConcluded"`
 				LicenseInfoFromFiles []string `json:"licenseInfoFromFiles"`
 				ExternalRefs         []struct {
@@ -135,6 +142,11 @@ func (s *Service) fetchDependencyHealthSummary(ctx context.Context, owner, repo
 	}
 	summary := &dependencyHealthSummary{Ecosystems: map[string]int{}}
 	for _, pkg := range payload.SBOM.Packages {
+		if slices.Contains(payload.SBOM.DocumentDescribes, pkg.SPDXID) {
+			if licenses := gatherLicenses(pkg.LicenseDeclared, nil); len(licenses) > 0 {
+				summary.RootLicense = licenses[0]
+			}
+		}
 		purlType := detectPurlType(pkg.ExternalRefs)
 		if purlType != "" {
 			if purlType == "github" {
@@ -183,8 +195,7 @@ func detectPurlType(refs []struct {
 func hasCopyleftLicense(concluded string, fromFiles []string) bool {
 	licenses := gatherLicenses(concluded, fromFiles)
 	for _, lic := range licenses {
-		slug := strings.ToLower(lic)
-		if strings.Contains(slug, "gpl") || strings.Contains(slug, "agpl") || strings.Contains(slug, "lgpl") || strings.Contains(slug, "sspl") || strings.Contains(slug, "copyleft") || strings.Contains(slug, "cc-by-sa") {
+		if models.IsCopyleftLicense(lic) {
 			return true
 		}
 	}
@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultEnrichLimit bounds how many servers one enrichment run refreshes, keeping runs within GitHub rate limits
+const defaultEnrichLimit = 100
+
+// EnrichOptions configures a refresh of the enriched details of existing servers
+type EnrichOptions struct {
+	// MaxAge re-enriches servers last enriched longer ago than this; zero re-enriches every server
+	MaxAge time.Duration
+	// Concurrency limits how many servers are enriched at the same time
+	Concurrency int
+	// Limit bounds how many servers are enriched in one run; zero uses a default of 100
+	Limit int
+}
+
+// EnrichExisting refreshes the enriched details (GitHub activity, OpenSSF scorecard, dependency health and image pull
+// stats) of the latest versions of servers already in the registry, least recently enriched first. Each attempt is
+// recorded, so servers that fail are retried on a later run rather than on every run.
+func (s *Service) EnrichExisting(ctx context.Context, opts EnrichOptions) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Importer.EnrichExisting")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultEnrichLimit
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	due, err := s.registry.ListServersDueForEnrichment(ctx, time.Now().Add(-opts.MaxAge), limit)
+	if err != nil {
+		return fmt.Errorf("failed to list servers due for enrichment: %w", err)
+	}
+	span.SetAttributes(attribute.Int("enrich.servers", len(due)))
+	if len(due) == 0 {
+		return nil
+	}
+
+	var failed atomic.Int32
+	wg := &sync.WaitGroup{}
+	sem := make(chan struct{}, concurrency)
+	for _, entry := range due {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := s.enrichExisting(ctx, entry); err != nil {
+				failed.Add(1)
+				log.Printf("Warning: enrichment failed for %s@%s: %v", entry.ServerName, entry.Version, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	log.Printf("Enriched %d servers (%d failed)", len(due)-int(failed.Load()), failed.Load())
+	if n := failed.Load(); n > 0 {
+		return fmt.Errorf("enrichment failed for %d of %d servers", n, len(due))
+	}
+	return nil
+}
+
+// enrichExisting enriches a single server version and stores the result
+func (s *Service) enrichExisting(ctx context.Context, entry *models.ServerEnrichment) error {
+	current, err := s.registry.GetServerByNameAndVersion(ctx, entry.ServerName, entry.Version, false)
+	if err != nil {
+		return err
+	}
+
+	// Drop the details of the previous enrichment so only fresh ones are stored; servers without a GitHub
+	// repository get none and keep what they have
+	server := current.Server
+	if server.Meta != nil && server.Meta.PublisherProvided != nil {
+		delete(server.Meta.PublisherProvided, models.EnrichmentMetadataKey)
+	}
+
+	enrichErr := s.enrichServer(ctx, &server)
+	var metadata map[string]any
+	if enrichErr == nil && server.Meta != nil {
+		metadata, _ = server.Meta.PublisherProvided[models.EnrichmentMetadataKey].(map[string]any)
+	}
+
+	if err := s.registry.RecordServerEnrichment(ctx, entry.ServerName, entry.Version, metadata, enrichErr); err != nil {
+		return err
+	}
+	return enrichErr
+}
@@ -0,0 +1,438 @@
+package importer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	githubOrgSourcePrefix = "github-org:"
+	defaultGitHubAPIURL   = "https://api.github.com"
+	githubReposPageSize   = 100
+
+	serverManifestFile = "server.json"
+	agentManifestFile  = "agent.yaml"
+)
+
+// crawlState remembers what earlier crawls of GitHub organizations saw, so re-crawls only download what changed.
+// Conditional requests answered with 304 Not Modified do not count against GitHub rate limits.
+type crawlState struct {
+	// Responses holds the ETag, and for repository listings the body, of each GitHub API response by URL
+	Responses map[string]cachedResponse `json:"responses"`
+	// Files holds the blob SHA of each server.json and agent.yaml imported, keyed by owner/repo/path
+	Files map[string]string `json:"files"`
+}
+
+type cachedResponse struct {
+	ETag string          `json:"etag"`
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+type githubRepo struct {
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch"`
+	Archived      bool   `json:"archived"`
+	Fork          bool   `json:"fork"`
+}
+
+// crawledFile is a server.json or agent.yaml found in a repository
+type crawledFile struct {
+	key    string // owner/repo/path
+	sha    string
+	server *apiv0.ServerJSON
+	agent  *models.AgentJSON
+}
+
+// SetGitHubAPIURL overrides the GitHub API base URL used to crawl github-org: sources, e.g. for GitHub Enterprise
+func (s *Service) SetGitHubAPIURL(apiURL string) {
+	if apiURL = strings.TrimRight(strings.TrimSpace(apiURL), "/"); apiURL != "" {
+		s.githubAPIURL = apiURL
+	}
+}
+
+// SetCrawlStatePath configures a file that remembers the ETags and imported files of github-org: crawls, so
+// re-crawling an organization only downloads the repositories and files that changed
+func (s *Service) SetCrawlStatePath(path string) {
+	s.crawlStatePath = strings.TrimSpace(path)
+}
+
+// importGitHubOrg crawls the non-archived, non-fork repositories of a GitHub organization for server.json and
+// agent.yaml files and imports the servers and agents they describe
+func (s *Service) importGitHubOrg(ctx context.Context, org string, enrichServerData bool, progress *importProgress) error {
+	org = strings.TrimSpace(org)
+	if org == "" || strings.Contains(org, "/") {
+		return fmt.Errorf("invalid GitHub organization %q, expected github-org:<org>", org)
+	}
+
+	state, err := s.loadCrawlState()
+	if err != nil {
+		return fmt.Errorf("failed to load crawl state: %w", err)
+	}
+
+	repos, err := s.listOrgRepos(ctx, org, state)
+	if err != nil {
+		return err
+	}
+
+	var (
+		servers   []*apiv0.ServerJSON
+		files     []crawledFile
+		treeETags = map[string]string{}
+		unchanged int
+	)
+	for _, repo := range repos {
+		if repo.Archived || repo.Fork {
+			continue
+		}
+		found, treeURL, etag, err := s.crawlRepo(ctx, repo, state)
+		if err != nil {
+			log.Printf("Warning: skipping repository %s: %v", repo.FullName, err)
+			continue
+		}
+		if found == nil {
+			unchanged++
+			continue
+		}
+		treeETags[treeURL] = etag
+		for _, f := range found {
+			if f.server != nil {
+				servers = append(servers, f.server)
+			}
+			files = append(files, f)
+		}
+	}
+	log.Printf("Crawled %d repositories of %s (%d unchanged): %d new or changed server.json and agent.yaml files",
+		len(repos), org, unchanged, len(files))
+
+	if err := s.importServers(ctx, servers, enrichServerData, progress); err != nil {
+		return err
+	}
+	if s.dryRun {
+		for _, f := range files {
+			if f.agent != nil {
+				log.Printf("[dry-run] import agent %s@%s", f.agent.Name, f.agent.Version)
+			}
+		}
+		return nil
+	}
+	var agents int
+	for _, f := range files {
+		if f.agent != nil {
+			agents++
+		}
+	}
+	progress.addTotal(ctx, agents)
+	for _, f := range files {
+		if f.agent != nil {
+			outcome, err := s.importCrawledAgent(ctx, f.agent)
+			progress.record(ctx, f.agent.Name, f.agent.Version, outcome, err)
+		}
+	}
+
+	// Only remember files that made it into the registry, and only skip a repository next time if all its files did
+	failedRepos := map[string]bool{}
+	for _, f := range files {
+		var err error
+		if f.server != nil {
+			_, err = s.registry.GetServerByNameAndVersion(ctx, f.server.Name, f.server.Version, false)
+		} else {
+			_, err = s.registry.GetAgentByNameAndVersion(ctx, f.agent.Name, f.agent.Version)
+		}
+		if err != nil {
+			failedRepos[repoOfKey(f.key)] = true
+			continue
+		}
+		state.Files[f.key] = f.sha
+	}
+	for treeURL, etag := range treeETags {
+		if !failedRepos[repoOfTreeURL(treeURL)] {
+			state.Responses[treeURL] = cachedResponse{ETag: etag}
+		}
+	}
+	return s.saveCrawlState(state)
+}
+
+// listOrgRepos lists the repositories of an organization, reusing the cached pages GitHub reports as unchanged
+func (s *Service) listOrgRepos(ctx context.Context, org string, state *crawlState) ([]githubRepo, error) {
+	var repos []githubRepo
+	for page := 1; ; page++ {
+		pageURL := fmt.Sprintf("%s/orgs/%s/repos?type=all&per_page=%d&page=%d", s.githubAPIURL, url.PathEscape(org), githubReposPageSize, page)
+		body, etag, notModified, err := s.githubConditionalGet(ctx, pageURL, state)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories of %s: %w", org, err)
+		}
+		if notModified {
+			body = state.Responses[pageURL].Body
+		} else {
+			state.Responses[pageURL] = cachedResponse{ETag: etag, Body: body}
+		}
+
+		var pageRepos []githubRepo
+		if err := json.Unmarshal(body, &pageRepos); err != nil {
+			return nil, fmt.Errorf("failed to parse repositories of %s: %w", org, err)
+		}
+		repos = append(repos, pageRepos...)
+		if len(pageRepos) < githubReposPageSize {
+			return repos, nil
+		}
+	}
+}
+
+// crawlRepo returns the new or changed manifest files of a repository, or nil if its tree has not changed since the
+// last crawl. It also returns the tree URL and ETag to remember once the files are imported.
+func (s *Service) crawlRepo(ctx context.Context, repo githubRepo, state *crawlState) ([]crawledFile, string, string, error) {
+	treeURL := fmt.Sprintf("%s/repos/%s/git/trees/%s?recursive=1", s.githubAPIURL, repo.FullName, url.PathEscape(repo.DefaultBranch))
+	body, etag, notModified, err := s.githubConditionalGet(ctx, treeURL, state)
+	if err != nil {
+		// Empty repositories have no tree
+		if strings.Contains(err.Error(), "status 409") {
+			return []crawledFile{}, treeURL, "", nil
+		}
+		return nil, "", "", err
+	}
+	if notModified {
+		return nil, treeURL, etag, nil
+	}
+
+	var tree struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+			SHA  string `json:"sha"`
+		} `json:"tree"`
+		Truncated bool `json:"truncated"`
+	}
+	if err := json.Unmarshal(body, &tree); err != nil {
+		return nil, "", "", fmt.Errorf("failed to parse tree: %w", err)
+	}
+	if tree.Truncated {
+		log.Printf("Warning: the tree of %s is too large to list completely; some manifests may be missed", repo.FullName)
+	}
+
+	found := []crawledFile{}
+	for _, entry := range tree.Tree {
+		base := path.Base(entry.Path)
+		if entry.Type != "blob" || (base != serverManifestFile && base != agentManifestFile) ||
+			strings.Contains(entry.Path, "node_modules/") || strings.Contains(entry.Path, "vendor/") {
+			continue
+		}
+		key := repo.FullName + "/" + entry.Path
+		if state.Files[key] == entry.SHA {
+			continue
+		}
+
+		content, err := s.fetchGitHubBlob(ctx, repo.FullName, entry.SHA)
+		if err != nil {
+			log.Printf("Warning: skipping %s: %v", key, err)
+			continue
+		}
+		file := crawledFile{key: key, sha: entry.SHA}
+		if base == serverManifestFile {
+			file.server, err = parseCrawledServer(content, repo, entry.Path)
+		} else {
+			file.agent, err = parseCrawledAgent(content, repo, entry.Path)
+		}
+		if err != nil {
+			log.Printf("Skipping invalid %s: %v", key, err)
+			continue
+		}
+		found = append(found, file)
+	}
+	return found, treeURL, etag, nil
+}
+
+func parseCrawledServer(content []byte, repo githubRepo, filePath string) (*apiv0.ServerJSON, error) {
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(content, &server); err != nil {
+		return nil, fmt.Errorf("failed to parse server.json: %w", err)
+	}
+	if server.Repository == nil || server.Repository.URL == "" {
+		server.Repository = crawledRepository(repo, filePath)
+	}
+	if err := validators.ValidateServerJSON(&server); err != nil {
+		return nil, err
+	}
+	return &server, nil
+}
+
+func parseCrawledAgent(content []byte, repo githubRepo, filePath string) (*models.AgentJSON, error) {
+	var manifest models.AgentManifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse agent.yaml: %w", err)
+	}
+	if manifest.Version == "" {
+		return nil, errors.New("agent.yaml has no version")
+	}
+	// Telemetry is a deployment concern and is not stored in the registry
+	manifest.TelemetryEndpoint = ""
+
+	agent := &models.AgentJSON{
+		AgentManifest: manifest,
+		Version:       manifest.Version,
+		Status:        "active",
+		Repository:    crawledRepository(repo, filePath),
+	}
+	if err := validators.ValidateAgentJSON(agent); err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
+
+func crawledRepository(repo githubRepo, filePath string) *model.Repository {
+	repository := &model.Repository{URL: repo.HTMLURL, Source: "github"}
+	if dir := path.Dir(filePath); dir != "." {
+		repository.Subfolder = dir
+	}
+	return repository
+}
+
+func (s *Service) importCrawledAgent(ctx context.Context, agent *models.AgentJSON) (importOutcome, error) {
+	if _, err := s.registry.CreateAgent(ctx, agent); err != nil {
+		if errors.Is(err, database.ErrInvalidVersion) {
+			log.Printf("Agent %s@%s already exists", agent.Name, agent.Version)
+			return outcomeSkipped, nil
+		}
+		log.Printf("Failed to create agent %s: %v", agent.Name, err)
+		return outcomeFailed, err
+	}
+	log.Printf("Imported agent %s@%s", agent.Name, agent.Version)
+	return outcomeCreated, nil
+}
+
+// githubConditionalGet fetches a GitHub API URL, sending the ETag of the previous response so GitHub can answer 304
+// Not Modified. It returns the body and ETag of the response and whether it was not modified.
+func (s *Service) githubConditionalGet(ctx context.Context, apiURL string, state *crawlState) ([]byte, string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, "", false, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if s.githubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.githubToken)
+	}
+	cached, ok := state.Responses[apiURL]
+	if ok && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	client := s.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, cached.ETag, true, nil
+	case http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return body, resp.Header.Get("ETag"), false, nil
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, "", false, fmt.Errorf("github api status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
+
+// fetchGitHubBlob downloads a file of a repository by its blob SHA
+func (s *Service) fetchGitHubBlob(ctx context.Context, fullName, sha string) ([]byte, error) {
+	body, _, _, err := s.githubConditionalGet(ctx, fmt.Sprintf("%s/repos/%s/git/blobs/%s", s.githubAPIURL, fullName, sha), &crawlState{})
+	if err != nil {
+		return nil, err
+	}
+	var blob struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := json.Unmarshal(body, &blob); err != nil {
+		return nil, err
+	}
+	if blob.Encoding != "base64" {
+		return []byte(blob.Content), nil
+	}
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(blob.Content, "\n", ""))
+}
+
+func (s *Service) loadCrawlState() (*crawlState, error) {
+	state := &crawlState{Responses: map[string]cachedResponse{}, Files: map[string]string{}}
+	if s.crawlStatePath == "" {
+		return state, nil
+	}
+	data, err := os.ReadFile(s.crawlStatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Responses == nil {
+		state.Responses = map[string]cachedResponse{}
+	}
+	if state.Files == nil {
+		state.Files = map[string]string{}
+	}
+	return state, nil
+}
+
+func (s *Service) saveCrawlState(state *crawlState) error {
+	if s.crawlStatePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.crawlStatePath), 0o755); err != nil {
+		return err
+	}
+	tmp := s.crawlStatePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write crawl state: %w", err)
+	}
+	return os.Rename(tmp, s.crawlStatePath)
+}
+
+// repoOfKey returns the owner/repo part of an owner/repo/path file key
+func repoOfKey(key string) string {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) < 2 {
+		return key
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+// repoOfTreeURL returns the owner/repo part of a .../repos/<owner>/<repo>/git/trees/... URL
+func repoOfTreeURL(treeURL string) string {
+	_, rest, _ := strings.Cut(treeURL, "/repos/")
+	rest, _, _ = strings.Cut(rest, "/git/trees/")
+	return rest
+}
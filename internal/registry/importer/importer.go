@@ -20,23 +20,32 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/embeddings"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/vulnscan"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// defaultConcurrency is how many servers an import creates and enriches at the same time by default
+const defaultConcurrency = 10
+
 // Service handles importing seed data into the registry
 type Service struct {
 	registry            service.RegistryService
 	httpClient          *http.Client
 	requestHeaders      map[string]string
-	updateIfExists      bool
+	conflictStrategy    ConflictStrategy
+	dryRun              bool
 	githubToken         string
 	readmeSeedPath      string
 	progressCachePath   string
@@ -45,6 +54,14 @@ type Service struct {
 	generateEmbeddings  bool
 	embeddingProvider   embeddings.Provider
 	embeddingDimensions int
+	imageScanner        vulnscan.Scanner
+	npmRegistryURL      string
+	pypiURL             string
+	githubAPIURL        string
+	crawlStatePath      string
+	seedVerifier        *seed.Verifier
+	concurrency         int
+	importID            string
 }
 
 // NewService creates a new importer service with sane defaults
@@ -61,6 +78,11 @@ func NewService(registry service.RegistryService) *Service {
 		httpClient:       &http.Client{Timeout: timeout},
 		requestHeaders:   map[string]string{},
 		processedServers: map[string]struct{}{},
+		conflictStrategy: ConflictSkip,
+		concurrency:      defaultConcurrency,
+		npmRegistryURL:   defaultNPMRegistryURL,
+		pypiURL:          defaultPyPIURL,
+		githubAPIURL:     defaultGitHubAPIURL,
 	}
 }
 
@@ -80,7 +102,34 @@ func (s *Service) SetHTTPClient(client *http.Client) {
 
 // SetUpdateIfExists toggles replacing existing name/version entries instead of skipping
 func (s *Service) SetUpdateIfExists(update bool) {
-	s.updateIfExists = update
+	if update {
+		s.conflictStrategy = ConflictOverwrite
+	} else {
+		s.conflictStrategy = ConflictSkip
+	}
+}
+
+// SetConcurrency sets how many servers are created and enriched at the same time
+func (s *Service) SetConcurrency(concurrency int) {
+	if concurrency > 0 {
+		s.concurrency = concurrency
+	}
+}
+
+// SetImportID records the progress of imports in the registry under an ID created with RegistryService.CreateImport,
+// so background imports can be followed via /v0/imports/{id}
+func (s *Service) SetImportID(id string) {
+	s.importID = id
+}
+
+// SetConflictStrategy sets how entries whose name collides with existing servers are imported
+func (s *Service) SetConflictStrategy(strategy ConflictStrategy) {
+	s.conflictStrategy = strategy
+}
+
+// SetDryRun makes imports log what they would create, overwrite, rename and skip without writing anything
+func (s *Service) SetDryRun(dryRun bool) {
+	s.dryRun = dryRun
 }
 
 // SetGitHubToken sets a token used only for GitHub enrichment calls
@@ -108,20 +157,58 @@ func (s *Service) SetGenerateEmbeddings(enabled bool) {
 	s.generateEmbeddings = enabled
 }
 
+// SetImageScanner configures a scanner used to record vulnerability summaries of OCI images during import.
+func (s *Service) SetImageScanner(scanner vulnscan.Scanner) {
+	s.imageScanner = scanner
+}
+
 // SetProgressCachePath configures a file used to persist import progress between runs.
 func (s *Service) SetProgressCachePath(path string) {
 	s.progressCachePath = strings.TrimSpace(path)
 }
 
+// SetPackageRegistryURLs overrides the npm registry and PyPI base URLs searched by npm: and pypi: sources, e.g. to use
+// a mirror. Empty values keep the defaults.
+func (s *Service) SetPackageRegistryURLs(npmURL, pypiURL string) {
+	if npmURL = strings.TrimRight(strings.TrimSpace(npmURL), "/"); npmURL != "" {
+		s.npmRegistryURL = npmURL
+	}
+	if pypiURL = strings.TrimRight(strings.TrimSpace(pypiURL), "/"); pypiURL != "" {
+		s.pypiURL = pypiURL
+	}
+}
+
 // ImportFromPath imports seed data from various sources:
 // 1. Local file paths (*.json files) - expects ServerJSON array format
 // 2. Direct HTTP URLs to seed.json files - expects ServerJSON array format
 // 3. Registry API endpoints (e.g., /v0/servers, /v0.1/servers) - handles pagination automatically
-func (s *Service) ImportFromPath(ctx context.Context, path string, enrichServerData bool) error {
+// 4. Package registry searches (npm:keyword=mcp-server, pypi:classifier=...) - builds unpublished draft entries
+// 5. GitHub organizations (github-org:myorg) - crawls the org's repos for server.json and agent.yaml files
+func (s *Service) ImportFromPath(ctx context.Context, path string, enrichServerData bool) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Importer.ImportFromPath", attribute.String("import.source", path))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	progress := s.newImportProgress(path)
+	defer func() { progress.finish(ctx, err) }()
+
+	if org, ok := strings.CutPrefix(path, githubOrgSourcePrefix); ok {
+		if err := s.requireVerifiableSource(path); err != nil {
+			return err
+		}
+		return s.importGitHubOrg(ctx, org, enrichServerData, progress)
+	}
+
 	servers, err := s.readSeedFile(ctx, path)
 	if err != nil {
 		return fmt.Errorf("failed to read seed data: %w", err)
 	}
+	return s.importServers(ctx, servers, enrichServerData, progress)
+}
+
+// importServers creates the given servers with a pool of workers, skipping those the progress cache lists as
+// processed. Cancelling ctx stops starting new servers; the import then returns ctx's error.
+func (s *Service) importServers(ctx context.Context, servers []*apiv0.ServerJSON, enrichServerData bool, progress *importProgress) error {
+	span := trace.SpanFromContext(ctx)
 
 	readmeSeeds, err := s.loadReadmeSeed(ctx)
 	if err != nil {
@@ -151,33 +238,60 @@ func (s *Service) ImportFromPath(ctx context.Context, path string, enrichServerD
 		log.Printf("All %d servers already processed; nothing to import", len(servers))
 		return nil
 	}
+	span.SetAttributes(
+		attribute.Int("import.servers", len(pending)),
+		attribute.String("import.strategy", string(s.conflictStrategy)),
+		attribute.Int("import.concurrency", s.concurrency),
+	)
 
-	// Import each server using registry service CreateServer
-	total := len(pending)
-	var processed int32
-
-	wg := &sync.WaitGroup{}
-	concurrencyLimit := 10
-	sem := make(chan struct{}, concurrencyLimit)
+	plan, err := s.planImport(ctx, pending)
+	if err != nil {
+		return err
+	}
+	if s.dryRun {
+		logImportPlan(plan)
+		return nil
+	}
+	progress.addTotal(ctx, len(plan))
 
-	for _, server := range pending {
-		srv := server
-		sem <- struct{}{}
+	// Feed the plan to a fixed pool of workers until it is done or the import is cancelled
+	entries := make(chan plannedImport)
+	var wg sync.WaitGroup
+	for range s.concurrency {
 		wg.Add(1)
 		go func() {
-			defer func() {
-				<-sem
-				wg.Done()
-			}()
-
-			current := atomic.AddInt32(&processed, 1)
-			log.Printf("Importing %d/%d: %s@%s", current, total, srv.Name, srv.Version)
-			s.importServer(ctx, srv, readmeSeeds, enrichServerData)
+			defer wg.Done()
+			for entry := range entries {
+				if entry.action == importActionSkip {
+					log.Printf("Skipping %s@%s: %s", entry.originalName, entry.server.Version, entry.reason)
+					s.markServerProcessed(entry.server)
+					progress.record(ctx, entry.server.Name, entry.server.Version, outcomeSkipped, nil)
+					continue
+				}
+				if entry.action == importActionRename {
+					log.Printf("Server name %s is taken by another repository; importing as %s", entry.originalName, entry.server.Name)
+				}
+				log.Printf("Importing %s@%s", entry.server.Name, entry.server.Version)
+				outcome, err := s.importServer(ctx, entry.server, readmeSeeds, enrichServerData)
+				progress.record(ctx, entry.server.Name, entry.server.Version, outcome, err)
+			}
 		}()
 	}
 
+feed:
+	for _, entry := range plan {
+		select {
+		case entries <- entry:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(entries)
 	wg.Wait()
 
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("import cancelled: %w", err)
+	}
 	return nil
 }
 
@@ -186,20 +300,30 @@ func (s *Service) importServer(
 	srv *apiv0.ServerJSON,
 	readmeSeeds seed.ReadmeFile,
 	enrichServerData bool,
-) {
+) (importOutcome, error) {
 	if srv != nil {
 		defer s.markServerProcessed(srv)
 	}
 	// check server json (schema validation) before attempting to enrich
 	if err := validators.ValidateServerJSON(srv); err != nil {
 		log.Printf("Skipping invalid server %s@%s: %v", srv.Name, srv.Version, err)
-		return
+		return outcomeFailed, err
 	}
 
+	ctx, span := telemetry.StartSpan(ctx, "Importer.ImportServer", telemetry.ResourceAttributes("mcp", srv.Name, srv.Version)...)
+	defer span.End()
+
 	// Best-effort enrichment
+	var enrichErr error
 	if enrichServerData {
-		if err := s.enrichServer(ctx, srv); err != nil {
-			log.Printf("Warning: enrichment failed for %s@%s: %v", srv.Name, srv.Version, err)
+		if enrichErr = s.enrichServer(ctx, srv); enrichErr != nil {
+			log.Printf("Warning: enrichment failed for %s@%s: %v", srv.Name, srv.Version, enrichErr)
+		}
+	}
+
+	if s.imageScanner != nil {
+		if summary := vulnscan.ScanServer(ctx, s.imageScanner, srv); summary != nil {
+			models.SetVulnerabilitySummary(srv, summary)
 		}
 	}
 
@@ -212,18 +336,26 @@ func (s *Service) importServer(
 		}
 	}
 
+	outcome := outcomeCreated
 	_, err := s.registry.CreateServer(ctx, srv)
 	if err != nil {
+		switch {
 		// If duplicate version and update is enabled, try update path
-		if s.updateIfExists && errors.Is(err, database.ErrInvalidVersion) {
+		case s.conflictStrategy == ConflictOverwrite && errors.Is(err, database.ErrInvalidVersion):
 			if _, uerr := s.registry.UpdateServer(ctx, srv.Name, srv.Version, srv, nil); uerr != nil {
 				log.Printf("Failed to update existing server %s: %v", srv.Name, uerr)
-			} else {
-				log.Printf("Updated existing server %s@%s", srv.Name, srv.Version)
+				span.SetStatus(codes.Error, uerr.Error())
+				return outcomeFailed, uerr
 			}
-		} else {
+			log.Printf("Updated existing server %s@%s", srv.Name, srv.Version)
+			outcome = outcomeUpdated
+		case errors.Is(err, database.ErrInvalidVersion):
+			log.Printf("Server %s@%s already exists", srv.Name, srv.Version)
+			return outcomeSkipped, nil
+		default:
 			log.Printf("Failed to create server %s: %v", srv.Name, err)
-			return
+			span.SetStatus(codes.Error, err.Error())
+			return outcomeFailed, err
 		}
 	}
 
@@ -235,7 +367,11 @@ func (s *Service) importServer(
 
 	if !enrichServerData {
 		// Skip README fetch if enrichment is disabled
-		return
+		return outcome, nil
+	}
+	// The enriched details were stored with the server; record when, so the enrichment job refreshes them later
+	if err := s.registry.RecordServerEnrichment(ctx, srv.Name, srv.Version, nil, enrichErr); err != nil {
+		log.Printf("Warning: recording enrichment failed for %s@%s: %v", srv.Name, srv.Version, err)
 	}
 	readmeContent, readmeContentType := s.readmeFromSeed(readmeSeeds, srv)
 	if len(readmeContent) == 0 {
@@ -250,6 +386,7 @@ func (s *Service) importServer(
 			log.Printf("Warning: storing README failed for %s@%s: %v", srv.Name, srv.Version, err)
 		}
 	}
+	return outcome, nil
 }
 
 func (s *Service) buildServerEmbedding(ctx context.Context, srv *apiv0.ServerJSON) (*database.SemanticEmbedding, error) {
@@ -259,13 +396,26 @@ func (s *Service) buildServerEmbedding(ctx context.Context, srv *apiv0.ServerJSO
 
 // readSeedFile reads seed data from various sources
 func (s *Service) readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error) {
+	search, ok, err := parsePackageSearch(path)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err := s.requireVerifiableSource(path); err != nil {
+			return nil, err
+		}
+		return s.searchPackages(ctx, search)
+	}
+
 	var data []byte
-	var err error
 
 	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
 		// Handle HTTP URLs
 		if strings.HasSuffix(path, "/servers") {
 			// This is a registry API endpoint - fetch paginated data
+			if err := s.requireVerifiableSource(path); err != nil {
+				return nil, err
+			}
 			return s.fetchFromRegistryAPI(ctx, path)
 		}
 		// This is a direct file URL
@@ -278,6 +428,9 @@ func (s *Service) readSeedFile(ctx context.Context, path string) ([]*apiv0.Serve
 	if err != nil {
 		return nil, fmt.Errorf("failed to read seed data from %s: %w", path, err)
 	}
+	if err := s.verifySeedData(ctx, path, data); err != nil {
+		return nil, err
+	}
 
 	// Parse ServerJSON array format
 	var serverResponses []apiv0.ServerJSON
@@ -604,10 +757,25 @@ func (s *Service) enrichServer(ctx context.Context, server *apiv0.ServerJSON) er
 		}(),
 	}
 
-	server.Meta.PublisherProvided["aregistry.ai/metadata"] = enterprise
+	server.Meta.PublisherProvided[models.EnrichmentMetadataKey] = enterprise
+	setDetectedLicense(server, repoSummary.License, dependencySummary)
 	return nil
 }
 
+// setDetectedLicense records the license GitHub detected in the repository, falling back to the one declared in its
+// SBOM. A license the publisher declared is kept.
+func setDetectedLicense(server *apiv0.ServerJSON, repoLicense string, dependencySummary *dependencyHealthSummary) {
+	if existing := models.LicenseFromMeta(server.Meta); existing != nil && existing.Source != models.LicenseSourceRepository && existing.Source != models.LicenseSourceSBOM {
+		return
+	}
+	switch {
+	case repoLicense != "":
+		models.SetLicense(server, &models.ServerLicense{SPDX: repoLicense, Source: models.LicenseSourceRepository})
+	case dependencySummary != nil && dependencySummary.RootLicense != "":
+		models.SetLicense(server, &models.ServerLicense{SPDX: dependencySummary.RootLicense, Source: models.LicenseSourceSBOM})
+	}
+}
+
 // parseGitHubRepo extracts owner/repo from common GitHub URL formats
 func parseGitHubRepo(raw string) (string, string) {
 	raw = strings.TrimSpace(raw)
@@ -700,6 +868,9 @@ func (s *Service) fetchGitHubRepoSummary(ctx context.Context, owner, repo string
 		CreatedAt       time.Time `json:"created_at"`
 		UpdatedAt       time.Time `json:"updated_at"`
 		PushedAt        time.Time `json:"pushed_at"`
+		License         *struct {
+			SPDXID string `json:"spdx_id"`
+		} `json:"license"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
 		return nil, err
@@ -708,6 +879,10 @@ func (s *Service) fetchGitHubRepoSummary(ctx context.Context, owner, repo string
 	if payload.Topics == nil {
 		payload.Topics = []string{}
 	}
+	license := ""
+	if payload.License != nil && !strings.EqualFold(payload.License.SPDXID, "NOASSERTION") {
+		license = payload.License.SPDXID
+	}
 	return &githubRepoSummary{
 		Stars:           payload.Stars,
 		ForksCount:      payload.ForksCount,
@@ -717,6 +892,7 @@ func (s *Service) fetchGitHubRepoSummary(ctx context.Context, owner, repo string
 		CreatedAt:       &payload.CreatedAt,
 		UpdatedAt:       &payload.UpdatedAt,
 		PushedAt:        &payload.PushedAt,
+		License:         license,
 	}, nil
 }
 
@@ -794,6 +970,7 @@ type githubRepoSummary struct {
 	CreatedAt       *time.Time
 	UpdatedAt       *time.Time
 	PushedAt        *time.Time
+	License         string // SPDX identifier GitHub detected, empty if none or unrecognized
 }
 
 // githubReleasesSummary captures aggregate release info used for enrichment.
@@ -1314,6 +1491,9 @@ func (s *Service) loadReadmeSeed(ctx context.Context) (seed.ReadmeFile, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read README seed data from %s: %w", s.readmeSeedPath, err)
 	}
+	if err := s.verifySeedData(ctx, s.readmeSeedPath, data); err != nil {
+		return nil, err
+	}
 
 	if len(data) == 0 {
 		return seed.ReadmeFile{}, nil
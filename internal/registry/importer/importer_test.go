@@ -2,10 +2,13 @@ package importer_test
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
@@ -13,6 +16,7 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/registry/importer"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -288,3 +292,264 @@ func TestImportService_ReadmeSeed(t *testing.T) {
 	assert.Equal(t, "text/markdown", readme.ContentType)
 	assert.Equal(t, string(readmeContent), string(readme.Content))
 }
+
+func TestImportService_PackageSearch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/-/v1/search", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "keywords:mcp-server", r.URL.Query().Get("text"))
+		_, _ = w.Write([]byte(`{"total": 3, "objects": [
+			{"package": {"name": "@acme/weather-mcp", "version": "1.2.0", "description": "Weather forecasts",
+				"links": {"repository": "git+https://github.com/Acme/weather-mcp.git", "homepage": "https://acme.dev"}}},
+			{"package": {"name": "notes-mcp", "version": "0.3.1", "description": ""}},
+			{"package": {"name": "broken-mcp", "version": "^1.0.0", "description": "Range version"}}
+		]}`))
+	})
+	mux.HandleFunc("/search/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Framework :: MCP", r.URL.Query().Get("c"))
+		if r.URL.Query().Get("page") != "1" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`<ul><li><a class="package-snippet" href="/project/mcp-files/">mcp-files</a></li></ul>`))
+	})
+	mux.HandleFunc("/pypi/mcp-files/json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"info": {"name": "mcp-files", "version": "2.0.0", "summary": "File access",
+			"project_urls": {"Source": "https://github.com/example/mcp-files"}}}`))
+	})
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false}, nil)
+	importerService := importer.NewService(registryService)
+	importerService.SetPackageRegistryURLs(httpServer.URL, httpServer.URL)
+
+	require.NoError(t, importerService.ImportFromPath(ctx, "npm:keyword=mcp-server", false))
+	require.NoError(t, importerService.ImportFromPath(ctx, "pypi:classifier=Framework :: MCP", false))
+
+	weather, err := registryService.GetServerByNameAndVersion(ctx, "io.github.acme/weather-mcp", "1.2.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Weather forecasts", weather.Server.Description)
+	assert.Equal(t, "https://github.com/Acme/weather-mcp", weather.Server.Repository.URL)
+	assert.Equal(t, "https://acme.dev", weather.Server.WebsiteURL)
+	require.Len(t, weather.Server.Packages, 1)
+	assert.Equal(t, "@acme/weather-mcp", weather.Server.Packages[0].Identifier)
+	assert.Equal(t, "npx", weather.Server.Packages[0].RunTimeHint)
+	assert.Contains(t, weather.Server.Meta.PublisherProvided, importer.PackageSearchMetadataKey)
+
+	notes, err := registryService.GetServerByNameAndVersion(ctx, "com.npmjs/notes-mcp", "0.3.1", false)
+	require.NoError(t, err)
+	assert.Equal(t, "MCP server from the npm package notes-mcp", notes.Server.Description)
+
+	files, err := registryService.GetServerByNameAndVersion(ctx, "io.github.example/mcp-files", "2.0.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, "pypi", files.Server.Packages[0].RegistryType)
+	assert.Equal(t, "uvx", files.Server.Packages[0].RunTimeHint)
+
+	// Packages with range versions do not make valid entries
+	_, err = registryService.GetServerByNameAndVersion(ctx, "com.npmjs/broken-mcp", "^1.0.0", false)
+	assert.Error(t, err)
+
+	assert.Error(t, importerService.ImportFromPath(ctx, "npm:author=someone", false))
+}
+
+func TestImportService_GitHubOrg(t *testing.T) {
+	serverJSON := `{"$schema": "` + model.CurrentSchemaURL + `", "name": "io.github.acme/weather", "description": "Weather server", "version": "1.0.0"}`
+	agentYAML := "agentName: acme-assistant\nversion: 0.2.0\nlanguage: python\nframework: adk\ndescription: Assistant\n"
+	blob := func(content string) string {
+		data, _ := json.Marshal(map[string]string{"content": base64.StdEncoding.EncodeToString([]byte(content)), "encoding": "base64"})
+		return string(data)
+	}
+
+	var treeFetches, blobFetches atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name": "weather", "full_name": "acme/weather", "html_url": "https://github.com/acme/weather", "default_branch": "main"},
+			{"name": "old", "full_name": "acme/old", "html_url": "https://github.com/acme/old", "default_branch": "main", "archived": true}
+		]`))
+	})
+	mux.HandleFunc("/repos/acme/weather/git/trees/main", func(w http.ResponseWriter, r *http.Request) {
+		treeFetches.Add(1)
+		if r.Header.Get("If-None-Match") == `"tree-v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"tree-v1"`)
+		_, _ = w.Write([]byte(`{"tree": [
+			{"path": "server.json", "type": "blob", "sha": "s1"},
+			{"path": "agents/assistant/agent.yaml", "type": "blob", "sha": "a1"},
+			{"path": "node_modules/dep/server.json", "type": "blob", "sha": "n1"},
+			{"path": "README.md", "type": "blob", "sha": "r1"}
+		]}`))
+	})
+	mux.HandleFunc("/repos/acme/weather/git/blobs/s1", func(w http.ResponseWriter, _ *http.Request) {
+		blobFetches.Add(1)
+		_, _ = w.Write([]byte(blob(serverJSON)))
+	})
+	mux.HandleFunc("/repos/acme/weather/git/blobs/a1", func(w http.ResponseWriter, _ *http.Request) {
+		blobFetches.Add(1)
+		_, _ = w.Write([]byte(blob(agentYAML)))
+	})
+	mux.HandleFunc("/repos/acme/old/", func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("archived repositories must not be crawled")
+	})
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false}, nil)
+	importerService := importer.NewService(registryService)
+	importerService.SetGitHubAPIURL(httpServer.URL)
+	importerService.SetCrawlStatePath(filepath.Join(t.TempDir(), "crawl-state.json"))
+
+	require.NoError(t, importerService.ImportFromPath(ctx, "github-org:acme", false))
+
+	server, err := registryService.GetServerByNameAndVersion(ctx, "io.github.acme/weather", "1.0.0", false)
+	require.NoError(t, err)
+	require.NotNil(t, server.Server.Repository)
+	assert.Equal(t, "https://github.com/acme/weather", server.Server.Repository.URL)
+
+	agent, err := registryService.GetAgentByNameAndVersion(ctx, "acme-assistant", "0.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "agents/assistant", agent.Agent.Repository.Subfolder)
+	assert.Equal(t, int32(2), blobFetches.Load())
+
+	// Re-crawling an unchanged organization only revalidates the repository tree
+	require.NoError(t, importerService.ImportFromPath(ctx, "github-org:acme", false))
+	assert.Equal(t, int32(2), treeFetches.Load())
+	assert.Equal(t, int32(2), blobFetches.Load())
+
+	assert.Error(t, importerService.ImportFromPath(ctx, "github-org:", false))
+}
+
+func TestImportService_ConflictStrategies(t *testing.T) {
+	ctx := context.Background()
+	server := func(name, version, description, repo string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: description,
+			Version:     version,
+			Repository:  &model.Repository{URL: "https://github.com/" + repo, Source: "github"},
+		}
+	}
+	setup := func(t *testing.T, seedServers ...*apiv0.ServerJSON) (service.RegistryService, string) {
+		t.Helper()
+		registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false}, nil)
+		_, err := registryService.CreateServer(ctx, server("io.github.test/weather", "1.0.0", "Original", "test/weather"))
+		require.NoError(t, err)
+
+		data, err := json.Marshal(seedServers)
+		require.NoError(t, err)
+		seedPath := filepath.Join(t.TempDir(), "seed.json")
+		require.NoError(t, os.WriteFile(seedPath, data, 0o600))
+		return registryService, seedPath
+	}
+	description := func(t *testing.T, registryService service.RegistryService, name, version string) string {
+		t.Helper()
+		existing, err := registryService.GetServerByNameAndVersion(ctx, name, version, false)
+		require.NoError(t, err)
+		return existing.Server.Description
+	}
+
+	t.Run("skip keeps existing versions", func(t *testing.T) {
+		registryService, seedPath := setup(t, server("io.github.test/weather", "1.0.0", "Updated", "test/weather"))
+		importerService := importer.NewService(registryService)
+		require.NoError(t, importerService.ImportFromPath(ctx, seedPath, false))
+		assert.Equal(t, "Original", description(t, registryService, "io.github.test/weather", "1.0.0"))
+	})
+
+	t.Run("overwrite replaces existing versions, except in dry runs", func(t *testing.T) {
+		registryService, seedPath := setup(t, server("io.github.test/weather", "1.0.0", "Updated", "test/weather"))
+		importerService := importer.NewService(registryService)
+		importerService.SetConflictStrategy(importer.ConflictOverwrite)
+
+		importerService.SetDryRun(true)
+		require.NoError(t, importerService.ImportFromPath(ctx, seedPath, false))
+		assert.Equal(t, "Original", description(t, registryService, "io.github.test/weather", "1.0.0"))
+
+		importerService.SetDryRun(false)
+		require.NoError(t, importerService.ImportFromPath(ctx, seedPath, false))
+		assert.Equal(t, "Updated", description(t, registryService, "io.github.test/weather", "1.0.0"))
+	})
+
+	t.Run("newest version wins", func(t *testing.T) {
+		registryService, seedPath := setup(t,
+			server("io.github.test/weather", "0.9.0", "Older", "test/weather"),
+			server("io.github.test/weather", "1.2.0", "Newest", "test/weather"),
+			server("io.github.test/weather", "1.1.0", "Newer", "test/weather"),
+		)
+		importerService := importer.NewService(registryService)
+		importerService.SetConflictStrategy(importer.ConflictNewestVersionWins)
+		require.NoError(t, importerService.ImportFromPath(ctx, seedPath, false))
+
+		versions, err := registryService.GetAllVersionsByServerName(ctx, "io.github.test/weather", false)
+		require.NoError(t, err)
+		assert.Len(t, versions, 2)
+		assert.Equal(t, "Newest", description(t, registryService, "io.github.test/weather", "1.2.0"))
+	})
+
+	t.Run("rename with suffix", func(t *testing.T) {
+		registryService, seedPath := setup(t,
+			server("io.github.test/weather", "1.0.0", "Another weather server", "other/weather"),
+			server("io.github.test/weather", "1.1.0", "Same repository", "test/weather"),
+		)
+		importerService := importer.NewService(registryService)
+		importerService.SetConflictStrategy(importer.ConflictRenameWithSuffix)
+		require.NoError(t, importerService.ImportFromPath(ctx, seedPath, false))
+		// Re-running the import finds the renamed server instead of renaming again
+		require.NoError(t, importerService.ImportFromPath(ctx, seedPath, false))
+
+		assert.Equal(t, "Another weather server", description(t, registryService, "io.github.test/weather-2", "1.0.0"))
+		assert.Equal(t, "Same repository", description(t, registryService, "io.github.test/weather", "1.1.0"))
+		_, err := registryService.GetServerByNameAndVersion(ctx, "io.github.test/weather-3", "1.0.0", false)
+		assert.Error(t, err)
+	})
+
+	_, err := importer.ParseConflictStrategy("merge")
+	assert.Error(t, err)
+}
+
+func TestImportService_Progress(t *testing.T) {
+	ctx := context.Background()
+	seedServers := []*apiv0.ServerJSON{
+		{Schema: model.CurrentSchemaURL, Name: "io.github.test/one", Description: "One", Version: "1.0.0"},
+		{Schema: model.CurrentSchemaURL, Name: "io.github.test/two", Description: "Two", Version: "1.0.0"},
+		{Schema: model.CurrentSchemaURL, Name: "io.github.test/two", Description: "Two again", Version: "1.0.0"},
+	}
+	data, err := json.Marshal(seedServers)
+	require.NoError(t, err)
+	seedPath := filepath.Join(t.TempDir(), "seed.json")
+	require.NoError(t, os.WriteFile(seedPath, data, 0o600))
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false}, nil)
+	tracked, err := registryService.CreateImport(ctx, seedPath, models.ImportOptions{})
+	require.NoError(t, err)
+
+	importerService := importer.NewService(registryService)
+	importerService.SetConcurrency(2)
+	importerService.SetImportID(tracked.ID)
+	require.NoError(t, importerService.ImportFromPath(ctx, seedPath, false))
+
+	status, err := registryService.GetImport(database.WithTestSession(ctx), tracked.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ImportStatusSucceeded, status.Status)
+	assert.Equal(t, 3, status.Total)
+	assert.Equal(t, 3, status.Processed)
+	assert.Equal(t, 2, status.Created)
+	assert.Equal(t, 1, status.Skipped)
+	assert.NotNil(t, status.FinishedAt)
+
+	// A cancelled import stops and is reported as cancelled
+	tracked, err = registryService.CreateImport(ctx, seedPath, models.ImportOptions{})
+	require.NoError(t, err)
+	importerService.SetImportID(tracked.ID)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, importerService.ImportFromPath(cancelled, seedPath, false), context.Canceled)
+
+	status, err = registryService.GetImport(database.WithTestSession(ctx), tracked.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ImportStatusCancelled, status.Status)
+}
@@ -0,0 +1,300 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// PackageSearchMetadataKey is the publisher-provided _meta key recording which package registry search an entry was
+// generated from, so curators can find the drafts to review:
+//
+//	"_meta": {"io.modelcontextprotocol.registry/publisher-provided": {"aregistry.ai/package-search": {"registry": "npm", "query": "keyword=mcp-server"}}}
+const PackageSearchMetadataKey = "aregistry.ai/package-search"
+
+const (
+	defaultNPMRegistryURL = "https://registry.npmjs.org"
+	defaultPyPIURL        = "https://pypi.org"
+
+	defaultPackageSearchLimit = 100
+	maxPackageSearchLimit     = 1000
+	npmSearchPageSize         = 250
+	maxDescriptionLength      = 100
+)
+
+var (
+	invalidNameCharsRe = regexp.MustCompile(`[^a-z0-9._-]+`)
+	pypiProjectLinkRe  = regexp.MustCompile(`<a class="package-snippet"\s+href="/project/([^/"]+)/"`)
+)
+
+// packageSearch is a package registry search given as a source, e.g. "npm:keyword=mcp-server" or
+// "pypi:classifier=Framework :: MCP"
+type packageSearch struct {
+	registry string
+	query    url.Values
+	limit    int
+}
+
+// parsePackageSearch parses a package registry search source. It returns false for sources that are not one.
+func parsePackageSearch(source string) (*packageSearch, bool, error) {
+	registry, rawQuery, ok := strings.Cut(source, ":")
+	if !ok || (registry != model.RegistryTypeNPM && registry != model.RegistryTypePyPI) {
+		return nil, false, nil
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid %s search %q: %w", registry, rawQuery, err)
+	}
+
+	search := &packageSearch{registry: registry, query: query, limit: defaultPackageSearchLimit}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxPackageSearchLimit {
+			return nil, true, fmt.Errorf("invalid %s search limit %q: must be between 1 and %d", registry, raw, maxPackageSearchLimit)
+		}
+		search.limit = limit
+	}
+	query.Del("limit")
+
+	allowed := map[string][]string{
+		model.RegistryTypeNPM:  {"keyword", "text"},
+		model.RegistryTypePyPI: {"classifier", "q"},
+	}[registry]
+	for key := range query {
+		if !slices.Contains(allowed, key) {
+			return nil, true, fmt.Errorf("unsupported %s search parameter %q (expected %s or limit)", registry, key, strings.Join(allowed, ", "))
+		}
+	}
+	if len(query) == 0 {
+		return nil, true, fmt.Errorf("%s search needs one of %s, e.g. %s:%s=mcp-server", registry, strings.Join(allowed, ", "), registry, allowed[0])
+	}
+	return search, true, nil
+}
+
+// searchPackages builds draft server entries from the packages a package registry search finds
+func (s *Service) searchPackages(ctx context.Context, search *packageSearch) ([]*apiv0.ServerJSON, error) {
+	var (
+		servers []*apiv0.ServerJSON
+		err     error
+	)
+	switch search.registry {
+	case model.RegistryTypeNPM:
+		servers, err = s.searchNPM(ctx, search)
+	default:
+		servers, err = s.searchPyPI(ctx, search)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Several packages can map to the same server name; keep the first, which ranks higher in the search
+	seen := make(map[string]struct{}, len(servers))
+	valid := make([]*apiv0.ServerJSON, 0, len(servers))
+	for _, server := range servers {
+		if _, ok := seen[server.Name]; ok {
+			log.Printf("Skipping package %s: server name %s is already taken by another package", server.Packages[0].Identifier, server.Name)
+			continue
+		}
+		if err := validators.ValidateServerJSON(server); err != nil {
+			log.Printf("Skipping package %s: %v", server.Packages[0].Identifier, err)
+			continue
+		}
+		seen[server.Name] = struct{}{}
+		valid = append(valid, server)
+	}
+	log.Printf("Found %d %s packages, built %d draft server entries", len(servers), search.registry, len(valid))
+	return valid, nil
+}
+
+func (s *Service) searchNPM(ctx context.Context, search *packageSearch) ([]*apiv0.ServerJSON, error) {
+	var terms []string
+	for _, keyword := range search.query["keyword"] {
+		terms = append(terms, "keywords:"+keyword)
+	}
+	terms = append(terms, search.query["text"]...)
+	text := strings.Join(terms, " ")
+
+	var servers []*apiv0.ServerJSON
+	for offset := 0; offset < search.limit; offset += npmSearchPageSize {
+		size := min(npmSearchPageSize, search.limit-offset)
+		searchURL := fmt.Sprintf("%s/-/v1/search?text=%s&size=%d&from=%d", s.npmRegistryURL, url.QueryEscape(text), size, offset)
+		data, err := s.fetchFromHTTP(ctx, searchURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search npm: %w", err)
+		}
+
+		var page struct {
+			Objects []struct {
+				Package struct {
+					Name        string `json:"name"`
+					Version     string `json:"version"`
+					Description string `json:"description"`
+					Links       struct {
+						Homepage   string `json:"homepage"`
+						Repository string `json:"repository"`
+					} `json:"links"`
+				} `json:"package"`
+			} `json:"objects"`
+			Total int `json:"total"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse npm search results: %w", err)
+		}
+
+		for _, obj := range page.Objects {
+			pkg := obj.Package
+			servers = append(servers, buildPackageServer(search, pkg.Name, pkg.Version, pkg.Description, pkg.Links.Repository, pkg.Links.Homepage))
+		}
+		if len(page.Objects) < size || offset+size >= page.Total {
+			break
+		}
+	}
+	return servers, nil
+}
+
+func (s *Service) searchPyPI(ctx context.Context, search *packageSearch) ([]*apiv0.ServerJSON, error) {
+	params := url.Values{}
+	for _, classifier := range search.query["classifier"] {
+		params.Add("c", classifier)
+	}
+	if q := search.query.Get("q"); q != "" {
+		params.Set("q", q)
+	}
+
+	// PyPI has no search API; collect project names from the search pages, then read each project's JSON metadata
+	var names []string
+	for page := 1; len(names) < search.limit; page++ {
+		params.Set("page", strconv.Itoa(page))
+		data, err := s.fetchFromHTTP(ctx, s.pypiURL+"/search/?"+params.Encode())
+		if err != nil {
+			// PyPI answers pages past the last one with 404
+			if page > 1 && strings.Contains(err.Error(), strconv.Itoa(http.StatusNotFound)) {
+				break
+			}
+			return nil, fmt.Errorf("failed to search PyPI: %w", err)
+		}
+		matches := pypiProjectLinkRe.FindAllStringSubmatch(string(data), -1)
+		if len(matches) == 0 {
+			break
+		}
+		for _, m := range matches {
+			if len(names) < search.limit {
+				names = append(names, m[1])
+			}
+		}
+	}
+
+	servers := make([]*apiv0.ServerJSON, 0, len(names))
+	for _, name := range names {
+		data, err := s.fetchFromHTTP(ctx, fmt.Sprintf("%s/pypi/%s/json", s.pypiURL, url.PathEscape(name)))
+		if err != nil {
+			log.Printf("Skipping PyPI project %s: %v", name, err)
+			continue
+		}
+		var project struct {
+			Info struct {
+				Name        string            `json:"name"`
+				Version     string            `json:"version"`
+				Summary     string            `json:"summary"`
+				HomePage    string            `json:"home_page"`
+				ProjectURLs map[string]string `json:"project_urls"`
+			} `json:"info"`
+		}
+		if err := json.Unmarshal(data, &project); err != nil {
+			log.Printf("Skipping PyPI project %s: failed to parse metadata: %v", name, err)
+			continue
+		}
+
+		info := project.Info
+		repository := ""
+		for _, link := range append([]string{info.HomePage}, slices.Sorted(maps.Values(info.ProjectURLs))...) {
+			if owner, _ := parseGitHubRepo(link); owner != "" {
+				repository = link
+				break
+			}
+		}
+		servers = append(servers, buildPackageServer(search, info.Name, info.Version, info.Summary, repository, info.HomePage))
+	}
+	return servers, nil
+}
+
+// buildPackageServer heuristically builds a server entry for a package: packages with a GitHub repository are named
+// io.github.<owner>/<package>, others com.npmjs/<package> or org.pypi/<package>, and run over stdio via npx or uvx
+func buildPackageServer(search *packageSearch, name, version, description, repository, homepage string) *apiv0.ServerJSON {
+	// Scoped npm packages (@scope/name) are named after the package part
+	baseName := name
+	if i := strings.LastIndex(baseName, "/"); i >= 0 {
+		baseName = baseName[i+1:]
+	}
+
+	namespace := "com.npmjs"
+	runtimeHint := "npx"
+	if search.registry == model.RegistryTypePyPI {
+		namespace = "org.pypi"
+		runtimeHint = "uvx"
+	}
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Description: truncateDescription(description, name, search.registry),
+		Version:     version,
+		Packages: []model.Package{{
+			RegistryType: search.registry,
+			Identifier:   name,
+			Version:      version,
+			RunTimeHint:  runtimeHint,
+			Transport:    model.Transport{Type: string(model.TransportTypeStdio)},
+		}},
+		Meta: &apiv0.ServerMeta{PublisherProvided: map[string]any{
+			PackageSearchMetadataKey: map[string]any{
+				"registry": search.registry,
+				"query":    search.query.Encode(),
+			},
+		}},
+	}
+	if owner, repo := parseGitHubRepo(repository); owner != "" {
+		namespace = "io.github." + sanitizeNamePart(owner)
+		server.Repository = &model.Repository{
+			URL:    fmt.Sprintf("https://github.com/%s/%s", owner, repo),
+			Source: "github",
+		}
+	}
+	if u, err := url.Parse(homepage); err == nil && u.Scheme == "https" && u.Host != "" {
+		server.WebsiteURL = homepage
+	}
+	server.Name = namespace + "/" + sanitizeNamePart(baseName)
+	return server
+}
+
+// sanitizeNamePart lowercases a package or owner name and replaces the characters server names do not allow
+func sanitizeNamePart(name string) string {
+	name = invalidNameCharsRe.ReplaceAllString(strings.ToLower(name), "-")
+	return strings.Trim(name, "._-")
+}
+
+func truncateDescription(description, name, registry string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		return fmt.Sprintf("MCP server from the %s package %s", registry, name)
+	}
+	if len(description) > maxDescriptionLength {
+		cut := strings.LastIndex(description[:maxDescriptionLength-3], " ")
+		if cut <= 0 {
+			cut = maxDescriptionLength - 3
+		}
+		description = description[:cut] + "..."
+	}
+	return description
+}
@@ -0,0 +1,111 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// progressReportInterval throttles how often the progress of a tracked import is recorded in the registry
+const progressReportInterval = time.Second
+
+type importOutcome int
+
+const (
+	outcomeCreated importOutcome = iota
+	outcomeUpdated
+	outcomeSkipped
+	outcomeFailed
+)
+
+// importProgress counts the outcomes of an import, logs them and, for imports with an ID, records them in the
+// registry so they can be followed via /v0/imports/{id}
+type importProgress struct {
+	s          *Service
+	mu         sync.Mutex
+	status     models.ImportStatus
+	lastReport time.Time
+	lastLogged int
+}
+
+func (s *Service) newImportProgress(source string) *importProgress {
+	return &importProgress{s: s, status: models.ImportStatus{
+		ID:        s.importID,
+		Source:    source,
+		Status:    models.ImportStatusRunning,
+		StartedAt: time.Now(),
+	}}
+}
+
+func (p *importProgress) addTotal(ctx context.Context, n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.Total += n
+	p.reportLocked(ctx, true)
+}
+
+func (p *importProgress) record(ctx context.Context, name, version string, outcome importOutcome, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.Processed++
+	switch outcome {
+	case outcomeCreated:
+		p.status.Created++
+	case outcomeUpdated:
+		p.status.Updated++
+	case outcomeSkipped:
+		p.status.Skipped++
+	case outcomeFailed:
+		p.status.Failed++
+		if len(p.status.Failures) < models.MaxImportFailures {
+			message := "unknown error"
+			if err != nil {
+				message = err.Error()
+			}
+			p.status.Failures = append(p.status.Failures, models.ImportFailure{Name: name, Version: version, Error: message})
+		}
+	}
+
+	// Log a summary every 10% of the import
+	if step := max(p.status.Total/10, 1); p.status.Processed-p.lastLogged >= step || p.status.Processed == p.status.Total {
+		p.lastLogged = p.status.Processed
+		log.Printf("Import progress: %d/%d processed (%d created, %d updated, %d skipped, %d failed)",
+			p.status.Processed, p.status.Total, p.status.Created, p.status.Updated, p.status.Skipped, p.status.Failed)
+	}
+	p.reportLocked(ctx, false)
+}
+
+// finish records the final status of the import
+func (p *importProgress) finish(ctx context.Context, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	finished := time.Now()
+	p.status.FinishedAt = &finished
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		p.status.Status = models.ImportStatusCancelled
+		p.status.Error = err.Error()
+	case err != nil:
+		p.status.Status = models.ImportStatusFailed
+		p.status.Error = err.Error()
+	default:
+		p.status.Status = models.ImportStatusSucceeded
+	}
+	// The import's own context may be cancelled; the final status must still be recorded
+	p.reportLocked(context.WithoutCancel(ctx), true)
+}
+
+// reportLocked must be called with the lock held
+func (p *importProgress) reportLocked(ctx context.Context, force bool) {
+	if p.status.ID == "" || (!force && time.Since(p.lastReport) < progressReportInterval) {
+		return
+	}
+	p.lastReport = time.Now()
+	if err := p.s.registry.UpdateImport(ctx, &p.status); err != nil {
+		log.Printf("Warning: failed to record progress of import %s: %v", p.status.ID, err)
+	}
+}
@@ -0,0 +1,77 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+)
+
+// SetSeedVerifier makes imports verify seed files against their detached <file>.sha256 checksum and <file>.minisig
+// signature files before applying them
+func (s *Service) SetSeedVerifier(verifier *seed.Verifier) {
+	s.seedVerifier = verifier
+}
+
+// verifySeedData verifies seed data read from a file path or URL against its detached checksum and signature files
+func (s *Service) verifySeedData(ctx context.Context, path string, data []byte) error {
+	verifier := s.seedVerifier
+	if verifier == nil {
+		return nil
+	}
+
+	checksum, err := s.readDetachedFile(ctx, path+seed.ChecksumSuffix)
+	if err != nil {
+		return err
+	}
+	var signature []byte
+	if verifier.PublicKey != nil {
+		if signature, err = s.readDetachedFile(ctx, path+seed.SignatureSuffix); err != nil {
+			return err
+		}
+	}
+	if err := verifier.Verify(path, data, checksum, signature); err != nil {
+		return err
+	}
+	if checksum != nil {
+		log.Printf("Verified seed data %s", path)
+	}
+	return nil
+}
+
+// requireVerifiableSource rejects sources that have no seed file to verify, such as registry APIs and package
+// searches, when verification is required
+func (s *Service) requireVerifiableSource(path string) error {
+	if s.seedVerifier != nil && (s.seedVerifier.Require || s.seedVerifier.PublicKey != nil) {
+		return fmt.Errorf("%w: %s is not a seed file and cannot be verified", seed.ErrUnverified, path)
+	}
+	return nil
+}
+
+// readDetachedFile reads a checksum or signature file next to a seed file, returning nil if there is none
+func (s *Service) readDetachedFile(ctx context.Context, path string) ([]byte, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		// Servers answer missing files with 404, or 403 for object stores that hide which objects exist
+		data, err := s.fetchFromHTTP(ctx, path)
+		if err != nil && (strings.HasSuffix(err.Error(), "status: 404") || strings.HasSuffix(err.Error(), "status: 403")) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return data, nil
+}
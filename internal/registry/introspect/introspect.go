@@ -0,0 +1,238 @@
+// Package introspect connects to an MCP server, performs the initialize handshake and lists the tools,
+// resources and prompts it exposes. Remote servers are connected to directly; stdio packages are started
+// in a short-lived, locked-down container that is removed once the inventory has been read.
+package introspect
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/internal/version"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// Sources of an introspection
+const (
+	SourceRemote  = "remote"
+	SourcePackage = "package"
+)
+
+// DefaultTimeout bounds a single introspection, including pulling the image of a package
+const DefaultTimeout = time.Minute
+
+// Limits applied to the container of an introspected package. The container keeps network access
+// because package runners such as npx and uvx download the server when they start.
+const (
+	sandboxMemory = "512m"
+	sandboxCPUs   = "1"
+	sandboxPids   = "256"
+)
+
+// ErrUnsupported is returned for servers that cannot be introspected, such as packages using an HTTP transport
+var ErrUnsupported = errors.New("server cannot be introspected")
+
+// Introspector reads the capabilities of MCP servers
+type Introspector struct {
+	// Timeout bounds a single introspection; zero uses DefaultTimeout
+	Timeout time.Duration
+	// DockerBinary runs package containers; empty uses "docker" from PATH
+	DockerBinary string
+}
+
+// Introspect connects to the first remote of the server, or starts its first package when it has no remote,
+// and returns the capabilities it reports. Servers whose required configuration has no default cannot be
+// started and return an error.
+func (i *Introspector) Introspect(ctx context.Context, server *apiv0.ServerJSON) (*models.ServerCapabilities, error) {
+	timeout := i.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		transport mcp.Transport
+		source    string
+	)
+	switch {
+	case len(server.Remotes) > 0:
+		transport, source = remoteTransport(server.Remotes[0].Type, server.Remotes[0].URL), SourceRemote
+	case len(server.Packages) > 0:
+		t, cleanup, err := i.packageTransport(ctx, server)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		transport, source = t, SourcePackage
+	default:
+		return nil, fmt.Errorf("%w: %s has no remotes or packages", ErrUnsupported, server.Name)
+	}
+
+	caps, err := Collect(ctx, transport)
+	if err != nil {
+		return nil, err
+	}
+	caps.ServerName = server.Name
+	caps.Version = server.Version
+	caps.Source = source
+	return caps, nil
+}
+
+// Collect performs the MCP handshake over transport and lists everything the server advertises
+func Collect(ctx context.Context, transport mcp.Transport) (*models.ServerCapabilities, error) {
+	client := mcp.NewClient(&mcp.Implementation{Name: "agentregistry-introspect", Version: version.Version}, nil)
+	session, err := client.Connect(ctx, transport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize MCP session: %w", err)
+	}
+	defer session.Close()
+	return ListCapabilities(ctx, session)
+}
+
+// ListCapabilities lists everything the server of an initialized session advertises
+func ListCapabilities(ctx context.Context, session *mcp.ClientSession) (*models.ServerCapabilities, error) {
+	caps := &models.ServerCapabilities{
+		Tools:          []models.ToolInfo{},
+		Resources:      []models.ResourceInfo{},
+		Prompts:        []models.PromptInfo{},
+		IntrospectedAt: time.Now(),
+	}
+	result := session.InitializeResult()
+	if result == nil || result.Capabilities == nil {
+		return caps, nil
+	}
+	caps.ProtocolVersion = result.ProtocolVersion
+
+	if result.Capabilities.Tools != nil {
+		for tool, err := range session.Tools(ctx, nil) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to list tools: %w", err)
+			}
+			caps.Tools = append(caps.Tools, models.ToolInfo{
+				Name:        tool.Name,
+				Title:       tool.Title,
+				Description: tool.Description,
+				InputSchema: tool.InputSchema,
+			})
+		}
+	}
+	if result.Capabilities.Resources != nil {
+		for resource, err := range session.Resources(ctx, nil) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to list resources: %w", err)
+			}
+			caps.Resources = append(caps.Resources, models.ResourceInfo{
+				URI:         resource.URI,
+				Name:        resource.Name,
+				Description: resource.Description,
+				MIMEType:    resource.MIMEType,
+			})
+		}
+	}
+	if result.Capabilities.Prompts != nil {
+		for prompt, err := range session.Prompts(ctx, nil) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to list prompts: %w", err)
+			}
+			info := models.PromptInfo{Name: prompt.Name, Description: prompt.Description}
+			for _, arg := range prompt.Arguments {
+				info.Arguments = append(info.Arguments, models.PromptArgument{
+					Name:        arg.Name,
+					Description: arg.Description,
+					Required:    arg.Required,
+				})
+			}
+			caps.Prompts = append(caps.Prompts, info)
+		}
+	}
+
+	sort.Slice(caps.Tools, func(a, b int) bool { return caps.Tools[a].Name < caps.Tools[b].Name })
+	sort.Slice(caps.Resources, func(a, b int) bool { return caps.Resources[a].URI < caps.Resources[b].URI })
+	sort.Slice(caps.Prompts, func(a, b int) bool { return caps.Prompts[a].Name < caps.Prompts[b].Name })
+	return caps, nil
+}
+
+func remoteTransport(transportType, url string) mcp.Transport {
+	if transportType == "sse" {
+		return &mcp.SSEClientTransport{Endpoint: url}
+	}
+	return &mcp.StreamableClientTransport{Endpoint: url, MaxRetries: -1}
+}
+
+// packageTransport starts the first package of the server in a container, the same way the local runtime
+// would run it with no deployer configuration. The returned cleanup removes the container.
+func (i *Introspector) packageTransport(ctx context.Context, server *apiv0.ServerJSON) (mcp.Transport, func(), error) {
+	translated, err := registry.NewTranslator().TranslateMCPServer(ctx, &registry.MCPServerRunRequest{
+		RegistryServer: server,
+		EnvValues:      map[string]string{},
+		ArgValues:      map[string]string{},
+		HeaderValues:   map[string]string{},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve package: %w", err)
+	}
+	if translated.Local == nil || translated.Local.TransportType != api.TransportTypeStdio {
+		return nil, nil, fmt.Errorf("%w: only stdio packages can be started for introspection", ErrUnsupported)
+	}
+
+	docker := i.DockerBinary
+	if docker == "" {
+		docker = "docker"
+	}
+	name, err := containerName()
+	if err != nil {
+		return nil, nil, err
+	}
+	cmd := exec.CommandContext(ctx, docker, sandboxArgs(name, translated.Local.Deployment)...)
+	cleanup := func() {
+		// The container stops once its stdin closes; removing it covers servers that ignore EOF
+		_ = exec.Command(docker, "rm", "-f", name).Run()
+	}
+	return &mcp.CommandTransport{Command: cmd, TerminateDuration: 2 * time.Second}, cleanup, nil
+}
+
+// sandboxArgs builds the docker run arguments for an introspection container: no capabilities, no privilege
+// escalation, bounded memory, CPU and process count, and removed when it exits
+func sandboxArgs(name string, deployment api.MCPServerDeployment) []string {
+	args := []string{
+		"run", "--rm", "-i",
+		"--name", name,
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"--memory", sandboxMemory,
+		"--cpus", sandboxCPUs,
+		"--pids-limit", sandboxPids,
+	}
+	keys := make([]string, 0, len(deployment.Env))
+	for k := range deployment.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+deployment.Env[k])
+	}
+	args = append(args, deployment.Image)
+	if deployment.Cmd != "" {
+		args = append(args, deployment.Cmd)
+	}
+	return append(args, deployment.Args...)
+}
+
+func containerName() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate container name: %w", err)
+	}
+	return "arctl-introspect-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,81 @@
+package introspect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+)
+
+func TestCollect(t *testing.T) {
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	noop := func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{}, nil
+	}
+	server.AddTool(&mcp.Tool{Name: "search", Description: "Search things", InputSchema: map[string]any{"type": "object"}}, noop)
+	server.AddTool(&mcp.Tool{Name: "fetch", InputSchema: map[string]any{"type": "object"}}, noop)
+	server.AddResource(&mcp.Resource{URI: "file:///readme", Name: "readme", MIMEType: "text/plain"},
+		func(context.Context, *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{}, nil
+		})
+	server.AddPrompt(&mcp.Prompt{Name: "summarize", Arguments: []*mcp.PromptArgument{{Name: "text", Required: true}}},
+		func(context.Context, *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return &mcp.GetPromptResult{}, nil
+		})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	session, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	caps, err := Collect(ctx, clientTransport)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, caps.ProtocolVersion)
+	require.Len(t, caps.Tools, 2)
+	assert.Equal(t, "fetch", caps.Tools[0].Name)
+	assert.Equal(t, "search", caps.Tools[1].Name)
+	assert.Equal(t, "Search things", caps.Tools[1].Description)
+	assert.NotNil(t, caps.Tools[1].InputSchema)
+	require.Len(t, caps.Resources, 1)
+	assert.Equal(t, "file:///readme", caps.Resources[0].URI)
+	assert.Equal(t, "text/plain", caps.Resources[0].MIMEType)
+	require.Len(t, caps.Prompts, 1)
+	assert.Equal(t, "summarize", caps.Prompts[0].Name)
+	assert.Equal(t, "text", caps.Prompts[0].Arguments[0].Name)
+	assert.True(t, caps.Prompts[0].Arguments[0].Required)
+}
+
+func TestSandboxArgs(t *testing.T) {
+	args := sandboxArgs("arctl-introspect-test", api.MCPServerDeployment{
+		Image: "node:24-alpine",
+		Cmd:   "npx",
+		Args:  []string{"-y", "@example/server"},
+		Env:   map[string]string{"B": "2", "A": "1"},
+	})
+
+	assert.Equal(t, []string{
+		"run", "--rm", "-i",
+		"--name", "arctl-introspect-test",
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"--memory", sandboxMemory,
+		"--cpus", sandboxCPUs,
+		"--pids-limit", sandboxPids,
+		"-e", "A=1",
+		"-e", "B=2",
+		"node:24-alpine", "npx", "-y", "@example/server",
+	}, args)
+}
+
+func TestIntrospectUnsupported(t *testing.T) {
+	i := &Introspector{}
+	_, err := i.Introspect(context.Background(), &apiv0.ServerJSON{Name: "io.example/empty", Version: "1.0.0"})
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
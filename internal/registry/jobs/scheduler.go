@@ -0,0 +1,310 @@
+// Package jobs runs the registry's named background jobs (reconciliation, seed imports, scans, ...)
+// and tracks their last run and next scheduled run so operators can inspect and trigger them.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+var (
+	// ErrJobNotFound is returned for names that do not match a registered job
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobRunning is returned when a job is triggered while a run is still in progress
+	ErrJobRunning = errors.New("job is already running")
+	// ErrSchedulerStopped is returned when a job is triggered after the scheduler began shutting down
+	ErrSchedulerStopped = errors.New("scheduler is shutting down")
+)
+
+// Job is a named unit of background work
+type Job struct {
+	Name        string
+	Description string
+	// Interval runs the job periodically; zero means the job only runs at startup or when triggered
+	Interval time.Duration
+	// RunOnStart runs the job once as soon as it is registered
+	RunOnStart bool
+	// Timeout bounds a single run; zero means no timeout
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+type jobState struct {
+	job     Job
+	running bool
+	lastRun *models.JobRun
+	nextRun *time.Time
+	runs    int
+	fails   int
+}
+
+// Scheduler runs registered jobs on their intervals and on demand. At most one run of a job is in progress at a time.
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wrap   func(context.Context) context.Context
+	// stopping is closed when the scheduler stops starting runs
+	stopping chan struct{}
+	stopOnce sync.Once
+
+	mu   sync.Mutex
+	jobs map[string]*jobState
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler. wrap, if non-nil, prepares the context of every run (e.g. to attach a system identity).
+func NewScheduler(wrap func(context.Context) context.Context) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		ctx:      ctx,
+		cancel:   cancel,
+		wrap:     wrap,
+		stopping: make(chan struct{}),
+		jobs:     map[string]*jobState{},
+	}
+}
+
+// Register adds a job and starts its schedule. Registering a name twice is an error.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return fmt.Errorf("job name and run function are required")
+	}
+
+	s.mu.Lock()
+	if s.stopped() {
+		s.mu.Unlock()
+		return ErrSchedulerStopped
+	}
+	if _, exists := s.jobs[job.Name]; exists {
+		s.mu.Unlock()
+		return fmt.Errorf("job %q is already registered", job.Name)
+	}
+	state := &jobState{job: job}
+	if job.Interval > 0 {
+		next := time.Now().Add(job.Interval)
+		state.nextRun = &next
+		s.wg.Add(1)
+		go s.loop(job.Name, job.Interval)
+	}
+	s.jobs[job.Name] = state
+	s.mu.Unlock()
+
+	if job.RunOnStart {
+		_, _ = s.start(job.Name, models.JobTriggerStartup)
+	}
+	return nil
+}
+
+// Trigger starts a run of the named job in the background and returns its status
+func (s *Scheduler) Trigger(name string) (*models.JobStatus, error) {
+	return s.start(name, models.JobTriggerManual)
+}
+
+// RunOnce runs a one-off job in the background without registering it, so it does not show up in List but is still
+// drained and cancelled with the other jobs on shutdown. The returned function cancels the run.
+func (s *Scheduler) RunOnce(job Job) (context.CancelFunc, error) {
+	if job.Run == nil {
+		return nil, fmt.Errorf("job run function is required")
+	}
+
+	s.mu.Lock()
+	if s.stopped() {
+		s.mu.Unlock()
+		return nil, ErrSchedulerStopped
+	}
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		started := time.Now()
+		if err := s.execute(ctx, job); err != nil {
+			log.Printf("Job %s failed after %s: %v", job.Name, time.Since(started).Round(time.Millisecond), err)
+		}
+	}()
+	return cancel, nil
+}
+
+// List returns the status of all jobs sorted by name
+func (s *Scheduler) List() []models.JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]models.JobStatus, 0, len(s.jobs))
+	for _, state := range s.jobs {
+		statuses = append(statuses, state.status())
+	}
+	slices.SortFunc(statuses, func(a, b models.JobStatus) int { return strings.Compare(a.Name, b.Name) })
+	return statuses
+}
+
+// Get returns the status of the named job
+func (s *Scheduler) Get(name string) (*models.JobStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.jobs[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	status := state.status()
+	return &status, nil
+}
+
+// Stop cancels running jobs and stops all schedules, waiting for them to exit
+func (s *Scheduler) Stop() {
+	s.stopScheduling()
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Drain stops all schedules and refuses new runs, then waits for the runs in progress to finish. Runs still in
+// progress when ctx is done are cancelled and ctx's error is returned.
+func (s *Scheduler) Drain(ctx context.Context) error {
+	s.stopScheduling()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// stopScheduling refuses new runs and ends the schedules. It holds the lock so no run is added to the wait group
+// after the scheduler started waiting for it.
+func (s *Scheduler) stopScheduling() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopOnce.Do(func() { close(s.stopping) })
+}
+
+// stopped must be called with the scheduler lock held
+func (s *Scheduler) stopped() bool {
+	select {
+	case <-s.stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Scheduler) loop(name string, interval time.Duration) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			next := time.Now().Add(interval)
+			s.jobs[name].nextRun = &next
+			s.mu.Unlock()
+			// A run still in progress from the previous tick (or a manual trigger) skips this tick
+			if _, err := s.start(name, models.JobTriggerSchedule); err != nil && !errors.Is(err, ErrJobRunning) {
+				log.Printf("Failed to start job %s: %v", name, err)
+			}
+		}
+	}
+}
+
+// start marks a job as running and executes it in a goroutine
+func (s *Scheduler) start(name, trigger string) (*models.JobStatus, error) {
+	s.mu.Lock()
+	state, ok := s.jobs[name]
+	if !ok {
+		s.mu.Unlock()
+		return nil, ErrJobNotFound
+	}
+	if state.running {
+		s.mu.Unlock()
+		return nil, ErrJobRunning
+	}
+	if s.stopped() {
+		s.mu.Unlock()
+		return nil, ErrSchedulerStopped
+	}
+	run := &models.JobRun{Trigger: trigger, Status: models.JobRunStatusRunning, StartedAt: time.Now()}
+	state.running = true
+	state.lastRun = run
+	state.runs++
+	status := state.status()
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	go func() {
+		defer s.wg.Done()
+		err := s.execute(s.ctx, state.job)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		finished := time.Now()
+		run.FinishedAt = &finished
+		state.running = false
+		if err != nil {
+			run.Status = models.JobRunStatusFailed
+			run.Error = err.Error()
+			state.fails++
+			log.Printf("Job %s failed after %s: %v", name, finished.Sub(run.StartedAt).Round(time.Millisecond), err)
+			return
+		}
+		run.Status = models.JobRunStatusSucceeded
+	}()
+	return &status, nil
+}
+
+func (s *Scheduler) execute(ctx context.Context, job Job) (err error) {
+	if s.wrap != nil {
+		ctx = s.wrap(ctx)
+	}
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+// status must be called with the scheduler lock held
+func (j *jobState) status() models.JobStatus {
+	status := models.JobStatus{
+		Name:            j.job.Name,
+		Description:     j.job.Description,
+		IntervalSeconds: int64(j.job.Interval / time.Second),
+		Running:         j.running,
+		NextRunAt:       j.nextRun,
+		RunCount:        j.runs,
+		FailureCount:    j.fails,
+	}
+	if j.lastRun != nil {
+		run := *j.lastRun
+		status.LastRun = &run
+	}
+	return status
+}
@@ -0,0 +1,221 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitForIdle(t *testing.T, s *Scheduler, name string) models.JobStatus {
+	t.Helper()
+	var status *models.JobStatus
+	require.Eventually(t, func() bool {
+		var err error
+		status, err = s.Get(name)
+		return err == nil && !status.Running && status.LastRun != nil
+	}, 2*time.Second, 5*time.Millisecond)
+	return *status
+}
+
+func TestSchedulerTrigger(t *testing.T) {
+	s := NewScheduler(nil)
+	defer s.Stop()
+
+	release := make(chan struct{})
+	var runs atomic.Int32
+	require.NoError(t, s.Register(Job{
+		Name: "sync",
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			<-release
+			return nil
+		},
+	}))
+
+	status, err := s.Trigger("sync")
+	require.NoError(t, err)
+	assert.True(t, status.Running)
+	assert.Equal(t, models.JobTriggerManual, status.LastRun.Trigger)
+
+	// Only one run at a time
+	_, err = s.Trigger("sync")
+	assert.ErrorIs(t, err, ErrJobRunning)
+
+	close(release)
+	final := waitForIdle(t, s, "sync")
+	assert.Equal(t, models.JobRunStatusSucceeded, final.LastRun.Status)
+	assert.NotNil(t, final.LastRun.FinishedAt)
+	assert.Equal(t, 1, final.RunCount)
+	assert.Equal(t, int32(1), runs.Load())
+
+	_, err = s.Trigger("missing")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestSchedulerRecordsFailures(t *testing.T) {
+	s := NewScheduler(nil)
+	defer s.Stop()
+
+	require.NoError(t, s.Register(Job{
+		Name:       "broken",
+		RunOnStart: true,
+		Run:        func(ctx context.Context) error { return errors.New("boom") },
+	}))
+
+	status := waitForIdle(t, s, "broken")
+	assert.Equal(t, models.JobRunStatusFailed, status.LastRun.Status)
+	assert.Equal(t, models.JobTriggerStartup, status.LastRun.Trigger)
+	assert.Equal(t, "boom", status.LastRun.Error)
+	assert.Equal(t, 1, status.FailureCount)
+
+	require.NoError(t, s.Register(Job{
+		Name:       "panics",
+		RunOnStart: true,
+		Run:        func(ctx context.Context) error { panic("unexpected") },
+	}))
+	status = waitForIdle(t, s, "panics")
+	assert.Contains(t, status.LastRun.Error, "panicked")
+}
+
+func TestSchedulerInterval(t *testing.T) {
+	s := NewScheduler(nil)
+
+	var runs atomic.Int32
+	require.NoError(t, s.Register(Job{
+		Name:     "tick",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	}))
+
+	status, err := s.Get("tick")
+	require.NoError(t, err)
+	assert.NotNil(t, status.NextRunAt)
+	assert.Nil(t, status.LastRun)
+
+	require.Eventually(t, func() bool { return runs.Load() >= 2 }, 2*time.Second, 5*time.Millisecond)
+	s.Stop()
+
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load(), "no runs after Stop")
+}
+
+func TestSchedulerRegisterValidation(t *testing.T) {
+	s := NewScheduler(nil)
+	defer s.Stop()
+
+	noop := func(ctx context.Context) error { return nil }
+	require.NoError(t, s.Register(Job{Name: "b", Run: noop}))
+	require.NoError(t, s.Register(Job{Name: "a", Run: noop}))
+	assert.Error(t, s.Register(Job{Name: "a", Run: noop}))
+	assert.Error(t, s.Register(Job{Name: "c"}))
+
+	list := s.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, "a", list[0].Name)
+	assert.Equal(t, "b", list[1].Name)
+}
+
+func TestSchedulerWrapsContext(t *testing.T) {
+	type key struct{}
+	s := NewScheduler(func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, key{}, "system")
+	})
+	defer s.Stop()
+
+	got := make(chan any, 1)
+	require.NoError(t, s.Register(Job{
+		Name:       "ctx",
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			got <- ctx.Value(key{})
+			return nil
+		},
+	}))
+	assert.Equal(t, "system", <-got)
+}
+
+func TestSchedulerDrain(t *testing.T) {
+	s := NewScheduler(nil)
+
+	release := make(chan struct{})
+	finished := make(chan error, 1)
+	require.NoError(t, s.Register(Job{
+		Name:       "publish",
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			<-release
+			finished <- ctx.Err()
+			return nil
+		},
+	}))
+	require.Eventually(t, func() bool {
+		status, err := s.Get("publish")
+		return err == nil && status.Running
+	}, time.Second, 5*time.Millisecond)
+
+	drained := make(chan error, 1)
+	go func() { drained <- s.Drain(context.Background()) }()
+
+	// The run in progress finishes uncancelled
+	close(release)
+	require.NoError(t, <-drained)
+	assert.NoError(t, <-finished)
+
+	// New runs are refused
+	_, err := s.Trigger("publish")
+	assert.ErrorIs(t, err, ErrSchedulerStopped)
+	assert.ErrorIs(t, s.Register(Job{Name: "late", Run: func(ctx context.Context) error { return nil }}), ErrSchedulerStopped)
+}
+
+func TestSchedulerDrainTimeout(t *testing.T) {
+	s := NewScheduler(nil)
+	require.NoError(t, s.Register(Job{
+		Name:       "stuck",
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.Drain(ctx), context.DeadlineExceeded)
+
+	status, err := s.Get("stuck")
+	require.NoError(t, err)
+	assert.False(t, status.Running)
+}
+
+func TestSchedulerRunOnce(t *testing.T) {
+	s := NewScheduler(nil)
+
+	cancelled := make(chan error, 1)
+	cancel, err := s.RunOnce(Job{
+		Name: "import-1",
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return ctx.Err()
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, s.List())
+
+	cancel()
+	assert.ErrorIs(t, <-cancelled, context.Canceled)
+	require.NoError(t, s.Drain(context.Background()))
+
+	_, err = s.RunOnce(Job{Name: "import-2", Run: func(context.Context) error { return nil }})
+	assert.ErrorIs(t, err, ErrSchedulerStopped)
+}
@@ -0,0 +1,246 @@
+// Package namespace verifies that a registry user owns the namespace (the reverse-DNS prefix of an artifact name,
+// e.g. "io.github.myorg" in "io.github.myorg/server") they publish under. GitHub namespaces are verified through
+// organization membership, all other namespaces through a DNS TXT record on the domain they name.
+package namespace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Verification methods
+const (
+	MethodGitHub = "github"
+	MethodDNS    = "dns"
+)
+
+const (
+	// githubNamespacePrefix prefixes namespaces owned by GitHub users and organizations
+	githubNamespacePrefix = "io.github."
+	// dnsRecordLabel is the label under a domain whose TXT records hold verification challenges
+	dnsRecordLabel = "_agentregistry"
+	// dnsRecordPrefix prefixes the value of a verification TXT record
+	dnsRecordPrefix = "agentregistry-verification="
+)
+
+var (
+	// ErrVerificationFailed is returned when the caller could not be shown to own a namespace
+	ErrVerificationFailed = errors.New("namespace verification failed")
+	// ErrNotOwned is returned when the caller publishes under a namespace they have not verified
+	ErrNotOwned = errors.New("namespace not owned")
+)
+
+var (
+	namespaceRegex  = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*(\.[a-zA-Z0-9][a-zA-Z0-9-]*)+$`)
+	githubNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+)
+
+// Of returns the namespace of an artifact name ("com.example/server" -> "com.example"), or "" for names without one
+func Of(name string) string {
+	namespace, _, found := strings.Cut(name, "/")
+	if !found {
+		return ""
+	}
+	return namespace
+}
+
+// Validate checks that a namespace is a reverse-DNS name with at least two labels
+func Validate(namespace string) error {
+	if !namespaceRegex.MatchString(namespace) {
+		return fmt.Errorf("namespace %q must be a reverse-DNS name such as com.example or io.github.myorg", namespace)
+	}
+	return nil
+}
+
+// GitHubOwner returns the GitHub user or organization owning a namespace ("io.github.myorg" -> "myorg")
+func GitHubOwner(namespace string) (string, bool) {
+	owner, found := strings.CutPrefix(namespace, githubNamespacePrefix)
+	if !found || !githubNameRegex.MatchString(owner) {
+		return "", false
+	}
+	return owner, true
+}
+
+// Domain returns the domain a namespace names ("com.example.api" -> "api.example.com")
+func Domain(namespace string) string {
+	labels := strings.Split(namespace, ".")
+	slices.Reverse(labels)
+	return strings.ToLower(strings.Join(labels, "."))
+}
+
+// Challenge returns the DNS TXT record that proves ownership of a namespace for a subject. The value is derived
+// from both, so a record published for one user cannot be used to verify the namespace for another.
+func Challenge(namespace, subject string) (recordName, recordValue string) {
+	sum := sha256.Sum256([]byte(namespace + "\n" + subject))
+	return dnsRecordLabel + "." + Domain(namespace), dnsRecordPrefix + hex.EncodeToString(sum[:16])
+}
+
+// DNSResolver looks up DNS TXT records
+type DNSResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Credentials identify the caller to the verification source
+type Credentials struct {
+	// Subject is the registry identity the namespace is verified for
+	Subject string
+	// GitHubLogin is the caller's GitHub login, when they signed in with GitHub
+	GitHubLogin string
+	// GitHubToken is a GitHub token of the caller, used to check private organization membership
+	GitHubToken string
+}
+
+// Verifier checks namespace ownership against GitHub and DNS
+type Verifier struct {
+	resolver      DNSResolver
+	githubBaseURL string
+	httpClient    *http.Client
+}
+
+// NewVerifier creates a Verifier that queries the public GitHub API and the system DNS resolver
+func NewVerifier() *Verifier {
+	return &Verifier{
+		resolver:      &net.Resolver{},
+		githubBaseURL: "https://api.github.com",
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SetResolver sets a custom DNS resolver (used for testing)
+func (v *Verifier) SetResolver(resolver DNSResolver) {
+	v.resolver = resolver
+}
+
+// SetGitHubBaseURL sets the GitHub API base URL (used for testing)
+func (v *Verifier) SetGitHubBaseURL(baseURL string) {
+	v.githubBaseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// Verify checks that the caller owns a namespace and returns the method that proved it. Failures to show ownership
+// wrap ErrVerificationFailed; other errors mean the verification source could not be reached.
+func (v *Verifier) Verify(ctx context.Context, namespace string, creds Credentials) (string, error) {
+	if err := Validate(namespace); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrVerificationFailed, err)
+	}
+	if creds.Subject == "" {
+		return "", fmt.Errorf("%w: an authenticated subject is required", ErrVerificationFailed)
+	}
+
+	if owner, ok := GitHubOwner(namespace); ok {
+		return MethodGitHub, v.verifyGitHub(ctx, owner, creds)
+	}
+	if strings.HasPrefix(namespace, githubNamespacePrefix) {
+		return "", fmt.Errorf("%w: %q is not a valid GitHub namespace", ErrVerificationFailed, namespace)
+	}
+	return MethodDNS, v.verifyDNS(ctx, namespace, creds.Subject)
+}
+
+// verifyDNS looks for the subject's challenge among the TXT records of the namespace's domain
+func (v *Verifier) verifyDNS(ctx context.Context, namespace, subject string) error {
+	recordName, recordValue := Challenge(namespace, subject)
+	records, err := v.resolver.LookupTXT(ctx, recordName)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return fmt.Errorf("%w: no TXT record found at %s", ErrVerificationFailed, recordName)
+		}
+		return fmt.Errorf("failed to look up TXT records for %s: %w", recordName, err)
+	}
+	if slices.Contains(records, recordValue) {
+		return nil
+	}
+	return fmt.Errorf("%w: TXT record %q not found at %s", ErrVerificationFailed, recordValue, recordName)
+}
+
+// verifyGitHub checks that the caller is the GitHub owner of a namespace or an active member of the organization.
+// With a GitHub token the caller's own memberships are checked, private ones included; without one only public
+// membership of the login they signed in with can be seen.
+func (v *Verifier) verifyGitHub(ctx context.Context, owner string, creds Credentials) error {
+	if creds.GitHubToken != "" {
+		var user struct {
+			Login string `json:"login"`
+		}
+		if _, err := v.githubGet(ctx, "/user", creds.GitHubToken, &user); err != nil {
+			return err
+		}
+		if strings.EqualFold(user.Login, owner) {
+			return nil
+		}
+
+		var membership struct {
+			State string `json:"state"`
+		}
+		status, err := v.githubGet(ctx, "/user/memberships/orgs/"+url.PathEscape(owner), creds.GitHubToken, &membership)
+		if err != nil {
+			return err
+		}
+		if status == http.StatusOK && membership.State == "active" {
+			return nil
+		}
+		return fmt.Errorf("%w: GitHub user %s is not an active member of %s", ErrVerificationFailed, user.Login, owner)
+	}
+
+	if creds.GitHubLogin == "" {
+		return fmt.Errorf("%w: a GitHub token is required to verify GitHub namespaces", ErrVerificationFailed)
+	}
+	if strings.EqualFold(creds.GitHubLogin, owner) {
+		return nil
+	}
+	status, err := v.githubGet(ctx, "/orgs/"+url.PathEscape(owner)+"/public_members/"+url.PathEscape(creds.GitHubLogin), "", nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNoContent {
+		return nil
+	}
+	return fmt.Errorf("%w: GitHub user %s is not a public member of %s; provide a GitHub token to verify private membership",
+		ErrVerificationFailed, creds.GitHubLogin, owner)
+}
+
+// githubGet performs a GitHub API request. Not found and forbidden responses are returned as statuses for the
+// caller to interpret; other unexpected statuses are errors.
+func (v *Verifier) githubGet(ctx context.Context, path, token string, out any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.githubBaseURL+path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query GitHub: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return 0, fmt.Errorf("failed to decode GitHub response: %w", err)
+			}
+		}
+	case http.StatusNoContent, http.StatusNotFound, http.StatusForbidden:
+	case http.StatusUnauthorized:
+		return 0, fmt.Errorf("%w: GitHub rejected the token", ErrVerificationFailed)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, body)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,120 @@
+package namespace_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
+)
+
+type fakeResolver struct {
+	records map[string][]string
+}
+
+func (f *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := f.records[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestHelpers(t *testing.T) {
+	assert.Equal(t, "com.example", namespace.Of("com.example/server"))
+	assert.Equal(t, "", namespace.Of("server"))
+	assert.Equal(t, "api.example.com", namespace.Domain("com.example.api"))
+
+	owner, ok := namespace.GitHubOwner("io.github.myorg")
+	assert.True(t, ok)
+	assert.Equal(t, "myorg", owner)
+	_, ok = namespace.GitHubOwner("com.example")
+	assert.False(t, ok)
+
+	assert.NoError(t, namespace.Validate("com.example"))
+	assert.Error(t, namespace.Validate("example"))
+	assert.Error(t, namespace.Validate("com..example"))
+
+	name, value := namespace.Challenge("com.example", "alice")
+	assert.Equal(t, "_agentregistry.example.com", name)
+	_, otherValue := namespace.Challenge("com.example", "bob")
+	assert.NotEqual(t, value, otherValue)
+}
+
+func TestVerifyDNS(t *testing.T) {
+	ctx := context.Background()
+	name, value := namespace.Challenge("com.example", "alice")
+	resolver := &fakeResolver{records: map[string][]string{name: {"v=spf1 -all", value}}}
+	verifier := namespace.NewVerifier()
+	verifier.SetResolver(resolver)
+
+	method, err := verifier.Verify(ctx, "com.example", namespace.Credentials{Subject: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, namespace.MethodDNS, method)
+
+	// The record proves ownership for alice only
+	_, err = verifier.Verify(ctx, "com.example", namespace.Credentials{Subject: "bob"})
+	assert.ErrorIs(t, err, namespace.ErrVerificationFailed)
+
+	_, err = verifier.Verify(ctx, "org.other", namespace.Credentials{Subject: "alice"})
+	assert.ErrorIs(t, err, namespace.ErrVerificationFailed)
+}
+
+func TestVerifyGitHub(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			if r.Header.Get("Authorization") != "Bearer good-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"login":"alice"}`))
+		case "/user/memberships/orgs/private-org":
+			_, _ = w.Write([]byte(`{"state":"active"}`))
+		case "/user/memberships/orgs/pending-org":
+			_, _ = w.Write([]byte(`{"state":"pending"}`))
+		case "/orgs/public-org/public_members/alice":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	verifier := namespace.NewVerifier()
+	verifier.SetGitHubBaseURL(server.URL)
+
+	tests := []struct {
+		name      string
+		namespace string
+		creds     namespace.Credentials
+		wantErr   bool
+	}{
+		{"own user namespace with token", "io.github.alice", namespace.Credentials{Subject: "alice", GitHubToken: "good-token"}, false},
+		{"private org member with token", "io.github.private-org", namespace.Credentials{Subject: "alice", GitHubToken: "good-token"}, false},
+		{"pending org member with token", "io.github.pending-org", namespace.Credentials{Subject: "alice", GitHubToken: "good-token"}, true},
+		{"non member with token", "io.github.other-org", namespace.Credentials{Subject: "alice", GitHubToken: "good-token"}, true},
+		{"rejected token", "io.github.alice", namespace.Credentials{Subject: "alice", GitHubToken: "bad-token"}, true},
+		{"own user namespace from login", "io.github.alice", namespace.Credentials{Subject: "alice", GitHubLogin: "alice"}, false},
+		{"public org member from login", "io.github.public-org", namespace.Credentials{Subject: "alice", GitHubLogin: "alice"}, false},
+		{"private org member from login", "io.github.private-org", namespace.Credentials{Subject: "alice", GitHubLogin: "alice"}, true},
+		{"no GitHub identity", "io.github.alice", namespace.Credentials{Subject: "alice"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, err := verifier.Verify(ctx, tt.namespace, tt.creds)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, namespace.ErrVerificationFailed)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, namespace.MethodGitHub, method)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Package objectstore stores flat named files in a local directory or a cloud bucket. Backups and content-addressed
+// blobs are kept with it.
+package objectstore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when an object does not exist in the storage location
+var ErrNotFound = errors.New("object not found")
+
+// Object is a file in a storage location
+type Object struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Storage stores files by name. Names are flat: implementations map them to a file or object under their location.
+type Storage interface {
+	Put(ctx context.Context, name string, r io.Reader) error
+	// Get returns the content of an object; the caller must close it
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	List(ctx context.Context) ([]Object, error)
+	Delete(ctx context.Context, name string) error
+	// String returns the location for display
+	String() string
+}
+
+// New returns the storage for a location: a local directory (path or file://), s3://bucket/prefix
+// using the aws CLI, or gs://bucket/prefix using the gcloud CLI. The cloud CLIs handle credentials.
+func New(location string) (Storage, error) {
+	location = strings.TrimSpace(location)
+	switch {
+	case location == "":
+		return nil, fmt.Errorf("storage location is required")
+	case strings.HasPrefix(location, "s3://"):
+		return newBucketStorage(location, awsCLI{})
+	case strings.HasPrefix(location, "gs://"):
+		return newBucketStorage(location, gcloudCLI{})
+	case strings.Contains(location, "://") && !strings.HasPrefix(location, "file://"):
+		return nil, fmt.Errorf("unsupported storage location %q (expected a directory, file://, s3:// or gs://)", location)
+	}
+	return &localStorage{dir: strings.TrimPrefix(location, "file://")}, nil
+}
+
+// localStorage keeps objects in a directory
+type localStorage struct {
+	dir string
+}
+
+func (l *localStorage) String() string { return l.dir }
+
+func (l *localStorage) Put(_ context.Context, name string, r io.Reader) error {
+	if err := os.MkdirAll(l.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	// Write to a temporary file first so a failed upload never leaves a truncated file behind
+	tmp, err := os.CreateTemp(l.dir, "."+name+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return os.Rename(tmp.Name(), filepath.Join(l.dir, name))
+}
+
+func (l *localStorage) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(l.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return f, err
+}
+
+func (l *localStorage) List(_ context.Context) ([]Object, error) {
+	entries, err := os.ReadDir(l.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", l.dir, err)
+	}
+	var objects []Object
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		objects = append(objects, Object{Name: e.Name(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return objects, nil
+}
+
+func (l *localStorage) Delete(_ context.Context, name string) error {
+	err := os.Remove(filepath.Join(l.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return err
+}
+
+// bucketCLI builds the commands of an object storage CLI
+type bucketCLI interface {
+	name() string
+	put(url string) []string
+	get(url string) []string
+	list(prefix string) []string
+	remove(url string) []string
+	// parseList extracts the objects under prefix from the list command output
+	parseList(out []byte, prefix string) []Object
+}
+
+// bucketStorage keeps objects under a bucket prefix, shelling out to the provider's CLI
+type bucketStorage struct {
+	prefix string // always ends with "/"
+	cli    bucketCLI
+}
+
+func newBucketStorage(location string, cli bucketCLI) (*bucketStorage, error) {
+	scheme, rest, _ := strings.Cut(location, "://")
+	bucket, _, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("storage location %q has no bucket", location)
+	}
+	return &bucketStorage{prefix: scheme + "://" + strings.TrimSuffix(rest, "/") + "/", cli: cli}, nil
+}
+
+func (b *bucketStorage) String() string { return b.prefix }
+
+func (b *bucketStorage) Put(ctx context.Context, name string, r io.Reader) error {
+	_, err := b.run(ctx, r, b.cli.put(b.prefix+name))
+	return err
+}
+
+func (b *bucketStorage) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	// Download to a temporary file so a failed transfer is reported before the caller reads anything
+	tmp, err := os.CreateTemp("", "arctl-object-*")
+	if err != nil {
+		return nil, err
+	}
+	args := b.cli.get(b.prefix + name)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stderr bytes.Buffer
+	cmd.Stdout = tmp
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "404") || strings.Contains(strings.ToLower(msg), "not found") || strings.Contains(msg, "NoSuchKey") {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return nil, fmt.Errorf("%s failed: %v: %s", b.cli.name(), err, msg)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return nil, err
+	}
+	return &tempFile{File: tmp}, nil
+}
+
+func (b *bucketStorage) List(ctx context.Context) ([]Object, error) {
+	out, err := b.run(ctx, nil, b.cli.list(b.prefix))
+	if err != nil {
+		return nil, err
+	}
+	return b.cli.parseList(out, b.prefix), nil
+}
+
+func (b *bucketStorage) Delete(ctx context.Context, name string) error {
+	_, err := b.run(ctx, nil, b.cli.remove(b.prefix+name))
+	return err
+}
+
+func (b *bucketStorage) run(ctx context.Context, stdin io.Reader, args []string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %v: %s", b.cli.name(), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// tempFile removes the downloaded file when closed
+type tempFile struct {
+	*os.File
+}
+
+func (t *tempFile) Close() error {
+	err := t.File.Close()
+	_ = os.Remove(t.Name())
+	return err
+}
+
+// awsCLI drives "aws s3"
+type awsCLI struct{}
+
+func (awsCLI) name() string {
+	return "aws s3"
+}
+
+func (awsCLI) put(url string) []string {
+	return []string{"aws", "s3", "cp", "--only-show-errors", "-", url}
+}
+
+func (awsCLI) get(url string) []string {
+	return []string{"aws", "s3", "cp", "--only-show-errors", url, "-"}
+}
+
+func (awsCLI) list(prefix string) []string {
+	return []string{"aws", "s3", "ls", prefix}
+}
+
+func (awsCLI) remove(url string) []string {
+	return []string{"aws", "s3", "rm", "--only-show-errors", url}
+}
+
+// parseList reads lines of the form "2026-01-02 15:04:05      12345 name"; "PRE dir/" lines are skipped
+func (awsCLI) parseList(out []byte, _ string) []Object {
+	var objects []Object
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[0] == "PRE" {
+			continue
+		}
+		modTime, err := time.ParseInLocation("2006-01-02 15:04:05", fields[0]+" "+fields[1], time.Local)
+		if err != nil {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		objects = append(objects, Object{Name: strings.Join(fields[3:], " "), Size: size, ModTime: modTime})
+	}
+	return objects
+}
+
+// gcloudCLI drives "gcloud storage"
+type gcloudCLI struct{}
+
+func (gcloudCLI) name() string {
+	return "gcloud storage"
+}
+
+func (gcloudCLI) put(url string) []string {
+	return []string{"gcloud", "storage", "cp", "-", url}
+}
+
+func (gcloudCLI) get(url string) []string {
+	return []string{"gcloud", "storage", "cat", url}
+}
+
+func (gcloudCLI) list(prefix string) []string {
+	return []string{"gcloud", "storage", "ls", "-l", prefix}
+}
+
+func (gcloudCLI) remove(url string) []string {
+	return []string{"gcloud", "storage", "rm", url}
+}
+
+// parseList reads lines of the form "     12345  2026-01-02T15:04:05Z  gs://bucket/prefix/name"; the TOTAL line is skipped
+func (gcloudCLI) parseList(out []byte, prefix string) []Object {
+	var objects []Object
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || !strings.HasPrefix(fields[2], prefix) {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		modTime, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			continue
+		}
+		name := strings.TrimPrefix(fields[2], prefix)
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		objects = append(objects, Object{Name: path.Base(name), Size: size, ModTime: modTime})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+	return objects
+}
@@ -0,0 +1,45 @@
+package objectstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	store, err := New("s3://backups/registry/prod")
+	require.NoError(t, err)
+	assert.Equal(t, "s3://backups/registry/prod/", store.String())
+
+	store, err = New("gs://backups")
+	require.NoError(t, err)
+	assert.Equal(t, "gs://backups/", store.String())
+
+	_, err = New("s3://")
+	assert.Error(t, err)
+	_, err = New("azure://container")
+	assert.Error(t, err)
+
+	store, err = New("./backups")
+	require.NoError(t, err)
+	assert.Equal(t, "./backups", store.String())
+}
+
+func TestParseCLIListings(t *testing.T) {
+	aws := awsCLI{}.parseList([]byte(`                           PRE old/
+2026-10-16 12:00:00      12345 agentregistry-20261016T120000Z.tar.gz
+`), "s3://backups/")
+	require.Len(t, aws, 1)
+	assert.Equal(t, "agentregistry-20261016T120000Z.tar.gz", aws[0].Name)
+	assert.Equal(t, int64(12345), aws[0].Size)
+
+	gcs := gcloudCLI{}.parseList([]byte(`     12345  2026-10-16T12:00:00Z  gs://backups/registry/agentregistry-20261016T120000Z.dump
+                                 gs://backups/registry/old/
+TOTAL: 1 objects, 12345 bytes (12.06kiB)
+`), "gs://backups/registry/")
+	require.Len(t, gcs, 1)
+	assert.Equal(t, "agentregistry-20261016T120000Z.dump", gcs[0].Name)
+	assert.Equal(t, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), gcs[0].ModTime)
+}
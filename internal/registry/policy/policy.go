@@ -0,0 +1,255 @@
+// Package policy evaluates deployment admission policies: rules an MCP server or agent must satisfy
+// before the registry deploys it (signed servers, allowlisted image registries, secret env vars, ...).
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/vulnscan"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/secrets"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"go.yaml.in/yaml/v3"
+)
+
+// ErrDenied is returned when a deployment violates a policy with deny enforcement
+var ErrDenied = errors.New("denied by deployment policy")
+
+var policyNameRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Input describes the deployment being admitted
+type Input struct {
+	ResourceType string // "mcp" or "agent"
+	Name         string
+	Version      string
+	Server       *apiv0.ServerJSON // set for "mcp"
+	Agent        *models.AgentJSON // set for "agent"
+	Config       map[string]string
+	PreferRemote bool
+	// Signature is the server's publisher signature, nil when the server is unsigned
+	Signature *models.ServerSignature
+	// TrustedKey reports whether a signing key fingerprint is trusted; nil trusts any key
+	TrustedKey func(fingerprint string) bool
+}
+
+// Validate checks a policy definition and fills in its defaults
+func Validate(p *models.DeploymentPolicy) error {
+	if p == nil {
+		return fmt.Errorf("policy is required")
+	}
+	if !policyNameRe.MatchString(p.Name) {
+		return fmt.Errorf("invalid policy name %q: use lowercase letters, digits and dashes", p.Name)
+	}
+	switch p.Rule {
+	case models.PolicyRuleRequireSigned, models.PolicyRuleSecretEnvRefs:
+	case models.PolicyRuleImageAllowlist:
+		if len(p.Registries) == 0 {
+			return fmt.Errorf("policy %s: image-allowlist requires at least one registry", p.Name)
+		}
+		for _, r := range p.Registries {
+			if strings.TrimSpace(r) == "" {
+				return fmt.Errorf("policy %s: registries must not be empty", p.Name)
+			}
+		}
+	default:
+		return fmt.Errorf("policy %s: unknown rule %q (expected require-signed, image-allowlist or secret-env-refs)", p.Name, p.Rule)
+	}
+	switch p.Enforcement {
+	case "":
+		p.Enforcement = models.PolicyEnforcementDeny
+	case models.PolicyEnforcementDeny, models.PolicyEnforcementWarn:
+	default:
+		return fmt.Errorf("policy %s: unknown enforcement %q (expected deny or warn)", p.Name, p.Enforcement)
+	}
+	for _, rt := range p.ResourceTypes {
+		if rt != "mcp" && rt != "agent" {
+			return fmt.Errorf("policy %s: unknown resource type %q (expected mcp or agent)", p.Name, rt)
+		}
+	}
+	return nil
+}
+
+// LoadFile reads policies from a YAML or JSON file of the form {"policies": [...]}
+func LoadFile(path string) ([]models.DeploymentPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var file struct {
+		Policies []models.DeploymentPolicy `yaml:"policies"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+
+	seen := map[string]bool{}
+	for i := range file.Policies {
+		p := &file.Policies[i]
+		if err := Validate(p); err != nil {
+			return nil, fmt.Errorf("policy file %s: %w", path, err)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("policy file %s: duplicate policy %s", path, p.Name)
+		}
+		seen[p.Name] = true
+		p.Source = models.PolicySourceConfig
+	}
+	return file.Policies, nil
+}
+
+// Evaluate returns the violations of every applicable policy, in policy order
+func Evaluate(policies []models.DeploymentPolicy, in Input) []models.PolicyViolation {
+	var violations []models.PolicyViolation
+	for _, p := range policies {
+		if len(p.ResourceTypes) > 0 && !slices.Contains(p.ResourceTypes, in.ResourceType) {
+			continue
+		}
+		enforcement := p.Enforcement
+		if enforcement == "" {
+			enforcement = models.PolicyEnforcementDeny
+		}
+		for _, msg := range evaluateRule(p, in) {
+			violations = append(violations, models.PolicyViolation{
+				Policy:      p.Name,
+				Rule:        p.Rule,
+				Enforcement: enforcement,
+				Message:     msg,
+			})
+		}
+	}
+	return violations
+}
+
+// DeniedError wraps ErrDenied with the deny violations, or returns nil when there are none
+func DeniedError(violations []models.PolicyViolation) error {
+	var msgs []string
+	for _, v := range violations {
+		if v.Enforcement == models.PolicyEnforcementDeny {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", v.Policy, v.Message))
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrDenied, strings.Join(msgs, "; "))
+}
+
+func evaluateRule(p models.DeploymentPolicy, in Input) []string {
+	switch p.Rule {
+	case models.PolicyRuleRequireSigned:
+		// Only server versions can be signed
+		if in.ResourceType != "mcp" {
+			return nil
+		}
+		if in.Signature == nil {
+			return []string{fmt.Sprintf("server %s version %s is not signed", in.Name, in.Version)}
+		}
+		if in.TrustedKey != nil && !in.TrustedKey(in.Signature.Fingerprint) {
+			return []string{fmt.Sprintf("server %s version %s is signed with untrusted key %s", in.Name, in.Version, in.Signature.Fingerprint)}
+		}
+	case models.PolicyRuleImageAllowlist:
+		var msgs []string
+		for _, image := range deployedImages(in) {
+			if !imageAllowed(image, p.Registries) {
+				msgs = append(msgs, fmt.Sprintf("image %s is not from an allowed registry (%s)", image, strings.Join(p.Registries, ", ")))
+			}
+		}
+		return msgs
+	case models.PolicyRuleSecretEnvRefs:
+		var names []string
+		for _, name := range secretEnvNames(in.Server) {
+			if v := in.Config[name]; v != "" && !secrets.IsReference(v) {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			return []string{fmt.Sprintf("secret environment variables must reference a secret store (secretRef:// or env://) instead of being set inline: %s", strings.Join(names, ", "))}
+		}
+	}
+	return nil
+}
+
+// deployedImages returns the container images the runtime would run for the deployment
+func deployedImages(in Input) []string {
+	switch in.ResourceType {
+	case "mcp":
+		if in.Server == nil {
+			return nil
+		}
+		// Mirrors the runtime: remotes win when preferred or when there is no package to run
+		if len(in.Server.Remotes) > 0 && (in.PreferRemote || len(in.Server.Packages) == 0) {
+			return nil
+		}
+		return vulnscan.ImageRefs(in.Server)
+	case "agent":
+		if in.Agent == nil {
+			return nil
+		}
+		var images []string
+		if in.Agent.Image != "" {
+			images = append(images, in.Agent.Image)
+		}
+		for _, srv := range in.Agent.McpServers {
+			if srv.Image != "" {
+				images = append(images, srv.Image)
+			}
+		}
+		return images
+	}
+	return nil
+}
+
+// secretEnvNames returns the env vars the server's packages mark as secret
+func secretEnvNames(server *apiv0.ServerJSON) []string {
+	if server == nil {
+		return nil
+	}
+	var names []string
+	for _, pkg := range server.Packages {
+		for _, env := range pkg.EnvironmentVariables {
+			if env.IsSecret && !slices.Contains(names, env.Name) {
+				names = append(names, env.Name)
+			}
+		}
+	}
+	return names
+}
+
+// imageAllowed reports whether an image reference is under one of the allowed registries or repository prefixes
+func imageAllowed(image string, allowed []string) bool {
+	ref := normalizeImage(image)
+	for _, a := range allowed {
+		prefix := strings.TrimSuffix(normalizeImage(strings.TrimSpace(a)), "/")
+		if ref == prefix || strings.HasPrefix(ref, prefix+"/") || strings.HasPrefix(ref, prefix+":") || strings.HasPrefix(ref, prefix+"@") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeImage expands Docker Hub shorthands (nginx, myorg/app) to their fully qualified form
+func normalizeImage(image string) string {
+	image = strings.ToLower(image)
+	first, rest, found := strings.Cut(image, "/")
+	if !found {
+		// A bare registry host such as "ghcr.io" in an allowlist stays as is
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			return image
+		}
+		return "docker.io/library/" + image
+	}
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		if first == "index.docker.io" {
+			return "docker.io/" + rest
+		}
+		return image
+	}
+	return "docker.io/" + image
+}
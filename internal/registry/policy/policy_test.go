@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func weatherServer() *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Name:    "io.github.acme/weather",
+		Version: "1.0.0",
+		Packages: []model.Package{{
+			RegistryType: "oci",
+			Identifier:   "docker.io/acme/weather",
+			Version:      "1.0.0",
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsSecret: true}}},
+				{Name: "REGION"},
+			},
+		}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	p := &models.DeploymentPolicy{Name: "signed-only", Rule: models.PolicyRuleRequireSigned}
+	require.NoError(t, Validate(p))
+	assert.Equal(t, models.PolicyEnforcementDeny, p.Enforcement)
+
+	assert.Error(t, Validate(&models.DeploymentPolicy{Name: "Bad Name", Rule: models.PolicyRuleRequireSigned}))
+	assert.Error(t, Validate(&models.DeploymentPolicy{Name: "unknown", Rule: "opa"}))
+	assert.Error(t, Validate(&models.DeploymentPolicy{Name: "images", Rule: models.PolicyRuleImageAllowlist}))
+	assert.Error(t, Validate(&models.DeploymentPolicy{Name: "signed", Rule: models.PolicyRuleRequireSigned, Enforcement: "audit"}))
+	assert.Error(t, Validate(&models.DeploymentPolicy{Name: "signed", Rule: models.PolicyRuleRequireSigned, ResourceTypes: []string{"skill"}}))
+}
+
+func TestEvaluateRequireSigned(t *testing.T) {
+	policies := []models.DeploymentPolicy{{Name: "signed-only", Rule: models.PolicyRuleRequireSigned, Enforcement: models.PolicyEnforcementDeny}}
+	in := Input{ResourceType: "mcp", Name: "io.github.acme/weather", Version: "1.0.0", Server: weatherServer()}
+
+	violations := Evaluate(policies, in)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "is not signed")
+	assert.ErrorIs(t, DeniedError(violations), ErrDenied)
+
+	in.Signature = &models.ServerSignature{Fingerprint: "SHA256:abc"}
+	assert.Empty(t, Evaluate(policies, in))
+
+	in.TrustedKey = func(fp string) bool { return fp == "SHA256:def" }
+	violations = Evaluate(policies, in)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "untrusted key SHA256:abc")
+
+	// Agents cannot be signed, so the rule does not apply to them
+	assert.Empty(t, Evaluate(policies, Input{ResourceType: "agent", Agent: &models.AgentJSON{}}))
+}
+
+func TestEvaluateImageAllowlist(t *testing.T) {
+	policies := []models.DeploymentPolicy{{Name: "trusted-images", Rule: models.PolicyRuleImageAllowlist, Registries: []string{"ghcr.io/acme", "nginx"}}}
+
+	server := weatherServer()
+	violations := Evaluate(policies, Input{ResourceType: "mcp", Server: server})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "docker.io/acme/weather:1.0.0")
+
+	server.Packages[0].Identifier = "ghcr.io/acme/weather"
+	assert.Empty(t, Evaluate(policies, Input{ResourceType: "mcp", Server: server}))
+
+	// A preferred remote means no image is run
+	server.Packages[0].Identifier = "quay.io/other/weather"
+	server.Remotes = []model.Transport{{Type: "streamable-http", URL: "https://weather.example.com/mcp"}}
+	assert.Empty(t, Evaluate(policies, Input{ResourceType: "mcp", Server: server, PreferRemote: true}))
+	assert.Len(t, Evaluate(policies, Input{ResourceType: "mcp", Server: server}), 1)
+
+	agent := &models.AgentJSON{AgentManifest: models.AgentManifest{
+		Image:      "nginx:1.27",
+		McpServers: []models.McpServerType{{Type: "command", Image: "ghcr.io/acmeevil/tool"}},
+	}}
+	violations = Evaluate(policies, Input{ResourceType: "agent", Agent: agent})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "ghcr.io/acmeevil/tool")
+}
+
+func TestEvaluateSecretEnvRefs(t *testing.T) {
+	policies := []models.DeploymentPolicy{{Name: "no-inline-secrets", Rule: models.PolicyRuleSecretEnvRefs, Enforcement: models.PolicyEnforcementWarn}}
+	in := Input{ResourceType: "mcp", Server: weatherServer(), Config: map[string]string{"REGION": "eu"}}
+	assert.Empty(t, Evaluate(policies, in))
+
+	in.Config["API_KEY"] = "secretRef://vault/secret/data/weather#api_key"
+	assert.Empty(t, Evaluate(policies, in))
+
+	in.Config["API_KEY"] = "hunter2"
+	violations := Evaluate(policies, in)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "API_KEY")
+	// Warnings never deny a deployment
+	assert.NoError(t, DeniedError(violations))
+}
+
+func TestEvaluateResourceTypes(t *testing.T) {
+	policies := []models.DeploymentPolicy{{Name: "agents-only", Rule: models.PolicyRuleImageAllowlist, Registries: []string{"ghcr.io"}, ResourceTypes: []string{"agent"}}}
+	assert.Empty(t, Evaluate(policies, Input{ResourceType: "mcp", Server: weatherServer()}))
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+policies:
+  - name: signed-only
+    rule: require-signed
+    resourceTypes: [mcp]
+  - name: trusted-images
+    rule: image-allowlist
+    enforcement: warn
+    registries: [ghcr.io/acme]
+`), 0o600))
+
+	policies, err := LoadFile(path)
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, models.PolicyEnforcementDeny, policies[0].Enforcement)
+	assert.Equal(t, models.PolicySourceConfig, policies[0].Source)
+	assert.Equal(t, []string{"ghcr.io/acme"}, policies[1].Registries)
+
+	require.NoError(t, os.WriteFile(path, []byte("policies:\n  - name: a\n    rule: require-signed\n  - name: a\n    rule: require-signed\n"), 0o600))
+	_, err = LoadFile(path)
+	assert.ErrorContains(t, err, "duplicate policy")
+}
+
+func TestNormalizeImage(t *testing.T) {
+	assert.Equal(t, "docker.io/library/nginx:1.27", normalizeImage("nginx:1.27"))
+	assert.Equal(t, "docker.io/acme/app", normalizeImage("acme/app"))
+	assert.Equal(t, "docker.io/acme/app", normalizeImage("index.docker.io/acme/app"))
+	assert.Equal(t, "localhost:5000/app", normalizeImage("localhost:5000/app"))
+	assert.Equal(t, "ghcr.io", normalizeImage("ghcr.io"))
+}
@@ -17,14 +17,17 @@ import (
 	mcpregistry "github.com/agentregistry-dev/agentregistry/internal/mcp/registryserver"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api"
 	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/backup"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/embeddings"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/importer"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 
@@ -53,24 +56,54 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 	}
 
 	// Resolve authn provider: use provided, or default to JWT-based if configured
+	// The default provider also accepts long-lived API tokens, resolved against the database
+	var apiTokenProvider *auth.APITokenAuthnProvider
 	authnProvider := options.AuthnProvider
 	if authnProvider == nil && jwtManager != nil {
-		authnProvider = jwtManager
+		apiTokenProvider = auth.NewAPITokenAuthnProvider(jwtManager, nil)
+		authnProvider = apiTokenProvider
 	}
 
 	// Resolve authz provider: use provided, or default to public authz
+	// The default provider enforces namespace role bindings on top of the public authz rules
+	var rbacProvider *auth.RBACAuthzProvider
 	authzProvider := options.AuthzProvider
 	if authzProvider == nil {
-		log.Println("Using public authz provider")
-		authzProvider = auth.NewPublicAuthzProvider(jwtManager)
+		log.Println("Using public authz provider with RBAC")
+		rbacProvider = auth.NewRBACAuthzProvider(auth.NewPublicAuthzProvider(jwtManager), nil)
+		authzProvider = rbacProvider
 	}
 	authz := auth.Authorizer{Authz: authzProvider}
 
 	// Connect to PostgreSQL with authz (runs OSS migrations)
-	baseDB, err := internaldb.NewPostgreSQL(ctx, cfg.DatabaseURL, authz)
+	poolConfig := internaldb.PoolConfig{
+		MaxConns:        cfg.DatabaseMaxConns,
+		MinConns:        cfg.DatabaseMinConns,
+		MaxConnLifetime: cfg.DatabaseMaxConnLifetime,
+		MaxConnIdleTime: cfg.DatabaseMaxConnIdleTime,
+	}
+	baseDB, err := internaldb.NewPostgreSQLWithPool(ctx, cfg.DatabaseURL, authz, poolConfig)
 	if err != nil {
 		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
+	if err := baseDB.UseBlobStorage(cfg.BlobStorage, cfg.BlobInlineMaxBytes); err != nil {
+		if err := baseDB.Close(); err != nil {
+			log.Printf("Error closing base database connection: %v", err)
+		}
+		return err
+	}
+	if err := baseDB.UseReadReplica(ctx, cfg.DatabaseReadURL, poolConfig); err != nil {
+		if err := baseDB.Close(); err != nil {
+			log.Printf("Error closing base database connection: %v", err)
+		}
+		return err
+	}
+	if rbacProvider != nil {
+		rbacProvider.SetStore(baseDB)
+	}
+	if apiTokenProvider != nil {
+		apiTokenProvider.SetStore(baseDB)
+	}
 
 	// Allow implementors to wrap the database, and run additional migrations
 	var db database.Database = baseDB
@@ -116,40 +149,82 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 		options.OnServiceCreated(registryService)
 	}
 
-	// Import builtin seed data unless it is disabled
+	// Import builtin seed data unless it is disabled. Seed imports are registered as background jobs
+	// so operators can see their outcome and re-run them.
 	if !cfg.DisableBuiltinSeed {
 		log.Printf("Importing builtin seed data in the background...")
-		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-			defer cancel()
-
-			ctx = auth.WithSystemContext(ctx)
-
-			if err := seed.ImportBuiltinSeedData(ctx, registryService); err != nil {
-				log.Printf("Failed to import builtin seed data: %v", err)
-			}
-		}()
+		err := registryService.RegisterJob(jobs.Job{
+			Name:        "builtin-seed",
+			Description: "Import the builtin seed data",
+			RunOnStart:  true,
+			Timeout:     5 * time.Minute,
+			Run: func(ctx context.Context) error {
+				return seed.ImportBuiltinSeedData(ctx, registryService)
+			},
+		})
+		if err != nil {
+			log.Printf("Failed to register builtin seed job: %v", err)
+		}
 	}
 
 	// Import seed data if seed source is provided
 	if cfg.SeedFrom != "" {
 		log.Printf("Importing data from %s in the background...", cfg.SeedFrom)
-		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-			defer cancel()
-
-			ctx = auth.WithSystemContext(ctx)
+		err := registryService.RegisterJob(jobs.Job{
+			Name:        "seed-import",
+			Description: "Import servers from " + cfg.SeedFrom,
+			RunOnStart:  true,
+			Timeout:     5 * time.Minute,
+			Run: func(ctx context.Context) error {
+				verifier, err := seed.NewVerifier(cfg.SeedRequireVerification, cfg.SeedMinisignPublicKey)
+				if err != nil {
+					return fmt.Errorf("invalid SEED_MINISIGN_PUBLIC_KEY: %w", err)
+				}
+				importerService := importer.NewService(registryService)
+				importerService.SetSeedVerifier(verifier)
+				importerService.SetConcurrency(cfg.ImportConcurrency)
+				if status, err := registryService.CreateImport(ctx, cfg.SeedFrom, models.ImportOptions{Enrich: cfg.EnrichServerData}); err != nil {
+					log.Printf("Warning: failed to track seed import progress: %v", err)
+				} else {
+					log.Printf("Seed import %s started; follow its progress at /v0/imports/%s", status.ID, status.ID)
+					importerService.SetImportID(status.ID)
+				}
+				if embeddingProvider != nil {
+					importerService.SetEmbeddingProvider(embeddingProvider)
+					importerService.SetEmbeddingDimensions(cfg.Embeddings.Dimensions)
+					importerService.SetGenerateEmbeddings(cfg.Embeddings.Enabled)
+				}
+				return importerService.ImportFromPath(ctx, cfg.SeedFrom, cfg.EnrichServerData)
+			},
+		})
+		if err != nil {
+			log.Printf("Failed to register seed import job: %v", err)
+		}
+	}
 
+	// Refresh the enriched details of existing servers on a schedule (or on demand via /admin/v0/jobs)
+	if err := registryService.RegisterJob(jobs.Job{
+		Name:        "enrich",
+		Description: "Refresh scorecard scores, dependency health and image pull stats of servers",
+		Interval:    cfg.EnrichInterval,
+		Timeout:     time.Hour,
+		Run: func(ctx context.Context) error {
 			importerService := importer.NewService(registryService)
-			if embeddingProvider != nil {
-				importerService.SetEmbeddingProvider(embeddingProvider)
-				importerService.SetEmbeddingDimensions(cfg.Embeddings.Dimensions)
-				importerService.SetGenerateEmbeddings(cfg.Embeddings.Enabled)
-			}
-			if err := importerService.ImportFromPath(ctx, cfg.SeedFrom, cfg.EnrichServerData); err != nil {
-				log.Printf("Failed to import seed data: %v", err)
-			}
-		}()
+			importerService.SetGitHubToken(cfg.EnrichGitHubToken)
+			return importerService.EnrichExisting(ctx, importer.EnrichOptions{
+				MaxAge:      cfg.EnrichInterval,
+				Concurrency: cfg.EnrichConcurrency,
+			})
+		},
+	}); err != nil {
+		log.Printf("Failed to register enrich job: %v", err)
+	}
+
+	// Back up the registry on a schedule (or on demand via /admin/v0/jobs) if a location is configured
+	if cfg.BackupLocation != "" {
+		if err := registerBackupJob(cfg, db, registryService); err != nil {
+			log.Printf("Warning: scheduled backups disabled: %v", err)
+		}
 	}
 
 	log.Printf("Starting agentregistry %s (commit: %s)", version.Version, version.GitCommit)
@@ -172,6 +247,19 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 		}
 	}()
 
+	if telemetry.TracingEnabled(cfg.OTLPEndpoint) {
+		shutdownTracing, err := telemetry.InitTracing(context.Background(), cfg.Version, cfg.OTLPEndpoint, cfg.TraceSampleRatio)
+		if err != nil {
+			return fmt.Errorf("failed to initialize tracing: %v", err)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				log.Printf("Failed to shutdown tracing: %v", err)
+			}
+		}()
+		log.Printf("Exporting traces over OTLP")
+	}
+
 	if cfg.ReconcileOnStartup {
 		log.Println("Reconciling existing deployments at startup...")
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
@@ -203,7 +291,7 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 
 	var mcpHTTPServer *http.Server
 	if cfg.MCPPort > 0 {
-		mcpServer := mcpregistry.NewServer(registryService)
+		mcpServer := mcpregistry.NewServer(registryService, mcpregistry.WithAuthn(authnProvider))
 
 		var handler http.Handler = mcp.NewStreamableHTTPHandler(func(_ *http.Request) *mcp.Server {
 			return mcpServer
@@ -245,11 +333,20 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 	<-quit
 	log.Println("Shutting down server...")
 
-	// Create context with timeout for shutdown
-	sctx, scancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Fail readiness and suspend background jobs right away; running jobs such as a reconciliation get the same
+	// grace period as in-flight requests
+	sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownDelay+cfg.ShutdownGracePeriod)
 	defer scancel()
+	jobsDrained := make(chan error, 1)
+	go func() { jobsDrained <- registryService.Shutdown(sctx) }()
+
+	// Keep serving while load balancers notice the failing readiness probe and stop routing here
+	if cfg.ShutdownDelay > 0 {
+		log.Printf("Draining: serving for another %s before closing the listener", cfg.ShutdownDelay)
+		time.Sleep(cfg.ShutdownDelay)
+	}
 
-	// Gracefully shutdown the server
+	// Gracefully shutdown the server, letting in-flight requests such as publishes finish
 	if err := server.Shutdown(sctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
@@ -259,6 +356,10 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 		}
 	}
 
+	if err := <-jobsDrained; err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
 	log.Println("Server exiting")
 	return nil
 }
@@ -280,3 +381,40 @@ func mcpAuthnMiddleware(authn auth.AuthnProvider) func(http.Handler) http.Handle
 		})
 	}
 }
+
+// registerBackupJob registers the "backup" job, which snapshots the registry to cfg.BackupLocation and applies the retention policy
+func registerBackupJob(cfg *config.Config, db database.Database, registryService service.RegistryService) error {
+	store, err := backup.NewStorage(cfg.BackupLocation)
+	if err != nil {
+		return err
+	}
+	format, err := backup.ParseFormat(cfg.BackupFormat)
+	if err != nil {
+		return err
+	}
+	retention := backup.Retention{Keep: cfg.BackupRetentionCount, MaxAge: cfg.BackupRetentionAge}
+
+	return registryService.RegisterJob(jobs.Job{
+		Name:        "backup",
+		Description: fmt.Sprintf("Back up the registry (%s) to %s", format, store),
+		Interval:    cfg.BackupInterval,
+		Timeout:     30 * time.Minute,
+		Run: func(ctx context.Context) error {
+			b, err := backup.Create(ctx, db, store, backup.Options{
+				Format:          format,
+				DatabaseURL:     cfg.DatabaseURL,
+				RegistryVersion: version.Version,
+			})
+			if err != nil {
+				return err
+			}
+			log.Printf("Backup %s written to %s (%d bytes)", b.Name, store, b.Size)
+
+			deleted, err := backup.Prune(ctx, store, retention)
+			for _, name := range deleted {
+				log.Printf("Deleted backup %s (retention policy)", name)
+			}
+			return err
+		},
+	})
+}
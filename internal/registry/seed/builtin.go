@@ -20,7 +20,22 @@ var builtinSeedData []byte
 //go:embed seed-readme.json
 var builtinReadmeData []byte
 
+// The checksums guard against the builtin seed data being edited without regenerating them (make seed-checksums)
+//
+//go:embed seed.json.sha256
+var builtinSeedChecksum []byte
+
+//go:embed seed-readme.json.sha256
+var builtinReadmeChecksum []byte
+
 func ImportBuiltinSeedData(ctx context.Context, registry service.RegistryService) error {
+	if err := VerifyChecksum(builtinSeedData, builtinSeedChecksum); err != nil {
+		return fmt.Errorf("builtin seed data: %w", err)
+	}
+	if err := VerifyChecksum(builtinReadmeData, builtinReadmeChecksum); err != nil {
+		return fmt.Errorf("builtin README seed data: %w", err)
+	}
+
 	servers, err := loadSeedData(builtinSeedData)
 	if err != nil {
 		return err
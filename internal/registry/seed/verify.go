@@ -0,0 +1,151 @@
+package seed
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// ChecksumSuffix is appended to a seed file's path or URL to find its detached SHA-256 checksum, in the format
+	// written by sha256sum
+	ChecksumSuffix = ".sha256"
+	// SignatureSuffix is appended to a seed file's path or URL to find its detached minisign signature
+	SignatureSuffix = ".minisig"
+)
+
+// ErrUnverified is returned when verification is required but a seed file has no checksum or signature to verify
+var ErrUnverified = errors.New("seed data is not verified")
+
+// Verifier checks seed files against their detached checksum and minisign signature files
+type Verifier struct {
+	// Require rejects seed files without a checksum file, and without a signature file if a public key is set
+	Require bool
+	// PublicKey verifies minisign signatures; when set, seed files must be signed with it
+	PublicKey *MinisignPublicKey
+}
+
+// NewVerifier creates a verifier from a minisign public key, either the base64 key line or the contents of a
+// minisign .pub file. An empty key only verifies checksums.
+func NewVerifier(require bool, publicKey string) (*Verifier, error) {
+	v := &Verifier{Require: require}
+	if strings.TrimSpace(publicKey) != "" {
+		key, err := ParseMinisignPublicKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		v.PublicKey = key
+	}
+	return v, nil
+}
+
+// Verify checks seed data against its detached checksum and signature files, which are nil when missing
+func (v *Verifier) Verify(name string, data, checksum, signature []byte) error {
+	if checksum == nil && v.Require {
+		return fmt.Errorf("%w: %s has no %s checksum file", ErrUnverified, name, ChecksumSuffix)
+	}
+	if checksum != nil {
+		if err := VerifyChecksum(data, checksum); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	if v.PublicKey == nil {
+		return nil
+	}
+	if signature == nil {
+		return fmt.Errorf("%w: %s has no %s signature file", ErrUnverified, name, SignatureSuffix)
+	}
+	if err := v.PublicKey.Verify(data, signature); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// VerifyChecksum checks data against a SHA-256 checksum file: the hex digest, optionally followed by the file name
+func VerifyChecksum(data, checksum []byte) error {
+	fields := strings.Fields(string(checksum))
+	if len(fields) == 0 {
+		return errors.New("checksum file is empty")
+	}
+	expected, err := hex.DecodeString(fields[0])
+	if err != nil || len(expected) != sha256.Size {
+		return errors.New("checksum file does not start with a SHA-256 hex digest")
+	}
+	actual := sha256.Sum256(data)
+	if !bytes.Equal(actual[:], expected) {
+		return fmt.Errorf("checksum mismatch: expected sha256 %s, got %s", fields[0], hex.EncodeToString(actual[:]))
+	}
+	return nil
+}
+
+// MinisignPublicKey is an Ed25519 public key in minisign format
+type MinisignPublicKey struct {
+	KeyID [8]byte
+	Key   ed25519.PublicKey
+}
+
+// ParseMinisignPublicKey parses the base64 key line of a minisign public key, or a whole .pub file
+func ParseMinisignPublicKey(key string) (*MinisignPublicKey, error) {
+	line := lastLine(key)
+	raw, err := base64.StdEncoding.DecodeString(line)
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != "Ed" {
+		return nil, errors.New("invalid minisign public key")
+	}
+	pk := &MinisignPublicKey{Key: ed25519.PublicKey(raw[10:])}
+	copy(pk.KeyID[:], raw[2:10])
+	return pk, nil
+}
+
+// Verify checks a minisign signature file over data, including the signature of its trusted comment
+func (k *MinisignPublicKey) Verify(data, signatureFile []byte) error {
+	lines := strings.Split(strings.ReplaceAll(strings.TrimSpace(string(signatureFile)), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return errors.New("invalid minisign signature file")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return errors.New("invalid minisign signature")
+	}
+	algorithm, keyID, signature := string(raw[:2]), raw[2:10], raw[10:]
+	if !bytes.Equal(keyID, k.KeyID[:]) {
+		return fmt.Errorf("signature was made with key %X, not the trusted key %X", keyID, k.KeyID)
+	}
+
+	// "ED" signatures sign the BLAKE2b-512 hash of the file, legacy "Ed" signatures the file itself
+	message := data
+	switch algorithm {
+	case "ED":
+		hash := blake2b.Sum512(data)
+		message = hash[:]
+	case "Ed":
+	default:
+		return fmt.Errorf("unsupported minisign signature algorithm %q", algorithm)
+	}
+	if !ed25519.Verify(k.Key, message, signature) {
+		return errors.New("signature verification failed")
+	}
+
+	trustedComment := strings.TrimPrefix(lines[2], "trusted comment: ")
+	globalSignature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(globalSignature) != ed25519.SignatureSize {
+		return errors.New("invalid minisign trusted comment signature")
+	}
+	if !ed25519.Verify(k.Key, append(bytes.Clone(signature), trustedComment...), globalSignature) {
+		return errors.New("trusted comment signature verification failed")
+	}
+	return nil
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
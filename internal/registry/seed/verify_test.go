@@ -0,0 +1,72 @@
+package seed_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisign signs data the way `minisign -S` does, returning the public key line and the .minisig file
+func minisign(t *testing.T, data []byte) (string, []byte) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyID := []byte("12345678")
+
+	hash := blake2b.Sum512(data)
+	signature := ed25519.Sign(privateKey, hash[:])
+	trustedComment := "timestamp:1700000000\tfile:seed.json\thashed"
+	globalSignature := ed25519.Sign(privateKey, append(append([]byte{}, signature...), trustedComment...))
+
+	key := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), publicKey...))
+	sig := fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte("ED"), keyID...), signature...)),
+		trustedComment,
+		base64.StdEncoding.EncodeToString(globalSignature))
+	return "untrusted comment: minisign public key\n" + key + "\n", []byte(sig)
+}
+
+func TestVerifier(t *testing.T) {
+	data := []byte(`[{"name": "io.github.test/server"}]`)
+	digest := sha256.Sum256(data)
+	checksum := []byte(hex.EncodeToString(digest[:]) + "  seed.json\n")
+	publicKey, signature := minisign(t, data)
+
+	// Without a key or requirement, only checksums that are present are checked
+	optional, err := seed.NewVerifier(false, "")
+	require.NoError(t, err)
+	assert.NoError(t, optional.Verify("seed.json", data, nil, nil))
+	assert.NoError(t, optional.Verify("seed.json", data, checksum, nil))
+	assert.ErrorContains(t, optional.Verify("seed.json", []byte("tampered"), checksum, nil), "checksum mismatch")
+
+	required, err := seed.NewVerifier(true, "")
+	require.NoError(t, err)
+	assert.ErrorIs(t, required.Verify("seed.json", data, nil, nil), seed.ErrUnverified)
+
+	signed, err := seed.NewVerifier(true, publicKey)
+	require.NoError(t, err)
+	assert.NoError(t, signed.Verify("seed.json", data, checksum, signature))
+	assert.ErrorIs(t, signed.Verify("seed.json", data, checksum, nil), seed.ErrUnverified)
+
+	signedOnly, err := seed.NewVerifier(false, publicKey)
+	require.NoError(t, err)
+	assert.NoError(t, signedOnly.Verify("seed.json", data, nil, signature))
+	assert.ErrorContains(t, signedOnly.Verify("seed.json", []byte("tampered"), nil, signature), "signature verification failed")
+
+	otherKey, _ := minisign(t, data)
+	other, err := seed.NewVerifier(false, otherKey)
+	require.NoError(t, err)
+	assert.Error(t, other.Verify("seed.json", data, nil, signature))
+
+	_, err = seed.NewVerifier(false, "not a key")
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// StoreAgentReadme stores or updates the README for an existing agent version
+func (s *registryServiceImpl) StoreAgentReadme(ctx context.Context, agentName, version string, content []byte, contentType string) error {
+	if len(content) == 0 {
+		return fmt.Errorf("%w: README content is empty", database.ErrInvalidInput)
+	}
+	if err := validators.ValidateReadmeSize(content, s.readmeMaxBytes()); err != nil {
+		return err
+	}
+	if contentType == "" {
+		contentType = "text/markdown"
+	}
+
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		if _, err := s.db.GetAgentByNameAndVersion(txCtx, tx, agentName, version); err != nil {
+			return err
+		}
+		return s.db.UpsertAgentReadme(txCtx, tx, &database.AgentReadme{
+			AgentName:   agentName,
+			Version:     version,
+			Content:     append([]byte(nil), content...),
+			ContentType: contentType,
+			SizeBytes:   len(content),
+			FetchedAt:   time.Now(),
+		})
+	})
+}
+
+// GetAgentReadmeLatest retrieves the README for the latest agent version
+func (s *registryServiceImpl) GetAgentReadmeLatest(ctx context.Context, agentName string) (*database.AgentReadme, error) {
+	return s.db.GetLatestAgentReadme(ctx, nil, agentName)
+}
+
+// GetAgentReadmeByVersion retrieves the README for a specific agent version
+func (s *registryServiceImpl) GetAgentReadmeByVersion(ctx context.Context, agentName, version string) (*database.AgentReadme, error) {
+	return s.db.GetAgentReadme(ctx, nil, agentName, version)
+}
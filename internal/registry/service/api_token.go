@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// apiTokenDisplayPrefixLen is how many leading characters of a token are stored for identification
+const apiTokenDisplayPrefixLen = 12
+
+// CreateAPIToken mints a new API token for the caller. The plaintext token is returned once and never stored.
+func (s *registryServiceImpl) CreateAPIToken(ctx context.Context, name string, permissions []auth.Permission, expiresAt *time.Time) (*models.CreatedAPIToken, error) {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: token expiry must be in the future", database.ErrInvalidInput)
+	}
+
+	plaintext, hash, err := auth.GenerateAPIToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.CreatedAPIToken, error) {
+		token := models.APIToken{
+			Name:        name,
+			Prefix:      plaintext[:apiTokenDisplayPrefixLen],
+			Permissions: permissions,
+			ExpiresAt:   expiresAt,
+		}
+		if err := s.db.CreateAPIToken(ctx, tx, &token, hash); err != nil {
+			return nil, err
+		}
+		details := map[string]any{"id": strconv.FormatInt(token.ID, 10), "permissions": permissions}
+		if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "token", token.Name, "", details); err != nil {
+			return nil, err
+		}
+		return &models.CreatedAPIToken{APIToken: token, Token: plaintext}, nil
+	})
+}
+
+// ListAPITokens lists the caller's API tokens, or all tokens when all is set
+func (s *registryServiceImpl) ListAPITokens(ctx context.Context, all bool) ([]*models.APIToken, error) {
+	return s.db.ListAPITokens(ctx, nil, all)
+}
+
+// RevokeAPIToken revokes an API token by ID
+func (s *registryServiceImpl) RevokeAPIToken(ctx context.Context, id int64) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		revoked, err := s.db.RevokeAPIToken(txCtx, tx, id)
+		if err != nil {
+			return err
+		}
+		details := map[string]any{"id": strconv.FormatInt(id, 10), "subject": revoked.Subject}
+		return s.recordAudit(txCtx, tx, models.AuditActionDelete, "token", revoked.Name, "", details)
+	})
+}
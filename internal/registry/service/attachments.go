@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/blobstore"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// UploadAttachment attaches an artifact file to a server or agent version, streaming its content into blob storage.
+// A file of the same name is replaced. Content larger than ARTIFACT_MAX_BYTES is rejected with blobstore.ErrTooLarge.
+func (s *registryServiceImpl) UploadAttachment(ctx context.Context, attachment *models.Attachment, content io.Reader) (_ *models.Attachment, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.UploadAttachment", telemetry.ResourceAttributes(attachment.ArtifactType, attachment.ArtifactName, attachment.Version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if s.blobs == nil {
+		return nil, blobstore.ErrNotConfigured
+	}
+	stored := *attachment
+	if err := normalizeAttachment(&stored); err != nil {
+		return nil, err
+	}
+	// Check the version exists before accepting what may be a large upload
+	if err := s.checkAttachmentTarget(ctx, nil, stored.ArtifactType, stored.ArtifactName, stored.Version); err != nil {
+		return nil, err
+	}
+
+	blob, err := s.blobs.Put(ctx, content, s.cfg.ArtifactMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("registry.blob.digest", blob.Digest))
+	stored.Digest = blob.Digest
+	stored.SizeBytes = blob.Size
+	stored.UploadedBy, _ = auth.ActorFrom(ctx)
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.Attachment, error) {
+		saved, err := s.db.UpsertAttachment(ctx, tx, &stored)
+		if err != nil {
+			return nil, err
+		}
+		details := map[string]any{"artifact": saved.FileName, "kind": saved.Kind, "digest": saved.Digest, "sizeBytes": saved.SizeBytes}
+		if err := s.recordAudit(ctx, tx, models.AuditActionUpdate, saved.ArtifactType, saved.ArtifactName, saved.Version, details); err != nil {
+			return nil, err
+		}
+		return saved, nil
+	})
+}
+
+// ListAttachments returns the artifact files attached to a server or agent version
+func (s *registryServiceImpl) ListAttachments(ctx context.Context, artifactType, name, version string) ([]*models.Attachment, error) {
+	if err := s.checkAttachmentTarget(ctx, nil, artifactType, name, version); err != nil {
+		return nil, err
+	}
+	return s.db.ListAttachments(ctx, nil, artifactType, name, version)
+}
+
+// OpenAttachment returns an artifact file attached to a server or agent version with its content; the caller must
+// close the content
+func (s *registryServiceImpl) OpenAttachment(ctx context.Context, artifactType, name, version, fileName string) (_ *models.Attachment, _ io.ReadCloser, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.OpenAttachment", telemetry.ResourceAttributes(artifactType, name, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if s.blobs == nil {
+		return nil, nil, blobstore.ErrNotConfigured
+	}
+	attachment, err := s.db.GetAttachment(ctx, nil, artifactType, name, version, fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.blobs.Open(ctx, attachment.Digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an artifact file from a server or agent version
+func (s *registryServiceImpl) DeleteAttachment(ctx context.Context, artifactType, name, version, fileName string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		deleted, err := s.db.DeleteAttachment(ctx, tx, artifactType, name, version, fileName)
+		if err != nil {
+			return err
+		}
+		details := map[string]any{"artifact": deleted.FileName, "removed": true}
+		return s.recordAudit(ctx, tx, models.AuditActionUpdate, artifactType, name, version, details)
+	})
+}
+
+// checkAttachmentTarget returns database.ErrNotFound unless the server or agent version exists
+func (s *registryServiceImpl) checkAttachmentTarget(ctx context.Context, tx pgx.Tx, artifactType, name, version string) error {
+	switch artifactType {
+	case "mcp":
+		_, err := s.db.GetServerByNameAndVersion(ctx, tx, name, version, false)
+		return err
+	case "agent":
+		_, err := s.db.GetAgentByNameAndVersion(ctx, tx, name, version)
+		return err
+	default:
+		return fmt.Errorf("%w: artifacts can only be attached to servers and agents", database.ErrInvalidInput)
+	}
+}
+
+// normalizeAttachment validates the file name and kind of an attachment and fills in its defaults: kind "other" and
+// a media type derived from the file extension
+func normalizeAttachment(attachment *models.Attachment) error {
+	name := attachment.FileName
+	if name == "" || len(name) > 255 || name == "." || name == ".." || strings.ContainsAny(name, `/\`) ||
+		strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return fmt.Errorf("%w: invalid artifact file name %q", database.ErrInvalidInput, name)
+	}
+
+	if attachment.Kind == "" {
+		attachment.Kind = models.AttachmentKindOther
+	}
+	if !slices.Contains(models.AttachmentKinds, attachment.Kind) {
+		return fmt.Errorf("%w: unknown artifact kind %q (expected one of %s)", database.ErrInvalidInput, attachment.Kind, strings.Join(models.AttachmentKinds, ", "))
+	}
+
+	if attachment.MediaType == "" {
+		attachment.MediaType = mime.TypeByExtension(path.Ext(name))
+	}
+	if attachment.MediaType == "" {
+		attachment.MediaType = "application/octet-stream"
+	}
+	if _, _, err := mime.ParseMediaType(attachment.MediaType); err != nil {
+		return fmt.Errorf("%w: invalid media type %q", database.ErrInvalidInput, attachment.MediaType)
+	}
+	return nil
+}
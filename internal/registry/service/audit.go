@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListAuditLog returns audit log entries (newest first) with cursor-based pagination and optional filtering
+func (s *registryServiceImpl) ListAuditLog(ctx context.Context, filter *models.AuditLogFilter, cursor string, limit int) ([]*models.AuditLogEntry, string, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.db.ListAuditLogEntries(ctx, nil, filter, cursor, limit)
+}
+
+// recordAudit writes an audit log entry for a mutating operation performed by the caller in ctx, and the event
+// describing it to the outbox. When tx is non-nil both are committed (or rolled back) together with the operation itself.
+func (s *registryServiceImpl) recordAudit(ctx context.Context, tx pgx.Tx, action, resourceType, resourceName, version string, details map[string]any) error {
+	actor, method := auth.ActorFrom(ctx)
+	entry := &models.AuditLogEntry{
+		Actor:        actor,
+		AuthMethod:   string(method),
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Version:      version,
+		Details:      details,
+	}
+	if err := s.db.CreateAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+	return s.recordEvent(ctx, tx, entry)
+}
+
+// recordAuditBestEffort records an audit entry outside of a transaction, logging instead of failing.
+// Used for operations that have side effects outside the database (e.g. deployments) and cannot be rolled back.
+func (s *registryServiceImpl) recordAuditBestEffort(ctx context.Context, action, resourceType, resourceName, version string, details map[string]any) {
+	if err := s.recordAudit(ctx, nil, action, resourceType, resourceName, version, details); err != nil {
+		log.Printf("Warning: failed to record audit entry (%s %s %s@%s): %v", action, resourceType, resourceName, version, err)
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// MaxBatchSize is the maximum number of items in a batch publish request
+const MaxBatchSize = 100
+
+// CreateServersBatch creates each server version in its own transaction, so one invalid entry does not
+// roll back the others. With publish, each version is also published in the same transaction.
+func (s *registryServiceImpl) CreateServersBatch(ctx context.Context, servers []*apiv0.ServerJSON, publish bool) []error {
+	errs := make([]error, len(servers))
+	if err := checkBatchSize(len(servers)); err != nil {
+		return fillErrors(errs, err)
+	}
+	for i, server := range servers {
+		if server == nil {
+			errs[i] = fmt.Errorf("%w: server is required", database.ErrInvalidInput)
+			continue
+		}
+		errs[i] = s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			created, err := s.createServerInTransaction(ctx, tx, server)
+			if err != nil {
+				return err
+			}
+			if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "mcp", created.Server.Name, created.Server.Version, nil); err != nil {
+				return err
+			}
+			if !publish {
+				return nil
+			}
+			return s.publishServerInTransaction(ctx, tx, created.Server.Name, created.Server.Version)
+		})
+		if errs[i] == nil && publish {
+			s.scanServerImagesInBackground(ctx, server.Name, server.Version)
+			s.introspectServerInBackground(ctx, server.Name, server.Version)
+		}
+	}
+	return errs
+}
+
+// CreateAgentsBatch creates each agent version in its own transaction, publishing it too when publish is set
+func (s *registryServiceImpl) CreateAgentsBatch(ctx context.Context, agents []*models.AgentJSON, publish bool) []error {
+	errs := make([]error, len(agents))
+	if err := checkBatchSize(len(agents)); err != nil {
+		return fillErrors(errs, err)
+	}
+	for i, agent := range agents {
+		if agent == nil {
+			errs[i] = fmt.Errorf("%w: agent is required", database.ErrInvalidInput)
+			continue
+		}
+		errs[i] = s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			created, err := s.createAgentInTransaction(ctx, tx, agent)
+			if err != nil {
+				return err
+			}
+			if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "agent", created.Agent.Name, created.Agent.Version, nil); err != nil {
+				return err
+			}
+			if !publish {
+				return nil
+			}
+			if err := s.db.PublishAgent(ctx, tx, created.Agent.Name, created.Agent.Version); err != nil {
+				return err
+			}
+			return s.recordAudit(ctx, tx, models.AuditActionPublish, "agent", created.Agent.Name, created.Agent.Version, nil)
+		})
+	}
+	return errs
+}
+
+// CreateSkillsBatch creates each skill version in its own transaction, publishing it too when publish is set
+func (s *registryServiceImpl) CreateSkillsBatch(ctx context.Context, skills []*models.SkillJSON, publish bool) []error {
+	errs := make([]error, len(skills))
+	if err := checkBatchSize(len(skills)); err != nil {
+		return fillErrors(errs, err)
+	}
+	for i, skill := range skills {
+		if skill == nil {
+			errs[i] = fmt.Errorf("%w: skill is required", database.ErrInvalidInput)
+			continue
+		}
+		errs[i] = s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			created, err := s.createSkillInTransaction(ctx, tx, skill)
+			if err != nil {
+				return err
+			}
+			if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "skill", created.Skill.Name, created.Skill.Version, nil); err != nil {
+				return err
+			}
+			if !publish {
+				return nil
+			}
+			if err := s.db.PublishSkill(ctx, tx, created.Skill.Name, created.Skill.Version); err != nil {
+				return err
+			}
+			return s.recordAudit(ctx, tx, models.AuditActionPublish, "skill", created.Skill.Name, created.Skill.Version, nil)
+		})
+	}
+	return errs
+}
+
+func checkBatchSize(n int) error {
+	if n > MaxBatchSize {
+		return fmt.Errorf("%w: batch has %d items, the maximum is %d", database.ErrInvalidInput, n, MaxBatchSize)
+	}
+	return nil
+}
+
+func fillErrors(errs []error, err error) []error {
+	for i := range errs {
+		errs[i] = err
+	}
+	return errs
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/blobstore"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PutBlob stores content in blob storage, streaming it from r, and returns its digest. Content larger than
+// BLOB_MAX_UPLOAD_BYTES is rejected with blobstore.ErrTooLarge.
+func (s *registryServiceImpl) PutBlob(ctx context.Context, r io.Reader) (_ *models.Blob, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.PutBlob")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if s.blobs == nil {
+		return nil, blobstore.ErrNotConfigured
+	}
+	blob, err := s.blobs.Put(ctx, r, s.cfg.BlobMaxUploadBytes)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("registry.blob.digest", blob.Digest), attribute.Int64("registry.blob.size", blob.Size))
+	return blob, nil
+}
+
+// OpenBlob returns the content of a blob by digest; the caller must close it
+func (s *registryServiceImpl) OpenBlob(ctx context.Context, digest string) (_ io.ReadCloser, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.OpenBlob", attribute.String("registry.blob.digest", digest))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if s.blobs == nil {
+		return nil, blobstore.ErrNotConfigured
+	}
+	return s.blobs.Open(ctx, digest)
+}
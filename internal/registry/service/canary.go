@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/policy"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/secrets"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// serverRollout returns the stable deployment of an MCP server and its canary, either of which may be nil
+func serverRollout(deployments []*models.Deployment, serverName string) (stable, canary *models.Deployment) {
+	for _, d := range deployments {
+		if d.ResourceType != "mcp" || d.ServerName != serverName {
+			continue
+		}
+		if d.CanaryWeight > 0 {
+			canary = d
+		} else {
+			stable = d
+		}
+	}
+	return stable, canary
+}
+
+// DeployServerCanary rolls a new version of a deployed MCP server out next to the current one. The agent gateway
+// routes weight percent of the server's traffic to it until it is promoted or removed, or rolled back after
+// failing its health checks. The canary inherits the target, origin and remote preference of the stable
+// deployment, and its config unless one is given.
+func (s *registryServiceImpl) DeployServerCanary(ctx context.Context, serverName, version string, config map[string]string, weight int) (_ *models.Deployment, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.DeployServerCanary", telemetry.ResourceAttributes("mcp", serverName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if weight < 1 || weight > 99 {
+		return nil, fmt.Errorf("%w: canary weight must be between 1 and 99 percent", database.ErrInvalidInput)
+	}
+
+	deployments, err := s.db.GetDeployments(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	stable, canary := serverRollout(deployments, serverName)
+	if stable == nil {
+		return nil, fmt.Errorf("%w: %s is not deployed; deploy it before rolling out a canary", database.ErrInvalidInput, serverName)
+	}
+	if canary != nil {
+		return nil, fmt.Errorf("%w: version %s of %s is already being rolled out; promote or remove it first", database.ErrInvalidInput, canary.Version, serverName)
+	}
+	target, err := s.deploymentTarget(stable)
+	if err != nil {
+		return nil, err
+	}
+	if target.Runtime() != "local" {
+		return nil, fmt.Errorf("%w: canary rollouts need the agent gateway of a docker target, %s runs on %s", database.ErrInvalidInput, serverName, target.Name)
+	}
+
+	// The traffic split of every canary on a target is taken out of the same gateway route
+	totalWeight := weight
+	for _, d := range deployments {
+		if d.CanaryWeight == 0 {
+			continue
+		}
+		if t, err := s.deploymentTarget(d); err == nil && t.Name == target.Name {
+			totalWeight += d.CanaryWeight
+		}
+	}
+	if totalWeight >= 100 {
+		return nil, fmt.Errorf("%w: canaries on %s would take %d%% of the MCP traffic; lower the weight", database.ErrInvalidInput, target.Name, totalWeight)
+	}
+
+	serverResp, err := s.resolveDeploymentServer(ctx, serverName, version, stable.Origin)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("server %s not found in registry: %w", serverName, database.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to verify server: %w", err)
+	}
+	version = serverResp.Server.Version
+	if version == stable.Version {
+		return nil, fmt.Errorf("%w: version %s of %s is already deployed", database.ErrInvalidInput, version, serverName)
+	}
+
+	if len(config) == 0 {
+		config = maps.Clone(stable.Config)
+	}
+	if err := secrets.ValidateConfig(config); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if err := registry.ValidateResourceConfig(config); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if err := s.checkDeploymentPolicies(ctx, policy.Input{
+		ResourceType: "mcp",
+		Name:         serverName,
+		Version:      version,
+		Server:       &serverResp.Server,
+		Config:       config,
+		PreferRemote: stable.PreferRemote,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.checkDeploymentQuota(ctx); err != nil {
+		return nil, err
+	}
+
+	deployment := &models.Deployment{
+		ServerName:   serverName,
+		Version:      version,
+		Status:       models.DeploymentStatusActive,
+		Config:       config,
+		PreferRemote: stable.PreferRemote,
+		ResourceType: "mcp",
+		Runtime:      stable.Runtime,
+		Target:       stable.Target,
+		Origin:       stable.Origin,
+		CanaryWeight: weight,
+		DeployedAt:   time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	if deployment.Config == nil {
+		deployment.Config = make(map[string]string)
+	}
+	if err := s.db.CreateDeployment(ctx, nil, deployment); err != nil {
+		return nil, err
+	}
+
+	if err := s.ReconcileAll(ctx); err != nil {
+		if cleanupErr := s.db.RemoveDeployment(ctx, nil, serverName, version, "mcp"); cleanupErr != nil {
+			return nil, fmt.Errorf("canary created but reconciliation failed: %v (cleanup failed: %v)", err, cleanupErr)
+		}
+		return nil, fmt.Errorf("canary created but reconciliation failed: %w", err)
+	}
+
+	s.recordAuditBestEffort(ctx, models.AuditActionDeploy, "mcp", serverName, version, map[string]any{"canaryWeight": weight, "stableVersion": stable.Version})
+
+	return s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, version, "mcp")
+}
+
+// PromoteDeployment finishes the canary rollout of an MCP server: the canary takes all of the traffic and the
+// previous version is removed.
+func (s *registryServiceImpl) PromoteDeployment(ctx context.Context, serverName string) (_ *models.Deployment, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.PromoteDeployment", telemetry.ResourceAttributes("mcp", serverName, "")...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	deployments, err := s.db.GetDeployments(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	stable, canary := serverRollout(deployments, serverName)
+	if canary == nil {
+		return nil, fmt.Errorf("no canary of %s to promote: %w", serverName, database.ErrNotFound)
+	}
+
+	err = s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if stable != nil {
+			if err := s.db.RemoveDeployment(ctx, tx, stable.ServerName, stable.Version, "mcp"); err != nil {
+				return err
+			}
+		}
+		return s.db.UpdateDeploymentCanaryWeight(ctx, tx, canary.ServerName, canary.Version, "mcp", 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ReconcileAll(ctx); err != nil {
+		return nil, fmt.Errorf("canary promoted but reconciliation failed: %w", err)
+	}
+
+	details := map[string]any{}
+	if stable != nil {
+		details["previousVersion"] = stable.Version
+	}
+	s.recordAuditBestEffort(ctx, models.AuditActionPromote, "mcp", serverName, canary.Version, details)
+	promoted := *canary
+	promoted.CanaryWeight = 0
+	s.recordRevisionBestEffort(ctx, &promoted, models.DeploymentChangePromote)
+
+	return s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, canary.Version, "mcp")
+}
+
+// rollbackCanary removes a canary that keeps failing its health checks, returning all traffic to the stable version
+func (s *registryServiceImpl) rollbackCanary(ctx context.Context, canary *models.Deployment, reason string) error {
+	log.Printf("Rolling back canary %s v%s: %s", canary.ServerName, canary.Version, reason)
+	if err := s.db.RemoveDeployment(ctx, nil, canary.ServerName, canary.Version, "mcp"); err != nil {
+		return fmt.Errorf("failed to remove canary %s v%s: %w", canary.ServerName, canary.Version, err)
+	}
+	if err := s.ReconcileAll(ctx); err != nil {
+		return fmt.Errorf("canary removed but reconciliation failed: %w", err)
+	}
+	s.recordAuditBestEffort(ctx, models.AuditActionRollback, "mcp", canary.ServerName, canary.Version, map[string]any{"reason": reason, "canaryWeight": canary.CanaryWeight})
+	return nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// GetServerChangelog returns the versions of a server, newest first, with the release notes their publishers provided
+// and a structural diff of each server.json against the version before it
+func (s *registryServiceImpl) GetServerChangelog(ctx context.Context, serverName string, publishedOnly bool) (_ *models.ServerChangelog, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.GetServerChangelog", telemetry.ResourceAttributes("mcp", serverName, "")...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	versions, err := s.db.GetAllVersionsByServerName(ctx, nil, serverName, publishedOnly)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(versions, func(a, b *apiv0.ServerResponse) int {
+		return CompareVersions(a.Server.Version, b.Server.Version, serverPublishedAt(a), serverPublishedAt(b))
+	})
+
+	changelog := &models.ServerChangelog{Name: serverName, Versions: make([]models.ChangelogEntry, 0, len(versions))}
+	var previous map[string]any
+	for i, v := range versions {
+		current, err := serverFields(&v.Server)
+		if err != nil {
+			return nil, err
+		}
+		entry := models.ChangelogEntry{
+			Version:     v.Server.Version,
+			PublishedAt: serverPublishedAt(v),
+			Notes:       serverChangelogNotes(&v.Server),
+			Changes:     []models.ChangelogChange{},
+		}
+		if i > 0 {
+			entry.PreviousVersion = versions[i-1].Server.Version
+			entry.Changes = append(entry.Changes, diffServerFields("", previous, current)...)
+		}
+		changelog.Versions = append(changelog.Versions, entry)
+		previous = current
+	}
+	slices.Reverse(changelog.Versions)
+	return changelog, nil
+}
+
+// validateChangelog rejects release notes in the publisher-provided metadata that are not a string or are too long
+func validateChangelog(server *apiv0.ServerJSON) error {
+	if server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return nil
+	}
+	raw, ok := server.Meta.PublisherProvided[models.ChangelogMetadataKey]
+	if !ok {
+		return nil
+	}
+	notes, ok := raw.(string)
+	if !ok {
+		return fmt.Errorf("%w: %s must be a markdown string", database.ErrInvalidInput, models.ChangelogMetadataKey)
+	}
+	if len(notes) > models.MaxChangelogLength {
+		return fmt.Errorf("%w: %s exceeds %d bytes", database.ErrInvalidInput, models.ChangelogMetadataKey, models.MaxChangelogLength)
+	}
+	return nil
+}
+
+func serverChangelogNotes(server *apiv0.ServerJSON) string {
+	if server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return ""
+	}
+	notes, _ := server.Meta.PublisherProvided[models.ChangelogMetadataKey].(string)
+	return notes
+}
+
+func serverPublishedAt(server *apiv0.ServerResponse) time.Time {
+	if server.Meta.Official == nil {
+		return time.Time{}
+	}
+	return server.Meta.Official.PublishedAt
+}
+
+// serverFields returns the fields of a server.json that are compared between versions: everything except its version
+// and _meta, which holds the release notes and registry-computed data
+func serverFields(server *apiv0.ServerJSON) (map[string]any, error) {
+	data, err := json.Marshal(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server %s: %w", server.Name, err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server %s: %w", server.Name, err)
+	}
+	delete(fields, "version")
+	delete(fields, "_meta")
+	return fields, nil
+}
+
+// diffServerFields compares two versions of a server.json field by field, descending into maps and lists. List
+// elements are compared by position, so an element appended to a list is reported as added.
+func diffServerFields(path string, previous, current any) []models.ChangelogChange {
+	child := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch p := previous.(type) {
+	case map[string]any:
+		c, ok := current.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := maps.Clone(p)
+		maps.Copy(keys, c)
+		var changes []models.ChangelogChange
+		for _, key := range slices.Sorted(maps.Keys(keys)) {
+			pv, inPrevious := p[key]
+			cv, inCurrent := c[key]
+			switch {
+			case !inPrevious:
+				changes = append(changes, changelogChange(child(key), nil, cv))
+			case !inCurrent:
+				changes = append(changes, changelogChange(child(key), pv, nil))
+			default:
+				changes = append(changes, diffServerFields(child(key), pv, cv)...)
+			}
+		}
+		return changes
+	case []any:
+		c, ok := current.([]any)
+		if !ok {
+			break
+		}
+		var changes []models.ChangelogChange
+		for i := range max(len(p), len(c)) {
+			elem := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(p):
+				changes = append(changes, changelogChange(elem, nil, c[i]))
+			case i >= len(c):
+				changes = append(changes, changelogChange(elem, p[i], nil))
+			default:
+				changes = append(changes, diffServerFields(elem, p[i], c[i])...)
+			}
+		}
+		return changes
+	}
+
+	if reflect.DeepEqual(previous, current) {
+		return nil
+	}
+	return []models.ChangelogChange{changelogChange(path, previous, current)}
+}
+
+func changelogChange(path string, previous, current any) models.ChangelogChange {
+	change := models.ChangelogChange{Field: path, Change: models.ChangelogChangeChanged}
+	switch {
+	case previous == nil:
+		change.Change = models.ChangelogChangeAdded
+	case current == nil:
+		change.Change = models.ChangelogChangeRemoved
+	}
+	change.Previous = formatChangelogValue(previous)
+	change.Current = formatChangelogValue(current)
+	return change
+}
+
+func formatChangelogValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,92 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetServerChangelog(t *testing.T) {
+	ctx := context.Background()
+	svc := NewRegistryService(internaldb.NewTestDB(t), &config.Config{EnableRegistryValidation: false}, nil)
+
+	_, err := svc.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	_, err = svc.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather and forecast server",
+		Title:       "Weather",
+		Version:     "1.1.0",
+		Meta: &apiv0.ServerMeta{PublisherProvided: map[string]any{
+			models.ChangelogMetadataKey: "- Add forecast tool",
+		}},
+	})
+	require.NoError(t, err)
+
+	changelog, err := svc.GetServerChangelog(ctx, "com.example/weather", false)
+	require.NoError(t, err)
+	require.Len(t, changelog.Versions, 2)
+
+	latest := changelog.Versions[0]
+	assert.Equal(t, "1.1.0", latest.Version)
+	assert.Equal(t, "1.0.0", latest.PreviousVersion)
+	assert.Equal(t, "- Add forecast tool", latest.Notes)
+	assert.Equal(t, []models.ChangelogChange{
+		{Field: "description", Change: models.ChangelogChangeChanged, Previous: "Weather server", Current: "Weather and forecast server"},
+		{Field: "title", Change: models.ChangelogChangeAdded, Current: "Weather"},
+	}, latest.Changes)
+
+	first := changelog.Versions[1]
+	assert.Equal(t, "1.0.0", first.Version)
+	assert.Empty(t, first.PreviousVersion)
+	assert.Empty(t, first.Changes)
+
+	// Unpublished versions are hidden from the public changelog
+	_, err = svc.GetServerChangelog(ctx, "com.example/weather", true)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	// Release notes must be markdown strings of bounded size
+	for _, notes := range []any{map[string]any{"notes": "x"}, strings.Repeat("x", models.MaxChangelogLength+1)} {
+		_, err = svc.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Weather server",
+			Version:     "1.2.0",
+			Meta:        &apiv0.ServerMeta{PublisherProvided: map[string]any{models.ChangelogMetadataKey: notes}},
+		})
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	}
+}
+
+func TestDiffServerFields(t *testing.T) {
+	previous := map[string]any{
+		"packages": []any{map[string]any{"identifier": "weather", "version": "1.0.0"}},
+		"remotes":  []any{map[string]any{"url": "https://a.example.com"}, map[string]any{"url": "https://b.example.com"}},
+	}
+	current := map[string]any{
+		"packages": []any{map[string]any{"identifier": "weather", "version": "1.1.0"}, map[string]any{"identifier": "weather-cli"}},
+		"remotes":  []any{map[string]any{"url": "https://a.example.com"}},
+	}
+
+	assert.Equal(t, []models.ChangelogChange{
+		{Field: "packages[0].version", Change: models.ChangelogChangeChanged, Previous: "1.0.0", Current: "1.1.0"},
+		{Field: "packages[1]", Change: models.ChangelogChangeAdded, Current: `{"identifier":"weather-cli"}`},
+		{Field: "remotes[1]", Change: models.ChangelogChangeRemoved, Previous: `{"url":"https://b.example.com"}`},
+	}, diffServerFields("", previous, current))
+}
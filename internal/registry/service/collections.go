@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// CreateCollection creates an unpublished collection version. Every server, agent and skill it lists must exist in
+// the registry, at the pinned version when one is given.
+func (s *registryServiceImpl) CreateCollection(ctx context.Context, req *models.CollectionJSON) (_ *models.CollectionResponse, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.CreateCollection", telemetry.ResourceAttributes("collection", req.Name, req.Version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := validators.ValidateCollectionJSON(req); err != nil {
+		return nil, err
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.CollectionResponse, error) {
+		if err := s.checkNamespaceOwnership(ctx, tx, req.Name); err != nil {
+			return nil, err
+		}
+		if err := s.validateCollectionItems(ctx, tx, req.Items); err != nil {
+			return nil, err
+		}
+		if err := s.db.AcquirePublishLock(ctx, tx, req.Name); err != nil {
+			return nil, err
+		}
+
+		isLatest := true
+		current, err := s.db.GetCollection(ctx, tx, req.Name, "")
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		if current != nil && CompareVersions(req.Version, current.Collection.Version, time.Now(), current.Meta.Official.PublishedAt) <= 0 {
+			isLatest = false
+		}
+
+		created, err := s.db.CreateCollection(ctx, tx, req, isLatest)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "collection", req.Name, req.Version, nil); err != nil {
+			return nil, err
+		}
+		return created, nil
+	})
+}
+
+// validateCollectionItems checks that every item of a collection exists
+func (s *registryServiceImpl) validateCollectionItems(ctx context.Context, tx pgx.Tx, items []models.CollectionItem) error {
+	var errs []error
+	for _, item := range items {
+		latest := item.Version == "" || item.Version == "latest"
+		var err error
+		switch item.Type {
+		case models.CollectionItemTypeMCP:
+			if latest {
+				_, err = s.db.GetServerByName(ctx, tx, item.Name)
+			} else {
+				_, err = s.db.GetServerByNameAndVersion(ctx, tx, item.Name, item.Version, false)
+			}
+		case models.CollectionItemTypeAgent:
+			if latest {
+				_, err = s.db.GetAgentByName(ctx, tx, item.Name)
+			} else {
+				_, err = s.db.GetAgentByNameAndVersion(ctx, tx, item.Name, item.Version)
+			}
+		case models.CollectionItemTypeSkill:
+			if latest {
+				_, err = s.db.GetSkillByName(ctx, tx, item.Name)
+			} else {
+				_, err = s.db.GetSkillByNameAndVersion(ctx, tx, item.Name, item.Version)
+			}
+		}
+		if errors.Is(err, database.ErrNotFound) {
+			if latest {
+				errs = append(errs, fmt.Errorf("%s %s does not exist", item.Type, item.Name))
+			} else {
+				errs = append(errs, fmt.Errorf("%s %s version %s does not exist", item.Type, item.Name, item.Version))
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", database.ErrInvalidInput, errors.Join(errs...))
+	}
+	return nil
+}
+
+// GetCollection retrieves a collection version, or its latest version when version is empty or "latest"
+func (s *registryServiceImpl) GetCollection(ctx context.Context, name, version string) (*models.CollectionResponse, error) {
+	if version == "latest" {
+		version = ""
+	}
+	return s.db.GetCollection(ctx, nil, name, version)
+}
+
+// ListCollections retrieves the latest version of each collection, optionally only published ones
+func (s *registryServiceImpl) ListCollections(ctx context.Context, publishedOnly bool) ([]*models.CollectionResponse, error) {
+	return s.db.ListCollections(ctx, nil, publishedOnly)
+}
+
+// PublishCollection marks a collection version as published
+func (s *registryServiceImpl) PublishCollection(ctx context.Context, name, version string) error {
+	return s.setCollectionPublished(ctx, name, version, true)
+}
+
+// UnpublishCollection marks a collection version as unpublished
+func (s *registryServiceImpl) UnpublishCollection(ctx context.Context, name, version string) error {
+	return s.setCollectionPublished(ctx, name, version, false)
+}
+
+func (s *registryServiceImpl) setCollectionPublished(ctx context.Context, name, version string, published bool) error {
+	action := models.AuditActionPublish
+	if !published {
+		action = models.AuditActionUnpublish
+	}
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		if err := s.db.SetCollectionPublished(txCtx, tx, name, version, published); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, action, "collection", name, version, nil)
+	})
+}
+
+// DeleteCollection removes a collection version
+func (s *registryServiceImpl) DeleteCollection(ctx context.Context, name, version string) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		if err := s.db.DeleteCollection(txCtx, tx, name, version); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, models.AuditActionDelete, "collection", name, version, nil)
+	})
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// maxConfigProfileDepth caps how many profiles a chain of extends may hold
+const maxConfigProfileDepth = 10
+
+var configProfileNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,99}$`)
+
+// ListConfigProfiles lists the config profiles of a server
+func (s *registryServiceImpl) ListConfigProfiles(ctx context.Context, serverName string) ([]models.ConfigProfile, error) {
+	stored, err := s.db.ListConfigProfiles(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]models.ConfigProfile, 0, len(stored))
+	for _, p := range stored {
+		profiles = append(profiles, *p)
+	}
+	return profiles, nil
+}
+
+// GetConfigProfile retrieves a config profile of a server, with its config resolved over the profiles it extends
+func (s *registryServiceImpl) GetConfigProfile(ctx context.Context, serverName, name string) (*models.ConfigProfile, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.ConfigProfile, error) {
+		profile, err := s.db.GetConfigProfile(ctx, tx, serverName, name)
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := s.resolveConfigProfile(ctx, tx, profile)
+		if err != nil {
+			return nil, err
+		}
+		profile.ResolvedConfig = resolved
+		return profile, nil
+	})
+}
+
+// PutConfigProfile creates or replaces a config profile of a published server
+func (s *registryServiceImpl) PutConfigProfile(ctx context.Context, profile *models.ConfigProfile) (_ *models.ConfigProfile, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.PutConfigProfile", telemetry.ResourceAttributes("mcp", profile.ServerName, "")...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if !configProfileNameRe.MatchString(profile.Name) {
+		return nil, fmt.Errorf("%w: profile name must be lowercase letters, digits, '.', '_' or '-', up to 100 characters", database.ErrInvalidInput)
+	}
+	if profile.Extends == profile.Name {
+		return nil, fmt.Errorf("%w: profile %s cannot extend itself", database.ErrInvalidInput, profile.Name)
+	}
+	for key := range profile.Config {
+		if key == "" {
+			return nil, fmt.Errorf("%w: config keys cannot be empty", database.ErrInvalidInput)
+		}
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.ConfigProfile, error) {
+		if _, err := s.db.GetServerByName(ctx, tx, profile.ServerName); err != nil {
+			return nil, err
+		}
+
+		stored := *profile
+		stored.ResolvedConfig = nil
+		if stored.Config == nil {
+			stored.Config = map[string]string{}
+		}
+		stored.CreatedBy, _ = auth.ActorFrom(ctx)
+
+		// Resolving the chain the profile would extend rejects a missing parent and a cycle through this profile
+		resolved, err := s.resolveConfigProfile(ctx, tx, &stored)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.UpsertConfigProfile(ctx, tx, &stored); err != nil {
+			return nil, err
+		}
+
+		details := map[string]any{"profile": stored.Name}
+		if stored.Extends != "" {
+			details["extends"] = stored.Extends
+		}
+		if err := s.recordAudit(ctx, tx, models.AuditActionUpdate, "config-profile", stored.ServerName, "", details); err != nil {
+			return nil, err
+		}
+		stored.ResolvedConfig = resolved
+		return &stored, nil
+	})
+}
+
+// DeleteConfigProfile removes a config profile of a server that no other profile extends
+func (s *registryServiceImpl) DeleteConfigProfile(ctx context.Context, serverName, name string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		profiles, err := s.db.ListConfigProfiles(ctx, tx, serverName)
+		if err != nil {
+			return err
+		}
+		for _, p := range profiles {
+			if p.Extends == name {
+				return fmt.Errorf("%w: profile %s is extended by profile %s", database.ErrInvalidInput, name, p.Name)
+			}
+		}
+
+		if err := s.db.DeleteConfigProfile(ctx, tx, serverName, name); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, tx, models.AuditActionDelete, "config-profile", serverName, "", map[string]any{"profile": name})
+	})
+}
+
+// resolveConfigProfile merges the config of a profile over the configs of the profiles it extends, nearest last
+func (s *registryServiceImpl) resolveConfigProfile(ctx context.Context, tx pgx.Tx, profile *models.ConfigProfile) (map[string]string, error) {
+	chain := []*models.ConfigProfile{profile}
+	seen := map[string]bool{profile.Name: true}
+	for parent := profile.Extends; parent != ""; {
+		if seen[parent] {
+			return nil, fmt.Errorf("%w: profile %s extends itself through profile %s", database.ErrInvalidInput, profile.Name, parent)
+		}
+		if len(chain) >= maxConfigProfileDepth {
+			return nil, fmt.Errorf("%w: profile %s extends more than %d profiles", database.ErrInvalidInput, profile.Name, maxConfigProfileDepth-1)
+		}
+		p, err := s.db.GetConfigProfile(ctx, tx, profile.ServerName, parent)
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("%w: profile %s extends profile %s, which does not exist", database.ErrInvalidInput, chain[len(chain)-1].Name, parent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		seen[parent] = true
+		chain = append(chain, p)
+		parent = p.Extends
+	}
+
+	resolved := map[string]string{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		resolved = models.MergeConfig(resolved, chain[i].Config)
+	}
+	return resolved, nil
+}
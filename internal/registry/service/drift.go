@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// DiffDeployment compares the desired state of a deployment, rendered from its registry record and stored config the
+// same way a reconcile would, with what actually runs on its target
+func (s *registryServiceImpl) DiffDeployment(ctx context.Context, serverName, version, resourceType string) (_ *models.DeploymentDiff, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.DiffDeployment", telemetry.ResourceAttributes(resourceType, serverName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	deployment, err := s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, version, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	requestsByTarget, err := s.reconcileRequestsByTarget(ctx, []*models.Deployment{deployment}, true)
+	if err != nil {
+		return nil, err
+	}
+	var requests *reconcileRequests
+	for _, r := range requestsByTarget {
+		requests = r
+	}
+	if requests == nil || len(requests.servers)+len(requests.agents) == 0 {
+		return nil, fmt.Errorf("%w: the desired state of %s v%s cannot be rendered; its target or manifest is no longer available", database.ErrInvalidInput, serverName, version)
+	}
+
+	agentRuntime := s.newTargetRuntime(requests.target, registry.NewTranslator())
+	drift, err := agentRuntime.Diff(ctx, requests.servers, requests.agents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the runtime state of %s: %w", requests.target.Name, err)
+	}
+	if drift == nil {
+		drift = []models.DeploymentDrift{}
+	}
+
+	return &models.DeploymentDiff{
+		ServerName:   deployment.ServerName,
+		Version:      deployment.Version,
+		ResourceType: deployment.ResourceType,
+		Runtime:      deployment.Runtime,
+		Target:       requests.target.Name,
+		InSync:       len(drift) == 0,
+		Drift:        drift,
+		CheckedAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListServersDueForEnrichment returns the latest server versions that were never enriched or last enriched before
+// enrichedBefore, least recently enriched first
+func (s *registryServiceImpl) ListServersDueForEnrichment(ctx context.Context, enrichedBefore time.Time, limit int) ([]*models.ServerEnrichment, error) {
+	return s.db.ListServersDueForEnrichment(ctx, nil, enrichedBefore, limit)
+}
+
+// RecordServerEnrichment stores the enriched details of a server version under its publisher-provided metadata and
+// records when it was enriched. A nil metadata only records the attempt, e.g. when enrichment failed or the server
+// has no GitHub repository, and keeps the details of an earlier enrichment.
+func (s *registryServiceImpl) RecordServerEnrichment(ctx context.Context, serverName, version string, metadata map[string]any, enrichErr error) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.RecordServerEnrichment", telemetry.ResourceAttributes("mcp", serverName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	enrichment := &models.ServerEnrichment{ServerName: serverName, Version: version}
+	if enrichErr != nil {
+		enrichment.Error = enrichErr.Error()
+	}
+
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if metadata != nil {
+			current, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version, false)
+			if err != nil {
+				return err
+			}
+			server := current.Server
+			if server.Meta == nil {
+				server.Meta = &apiv0.ServerMeta{}
+			}
+			if server.Meta.PublisherProvided == nil {
+				server.Meta.PublisherProvided = map[string]any{}
+			}
+			server.Meta.PublisherProvided[models.EnrichmentMetadataKey] = metadata
+			if _, err := s.db.UpdateServer(ctx, tx, serverName, version, &server); err != nil {
+				return err
+			}
+		}
+		return s.db.UpsertServerEnrichment(ctx, tx, enrichment)
+	})
+}
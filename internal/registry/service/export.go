@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/dockercompose"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"go.opentelemetry.io/otel/attribute"
+	"go.yaml.in/yaml/v3"
+)
+
+// ExportRuntime renders the current deployments as a bundle of the files they are applied from, one directory per
+// target: the compose format covers docker targets, and the kustomize and helm formats kubernetes targets. Secret
+// references in deployment configs are exported as they are stored, so the bundle can be committed.
+func (s *registryServiceImpl) ExportRuntime(ctx context.Context, format string) (_ *models.RuntimeExport, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.ExportRuntime", attribute.String("export.format", format))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	var targetType string
+	switch format {
+	case models.RuntimeExportFormatCompose:
+		targetType = runtime.TargetTypeDocker
+	case models.RuntimeExportFormatKustomize, models.RuntimeExportFormatHelm:
+		targetType = runtime.TargetTypeKubernetes
+	default:
+		return nil, fmt.Errorf("%w: unknown export format %q, expected %s, %s or %s", database.ErrInvalidInput, format,
+			models.RuntimeExportFormatCompose, models.RuntimeExportFormatKustomize, models.RuntimeExportFormatHelm)
+	}
+
+	deployments, err := s.GetDeployments(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	requestsByTarget, err := s.reconcileRequestsByTarget(ctx, deployments, false)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.RuntimeExport{Format: format, Files: []models.RuntimeExportFile{}}
+	var targetDirs []string
+	for _, targetName := range slices.Sorted(maps.Keys(requestsByTarget)) {
+		requests := requestsByTarget[targetName]
+		if requests.target.Type != targetType || len(requests.servers)+len(requests.agents) == 0 {
+			continue
+		}
+
+		files, err := s.newExportRuntime(requests.target, format).Render(ctx, requests.servers, requests.agents)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", targetName, err)
+		}
+		for _, name := range slices.Sorted(maps.Keys(files)) {
+			export.Files = append(export.Files, models.RuntimeExportFile{
+				Path:    path.Join(targetName, name),
+				Content: string(files[name]),
+			})
+		}
+		targetDirs = append(targetDirs, targetName)
+	}
+
+	// A root kustomization lets the whole bundle be applied with kubectl apply -k
+	if format == models.RuntimeExportFormatKustomize && len(targetDirs) > 0 {
+		kustomization, err := yaml.Marshal(map[string]any{
+			"apiVersion": "kustomize.config.k8s.io/v1beta1",
+			"kind":       "Kustomization",
+			"resources":  targetDirs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal kustomization: %w", err)
+		}
+		export.Files = append(export.Files, models.RuntimeExportFile{Path: "kustomization.yaml", Content: string(kustomization)})
+	}
+
+	return export, nil
+}
+
+// newExportRuntime creates the runtime backend that renders the deployments of a target. Local paths are rendered
+// relative to the target directory of the bundle instead of the runtime directory of the registry, and each
+// kubernetes target becomes a chart of its own in the helm format.
+func (s *registryServiceImpl) newExportRuntime(target runtime.Target, format string) runtime.AgentRegistryRuntime {
+	if target.Type == runtime.TargetTypeKubernetes {
+		var opts []runtime.Option
+		if format == models.RuntimeExportFormatHelm {
+			opts = append(opts, runtime.WithHelmChart("agentregistry-"+target.Name, ""))
+		}
+		return runtime.NewAgentRegistryRuntime(registry.NewTranslator(), kagent.NewTranslatorWithNamespace(target.Namespace), ".", s.cfg.Verbose, opts...)
+	}
+	composeTranslator := dockercompose.NewAgentGatewayTranslator(".", s.cfg.AgentGatewayPort)
+	if target.Name != "local" {
+		composeTranslator = dockercompose.NewAgentGatewayTranslatorWithProjectName(".", s.cfg.AgentGatewayPort, dockercompose.DefaultProjectName+"-"+target.Name)
+	}
+	return runtime.NewAgentRegistryRuntime(registry.NewTranslator(), composeTranslator, ".", s.cfg.Verbose)
+}
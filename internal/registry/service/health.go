@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// healthCheckJobTimeout bounds one pass of the health check job
+const healthCheckJobTimeout = time.Minute
+
+// healthTracker counts the automatic restarts of each local deployment since it was last healthy.
+// The zero value is ready to use.
+type healthTracker struct {
+	mu       sync.Mutex
+	restarts map[string]int
+}
+
+func (h *healthTracker) count(key string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.restarts[key]
+}
+
+func (h *healthTracker) increment(key string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.restarts == nil {
+		h.restarts = make(map[string]int)
+	}
+	h.restarts[key]++
+	return h.restarts[key]
+}
+
+func (h *healthTracker) reset(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.restarts, key)
+}
+
+// retain forgets the counters of deployments that no longer exist
+func (h *healthTracker) retain(keys map[string]bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for key := range h.restarts {
+		if !keys[key] {
+			delete(h.restarts, key)
+		}
+	}
+}
+
+func healthKey(d *models.Deployment) string {
+	return d.ResourceType + "/" + d.ServerName + "@" + d.Version
+}
+
+// isLocalDeployment reports whether a deployment runs on the built-in local target, the only one whose
+// containers are health checked
+func isLocalDeployment(d *models.Deployment) bool {
+	return !d.IsExternal && (d.Runtime == "" || d.Runtime == "local") && (d.Target == "" || d.Target == "local")
+}
+
+// localServiceName returns the compose service a local deployment runs as, matching the runtime translators
+func localServiceName(d *models.Deployment) string {
+	if d.ResourceType == "agent" {
+		return d.ServerName
+	}
+	if d.CanaryWeight > 0 {
+		return registry.GenerateInternalName(d.ServerName) + api.CanaryServiceSuffix
+	}
+	return registry.GenerateInternalName(d.ServerName)
+}
+
+// GetDeploymentHealth returns the runtime state of all deployments. MCP servers without their own container
+// (stdio and remote servers) report the state of the agent gateway that serves them.
+func (s *registryServiceImpl) GetDeploymentHealth(ctx context.Context) ([]models.DeploymentHealth, error) {
+	deployments, err := s.GetDeployments(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses map[string]runtime.ServiceStatus
+	var statusErr error
+	now := time.Now()
+	result := make([]models.DeploymentHealth, 0, len(deployments))
+	for _, d := range deployments {
+		health := models.DeploymentHealth{
+			ServerName:      d.ServerName,
+			Version:         d.Version,
+			ResourceType:    d.ResourceType,
+			Runtime:         d.Runtime,
+			Status:          d.Status,
+			State:           runtime.ServiceStateUnknown,
+			Restarts:        s.health.count(healthKey(d)),
+			CheckedAt:       now,
+			DesiredReplicas: registry.DesiredReplicas(d.Config),
+		}
+		if !isLocalDeployment(d) {
+			result = append(result, health)
+			continue
+		}
+
+		if statuses == nil && statusErr == nil {
+			statuses, statusErr = runtime.LocalServiceStatuses(ctx, s.runtimeDir())
+			if statusErr != nil {
+				log.Printf("Warning: failed to read local runtime status: %v", statusErr)
+			}
+		}
+		if statusErr != nil {
+			result = append(result, health)
+			continue
+		}
+
+		health.Service = localServiceName(d)
+		status, ok := statuses[health.Service]
+		if !ok && d.ResourceType == "mcp" {
+			health.Service = runtime.GatewayServiceName
+			status, ok = statuses[health.Service]
+		}
+		if ok {
+			health.State = status.State
+			health.Health = status.Health
+			health.CurrentReplicas = status.Running
+		} else {
+			health.State = runtime.ServiceStateMissing
+		}
+		result = append(result, health)
+	}
+	return result, nil
+}
+
+// checkDeploymentHealth restarts the failed containers of local deployments. A deployment whose container
+// keeps failing after HealthRestartBudget restarts is marked failed and left alone until it recovers, except for
+// canaries, which are rolled back.
+func (s *registryServiceImpl) checkDeploymentHealth(ctx context.Context) error {
+	deployments, err := s.db.GetDeployments(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	var local []*models.Deployment
+	keys := map[string]bool{runtime.GatewayServiceName: true}
+	for _, d := range deployments {
+		if isLocalDeployment(d) {
+			local = append(local, d)
+			keys[healthKey(d)] = true
+		}
+	}
+	s.health.retain(keys)
+	if len(local) == 0 {
+		return nil
+	}
+
+	statuses, err := runtime.LocalServiceStatuses(ctx, s.runtimeDir())
+	if err != nil {
+		return err
+	}
+
+	budget := s.cfg.HealthRestartBudget
+	var errs []error
+
+	// The gateway serves every stdio and remote server, so it is restarted within the same budget
+	// but never marks a deployment failed
+	if gateway, ok := statuses[runtime.GatewayServiceName]; ok {
+		if !gateway.Failed() {
+			s.health.reset(runtime.GatewayServiceName)
+		} else if s.health.count(runtime.GatewayServiceName) < budget {
+			errs = append(errs, s.restartService(ctx, runtime.GatewayServiceName, runtime.GatewayServiceName, gateway))
+		}
+	}
+
+	for _, d := range local {
+		key := healthKey(d)
+		status, ok := statuses[localServiceName(d)]
+		if !ok {
+			continue
+		}
+
+		if !status.Failed() {
+			s.health.reset(key)
+			if d.Status == models.DeploymentStatusFailed {
+				log.Printf("%s %s v%s recovered", d.ResourceType, d.ServerName, d.Version)
+				errs = append(errs, s.db.UpdateDeploymentStatus(ctx, nil, d.ServerName, d.Version, d.ResourceType, models.DeploymentStatusActive))
+			}
+			continue
+		}
+
+		if s.health.count(key) >= budget {
+			if d.CanaryWeight > 0 {
+				errs = append(errs, s.rollbackCanary(ctx, d, fmt.Sprintf("still %s after %d restart(s)", describeStatus(status), budget)))
+				continue
+			}
+			if d.Status != models.DeploymentStatusFailed {
+				log.Printf("%s %s v%s is still %s after %d restart(s), marking it failed", d.ResourceType, d.ServerName, d.Version, describeStatus(status), budget)
+				errs = append(errs, s.db.UpdateDeploymentStatus(ctx, nil, d.ServerName, d.Version, d.ResourceType, models.DeploymentStatusFailed))
+			}
+			continue
+		}
+		errs = append(errs, s.restartService(ctx, key, localServiceName(d), status))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *registryServiceImpl) restartService(ctx context.Context, key, service string, status runtime.ServiceStatus) error {
+	attempt := s.health.increment(key)
+	log.Printf("Restarting %s (%s), attempt %d of %d", service, describeStatus(status), attempt, s.cfg.HealthRestartBudget)
+	return runtime.RestartLocalService(ctx, s.runtimeDir(), service)
+}
+
+func (s *registryServiceImpl) runtimeDir() string {
+	if s.cfg == nil {
+		return ""
+	}
+	return s.cfg.RuntimeDir
+}
+
+func describeStatus(status runtime.ServiceStatus) string {
+	if status.State != runtime.ServiceStateRunning {
+		return fmt.Sprintf("%s (exit code %d)", status.State, status.ExitCode)
+	}
+	return status.Health
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// maxListedImports bounds how many imports ListImports returns
+const maxListedImports = 100
+
+// ErrImportNotRunning is returned when cancelling an import that already finished
+var ErrImportNotRunning = errors.New("import is not running")
+
+// ImportRunner runs an import started with StartImport, recording its progress under status.ID. The caller provides it
+// because the importer depends on the registry service.
+type ImportRunner func(ctx context.Context, status *models.ImportStatus) error
+
+// importTracker cancels the imports running in this registry process
+type importTracker struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// CreateImport records a running import of the given source and returns its status with a new ID
+func (s *registryServiceImpl) CreateImport(ctx context.Context, source string, options models.ImportOptions) (*models.ImportStatus, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	actor, _ := auth.ActorFrom(ctx)
+	status := &models.ImportStatus{
+		ID:        hex.EncodeToString(id),
+		Source:    source,
+		Options:   options,
+		Status:    models.ImportStatusRunning,
+		StartedAt: time.Now(),
+		CreatedBy: actor,
+	}
+	if err := s.db.CreateImport(ctx, nil, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// UpdateImport records the progress of an import created with CreateImport
+func (s *registryServiceImpl) UpdateImport(ctx context.Context, status *models.ImportStatus) error {
+	return s.db.UpdateImport(ctx, nil, status)
+}
+
+// GetImport returns the progress of an import
+func (s *registryServiceImpl) GetImport(ctx context.Context, id string) (*models.ImportStatus, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	return s.db.GetImport(ctx, nil, id)
+}
+
+// ListImports returns the most recent imports, newest first
+func (s *registryServiceImpl) ListImports(ctx context.Context) ([]models.ImportStatus, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	return s.db.ListImports(ctx, nil, maxListedImports)
+}
+
+// StartImport records an import and runs it in the background with the registry's system identity
+func (s *registryServiceImpl) StartImport(ctx context.Context, request models.ImportRequest, run ImportRunner) (*models.ImportStatus, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	if request.Source == "" {
+		return nil, fmt.Errorf("%w: import source is required", database.ErrInvalidInput)
+	}
+
+	status, err := s.CreateImport(ctx, request.Source, request.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hold the lock until the cancel function is stored, so the run cannot finish and clean up before it is
+	s.imports.mu.Lock()
+	defer s.imports.mu.Unlock()
+	cancel, err := s.jobs.RunOnce(jobs.Job{
+		Name: "import-" + status.ID,
+		Run: func(ctx context.Context) error {
+			err := run(ctx, status)
+			s.imports.mu.Lock()
+			delete(s.imports.cancels, status.ID)
+			s.imports.mu.Unlock()
+			s.recordImportResult(ctx, status.ID, err)
+			return err
+		},
+	})
+	if err != nil {
+		s.recordImportResult(ctx, status.ID, err)
+		return nil, err
+	}
+	if s.imports.cancels == nil {
+		s.imports.cancels = map[string]context.CancelFunc{}
+	}
+	s.imports.cancels[status.ID] = cancel
+
+	actor, _ := auth.ActorFrom(ctx)
+	log.Printf("Import %s of %s started by %s", status.ID, request.Source, actor)
+	return status, nil
+}
+
+// CancelImport cancels a running import. Imports left running by a registry process that stopped are marked as
+// cancelled.
+func (s *registryServiceImpl) CancelImport(ctx context.Context, id string) (*models.ImportStatus, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	status, err := s.db.GetImport(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	if status.Status != models.ImportStatusRunning {
+		return nil, ErrImportNotRunning
+	}
+
+	s.imports.mu.Lock()
+	cancel, ok := s.imports.cancels[id]
+	s.imports.mu.Unlock()
+	if ok {
+		// The importer records the cancellation when it stops
+		cancel()
+		return status, nil
+	}
+
+	finished := time.Now()
+	status.Status = models.ImportStatusCancelled
+	status.FinishedAt = &finished
+	status.Error = "cancelled while not running"
+	if err := s.db.UpdateImport(ctx, nil, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// recordImportResult makes sure the final status of an import is recorded, in case its runner failed before the
+// importer could record it
+func (s *registryServiceImpl) recordImportResult(ctx context.Context, id string, runErr error) {
+	ctx = context.WithoutCancel(ctx)
+	status, err := s.db.GetImport(ctx, nil, id)
+	if err != nil || status.Status != models.ImportStatusRunning {
+		return
+	}
+	finished := time.Now()
+	status.FinishedAt = &finished
+	switch {
+	case errors.Is(runErr, context.Canceled):
+		status.Status = models.ImportStatusCancelled
+	case runErr != nil:
+		status.Status = models.ImportStatusFailed
+	default:
+		status.Status = models.ImportStatusSucceeded
+	}
+	if runErr != nil {
+		status.Error = runErr.Error()
+	}
+	if err := s.db.UpdateImport(ctx, nil, status); err != nil {
+		log.Printf("Warning: failed to record the final status of import %s: %v", id, err)
+	}
+}
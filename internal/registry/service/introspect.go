@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/introspect"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// introspectServerInBackground records the capabilities of a published server version when INTROSPECT_ON_PUBLISH is set
+func (s *registryServiceImpl) introspectServerInBackground(ctx context.Context, serverName, version string) {
+	if s.cfg == nil || !s.cfg.IntrospectOnPublish {
+		return
+	}
+	// Introspection outlives the request, and the results are written by the registry rather than the publisher
+	ctx = auth.WithSystemContext(context.WithoutCancel(ctx))
+	go func() {
+		caps, err := s.IntrospectServer(ctx, serverName, version)
+		if err != nil {
+			log.Printf("Warning: introspection of %s@%s failed: %v", serverName, version, err)
+		} else if caps.Error != "" {
+			log.Printf("Warning: introspection of %s@%s failed: %s", serverName, version, caps.Error)
+		}
+	}()
+}
+
+// IntrospectServer starts or connects to a server version, lists its tools, resources and prompts and stores them.
+// A failed introspection is stored too, with the reason in Error, so it is not mistaken for a server without tools.
+func (s *registryServiceImpl) IntrospectServer(ctx context.Context, serverName, version string) (*models.ServerCapabilities, error) {
+	server, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each introspection may start a container, so only a few run at once
+	select {
+	case s.introspectSlots <- struct{}{}:
+		defer func() { <-s.introspectSlots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	introspector := &introspect.Introspector{}
+	if s.cfg != nil {
+		introspector.Timeout = s.cfg.IntrospectTimeout
+	}
+	caps, err := introspector.Introspect(ctx, &server.Server)
+	if err != nil {
+		caps = &models.ServerCapabilities{
+			ServerName: serverName,
+			Version:    version,
+			Source:     introspectionSource(len(server.Server.Remotes) > 0),
+			Error:      err.Error(),
+		}
+	}
+
+	if err := s.db.UpsertServerCapabilities(ctx, nil, caps); err != nil {
+		return nil, err
+	}
+	return caps, nil
+}
+
+// GetServerCapabilities retrieves the stored capabilities of a server version
+func (s *registryServiceImpl) GetServerCapabilities(ctx context.Context, serverName, version string) (*models.ServerCapabilities, error) {
+	return s.db.GetServerCapabilities(ctx, nil, serverName, version)
+}
+
+// SearchTools finds tools of published servers whose name or description contains query
+func (s *registryServiceImpl) SearchTools(ctx context.Context, query string, limit int) ([]models.ToolSearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: query is required", database.ErrInvalidInput)
+	}
+	return s.db.SearchServerTools(ctx, nil, query, limit)
+}
+
+func introspectionSource(hasRemote bool) string {
+	if hasRemote {
+		return introspect.SourceRemote
+	}
+	return introspect.SourcePackage
+}
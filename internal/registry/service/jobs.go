@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// reconcileJobTimeout bounds a background reconciliation, matching the startup reconciliation
+const reconcileJobTimeout = 2 * time.Minute
+
+// registerBuiltinJobs registers the background jobs owned by the service itself
+func (s *registryServiceImpl) registerBuiltinJobs() {
+	var interval time.Duration
+	if s.cfg != nil {
+		interval = s.cfg.ReconcileInterval
+	}
+	err := s.jobs.Register(jobs.Job{
+		Name:        "reconcile",
+		Description: "Reconcile deployed MCP servers and agents with the runtime",
+		Interval:    interval,
+		Timeout:     reconcileJobTimeout,
+		Run:         s.ReconcileAll,
+	})
+	if err != nil {
+		log.Printf("Warning: failed to register reconcile job: %v", err)
+	}
+
+	if err := s.jobs.Register(s.tagCountsJob()); err != nil {
+		log.Printf("Warning: failed to register tag counts job: %v", err)
+	}
+
+	if err := s.jobs.Register(s.outboxDispatchJob()); err != nil {
+		log.Printf("Warning: failed to register outbox dispatch job: %v", err)
+	}
+
+	if s.cfg != nil && s.cfg.TrashRetention > 0 {
+		if err := s.jobs.Register(s.trashPurgeJob()); err != nil {
+			log.Printf("Warning: failed to register trash purge job: %v", err)
+		}
+	}
+
+	if err := s.jobs.Register(s.runtimePruneJob()); err != nil {
+		log.Printf("Warning: failed to register runtime prune job: %v", err)
+	}
+
+	if s.cfg != nil && s.cfg.HealthCheckInterval > 0 {
+		err := s.jobs.Register(jobs.Job{
+			Name:        "health-check",
+			Description: "Restart failed containers of local deployments",
+			Interval:    s.cfg.HealthCheckInterval,
+			Timeout:     healthCheckJobTimeout,
+			Run:         s.checkDeploymentHealth,
+		})
+		if err != nil {
+			log.Printf("Warning: failed to register health check job: %v", err)
+		}
+	}
+}
+
+// RegisterJob adds a named background job to the registry's scheduler
+func (s *registryServiceImpl) RegisterJob(job jobs.Job) error {
+	return s.jobs.Register(job)
+}
+
+// ListJobs returns the status of all background jobs
+func (s *registryServiceImpl) ListJobs(ctx context.Context) ([]models.JobStatus, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	return s.jobs.List(), nil
+}
+
+// RunJob starts a background job immediately and returns its status
+func (s *registryServiceImpl) RunJob(ctx context.Context, name string) (*models.JobStatus, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	status, err := s.jobs.Trigger(name)
+	if err != nil {
+		return nil, err
+	}
+	actor, _ := auth.ActorFrom(ctx)
+	log.Printf("Job %s triggered by %s", name, actor)
+	return status, nil
+}
+
+// Shutdown fails the readiness check from now on and suspends the background jobs: scheduled reconciliation and
+// health checks stop, and runs in progress get until ctx is done to finish before they are cancelled
+func (s *registryServiceImpl) Shutdown(ctx context.Context) error {
+	s.shuttingDown.Store(true)
+	if err := s.jobs.Drain(ctx); err != nil {
+		return fmt.Errorf("background jobs did not finish in time: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// VerifyNamespace proves that the caller owns a namespace and records the verification. GitHub namespaces
+// (io.github.<owner>) are checked against GitHub organization membership, using githubToken when given and the
+// caller's GitHub login otherwise; all other namespaces against the caller's DNS TXT challenge record.
+func (s *registryServiceImpl) VerifyNamespace(ctx context.Context, ns, githubToken string) (_ *models.VerifiedNamespace, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.VerifyNamespace", attribute.String("namespace", ns))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := namespace.Validate(ns); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	subject, method := auth.ActorFrom(ctx)
+	if subject == "anonymous" || subject == "system" {
+		return nil, auth.ErrUnauthenticated
+	}
+	creds := namespace.Credentials{Subject: subject, GitHubToken: githubToken}
+	if method == auth.MethodGitHubAT {
+		creds.GitHubLogin = subject
+	}
+
+	verifiedBy, err := s.namespaces.Verify(ctx, ns, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.VerifiedNamespace, error) {
+		verified, err := s.db.UpsertVerifiedNamespace(ctx, tx, &models.VerifiedNamespace{
+			Namespace: ns,
+			Subject:   subject,
+			Method:    verifiedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "namespace", ns, "", map[string]any{"method": verifiedBy}); err != nil {
+			return nil, err
+		}
+		return verified, nil
+	})
+}
+
+// GetNamespaceChallenge returns the DNS TXT record the caller must publish to verify a namespace
+func (s *registryServiceImpl) GetNamespaceChallenge(ctx context.Context, ns string) (*models.NamespaceChallenge, error) {
+	if err := namespace.Validate(ns); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	subject, _ := auth.ActorFrom(ctx)
+	if subject == "anonymous" || subject == "system" {
+		return nil, auth.ErrUnauthenticated
+	}
+	recordName, recordValue := namespace.Challenge(ns, subject)
+	return &models.NamespaceChallenge{Namespace: ns, RecordName: recordName, RecordValue: recordValue}, nil
+}
+
+// ListVerifiedNamespaces returns verified namespaces, optionally only those of one namespace or subject
+func (s *registryServiceImpl) ListVerifiedNamespaces(ctx context.Context, ns, subject string) ([]models.VerifiedNamespace, error) {
+	return s.db.ListVerifiedNamespaces(ctx, nil, ns, subject)
+}
+
+// RevokeNamespace removes the verification of a namespace for a subject (admin only)
+func (s *registryServiceImpl) RevokeNamespace(ctx context.Context, ns, subject string) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		if err := s.db.DeleteVerifiedNamespace(txCtx, tx, ns, subject); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, models.AuditActionDelete, "namespace", ns, "", map[string]any{"subject": subject})
+	})
+}
+
+// checkNamespaceOwnership refuses to create an artifact under a namespace verified by other users. Unverified
+// namespaces stay open unless REQUIRE_VERIFIED_NAMESPACES is set. Registry admins may create under any namespace.
+func (s *registryServiceImpl) checkNamespaceOwnership(ctx context.Context, tx pgx.Tx, name string) error {
+	ns := namespace.Of(name)
+	if ns == "" || s.db.IsRegistryAdmin(ctx) {
+		return nil
+	}
+
+	owners, err := s.db.ListVerifiedNamespaces(ctx, tx, ns, "")
+	if err != nil {
+		return fmt.Errorf("failed to check namespace ownership: %w", err)
+	}
+	if len(owners) == 0 {
+		if s.cfg != nil && s.cfg.RequireVerifiedNamespaces {
+			return fmt.Errorf("%w: namespace %s has not been verified; verify it with POST /v0/namespaces/verify", namespace.ErrNotOwned, ns)
+		}
+		return nil
+	}
+
+	subject, _ := auth.ActorFrom(ctx)
+	if slices.ContainsFunc(owners, func(owner models.VerifiedNamespace) bool { return owner.Subject == subject }) {
+		return nil
+	}
+	return fmt.Errorf("%w: namespace %s is verified by another owner", namespace.ErrNotOwned, ns)
+}
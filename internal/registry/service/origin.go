@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// originHTTPClient is used to fetch server manifests from the registry a deployment is pinned to
+var originHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// normalizeOrigin validates a registry base URL used as a deployment origin.
+// Trailing slashes and a trailing /v0 are stripped so equivalent URLs compare equal.
+func normalizeOrigin(origin string) (string, error) {
+	origin = strings.TrimSpace(origin)
+	if origin == "" {
+		return "", nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%w: origin must be an http(s) registry URL, got %q", database.ErrInvalidInput, origin)
+	}
+	origin = strings.TrimRight(origin, "/")
+	origin = strings.TrimSuffix(origin, "/v0")
+	return origin, nil
+}
+
+// resolveDeploymentServer returns the server manifest a deployment runs: from this registry when origin is empty,
+// otherwise from the origin registry the deployment is pinned to.
+func (s *registryServiceImpl) resolveDeploymentServer(ctx context.Context, serverName, version, origin string) (*apiv0.ServerResponse, error) {
+	if origin == "" {
+		return s.GetServerByNameAndVersion(ctx, serverName, version, true)
+	}
+	return fetchOriginServer(ctx, origin, serverName, version)
+}
+
+// fetchOriginServer fetches a server version from another registry's v0 API
+func fetchOriginServer(ctx context.Context, origin, serverName, version string) (*apiv0.ServerResponse, error) {
+	if version == "" {
+		version = "latest"
+	}
+	fetchURL := fmt.Sprintf("%s/v0/servers/%s/versions/%s", origin, url.PathEscape(serverName), url.PathEscape(version))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to origin %s: %w", origin, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := originHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch server %s from origin %s: %w", serverName, origin, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("server %s version %s not found in origin %s: %w", serverName, version, origin, database.ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status %d from origin %s: %s", resp.StatusCode, origin, string(body))
+	}
+
+	var serverResp apiv0.ServerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&serverResp); err != nil {
+		return nil, fmt.Errorf("failed to decode server from origin %s: %w", origin, err)
+	}
+	return &serverResp, nil
+}
@@ -0,0 +1,65 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		origin  string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty means this registry", origin: "", want: ""},
+		{name: "trailing slash", origin: "https://registry.example.com/", want: "https://registry.example.com"},
+		{name: "v0 suffix", origin: "https://registry.example.com/v0", want: "https://registry.example.com"},
+		{name: "whitespace", origin: "  http://localhost:12121  ", want: "http://localhost:12121"},
+		{name: "missing scheme", origin: "registry.example.com", wantErr: true},
+		{name: "unsupported scheme", origin: "ftp://registry.example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeOrigin(tt.origin)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, database.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFetchOriginServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v0/servers/io.github.user%2Fweather/versions/1.0.0" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{
+			Server: apiv0.ServerJSON{Name: "io.github.user/weather", Version: "1.0.0", Description: "from origin"},
+		})
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	resp, err := fetchOriginServer(ctx, srv.URL, "io.github.user/weather", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "from origin", resp.Server.Description)
+
+	_, err = fetchOriginServer(ctx, srv.URL, "io.github.user/weather", "2.0.0")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+const (
+	// outboxBatchSize is how many events one outbox transaction claims and delivers
+	outboxBatchSize = 100
+	// outboxMaxRetryDelay caps the exponential backoff between deliveries of an event that keeps failing
+	outboxMaxRetryDelay = time.Hour
+	// outboxDeliveredRetention is how long delivered events are kept before the dispatcher removes them
+	outboxDeliveredRetention = 24 * time.Hour
+)
+
+// EventHandler receives the registry events drained from the outbox. Events are delivered at least once: when a
+// handler fails, or the registry stops before the delivery is recorded, the event is delivered again to every handler,
+// so handlers must tolerate duplicates.
+type EventHandler func(ctx context.Context, event models.Event) error
+
+// eventHandlers holds the handlers registered via AddEventHandler
+type eventHandlers struct {
+	mu       sync.RWMutex
+	handlers []EventHandler
+}
+
+func (h *eventHandlers) add(handler EventHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers = append(h.handlers, handler)
+}
+
+func (h *eventHandlers) list() []EventHandler {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return append([]EventHandler(nil), h.handlers...)
+}
+
+// AddEventHandler registers a handler that receives every registry event written to the outbox
+func (s *registryServiceImpl) AddEventHandler(handler EventHandler) {
+	if handler != nil {
+		s.events.add(handler)
+	}
+}
+
+// outboxDispatchJob delivers the events written to the outbox to the event handlers
+func (s *registryServiceImpl) outboxDispatchJob() jobs.Job {
+	var interval time.Duration
+	if s.cfg != nil {
+		interval = s.cfg.OutboxDispatchInterval
+	}
+	return jobs.Job{
+		Name:        "outbox-dispatch",
+		Description: "Deliver registry events from the outbox to event handlers",
+		Interval:    interval,
+		RunOnStart:  true,
+		Timeout:     5 * time.Minute,
+		Run:         s.dispatchOutbox,
+	}
+}
+
+// dispatchOutbox delivers every due event in the outbox and removes the events delivered a while ago
+func (s *registryServiceImpl) dispatchOutbox(ctx context.Context) error {
+	for {
+		claimed, err := s.dispatchOutboxBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if claimed < outboxBatchSize {
+			break
+		}
+	}
+
+	if _, err := s.db.DeleteDeliveredOutboxEvents(ctx, nil, time.Now().Add(-outboxDeliveredRetention)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// dispatchOutboxBatch claims a batch of due events and delivers them. The events stay locked until their deliveries
+// are recorded, so other registry instances skip them; if the registry stops in between, they are delivered again.
+func (s *registryServiceImpl) dispatchOutboxBatch(ctx context.Context) (int, error) {
+	handlers := s.events.list()
+	claimed := 0
+	err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		events, err := s.db.ClaimOutboxEvents(ctx, tx, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		claimed = len(events)
+
+		for _, event := range events {
+			if err := deliverEvent(ctx, handlers, event); err != nil {
+				retryAt := time.Now().Add(outboxRetryDelay(event.Attempts + 1))
+				log.Printf("Warning: failed to deliver event %d (%s %s), retrying at %s: %v",
+					event.ID, event.Type, event.ResourceName, retryAt.Format(time.RFC3339), err)
+				if err := s.db.MarkOutboxEventFailed(ctx, tx, event.ID, err.Error(), retryAt); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := s.db.MarkOutboxEventDelivered(ctx, tx, event.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+// deliverEvent hands an event to every handler, failing when any of them fails
+func deliverEvent(ctx context.Context, handlers []EventHandler, event models.Event) error {
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outboxRetryDelay is the backoff before retrying an event whose delivery failed the given number of times
+func outboxRetryDelay(failures int) time.Duration {
+	delay := 5 * time.Second
+	for i := 1; i < failures && delay < outboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxRetryDelay)
+}
+
+// recordEvent writes the event describing an audited change to the outbox, within the change's transaction
+func (s *registryServiceImpl) recordEvent(ctx context.Context, tx pgx.Tx, entry *models.AuditLogEntry) error {
+	return s.db.CreateOutboxEvent(ctx, tx, &models.Event{
+		Type:         fmt.Sprintf("%s.%s", entry.ResourceType, entry.Action),
+		ResourceType: entry.ResourceType,
+		ResourceName: entry.ResourceName,
+		Version:      entry.Version,
+		Actor:        entry.Actor,
+		Details:      entry.Details,
+	})
+}
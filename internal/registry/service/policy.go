@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/policy"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// loadPolicyFile reads DEPLOYMENT_POLICY_FILE. A broken file denies every deployment rather than silently disabling its policies.
+func (s *registryServiceImpl) loadPolicyFile() {
+	if s.cfg == nil || s.cfg.DeploymentPolicyFile == "" {
+		return
+	}
+	policies, err := policy.LoadFile(s.cfg.DeploymentPolicyFile)
+	if err != nil {
+		log.Printf("Error: deployments will be denied until the policy file is fixed: %v", err)
+		s.policyFileErr = err
+		return
+	}
+	s.configPolicies = policies
+}
+
+// ListDeploymentPolicies returns the policies from DEPLOYMENT_POLICY_FILE followed by those managed through the API
+func (s *registryServiceImpl) ListDeploymentPolicies(ctx context.Context) ([]models.DeploymentPolicy, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	return s.activePolicies(ctx)
+}
+
+// PutDeploymentPolicy creates or replaces a deployment policy managed through the API
+func (s *registryServiceImpl) PutDeploymentPolicy(ctx context.Context, p *models.DeploymentPolicy) (*models.DeploymentPolicy, error) {
+	stored := *p
+	if err := policy.Validate(&stored); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if s.isConfigPolicy(stored.Name) {
+		return nil, fmt.Errorf("%w: policy %s is defined in the policy file and cannot be changed through the API", database.ErrInvalidInput, stored.Name)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.DeploymentPolicy, error) {
+		stored.CreatedBy, _ = auth.ActorFrom(ctx)
+		if err := s.db.UpsertDeploymentPolicy(ctx, tx, &stored); err != nil {
+			return nil, err
+		}
+		details := map[string]any{"rule": stored.Rule, "enforcement": stored.Enforcement}
+		if err := s.recordAudit(ctx, tx, models.AuditActionUpdate, "policy", stored.Name, "", details); err != nil {
+			return nil, err
+		}
+		return &stored, nil
+	})
+}
+
+// DeleteDeploymentPolicy removes a deployment policy managed through the API
+func (s *registryServiceImpl) DeleteDeploymentPolicy(ctx context.Context, name string) error {
+	if s.isConfigPolicy(name) {
+		return fmt.Errorf("%w: policy %s is defined in the policy file and cannot be deleted through the API", database.ErrInvalidInput, name)
+	}
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		if err := s.db.DeleteDeploymentPolicy(txCtx, tx, name); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, models.AuditActionDelete, "policy", name, "", nil)
+	})
+}
+
+func (s *registryServiceImpl) isConfigPolicy(name string) bool {
+	return slices.ContainsFunc(s.configPolicies, func(p models.DeploymentPolicy) bool { return p.Name == name })
+}
+
+func (s *registryServiceImpl) activePolicies(ctx context.Context) ([]models.DeploymentPolicy, error) {
+	stored, err := s.db.ListDeploymentPolicies(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	policies := slices.Clone(s.configPolicies)
+	for _, p := range stored {
+		policies = append(policies, *p)
+	}
+	return policies, nil
+}
+
+// checkDeploymentPolicies evaluates the active policies for a deployment, logging warnings and returning an error wrapping
+// policy.ErrDenied when a deny policy is violated
+func (s *registryServiceImpl) checkDeploymentPolicies(ctx context.Context, in policy.Input) error {
+	if s.policyFileErr != nil {
+		return fmt.Errorf("%w: the policy file could not be loaded: %v", policy.ErrDenied, s.policyFileErr)
+	}
+	policies, err := s.activePolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load deployment policies: %w", err)
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	if in.ResourceType == "mcp" && in.Signature == nil {
+		// Servers deployed from another origin are never signed in this registry
+		sig, err := s.db.GetServerSignature(ctx, nil, in.Name, in.Version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return fmt.Errorf("failed to load server signature: %w", err)
+		}
+		in.Signature = sig
+		in.TrustedKey = s.signingKeyTrusted
+	}
+
+	violations := policy.Evaluate(policies, in)
+	for _, v := range violations {
+		if v.Enforcement == models.PolicyEnforcementWarn {
+			log.Printf("Warning: deployment of %s %s@%s violates policy %s: %s", in.ResourceType, in.Name, in.Version, v.Policy, v.Message)
+		}
+	}
+	return policy.DeniedError(violations)
+}
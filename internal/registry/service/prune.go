@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// runtimePruneJobTimeout bounds a background prune, which removes images one at a time
+const runtimePruneJobTimeout = 10 * time.Minute
+
+func (s *registryServiceImpl) runtimePruneJob() jobs.Job {
+	var interval time.Duration
+	if s.cfg != nil {
+		interval = s.cfg.RuntimePruneInterval
+	}
+	return jobs.Job{
+		Name:        "runtime-prune",
+		Description: "Remove images, runtime directories and gateway configs no deployment uses anymore",
+		Interval:    interval,
+		Timeout:     runtimePruneJobTimeout,
+		Run: func(ctx context.Context) error {
+			report, err := s.pruneRuntime(ctx, false)
+			if err != nil {
+				return err
+			}
+			pruned, failed := 0, 0
+			for _, item := range report.Items {
+				switch {
+				case item.Error != "":
+					log.Printf("Failed to prune %s %s: %s", item.Kind, item.Name, item.Error)
+					failed++
+				case item.Reclaimed():
+					pruned++
+				}
+			}
+			if pruned > 0 {
+				log.Printf("Pruned %d runtime artifact(s), reclaiming %d bytes", pruned, report.ReclaimedBytes)
+			}
+			if failed > 0 {
+				return fmt.Errorf("failed to prune %d runtime artifact(s)", failed)
+			}
+			return nil
+		},
+	}
+}
+
+// PruneRuntime removes the runtime artifacts no deployment uses anymore, or only reports them in a dry run. Only
+// registry admins may prune.
+func (s *registryServiceImpl) PruneRuntime(ctx context.Context, dryRun bool) (*models.PruneReport, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	report, err := s.pruneRuntime(ctx, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		actor, _ := auth.ActorFrom(ctx)
+		log.Printf("Runtime pruned by %s: %d artifact(s), %d bytes reclaimed", actor, len(report.Items), report.ReclaimedBytes)
+	}
+	return report, nil
+}
+
+func (s *registryServiceImpl) pruneRuntime(ctx context.Context, dryRun bool) (*models.PruneReport, error) {
+	deployments, err := s.db.GetDeployments(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	// Agents write their MCP config below the runtime directory of their target; kubernetes targets use the base one
+	agentConfigDirs := map[string]bool{}
+	for _, d := range deployments {
+		if d.ResourceType != "agent" {
+			continue
+		}
+		target, err := s.deploymentTarget(d)
+		if err != nil {
+			continue
+		}
+		dir := s.runtimeDir()
+		if target.Type == runtime.TargetTypeDocker {
+			dir = target.RuntimeDir(dir)
+		}
+		agentConfigDirs[runtime.AgentConfigDir(dir, d.ServerName, d.Version)] = true
+	}
+
+	return runtime.Prune(ctx, runtime.PruneInput{
+		BaseDir:         s.runtimeDir(),
+		Targets:         s.targets,
+		AgentConfigDirs: agentConfigDirs,
+		DryRun:          dryRun,
+	})
+}
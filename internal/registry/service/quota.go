@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// ErrQuotaExceeded is returned when an operation would go over the deployments of QUOTA_MAX_DEPLOYMENTS or the
+// servers of QUOTA_MAX_SERVERS_PER_NAMESPACE
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// ErrPublishQuotaExceeded is returned when a user already published QUOTA_MAX_PUBLISHES_PER_DAY versions in the last
+// 24 hours; publishing succeeds again once older versions leave the window
+var ErrPublishQuotaExceeded = errors.New("publish quota exceeded")
+
+// publishQuotaWindow is the rolling window of QUOTA_MAX_PUBLISHES_PER_DAY
+const publishQuotaWindow = 24 * time.Hour
+
+// publishedResourceTypes are the resource types of the audit entries counted against QUOTA_MAX_PUBLISHES_PER_DAY
+var publishedResourceTypes = []string{"mcp", "agent", "skill"}
+
+// GetQuota reports the quotas that apply to the caller and their usage. With a namespace, it also counts the servers
+// published under it.
+func (s *registryServiceImpl) GetQuota(ctx context.Context, ns string) (*models.Quota, error) {
+	workspace, actor, scope := quotaScope(ctx)
+	quota := &models.Quota{Scope: scope, Exempt: s.db.IsRegistryAdmin(ctx), Namespace: ns}
+	if s.cfg != nil {
+		quota.Deployments.Limit = s.cfg.QuotaMaxDeployments
+		quota.PublishesPerDay.Limit = s.cfg.QuotaMaxPublishesPerDay
+		quota.ServersPerNamespace.Limit = s.cfg.QuotaMaxServersPerNamespace
+	}
+
+	var err error
+	if quota.Deployments.Used, err = s.db.CountDeploymentsInScope(ctx, nil, workspace, actor); err != nil {
+		return nil, err
+	}
+	since := time.Now().Add(-publishQuotaWindow)
+	if quota.PublishesPerDay.Used, err = s.db.CountAuditLogEntriesSince(ctx, nil, actor, models.AuditActionCreate, publishedResourceTypes, since); err != nil {
+		return nil, err
+	}
+	if ns != "" {
+		if quota.ServersPerNamespace.Used, err = s.db.CountServersInNamespace(ctx, nil, ns); err != nil {
+			return nil, err
+		}
+	}
+	return quota, nil
+}
+
+// quotaScope returns the workspace and actor of a request, and the scope its deployments are counted against
+func quotaScope(ctx context.Context) (workspace, actor, scope string) {
+	workspace = auth.WorkspaceFrom(ctx)
+	actor, _ = auth.ActorFrom(ctx)
+	if workspace != "" {
+		return workspace, actor, "workspace:" + workspace
+	}
+	return "", actor, "user:" + actor
+}
+
+// checkDeploymentQuota fails with ErrQuotaExceeded when the caller's workspace, or the caller outside any workspace,
+// already has QUOTA_MAX_DEPLOYMENTS deployments
+func (s *registryServiceImpl) checkDeploymentQuota(ctx context.Context) error {
+	if s.cfg == nil || s.cfg.QuotaMaxDeployments <= 0 || s.db.IsRegistryAdmin(ctx) {
+		return nil
+	}
+	workspace, actor, scope := quotaScope(ctx)
+	used, err := s.db.CountDeploymentsInScope(ctx, nil, workspace, actor)
+	if err != nil {
+		return fmt.Errorf("failed to check deployment quota: %w", err)
+	}
+	usage := models.QuotaUsage{Limit: s.cfg.QuotaMaxDeployments, Used: used}
+	if usage.Exceeded() {
+		return fmt.Errorf("%w: %s already has %d of %d deployments; remove one before deploying another", ErrQuotaExceeded, scope, usage.Used, usage.Limit)
+	}
+	return nil
+}
+
+// checkPublishQuota fails with ErrPublishQuotaExceeded when the caller published QUOTA_MAX_PUBLISHES_PER_DAY versions
+// in the last 24 hours. Versions created earlier in tx, such as by a batch, count too.
+func (s *registryServiceImpl) checkPublishQuota(ctx context.Context, tx pgx.Tx) error {
+	if s.cfg == nil || s.cfg.QuotaMaxPublishesPerDay <= 0 || s.db.IsRegistryAdmin(ctx) {
+		return nil
+	}
+	actor, _ := auth.ActorFrom(ctx)
+	used, err := s.db.CountAuditLogEntriesSince(ctx, tx, actor, models.AuditActionCreate, publishedResourceTypes, time.Now().Add(-publishQuotaWindow))
+	if err != nil {
+		return fmt.Errorf("failed to check publish quota: %w", err)
+	}
+	usage := models.QuotaUsage{Limit: s.cfg.QuotaMaxPublishesPerDay, Used: used}
+	if usage.Exceeded() {
+		return fmt.Errorf("%w: %s published %d of %d versions allowed in 24 hours", ErrPublishQuotaExceeded, actor, usage.Used, usage.Limit)
+	}
+	return nil
+}
+
+// checkNamespaceServerQuota fails with ErrQuotaExceeded when publishing a new server would put more than
+// QUOTA_MAX_SERVERS_PER_NAMESPACE servers under its namespace
+func (s *registryServiceImpl) checkNamespaceServerQuota(ctx context.Context, tx pgx.Tx, serverName string) error {
+	ns := namespace.Of(serverName)
+	if ns == "" || s.cfg == nil || s.cfg.QuotaMaxServersPerNamespace <= 0 || s.db.IsRegistryAdmin(ctx) {
+		return nil
+	}
+	used, err := s.db.CountServersInNamespace(ctx, tx, ns)
+	if err != nil {
+		return fmt.Errorf("failed to check namespace quota: %w", err)
+	}
+	usage := models.QuotaUsage{Limit: s.cfg.QuotaMaxServersPerNamespace, Used: used}
+	if usage.Exceeded() {
+		return fmt.Errorf("%w: namespace %s already has %d of %d servers", ErrQuotaExceeded, ns, usage.Used, usage.Limit)
+	}
+	return nil
+}
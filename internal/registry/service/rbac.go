@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateRoleBinding grants a role on a namespace to a subject
+func (s *registryServiceImpl) CreateRoleBinding(ctx context.Context, binding *models.RoleBinding) (*models.RoleBinding, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.RoleBinding, error) {
+		created := *binding
+		created.CreatedBy, _ = auth.ActorFrom(ctx)
+		if err := s.db.CreateRoleBinding(ctx, tx, &created); err != nil {
+			return nil, err
+		}
+		details := map[string]any{"subject": created.Subject, "role": created.Role}
+		if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "role", created.Namespace, "", details); err != nil {
+			return nil, err
+		}
+		return &created, nil
+	})
+}
+
+// ListRoleBindings lists role bindings, optionally filtered by subject
+func (s *registryServiceImpl) ListRoleBindings(ctx context.Context, subject *string) ([]*models.RoleBinding, error) {
+	return s.db.ListRoleBindings(ctx, nil, subject)
+}
+
+// DeleteRoleBinding removes a role binding by ID
+func (s *registryServiceImpl) DeleteRoleBinding(ctx context.Context, id int64) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		deleted, err := s.db.DeleteRoleBinding(txCtx, tx, id)
+		if err != nil {
+			return err
+		}
+		details := map[string]any{"id": strconv.FormatInt(id, 10), "subject": deleted.Subject, "role": deleted.Role}
+		return s.recordAudit(txCtx, tx, models.AuditActionDelete, "role", deleted.Namespace, "", details)
+	})
+}
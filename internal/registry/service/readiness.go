@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// readinessCheckTimeout bounds each component check, so a hanging dependency fails the probe instead of stalling it
+const readinessCheckTimeout = 3 * time.Second
+
+// readinessCheck checks one component and returns details to report when it passes
+type readinessCheck struct {
+	name string
+	run  func(context.Context) (string, error)
+}
+
+// CheckReadiness checks the database connection and the schema migrations and, when checkRuntime is set, that the
+// container engine of the local runtime is reachable
+func (s *registryServiceImpl) CheckReadiness(ctx context.Context, checkRuntime bool) *models.Readiness {
+	checks := []readinessCheck{
+		{"database", s.checkDatabase},
+		{"migrations", s.checkMigrations},
+	}
+	if checkRuntime {
+		checks = append(checks, readinessCheck{"runtime", checkRuntimeEngine})
+	}
+
+	readiness := &models.Readiness{Status: models.ReadinessStatusOK, CheckedAt: time.Now()}
+	// A registry that is shutting down still serves requests in flight but should not receive new ones
+	if s.shuttingDown.Load() {
+		readiness.Status = models.ReadinessStatusFail
+		readiness.Components = append(readiness.Components, models.ComponentStatus{
+			Name:    "server",
+			Status:  models.ReadinessStatusFail,
+			Message: "registry is shutting down",
+		})
+	}
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+		start := time.Now()
+		message, err := check.run(checkCtx)
+		cancel()
+
+		component := models.ComponentStatus{
+			Name:      check.name,
+			Status:    models.ReadinessStatusOK,
+			Message:   message,
+			LatencyMs: time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			component.Status = models.ReadinessStatusFail
+			component.Message = err.Error()
+			readiness.Status = models.ReadinessStatusFail
+		}
+		readiness.Components = append(readiness.Components, component)
+	}
+	return readiness
+}
+
+func (s *registryServiceImpl) checkDatabase(ctx context.Context) (string, error) {
+	if err := s.db.Ping(ctx); err != nil {
+		return "", fmt.Errorf("database is not reachable: %w", err)
+	}
+	return "", nil
+}
+
+// checkMigrations fails while migrations are pending, e.g. when a newer replica has not finished migrating the
+// schema this one expects
+func (s *registryServiceImpl) checkMigrations(ctx context.Context) (string, error) {
+	statuses, err := s.db.MigrationStatus(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read migration status: %w", err)
+	}
+	latest, pending := 0, 0
+	for _, status := range statuses {
+		if !status.Applied {
+			pending++
+			continue
+		}
+		latest = max(latest, status.Version)
+	}
+	if pending > 0 {
+		return "", fmt.Errorf("%d migration(s) pending", pending)
+	}
+	return fmt.Sprintf("schema version %d", latest), nil
+}
+
+func checkRuntimeEngine(ctx context.Context) (string, error) {
+	version, err := runtime.PingContainerEngine(ctx)
+	if err != nil {
+		return "", fmt.Errorf("container engine is not reachable: %w", err)
+	}
+	return "server " + version, nil
+}
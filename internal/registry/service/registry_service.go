@@ -6,21 +6,31 @@ import (
 	"fmt"
 	"log"
 	"maps"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/blobstore"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/embeddings"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/policy"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/vulnscan"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime"
-	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/dockercompose"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/secrets"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/jackc/pgx/v5"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"go.opentelemetry.io/otel/attribute"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,6 +42,28 @@ type registryServiceImpl struct {
 	db                 database.Database
 	cfg                *config.Config
 	embeddingsProvider embeddings.Provider
+	imageScanner       vulnscan.Scanner
+	jobs               *jobs.Scheduler
+	secrets            *secrets.Resolver
+	// configPolicies are the deployment policies from DEPLOYMENT_POLICY_FILE
+	configPolicies []models.DeploymentPolicy
+	policyFileErr  error
+	// health counts automatic restarts of failing local deployments
+	health healthTracker
+	// introspectSlots limits concurrent capability introspections to INTROSPECT_CONCURRENCY
+	introspectSlots chan struct{}
+	// targets are the deployment targets: the built-in ones and those from DEPLOYMENT_TARGETS_FILE
+	targets runtime.Targets
+	// namespaces verifies namespace ownership against GitHub and DNS
+	namespaces *namespace.Verifier
+	// shuttingDown is set once Shutdown is called and fails the readiness check
+	shuttingDown atomic.Bool
+	// blobs is the blob storage of BLOB_STORAGE; nil disables the blob APIs
+	blobs *blobstore.Store
+	// imports cancels the imports started by this process
+	imports importTracker
+	// events receive the registry events drained from the outbox
+	events eventHandlers
 }
 
 // NewRegistryService creates a new registry service with the provided database and configuration
@@ -40,11 +72,38 @@ func NewRegistryService(
 	cfg *config.Config,
 	embeddingProvider embeddings.Provider,
 ) RegistryService {
-	return &registryServiceImpl{
+	svc := &registryServiceImpl{
 		db:                 db,
 		cfg:                cfg,
 		embeddingsProvider: embeddingProvider,
+		jobs:               jobs.NewScheduler(auth.WithSystemContext),
+		secrets:            secrets.NewResolver(),
+		namespaces:         namespace.NewVerifier(),
+	}
+	svc.registerBuiltinJobs()
+	introspectConcurrency := 1
+	if cfg != nil && cfg.IntrospectConcurrency > 0 {
+		introspectConcurrency = cfg.IntrospectConcurrency
+	}
+	svc.introspectSlots = make(chan struct{}, introspectConcurrency)
+	if cfg != nil {
+		scanner, err := vulnscan.New(cfg.ImageScanner)
+		if err != nil {
+			log.Printf("Warning: image scanning disabled: %v", err)
+		}
+		svc.imageScanner = scanner
+
+		if cfg.BlobStorage != "" {
+			blobs, err := blobstore.New(cfg.BlobStorage)
+			if err != nil {
+				log.Printf("Warning: blob storage disabled: %v", err)
+			}
+			svc.blobs = blobs
+		}
 	}
+	svc.loadPolicyFile()
+	svc.loadTargets()
+	return svc
 }
 
 // ListServers returns registry entries with cursor-based pagination and optional filtering
@@ -100,10 +159,20 @@ func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, se
 }
 
 // CreateServer creates a new server version
-func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (_ *apiv0.ServerResponse, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.CreateServer", telemetry.ResourceAttributes("mcp", req.Name, req.Version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// Wrap the entire operation in a transaction
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.createServerInTransaction(ctx, tx, req)
+		created, err := s.createServerInTransaction(ctx, tx, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "mcp", created.Server.Name, created.Server.Version, nil); err != nil {
+			return nil, err
+		}
+		return created, nil
 	})
 }
 
@@ -113,14 +182,24 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
 		return nil, err
 	}
+	if err := validateChangelog(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkNamespaceOwnership(ctx, tx, req.Name); err != nil {
+		return nil, err
+	}
 
 	publishTime := time.Now()
 	serverJSON := *req
+	models.TagServer(&serverJSON)
 
 	// Acquire advisory lock to prevent concurrent publishes of the same server
 	if err := s.db.AcquirePublishLock(ctx, tx, serverJSON.Name); err != nil {
 		return nil, err
 	}
+	if err := s.checkPublishQuota(ctx, tx); err != nil {
+		return nil, err
+	}
 
 	// Check for duplicate remote URLs
 	if err := s.validateNoDuplicateRemoteURLs(ctx, tx, serverJSON); err != nil {
@@ -135,6 +214,11 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	if versionCount >= maxServerVersionsPerServer {
 		return nil, database.ErrMaxServersReached
 	}
+	if versionCount == 0 {
+		if err := s.checkNamespaceServerQuota(ctx, tx, serverJSON.Name); err != nil {
+			return nil, err
+		}
+	}
 
 	// Check this isn't a duplicate version
 	versionExists, err := s.db.CheckVersionExists(ctx, tx, serverJSON.Name, serverJSON.Version)
@@ -240,16 +324,37 @@ func (s *registryServiceImpl) GetAllVersionsBySkillName(ctx context.Context, ski
 }
 
 // CreateSkill creates a new skill version
-func (s *registryServiceImpl) CreateSkill(ctx context.Context, req *models.SkillJSON) (*models.SkillResponse, error) {
+func (s *registryServiceImpl) CreateSkill(ctx context.Context, req *models.SkillJSON) (_ *models.SkillResponse, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.CreateSkill", telemetry.ResourceAttributes("skill", req.Name, req.Version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.SkillResponse, error) {
-		return s.createSkillInTransaction(ctx, tx, req)
+		created, err := s.createSkillInTransaction(ctx, tx, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "skill", created.Skill.Name, created.Skill.Version, nil); err != nil {
+			return nil, err
+		}
+		return created, nil
 	})
 }
 
 func (s *registryServiceImpl) createSkillInTransaction(ctx context.Context, tx pgx.Tx, req *models.SkillJSON) (*models.SkillResponse, error) {
-	// Basic validation: ensure required fields present
-	if req == nil || req.Name == "" || req.Version == "" {
-		return nil, fmt.Errorf("invalid skill payload: name and version are required")
+	if err := validators.ValidateSkillJSON(req); err != nil {
+		return nil, err
+	}
+	if err := validators.ValidateDocumentSize("skill", req, s.documentMaxBytes()); err != nil {
+		return nil, err
+	}
+	if err := validators.ValidateSkillURLs(ctx, req, validators.URLPolicyFromConfig(s.cfg)); err != nil {
+		return nil, err
+	}
+	if err := s.validateSkillDependencies(ctx, tx, req); err != nil {
+		return nil, err
+	}
+	if err := s.checkNamespaceOwnership(ctx, tx, req.Name); err != nil {
+		return nil, err
 	}
 
 	publishTime := time.Now()
@@ -259,6 +364,9 @@ func (s *registryServiceImpl) createSkillInTransaction(ctx context.Context, tx p
 	if err := s.db.AcquirePublishLock(ctx, tx, skillJSON.Name); err != nil {
 		return nil, err
 	}
+	if err := s.checkPublishQuota(ctx, tx); err != nil {
+		return nil, err
+	}
 
 	// Check duplicate remote URLs among skills
 	for _, remote := range skillJSON.Remotes {
@@ -327,24 +435,47 @@ func (s *registryServiceImpl) createSkillInTransaction(ctx context.Context, tx p
 }
 
 // PublishSkill marks a skill as published
-func (s *registryServiceImpl) PublishSkill(ctx context.Context, skillName, version string) error {
+func (s *registryServiceImpl) PublishSkill(ctx context.Context, skillName, version string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.PublishSkill", telemetry.ResourceAttributes("skill", skillName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.PublishSkill(txCtx, tx, skillName, version)
+		if err := s.db.PublishSkill(txCtx, tx, skillName, version); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, models.AuditActionPublish, "skill", skillName, version, nil)
 	})
 }
 
 // UnpublishSkill marks a skill as unpublished
 func (s *registryServiceImpl) UnpublishSkill(ctx context.Context, skillName, version string) error {
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.UnpublishSkill(txCtx, tx, skillName, version)
+		if err := s.db.UnpublishSkill(txCtx, tx, skillName, version); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, models.AuditActionUnpublish, "skill", skillName, version, nil)
 	})
 }
 
 // UpdateServer updates an existing server with new details
-func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (_ *apiv0.ServerResponse, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.UpdateServer", telemetry.ResourceAttributes("mcp", serverName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// Wrap the entire operation in a transaction
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus)
+		updated, err := s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus)
+		if err != nil {
+			return nil, err
+		}
+		var details map[string]any
+		if newStatus != nil {
+			details = map[string]any{"status": *newStatus}
+		}
+		if err := s.recordAudit(ctx, tx, models.AuditActionUpdate, "mcp", serverName, version, details); err != nil {
+			return nil, err
+		}
+		return updated, nil
 	})
 }
 
@@ -375,6 +506,7 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 
 	// Merge the request with the current server, preserving metadata
 	updatedServer := *req
+	models.TagServer(&updatedServer)
 
 	// Check for duplicate remote URLs using the updated server
 	if err := s.validateNoDuplicateRemoteURLs(ctx, tx, updatedServer); err != nil {
@@ -403,6 +535,9 @@ func (s *registryServiceImpl) StoreServerReadme(ctx context.Context, serverName,
 	if len(content) == 0 {
 		return nil
 	}
+	if err := validators.ValidateReadmeSize(content, s.readmeMaxBytes()); err != nil {
+		return err
+	}
 	if contentType == "" {
 		contentType = "text/markdown"
 	}
@@ -429,6 +564,22 @@ func (s *registryServiceImpl) StoreServerReadme(ctx context.Context, serverName,
 	})
 }
 
+// documentMaxBytes is the DOCUMENT_MAX_BYTES limit of server.json, agent and skill documents; 0 is unlimited
+func (s *registryServiceImpl) documentMaxBytes() int {
+	if s.cfg == nil {
+		return 0
+	}
+	return s.cfg.DocumentMaxBytes
+}
+
+// readmeMaxBytes is the README_MAX_BYTES limit of uploaded READMEs; 0 is unlimited
+func (s *registryServiceImpl) readmeMaxBytes() int {
+	if s.cfg == nil {
+		return 0
+	}
+	return s.cfg.ReadmeMaxBytes
+}
+
 func (s *registryServiceImpl) GetServerReadmeLatest(ctx context.Context, serverName string) (*database.ServerReadme, error) {
 	return s.db.GetLatestServerReadme(ctx, nil, serverName)
 }
@@ -438,43 +589,113 @@ func (s *registryServiceImpl) GetServerReadmeByVersion(ctx context.Context, serv
 }
 
 // PublishServer marks a server as published
-func (s *registryServiceImpl) PublishServer(ctx context.Context, serverName, version string) error {
-	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.PublishServer(txCtx, tx, serverName, version)
+func (s *registryServiceImpl) PublishServer(ctx context.Context, serverName, version string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.PublishServer", telemetry.ResourceAttributes("mcp", serverName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	err = s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		return s.publishServerInTransaction(txCtx, tx, serverName, version)
 	})
+	if err != nil {
+		return err
+	}
+	s.scanServerImagesInBackground(ctx, serverName, version)
+	s.introspectServerInBackground(ctx, serverName, version)
+	return nil
+}
+
+func (s *registryServiceImpl) publishServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if err := s.checkServerSignaturePolicy(ctx, tx, serverName, version); err != nil {
+		return err
+	}
+	if err := s.db.PublishServer(ctx, tx, serverName, version); err != nil {
+		return err
+	}
+	return s.recordAudit(ctx, tx, models.AuditActionPublish, "mcp", serverName, version, nil)
 }
 
 // UnpublishServer marks a server as unpublished
 func (s *registryServiceImpl) UnpublishServer(ctx context.Context, serverName, version string) error {
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		// Check if the server is currently deployed
-		deployment, err := s.db.GetDeploymentByNameAndVersion(txCtx, tx, serverName, version, "mcp")
-		if err != nil && !errors.Is(err, database.ErrNotFound) {
-			return fmt.Errorf("failed to check deployment status: %w", err)
-		}
+		return s.unpublishServerInTransaction(txCtx, tx, serverName, version)
+	})
+}
 
-		// If deployed (record exists) and it's the same version being unpublished, prevent unpublish
-		if deployment != nil && deployment.Version == version {
-			return fmt.Errorf("cannot unpublish deployed server %s (version %s): server must be removed from deployment first", serverName, version)
-		}
+func (s *registryServiceImpl) unpublishServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	// Check if the server is currently deployed
+	deployment, err := s.db.GetDeploymentByNameAndVersion(ctx, tx, serverName, version, "mcp")
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("failed to check deployment status: %w", err)
+	}
+
+	// If deployed (record exists) and it's the same version being unpublished, prevent unpublish
+	if deployment != nil && deployment.Version == version {
+		return fmt.Errorf("cannot unpublish deployed server %s (version %s): server must be removed from deployment first", serverName, version)
+	}
+
+	if err := s.db.UnpublishServer(ctx, tx, serverName, version); err != nil {
+		return err
+	}
+	return s.recordAudit(ctx, tx, models.AuditActionUnpublish, "mcp", serverName, version, nil)
+}
 
-		return s.db.UnpublishServer(txCtx, tx, serverName, version)
+// GetServerVersionStatuses retrieves the status and published flag of every version of a server
+func (s *registryServiceImpl) GetServerVersionStatuses(ctx context.Context, serverName string) ([]*models.ServerVersionStatus, error) {
+	return s.db.ListServerVersionStatuses(ctx, nil, serverName)
+}
+
+// SetServerVersionsPublished publishes or unpublishes several versions of a server.
+// The change is atomic: if any version fails, none of them are modified.
+func (s *registryServiceImpl) SetServerVersionsPublished(ctx context.Context, serverName string, versions []string, published bool) error {
+	if len(versions) == 0 {
+		return fmt.Errorf("%w: at least one version is required", database.ErrInvalidInput)
+	}
+
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		for _, version := range versions {
+			var err error
+			if published {
+				err = s.publishServerInTransaction(txCtx, tx, serverName, version)
+			} else {
+				err = s.unpublishServerInTransaction(txCtx, tx, serverName, version)
+			}
+			if err != nil {
+				return fmt.Errorf("version %s: %w", version, err)
+			}
+		}
+		return nil
 	})
 }
 
-// DeleteServer permanently removes a server version from the registry
+// DeleteServer moves a server version to the trash, from which it can be restored until it is purged
 func (s *registryServiceImpl) DeleteServer(ctx context.Context, serverName, version string) error {
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.DeleteServer(txCtx, tx, serverName, version)
+		if err := s.db.AcquirePublishLock(txCtx, tx, serverName); err != nil {
+			return err
+		}
+		actor, _ := auth.ActorFrom(txCtx)
+		if err := s.db.TrashServer(txCtx, tx, serverName, version, actor); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, models.AuditActionDelete, "mcp", serverName, version, nil)
 	})
 }
 
 // validateUpdateRequest validates an update request with optional registry validation skipping
 func (s *registryServiceImpl) validateUpdateRequest(ctx context.Context, req apiv0.ServerJSON, skipRegistryValidation bool) error {
+	if err := validators.ValidateDocumentSize("server.json", req, s.documentMaxBytes()); err != nil {
+		return err
+	}
 	// Always validate the server JSON structure
 	if err := validators.ValidateServerJSON(&req); err != nil {
 		return err
 	}
+	if err := validators.ValidateServerURLs(ctx, req, validators.URLPolicyFromConfig(s.cfg)); err != nil {
+		return err
+	}
+	if err := validateChangelog(&req); err != nil {
+		return err
+	}
 
 	// Skip registry validation if requested (for deleted servers)
 	if skipRegistryValidation || !s.cfg.EnableRegistryValidation {
@@ -528,25 +749,47 @@ func (s *registryServiceImpl) GetAllVersionsByAgentName(ctx context.Context, age
 }
 
 // CreateAgent creates a new agent version
-func (s *registryServiceImpl) CreateAgent(ctx context.Context, req *models.AgentJSON) (*models.AgentResponse, error) {
+func (s *registryServiceImpl) CreateAgent(ctx context.Context, req *models.AgentJSON) (_ *models.AgentResponse, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.CreateAgent", telemetry.ResourceAttributes("agent", req.Name, req.Version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.AgentResponse, error) {
-		return s.createAgentInTransaction(ctx, tx, req)
+		created, err := s.createAgentInTransaction(ctx, tx, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "agent", created.Agent.Name, created.Agent.Version, nil); err != nil {
+			return nil, err
+		}
+		return created, nil
 	})
 }
 
 func (s *registryServiceImpl) createAgentInTransaction(ctx context.Context, tx pgx.Tx, req *models.AgentJSON) (*models.AgentResponse, error) {
-	// Basic validation: ensure required fields present
-	if req == nil || req.Name == "" || req.Version == "" {
-		return nil, fmt.Errorf("invalid agent payload: name and version are required")
+	if err := validators.ValidateAgentJSON(req); err != nil {
+		return nil, err
+	}
+	if err := validators.ValidateDocumentSize("agent", req, s.documentMaxBytes()); err != nil {
+		return nil, err
+	}
+	if err := validators.ValidateAgentURLs(ctx, req, validators.URLPolicyFromConfig(s.cfg)); err != nil {
+		return nil, err
+	}
+	if err := s.checkNamespaceOwnership(ctx, tx, req.Name); err != nil {
+		return nil, err
 	}
 
 	publishTime := time.Now()
 	agentJSON := *req
+	models.TagAgent(&agentJSON)
 
 	// Acquire advisory lock per agent name
 	if err := s.db.AcquirePublishLock(ctx, tx, agentJSON.Name); err != nil {
 		return nil, err
 	}
+	if err := s.checkPublishQuota(ctx, tx); err != nil {
+		return nil, err
+	}
 
 	// Check duplicate remote URLs among agents
 	for _, remote := range agentJSON.Remotes {
@@ -615,23 +858,35 @@ func (s *registryServiceImpl) createAgentInTransaction(ctx context.Context, tx p
 }
 
 // PublishAgent marks an agent as published
-func (s *registryServiceImpl) PublishAgent(ctx context.Context, agentName, version string) error {
+func (s *registryServiceImpl) PublishAgent(ctx context.Context, agentName, version string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.PublishAgent", telemetry.ResourceAttributes("agent", agentName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.PublishAgent(txCtx, tx, agentName, version)
+		if err := s.db.PublishAgent(txCtx, tx, agentName, version); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, models.AuditActionPublish, "agent", agentName, version, nil)
 	})
 }
 
 // UnpublishAgent marks an agent as unpublished
 func (s *registryServiceImpl) UnpublishAgent(ctx context.Context, agentName, version string) error {
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.UnpublishAgent(txCtx, tx, agentName, version)
+		if err := s.db.UnpublishAgent(txCtx, tx, agentName, version); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, models.AuditActionUnpublish, "agent", agentName, version, nil)
 	})
 }
 
 // DeleteAgent permanently removes an agent version from the registry
 func (s *registryServiceImpl) DeleteAgent(ctx context.Context, agentName, version string) error {
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.DeleteAgent(txCtx, tx, agentName, version)
+		if err := s.db.DeleteAgent(txCtx, tx, agentName, version); err != nil {
+			return err
+		}
+		return s.recordAudit(txCtx, tx, models.AuditActionDelete, "agent", agentName, version, nil)
 	})
 }
 
@@ -673,12 +928,19 @@ func (s *registryServiceImpl) GetDeployments(ctx context.Context, filter *models
 			if filter.ResourceType != nil && d.ResourceType != *filter.ResourceType {
 				continue
 			}
+			if filter.Workspace != nil && d.Workspace != *filter.Workspace {
+				continue
+			}
 		}
 		deployments = append(deployments, d)
 	}
 
-	// If runtime filter includes kubernetes (or no filter i.e. default), fetch from K8s
+	// If runtime filter includes kubernetes (or no filter i.e. default), fetch from K8s. External resources belong to
+	// no workspace, so they are left out of workspace listings.
 	includeK8s := filter == nil || filter.Runtime == nil || *filter.Runtime == "kubernetes"
+	if filter != nil && filter.Workspace != nil {
+		includeK8s = false
+	}
 	if includeK8s {
 		// Use empty namespace to list all (or default)
 		k8sResources, err := s.listKubernetesDeployments(ctx, "")
@@ -713,9 +975,23 @@ func (s *registryServiceImpl) IsServerPublished(ctx context.Context, serverName,
 	return s.db.IsServerPublished(ctx, nil, serverName, version)
 }
 
-// DeployServer deploys a server with configuration
-func (s *registryServiceImpl) DeployServer(ctx context.Context, serverName, version string, config map[string]string, preferRemote bool, runtimeTarget string) (*models.Deployment, error) {
-	serverResp, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version, true)
+// DeployServer deploys a server with configuration. runtimeTarget is a runtime (local, kubernetes) or the name of a
+// deployment target. When origin is set, the manifest is resolved from that registry instead of this one, now and on
+// every reconcile.
+func (s *registryServiceImpl) DeployServer(ctx context.Context, serverName, version string, config map[string]string, preferRemote bool, runtimeTarget string, origin string) (_ *models.Deployment, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.DeployServer", append(telemetry.ResourceAttributes("mcp", serverName, version), attribute.String("deployment.runtime", runtimeTarget))...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	target, err := s.resolveDeploymentTarget(runtimeTarget)
+	if err != nil {
+		return nil, err
+	}
+	origin, err = normalizeOrigin(origin)
+	if err != nil {
+		return nil, err
+	}
+
+	serverResp, err := s.resolveDeploymentServer(ctx, serverName, version, origin)
 	if err != nil {
 		if errors.Is(err, database.ErrNotFound) {
 			return nil, fmt.Errorf("server %s not found in registry: %w", serverName, database.ErrNotFound)
@@ -723,6 +999,26 @@ func (s *registryServiceImpl) DeployServer(ctx context.Context, serverName, vers
 		return nil, fmt.Errorf("failed to verify server: %w", err)
 	}
 
+	if err := secrets.ValidateConfig(config); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if err := registry.ValidateResourceConfig(config); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if err := s.checkDeploymentPolicies(ctx, policy.Input{
+		ResourceType: "mcp",
+		Name:         serverName,
+		Version:      serverResp.Server.Version,
+		Server:       &serverResp.Server,
+		Config:       config,
+		PreferRemote: preferRemote,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.checkDeploymentQuota(ctx); err != nil {
+		return nil, err
+	}
+
 	deployment := &models.Deployment{
 		ServerName:   serverName,
 		Version:      serverResp.Server.Version,
@@ -730,7 +1026,9 @@ func (s *registryServiceImpl) DeployServer(ctx context.Context, serverName, vers
 		Config:       config,
 		PreferRemote: preferRemote,
 		ResourceType: "mcp",
-		Runtime:      runtimeTarget,
+		Runtime:      target.Runtime(),
+		Target:       target.Name,
+		Origin:       origin,
 		DeployedAt:   time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -752,12 +1050,29 @@ func (s *registryServiceImpl) DeployServer(ctx context.Context, serverName, vers
 		return nil, fmt.Errorf("deployment created but reconciliation failed: %w", err)
 	}
 
+	details := map[string]any{"runtime": target.Runtime(), "target": target.Name, "preferRemote": preferRemote}
+	if origin != "" {
+		details["origin"] = origin
+	}
+	s.recordAuditBestEffort(ctx, models.AuditActionDeploy, "mcp", serverName, deployment.Version, details)
+	s.recordRevisionBestEffort(ctx, deployment, models.DeploymentChangeDeploy)
+	s.recordUsageBestEffort(ctx, "mcp", serverName, models.UsageEventDeploy)
+
 	// Return the created deployment
 	return s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, version, "mcp")
 }
 
-// DeployAgent deploys an agent with configuration
-func (s *registryServiceImpl) DeployAgent(ctx context.Context, agentName, version string, config map[string]string, preferRemote bool, runtimeTarget string) (*models.Deployment, error) {
+// DeployAgent deploys an agent with configuration. runtimeTarget is a runtime (local, kubernetes) or the name of a
+// deployment target; the registry-type MCP servers of the agent are deployed to the same target.
+func (s *registryServiceImpl) DeployAgent(ctx context.Context, agentName, version string, config map[string]string, preferRemote bool, runtimeTarget string) (_ *models.Deployment, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.DeployAgent", append(telemetry.ResourceAttributes("agent", agentName, version), attribute.String("deployment.runtime", runtimeTarget))...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	target, err := s.resolveDeploymentTarget(runtimeTarget)
+	if err != nil {
+		return nil, err
+	}
+
 	agentResp, err := s.db.GetAgentByNameAndVersion(ctx, nil, agentName, version)
 	if err != nil {
 		if errors.Is(err, database.ErrNotFound) {
@@ -766,6 +1081,26 @@ func (s *registryServiceImpl) DeployAgent(ctx context.Context, agentName, versio
 		return nil, fmt.Errorf("failed to verify agent: %w", err)
 	}
 
+	if err := secrets.ValidateConfig(config); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if err := registry.ValidateResourceConfig(config); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if err := s.checkDeploymentPolicies(ctx, policy.Input{
+		ResourceType: "agent",
+		Name:         agentName,
+		Version:      agentResp.Agent.Version,
+		Agent:        &agentResp.Agent,
+		Config:       config,
+		PreferRemote: preferRemote,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.checkDeploymentQuota(ctx); err != nil {
+		return nil, err
+	}
+
 	deployment := &models.Deployment{
 		ServerName:   agentName,
 		Version:      agentResp.Agent.Version,
@@ -773,7 +1108,8 @@ func (s *registryServiceImpl) DeployAgent(ctx context.Context, agentName, versio
 		Config:       config,
 		PreferRemote: preferRemote,
 		ResourceType: "agent",
-		Runtime:      runtimeTarget,
+		Runtime:      target.Runtime(),
+		Target:       target.Name,
 		DeployedAt:   time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -801,7 +1137,8 @@ func (s *registryServiceImpl) DeployAgent(ctx context.Context, agentName, versio
 				Config:       make(map[string]string),
 				PreferRemote: serverReq.PreferRemote,
 				ResourceType: "mcp",
-				Runtime:      runtimeTarget,
+				Runtime:      target.Runtime(),
+				Target:       target.Name,
 				DeployedAt:   time.Now(),
 				UpdatedAt:    time.Now(),
 			}
@@ -823,16 +1160,34 @@ func (s *registryServiceImpl) DeployAgent(ctx context.Context, agentName, versio
 		return nil, fmt.Errorf("deployment created but reconciliation failed: %w", err)
 	}
 
+	s.recordAuditBestEffort(ctx, models.AuditActionDeploy, "agent", agentName, deployment.Version, map[string]any{"runtime": target.Runtime(), "target": target.Name, "preferRemote": preferRemote})
+	s.recordRevisionBestEffort(ctx, deployment, models.DeploymentChangeDeploy)
+	s.recordUsageBestEffort(ctx, "agent", agentName, models.UsageEventDeploy)
+
 	return s.db.GetDeploymentByNameAndVersion(ctx, nil, agentName, version, "agent")
 }
 
 // UpdateDeploymentConfig updates the configuration for a deployment
 func (s *registryServiceImpl) UpdateDeploymentConfig(ctx context.Context, serverName string, version string, artifactType string, config map[string]string) (*models.Deployment, error) {
-	_, err := s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, version, artifactType)
+	deployment, err := s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, version, artifactType)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := secrets.ValidateConfig(config); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if err := registry.ValidateResourceConfig(config); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+
+	// New config values (e.g. inline secrets) are subject to the same policies as a new deployment
+	updated := *deployment
+	updated.Config = config
+	if err := s.checkDeploymentRecordPolicies(ctx, &updated); err != nil {
+		return nil, err
+	}
+
 	err = s.db.UpdateDeploymentConfig(ctx, nil, serverName, version, artifactType, config)
 	if err != nil {
 		return nil, err
@@ -843,31 +1198,87 @@ func (s *registryServiceImpl) UpdateDeploymentConfig(ctx context.Context, server
 		return nil, fmt.Errorf("config updated but reconciliation failed: %w", err)
 	}
 
+	// Only record the keys that changed; values may contain secrets
+	s.recordAuditBestEffort(ctx, models.AuditActionConfigChange, artifactType, serverName, version, map[string]any{"keys": slices.Sorted(maps.Keys(config))})
+	s.recordRevisionBestEffort(ctx, &updated, models.DeploymentChangeConfig)
+
 	return s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, version, artifactType)
 }
 
+// checkDeploymentRecordPolicies evaluates the deployment policies against the desired state of an existing deployment
+func (s *registryServiceImpl) checkDeploymentRecordPolicies(ctx context.Context, deployment *models.Deployment) error {
+	in := policy.Input{
+		ResourceType: deployment.ResourceType,
+		Name:         deployment.ServerName,
+		Version:      deployment.Version,
+		Config:       deployment.Config,
+		PreferRemote: deployment.PreferRemote,
+	}
+	if deployment.ResourceType == "agent" {
+		agentResp, err := s.db.GetAgentByNameAndVersion(ctx, nil, deployment.ServerName, deployment.Version)
+		if err != nil {
+			return fmt.Errorf("failed to verify agent: %w", err)
+		}
+		in.Agent = &agentResp.Agent
+	} else {
+		serverResp, err := s.resolveDeploymentServer(ctx, deployment.ServerName, deployment.Version, deployment.Origin)
+		if err != nil {
+			return fmt.Errorf("failed to verify server: %w", err)
+		}
+		in.Server = &serverResp.Server
+	}
+	return s.checkDeploymentPolicies(ctx, in)
+}
+
+// UpdateDeploymentOrigin switches the registry an MCP server deployment resolves its manifest from.
+// An empty origin pins the deployment back to this registry.
+func (s *registryServiceImpl) UpdateDeploymentOrigin(ctx context.Context, serverName string, version string, origin string) (*models.Deployment, error) {
+	origin, err := normalizeOrigin(origin)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, version, "mcp")
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Origin == origin {
+		return deployment, nil
+	}
+
+	// Make sure the new origin actually serves this version before switching
+	if _, err := s.resolveDeploymentServer(ctx, serverName, deployment.Version, origin); err != nil {
+		return nil, fmt.Errorf("%w: server %s version %s is not available from the requested origin: %v", database.ErrInvalidInput, serverName, deployment.Version, err)
+	}
+
+	if err := s.db.UpdateDeploymentOrigin(ctx, nil, serverName, version, "mcp", origin); err != nil {
+		return nil, err
+	}
+
+	if err := s.ReconcileAll(ctx); err != nil {
+		return nil, fmt.Errorf("origin updated but reconciliation failed: %w", err)
+	}
+
+	s.recordAuditBestEffort(ctx, models.AuditActionUpdate, "mcp", serverName, version, map[string]any{"origin": origin, "previousOrigin": deployment.Origin})
+	updated := *deployment
+	updated.Origin = origin
+	s.recordRevisionBestEffort(ctx, &updated, models.DeploymentChangeOrigin)
+
+	return s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, version, "mcp")
+}
+
 // RemoveDeployment removes a deployment
-func (s *registryServiceImpl) RemoveDeployment(ctx context.Context, serverName string, version string, artifactType string) error {
+func (s *registryServiceImpl) RemoveDeployment(ctx context.Context, serverName string, version string, artifactType string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.RemoveDeployment", telemetry.ResourceAttributes(artifactType, serverName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	deployment, err := s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, version, artifactType)
 	if err != nil {
 		return err
 	}
 
-	// Clean up kubernetes resources
-	if deployment != nil && deployment.Runtime == "kubernetes" {
-		if artifactType == "agent" {
-			if err := runtime.DeleteKubernetesAgent(ctx, serverName, version, kagent.DefaultNamespace); err != nil {
-				return err
-			}
-		}
-		if artifactType == "mcp" {
-			if err := runtime.DeleteKubernetesMCPServer(ctx, serverName, kagent.DefaultNamespace); err != nil {
-				return err
-			}
-			if err := runtime.DeleteKubernetesRemoteMCPServer(ctx, serverName, kagent.DefaultNamespace); err != nil {
-				return err
-			}
-		}
+	if err := s.deleteKubernetesResources(ctx, deployment); err != nil {
+		return err
 	}
 
 	err = s.db.RemoveDeployment(ctx, nil, serverName, version, artifactType)
@@ -879,6 +1290,34 @@ func (s *registryServiceImpl) RemoveDeployment(ctx context.Context, serverName s
 		return fmt.Errorf("deployment removed but reconciliation failed: %w", err)
 	}
 
+	s.recordAuditBestEffort(ctx, models.AuditActionUndeploy, artifactType, serverName, version, nil)
+
+	return nil
+}
+
+// deleteKubernetesResources cleans up the resources of a deployment on its kubernetes target; the reconciler only
+// applies desired state there and does not delete anything.
+func (s *registryServiceImpl) deleteKubernetesResources(ctx context.Context, deployment *models.Deployment) error {
+	if deployment == nil || deployment.Runtime != "kubernetes" {
+		return nil
+	}
+	target, err := s.deploymentTarget(deployment)
+	if err != nil {
+		return err
+	}
+	namespace := target.Namespace
+	if namespace == "" {
+		namespace = kagent.DefaultNamespace
+	}
+	switch deployment.ResourceType {
+	case "agent":
+		return runtime.DeleteKubernetesAgent(ctx, target.Context, deployment.ServerName, deployment.Version, namespace)
+	case "mcp":
+		if err := runtime.DeleteKubernetesMCPServer(ctx, target.Context, deployment.ServerName, namespace); err != nil {
+			return err
+		}
+		return runtime.DeleteKubernetesRemoteMCPServer(ctx, target.Context, deployment.ServerName, namespace)
+	}
 	return nil
 }
 
@@ -890,7 +1329,10 @@ func (s *registryServiceImpl) RemoveAgent(ctx context.Context, agentName string,
 
 // ReconcileAll fetches all deployments from database and reconciles containers
 // This implements the Reconciler interface
-func (s *registryServiceImpl) ReconcileAll(ctx context.Context) error {
+func (s *registryServiceImpl) ReconcileAll(ctx context.Context) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.ReconcileAll")
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// Get all deployments from database
 	deployments, err := s.GetDeployments(ctx, nil)
 	if err != nil {
@@ -899,52 +1341,105 @@ func (s *registryServiceImpl) ReconcileAll(ctx context.Context) error {
 
 	log.Printf("Reconciling %d deployment(s)", len(deployments))
 
-	type runtimeRequests struct {
-		servers []*registry.MCPServerRunRequest
-		agents  []*registry.AgentRunRequest
+	requestsByTarget, err := s.reconcileRequestsByTarget(ctx, deployments, true)
+	if err != nil {
+		return err
 	}
-	// Store server and agent run requests by runtime target
-	requestsByRuntime := map[string]*runtimeRequests{
-		"local":      {},
-		"kubernetes": {},
+
+	regTranslator := registry.NewTranslator()
+
+	for targetName, requests := range requestsByTarget {
+		if len(requests.servers) == 0 && len(requests.agents) == 0 {
+			continue
+		}
+
+		// Reconcile the requests with the runtime backend of the target
+		agentRuntime := s.newTargetRuntime(requests.target, regTranslator)
+		if err := agentRuntime.ReconcileAll(ctx, requests.servers, requests.agents); err != nil {
+			return fmt.Errorf("failed %s reconciliation: %w", targetName, err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileRequests are the run requests of the deployments of one target
+type reconcileRequests struct {
+	target  runtime.Target
+	servers []*registry.MCPServerRunRequest
+	agents  []*registry.AgentRunRequest
+}
+
+// reconcileRequestsByTarget turns deployments into run requests, grouped by deployment target. Deployments whose
+// target or manifest cannot be resolved are logged and skipped. Unless resolveSecrets is set, secret references in
+// deployment configs are passed on as they are stored.
+func (s *registryServiceImpl) reconcileRequestsByTarget(ctx context.Context, deployments []*models.Deployment, resolveSecrets bool) (map[string]*reconcileRequests, error) {
+	resolver := s.secrets
+	if resolver == nil {
+		resolver = secrets.NewResolver()
+	}
+
+	// Servers of this registry are loaded in one query instead of one lookup per deployment
+	var serverNames []string
+	for _, dep := range deployments {
+		if dep.ResourceType == "mcp" && dep.Origin == "" {
+			serverNames = append(serverNames, dep.ServerName)
+		}
+	}
+	servers, err := s.loadServerVersions(ctx, serverNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployed servers: %w", err)
 	}
 
+	// Store server and agent run requests by deployment target
+	requestsByTarget := map[string]*reconcileRequests{}
+
 	for _, dep := range deployments {
-		runtimeTarget := dep.Runtime
-		if runtimeTarget == "" {
-			runtimeTarget = "local"
+		target, err := s.deploymentTarget(dep)
+		if err != nil {
+			log.Printf("Warning: Skipping %s %s v%s: %v", dep.ResourceType, dep.ServerName, dep.Version, err)
+			continue
+		}
+		targetRequests, ok := requestsByTarget[target.Name]
+		if !ok {
+			targetRequests = &reconcileRequests{target: target}
+			requestsByTarget[target.Name] = targetRequests
+		}
+
+		// Secret references are resolved here so their values only ever reach the runtime, never the database.
+		// Failing the whole reconcile keeps a store outage from tearing down running deployments.
+		depConfig := dep.Config
+		if resolveSecrets {
+			if depConfig, err = resolver.ResolveConfig(ctx, dep.Config); err != nil {
+				return nil, fmt.Errorf("failed to resolve secrets for %s %s v%s: %w", dep.ResourceType, dep.ServerName, dep.Version, err)
+			}
 		}
-		targetRequests := requestsByRuntime[runtimeTarget]
 
 		switch dep.ResourceType {
 		case "mcp":
-			depServer, err := s.GetServerByNameAndVersion(ctx, dep.ServerName, dep.Version, true)
+			// Re-resolve from the registry the deployment is pinned to so the manifest does not silently change
+			var depServer *apiv0.ServerResponse
+			if dep.Origin == "" {
+				depServer, err = servers.find(dep.ServerName, dep.Version)
+			} else {
+				depServer, err = fetchOriginServer(ctx, dep.Origin, dep.ServerName, dep.Version)
+			}
 			if err != nil {
 				log.Printf("Warning: Failed to get server %s v%s: %v", dep.ServerName, dep.Version, err)
 				continue
 			}
 
-			// Extract some configurations from deployment config
-			envValues := make(map[string]string)
-			argValues := make(map[string]string)
-			headerValues := make(map[string]string)
-			for k, v := range dep.Config {
-				switch {
-				case len(k) > 7 && k[:7] == "HEADER_":
-					headerValues[k[7:]] = v
-				case len(k) > 4 && k[:4] == "ARG_":
-					argValues[k[4:]] = v
-				default:
-					envValues[k] = v
-				}
-			}
+			// Split the deployment config into environment variables, arguments, headers and resources
+			installConfig := models.ParseInstallationConfig(depConfig)
 
 			targetRequests.servers = append(targetRequests.servers, &registry.MCPServerRunRequest{
 				RegistryServer: &depServer.Server,
 				PreferRemote:   dep.PreferRemote,
-				EnvValues:      envValues,
-				ArgValues:      argValues,
-				HeaderValues:   headerValues,
+				EnvValues:      installConfig.Env,
+				ArgValues:      installConfig.Args,
+				HeaderValues:   installConfig.Headers,
+				ResourceValues: installConfig.Resources,
+				CanaryWeight:   dep.CanaryWeight,
 			})
 
 		case "agent":
@@ -955,11 +1450,16 @@ func (s *registryServiceImpl) ReconcileAll(ctx context.Context) error {
 			}
 
 			depEnvValues := make(map[string]string)
-			maps.Copy(depEnvValues, dep.Config)
+			maps.Copy(depEnvValues, depConfig)
+			resourceValues := registry.ExtractResourceValues(depConfig)
+			for k := range resourceValues {
+				delete(depEnvValues, registry.ResourceConfigPrefix+k)
+			}
 
 			targetRequests.agents = append(targetRequests.agents, &registry.AgentRunRequest{
-				RegistryAgent: &depAgent.Agent,
-				EnvValues:     depEnvValues,
+				RegistryAgent:  &depAgent.Agent,
+				EnvValues:      depEnvValues,
+				ResourceValues: resourceValues,
 			})
 
 		default:
@@ -967,18 +1467,24 @@ func (s *registryServiceImpl) ReconcileAll(ctx context.Context) error {
 		}
 	}
 
-	regTranslator := registry.NewTranslator()
-
-	for runtimeTarget, requests := range requestsByRuntime {
-		if len(requests.servers) == 0 && len(requests.agents) == 0 {
-			continue
+	// The registry-type MCP servers of all agent manifests are loaded in one query as well
+	var manifestServerNames []string
+	for _, requests := range requestsByTarget {
+		for _, agentReq := range requests.agents {
+			manifestServerNames = append(manifestServerNames, manifestRegistryServerNames(&agentReq.RegistryAgent.AgentManifest)...)
 		}
+	}
+	manifestServers, err := s.loadServerVersions(ctx, manifestServerNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent MCP servers: %w", err)
+	}
 
+	for targetName, requests := range requestsByTarget {
 		// Resolve registry-type MCP servers from agent manifests
 		for _, agentReq := range requests.agents {
-			resolvedServers, err := s.resolveAgentManifestMCPServers(ctx, &agentReq.RegistryAgent.AgentManifest)
+			resolvedServers, err := resolveManifestMCPServers(&agentReq.RegistryAgent.AgentManifest, manifestServers)
 			if err != nil {
-				return fmt.Errorf("failed to resolve MCP servers for agent %s: %w", agentReq.RegistryAgent.Name, err)
+				return nil, fmt.Errorf("failed to resolve MCP servers for agent %s: %w", agentReq.RegistryAgent.Name, err)
 			}
 
 			// Propagate KAGENT_NAMESPACE from agent to resolved MCP servers
@@ -992,26 +1498,12 @@ func (s *registryServiceImpl) ReconcileAll(ctx context.Context) error {
 			agentReq.ResolvedMCPServers = resolvedServers
 			requests.servers = append(requests.servers, resolvedServers...)
 			if s.cfg.Verbose && len(resolvedServers) > 0 {
-				log.Printf("Resolved %d MCP server(s) of type 'registry' for %s agent %s", len(resolvedServers), runtimeTarget, agentReq.RegistryAgent.Name)
+				log.Printf("Resolved %d MCP server(s) of type 'registry' for %s agent %s", len(resolvedServers), targetName, agentReq.RegistryAgent.Name)
 			}
 		}
-
-		// Create the appropriate runtime translator for the target runtime and reconcile the requests
-		var agentRuntime runtime.AgentRegistryRuntime
-		if runtimeTarget == "kubernetes" {
-			k8sTranslator := kagent.NewTranslator()
-			agentRuntime = runtime.NewAgentRegistryRuntime(regTranslator, k8sTranslator, s.cfg.RuntimeDir, s.cfg.Verbose)
-		} else {
-			composeTranslator := dockercompose.NewAgentGatewayTranslator(s.cfg.RuntimeDir, s.cfg.AgentGatewayPort)
-			agentRuntime = runtime.NewAgentRegistryRuntime(regTranslator, composeTranslator, s.cfg.RuntimeDir, s.cfg.Verbose)
-		}
-
-		if err := agentRuntime.ReconcileAll(ctx, requests.servers, requests.agents); err != nil {
-			return fmt.Errorf("failed %s reconciliation: %w", runtimeTarget, err)
-		}
 	}
 
-	return nil
+	return requestsByTarget, nil
 }
 
 // resolveAgentManifestMCPServers extracts and resolves registry-type MCP servers from an agent manifest
@@ -1019,6 +1511,28 @@ func (s *registryServiceImpl) ReconcileAll(ctx context.Context) error {
 // TODO: Should we also be resolving the other types (i.e. command)? I didn't see my command server configured in the agent-gateway yaml, unsure if expected or a bug.
 // cat /tmp/arctl-runtime/agent-gateway.yaml only had an mcp route for the registry-resolved (since we added it to the run requests).
 func (s *registryServiceImpl) resolveAgentManifestMCPServers(ctx context.Context, manifest *models.AgentManifest) ([]*registry.MCPServerRunRequest, error) {
+	// Use the registry service's own database instead of making HTTP calls
+	servers, err := s.loadServerVersions(ctx, manifestRegistryServerNames(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MCP servers from registry database: %w", err)
+	}
+	return resolveManifestMCPServers(manifest, servers)
+}
+
+// manifestRegistryServerNames returns the names of the registry-type MCP servers of an agent manifest
+func manifestRegistryServerNames(manifest *models.AgentManifest) []string {
+	var names []string
+	for _, mcpServer := range manifest.McpServers {
+		if mcpServer.Type == "registry" {
+			names = append(names, mcpServer.RegistryServerName)
+		}
+	}
+	return names
+}
+
+// resolveManifestMCPServers turns the registry-type MCP servers of an agent manifest into run requests, looking them
+// up in servers
+func resolveManifestMCPServers(manifest *models.AgentManifest, servers serverVersionIndex) ([]*registry.MCPServerRunRequest, error) {
 	var resolvedServers []*registry.MCPServerRunRequest
 
 	for _, mcpServer := range manifest.McpServers {
@@ -1032,8 +1546,7 @@ func (s *registryServiceImpl) resolveAgentManifestMCPServers(ctx context.Context
 			version = "latest"
 		}
 
-		// Use the registry service's own database instead of making HTTP calls
-		serverResp, err := s.GetServerByNameAndVersion(ctx, mcpServer.RegistryServerName, version, true)
+		serverResp, err := servers.find(mcpServer.RegistryServerName, version)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get server %q version %s from registry database: %w", mcpServer.RegistryServerName, version, err)
 		}
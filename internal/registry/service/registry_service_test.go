@@ -4,12 +4,17 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/namespace"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -370,6 +375,399 @@ func TestGetServerReadmeMissing(t *testing.T) {
 	assert.Equal(t, database.ErrNotFound, err)
 }
 
+func TestAgentReadme(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+
+	agentName := "com.example/readme-agent"
+	_, err := svc.CreateAgent(ctx, &models.AgentJSON{
+		AgentManifest: models.AgentManifest{Name: agentName, Description: "Agent v1"},
+		Version:       "1.0.0",
+	})
+	require.NoError(t, err)
+
+	_, err = svc.GetAgentReadmeLatest(ctx, agentName)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	readme := []byte("# My agent\n")
+	require.NoError(t, svc.StoreAgentReadme(ctxWithAuth, agentName, "1.0.0", readme, ""))
+	assert.ErrorIs(t, svc.StoreAgentReadme(ctxWithAuth, agentName, "9.9.9", readme, ""), database.ErrNotFound)
+	assert.ErrorIs(t, svc.StoreAgentReadme(ctxWithAuth, agentName, "1.0.0", nil, ""), database.ErrInvalidInput)
+
+	latest, err := svc.GetAgentReadmeLatest(ctx, agentName)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.Version)
+	assert.Equal(t, "text/markdown", latest.ContentType)
+	assert.Equal(t, string(readme), string(latest.Content))
+
+	byVersion, err := svc.GetAgentReadmeByVersion(ctx, agentName, "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, latest.SHA256, byVersion.SHA256)
+}
+
+func TestServerEnrichment(t *testing.T) {
+	ctx := auth.WithSystemContext(context.Background())
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+
+	serverName := "com.example/enriched-server"
+	_, err := svc.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Enriched server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	due, err := svc.ListServersDueForEnrichment(ctx, time.Now(), 0)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, serverName, due[0].ServerName)
+	assert.Nil(t, due[0].EnrichedAt)
+
+	metadata := map[string]any{"stars": float64(42)}
+	require.NoError(t, svc.RecordServerEnrichment(ctx, serverName, "1.0.0", metadata, nil))
+
+	server, err := svc.GetServerByNameAndVersion(ctx, serverName, "1.0.0", false)
+	require.NoError(t, err)
+	require.NotNil(t, server.Server.Meta)
+	assert.Equal(t, metadata, server.Server.Meta.PublisherProvided[models.EnrichmentMetadataKey])
+
+	due, err = svc.ListServersDueForEnrichment(ctx, time.Now().Add(-time.Hour), 0)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	// A failed attempt keeps the earlier details and is still recorded
+	require.NoError(t, svc.RecordServerEnrichment(ctx, serverName, "1.0.0", nil, fmt.Errorf("rate limited")))
+	due, err = svc.ListServersDueForEnrichment(ctx, time.Now().Add(time.Minute), 0)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "rate limited", due[0].Error)
+
+	server, err = svc.GetServerByNameAndVersion(ctx, serverName, "1.0.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, metadata, server.Server.Meta.PublisherProvided[models.EnrichmentMetadataKey])
+}
+
+func TestArtifactStats(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+
+	for _, name := range []string{"com.example/a-server", "com.example/b-server"} {
+		_, err := svc.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	stats, err := svc.GetArtifactStats(ctx, "mcp", "com.example/b-server")
+	require.NoError(t, err)
+	assert.Zero(t, stats.Popularity)
+	assert.Nil(t, stats.UpdatedAt)
+
+	require.NoError(t, svc.RecordArtifactUsage(ctx, "mcp", "com.example/b-server", models.UsageEventDownload))
+	require.NoError(t, svc.RecordArtifactUsage(ctx, "mcp", "com.example/b-server", models.UsageEventInstall))
+	assert.ErrorIs(t, svc.RecordArtifactUsage(ctx, "mcp", "com.example/missing", models.UsageEventDownload), database.ErrNotFound)
+	assert.ErrorIs(t, svc.RecordArtifactUsage(ctx, "mcp", "com.example/b-server", "star"), database.ErrInvalidInput)
+
+	stats, err = svc.GetArtifactStats(ctx, "mcp", "com.example/b-server")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Downloads)
+	assert.Equal(t, int64(1), stats.Installs)
+	assert.Equal(t, int64(2), stats.Popularity)
+
+	servers, next, err := svc.ListServers(ctx, &database.ServerFilter{SortByPopularity: true}, "", 1)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "com.example/b-server", servers[0].Server.Name)
+	assert.Equal(t, "1", next)
+
+	servers, _, err = svc.ListServers(ctx, &database.ServerFilter{SortByPopularity: true}, next, 1)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "com.example/a-server", servers[0].Server.Name)
+}
+
+// reviewerSession is a signed-in user without any registry permissions
+type reviewerSession struct{ subject string }
+
+func (s *reviewerSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: s.subject, AuthMethod: auth.MethodGitHubAT}}
+}
+
+func TestReviews(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+
+	serverName := "com.example/reviewed-server"
+	_, err := svc.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Reviewed server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	alice := auth.AuthSessionTo(ctx, &reviewerSession{subject: "alice"})
+	bob := auth.AuthSessionTo(ctx, &reviewerSession{subject: "bob"})
+
+	_, err = svc.SubmitReview(ctx, "mcp", serverName, 5, "")
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+	_, err = svc.SubmitReview(alice, "mcp", serverName, 6, "")
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+	_, err = svc.SubmitReview(alice, "skill", serverName, 5, "")
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	_, err = svc.SubmitReview(alice, "mcp", serverName, 2, "Flaky")
+	require.NoError(t, err)
+	aliceReview, err := svc.SubmitReview(alice, "mcp", serverName, 4, "Much better now")
+	require.NoError(t, err)
+	bobReview, err := svc.SubmitReview(bob, "mcp", serverName, 5, "Great")
+	require.NoError(t, err)
+
+	list, err := svc.ListReviews(ctx, "mcp", serverName, false, "", 0)
+	require.NoError(t, err)
+	require.Len(t, list.Reviews, 2)
+	assert.Equal(t, "bob", list.Reviews[0].Author)
+	assert.Equal(t, "Much better now", list.Reviews[1].Body)
+	assert.Equal(t, models.RatingSummary{Average: 4.5, Count: 2}, list.Rating)
+
+	// Hidden reviews drop out of public listings and ratings
+	admin := auth.WithSystemContext(ctx)
+	hidden, err := svc.ModerateReview(admin, bobReview.ID, true)
+	require.NoError(t, err)
+	assert.True(t, hidden.Hidden)
+	_, err = svc.ModerateReview(alice, bobReview.ID, false)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	list, err = svc.ListReviews(ctx, "mcp", serverName, false, "", 0)
+	require.NoError(t, err)
+	require.Len(t, list.Reviews, 1)
+	assert.Equal(t, models.RatingSummary{Average: 4, Count: 1}, list.Rating)
+	list, err = svc.ListReviews(admin, "mcp", serverName, true, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, list.Reviews, 2)
+
+	// Users may only delete their own reviews
+	assert.ErrorIs(t, svc.DeleteReview(bob, aliceReview.ID), database.ErrNotFound)
+	require.NoError(t, svc.DeleteReview(alice, aliceReview.ID))
+	ratings, err := svc.GetRatingSummaries(ctx, "mcp", []string{serverName})
+	require.NoError(t, err)
+	assert.Empty(t, ratings)
+}
+
+// fakeTXTResolver serves DNS TXT records from a map
+type fakeTXTResolver map[string][]string
+
+func (r fakeTXTResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	return r[name], nil
+}
+
+func TestNamespaceOwnership(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	cfg := &config.Config{EnableRegistryValidation: false}
+	svc := NewRegistryService(testDB, cfg, nil)
+
+	alice := auth.AuthSessionTo(ctx, &reviewerSession{subject: "alice"})
+	bob := auth.AuthSessionTo(ctx, &reviewerSession{subject: "bob"})
+	createServer := func(ctx context.Context, name string) error {
+		_, err := svc.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Namespaced server",
+			Version:     "1.0.0",
+		})
+		return err
+	}
+
+	// Unverified namespaces are open to everyone
+	require.NoError(t, createServer(bob, "com.example/open-server"))
+
+	challenge, err := svc.GetNamespaceChallenge(alice, "com.example")
+	require.NoError(t, err)
+	assert.Equal(t, "_agentregistry.example.com", challenge.RecordName)
+	_, err = svc.VerifyNamespace(ctx, "com.example", "")
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+	verifier := svc.(*registryServiceImpl).namespaces
+	verifier.SetResolver(fakeTXTResolver{})
+	_, err = svc.VerifyNamespace(alice, "com.example", "")
+	assert.ErrorIs(t, err, namespace.ErrVerificationFailed)
+
+	verifier.SetResolver(fakeTXTResolver{challenge.RecordName: {challenge.RecordValue}})
+	verified, err := svc.VerifyNamespace(alice, "com.example", "")
+	require.NoError(t, err)
+	assert.Equal(t, namespace.MethodDNS, verified.Method)
+	// bob cannot reuse alice's record
+	_, err = svc.VerifyNamespace(bob, "com.example", "")
+	assert.ErrorIs(t, err, namespace.ErrVerificationFailed)
+
+	listed, err := svc.ListVerifiedNamespaces(ctx, "com.example", "")
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "alice", listed[0].Subject)
+
+	// Verified namespaces only accept the verifier and admins
+	assert.ErrorIs(t, createServer(bob, "com.example/server"), namespace.ErrNotOwned)
+	require.NoError(t, createServer(alice, "com.example/server"))
+	require.NoError(t, createServer(auth.WithSystemContext(ctx), "com.example/admin-server"))
+
+	// Strict mode closes unverified namespaces
+	cfg.RequireVerifiedNamespaces = true
+	assert.ErrorIs(t, createServer(bob, "org.unverified/server"), namespace.ErrNotOwned)
+	cfg.RequireVerifiedNamespaces = false
+
+	assert.ErrorIs(t, svc.RevokeNamespace(bob, "com.example", "alice"), auth.ErrForbidden)
+	require.NoError(t, svc.RevokeNamespace(auth.WithSystemContext(ctx), "com.example", "alice"))
+	require.NoError(t, createServer(bob, "com.example/server-two"))
+}
+
+func TestQuotas(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	cfg := &config.Config{EnableRegistryValidation: false, QuotaMaxPublishesPerDay: 3, QuotaMaxServersPerNamespace: 2}
+	svc := NewRegistryService(testDB, cfg, nil)
+
+	alice := auth.AuthSessionTo(ctx, &reviewerSession{subject: "alice"})
+	bob := auth.AuthSessionTo(ctx, &reviewerSession{subject: "bob"})
+	createServer := func(ctx context.Context, name, version string) error {
+		_, err := svc.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Quota server",
+			Version:     version,
+		})
+		return err
+	}
+
+	// New versions of a server do not count against the namespace quota
+	require.NoError(t, createServer(alice, "com.example/first", "1.0.0"))
+	require.NoError(t, createServer(alice, "com.example/first", "1.1.0"))
+	require.NoError(t, createServer(alice, "com.example/first", "1.2.0"))
+	require.NoError(t, createServer(bob, "com.example/second", "1.0.0"))
+	assert.ErrorIs(t, createServer(bob, "com.example/third", "1.0.0"), ErrQuotaExceeded)
+	require.NoError(t, createServer(bob, "org.example/third", "1.0.0"))
+
+	// alice used up her publishes, bob did not
+	assert.ErrorIs(t, createServer(alice, "org.example/third", "1.1.0"), ErrPublishQuotaExceeded)
+	require.NoError(t, createServer(bob, "org.example/third", "1.1.0"))
+
+	quota, err := svc.GetQuota(alice, "com.example")
+	require.NoError(t, err)
+	assert.Equal(t, "user:alice", quota.Scope)
+	assert.False(t, quota.Exempt)
+	assert.Equal(t, models.QuotaUsage{Limit: 3, Used: 3}, quota.PublishesPerDay)
+	assert.Equal(t, models.QuotaUsage{Limit: 2, Used: 2}, quota.ServersPerNamespace)
+	assert.Equal(t, models.QuotaUsage{}, quota.Deployments)
+
+	// Admins are exempt
+	require.NoError(t, createServer(auth.WithSystemContext(ctx), "com.example/admin-server", "1.0.0"))
+}
+
+func TestPayloadSizeLimits(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{DocumentMaxBytes: 512, ReadmeMaxBytes: 16}, nil)
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+
+	_, err := svc.CreateSkill(ctx, &models.SkillJSON{Name: "com.example/big-skill", Description: strings.Repeat("x", 600), Version: "1.0.0"})
+	assert.ErrorIs(t, err, validators.ErrDocumentTooLarge)
+
+	_, err = svc.CreateSkill(ctx, &models.SkillJSON{Name: "com.example/small-skill", Description: "Small", Version: "1.0.0"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, svc.StoreSkillReadme(ctxWithAuth, "com.example/small-skill", "1.0.0", []byte(strings.Repeat("#", 17)), ""), validators.ErrReadmeTooLarge)
+	require.NoError(t, svc.StoreSkillReadme(ctxWithAuth, "com.example/small-skill", "1.0.0", []byte("# Small skill\n"), ""))
+}
+
+func TestSkillReadmeAndStatus(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+
+	skillName := "com.example/readme-skill"
+	_, err := svc.CreateSkill(ctx, &models.SkillJSON{Name: skillName, Description: "Skill v1", Version: "1.0.0"})
+	require.NoError(t, err)
+
+	readme := []byte("# My skill\n")
+	require.NoError(t, svc.StoreSkillReadme(ctxWithAuth, skillName, "1.0.0", readme, ""))
+	assert.ErrorIs(t, svc.StoreSkillReadme(ctxWithAuth, skillName, "9.9.9", readme, ""), database.ErrNotFound)
+
+	latest, err := svc.GetSkillReadmeLatest(ctx, skillName)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.Version)
+	assert.Equal(t, "text/markdown", latest.ContentType)
+	assert.Equal(t, string(readme), string(latest.Content))
+
+	updated, err := svc.SetSkillStatus(ctxWithAuth, skillName, "1.0.0", "deprecated")
+	require.NoError(t, err)
+	assert.Equal(t, "deprecated", updated.Meta.Official.Status)
+
+	_, err = svc.SetSkillStatus(ctxWithAuth, skillName, "1.0.0", "retired")
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	_, err = svc.SetSkillStatus(ctxWithAuth, skillName, "1.0.0", "deleted")
+	require.NoError(t, err)
+	_, err = svc.SetSkillStatus(ctxWithAuth, skillName, "1.0.0", "active")
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	statuses, err := svc.GetSkillVersionStatuses(ctx, skillName)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "deleted", statuses[0].Status)
+	assert.False(t, statuses[0].Published)
+}
+
+func TestCreateSkillValidatesServerDependencies(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+
+	serverName := "com.example/dependency-server"
+	_, err := svc.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Server used by a skill",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	newSkill := func(version string, deps ...models.SkillServerDependency) *models.SkillJSON {
+		return &models.SkillJSON{Name: "com.example/dependent-skill", Description: "Skill", Version: version, MCPServers: deps}
+	}
+
+	_, err = svc.CreateSkill(ctx, newSkill("1.0.0", models.SkillServerDependency{Name: "com.example/missing"}))
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	_, err = svc.CreateSkill(ctx, newSkill("1.0.0", models.SkillServerDependency{Name: serverName, Version: "2.0.0"}))
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	// Tools cannot be checked before the server is introspected
+	_, err = svc.CreateSkill(ctx, newSkill("1.0.0", models.SkillServerDependency{Name: serverName, Tools: []string{"search"}}))
+	require.NoError(t, err)
+
+	require.NoError(t, testDB.UpsertServerCapabilities(ctxWithAuth, nil, &models.ServerCapabilities{
+		ServerName: serverName,
+		Version:    "1.0.0",
+		Tools:      []models.ToolInfo{{Name: "search"}},
+	}))
+
+	_, err = svc.CreateSkill(ctx, newSkill("1.1.0", models.SkillServerDependency{Name: serverName, Version: "1.0.0", Tools: []string{"search"}}))
+	require.NoError(t, err)
+
+	_, err = svc.CreateSkill(ctx, newSkill("1.2.0", models.SkillServerDependency{Name: serverName, Tools: []string{"search", "delete"}}))
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+	assert.ErrorContains(t, err, "has no tool delete")
+}
+
 func TestGetAllVersionsByServerName(t *testing.T) {
 	ctx := context.Background()
 	testDB := internaldb.NewTestDB(t)
@@ -466,6 +864,51 @@ func TestGetAllVersionsByServerName(t *testing.T) {
 	}
 }
 
+func TestSetServerVersionsPublished(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+
+	serverName := "com.example/selective-unpublish"
+	for _, version := range []string{"1.0.0", "1.1.0", "1.2.0"} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Selective unpublish server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, service.SetServerVersionsPublished(ctx, serverName, []string{"1.0.0", "1.1.0", "1.2.0"}, true))
+	require.NoError(t, service.SetServerVersionsPublished(ctx, serverName, []string{"1.0.0", "1.1.0"}, false))
+
+	statuses, err := service.GetServerVersionStatuses(ctx, serverName)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+
+	published := map[string]bool{}
+	for _, st := range statuses {
+		published[st.Version] = st.Published
+	}
+	assert.Equal(t, map[string]bool{"1.0.0": false, "1.1.0": false, "1.2.0": true}, published)
+
+	// An unknown version fails the whole batch and leaves other versions untouched
+	err = service.SetServerVersionsPublished(ctx, serverName, []string{"1.2.0", "9.9.9"}, false)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	statuses, err = service.GetServerVersionStatuses(ctx, serverName)
+	require.NoError(t, err)
+	for _, st := range statuses {
+		if st.Version == "1.2.0" {
+			assert.True(t, st.Published)
+		}
+	}
+
+	err = service.SetServerVersionsPublished(ctx, serverName, nil, false)
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+}
+
 func TestCreateServerConcurrentVersionsNoRace(t *testing.T) {
 	ctx := context.Background()
 	testDB := internaldb.NewTestDB(t)
@@ -873,3 +1316,115 @@ func TestVersionComparison(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestImports(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+	admin := auth.WithSystemContext(ctx)
+
+	// Runs until cancelled, like an import of a large source
+	started := make(chan struct{})
+	run := func(ctx context.Context, status *models.ImportStatus) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	request := models.ImportRequest{Source: "https://example.com/seed.json", Options: models.ImportOptions{Strategy: "overwrite"}}
+	_, err := svc.StartImport(ctx, request, run)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	_, err = svc.StartImport(admin, models.ImportRequest{}, run)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	status, err := svc.StartImport(admin, request, run)
+	require.NoError(t, err)
+	assert.Equal(t, models.ImportStatusRunning, status.Status)
+	<-started
+
+	imports, err := svc.ListImports(admin)
+	require.NoError(t, err)
+	require.Len(t, imports, 1)
+	assert.Equal(t, "overwrite", imports[0].Options.Strategy)
+
+	_, err = svc.CancelImport(admin, status.ID)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		current, err := svc.GetImport(admin, status.ID)
+		return err == nil && current.Status == models.ImportStatusCancelled
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = svc.CancelImport(admin, status.ID)
+	assert.ErrorIs(t, err, ErrImportNotRunning)
+	_, err = svc.GetImport(admin, "missing")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestConfigProfiles(t *testing.T) {
+	ctx := internaldb.WithTestSession(context.Background())
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+
+	_, err := svc.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	_, err = svc.PutConfigProfile(ctx, &models.ConfigProfile{ServerName: "com.example/missing", Name: "dev"})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	_, err = svc.PutConfigProfile(ctx, &models.ConfigProfile{ServerName: "com.example/weather", Name: "Prod!"})
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+	_, err = svc.PutConfigProfile(ctx, &models.ConfigProfile{ServerName: "com.example/weather", Name: "prod", Extends: "base"})
+	assert.ErrorIs(t, err, database.ErrInvalidInput, "extending a missing profile")
+
+	_, err = svc.PutConfigProfile(ctx, &models.ConfigProfile{
+		ServerName: "com.example/weather",
+		Name:       "base",
+		Config:     map[string]string{"LOG_LEVEL": "info", "API_KEY": "secretRef://vault/secret/weather#key", "ARG_port": "8080"},
+	})
+	require.NoError(t, err)
+	prod, err := svc.PutConfigProfile(ctx, &models.ConfigProfile{
+		ServerName: "com.example/weather",
+		Name:       "prod",
+		Extends:    "base",
+		Config:     map[string]string{"LOG_LEVEL": "warn", "HEADER_X-Region": "eu"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"LOG_LEVEL":       "warn",
+		"API_KEY":         "secretRef://vault/secret/weather#key",
+		"ARG_port":        "8080",
+		"HEADER_X-Region": "eu",
+	}, prod.ResolvedConfig)
+
+	// Keys of the parent changed later are inherited when the profile is read
+	_, err = svc.PutConfigProfile(ctx, &models.ConfigProfile{
+		ServerName: "com.example/weather",
+		Name:       "base",
+		Config:     map[string]string{"LOG_LEVEL": "debug", "ARG_port": "9090"},
+	})
+	require.NoError(t, err)
+	prod, err = svc.GetConfigProfile(ctx, "com.example/weather", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "warn", "HEADER_X-Region": "eu"}, prod.Config)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "warn", "ARG_port": "9090", "HEADER_X-Region": "eu"}, prod.ResolvedConfig)
+
+	// A profile cannot extend a profile that extends it
+	_, err = svc.PutConfigProfile(ctx, &models.ConfigProfile{ServerName: "com.example/weather", Name: "base", Extends: "prod"})
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	profiles, err := svc.ListConfigProfiles(ctx, "com.example/weather")
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "base", profiles[0].Name)
+	assert.Equal(t, "prod", profiles[1].Name)
+
+	// A profile another profile extends cannot be deleted
+	assert.ErrorIs(t, svc.DeleteConfigProfile(ctx, "com.example/weather", "base"), database.ErrInvalidInput)
+	require.NoError(t, svc.DeleteConfigProfile(ctx, "com.example/weather", "prod"))
+	require.NoError(t, svc.DeleteConfigProfile(ctx, "com.example/weather", "base"))
+	assert.ErrorIs(t, svc.DeleteConfigProfile(ctx, "com.example/weather", "base"), database.ErrNotFound)
+}
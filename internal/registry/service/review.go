@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// SubmitReview stores the caller's star rating and review of a server ("mcp") or agent, replacing their earlier
+// review of it. Anonymous callers cannot review.
+func (s *registryServiceImpl) SubmitReview(ctx context.Context, artifactType, name string, rating int, body string) (_ *models.Review, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.SubmitReview", telemetry.ResourceAttributes(artifactType, name, "")...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := validateReviewType(artifactType); err != nil {
+		return nil, err
+	}
+	if rating < 1 || rating > 5 {
+		return nil, fmt.Errorf("%w: rating must be between 1 and 5", database.ErrInvalidInput)
+	}
+	body = strings.TrimSpace(body)
+	if utf8.RuneCountInString(body) > models.MaxReviewLength {
+		return nil, fmt.Errorf("%w: review must be at most %d characters", database.ErrInvalidInput, models.MaxReviewLength)
+	}
+	author, _ := auth.ActorFrom(ctx)
+	if author == "anonymous" {
+		return nil, auth.ErrUnauthenticated
+	}
+	if err := s.ensureArtifactExists(ctx, artifactType, name); err != nil {
+		return nil, err
+	}
+
+	return s.db.UpsertReview(ctx, nil, &models.Review{
+		ArtifactType: artifactType,
+		Name:         name,
+		Author:       author,
+		Rating:       rating,
+		Body:         body,
+	})
+}
+
+// ListReviews returns the reviews of a server ("mcp") or agent, newest first, with its aggregate rating
+func (s *registryServiceImpl) ListReviews(ctx context.Context, artifactType, name string, includeHidden bool, cursor string, limit int) (*models.ReviewListResponse, error) {
+	if err := validateReviewType(artifactType); err != nil {
+		return nil, err
+	}
+	if err := s.ensureArtifactExists(ctx, artifactType, name); err != nil {
+		return nil, err
+	}
+
+	reviews, nextCursor, err := s.db.ListReviews(ctx, nil, artifactType, name, includeHidden, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := s.db.GetRatingSummaries(ctx, nil, artifactType, []string{name})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.ReviewListResponse{
+		Reviews:  make([]models.Review, len(reviews)),
+		Rating:   summaries[name],
+		Metadata: models.ReviewMetadata{NextCursor: nextCursor, Count: len(reviews)},
+	}
+	for i, r := range reviews {
+		resp.Reviews[i] = *r
+	}
+	return resp, nil
+}
+
+// GetRatingSummaries returns the aggregate ratings of the named servers ("mcp") or agents; unreviewed ones are omitted
+func (s *registryServiceImpl) GetRatingSummaries(ctx context.Context, artifactType string, names []string) (map[string]models.RatingSummary, error) {
+	return s.db.GetRatingSummaries(ctx, nil, artifactType, names)
+}
+
+// ModerateReview hides or restores a review (admin only)
+func (s *registryServiceImpl) ModerateReview(ctx context.Context, id int64, hidden bool) (_ *models.Review, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.ModerateReview")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	review, err := s.db.SetReviewHidden(ctx, nil, id, hidden)
+	if err != nil {
+		return nil, err
+	}
+	s.recordAuditBestEffort(ctx, models.AuditActionModerate, review.ArtifactType, review.Name, "", map[string]any{"reviewId": id, "hidden": hidden})
+	return review, nil
+}
+
+// DeleteReview deletes one of the caller's reviews; admins may delete any review
+func (s *registryServiceImpl) DeleteReview(ctx context.Context, id int64) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.DeleteReview")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	actor, _ := auth.ActorFrom(ctx)
+	if actor == "anonymous" {
+		return auth.ErrUnauthenticated
+	}
+	review, err := s.db.DeleteReview(ctx, nil, id, actor)
+	if err != nil {
+		return err
+	}
+	if review.Author != actor {
+		s.recordAuditBestEffort(ctx, models.AuditActionModerate, review.ArtifactType, review.Name, "", map[string]any{"reviewId": id, "deleted": true, "author": review.Author})
+	}
+	return nil
+}
+
+func validateReviewType(artifactType string) error {
+	if artifactType != "mcp" && artifactType != "agent" {
+		return fmt.Errorf("%w: only servers and agents can be reviewed", database.ErrInvalidInput)
+	}
+	return nil
+}
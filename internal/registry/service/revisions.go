@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListDeploymentRevisions returns the revision history of a deployment, newest first
+func (s *registryServiceImpl) ListDeploymentRevisions(ctx context.Context, serverName, resourceType string) ([]*models.DeploymentRevision, error) {
+	return s.db.ListDeploymentRevisions(ctx, nil, serverName, resourceType)
+}
+
+// recordRevisionBestEffort appends the state of a deployment to its revision history, logging instead of failing
+// since the change has already been applied to the runtime.
+func (s *registryServiceImpl) recordRevisionBestEffort(ctx context.Context, deployment *models.Deployment, change string) {
+	rev := &models.DeploymentRevision{
+		ServerName:   deployment.ServerName,
+		ResourceType: deployment.ResourceType,
+		Version:      deployment.Version,
+		Config:       deployment.Config,
+		PreferRemote: deployment.PreferRemote,
+		Runtime:      deployment.Runtime,
+		Target:       deployment.Target,
+		Origin:       deployment.Origin,
+		Change:       change,
+	}
+	if err := s.db.CreateDeploymentRevision(ctx, nil, rev); err != nil {
+		log.Printf("Warning: failed to record deployment revision (%s %s@%s): %v", deployment.ResourceType, deployment.ServerName, deployment.Version, err)
+	}
+}
+
+// RollbackDeployment returns a deployment to the version and configuration of an earlier revision. Revision 0
+// selects the revision before the latest one. The rollback itself is recorded as a new revision.
+func (s *registryServiceImpl) RollbackDeployment(ctx context.Context, serverName, resourceType string, revision int) (_ *models.Deployment, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.RollbackDeployment", telemetry.ResourceAttributes(resourceType, serverName, "")...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	deployments, err := s.db.GetDeployments(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	var current *models.Deployment
+	for _, d := range deployments {
+		if d.ServerName != serverName || d.ResourceType != resourceType {
+			continue
+		}
+		if d.CanaryWeight > 0 {
+			return nil, fmt.Errorf("%w: version %s of %s is being rolled out as a canary; promote or remove it first", database.ErrInvalidInput, d.Version, serverName)
+		}
+		current = d
+	}
+	if current == nil {
+		return nil, fmt.Errorf("%s is not deployed: %w", serverName, database.ErrNotFound)
+	}
+
+	revisions, err := s.db.ListDeploymentRevisions(ctx, nil, serverName, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	var rev *models.DeploymentRevision
+	if revision == 0 {
+		if len(revisions) < 2 {
+			return nil, fmt.Errorf("%w: %s has no earlier revision to roll back to", database.ErrInvalidInput, serverName)
+		}
+		rev = revisions[1]
+	} else {
+		for _, r := range revisions {
+			if r.Revision == revision {
+				rev = r
+				break
+			}
+		}
+		if rev == nil {
+			return nil, fmt.Errorf("revision %d of %s: %w", revision, serverName, database.ErrNotFound)
+		}
+	}
+
+	desired := *current
+	desired.Version = rev.Version
+	desired.Config = maps.Clone(rev.Config)
+	desired.PreferRemote = rev.PreferRemote
+	desired.Runtime = rev.Runtime
+	desired.Target = rev.Target
+	desired.Origin = rev.Origin
+	desired.Status = models.DeploymentStatusActive
+	desired.UpdatedAt = time.Now()
+
+	inPlace := desired.Version == current.Version && desired.Runtime == current.Runtime &&
+		desired.Target == current.Target && desired.PreferRemote == current.PreferRemote
+	if inPlace && desired.Origin == current.Origin && maps.Equal(desired.Config, current.Config) {
+		return nil, fmt.Errorf("%w: %s already runs the state of revision %d", database.ErrInvalidInput, serverName, rev.Revision)
+	}
+	// The target of an old revision may have been removed from the registry configuration since
+	if _, err := s.deploymentTarget(&desired); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if err := s.checkDeploymentRecordPolicies(ctx, &desired); err != nil {
+		return nil, err
+	}
+
+	err = s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if inPlace {
+			if err := s.db.UpdateDeploymentConfig(ctx, tx, serverName, current.Version, resourceType, desired.Config); err != nil {
+				return err
+			}
+			if desired.Origin != current.Origin {
+				return s.db.UpdateDeploymentOrigin(ctx, tx, serverName, current.Version, resourceType, desired.Origin)
+			}
+			return nil
+		}
+		if err := s.db.RemoveDeployment(ctx, tx, serverName, current.Version, resourceType); err != nil {
+			return err
+		}
+		desired.DeployedAt = time.Now()
+		return s.db.CreateDeployment(ctx, tx, &desired)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !inPlace {
+		if err := s.deleteKubernetesResources(ctx, current); err != nil {
+			log.Printf("Warning: failed to clean up kubernetes resources of %s v%s after rollback: %v", serverName, current.Version, err)
+		}
+	}
+	if err := s.ReconcileAll(ctx); err != nil {
+		return nil, fmt.Errorf("deployment rolled back but reconciliation failed: %w", err)
+	}
+
+	s.recordRevisionBestEffort(ctx, &desired, models.DeploymentChangeRollback)
+	s.recordAuditBestEffort(ctx, models.AuditActionRollback, resourceType, serverName, desired.Version, map[string]any{"revision": rev.Revision, "previousVersion": current.Version})
+
+	return s.db.GetDeploymentByNameAndVersion(ctx, nil, serverName, desired.Version, resourceType)
+}
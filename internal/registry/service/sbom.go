@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxSBOMSize bounds stored SBOM documents; SBOMs of large images are typically a few MB
+const maxSBOMSize = 32 << 20
+
+// StoreAgentSBOM attaches an SPDX or CycloneDX JSON SBOM to an existing agent version
+func (s *registryServiceImpl) StoreAgentSBOM(ctx context.Context, agentName, version string, content []byte) (*database.AgentSBOM, error) {
+	if len(content) > maxSBOMSize {
+		return nil, fmt.Errorf("%w: SBOM exceeds the maximum size of %d bytes", database.ErrInvalidInput, maxSBOMSize)
+	}
+	format, components, err := inspectSBOM(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.AgentSBOM, error) {
+		if _, err := s.db.GetAgentByNameAndVersion(ctx, tx, agentName, version); err != nil {
+			return nil, err
+		}
+
+		sbom := &database.AgentSBOM{
+			AgentName:      agentName,
+			Version:        version,
+			Format:         format,
+			Content:        append([]byte(nil), content...),
+			ComponentCount: components,
+			CreatedAt:      time.Now(),
+		}
+		if err := s.db.UpsertAgentSBOM(ctx, tx, sbom); err != nil {
+			return nil, err
+		}
+
+		details := map[string]any{"sbomFormat": format, "components": components}
+		if err := s.recordAudit(ctx, tx, models.AuditActionUpdate, "agent", agentName, version, details); err != nil {
+			return nil, err
+		}
+		return sbom, nil
+	})
+}
+
+// GetAgentSBOM retrieves the SBOM attached to an agent version
+func (s *registryServiceImpl) GetAgentSBOM(ctx context.Context, agentName, version string) (*database.AgentSBOM, error) {
+	return s.db.GetAgentSBOM(ctx, nil, agentName, version)
+}
+
+// inspectSBOM detects the format of a JSON SBOM document and counts the components (packages) it lists
+func inspectSBOM(content []byte) (format string, components int, err error) {
+	var doc struct {
+		BOMFormat   string            `json:"bomFormat"`
+		Components  []json.RawMessage `json:"components"`
+		SPDXVersion string            `json:"spdxVersion"`
+		Packages    []json.RawMessage `json:"packages"`
+	}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return "", 0, fmt.Errorf("%w: SBOM must be a JSON document: %v", database.ErrInvalidInput, err)
+	}
+
+	switch {
+	case doc.BOMFormat == "CycloneDX":
+		return "cyclonedx", len(doc.Components), nil
+	case doc.SPDXVersion != "":
+		return "spdx", len(doc.Packages), nil
+	default:
+		return "", 0, fmt.Errorf("%w: unrecognized SBOM format (expected SPDX or CycloneDX JSON)", database.ErrInvalidInput)
+	}
+}
@@ -0,0 +1,48 @@
+//nolint:testpackage
+package service
+
+import (
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectSBOM(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		format     string
+		components int
+		wantErr    bool
+	}{
+		{
+			name:       "cyclonedx",
+			content:    `{"bomFormat":"CycloneDX","specVersion":"1.5","components":[{"name":"a"},{"name":"b"}]}`,
+			format:     "cyclonedx",
+			components: 2,
+		},
+		{
+			name:       "spdx",
+			content:    `{"spdxVersion":"SPDX-2.3","packages":[{"name":"a"},{"name":"b"},{"name":"c"}]}`,
+			format:     "spdx",
+			components: 3,
+		},
+		{name: "unknown format", content: `{"foo":"bar"}`, wantErr: true},
+		{name: "not json", content: `<bom/>`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, components, err := inspectSBOM([]byte(tt.content))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, database.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, format)
+			assert.Equal(t, tt.components, components)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/vulnscan"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/jackc/pgx/v5"
+)
+
+// imageScanTimeout bounds the scan of all images referenced by a server version
+const imageScanTimeout = 15 * time.Minute
+
+// scanServerImagesInBackground scans the OCI images of a published server version when IMAGE_SCANNER is configured.
+// Scans can take minutes, so they run after the publish request has returned.
+func (s *registryServiceImpl) scanServerImagesInBackground(ctx context.Context, serverName, version string) {
+	if s.imageScanner == nil {
+		return
+	}
+	// The scan outlives the request, and the results are written by the registry rather than the publisher
+	ctx = auth.WithSystemContext(context.WithoutCancel(ctx))
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, imageScanTimeout)
+		defer cancel()
+		if err := s.scanServerImages(ctx, serverName, version); err != nil {
+			log.Printf("Warning: image scan of %s@%s failed: %v", serverName, version, err)
+		}
+	}()
+}
+
+// scanServerImages scans the OCI images of a server version and stores the vulnerability summary in its _meta
+func (s *registryServiceImpl) scanServerImages(ctx context.Context, serverName, version string) error {
+	server, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version, false)
+	if err != nil {
+		return err
+	}
+	summary := vulnscan.ScanServer(ctx, s.imageScanner, &server.Server)
+	if summary == nil {
+		return nil
+	}
+
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		// Re-read the server so edits made while scanning are not overwritten
+		current, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version, false)
+		if err != nil {
+			return err
+		}
+		updated := current.Server
+		models.SetVulnerabilitySummary(&updated, summary)
+		_, err = s.db.UpdateServer(ctx, tx, serverName, version, &updated)
+		return err
+	})
+}
@@ -0,0 +1,57 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubImageScanner struct{}
+
+func (stubImageScanner) Name() string { return "stub" }
+
+func (stubImageScanner) ScanImage(context.Context, string) (*models.VulnerabilityCounts, error) {
+	return &models.VulnerabilityCounts{Critical: 2, High: 5}, nil
+}
+
+func TestScanServerImagesStoresSummary(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil).(*registryServiceImpl)
+	svc.imageScanner = stubImageScanner{}
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/scanned-server",
+		Description: "A server with an image",
+		Version:     "1.0.0",
+		Packages: []model.Package{{
+			RegistryType: "oci",
+			Identifier:   "docker.io/example/scanned-server:1.0.0",
+			Transport:    model.Transport{Type: "stdio"},
+		}},
+	}
+	_, err := svc.CreateServer(ctx, server)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.scanServerImages(ctx, server.Name, server.Version))
+
+	stored, err := svc.GetServerByNameAndVersion(ctx, server.Name, server.Version, false)
+	require.NoError(t, err)
+	summary := models.VulnerabilitySummaryFromMeta(stored.Server.Meta)
+	require.NotNil(t, summary)
+	assert.Equal(t, "stub", summary.Scanner)
+	assert.Equal(t, 2, summary.Critical)
+	assert.Equal(t, 5, summary.High)
+	require.Len(t, summary.Images, 1)
+	assert.Equal(t, "docker.io/example/scanned-server:1.0.0", summary.Images[0].Image)
+	assert.Equal(t, server.Description, stored.Server.Description)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// RenameServer renames every version of a server. Only registry admins may rename servers. The former name becomes an
+// alias, so lookups by it are redirected to the new name.
+func (s *registryServiceImpl) RenameServer(ctx context.Context, serverName, newName string) (_ *apiv0.ServerResponse, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.RenameServer", telemetry.ResourceAttributes("mcp", serverName, "")...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, fmt.Errorf("%w: only registry admins can rename servers", auth.ErrForbidden)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		// Lock both names so that no version is published under either while renaming
+		for _, name := range []string{serverName, newName} {
+			if err := s.db.AcquirePublishLock(ctx, tx, name); err != nil {
+				return nil, err
+			}
+		}
+
+		current, err := s.db.GetServerByName(ctx, tx, serverName)
+		if err != nil {
+			return nil, err
+		}
+		renamed := current.Server
+		renamed.Name = newName
+		if err := validators.ValidateServerJSON(&renamed); err != nil {
+			return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+		}
+
+		actor, _ := auth.ActorFrom(ctx)
+		if err := s.db.RenameServer(ctx, tx, serverName, newName, actor); err != nil {
+			return nil, err
+		}
+		if err := s.recordAudit(ctx, tx, models.AuditActionUpdate, "mcp", serverName, "", map[string]any{"renamedTo": newName}); err != nil {
+			return nil, err
+		}
+		return s.db.GetServerByName(ctx, tx, newName)
+	})
+}
+
+// ResolveServerAlias returns the current name of a server renamed from alias, or ErrNotFound
+func (s *registryServiceImpl) ResolveServerAlias(ctx context.Context, alias string) (string, error) {
+	return s.db.GetServerAlias(ctx, nil, alias)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// GetServersByNames retrieves every version of the named servers with a single database query
+func (s *registryServiceImpl) GetServersByNames(ctx context.Context, serverNames []string, publishedOnly bool) ([]*apiv0.ServerResponse, error) {
+	return s.db.GetServersByNames(ctx, nil, serverNames, publishedOnly)
+}
+
+// serverVersionIndex holds the published versions of a batch of servers, newest first, so that resolving many
+// deployments or manifest references takes one query instead of one per server
+type serverVersionIndex map[string][]*apiv0.ServerResponse
+
+// loadServerVersions loads the published versions of the named servers in one query
+func (s *registryServiceImpl) loadServerVersions(ctx context.Context, serverNames []string) (serverVersionIndex, error) {
+	index := serverVersionIndex{}
+	if len(serverNames) == 0 {
+		return index, nil
+	}
+	names := slices.Clone(serverNames)
+	slices.Sort(names)
+	servers, err := s.GetServersByNames(ctx, slices.Compact(names), true)
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range servers {
+		index[server.Server.Name] = append(index[server.Server.Name], server)
+	}
+	return index, nil
+}
+
+// find returns a version of a server. An empty version or "latest" returns the latest version, or the most
+// recently published one when the latest version is not published.
+func (idx serverVersionIndex) find(serverName, version string) (*apiv0.ServerResponse, error) {
+	versions := idx[serverName]
+	if version == "" || version == "latest" {
+		for _, server := range versions {
+			if server.Meta.Official != nil && server.Meta.Official.IsLatest {
+				return server, nil
+			}
+		}
+		if len(versions) > 0 {
+			return versions[0], nil
+		}
+	}
+	for _, server := range versions {
+		if server.Server.Version == version {
+			return server, nil
+		}
+	}
+	return nil, fmt.Errorf("server %s version %s: %w", serverName, version, database.ErrNotFound)
+}
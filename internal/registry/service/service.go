@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
@@ -23,8 +28,22 @@ type RegistryService interface {
 	GetServerByNameAndVersion(ctx context.Context, serverName string, version string, publishedOnly bool) (*apiv0.ServerResponse, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, serverName string, publishedOnly bool) ([]*apiv0.ServerResponse, error)
+	// GetServersByNames retrieves every version of the named servers with a single query
+	GetServersByNames(ctx context.Context, serverNames []string, publishedOnly bool) ([]*apiv0.ServerResponse, error)
+	// GetServerChangelog lists the versions of a server, newest first, with their release notes and server.json diffs
+	GetServerChangelog(ctx context.Context, serverName string, publishedOnly bool) (*models.ServerChangelog, error)
+	// GetServerVersionStatuses retrieves the status and published flag of every version of a server
+	GetServerVersionStatuses(ctx context.Context, serverName string) ([]*models.ServerVersionStatus, error)
+	// SetServerVersionsPublished publishes or unpublishes several versions of a server atomically
+	SetServerVersionsPublished(ctx context.Context, serverName string, versions []string, published bool) error
 	// CreateServer creates a new server version
 	CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
+	// CreateServersBatch creates (and optionally publishes) each server version in its own transaction, returning one error per item
+	CreateServersBatch(ctx context.Context, servers []*apiv0.ServerJSON, publish bool) []error
+	// RenameServer renames every version of a server, keeping the former name as an alias
+	RenameServer(ctx context.Context, serverName, newName string) (*apiv0.ServerResponse, error)
+	// ResolveServerAlias returns the current name of a server renamed from alias
+	ResolveServerAlias(ctx context.Context, alias string) (string, error)
 	// UpdateServer updates an existing server and optionally its status
 	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error)
 	// StoreServerReadme stores or updates the README for a server version
@@ -33,12 +52,58 @@ type RegistryService interface {
 	GetServerReadmeLatest(ctx context.Context, serverName string) (*database.ServerReadme, error)
 	// GetServerReadmeByVersion retrieves the README for a specific server version
 	GetServerReadmeByVersion(ctx context.Context, serverName, version string) (*database.ServerReadme, error)
+	// SetServerSignature verifies and stores the publisher signature of a server version
+	SetServerSignature(ctx context.Context, sig *models.ServerSignature) (*models.ServerSignature, error)
+	// GetServerSignature retrieves the publisher signature of a server version
+	GetServerSignature(ctx context.Context, serverName, version string) (*models.ServerSignature, error)
+	// IntrospectServer starts or connects to a server version and stores the tools, resources and prompts it exposes
+	IntrospectServer(ctx context.Context, serverName, version string) (*models.ServerCapabilities, error)
+	// GetServerCapabilities retrieves the stored capabilities of a server version
+	GetServerCapabilities(ctx context.Context, serverName, version string) (*models.ServerCapabilities, error)
+	// ListServersDueForEnrichment returns the latest server versions never enriched or last enriched before enrichedBefore
+	ListServersDueForEnrichment(ctx context.Context, enrichedBefore time.Time, limit int) ([]*models.ServerEnrichment, error)
+	// RecordServerEnrichment stores the enriched details of a server version and when it was enriched
+	RecordServerEnrichment(ctx context.Context, serverName, version string, metadata map[string]any, enrichErr error) error
+	// RecordArtifactUsage counts a usage event (download, deploy, install) of a server, agent or skill
+	RecordArtifactUsage(ctx context.Context, artifactType, name, event string) error
+	// GetArtifactStats returns the usage counts of a server, agent or skill
+	GetArtifactStats(ctx context.Context, artifactType, name string) (*models.ArtifactStats, error)
+	// ListTags returns the tags of published servers and agents with their counts, most used first
+	ListTags(ctx context.Context) ([]models.Tag, error)
+	// SetVisibility changes the visibility of a server, agent or skill version
+	SetVisibility(ctx context.Context, artifactType, name, version, visibility string) error
+	// SubmitReview stores the caller's star rating and review of a server or agent, replacing their earlier one
+	SubmitReview(ctx context.Context, artifactType, name string, rating int, body string) (*models.Review, error)
+	// ListReviews returns the reviews of a server or agent, newest first, with its aggregate rating
+	ListReviews(ctx context.Context, artifactType, name string, includeHidden bool, cursor string, limit int) (*models.ReviewListResponse, error)
+	// GetRatingSummaries returns the aggregate ratings of the named servers or agents
+	GetRatingSummaries(ctx context.Context, artifactType string, names []string) (map[string]models.RatingSummary, error)
+	// ModerateReview hides or restores a review (admin only)
+	ModerateReview(ctx context.Context, id int64, hidden bool) (*models.Review, error)
+	// DeleteReview deletes one of the caller's reviews, or any review for admins
+	DeleteReview(ctx context.Context, id int64) error
+	// VerifyNamespace proves that the caller owns a namespace through GitHub membership or DNS and records it
+	VerifyNamespace(ctx context.Context, namespace, githubToken string) (*models.VerifiedNamespace, error)
+	// GetNamespaceChallenge returns the DNS TXT record the caller must publish to verify a namespace
+	GetNamespaceChallenge(ctx context.Context, namespace string) (*models.NamespaceChallenge, error)
+	// ListVerifiedNamespaces returns verified namespaces, optionally only those of one namespace or subject
+	ListVerifiedNamespaces(ctx context.Context, namespace, subject string) ([]models.VerifiedNamespace, error)
+	// RevokeNamespace removes the verification of a namespace for a subject (admin only)
+	RevokeNamespace(ctx context.Context, namespace, subject string) error
+	// SearchTools finds tools of published servers whose name or description contains query
+	SearchTools(ctx context.Context, query string, limit int) ([]models.ToolSearchResult, error)
 	// PublishServer marks a server as published
 	PublishServer(ctx context.Context, serverName, version string) error
 	// UnpublishServer marks a server as unpublished
 	UnpublishServer(ctx context.Context, serverName, version string) error
-	// DeleteServer permanently removes a server version from the registry
+	// DeleteServer moves a server version to the trash
 	DeleteServer(ctx context.Context, serverName, version string) error
+	// ListTrash lists the deleted server versions that can still be restored
+	ListTrash(ctx context.Context) ([]models.TrashEntry, error)
+	// RestoreServer restores a deleted server version from the trash
+	RestoreServer(ctx context.Context, serverName, version string) error
+	// PurgeServer permanently removes a deleted server version from the trash
+	PurgeServer(ctx context.Context, serverName, version string) error
 	// UpsertServerEmbedding stores semantic embedding metadata for a server version
 	UpsertServerEmbedding(ctx context.Context, serverName, version string, embedding *database.SemanticEmbedding) error
 	// GetServerEmbeddingMetadata retrieves the embedding metadata for a server version
@@ -55,6 +120,8 @@ type RegistryService interface {
 	GetAllVersionsByAgentName(ctx context.Context, agentName string) ([]*models.AgentResponse, error)
 	// CreateAgent creates a new agent version
 	CreateAgent(ctx context.Context, req *models.AgentJSON) (*models.AgentResponse, error)
+	// CreateAgentsBatch creates (and optionally publishes) each agent version in its own transaction, returning one error per item
+	CreateAgentsBatch(ctx context.Context, agents []*models.AgentJSON, publish bool) []error
 	// PublishAgent marks an agent as published
 	PublishAgent(ctx context.Context, agentName, version string) error
 	// UnpublishAgent marks an agent as unpublished
@@ -65,6 +132,16 @@ type RegistryService interface {
 	UpsertAgentEmbedding(ctx context.Context, agentName, version string, embedding *database.SemanticEmbedding) error
 	// GetAgentEmbeddingMetadata retrieves the embedding metadata for an agent version
 	GetAgentEmbeddingMetadata(ctx context.Context, agentName, version string) (*database.SemanticEmbeddingMetadata, error)
+	// StoreAgentSBOM attaches an SPDX or CycloneDX JSON SBOM to an agent version
+	StoreAgentSBOM(ctx context.Context, agentName, version string, content []byte) (*database.AgentSBOM, error)
+	// GetAgentSBOM retrieves the SBOM attached to an agent version
+	GetAgentSBOM(ctx context.Context, agentName, version string) (*database.AgentSBOM, error)
+	// StoreAgentReadme stores or updates the README for an agent version
+	StoreAgentReadme(ctx context.Context, agentName, version string, content []byte, contentType string) error
+	// GetAgentReadmeLatest retrieves the README for the latest agent version
+	GetAgentReadmeLatest(ctx context.Context, agentName string) (*database.AgentReadme, error)
+	// GetAgentReadmeByVersion retrieves the README for a specific agent version
+	GetAgentReadmeByVersion(ctx context.Context, agentName, version string) (*database.AgentReadme, error)
 	// Skills APIs
 	// ListSkills retrieve all skills with optional filtering
 	ListSkills(ctx context.Context, filter *database.SkillFilter, cursor string, limit int) ([]*models.SkillResponse, string, error)
@@ -76,24 +153,177 @@ type RegistryService interface {
 	GetAllVersionsBySkillName(ctx context.Context, skillName string) ([]*models.SkillResponse, error)
 	// CreateSkill creates a new skill version
 	CreateSkill(ctx context.Context, req *models.SkillJSON) (*models.SkillResponse, error)
+	// CreateSkillsBatch creates (and optionally publishes) each skill version in its own transaction, returning one error per item
+	CreateSkillsBatch(ctx context.Context, skills []*models.SkillJSON, publish bool) []error
 	// PublishSkill marks a skill as published
 	PublishSkill(ctx context.Context, skillName, version string) error
 	// UnpublishSkill marks a skill as unpublished
 	UnpublishSkill(ctx context.Context, skillName, version string) error
+	// SetSkillStatus changes the lifecycle status (active, deprecated, deleted) of a skill version
+	SetSkillStatus(ctx context.Context, skillName, version, status string) (*models.SkillResponse, error)
+	// GetSkillVersionStatuses retrieves the status and published flag of every version of a skill
+	GetSkillVersionStatuses(ctx context.Context, skillName string) ([]*models.SkillVersionStatus, error)
+	// StoreSkillReadme stores or updates the README for a skill version
+	StoreSkillReadme(ctx context.Context, skillName, version string, content []byte, contentType string) error
+	// GetSkillReadmeLatest retrieves the README for the latest skill version
+	GetSkillReadmeLatest(ctx context.Context, skillName string) (*database.SkillReadme, error)
+	// GetSkillReadmeByVersion retrieves the README for a specific skill version
+	GetSkillReadmeByVersion(ctx context.Context, skillName, version string) (*database.SkillReadme, error)
+
+	// Collections APIs
+	// CreateCollection creates an unpublished collection version after checking that every item exists
+	CreateCollection(ctx context.Context, req *models.CollectionJSON) (*models.CollectionResponse, error)
+	// GetCollection retrieves a collection version, or its latest version when version is empty or "latest"
+	GetCollection(ctx context.Context, name, version string) (*models.CollectionResponse, error)
+	// ListCollections retrieves the latest version of each collection, optionally only published ones
+	ListCollections(ctx context.Context, publishedOnly bool) ([]*models.CollectionResponse, error)
+	// PublishCollection marks a collection version as published
+	PublishCollection(ctx context.Context, name, version string) error
+	// UnpublishCollection marks a collection version as unpublished
+	UnpublishCollection(ctx context.Context, name, version string) error
+	// DeleteCollection removes a collection version
+	DeleteCollection(ctx context.Context, name, version string) error
+
+	// Workspaces APIs
+	// CreateWorkspace creates a workspace owned by the caller
+	CreateWorkspace(ctx context.Context, workspace *models.Workspace) (*models.Workspace, error)
+	// ListWorkspaces lists the workspaces the caller is a member of, or every workspace for registry admins
+	ListWorkspaces(ctx context.Context) ([]models.Workspace, error)
+	// GetWorkspace retrieves a workspace the caller is a member of
+	GetWorkspace(ctx context.Context, name string) (*models.Workspace, error)
+	// CheckWorkspaceAccess returns nil when the caller may act within a workspace, and ErrNotFound otherwise
+	CheckWorkspaceAccess(ctx context.Context, name string) error
+	// DeleteWorkspace deletes a workspace that no entries or deployments belong to
+	DeleteWorkspace(ctx context.Context, name string) error
+	// ListWorkspaceMembers lists the members of a workspace
+	ListWorkspaceMembers(ctx context.Context, name string) ([]models.WorkspaceMember, error)
+	// SetWorkspaceMember adds a member to a workspace or changes their role
+	SetWorkspaceMember(ctx context.Context, name, subject, role string) (*models.WorkspaceMember, error)
+	// RemoveWorkspaceMember removes a member from a workspace
+	RemoveWorkspaceMember(ctx context.Context, name, subject string) error
 
 	// Deployments APIs
 	// GetDeployments retrieves all deployed resources (MCP servers, agents)
 	GetDeployments(ctx context.Context, filter *models.DeploymentFilter) ([]*models.Deployment, error)
+	// GetQuota reports the quotas that apply to the caller and their usage, counting the servers of namespace when set
+	GetQuota(ctx context.Context, namespace string) (*models.Quota, error)
+	// ListDeploymentTargets returns the named targets deployments can land on
+	ListDeploymentTargets(ctx context.Context) ([]runtime.Target, error)
 	// GetDeploymentByName retrieves a specific deployment by resource name
 	GetDeploymentByNameAndVersion(ctx context.Context, resourceName string, version string, artifactType string) (*models.Deployment, error)
-	// DeployServer deploys an MCP server with configuration
-	DeployServer(ctx context.Context, serverName, version string, config map[string]string, preferRemote bool, runtime string) (*models.Deployment, error)
+	// DeployServer deploys an MCP server with configuration, optionally resolving its manifest from an origin registry
+	DeployServer(ctx context.Context, serverName, version string, config map[string]string, preferRemote bool, runtime string, origin string) (*models.Deployment, error)
+	// DeployServerCanary rolls a new version of a deployed MCP server out next to the current one, routing weight percent of its traffic to it
+	DeployServerCanary(ctx context.Context, serverName, version string, config map[string]string, weight int) (*models.Deployment, error)
+	// PromoteDeployment finishes the canary rollout of an MCP server, replacing the previous version
+	PromoteDeployment(ctx context.Context, serverName string) (*models.Deployment, error)
+	// ListDeploymentRevisions returns the revision history of a deployment, newest first
+	ListDeploymentRevisions(ctx context.Context, serverName, resourceType string) ([]*models.DeploymentRevision, error)
+	// GetDeploymentMetrics samples the CPU and memory usage of the containers of the deployments of a server or agent
+	GetDeploymentMetrics(ctx context.Context, serverName, resourceType string) (*models.DeploymentMetrics, error)
+	// RollbackDeployment returns a deployment to an earlier revision; revision 0 selects the one before the latest
+	RollbackDeployment(ctx context.Context, serverName, resourceType string, revision int) (*models.Deployment, error)
+	// DiffDeployment compares the desired state of a deployment with what runs on its target
+	DiffDeployment(ctx context.Context, serverName, version, resourceType string) (*models.DeploymentDiff, error)
+	// ExportRuntime renders all current deployments as a compose, kustomize or helm bundle, leaving secret references unresolved
+	ExportRuntime(ctx context.Context, format string) (*models.RuntimeExport, error)
 	// DeployAgent deploys an agent with configuration (to be implemented)
 	DeployAgent(ctx context.Context, agentName, version string, config map[string]string, preferRemote bool, runtime string) (*models.Deployment, error)
 	// UpdateDeploymentConfig updates the configuration for a deployment
 	UpdateDeploymentConfig(ctx context.Context, resourceName string, version string, artifactType string, config map[string]string) (*models.Deployment, error)
+	// UpdateDeploymentOrigin switches the registry an MCP server deployment resolves its manifest from
+	UpdateDeploymentOrigin(ctx context.Context, serverName string, version string, origin string) (*models.Deployment, error)
 	// RemoveDeployment removes a deployment (works for any resource type)
 	RemoveDeployment(ctx context.Context, resourceName string, version string, artifactType string) error
+	// GetDeploymentHealth returns the runtime state of all deployments
+	GetDeploymentHealth(ctx context.Context) ([]models.DeploymentHealth, error)
+	// CheckReadiness checks the dependencies the registry needs to serve requests: the database, the schema
+	// migrations and, if checkRuntime is set, the local runtime
+	CheckReadiness(ctx context.Context, checkRuntime bool) *models.Readiness
+
+	// Blob APIs
+	// PutBlob streams content into blob storage and returns its SHA-256 digest
+	PutBlob(ctx context.Context, r io.Reader) (*models.Blob, error)
+	// OpenBlob returns the content of a blob by digest; the caller must close it
+	OpenBlob(ctx context.Context, digest string) (io.ReadCloser, error)
+
+	// Attachment APIs
+	// UploadAttachment attaches an artifact file to a server ("mcp") or agent version, streaming it into blob storage
+	UploadAttachment(ctx context.Context, attachment *models.Attachment, content io.Reader) (*models.Attachment, error)
+	// ListAttachments returns the artifact files attached to a server or agent version
+	ListAttachments(ctx context.Context, artifactType, name, version string) ([]*models.Attachment, error)
+	// OpenAttachment returns an attached artifact file with its content; the caller must close the content
+	OpenAttachment(ctx context.Context, artifactType, name, version, fileName string) (*models.Attachment, io.ReadCloser, error)
+	// DeleteAttachment removes an artifact file from a server or agent version
+	DeleteAttachment(ctx context.Context, artifactType, name, version, fileName string) error
+
+	// Audit APIs
+	// ListAuditLog retrieves audit log entries with optional filtering (admin only)
+	ListAuditLog(ctx context.Context, filter *models.AuditLogFilter, cursor string, limit int) ([]*models.AuditLogEntry, string, error)
+
+	// RBAC APIs
+	// CreateRoleBinding grants a role on a namespace to a subject (admin only)
+	CreateRoleBinding(ctx context.Context, binding *models.RoleBinding) (*models.RoleBinding, error)
+	// ListRoleBindings lists role bindings, optionally filtered by subject (admin only)
+	ListRoleBindings(ctx context.Context, subject *string) ([]*models.RoleBinding, error)
+	// DeleteRoleBinding removes a role binding by ID (admin only)
+	DeleteRoleBinding(ctx context.Context, id int64) error
+
+	// API token APIs
+	// CreateAPIToken mints a new API token for the caller; the plaintext token is only returned here
+	CreateAPIToken(ctx context.Context, name string, permissions []auth.Permission, expiresAt *time.Time) (*models.CreatedAPIToken, error)
+	// ListAPITokens lists the caller's API tokens, or all tokens when all is set (admin only)
+	ListAPITokens(ctx context.Context, all bool) ([]*models.APIToken, error)
+	// RevokeAPIToken revokes an API token by ID
+	RevokeAPIToken(ctx context.Context, id int64) error
+
+	// Background job APIs
+	// RegisterJob adds a named background job to the registry's scheduler
+	RegisterJob(job jobs.Job) error
+	// PruneRuntime removes the images, runtime directories and gateway configs no deployment uses anymore, or only reports them in a dry run (admin only)
+	PruneRuntime(ctx context.Context, dryRun bool) (*models.PruneReport, error)
+	// GetSystemUsage reports the disk usage of the registry database and of the runtime's directory, images and volumes (admin only)
+	GetSystemUsage(ctx context.Context) (*models.SystemUsage, error)
+	// ListJobs returns the status of all background jobs (admin only)
+	ListJobs(ctx context.Context) ([]models.JobStatus, error)
+	// RunJob starts a background job immediately (admin only)
+	RunJob(ctx context.Context, name string) (*models.JobStatus, error)
+
+	// AddEventHandler registers a handler receiving the registry events written to the outbox, at least once each
+	AddEventHandler(handler EventHandler)
+
+	// CreateImport records a running import of seed data and assigns it an ID
+	CreateImport(ctx context.Context, source string, options models.ImportOptions) (*models.ImportStatus, error)
+	// UpdateImport records the progress of an import
+	UpdateImport(ctx context.Context, status *models.ImportStatus) error
+	// GetImport returns the progress of an import (admin only)
+	GetImport(ctx context.Context, id string) (*models.ImportStatus, error)
+	// ListImports returns the most recent imports, newest first (admin only)
+	ListImports(ctx context.Context) ([]models.ImportStatus, error)
+	// StartImport records an import and runs it in the background (admin only)
+	StartImport(ctx context.Context, request models.ImportRequest, run ImportRunner) (*models.ImportStatus, error)
+	// CancelImport cancels a running import (admin only)
+	CancelImport(ctx context.Context, id string) (*models.ImportStatus, error)
+	// Shutdown fails readiness and suspends background jobs, waiting until ctx is done for running ones to finish
+	Shutdown(ctx context.Context) error
+
+	// Deployment policy APIs
+	// ListDeploymentPolicies returns the active deployment policies from config and the API (admin only)
+	ListDeploymentPolicies(ctx context.Context) ([]models.DeploymentPolicy, error)
+	// PutDeploymentPolicy creates or replaces a deployment policy (admin only)
+	PutDeploymentPolicy(ctx context.Context, policy *models.DeploymentPolicy) (*models.DeploymentPolicy, error)
+	// DeleteDeploymentPolicy removes a deployment policy (admin only)
+	DeleteDeploymentPolicy(ctx context.Context, name string) error
+
+	// Config profile APIs; every method requires permission to deploy the server
+	// ListConfigProfiles lists the config profiles of a server
+	ListConfigProfiles(ctx context.Context, serverName string) ([]models.ConfigProfile, error)
+	// GetConfigProfile retrieves a config profile with its config resolved over the profiles it extends
+	GetConfigProfile(ctx context.Context, serverName, name string) (*models.ConfigProfile, error)
+	// PutConfigProfile creates or replaces a config profile of a server
+	PutConfigProfile(ctx context.Context, profile *models.ConfigProfile) (*models.ConfigProfile, error)
+	// DeleteConfigProfile removes a config profile that no other profile extends
+	DeleteConfigProfile(ctx context.Context, serverName, name string) error
 
 	Reconciler
 }
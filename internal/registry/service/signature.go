@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+	"github.com/jackc/pgx/v5"
+)
+
+// SetServerSignature verifies a publisher signature against the stored server.json and attaches it to the server version.
+// When TRUSTED_SIGNING_KEYS is configured only signatures from those keys are accepted.
+func (s *registryServiceImpl) SetServerSignature(ctx context.Context, sig *models.ServerSignature) (*models.ServerSignature, error) {
+	if sig == nil || sig.ServerName == "" || sig.Version == "" {
+		return nil, fmt.Errorf("%w: server name and version are required", database.ErrInvalidInput)
+	}
+	if len(sig.Signature) == 0 {
+		return nil, fmt.Errorf("%w: signature is required", database.ErrInvalidInput)
+	}
+
+	pub, err := signing.ParsePublicKey([]byte(sig.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	fingerprint, err := signing.Fingerprint(pub)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	if !s.signingKeyTrusted(fingerprint) {
+		return nil, fmt.Errorf("%w: signing key %s is not trusted by this registry", database.ErrInvalidInput, fingerprint)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.ServerSignature, error) {
+		server, err := s.db.GetServerByNameAndVersion(ctx, tx, sig.ServerName, sig.Version, false)
+		if err != nil {
+			return nil, err
+		}
+		if err := signing.Verify(pub, &server.Server, sig.Algorithm, sig.Signature); err != nil {
+			return nil, fmt.Errorf("%w: signature does not match the stored server.json: %v", database.ErrInvalidInput, err)
+		}
+
+		actor, _ := auth.ActorFrom(ctx)
+		stored := &models.ServerSignature{
+			ServerName:  sig.ServerName,
+			Version:     sig.Version,
+			Algorithm:   sig.Algorithm,
+			Signature:   sig.Signature,
+			PublicKey:   sig.PublicKey,
+			Fingerprint: fingerprint,
+			SignedBy:    actor,
+			CreatedAt:   time.Now(),
+		}
+		if err := s.db.UpsertServerSignature(ctx, tx, stored); err != nil {
+			return nil, err
+		}
+
+		details := map[string]any{"signatureFingerprint": fingerprint, "algorithm": sig.Algorithm}
+		if err := s.recordAudit(ctx, tx, models.AuditActionUpdate, "mcp", sig.ServerName, sig.Version, details); err != nil {
+			return nil, err
+		}
+		return stored, nil
+	})
+}
+
+// GetServerSignature retrieves the publisher signature of a server version
+func (s *registryServiceImpl) GetServerSignature(ctx context.Context, serverName, version string) (*models.ServerSignature, error) {
+	return s.db.GetServerSignature(ctx, nil, serverName, version)
+}
+
+// checkServerSignaturePolicy enforces REQUIRE_SIGNED_SERVERS when a server version is published
+func (s *registryServiceImpl) checkServerSignaturePolicy(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if s.cfg == nil || !s.cfg.RequireSignedServers {
+		return nil
+	}
+
+	sig, err := s.db.GetServerSignature(ctx, tx, serverName, version)
+	if errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("%w: server %s version %s must be signed before it can be published", database.ErrInvalidInput, serverName, version)
+	}
+	if err != nil {
+		return err
+	}
+	// The trusted key list may have changed since the signature was uploaded
+	if !s.signingKeyTrusted(sig.Fingerprint) {
+		return fmt.Errorf("%w: server %s version %s is signed with untrusted key %s", database.ErrInvalidInput, serverName, version, sig.Fingerprint)
+	}
+	return nil
+}
+
+// signingKeyTrusted reports whether the key fingerprint is allowed by TRUSTED_SIGNING_KEYS (any key when unset)
+func (s *registryServiceImpl) signingKeyTrusted(fingerprint string) bool {
+	if s.cfg == nil || strings.TrimSpace(s.cfg.TrustedSigningKeys) == "" {
+		return true
+	}
+	var trusted []string
+	for _, fp := range strings.Split(s.cfg.TrustedSigningKeys, ",") {
+		trusted = append(trusted, strings.TrimSpace(fp))
+	}
+	return slices.Contains(trusted, fingerprint)
+}
@@ -0,0 +1,76 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerSignaturePolicy(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	cfg := &config.Config{EnableRegistryValidation: false, RequireSignedServers: true}
+	svc := NewRegistryService(testDB, cfg, nil)
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/signed-server",
+		Description: "A signed server",
+		Version:     "1.0.0",
+	}
+	_, err := svc.CreateServer(ctx, server)
+	require.NoError(t, err)
+
+	// Unsigned servers cannot be published
+	err = svc.PublishServer(ctx, server.Name, server.Version)
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	privPEM, pubPEM, err := signing.GenerateKey()
+	require.NoError(t, err)
+	key, err := signing.ParsePrivateKey(privPEM)
+	require.NoError(t, err)
+	fingerprint, err := signing.Fingerprint(key.Public())
+	require.NoError(t, err)
+
+	// A signature over different content is rejected
+	tampered := *server
+	tampered.Description = "something else"
+	badSig, alg, err := signing.Sign(key, &tampered)
+	require.NoError(t, err)
+	_, err = svc.SetServerSignature(ctx, &models.ServerSignature{
+		ServerName: server.Name, Version: server.Version, Algorithm: alg, Signature: badSig, PublicKey: string(pubPEM),
+	})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	// Untrusted keys are rejected when a trust list is configured
+	sig, alg, err := signing.Sign(key, server)
+	require.NoError(t, err)
+	cfg.TrustedSigningKeys = "SHA256:0000"
+	_, err = svc.SetServerSignature(ctx, &models.ServerSignature{
+		ServerName: server.Name, Version: server.Version, Algorithm: alg, Signature: sig, PublicKey: string(pubPEM),
+	})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	cfg.TrustedSigningKeys = "SHA256:0000, " + fingerprint
+	stored, err := svc.SetServerSignature(ctx, &models.ServerSignature{
+		ServerName: server.Name, Version: server.Version, Algorithm: alg, Signature: sig, PublicKey: string(pubPEM),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, stored.Fingerprint)
+
+	got, err := svc.GetServerSignature(ctx, server.Name, server.Version)
+	require.NoError(t, err)
+	assert.Equal(t, sig, got.Signature)
+
+	require.NoError(t, svc.PublishServer(ctx, server.Name, server.Version))
+}
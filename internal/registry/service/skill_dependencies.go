@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// validateSkillDependencies checks that every MCP server a skill declares exists in the registry. Declared tools
+// are checked against the stored tool inventory of the server version; servers that were never introspected,
+// or whose introspection failed, are accepted as is.
+func (s *registryServiceImpl) validateSkillDependencies(ctx context.Context, tx pgx.Tx, skill *models.SkillJSON) error {
+	var errs []error
+	seen := make(map[string]bool, len(skill.MCPServers))
+	for _, dep := range skill.MCPServers {
+		if dep.Name == "" {
+			errs = append(errs, errors.New("MCP server dependency without a name"))
+			continue
+		}
+		if seen[dep.Name] {
+			errs = append(errs, fmt.Errorf("MCP server %s is declared more than once", dep.Name))
+			continue
+		}
+		seen[dep.Name] = true
+
+		var (
+			server *apiv0.ServerResponse
+			err    error
+		)
+		latest := dep.Version == "" || dep.Version == "latest"
+		if latest {
+			server, err = s.db.GetServerByName(ctx, tx, dep.Name)
+		} else {
+			server, err = s.db.GetServerByNameAndVersion(ctx, tx, dep.Name, dep.Version, false)
+		}
+		if errors.Is(err, database.ErrNotFound) {
+			if latest {
+				errs = append(errs, fmt.Errorf("MCP server %s does not exist", dep.Name))
+			} else {
+				errs = append(errs, fmt.Errorf("MCP server %s version %s does not exist", dep.Name, dep.Version))
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(dep.Tools) == 0 {
+			continue
+		}
+		caps, err := s.db.GetServerCapabilities(ctx, tx, server.Server.Name, server.Server.Version)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if caps.Error != "" {
+			continue
+		}
+		for _, tool := range dep.Tools {
+			if !slices.ContainsFunc(caps.Tools, func(t models.ToolInfo) bool { return t.Name == tool }) {
+				errs = append(errs, fmt.Errorf("MCP server %s version %s has no tool %s", dep.Name, server.Server.Version, tool))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", database.ErrInvalidInput, errors.Join(errs...))
+	}
+	return nil
+}
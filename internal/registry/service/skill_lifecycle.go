@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// SetSkillStatus changes the lifecycle status of a skill version. As with servers, a deleted
+// skill version cannot be brought back to another status.
+func (s *registryServiceImpl) SetSkillStatus(ctx context.Context, skillName, version, status string) (*models.SkillResponse, error) {
+	switch model.Status(status) {
+	case model.StatusActive, model.StatusDeprecated, model.StatusDeleted:
+	default:
+		return nil, fmt.Errorf("%w: invalid status %q, expected active, deprecated or deleted", database.ErrInvalidInput, status)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(txCtx context.Context, tx pgx.Tx) (*models.SkillResponse, error) {
+		current, err := s.db.GetSkillByNameAndVersion(txCtx, tx, skillName, version)
+		if err != nil {
+			return nil, err
+		}
+		if current.Meta.Official != nil && current.Meta.Official.Status == string(model.StatusDeleted) && status != string(model.StatusDeleted) {
+			return nil, fmt.Errorf("%w: deleted skills cannot be undeleted", database.ErrInvalidInput)
+		}
+
+		updated, err := s.db.SetSkillStatus(txCtx, tx, skillName, version, status)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.recordAudit(txCtx, tx, models.AuditActionUpdate, "skill", skillName, version, map[string]any{"status": status}); err != nil {
+			return nil, err
+		}
+		return updated, nil
+	})
+}
+
+// GetSkillVersionStatuses retrieves the status and published flag of every version of a skill
+func (s *registryServiceImpl) GetSkillVersionStatuses(ctx context.Context, skillName string) ([]*models.SkillVersionStatus, error) {
+	return s.db.ListSkillVersionStatuses(ctx, nil, skillName)
+}
+
+// StoreSkillReadme stores or updates the README for an existing skill version
+func (s *registryServiceImpl) StoreSkillReadme(ctx context.Context, skillName, version string, content []byte, contentType string) error {
+	if len(content) == 0 {
+		return fmt.Errorf("%w: README content is empty", database.ErrInvalidInput)
+	}
+	if err := validators.ValidateReadmeSize(content, s.readmeMaxBytes()); err != nil {
+		return err
+	}
+	if contentType == "" {
+		contentType = "text/markdown"
+	}
+
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		if _, err := s.db.GetSkillByNameAndVersion(txCtx, tx, skillName, version); err != nil {
+			return err
+		}
+		return s.db.UpsertSkillReadme(txCtx, tx, &database.SkillReadme{
+			SkillName:   skillName,
+			Version:     version,
+			Content:     append([]byte(nil), content...),
+			ContentType: contentType,
+			SizeBytes:   len(content),
+			FetchedAt:   time.Now(),
+		})
+	})
+}
+
+// GetSkillReadmeLatest retrieves the README for the latest skill version
+func (s *registryServiceImpl) GetSkillReadmeLatest(ctx context.Context, skillName string) (*database.SkillReadme, error) {
+	return s.db.GetLatestSkillReadme(ctx, nil, skillName)
+}
+
+// GetSkillReadmeByVersion retrieves the README for a specific skill version
+func (s *registryServiceImpl) GetSkillReadmeByVersion(ctx context.Context, skillName, version string) (*database.SkillReadme, error) {
+	return s.db.GetSkillReadme(ctx, nil, skillName, version)
+}
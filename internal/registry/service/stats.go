@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// RecordArtifactUsage counts a usage event of a server, agent or skill. Only artifacts in the registry are counted,
+// so anonymous pings cannot add arbitrary names.
+func (s *registryServiceImpl) RecordArtifactUsage(ctx context.Context, artifactType, name, event string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.RecordArtifactUsage", telemetry.ResourceAttributes(artifactType, name, "")...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := s.ensureArtifactExists(ctx, artifactType, name); err != nil {
+		return err
+	}
+	return s.db.IncrementArtifactUsage(ctx, nil, artifactType, name, event)
+}
+
+// GetArtifactStats returns the usage counts of a server, agent or skill
+func (s *registryServiceImpl) GetArtifactStats(ctx context.Context, artifactType, name string) (*models.ArtifactStats, error) {
+	if err := s.ensureArtifactExists(ctx, artifactType, name); err != nil {
+		return nil, err
+	}
+	return s.db.GetArtifactStats(ctx, nil, artifactType, name)
+}
+
+// recordUsageBestEffort counts a usage event, logging instead of failing the operation that used the artifact
+func (s *registryServiceImpl) recordUsageBestEffort(ctx context.Context, artifactType, name, event string) {
+	if err := s.db.IncrementArtifactUsage(ctx, nil, artifactType, name, event); err != nil {
+		log.Printf("Warning: failed to record %s of %s %s: %v", event, artifactType, name, err)
+	}
+}
+
+func (s *registryServiceImpl) ensureArtifactExists(ctx context.Context, artifactType, name string) error {
+	var err error
+	switch artifactType {
+	case "mcp":
+		_, err = s.db.GetServerByName(ctx, nil, name)
+	case "agent":
+		_, err = s.db.GetAgentByName(ctx, nil, name)
+	case "skill":
+		_, err = s.db.GetSkillByName(ctx, nil, name)
+	default:
+		return fmt.Errorf("%w: artifact type must be mcp, agent or skill", database.ErrInvalidInput)
+	}
+	return err
+}
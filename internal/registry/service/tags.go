@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// tagCountsInterval is how often the tag-counts job recounts the entries carrying each tag
+const tagCountsInterval = 10 * time.Minute
+
+// tagCountsJob recounts the published servers and agents carrying each tag for GET /v0/tags
+func (s *registryServiceImpl) tagCountsJob() jobs.Job {
+	return jobs.Job{
+		Name:        "tag-counts",
+		Description: "Count the published servers and agents carrying each tag",
+		Interval:    tagCountsInterval,
+		RunOnStart:  true,
+		Timeout:     time.Minute,
+		Run: func(ctx context.Context) error {
+			return s.db.RefreshTagCounts(ctx, nil)
+		},
+	}
+}
+
+// ListTags returns the tags of published servers and agents with their counts, most used first
+func (s *registryServiceImpl) ListTags(ctx context.Context) ([]models.Tag, error) {
+	return s.db.ListTags(ctx, nil)
+}
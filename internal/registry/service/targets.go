@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/dockercompose"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// loadTargets reads DEPLOYMENT_TARGETS_FILE. When it cannot be read only the built-in targets are available,
+// so deployments to the local docker host and current kubernetes context keep working.
+func (s *registryServiceImpl) loadTargets() {
+	s.targets = runtime.DefaultTargets()
+	if s.cfg == nil || s.cfg.DeploymentTargetsFile == "" {
+		return
+	}
+	targets, err := runtime.LoadTargets(s.cfg.DeploymentTargetsFile)
+	if err != nil {
+		log.Printf("Error: only the built-in deployment targets are available: %v", err)
+		return
+	}
+	s.targets = targets
+}
+
+// ListDeploymentTargets returns the deployment targets of the registry
+func (s *registryServiceImpl) ListDeploymentTargets(_ context.Context) ([]runtime.Target, error) {
+	return s.targets.List(), nil
+}
+
+// resolveDeploymentTarget returns the target named by a deploy request, which is either a runtime
+// ("local", "kubernetes") or a target from DEPLOYMENT_TARGETS_FILE.
+func (s *registryServiceImpl) resolveDeploymentTarget(name string) (runtime.Target, error) {
+	target, err := s.targets.Resolve(name, "")
+	if err != nil {
+		return runtime.Target{}, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	return target, nil
+}
+
+// deploymentTarget returns the target an existing deployment is reconciled on
+func (s *registryServiceImpl) deploymentTarget(d *models.Deployment) (runtime.Target, error) {
+	return s.targets.Resolve(d.Target, d.Runtime)
+}
+
+// newTargetRuntime creates the runtime backend that reconciles the deployments of a target
+func (s *registryServiceImpl) newTargetRuntime(target runtime.Target, regTranslator registry.Translator) runtime.AgentRegistryRuntime {
+	if target.Type == runtime.TargetTypeKubernetes {
+		return runtime.NewAgentRegistryRuntime(regTranslator, kagent.NewTranslatorWithNamespace(target.Namespace), s.cfg.RuntimeDir, s.cfg.Verbose,
+			runtime.WithKubeContext(target.Context))
+	}
+	runtimeDir := target.RuntimeDir(s.cfg.RuntimeDir)
+	composeTranslator := dockercompose.NewAgentGatewayTranslator(runtimeDir, s.cfg.AgentGatewayPort)
+	if target.Name != "local" {
+		// A separate compose project keeps --remove-orphans of one target from removing another's containers
+		// when both use the same docker host
+		composeTranslator = dockercompose.NewAgentGatewayTranslatorWithProjectName(runtimeDir, s.cfg.AgentGatewayPort, dockercompose.DefaultProjectName+"-"+target.Name)
+	}
+	return runtime.NewAgentRegistryRuntime(regTranslator, composeTranslator, runtimeDir, s.cfg.Verbose,
+		runtime.WithDockerHost(target.Host))
+}
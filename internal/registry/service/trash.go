@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// trashPurgeInterval is how often the trash-purge job removes the server versions deleted longer than the trash
+// retention ago
+const trashPurgeInterval = time.Hour
+
+// trashPurgeJob purges the server versions whose trash retention has passed
+func (s *registryServiceImpl) trashPurgeJob() jobs.Job {
+	return jobs.Job{
+		Name:        "trash-purge",
+		Description: "Permanently remove server versions deleted longer than the trash retention ago",
+		Interval:    trashPurgeInterval,
+		Timeout:     10 * time.Minute,
+		Run:         s.purgeExpiredTrash,
+	}
+}
+
+// ListTrash lists the deleted server versions that can still be restored. Only registry admins may list the trash.
+func (s *registryServiceImpl) ListTrash(ctx context.Context) ([]models.TrashEntry, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	entries, err := s.db.ListTrashedServers(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if retention := s.trashRetention(); retention > 0 {
+		for i := range entries {
+			purgeAt := entries[i].DeletedAt.Add(retention)
+			entries[i].PurgeAt = &purgeAt
+		}
+	}
+	return entries, nil
+}
+
+// RestoreServer restores a deleted server version from the trash. Only registry admins may restore servers.
+func (s *registryServiceImpl) RestoreServer(ctx context.Context, serverName, version string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.RestoreServer", telemetry.ResourceAttributes("mcp", serverName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if !s.db.IsRegistryAdmin(ctx) {
+		return fmt.Errorf("%w: only registry admins can restore servers", auth.ErrForbidden)
+	}
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return err
+		}
+		if err := s.db.RestoreServer(ctx, tx, serverName, version); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, tx, models.AuditActionRestore, "mcp", serverName, version, nil)
+	})
+}
+
+// PurgeServer permanently removes a deleted server version from the trash. Only registry admins may purge servers.
+func (s *registryServiceImpl) PurgeServer(ctx context.Context, serverName, version string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.PurgeServer", telemetry.ResourceAttributes("mcp", serverName, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if !s.db.IsRegistryAdmin(ctx) {
+		return fmt.Errorf("%w: only registry admins can purge servers", auth.ErrForbidden)
+	}
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		// Versions that are not deleted are not in the trash
+		if _, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version, false); err == nil {
+			return fmt.Errorf("%w: server %s version %s is not in the trash", database.ErrInvalidInput, serverName, version)
+		}
+		return s.purgeServer(ctx, tx, serverName, version)
+	})
+}
+
+// purgeExpiredTrash permanently removes the server versions deleted longer than the trash retention ago
+func (s *registryServiceImpl) purgeExpiredTrash(ctx context.Context) error {
+	retention := s.trashRetention()
+	if retention <= 0 {
+		return nil
+	}
+	deletedBefore := time.Now().Add(-retention)
+	entries, err := s.db.ListTrashedServers(ctx, nil, &deletedBefore)
+	if err != nil {
+		return err
+	}
+
+	purged := 0
+	for _, entry := range entries {
+		err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			return s.purgeServer(ctx, tx, entry.Name, entry.Version)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to purge server %s version %s: %w", entry.Name, entry.Version, err)
+		}
+		purged++
+	}
+	if purged > 0 {
+		log.Printf("Purged %d deleted server versions older than %s", purged, retention)
+	}
+	return nil
+}
+
+func (s *registryServiceImpl) purgeServer(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if err := s.db.DeleteServer(ctx, tx, serverName, version); err != nil {
+		return err
+	}
+	return s.recordAudit(ctx, tx, models.AuditActionPurge, "mcp", serverName, version, nil)
+}
+
+// trashRetention is how long deleted server versions stay in the trash; zero keeps them until purged by hand
+func (s *registryServiceImpl) trashRetention() time.Duration {
+	if s.cfg == nil {
+		return 0
+	}
+	return s.cfg.TrashRetention
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// GetSystemUsage reports the disk usage of the registry database and of the runtime: its directory and the images
+// and volumes of the docker targets. Only registry admins may read it.
+func (s *registryServiceImpl) GetSystemUsage(ctx context.Context) (*models.SystemUsage, error) {
+	if !s.db.IsRegistryAdmin(ctx) {
+		return nil, auth.ErrForbidden
+	}
+	usage := runtime.DiskUsage(ctx, s.runtimeDir(), s.targets)
+	size, err := s.db.DatabaseSize(ctx)
+	if err != nil {
+		usage.Warnings = append(usage.Warnings, err.Error())
+	}
+	usage.DatabaseBytes = size
+	return usage, nil
+}
+
+// GetDeploymentMetrics samples the CPU and memory usage of the containers of every deployed version of a server or
+// agent, from docker stats on docker targets and the metrics API on kubernetes targets
+func (s *registryServiceImpl) GetDeploymentMetrics(ctx context.Context, serverName, resourceType string) (*models.DeploymentMetrics, error) {
+	if resourceType == "" {
+		resourceType = "mcp"
+	}
+	deployments, err := s.GetDeployments(ctx, &models.DeploymentFilter{ResourceType: &resourceType})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &models.DeploymentMetrics{ServerName: serverName, ResourceType: resourceType, Containers: []models.ContainerMetrics{}}
+	found := false
+	for _, d := range deployments {
+		if d.ServerName != serverName {
+			continue
+		}
+		found = true
+		if d.IsExternal {
+			metrics.Notes = append(metrics.Notes, fmt.Sprintf("version %s is not managed by the registry", d.Version))
+			continue
+		}
+		samples, err := s.sampleDeployment(ctx, d)
+		if err != nil {
+			metrics.Notes = append(metrics.Notes, fmt.Sprintf("version %s: %v", d.Version, err))
+			continue
+		}
+		if len(samples) == 0 {
+			metrics.Notes = append(metrics.Notes, fmt.Sprintf("version %s has no running container of its own; stdio and remote MCP servers are served by the agent gateway", d.Version))
+			continue
+		}
+		for _, sample := range samples {
+			sample.Version = d.Version
+			metrics.Containers = append(metrics.Containers, sample)
+		}
+	}
+	if !found {
+		return nil, database.ErrNotFound
+	}
+	metrics.SampledAt = time.Now()
+	return metrics, nil
+}
+
+// sampleDeployment samples the containers of one deployment on the target it landed on
+func (s *registryServiceImpl) sampleDeployment(ctx context.Context, d *models.Deployment) ([]models.ContainerMetrics, error) {
+	target, err := s.deploymentTarget(d)
+	if err != nil {
+		return nil, err
+	}
+	if target.Type != runtime.TargetTypeKubernetes {
+		return runtime.LocalServiceMetrics(ctx, target.RuntimeDir(s.runtimeDir()), target.Host, localServiceName(d))
+	}
+
+	namespace := d.Config["KAGENT_NAMESPACE"]
+	if namespace == "" {
+		namespace = target.Namespace
+	}
+	if namespace == "" {
+		namespace = kagent.DefaultNamespace
+	}
+	workload := kagent.MCPServerResourceName(d.ServerName)
+	if d.ResourceType == "agent" {
+		workload = kagent.AgentResourceName(d.ServerName, d.Version)
+	}
+	return runtime.KubernetesPodMetrics(ctx, target.Context, namespace, workload)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// SetVisibility changes the visibility of a server, agent or skill version. Private entries must belong to a
+// workspace.
+func (s *registryServiceImpl) SetVisibility(ctx context.Context, artifactType, name, version, visibility string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.SetVisibility", telemetry.ResourceAttributes(artifactType, name, version)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if visibility == "" || !models.ValidVisibility(visibility) {
+		return fmt.Errorf("%w: visibility must be one of %s", database.ErrInvalidInput, strings.Join(models.Visibilities, ", "))
+	}
+
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		switch artifactType {
+		case "mcp":
+			current, err := s.db.GetServerByNameAndVersion(ctx, tx, name, version, false)
+			if err != nil {
+				return err
+			}
+			server := current.Server
+			models.SetServerVisibility(&server, visibility)
+			if _, err := s.db.UpdateServer(ctx, tx, name, version, &server); err != nil {
+				return err
+			}
+		case "agent":
+			current, err := s.db.GetAgentByNameAndVersion(ctx, tx, name, version)
+			if err != nil {
+				return err
+			}
+			agent := current.Agent
+			agent.Visibility = visibility
+			if _, err := s.db.UpdateAgent(ctx, tx, name, version, &agent); err != nil {
+				return err
+			}
+		case "skill":
+			current, err := s.db.GetSkillByNameAndVersion(ctx, tx, name, version)
+			if err != nil {
+				return err
+			}
+			skill := current.Skill
+			skill.Visibility = visibility
+			if _, err := s.db.UpdateSkill(ctx, tx, name, version, &skill); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: unsupported artifact type %q", database.ErrInvalidInput, artifactType)
+		}
+		return s.recordAudit(ctx, tx, models.AuditActionUpdate, artifactType, name, version, map[string]any{"visibility": visibility})
+	})
+}
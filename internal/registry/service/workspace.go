@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// CreateWorkspace creates a workspace owned by the caller
+func (s *registryServiceImpl) CreateWorkspace(ctx context.Context, workspace *models.Workspace) (_ *models.Workspace, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.CreateWorkspace", telemetry.ResourceAttributes("workspace", workspace.Name, "")...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	actor, _ := auth.ActorFrom(ctx)
+	if actor == "anonymous" {
+		return nil, fmt.Errorf("%w: workspaces can only be created by an identified user", auth.ErrForbidden)
+	}
+	if workspace.Name == "" {
+		return nil, fmt.Errorf("%w: workspace name is required", database.ErrInvalidInput)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.Workspace, error) {
+		created := *workspace
+		created.CreatedBy = actor
+		if err := s.db.CreateWorkspace(ctx, tx, &created); err != nil {
+			return nil, err
+		}
+		if err := s.recordAudit(ctx, tx, models.AuditActionCreate, "workspace", created.Name, "", nil); err != nil {
+			return nil, err
+		}
+		return &created, nil
+	})
+}
+
+// ListWorkspaces lists the workspaces the caller is a member of, or every workspace for registry admins
+func (s *registryServiceImpl) ListWorkspaces(ctx context.Context) ([]models.Workspace, error) {
+	if s.db.IsRegistryAdmin(ctx) {
+		return s.db.ListWorkspaces(ctx, nil, "")
+	}
+	actor, _ := auth.ActorFrom(ctx)
+	if actor == "anonymous" {
+		return []models.Workspace{}, nil
+	}
+	return s.db.ListWorkspaces(ctx, nil, actor)
+}
+
+// GetWorkspace retrieves a workspace the caller is a member of
+func (s *registryServiceImpl) GetWorkspace(ctx context.Context, name string) (*models.Workspace, error) {
+	if _, err := s.workspaceRole(ctx, nil, name); err != nil {
+		return nil, err
+	}
+	return s.db.GetWorkspace(ctx, nil, name)
+}
+
+// CheckWorkspaceAccess returns nil when the caller may act within a workspace, and ErrNotFound otherwise
+func (s *registryServiceImpl) CheckWorkspaceAccess(ctx context.Context, name string) error {
+	_, err := s.workspaceRole(ctx, nil, name)
+	return err
+}
+
+// DeleteWorkspace deletes a workspace. Only its owners and registry admins may delete it, and only once no entries or
+// deployments belong to it.
+func (s *registryServiceImpl) DeleteWorkspace(ctx context.Context, name string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.requireWorkspaceOwner(ctx, tx, name); err != nil {
+			return err
+		}
+		if err := s.db.DeleteWorkspace(ctx, tx, name); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, tx, models.AuditActionDelete, "workspace", name, "", nil)
+	})
+}
+
+// ListWorkspaceMembers lists the members of a workspace the caller is a member of
+func (s *registryServiceImpl) ListWorkspaceMembers(ctx context.Context, name string) ([]models.WorkspaceMember, error) {
+	if _, err := s.workspaceRole(ctx, nil, name); err != nil {
+		return nil, err
+	}
+	return s.db.ListWorkspaceMembers(ctx, nil, name)
+}
+
+// SetWorkspaceMember adds a member to a workspace or changes their role. Only owners and registry admins may manage
+// members.
+func (s *registryServiceImpl) SetWorkspaceMember(ctx context.Context, name, subject, role string) (*models.WorkspaceMember, error) {
+	if subject == "" {
+		return nil, fmt.Errorf("%w: member subject is required", database.ErrInvalidInput)
+	}
+	if role == "" {
+		role = models.WorkspaceRoleMember
+	}
+	if role != models.WorkspaceRoleOwner && role != models.WorkspaceRoleMember {
+		return nil, fmt.Errorf("%w: role must be %q or %q", database.ErrInvalidInput, models.WorkspaceRoleOwner, models.WorkspaceRoleMember)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.WorkspaceMember, error) {
+		if err := s.requireWorkspaceOwner(ctx, tx, name); err != nil {
+			return nil, err
+		}
+		if role != models.WorkspaceRoleOwner {
+			if err := s.checkNotLastOwner(ctx, tx, name, subject); err != nil {
+				return nil, err
+			}
+		}
+		member := models.WorkspaceMember{Subject: subject, Role: role}
+		if err := s.db.SetWorkspaceMember(ctx, tx, name, &member); err != nil {
+			return nil, err
+		}
+		details := map[string]any{"subject": subject, "role": role}
+		if err := s.recordAudit(ctx, tx, models.AuditActionUpdate, "workspace", name, "", details); err != nil {
+			return nil, err
+		}
+		return &member, nil
+	})
+}
+
+// RemoveWorkspaceMember removes a member from a workspace. Owners and registry admins may remove anyone; members may
+// only remove themselves. The last owner cannot be removed.
+func (s *registryServiceImpl) RemoveWorkspaceMember(ctx context.Context, name, subject string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		role, err := s.workspaceRole(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+		if actor, _ := auth.ActorFrom(ctx); role != models.WorkspaceRoleOwner && actor != subject {
+			return auth.ErrForbidden
+		}
+		if err := s.checkNotLastOwner(ctx, tx, name, subject); err != nil {
+			return err
+		}
+		if err := s.db.RemoveWorkspaceMember(ctx, tx, name, subject); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, tx, models.AuditActionUpdate, "workspace", name, "", map[string]any{"removed": subject})
+	})
+}
+
+// workspaceRole returns the caller's role in a workspace. Registry admins act as owners of every workspace. Callers
+// outside a workspace get ErrNotFound so that its existence is not revealed.
+func (s *registryServiceImpl) workspaceRole(ctx context.Context, tx pgx.Tx, name string) (string, error) {
+	if _, err := s.db.GetWorkspace(ctx, tx, name); err != nil {
+		return "", err
+	}
+	if s.db.IsRegistryAdmin(ctx) {
+		return models.WorkspaceRoleOwner, nil
+	}
+	actor, _ := auth.ActorFrom(ctx)
+	if actor == "anonymous" {
+		return "", database.ErrNotFound
+	}
+	return s.db.GetWorkspaceRole(ctx, tx, name, actor)
+}
+
+// requireWorkspaceOwner fails unless the caller owns the workspace or is a registry admin
+func (s *registryServiceImpl) requireWorkspaceOwner(ctx context.Context, tx pgx.Tx, name string) error {
+	role, err := s.workspaceRole(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+	if role != models.WorkspaceRoleOwner {
+		return auth.ErrForbidden
+	}
+	return nil
+}
+
+// checkNotLastOwner fails when subject is the only owner of a workspace, which would leave it unmanageable
+func (s *registryServiceImpl) checkNotLastOwner(ctx context.Context, tx pgx.Tx, name, subject string) error {
+	role, err := s.db.GetWorkspaceRole(ctx, tx, name, subject)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if role != models.WorkspaceRoleOwner {
+		return nil
+	}
+
+	members, err := s.db.ListWorkspaceMembers(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+	owners := 0
+	for _, member := range members {
+		if member.Role == models.WorkspaceRoleOwner {
+			owners++
+		}
+	}
+	if owners <= 1 {
+		return fmt.Errorf("%w: %s is the last owner of workspace %s", database.ErrInvalidInput, subject, name)
+	}
+	return nil
+}
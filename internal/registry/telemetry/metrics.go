@@ -93,10 +93,8 @@ func NewPrometheusMeterProvider(res *resource.Resource, exp *prometheus.Exporter
 	return meterProvider, nil
 }
 
-func InitMetrics(version string) (ShutdownFunc, *Metrics, error) {
-	// Initialized the returned shutdownFunc to no-op.
-	shutdown := func(_ context.Context) error { return nil }
-
+// newResource describes the registry process for metrics and traces
+func newResource(version string) (*resource.Resource, error) {
 	res, err := resource.New(context.Background(),
 		resource.WithAttributes(
 			semconv.ServiceName(Namespace),
@@ -105,12 +103,23 @@ func InitMetrics(version string) (ShutdownFunc, *Metrics, error) {
 		resource.WithProcessRuntimeDescription(),
 	)
 	if err != nil {
-		return shutdown, nil, fmt.Errorf("failed to create resource: %w", err)
+		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
 	res, err = resource.Merge(resource.Default(), res)
 	if err != nil {
-		return shutdown, nil, fmt.Errorf("failed to merge resources: %w", err)
+		return nil, fmt.Errorf("failed to merge resources: %w", err)
+	}
+	return res, nil
+}
+
+func InitMetrics(version string) (ShutdownFunc, *Metrics, error) {
+	// Initialized the returned shutdownFunc to no-op.
+	shutdown := func(_ context.Context) error { return nil }
+
+	res, err := newResource(version)
+	if err != nil {
+		return shutdown, nil, err
 	}
 
 	exporter, err := prometheus.New()
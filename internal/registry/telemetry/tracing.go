@@ -0,0 +1,91 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingEnabled reports whether traces should be exported: an endpoint is configured either through
+// AGENT_REGISTRY_OTLP_ENDPOINT or the standard OTEL_EXPORTER_OTLP_* variables
+func TracingEnabled(endpoint string) bool {
+	return endpoint != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != ""
+}
+
+// InitTracing installs a tracer provider that exports spans over OTLP/HTTP, and the W3C trace context
+// propagator so traces started by callers continue through the registry. The exporter also reads the
+// standard OTEL_EXPORTER_OTLP_* variables (headers, timeout, TLS).
+func InitTracing(ctx context.Context, version, endpoint string, sampleRatio float64) (ShutdownFunc, error) {
+	shutdown := func(_ context.Context) error { return nil }
+
+	res, err := newResource(version)
+	if err != nil {
+		return shutdown, err
+	}
+
+	var opts []otlptracehttp.Option
+	if endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return shutdown, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exporter),
+		// Follow the caller's sampling decision; only sample a fraction of the traces started here
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tp.Shutdown, nil
+}
+
+// Tracer returns the registry tracer. Its spans are no-ops until InitTracing installs a provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(Namespace, trace.WithSchemaURL(semconv.SchemaURL))
+}
+
+// StartSpan starts a span as a child of the span in ctx, if any
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err on the span, if any, and ends it. Call it in a deferred closure over a named error
+// result so the final error is recorded:
+//
+//	ctx, span := telemetry.StartSpan(ctx, "RegistryService.PublishServer")
+//	defer func() { telemetry.EndSpan(span, err) }()
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ResourceAttributes describes the registry resource a span operates on
+func ResourceAttributes(resourceType, name, version string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("registry.resource.type", resourceType),
+		attribute.String("registry.resource.name", name),
+	}
+	if version != "" {
+		attrs = append(attrs, attribute.String("registry.resource.version", version))
+	}
+	return attrs
+}
@@ -0,0 +1,52 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+)
+
+func TestStartSpanAndEndSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, parent := telemetry.StartSpan(context.Background(), "parent")
+	_, child := telemetry.StartSpan(ctx, "child", telemetry.ResourceAttributes("mcp", "com.example/server", "1.0.0")...)
+	telemetry.EndSpan(child, errors.New("boom"))
+	telemetry.EndSpan(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	childSpan, parentSpan := spans[0], spans[1]
+	assert.Equal(t, "child", childSpan.Name())
+	assert.Equal(t, parentSpan.SpanContext().SpanID(), childSpan.Parent().SpanID())
+	assert.Equal(t, codes.Error, childSpan.Status().Code)
+	assert.Equal(t, "boom", childSpan.Status().Description)
+	assert.Contains(t, childSpan.Attributes(), attribute.String("registry.resource.name", "com.example/server"))
+	assert.Contains(t, childSpan.Attributes(), attribute.String("registry.resource.version", "1.0.0"))
+	assert.Equal(t, codes.Unset, parentSpan.Status().Code)
+}
+
+func TestTracingEnabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	assert.False(t, telemetry.TracingEnabled(""))
+	assert.True(t, telemetry.TracingEnabled("http://localhost:4318"))
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+	assert.True(t, telemetry.TracingEnabled(""))
+}
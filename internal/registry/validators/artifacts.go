@@ -0,0 +1,239 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+const (
+	maxArtifactNameLength  = 200
+	maxArtifactTitleLength = 100
+)
+
+// artifactNameRegex matches agent and skill names: a name part optionally prefixed by a reverse-DNS namespace
+var artifactNameRegex = regexp.MustCompile(`^(` + namespacePattern + `/)?[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$`)
+
+// agentMCPServerTypes are the kinds of MCP servers an agent can reference
+var agentMCPServerTypes = []string{"remote", "command", "registry"}
+
+// FieldError is a problem with a single field of a published payload
+type FieldError struct {
+	// Field is the path of the field, e.g. "packages[0].identifier"
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Value   any    `json:"value,omitempty"`
+}
+
+// ValidationError lists every field-level problem of an agent or skill payload. It wraps
+// database.ErrInvalidInput so callers that only distinguish invalid input keep working.
+type ValidationError struct {
+	Kind   string
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + ": " + f.Message
+	}
+	return fmt.Sprintf("invalid %s payload: %s", e.Kind, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return database.ErrInvalidInput
+}
+
+// fieldErrors collects field errors while validating a payload
+type fieldErrors struct {
+	fields []FieldError
+}
+
+func (f *fieldErrors) add(field string, value any, format string, args ...any) {
+	f.fields = append(f.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...), Value: value})
+}
+
+func (f *fieldErrors) check(field string, value any, err error) {
+	if err != nil {
+		f.fields = append(f.fields, FieldError{Field: field, Message: err.Error(), Value: value})
+	}
+}
+
+func (f *fieldErrors) err(kind string) error {
+	if len(f.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Kind: kind, Fields: f.fields}
+}
+
+// ValidateAgentJSON checks an agent payload before it is stored, reporting every invalid field
+func ValidateAgentJSON(agent *models.AgentJSON) error {
+	if agent == nil {
+		return &ValidationError{Kind: "agent", Fields: []FieldError{{Field: "", Message: "payload is required"}}}
+	}
+	var errs fieldErrors
+	validateArtifactCommon(&errs, agent.Name, agent.Version, agent.Title, agent.WebsiteURL)
+	errs.check("repository", agent.Repository, validateRepository(agent.Repository))
+	if agent.TelemetryEndpoint != "" {
+		errs.check("telemetryEndpoint", agent.TelemetryEndpoint, validateAbsoluteURL(agent.TelemetryEndpoint))
+	}
+
+	for i, pkg := range agent.Packages {
+		validateArtifactPackage(&errs, fmt.Sprintf("packages[%d]", i), pkg.RegistryType, pkg.Identifier, pkg.Version)
+	}
+	for i, remote := range agent.Remotes {
+		field := fmt.Sprintf("remotes[%d]", i)
+		if remote.Type == "" {
+			errs.add(field+".type", remote.Type, "transport type is required")
+		}
+		errs.check(field+".url", remote.URL, validateAbsoluteURL(remote.URL))
+	}
+
+	for i, server := range agent.McpServers {
+		field := fmt.Sprintf("mcpServers[%d]", i)
+		if server.Name == "" {
+			errs.add(field+".name", server.Name, "name is required")
+		}
+		switch server.Type {
+		case "remote":
+			errs.check(field+".url", server.URL, validateAbsoluteURL(server.URL))
+		case "command":
+			if server.Command == "" && server.Image == "" && server.Build == "" {
+				errs.add(field+".command", server.Command, "command, image or build is required for command servers")
+			}
+		case "registry":
+			if server.RegistryServerName == "" {
+				errs.add(field+".registryServerName", server.RegistryServerName, "registryServerName is required for registry servers")
+			}
+		default:
+			errs.add(field+".type", server.Type, "type must be one of %s", strings.Join(agentMCPServerTypes, ", "))
+		}
+	}
+	if !models.ValidVisibility(agent.Visibility) {
+		errs.add("visibility", agent.Visibility, "visibility must be one of %s", strings.Join(models.Visibilities, ", "))
+	}
+	return errs.err("agent")
+}
+
+// ValidateSkillJSON checks a skill payload before it is stored, reporting every invalid field
+func ValidateSkillJSON(skill *models.SkillJSON) error {
+	if skill == nil {
+		return &ValidationError{Kind: "skill", Fields: []FieldError{{Field: "", Message: "payload is required"}}}
+	}
+	var errs fieldErrors
+	validateArtifactCommon(&errs, skill.Name, skill.Version, skill.Title, skill.WebsiteURL)
+	if skill.Repository != nil && skill.Repository.URL != "" {
+		errs.check("repository.url", skill.Repository.URL, validateAbsoluteURL(skill.Repository.URL))
+	}
+
+	for i, pkg := range skill.Packages {
+		validateArtifactPackage(&errs, fmt.Sprintf("packages[%d]", i), pkg.RegistryType, pkg.Identifier, pkg.Version)
+	}
+	for i, remote := range skill.Remotes {
+		errs.check(fmt.Sprintf("remotes[%d].url", i), remote.URL, validateAbsoluteURL(remote.URL))
+	}
+	for i, dep := range skill.MCPServers {
+		field := fmt.Sprintf("mcpServers[%d]", i)
+		if dep.Name == "" {
+			errs.add(field+".name", dep.Name, "name is required")
+		}
+		if dep.Version != "" {
+			errs.check(field+".version", dep.Version, validateVersion(dep.Version))
+		}
+	}
+	if !models.ValidVisibility(skill.Visibility) {
+		errs.add("visibility", skill.Visibility, "visibility must be one of %s", strings.Join(models.Visibilities, ", "))
+	}
+	return errs.err("skill")
+}
+
+// ValidateCollectionJSON checks a collection payload and the type, name and version of each of its items
+func ValidateCollectionJSON(collection *models.CollectionJSON) error {
+	if collection == nil {
+		return &ValidationError{Kind: "collection", Fields: []FieldError{{Field: "", Message: "payload is required"}}}
+	}
+	var errs fieldErrors
+	validateArtifactCommon(&errs, collection.Name, collection.Version, collection.Title, "")
+	if len(collection.Items) == 0 {
+		errs.add("items", nil, "a collection needs at least one item")
+	}
+	seen := make(map[string]bool, len(collection.Items))
+	for i, item := range collection.Items {
+		field := fmt.Sprintf("items[%d]", i)
+		switch item.Type {
+		case models.CollectionItemTypeMCP, models.CollectionItemTypeAgent, models.CollectionItemTypeSkill:
+		default:
+			errs.add(field+".type", item.Type, "type must be mcp, agent or skill")
+		}
+		if item.Name == "" {
+			errs.add(field+".name", item.Name, "name is required")
+		} else if seen[item.Type+"/"+item.Name] {
+			errs.add(field+".name", item.Name, "%s %s is listed more than once", item.Type, item.Name)
+		}
+		seen[item.Type+"/"+item.Name] = true
+		if item.Version != "" && item.Version != "latest" {
+			errs.check(field+".version", item.Version, validateVersion(item.Version))
+		}
+	}
+	return errs.err("collection")
+}
+
+// validateArtifactCommon checks the fields agents and skills share
+func validateArtifactCommon(errs *fieldErrors, name, version, title, websiteURL string) {
+	switch {
+	case name == "":
+		errs.add("name", name, "name is required")
+	case len(name) > maxArtifactNameLength:
+		errs.add("name", name, "name must be at most %d characters", maxArtifactNameLength)
+	case !artifactNameRegex.MatchString(name):
+		errs.add("name", name, "name must be letters, digits, '.', '_' and '-', optionally prefixed by a reverse-DNS namespace (e.g. com.example/my-agent)")
+	}
+
+	if version == "" {
+		errs.add("version", version, "version is required")
+	} else {
+		errs.check("version", version, validateVersion(version))
+	}
+
+	if len(title) > maxArtifactTitleLength {
+		errs.add("title", title, "title must be at most %d characters", maxArtifactTitleLength)
+	} else {
+		errs.check("title", title, validateTitle(title))
+	}
+	errs.check("websiteUrl", websiteURL, validateWebsiteURL(websiteURL))
+}
+
+// validateArtifactPackage checks a package reference of an agent or skill
+func validateArtifactPackage(errs *fieldErrors, field, registryType, identifier, version string) {
+	if registryType == "" {
+		errs.add(field+".registryType", registryType, "registryType is required")
+	}
+	if identifier == "" {
+		errs.add(field+".identifier", identifier, "identifier is required")
+	} else if !HasNoSpaces(identifier) {
+		errs.check(field+".identifier", identifier, ErrPackageNameHasSpaces)
+	}
+	if version != "" {
+		errs.check(field+".version", version, validateVersion(version))
+	}
+}
+
+// validateAbsoluteURL checks that a value is an absolute http(s) URL
+func validateAbsoluteURL(value string) error {
+	if value == "" {
+		return errors.New("url is required")
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if !parsed.IsAbs() || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != SchemeHTTPS) {
+		return fmt.Errorf("must be an absolute http or https URL: %s", value)
+	}
+	return nil
+}
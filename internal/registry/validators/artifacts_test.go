@@ -0,0 +1,131 @@
+package validators_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func fieldMessages(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var verr *validators.ValidationError
+	require.True(t, errors.As(err, &verr), "expected a ValidationError, got %v", err)
+	fields := map[string]string{}
+	for _, f := range verr.Fields {
+		fields[f.Field] = f.Message
+	}
+	return fields
+}
+
+func TestValidateAgentJSON(t *testing.T) {
+	t.Run("valid agent", func(t *testing.T) {
+		err := validators.ValidateAgentJSON(&models.AgentJSON{
+			AgentManifest: models.AgentManifest{
+				Name:        "com.example/planner",
+				Description: "Plans trips",
+				McpServers: []models.McpServerType{
+					{Type: "remote", Name: "weather", URL: "http://weather:3000/mcp"},
+					{Type: "registry", Name: "search", RegistryServerName: "com.example/search"},
+				},
+			},
+			Version: "1.0.0",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		err := validators.ValidateAgentJSON(&models.AgentJSON{
+			AgentManifest: models.AgentManifest{
+				Name: "my agent",
+				McpServers: []models.McpServerType{
+					{Type: "remote", Name: "weather", URL: "weather:3000"},
+					{Type: "stdio", Name: "local"},
+				},
+			},
+			Packages: []models.AgentPackageInfo{{RegistryType: "oci"}},
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, database.ErrInvalidInput))
+
+		fields := fieldMessages(t, err)
+		assert.Contains(t, fields, "name")
+		assert.Contains(t, fields, "version")
+		assert.Contains(t, fields, "packages[0].identifier")
+		assert.Contains(t, fields, "mcpServers[0].url")
+		assert.Contains(t, fields["mcpServers[1].type"], "remote, command, registry")
+	})
+}
+
+func TestValidateSkillJSON(t *testing.T) {
+	t.Run("valid skill", func(t *testing.T) {
+		err := validators.ValidateSkillJSON(&models.SkillJSON{
+			Name:       "com.example/summarize",
+			Version:    "0.1.0",
+			Repository: &models.SkillRepository{URL: "https://github.com/example/skills", Source: "github"},
+			MCPServers: []models.SkillServerDependency{{Name: "com.example/search", Version: "1.0.0"}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		err := validators.ValidateSkillJSON(&models.SkillJSON{
+			Name:       "com.example/summarize",
+			Version:    "^1.0.0",
+			Repository: &models.SkillRepository{URL: "not a url"},
+			MCPServers: []models.SkillServerDependency{{}},
+		})
+		require.Error(t, err)
+
+		fields := fieldMessages(t, err)
+		assert.NotContains(t, fields, "name")
+		assert.Contains(t, fields, "version")
+		assert.Contains(t, fields, "repository.url")
+		assert.Contains(t, fields, "mcpServers[0].name")
+	})
+}
+
+func TestValidateCollectionJSON(t *testing.T) {
+	t.Run("valid collection", func(t *testing.T) {
+		err := validators.ValidateCollectionJSON(&models.CollectionJSON{
+			Name:    "com.example/data-engineering",
+			Version: "1.0.0",
+			Items: []models.CollectionItem{
+				{Type: models.CollectionItemTypeMCP, Name: "com.example/postgres", Version: "1.2.0"},
+				{Type: models.CollectionItemTypeSkill, Name: "com.example/sql-review"},
+			},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports every invalid item", func(t *testing.T) {
+		err := validators.ValidateCollectionJSON(&models.CollectionJSON{
+			Name:    "com.example/data-engineering",
+			Version: "1.0.0",
+			Items: []models.CollectionItem{
+				{Type: "plugin", Name: "com.example/postgres"},
+				{Type: models.CollectionItemTypeMCP, Name: "com.example/postgres", Version: "^1.0.0"},
+				{Type: models.CollectionItemTypeMCP, Name: "com.example/postgres"},
+				{Type: models.CollectionItemTypeAgent},
+			},
+		})
+		require.Error(t, err)
+
+		fields := fieldMessages(t, err)
+		assert.Contains(t, fields, "items[0].type")
+		assert.Contains(t, fields, "items[1].version")
+		assert.Contains(t, fields, "items[2].name")
+		assert.Contains(t, fields, "items[3].name")
+	})
+
+	t.Run("requires items", func(t *testing.T) {
+		err := validators.ValidateCollectionJSON(&models.CollectionJSON{Name: "com.example/empty", Version: "1.0.0"})
+		require.Error(t, err)
+		assert.Contains(t, fieldMessages(t, err), "items")
+	})
+}
@@ -16,6 +16,9 @@ var (
 	// Remote validation errors
 	ErrInvalidRemoteURL = errors.New("invalid remote URL")
 
+	// URL host validation errors
+	ErrDisallowedURLHost = errors.New("URL host is not allowed")
+
 	// Registry validation errors
 	ErrUnsupportedRegistryBaseURL   = errors.New("unsupported registry base URL")
 	ErrMismatchedRegistryTypeAndURL = errors.New("registry type and base URL do not match")
@@ -29,6 +32,10 @@ var (
 	// Server name validation errors
 	ErrMultipleSlashesInServerName = errors.New("server name cannot contain multiple slashes")
 	ErrInvalidServerNameFormat     = errors.New("server name format is invalid")
+
+	// Size validation errors
+	ErrDocumentTooLarge = errors.New("document is too large")
+	ErrReadmeTooLarge   = errors.New("README is too large")
 )
 
 // RepositorySource represents valid repository sources
@@ -0,0 +1,109 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Severities of validation issues
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is a single problem found in a server.json. Errors make the publish endpoint reject the server; warnings
+// point at optional fields worth filling in.
+type Issue struct {
+	Severity string `json:"severity"`
+	// Field is the path of the offending field, e.g. "packages[0]" or "websiteUrl"
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// Report collects every issue found in a server.json
+type Report struct {
+	Issues []Issue `json:"issues"`
+}
+
+// Valid reports whether the server.json has no errors and would be accepted for publishing
+func (r *Report) Valid() bool {
+	return r.Count(SeverityError) == 0
+}
+
+// Count returns the number of issues of a severity
+func (r *Report) Count(severity string) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *Report) add(severity, field string, err error) {
+	if err != nil {
+		r.Issues = append(r.Issues, Issue{Severity: severity, Field: field, Message: err.Error()})
+	}
+}
+
+// CheckServerJSON runs the checks of ValidatePublishRequest on a server.json, collecting every issue instead of
+// stopping at the first one, and adds warnings for recommended fields that are missing. checkRegistries also
+// verifies that each package exists in its registry and references the server, which needs network access.
+func CheckServerJSON(ctx context.Context, req apiv0.ServerJSON, checkRegistries bool) *Report {
+	report := &Report{Issues: []Issue{}}
+
+	report.add(SeverityError, "_meta", validatePublisherExtensions(req))
+	report.add(SeverityError, "$schema", validateSchema(req.Schema))
+	_, nameErr := parseServerName(req)
+	report.add(SeverityError, "name", nameErr)
+	report.add(SeverityError, "version", validateVersion(req.Version))
+	report.add(SeverityError, "repository", validateRepository(req.Repository))
+	report.add(SeverityError, "websiteUrl", validateWebsiteURL(req.WebsiteURL))
+	report.add(SeverityError, "title", validateTitle(req.Title))
+	report.add(SeverityError, "icons", validateIcons(req.Icons))
+
+	for i, pkg := range req.Packages {
+		field := fmt.Sprintf("packages[%d]", i)
+		if err := validatePackageField(&pkg); err != nil {
+			report.add(SeverityError, field, err)
+			continue
+		}
+		if checkRegistries && nameErr == nil {
+			if err := ValidatePackage(ctx, pkg, req.Name); err != nil {
+				report.add(SeverityError, field, fmt.Errorf("registry validation failed (%s): %w", pkg.Identifier, err))
+			}
+		}
+	}
+	// Registries reject local and private URL hosts unless ALLOW_PRIVATE_URLS is set
+	var urlPolicy URLPolicy
+	if req.WebsiteURL != "" && validateWebsiteURL(req.WebsiteURL) == nil {
+		report.add(SeverityError, "websiteUrl", urlPolicy.Check(ctx, req.WebsiteURL))
+	}
+	for i, remote := range req.Remotes {
+		field := fmt.Sprintf("remotes[%d]", i)
+		if err := validateRemoteTransport(&remote); err != nil {
+			report.add(SeverityError, field, err)
+			continue
+		}
+		report.add(SeverityError, field+".url", urlPolicy.Check(ctx, remote.URL))
+	}
+	if nameErr == nil {
+		report.add(SeverityError, "remotes", validateRemoteNamespaceMatch(req))
+		report.add(SeverityError, "websiteUrl", validateWebsiteURLNamespaceMatch(req))
+	}
+
+	if req.Description == "" {
+		report.add(SeverityWarning, "description", errors.New("description is empty; it is shown in listings and used for search"))
+	}
+	if req.Repository == nil || req.Repository.URL == "" {
+		report.add(SeverityWarning, "repository", errors.New("no source repository; it is needed for enrichment and for users to review the code"))
+	}
+	if len(req.Packages) == 0 && len(req.Remotes) == 0 {
+		report.add(SeverityWarning, "", errors.New("server has neither packages nor remotes, so it cannot be run or deployed"))
+	}
+	return report
+}
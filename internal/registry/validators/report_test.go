@@ -0,0 +1,51 @@
+package validators_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestCheckServerJSON(t *testing.T) {
+	t.Run("collects every issue", func(t *testing.T) {
+		report := validators.CheckServerJSON(context.Background(), apiv0.ServerJSON{
+			Name:    "com.example/test-server",
+			Version: "^1.0.0",
+			Remotes: []model.Transport{
+				{Type: "streamable-http", URL: "https://other.org/mcp"},
+			},
+		}, false)
+
+		assert.False(t, report.Valid())
+		fields := map[string]string{}
+		for _, issue := range report.Issues {
+			fields[issue.Field] = issue.Severity
+		}
+		assert.Equal(t, validators.SeverityError, fields["$schema"])
+		assert.Equal(t, validators.SeverityError, fields["version"])
+		assert.Equal(t, validators.SeverityError, fields["remotes"])
+		assert.Equal(t, validators.SeverityWarning, fields["description"])
+		assert.Equal(t, 3, report.Count(validators.SeverityError))
+	})
+
+	t.Run("valid server only warns about missing fields", func(t *testing.T) {
+		report := validators.CheckServerJSON(context.Background(), apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/test-server",
+			Description: "A test server",
+			Version:     "1.0.0",
+			Remotes: []model.Transport{
+				{Type: "streamable-http", URL: "https://mcp.example.com/mcp"},
+			},
+		}, false)
+
+		assert.True(t, report.Valid())
+		assert.Len(t, report.Issues, 1)
+		assert.Equal(t, "repository", report.Issues[0].Field)
+	})
+}
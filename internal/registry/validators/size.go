@@ -0,0 +1,31 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ValidateDocumentSize fails with ErrDocumentTooLarge when the JSON encoding of a server.json, agent or skill document
+// is larger than maxBytes. A limit of 0 or less is unlimited.
+func ValidateDocumentSize(kind string, doc any, maxBytes int) error {
+	if maxBytes <= 0 {
+		return nil
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", kind, err)
+	}
+	if len(data) > maxBytes {
+		return fmt.Errorf("%w: the %s is %d bytes, the limit is %d bytes", ErrDocumentTooLarge, kind, len(data), maxBytes)
+	}
+	return nil
+}
+
+// ValidateReadmeSize fails with ErrReadmeTooLarge when a README is larger than maxBytes. A limit of 0 or less is
+// unlimited.
+func ValidateReadmeSize(content []byte, maxBytes int) error {
+	if maxBytes > 0 && len(content) > maxBytes {
+		return fmt.Errorf("%w: the README is %d bytes, the limit is %d bytes", ErrReadmeTooLarge, len(content), maxBytes)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package validators_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestValidateDocumentSize(t *testing.T) {
+	skill := &models.SkillJSON{Name: "com.example/skill", Version: "1.0.0", Description: strings.Repeat("x", 100)}
+
+	assert.NoError(t, validators.ValidateDocumentSize("skill", skill, 0))
+	assert.NoError(t, validators.ValidateDocumentSize("skill", skill, 1024))
+
+	err := validators.ValidateDocumentSize("skill", skill, 64)
+	assert.ErrorIs(t, err, validators.ErrDocumentTooLarge)
+	assert.Contains(t, err.Error(), "the limit is 64 bytes")
+}
+
+func TestValidateReadmeSize(t *testing.T) {
+	assert.NoError(t, validators.ValidateReadmeSize([]byte("# README"), 0))
+	assert.NoError(t, validators.ValidateReadmeSize([]byte("# README"), 8))
+	assert.ErrorIs(t, validators.ValidateReadmeSize([]byte("# README!"), 8), validators.ErrReadmeTooLarge)
+}
@@ -0,0 +1,202 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// urlResolveTimeout bounds the DNS lookup of a URL host when URLPolicy.ResolveHosts is set
+const urlResolveTimeout = 3 * time.Second
+
+// localHostSuffixes are name suffixes that only resolve on a local network or inside a cloud provider, such as
+// metadata.google.internal
+var localHostSuffixes = []string{".localhost", ".local", ".internal", ".home.arpa"}
+
+// numericHostRe matches hosts that are not valid IP literals but that some resolvers read as an IPv4 address, e.g.
+// 2130706433 or 0x7f.1
+var numericHostRe = regexp.MustCompile(`^(0x[0-9a-fA-F]+|[0-9]+)(\.(0x[0-9a-fA-F]+|[0-9]+)){0,3}\.?$`)
+
+// cgnatPrefix is the shared address space of carrier-grade NAT, which netip does not count as private
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+// URLPolicy decides which hosts the URLs of a published server, agent or skill may point at. The registry and its
+// deployments fetch some of these URLs, so by default they must not point at loopback, link-local or private
+// addresses.
+type URLPolicy struct {
+	// AllowPrivateHosts accepts localhost and private addresses, for registries used in development
+	AllowPrivateHosts bool
+	// ResolveHosts also looks up host names and rejects those that do not resolve or resolve to a private address
+	ResolveHosts bool
+	// Resolver looks up host names; nil uses net.DefaultResolver
+	Resolver *net.Resolver
+}
+
+// URLPolicyFromConfig returns the policy of ALLOW_PRIVATE_URLS and RESOLVE_URL_HOSTS
+func URLPolicyFromConfig(cfg *config.Config) URLPolicy {
+	if cfg == nil {
+		return URLPolicy{}
+	}
+	return URLPolicy{AllowPrivateHosts: cfg.AllowPrivateURLs, ResolveHosts: cfg.ResolveURLHosts}
+}
+
+// Check validates a published URL: it must be an absolute http or https URL without credentials, and unless
+// AllowPrivateHosts is set its host must not be local or private. Template variables such as {port} are replaced by
+// placeholders first, like IsValidURL does.
+func (p URLPolicy) Check(ctx context.Context, rawURL string) error {
+	if strings.IndexFunc(rawURL, func(r rune) bool { return r <= ' ' || r == 0x7f }) >= 0 {
+		return fmt.Errorf("URL must not contain whitespace or control characters: %q", rawURL)
+	}
+	u, err := url.Parse(replaceTemplateVariables(rawURL))
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != SchemeHTTPS {
+		return fmt.Errorf("URL scheme must be http or https: %s", rawURL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("URL must not contain credentials: %s", u.Redacted())
+	}
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("URL must have a host: %s", rawURL)
+	}
+	if p.AllowPrivateHosts {
+		return nil
+	}
+	if err := checkPublicHost(host); err != nil {
+		return err
+	}
+	if !p.ResolveHosts {
+		return nil
+	}
+	return p.resolvePublicHost(ctx, host)
+}
+
+// checkPublicHost rejects local host names and IP literals that are not public unicast addresses
+func checkPublicHost(host string) error {
+	name := strings.TrimSuffix(strings.ToLower(host), ".")
+	if name == "localhost" {
+		return fmt.Errorf("%w: %s is local", ErrDisallowedURLHost, host)
+	}
+	for _, suffix := range localHostSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return fmt.Errorf("%w: %s is local", ErrDisallowedURLHost, host)
+		}
+	}
+	addr, err := netip.ParseAddr(name)
+	if err != nil {
+		if numericHostRe.MatchString(name) {
+			return fmt.Errorf("%w: %s is an ambiguous numeric address", ErrDisallowedURLHost, host)
+		}
+		return nil
+	}
+	return checkPublicAddr(host, addr)
+}
+
+// checkPublicAddr rejects loopback, link-local, private, shared, unspecified and multicast addresses
+func checkPublicAddr(host string, addr netip.Addr) error {
+	addr = addr.Unmap()
+	switch {
+	case addr.IsLoopback():
+		return fmt.Errorf("%w: %s is a loopback address", ErrDisallowedURLHost, host)
+	case addr.IsLinkLocalUnicast(), addr.IsLinkLocalMulticast():
+		return fmt.Errorf("%w: %s is a link-local address", ErrDisallowedURLHost, host)
+	case addr.IsPrivate(), cgnatPrefix.Contains(addr):
+		return fmt.Errorf("%w: %s is a private address", ErrDisallowedURLHost, host)
+	case addr.IsUnspecified(), addr.IsMulticast(), addr.IsInterfaceLocalMulticast():
+		return fmt.Errorf("%w: %s is not a unicast address", ErrDisallowedURLHost, host)
+	}
+	return nil
+}
+
+// resolvePublicHost looks up a host name and rejects it unless every address it resolves to is public
+func (p URLPolicy) resolvePublicHost(ctx context.Context, host string) error {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return nil
+	}
+	resolver := p.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	ctx, cancel := context.WithTimeout(ctx, urlResolveTimeout)
+	defer cancel()
+	addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return fmt.Errorf("%w: %s does not resolve", ErrDisallowedURLHost, host)
+		}
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if err := checkPublicAddr(host, addr); err != nil {
+			return fmt.Errorf("%w (resolves to %s)", err, addr)
+		}
+	}
+	return nil
+}
+
+// ValidateServerURLs checks the websiteUrl and remote URLs of a server.json against a URL policy
+func ValidateServerURLs(ctx context.Context, req apiv0.ServerJSON, policy URLPolicy) error {
+	if req.WebsiteURL != "" {
+		if err := policy.Check(ctx, req.WebsiteURL); err != nil {
+			return fmt.Errorf("websiteUrl: %w", err)
+		}
+	}
+	for i, remote := range req.Remotes {
+		if err := policy.Check(ctx, remote.URL); err != nil {
+			return fmt.Errorf("remotes[%d].url: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ValidateAgentURLs checks the websiteUrl, repository, remote and remote MCP server URLs of an agent against a URL
+// policy, reporting every rejected field
+func ValidateAgentURLs(ctx context.Context, agent *models.AgentJSON, policy URLPolicy) error {
+	var errs fieldErrors
+	checkOptionalURL(ctx, &errs, policy, "websiteUrl", agent.WebsiteURL)
+	if agent.Repository != nil {
+		checkOptionalURL(ctx, &errs, policy, "repository.url", agent.Repository.URL)
+	}
+	for i, remote := range agent.Remotes {
+		checkOptionalURL(ctx, &errs, policy, fmt.Sprintf("remotes[%d].url", i), remote.URL)
+	}
+	for i, server := range agent.McpServers {
+		if server.Type == "remote" {
+			checkOptionalURL(ctx, &errs, policy, fmt.Sprintf("mcpServers[%d].url", i), server.URL)
+		}
+	}
+	return errs.err("agent")
+}
+
+// ValidateSkillURLs checks the websiteUrl, repository and remote URLs of a skill against a URL policy, reporting every
+// rejected field
+func ValidateSkillURLs(ctx context.Context, skill *models.SkillJSON, policy URLPolicy) error {
+	var errs fieldErrors
+	checkOptionalURL(ctx, &errs, policy, "websiteUrl", skill.WebsiteURL)
+	if skill.Repository != nil {
+		checkOptionalURL(ctx, &errs, policy, "repository.url", skill.Repository.URL)
+	}
+	for i, remote := range skill.Remotes {
+		checkOptionalURL(ctx, &errs, policy, fmt.Sprintf("remotes[%d].url", i), remote.URL)
+	}
+	return errs.err("skill")
+}
+
+func checkOptionalURL(ctx context.Context, errs *fieldErrors, policy URLPolicy, field, value string) {
+	if value != "" {
+		errs.check(field, value, policy.Check(ctx, value))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -54,13 +55,8 @@ var (
 
 func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
 	// Validate schema version is provided and supported
-	// Note: Schema field is also marked as required in the ServerJSON struct definition
-	// for API-level validation and documentation
-	if serverJSON.Schema == "" {
-		return fmt.Errorf("$schema field is required")
-	}
-	if !strings.Contains(serverJSON.Schema, model.CurrentSchemaVersion) {
-		return fmt.Errorf("schema version %s is not supported. Please use schema version %s", serverJSON.Schema, model.CurrentSchemaVersion)
+	if err := validateSchema(serverJSON.Schema); err != nil {
+		return err
 	}
 
 	// Validate server name exists and format
@@ -118,6 +114,39 @@ func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
 		return err
 	}
 
+	// Validate visibility if provided
+	if err := validateServerVisibility(serverJSON.Meta); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateServerVisibility checks the visibility stored in a server's publisher-provided _meta
+func validateServerVisibility(meta *apiv0.ServerMeta) error {
+	if meta == nil || meta.PublisherProvided == nil {
+		return nil
+	}
+	value, ok := meta.PublisherProvided[models.VisibilityMetadataKey]
+	if !ok {
+		return nil
+	}
+	if visibility, isString := value.(string); !isString || !models.ValidVisibility(visibility) {
+		return fmt.Errorf("%s must be one of %s", models.VisibilityMetadataKey, strings.Join(models.Visibilities, ", "))
+	}
+	return nil
+}
+
+// validateSchema checks that the $schema of a server.json is provided and supported.
+// Note: Schema field is also marked as required in the ServerJSON struct definition
+// for API-level validation and documentation
+func validateSchema(schema string) error {
+	if schema == "" {
+		return fmt.Errorf("$schema field is required")
+	}
+	if !strings.Contains(schema, model.CurrentSchemaVersion) {
+		return fmt.Errorf("schema version %s is not supported. Please use schema version %s", schema, model.CurrentSchemaVersion)
+	}
 	return nil
 }
 
@@ -426,6 +455,10 @@ func validateRemoteTransport(obj *model.Transport) error {
 
 // ValidatePublishRequest validates a complete publish request including extensions
 func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) error {
+	if err := ValidateDocumentSize("server.json", req, cfg.DocumentMaxBytes); err != nil {
+		return err
+	}
+
 	// Validate publisher extensions in _meta
 	if err := validatePublisherExtensions(req); err != nil {
 		return err
@@ -435,6 +468,9 @@ func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *conf
 	if err := ValidateServerJSON(&req); err != nil {
 		return err
 	}
+	if err := ValidateServerURLs(ctx, req, URLPolicyFromConfig(cfg)); err != nil {
+		return err
+	}
 
 	// Validate registry ownership for all packages if validation is enabled
 	if cfg.EnableRegistryValidation {
@@ -0,0 +1,179 @@
+// Package vulnscan scans the OCI images referenced by MCP servers for known vulnerabilities
+// using an external scanner (Trivy or Grype) installed on the registry host.
+package vulnscan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Supported scanners
+const (
+	ScannerTrivy = "trivy"
+	ScannerGrype = "grype"
+)
+
+// Scanner scans a single OCI image reference
+type Scanner interface {
+	Name() string
+	ScanImage(ctx context.Context, image string) (*models.VulnerabilityCounts, error)
+}
+
+// New returns the scanner with the given name. An empty name or "none" disables scanning and returns nil.
+func New(name string) (Scanner, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return nil, nil
+	case ScannerTrivy:
+		return &execScanner{name: ScannerTrivy, args: trivyArgs, parse: parseTrivyReport}, nil
+	case ScannerGrype:
+		return &execScanner{name: ScannerGrype, args: grypeArgs, parse: parseGrypeReport}, nil
+	default:
+		return nil, fmt.Errorf("unsupported image scanner %q (expected trivy or grype)", name)
+	}
+}
+
+// ScanServer scans every OCI package of a server and aggregates the results.
+// It returns nil when the server references no OCI images. Images that fail to scan are
+// recorded with their error rather than failing the whole scan.
+func ScanServer(ctx context.Context, scanner Scanner, server *apiv0.ServerJSON) *models.VulnerabilitySummary {
+	images := ImageRefs(server)
+	if scanner == nil || len(images) == 0 {
+		return nil
+	}
+
+	summary := &models.VulnerabilitySummary{Scanner: scanner.Name()}
+	for _, image := range images {
+		result := models.ImageVulnerabilities{Image: image}
+		counts, err := scanner.ScanImage(ctx, image)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.VulnerabilityCounts = *counts
+			summary.Critical += counts.Critical
+			summary.High += counts.High
+			summary.Medium += counts.Medium
+			summary.Low += counts.Low
+			summary.Unknown += counts.Unknown
+		}
+		summary.Images = append(summary.Images, result)
+	}
+	summary.ScannedAt = time.Now().UTC()
+	return summary
+}
+
+// ImageRefs returns the image references of a server's OCI packages, adding the package
+// version as the tag when the identifier has neither a tag nor a digest
+func ImageRefs(server *apiv0.ServerJSON) []string {
+	if server == nil {
+		return nil
+	}
+	var refs []string
+	for _, pkg := range server.Packages {
+		if pkg.RegistryType != "oci" || pkg.Identifier == "" {
+			continue
+		}
+		ref := pkg.Identifier
+		lastSegment := ref[strings.LastIndex(ref, "/")+1:]
+		if !strings.Contains(lastSegment, ":") && !strings.Contains(ref, "@") && pkg.Version != "" {
+			ref += ":" + pkg.Version
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// execScanner runs a scanner CLI and parses its JSON report
+type execScanner struct {
+	name  string
+	args  func(image string) []string
+	parse func([]byte) (*models.VulnerabilityCounts, error)
+}
+
+func (e *execScanner) Name() string { return e.name }
+
+func (e *execScanner) ScanImage(ctx context.Context, image string) (*models.VulnerabilityCounts, error) {
+	cmd := exec.CommandContext(ctx, e.name, e.args(image)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > 512 {
+			msg = msg[len(msg)-512:]
+		}
+		return nil, fmt.Errorf("%s scan of %s failed: %w: %s", e.name, image, err, msg)
+	}
+	return e.parse(stdout.Bytes())
+}
+
+func trivyArgs(image string) []string {
+	return []string{"image", "--quiet", "--format", "json", "--scanners", "vuln", image}
+}
+
+func grypeArgs(image string) []string {
+	return []string{image, "--quiet", "--output", "json"}
+}
+
+// parseTrivyReport counts the findings of a `trivy image --format json` report
+func parseTrivyReport(data []byte) (*models.VulnerabilityCounts, error) {
+	var report struct {
+		Results []struct {
+			Vulnerabilities []struct {
+				Severity string `json:"Severity"`
+			} `json:"Vulnerabilities"`
+		} `json:"Results"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse trivy report: %w", err)
+	}
+	counts := &models.VulnerabilityCounts{}
+	for _, result := range report.Results {
+		for _, v := range result.Vulnerabilities {
+			countSeverity(counts, v.Severity)
+		}
+	}
+	return counts, nil
+}
+
+// parseGrypeReport counts the findings of a `grype --output json` report
+func parseGrypeReport(data []byte) (*models.VulnerabilityCounts, error) {
+	var report struct {
+		Matches []struct {
+			Vulnerability struct {
+				Severity string `json:"severity"`
+			} `json:"vulnerability"`
+		} `json:"matches"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse grype report: %w", err)
+	}
+	counts := &models.VulnerabilityCounts{}
+	for _, m := range report.Matches {
+		countSeverity(counts, m.Vulnerability.Severity)
+	}
+	return counts, nil
+}
+
+func countSeverity(counts *models.VulnerabilityCounts, severity string) {
+	switch strings.ToLower(severity) {
+	case "critical":
+		counts.Critical++
+	case "high":
+		counts.High++
+	case "medium":
+		counts.Medium++
+	case "low", "negligible":
+		counts.Low++
+	default:
+		counts.Unknown++
+	}
+}
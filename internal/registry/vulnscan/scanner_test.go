@@ -0,0 +1,115 @@
+package vulnscan
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	s, err := New("")
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	s, err = New("Trivy")
+	require.NoError(t, err)
+	assert.Equal(t, ScannerTrivy, s.Name())
+
+	s, err = New("grype")
+	require.NoError(t, err)
+	assert.Equal(t, ScannerGrype, s.Name())
+
+	_, err = New("clair")
+	assert.Error(t, err)
+}
+
+func TestParseTrivyReport(t *testing.T) {
+	report := `{"Results":[
+		{"Target":"alpine","Vulnerabilities":[{"VulnerabilityID":"CVE-1","Severity":"CRITICAL"},{"VulnerabilityID":"CVE-2","Severity":"HIGH"}]},
+		{"Target":"node-pkg","Vulnerabilities":[{"VulnerabilityID":"CVE-3","Severity":"HIGH"},{"VulnerabilityID":"CVE-4","Severity":"LOW"},{"VulnerabilityID":"CVE-5","Severity":"UNKNOWN"}]},
+		{"Target":"empty"}
+	]}`
+	counts, err := parseTrivyReport([]byte(report))
+	require.NoError(t, err)
+	assert.Equal(t, models.VulnerabilityCounts{Critical: 1, High: 2, Low: 1, Unknown: 1}, *counts)
+
+	_, err = parseTrivyReport([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestParseGrypeReport(t *testing.T) {
+	report := `{"matches":[
+		{"vulnerability":{"id":"CVE-1","severity":"Critical"}},
+		{"vulnerability":{"id":"CVE-2","severity":"Medium"}},
+		{"vulnerability":{"id":"CVE-3","severity":"Negligible"}}
+	]}`
+	counts, err := parseGrypeReport([]byte(report))
+	require.NoError(t, err)
+	assert.Equal(t, models.VulnerabilityCounts{Critical: 1, Medium: 1, Low: 1}, *counts)
+}
+
+func TestImageRefs(t *testing.T) {
+	server := &apiv0.ServerJSON{
+		Packages: []model.Package{
+			{RegistryType: "oci", Identifier: "docker.io/acme/weather:1.2.0"},
+			{RegistryType: "oci", Identifier: "ghcr.io/acme/tools", Version: "2.0.0"},
+			{RegistryType: "oci", Identifier: "localhost:5000/acme/db@sha256:abc", Version: "3.0.0"},
+			{RegistryType: "npm", Identifier: "@acme/server", Version: "1.0.0"},
+		},
+	}
+	assert.Equal(t, []string{
+		"docker.io/acme/weather:1.2.0",
+		"ghcr.io/acme/tools:2.0.0",
+		"localhost:5000/acme/db@sha256:abc",
+	}, ImageRefs(server))
+}
+
+type fakeScanner struct {
+	results map[string]*models.VulnerabilityCounts
+}
+
+func (f *fakeScanner) Name() string { return "fake" }
+
+func (f *fakeScanner) ScanImage(_ context.Context, image string) (*models.VulnerabilityCounts, error) {
+	if counts, ok := f.results[image]; ok {
+		return counts, nil
+	}
+	return nil, errors.New("image not found")
+}
+
+func TestScanServer(t *testing.T) {
+	scanner := &fakeScanner{results: map[string]*models.VulnerabilityCounts{
+		"acme/a:1": {Critical: 1, High: 2},
+		"acme/b:1": {High: 1, Medium: 4},
+	}}
+	server := &apiv0.ServerJSON{
+		Packages: []model.Package{
+			{RegistryType: "oci", Identifier: "acme/a:1"},
+			{RegistryType: "oci", Identifier: "acme/b:1"},
+			{RegistryType: "oci", Identifier: "acme/missing:1"},
+		},
+	}
+
+	summary := ScanServer(context.Background(), scanner, server)
+	require.NotNil(t, summary)
+	assert.Equal(t, "fake", summary.Scanner)
+	assert.Equal(t, models.VulnerabilityCounts{Critical: 1, High: 3, Medium: 4}, summary.VulnerabilityCounts)
+	require.Len(t, summary.Images, 3)
+	assert.Equal(t, "image not found", summary.Images[2].Error)
+	assert.False(t, summary.ScannedAt.IsZero())
+
+	// Round-trips through the server's _meta
+	models.SetVulnerabilitySummary(server, summary)
+	stored := models.VulnerabilitySummaryFromMeta(server.Meta)
+	require.NotNil(t, stored)
+	assert.Equal(t, summary.VulnerabilityCounts, stored.VulnerabilityCounts)
+
+	assert.Nil(t, ScanServer(context.Background(), scanner, &apiv0.ServerJSON{}))
+	assert.Nil(t, ScanServer(context.Background(), nil, server))
+}
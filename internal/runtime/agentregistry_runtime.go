@@ -1,22 +1,28 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
 	"fmt"
 	"maps"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sync"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"go.opentelemetry.io/otel/attribute"
 	"go.yaml.in/yaml/v3"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -41,6 +47,9 @@ var (
 	k8sClient    client.Client
 	k8sClientErr error
 	clientOnce   sync.Once
+
+	contextClientsMu sync.Mutex
+	contextClients   = map[string]client.Client{}
 )
 
 // controller-runtime client singleton
@@ -66,6 +75,29 @@ func GetKubeClient() (client.Client, error) {
 	return k8sClient, nil
 }
 
+// KubeClientForContext returns a client for a kubeconfig context. The empty context is the current one.
+func KubeClientForContext(kubeContext string) (client.Client, error) {
+	if kubeContext == "" {
+		return GetKubeClient()
+	}
+
+	contextClientsMu.Lock()
+	defer contextClientsMu.Unlock()
+	if c, ok := contextClients[kubeContext]; ok {
+		return c, nil
+	}
+	restConfig, err := config.GetConfigWithContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubernetes config for context %s: %w", kubeContext, err)
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client for context %s: %w", kubeContext, err)
+	}
+	contextClients[kubeContext] = c
+	return c, nil
+}
+
 // applyResource uses server-side apply to create or update a Kubernetes resource.
 func applyResource(ctx context.Context, c client.Client, obj client.Object, verbose bool) error {
 	if verbose {
@@ -101,6 +133,19 @@ type AgentRegistryRuntime interface {
 		servers []*registry.MCPServerRunRequest,
 		agents []*registry.AgentRunRequest,
 	) error
+	// Diff compares the desired state of the given servers and agents with what runs on the runtime
+	Diff(
+		ctx context.Context,
+		servers []*registry.MCPServerRunRequest,
+		agents []*registry.AgentRunRequest,
+	) ([]models.DeploymentDrift, error)
+	// Render returns the files the desired state of the given servers and agents is applied from, keyed by path
+	// relative to the runtime directory, without applying them
+	Render(
+		ctx context.Context,
+		servers []*registry.MCPServerRunRequest,
+		agents []*registry.AgentRunRequest,
+	) (map[string][]byte, error)
 }
 
 type agentRegistryRuntime struct {
@@ -108,6 +153,39 @@ type agentRegistryRuntime struct {
 	runtimeTranslator  api.RuntimeTranslator
 	runtimeDir         string
 	verbose            bool
+	// dockerHost is the DOCKER_HOST the local runtime's compose project is started on; empty is the local daemon
+	dockerHost string
+	// kubeContext is the kubeconfig context the kubernetes runtime applies resources to; empty is the current one
+	kubeContext string
+	// helmChart names the chart Render packages kubernetes resources as; empty renders raw manifests
+	helmChart        string
+	helmChartVersion string
+}
+
+// Option configures an AgentRegistryRuntime
+type Option func(*agentRegistryRuntime)
+
+// WithDockerHost starts the compose project of the local runtime on another docker host, e.g. ssh://user@host.
+// Bind mounts are resolved on that host, so the runtime directory has to be present there at the same path.
+func WithDockerHost(host string) Option {
+	return func(r *agentRegistryRuntime) {
+		r.dockerHost = host
+	}
+}
+
+// WithKubeContext applies the resources of the kubernetes runtime through a kubeconfig context
+func WithKubeContext(kubeContext string) Option {
+	return func(r *agentRegistryRuntime) {
+		r.kubeContext = kubeContext
+	}
+}
+
+// WithHelmChart makes Render package the resources of the kubernetes runtime as a Helm chart instead of raw manifests
+func WithHelmChart(name, version string) Option {
+	return func(r *agentRegistryRuntime) {
+		r.helmChart = name
+		r.helmChartVersion = version
+	}
 }
 
 func NewAgentRegistryRuntime(
@@ -115,33 +193,96 @@ func NewAgentRegistryRuntime(
 	translator api.RuntimeTranslator,
 	runtimeDir string,
 	verbose bool,
+	opts ...Option,
 ) AgentRegistryRuntime {
-	return &agentRegistryRuntime{
+	r := &agentRegistryRuntime{
 		registryTranslator: registryTranslator,
 		runtimeTranslator:  translator,
 		runtimeDir:         runtimeDir,
 		verbose:            verbose,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *agentRegistryRuntime) ReconcileAll(
 	ctx context.Context,
 	serverRequests []*registry.MCPServerRunRequest,
 	agentRequests []*registry.AgentRunRequest,
-) error {
+) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Runtime.ReconcileAll",
+		attribute.Int("runtime.mcp_servers", len(serverRequests)),
+		attribute.Int("runtime.agents", len(agentRequests)),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	desiredState, err := r.desiredState(ctx, serverRequests, agentRequests)
+	if err != nil {
+		return err
+	}
+
+	for _, agent := range desiredState.Agents {
+		if err := common.RefreshMCPConfig(
+			&common.MCPConfigTarget{
+				BaseDir:   r.runtimeDir,
+				AgentName: agent.Name,
+				Version:   agent.Version,
+			},
+			pythonMCPServers(agent),
+			r.verbose,
+		); err != nil {
+			return fmt.Errorf("failed to refresh resolved MCP server config for agent %s: %w", agent.Name, err)
+		}
+	}
+
+	runtimeCfg, err := r.runtimeTranslator.TranslateRuntimeConfig(ctx, desiredState)
+	if err != nil {
+		return fmt.Errorf("translate runtime config: %w", err)
+	}
+
+	if r.verbose {
+		fmt.Printf("desired state: agents=%d MCP servers=%d\n", len(desiredState.Agents), len(desiredState.MCPServers))
+	}
+
+	return r.ensureRuntime(ctx, runtimeCfg)
+}
+
+// pythonMCPServers converts the resolved MCP servers of an agent back to PythonMCPServer for local runtime
+// backward compatibility
+func pythonMCPServers(agent *api.Agent) []common.PythonMCPServer {
+	var pythonServers []common.PythonMCPServer
+	for _, cfg := range agent.ResolvedMCPServers {
+		pythonServers = append(pythonServers, common.PythonMCPServer{
+			Name:    cfg.Name,
+			Type:    cfg.Type,
+			URL:     cfg.URL,
+			Headers: cfg.Headers,
+		})
+	}
+	return pythonServers
+}
+
+// desiredState translates the run requests into the runtime-neutral state of the runtime
+func (r *agentRegistryRuntime) desiredState(
+	ctx context.Context,
+	serverRequests []*registry.MCPServerRunRequest,
+	agentRequests []*registry.AgentRunRequest,
+) (*api.DesiredState, error) {
 	desiredState := &api.DesiredState{}
 	for _, req := range serverRequests {
-		mcpServer, err := r.registryTranslator.TranslateMCPServer(context.TODO(), req)
+		mcpServer, err := r.registryTranslator.TranslateMCPServer(ctx, req)
 		if err != nil {
-			return fmt.Errorf("translate mcp server %s: %w", req.RegistryServer.Name, err)
+			return nil, fmt.Errorf("translate mcp server %s: %w", req.RegistryServer.Name, err)
 		}
 		desiredState.MCPServers = append(desiredState.MCPServers, mcpServer)
 	}
 
 	for _, req := range agentRequests {
-		agent, err := r.registryTranslator.TranslateAgent(context.TODO(), req)
+		agent, err := r.registryTranslator.TranslateAgent(ctx, req)
 		if err != nil {
-			return fmt.Errorf("translate agent %s: %w", req.RegistryAgent.Name, err)
+			return nil, fmt.Errorf("translate agent %s: %w", req.RegistryAgent.Name, err)
 		}
 
 		// Extract namespace from agent's env (if set) to propagate to MCP servers
@@ -152,9 +293,9 @@ func (r *agentRegistryRuntime) ReconcileAll(
 
 		// Translate and add resolved MCP servers from agent manifest to desired state
 		for _, serverReq := range req.ResolvedMCPServers {
-			mcpServer, err := r.registryTranslator.TranslateMCPServer(context.TODO(), serverReq)
+			mcpServer, err := r.registryTranslator.TranslateMCPServer(ctx, serverReq)
 			if err != nil {
-				return fmt.Errorf("translate resolved MCP server %s for agent %s: %w", serverReq.RegistryServer.Name, req.RegistryAgent.Name, err)
+				return nil, fmt.Errorf("translate resolved MCP server %s for agent %s: %w", serverReq.RegistryServer.Name, req.RegistryAgent.Name, err)
 			}
 			// Propagate namespace from agent to MCP server for co-location
 			if agentNamespace != "" {
@@ -164,51 +305,20 @@ func (r *agentRegistryRuntime) ReconcileAll(
 		}
 
 		// Populate ResolvedMCPServers on the agent for ConfigMap generation
-		resolvedConfigs := createResolvedMCPServerConfigs(req.ResolvedMCPServers)
-		agent.ResolvedMCPServers = resolvedConfigs
+		agent.ResolvedMCPServers = createResolvedMCPServerConfigs(req.ResolvedMCPServers)
 
 		desiredState.Agents = append(desiredState.Agents, agent)
-
-		// Convert back to PythonMCPServer for local runtime backward compatibility
-		var pythonServers []common.PythonMCPServer
-		for _, cfg := range resolvedConfigs {
-			pythonServers = append(pythonServers, common.PythonMCPServer{
-				Name:    cfg.Name,
-				Type:    cfg.Type,
-				URL:     cfg.URL,
-				Headers: cfg.Headers,
-			})
-		}
-
-		if err := common.RefreshMCPConfig(
-			&common.MCPConfigTarget{
-				BaseDir:   r.runtimeDir,
-				AgentName: req.RegistryAgent.Name,
-				Version:   req.RegistryAgent.Version,
-			},
-			pythonServers,
-			r.verbose,
-		); err != nil {
-			return fmt.Errorf("failed to refresh resolved MCP server config for agent %s: %w", req.RegistryAgent.Name, err)
-		}
-	}
-
-	runtimeCfg, err := r.runtimeTranslator.TranslateRuntimeConfig(ctx, desiredState)
-	if err != nil {
-		return fmt.Errorf("translate runtime config: %w", err)
-	}
-
-	if r.verbose {
-		fmt.Printf("desired state: agents=%d MCP servers=%d\n", len(desiredState.Agents), len(desiredState.MCPServers))
 	}
-
-	return r.ensureRuntime(ctx, runtimeCfg)
+	return desiredState, nil
 }
 
 func (r *agentRegistryRuntime) ensureRuntime(
 	ctx context.Context,
 	cfg *api.AIRuntimeConfig,
-) error {
+) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Runtime.Apply", attribute.String("runtime.type", string(cfg.Type)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	switch cfg.Type {
 	case api.RuntimeConfigTypeLocal:
 		return r.ensureLocalRuntime(ctx, cfg.Local)
@@ -238,21 +348,30 @@ func (r *agentRegistryRuntime) ensureLocalRuntime(
 	if err := os.WriteFile(filepath.Join(r.runtimeDir, "docker-compose.yaml"), dockerComposeYaml, 0644); err != nil {
 		return fmt.Errorf("failed to write docker compose yaml: %w", err)
 	}
-	// step 3: write the agentconfig yaml to the dir
+	// step 3: write the agentconfig yaml to the dir. The gateway watches this file and reloads its
+	// routes in place, so a route change does not need to restart the gateway container.
 	agentGatewayYaml, err := yaml.Marshal(cfg.AgentGateway)
 	if err != nil {
 		return fmt.Errorf("failed to marshal agent config yaml: %w", err)
 	}
-	if err := os.WriteFile(filepath.Join(r.runtimeDir, "agent-gateway.yaml"), agentGatewayYaml, 0644); err != nil {
+	gatewayChanged, err := writeFileIfChanged(filepath.Join(r.runtimeDir, "agent-gateway.yaml"), agentGatewayYaml, 0644)
+	if err != nil {
 		return fmt.Errorf("failed to write agent config yaml: %w", err)
 	}
 	if r.verbose {
 		fmt.Printf("Agent Gateway YAML:\n%s\n", string(agentGatewayYaml))
+		if gatewayChanged {
+			fmt.Println("Agent gateway config changed, gateway will reload its routes")
+		}
 	}
-	// step 4: start docker compose with -d --remove-orphans --force-recreate
-	// Using --force-recreate ensures all containers are recreated even if config hasn't changed
-	cmd := exec.CommandContext(ctx, "docker", "compose", "up", "-d", "--remove-orphans", "--force-recreate")
+	// step 4: start docker compose with -d --remove-orphans
+	// Compose only recreates services whose definition changed, so containers for unrelated MCP servers
+	// and the gateway keep running and in-flight sessions are not dropped.
+	cmd := utils.ComposeCmd(ctx, "up", "-d", "--remove-orphans")
 	cmd.Dir = r.runtimeDir
+	if r.dockerHost != "" {
+		cmd.Env = append(os.Environ(), utils.ContainerHostEnv(r.dockerHost)...)
+	}
 	if r.verbose {
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -263,9 +382,44 @@ func (r *agentRegistryRuntime) ensureLocalRuntime(
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to start docker compose: %w", err)
 	}
+	// step 5: record the images of the project, so Prune can remove them once no deployment uses them
+	var images []string
+	for _, svc := range cfg.DockerCompose.Services {
+		images = append(images, svc.Image)
+	}
+	if err := recordImages(r.runtimeDir, images); err != nil && r.verbose {
+		fmt.Printf("Warning: %v\n", err)
+	}
 	return nil
 }
 
+// writeFileIfChanged atomically replaces path with data, unless it already holds data.
+// Writing through a rename means file watchers never observe a partially written file.
+func writeFileIfChanged(path string, data []byte, perm os.FileMode) (bool, error) {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return false, nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *agentRegistryRuntime) ensureKubernetesRuntime(
 	ctx context.Context,
 	cfg *api.KubernetesRuntimeConfig,
@@ -274,7 +428,7 @@ func (r *agentRegistryRuntime) ensureKubernetesRuntime(
 		return nil
 	}
 
-	c, err := GetKubeClient()
+	c, err := KubeClientForContext(r.kubeContext)
 	if err != nil {
 		return err
 	}
@@ -316,6 +470,30 @@ func (r *agentRegistryRuntime) ensureKubernetesRuntime(
 		}
 	}
 
+	restricted := map[string]bool{}
+	for _, policy := range cfg.NetworkPolicies {
+		if policy.Namespace == "" {
+			policy.Namespace = kagent.DefaultNamespace
+		}
+		if err := applyResource(ctx, c, policy, r.verbose); err != nil {
+			return fmt.Errorf("NetworkPolicy %s: %w", policy.Name, err)
+		}
+		restricted[policy.Namespace+"/"+policy.Name] = true
+	}
+	// A server redeployed without --restrict-egress drops the policy of its previous deployment. Clusters that do
+	// not let the registry manage NetworkPolicies only fail deployments that ask for one.
+	for _, mcpServer := range cfg.MCPServers {
+		policy := &networkingv1.NetworkPolicy{}
+		policy.Name = kagent.EgressPolicyName(mcpServer.Name)
+		policy.Namespace = mcpServer.Namespace
+		if restricted[policy.Namespace+"/"+policy.Name] {
+			continue
+		}
+		if err := deleteResource(ctx, c, policy); err != nil && !apierrors.IsForbidden(err) {
+			return fmt.Errorf("NetworkPolicy %s: %w", policy.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -391,13 +569,13 @@ func ListRemoteMCPServers(ctx context.Context, namespace string) ([]*v1alpha2.Re
 	return servers, nil
 }
 
-// DeleteKubernetesAgent deletes a kagent Agent CR by name/version.
-func DeleteKubernetesAgent(ctx context.Context, name, version, namespace string) error {
+// DeleteKubernetesAgent deletes a kagent Agent CR by name/version through a kubeconfig context (empty for the current one).
+func DeleteKubernetesAgent(ctx context.Context, kubeContext, name, version, namespace string) error {
 	if namespace == "" {
 		namespace = kagent.DefaultNamespace
 	}
 
-	c, err := GetKubeClient()
+	c, err := KubeClientForContext(kubeContext)
 	if err != nil {
 		return err
 	}
@@ -412,13 +590,13 @@ func DeleteKubernetesAgent(ctx context.Context, name, version, namespace string)
 	return nil
 }
 
-// DeleteKubernetesRemoteMCPServer deletes a kagent RemoteMCPServer CR by name.
-func DeleteKubernetesRemoteMCPServer(ctx context.Context, name, namespace string) error {
+// DeleteKubernetesRemoteMCPServer deletes a kagent RemoteMCPServer CR by name through a kubeconfig context.
+func DeleteKubernetesRemoteMCPServer(ctx context.Context, kubeContext, name, namespace string) error {
 	if namespace == "" {
 		namespace = kagent.DefaultNamespace
 	}
 
-	c, err := GetKubeClient()
+	c, err := KubeClientForContext(kubeContext)
 	if err != nil {
 		return err
 	}
@@ -433,13 +611,13 @@ func DeleteKubernetesRemoteMCPServer(ctx context.Context, name, namespace string
 	return nil
 }
 
-// DeleteKubernetesMCPServer deletes a kagent MCPServer CR by name.
-func DeleteKubernetesMCPServer(ctx context.Context, name, namespace string) error {
+// DeleteKubernetesMCPServer deletes a kagent MCPServer CR by name through a kubeconfig context.
+func DeleteKubernetesMCPServer(ctx context.Context, kubeContext, name, namespace string) error {
 	if namespace == "" {
 		namespace = kagent.DefaultNamespace
 	}
 
-	c, err := GetKubeClient()
+	c, err := KubeClientForContext(kubeContext)
 	if err != nil {
 		return err
 	}
@@ -451,6 +629,13 @@ func DeleteKubernetesMCPServer(ctx context.Context, name, namespace string) erro
 	if err := deleteResource(ctx, c, mcpServer); err != nil {
 		return fmt.Errorf("failed to delete MCP server %s: %w", mcpServer.Name, err)
 	}
+
+	policy := &networkingv1.NetworkPolicy{}
+	policy.Name = kagent.EgressPolicyName(mcpServer.Name)
+	policy.Namespace = namespace
+	if err := deleteResource(ctx, c, policy); err != nil && !apierrors.IsForbidden(err) {
+		return fmt.Errorf("failed to delete egress policy %s: %w", policy.Name, err)
+	}
 	return nil
 }
 
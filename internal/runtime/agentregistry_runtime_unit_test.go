@@ -3,6 +3,8 @@ package runtime
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
@@ -140,3 +142,91 @@ func parseServerReqUnit(
 	}
 	return &registry.MCPServerRunRequest{RegistryServer: &server}
 }
+
+func Test_WriteFileIfChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent-gateway.yaml")
+
+	changed, err := writeFileIfChanged(path, []byte("routes: []\n"), 0644)
+	if err != nil {
+		t.Fatalf("writeFileIfChanged failed: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected first write to report a change")
+	}
+
+	changed, err = writeFileIfChanged(path, []byte("routes: []\n"), 0644)
+	if err != nil {
+		t.Fatalf("writeFileIfChanged failed: %v", err)
+	}
+	if changed {
+		t.Fatal("expected identical content to be left untouched")
+	}
+
+	changed, err = writeFileIfChanged(path, []byte("routes: [a]\n"), 0644)
+	if err != nil {
+		t.Fatalf("writeFileIfChanged failed: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected new content to report a change")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if string(data) != "routes: [a]\n" {
+		t.Fatalf("unexpected file content: %q", data)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected temp files to be cleaned up, found %d entries", len(entries))
+	}
+}
+
+func Test_ParseComposePS(t *testing.T) {
+	lines := `{"Service":"agent_gateway","State":"running","Health":"healthy","ExitCode":0}
+{"Service":"io-github-user-weather","State":"exited","Health":"","ExitCode":1}
+`
+	array := `[{"Service":"agent_gateway","State":"running","Health":"healthy","ExitCode":0},` +
+		`{"Service":"io-github-user-weather","State":"exited","Health":"","ExitCode":1}]`
+
+	for name, out := range map[string]string{"lines": lines, "array": array} {
+		statuses, err := parseComposePS([]byte(out))
+		if err != nil {
+			t.Fatalf("%s: parseComposePS failed: %v", name, err)
+		}
+		if len(statuses) != 2 {
+			t.Fatalf("%s: expected 2 services, got %d", name, len(statuses))
+		}
+		if statuses[GatewayServiceName].Failed() {
+			t.Errorf("%s: expected the gateway to be healthy", name)
+		}
+		if !statuses["io-github-user-weather"].Failed() {
+			t.Errorf("%s: expected the exited server to be failed", name)
+		}
+	}
+
+	replicas := `{"Service":"agent","State":"running","Health":"healthy","ExitCode":0}
+{"Service":"agent","State":"exited","Health":"","ExitCode":137}
+{"Service":"agent","State":"running","Health":"healthy","ExitCode":0}
+`
+	statuses, err := parseComposePS([]byte(replicas))
+	if err != nil {
+		t.Fatalf("replicas: parseComposePS failed: %v", err)
+	}
+	agent := statuses["agent"]
+	if agent.Replicas != 3 || agent.Running != 2 {
+		t.Errorf("expected 2 of 3 replicas running, got %d of %d", agent.Running, agent.Replicas)
+	}
+	if !agent.Failed() {
+		t.Error("expected a service with an exited replica to be failed")
+	}
+
+	statuses, err = parseComposePS(nil)
+	if err != nil || len(statuses) != 0 {
+		t.Errorf("expected no services for empty output, got %v, %v", statuses, err)
+	}
+}
@@ -0,0 +1,306 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.yaml.in/yaml/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// redactedValue replaces the values of fields that may hold secrets in drift reports
+const redactedValue = "(redacted)"
+
+// Diff compares the desired state of the given servers and agents with what runs on the runtime. On the local
+// runtime it compares their compose services with the rendered docker-compose.yaml and `docker compose ps`; on
+// kubernetes it compares their objects with the live ones. Other deployments of the runtime are not checked, and
+// values of environment variables and headers are redacted.
+func (r *agentRegistryRuntime) Diff(
+	ctx context.Context,
+	serverRequests []*registry.MCPServerRunRequest,
+	agentRequests []*registry.AgentRunRequest,
+) (_ []models.DeploymentDrift, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Runtime.Diff",
+		attribute.Int("runtime.mcp_servers", len(serverRequests)),
+		attribute.Int("runtime.agents", len(agentRequests)),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	desiredState, err := r.desiredState(ctx, serverRequests, agentRequests)
+	if err != nil {
+		return nil, err
+	}
+	runtimeCfg, err := r.runtimeTranslator.TranslateRuntimeConfig(ctx, desiredState)
+	if err != nil {
+		return nil, fmt.Errorf("translate runtime config: %w", err)
+	}
+
+	switch runtimeCfg.Type {
+	case api.RuntimeConfigTypeLocal:
+		return r.diffLocalRuntime(ctx, runtimeCfg.Local)
+	case api.RuntimeConfigTypeKubernetes:
+		return r.diffKubernetesRuntime(ctx, runtimeCfg.Kubernetes)
+	default:
+		return nil, fmt.Errorf("unsupported runtime config type: %v", runtimeCfg.Type)
+	}
+}
+
+func (r *agentRegistryRuntime) diffLocalRuntime(ctx context.Context, cfg *api.LocalRuntimeConfig) ([]models.DeploymentDrift, error) {
+	rendered, err := cfg.DockerCompose.MarshalYAML()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal docker compose yaml: %w", err)
+	}
+	desired, err := composeServices(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[string]any{}
+	if data, err := os.ReadFile(filepath.Join(r.runtimeDir, "docker-compose.yaml")); err == nil {
+		if applied, err = composeServices(data); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read docker compose yaml: %w", err)
+	}
+
+	statuses, err := composeServiceStatuses(ctx, r.runtimeDir, r.dockerHost)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []models.DeploymentDrift
+	for _, name := range slices.Sorted(maps.Keys(desired)) {
+		// The gateway is shared by all deployments of the runtime
+		if name == GatewayServiceName {
+			continue
+		}
+		resource := "service " + name
+		if appliedService, ok := applied[name]; ok {
+			drift = append(drift, diffFields(resource, "", desired[name], appliedService, false)...)
+		} else {
+			drift = append(drift, models.DeploymentDrift{Resource: resource, Field: "definition", Desired: "rendered", Actual: "not rendered"})
+		}
+		drift = append(drift, diffServiceStatus(resource, desired[name], statuses[name])...)
+	}
+	return drift, nil
+}
+
+// diffServiceStatus compares the containers of a compose service with its definition
+func diffServiceStatus(resource string, service any, status ServiceStatus) []models.DeploymentDrift {
+	definition, _ := service.(map[string]any)
+	if status.Replicas == 0 {
+		return []models.DeploymentDrift{{Resource: resource, Field: "state", Desired: ServiceStateRunning, Actual: ServiceStateMissing}}
+	}
+
+	var drift []models.DeploymentDrift
+	if status.State != ServiceStateRunning {
+		drift = append(drift, models.DeploymentDrift{Resource: resource, Field: "state", Desired: ServiceStateRunning, Actual: status.State})
+	}
+	if image, _ := definition["image"].(string); image != "" && status.Image != "" && image != status.Image {
+		drift = append(drift, models.DeploymentDrift{Resource: resource, Field: "image", Desired: image, Actual: status.Image})
+	}
+	replicas := 1
+	if deploy, ok := definition["deploy"].(map[string]any); ok {
+		if n, ok := deploy["replicas"].(int); ok {
+			replicas = n
+		}
+	}
+	if status.Running != replicas && status.State == ServiceStateRunning {
+		drift = append(drift, models.DeploymentDrift{Resource: resource, Field: "replicas", Desired: strconv.Itoa(replicas), Actual: strconv.Itoa(status.Running)})
+	}
+	return drift
+}
+
+// composeServices returns the services of a compose file, keyed by name
+func composeServices(data []byte) (map[string]any, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse docker compose yaml: %w", err)
+	}
+	services, _ := doc["services"].(map[string]any)
+	if services == nil {
+		services = map[string]any{}
+	}
+	return services, nil
+}
+
+func (r *agentRegistryRuntime) diffKubernetesRuntime(ctx context.Context, cfg *api.KubernetesRuntimeConfig) ([]models.DeploymentDrift, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var objects []client.Object
+	for _, obj := range cfg.ConfigMaps {
+		objects = append(objects, obj)
+	}
+	for _, obj := range cfg.Agents {
+		objects = append(objects, obj)
+	}
+	for _, obj := range cfg.RemoteMCPServers {
+		objects = append(objects, obj)
+	}
+	for _, obj := range cfg.MCPServers {
+		objects = append(objects, obj)
+	}
+	for _, obj := range cfg.NetworkPolicies {
+		objects = append(objects, obj)
+	}
+	if len(objects) == 0 {
+		return nil, nil
+	}
+
+	c, err := KubeClientForContext(r.kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []models.DeploymentDrift
+	for _, obj := range objects {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(kagent.DefaultNamespace)
+		}
+		resource := fmt.Sprintf("%s %s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName())
+
+		// Decode into a fresh object so fields missing from the live one are not filled in from the desired one
+		live := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+			if apierrors.IsNotFound(err) {
+				drift = append(drift, models.DeploymentDrift{Resource: resource, Field: "object", Desired: "present", Actual: ServiceStateMissing})
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s: %w", resource, err)
+		}
+
+		desiredFields, err := objectFields(obj)
+		if err != nil {
+			return nil, err
+		}
+		liveFields, err := objectFields(live)
+		if err != nil {
+			return nil, err
+		}
+		// The API server defaults fields the translator leaves unset, so only the desired fields are compared
+		for _, field := range []string{"spec", "data"} {
+			if value, ok := desiredFields[field]; ok {
+				drift = append(drift, diffFields(resource, field, value, liveFields[field], true)...)
+			}
+		}
+	}
+	return drift, nil
+}
+
+func objectFields(obj client.Object) (map[string]any, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", obj.GetName(), err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", obj.GetName(), err)
+	}
+	return fields, nil
+}
+
+// diffFields compares a desired and an actual value field by field, descending into maps and into lists of equal
+// length. In subset mode fields only the actual value sets are ignored.
+func diffFields(resource, path string, desired, actual any, subset bool) []models.DeploymentDrift {
+	child := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch d := desired.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := maps.Clone(d)
+		if !subset {
+			maps.Copy(keys, a)
+		}
+		var drift []models.DeploymentDrift
+		for _, key := range slices.Sorted(maps.Keys(keys)) {
+			dv, inDesired := d[key]
+			av, inActual := a[key]
+			switch {
+			case !inDesired:
+				drift = append(drift, fieldDrift(resource, child(key), nil, av))
+			case !inActual:
+				drift = append(drift, fieldDrift(resource, child(key), dv, nil))
+			default:
+				drift = append(drift, diffFields(resource, child(key), dv, av, subset)...)
+			}
+		}
+		return drift
+	case []any:
+		a, ok := actual.([]any)
+		if !ok || len(a) != len(d) {
+			break
+		}
+		var drift []models.DeploymentDrift
+		for i := range d {
+			drift = append(drift, diffFields(resource, fmt.Sprintf("%s[%d]", path, i), d[i], a[i], subset)...)
+		}
+		return drift
+	}
+
+	if reflect.DeepEqual(desired, actual) {
+		return nil
+	}
+	return []models.DeploymentDrift{fieldDrift(resource, path, desired, actual)}
+}
+
+func fieldDrift(resource, path string, desired, actual any) models.DeploymentDrift {
+	drift := models.DeploymentDrift{Resource: resource, Field: path, Desired: formatField(desired), Actual: formatField(actual)}
+	if sensitiveField(path) {
+		if drift.Desired != "" {
+			drift.Desired = redactedValue
+		}
+		if drift.Actual != "" {
+			drift.Actual = redactedValue
+		}
+	}
+	return drift
+}
+
+func formatField(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// sensitiveField reports whether a field may hold secret values: environment variables, headers and the data of
+// config maps, which carry the MCP server headers of agents
+func sensitiveField(path string) bool {
+	path = strings.ToLower(path)
+	return strings.Contains(path, "env") || strings.Contains(path, "header") || strings.HasPrefix(path, "data")
+}
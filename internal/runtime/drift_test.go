@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFields_ComposeService(t *testing.T) {
+	desired, err := composeServices([]byte(`
+services:
+  weather:
+    image: ghcr.io/example/weather:1.1.0
+    environment:
+      API_KEY: new-secret
+      LOG_LEVEL: info
+    command: ["serve", "--port", "3000"]
+`))
+	require.NoError(t, err)
+	applied, err := composeServices([]byte(`
+services:
+  weather:
+    image: ghcr.io/example/weather:1.0.0
+    environment:
+      API_KEY: old-secret
+    command: ["serve", "--port", "3000"]
+    labels:
+      edited: "by-hand"
+`))
+	require.NoError(t, err)
+
+	drift := diffFields("service weather", "", desired["weather"], applied["weather"], false)
+	assert.Equal(t, []models.DeploymentDrift{
+		{Resource: "service weather", Field: "environment.API_KEY", Desired: redactedValue, Actual: redactedValue},
+		{Resource: "service weather", Field: "environment.LOG_LEVEL", Desired: redactedValue},
+		{Resource: "service weather", Field: "image", Desired: "ghcr.io/example/weather:1.1.0", Actual: "ghcr.io/example/weather:1.0.0"},
+		{Resource: "service weather", Field: "labels", Actual: `{"edited":"by-hand"}`},
+	}, drift)
+
+	assert.Empty(t, diffFields("service weather", "", desired["weather"], desired["weather"], false))
+}
+
+func TestDiffFields_Subset(t *testing.T) {
+	desired := map[string]any{
+		"deployment": map[string]any{"image": "weather:1.1.0", "port": float64(3000)},
+		"containers": []any{map[string]any{"name": "weather"}},
+	}
+	live := map[string]any{
+		"deployment": map[string]any{"image": "weather:1.1.0", "port": float64(3000), "replicas": float64(1)},
+		"containers": []any{map[string]any{"name": "weather", "imagePullPolicy": "IfNotPresent"}},
+	}
+	assert.Empty(t, diffFields("MCPServer default/weather", "spec", desired, live, true))
+
+	live["deployment"].(map[string]any)["port"] = float64(8080)
+	assert.Equal(t, []models.DeploymentDrift{
+		{Resource: "MCPServer default/weather", Field: "spec.deployment.port", Desired: "3000", Actual: "8080"},
+	}, diffFields("MCPServer default/weather", "spec", desired, live, true))
+}
+
+func TestDiffServiceStatus(t *testing.T) {
+	service := map[string]any{"image": "weather:1.1.0", "deploy": map[string]any{"replicas": 3}}
+
+	assert.Equal(t, []models.DeploymentDrift{
+		{Resource: "service weather", Field: "state", Desired: ServiceStateRunning, Actual: ServiceStateMissing},
+	}, diffServiceStatus("service weather", service, ServiceStatus{}))
+
+	assert.Equal(t, []models.DeploymentDrift{
+		{Resource: "service weather", Field: "image", Desired: "weather:1.1.0", Actual: "weather:1.0.0"},
+		{Resource: "service weather", Field: "replicas", Desired: "3", Actual: "2"},
+	}, diffServiceStatus("service weather", service, ServiceStatus{
+		Service: "weather", Image: "weather:1.0.0", State: ServiceStateRunning, Replicas: 2, Running: 2,
+	}))
+
+	assert.Empty(t, diffServiceStatus("service weather", service, ServiceStatus{
+		Service: "weather", Image: "weather:1.1.0", State: ServiceStateRunning, Replicas: 3, Running: 3,
+	}))
+}
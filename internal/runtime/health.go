@@ -0,0 +1,147 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+)
+
+// GatewayServiceName is the compose service of the agent gateway. Stdio MCP servers run inside it,
+// so their health is the gateway's.
+const GatewayServiceName = "agent_gateway"
+
+// Container states and health values reported by docker compose
+const (
+	ServiceStateRunning = "running"
+	ServiceStateMissing = "missing"
+	// ServiceStateUnknown is reported for deployments whose runtime state cannot be read
+	ServiceStateUnknown = "unknown"
+
+	ServiceHealthHealthy   = "healthy"
+	ServiceHealthUnhealthy = "unhealthy"
+	ServiceHealthStarting  = "starting"
+)
+
+// ServiceStatus is the state of a container of the local runtime
+type ServiceStatus struct {
+	Service  string `json:"Service"`
+	Image    string `json:"Image"`
+	State    string `json:"State"`
+	Health   string `json:"Health"`
+	ExitCode int    `json:"ExitCode"`
+	Status   string `json:"Status"`
+	// Replicas counts the containers of the service and Running those that are running
+	Replicas int `json:"-"`
+	Running  int `json:"-"`
+}
+
+// Failed reports whether the container stopped or its healthcheck fails
+func (s ServiceStatus) Failed() bool {
+	return s.State != ServiceStateRunning || s.Health == ServiceHealthUnhealthy
+}
+
+// LocalServiceStatuses returns the containers of the local runtime in runtimeDir, keyed by compose service.
+// A service with several replicas reports the state of a failed replica if there is one.
+// It returns an empty map when the runtime has never been started.
+func LocalServiceStatuses(ctx context.Context, runtimeDir string) (map[string]ServiceStatus, error) {
+	return composeServiceStatuses(ctx, runtimeDir, "")
+}
+
+// composeServiceStatuses reads the containers of the compose project in runtimeDir from the docker daemon at
+// dockerHost; empty is the local daemon
+func composeServiceStatuses(ctx context.Context, runtimeDir, dockerHost string) (map[string]ServiceStatus, error) {
+	if _, err := os.Stat(filepath.Join(runtimeDir, "docker-compose.yaml")); os.IsNotExist(err) {
+		return map[string]ServiceStatus{}, nil
+	}
+	cmd := utils.ComposeCmd(ctx, "ps", "--all", "--format", "json")
+	cmd.Dir = runtimeDir
+	if dockerHost != "" {
+		cmd.Env = append(os.Environ(), utils.ContainerHostEnv(dockerHost)...)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker compose ps: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseComposePS(out)
+}
+
+// PingContainerEngine checks that the docker (or podman) daemon of the local runtime is reachable and returns its
+// server version
+func PingContainerEngine(ctx context.Context) (string, error) {
+	cmd := utils.ContainerCommand(ctx, "info", "--format", "{{.ServerVersion}}")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s info: %w: %s", utils.ContainerEngine(), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RestartLocalService restarts one container of the local runtime, starting it again if it exited
+func RestartLocalService(ctx context.Context, runtimeDir, service string) error {
+	cmd := utils.ComposeCmd(ctx, "restart", service)
+	cmd.Dir = runtimeDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("docker compose restart %s: %w: %s", service, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// parseComposePS parses `docker compose ps --format json`, which is a JSON array on older compose
+// releases and one object per line on newer ones
+func parseComposePS(out []byte) (map[string]ServiceStatus, error) {
+	statuses := make(map[string]ServiceStatus)
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return statuses, nil
+	}
+
+	var list []ServiceStatus
+	if out[0] == '[' {
+		if err := json.Unmarshal(out, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse docker compose ps output: %w", err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(out))
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			var status ServiceStatus
+			if err := json.Unmarshal(line, &status); err != nil {
+				return nil, fmt.Errorf("failed to parse docker compose ps output: %w", err)
+			}
+			list = append(list, status)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, status := range list {
+		seen, ok := statuses[status.Service]
+		status.Replicas = seen.Replicas + 1
+		status.Running = seen.Running
+		if status.State == ServiceStateRunning {
+			status.Running++
+		}
+		if ok && seen.Failed() && !status.Failed() {
+			seen.Replicas, seen.Running = status.Replicas, status.Running
+			status = seen
+		}
+		statuses[status.Service] = status
+	}
+	return statuses, nil
+}
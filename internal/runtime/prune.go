@@ -0,0 +1,399 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// imageLedgerFile lists every image the compose project of a runtime directory referenced, so Prune only removes
+// images the runtime pulled and never those a user pulled or built for other purposes
+const imageLedgerFile = ".images.json"
+
+// staleTempFileAge is the age after which a temp file of writeFileIfChanged is left over by a crashed write rather
+// than being written right now
+const staleTempFileAge = time.Minute
+
+// ledgerMu serializes the read-modify-write of image ledgers by reconciliation and Prune
+var ledgerMu sync.Mutex
+
+// PruneInput describes what the deployments of a registry still use
+type PruneInput struct {
+	// BaseDir is the runtime directory of the registry, holding the local target and the targets directory
+	BaseDir string
+	// Targets are the configured deployment targets; directories of other targets are stale
+	Targets Targets
+	// AgentConfigDirs are the MCP config directories of deployed agents, as returned by AgentConfigDir
+	AgentConfigDirs map[string]bool
+	DryRun          bool
+}
+
+// AgentConfigDir returns the directory the resolved MCP server config of an agent version is written to
+func AgentConfigDir(runtimeDir, agentName, version string) string {
+	dir, _ := common.ComputeMCPConfigPath(&common.MCPConfigTarget{BaseDir: runtimeDir, AgentName: agentName, Version: version})
+	return dir
+}
+
+// runtimeDirectory is a compose project directory of a docker target
+type runtimeDirectory struct {
+	target string
+	dir    string
+	host   string
+}
+
+type pruner struct {
+	in     PruneInput
+	report *models.PruneReport
+}
+
+// Prune removes the runtime artifacts no deployment uses anymore: images the compose projects stopped referencing,
+// MCP config directories of agents that are no longer deployed, directories of targets that are no longer configured,
+// and gateway configs without a compose project. With DryRun it only reports them.
+func Prune(ctx context.Context, in PruneInput) (*models.PruneReport, error) {
+	report := &models.PruneReport{DryRun: in.DryRun, Items: []models.PruneItem{}}
+	if in.BaseDir == "" {
+		return report, nil
+	}
+	if _, err := os.Stat(in.BaseDir); os.IsNotExist(err) {
+		return report, nil
+	}
+
+	in.BaseDir = filepath.Clean(in.BaseDir)
+	p := &pruner{in: in, report: report}
+	dirs, err := p.pruneTargetDirs()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dirs {
+		if err := p.pruneAgentConfigs(d); err != nil {
+			return nil, err
+		}
+		p.pruneGatewayConfigs(d)
+	}
+	if err := p.pruneImages(ctx, dirs); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		a, b := report.Items[i], report.Items[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	for _, item := range report.Items {
+		if item.Reclaimed() {
+			report.ReclaimedBytes += item.Size
+		}
+	}
+	return report, nil
+}
+
+func (p *pruner) add(item models.PruneItem, remove func() error) {
+	if item.Skipped == "" && !p.in.DryRun && remove != nil {
+		if err := remove(); err != nil {
+			item.Error = err.Error()
+		}
+	}
+	p.report.Items = append(p.report.Items, item)
+}
+
+// pruneTargetDirs removes the directories of targets that are no longer configured and returns the compose project
+// directories of the configured docker targets. A directory whose compose file still declares services is kept, as
+// its containers may still run and compose needs the file to stop them.
+func (p *pruner) pruneTargetDirs() ([]runtimeDirectory, error) {
+	dirs := dockerRuntimeDirs(p.in.BaseDir, p.in.Targets)
+	targetsDir := filepath.Join(p.in.BaseDir, "targets")
+	entries, err := os.ReadDir(targetsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", targetsDir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		dir := filepath.Join(targetsDir, name)
+		if t, ok := p.in.Targets[name]; ok && t.Type == TargetTypeDocker {
+			continue
+		}
+
+		item := models.PruneItem{Kind: models.PruneKindTargetDir, Target: name, Name: dir, Size: dirSize(dir)}
+		if data, err := os.ReadFile(filepath.Join(dir, "docker-compose.yaml")); err == nil {
+			if services, err := composeServices(data); err == nil && len(services) > 0 {
+				item.Skipped = fmt.Sprintf("target is no longer configured but its compose project declares %d services; "+
+					"stop them with `docker compose down` in this directory first", len(services))
+			}
+		}
+		p.add(item, func() error { return os.RemoveAll(dir) })
+	}
+	return dirs, nil
+}
+
+// pruneAgentConfigs removes the MCP config directories of agent versions that are no longer deployed to a target
+func (p *pruner) pruneAgentConfigs(d runtimeDirectory) error {
+	return filepath.WalkDir(d.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			// The directories of other targets are walked on their own
+			if path == filepath.Join(p.in.BaseDir, "targets") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != "mcp-servers.json" {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		if p.in.AgentConfigDirs[dir] {
+			return nil
+		}
+		rel, _ := filepath.Rel(d.dir, dir)
+		p.add(models.PruneItem{Kind: models.PruneKindAgentConfig, Target: d.target, Name: dir, Size: fileSize(path)}, func() error {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			removeEmptyParents(dir, d.dir, strings.Count(rel, string(filepath.Separator))+1)
+			return nil
+		})
+		return nil
+	})
+}
+
+// pruneGatewayConfigs removes a gateway config without a compose project running the gateway, and temp files left
+// over by crashed writes of the gateway config
+func (p *pruner) pruneGatewayConfigs(d runtimeDirectory) {
+	gatewayConfig := filepath.Join(d.dir, "agent-gateway.yaml")
+	if _, err := os.Stat(filepath.Join(d.dir, "docker-compose.yaml")); os.IsNotExist(err) {
+		if info, err := os.Stat(gatewayConfig); err == nil {
+			p.add(models.PruneItem{Kind: models.PruneKindGatewayConfig, Target: d.target, Name: gatewayConfig, Size: info.Size()},
+				func() error { return os.Remove(gatewayConfig) })
+		}
+	}
+
+	temps, _ := filepath.Glob(filepath.Join(d.dir, ".agent-gateway.yaml.*"))
+	for _, path := range temps {
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) < staleTempFileAge {
+			continue
+		}
+		p.add(models.PruneItem{Kind: models.PruneKindGatewayConfig, Target: d.target, Name: path, Size: info.Size()},
+			func() error { return os.Remove(path) })
+	}
+}
+
+// pruneImages removes the images in the ledgers of the compose projects of a docker host that none of their compose
+// files references anymore. Images still used by a container fail to be removed and stay in the ledger.
+func (p *pruner) pruneImages(ctx context.Context, dirs []runtimeDirectory) error {
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	byHost := map[string][]runtimeDirectory{}
+	for _, d := range dirs {
+		byHost[d.host] = append(byHost[d.host], d)
+	}
+	for host, hostDirs := range byHost {
+		referenced := map[string]bool{}
+		ledgers := map[string][]string{}
+		for _, d := range hostDirs {
+			for _, image := range composeFileImages(filepath.Join(d.dir, "docker-compose.yaml")) {
+				referenced[image] = true
+			}
+			ledger, err := readImageLedger(d.dir)
+			if err != nil {
+				return err
+			}
+			ledgers[d.dir] = ledger
+		}
+		// candidates maps the unreferenced images to the first target that recorded them
+		var candidates []string
+		candidateTargets := map[string]string{}
+		for _, d := range hostDirs {
+			for _, image := range ledgers[d.dir] {
+				if _, seen := candidateTargets[image]; !referenced[image] && !seen {
+					candidates = append(candidates, image)
+					candidateTargets[image] = d.target
+				}
+			}
+		}
+
+		removed := map[string]bool{}
+		for _, image := range candidates {
+			size, found := imageSize(ctx, host, image)
+			if !found {
+				// Already removed by hand, so only the ledger entry is left to drop
+				removed[image] = true
+				continue
+			}
+			item := models.PruneItem{Kind: models.PruneKindImage, Target: candidateTargets[image], Name: image, Size: size}
+			p.add(item, func() error {
+				if err := removeImage(ctx, host, image); err != nil {
+					return err
+				}
+				removed[image] = true
+				return nil
+			})
+		}
+
+		if p.in.DryRun || len(removed) == 0 {
+			continue
+		}
+		for dir, ledger := range ledgers {
+			kept := slices.DeleteFunc(slices.Clone(ledger), func(image string) bool { return removed[image] })
+			if len(kept) != len(ledger) {
+				if err := writeImageLedger(dir, kept); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// recordImages adds the images of a compose project to the ledger of its runtime directory
+func recordImages(runtimeDir string, images []string) error {
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	ledger, err := readImageLedger(runtimeDir)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, image := range images {
+		if image != "" && !slices.Contains(ledger, image) {
+			ledger = append(ledger, image)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return writeImageLedger(runtimeDir, ledger)
+}
+
+func readImageLedger(runtimeDir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(runtimeDir, imageLedgerFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image ledger: %w", err)
+	}
+	var images []string
+	if err := json.Unmarshal(data, &images); err != nil {
+		return nil, fmt.Errorf("failed to parse image ledger %s: %w", filepath.Join(runtimeDir, imageLedgerFile), err)
+	}
+	return images, nil
+}
+
+func writeImageLedger(runtimeDir string, images []string) error {
+	sort.Strings(images)
+	data, err := json.MarshalIndent(images, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := writeFileIfChanged(filepath.Join(runtimeDir, imageLedgerFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write image ledger: %w", err)
+	}
+	return nil
+}
+
+// composeFileImages returns the images of the services of a compose file, or none when it cannot be read
+func composeFileImages(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	services, err := composeServices(data)
+	if err != nil {
+		return nil
+	}
+	var images []string
+	for _, svc := range services {
+		if m, ok := svc.(map[string]any); ok {
+			if image, ok := m["image"].(string); ok && image != "" {
+				images = append(images, image)
+			}
+		}
+	}
+	return images
+}
+
+// imageSize returns the size of an image on a docker host and whether the host has it
+func imageSize(ctx context.Context, host, image string) (int64, bool) {
+	cmd := utils.ContainerCommand(ctx, "image", "inspect", "--format", "{{.Size}}", image)
+	if host != "" {
+		cmd.Env = append(os.Environ(), utils.ContainerHostEnv(host)...)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, false
+	}
+	size, _ := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	return size, true
+}
+
+// removeImage removes an image from a docker host. It is not forced, so images of existing containers are kept.
+func removeImage(ctx context.Context, host, image string) error {
+	cmd := utils.ContainerCommand(ctx, "image", "rm", image)
+	if host != "" {
+		cmd.Env = append(os.Environ(), utils.ContainerHostEnv(host)...)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s image rm: %w: %s", utils.ContainerEngine(), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// removeEmptyParents removes dir and up to levels-1 of its parents while they are empty, stopping at root
+func removeEmptyParents(dir, root string, levels int) {
+	for range levels {
+		if dir == root || os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			if info, err := entry.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,91 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func writeRuntimeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestPrune(t *testing.T) {
+	base := t.TempDir()
+	targets := DefaultTargets()
+	targets["edge"] = Target{Name: "edge", Type: TargetTypeDocker}
+
+	deployed := AgentConfigDir(base, "com.example/agent", "1.1.0")
+	stale := AgentConfigDir(base, "com.example/agent", "1.0.0")
+	removedAgent := AgentConfigDir(filepath.Join(base, "targets", "edge"), "old-agent", "1.0.0")
+	writeRuntimeFile(t, filepath.Join(deployed, "mcp-servers.json"), "[]")
+	writeRuntimeFile(t, filepath.Join(stale, "mcp-servers.json"), "[]")
+	writeRuntimeFile(t, filepath.Join(removedAgent, "mcp-servers.json"), "[]")
+	writeRuntimeFile(t, filepath.Join(base, "docker-compose.yaml"), "services: {}\n")
+	writeRuntimeFile(t, filepath.Join(base, "agent-gateway.yaml"), "binds: []\n")
+
+	// edge lost its compose project, gone has no target anymore, busy still declares a service
+	writeRuntimeFile(t, filepath.Join(base, "targets", "edge", "agent-gateway.yaml"), "binds: []\n")
+	writeRuntimeFile(t, filepath.Join(base, "targets", "gone", "agent-gateway.yaml"), "binds: []\n")
+	writeRuntimeFile(t, filepath.Join(base, "targets", "busy", "docker-compose.yaml"), "services:\n  weather:\n    image: weather:1\n")
+	leftover := filepath.Join(base, ".agent-gateway.yaml.123")
+	writeRuntimeFile(t, leftover, "partial")
+	old := time.Now().Add(-2 * staleTempFileAge)
+	require.NoError(t, os.Chtimes(leftover, old, old))
+
+	in := PruneInput{BaseDir: base, Targets: targets, AgentConfigDirs: map[string]bool{deployed: true}, DryRun: true}
+	report, err := Prune(context.Background(), in)
+	require.NoError(t, err)
+
+	names := map[string]models.PruneItem{}
+	for _, item := range report.Items {
+		names[item.Name] = item
+	}
+	assert.Len(t, report.Items, 6)
+	assert.Equal(t, models.PruneKindAgentConfig, names[stale].Kind)
+	assert.Equal(t, "edge", names[removedAgent].Target)
+	assert.Equal(t, models.PruneKindGatewayConfig, names[filepath.Join(base, "targets", "edge", "agent-gateway.yaml")].Kind)
+	assert.Equal(t, models.PruneKindGatewayConfig, names[leftover].Kind)
+	assert.True(t, names[filepath.Join(base, "targets", "gone")].Reclaimed())
+	assert.NotEmpty(t, names[filepath.Join(base, "targets", "busy")].Skipped)
+	assert.Positive(t, report.ReclaimedBytes)
+	assert.FileExists(t, filepath.Join(stale, "mcp-servers.json"), "a dry run removes nothing")
+
+	in.DryRun = false
+	report, err = Prune(context.Background(), in)
+	require.NoError(t, err)
+	for _, item := range report.Items {
+		assert.Empty(t, item.Error, item.Name)
+	}
+	assert.NoDirExists(t, stale)
+	assert.NoDirExists(t, filepath.Join(base, "targets", "edge", "old-agent"))
+	assert.NoDirExists(t, filepath.Join(base, "targets", "gone"))
+	assert.DirExists(t, filepath.Join(base, "targets", "busy"))
+	assert.NoFileExists(t, leftover)
+	assert.FileExists(t, filepath.Join(deployed, "mcp-servers.json"))
+	assert.FileExists(t, filepath.Join(base, "agent-gateway.yaml"))
+
+	report, err = Prune(context.Background(), in)
+	require.NoError(t, err)
+	require.Len(t, report.Items, 1)
+	assert.NotEmpty(t, report.Items[0].Skipped)
+}
+
+func TestRecordImages(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, recordImages(dir, []string{"b:1", "a:1", ""}))
+	require.NoError(t, recordImages(dir, []string{"a:1", "c:1"}))
+
+	ledger, err := readImageLedger(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a:1", "b:1", "c:1"}, ledger)
+}
@@ -0,0 +1,167 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/registry"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.yaml.in/yaml/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Render returns the files the desired state of the given servers and agents is applied from, keyed by slash
+// separated path. The local runtime renders the docker-compose.yaml, the agent gateway config and the MCP server
+// config of each agent; kubernetes renders one manifest per object and a kustomization.yaml listing them, or a Helm
+// chart when configured WithHelmChart. Nothing is written or applied.
+func (r *agentRegistryRuntime) Render(
+	ctx context.Context,
+	serverRequests []*registry.MCPServerRunRequest,
+	agentRequests []*registry.AgentRunRequest,
+) (_ map[string][]byte, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Runtime.Render",
+		attribute.Int("runtime.mcp_servers", len(serverRequests)),
+		attribute.Int("runtime.agents", len(agentRequests)),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	desiredState, err := r.desiredState(ctx, serverRequests, agentRequests)
+	if err != nil {
+		return nil, err
+	}
+	runtimeCfg, err := r.runtimeTranslator.TranslateRuntimeConfig(ctx, desiredState)
+	if err != nil {
+		return nil, fmt.Errorf("translate runtime config: %w", err)
+	}
+
+	switch runtimeCfg.Type {
+	case api.RuntimeConfigTypeLocal:
+		return renderLocalRuntime(desiredState, runtimeCfg.Local)
+	case api.RuntimeConfigTypeKubernetes:
+		if r.helmChart != "" {
+			return kagent.TranslateHelmChart(runtimeCfg.Kubernetes, r.helmChart, r.helmChartVersion)
+		}
+		return renderKubernetesRuntime(runtimeCfg.Kubernetes)
+	default:
+		return nil, fmt.Errorf("unsupported runtime config type: %v", runtimeCfg.Type)
+	}
+}
+
+func renderLocalRuntime(desiredState *api.DesiredState, cfg *api.LocalRuntimeConfig) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	// Compose treats bind mount sources that are neither absolute nor start with "." as named volumes
+	for _, service := range cfg.DockerCompose.Services {
+		for i, volume := range service.Volumes {
+			if volume.Source != "" && !filepath.IsAbs(volume.Source) && !strings.HasPrefix(volume.Source, ".") {
+				service.Volumes[i].Source = "./" + filepath.ToSlash(volume.Source)
+			}
+		}
+	}
+	dockerComposeYaml, err := cfg.DockerCompose.MarshalYAML()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal docker compose yaml: %w", err)
+	}
+	files["docker-compose.yaml"] = dockerComposeYaml
+
+	agentGatewayYaml, err := yaml.Marshal(cfg.AgentGateway)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal agent config: %w", err)
+	}
+	files["agent-gateway.yaml"] = agentGatewayYaml
+
+	for _, agent := range desiredState.Agents {
+		servers := pythonMCPServers(agent)
+		if len(servers) == 0 {
+			continue
+		}
+		configData, err := json.MarshalIndent(servers, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal MCP server config of agent %s: %w", agent.Name, err)
+		}
+		configPath := path.Join(agent.Name, "mcp-servers.json")
+		if agent.Version != "" {
+			configPath = path.Join(agent.Name, utils.SanitizeVersion(agent.Version), "mcp-servers.json")
+		}
+		files[configPath] = configData
+	}
+	return files, nil
+}
+
+func renderKubernetesRuntime(cfg *api.KubernetesRuntimeConfig) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	if cfg == nil {
+		return files, nil
+	}
+
+	// Objects are listed in the order they are applied in
+	var objects []client.Object
+	for _, obj := range cfg.ConfigMaps {
+		objects = append(objects, obj)
+	}
+	for _, obj := range cfg.Agents {
+		objects = append(objects, obj)
+	}
+	for _, obj := range cfg.RemoteMCPServers {
+		objects = append(objects, obj)
+	}
+	for _, obj := range cfg.MCPServers {
+		objects = append(objects, obj)
+	}
+	for _, obj := range cfg.NetworkPolicies {
+		objects = append(objects, obj)
+	}
+
+	var resources []string
+	for _, obj := range objects {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(kagent.DefaultNamespace)
+		}
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the kind of %s: %w", obj.GetName(), err)
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+		fields, err := objectFields(obj)
+		if err != nil {
+			return nil, err
+		}
+		// Status and server-set metadata are not part of the desired state
+		delete(fields, "status")
+		if metadata, ok := fields["metadata"].(map[string]any); ok {
+			delete(metadata, "creationTimestamp")
+		}
+		data, err := yaml.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s %s: %w", gvk.Kind, obj.GetName(), err)
+		}
+
+		name := fmt.Sprintf("%s-%s-%s.yaml", strings.ToLower(gvk.Kind), obj.GetNamespace(), obj.GetName())
+		if _, ok := files[name]; ok {
+			continue
+		}
+		files[name] = data
+		resources = append(resources, name)
+	}
+
+	kustomization, err := yaml.Marshal(map[string]any{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  resources,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal kustomization: %w", err)
+	}
+	files["kustomization.yaml"] = kustomization
+	return files, nil
+}
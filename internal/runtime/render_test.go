@@ -0,0 +1,35 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderKubernetesRuntime(t *testing.T) {
+	files, err := renderKubernetesRuntime(&api.KubernetesRuntimeConfig{
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-mcp-servers"},
+			Data:       map[string]string{"mcp-servers.json": "[]"},
+		}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `apiVersion: v1
+data:
+    mcp-servers.json: '[]'
+kind: ConfigMap
+metadata:
+    name: weather-mcp-servers
+    namespace: kagent
+`, string(files["configmap-kagent-weather-mcp-servers.yaml"]))
+	assert.Equal(t, `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+    - configmap-kagent-weather-mcp-servers.yaml
+`, string(files["kustomization.yaml"]))
+}
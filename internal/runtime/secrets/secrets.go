@@ -0,0 +1,273 @@
+// Package secrets resolves references to external secret sources in deployment config, so that only the
+// reference is stored in the registry database and the value is fetched when a deployment is reconciled.
+//
+// Supported references:
+//
+//	secretRef://vault/<path>#<key>      HashiCorp Vault (KV v1 or v2), using VAULT_ADDR and VAULT_TOKEN
+//	secretRef://aws/<secret-id>[#<key>] AWS Secrets Manager, using the aws CLI and its credential chain
+//	env://<NAME>                        an environment variable of the registry host
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Reference schemes
+const (
+	SchemeSecretRef = "secretRef://"
+	SchemeEnv       = "env://"
+)
+
+// Secret providers
+const (
+	ProviderVault = "vault"
+	ProviderAWS   = "aws"
+)
+
+// deniedEnvPrefixes are host variables env:// may not read: the registry's own configuration and the
+// credentials it uses to reach secret stores
+var deniedEnvPrefixes = []string{"AGENT_REGISTRY_", "VAULT_", "AWS_"}
+
+// Reference is a parsed secret reference
+type Reference struct {
+	Provider string // "vault", "aws" or "env"
+	Path     string
+	Key      string
+}
+
+// Provider fetches secrets from an external store
+type Provider interface {
+	// Get returns the secret at path, or the given key of it when key is non-empty
+	Get(ctx context.Context, path, key string) (string, error)
+}
+
+// IsReference reports whether a config value refers to an external secret
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, SchemeSecretRef) || strings.HasPrefix(value, SchemeEnv)
+}
+
+// ParseReference parses a secretRef:// or env:// value
+func ParseReference(value string) (*Reference, error) {
+	if name, ok := strings.CutPrefix(value, SchemeEnv); ok {
+		if name == "" || strings.ContainsAny(name, "/#=") {
+			return nil, fmt.Errorf("invalid env reference %q: expected env://NAME", value)
+		}
+		return &Reference{Provider: "env", Path: name}, nil
+	}
+
+	rest, ok := strings.CutPrefix(value, SchemeSecretRef)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a secret reference", value)
+	}
+	provider, path, _ := strings.Cut(rest, "/")
+	var key string
+	if i := strings.LastIndex(path, "#"); i >= 0 {
+		path, key = path[:i], path[i+1:]
+	}
+	if path == "" {
+		return nil, fmt.Errorf("invalid secret reference %q: missing path", value)
+	}
+	switch provider {
+	case ProviderVault:
+		if key == "" {
+			return nil, fmt.Errorf("invalid secret reference %q: vault references need a #key", value)
+		}
+	case ProviderAWS:
+	default:
+		return nil, fmt.Errorf("invalid secret reference %q: unknown provider %q (expected vault or aws)", value, provider)
+	}
+	return &Reference{Provider: provider, Path: path, Key: key}, nil
+}
+
+// ValidateConfig checks the syntax of every secret reference in a deployment config without resolving it
+func ValidateConfig(config map[string]string) error {
+	for k, v := range config {
+		if !IsReference(v) {
+			continue
+		}
+		if _, err := ParseReference(v); err != nil {
+			return fmt.Errorf("config %s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// Resolver resolves secret references using the configured providers
+type Resolver struct {
+	providers map[string]Provider
+	lookupEnv func(string) (string, bool)
+}
+
+// NewResolver creates a resolver backed by Vault (VAULT_ADDR, VAULT_TOKEN, VAULT_NAMESPACE),
+// AWS Secrets Manager and the host environment
+func NewResolver() *Resolver {
+	return &Resolver{
+		providers: map[string]Provider{
+			ProviderVault: &vaultProvider{
+				addr:      os.Getenv("VAULT_ADDR"),
+				token:     os.Getenv("VAULT_TOKEN"),
+				namespace: os.Getenv("VAULT_NAMESPACE"),
+				client:    &http.Client{Timeout: 30 * time.Second},
+			},
+			ProviderAWS: &awsProvider{},
+		},
+		lookupEnv: os.LookupEnv,
+	}
+}
+
+// Resolve returns the value a reference points to; values that are not references are returned unchanged
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+	ref, err := ParseReference(value)
+	if err != nil {
+		return "", err
+	}
+
+	if ref.Provider == "env" {
+		for _, prefix := range deniedEnvPrefixes {
+			if strings.HasPrefix(ref.Path, prefix) {
+				return "", fmt.Errorf("env://%s: variables starting with %s cannot be referenced", ref.Path, prefix)
+			}
+		}
+		v, ok := r.lookupEnv(ref.Path)
+		if !ok {
+			return "", fmt.Errorf("env://%s: variable is not set on the registry host", ref.Path)
+		}
+		return v, nil
+	}
+
+	provider, ok := r.providers[ref.Provider]
+	if !ok {
+		return "", fmt.Errorf("secret provider %s is not configured", ref.Provider)
+	}
+	v, err := provider.Get(ctx, ref.Path, ref.Key)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", value, err)
+	}
+	return v, nil
+}
+
+// ResolveConfig returns a copy of a deployment config with every secret reference replaced by its value
+func (r *Resolver) ResolveConfig(ctx context.Context, config map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(config))
+	cache := map[string]string{}
+	for k, v := range config {
+		if !IsReference(v) {
+			resolved[k] = v
+			continue
+		}
+		if cached, ok := cache[v]; ok {
+			resolved[k] = cached
+			continue
+		}
+		value, err := r.Resolve(ctx, v)
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", k, err)
+		}
+		cache[v] = value
+		resolved[k] = value
+	}
+	return resolved, nil
+}
+
+// vaultProvider reads secrets through the Vault HTTP API
+type vaultProvider struct {
+	addr      string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+func (v *vaultProvider) Get(ctx context.Context, path, key string) (string, error) {
+	if v.addr == "" || v.token == "" {
+		return "", fmt.Errorf("vault is not configured (set VAULT_ADDR and VAULT_TOKEN)")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(v.addr, "/")+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach vault: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned %s", resp.Status)
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("failed to parse vault response: %w", err)
+	}
+	data := secret.Data
+	// KV v2 nests the secret under data.data next to its metadata
+	if inner, ok := data["data"].(map[string]any); ok {
+		if _, hasMeta := data["metadata"]; hasMeta {
+			data = inner
+		}
+	}
+	return lookupKey(data, key)
+}
+
+// awsProvider reads secrets from AWS Secrets Manager with the aws CLI, which handles credentials and regions
+type awsProvider struct{}
+
+func (a *awsProvider) Get(ctx context.Context, path, key string) (string, error) {
+	cmd := exec.CommandContext(ctx, "aws", "secretsmanager", "get-secret-value",
+		"--secret-id", path, "--query", "SecretString", "--output", "text")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("aws secretsmanager failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	value := strings.TrimSuffix(stdout.String(), "\n")
+	if key == "" {
+		return value, nil
+	}
+	var data map[string]any
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return "", fmt.Errorf("secret is not a JSON object, so #%s cannot be selected", key)
+	}
+	return lookupKey(data, key)
+}
+
+func lookupKey(data map[string]any, key string) (string, error) {
+	v, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret", key)
+	}
+	switch val := v.(type) {
+	case string:
+		return val, nil
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode key %q: %w", key, err)
+		}
+		return string(b), nil
+	}
+}
@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("secretRef://vault/secret/data/weather#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, Reference{Provider: ProviderVault, Path: "secret/data/weather", Key: "api_key"}, *ref)
+
+	ref, err = ParseReference("secretRef://aws/arn:aws:secretsmanager:us-east-1:123:secret:weather")
+	require.NoError(t, err)
+	assert.Equal(t, Reference{Provider: ProviderAWS, Path: "arn:aws:secretsmanager:us-east-1:123:secret:weather"}, *ref)
+
+	ref, err = ParseReference("env://WEATHER_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, Reference{Provider: "env", Path: "WEATHER_KEY"}, *ref)
+
+	for _, invalid := range []string{
+		"secretRef://vault/secret/data/weather", // vault needs a key
+		"secretRef://gcp/projects/x#y",
+		"secretRef://aws/",
+		"env://",
+		"env://A/B",
+	} {
+		_, err := ParseReference(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, ValidateConfig(map[string]string{"REGION": "eu", "API_KEY": "env://WEATHER_KEY"}))
+	assert.ErrorContains(t, ValidateConfig(map[string]string{"API_KEY": "secretRef://nope/x"}), "config API_KEY")
+}
+
+func TestResolveConfig(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/weather":
+			_, _ = w.Write([]byte(`{"data":{"data":{"api_key":"kv2-value","port":8080},"metadata":{"version":3}}}`))
+		case "/v1/kv/weather":
+			_, _ = w.Write([]byte(`{"data":{"api_key":"kv1-value"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+
+	r := &Resolver{
+		providers: map[string]Provider{
+			ProviderVault: &vaultProvider{addr: vault.URL, token: "root", client: vault.Client()},
+		},
+		lookupEnv: func(name string) (string, bool) {
+			env := map[string]string{"WEATHER_KEY": "from-env", "AGENT_REGISTRY_JWT_PRIVATE_KEY": "private"}
+			v, ok := env[name]
+			return v, ok
+		},
+	}
+	ctx := context.Background()
+
+	resolved, err := r.ResolveConfig(ctx, map[string]string{
+		"REGION": "eu",
+		"KEY_V2": "secretRef://vault/secret/data/weather#api_key",
+		"PORT":   "secretRef://vault/secret/data/weather#port",
+		"KEY_V1": "secretRef://vault/kv/weather#api_key",
+		"KEY":    "env://WEATHER_KEY",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"REGION": "eu",
+		"KEY_V2": "kv2-value",
+		"PORT":   "8080",
+		"KEY_V1": "kv1-value",
+		"KEY":    "from-env",
+	}, resolved)
+
+	_, err = r.Resolve(ctx, "secretRef://vault/secret/data/weather#missing")
+	assert.ErrorContains(t, err, "not found")
+	_, err = r.Resolve(ctx, "secretRef://vault/secret/data/other#api_key")
+	assert.ErrorContains(t, err, "404")
+	_, err = r.Resolve(ctx, "env://UNSET")
+	assert.ErrorContains(t, err, "not set")
+	// The registry's own configuration is off limits
+	_, err = r.Resolve(ctx, "env://AGENT_REGISTRY_JWT_PRIVATE_KEY")
+	assert.ErrorContains(t, err, "cannot be referenced")
+	_, err = r.Resolve(ctx, "secretRef://aws/weather")
+	assert.ErrorContains(t, err, "not configured")
+}
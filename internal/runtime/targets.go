@@ -0,0 +1,150 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Deployment target types
+const (
+	TargetTypeDocker     = "docker"
+	TargetTypeKubernetes = "kubernetes"
+)
+
+// Target is a named place deployments land on: a docker host or a kubernetes cluster.
+type Target struct {
+	Name string `yaml:"name" json:"name"`
+	// Type is docker or kubernetes
+	Type string `yaml:"type" json:"type"`
+	// Host is the docker host of a docker target, in DOCKER_HOST form (ssh://user@host, tcp://host:2376).
+	// Empty is the docker host of the registry server.
+	Host string `yaml:"host,omitempty" json:"host,omitempty"`
+	// Context is the kubeconfig context of a kubernetes target. Empty is the current context.
+	Context string `yaml:"context,omitempty" json:"context,omitempty"`
+	// Namespace is the default namespace of a kubernetes target
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// Runtime returns the runtime ("local" or "kubernetes") deployments to the target are reconciled with.
+func (t Target) Runtime() string {
+	if t.Type == TargetTypeKubernetes {
+		return "kubernetes"
+	}
+	return "local"
+}
+
+// RuntimeDir returns the directory the compose project of a docker target is written to. The built-in local
+// target keeps using base, so existing deployments are not moved.
+func (t Target) RuntimeDir(base string) string {
+	if t.Name == "local" {
+		return base
+	}
+	return filepath.Join(base, "targets", t.Name)
+}
+
+// builtinTargets are named after the runtimes, so a deployment's runtime is also its target.
+var builtinTargets = map[string]Target{
+	"local":      {Name: "local", Type: TargetTypeDocker},
+	"kubernetes": {Name: "kubernetes", Type: TargetTypeKubernetes},
+}
+
+var targetNameRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// Targets holds the deployment targets of a registry by name.
+type Targets map[string]Target
+
+// DefaultTargets returns the built-in targets: the local docker host and the current kubernetes context.
+func DefaultTargets() Targets {
+	targets := Targets{}
+	for name, t := range builtinTargets {
+		targets[name] = t
+	}
+	return targets
+}
+
+// LoadTargets reads the deployment targets from a YAML or JSON file of the form
+//
+//	targets:
+//	  - name: edge
+//	    type: docker
+//	    host: ssh://deploy@edge-1
+//	  - name: prod
+//	    type: kubernetes
+//	    context: prod-cluster
+//	    namespace: agents
+//
+// and returns them together with the built-in targets.
+func LoadTargets(path string) (Targets, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployment targets file: %w", err)
+	}
+	var file struct {
+		Targets []Target `yaml:"targets"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment targets file %s: %w", path, err)
+	}
+
+	targets := DefaultTargets()
+	for _, t := range file.Targets {
+		if err := validateTarget(t); err != nil {
+			return nil, err
+		}
+		if _, exists := targets[t.Name]; exists {
+			return nil, fmt.Errorf("deployment target %q is defined more than once or shadows a built-in target", t.Name)
+		}
+		targets[t.Name] = t
+	}
+	return targets, nil
+}
+
+func validateTarget(t Target) error {
+	if !targetNameRe.MatchString(t.Name) {
+		return fmt.Errorf("invalid deployment target name %q: use lowercase letters, digits and dashes", t.Name)
+	}
+	switch t.Type {
+	case TargetTypeDocker:
+		if t.Context != "" || t.Namespace != "" {
+			return fmt.Errorf("deployment target %s: context and namespace only apply to kubernetes targets", t.Name)
+		}
+	case TargetTypeKubernetes:
+		if t.Host != "" {
+			return fmt.Errorf("deployment target %s: host only applies to docker targets", t.Name)
+		}
+	default:
+		return fmt.Errorf("deployment target %s: unsupported type %q (expected docker or kubernetes)", t.Name, t.Type)
+	}
+	return nil
+}
+
+// Resolve returns the target of a deployment. Deployments made before targets existed have no target and
+// resolve to the built-in target of their runtime.
+func (ts Targets) Resolve(name, runtime string) (Target, error) {
+	if name == "" {
+		name = runtime
+	}
+	if name == "" {
+		name = "local"
+	}
+	t, ok := ts[name]
+	if !ok {
+		return Target{}, fmt.Errorf("unknown deployment target %q", name)
+	}
+	return t, nil
+}
+
+// List returns the targets sorted by name.
+func (ts Targets) List() []Target {
+	list := make([]Target, 0, len(ts))
+	for _, t := range ts {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
@@ -0,0 +1,89 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTargetsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "targets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadTargets(t *testing.T) {
+	path := writeTargetsFile(t, `
+targets:
+  - name: edge
+    type: docker
+    host: ssh://deploy@edge-1
+  - name: prod
+    type: kubernetes
+    context: prod-cluster
+    namespace: agents
+`)
+
+	targets, err := LoadTargets(path)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, target := range targets.List() {
+		names = append(names, target.Name)
+	}
+	assert.Equal(t, []string{"edge", "kubernetes", "local", "prod"}, names)
+
+	edge := targets["edge"]
+	assert.Equal(t, "local", edge.Runtime())
+	assert.Equal(t, "ssh://deploy@edge-1", edge.Host)
+	assert.Equal(t, filepath.Join("/tmp/rt", "targets", "edge"), edge.RuntimeDir("/tmp/rt"))
+
+	prod := targets["prod"]
+	assert.Equal(t, "kubernetes", prod.Runtime())
+	assert.Equal(t, "prod-cluster", prod.Context)
+	assert.Equal(t, "agents", prod.Namespace)
+
+	assert.Equal(t, "/tmp/rt", targets["local"].RuntimeDir("/tmp/rt"))
+}
+
+func TestLoadTargets_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"shadows built-in":  "targets:\n  - name: local\n    type: docker\n",
+		"duplicate":         "targets:\n  - name: a\n    type: docker\n  - name: a\n    type: docker\n",
+		"bad name":          "targets:\n  - name: Edge_1\n    type: docker\n",
+		"bad type":          "targets:\n  - name: edge\n    type: nomad\n",
+		"host on k8s":       "targets:\n  - name: prod\n    type: kubernetes\n    host: tcp://x:2376\n",
+		"context on docker": "targets:\n  - name: edge\n    type: docker\n    context: prod\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadTargets(writeTargetsFile(t, content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTargetsResolve(t *testing.T) {
+	targets := DefaultTargets()
+	targets["edge"] = Target{Name: "edge", Type: TargetTypeDocker, Host: "tcp://edge:2376"}
+
+	target, err := targets.Resolve("", "")
+	require.NoError(t, err)
+	assert.Equal(t, "local", target.Name)
+
+	// Deployments without a target resolve to the built-in target of their runtime
+	target, err = targets.Resolve("", "kubernetes")
+	require.NoError(t, err)
+	assert.Equal(t, "kubernetes", target.Name)
+
+	target, err = targets.Resolve("edge", "local")
+	require.NoError(t, err)
+	assert.Equal(t, "tcp://edge:2376", target.Host)
+
+	_, err = targets.Resolve("missing", "")
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 )
 
 // DesiredState represents the desired set of MCPServevrs the user wishes to run locally
@@ -42,6 +43,20 @@ type MCPServer struct {
 	Local *LocalMCPServer `json:"local,omitempty"`
 	// Namespace is the target namespace for Kubernetes deployments (optional, defaults to "kagent")
 	Namespace string `json:"namespace,omitempty"`
+	// CanaryWeight is the percentage of the server's traffic routed to this version, which runs next to the
+	// stable version of the same name. Zero is the stable version.
+	CanaryWeight int `json:"canaryWeight,omitempty"`
+}
+
+// CanaryServiceSuffix is appended to the service of a canary so it can run next to the stable version
+const CanaryServiceSuffix = "-canary"
+
+// ServiceName returns the name the container of the server runs as
+func (s *MCPServer) ServiceName() string {
+	if s.CanaryWeight > 0 {
+		return s.Name + CanaryServiceSuffix
+	}
+	return s.Name
 }
 
 type MCPServerType string
@@ -75,12 +90,22 @@ type LocalMCPServer struct {
 	TransportType TransportType `json:"transportType"`
 	// HTTP defines the configuration for an HTTP transport.(only for TransportTypeHTTP)
 	HTTP *HTTPTransport `json:"http,omitempty"`
+	// Egress restricts the outbound connections of the server when set
+	Egress *EgressPolicy `json:"egress,omitempty"`
+}
+
+// EgressPolicy restricts the outbound connections of a local MCP server to an allowlist of hosts
+type EgressPolicy struct {
+	// AllowedHosts are the host names the server may connect to, sorted; empty denies all egress
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
 }
 
 // HTTPTransport defines the configuration for an HTTP transport
 type HTTPTransport struct {
 	Port uint32 `json:"port"`
 	Path string `json:"path,omitempty"`
+	// HealthPath is probed by the container healthcheck; empty probes Path
+	HealthPath string `json:"healthPath,omitempty"`
 }
 
 // MCPServerTransportType defines the type of transport for the MCP server.
@@ -107,12 +132,35 @@ type MCPServerDeployment struct {
 
 	// Env defines the environment variables to set in the container.
 	Env map[string]string `json:"env,omitempty"`
+
+	// Resources defines the compute limits and restart policy of the container.
+	Resources *Resources `json:"resources,omitempty"`
 }
 
 type AgentDeployment struct {
-	Image string            `json:"image,omitempty"`
-	Env   map[string]string `json:"env,omitempty"`
-	Port  uint16            `json:"port,omitempty"`
+	Image     string            `json:"image,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	Port      uint16            `json:"port,omitempty"`
+	Resources *Resources        `json:"resources,omitempty"`
+}
+
+// Resources defines the compute limits, restart policy and replica count of a deployed container.
+// Zero values are left unset.
+type Resources struct {
+	// CPULimit and CPURequest are in cores
+	CPULimit   float64 `json:"cpuLimit,omitempty"`
+	CPURequest float64 `json:"cpuRequest,omitempty"`
+	// MemoryLimit and MemoryRequest are in bytes
+	MemoryLimit   int64 `json:"memoryLimit,omitempty"`
+	MemoryRequest int64 `json:"memoryRequest,omitempty"`
+	// RestartPolicy is one of no, always, on-failure or unless-stopped. It only applies to the local runtime,
+	// Kubernetes always restarts pods of a Deployment.
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+	// Replicas is the number of instances to run; zero runs one
+	Replicas int `json:"replicas,omitempty"`
+	// RestrictEgress limits the outbound connections of an MCP server run from a package to the hosts its manifest
+	// declares. It does not apply to agents.
+	RestrictEgress bool `json:"restrictEgress,omitempty"`
 }
 
 type AIRuntimeConfig struct {
@@ -134,4 +182,6 @@ type KubernetesRuntimeConfig struct {
 	RemoteMCPServers []*v1alpha2.RemoteMCPServer `json:"remoteMCPServers"`
 	MCPServers       []*kmcpv1alpha1.MCPServer   `json:"mcpServers"`
 	ConfigMaps       []*corev1.ConfigMap         `json:"configMaps,omitempty"`
+	// NetworkPolicies restrict the egress of the MCP servers deployed with an egress policy
+	NetworkPolicies []*networkingv1.NetworkPolicy `json:"networkPolicies,omitempty"`
 }
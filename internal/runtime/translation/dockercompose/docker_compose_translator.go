@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"slices"
+	"time"
 
 	api "github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
 	"github.com/agentregistry-dev/agentregistry/internal/utils"
@@ -13,6 +14,9 @@ import (
 	"github.com/compose-spec/compose-go/v2/types"
 )
 
+// DefaultProjectName is the docker compose project the local runtime is deployed under
+const DefaultProjectName = "agentregistry_runtime"
+
 type agentGatewayTranslator struct {
 	composeWorkingDir string
 	agentGatewayPort  uint16
@@ -23,7 +27,7 @@ func NewAgentGatewayTranslator(composeWorkingDir string, agentGatewayPort uint16
 	return &agentGatewayTranslator{
 		composeWorkingDir: composeWorkingDir,
 		agentGatewayPort:  agentGatewayPort,
-		projectName:       "agentregistry_runtime",
+		projectName:       DefaultProjectName,
 	}
 }
 
@@ -48,21 +52,34 @@ func (t *agentGatewayTranslator) TranslateRuntimeConfig(
 		"agent_gateway": *agentGatewayService,
 	}
 
+	networks := types.Networks{}
+	configs := types.Configs{}
 	for _, mcpServer := range desired.MCPServers {
+		if mcpServer.MCPServerType == api.MCPServerTypeLocal && mcpServer.Local.TransportType == api.TransportTypeStdio && mcpServer.Local.Egress != nil {
+			return nil, fmt.Errorf("MCPServer %s: egress cannot be restricted for stdio servers on the local runtime, they run inside the agent gateway", mcpServer.Name)
+		}
 		// only need to create services for local servers
 		if mcpServer.MCPServerType != api.MCPServerTypeLocal || mcpServer.Local.TransportType == api.TransportTypeStdio {
 			continue
 		}
 		// error if MCPServer name is not unique
-		if _, exists := dockerComposeServices[mcpServer.Name]; exists {
-			return nil, fmt.Errorf("duplicate MCPServer name found: %s", mcpServer.Name)
+		serviceName := mcpServer.ServiceName()
+		if _, exists := dockerComposeServices[serviceName]; exists {
+			return nil, fmt.Errorf("duplicate MCPServer name found: %s", serviceName)
 		}
 
 		serviceConfig, err := t.translateMCPServerToServiceConfig(mcpServer)
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate MCPServer %s to service config: %w", mcpServer.Name, err)
 		}
-		dockerComposeServices[mcpServer.Name] = *serviceConfig
+		if mcpServer.Local.Egress != nil {
+			proxy := restrictEgress(serviceConfig, mcpServer.Local.Egress, networks, configs)
+			if _, exists := dockerComposeServices[proxy.Name]; exists {
+				return nil, fmt.Errorf("duplicate MCPServer name found: %s", proxy.Name)
+			}
+			dockerComposeServices[proxy.Name] = *proxy
+		}
+		dockerComposeServices[serviceName] = *serviceConfig
 	}
 
 	for _, agent := range desired.Agents {
@@ -74,14 +91,22 @@ func (t *agentGatewayTranslator) TranslateRuntimeConfig(
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate Agent %s to service config: %w", agent.Name, err)
 		}
+		// Agents connect to the servers they use directly, so they join the networks of those with restricted egress
+		joinEgressNetworks(serviceConfig, networks)
 		dockerComposeServices[agent.Name] = *serviceConfig
 	}
+	joinEgressNetworks(agentGatewayService, networks)
+	dockerComposeServices["agent_gateway"] = *agentGatewayService
 
 	dockerCompose := &api.DockerComposeConfig{
 		Name:       t.projectName,
 		WorkingDir: t.composeWorkingDir,
 		Services:   dockerComposeServices,
 	}
+	if len(networks) > 0 {
+		dockerCompose.Networks = networks
+		dockerCompose.Configs = configs
+	}
 
 	gwConfig, err := t.translateAgentGatewayConfig(desired.MCPServers, desired.Agents)
 	if err != nil {
@@ -119,9 +144,43 @@ func (t *agentGatewayTranslator) translateAgentGatewayService() (*types.ServiceC
 			Source: t.composeWorkingDir,
 			Target: "/config",
 		}},
+		// Stdio MCP servers run inside the gateway, so it answering on its MCP route is their liveness check
+		HealthCheck: httpHealthCheck(uint32(port), "/mcp"),
 	}, nil
 }
 
+// Healthcheck timings: a container is unhealthy after healthCheckRetries failed probes
+const (
+	healthCheckInterval    = 30 * time.Second
+	healthCheckTimeout     = 5 * time.Second
+	healthCheckStartPeriod = 20 * time.Second
+	healthCheckRetries     = 3
+)
+
+// httpHealthCheck probes an HTTP path of the container. Any HTTP response counts as alive since MCP endpoints
+// reject plain GET requests; curl is tried first and wget is the fallback for images without it (its exit
+// code 8 means the server answered with an error status).
+func httpHealthCheck(port uint32, path string) *types.HealthCheckConfig {
+	if path == "" || path[0] != '/' {
+		path = "/" + path
+	}
+	url := fmt.Sprintf("http://localhost:%d%s", port, path)
+	interval := types.Duration(healthCheckInterval)
+	timeout := types.Duration(healthCheckTimeout)
+	startPeriod := types.Duration(healthCheckStartPeriod)
+	retries := uint64(healthCheckRetries)
+	return &types.HealthCheckConfig{
+		Test: types.HealthCheckTest{
+			"CMD-SHELL",
+			fmt.Sprintf("curl -s -o /dev/null %[1]s || wget -q -O /dev/null %[1]s || [ $? -eq 8 ]", url),
+		},
+		Interval:    &interval,
+		Timeout:     &timeout,
+		StartPeriod: &startPeriod,
+		Retries:     &retries,
+	}
+}
+
 func (t *agentGatewayTranslator) translateMCPServerToServiceConfig(server *api.MCPServer) (*types.ServiceConfig, error) {
 	image := server.Local.Deployment.Image
 	if image == "" {
@@ -140,12 +199,21 @@ func (t *agentGatewayTranslator) translateMCPServerToServiceConfig(server *api.M
 		return cmp.Compare(a, b)
 	})
 
-	return &types.ServiceConfig{
-		Name:        server.Name,
+	service := &types.ServiceConfig{
+		Name:        server.ServiceName(),
 		Image:       image,
 		Command:     cmd,
 		Environment: types.NewMappingWithEquals(envValues),
-	}, nil
+	}
+	applyResources(service, server.Local.Deployment.Resources)
+	if http := server.Local.HTTP; server.Local.TransportType == api.TransportTypeHTTP && http != nil && http.Port > 0 {
+		path := http.HealthPath
+		if path == "" {
+			path = http.Path
+		}
+		service.HealthCheck = httpHealthCheck(http.Port, path)
+	}
+	return service, nil
 }
 
 func (t *agentGatewayTranslator) translateAgentToServiceConfig(agent *api.Agent) (*types.ServiceConfig, error) {
@@ -178,64 +246,105 @@ func (t *agentGatewayTranslator) translateAgentToServiceConfig(agent *api.Agent)
 		agentConfigDir = filepath.Join(t.composeWorkingDir, agent.Name)
 	}
 
-	return &types.ServiceConfig{
+	service := &types.ServiceConfig{
 		Name:        agent.Name,
 		Image:       image,
 		Command:     []string{agent.Name, "--local", "--port", fmt.Sprintf("%d", port)},
 		Environment: types.NewMappingWithEquals(envValues),
-		Ports: []types.ServicePortConfig{{
-			Target:    uint32(port),
-			Published: fmt.Sprintf("%d", port),
-		}},
 		Volumes: []types.ServiceVolumeConfig{{
 			Type:   types.VolumeTypeBind,
 			Source: agentConfigDir,
 			Target: "/config",
 		}},
-	}, nil
+	}
+	// Replicas cannot share a host port; they are reached through the agent gateway only
+	if replicas(agent.Deployment.Resources) == 1 {
+		service.Ports = []types.ServicePortConfig{{
+			Target:    uint32(port),
+			Published: fmt.Sprintf("%d", port),
+		}}
+	}
+	applyResources(service, agent.Deployment.Resources)
+	return service, nil
+}
+
+// applyResources sets the compose deploy.resources limits and reservations, deploy.replicas and the restart
+// policy of a service
+func applyResources(service *types.ServiceConfig, res *api.Resources) {
+	if res == nil {
+		return
+	}
+	service.Restart = res.RestartPolicy
+
+	var limits, reservations *types.Resource
+	if res.CPULimit > 0 || res.MemoryLimit > 0 {
+		limits = &types.Resource{
+			NanoCPUs:    types.NanoCPUs(res.CPULimit),
+			MemoryBytes: types.UnitBytes(res.MemoryLimit),
+		}
+	}
+	if res.CPURequest > 0 || res.MemoryRequest > 0 {
+		reservations = &types.Resource{
+			NanoCPUs:    types.NanoCPUs(res.CPURequest),
+			MemoryBytes: types.UnitBytes(res.MemoryRequest),
+		}
+	}
+	if limits == nil && reservations == nil && res.Replicas <= 1 {
+		return
+	}
+	service.Deploy = &types.DeployConfig{
+		Resources: types.Resources{
+			Limits:       limits,
+			Reservations: reservations,
+		},
+	}
+	if res.Replicas > 1 {
+		// The gateway addresses services by name, which docker DNS resolves to every replica in turn
+		n := res.Replicas
+		service.Deploy.Replicas = &n
+	}
+}
+
+// replicas returns the number of containers a service runs
+func replicas(res *api.Resources) int {
+	if res == nil || res.Replicas < 1 {
+		return 1
+	}
+	return res.Replicas
 }
 
 func (t *agentGatewayTranslator) translateAgentGatewayConfig(servers []*api.MCPServer, agents []*api.Agent) (*api.AgentGatewayConfig, error) {
 	var targets []api.MCPTarget
+	var canaries []*api.MCPServer
+	stable := map[string]bool{}
 
 	for _, server := range servers {
-		mcpTarget := api.MCPTarget{
-			Name: server.Name,
+		if server.CanaryWeight > 0 {
+			canaries = append(canaries, server)
+			continue
 		}
-
-		switch server.MCPServerType {
-		case api.MCPServerTypeRemote:
-			mcpTarget.SSE = &api.SSETargetSpec{
-				Host: server.Remote.Host,
-				Port: server.Remote.Port,
-				Path: server.Remote.Path,
-			}
-		case api.MCPServerTypeLocal:
-			switch server.Local.TransportType {
-			case api.TransportTypeStdio:
-				mcpTarget.Stdio = &api.StdioTargetSpec{
-					Cmd:  server.Local.Deployment.Cmd,
-					Args: server.Local.Deployment.Args,
-					Env:  server.Local.Deployment.Env,
-				}
-			case api.TransportTypeHTTP:
-				httpTransportConfig := server.Local.HTTP
-				if httpTransportConfig == nil || httpTransportConfig.Port == 0 {
-					return nil, fmt.Errorf("HTTP transport requires a target port")
-				}
-				mcpTarget.SSE = &api.SSETargetSpec{
-					Host: server.Name,
-					Port: httpTransportConfig.Port,
-					Path: httpTransportConfig.Path,
-				}
-			default:
-				return nil, fmt.Errorf("unsupported transport type: %s", server.Local.TransportType)
-			}
+		mcpTarget, err := translateMCPTarget(server)
+		if err != nil {
+			return nil, err
 		}
-
+		stable[server.Name] = true
 		targets = append(targets, mcpTarget)
 	}
 
+	// A canary whose stable version is gone serves all of the traffic
+	var canaryTargets []weightedTarget
+	for _, server := range canaries {
+		mcpTarget, err := translateMCPTarget(server)
+		if err != nil {
+			return nil, err
+		}
+		if !stable[server.Name] {
+			targets = append(targets, mcpTarget)
+			continue
+		}
+		canaryTargets = append(canaryTargets, weightedTarget{target: mcpTarget, weight: server.CanaryWeight})
+	}
+
 	// create route for each agent
 	var agentRoutes []api.LocalRoute
 	for _, agent := range agents {
@@ -289,6 +398,13 @@ func (t *agentGatewayTranslator) translateAgentGatewayConfig(servers []*api.MCPS
 			},
 		}},
 	}
+	if len(canaryTargets) > 0 {
+		backends, err := canaryBackends(targets, canaryTargets)
+		if err != nil {
+			return nil, err
+		}
+		mcpRoute.Backends = backends
+	}
 
 	var allRoutes []api.LocalRoute
 	if len(targets) > 0 {
@@ -312,3 +428,84 @@ func (t *agentGatewayTranslator) translateAgentGatewayConfig(servers []*api.MCPS
 		},
 	}, nil
 }
+
+// translateMCPTarget returns the gateway target that proxies to or runs an MCP server
+func translateMCPTarget(server *api.MCPServer) (api.MCPTarget, error) {
+	mcpTarget := api.MCPTarget{
+		Name: server.Name,
+	}
+
+	switch server.MCPServerType {
+	case api.MCPServerTypeRemote:
+		mcpTarget.SSE = &api.SSETargetSpec{
+			Host: server.Remote.Host,
+			Port: server.Remote.Port,
+			Path: server.Remote.Path,
+		}
+	case api.MCPServerTypeLocal:
+		switch server.Local.TransportType {
+		case api.TransportTypeStdio:
+			mcpTarget.Stdio = &api.StdioTargetSpec{
+				Cmd:  server.Local.Deployment.Cmd,
+				Args: server.Local.Deployment.Args,
+				Env:  server.Local.Deployment.Env,
+			}
+		case api.TransportTypeHTTP:
+			httpTransportConfig := server.Local.HTTP
+			if httpTransportConfig == nil || httpTransportConfig.Port == 0 {
+				return api.MCPTarget{}, fmt.Errorf("HTTP transport requires a target port")
+			}
+			mcpTarget.SSE = &api.SSETargetSpec{
+				Host: server.ServiceName(),
+				Port: httpTransportConfig.Port,
+				Path: httpTransportConfig.Path,
+			}
+		default:
+			return api.MCPTarget{}, fmt.Errorf("unsupported transport type: %s", server.Local.TransportType)
+		}
+	}
+	return mcpTarget, nil
+}
+
+// weightedTarget is the gateway target of a canary with its share of traffic
+type weightedTarget struct {
+	target api.MCPTarget
+	weight int
+}
+
+// canaryBackends splits the MCP route between the stable targets and one backend per canary, in which the canary
+// replaces the stable version of its server. Each server gets exactly its canary's share of requests on the new
+// version, since all other backends serve its stable version.
+func canaryBackends(targets []api.MCPTarget, canaries []weightedTarget) ([]api.RouteBackend, error) {
+	slices.SortStableFunc(canaries, func(a, b weightedTarget) int {
+		return cmp.Compare(a.target.Name, b.target.Name)
+	})
+
+	stableWeight := 100
+	var backends []api.RouteBackend
+	for _, canary := range canaries {
+		stableWeight -= canary.weight
+		canaryTargets := slices.Clone(targets)
+		for i := range canaryTargets {
+			if canaryTargets[i].Name == canary.target.Name {
+				canaryTargets[i] = canary.target
+			}
+		}
+		backends = append(backends, api.RouteBackend{
+			Weight: canary.weight,
+			MCP: &api.MCPBackend{
+				Targets: canaryTargets,
+			},
+		})
+	}
+	if stableWeight <= 0 {
+		return nil, fmt.Errorf("canary weights add up to %d%%, leaving no traffic for stable versions", 100-stableWeight)
+	}
+
+	return append([]api.RouteBackend{{
+		Weight: stableWeight,
+		MCP: &api.MCPBackend{
+			Targets: targets,
+		},
+	}}, backends...), nil
+}
@@ -2,6 +2,7 @@ package dockercompose
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
@@ -111,6 +112,10 @@ func TestTranslateAgentGatewayService(t *testing.T) {
 			if service.Volumes[0].Target != "/config" {
 				t.Errorf("expected volume target /config, got %s", service.Volumes[0].Target)
 			}
+
+			if service.HealthCheck == nil {
+				t.Error("expected the gateway to have a healthcheck")
+			}
 		})
 	}
 }
@@ -161,6 +166,72 @@ func TestTranslateMCPServerToServiceConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "http server probes its health path",
+			server: &api.MCPServer{
+				Name:          "test-server",
+				MCPServerType: api.MCPServerTypeLocal,
+				Local: &api.LocalMCPServer{
+					Deployment: api.MCPServerDeployment{
+						Image: "example/server:latest",
+					},
+					TransportType: api.TransportTypeHTTP,
+					HTTP: &api.HTTPTransport{
+						Port:       3000,
+						Path:       "/mcp",
+						HealthPath: "/healthz",
+					},
+				},
+			},
+			checkFunc: func(t *testing.T, service *types.ServiceConfig) {
+				if service.HealthCheck == nil {
+					t.Fatal("expected a healthcheck")
+				}
+				if len(service.HealthCheck.Test) != 2 || service.HealthCheck.Test[0] != "CMD-SHELL" {
+					t.Fatalf("unexpected healthcheck test %v", service.HealthCheck.Test)
+				}
+				if !strings.Contains(service.HealthCheck.Test[1], "http://localhost:3000/healthz") {
+					t.Errorf("expected the healthcheck to probe the health path, got %s", service.HealthCheck.Test[1])
+				}
+			},
+		},
+		{
+			name: "server with resources",
+			server: &api.MCPServer{
+				Name:          "test-server",
+				MCPServerType: api.MCPServerTypeLocal,
+				Local: &api.LocalMCPServer{
+					Deployment: api.MCPServerDeployment{
+						Image: "node:latest",
+						Cmd:   "npx",
+						Resources: &api.Resources{
+							CPULimit:      0.5,
+							MemoryLimit:   512 * 1024 * 1024,
+							RestartPolicy: "unless-stopped",
+						},
+					},
+					TransportType: api.TransportTypeStdio,
+				},
+			},
+			checkFunc: func(t *testing.T, service *types.ServiceConfig) {
+				if service.Restart != "unless-stopped" {
+					t.Errorf("expected restart unless-stopped, got %q", service.Restart)
+				}
+				if service.Deploy == nil || service.Deploy.Resources.Limits == nil {
+					t.Fatal("expected deploy.resources.limits to be set")
+				}
+				limits := service.Deploy.Resources.Limits
+				if float64(limits.NanoCPUs) != 0.5 {
+					t.Errorf("expected 0.5 CPUs, got %v", limits.NanoCPUs)
+				}
+				if limits.MemoryBytes != 512*1024*1024 {
+					t.Errorf("expected 512MiB memory limit, got %d", limits.MemoryBytes)
+				}
+				if service.Deploy.Resources.Reservations != nil {
+					t.Error("expected no reservations")
+				}
+			},
+		},
 		{
 			name: "missing image",
 			server: &api.MCPServer{
@@ -232,6 +303,29 @@ func TestTranslateAgentToServiceConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "agent with replicas",
+			agent: &api.Agent{
+				Name: "scaled-agent",
+				Deployment: api.AgentDeployment{
+					Image:     "test-image:v1",
+					Port:      9000,
+					Resources: &api.Resources{Replicas: 3},
+				},
+			},
+			expectError: false,
+			checkFunc: func(t *testing.T, service *types.ServiceConfig) {
+				if len(service.Ports) != 0 {
+					t.Errorf("expected no published ports for replicas, got %d", len(service.Ports))
+				}
+				if service.Deploy == nil || service.Deploy.Replicas == nil || *service.Deploy.Replicas != 3 {
+					t.Fatalf("expected deploy.replicas 3, got %+v", service.Deploy)
+				}
+				if service.Deploy.Resources.Limits != nil {
+					t.Error("expected no resource limits")
+				}
+			},
+		},
 		{
 			name: "agent without image",
 			agent: &api.Agent{
@@ -766,6 +860,162 @@ func TestTranslateAgentGatewayConfig_UnsupportedTransportType(t *testing.T) {
 	}
 }
 
+func TestTranslateAgentGatewayConfig_Canary(t *testing.T) {
+	translator := &agentGatewayTranslator{
+		composeWorkingDir: "/tmp/test",
+		agentGatewayPort:  8080,
+		projectName:       "test-project",
+	}
+
+	httpServer := func(name, image string, weight int) *api.MCPServer {
+		return &api.MCPServer{
+			Name:          name,
+			MCPServerType: api.MCPServerTypeLocal,
+			CanaryWeight:  weight,
+			Local: &api.LocalMCPServer{
+				Deployment:    api.MCPServerDeployment{Image: image},
+				TransportType: api.TransportTypeHTTP,
+				HTTP:          &api.HTTPTransport{Port: 3000, Path: "/mcp"},
+			},
+		}
+	}
+	servers := []*api.MCPServer{
+		httpServer("weather", "weather:1", 0),
+		httpServer("weather", "weather:2", 10),
+		httpServer("search", "search:1", 0),
+	}
+
+	config, err := translator.translateAgentGatewayConfig(servers, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	backends := config.Binds[0].Listeners[0].Routes[0].Backends
+	if len(backends) != 2 {
+		t.Fatalf("expected a stable and a canary backend, got %d", len(backends))
+	}
+	if backends[0].Weight != 90 || backends[1].Weight != 10 {
+		t.Errorf("expected a 90/10 split, got %d/%d", backends[0].Weight, backends[1].Weight)
+	}
+	hosts := func(b api.RouteBackend) map[string]string {
+		m := map[string]string{}
+		for _, target := range b.MCP.Targets {
+			m[target.Name] = target.SSE.Host
+		}
+		return m
+	}
+	if got := hosts(backends[0]); got["weather"] != "weather" || got["search"] != "search" {
+		t.Errorf("unexpected stable targets: %v", got)
+	}
+	if got := hosts(backends[1]); got["weather"] != "weather"+api.CanaryServiceSuffix || got["search"] != "search" {
+		t.Errorf("unexpected canary targets: %v", got)
+	}
+
+	// The canary runs as its own compose service next to the stable version
+	runtimeConfig, err := translator.TranslateRuntimeConfig(context.Background(), &api.DesiredState{MCPServers: servers})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if service, ok := runtimeConfig.Local.DockerCompose.Services["weather"+api.CanaryServiceSuffix]; !ok || service.Image != "weather:2" {
+		t.Errorf("expected a canary service running weather:2, got %+v", service)
+	}
+
+	servers = append(servers, httpServer("search", "search:2", 95))
+	if _, err := translator.translateAgentGatewayConfig(servers, nil); err == nil {
+		t.Error("expected an error for canary weights over 100%")
+	}
+}
+
+func TestTranslateRuntimeConfig_RestrictEgress(t *testing.T) {
+	translator := &agentGatewayTranslator{composeWorkingDir: "/tmp/test", agentGatewayPort: 8080}
+	restricted := &api.MCPServer{
+		Name:          "weather",
+		MCPServerType: api.MCPServerTypeLocal,
+		Local: &api.LocalMCPServer{
+			Deployment:    api.MCPServerDeployment{Image: "weather:1", Cmd: "serve"},
+			TransportType: api.TransportTypeHTTP,
+			HTTP:          &api.HTTPTransport{Port: 3000, Path: "/mcp"},
+			Egress:        &api.EgressPolicy{AllowedHosts: []string{"api.weather.example", "registry.npmjs.org"}},
+		},
+	}
+	unrestricted := &api.MCPServer{
+		Name:          "search",
+		MCPServerType: api.MCPServerTypeLocal,
+		Local: &api.LocalMCPServer{
+			Deployment:    api.MCPServerDeployment{Image: "search:1", Cmd: "serve"},
+			TransportType: api.TransportTypeHTTP,
+			HTTP:          &api.HTTPTransport{Port: 3001},
+		},
+	}
+	agent := &api.Agent{Name: "planner", Version: "1.0.0", Deployment: api.AgentDeployment{Image: "planner:1", Port: 9000}}
+
+	config, err := translator.TranslateRuntimeConfig(context.Background(), &api.DesiredState{
+		MCPServers: []*api.MCPServer{restricted, unrestricted},
+		Agents:     []*api.Agent{agent},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	project := config.Local.DockerCompose
+	network := egressNetworkName("weather")
+
+	if n, ok := project.Networks[network]; !ok || !n.Internal {
+		t.Fatalf("expected internal network %s, got %+v", network, project.Networks)
+	}
+	server := project.Services["weather"]
+	if _, ok := server.Networks[network]; !ok || len(server.Networks) != 1 {
+		t.Errorf("expected the server to only join %s, got %v", network, server.Networks)
+	}
+	proxyName := "weather" + EgressProxySuffix
+	if proxy := server.Environment["HTTPS_PROXY"]; proxy == nil || *proxy != "http://"+proxyName+":3128" {
+		t.Errorf("expected HTTPS_PROXY to point at the egress proxy, got %v", proxy)
+	}
+
+	proxy, ok := project.Services[proxyName]
+	if !ok {
+		t.Fatalf("expected an egress proxy service %s", proxyName)
+	}
+	if _, ok := proxy.Networks["default"]; !ok {
+		t.Errorf("expected the proxy to join the default network, got %v", proxy.Networks)
+	}
+	if _, ok := proxy.Networks[network]; !ok {
+		t.Errorf("expected the proxy to join %s, got %v", network, proxy.Networks)
+	}
+	squid := project.Configs[proxyName].Content
+	if !strings.Contains(squid, "acl allowed_hosts dstdomain api.weather.example registry.npmjs.org\n") {
+		t.Errorf("expected the allowlist in the proxy config, got:\n%s", squid)
+	}
+
+	// The gateway and agents reach the server on its network; unrestricted servers keep the default network
+	for _, name := range []string{"agent_gateway", "planner"} {
+		if _, ok := project.Services[name].Networks[network]; !ok {
+			t.Errorf("expected %s to join %s, got %v", name, network, project.Services[name].Networks)
+		}
+	}
+	if networks := project.Services["search"].Networks; networks != nil {
+		t.Errorf("expected the unrestricted server to keep the default network, got %v", networks)
+	}
+
+	stdio := &api.MCPServer{
+		Name:          "files",
+		MCPServerType: api.MCPServerTypeLocal,
+		Local: &api.LocalMCPServer{
+			Deployment:    api.MCPServerDeployment{Cmd: "npx"},
+			TransportType: api.TransportTypeStdio,
+			Egress:        &api.EgressPolicy{},
+		},
+	}
+	if _, err := translator.TranslateRuntimeConfig(context.Background(), &api.DesiredState{MCPServers: []*api.MCPServer{stdio}}); err == nil {
+		t.Error("expected an error restricting the egress of a stdio server")
+	}
+}
+
+func TestSquidConfigDeniesWithoutAllowlist(t *testing.T) {
+	squid := squidConfig(nil)
+	if strings.Contains(squid, "http_access allow") || !strings.Contains(squid, "http_access deny all\n") {
+		t.Errorf("expected every request to be denied, got:\n%s", squid)
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -0,0 +1,97 @@
+package dockercompose
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/compose-spec/compose-go/v2/types"
+)
+
+// EgressProxyImage runs the forward proxy that enforces the egress allowlist of a server
+const EgressProxyImage = "ubuntu/squid:5.2-22.04_beta"
+
+const egressProxyPort = 3128
+
+// EgressProxySuffix is appended to the service of a server to name the proxy of its egress
+const EgressProxySuffix = "-egress-proxy"
+
+// egressNetworkName returns the internal network a server with restricted egress is attached to
+func egressNetworkName(service string) string {
+	return service + "_egress"
+}
+
+// restrictEgress moves the service of a server onto an internal network, which has no route out of the docker host.
+// Its only way out is a forward proxy on both that network and the default one, which allows the hosts of the
+// policy and denies everything else. The proxy service is returned, and its network and config are added to
+// networks and configs.
+func restrictEgress(
+	service *types.ServiceConfig,
+	policy *api.EgressPolicy,
+	networks types.Networks,
+	configs types.Configs,
+) *types.ServiceConfig {
+	network := egressNetworkName(service.Name)
+	proxyName := service.Name + EgressProxySuffix
+	networks[network] = types.NetworkConfig{Internal: true}
+	configs[proxyName] = types.ConfigObjConfig{Content: squidConfig(policy.AllowedHosts)}
+
+	service.Networks = map[string]*types.ServiceNetworkConfig{network: nil}
+	if service.Environment == nil {
+		service.Environment = types.MappingWithEquals{}
+	}
+	proxyURL := fmt.Sprintf("http://%s:%d", proxyName, egressProxyPort)
+	noProxy := "localhost,127.0.0.1"
+	for key, value := range map[string]string{
+		"HTTP_PROXY":  proxyURL,
+		"HTTPS_PROXY": proxyURL,
+		"NO_PROXY":    noProxy,
+	} {
+		// Runtimes disagree on the case they read, so both are set
+		service.Environment[key] = &value
+		service.Environment[strings.ToLower(key)] = &value
+	}
+
+	return &types.ServiceConfig{
+		Name:  proxyName,
+		Image: EgressProxyImage,
+		Configs: []types.ServiceConfigObjConfig{{
+			Source: proxyName,
+			Target: "/etc/squid/squid.conf",
+		}},
+		Networks: map[string]*types.ServiceNetworkConfig{
+			"default": nil,
+			network:   nil,
+		},
+		Restart: "unless-stopped",
+	}
+}
+
+// squidConfig renders a squid config that proxies plain and CONNECT requests to ports 80 and 443 of the allowed
+// hosts only. An empty allowlist denies every request.
+func squidConfig(allowedHosts []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "http_port %d\n", egressProxyPort)
+	b.WriteString("acl safe_ports port 80 443\n")
+	b.WriteString("http_access deny !safe_ports\n")
+	if len(allowedHosts) > 0 {
+		fmt.Fprintf(&b, "acl allowed_hosts dstdomain %s\n", strings.Join(allowedHosts, " "))
+		b.WriteString("http_access allow allowed_hosts\n")
+	}
+	b.WriteString("http_access deny all\n")
+	b.WriteString("cache deny all\n")
+	b.WriteString("access_log stdio:/dev/stdout\n")
+	return b.String()
+}
+
+// joinEgressNetworks attaches a service to the internal networks of the servers with restricted egress, so it can
+// still reach them, in addition to the default network
+func joinEgressNetworks(service *types.ServiceConfig, networks types.Networks) {
+	if len(networks) == 0 {
+		return
+	}
+	service.Networks = map[string]*types.ServiceNetworkConfig{"default": nil}
+	for name := range networks {
+		service.Networks[name] = nil
+	}
+}
@@ -0,0 +1,82 @@
+package kagent
+
+import (
+	"strings"
+
+	api "github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// EgressAllowedHostsAnnotation lists the hosts of the egress allowlist of a server on its NetworkPolicy, for CNI
+// plugins that can filter egress by host name
+const EgressAllowedHostsAnnotation = "aregistry.ai/egress-allowed-hosts"
+
+// privateCIDRs are the cluster, private, loopback and link-local ranges, which include cloud metadata services
+var privateCIDRs = map[string][]string{
+	"0.0.0.0/0": {"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16"},
+	"::/0":      {"fc00::/7", "fe80::/10", "::1/128"},
+}
+
+// translateEgressPolicy restricts the egress of the pods kmcp runs for a server. NetworkPolicies match addresses
+// rather than host names, so the policy allows DNS and HTTP(S) to public addresses only, which keeps the server out
+// of the cluster and private networks; the allowed hosts are recorded in EgressAllowedHostsAnnotation. An empty
+// allowlist denies all egress but DNS.
+func translateEgressPolicy(policy *api.EgressPolicy, mcpServer *kmcpv1alpha1.MCPServer) *networkingv1.NetworkPolicy {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	port := func(protocol *corev1.Protocol, n int32) networkingv1.NetworkPolicyPort {
+		p := intstr.FromInt32(n)
+		return networkingv1.NetworkPolicyPort{Protocol: protocol, Port: &p}
+	}
+
+	rules := []networkingv1.NetworkPolicyEgressRule{{
+		Ports: []networkingv1.NetworkPolicyPort{port(&udp, 53), port(&tcp, 53)},
+	}}
+	if len(policy.AllowedHosts) > 0 {
+		rule := networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{port(&tcp, 443), port(&tcp, 80)},
+		}
+		for _, cidr := range []string{"0.0.0.0/0", "::/0"} {
+			rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr, Except: privateCIDRs[cidr]},
+			})
+		}
+		rules = append(rules, rule)
+	}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      EgressPolicyName(mcpServer.Name),
+			Namespace: mcpServer.Namespace,
+			Labels: map[string]string{
+				"aregistry.ai/managed": "true",
+			},
+			Annotations: map[string]string{
+				EgressAllowedHostsAnnotation: strings.Join(policy.AllowedHosts, ","),
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			// kmcp labels the pods of a server with the name of its MCPServer
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     mcpServer.Name,
+					"app.kubernetes.io/instance": mcpServer.Name,
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      rules,
+		},
+	}
+}
+
+// EgressPolicyName returns the name of the NetworkPolicy restricting the egress of a server
+func EgressPolicyName(name string) string {
+	return sanitizeK8sName(name + "-egress")
+}
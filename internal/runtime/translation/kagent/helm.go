@@ -0,0 +1,149 @@
+package kagent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	api "github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"go.yaml.in/yaml/v3"
+)
+
+// helmTemplate renders one translated object from its entry in values.yaml. The entry can disable the object,
+// move it to another namespace (the release namespace when empty) and override its spec or data.
+const helmTemplate = `{{- $values := index .Values.%[1]s %[2]q }}
+{{- if $values.enabled }}
+apiVersion: %[3]s
+kind: %[4]s
+metadata:
+  name: %[2]s
+  namespace: {{ $values.namespace | default .Release.Namespace }}
+  {{- with $values.labels }}
+  labels:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
+  {{- with $values.annotations }}
+  annotations:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
+%[5]s:
+  {{- toYaml $values.%[5]s | nindent 2 }}
+{{- end }}
+`
+
+// TranslateHelmChart packages a translated Kubernetes runtime config as a Helm chart instead of raw CRs. Every
+// object becomes a template whose namespace, labels, annotations and spec (data for config maps) come from
+// values.yaml, keyed by object kind and name:
+//
+//	agents:
+//	  weather-agent:
+//	    enabled: true
+//	    namespace: kagent
+//	    spec: {...}
+//
+// The returned files are keyed by path relative to the chart directory.
+func TranslateHelmChart(cfg *api.KubernetesRuntimeConfig, chartName, chartVersion string) (map[string][]byte, error) {
+	if chartName == "" {
+		return nil, fmt.Errorf("chart name is required")
+	}
+	if chartVersion == "" {
+		chartVersion = "0.1.0"
+	}
+
+	values := map[string]map[string]any{
+		"configMaps":       {},
+		"agents":           {},
+		"remoteMCPServers": {},
+		"mcpServers":       {},
+	}
+	files := map[string][]byte{}
+
+	addObject := func(valuesKey, bodyField string, obj any) error {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		var fields struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Metadata   struct {
+				Name        string            `json:"name"`
+				Namespace   string            `json:"namespace"`
+				Labels      map[string]string `json:"labels"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+			Spec any `json:"spec"`
+			Data any `json:"data"`
+		}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		if fields.APIVersion == "" || fields.Kind == "" {
+			return fmt.Errorf("%s %s has no apiVersion or kind", valuesKey, fields.Metadata.Name)
+		}
+
+		entry := map[string]any{
+			"enabled":   true,
+			"namespace": fields.Metadata.Namespace,
+		}
+		if len(fields.Metadata.Labels) > 0 {
+			entry["labels"] = fields.Metadata.Labels
+		}
+		if len(fields.Metadata.Annotations) > 0 {
+			entry["annotations"] = fields.Metadata.Annotations
+		}
+		if bodyField == "data" {
+			entry["data"] = fields.Data
+		} else {
+			entry["spec"] = fields.Spec
+		}
+		values[valuesKey][fields.Metadata.Name] = entry
+
+		templatePath := fmt.Sprintf("templates/%s-%s.yaml", strings.ToLower(fields.Kind), fields.Metadata.Name)
+		files[templatePath] = fmt.Appendf(nil, helmTemplate, valuesKey, fields.Metadata.Name, fields.APIVersion, fields.Kind, bodyField)
+		return nil
+	}
+
+	if cfg != nil {
+		for _, obj := range cfg.ConfigMaps {
+			if err := addObject("configMaps", "data", obj); err != nil {
+				return nil, fmt.Errorf("ConfigMap %s: %w", obj.Name, err)
+			}
+		}
+		for _, obj := range cfg.Agents {
+			if err := addObject("agents", "spec", obj); err != nil {
+				return nil, fmt.Errorf("agent %s: %w", obj.Name, err)
+			}
+		}
+		for _, obj := range cfg.RemoteMCPServers {
+			if err := addObject("remoteMCPServers", "spec", obj); err != nil {
+				return nil, fmt.Errorf("remote MCP server %s: %w", obj.Name, err)
+			}
+		}
+		for _, obj := range cfg.MCPServers {
+			if err := addObject("mcpServers", "spec", obj); err != nil {
+				return nil, fmt.Errorf("MCP server %s: %w", obj.Name, err)
+			}
+		}
+	}
+
+	valuesYaml, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal helm values: %w", err)
+	}
+	files["values.yaml"] = valuesYaml
+
+	chartYaml, err := yaml.Marshal(map[string]any{
+		"apiVersion":  "v2",
+		"name":        chartName,
+		"description": "Agents and MCP servers deployed by agentregistry",
+		"type":        "application",
+		"version":     chartVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Chart.yaml: %w", err)
+	}
+	files["Chart.yaml"] = chartYaml
+
+	return files, nil
+}
@@ -0,0 +1,65 @@
+package kagent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestTranslateHelmChart(t *testing.T) {
+	config, err := NewTranslator().TranslateRuntimeConfig(context.Background(), &api.DesiredState{
+		Agents: []*api.Agent{
+			{
+				Name:    "test-agent",
+				Version: "v1",
+				Deployment: api.AgentDeployment{
+					Image: "agent-image:latest",
+					Env:   map[string]string{"ENV_VAR": "value"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+
+	files, err := TranslateHelmChart(config.Kubernetes, "agentregistry-kubernetes", "")
+	if err != nil {
+		t.Fatalf("TranslateHelmChart failed: %v", err)
+	}
+
+	var chart map[string]any
+	if err := yaml.Unmarshal(files["Chart.yaml"], &chart); err != nil {
+		t.Fatalf("failed to parse Chart.yaml: %v", err)
+	}
+	if chart["name"] != "agentregistry-kubernetes" || chart["version"] != "0.1.0" || chart["apiVersion"] != "v2" {
+		t.Errorf("unexpected Chart.yaml: %v", chart)
+	}
+
+	var values map[string]map[string]map[string]any
+	if err := yaml.Unmarshal(files["values.yaml"], &values); err != nil {
+		t.Fatalf("failed to parse values.yaml: %v", err)
+	}
+	agent, ok := values["agents"]["test-agent"]
+	if !ok {
+		t.Fatalf("expected values for agent test-agent, got %v", values["agents"])
+	}
+	if agent["enabled"] != true || agent["namespace"] != DefaultNamespace || agent["spec"] == nil {
+		t.Errorf("unexpected agent values: %v", agent)
+	}
+
+	template := string(files["templates/agent-test-agent.yaml"])
+	for _, want := range []string{
+		`{{- $values := index .Values.agents "test-agent" }}`,
+		"kind: Agent",
+		"name: test-agent",
+		"{{- toYaml $values.spec | nindent 2 }}",
+	} {
+		if !strings.Contains(template, want) {
+			t.Errorf("expected template to contain %q, got:\n%s", want, template)
+		}
+	}
+}
@@ -11,6 +11,8 @@ import (
 	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,6 +27,15 @@ func NewTranslator() api.RuntimeTranslator {
 	return &translator{defaultNamespace: DefaultNamespace}
 }
 
+// NewTranslatorWithNamespace returns a kagent translator that places resources without an explicit
+// KAGENT_NAMESPACE in namespace instead of DefaultNamespace.
+func NewTranslatorWithNamespace(namespace string) api.RuntimeTranslator {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	return &translator{defaultNamespace: namespace}
+}
+
 // TranslateRuntimeConfig translates the desired state into a Kubernetes runtime config supported by Kagent.
 // This handles agent, local and remote MCP servers.
 func (t *translator) TranslateRuntimeConfig(
@@ -53,7 +64,11 @@ func (t *translator) TranslateRuntimeConfig(
 
 	remoteMCPs := make([]*v1alpha2.RemoteMCPServer, 0)
 	mcpServers := make([]*kmcpv1alpha1.MCPServer, 0)
+	var networkPolicies []*networkingv1.NetworkPolicy
 	for _, server := range desired.MCPServers {
+		if server.CanaryWeight > 0 {
+			return nil, fmt.Errorf("MCP server %s: canary rollouts need the agent gateway of the local runtime", server.Name)
+		}
 		switch server.MCPServerType {
 		case api.MCPServerTypeRemote:
 			if server.Remote == nil {
@@ -73,6 +88,9 @@ func (t *translator) TranslateRuntimeConfig(
 				return nil, err
 			}
 			mcpServers = append(mcpServers, resource)
+			if server.Local.Egress != nil {
+				networkPolicies = append(networkPolicies, translateEgressPolicy(server.Local.Egress, resource))
+			}
 		}
 	}
 
@@ -83,6 +101,7 @@ func (t *translator) TranslateRuntimeConfig(
 			RemoteMCPServers: remoteMCPs,
 			MCPServers:       mcpServers,
 			ConfigMaps:       configMaps,
+			NetworkPolicies:  networkPolicies,
 		},
 	}, nil
 }
@@ -115,7 +134,12 @@ func (t *translator) translateAgent(agent *api.Agent) (*v1alpha2.Agent, error) {
 
 	// Build SharedDeploymentSpec with optional ConfigMap volume mount for resolved MCP servers
 	sharedSpec := v1alpha2.SharedDeploymentSpec{
-		Env: envVars,
+		Env:       envVars,
+		Resources: translateResources(agent.Deployment.Resources),
+	}
+	if res := agent.Deployment.Resources; res != nil && res.Replicas > 0 {
+		replicas := int32(res.Replicas)
+		sharedSpec.Replicas = &replicas
 	}
 
 	// If agent has resolved MCP servers, add ConfigMap volume mount
@@ -223,6 +247,7 @@ func (t *translator) translateLocalMCPServer(server *api.MCPServer) (*kmcpv1alph
 			namespace = ns
 		}
 	}
+	// Resources and replicas are not translated for MCP servers: kmcp renders and owns the server Deployment
 	deployment := kmcpv1alpha1.MCPServerDeployment{
 		Image: server.Local.Deployment.Image,
 		Cmd:   server.Local.Deployment.Cmd,
@@ -306,6 +331,39 @@ func (t *translator) translateAgentConfigMap(agent *api.Agent) (*corev1.ConfigMa
 	}, nil
 }
 
+// translateResources converts resources into Kubernetes requests and limits. The restart policy is not
+// translated since Deployments always restart their pods.
+func translateResources(res *api.Resources) *corev1.ResourceRequirements {
+	if res == nil {
+		return nil
+	}
+	limits := corev1.ResourceList{}
+	requests := corev1.ResourceList{}
+	if res.CPULimit > 0 {
+		limits[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(res.CPULimit*1000), resource.DecimalSI)
+	}
+	if res.MemoryLimit > 0 {
+		limits[corev1.ResourceMemory] = *resource.NewQuantity(res.MemoryLimit, resource.BinarySI)
+	}
+	if res.CPURequest > 0 {
+		requests[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(res.CPURequest*1000), resource.DecimalSI)
+	}
+	if res.MemoryRequest > 0 {
+		requests[corev1.ResourceMemory] = *resource.NewQuantity(res.MemoryRequest, resource.BinarySI)
+	}
+	if len(limits) == 0 && len(requests) == 0 {
+		return nil
+	}
+	requirements := &corev1.ResourceRequirements{}
+	if len(limits) > 0 {
+		requirements.Limits = limits
+	}
+	if len(requests) > 0 {
+		requirements.Requests = requests
+	}
+	return requirements
+}
+
 // AgentConfigMapName returns the ConfigMap name for an agent
 func AgentConfigMapName(name, version string) string {
 	base := fmt.Sprintf("%s-mcp-config", name)
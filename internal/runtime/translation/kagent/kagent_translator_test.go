@@ -55,6 +55,54 @@ func TestTranslateRuntimeConfig_AgentOnly(t *testing.T) {
 	}
 }
 
+func TestTranslateRuntimeConfig_AgentResources(t *testing.T) {
+	translator := NewTranslator()
+
+	desired := &api.DesiredState{
+		Agents: []*api.Agent{
+			{
+				Name:    "test-agent",
+				Version: "v1",
+				Deployment: api.AgentDeployment{
+					Image: "agent-image:latest",
+					Resources: &api.Resources{
+						CPULimit:      1,
+						CPURequest:    0.25,
+						MemoryLimit:   1024 * 1024 * 1024,
+						RestartPolicy: "always",
+						Replicas:      2,
+					},
+				},
+			},
+		},
+	}
+
+	config, err := translator.TranslateRuntimeConfig(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+
+	resources := config.Kubernetes.Agents[0].Spec.BYO.Deployment.Resources
+	if resources == nil {
+		t.Fatal("Expected resources to be set")
+	}
+	if got := resources.Limits.Cpu().String(); got != "1" {
+		t.Errorf("Expected CPU limit 1, got %s", got)
+	}
+	if got := resources.Requests.Cpu().String(); got != "250m" {
+		t.Errorf("Expected CPU request 250m, got %s", got)
+	}
+	if got := resources.Limits.Memory().String(); got != "1Gi" {
+		t.Errorf("Expected memory limit 1Gi, got %s", got)
+	}
+	if _, ok := resources.Requests["memory"]; ok {
+		t.Error("Expected no memory request")
+	}
+	if replicas := config.Kubernetes.Agents[0].Spec.BYO.Deployment.Replicas; replicas == nil || *replicas != 2 {
+		t.Errorf("Expected 2 replicas, got %v", replicas)
+	}
+}
+
 func TestTranslateRuntimeConfig_RemoteMCP(t *testing.T) {
 	translator := NewTranslator()
 	ctx := context.Background()
@@ -141,6 +189,68 @@ func TestTranslateRuntimeConfig_LocalMCP(t *testing.T) {
 	}
 }
 
+func TestTranslateRuntimeConfig_LocalMCPEgress(t *testing.T) {
+	translator := NewTranslator()
+	server := func(name string, egress *api.EgressPolicy) *api.MCPServer {
+		return &api.MCPServer{
+			Name:          name,
+			MCPServerType: api.MCPServerTypeLocal,
+			Namespace:     "tools",
+			Local: &api.LocalMCPServer{
+				TransportType: api.TransportTypeStdio,
+				Deployment:    api.MCPServerDeployment{Image: "node:24", Cmd: "npx"},
+				Egress:        egress,
+			},
+		}
+	}
+
+	config, err := translator.TranslateRuntimeConfig(context.Background(), &api.DesiredState{
+		MCPServers: []*api.MCPServer{
+			server("weather", &api.EgressPolicy{AllowedHosts: []string{"api.weather.example", "registry.npmjs.org"}}),
+			server("search", nil),
+		},
+	})
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+
+	if len(config.Kubernetes.NetworkPolicies) != 1 {
+		t.Fatalf("Expected 1 NetworkPolicy, got %d", len(config.Kubernetes.NetworkPolicies))
+	}
+	policy := config.Kubernetes.NetworkPolicies[0]
+	if policy.Name != "weather-egress" || policy.Namespace != "tools" {
+		t.Errorf("Expected NetworkPolicy tools/weather-egress, got %s/%s", policy.Namespace, policy.Name)
+	}
+	if got := policy.Spec.PodSelector.MatchLabels["app.kubernetes.io/name"]; got != "weather" {
+		t.Errorf("Expected the policy to select the pods of weather, got %q", got)
+	}
+	if got := policy.Annotations[EgressAllowedHostsAnnotation]; got != "api.weather.example,registry.npmjs.org" {
+		t.Errorf("Expected the allowed hosts annotation, got %q", got)
+	}
+	if len(policy.Spec.PolicyTypes) != 1 || policy.Spec.PolicyTypes[0] != "Egress" {
+		t.Errorf("Expected an egress only policy, got %v", policy.Spec.PolicyTypes)
+	}
+	if len(policy.Spec.Egress) != 2 {
+		t.Fatalf("Expected DNS and HTTP(S) egress rules, got %d", len(policy.Spec.Egress))
+	}
+	for _, peer := range policy.Spec.Egress[1].To {
+		if peer.IPBlock == nil || len(peer.IPBlock.Except) == 0 {
+			t.Errorf("Expected HTTP(S) egress to exclude private ranges, got %+v", peer)
+		}
+	}
+
+	// Without allowed hosts only DNS is left
+	config, err = translator.TranslateRuntimeConfig(context.Background(), &api.DesiredState{
+		MCPServers: []*api.MCPServer{server("weather", &api.EgressPolicy{})},
+	})
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+	if rules := config.Kubernetes.NetworkPolicies[0].Spec.Egress; len(rules) != 1 {
+		t.Errorf("Expected only the DNS rule, got %d rules", len(rules))
+	}
+}
+
 func TestTranslateRuntimeConfig_AgentWithMCPServers(t *testing.T) {
 	translator := NewTranslator()
 	ctx := context.Background()
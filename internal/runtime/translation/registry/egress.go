@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// defaultPackageRegistryHosts are the hosts a package runner downloads packages of a registry type from when the
+// package does not set registryBaseUrl. OCI images are pulled by the container engine, not the server, so they
+// need none.
+var defaultPackageRegistryHosts = map[string][]string{
+	model.RegistryTypeNPM:   {"registry.npmjs.org"},
+	model.RegistryTypePyPI:  {"pypi.org", "files.pythonhosted.org"},
+	model.RegistryTypeNuGet: {"api.nuget.org"},
+}
+
+// egressHosts returns the hosts a server run from pkg may connect to: the hosts of the remotes its manifest declares
+// and those of the registry its package is downloaded from. Remote URLs whose host is templated are skipped, since
+// the host is only known to the deployer.
+func egressHosts(server *apiv0.ServerJSON, pkg model.Package) []string {
+	var hosts []string
+	for _, remote := range server.Remotes {
+		if host := urlHost(remote.URL); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	registryType := strings.ToLower(pkg.RegistryType)
+	switch {
+	case pkg.RegistryBaseURL != "":
+		if host := urlHost(pkg.RegistryBaseURL); host != "" {
+			hosts = append(hosts, host)
+		}
+	case registryType == model.RegistryTypeMCPB:
+		// The identifier of an MCPB package is the URL of its bundle
+		if host := urlHost(pkg.Identifier); host != "" {
+			hosts = append(hosts, host)
+		}
+	default:
+		hosts = append(hosts, defaultPackageRegistryHosts[registryType]...)
+	}
+
+	slices.Sort(hosts)
+	return slices.Compact(hosts)
+}
+
+// urlHost returns the lowercased host of a URL, or "" when it cannot be parsed or its host is templated
+func urlHost(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	host := u.Hostname()
+	if host == "" || strings.ContainsAny(host, "{}") {
+		return ""
+	}
+	return strings.ToLower(host)
+}
@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestEgressHosts(t *testing.T) {
+	remotes := []model.Transport{
+		{Type: "streamable-http", URL: "https://API.example.com/mcp"},
+		{Type: "sse", URL: "https://api.example.com/sse"},
+		{Type: "streamable-http", URL: "https://{tenant}.example.com/mcp"},
+	}
+
+	tests := []struct {
+		name string
+		pkg  model.Package
+		want []string
+	}{
+		{
+			name: "npm default registry",
+			pkg:  model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "@example/server"},
+			want: []string{"api.example.com", "registry.npmjs.org"},
+		},
+		{
+			name: "pypi default registry",
+			pkg:  model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "example-server"},
+			want: []string{"api.example.com", "files.pythonhosted.org", "pypi.org"},
+		},
+		{
+			name: "custom registry",
+			pkg:  model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "@example/server", RegistryBaseURL: "https://npm.example.org"},
+			want: []string{"api.example.com", "npm.example.org"},
+		},
+		{
+			name: "mcpb bundle",
+			pkg:  model.Package{RegistryType: model.RegistryTypeMCPB, Identifier: "https://github.com/example/server/releases/download/v1/server.mcpb"},
+			want: []string{"api.example.com", "github.com"},
+		},
+		{
+			name: "oci image",
+			pkg:  model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/example/server:1.0.0"},
+			want: []string{"api.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &apiv0.ServerJSON{Name: "com.example/server", Remotes: remotes}
+			if got := egressHosts(server, tt.pkg); !slices.Equal(got, tt.want) {
+				t.Errorf("egressHosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslateMCPServerRestrictEgress(t *testing.T) {
+	server := &apiv0.ServerJSON{
+		Name: "com.example/server",
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/server",
+			Transport:    model.Transport{Type: "stdio"},
+		}},
+	}
+
+	for value, restricted := range map[string]bool{"true": true, "false": false, "": false} {
+		resourceValues := map[string]string{}
+		if value != "" {
+			resourceValues[ResourceRestrictEgress] = value
+		}
+		mcpServer, err := NewTranslator().TranslateMCPServer(context.Background(), &MCPServerRunRequest{
+			RegistryServer: server,
+			EnvValues:      map[string]string{},
+			ArgValues:      map[string]string{},
+			ResourceValues: resourceValues,
+		})
+		if err != nil {
+			t.Fatalf("TranslateMCPServer failed: %v", err)
+		}
+		egress := mcpServer.Local.Egress
+		if (egress != nil) != restricted {
+			t.Fatalf("RESTRICT_EGRESS=%q: expected restricted %v, got egress %+v", value, restricted, egress)
+		}
+		if restricted && !slices.Equal(egress.AllowedHosts, []string{"registry.npmjs.org"}) {
+			t.Errorf("expected the npm registry to be allowed, got %v", egress.AllowedHosts)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package registry
+
+import (
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// PublisherHealthKey is the publisher-provided _meta key where a server.json can declare the HTTP path its
+// container healthcheck probes, instead of the MCP endpoint:
+//
+//	"_meta": {"io.modelcontextprotocol.registry/publisher-provided": {"aregistry.ai/health": {"path": "/healthz"}}}
+const PublisherHealthKey = "aregistry.ai/health"
+
+func publisherHealthPath(server *apiv0.ServerJSON) string {
+	if server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return ""
+	}
+	declared, ok := server.Meta.PublisherProvided[PublisherHealthKey].(map[string]any)
+	if !ok {
+		return ""
+	}
+	path, _ := declared["path"].(string)
+	path = strings.TrimSpace(path)
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
@@ -24,11 +24,18 @@ type MCPServerRunRequest struct {
 	EnvValues      map[string]string
 	ArgValues      map[string]string
 	HeaderValues   map[string]string
+	// ResourceValues holds the deployer's resource settings, keyed without ResourceConfigPrefix
+	ResourceValues map[string]string
+	// CanaryWeight is the percentage of traffic routed to this version while it is rolled out next to the
+	// stable version of the server; zero for stable deployments
+	CanaryWeight int
 }
 
 type AgentRunRequest struct {
 	RegistryAgent *models.AgentJSON
 	EnvValues     map[string]string
+	// ResourceValues holds the deployer's resource settings, keyed without ResourceConfigPrefix
+	ResourceValues map[string]string
 	// Registry-type MCP servers resolved from agent manifest at deploy time to inject into the agent
 	ResolvedMCPServers []*MCPServerRunRequest
 }
@@ -75,6 +82,11 @@ func (t *registryTranslator) TranslateAgent(
 	env["MODEL_PROVIDER"] = manifest.ModelProvider
 	env["MODEL_NAME"] = manifest.ModelName
 
+	resources, err := ParseResources(req.ResourceValues)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resources for agent %s: %w", req.RegistryAgent.Name, err)
+	}
+
 	port, err := utils.FindAvailablePort()
 	if err != nil {
 		return nil, fmt.Errorf("failed to find available port: %w", err)
@@ -83,9 +95,10 @@ func (t *registryTranslator) TranslateAgent(
 		Name:    req.RegistryAgent.Name,
 		Version: req.RegistryAgent.Version,
 		Deployment: api.AgentDeployment{
-			Image: req.RegistryAgent.Image,
-			Port:  port,
-			Env:   env,
+			Image:     req.RegistryAgent.Image,
+			Port:      port,
+			Env:       env,
+			Resources: resources,
 		},
 	}, nil
 }
@@ -97,23 +110,31 @@ func (t *registryTranslator) TranslateMCPServer(
 	useRemote := len(req.RegistryServer.Remotes) > 0 && (req.PreferRemote || len(req.RegistryServer.Packages) == 0)
 	usePackage := len(req.RegistryServer.Packages) > 0 && (!req.PreferRemote || len(req.RegistryServer.Remotes) == 0)
 
+	var server *api.MCPServer
+	var err error
 	switch {
 	case useRemote:
-		return translateRemoteMCPServer(
+		server, err = translateRemoteMCPServer(
 			ctx,
 			req.RegistryServer,
 			req.HeaderValues,
 		)
 	case usePackage:
-		return translateLocalMCPServer(
+		server, err = translateLocalMCPServer(
 			ctx,
 			req.RegistryServer,
 			req.EnvValues,
 			req.ArgValues,
+			req.ResourceValues,
 		)
+	default:
+		return nil, fmt.Errorf("no valid deployment method found for server: %s", req.RegistryServer.Name)
 	}
-
-	return nil, fmt.Errorf("no valid deployment method found for server: %s", req.RegistryServer.Name)
+	if err != nil {
+		return nil, err
+	}
+	server.CanaryWeight = req.CanaryWeight
+	return server, nil
 }
 
 func translateRemoteMCPServer(
@@ -159,7 +180,13 @@ func translateLocalMCPServer(
 	registryServer *apiv0.ServerJSON,
 	envValues map[string]string,
 	argValues map[string]string,
+	resourceValues map[string]string,
 ) (*api.MCPServer, error) {
+	resources, err := serverResources(registryServer, resourceValues)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resources for server %s: %w", registryServer.Name, err)
+	}
+
 	// deploy the server either as stdio or http
 	packageInfo := registryServer.Packages[0]
 
@@ -232,23 +259,31 @@ func translateLocalMCPServer(
 			return nil, fmt.Errorf("failed to parse transport url: %v", err)
 		}
 		httpTransport = &api.HTTPTransport{
-			Port: u.port,
-			Path: u.path,
+			Port:       u.port,
+			Path:       u.path,
+			HealthPath: publisherHealthPath(registryServer),
 		}
 	}
 
+	var egress *api.EgressPolicy
+	if resources != nil && resources.RestrictEgress {
+		egress = &api.EgressPolicy{AllowedHosts: egressHosts(registryServer, packageInfo)}
+	}
+
 	return &api.MCPServer{
 		Name:          GenerateInternalName(registryServer.Name),
 		MCPServerType: api.MCPServerTypeLocal,
 		Local: &api.LocalMCPServer{
 			Deployment: api.MCPServerDeployment{
-				Image: config.Image,
-				Cmd:   config.Command,
-				Args:  args,
-				Env:   envValues,
+				Image:     config.Image,
+				Cmd:       config.Command,
+				Args:      args,
+				Env:       envValues,
+				Resources: resources,
 			},
 			TransportType: transportType,
 			HTTP:          httpTransport,
+			Egress:        egress,
 		},
 	}, nil
 }
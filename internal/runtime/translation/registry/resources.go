@@ -0,0 +1,202 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ResourceConfigPrefix marks deployment config keys that set resource limits instead of environment variables,
+// the same way ARG_ and HEADER_ mark arguments and headers
+const ResourceConfigPrefix = models.ConfigResourcePrefix
+
+// Resource keys, used after ResourceConfigPrefix in deployment config (RESOURCE_CPU_LIMIT=0.5)
+const (
+	ResourceCPULimit      = "CPU_LIMIT"
+	ResourceMemoryLimit   = "MEMORY_LIMIT"
+	ResourceCPURequest    = "CPU_REQUEST"
+	ResourceMemoryRequest = "MEMORY_REQUEST"
+	ResourceRestartPolicy = "RESTART_POLICY"
+	ResourceReplicas      = "REPLICAS"
+	// ResourceRestrictEgress limits the outbound connections of the server to the hosts of its remotes and
+	// package registry (RESOURCE_RESTRICT_EGRESS=true)
+	ResourceRestrictEgress = "RESTRICT_EGRESS"
+)
+
+// MaxReplicas bounds the replica count of a deployment
+const MaxReplicas = 50
+
+// PublisherResourcesKey is the publisher-provided _meta key where a server.json can declare default resources:
+//
+//	"_meta": {"io.modelcontextprotocol.registry/publisher-provided": {"aregistry.ai/resources": {"cpuLimit": "0.5", "memoryLimit": "512Mi"}}}
+const PublisherResourcesKey = "aregistry.ai/resources"
+
+var publisherResourceKeys = map[string]string{
+	"cpuLimit":      ResourceCPULimit,
+	"memoryLimit":   ResourceMemoryLimit,
+	"cpuRequest":    ResourceCPURequest,
+	"memoryRequest": ResourceMemoryRequest,
+	"restartPolicy": ResourceRestartPolicy,
+	"replicas":      ResourceReplicas,
+}
+
+var restartPolicies = []string{"no", "always", "on-failure", "unless-stopped"}
+
+// ValidateResourceConfig checks the resource keys of a deployment config, so bad values are rejected when the
+// deployment is created rather than on every reconcile
+func ValidateResourceConfig(config map[string]string) error {
+	_, err := ParseResources(ExtractResourceValues(config))
+	return err
+}
+
+// ExtractResourceValues returns the resource keys of a deployment config with ResourceConfigPrefix removed
+func ExtractResourceValues(config map[string]string) map[string]string {
+	values := make(map[string]string)
+	for k, v := range config {
+		if name, ok := strings.CutPrefix(k, ResourceConfigPrefix); ok && name != "" {
+			values[name] = v
+		}
+	}
+	return values
+}
+
+// ParseResources parses resource values keyed by the Resource* names. It returns nil when no value is set.
+// CPU accepts cores ("0.5") or millicores ("500m"); memory accepts Kubernetes ("512Mi") or Docker ("512m") units.
+func ParseResources(values map[string]string) (*api.Resources, error) {
+	res := &api.Resources{}
+	set := false
+	for key, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		var err error
+		switch key {
+		case ResourceCPULimit:
+			res.CPULimit, err = parseCPU(value)
+		case ResourceCPURequest:
+			res.CPURequest, err = parseCPU(value)
+		case ResourceMemoryLimit:
+			res.MemoryLimit, err = parseMemory(value)
+		case ResourceMemoryRequest:
+			res.MemoryRequest, err = parseMemory(value)
+		case ResourceRestartPolicy:
+			if !slices.Contains(restartPolicies, value) {
+				err = fmt.Errorf("must be one of %s", strings.Join(restartPolicies, ", "))
+			}
+			res.RestartPolicy = value
+		case ResourceReplicas:
+			res.Replicas, err = parseReplicas(value)
+		case ResourceRestrictEgress:
+			if res.RestrictEgress, err = strconv.ParseBool(value); err != nil {
+				err = fmt.Errorf("must be true or false")
+			}
+		default:
+			return nil, fmt.Errorf("unknown resource setting %s%s", ResourceConfigPrefix, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s%s %q: %w", ResourceConfigPrefix, key, value, err)
+		}
+		set = true
+	}
+	if !set {
+		return nil, nil
+	}
+	if res.CPULimit > 0 && res.CPURequest > res.CPULimit {
+		return nil, fmt.Errorf("CPU request %g exceeds CPU limit %g", res.CPURequest, res.CPULimit)
+	}
+	if res.MemoryLimit > 0 && res.MemoryRequest > res.MemoryLimit {
+		return nil, fmt.Errorf("memory request %d exceeds memory limit %d bytes", res.MemoryRequest, res.MemoryLimit)
+	}
+	return res, nil
+}
+
+// DesiredReplicas returns the replica count a deployment config asks for; one when unset or invalid
+func DesiredReplicas(config map[string]string) int {
+	value, ok := config[ResourceConfigPrefix+ResourceReplicas]
+	if !ok {
+		return 1
+	}
+	replicas, err := parseReplicas(strings.TrimSpace(value))
+	if err != nil {
+		return 1
+	}
+	return replicas
+}
+
+// serverResources combines the publisher defaults of a server with the deployer's values, which take precedence
+func serverResources(server *apiv0.ServerJSON, values map[string]string) (*api.Resources, error) {
+	merged := publisherResourceValues(server)
+	for k, v := range values {
+		merged[k] = v
+	}
+	return ParseResources(merged)
+}
+
+func publisherResourceValues(server *apiv0.ServerJSON) map[string]string {
+	values := make(map[string]string)
+	if server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return values
+	}
+	declared, ok := server.Meta.PublisherProvided[PublisherResourcesKey].(map[string]any)
+	if !ok {
+		return values
+	}
+	for field, key := range publisherResourceKeys {
+		if v, ok := declared[field]; ok {
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return values
+}
+
+func parseReplicas(value string) (int, error) {
+	replicas, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("must be a whole number")
+	}
+	if replicas < 1 || replicas > MaxReplicas {
+		return 0, fmt.Errorf("must be between 1 and %d", MaxReplicas)
+	}
+	return replicas, nil
+}
+
+func parseCPU(value string) (float64, error) {
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+	cores := float64(q.MilliValue()) / 1000
+	if cores <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return cores, nil
+}
+
+// parseMemory converts Docker style lowercase units (512m, 1g) to their binary Kubernetes equivalent
+// before parsing, since "512m" would otherwise mean 0.512 bytes
+func parseMemory(value string) (int64, error) {
+	value = strings.TrimSuffix(value, "b")
+	for docker, k8s := range map[string]string{"k": "Ki", "m": "Mi", "g": "Gi"} {
+		if strings.HasSuffix(value, docker) {
+			value = strings.TrimSuffix(value, docker) + k8s
+			break
+		}
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+	bytes := q.Value()
+	if bytes <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return bytes, nil
+}
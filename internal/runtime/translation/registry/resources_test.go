@@ -0,0 +1,101 @@
+package registry
+
+import (
+	"testing"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestParseResources(t *testing.T) {
+	res, err := ParseResources(map[string]string{
+		ResourceCPULimit:       "500m",
+		ResourceMemoryLimit:    "512m",
+		ResourceMemoryRequest:  "256Mi",
+		ResourceRestartPolicy:  "on-failure",
+		ResourceReplicas:       "3",
+		ResourceRestrictEgress: "true",
+	})
+	if err != nil {
+		t.Fatalf("ParseResources failed: %v", err)
+	}
+	if res.CPULimit != 0.5 {
+		t.Errorf("expected CPU limit 0.5, got %v", res.CPULimit)
+	}
+	if res.MemoryLimit != 512*1024*1024 {
+		t.Errorf("expected Docker style 512m to mean 512MiB, got %d", res.MemoryLimit)
+	}
+	if res.MemoryRequest != 256*1024*1024 {
+		t.Errorf("expected memory request 256MiB, got %d", res.MemoryRequest)
+	}
+	if res.RestartPolicy != "on-failure" {
+		t.Errorf("expected restart policy on-failure, got %s", res.RestartPolicy)
+	}
+	if res.Replicas != 3 {
+		t.Errorf("expected 3 replicas, got %d", res.Replicas)
+	}
+	if !res.RestrictEgress {
+		t.Error("expected egress to be restricted")
+	}
+
+	res, err = ParseResources(nil)
+	if err != nil || res != nil {
+		t.Errorf("expected nil resources without values, got %v, %v", res, err)
+	}
+
+	for name, values := range map[string]map[string]string{
+		"bad cpu":             {ResourceCPULimit: "lots"},
+		"negative memory":     {ResourceMemoryLimit: "-1g"},
+		"bad restart policy":  {ResourceRestartPolicy: "sometimes"},
+		"unknown key":         {"GPU_LIMIT": "1"},
+		"request over limit":  {ResourceCPULimit: "1", ResourceCPURequest: "2"},
+		"zero replicas":       {ResourceReplicas: "0"},
+		"too many replicas":   {ResourceReplicas: "1000"},
+		"fractional replica":  {ResourceReplicas: "1.5"},
+		"bad restrict egress": {ResourceRestrictEgress: "sometimes"},
+	} {
+		if _, err := ParseResources(values); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestDesiredReplicas(t *testing.T) {
+	for config, want := range map[string]int{
+		"":    1,
+		"4":   4,
+		"abc": 1,
+	} {
+		values := map[string]string{}
+		if config != "" {
+			values[ResourceConfigPrefix+ResourceReplicas] = config
+		}
+		if got := DesiredReplicas(values); got != want {
+			t.Errorf("DesiredReplicas(%q) = %d, want %d", config, got, want)
+		}
+	}
+}
+
+func TestServerResourcesPublisherDefaults(t *testing.T) {
+	server := &apiv0.ServerJSON{
+		Name: "com.example/server",
+		Meta: &apiv0.ServerMeta{
+			PublisherProvided: map[string]any{
+				PublisherResourcesKey: map[string]any{
+					"cpuLimit":    "1",
+					"memoryLimit": "1Gi",
+				},
+			},
+		},
+	}
+
+	res, err := serverResources(server, map[string]string{ResourceCPULimit: "0.5"})
+	if err != nil {
+		t.Fatalf("serverResources failed: %v", err)
+	}
+	if res.CPULimit != 0.5 {
+		t.Errorf("expected the deployer's CPU limit to win, got %v", res.CPULimit)
+	}
+	if res.MemoryLimit != 1024*1024*1024 {
+		t.Errorf("expected the publisher's memory limit, got %d", res.MemoryLimit)
+	}
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/secrets"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Kinds of configuration a server declares
+const (
+	ConfigKindEnv    = "env"
+	ConfigKindArg    = "arg"
+	ConfigKindHeader = "header"
+)
+
+// ConfigRequirement is an input a server.json declares for the way the server is deployed: an environment variable
+// or argument of its first package, or a header of its first remote
+type ConfigRequirement struct {
+	Kind string
+	Name string
+	model.Input
+}
+
+// Key returns the key of the requirement in a flat deployment config
+func (r ConfigRequirement) Key() string {
+	switch r.Kind {
+	case ConfigKindArg:
+		return models.ConfigArgPrefix + r.Name
+	case ConfigKindHeader:
+		return models.ConfigHeaderPrefix + r.Name
+	default:
+		return r.Name
+	}
+}
+
+// NeedsValue reports whether the deployer has to provide the value: the input is required and the server.json sets
+// neither a value nor a default
+func (r ConfigRequirement) NeedsValue() bool {
+	return r.IsRequired && r.Value == "" && r.Default == ""
+}
+
+// Validate checks a value against the declared choices and format
+func (r ConfigRequirement) Validate(value string) error {
+	if len(r.Choices) > 0 && !slices.Contains(r.Choices, value) {
+		return fmt.Errorf("%s %s must be one of %s", r.Kind, r.Name, strings.Join(r.Choices, ", "))
+	}
+	switch r.Format {
+	case model.FormatNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s %s must be a number", r.Kind, r.Name)
+		}
+	case model.FormatBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s %s must be true or false", r.Kind, r.Name)
+		}
+	}
+	return nil
+}
+
+// ConfigRequirements returns the inputs declared for the way a server is deployed, following the choice between
+// its first remote and its first package that the translator makes
+func ConfigRequirements(server *apiv0.ServerJSON, preferRemote bool) []ConfigRequirement {
+	var reqs []ConfigRequirement
+	useRemote := len(server.Remotes) > 0 && (preferRemote || len(server.Packages) == 0)
+	if useRemote {
+		for _, h := range server.Remotes[0].Headers {
+			reqs = append(reqs, ConfigRequirement{Kind: ConfigKindHeader, Name: h.Name, Input: h.Input})
+		}
+		return reqs
+	}
+	if len(server.Packages) == 0 {
+		return nil
+	}
+
+	pkg := server.Packages[0]
+	for _, env := range pkg.EnvironmentVariables {
+		reqs = append(reqs, ConfigRequirement{Kind: ConfigKindEnv, Name: env.Name, Input: env.Input})
+	}
+	for _, arg := range slices.Concat(pkg.RuntimeArguments, pkg.PackageArguments) {
+		// Arguments are configured by name; unnamed positional arguments cannot be set
+		if arg.Name == "" {
+			continue
+		}
+		reqs = append(reqs, ConfigRequirement{Kind: ConfigKindArg, Name: arg.Name, Input: arg.Input})
+	}
+	return reqs
+}
+
+// CheckConfig validates the values config sets for the requirements and returns the requirements that need a value
+// config does not set
+func CheckConfig(reqs []ConfigRequirement, config map[string]string) (missing []ConfigRequirement, err error) {
+	var invalid []string
+	for _, req := range reqs {
+		value, ok := config[req.Key()]
+		if !ok || value == "" {
+			if req.NeedsValue() {
+				missing = append(missing, req)
+			}
+			continue
+		}
+		// Secret references are only resolved when the deployment is reconciled
+		if secrets.IsReference(value) {
+			continue
+		}
+		if err := req.Validate(value); err != nil {
+			invalid = append(invalid, err.Error())
+		}
+	}
+	if len(invalid) > 0 {
+		return missing, fmt.Errorf("invalid configuration: %s", strings.Join(invalid, "; "))
+	}
+	return missing, nil
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConfig(t *testing.T) {
+	required := func(name string, input model.Input) model.KeyValueInput {
+		input.IsRequired = true
+		return model.KeyValueInput{Name: name, InputWithVariables: model.InputWithVariables{Input: input}}
+	}
+	server := &apiv0.ServerJSON{
+		Packages: []model.Package{{
+			EnvironmentVariables: []model.KeyValueInput{
+				required("API_KEY", model.Input{IsSecret: true}),
+				required("REGION", model.Input{Choices: []string{"eu", "us"}}),
+				required("TIMEOUT", model.Input{Format: model.FormatNumber, Default: "30"}),
+			},
+			PackageArguments: []model.Argument{{
+				Name:               "--port",
+				Type:               model.ArgumentTypeNamed,
+				InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, Format: model.FormatNumber}},
+			}},
+		}},
+		Remotes: []model.Transport{{Type: "streamable-http", URL: "https://example.com/mcp"}},
+	}
+
+	reqs := ConfigRequirements(server, false)
+	require.Len(t, reqs, 4)
+	assert.Equal(t, "ARG_--port", reqs[3].Key())
+
+	// Values with a default are not missing
+	missing, err := CheckConfig(reqs, map[string]string{"REGION": "eu"})
+	require.NoError(t, err)
+	var names []string
+	for _, req := range missing {
+		names = append(names, req.Name)
+	}
+	assert.Equal(t, []string{"API_KEY", "--port"}, names)
+
+	_, err = CheckConfig(reqs, map[string]string{"REGION": "asia", "ARG_--port": "http"})
+	assert.ErrorContains(t, err, "env REGION must be one of eu, us")
+	assert.ErrorContains(t, err, "arg --port must be a number")
+
+	// Secret references are not validated
+	missing, err = CheckConfig(reqs, map[string]string{"API_KEY": "x", "REGION": "env://REGION", "ARG_--port": "8080"})
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+
+	// Preferring the remote checks its headers instead
+	assert.Empty(t, ConfigRequirements(server, true))
+}
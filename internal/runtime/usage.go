@@ -0,0 +1,324 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.yaml.in/yaml/v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// dockerRuntimeDirs returns the compose project directories of the built-in local target and of the configured docker
+// targets that were deployed to
+func dockerRuntimeDirs(baseDir string, targets Targets) []runtimeDirectory {
+	dirs := []runtimeDirectory{{target: "local", dir: baseDir}}
+	for _, t := range targets.List() {
+		if t.Type != TargetTypeDocker || t.Name == "local" {
+			continue
+		}
+		dir := t.RuntimeDir(baseDir)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, runtimeDirectory{target: t.Name, dir: dir, host: t.Host})
+		}
+	}
+	return dirs
+}
+
+// DiskUsage reports the size of the runtime directory and of the images and volumes of the compose projects of the
+// docker targets. Images and volumes of a docker host that cannot be read are reported as warnings.
+func DiskUsage(ctx context.Context, baseDir string, targets Targets) *models.SystemUsage {
+	usage := &models.SystemUsage{Images: []models.ImageUsage{}, Volumes: []models.VolumeUsage{}}
+	if baseDir == "" {
+		return usage
+	}
+	usage.RuntimeDirBytes = dirSize(baseDir)
+
+	byHost := map[string][]runtimeDirectory{}
+	var hosts []string
+	for _, d := range dockerRuntimeDirs(filepath.Clean(baseDir), targets) {
+		if _, ok := byHost[d.host]; !ok {
+			hosts = append(hosts, d.host)
+		}
+		byHost[d.host] = append(byHost[d.host], d)
+	}
+
+	for _, host := range hosts {
+		dirs := byHost[host]
+		referenced := map[string]bool{}
+		projects := map[string]string{}
+		for _, d := range dirs {
+			path := filepath.Join(d.dir, "docker-compose.yaml")
+			for _, image := range composeFileImages(path) {
+				referenced[image] = true
+			}
+			if name := composeProjectName(path); name != "" {
+				projects[name] = d.target
+			}
+		}
+
+		seen := map[string]bool{}
+		for _, d := range dirs {
+			ledger, err := readImageLedger(d.dir)
+			if err != nil {
+				usage.Warnings = append(usage.Warnings, err.Error())
+				continue
+			}
+			for _, image := range append(ledger, composeFileImages(filepath.Join(d.dir, "docker-compose.yaml"))...) {
+				if seen[image] {
+					continue
+				}
+				seen[image] = true
+				if size, found := imageSize(ctx, host, image); found {
+					usage.Images = append(usage.Images, models.ImageUsage{Target: d.target, Name: image, Size: size, InUse: referenced[image]})
+				}
+			}
+		}
+
+		if len(projects) == 0 {
+			continue
+		}
+		volumes, err := composeVolumes(ctx, host, projects)
+		if err != nil {
+			usage.Warnings = append(usage.Warnings, fmt.Sprintf("failed to read the volumes of target %s: %v", dirs[0].target, err))
+			continue
+		}
+		usage.Volumes = append(usage.Volumes, volumes...)
+	}
+	return usage
+}
+
+// composeProjectName returns the project name a compose file declares, or "" when it cannot be read
+func composeProjectName(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var doc struct {
+		Name string `yaml:"name"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return ""
+	}
+	return doc.Name
+}
+
+// composeVolumes returns the volumes of a docker host that belong to the given compose projects, which are mapped to
+// the targets they belong to
+func composeVolumes(ctx context.Context, host string, projects map[string]string) ([]models.VolumeUsage, error) {
+	cmd := utils.ContainerCommand(ctx, "system", "df", "-v", "--format", "json")
+	if host != "" {
+		cmd.Env = append(os.Environ(), utils.ContainerHostEnv(host)...)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s system df: %w: %s", utils.ContainerEngine(), err, strings.TrimSpace(stderr.String()))
+	}
+	return parseSystemDFVolumes(out, projects)
+}
+
+// parseSystemDFVolumes parses the volumes of `docker system df -v --format json` and keeps those labeled with one of
+// the given compose projects
+func parseSystemDFVolumes(out []byte, projects map[string]string) ([]models.VolumeUsage, error) {
+	var df struct {
+		Volumes []struct {
+			Name   string `json:"Name"`
+			Labels string `json:"Labels"`
+			Links  any    `json:"Links"`
+			Size   string `json:"Size"`
+		} `json:"Volumes"`
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(out), &df); err != nil {
+		return nil, fmt.Errorf("failed to parse system df output: %w", err)
+	}
+
+	var volumes []models.VolumeUsage
+	for _, v := range df.Volumes {
+		target := ""
+		for _, label := range strings.Split(v.Labels, ",") {
+			if project, ok := strings.CutPrefix(label, "com.docker.compose.project="); ok {
+				target = projects[project]
+			}
+		}
+		if target == "" {
+			continue
+		}
+		size, _ := parseDockerSize(v.Size)
+		links := fmt.Sprint(v.Links)
+		volumes = append(volumes, models.VolumeUsage{Target: target, Name: v.Name, Size: size, InUse: links != "0" && links != "" && links != "<nil>"})
+	}
+	return volumes, nil
+}
+
+// LocalServiceMetrics samples the resource usage of the containers of a compose service of the runtime in runtimeDir
+// with docker stats. It returns no samples for a service the compose file does not declare, such as a stdio MCP
+// server run by the agent gateway.
+func LocalServiceMetrics(ctx context.Context, runtimeDir, dockerHost, service string) ([]models.ContainerMetrics, error) {
+	data, err := os.ReadFile(filepath.Join(runtimeDir, "docker-compose.yaml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker compose yaml: %w", err)
+	}
+	services, err := composeServices(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := services[service]; !ok {
+		return nil, nil
+	}
+
+	var env []string
+	if dockerHost != "" {
+		env = append(os.Environ(), utils.ContainerHostEnv(dockerHost)...)
+	}
+	ps := utils.ComposeCmd(ctx, "ps", "-q", service)
+	ps.Dir = runtimeDir
+	ps.Env = env
+	var stderr bytes.Buffer
+	ps.Stderr = &stderr
+	out, err := ps.Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker compose ps: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	ids := strings.Fields(string(out))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	stats := utils.ContainerCommand(ctx, append([]string{"stats", "--no-stream", "--format", "{{json .}}"}, ids...)...)
+	stats.Env = env
+	stderr.Reset()
+	stats.Stderr = &stderr
+	out, err = stats.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s stats: %w: %s", utils.ContainerEngine(), err, strings.TrimSpace(stderr.String()))
+	}
+	return parseDockerStats(out)
+}
+
+// parseDockerStats parses `docker stats --format "{{json .}}"`, one object per container
+func parseDockerStats(out []byte) ([]models.ContainerMetrics, error) {
+	var samples []models.ContainerMetrics
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var stat struct {
+			Name     string `json:"Name"`
+			CPUPerc  string `json:"CPUPerc"`
+			MemUsage string `json:"MemUsage"`
+		}
+		if err := json.Unmarshal(line, &stat); err != nil {
+			return nil, fmt.Errorf("failed to parse docker stats output: %w", err)
+		}
+
+		sample := models.ContainerMetrics{Name: stat.Name}
+		// docker reports CPU as a percentage of one core
+		if percent, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(stat.CPUPerc), "%"), 64); err == nil {
+			sample.CPUMillicores = int64(percent * 10)
+		}
+		used, limit, _ := strings.Cut(stat.MemUsage, "/")
+		sample.MemoryBytes, _ = parseDockerSize(used)
+		sample.MemoryLimitBytes, _ = parseDockerSize(limit)
+		samples = append(samples, sample)
+	}
+	return samples, scanner.Err()
+}
+
+var dockerSizeUnits = map[string]float64{
+	"b":  1,
+	"kb": 1e3, "mb": 1e6, "gb": 1e9, "tb": 1e12,
+	"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40,
+}
+
+// parseDockerSize parses a size as the docker CLI prints it, e.g. "12.5MiB" or "1.2GB"
+func parseDockerSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, unicode.IsLetter)
+	if i < 0 {
+		i = len(s)
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(s[:i]), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	unit := strings.ToLower(strings.TrimSpace(s[i:]))
+	if unit == "" {
+		unit = "b"
+	}
+	factor, ok := dockerSizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit in %q", s)
+	}
+	return int64(value * factor), nil
+}
+
+// podOfDeploymentRe matches the suffix the ReplicaSet of a Deployment appends to the names of its pods
+var podOfDeploymentRe = regexp.MustCompile(`^[a-z0-9]{1,10}-[a-z0-9]{5}$`)
+
+var podMetricsGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetricsList"}
+
+// KubernetesPodMetrics reads the resource usage of the pods of a workload from the metrics API, which requires
+// metrics-server in the cluster. Pods are matched by name, as the kagent controllers run a workload as a Deployment
+// of the same name.
+func KubernetesPodMetrics(ctx context.Context, kubeContext, namespace, workload string) ([]models.ContainerMetrics, error) {
+	c, err := KubeClientForContext(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(podMetricsGVK)
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to read pod metrics (is metrics-server installed?): %w", err)
+	}
+	return podMetrics(list.Items, workload), nil
+}
+
+// podMetrics sums the container usage of the PodMetrics of the pods of a workload
+func podMetrics(items []unstructured.Unstructured, workload string) []models.ContainerMetrics {
+	var samples []models.ContainerMetrics
+	for _, item := range items {
+		suffix, ok := strings.CutPrefix(item.GetName(), workload+"-")
+		if !ok || !podOfDeploymentRe.MatchString(suffix) {
+			continue
+		}
+		sample := models.ContainerMetrics{Name: item.GetName()}
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		for _, container := range containers {
+			m, _ := container.(map[string]any)
+			usage, _ := m["usage"].(map[string]any)
+			if cpu, ok := usage["cpu"].(string); ok {
+				if q, err := resource.ParseQuantity(cpu); err == nil {
+					sample.CPUMillicores += q.MilliValue()
+				}
+			}
+			if memory, ok := usage["memory"].(string); ok {
+				if q, err := resource.ParseQuantity(memory); err == nil {
+					sample.MemoryBytes += q.Value()
+				}
+			}
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
@@ -0,0 +1,90 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestParseDockerSize(t *testing.T) {
+	tests := map[string]int64{
+		"0B":      0,
+		"512B":    512,
+		"12.5MiB": 12.5 * (1 << 20),
+		"1.2GB":   1.2e9,
+		" 2 KiB ": 2048,
+		"1024":    1024,
+		"3.4kB":   3400,
+		"1.5TB":   1.5e12,
+	}
+	for in, want := range tests {
+		got, err := parseDockerSize(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := parseDockerSize("N/A")
+	assert.Error(t, err)
+	_, err = parseDockerSize("12XB")
+	assert.Error(t, err)
+}
+
+func TestParseDockerStats(t *testing.T) {
+	out := []byte(`{"Name":"weather-1","CPUPerc":"12.50%","MemUsage":"64MiB / 1GiB"}
+
+{"Name":"weather-2","CPUPerc":"0.00%","MemUsage":"1.5MiB / 0B"}
+`)
+	samples, err := parseDockerStats(out)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ContainerMetrics{
+		{Name: "weather-1", CPUMillicores: 125, MemoryBytes: 64 << 20, MemoryLimitBytes: 1 << 30},
+		{Name: "weather-2", MemoryBytes: 1.5 * (1 << 20)},
+	}, samples)
+
+	_, err = parseDockerStats([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestParseSystemDFVolumes(t *testing.T) {
+	out := []byte(`{"Images":[],"Volumes":[
+		{"Name":"agentregistry_data","Labels":"com.docker.compose.project=agentregistry,com.docker.compose.volume=data","Links":1,"Size":"10MB"},
+		{"Name":"edge_cache","Labels":"com.docker.compose.project=edge","Links":0,"Size":"1.5kB"},
+		{"Name":"unrelated","Labels":"com.docker.compose.project=other","Links":1,"Size":"1GB"},
+		{"Name":"anonymous","Labels":"","Links":0,"Size":"0B"}
+	]}`)
+	volumes, err := parseSystemDFVolumes(out, map[string]string{"agentregistry": "local", "edge": "edge"})
+	require.NoError(t, err)
+	assert.Equal(t, []models.VolumeUsage{
+		{Target: "local", Name: "agentregistry_data", Size: 10e6, InUse: true},
+		{Target: "edge", Name: "edge_cache", Size: 1500},
+	}, volumes)
+}
+
+func TestPodMetrics(t *testing.T) {
+	pod := func(name string, usages ...map[string]any) unstructured.Unstructured {
+		containers := make([]any, 0, len(usages))
+		for _, usage := range usages {
+			containers = append(containers, map[string]any{"name": "c", "usage": usage})
+		}
+		return unstructured.Unstructured{Object: map[string]any{
+			"metadata":   map[string]any{"name": name},
+			"containers": containers,
+		}}
+	}
+	items := []unstructured.Unstructured{
+		pod("weather-7d9f8c6b5-x2k4p",
+			map[string]any{"cpu": "250m", "memory": "64Mi"},
+			map[string]any{"cpu": "1500000n", "memory": "1Mi"}),
+		pod("weather-api-7d9f8c6b5-abcde", map[string]any{"cpu": "1", "memory": "1Gi"}),
+		pod("other-7d9f8c6b5-x2k4p", map[string]any{"cpu": "1", "memory": "1Gi"}),
+	}
+
+	samples := podMetrics(items, "weather")
+	assert.Equal(t, []models.ContainerMetrics{
+		{Name: "weather-7d9f8c6b5-x2k4p", CPUMillicores: 252, MemoryBytes: 65 << 20},
+	}, samples)
+}
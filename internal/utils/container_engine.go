@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ContainerEngineEnvVar selects the container engine arctl and the runtime shell out to. When it is unset docker
+// is used, or podman when only podman is installed.
+const ContainerEngineEnvVar = "ARCTL_CONTAINER_ENGINE"
+
+// Supported container engines
+const (
+	ContainerEngineDocker = "docker"
+	ContainerEnginePodman = "podman"
+)
+
+// dockerSocketPath is where the docker daemon listens, and where containers that manage containers expect the
+// engine socket to be mounted
+const dockerSocketPath = "/var/run/docker.sock"
+
+var (
+	composeCommandOnce sync.Once
+	composeCommand     []string
+)
+
+// ContainerEngine returns the container engine CLI to use: the one named by ARCTL_CONTAINER_ENGINE, otherwise
+// docker unless only podman is installed
+func ContainerEngine() string {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(ContainerEngineEnvVar))) {
+	case ContainerEnginePodman:
+		return ContainerEnginePodman
+	case ContainerEngineDocker:
+		return ContainerEngineDocker
+	}
+	if _, err := exec.LookPath(ContainerEngineDocker); err != nil {
+		if _, err := exec.LookPath(ContainerEnginePodman); err == nil {
+			return ContainerEnginePodman
+		}
+	}
+	return ContainerEngineDocker
+}
+
+// ValidateContainerEngine reports an ARCTL_CONTAINER_ENGINE value that is not a supported engine
+func ValidateContainerEngine() error {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv(ContainerEngineEnvVar)))
+	if value == "" || value == ContainerEngineDocker || value == ContainerEnginePodman {
+		return nil
+	}
+	return fmt.Errorf("%s=%q is not supported, expected %s or %s", ContainerEngineEnvVar, value, ContainerEngineDocker, ContainerEnginePodman)
+}
+
+// ContainerCommand returns a command running the container engine CLI with args, e.g. build, push or ps
+func ContainerCommand(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, ContainerEngine(), args...)
+}
+
+// ComposeCommand returns the compose invocation of the container engine: the compose plugin (docker compose,
+// podman compose) when it is available, otherwise the standalone binary (docker-compose, podman-compose)
+func ComposeCommand() []string {
+	composeCommandOnce.Do(func() {
+		engine := ContainerEngine()
+		if _, err := exec.LookPath(engine); err == nil {
+			if err := exec.Command(engine, "compose", "version").Run(); err == nil {
+				composeCommand = []string{engine, "compose"}
+				return
+			}
+		}
+		composeCommand = []string{engine + "-compose"}
+	})
+	return composeCommand
+}
+
+// ComposeCmd returns a command running compose with args
+func ComposeCmd(ctx context.Context, args ...string) *exec.Cmd {
+	compose := ComposeCommand()
+	return exec.CommandContext(ctx, compose[0], append(compose[1:], args...)...)
+}
+
+// ContainerHostEnv returns the environment that points the container engine at host, in DOCKER_HOST form
+// (ssh://user@host, tcp://host:2376). Podman reads CONTAINER_HOST; compose providers read DOCKER_HOST.
+func ContainerHostEnv(host string) []string {
+	env := []string{"DOCKER_HOST=" + host}
+	if ContainerEngine() == ContainerEnginePodman {
+		env = append(env, "CONTAINER_HOST="+host)
+	}
+	return env
+}
+
+// ContainerSocketPath returns the host path of the API socket of the container engine, which is mounted into
+// containers that manage containers. Rootless podman listens below XDG_RUNTIME_DIR, rootful podman in /run.
+func ContainerSocketPath() string {
+	if ContainerEngine() != ContainerEnginePodman {
+		return dockerSocketPath
+	}
+	if os.Geteuid() != 0 {
+		runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+		if runtimeDir == "" {
+			runtimeDir = fmt.Sprintf("/run/user/%d", os.Geteuid())
+		}
+		return filepath.Join(runtimeDir, "podman", "podman.sock")
+	}
+	return "/run/podman/podman.sock"
+}
+
+// WithContainerSocket points docker socket mounts of a compose file at the socket of the container engine
+func WithContainerSocket(composeYAML string) string {
+	socket := ContainerSocketPath()
+	if socket == dockerSocketPath {
+		return composeYAML
+	}
+	return strings.ReplaceAll(composeYAML, dockerSocketPath+":"+dockerSocketPath, socket+":"+dockerSocketPath)
+}
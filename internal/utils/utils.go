@@ -1,9 +1,9 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"net"
-	"os/exec"
 	"regexp"
 	"strings"
 )
@@ -66,8 +66,9 @@ func SanitizeVersion(version string) string {
 	return sanitized
 }
 
+// IsDockerComposeAvailable reports whether compose can be run with the container engine, docker or podman
 func IsDockerComposeAvailable() bool {
-	cmd := exec.Command("docker", "compose", "version")
+	cmd := ComposeCmd(context.Background(), "version")
 	_, err := cmd.CombinedOutput()
 	return err == nil
 }
@@ -1,20 +1,26 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent"
 	agentutils "github.com/agentregistry-dev/agentregistry/internal/cli/agent/utils"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/cliconfig"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/completion"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/configure"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/mcp"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/skill"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
 	"github.com/agentregistry-dev/agentregistry/internal/utils"
 	"github.com/agentregistry-dev/agentregistry/pkg/daemon"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -33,6 +39,15 @@ type CLIOptions struct {
 var cliOptions CLIOptions
 var registryURL string
 var registryToken string
+var outputFormat string
+var noHeaders bool
+var wideOutput bool
+var contextName string
+var workspaceName string
+var requestTimeout string
+
+// cliConfig is the arctl config file, loaded before every command
+var cliConfig = &cliconfig.Config{}
 
 const defaultRegistryPort = "12121"
 
@@ -46,18 +61,66 @@ var rootCmd = &cobra.Command{
 	Short: "Agent Registry CLI",
 	Long:  `arctl is a CLI tool for managing agents, MCP servers and skills.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+		if contextName != "" {
+			// --context selects a context for this command only; the config file is left unchanged
+			if err := cfg.UseContext(contextName); err != nil {
+				return err
+			}
+		}
+		applyConfigDefaults(cmd, cfg)
+		if err := configureOutput(); err != nil {
+			return err
+		}
+
+		// The config and context commands only edit the config file
+		if cmd.HasParent() && (cmd.Parent() == cli.ConfigCmd || cmd.Parent() == cli.ContextCmd) {
+			return nil
+		}
+		// Refresh talks to every configured registry with its own clients
+		if cmd == cli.RefreshCmd {
+			return nil
+		}
+
 		baseURL, token := resolveRegistryTarget()
 
+		// Shell completion must answer quickly and must not start the daemon; names are fetched with a client
+		// that skips the connectivity check and completion simply offers nothing when the registry is down
+		if isCompletionCmd(cmd) {
+			if token == "" && cliOptions.AuthnProvider == nil {
+				token = cli.StoredRegistryToken(cmd.Context(), baseURL)
+			}
+			completionClient := client.NewClient(baseURL, token)
+			completionClient.SetWorkspace(workspaceName)
+			completionClient.SetContext(cmd.Context())
+			completion.SetAPIClient(completionClient)
+			return nil
+		}
+
+		// Doctor diagnoses the environment the daemon needs, so it must not start the daemon or require the API
+		if cmd == cli.DoctorCmd {
+			cli.SetDoctorTarget(baseURL, token)
+			return nil
+		}
+
 		dm := cliOptions.DaemonManager
 		if dm == nil {
 			dm = daemon.NewDaemonManager(nil)
 		}
 
+		if err := utils.ValidateContainerEngine(); err != nil {
+			return err
+		}
+
 		if shouldAutoStartDaemon(baseURL) {
 			if !utils.IsDockerComposeAvailable() {
-				fmt.Println("Docker compose is not available. Please install docker compose and try again.")
+				fmt.Println("Docker compose is not available. Please install docker compose (or podman compose) and try again.")
 				fmt.Println("See https://docs.docker.com/compose/install/ for installation instructions.")
 				fmt.Println("agent registry uses docker compose to start the server and the agent gateway.")
+				fmt.Printf("Set %s=podman to use podman instead of docker.\n", utils.ContainerEngineEnvVar)
 				return fmt.Errorf("docker compose is not available")
 			}
 			if !dm.IsRunning() {
@@ -69,25 +132,39 @@ var rootCmd = &cobra.Command{
 
 		// Get authentication token if no token override was provided
 		if token == "" && cliOptions.AuthnProvider != nil {
-			var err error
 			token, err = cliOptions.AuthnProvider.Authenticate(cmd.Context())
 			if err != nil {
 				return fmt.Errorf("CLI authentication failed: %w", err)
 			}
 		}
 
+		// Fall back to the credentials stored by 'arctl login'. Login and logout manage
+		// those credentials themselves and must not trigger a refresh.
+		if token == "" && cliOptions.AuthnProvider == nil && cmd != cli.LoginCmd && cmd != cli.LogoutCmd {
+			token = cli.StoredRegistryToken(cmd.Context(), baseURL)
+		}
+
+		timeout, err := cliconfig.ParseRequestTimeout(requestTimeout)
+		if err != nil {
+			return err
+		}
+
 		// Check if local registry is running and create API client
 		c, err := client.NewClientWithConfig(baseURL, token)
 		if err != nil {
 			return fmt.Errorf("API client not initialized: %w", err)
 		}
 
+		c.SetWorkspace(workspaceName)
+		c.SetContext(cmd.Context())
+		c.SetTimeout(timeout)
 		APIClient = c
 		mcp.SetAPIClient(APIClient)
 		agent.SetAPIClient(APIClient)
 		agentutils.SetDefaultRegistryURL(APIClient.BaseURL)
 		skill.SetAPIClient(APIClient)
 		cli.SetAPIClient(APIClient)
+		completion.SetAPIClient(APIClient)
 		return nil
 	},
 }
@@ -98,16 +175,37 @@ var verbose bool
 
 func Execute() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "V", false, "Verbose output")
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := SignalContext()
+	defer stop()
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		stop()
 		os.Exit(1)
 	}
 }
 
+// SignalContext returns the context commands run with, canceled on the first Ctrl-C or SIGTERM so that requests in
+// flight are aborted and long operations stop between steps. A second Ctrl-C terminates the process right away.
+func SignalContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		// Restores the default handling of the signals
+		stop()
+	}()
+	return ctx, stop
+}
+
 func init() {
 	envBaseURL := os.Getenv("ARCTL_API_BASE_URL")
 	envToken := os.Getenv("ARCTL_API_TOKEN")
 	rootCmd.PersistentFlags().StringVar(&registryURL, "registry-url", envBaseURL, "Registry base URL (overrides ARCTL_API_BASE_URL; default http://localhost:12121)")
 	rootCmd.PersistentFlags().StringVar(&registryToken, "registry-token", envToken, "Registry bearer token (overrides ARCTL_API_TOKEN)")
+	rootCmd.PersistentFlags().StringVar(&contextName, "context", "", "Context of the config file to run the command against (default: the current context)")
+	rootCmd.PersistentFlags().StringVar(&workspaceName, "workspace", os.Getenv("ARCTL_WORKSPACE"), "Workspace to act in; new entries, deployments and tokens belong to it (overrides ARCTL_WORKSPACE)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, wide, json, yaml)")
+	rootCmd.PersistentFlags().BoolVar(&noHeaders, "no-headers", false, "Omit the header row of table output")
+	rootCmd.PersistentFlags().BoolVar(&wideOutput, "wide", false, "Show additional columns in table output (same as -o wide)")
+	rootCmd.PersistentFlags().StringVar(&requestTimeout, "request-timeout", envOrDefault("ARCTL_REQUEST_TIMEOUT", client.DefaultTimeout.String()), "Time limit of each registry API request, such as 30s or 2m (overrides ARCTL_REQUEST_TIMEOUT)")
 
 	// Add subcommands
 	rootCmd.AddCommand(mcp.McpCmd)
@@ -117,24 +215,121 @@ func init() {
 	rootCmd.AddCommand(cli.VersionCmd)
 	rootCmd.AddCommand(cli.ImportCmd)
 	rootCmd.AddCommand(cli.ExportCmd)
+	rootCmd.AddCommand(cli.BundleCmd)
+	rootCmd.AddCommand(cli.InstallCmd)
+	rootCmd.AddCommand(cli.UninstallCmd)
+	rootCmd.AddCommand(cli.ApplyCmd)
+	rootCmd.AddCommand(cli.StateCmd)
+	rootCmd.AddCommand(cli.WorkspaceCmd)
 	rootCmd.AddCommand(cli.EmbeddingsCmd)
+	rootCmd.AddCommand(cli.AuditCmd)
+	rootCmd.AddCommand(cli.QuotaCmd)
+	rootCmd.AddCommand(cli.AuthCmd)
+	rootCmd.AddCommand(cli.EventsCmd)
+	rootCmd.AddCommand(cli.LoginCmd)
+	rootCmd.AddCommand(cli.LogoutCmd)
+	rootCmd.AddCommand(cli.AdminCmd)
+	rootCmd.AddCommand(cli.SystemCmd)
+	rootCmd.AddCommand(cli.RegistryCmd)
+	rootCmd.AddCommand(cli.ServerCmd)
+	rootCmd.AddCommand(cli.DoctorCmd)
+	rootCmd.AddCommand(cli.ConfigCmd)
+	rootCmd.AddCommand(cli.ContextCmd)
+	rootCmd.AddCommand(cli.RefreshCmd)
 }
 
 func Root() *cobra.Command {
 	return rootCmd
 }
 
+// loadCLIConfig reads the arctl config file, if any
+func loadCLIConfig() (*cliconfig.Config, error) {
+	path, err := cliconfig.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := cliconfig.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	cliConfig = cfg
+	return cfg, nil
+}
+
+// applyConfigDefaults fills flags the user did not set with the values of the config file
+func applyConfigDefaults(cmd *cobra.Command, cfg *cliconfig.Config) {
+	if cfg.Output != "" && !rootCmd.PersistentFlags().Changed("output") {
+		outputFormat = cfg.Output
+	}
+	setFlagDefault(cmd, "runtime", cfg.Runtime)
+	if cfg.Verbose {
+		setFlagDefault(cmd, "verbose", "true")
+	}
+	if cfg.RequestTimeout != "" && !rootCmd.PersistentFlags().Changed("request-timeout") && os.Getenv("ARCTL_REQUEST_TIMEOUT") == "" {
+		requestTimeout = cfg.RequestTimeout
+	}
+	client.SetTelemetryDisabled(cfg.DisableTelemetry)
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// setFlagDefault sets a flag of cmd to value unless the user set it or the command has no such flag
+func setFlagDefault(cmd *cobra.Command, name, value string) {
+	if value == "" {
+		return
+	}
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil || flag.Changed {
+		return
+	}
+	_ = flag.Value.Set(value)
+}
+
+// configureOutput applies the global output flags to the printer every command writes through
+func configureOutput() error {
+	format, err := printer.ParseOutputType(outputFormat)
+	if err != nil {
+		return err
+	}
+	printer.SetOptions(printer.Options{Format: format, NoHeaders: noHeaders, Wide: wideOutput})
+	return nil
+}
+
+// isCompletionCmd reports whether cmd serves shell completion: the hidden request command the generated scripts call,
+// or 'arctl completion <shell>' which prints those scripts
+func isCompletionCmd(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	return cmd.HasParent() && cmd.Parent().Name() == "completion"
+}
+
 func resolveRegistryTarget() (string, string) {
 	base := strings.TrimSpace(registryURL)
 	if base == "" {
 		base = strings.TrimSpace(os.Getenv("ARCTL_API_BASE_URL"))
 	}
+	fromContext := false
+	if base == "" {
+		base = cliConfig.ActiveRegistryURL()
+		fromContext = true
+	}
 	base = normalizeBaseURL(base)
 
 	token := registryToken
 	if token == "" {
 		token = os.Getenv("ARCTL_API_TOKEN")
 	}
+	// A context token is only sent to the registry of its context, never to a URL given on the command line
+	if token == "" && fromContext {
+		token = cliConfig.ActiveToken()
+	}
 
 	return base, token
 }
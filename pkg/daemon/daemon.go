@@ -1,17 +1,18 @@
 package daemon
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
 
 	"github.com/agentregistry-dev/agentregistry/internal/daemon"
+	"github.com/agentregistry-dev/agentregistry/internal/utils"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 	"gopkg.in/yaml.v3"
@@ -138,8 +139,8 @@ func (d *DefaultDaemonManager) getComposeYAML() string {
 func (d *DefaultDaemonManager) Start() error {
 	fmt.Printf("Starting %s daemon...\n", d.config.ProjectName)
 	// Pipe the docker-compose.yml via stdin to docker compose
-	cmd := exec.Command("docker", "compose", "-p", d.config.ProjectName, "-f", "-", "up", "-d", "--wait")
-	cmd.Stdin = strings.NewReader(d.getComposeYAML())
+	cmd := utils.ComposeCmd(context.Background(), "-p", d.config.ProjectName, "-f", "-", "up", "-d", "--wait")
+	cmd.Stdin = strings.NewReader(utils.WithContainerSocket(d.getComposeYAML()))
 	cmd.Env = append(os.Environ(), fmt.Sprintf("VERSION=%s", d.config.Version), fmt.Sprintf("DOCKER_REGISTRY=%s", d.config.DockerRegistry))
 	if byt, err := cmd.CombinedOutput(); err != nil {
 		fmt.Printf("failed to start docker compose: %v, output: %s", err, string(byt))
@@ -157,8 +158,8 @@ func (d *DefaultDaemonManager) IsRunning() bool {
 		return true
 	}
 
-	cmd := exec.Command("docker", "compose", "-p", d.config.ProjectName, "-f", "-", "ps")
-	cmd.Stdin = strings.NewReader(d.getComposeYAML())
+	cmd := utils.ComposeCmd(context.Background(), "-p", d.config.ProjectName, "-f", "-", "ps")
+	cmd.Stdin = strings.NewReader(utils.WithContainerSocket(d.getComposeYAML()))
 	cmd.Env = append(os.Environ(), fmt.Sprintf("VERSION=%s", d.config.Version), fmt.Sprintf("DOCKER_REGISTRY=%s", d.config.DockerRegistry))
 	output, err := cmd.CombinedOutput()
 	if err != nil {
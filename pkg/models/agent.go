@@ -16,6 +16,8 @@ type AgentJSON struct {
 	Repository    *model.Repository  `json:"repository,omitempty" doc:"Optional repository metadata for the agent source code."`
 	Packages      []AgentPackageInfo `json:"packages,omitempty"`
 	Remotes       []model.Transport  `json:"remotes,omitempty"`
+	Tags          []string           `json:"tags,omitempty" doc:"Tags describing the agent, e.g. database or search. Tags implied by the description are added."`
+	Visibility    string             `json:"visibility,omitempty" doc:"Who can see the agent: public (default), unlisted (hidden from listings and search) or private (members of its workspace only)." enum:"public,unlisted,private"`
 }
 
 type AgentPackageInfo struct {
@@ -43,6 +45,7 @@ type AgentSemanticMeta struct {
 type AgentResponseMeta struct {
 	Official *AgentRegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty"`
 	Semantic *AgentSemanticMeta       `json:"aregistry.ai/semantic,omitempty"`
+	Rating   *RatingSummary           `json:"aregistry.ai/rating,omitempty"`
 }
 
 type AgentResponse struct {
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// A2AProtocolVersion is the A2A protocol version of the agent cards the registry generates
+const A2AProtocolVersion = "0.3.0"
+
+// AgentCard is the A2A agent card of an agent, as served by agents on /.well-known/agent-card.json
+type AgentCard struct {
+	ProtocolVersion      string                         `json:"protocolVersion"`
+	Name                 string                         `json:"name"`
+	Description          string                         `json:"description"`
+	URL                  string                         `json:"url,omitempty" doc:"Primary A2A endpoint; empty when the agent has no known endpoint"`
+	PreferredTransport   string                         `json:"preferredTransport,omitempty"`
+	AdditionalInterfaces []AgentInterface               `json:"additionalInterfaces,omitempty"`
+	Version              string                         `json:"version"`
+	DocumentationURL     string                         `json:"documentationUrl,omitempty"`
+	Capabilities         AgentCapabilities              `json:"capabilities"`
+	SecuritySchemes      map[string]AgentSecurityScheme `json:"securitySchemes,omitempty"`
+	Security             []map[string][]string          `json:"security,omitempty"`
+	DefaultInputModes    []string                       `json:"defaultInputModes"`
+	DefaultOutputModes   []string                       `json:"defaultOutputModes"`
+	Skills               []AgentSkill                   `json:"skills"`
+}
+
+// AgentInterface is an additional endpoint an agent is reachable at
+type AgentInterface struct {
+	URL       string `json:"url"`
+	Transport string `json:"transport"`
+}
+
+// AgentCapabilities lists the optional A2A features an agent supports
+type AgentCapabilities struct {
+	Streaming bool `json:"streaming"`
+}
+
+// AgentSecurityScheme describes how clients authenticate to an agent
+type AgentSecurityScheme struct {
+	Type   string `json:"type" doc:"http or apiKey"`
+	Scheme string `json:"scheme,omitempty" doc:"HTTP auth scheme, e.g. bearer"`
+	In     string `json:"in,omitempty" doc:"Location of the API key"`
+	Name   string `json:"name,omitempty" doc:"Name of the API key header"`
+}
+
+// AgentSkill is a capability an agent advertises
+type AgentSkill struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// NewAgentCard generates the A2A agent card of a registry agent. The agent itself is its main skill and every
+// MCP server it uses adds one. Its remotes are its endpoints, followed by deploymentURL when the agent is deployed;
+// authentication is derived from the headers the first remote declares.
+func NewAgentCard(agent *AgentJSON, deploymentURL string) *AgentCard {
+	card := &AgentCard{
+		ProtocolVersion:    A2AProtocolVersion,
+		Name:               agent.Name,
+		Description:        agent.Description,
+		Version:            agent.Version,
+		DocumentationURL:   agent.WebsiteURL,
+		Capabilities:       AgentCapabilities{Streaming: true},
+		DefaultInputModes:  []string{"text"},
+		DefaultOutputModes: []string{"text"},
+	}
+	if agent.Title != "" {
+		card.Name = agent.Title
+	}
+
+	var interfaces []AgentInterface
+	for _, remote := range agent.Remotes {
+		if remote.URL != "" {
+			interfaces = append(interfaces, AgentInterface{URL: remote.URL, Transport: "JSONRPC"})
+		}
+	}
+	if deploymentURL != "" {
+		interfaces = append(interfaces, AgentInterface{URL: deploymentURL, Transport: "JSONRPC"})
+	}
+	if len(interfaces) > 0 {
+		card.URL = interfaces[0].URL
+		card.PreferredTransport = interfaces[0].Transport
+		card.AdditionalInterfaces = interfaces[1:]
+	}
+	if len(agent.Remotes) > 0 {
+		card.SecuritySchemes, card.Security = agentSecurity(agent.Remotes[0])
+	}
+
+	tags := []string{}
+	if agent.Framework != "" {
+		tags = append(tags, agent.Framework)
+	}
+	card.Skills = append(card.Skills, AgentSkill{
+		ID:          agentShortName(agent.Name),
+		Name:        card.Name,
+		Description: agent.Description,
+		Tags:        tags,
+	})
+	for _, server := range agent.McpServers {
+		name := server.Name
+		if server.RegistryServerName != "" {
+			name = server.RegistryServerName
+		}
+		card.Skills = append(card.Skills, AgentSkill{
+			ID:          server.Name,
+			Name:        name,
+			Description: "Tools of the MCP server " + name,
+			Tags:        []string{"mcp"},
+		})
+	}
+	return card
+}
+
+// agentSecurity maps the required headers of a remote to security schemes: Authorization becomes bearer auth,
+// any other required header an API key.
+func agentSecurity(remote model.Transport) (map[string]AgentSecurityScheme, []map[string][]string) {
+	schemes := map[string]AgentSecurityScheme{}
+	var security []map[string][]string
+	for _, header := range remote.Headers {
+		if !header.IsRequired && !header.IsSecret {
+			continue
+		}
+		if strings.EqualFold(header.Name, "Authorization") {
+			schemes["bearer"] = AgentSecurityScheme{Type: "http", Scheme: "bearer"}
+			security = append(security, map[string][]string{"bearer": {}})
+			continue
+		}
+		schemes[header.Name] = AgentSecurityScheme{Type: "apiKey", In: "header", Name: header.Name}
+		security = append(security, map[string][]string{header.Name: {}})
+	}
+	if len(schemes) == 0 {
+		return nil, nil
+	}
+	return schemes, security
+}
+
+// agentShortName returns the part of a namespaced agent name after the last slash
+func agentShortName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// APIToken is a long-lived, scoped registry credential. The token value itself is only returned once, on creation.
+type APIToken struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Subject     string            `json:"subject"`     // owner of the token; requests made with it act as this subject
+	Prefix      string            `json:"tokenPrefix"` // first characters of the token, to help identify it
+	Permissions []auth.Permission `json:"permissions"`
+	Workspace   string            `json:"workspace,omitempty"` // workspace the token is restricted to; empty for none
+	CreatedAt   time.Time         `json:"createdAt"`
+	ExpiresAt   *time.Time        `json:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time        `json:"lastUsedAt,omitempty"`
+	RevokedAt   *time.Time        `json:"revokedAt,omitempty"`
+}
+
+// CreatedAPIToken is returned when a token is minted and includes the plaintext token
+type CreatedAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+// APITokenListResponse is a list of API tokens (without their values)
+type APITokenListResponse struct {
+	Tokens []APIToken `json:"tokens"`
+}
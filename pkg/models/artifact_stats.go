@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Usage events counted per artifact
+const (
+	UsageEventDownload = "download" // pulled or run locally through the CLI
+	UsageEventDeploy   = "deploy"   // deployed through the deployments API
+	UsageEventInstall  = "install"  // installed through the CLI, e.g. a skill with its MCP servers
+)
+
+// ArtifactStats holds the usage counts of a server, agent or skill across all of its versions
+type ArtifactStats struct {
+	ArtifactType string     `json:"artifactType"` // "mcp", "agent" or "skill"
+	Name         string     `json:"name"`
+	Downloads    int64      `json:"downloads"`
+	Deploys      int64      `json:"deploys"`
+	Installs     int64      `json:"installs"`
+	Popularity   int64      `json:"popularity"`          // sum of all counts, used by sort=popularity
+	UpdatedAt    *time.Time `json:"updatedAt,omitempty"` // nil when the artifact was never used
+}
+
+// UsagePing is the anonymous usage event the CLI reports; it carries no user or machine details
+type UsagePing struct {
+	ArtifactType string `json:"artifactType" enum:"mcp,agent,skill" doc:"Type of the artifact"`
+	Name         string `json:"name" doc:"Name of the artifact" minLength:"1"`
+	Event        string `json:"event" enum:"download,install" doc:"What the CLI did with the artifact"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Kinds of artifacts attached to a server or agent version
+const (
+	AttachmentKindSchema         = "schema"
+	AttachmentKindExampleConfig  = "example-config"
+	AttachmentKindPromptTemplate = "prompt-template"
+	AttachmentKindBinary         = "binary"
+	AttachmentKindOther          = "other"
+)
+
+// AttachmentKinds lists the valid attachment kinds
+var AttachmentKinds = []string{
+	AttachmentKindSchema,
+	AttachmentKindExampleConfig,
+	AttachmentKindPromptTemplate,
+	AttachmentKindBinary,
+	AttachmentKindOther,
+}
+
+// Attachment is an artifact file, such as a schema, an example config or a binary, that a publisher attached to a
+// server or agent version. Its content lives in blob storage.
+type Attachment struct {
+	ArtifactType string    `json:"artifactType"` // "mcp" or "agent"
+	ArtifactName string    `json:"artifactName"`
+	Version      string    `json:"version"`
+	FileName     string    `json:"fileName"`
+	Kind         string    `json:"kind"`
+	MediaType    string    `json:"mediaType"`
+	Digest       string    `json:"digest"` // sha256:<hex> of the content
+	SizeBytes    int64     `json:"sizeBytes"`
+	UploadedBy   string    `json:"uploadedBy,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// AttachmentListResponse lists the artifacts attached to a version
+type AttachmentListResponse struct {
+	Artifacts []Attachment `json:"artifacts"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Audit actions recorded for mutating registry operations
+const (
+	AuditActionCreate       = "create"
+	AuditActionUpdate       = "update"
+	AuditActionPublish      = "publish"
+	AuditActionUnpublish    = "unpublish"
+	AuditActionDelete       = "delete"
+	AuditActionDeploy       = "deploy"
+	AuditActionUndeploy     = "undeploy"
+	AuditActionConfigChange = "config_change"
+	AuditActionPromote      = "promote"
+	AuditActionRollback     = "rollback"
+	AuditActionModerate     = "moderate"
+	AuditActionRestore      = "restore"
+	AuditActionPurge        = "purge"
+)
+
+// AuditLogEntry records who performed a mutating operation on which resource and when
+type AuditLogEntry struct {
+	ID           int64          `json:"id"`
+	OccurredAt   time.Time      `json:"occurredAt"`
+	Actor        string         `json:"actor"`
+	AuthMethod   string         `json:"authMethod,omitempty"`
+	Action       string         `json:"action"`
+	ResourceType string         `json:"resourceType"` // "mcp", "agent", "skill", "role" or "namespace"
+	ResourceName string         `json:"resourceName"`
+	Version      string         `json:"version,omitempty"`
+	Details      map[string]any `json:"details,omitempty"`
+}
+
+// AuditLogFilter defines filtering options for audit log queries
+type AuditLogFilter struct {
+	Actor        *string
+	Action       *string
+	ResourceType *string
+	ResourceName *string
+	Since        *time.Time
+	Until        *time.Time
+}
+
+// AuditLogResponse is a paginated list of audit log entries
+type AuditLogResponse struct {
+	Entries  []AuditLogEntry `json:"entries"`
+	Metadata AuditMetadata   `json:"metadata"`
+}
+
+// AuditMetadata holds pagination metadata for audit log listings
+type AuditMetadata struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	Count      int    `json:"count"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Batch publish item statuses
+const (
+	BatchItemCreated   = "created"
+	BatchItemPublished = "published"
+	BatchItemFailed    = "failed"
+)
+
+// BatchPublishResult is the outcome of one item of a batch publish request
+type BatchPublishResult struct {
+	Index   int    `json:"index" doc:"Position of the item in the request"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Status  string `json:"status" enum:"created,published,failed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BatchPublishResponse reports the per-item results of a batch publish request
+type BatchPublishResponse struct {
+	Results   []BatchPublishResult `json:"results"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Blob is content kept in the registry's blob storage, addressed by the SHA-256 of its content
+type Blob struct {
+	// Digest is "sha256:" followed by the hex SHA-256 of the content
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
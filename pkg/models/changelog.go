@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// ChangelogMetadataKey is the publisher-provided _meta key where a server.json carries the release notes of its
+// version as markdown:
+//
+//	"_meta": {"io.modelcontextprotocol.registry/publisher-provided": {"aregistry.ai/changelog": "- Add forecast tool"}}
+const ChangelogMetadataKey = "aregistry.ai/changelog"
+
+// MaxChangelogLength bounds the release notes of a version
+const MaxChangelogLength = 64 * 1024
+
+// Changelog field change kinds
+const (
+	ChangelogChangeAdded   = "added"
+	ChangelogChangeRemoved = "removed"
+	ChangelogChangeChanged = "changed"
+)
+
+// ServerChangelog lists the versions of a server, newest first, with their release notes and how their server.json
+// differs from the version before
+type ServerChangelog struct {
+	Name     string           `json:"name"`
+	Versions []ChangelogEntry `json:"versions"`
+}
+
+// ChangelogEntry describes one version of a server
+type ChangelogEntry struct {
+	Version         string            `json:"version"`
+	PublishedAt     time.Time         `json:"publishedAt"`
+	Notes           string            `json:"notes,omitempty"`           // release notes provided by the publisher
+	PreviousVersion string            `json:"previousVersion,omitempty"` // empty for the first version
+	Changes         []ChangelogChange `json:"changes"`
+}
+
+// ChangelogChange is one field of server.json that differs from the previous version
+type ChangelogChange struct {
+	Field    string `json:"field"`              // dotted path of the field, e.g. "packages[0].version"
+	Change   string `json:"change"`             // added, removed or changed
+	Previous string `json:"previous,omitempty"` // empty when the field was added
+	Current  string `json:"current,omitempty"`  // empty when the field was removed
+}
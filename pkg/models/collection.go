@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Types of the entries a collection can list
+const (
+	CollectionItemTypeMCP   = "mcp"
+	CollectionItemTypeAgent = "agent"
+	CollectionItemTypeSkill = "skill"
+)
+
+// CollectionJSON is a named, versioned list of servers, agents and skills installed together, such as a
+// "data-engineering starter pack"
+type CollectionJSON struct {
+	Name        string           `json:"name" doc:"Collection name" example:"io.example/data-engineering"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Version     string           `json:"version" example:"1.0.0"`
+	Items       []CollectionItem `json:"items" doc:"Servers, agents and skills in the collection"`
+}
+
+// CollectionItem is a server, agent or skill listed in a collection
+type CollectionItem struct {
+	Type string `json:"type" enum:"mcp,agent,skill"`
+	Name string `json:"name"`
+	// Version pins an exact version; empty means the latest version at install time
+	Version string `json:"version,omitempty" required:"false"`
+}
+
+// CollectionRegistryExtensions is the registry metadata of a collection version
+type CollectionRegistryExtensions struct {
+	PublishedAt time.Time `json:"publishedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	IsLatest    bool      `json:"isLatest"`
+	Published   bool      `json:"published"`
+}
+
+type CollectionResponseMeta struct {
+	Official *CollectionRegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty"`
+}
+
+type CollectionResponse struct {
+	Collection CollectionJSON         `json:"collection"`
+	Meta       CollectionResponseMeta `json:"_meta"`
+}
+
+// CollectionListResponse lists the latest version of each collection
+type CollectionListResponse struct {
+	Collections []CollectionResponse `json:"collections"`
+}
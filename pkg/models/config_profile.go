@@ -0,0 +1,35 @@
+package models
+
+import (
+	"maps"
+	"time"
+)
+
+// ConfigProfile is a named deployment config of a server, such as dev or prod, that deployments can start from.
+// Config is flat, like the config of a deployment.
+type ConfigProfile struct {
+	ServerName  string `json:"serverName"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Extends names a profile of the same server whose keys this profile inherits; keys set in Config override them
+	Extends string            `json:"extends,omitempty"`
+	Config  map[string]string `json:"config"`
+	// ResolvedConfig is Config merged over the profiles it extends; it is set when a single profile is read
+	ResolvedConfig map[string]string `json:"resolvedConfig,omitempty"`
+	CreatedBy      string            `json:"createdBy,omitempty"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+}
+
+// ConfigProfileListResponse is the list of config profiles of a server
+type ConfigProfileListResponse struct {
+	Profiles []ConfigProfile `json:"profiles"`
+}
+
+// MergeConfig returns the keys of base overridden by the keys of override
+func MergeConfig(base, override map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(override))
+	maps.Copy(merged, base)
+	maps.Copy(merged, override)
+	return merged
+}
@@ -11,13 +11,107 @@ type Deployment struct {
 	Status       string            `json:"status"`
 	Config       map[string]string `json:"config"`
 	PreferRemote bool              `json:"preferRemote"`
-	ResourceType string            `json:"resourceType"` // "mcp" or "agent"
-	Runtime      string            `json:"runtime"`      // "local" or "kubernetes"
-	IsExternal   bool              `json:"isExternal"`   // true if not managed by registry
+	ResourceType string            `json:"resourceType"`           // "mcp" or "agent"
+	Runtime      string            `json:"runtime"`                // "local" or "kubernetes"
+	IsExternal   bool              `json:"isExternal"`             // true if not managed by registry
+	Origin       string            `json:"origin,omitempty"`       // base URL of the registry the manifest is resolved from; empty for this registry
+	Target       string            `json:"target,omitempty"`       // named deployment target; empty for the built-in target of the runtime
+	CanaryWeight int               `json:"canaryWeight,omitempty"` // percentage of traffic routed to this canary version; zero for stable deployments
+	Workspace    string            `json:"workspace,omitempty"`    // workspace the deployment belongs to; empty for none
 }
 
 // DeploymentFilter defines filtering options for deployment queries
 type DeploymentFilter struct {
 	Runtime      *string // "local" or "kubernetes"
 	ResourceType *string // "mcp" or "agent"
+	Workspace    *string // only deployments of this workspace
+}
+
+// Deployment statuses
+const (
+	DeploymentStatusActive = "active"
+	// DeploymentStatusFailed marks a deployment whose container kept failing after its automatic restarts
+	DeploymentStatusFailed = "failed"
+)
+
+// DeploymentHealth is the runtime state of a deployment
+type DeploymentHealth struct {
+	ServerName   string `json:"serverName"`
+	Version      string `json:"version"`
+	ResourceType string `json:"resourceType"`
+	Runtime      string `json:"runtime"`
+	Status       string `json:"status"`            // deployment status: "active" or "failed"
+	Service      string `json:"service,omitempty"` // runtime container the state was read from
+	State        string `json:"state"`             // container state: "running", "exited", "missing" or "unknown"
+	Health       string `json:"health,omitempty"`  // "healthy", "unhealthy", "starting" or empty without a healthcheck
+	Restarts     int    `json:"restarts"`          // automatic restarts since the deployment was last healthy
+	// DesiredReplicas is the replica count the deployment asks for. CurrentReplicas counts its running
+	// containers and is only known on the local runtime.
+	DesiredReplicas int       `json:"desiredReplicas"`
+	CurrentReplicas int       `json:"currentReplicas"`
+	CheckedAt       time.Time `json:"checkedAt"`
+}
+
+// Changes recorded in the revision history of a deployment
+const (
+	DeploymentChangeDeploy   = "deploy"
+	DeploymentChangeConfig   = "config"
+	DeploymentChangeOrigin   = "origin"
+	DeploymentChangePromote  = "promote"
+	DeploymentChangeRollback = "rollback"
+)
+
+// DeploymentRevision is a snapshot of a deployment taken every time its version or configuration changes
+type DeploymentRevision struct {
+	ServerName   string            `json:"serverName"`
+	ResourceType string            `json:"resourceType"`
+	Revision     int               `json:"revision"` // increases by one with every change of the deployment
+	Version      string            `json:"version"`
+	Config       map[string]string `json:"config"`
+	PreferRemote bool              `json:"preferRemote"`
+	Runtime      string            `json:"runtime"`
+	Target       string            `json:"target,omitempty"`
+	Origin       string            `json:"origin,omitempty"`
+	Change       string            `json:"change"` // what created the revision: deploy, config, origin, promote or rollback
+	CreatedAt    time.Time         `json:"createdAt"`
+}
+
+// DeploymentDiff compares the desired state of a deployment, rendered from its registry record and stored
+// configuration, with what actually runs on its target
+type DeploymentDiff struct {
+	ServerName   string            `json:"serverName"`
+	Version      string            `json:"version"`
+	ResourceType string            `json:"resourceType"`
+	Runtime      string            `json:"runtime"`
+	Target       string            `json:"target,omitempty"`
+	InSync       bool              `json:"inSync"`
+	Drift        []DeploymentDrift `json:"drift"`
+	CheckedAt    time.Time         `json:"checkedAt"`
+}
+
+// DeploymentDrift is one difference between the desired and the actual state of a deployment
+type DeploymentDrift struct {
+	Resource string `json:"resource"`          // compose service or kubernetes object, e.g. "service weather"
+	Field    string `json:"field"`             // dotted path of the field that differs, e.g. "environment.LOG_LEVEL"
+	Desired  string `json:"desired,omitempty"` // empty when the field should not be set
+	Actual   string `json:"actual,omitempty"`  // empty when the field is not set
+}
+
+// Runtime export formats
+const (
+	RuntimeExportFormatCompose   = "compose"
+	RuntimeExportFormatKustomize = "kustomize"
+	RuntimeExportFormatHelm      = "helm"
+)
+
+// RuntimeExport is a bundle of the files the current deployments are applied from, for managing them through git
+type RuntimeExport struct {
+	Format string              `json:"format"`
+	Files  []RuntimeExportFile `json:"files"`
+}
+
+// RuntimeExportFile is one file of a runtime export
+type RuntimeExportFile struct {
+	Path    string `json:"path"` // slash separated, relative to the root of the bundle
+	Content string `json:"content"`
 }
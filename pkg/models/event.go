@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Event describes a change to the registry. Events are written to the outbox in the same transaction as the change
+// and delivered to event handlers at least once.
+type Event struct {
+	ID           int64          `json:"id"`
+	Type         string         `json:"type"` // "<resourceType>.<action>", e.g. "mcp.publish"
+	OccurredAt   time.Time      `json:"occurredAt"`
+	ResourceType string         `json:"resourceType"`
+	ResourceName string         `json:"resourceName"`
+	Version      string         `json:"version,omitempty"`
+	Actor        string         `json:"actor,omitempty"`
+	Details      map[string]any `json:"details,omitempty"`
+	// Attempts is how many earlier deliveries of the event failed
+	Attempts int `json:"attempts"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// Import statuses
+const (
+	ImportStatusRunning   = "running"
+	ImportStatusSucceeded = "succeeded"
+	ImportStatusFailed    = "failed"
+	ImportStatusCancelled = "cancelled"
+)
+
+// MaxImportFailures bounds how many failed entries an import status lists; the failed count covers all of them
+const MaxImportFailures = 100
+
+// ImportFailure is an entry an import could not create or update
+type ImportFailure struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Error   string `json:"error"`
+}
+
+// ImportOptions are the options of an import
+type ImportOptions struct {
+	// Enrich fetches scorecard scores, READMEs and other details of the imported servers
+	Enrich bool `json:"enrich,omitempty"`
+	// Strategy resolves entries that collide with existing servers: skip, overwrite, newest-version-wins or rename-with-suffix
+	Strategy string `json:"strategy,omitempty" enum:"skip,overwrite,newest-version-wins,rename-with-suffix"`
+	// DryRun only reports what the import would change
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ImportRequest starts an import through the imports API
+type ImportRequest struct {
+	// Source is a seed file path or URL, a registry /v0/servers URL, a package registry search (npm:..., pypi:...) or
+	// a GitHub organization (github-org:...)
+	Source  string        `json:"source" minLength:"1"`
+	Options ImportOptions `json:"options,omitempty"`
+}
+
+// ImportStatus is the progress of an import of seed data into the registry
+type ImportStatus struct {
+	ID         string          `json:"id"`
+	Source     string          `json:"source"`
+	Options    ImportOptions   `json:"options"`
+	Status     string          `json:"status"` // "running", "succeeded", "failed" or "cancelled"
+	Total      int             `json:"total"`  // entries to import, known once the source has been read
+	Processed  int             `json:"processed"`
+	Created    int             `json:"created"`
+	Updated    int             `json:"updated"`
+	Skipped    int             `json:"skipped"`
+	Failed     int             `json:"failed"`
+	Failures   []ImportFailure `json:"failures,omitempty"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt *time.Time      `json:"finishedAt,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	CreatedBy  string          `json:"createdBy,omitempty"`
+}
+
+// ImportListResponse is a list of imports, newest first
+type ImportListResponse struct {
+	Imports []ImportStatus `json:"imports"`
+}
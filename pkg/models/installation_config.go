@@ -0,0 +1,68 @@
+package models
+
+import (
+	"maps"
+	"strings"
+)
+
+// Key prefixes of the flat deployment config the API and CLI exchange. Keys without one of these prefixes are
+// environment variables.
+const (
+	ConfigArgPrefix      = "ARG_"
+	ConfigHeaderPrefix   = "HEADER_"
+	ConfigResourcePrefix = "RESOURCE_"
+)
+
+// InstallationConfig is the configuration of a deployment split by what each value sets. Deployments store their
+// config in this form; the API and CLI exchange the flat form, where ARG_, HEADER_ and RESOURCE_ key prefixes mark
+// the sections other than the environment.
+type InstallationConfig struct {
+	Env       map[string]string `json:"env,omitempty"`
+	Args      map[string]string `json:"args,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Resources map[string]string `json:"resources,omitempty"`
+}
+
+// ParseInstallationConfig splits a flat deployment config into its sections, all of which are non-nil. A key that
+// is only a prefix is an environment variable.
+func ParseInstallationConfig(flat map[string]string) InstallationConfig {
+	c := InstallationConfig{
+		Env:       map[string]string{},
+		Args:      map[string]string{},
+		Headers:   map[string]string{},
+		Resources: map[string]string{},
+	}
+	for key, value := range flat {
+		switch {
+		case hasConfigPrefix(key, ConfigArgPrefix):
+			c.Args[strings.TrimPrefix(key, ConfigArgPrefix)] = value
+		case hasConfigPrefix(key, ConfigHeaderPrefix):
+			c.Headers[strings.TrimPrefix(key, ConfigHeaderPrefix)] = value
+		case hasConfigPrefix(key, ConfigResourcePrefix):
+			c.Resources[strings.TrimPrefix(key, ConfigResourcePrefix)] = value
+		default:
+			c.Env[key] = value
+		}
+	}
+	return c
+}
+
+// Flatten returns the flat form of the config
+func (c InstallationConfig) Flatten() map[string]string {
+	flat := make(map[string]string, len(c.Env)+len(c.Args)+len(c.Headers)+len(c.Resources))
+	maps.Copy(flat, c.Env)
+	for key, value := range c.Args {
+		flat[ConfigArgPrefix+key] = value
+	}
+	for key, value := range c.Headers {
+		flat[ConfigHeaderPrefix+key] = value
+	}
+	for key, value := range c.Resources {
+		flat[ConfigResourcePrefix+key] = value
+	}
+	return flat
+}
+
+func hasConfigPrefix(key, prefix string) bool {
+	return len(key) > len(prefix) && strings.HasPrefix(key, prefix)
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Job run statuses
+const (
+	JobRunStatusRunning   = "running"
+	JobRunStatusSucceeded = "succeeded"
+	JobRunStatusFailed    = "failed"
+)
+
+// Job run triggers
+const (
+	JobTriggerStartup  = "startup"
+	JobTriggerSchedule = "schedule"
+	JobTriggerManual   = "manual"
+)
+
+// JobRun describes a single execution of a background job
+type JobRun struct {
+	Trigger    string     `json:"trigger"` // "startup", "schedule" or "manual"
+	Status     string     `json:"status"`  // "running", "succeeded" or "failed"
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// JobStatus is the state of a named background job
+type JobStatus struct {
+	Name            string     `json:"name"`
+	Description     string     `json:"description,omitempty"`
+	IntervalSeconds int64      `json:"intervalSeconds"` // zero for jobs that only run at startup or on demand
+	Running         bool       `json:"running"`
+	LastRun         *JobRun    `json:"lastRun,omitempty"`
+	NextRunAt       *time.Time `json:"nextRunAt,omitempty"`
+	RunCount        int        `json:"runCount"`
+	FailureCount    int        `json:"failureCount"`
+}
+
+// JobListResponse is the list of registered background jobs
+type JobListResponse struct {
+	Jobs []JobStatus `json:"jobs"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// VerifiedNamespace records that a user proved ownership of a namespace (e.g. "io.github.myorg" or "com.example").
+// Once a namespace is verified, only the users who verified it and registry admins may publish under it.
+type VerifiedNamespace struct {
+	Namespace  string    `json:"namespace"`
+	Subject    string    `json:"subject"`
+	Method     string    `json:"method"` // "github" or "dns"
+	VerifiedAt time.Time `json:"verifiedAt"`
+}
+
+// NamespaceListResponse is the response of the verified namespaces listing
+type NamespaceListResponse struct {
+	Namespaces []VerifiedNamespace `json:"namespaces"`
+}
+
+// NamespaceChallenge describes the DNS TXT record that proves ownership of a namespace for the caller
+type NamespaceChallenge struct {
+	Namespace   string `json:"namespace"`
+	RecordName  string `json:"recordName"`
+	RecordValue string `json:"recordValue"`
+}
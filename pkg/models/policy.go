@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Deployment policy rules
+const (
+	// PolicyRuleRequireSigned requires MCP servers to carry a publisher signature from a trusted key
+	PolicyRuleRequireSigned = "require-signed"
+	// PolicyRuleImageAllowlist requires every container image to come from one of the allowed registries
+	PolicyRuleImageAllowlist = "image-allowlist"
+	// PolicyRuleSecretEnvRefs requires env vars the server marks as secret to be secretRef:// or env:// references in the deployment config
+	PolicyRuleSecretEnvRefs = "secret-env-refs"
+)
+
+// Deployment policy enforcement modes
+const (
+	PolicyEnforcementDeny = "deny"
+	PolicyEnforcementWarn = "warn"
+)
+
+// Deployment policy sources
+const (
+	PolicySourceConfig = "config"
+	PolicySourceAPI    = "api"
+)
+
+// DeploymentPolicy is an admission rule evaluated before a server or agent is deployed
+type DeploymentPolicy struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Rule        string `json:"rule" yaml:"rule"`                                   // "require-signed", "image-allowlist" or "secret-env-refs"
+	Enforcement string `json:"enforcement,omitempty" yaml:"enforcement,omitempty"` // "deny" (default) or "warn"
+	// ResourceTypes limits the policy to "mcp" or "agent" deployments; empty applies to both
+	ResourceTypes []string `json:"resourceTypes,omitempty" yaml:"resourceTypes,omitempty"`
+	// Registries are the allowed image registries or repository prefixes for image-allowlist, e.g. ghcr.io/myorg
+	Registries []string   `json:"registries,omitempty" yaml:"registries,omitempty"`
+	Source     string     `json:"source" yaml:"-"` // "config" for policies from DEPLOYMENT_POLICY_FILE, "api" otherwise
+	CreatedBy  string     `json:"createdBy,omitempty" yaml:"-"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty" yaml:"-"`
+}
+
+// DeploymentPolicyListResponse is the list of active deployment policies
+type DeploymentPolicyListResponse struct {
+	Policies []DeploymentPolicy `json:"policies"`
+}
+
+// PolicyViolation describes a deployment policy a deployment does not satisfy
+type PolicyViolation struct {
+	Policy      string `json:"policy"`
+	Rule        string `json:"rule"`
+	Enforcement string `json:"enforcement"`
+	Message     string `json:"message"`
+}
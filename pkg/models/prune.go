@@ -0,0 +1,33 @@
+package models
+
+// Kinds of the runtime artifacts a prune removes
+const (
+	PruneKindImage         = "image"
+	PruneKindAgentConfig   = "agent-config"
+	PruneKindTargetDir     = "target-dir"
+	PruneKindGatewayConfig = "gateway-config"
+)
+
+// PruneItem is a runtime artifact no deployment uses anymore
+type PruneItem struct {
+	Kind   string `json:"kind" doc:"Kind of artifact: image, agent-config, target-dir or gateway-config" example:"image"`
+	Target string `json:"target,omitempty" doc:"Deployment target the artifact belongs to" example:"local"`
+	Name   string `json:"name" doc:"Image reference or path of the artifact" example:"ghcr.io/example/weather:1.0.0"`
+	Size   int64  `json:"size" doc:"Size of the artifact in bytes"`
+	// Skipped explains why an unused artifact was kept, e.g. a directory whose compose project may still run
+	Skipped string `json:"skipped,omitempty" doc:"Why the artifact was kept"`
+	Error   string `json:"error,omitempty" doc:"Why removing the artifact failed"`
+}
+
+// Reclaimed reports whether the artifact was, or in a dry run would be, removed
+func (i PruneItem) Reclaimed() bool {
+	return i.Skipped == "" && i.Error == ""
+}
+
+// PruneReport lists the runtime artifacts a prune removed, or would remove in a dry run
+type PruneReport struct {
+	DryRun bool        `json:"dryRun"`
+	Items  []PruneItem `json:"items"`
+	// ReclaimedBytes sums the sizes of the items that were, or would be, removed
+	ReclaimedBytes int64 `json:"reclaimedBytes"`
+}
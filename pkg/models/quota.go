@@ -0,0 +1,27 @@
+package models
+
+// QuotaUsage is a quota and how much of it is used. A limit of 0 means unlimited.
+type QuotaUsage struct {
+	Limit int `json:"limit"`
+	Used  int `json:"used"`
+}
+
+// Exceeded reports whether one more use would go over the limit
+func (u QuotaUsage) Exceeded() bool {
+	return u.Limit > 0 && u.Used >= u.Limit
+}
+
+// Quota reports the quotas that apply to a caller and their usage
+type Quota struct {
+	// Scope is what deployments are counted against: "workspace:<name>", or "user:<subject>" outside any workspace
+	Scope string `json:"scope"`
+	// Exempt is set for registry admins, whom quotas do not apply to
+	Exempt bool `json:"exempt,omitempty"`
+	// Deployments counts the deployments of the scope
+	Deployments QuotaUsage `json:"deployments"`
+	// PublishesPerDay counts the server, agent and skill versions the caller published in the last 24 hours
+	PublishesPerDay QuotaUsage `json:"publishesPerDay"`
+	// Namespace is the namespace ServersPerNamespace counts the servers of, when one was asked for
+	Namespace           string     `json:"namespace,omitempty"`
+	ServersPerNamespace QuotaUsage `json:"serversPerNamespace"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Readiness and component statuses
+const (
+	ReadinessStatusOK   = "ok"
+	ReadinessStatusFail = "fail"
+)
+
+// ComponentStatus is the outcome of the readiness check of one dependency of the registry
+type ComponentStatus struct {
+	Name    string `json:"name"`              // "database", "migrations" or "runtime"
+	Status  string `json:"status"`            // "ok" or "fail"
+	Message string `json:"message,omitempty"` // what failed, or details such as the schema version
+	// LatencyMs is how long the check took
+	LatencyMs int64 `json:"latencyMs"`
+}
+
+// Readiness reports whether the registry can serve requests. It is ready when every component is.
+type Readiness struct {
+	Status     string            `json:"status"`
+	Components []ComponentStatus `json:"components"`
+	CheckedAt  time.Time         `json:"checkedAt"`
+}
+
+// Ready reports whether every component passed
+func (r *Readiness) Ready() bool {
+	return r.Status == ReadinessStatusOK
+}
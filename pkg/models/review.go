@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// MaxReviewLength bounds the text of a review
+const MaxReviewLength = 2000
+
+// Review is a user's star rating and short review of a server or agent. Each user has at most one review per
+// artifact; submitting again replaces it.
+type Review struct {
+	ID           int64     `json:"id"`
+	ArtifactType string    `json:"artifactType"` // "mcp" or "agent"
+	Name         string    `json:"name"`
+	Author       string    `json:"author"`
+	Rating       int       `json:"rating"` // 1 to 5 stars
+	Body         string    `json:"body,omitempty"`
+	Hidden       bool      `json:"hidden,omitempty"` // hidden by a moderator; only admins see hidden reviews
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// RatingSummary aggregates the visible reviews of an artifact
+type RatingSummary struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+// ReviewMetadata holds pagination info for review listings
+type ReviewMetadata struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	Count      int    `json:"count"`
+}
+
+// ReviewListResponse lists the reviews of an artifact, newest first, with its aggregate rating
+type ReviewListResponse struct {
+	Reviews  []Review       `json:"reviews"`
+	Rating   RatingSummary  `json:"rating"`
+	Metadata ReviewMetadata `json:"metadata"`
+}
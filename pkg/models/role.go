@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Registry roles that can be bound to a namespace
+const (
+	RoleAdmin     = "admin"
+	RolePublisher = "publisher"
+	RoleReader    = "reader"
+)
+
+// RoleBinding grants a subject a role on every resource matching a namespace pattern
+type RoleBinding struct {
+	ID        int64     `json:"id"`
+	Subject   string    `json:"subject"`   // JWT subject the role is granted to
+	Role      string    `json:"role"`      // "admin", "publisher" or "reader"
+	Namespace string    `json:"namespace"` // e.g. "io.github.myorg/*" or "*"
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RoleBindingListResponse is a list of role bindings
+type RoleBindingListResponse struct {
+	Bindings []RoleBinding `json:"bindings"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// ServerCapabilities is the inventory of tools, resources and prompts a server version reported
+// during the MCP initialize handshake and list calls
+type ServerCapabilities struct {
+	ServerName      string         `json:"serverName"`
+	Version         string         `json:"version"`
+	ProtocolVersion string         `json:"protocolVersion,omitempty"`
+	Source          string         `json:"source"` // "remote" or "package": what was started or connected to
+	Tools           []ToolInfo     `json:"tools"`
+	Resources       []ResourceInfo `json:"resources"`
+	Prompts         []PromptInfo   `json:"prompts"`
+	Error           string         `json:"error,omitempty"` // set when introspection failed; the lists are then empty
+	IntrospectedAt  time.Time      `json:"introspectedAt"`
+}
+
+// ToolInfo describes a tool exposed by a server
+type ToolInfo struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	InputSchema any    `json:"inputSchema,omitempty"`
+}
+
+// ResourceInfo describes a resource exposed by a server
+type ResourceInfo struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MIMEType    string `json:"mimeType,omitempty"`
+}
+
+// PromptInfo describes a prompt exposed by a server
+type PromptInfo struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+// PromptArgument describes an argument of a prompt
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// ToolSearchResult is a tool matching a tool search, with the server version that provides it
+type ToolSearchResult struct {
+	ServerName string   `json:"serverName"`
+	Version    string   `json:"version"`
+	Tool       ToolInfo `json:"tool"`
+}
+
+// ToolSearchResponse is the response of a tool search
+type ToolSearchResponse struct {
+	Tools []ToolSearchResult `json:"tools"`
+	Count int                `json:"count"`
+}
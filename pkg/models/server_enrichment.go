@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// EnrichmentMetadataKey is the publisher-provided _meta key that holds the enriched details of a server
+// (GitHub activity, OpenSSF scorecard, dependency health, container image stats)
+const EnrichmentMetadataKey = "aregistry.ai/metadata"
+
+// ServerEnrichment records when the details of a server version were last refreshed
+type ServerEnrichment struct {
+	ServerName string     `json:"serverName"`
+	Version    string     `json:"version"`
+	EnrichedAt *time.Time `json:"enrichedAt,omitempty"` // nil when the version has never been enriched
+	Error      string     `json:"error,omitempty"`      // set when the last enrichment failed; the previous details are kept
+}
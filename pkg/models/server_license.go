@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// LicenseMetadataKey is the _meta.io.modelcontextprotocol.registry/publisher-provided key holding a server's license.
+// Publishers may set it in server.json; otherwise enrichment fills it from the repository or its SBOM:
+//
+//	"_meta": {"io.modelcontextprotocol.registry/publisher-provided": {"aregistry.ai/license": {"spdx": "MIT", "source": "repository"}}}
+const LicenseMetadataKey = "aregistry.ai/license"
+
+// Where a server's license was found
+const (
+	LicenseSourcePublisher  = "publisher"
+	LicenseSourceRepository = "repository"
+	LicenseSourceSBOM       = "sbom"
+)
+
+// ServerLicense is the license of a server
+type ServerLicense struct {
+	SPDX   string `json:"spdx"`             // SPDX license identifier or expression, e.g. "MIT" or "Apache-2.0 OR MIT"
+	Source string `json:"source,omitempty"` // "publisher", "repository" or "sbom"
+}
+
+// Copyleft reports whether the license requires derived works to use the same license
+func (l *ServerLicense) Copyleft() bool {
+	return l != nil && IsCopyleftLicense(l.SPDX)
+}
+
+// IsCopyleftLicense reports whether an SPDX license identifier or expression includes a copyleft license (GPL, AGPL,
+// LGPL, SSPL, CC-BY-SA)
+func IsCopyleftLicense(license string) bool {
+	slug := strings.ToLower(license)
+	for _, marker := range []string{"gpl", "sspl", "copyleft", "cc-by-sa"} {
+		if strings.Contains(slug, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// LicenseFromMeta extracts the license from a server's _meta, or nil if it has none
+func LicenseFromMeta(meta *apiv0.ServerMeta) *ServerLicense {
+	if meta == nil || meta.PublisherProvided == nil {
+		return nil
+	}
+	raw, ok := meta.PublisherProvided[LicenseMetadataKey]
+	if !ok {
+		return nil
+	}
+	// Values read back from the database are generic maps; round-trip them through JSON
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var license ServerLicense
+	if err := json.Unmarshal(data, &license); err != nil || strings.TrimSpace(license.SPDX) == "" {
+		return nil
+	}
+	return &license
+}
+
+// SetLicense stores a license in a server's _meta, replacing the previous one
+func SetLicense(server *apiv0.ServerJSON, license *ServerLicense) {
+	if server.Meta == nil {
+		server.Meta = &apiv0.ServerMeta{}
+	}
+	if server.Meta.PublisherProvided == nil {
+		server.Meta.PublisherProvided = map[string]any{}
+	}
+	server.Meta.PublisherProvided[LicenseMetadataKey] = map[string]any{
+		"spdx":   license.SPDX,
+		"source": license.Source,
+	}
+}
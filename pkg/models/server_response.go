@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
@@ -13,6 +15,7 @@ type ServerSemanticMeta struct {
 type ServerResponseMeta struct {
 	Official *apiv0.RegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty"`
 	Semantic *ServerSemanticMeta       `json:"aregistry.ai/semantic,omitempty"`
+	Rating   *RatingSummary            `json:"aregistry.ai/rating,omitempty"`
 }
 
 // ServerResponse is the server API shape with registry-managed metadata.
@@ -32,3 +35,19 @@ type ServerListResponse struct {
 	Servers  []ServerResponse `json:"servers"`
 	Metadata ServerMetadata   `json:"metadata"`
 }
+
+// ServerVersionStatus is the lifecycle and visibility state of a single server version.
+type ServerVersionStatus struct {
+	Version     string    `json:"version"`
+	Status      string    `json:"status"`
+	Published   bool      `json:"published"`
+	IsLatest    bool      `json:"isLatest"`
+	PublishedAt time.Time `json:"publishedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ServerVersionsStatusResponse lists the state of every version of a server.
+type ServerVersionsStatusResponse struct {
+	Name     string                `json:"name"`
+	Versions []ServerVersionStatus `json:"versions"`
+}
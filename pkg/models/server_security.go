@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// SecurityMetadataKey is the _meta.io.modelcontextprotocol.registry/publisher-provided key holding image scan results
+const SecurityMetadataKey = "aregistry.ai/security"
+
+// VulnerabilityCounts counts vulnerability findings by severity
+type VulnerabilityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Unknown  int `json:"unknown"`
+}
+
+// ImageVulnerabilities is the scan result of a single OCI image referenced by a server
+type ImageVulnerabilities struct {
+	Image string `json:"image"`
+	VulnerabilityCounts
+	Error string `json:"error,omitempty"` // set when the image could not be scanned
+}
+
+// VulnerabilitySummary aggregates the scan results of all OCI images referenced by a server version
+type VulnerabilitySummary struct {
+	Scanner string `json:"scanner"` // "trivy" or "grype"
+	VulnerabilityCounts
+	Images    []ImageVulnerabilities `json:"images"`
+	ScannedAt time.Time              `json:"scannedAt"`
+}
+
+// ServerSecurity is the security report of a server version
+type ServerSecurity struct {
+	ServerName      string                `json:"serverName"`
+	Version         string                `json:"version"`
+	Vulnerabilities *VulnerabilitySummary `json:"vulnerabilities,omitempty"` // nil when the server has not been scanned
+}
+
+// VulnerabilitySummaryFromMeta extracts the stored scan results from a server's _meta, or nil if it has none
+func VulnerabilitySummaryFromMeta(meta *apiv0.ServerMeta) *VulnerabilitySummary {
+	if meta == nil || meta.PublisherProvided == nil {
+		return nil
+	}
+	raw, ok := meta.PublisherProvided[SecurityMetadataKey]
+	if !ok {
+		return nil
+	}
+	// Values read back from the database are generic maps; round-trip them through JSON
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var wrapper struct {
+		Vulnerabilities *VulnerabilitySummary `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil
+	}
+	return wrapper.Vulnerabilities
+}
+
+// SetVulnerabilitySummary stores scan results in a server's _meta, replacing previous results
+func SetVulnerabilitySummary(server *apiv0.ServerJSON, summary *VulnerabilitySummary) {
+	if server.Meta == nil {
+		server.Meta = &apiv0.ServerMeta{}
+	}
+	if server.Meta.PublisherProvided == nil {
+		server.Meta.PublisherProvided = map[string]any{}
+	}
+	server.Meta.PublisherProvided[SecurityMetadataKey] = map[string]any{
+		"vulnerabilities": summary,
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// ServerSignature is a publisher's signature over a server version's server.json (excluding _meta)
+type ServerSignature struct {
+	ServerName  string    `json:"serverName"`
+	Version     string    `json:"version"`
+	Algorithm   string    `json:"algorithm"`          // "ed25519" or "ecdsa-p256-sha256"
+	Signature   []byte    `json:"signature"`          // base64 in JSON
+	PublicKey   string    `json:"publicKey"`          // PEM encoded PKIX public key
+	Fingerprint string    `json:"fingerprint"`        // SHA256:<hex> of the public key
+	SignedBy    string    `json:"signedBy,omitempty"` // actor that uploaded the signature
+	CreatedAt   time.Time `json:"createdAt"`
+}
@@ -14,6 +14,18 @@ type SkillJSON struct {
 	Repository  *SkillRepository   `json:"repository,omitempty"`
 	Packages    []SkillPackageInfo `json:"packages,omitempty"`
 	Remotes     []SkillRemoteInfo  `json:"remotes,omitempty"`
+	// MCPServers lists the MCP servers the skill needs to be usable
+	MCPServers []SkillServerDependency `json:"mcpServers,omitempty"`
+	// Visibility is public (default), unlisted (hidden from listings and search) or private (members of its workspace only)
+	Visibility string `json:"visibility,omitempty" enum:"public,unlisted,private"`
+}
+
+// SkillServerDependency declares an MCP server a skill relies on and, optionally, the tools it calls
+type SkillServerDependency struct {
+	Name string `json:"name" yaml:"name"`
+	// Version pins an exact server version; empty means the latest version
+	Version string   `json:"version,omitempty" yaml:"version,omitempty"`
+	Tools   []string `json:"tools,omitempty" yaml:"tools,omitempty"`
 }
 
 type SkillRepository struct {
@@ -61,3 +73,19 @@ type SkillListResponse struct {
 	Skills   []SkillResponse `json:"skills"`
 	Metadata SkillMetadata   `json:"metadata"`
 }
+
+// SkillVersionStatus is the lifecycle and visibility state of a single skill version.
+type SkillVersionStatus struct {
+	Version     string    `json:"version"`
+	Status      string    `json:"status"`
+	Published   bool      `json:"published"`
+	IsLatest    bool      `json:"isLatest"`
+	PublishedAt time.Time `json:"publishedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// SkillVersionsStatusResponse lists the state of every version of a skill.
+type SkillVersionsStatusResponse struct {
+	Name     string               `json:"name"`
+	Versions []SkillVersionStatus `json:"versions"`
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// TagsMetadataKey is the _meta.io.modelcontextprotocol.registry/publisher-provided key holding a server's tags:
+//
+//	"_meta": {"io.modelcontextprotocol.registry/publisher-provided": {"aregistry.ai/tags": ["database", "postgres"]}}
+const TagsMetadataKey = "aregistry.ai/tags"
+
+// MaxTags bounds how many tags an entry keeps, publisher-provided ones first
+const MaxTags = 20
+
+var (
+	invalidTagCharsRe = regexp.MustCompile(`[^a-z0-9-]+`)
+	tagWordRe         = regexp.MustCompile(`[a-z0-9]+`)
+)
+
+// tagKeywords maps the tags derived from descriptions to the words that imply them
+var tagKeywords = map[string][]string{
+	"ai":            {"llm", "llms", "openai", "anthropic", "embedding", "embeddings", "rag"},
+	"analytics":     {"analytics", "metrics", "dashboard", "dashboards"},
+	"browser":       {"browser", "playwright", "puppeteer", "selenium", "scraping", "scraper"},
+	"cloud":         {"aws", "azure", "gcp", "cloud", "s3", "lambda"},
+	"communication": {"slack", "discord", "email", "gmail", "teams", "telegram", "chat"},
+	"database":      {"database", "databases", "sql", "postgres", "postgresql", "mysql", "sqlite", "mongodb", "redis", "supabase"},
+	"devtools":      {"git", "github", "gitlab", "ci", "debugging", "lint", "linter", "ide"},
+	"documents":     {"pdf", "docx", "markdown", "notion", "confluence", "documents", "docs"},
+	"filesystem":    {"filesystem", "files", "file", "directory", "directories"},
+	"finance":       {"finance", "stock", "stocks", "payments", "stripe", "crypto", "trading"},
+	"kubernetes":    {"kubernetes", "k8s", "helm", "kubectl"},
+	"monitoring":    {"monitoring", "observability", "logs", "logging", "tracing", "prometheus", "grafana", "sentry"},
+	"search":        {"search", "brave", "tavily", "bing"},
+	"security":      {"security", "vulnerability", "vulnerabilities", "secrets", "auth", "oauth"},
+}
+
+// NormalizeTags lowercases tags, replaces characters other than letters, digits and dashes with dashes, and drops
+// empty and duplicate tags, keeping the first MaxTags in order
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.Trim(invalidTagCharsRe.ReplaceAllString(strings.ToLower(strings.TrimSpace(tag)), "-"), "-")
+		if tag == "" || len(tag) > 50 || slices.Contains(normalized, tag) {
+			continue
+		}
+		if normalized = append(normalized, tag); len(normalized) == MaxTags {
+			break
+		}
+	}
+	return normalized
+}
+
+// DeriveTags returns the taxonomy tags implied by the words of a text, such as a description, sorted by name
+func DeriveTags(texts ...string) []string {
+	words := map[string]bool{}
+	for _, text := range texts {
+		for _, word := range tagWordRe.FindAllString(strings.ToLower(text), -1) {
+			words[word] = true
+		}
+	}
+	var tags []string
+	for tag, keywords := range tagKeywords {
+		if words[tag] || slices.ContainsFunc(keywords, func(k string) bool { return words[k] }) {
+			tags = append(tags, tag)
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// ServerTagsFromMeta returns the tags stored in a server's _meta
+func ServerTagsFromMeta(meta *apiv0.ServerMeta) []string {
+	if meta == nil || meta.PublisherProvided == nil {
+		return nil
+	}
+	var tags []string
+	switch raw := meta.PublisherProvided[TagsMetadataKey].(type) {
+	case []string:
+		tags = slices.Clone(raw)
+	case []any:
+		for _, v := range raw {
+			if tag, ok := v.(string); ok {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// TagServer stores the publisher-provided tags of a server together with the ones derived from its title and
+// description in its _meta. The _meta map is copied, so the caller's server is not changed.
+func TagServer(server *apiv0.ServerJSON) {
+	tags := NormalizeTags(append(ServerTagsFromMeta(server.Meta), DeriveTags(server.Title, server.Description)...))
+	if server.Meta == nil && len(tags) == 0 {
+		return
+	}
+	meta := apiv0.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	publisherProvided := make(map[string]any, len(meta.PublisherProvided)+1)
+	for k, v := range meta.PublisherProvided {
+		publisherProvided[k] = v
+	}
+	if len(tags) > 0 {
+		publisherProvided[TagsMetadataKey] = tags
+	} else {
+		delete(publisherProvided, TagsMetadataKey)
+	}
+	if len(publisherProvided) == 0 {
+		publisherProvided = nil
+	}
+	meta.PublisherProvided = publisherProvided
+	server.Meta = &meta
+}
+
+// TagAgent adds the tags derived from an agent's title and description to its publisher-provided tags
+func TagAgent(agent *AgentJSON) {
+	agent.Tags = NormalizeTags(append(slices.Clone(agent.Tags), DeriveTags(agent.Title, agent.Description)...))
+}
+
+// Tag is a tag with the number of published servers and agents that carry it, counting their latest versions
+type Tag struct {
+	Name    string `json:"name"`
+	Servers int    `json:"servers"`
+	Agents  int    `json:"agents"`
+}
+
+// TagListResponse is a list of tags, most used first
+type TagListResponse struct {
+	Tags []Tag `json:"tags"`
+}
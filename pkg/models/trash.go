@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// TrashEntry is a deleted server version that can still be restored
+type TrashEntry struct {
+	ArtifactType string     `json:"artifactType" doc:"Type of the deleted entry" example:"mcp"`
+	Name         string     `json:"name" doc:"Name of the deleted entry" example:"com.example/my-server"`
+	Version      string     `json:"version" doc:"Version of the deleted entry" example:"1.0.0"`
+	DeletedAt    time.Time  `json:"deletedAt" doc:"When the entry was deleted"`
+	DeletedBy    string     `json:"deletedBy,omitempty" doc:"Who deleted the entry"`
+	PurgeAt      *time.Time `json:"purgeAt,omitempty" doc:"When the entry will be purged for good; absent when deleted entries are kept until purged by hand"`
+}
+
+// TrashListResponse lists the entries in the trash
+type TrashListResponse struct {
+	Entries []TrashEntry `json:"entries"`
+}
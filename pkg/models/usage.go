@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// SystemUsage is the disk usage of the registry database and the runtime its deployments run on
+type SystemUsage struct {
+	DatabaseBytes   int64 `json:"databaseBytes" doc:"Size of the registry database in bytes"`
+	RuntimeDirBytes int64 `json:"runtimeDirBytes" doc:"Size of the runtime directory holding compose projects and agent configs in bytes"`
+	// Images are the images the runtime pulled for deployments, on every docker target
+	Images []ImageUsage `json:"images"`
+	// Volumes are the volumes of the compose projects of the runtime, on every docker target
+	Volumes []VolumeUsage `json:"volumes"`
+	// Warnings name the usage that could not be read, such as the images of an unreachable docker host
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ImageUsage is the size of an image the runtime pulled
+type ImageUsage struct {
+	Target string `json:"target" doc:"Deployment target the image was pulled to" example:"local"`
+	Name   string `json:"name" doc:"Image reference" example:"ghcr.io/example/weather:1.0.0"`
+	Size   int64  `json:"size" doc:"Size of the image in bytes"`
+	InUse  bool   `json:"inUse" doc:"Whether a deployment still references the image"`
+}
+
+// VolumeUsage is the size of a volume of a compose project of the runtime
+type VolumeUsage struct {
+	Target string `json:"target" doc:"Deployment target the volume is on" example:"local"`
+	Name   string `json:"name" doc:"Volume name"`
+	Size   int64  `json:"size" doc:"Size of the volume in bytes"`
+	InUse  bool   `json:"inUse" doc:"Whether a container mounts the volume"`
+}
+
+// ContainerMetrics is a sample of the resource usage of one container or pod of a deployment
+type ContainerMetrics struct {
+	Version string `json:"version" doc:"Deployed version the container runs" example:"1.0.0"`
+	Name    string `json:"name" doc:"Container or pod name"`
+	// CPUMillicores is the CPU the container uses, in thousandths of a core
+	CPUMillicores int64 `json:"cpuMillicores" doc:"CPU usage in thousandths of a core" example:"250"`
+	MemoryBytes   int64 `json:"memoryBytes" doc:"Memory usage in bytes"`
+	// MemoryLimitBytes is the memory limit of the container; 0 when it is not known
+	MemoryLimitBytes int64 `json:"memoryLimitBytes,omitempty" doc:"Memory limit in bytes, when known"`
+}
+
+// DeploymentMetrics is the resource usage of the containers or pods of the deployments of a server or agent
+type DeploymentMetrics struct {
+	ServerName   string             `json:"serverName"`
+	ResourceType string             `json:"resourceType"`
+	Containers   []ContainerMetrics `json:"containers"`
+	// Notes explain deployments without samples, such as MCP servers served by the agent gateway
+	Notes     []string  `json:"notes,omitempty"`
+	SampledAt time.Time `json:"sampledAt"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+// VisibilityMetadataKey is the _meta.io.modelcontextprotocol.registry/publisher-provided key holding a server's
+// visibility:
+//
+//	"_meta": {"io.modelcontextprotocol.registry/publisher-provided": {"aregistry.ai/visibility": "unlisted"}}
+const VisibilityMetadataKey = "aregistry.ai/visibility"
+
+// Visibilities of registry entries. Public entries are listed and searchable. Unlisted entries can be fetched by
+// name but are left out of listings and search. Private entries belong to a workspace and only its members see them.
+const (
+	VisibilityPublic   = "public"
+	VisibilityUnlisted = "unlisted"
+	VisibilityPrivate  = "private"
+)
+
+// Visibilities lists the valid visibilities
+var Visibilities = []string{VisibilityPublic, VisibilityUnlisted, VisibilityPrivate}
+
+// ValidVisibility reports whether visibility is valid; empty means public
+func ValidVisibility(visibility string) bool {
+	switch visibility {
+	case "", VisibilityPublic, VisibilityUnlisted, VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// ServerVisibility returns the visibility stored in a server's _meta, or public when none is set
+func ServerVisibility(meta *apiv0.ServerMeta) string {
+	if meta == nil || meta.PublisherProvided == nil {
+		return VisibilityPublic
+	}
+	visibility, _ := meta.PublisherProvided[VisibilityMetadataKey].(string)
+	if visibility == "" {
+		return VisibilityPublic
+	}
+	return visibility
+}
+
+// SetServerVisibility stores a visibility in a server's _meta. The _meta map is copied, so servers sharing it are not
+// changed.
+func SetServerVisibility(server *apiv0.ServerJSON, visibility string) {
+	meta := apiv0.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	publisherProvided := make(map[string]any, len(meta.PublisherProvided)+1)
+	for k, v := range meta.PublisherProvided {
+		publisherProvided[k] = v
+	}
+	publisherProvided[VisibilityMetadataKey] = visibility
+	meta.PublisherProvided = publisherProvided
+	server.Meta = &meta
+}
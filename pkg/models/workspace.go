@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Roles of workspace members. Owners manage the workspace and its members.
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleMember = "member"
+)
+
+// Workspace groups the entries, deployments, members and API tokens of a team. Unpublished entries of a workspace
+// are only visible to its members.
+type Workspace struct {
+	Name        string    `json:"name" doc:"Workspace name: lowercase letters, digits and dashes" example:"data-platform"`
+	DisplayName string    `json:"displayName,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedBy   string    `json:"createdBy,omitempty" readOnly:"true"`
+	CreatedAt   time.Time `json:"createdAt" readOnly:"true"`
+}
+
+// WorkspaceMember is a subject belonging to a workspace
+type WorkspaceMember struct {
+	Subject string    `json:"subject"`
+	Role    string    `json:"role"` // "owner" or "member"
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// WorkspaceListResponse is a list of workspaces
+type WorkspaceListResponse struct {
+	Workspaces []Workspace `json:"workspaces"`
+}
+
+// WorkspaceMemberListResponse is a list of the members of a workspace
+type WorkspaceMemberListResponse struct {
+	Members []WorkspaceMember `json:"members"`
+}
@@ -0,0 +1,38 @@
+package printer
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"golang.org/x/term"
+)
+
+// maxMarkdownWidth caps the wrap width of rendered documents on wide terminals
+const maxMarkdownWidth = 120
+
+// RenderMarkdown renders a README for the terminal. Documents that are not markdown, and output that is piped or
+// redirected, are returned unchanged.
+func RenderMarkdown(content, contentType string) string {
+	if contentType != "" && !strings.Contains(contentType, "markdown") {
+		return content
+	}
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return content
+	}
+
+	width := maxMarkdownWidth
+	if w, _, err := term.GetSize(fd); err == nil && w > 0 && w < width {
+		width = w
+	}
+	renderer, err := glamour.NewTermRenderer(glamour.WithAutoStyle(), glamour.WithWordWrap(width))
+	if err != nil {
+		return content
+	}
+	rendered, err := renderer.Render(content)
+	if err != nil {
+		return content
+	}
+	return rendered
+}
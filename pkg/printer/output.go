@@ -0,0 +1,96 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Options are the output settings shared by every command, set once from the global --output, --no-headers and
+// --wide flags
+type Options struct {
+	// Format is the output format; OutputTypeWide is normalized to OutputTypeTable with Wide set
+	Format OutputType
+	// NoHeaders omits the header row of tables
+	NoHeaders bool
+	// Wide shows additional table columns
+	Wide bool
+}
+
+var globalOptions = Options{Format: OutputTypeTable}
+
+// SetOptions sets the output settings used by Format, PrintData and new table printers
+func SetOptions(opts Options) {
+	if opts.Format == "" {
+		opts.Format = OutputTypeTable
+	}
+	if opts.Format == OutputTypeWide {
+		opts.Format = OutputTypeTable
+		opts.Wide = true
+	}
+	globalOptions = opts
+}
+
+// CurrentOptions returns the output settings set with SetOptions
+func CurrentOptions() Options {
+	return globalOptions
+}
+
+// Format returns the output format selected with --output
+func Format() OutputType {
+	return globalOptions.Format
+}
+
+// ParseOutputType validates an --output value
+func ParseOutputType(value string) (OutputType, error) {
+	switch t := OutputType(strings.ToLower(strings.TrimSpace(value))); t {
+	case "":
+		return OutputTypeTable, nil
+	case OutputTypeTable, OutputTypeWide, OutputTypeJSON, OutputTypeYAML:
+		return t, nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q (use table, wide, json or yaml)", value)
+	}
+}
+
+// IsStructured reports whether the format is machine readable (JSON or YAML) rather than a table
+func (t OutputType) IsStructured() bool {
+	return t == OutputTypeJSON || t == OutputTypeYAML
+}
+
+// PrintYAML prints data in YAML format. The data is converted through JSON first, so the field names match the
+// JSON output and the API.
+func (p *Printer) PrintYAML(data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(p.out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// PrintData prints data as YAML when the printer's output type is YAML and as JSON otherwise
+func (p *Printer) PrintData(data any) error {
+	if p.outputType == OutputTypeYAML {
+		return p.PrintYAML(data)
+	}
+	return p.PrintJSON(data)
+}
+
+// PrintStructured prints data to stdout in the format selected with --output (JSON unless YAML was chosen)
+func PrintStructured(data any) error {
+	if err := New(Format(), globalOptions.Wide).PrintData(data); err != nil {
+		return fmt.Errorf("failed to write %s output: %w", Format(), err)
+	}
+	return nil
+}
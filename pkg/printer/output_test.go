@@ -0,0 +1,46 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputType(t *testing.T) {
+	for _, value := range []string{"", "table", "wide", "JSON", " yaml "} {
+		_, err := ParseOutputType(value)
+		assert.NoError(t, err, value)
+	}
+	_, err := ParseOutputType("xml")
+	assert.Error(t, err)
+}
+
+func TestPrintYAMLUsesJSONFieldNames(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(OutputTypeYAML, false)
+	p.SetOutput(&buf)
+
+	data := struct {
+		ServerName string `json:"serverName"`
+		Version    string `json:"version,omitempty"`
+	}{ServerName: "com.example/weather"}
+	require.NoError(t, p.PrintData(data))
+	assert.Equal(t, "serverName: com.example/weather\n", buf.String())
+}
+
+func TestTablePrinterGlobalOptions(t *testing.T) {
+	defer SetOptions(Options{})
+
+	SetOptions(Options{Format: OutputTypeWide, NoHeaders: true})
+	assert.Equal(t, OutputTypeTable, Format())
+
+	var buf bytes.Buffer
+	tp := NewTablePrinter(&buf)
+	assert.True(t, tp.Wide())
+	tp.SetHeaders("Name", "Version")
+	tp.AddRow("weather", "1.0.0")
+	require.NoError(t, tp.Render())
+	assert.Equal(t, "weather   1.0.0\n", buf.String())
+}
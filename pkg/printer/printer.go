@@ -88,3 +88,17 @@ func FormatAge(t time.Time) string {
 	seconds := int(duration.Seconds())
 	return fmt.Sprintf("%ds", seconds)
 }
+
+// FormatBytes formats a size in bytes with binary units (e.g., "512 B", "1.5 MiB")
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -57,7 +57,8 @@ func WithOutputType(t OutputType) Option {
 }
 
 // NewTablePrinter creates a new table printer with kubectl-style formatting
-// It uses tabwriter for clean column alignment with minimal styling
+// It uses tabwriter for clean column alignment with minimal styling. The global --no-headers and --wide settings
+// apply unless overridden by opts.
 func NewTablePrinter(out io.Writer, opts ...Option) *TablePrinter {
 	if out == nil {
 		out = os.Stdout
@@ -66,6 +67,8 @@ func NewTablePrinter(out io.Writer, opts ...Option) *TablePrinter {
 	p := &TablePrinter{
 		writer:     tabwriter.NewWriter(out, 0, 0, 3, ' ', 0),
 		rows:       make([][]string, 0),
+		noHeaders:  globalOptions.NoHeaders,
+		wide:       globalOptions.Wide,
 		outputType: OutputTypeTable,
 	}
 
@@ -76,6 +79,11 @@ func NewTablePrinter(out io.Writer, opts ...Option) *TablePrinter {
 	return p
 }
 
+// Wide reports whether additional columns should be shown, so callers can add them to headers and rows
+func (p *TablePrinter) Wide() bool {
+	return p.wide
+}
+
 // SetHeaders sets the table headers
 func (p *TablePrinter) SetHeaders(headers ...string) {
 	p.headers = headers
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// APITokenPrefix identifies long-lived API tokens so they can be told apart from registry JWTs
+const APITokenPrefix = "arg_"
+
+// APITokenIdentity is the identity and scope attached to a valid API token
+type APITokenIdentity struct {
+	TokenID     int64
+	Subject     string
+	Permissions []Permission
+	// Workspace is the workspace the token is restricted to, if any
+	Workspace string
+}
+
+// APITokenStore resolves hashed API tokens. Lookup returns nil (and no error) for unknown, revoked or expired tokens.
+type APITokenStore interface {
+	LookupAPIToken(ctx context.Context, tokenHash string) (*APITokenIdentity, error)
+}
+
+// GenerateAPIToken creates a new random API token and returns it together with the hash to persist
+func GenerateAPIToken() (token string, tokenHash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate API token: %w", err)
+	}
+	token = APITokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashAPIToken(token), nil
+}
+
+// HashAPIToken returns the hex-encoded SHA-256 hash of an API token. Only the hash is ever stored.
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type apiTokenSession struct {
+	identity *APITokenIdentity
+}
+
+func (s *apiTokenSession) Principal() Principal {
+	return Principal{
+		User: User{
+			Subject:     s.identity.Subject,
+			AuthMethod:  MethodAPIToken,
+			Permissions: s.identity.Permissions,
+			Workspace:   s.identity.Workspace,
+		},
+	}
+}
+
+var _ AuthnProvider = &APITokenAuthnProvider{}
+
+// APITokenAuthnProvider authenticates bearer API tokens and delegates every other credential to a base provider.
+type APITokenAuthnProvider struct {
+	base  AuthnProvider
+	store APITokenStore
+}
+
+// NewAPITokenAuthnProvider creates an API token provider wrapping base (which may be nil).
+// The store may be set later with SetStore.
+func NewAPITokenAuthnProvider(base AuthnProvider, store APITokenStore) *APITokenAuthnProvider {
+	return &APITokenAuthnProvider{base: base, store: store}
+}
+
+// SetStore sets the API token store. Needed because the database is created after the auth providers.
+func (p *APITokenAuthnProvider) SetStore(store APITokenStore) {
+	p.store = store
+}
+
+// Authenticate resolves "Authorization: Bearer arg_..." headers to an API token session.
+func (p *APITokenAuthnProvider) Authenticate(ctx context.Context, reqHeaders func(name string) string, query url.Values) (Session, error) {
+	const bearerPrefix = "Bearer "
+	authHeader := reqHeaders("Authorization")
+	if len(authHeader) > len(bearerPrefix) && strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		token := authHeader[len(bearerPrefix):]
+		if strings.HasPrefix(token, APITokenPrefix) {
+			if p.store == nil {
+				return nil, huma.Error401Unauthorized("API tokens are not supported by this registry")
+			}
+			identity, err := p.store.LookupAPIToken(ctx, HashAPIToken(token))
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to validate API token", err)
+			}
+			if identity == nil {
+				return nil, huma.Error401Unauthorized("Invalid, expired or revoked API token")
+			}
+			return &apiTokenSession{identity: identity}, nil
+		}
+	}
+
+	if p.base == nil {
+		return nil, nil
+	}
+	return p.base.Authenticate(ctx, reqHeaders, query)
+}
+
+// PermissionCovered reports whether the requested permission is granted by one of the held permissions,
+// i.e. the same action on the same or a broader resource pattern. Used to keep minted tokens within the caller's scope.
+func PermissionCovered(held []Permission, requested Permission) bool {
+	for _, perm := range held {
+		if perm.Action != requested.Action {
+			continue
+		}
+		if perm.ResourcePattern == "*" || perm.ResourcePattern == requested.ResourcePattern {
+			return true
+		}
+		if prefix, found := strings.CutSuffix(perm.ResourcePattern, "*"); found && strings.HasPrefix(requested.ResourcePattern, prefix) {
+			return true
+		}
+	}
+	return false
+}
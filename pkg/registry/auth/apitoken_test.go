@@ -0,0 +1,102 @@
+package auth_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticTokens map[string]*auth.APITokenIdentity
+
+func (s staticTokens) LookupAPIToken(_ context.Context, tokenHash string) (*auth.APITokenIdentity, error) {
+	return s[tokenHash], nil
+}
+
+type fixedAuthn struct {
+	session auth.Session
+}
+
+func (f *fixedAuthn) Authenticate(context.Context, func(string) string, url.Values) (auth.Session, error) {
+	return f.session, nil
+}
+
+func headers(authorization string) func(string) string {
+	return func(name string) string {
+		if name == "Authorization" {
+			return authorization
+		}
+		return ""
+	}
+}
+
+func TestGenerateAPIToken(t *testing.T) {
+	token, hash, err := auth.GenerateAPIToken()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, auth.APITokenPrefix))
+	assert.Equal(t, auth.HashAPIToken(token), hash)
+	assert.Len(t, hash, 64)
+
+	other, _, err := auth.GenerateAPIToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestAPITokenAuthnProvider_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	token, hash, err := auth.GenerateAPIToken()
+	require.NoError(t, err)
+
+	perms := []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.myorg/*"}}
+	baseSession := &userSession{user: auth.User{Subject: "jwt-user"}}
+	provider := auth.NewAPITokenAuthnProvider(&fixedAuthn{session: baseSession}, staticTokens{
+		hash: {TokenID: 1, Subject: "alice", Permissions: perms},
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		session, err := provider.Authenticate(ctx, headers("Bearer "+token), nil)
+		require.NoError(t, err)
+		require.NotNil(t, session)
+		user := session.Principal().User
+		assert.Equal(t, "alice", user.Subject)
+		assert.Equal(t, auth.MethodAPIToken, user.AuthMethod)
+		assert.Equal(t, perms, user.Permissions)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		session, err := provider.Authenticate(ctx, headers("Bearer "+auth.APITokenPrefix+"unknown"), nil)
+		assert.Error(t, err)
+		assert.Nil(t, session)
+	})
+
+	t.Run("other credentials go to the base provider", func(t *testing.T) {
+		session, err := provider.Authenticate(ctx, headers("Bearer some.jwt.token"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, baseSession, session)
+	})
+
+	t.Run("no base provider", func(t *testing.T) {
+		p := auth.NewAPITokenAuthnProvider(nil, staticTokens{})
+		session, err := p.Authenticate(ctx, headers(""), nil)
+		assert.NoError(t, err)
+		assert.Nil(t, session)
+	})
+}
+
+func TestPermissionCovered(t *testing.T) {
+	held := []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.myorg/*"},
+		{Action: auth.PermissionActionPush, ResourcePattern: "io.github.myorg/tool"},
+	}
+
+	assert.True(t, auth.PermissionCovered(held, auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.myorg/*"}))
+	assert.True(t, auth.PermissionCovered(held, auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.myorg/tool"}))
+	assert.True(t, auth.PermissionCovered(held, auth.Permission{Action: auth.PermissionActionPush, ResourcePattern: "io.github.myorg/tool"}))
+	assert.False(t, auth.PermissionCovered(held, auth.Permission{Action: auth.PermissionActionPush, ResourcePattern: "io.github.myorg/*"}))
+	assert.False(t, auth.PermissionCovered(held, auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "*"}))
+	assert.False(t, auth.PermissionCovered(held, auth.Permission{Action: auth.PermissionActionDelete, ResourcePattern: "io.github.myorg/tool"}))
+}
@@ -14,7 +14,12 @@ type Resource struct {
 }
 
 type User struct {
+	// Subject identifies the authenticated user (e.g. GitHub username or OIDC subject)
+	Subject     string
+	AuthMethod  Method
 	Permissions []Permission
+	// Workspace is the workspace the credential is restricted to, if any (workspace API tokens)
+	Workspace string
 }
 
 // Authn
@@ -47,6 +52,23 @@ func AuthSessionTo(ctx context.Context, session Session) context.Context {
 	return context.WithValue(ctx, sessionKey, session)
 }
 
+// ActorFrom returns the identity of the caller for auditing purposes.
+// Unauthenticated callers are reported as "anonymous" and internal operations as "system".
+func ActorFrom(ctx context.Context) (actor string, method Method) {
+	s, ok := AuthSessionFrom(ctx)
+	if !ok {
+		return "anonymous", ""
+	}
+	if IsSystemSession(s) {
+		return "system", ""
+	}
+	user := s.Principal().User
+	if user.Subject == "" {
+		return "anonymous", user.AuthMethod
+	}
+	return user.Subject, user.AuthMethod
+}
+
 func AuthnMiddleware(authn AuthnProvider) func(ctx huma.Context, next func(huma.Context)) {
 	return func(ctx huma.Context, next func(huma.Context)) {
 		if authn == nil {
@@ -18,9 +18,10 @@ import (
 type PermissionArtifactType string
 
 const (
-	PermissionArtifactTypeAgent  PermissionArtifactType = "agent"
-	PermissionArtifactTypeSkill  PermissionArtifactType = "skill"
-	PermissionArtifactTypeServer PermissionArtifactType = "server"
+	PermissionArtifactTypeAgent      PermissionArtifactType = "agent"
+	PermissionArtifactTypeSkill      PermissionArtifactType = "skill"
+	PermissionArtifactTypeServer     PermissionArtifactType = "server"
+	PermissionArtifactTypeCollection PermissionArtifactType = "collection"
 )
 
 // PermissionAction represents the type of action that can be performed
@@ -144,8 +145,14 @@ type jwtSession struct {
 }
 
 func (s *jwtSession) Principal() Principal {
+	subject := s.claims.AuthMethodSubject
+	if subject == "" {
+		subject = s.claims.Subject
+	}
 	return Principal{
 		User: User{
+			Subject:     subject,
+			AuthMethod:  s.claims.AuthMethod,
 			Permissions: s.claims.Permissions,
 		},
 	}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+// Role is a named set of actions that can be granted on a namespace
+type Role string
+
+const (
+	// RoleAdmin can perform every action on the namespace. Bound to "*" it makes the subject a registry admin.
+	RoleAdmin Role = "admin"
+	// RolePublisher can push, publish and edit resources in the namespace
+	RolePublisher Role = "publisher"
+	// RoleReader can only read resources in the namespace
+	RoleReader Role = "reader"
+)
+
+var roleActions = map[Role]map[PermissionAction]bool{
+	RoleAdmin: {
+		PermissionActionRead:    true,
+		PermissionActionPush:    true,
+		PermissionActionPublish: true,
+		PermissionActionEdit:    true,
+		PermissionActionDelete:  true,
+		PermissionActionDeploy:  true,
+	},
+	RolePublisher: {
+		PermissionActionRead:    true,
+		PermissionActionPush:    true,
+		PermissionActionPublish: true,
+		PermissionActionEdit:    true,
+	},
+	RoleReader: {
+		PermissionActionRead: true,
+	},
+}
+
+// IsValid reports whether r is a known role
+func (r Role) IsValid() bool {
+	_, ok := roleActions[r]
+	return ok
+}
+
+// Allows reports whether the role grants the action
+func (r Role) Allows(action PermissionAction) bool {
+	return roleActions[r][action]
+}
+
+// RoleBinding grants Subject the Role on every resource whose name matches Namespace (e.g. "io.github.myorg/*")
+type RoleBinding struct {
+	Subject   string
+	Role      Role
+	Namespace string
+}
+
+// RoleBindingStore provides the role bindings used by the RBAC authz provider
+type RoleBindingStore interface {
+	RoleBindings(ctx context.Context) ([]RoleBinding, error)
+}
+
+var _ AuthzProvider = &RBACAuthzProvider{}
+
+// RBACAuthzProvider enforces namespace role bindings on top of another AuthzProvider.
+//
+// Namespaces without any binding are handled entirely by the base provider. Once a namespace has
+// at least one binding it becomes role-managed: reads still go through the base provider, but every
+// other action requires a role granting it, an explicit JWT permission, or registry admin.
+type RBACAuthzProvider struct {
+	base  AuthzProvider
+	store RoleBindingStore
+}
+
+// NewRBACAuthzProvider creates an RBAC provider wrapping base. The store may be set later with SetStore.
+func NewRBACAuthzProvider(base AuthzProvider, store RoleBindingStore) *RBACAuthzProvider {
+	return &RBACAuthzProvider{base: base, store: store}
+}
+
+// SetStore sets the role binding store. Needed because the database is created after its authorizer.
+func (p *RBACAuthzProvider) SetStore(store RoleBindingStore) {
+	p.store = store
+}
+
+// Check verifies if the session can perform the action on the resource.
+func (p *RBACAuthzProvider) Check(ctx context.Context, s Session, verb PermissionAction, resource Resource) error {
+	if p.IsRegistryAdmin(ctx, s) {
+		return nil
+	}
+
+	bindings, err := p.bindings(ctx)
+	if err != nil {
+		return err
+	}
+
+	managed := false
+	subject := sessionSubject(s)
+	for _, b := range bindings {
+		if !isResourceMatch(resource.Name, b.Namespace) {
+			continue
+		}
+		managed = true
+		if subject != "" && b.Subject == subject && b.Role.Allows(verb) {
+			return nil
+		}
+	}
+
+	if !managed || verb == PermissionActionRead {
+		return p.base.Check(ctx, s, verb, resource)
+	}
+
+	if s == nil {
+		return ErrUnauthenticated
+	}
+	for _, perm := range s.Principal().User.Permissions {
+		if perm.Action == verb && isResourceMatch(resource.Name, perm.ResourcePattern) {
+			return nil
+		}
+	}
+	return ErrForbidden
+}
+
+// IsRegistryAdmin reports whether the session is a registry admin in the base provider or holds the admin role on "*".
+func (p *RBACAuthzProvider) IsRegistryAdmin(ctx context.Context, s Session) bool {
+	if p.base.IsRegistryAdmin(ctx, s) {
+		return true
+	}
+
+	subject := sessionSubject(s)
+	if subject == "" {
+		return false
+	}
+	bindings, err := p.bindings(ctx)
+	if err != nil {
+		return false
+	}
+	for _, b := range bindings {
+		if b.Subject == subject && b.Role == RoleAdmin && b.Namespace == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RBACAuthzProvider) bindings(ctx context.Context) ([]RoleBinding, error) {
+	if p.store == nil {
+		return nil, nil
+	}
+	bindings, err := p.store.RoleBindings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load role bindings: %w", err)
+	}
+	return bindings, nil
+}
+
+func sessionSubject(s Session) string {
+	if s == nil {
+		return ""
+	}
+	return s.Principal().User.Subject
+}
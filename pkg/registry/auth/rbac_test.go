@@ -0,0 +1,89 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticBindings []auth.RoleBinding
+
+func (b staticBindings) RoleBindings(context.Context) ([]auth.RoleBinding, error) {
+	return b, nil
+}
+
+type userSession struct {
+	user auth.User
+}
+
+func (s *userSession) Principal() auth.Principal {
+	return auth.Principal{User: s.user}
+}
+
+func TestRBACAuthzProvider_Check(t *testing.T) {
+	ctx := context.Background()
+	provider := auth.NewRBACAuthzProvider(auth.NewPublicAuthzProvider(nil), staticBindings{
+		{Subject: "alice", Role: auth.RolePublisher, Namespace: "io.github.myorg/*"},
+		{Subject: "bob", Role: auth.RoleReader, Namespace: "io.github.myorg/*"},
+		{Subject: "carol", Role: auth.RoleAdmin, Namespace: "io.github.myorg/*"},
+		{Subject: "root", Role: auth.RoleAdmin, Namespace: "*"},
+	})
+
+	alice := &userSession{user: auth.User{Subject: "alice"}}
+	bob := &userSession{user: auth.User{Subject: "bob"}}
+	carol := &userSession{user: auth.User{Subject: "carol"}}
+	root := &userSession{user: auth.User{Subject: "root"}}
+	dave := &userSession{user: auth.User{
+		Subject:     "dave",
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.myorg/tool"}},
+	}}
+
+	managed := auth.Resource{Name: "io.github.myorg/tool", Type: auth.PermissionArtifactTypeServer}
+	unmanaged := auth.Resource{Name: "io.github.other/tool", Type: auth.PermissionArtifactTypeServer}
+
+	tests := []struct {
+		name     string
+		session  auth.Session
+		verb     auth.PermissionAction
+		resource auth.Resource
+		wantErr  error
+	}{
+		{name: "publisher can publish", session: alice, verb: auth.PermissionActionPublish, resource: managed},
+		{name: "publisher cannot delete", session: alice, verb: auth.PermissionActionDelete, resource: managed, wantErr: auth.ErrForbidden},
+		{name: "reader cannot push", session: bob, verb: auth.PermissionActionPush, resource: managed, wantErr: auth.ErrForbidden},
+		{name: "reader can read", session: bob, verb: auth.PermissionActionRead, resource: managed},
+		{name: "namespace admin can deploy", session: carol, verb: auth.PermissionActionDeploy, resource: managed},
+		{name: "registry admin can delete", session: root, verb: auth.PermissionActionDelete, resource: managed},
+		{name: "explicit JWT permission is honored", session: dave, verb: auth.PermissionActionPublish, resource: managed},
+		{name: "anonymous cannot publish to managed namespace", session: nil, verb: auth.PermissionActionPublish, resource: managed, wantErr: auth.ErrUnauthenticated},
+		{name: "anonymous can read managed namespace", session: nil, verb: auth.PermissionActionRead, resource: managed},
+		{name: "unmanaged namespace falls back to public rules", session: nil, verb: auth.PermissionActionPublish, resource: unmanaged},
+		{name: "unmanaged namespace edit still requires auth", session: nil, verb: auth.PermissionActionEdit, resource: unmanaged, wantErr: auth.ErrUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := provider.Check(ctx, tt.session, tt.verb, tt.resource)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRBACAuthzProvider_IsRegistryAdmin(t *testing.T) {
+	ctx := context.Background()
+	provider := auth.NewRBACAuthzProvider(auth.NewPublicAuthzProvider(nil), staticBindings{
+		{Subject: "root", Role: auth.RoleAdmin, Namespace: "*"},
+		{Subject: "carol", Role: auth.RoleAdmin, Namespace: "io.github.myorg/*"},
+	})
+
+	assert.True(t, provider.IsRegistryAdmin(ctx, &userSession{user: auth.User{Subject: "root"}}))
+	assert.False(t, provider.IsRegistryAdmin(ctx, &userSession{user: auth.User{Subject: "carol"}}))
+	assert.False(t, provider.IsRegistryAdmin(ctx, nil))
+	assert.True(t, provider.IsRegistryAdmin(ctx, &auth.SystemSession{}))
+}
@@ -0,0 +1,22 @@
+package auth
+
+import "context"
+
+// WorkspaceHeader names the workspace a request acts in. Entries, deployments and API tokens created by the request
+// belong to that workspace.
+const WorkspaceHeader = "X-Workspace"
+
+type workspaceKeyType struct{}
+
+var workspaceKey = workspaceKeyType{}
+
+// WorkspaceTo returns a context acting in the given workspace. The caller must have checked the membership.
+func WorkspaceTo(ctx context.Context, workspace string) context.Context {
+	return context.WithValue(ctx, workspaceKey, workspace)
+}
+
+// WorkspaceFrom returns the workspace a context acts in, or "" for none
+func WorkspaceFrom(ctx context.Context) string {
+	workspace, _ := ctx.Value(workspaceKey).(string)
+	return workspace
+}
@@ -30,7 +30,14 @@ type ServerFilter struct {
 	Version       *string    // for exact version matching
 	IsLatest      *bool      // for filtering latest versions only
 	Published     *bool      // for filtering by published status (nil = no filter)
+	License       *string    // for filtering by SPDX license (case-insensitive)
+	Tags          []string   // for filtering by tags; entries must carry all of them
+	Workspace     *string    // for filtering by the workspace entries belong to
 	Semantic      *SemanticSearchOptions
+	// SortByPopularity orders by usage counts, most used first; the cursor is then an offset. Ignored for semantic search.
+	SortByPopularity bool
+	// Summary only loads the name, title, description and version of each server instead of its whole server.json
+	Summary bool
 }
 
 // ServerReadme represents a stored README blob for a server version
@@ -44,6 +51,40 @@ type ServerReadme struct {
 	FetchedAt   time.Time
 }
 
+// SkillReadme represents a stored README blob for a skill version
+type SkillReadme struct {
+	SkillName   string
+	Version     string
+	Content     []byte
+	ContentType string
+	SizeBytes   int
+	SHA256      []byte
+	FetchedAt   time.Time
+}
+
+// AgentReadme represents a stored README blob for an agent version
+type AgentReadme struct {
+	AgentName   string
+	Version     string
+	Content     []byte
+	ContentType string
+	SizeBytes   int
+	SHA256      []byte
+	FetchedAt   time.Time
+}
+
+// AgentSBOM represents a stored software bill of materials for an agent version's image
+type AgentSBOM struct {
+	AgentName      string
+	Version        string
+	Format         string // "spdx" or "cyclonedx"
+	Content        []byte
+	ComponentCount int
+	SizeBytes      int
+	SHA256         []byte
+	CreatedAt      time.Time
+}
+
 // SkillFilter defines filtering options for skill queries (mirrors ServerFilter)
 type SkillFilter struct {
 	Name          *string    // for finding versions of same skill
@@ -53,7 +94,10 @@ type SkillFilter struct {
 	Version       *string    // for exact version matching
 	IsLatest      *bool      // for filtering latest versions only
 	Published     *bool      // for filtering by published status (nil = no filter)
+	Workspace     *string    // for filtering by the workspace entries belong to
 	Semantic      *SemanticSearchOptions
+	// SortByPopularity orders by usage counts, most used first; the cursor is then an offset. Ignored for semantic search.
+	SortByPopularity bool
 }
 
 // AgentFilter defines filtering options for agent queries (mirrors ServerFilter)
@@ -65,7 +109,11 @@ type AgentFilter struct {
 	Version       *string    // for exact version matching
 	IsLatest      *bool      // for filtering latest versions only
 	Published     *bool      // for filtering by published status (nil = no filter)
+	Tags          []string   // for filtering by tags; entries must carry all of them
+	Workspace     *string    // for filtering by the workspace entries belong to
 	Semantic      *SemanticSearchOptions
+	// SortByPopularity orders by usage counts, most used first; the cursor is then an offset. Ignored for semantic search.
+	SortByPopularity bool
 }
 
 // SemanticEmbedding captures data stored alongside registry resources for semantic search.
@@ -104,10 +152,20 @@ type SemanticSearchOptions struct {
 type Database interface {
 	// DeleteServer permanently removes a server version from the database
 	DeleteServer(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// TrashServer soft-deletes a server version, hiding it until it is restored or purged
+	TrashServer(ctx context.Context, tx pgx.Tx, serverName, version, deletedBy string) error
+	// RestoreServer restores a soft-deleted server version
+	RestoreServer(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// ListTrashedServers lists the soft-deleted server versions, optionally only those deleted before a time
+	ListTrashedServers(ctx context.Context, tx pgx.Tx, deletedBefore *time.Time) ([]models.TrashEntry, error)
 	// CreateServer inserts a new server version with official metadata
 	CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error)
 	// UpdateServer updates an existing server record
 	UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
+	// RenameServer renames every version of a server and records the former name as an alias of the new one
+	RenameServer(ctx context.Context, tx pgx.Tx, serverName, newName, renamedBy string) error
+	// GetServerAlias returns the current name of a server renamed from alias
+	GetServerAlias(ctx context.Context, tx pgx.Tx, alias string) (string, error)
 	// SetServerStatus updates the status of a specific server version
 	SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error)
 	// ListServers retrieve server entries with optional filtering
@@ -118,6 +176,10 @@ type Database interface {
 	GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string, publishedOnly bool) (*apiv0.ServerResponse, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string, publishedOnly bool) ([]*apiv0.ServerResponse, error)
+	// GetServersByNames retrieves every version of the named servers in one query, ordered by name and newest first
+	GetServersByNames(ctx context.Context, tx pgx.Tx, serverNames []string, publishedOnly bool) ([]*apiv0.ServerResponse, error)
+	// ListServerVersionStatuses retrieves the status and published flag of every version of a server
+	ListServerVersionStatuses(ctx context.Context, tx pgx.Tx, serverName string) ([]*models.ServerVersionStatus, error)
 	// GetCurrentLatestVersion retrieve the current latest version of a server by server name
 	GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
 	// CountServerVersions count the number of versions for a server
@@ -139,14 +201,85 @@ type Database interface {
 	SetServerEmbedding(ctx context.Context, tx pgx.Tx, serverName, version string, embedding *SemanticEmbedding) error
 	// GetServerEmbeddingMetadata returns metadata about a server's embedding without loading the vector
 	GetServerEmbeddingMetadata(ctx context.Context, tx pgx.Tx, serverName, version string) (*SemanticEmbeddingMetadata, error)
+	// GetServerEmbedding returns a server's embedding including the vector, or nil when it has none
+	GetServerEmbedding(ctx context.Context, tx pgx.Tx, serverName, version string) (*SemanticEmbedding, error)
 	// UpsertServerReadme stores or updates a README blob for a server version
 	UpsertServerReadme(ctx context.Context, tx pgx.Tx, readme *ServerReadme) error
 	// GetServerReadme retrieves the README blob for a specific server version
 	GetServerReadme(ctx context.Context, tx pgx.Tx, serverName, version string) (*ServerReadme, error)
 	// GetLatestServerReadme retrieves the README blob for the latest server version
 	GetLatestServerReadme(ctx context.Context, tx pgx.Tx, serverName string) (*ServerReadme, error)
+	// UpsertServerSignature stores or replaces the publisher signature of a server version
+	UpsertServerSignature(ctx context.Context, tx pgx.Tx, sig *models.ServerSignature) error
+	// GetServerSignature retrieves the publisher signature of a specific server version
+	GetServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string) (*models.ServerSignature, error)
+	// UpsertServerCapabilities stores or replaces the introspected capabilities of a server version
+	UpsertServerCapabilities(ctx context.Context, tx pgx.Tx, caps *models.ServerCapabilities) error
+	// GetServerCapabilities retrieves the introspected capabilities of a specific server version
+	GetServerCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string) (*models.ServerCapabilities, error)
+	// UpsertServerEnrichment records when the details of a server version were last refreshed
+	UpsertServerEnrichment(ctx context.Context, tx pgx.Tx, enrichment *models.ServerEnrichment) error
+	// ListServersDueForEnrichment returns the latest server versions never enriched or last enriched before enrichedBefore
+	ListServersDueForEnrichment(ctx context.Context, tx pgx.Tx, enrichedBefore time.Time, limit int) ([]*models.ServerEnrichment, error)
+	// IncrementArtifactUsage counts one usage event (download, deploy, install) of a server, agent or skill
+	IncrementArtifactUsage(ctx context.Context, tx pgx.Tx, artifactType, name, event string) error
+	// GetArtifactStats retrieves the usage counts of a server, agent or skill
+	GetArtifactStats(ctx context.Context, tx pgx.Tx, artifactType, name string) (*models.ArtifactStats, error)
+	// UpsertReview stores the review of an author, replacing their earlier review of the same artifact
+	UpsertReview(ctx context.Context, tx pgx.Tx, review *models.Review) (*models.Review, error)
+	// ListReviews returns the reviews of a server or agent, newest first
+	ListReviews(ctx context.Context, tx pgx.Tx, artifactType, name string, includeHidden bool, cursor string, limit int) ([]*models.Review, string, error)
+	// GetRatingSummaries aggregates the visible reviews of the named servers or agents
+	GetRatingSummaries(ctx context.Context, tx pgx.Tx, artifactType string, names []string) (map[string]models.RatingSummary, error)
+	// SetReviewHidden hides or restores a review (admin only)
+	SetReviewHidden(ctx context.Context, tx pgx.Tx, id int64, hidden bool) (*models.Review, error)
+	// DeleteReview deletes a review written by author, or any review for admins
+	DeleteReview(ctx context.Context, tx pgx.Tx, id int64, author string) (*models.Review, error)
+	// UpsertAttachment records an artifact file attached to a server or agent version, replacing one of the same name
+	UpsertAttachment(ctx context.Context, tx pgx.Tx, attachment *models.Attachment) (*models.Attachment, error)
+	// ListAttachments returns the artifact files attached to a server or agent version
+	ListAttachments(ctx context.Context, tx pgx.Tx, artifactType, name, version string) ([]*models.Attachment, error)
+	// GetAttachment retrieves one artifact file attached to a server or agent version
+	GetAttachment(ctx context.Context, tx pgx.Tx, artifactType, name, version, fileName string) (*models.Attachment, error)
+	// DeleteAttachment removes an artifact file from a server or agent version and returns it
+	DeleteAttachment(ctx context.Context, tx pgx.Tx, artifactType, name, version, fileName string) (*models.Attachment, error)
+	// CreateImport records a new import of seed data
+	CreateImport(ctx context.Context, tx pgx.Tx, status *models.ImportStatus) error
+	// UpdateImport records the status and progress of an import
+	UpdateImport(ctx context.Context, tx pgx.Tx, status *models.ImportStatus) error
+	// GetImport retrieves an import by ID
+	GetImport(ctx context.Context, tx pgx.Tx, id string) (*models.ImportStatus, error)
+	// ListImports returns the most recent imports, newest first
+	ListImports(ctx context.Context, tx pgx.Tx, limit int) ([]models.ImportStatus, error)
+	// RefreshTagCounts recounts the published servers and agents carrying each tag
+	RefreshTagCounts(ctx context.Context, tx pgx.Tx) error
+	// ListTags returns the tags with their counts, most used first
+	ListTags(ctx context.Context, tx pgx.Tx) ([]models.Tag, error)
+	// UpsertVerifiedNamespace records that a subject proved ownership of a namespace
+	UpsertVerifiedNamespace(ctx context.Context, tx pgx.Tx, verified *models.VerifiedNamespace) (*models.VerifiedNamespace, error)
+	// ListVerifiedNamespaces returns verified namespaces, optionally only those of one namespace or subject
+	ListVerifiedNamespaces(ctx context.Context, tx pgx.Tx, namespace, subject string) ([]models.VerifiedNamespace, error)
+	// DeleteVerifiedNamespace revokes the verification of a namespace for a subject (admin only)
+	DeleteVerifiedNamespace(ctx context.Context, tx pgx.Tx, namespace, subject string) error
+	// SearchServerTools finds introspected tools of the latest published server versions by name or description
+	SearchServerTools(ctx context.Context, tx pgx.Tx, query string, limit int) ([]models.ToolSearchResult, error)
 	// InTransaction executes a function within a database transaction
 	InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error
+	// CountDeploymentsInScope counts the deployments of a workspace, or those deployedBy created outside any workspace
+	// when workspace is empty
+	CountDeploymentsInScope(ctx context.Context, tx pgx.Tx, workspace, deployedBy string) (int, error)
+	// CountAuditLogEntriesSince counts the audit log entries of an actor with an action on any of resourceTypes after since
+	CountAuditLogEntriesSince(ctx context.Context, tx pgx.Tx, actor, action string, resourceTypes []string, since time.Time) (int, error)
+	// CountServersInNamespace counts the distinct servers published under a namespace
+	CountServersInNamespace(ctx context.Context, tx pgx.Tx, namespace string) (int, error)
+	// IsRegistryAdmin reports whether the caller in ctx has registry-wide admin permissions
+	IsRegistryAdmin(ctx context.Context) bool
+	// Ping checks that the database accepts connections
+	Ping(ctx context.Context) error
+	// DatabaseSize returns the disk space the registry database takes in bytes
+	DatabaseSize(ctx context.Context) (int64, error)
+	// MigrationStatus returns the migrations of the registry and whether each one has been applied
+	MigrationStatus(ctx context.Context) ([]MigrationStatus, error)
 	// Close closes the database connection
 	Close() error
 
@@ -185,6 +318,18 @@ type Database interface {
 	SetAgentEmbedding(ctx context.Context, tx pgx.Tx, agentName, version string, embedding *SemanticEmbedding) error
 	// GetAgentEmbeddingMetadata returns metadata about an agent's embedding without loading the vector
 	GetAgentEmbeddingMetadata(ctx context.Context, tx pgx.Tx, agentName, version string) (*SemanticEmbeddingMetadata, error)
+	// GetAgentEmbedding returns an agent's embedding including the vector, or nil when it has none
+	GetAgentEmbedding(ctx context.Context, tx pgx.Tx, agentName, version string) (*SemanticEmbedding, error)
+	// UpsertAgentSBOM stores or replaces the SBOM of an agent version
+	UpsertAgentSBOM(ctx context.Context, tx pgx.Tx, sbom *AgentSBOM) error
+	// GetAgentSBOM retrieves the SBOM of a specific agent version
+	GetAgentSBOM(ctx context.Context, tx pgx.Tx, agentName, version string) (*AgentSBOM, error)
+	// UpsertAgentReadme stores or updates the README of an agent version
+	UpsertAgentReadme(ctx context.Context, tx pgx.Tx, readme *AgentReadme) error
+	// GetAgentReadme retrieves the README of a specific agent version
+	GetAgentReadme(ctx context.Context, tx pgx.Tx, agentName, version string) (*AgentReadme, error)
+	// GetLatestAgentReadme retrieves the README of the latest version of an agent
+	GetLatestAgentReadme(ctx context.Context, tx pgx.Tx, agentName string) (*AgentReadme, error)
 
 	// Skills API
 	// CreateSkill inserts a new skill version with official metadata
@@ -215,6 +360,26 @@ type Database interface {
 	UnpublishSkill(ctx context.Context, tx pgx.Tx, skillName, version string) error
 	// IsSkillPublished checks if a skill is published
 	IsSkillPublished(ctx context.Context, tx pgx.Tx, skillName, version string) (bool, error)
+	// ListSkillVersionStatuses retrieves the status and published flag of every version of a skill
+	ListSkillVersionStatuses(ctx context.Context, tx pgx.Tx, skillName string) ([]*models.SkillVersionStatus, error)
+	// UpsertSkillReadme stores or updates the README of a skill version
+	UpsertSkillReadme(ctx context.Context, tx pgx.Tx, readme *SkillReadme) error
+	// GetSkillReadme retrieves the README of a specific skill version
+	GetSkillReadme(ctx context.Context, tx pgx.Tx, skillName, version string) (*SkillReadme, error)
+	// GetLatestSkillReadme retrieves the README of the latest version of a skill
+	GetLatestSkillReadme(ctx context.Context, tx pgx.Tx, skillName string) (*SkillReadme, error)
+
+	// Collections API
+	// CreateCollection inserts a collection version; when isLatest is set it replaces the current latest version
+	CreateCollection(ctx context.Context, tx pgx.Tx, collection *models.CollectionJSON, isLatest bool) (*models.CollectionResponse, error)
+	// GetCollection retrieves a collection version, or its latest version when version is empty
+	GetCollection(ctx context.Context, tx pgx.Tx, name, version string) (*models.CollectionResponse, error)
+	// ListCollections retrieves the latest version of each collection, optionally only published ones
+	ListCollections(ctx context.Context, tx pgx.Tx, publishedOnly bool) ([]*models.CollectionResponse, error)
+	// SetCollectionPublished publishes or unpublishes a collection version
+	SetCollectionPublished(ctx context.Context, tx pgx.Tx, name, version string, published bool) error
+	// DeleteCollection removes a collection version, promoting the most recent remaining version to latest
+	DeleteCollection(ctx context.Context, tx pgx.Tx, name, version string) error
 
 	// Deployments API
 	// CreateDeployment creates a new deployment record
@@ -225,10 +390,89 @@ type Database interface {
 	GetDeploymentByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string, artifactType string) (*models.Deployment, error)
 	// UpdateDeploymentConfig updates the configuration for a deployment
 	UpdateDeploymentConfig(ctx context.Context, tx pgx.Tx, serverName string, version string, artifactType string, config map[string]string) error
+	// UpdateDeploymentOrigin changes the registry a deployment resolves its manifest from
+	UpdateDeploymentOrigin(ctx context.Context, tx pgx.Tx, serverName string, version string, artifactType string, origin string) error
 	// UpdateDeploymentStatus updates the status of a deployment
 	UpdateDeploymentStatus(ctx context.Context, tx pgx.Tx, serverName, version, artifactType, status string) error
+	// UpdateDeploymentCanaryWeight sets the traffic percentage of a canary deployment; zero makes it the stable one
+	UpdateDeploymentCanaryWeight(ctx context.Context, tx pgx.Tx, serverName, version, artifactType string, weight int) error
 	// RemoveDeployment removes a deployment
 	RemoveDeployment(ctx context.Context, tx pgx.Tx, serverName string, version string, artifactType string) error
+
+	// CreateDeploymentRevision appends a snapshot of a deployment to its revision history, assigning the next revision number
+	CreateDeploymentRevision(ctx context.Context, tx pgx.Tx, revision *models.DeploymentRevision) error
+	// ListDeploymentRevisions returns the revision history of a deployment, newest first
+	ListDeploymentRevisions(ctx context.Context, tx pgx.Tx, serverName, resourceType string) ([]*models.DeploymentRevision, error)
+
+	// Audit API
+	// CreateAuditLogEntry records a mutating operation in the audit log
+	CreateAuditLogEntry(ctx context.Context, tx pgx.Tx, entry *models.AuditLogEntry) error
+	// ListAuditLogEntries retrieves audit log entries (newest first) with optional filtering (registry admins only)
+	ListAuditLogEntries(ctx context.Context, tx pgx.Tx, filter *models.AuditLogFilter, cursor string, limit int) ([]*models.AuditLogEntry, string, error)
+
+	// Event outbox API
+	// CreateOutboxEvent writes an event to the outbox within the transaction of the change it describes
+	CreateOutboxEvent(ctx context.Context, tx pgx.Tx, event *models.Event) error
+	// ClaimOutboxEvents locks up to limit due, undelivered events until tx ends, skipping events claimed by others (registry admins only)
+	ClaimOutboxEvents(ctx context.Context, tx pgx.Tx, limit int) ([]models.Event, error)
+	// MarkOutboxEventDelivered records that an event was delivered
+	MarkOutboxEventDelivered(ctx context.Context, tx pgx.Tx, id int64) error
+	// MarkOutboxEventFailed records a failed delivery of an event and when to retry it
+	MarkOutboxEventFailed(ctx context.Context, tx pgx.Tx, id int64, lastError string, retryAt time.Time) error
+	// DeleteDeliveredOutboxEvents removes the events delivered before a time (registry admins only)
+	DeleteDeliveredOutboxEvents(ctx context.Context, tx pgx.Tx, deliveredBefore time.Time) (int64, error)
+
+	// Workspaces API
+	// CreateWorkspace creates a workspace with its creator as owner
+	CreateWorkspace(ctx context.Context, tx pgx.Tx, workspace *models.Workspace) error
+	// GetWorkspace retrieves a workspace by name
+	GetWorkspace(ctx context.Context, tx pgx.Tx, name string) (*models.Workspace, error)
+	// ListWorkspaces lists the workspaces a subject is a member of, or every workspace when subject is empty
+	ListWorkspaces(ctx context.Context, tx pgx.Tx, subject string) ([]models.Workspace, error)
+	// DeleteWorkspace deletes a workspace with its members and API tokens; it fails while entries or deployments belong to it
+	DeleteWorkspace(ctx context.Context, tx pgx.Tx, name string) error
+	// GetWorkspaceRole returns the role of a subject in a workspace, or ErrNotFound when it is not a member
+	GetWorkspaceRole(ctx context.Context, tx pgx.Tx, workspace, subject string) (string, error)
+	// ListWorkspaceMembers lists the members of a workspace, owners first
+	ListWorkspaceMembers(ctx context.Context, tx pgx.Tx, workspace string) ([]models.WorkspaceMember, error)
+	// SetWorkspaceMember adds a member to a workspace or changes the role of an existing member
+	SetWorkspaceMember(ctx context.Context, tx pgx.Tx, workspace string, member *models.WorkspaceMember) error
+	// RemoveWorkspaceMember removes a member from a workspace
+	RemoveWorkspaceMember(ctx context.Context, tx pgx.Tx, workspace, subject string) error
+
+	// RBAC API
+	// CreateRoleBinding grants a role on a namespace to a subject (registry admins only)
+	CreateRoleBinding(ctx context.Context, tx pgx.Tx, binding *models.RoleBinding) error
+	// ListRoleBindings lists role bindings, optionally filtered by subject (registry admins only)
+	ListRoleBindings(ctx context.Context, tx pgx.Tx, subject *string) ([]*models.RoleBinding, error)
+	// DeleteRoleBinding removes a role binding by ID and returns it (registry admins only)
+	DeleteRoleBinding(ctx context.Context, tx pgx.Tx, id int64) (*models.RoleBinding, error)
+
+	// Deployment policy API
+	// ListDeploymentPolicies lists the policies managed through the API; unrestricted since every deployment evaluates them
+	ListDeploymentPolicies(ctx context.Context, tx pgx.Tx) ([]*models.DeploymentPolicy, error)
+	// UpsertDeploymentPolicy creates or replaces a deployment policy (registry admins only)
+	UpsertDeploymentPolicy(ctx context.Context, tx pgx.Tx, policy *models.DeploymentPolicy) error
+	// DeleteDeploymentPolicy removes a deployment policy by name (registry admins only)
+	DeleteDeploymentPolicy(ctx context.Context, tx pgx.Tx, name string) error
+
+	// Config profile API; every method requires permission to deploy the server
+	// ListConfigProfiles lists the config profiles of a server ordered by name
+	ListConfigProfiles(ctx context.Context, tx pgx.Tx, serverName string) ([]*models.ConfigProfile, error)
+	// GetConfigProfile retrieves a config profile of a server by name
+	GetConfigProfile(ctx context.Context, tx pgx.Tx, serverName, name string) (*models.ConfigProfile, error)
+	// UpsertConfigProfile creates or replaces a config profile of a server
+	UpsertConfigProfile(ctx context.Context, tx pgx.Tx, profile *models.ConfigProfile) error
+	// DeleteConfigProfile removes a config profile of a server
+	DeleteConfigProfile(ctx context.Context, tx pgx.Tx, serverName, name string) error
+
+	// API token API
+	// CreateAPIToken stores a new API token owned by the caller; only the token hash is persisted
+	CreateAPIToken(ctx context.Context, tx pgx.Tx, token *models.APIToken, tokenHash string) error
+	// ListAPITokens lists the caller's API tokens, or all tokens when all is set (registry admins only)
+	ListAPITokens(ctx context.Context, tx pgx.Tx, all bool) ([]*models.APIToken, error)
+	// RevokeAPIToken revokes an API token owned by the caller (or any token for registry admins)
+	RevokeAPIToken(ctx context.Context, tx pgx.Tx, id int64) (*models.APIToken, error)
 }
 
 // InTransactionT is a generic helper that wraps InTransaction for functions returning a value
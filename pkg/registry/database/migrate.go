@@ -7,12 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"path"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 // Migration represents a database migration
@@ -20,8 +23,23 @@ type Migration struct {
 	Version int
 	Name    string
 	SQL     string
+	// Down reverts the migration; empty when the migration cannot be rolled back
+	Down string
 }
 
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+	// Reversible is true when the migration has a down migration
+	Reversible bool `json:"reversible"`
+}
+
+// ErrIrreversibleMigration is returned when rolling back past a migration that has no down migration
+var ErrIrreversibleMigration = errors.New("migration cannot be rolled back")
+
 // MigratorConfig configures a migrator instance.
 // This allows external libraries (e.g., Enterprise extensions) to provide
 // their own migrations while sharing the same schema_migrations table.
@@ -29,6 +47,7 @@ type MigratorConfig struct {
 	// MigrationFiles is the embedded filesystem containing migration files.
 	// The filesystem should contain a "migrations" directory with .sql files
 	// named using the pattern "NNN_description.sql" (e.g., "001_initial_schema.sql").
+	// An optional "NNN_description.down.sql" file reverts the migration of the same version.
 	MigrationFiles embed.FS
 	// VersionOffset is added to all migration versions to avoid conflicts.
 	// Set to 0 for OSS migrations, 500+ for extensions.
@@ -101,6 +120,7 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 	}
 
 	var migrations []Migration
+	downs := make(map[int]string)
 	for _, entry := range entries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
 			continue
@@ -108,6 +128,7 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 
 		// Parse version from filename (e.g., "001_initial_schema.sql" -> version 1)
 		name := entry.Name()
+		isDown := strings.HasSuffix(name, ".down.sql")
 		parts := strings.SplitN(name, "_", 2)
 		if len(parts) != 2 {
 			log.Printf("Skipping migration file with invalid name format: %s", name)
@@ -129,6 +150,11 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 			return nil, fmt.Errorf("failed to read migration file %s: %w", name, err)
 		}
 
+		if isDown {
+			downs[offsetVersion] = string(content)
+			continue
+		}
+
 		// Generate migration name with offset if offset is applied
 		var migrationName string
 		if m.config.VersionOffset > 0 {
@@ -145,6 +171,15 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 		})
 	}
 
+	for i := range migrations {
+		migrations[i].Down = downs[migrations[i].Version]
+		delete(downs, migrations[i].Version)
+	}
+	if len(downs) > 0 {
+		orphans := slices.Sorted(maps.Keys(downs))
+		return nil, fmt.Errorf("down migration for version %d has no matching migration", orphans[0])
+	}
+
 	// Sort migrations by version
 	slices.SortFunc(migrations, func(a, b Migration) int {
 		return cmp.Compare(a.Version, b.Version)
@@ -155,6 +190,13 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 
 // Migrate runs all pending migrations.
 func (m *Migrator) Migrate(ctx context.Context) error {
+	return m.MigrateTo(ctx, -1)
+}
+
+// MigrateTo moves the schema to the target version: pending migrations up to and including target are applied,
+// and applied migrations above target are rolled back newest first. A negative target applies all migrations.
+// Rolling back fails before changing anything if a migration to revert has no down migration.
+func (m *Migrator) MigrateTo(ctx context.Context, target int) error {
 	// Ensure the migrations table exists if configured
 	if m.config.EnsureTable {
 		if err := m.ensureMigrationsTable(ctx); err != nil {
@@ -174,20 +216,46 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 		return fmt.Errorf("failed to load migrations: %w", err)
 	}
 
-	// Find pending migrations
-	var pending []Migration
+	if target > 0 && !slices.ContainsFunc(migrations, func(mg Migration) bool { return mg.Version == target }) {
+		return fmt.Errorf("unknown migration version %d", target)
+	}
+
+	// Find pending migrations and migrations to roll back
+	var pending, rollback []Migration
 	for _, migration := range migrations {
-		if _, ok := applied[migration.Version]; !ok {
+		_, ok := applied[migration.Version]
+		switch {
+		case !ok && (target < 0 || migration.Version <= target):
 			pending = append(pending, migration)
+		case ok && target >= 0 && migration.Version > target:
+			rollback = append(rollback, migration)
+		}
+	}
+	slices.Reverse(rollback)
+	for _, migration := range rollback {
+		if migration.Down == "" {
+			return fmt.Errorf("%w: %s (v%d) has no down migration", ErrIrreversibleMigration, migration.Name, migration.Version)
 		}
 	}
 
-	if len(pending) == 0 {
+	if len(pending) == 0 && len(rollback) == 0 {
 		log.Println("No pending migrations")
 		return nil
 	}
 
-	log.Printf("Applying %d pending migrations", len(pending))
+	if len(rollback) > 0 {
+		log.Printf("Rolling back %d migrations", len(rollback))
+	}
+	for _, migration := range rollback {
+		if err := m.revertMigration(ctx, migration); err != nil {
+			return fmt.Errorf("failed to roll back migration %s (v%d): %w", migration.Name, migration.Version, err)
+		}
+		log.Printf("Rolled back migration %d: %s", migration.Version, migration.Name)
+	}
+
+	if len(pending) > 0 {
+		log.Printf("Applying %d pending migrations", len(pending))
+	}
 
 	// Apply each pending migration in a transaction
 	for _, migration := range pending {
@@ -201,6 +269,52 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 	return nil
 }
 
+// Status returns every known migration in version order and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := m.loadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	appliedAt := make(map[int]time.Time)
+	rows, err := m.conn.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		var pgErr *pgconn.PgError
+		// A database that was never migrated has no schema_migrations table yet
+		if !errors.As(err, &pgErr) || pgErr.Code != "42P01" {
+			return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+		}
+	} else {
+		defer rows.Close()
+		for rows.Next() {
+			var version int
+			var at time.Time
+			if err := rows.Scan(&version, &at); err != nil {
+				return nil, fmt.Errorf("failed to scan migration version: %w", err)
+			}
+			appliedAt[version] = at
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read migration rows: %w", err)
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{
+			Version:    migration.Version,
+			Name:       migration.Name,
+			Reversible: migration.Down != "",
+		}
+		if at, ok := appliedAt[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
 // applyMigration applies a single migration in a transaction
 func (m *Migrator) applyMigration(ctx context.Context, migration Migration) error {
 	tx, err := m.conn.Begin(ctx)
@@ -230,3 +344,25 @@ func (m *Migrator) applyMigration(ctx context.Context, migration Migration) erro
 
 	return tx.Commit(ctx)
 }
+
+// revertMigration runs a down migration and removes its record in a transaction
+func (m *Migrator) revertMigration(ctx context.Context, migration Migration) error {
+	tx, err := m.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("Failed to rollback migration transaction: %v", err)
+		}
+	}()
+
+	if _, err := tx.Exec(ctx, migration.Down); err != nil {
+		return fmt.Errorf("failed to execute down migration SQL: %w", err)
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+		return fmt.Errorf("failed to remove migration record: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
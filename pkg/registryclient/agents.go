@@ -0,0 +1,49 @@
+package registryclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// ListAgents returns an iterator over the agents matching opts
+func (c *Client) ListAgents(ctx context.Context, opts ListOptions) iter.Seq2[*models.AgentResponse, error] {
+	return paginate(c, ctx, opts.listPath("agents"), opts.query(), func(resp *models.AgentListResponse) ([]models.AgentResponse, string) {
+		return resp.Agents, resp.Metadata.NextCursor
+	})
+}
+
+// GetAgent returns a published version of an agent, the latest one when version is empty
+func (c *Client) GetAgent(ctx context.Context, name, version string) (*models.AgentResponse, error) {
+	var resp models.AgentResponse
+	if err := c.do(ctx, http.MethodGet, versionPath("agents", name, version), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateAgent stores a new agent version without publishing it
+func (c *Client) CreateAgent(ctx context.Context, agent *models.AgentJSON) (*models.AgentResponse, error) {
+	var resp models.AgentResponse
+	if err := c.do(ctx, http.MethodPost, "/v0/agents/push", nil, agent, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PublishAgent makes an agent version visible in public listings
+func (c *Client) PublishAgent(ctx context.Context, name, version string) error {
+	return c.setPublished(ctx, "agents", name, version, true)
+}
+
+// UnpublishAgent hides an agent version from public listings
+func (c *Client) UnpublishAgent(ctx context.Context, name, version string) error {
+	return c.setPublished(ctx, "agents", name, version, false)
+}
+
+// DeleteAgent deletes an agent version
+func (c *Client) DeleteAgent(ctx context.Context, name, version string) error {
+	return c.do(ctx, http.MethodDelete, "/admin"+versionPath("agents", name, version), nil, nil, nil)
+}
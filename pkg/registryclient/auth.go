@@ -0,0 +1,88 @@
+package registryclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// LoginConfig lists the interactive login methods a registry supports
+type LoginConfig struct {
+	GitHub *GitHubLoginConfig `json:"github,omitempty"`
+	OIDC   *OIDCLoginConfig   `json:"oidc,omitempty"`
+}
+
+// GitHubLoginConfig holds the GitHub OAuth app used for the device flow
+type GitHubLoginConfig struct {
+	ClientID string `json:"clientId"`
+}
+
+// OIDCLoginConfig holds the OIDC provider used for the device flow
+type OIDCLoginConfig struct {
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"clientId"`
+}
+
+// GetLoginConfig returns the login methods supported by the registry
+func (c *Client) GetLoginConfig(ctx context.Context) (*LoginConfig, error) {
+	var resp LoginConfig
+	if err := c.do(ctx, http.MethodGet, "/v0/auth/login-config", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExchangeGitHubToken exchanges a GitHub OAuth access token for a registry token
+func (c *Client) ExchangeGitHubToken(ctx context.Context, githubToken string) (*auth.TokenResponse, error) {
+	return c.exchangeToken(ctx, "/v0/auth/github-at", map[string]string{"github_token": githubToken})
+}
+
+// ExchangeOIDCToken exchanges an OIDC ID token for a registry token
+func (c *Client) ExchangeOIDCToken(ctx context.Context, idToken string) (*auth.TokenResponse, error) {
+	return c.exchangeToken(ctx, "/v0/auth/oidc", map[string]string{"oidc_token": idToken})
+}
+
+func (c *Client) exchangeToken(ctx context.Context, path string, body map[string]string) (*auth.TokenResponse, error) {
+	var resp auth.TokenResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateAPIToken mints a long-lived API token. The token value is only returned once.
+func (c *Client) CreateAPIToken(ctx context.Context, name string, permissions []auth.Permission, expiresAt *time.Time) (*models.CreatedAPIToken, error) {
+	body := struct {
+		Name        string            `json:"name"`
+		Permissions []auth.Permission `json:"permissions"`
+		ExpiresAt   *time.Time        `json:"expiresAt,omitempty"`
+	}{Name: name, Permissions: permissions, ExpiresAt: expiresAt}
+	var resp models.CreatedAPIToken
+	if err := c.do(ctx, http.MethodPost, "/v0/auth/tokens", nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListAPITokens lists the caller's API tokens, or every token when all is set (admin only)
+func (c *Client) ListAPITokens(ctx context.Context, all bool) ([]models.APIToken, error) {
+	var params url.Values
+	if all {
+		params = url.Values{"all": {"true"}}
+	}
+	var resp models.APITokenListResponse
+	if err := c.do(ctx, http.MethodGet, "/v0/auth/tokens", params, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Tokens, nil
+}
+
+// RevokeAPIToken revokes an API token by ID
+func (c *Client) RevokeAPIToken(ctx context.Context, id int64) error {
+	return c.do(ctx, http.MethodDelete, "/v0/auth/tokens/"+strconv.FormatInt(id, 10), nil, nil, nil)
+}
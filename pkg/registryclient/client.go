@@ -0,0 +1,344 @@
+// Package registryclient is a typed Go client for the agent registry v0 HTTP API.
+//
+// It covers the servers, agents, skills, deployments and auth endpoints described by the OpenAPI document the
+// registry serves at /openapi.json. Every call takes a context, transient failures are retried with backoff, and
+// list endpoints are exposed as iterators that fetch pages lazily:
+//
+//	c := registryclient.New("https://registry.example.com", registryclient.WithToken(token))
+//	for server, err := range c.ListServers(ctx, registryclient.ListOptions{Search: "weather"}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(server.Server.Name)
+//	}
+package registryclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the address of a registry started locally with arctl
+const DefaultBaseURL = "http://localhost:12121"
+
+// Client calls the registry API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	token      string
+	userAgent  string
+	httpClient *http.Client
+	retry      RetryPolicy
+}
+
+// RetryPolicy controls how failed requests are retried. Network errors and 429, 502, 503 and 504 responses are
+// retried; other requests are only retried when they are idempotent.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request, including the first; values below 1 mean 1
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, doubled for each further retry unless the server sends Retry-After
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between attempts
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the retry behaviour of clients created without WithRetryPolicy
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, BaseDelay: 500 * time.Millisecond, MaxDelay: 30 * time.Second}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithToken authenticates requests with a registry token (a JWT from ExchangeGitHubToken or ExchangeOIDCToken,
+// or a long-lived API token)
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithHTTPClient replaces the default HTTP client, e.g. to configure TLS or a proxy
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// New returns a client for the registry at baseURL (DefaultBaseURL when empty). A trailing /v0 is accepted and
+// ignored, so the same value used for ARCTL_API_BASE_URL works.
+func New(baseURL string, opts ...Option) *Client {
+	base := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	base = strings.TrimSuffix(base, "/v0")
+
+	c := &Client{
+		baseURL:    base,
+		userAgent:  "agentregistry-go-client",
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BaseURL returns the registry address the client talks to, without the API version
+func (c *Client) BaseURL() string { return c.baseURL }
+
+// APIError is a non-2xx response of the registry. The registry reports errors as RFC 9457 problem details;
+// validation failures list every invalid field in Errors.
+type APIError struct {
+	StatusCode int           `json:"status"`
+	Title      string        `json:"title,omitempty"`
+	Detail     string        `json:"detail,omitempty"`
+	Errors     []ErrorDetail `json:"errors,omitempty"`
+}
+
+// ErrorDetail describes a single problem of a request, e.g. an invalid field
+type ErrorDetail struct {
+	Message  string `json:"message,omitempty"`
+	Location string `json:"location,omitempty"`
+	Value    any    `json:"value,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "registry returned %d: %s", e.StatusCode, msg)
+	for _, d := range e.Errors {
+		if d.Location != "" {
+			fmt.Fprintf(&b, "; %s: %s", d.Location, d.Message)
+		} else if d.Message != "" {
+			fmt.Fprintf(&b, "; %s", d.Message)
+		}
+	}
+	return b.String()
+}
+
+// IsNotFound reports whether err is a 404 response of the registry
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// StatusCode returns the HTTP status of an APIError in err's chain, or 0 if there is none
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// do sends a request to path (relative to the base URL, including the API version) and decodes the JSON response
+// into out when it is non-nil. Transient failures are retried according to the client's retry policy.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("failed to marshal %T: %w", in, err)
+		}
+	}
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	attempts := max(c.retry.MaxAttempts, 1)
+	var lastErr error
+	for attempt := range attempts {
+		if attempt > 0 {
+			if err := sleepCtx(ctx, c.retryDelay(attempt, lastErr)); err != nil {
+				return err
+			}
+		}
+
+		resp, err := c.send(ctx, method, target, body, out != nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			apiErr := readAPIError(resp)
+			retryable := isRetryableStatus(resp.StatusCode) && (isIdempotent(method) || resp.StatusCode == http.StatusTooManyRequests)
+			if !retryable {
+				return apiErr
+			}
+			lastErr = &retryAfterError{err: apiErr, after: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+			continue
+		}
+
+		defer func() { _ = resp.Body.Close() }()
+		if out == nil || resp.StatusCode == http.StatusNoContent {
+			return nil
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response of %s %s: %w", method, path, err)
+		}
+		return nil
+	}
+
+	var ra *retryAfterError
+	if errors.As(lastErr, &ra) {
+		lastErr = ra.err
+	}
+	return fmt.Errorf("%s %s failed after %d attempts: %w", method, path, attempts, lastErr)
+}
+
+func (c *Client) send(ctx context.Context, method, target string, body []byte, wantJSON bool) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if wantJSON {
+		req.Header.Set("Accept", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	return c.httpClient.Do(req)
+}
+
+// readAPIError turns an error response into an APIError, falling back to the raw body when it is not a problem document
+func readAPIError(resp *http.Response) *APIError {
+	defer func() { _ = resp.Body.Close() }()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	apiErr := &APIError{}
+	if err := json.Unmarshal(data, apiErr); err != nil || (apiErr.Title == "" && apiErr.Detail == "") {
+		apiErr = &APIError{Detail: strings.TrimSpace(string(data))}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	return apiErr
+}
+
+// retryAfterError carries the server's Retry-After hint alongside a retryable error response
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryAfterError) Error() string { return e.err.Error() }
+
+func (e *retryAfterError) Unwrap() error { return e.err }
+
+// retryDelay returns the wait before retry attempt n: the server's Retry-After if given, otherwise exponential backoff
+func (c *Client) retryDelay(attempt int, lastErr error) time.Duration {
+	maxDelay := c.retry.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryPolicy.MaxDelay
+	}
+	var ra *retryAfterError
+	if errors.As(lastErr, &ra) && ra.after > 0 {
+		return min(ra.after, maxDelay)
+	}
+	return min(c.retry.BaseDelay<<(attempt-1), maxDelay)
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// versionPath returns the path of an artifact version, "latest" when version is empty
+func versionPath(collection, name, version string) string {
+	if version == "" {
+		version = "latest"
+	}
+	return "/v0/" + collection + "/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version)
+}
+
+// VersionInfo describes the build of the registry server
+type VersionInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"git_commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// Ping checks that the registry is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/v0/ping", nil, nil, nil)
+}
+
+// GetVersion returns the version of the registry server
+func (c *Client) GetVersion(ctx context.Context) (*VersionInfo, error) {
+	var resp VersionInfo
+	if err := c.do(ctx, http.MethodGet, "/v0/version", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,150 @@
+package registryclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registryclient"
+	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+var fastRetries = registryclient.WithRetryPolicy(registryclient.RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+})
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestListServersPaginates(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/v0/servers", r.URL.Path)
+		assert.Equal(t, "weather", r.URL.Query().Get("search"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		resp := v0.ServerListResponse{}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			resp.Servers = []v0.ServerResponse{{Server: v0.ServerJSON{Name: "com.example/a"}}, {Server: v0.ServerJSON{Name: "com.example/b"}}}
+			resp.Metadata.NextCursor = "page-2"
+		case "page-2":
+			resp.Servers = []v0.ServerResponse{{Server: v0.ServerJSON{Name: "com.example/c"}}}
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}))
+	defer srv.Close()
+
+	c := registryclient.New(srv.URL+"/v0", registryclient.WithToken("secret"))
+	servers, err := registryclient.Collect(c.ListServers(context.Background(), registryclient.ListOptions{Search: "weather"}))
+	require.NoError(t, err)
+
+	var names []string
+	for _, s := range servers {
+		names = append(names, s.Server.Name)
+	}
+	assert.Equal(t, []string{"com.example/a", "com.example/b", "com.example/c"}, names)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Breaking out of the loop stops fetching further pages
+	requests.Store(0)
+	for range c.ListServers(context.Background(), registryclient.ListOptions{Search: "weather"}) {
+		break
+	}
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestRetries(t *testing.T) {
+	t.Run("retries idempotent requests on transient errors", func(t *testing.T) {
+		var requests atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			writeJSON(w, http.StatusOK, models.AgentResponse{Agent: models.AgentJSON{Version: "1.0.0"}})
+		}))
+		defer srv.Close()
+
+		c := registryclient.New(srv.URL, fastRetries)
+		agent, err := c.GetAgent(context.Background(), "com.example/planner", "")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", agent.Agent.Version)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("does not retry non-idempotent requests on server errors", func(t *testing.T) {
+		var requests atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer srv.Close()
+
+		c := registryclient.New(srv.URL, fastRetries)
+		_, err := c.CreateSkill(context.Background(), &models.SkillJSON{Name: "com.example/skill", Version: "1.0.0"})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadGateway, registryclient.StatusCode(err))
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer srv.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		c := registryclient.New(srv.URL, registryclient.WithRetryPolicy(registryclient.RetryPolicy{MaxAttempts: 3, MaxDelay: time.Minute}))
+		err := c.Ping(ctx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+	})
+}
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v0/agents/push":
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]any{
+				"title":  "Unprocessable Entity",
+				"status": http.StatusUnprocessableEntity,
+				"detail": "invalid agent payload",
+				"errors": []map[string]any{{"location": "body.version", "message": "version is required"}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := registryclient.New(srv.URL)
+
+	_, err := c.CreateAgent(context.Background(), &models.AgentJSON{})
+	var apiErr *registryclient.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	require.Len(t, apiErr.Errors, 1)
+	assert.Equal(t, "body.version", apiErr.Errors[0].Location)
+	assert.Contains(t, err.Error(), "body.version: version is required")
+
+	_, err = c.GetSkill(context.Background(), "com.example/missing", "1.0.0")
+	assert.True(t, registryclient.IsNotFound(err))
+}
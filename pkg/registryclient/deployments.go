@@ -0,0 +1,121 @@
+package registryclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// Deployable resource types
+const (
+	ResourceTypeMCP   = "mcp"
+	ResourceTypeAgent = "agent"
+)
+
+// DeployRequest deploys a version of an MCP server or agent
+type DeployRequest struct {
+	// ServerName is the name of the MCP server or agent to deploy
+	ServerName string `json:"serverName"`
+	// Version to deploy, "latest" when empty
+	Version string `json:"version"`
+	// Config holds environment variables, arguments and headers; values may be secret references
+	Config       map[string]string `json:"config,omitempty"`
+	PreferRemote bool              `json:"preferRemote,omitempty"`
+	// ResourceType is ResourceTypeMCP (default) or ResourceTypeAgent
+	ResourceType string `json:"resourceType,omitempty"`
+	// Runtime is local (default) or kubernetes
+	Runtime string `json:"runtime,omitempty"`
+	// Target selects a named deployment target configured on the registry
+	Target string `json:"target,omitempty"`
+	// Origin resolves the server manifest from another registry (MCP servers only)
+	Origin string `json:"origin,omitempty"`
+	// CanaryWeight rolls the version out as a canary receiving this percentage of traffic
+	CanaryWeight int `json:"canaryWeight,omitempty"`
+	// Replicas is the number of instances to run
+	Replicas int `json:"replicas,omitempty"`
+}
+
+// DeploymentListOptions narrows the deployments returned by ListDeployments
+type DeploymentListOptions struct {
+	// ResourceType is ResourceTypeMCP or ResourceTypeAgent; empty returns both
+	ResourceType string
+	// Runtime is local or kubernetes; empty returns both
+	Runtime string
+}
+
+// ListDeployments returns the deployments matching opts
+func (c *Client) ListDeployments(ctx context.Context, opts DeploymentListOptions) ([]models.Deployment, error) {
+	params := url.Values{}
+	if opts.ResourceType != "" {
+		params.Set("resourceType", opts.ResourceType)
+	}
+	if opts.Runtime != "" {
+		params.Set("runtime", opts.Runtime)
+	}
+	var resp struct {
+		Deployments []models.Deployment `json:"deployments"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v0/deployments", params, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Deployments, nil
+}
+
+// GetDeployment returns the deployment of a version of an MCP server or agent
+func (c *Client) GetDeployment(ctx context.Context, name, version, resourceType string) (*models.Deployment, error) {
+	var resp models.Deployment
+	if err := c.do(ctx, http.MethodGet, deploymentPath(name, version), resourceTypeQuery(resourceType), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDeploymentHealth returns the runtime state of every deployment
+func (c *Client) GetDeploymentHealth(ctx context.Context) ([]models.DeploymentHealth, error) {
+	var resp struct {
+		Deployments []models.DeploymentHealth `json:"deployments"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v0/deployments/health", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Deployments, nil
+}
+
+// Deploy deploys a version of an MCP server or agent
+func (c *Client) Deploy(ctx context.Context, req *DeployRequest) (*models.Deployment, error) {
+	var resp models.Deployment
+	if err := c.do(ctx, http.MethodPost, "/v0/deployments", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateDeploymentConfig replaces the configuration of a deployment and redeploys it
+func (c *Client) UpdateDeploymentConfig(ctx context.Context, name, version, resourceType string, config map[string]string) (*models.Deployment, error) {
+	body := struct {
+		Config map[string]string `json:"config"`
+	}{Config: config}
+	var resp models.Deployment
+	if err := c.do(ctx, http.MethodPut, deploymentPath(name, version), resourceTypeQuery(resourceType), body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveDeployment stops and removes a deployment
+func (c *Client) RemoveDeployment(ctx context.Context, name, version, resourceType string) error {
+	return c.do(ctx, http.MethodDelete, deploymentPath(name, version), resourceTypeQuery(resourceType), nil, nil)
+}
+
+func deploymentPath(name, version string) string {
+	return "/v0/deployments/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version)
+}
+
+func resourceTypeQuery(resourceType string) url.Values {
+	if resourceType == "" {
+		resourceType = ResourceTypeMCP
+	}
+	return url.Values{"resourceType": {resourceType}}
+}
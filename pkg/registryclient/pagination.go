@@ -0,0 +1,102 @@
+package registryclient
+
+import (
+	"context"
+	"iter"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// maxPageSize is the largest page the registry returns
+const maxPageSize = 100
+
+// ListOptions narrows the artifacts returned by the List methods
+type ListOptions struct {
+	// Search matches artifacts whose name contains the given substring
+	Search string
+	// Version is "latest" or an exact version; empty returns all versions
+	Version string
+	// UpdatedSince only returns artifacts updated after this time
+	UpdatedSince time.Time
+	// Sort is "name" (default) or "popularity"
+	Sort string
+	// IncludeUnpublished lists from the admin endpoint, which also returns unpublished artifacts
+	IncludeUnpublished bool
+	// PageSize is the number of artifacts requested per page (default and maximum 100)
+	PageSize int
+}
+
+func (o ListOptions) query() url.Values {
+	params := url.Values{}
+	pageSize := o.PageSize
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	params.Set("limit", strconv.Itoa(pageSize))
+	if o.Search != "" {
+		params.Set("search", o.Search)
+	}
+	if o.Version != "" {
+		params.Set("version", o.Version)
+	}
+	if !o.UpdatedSince.IsZero() {
+		params.Set("updated_since", o.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	if o.Sort != "" {
+		params.Set("sort", o.Sort)
+	}
+	return params
+}
+
+// listPath returns the public or admin list path of a collection
+func (o ListOptions) listPath(collection string) string {
+	if o.IncludeUnpublished {
+		return "/admin/v0/" + collection
+	}
+	return "/v0/" + collection
+}
+
+// paginate returns an iterator over the items of a cursor-paginated list endpoint. page decodes one response into
+// its items and next cursor. Pages are fetched as the caller advances and breaking out of the loop stops further
+// requests; on an error the iterator yields it once and stops.
+func paginate[T any, R any](c *Client, ctx context.Context, path string, params url.Values, page func(*R) ([]T, string)) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		// Copy the parameters so the iterator can be ranged over more than once
+		params := maps.Clone(params)
+		if params == nil {
+			params = url.Values{}
+		}
+		for {
+			var resp R
+			if err := c.do(ctx, http.MethodGet, path, params, nil, &resp); err != nil {
+				yield(nil, err)
+				return
+			}
+			items, cursor := page(&resp)
+			for i := range items {
+				if !yield(&items[i], nil) {
+					return
+				}
+			}
+			if cursor == "" {
+				return
+			}
+			params.Set("cursor", cursor)
+		}
+	}
+}
+
+// Collect drains an iterator returned by a List method into a slice
+func Collect[T any](seq iter.Seq2[*T, error]) ([]*T, error) {
+	var all []*T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, item)
+	}
+	return all, nil
+}
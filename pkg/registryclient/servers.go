@@ -0,0 +1,72 @@
+package registryclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+
+	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListServers returns an iterator over the MCP servers matching opts
+func (c *Client) ListServers(ctx context.Context, opts ListOptions) iter.Seq2[*v0.ServerResponse, error] {
+	return paginate(c, ctx, opts.listPath("servers"), opts.query(), func(resp *v0.ServerListResponse) ([]v0.ServerResponse, string) {
+		return resp.Servers, resp.Metadata.NextCursor
+	})
+}
+
+// GetServer returns a published version of an MCP server, the latest one when version is empty
+func (c *Client) GetServer(ctx context.Context, name, version string) (*v0.ServerResponse, error) {
+	// The version endpoint responds with a list holding the single matching version
+	var resp v0.ServerListResponse
+	if err := c.do(ctx, http.MethodGet, versionPath("servers", name, version), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Servers) == 0 {
+		return nil, &APIError{StatusCode: http.StatusNotFound, Detail: "server " + name + " not found"}
+	}
+	return &resp.Servers[0], nil
+}
+
+// ListServerVersions returns every published version of an MCP server
+func (c *Client) ListServerVersions(ctx context.Context, name string) ([]v0.ServerResponse, error) {
+	var resp v0.ServerListResponse
+	if err := c.do(ctx, http.MethodGet, "/v0/servers/"+url.PathEscape(name)+"/versions", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Servers, nil
+}
+
+// CreateServer stores a new MCP server version without publishing it
+func (c *Client) CreateServer(ctx context.Context, server *v0.ServerJSON) (*v0.ServerResponse, error) {
+	var resp v0.ServerResponse
+	if err := c.do(ctx, http.MethodPost, "/v0/servers/push", nil, server, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PublishServer makes an MCP server version visible in public listings
+func (c *Client) PublishServer(ctx context.Context, name, version string) error {
+	return c.setPublished(ctx, "servers", name, version, true)
+}
+
+// UnpublishServer hides an MCP server version from public listings
+func (c *Client) UnpublishServer(ctx context.Context, name, version string) error {
+	return c.setPublished(ctx, "servers", name, version, false)
+}
+
+// DeleteServer deletes an MCP server version
+func (c *Client) DeleteServer(ctx context.Context, name, version string) error {
+	return c.do(ctx, http.MethodDelete, "/admin"+versionPath("servers", name, version), nil, nil, nil)
+}
+
+// setPublished publishes or unpublishes an artifact version through the admin API
+func (c *Client) setPublished(ctx context.Context, collection, name, version string, published bool) error {
+	action := "/unpublish"
+	if published {
+		action = "/publish"
+	}
+	return c.do(ctx, http.MethodPost, "/admin"+versionPath(collection, name, version)+action, nil, nil, nil)
+}
@@ -0,0 +1,49 @@
+package registryclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// ListSkills returns an iterator over the skills matching opts
+func (c *Client) ListSkills(ctx context.Context, opts ListOptions) iter.Seq2[*models.SkillResponse, error] {
+	return paginate(c, ctx, opts.listPath("skills"), opts.query(), func(resp *models.SkillListResponse) ([]models.SkillResponse, string) {
+		return resp.Skills, resp.Metadata.NextCursor
+	})
+}
+
+// GetSkill returns a published version of a skill, the latest one when version is empty
+func (c *Client) GetSkill(ctx context.Context, name, version string) (*models.SkillResponse, error) {
+	var resp models.SkillResponse
+	if err := c.do(ctx, http.MethodGet, versionPath("skills", name, version), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateSkill stores a new skill version without publishing it
+func (c *Client) CreateSkill(ctx context.Context, skill *models.SkillJSON) (*models.SkillResponse, error) {
+	var resp models.SkillResponse
+	if err := c.do(ctx, http.MethodPost, "/v0/skills/publish", nil, skill, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PublishSkill makes a skill version visible in public listings
+func (c *Client) PublishSkill(ctx context.Context, name, version string) error {
+	return c.setPublished(ctx, "skills", name, version, true)
+}
+
+// UnpublishSkill hides a skill version from public listings
+func (c *Client) UnpublishSkill(ctx context.Context, name, version string) error {
+	return c.setPublished(ctx, "skills", name, version, false)
+}
+
+// DeleteSkill deletes a skill version
+func (c *Client) DeleteSkill(ctx context.Context, name, version string) error {
+	return c.do(ctx, http.MethodDelete, "/admin"+versionPath("skills", name, version), nil, nil, nil)
+}
@@ -0,0 +1,192 @@
+// Package signing signs and verifies MCP server.json documents with publisher keys.
+//
+// Keys are PEM encoded: PKCS#8 private keys and PKIX public keys, either Ed25519 (as produced by
+// 'arctl mcp keygen' or 'openssl genpkey -algorithm ed25519') or ECDSA P-256 (as used by cosign).
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Supported signature algorithms
+const (
+	AlgorithmEd25519   = "ed25519"
+	AlgorithmECDSAP256 = "ecdsa-p256-sha256"
+)
+
+// ErrInvalidSignature is returned when a signature does not match the signed content
+var ErrInvalidSignature = errors.New("signature verification failed")
+
+// Payload returns the bytes that are signed for a server: its JSON encoding without _meta.
+// _meta is excluded because the registry annotates it (e.g. enrichment and search scores) after publishing.
+func Payload(server *apiv0.ServerJSON) ([]byte, error) {
+	if server == nil {
+		return nil, fmt.Errorf("server is required")
+	}
+	unsigned := *server
+	unsigned.Meta = nil
+	return json.Marshal(&unsigned)
+}
+
+// GenerateKey creates a new Ed25519 key pair and returns the PEM encoded private and public keys
+func GenerateKey() (privatePEM, publicPEM []byte, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+	pubPEM, err := EncodePublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), pubPEM, nil
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS#8 Ed25519 or ECDSA P-256 private key
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in private key")
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" || block.Type == "ENCRYPTED SIGSTORE PRIVATE KEY" {
+		return nil, fmt.Errorf("encrypted private keys are not supported; export an unencrypted PKCS#8 key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		// Fall back to SEC 1 encoded EC keys ("EC PRIVATE KEY")
+		ecKey, ecErr := x509.ParseECPrivateKey(block.Bytes)
+		if ecErr != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		key = ecKey
+	}
+
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported ECDSA curve %s (expected P-256)", k.Curve.Params().Name)
+		}
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T (expected Ed25519 or ECDSA P-256)", key)
+	}
+}
+
+// ParsePublicKey parses a PEM encoded PKIX Ed25519 or ECDSA P-256 public key
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	if _, err := algorithmFor(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EncodePublicKey PEM encodes a public key in PKIX form
+func EncodePublicKey(pub crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// Fingerprint identifies a public key as "SHA256:" followed by the hex SHA-256 of its PKIX encoding
+func Fingerprint(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return "SHA256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Sign signs the server's payload and returns the signature and its algorithm
+func Sign(key crypto.Signer, server *apiv0.ServerJSON) (signature []byte, algorithm string, err error) {
+	payload, err := Payload(server)
+	if err != nil {
+		return nil, "", err
+	}
+	algorithm, err = algorithmFor(key.Public())
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch algorithm {
+	case AlgorithmEd25519:
+		signature, err = key.Sign(rand.Reader, payload, crypto.Hash(0))
+	default:
+		digest := sha256.Sum256(payload)
+		signature, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to sign server: %w", err)
+	}
+	return signature, algorithm, nil
+}
+
+// Verify checks that signature was produced over the server's payload by the private key matching pub
+func Verify(pub crypto.PublicKey, server *apiv0.ServerJSON, algorithm string, signature []byte) error {
+	payload, err := Payload(server)
+	if err != nil {
+		return err
+	}
+	keyAlgorithm, err := algorithmFor(pub)
+	if err != nil {
+		return err
+	}
+	if algorithm != keyAlgorithm {
+		return fmt.Errorf("%w: algorithm %q does not match %s public key", ErrInvalidSignature, algorithm, keyAlgorithm)
+	}
+
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, payload, signature) {
+			return ErrInvalidSignature
+		}
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		if !ecdsa.VerifyASN1(k, digest[:], signature) {
+			return ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func algorithmFor(pub crypto.PublicKey) (string, error) {
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		return AlgorithmEd25519, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return "", fmt.Errorf("unsupported ECDSA curve %s (expected P-256)", k.Curve.Params().Name)
+		}
+		return AlgorithmECDSAP256, nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T (expected Ed25519 or ECDSA P-256)", pub)
+	}
+}
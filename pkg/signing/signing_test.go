@@ -0,0 +1,78 @@
+package signing_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testServer() *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Name:        "io.github.example/weather",
+		Description: "Weather tools",
+		Version:     "1.0.0",
+	}
+}
+
+func TestSignVerifyEd25519(t *testing.T) {
+	privPEM, pubPEM, err := signing.GenerateKey()
+	require.NoError(t, err)
+
+	key, err := signing.ParsePrivateKey(privPEM)
+	require.NoError(t, err)
+	pub, err := signing.ParsePublicKey(pubPEM)
+	require.NoError(t, err)
+
+	server := testServer()
+	sig, alg, err := signing.Sign(key, server)
+	require.NoError(t, err)
+	assert.Equal(t, signing.AlgorithmEd25519, alg)
+	require.NoError(t, signing.Verify(pub, server, alg, sig))
+
+	// Registry-added metadata does not affect the signature
+	server.Meta = &apiv0.ServerMeta{PublisherProvided: map[string]any{"score": 0.5}}
+	require.NoError(t, signing.Verify(pub, server, alg, sig))
+
+	// Any other change does
+	server.Description = "tampered"
+	assert.ErrorIs(t, signing.Verify(pub, server, alg, sig), signing.ErrInvalidSignature)
+}
+
+func TestSignVerifyECDSA(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.NoError(t, err)
+
+	key, err := signing.ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	server := testServer()
+	sig, alg, err := signing.Sign(key, server)
+	require.NoError(t, err)
+	assert.Equal(t, signing.AlgorithmECDSAP256, alg)
+	require.NoError(t, signing.Verify(key.Public(), server, alg, sig))
+	assert.Error(t, signing.Verify(key.Public(), server, signing.AlgorithmEd25519, sig))
+}
+
+func TestFingerprintStable(t *testing.T) {
+	_, pubPEM, err := signing.GenerateKey()
+	require.NoError(t, err)
+	pub, err := signing.ParsePublicKey(pubPEM)
+	require.NoError(t, err)
+
+	fp1, err := signing.Fingerprint(pub)
+	require.NoError(t, err)
+	fp2, err := signing.Fingerprint(pub)
+	require.NoError(t, err)
+	assert.Equal(t, fp1, fp2)
+	assert.Regexp(t, `^SHA256:[0-9a-f]{64}$`, fp1)
+}
@@ -10,7 +10,7 @@ import {
   TooltipProvider,
   TooltipTrigger,
 } from "@/components/ui/tooltip"
-import { Calendar, Tag, Bot, Upload, Container, Cpu, Brain, Github } from "lucide-react"
+import { Calendar, Tag, Bot, Upload, Container, Cpu, Brain, Github, MessageSquare } from "lucide-react"
 
 interface AgentCardProps {
   agent: AgentResponse
@@ -25,6 +25,7 @@ interface AgentCardProps {
 export function AgentCard({ agent, onDelete, onPublish, showDelete = false, showPublish = false, onClick }: AgentCardProps) {
   const { agent: agentData, _meta } = agent
   const official = _meta?.['io.modelcontextprotocol.registry/official']
+  const rating = _meta?.['aregistry.ai/rating']
 
   const handleClick = () => {
     if (onClick) {
@@ -134,6 +135,14 @@ export function AgentCard({ agent, onDelete, onPublish, showDelete = false, show
           </div>
         )}
 
+        {rating && rating.count > 0 && (
+          <div className="flex items-center gap-1" title={`${rating.count} review${rating.count !== 1 ? 's' : ''}`}>
+            <MessageSquare className="h-3 w-3" />
+            <span className="font-medium">{rating.average.toFixed(1)}/5</span>
+            <span>({rating.count})</span>
+          </div>
+        )}
+
         {agentData.repository?.url && (
           <a
             href={agentData.repository.url}
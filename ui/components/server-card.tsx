@@ -9,7 +9,7 @@ import {
   TooltipProvider,
   TooltipTrigger,
 } from "@/components/ui/tooltip"
-import { Package, Calendar, Tag, ExternalLink, GitBranch, Star, Github, Globe, Trash2, Upload, ShieldCheck, BadgeCheck, Play } from "lucide-react"
+import { Package, Calendar, Tag, ExternalLink, GitBranch, Star, Github, Globe, Trash2, Upload, ShieldCheck, BadgeCheck, Play, MessageSquare } from "lucide-react"
 
 interface ServerCardProps {
   server: ServerResponse
@@ -27,6 +27,7 @@ interface ServerCardProps {
 export function ServerCard({ server, onDelete, onPublish, onDeploy, showDelete = false, showPublish = false, showDeploy = false, showExternalLinks = true, onClick, versionCount }: ServerCardProps) {
   const { server: serverData, _meta } = server
   const official = _meta?.['io.modelcontextprotocol.registry/official']
+  const rating = _meta?.['aregistry.ai/rating']
   
   // Extract metadata
   const publisherMetadata = serverData._meta?.['io.modelcontextprotocol.registry/publisher-provided']?.['aregistry.ai/metadata']
@@ -235,6 +236,14 @@ export function ServerCard({ server, onDelete, onPublish, onDeploy, showDelete =
           </div>
         )}
 
+        {rating && rating.count > 0 && (
+          <div className="flex items-center gap-1" title={`${rating.count} review${rating.count !== 1 ? 's' : ''}`}>
+            <MessageSquare className="h-3 w-3" />
+            <span className="font-medium">{rating.average.toFixed(1)}/5</span>
+            <span>({rating.count})</span>
+          </div>
+        )}
+
         {githubStars !== undefined && (
           <div className="flex items-center gap-1 text-yellow-600 dark:text-yellow-400">
             <Star className="h-3 w-3 fill-yellow-600 dark:fill-yellow-400" />
@@ -103,10 +103,17 @@ export interface RegistryExtensions {
   isLatest: boolean
 }
 
+// Average star rating of the visible reviews of a server or agent
+export interface RatingSummary {
+  average: number
+  count: number
+}
+
 export interface ServerResponse {
   server: ServerJSON
   _meta: {
     'io.modelcontextprotocol.registry/official'?: RegistryExtensions
+    'aregistry.ai/rating'?: RatingSummary
   }
 }
 
@@ -220,6 +227,7 @@ export interface AgentResponse {
   agent: AgentJSON
   _meta: {
     'io.modelcontextprotocol.registry/official'?: AgentRegistryExtensions
+    'aregistry.ai/rating'?: RatingSummary
   }
 }
 